@@ -58,6 +58,8 @@ type ConversationalReactDescriptionOptions struct {
 	AIPrefix      string
 	OutputKey     string
 	MaxIterations int
+	// ReturnIntermediateSteps adds the executed []schema.AgentStep to the outputs under IntermediateStepsKey.
+	ReturnIntermediateSteps bool
 }
 
 type ConversationalReactDescription struct {
@@ -96,6 +98,7 @@ func NewConversationalReactDescription(llm schema.Model, tools []schema.Tool, op
 
 	return NewExecutor(agent, tools, func(o *ExecutorOptions) {
 		o.MaxIterations = opts.MaxIterations
+		o.ReturnIntermediateSteps = opts.ReturnIntermediateSteps
 	})
 }
 
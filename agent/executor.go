@@ -15,12 +15,17 @@ var _ schema.Chain = (*Executor)(nil)
 
 const DefaultMaxIterations = 5
 
+// IntermediateStepsKey is the output key of the intermediate steps, if ReturnIntermediateSteps is enabled.
+const IntermediateStepsKey = "intermediateSteps"
+
 // ExecutorOptions holds configuration options for the Executor.
 type ExecutorOptions struct {
 	*schema.CallbackOptions
 	MaxIterations  int
 	Memory         schema.Memory
 	AgentChainType string
+	// ReturnIntermediateSteps adds the executed []schema.AgentStep to the outputs under IntermediateStepsKey.
+	ReturnIntermediateSteps bool
 }
 
 // Executor represents an agent executor that executes a chain of actions based on inputs and a defined agent model.
@@ -70,12 +75,14 @@ func (e Executor) Call(ctx context.Context, inputs schema.ChainValues, optFns ..
 
 	steps := []schema.AgentStep{}
 
+	planCtx := callback.WithChainRunManager(ctx, opts.CallbackManger)
+
 	for i := 0; i <= e.opts.MaxIterations; i++ {
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		default:
-			actions, finish, err := e.agent.Plan(ctx, steps, inputs.Clone())
+			actions, finish, err := e.agent.Plan(planCtx, steps, inputs.Clone())
 			if err != nil {
 				return nil, err
 			}
@@ -91,7 +98,18 @@ func (e Executor) Call(ctx context.Context, inputs schema.ChainValues, optFns ..
 					return nil, cbErr
 				}
 
-				return finish.ReturnValues, nil
+				if !e.opts.ReturnIntermediateSteps {
+					return finish.ReturnValues, nil
+				}
+
+				outputs := make(schema.ChainValues, len(finish.ReturnValues)+1)
+				for k, v := range finish.ReturnValues {
+					outputs[k] = v
+				}
+
+				outputs[IntermediateStepsKey] = steps
+
+				return outputs, nil
 			}
 
 			for _, action := range actions {
@@ -101,25 +119,18 @@ func (e Executor) Call(ctx context.Context, inputs schema.ChainValues, optFns ..
 					return nil, cbErr
 				}
 
-				t, ok := e.toolsMap[action.Tool]
-				if !ok {
-					steps = append(steps, schema.AgentStep{
-						Action:      action,
-						Observation: fmt.Sprintf("%s is not a valid tool, try another one", action.Tool),
-					})
-
-					continue
-				}
-
-				observation, err := tool.Run(ctx, t, action.ToolInput)
+				step, err := e.runAction(ctx, action, opts.CallbackManger)
 				if err != nil {
 					return nil, err
 				}
 
-				steps = append(steps, schema.AgentStep{
-					Action:      action,
-					Observation: observation,
-				})
+				if cbErr := opts.CallbackManger.OnAgentStep(ctx, &schema.AgentStepManagerInput{
+					Step: &step,
+				}); cbErr != nil {
+					return nil, cbErr
+				}
+
+				steps = append(steps, step)
 			}
 		}
 	}
@@ -127,6 +138,31 @@ func (e Executor) Call(ctx context.Context, inputs schema.ChainValues, optFns ..
 	return nil, ErrNotFinished
 }
 
+// runAction runs the tool of the action with the callbacks of the executor and returns the step
+// with the observation of the tool.
+func (e Executor) runAction(ctx context.Context, action *schema.AgentAction, cm schema.CallbackManagerForChainRun) (schema.AgentStep, error) {
+	t, ok := e.toolsMap[action.Tool]
+	if !ok {
+		return schema.AgentStep{
+			Action:      action,
+			Observation: fmt.Sprintf("%s is not a valid tool, try another one", action.Tool),
+		}, nil
+	}
+
+	observation, err := tool.Run(ctx, t, action.ToolInput, func(o *tool.Options) {
+		o.Callbacks = cm.GetInheritableCallbacks()
+		o.ParentRunID = cm.RunID()
+	})
+	if err != nil {
+		return schema.AgentStep{}, err
+	}
+
+	return schema.AgentStep{
+		Action:      action,
+		Observation: observation,
+	}, nil
+}
+
 // Memory returns the memory associated with the chain.
 func (e Executor) Memory() schema.Memory {
 	return e.opts.Memory
@@ -154,5 +190,9 @@ func (e Executor) InputKeys() []string {
 
 // OutputKeys returns the output keys the chain will return.
 func (e Executor) OutputKeys() []string {
+	if e.opts.ReturnIntermediateSteps {
+		return append(e.agent.OutputKeys(), IntermediateStepsKey)
+	}
+
 	return e.agent.OutputKeys()
 }
@@ -3,10 +3,14 @@ package agent
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/callback"
 	"github.com/hupe1980/golc/schema"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExecutor(t *testing.T) {
@@ -65,6 +69,53 @@ func TestExecutor(t *testing.T) {
 		assert.ErrorContains(t, err, "executor error")
 	})
 
+	t.Run("Call_Trajectory", func(t *testing.T) {
+		t.Parallel()
+
+		agent := &mockAgent{
+			OKeys: []string{"output"},
+			PlanFunc: func(ctx context.Context, steps []schema.AgentStep, inputs schema.ChainValues) ([]*schema.AgentAction, *schema.AgentFinish, error) {
+				if len(steps) == 0 {
+					return []*schema.AgentAction{
+						{Tool: "Mock", ToolInput: schema.NewToolInputFromString("input"), Log: "I should use the mock tool"},
+						{Tool: "Unknown", ToolInput: schema.NewToolInputFromString("input")},
+					}, nil, nil
+				}
+
+				return nil, &schema.AgentFinish{ReturnValues: map[string]any{"output": "done"}, Log: "I know the answer"}, nil
+			},
+		}
+
+		executor, err := NewExecutor(agent, []schema.Tool{tool}, func(o *ExecutorOptions) {
+			o.ReturnIntermediateSteps = true
+		})
+		require.NoError(t, err)
+
+		handler := &agentEventHandler{}
+
+		outputs, err := golc.Call(context.Background(), executor, schema.ChainValues{}, func(o *golc.CallOptions) {
+			o.Callbacks = []schema.Callback{handler}
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"action: Mock: I should use the mock tool",
+			"tool start: Mock",
+			"tool end: Observation",
+			"step: Mock: Observation",
+			"action: Unknown: ",
+			"step: Unknown: Unknown is not a valid tool, try another one",
+			"finish: I know the answer",
+		}, handler.events)
+
+		steps, ok := outputs[IntermediateStepsKey].([]schema.AgentStep)
+		require.True(t, ok)
+		require.Len(t, steps, 2)
+		assert.Equal(t, "Observation", steps[0].Observation)
+		assert.Equal(t, "done", outputs["output"])
+		assert.Equal(t, []string{"output", IntermediateStepsKey}, executor.OutputKeys())
+	})
+
 	t.Run("InputKeys", func(t *testing.T) {
 		agent := &mockAgent{
 			IKeys: []string{"foo", "bar"},
@@ -152,3 +203,38 @@ func (m *mockAgent) InputKeys() []string {
 func (m *mockAgent) OutputKeys() []string {
 	return m.OKeys
 }
+
+// agentEventHandler records the agent and tool events of an execution.
+type agentEventHandler struct {
+	callback.NoopHandler
+	events []string
+}
+
+func (h *agentEventHandler) AlwaysVerbose() bool {
+	return true
+}
+
+func (h *agentEventHandler) OnAgentAction(ctx context.Context, input *schema.AgentActionInput) error {
+	h.events = append(h.events, fmt.Sprintf("action: %s: %s", input.Action.Tool, input.Action.Log))
+	return nil
+}
+
+func (h *agentEventHandler) OnAgentStep(ctx context.Context, input *schema.AgentStepInput) error {
+	h.events = append(h.events, fmt.Sprintf("step: %s: %s", input.Step.Action.Tool, input.Step.Observation))
+	return nil
+}
+
+func (h *agentEventHandler) OnAgentFinish(ctx context.Context, input *schema.AgentFinishInput) error {
+	h.events = append(h.events, fmt.Sprintf("finish: %s", input.Finish.Log))
+	return nil
+}
+
+func (h *agentEventHandler) OnToolStart(ctx context.Context, input *schema.ToolStartInput) error {
+	h.events = append(h.events, fmt.Sprintf("tool start: %s", input.ToolName))
+	return nil
+}
+
+func (h *agentEventHandler) OnToolEnd(ctx context.Context, input *schema.ToolEndInput) error {
+	h.events = append(h.events, fmt.Sprintf("tool end: %s", input.Output))
+	return nil
+}
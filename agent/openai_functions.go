@@ -9,7 +9,6 @@ import (
 	"github.com/hupe1980/golc/model"
 	"github.com/hupe1980/golc/prompt"
 	"github.com/hupe1980/golc/schema"
-	"github.com/hupe1980/golc/tool"
 )
 
 // Compile time check to ensure OpenAIFunctions satisfies the agent interface.
@@ -23,6 +22,8 @@ type OpenAIFunctionsOptions struct {
 	SystemMessage *prompt.SystemMessageTemplate
 	ExtraMessages []prompt.MessageTemplate
 	MaxIterations int
+	// ReturnIntermediateSteps adds the executed []schema.AgentStep to the outputs under IntermediateStepsKey.
+	ReturnIntermediateSteps bool
 }
 
 // OpenAIFunctions is an agent that uses OpenAI chatModels and schema.Tools to perform actions.
@@ -56,7 +57,7 @@ func NewOpenAIFunctions(model schema.ChatModel, tools []schema.Tool, optFns ...f
 	functions := make([]schema.FunctionDefinition, len(tools))
 
 	for i, t := range tools {
-		f, err := tool.ToFunction(t)
+		f, err := schema.ToFunction(t)
 		if err != nil {
 			return nil, err
 		}
@@ -72,6 +73,7 @@ func NewOpenAIFunctions(model schema.ChatModel, tools []schema.Tool, optFns ...f
 
 	return NewExecutor(agent, tools, func(o *ExecutorOptions) {
 		o.MaxIterations = opts.MaxIterations
+		o.ReturnIntermediateSteps = opts.ReturnIntermediateSteps
 		o.AgentChainType = "OpenAIFunctions"
 	})
 }
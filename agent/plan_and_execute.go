@@ -0,0 +1,340 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/chain"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure PlanAndExecute satisfies the chain interface.
+var _ schema.Chain = (*PlanAndExecute)(nil)
+
+const defaultPlannerTemplate = `Let's first understand the problem and devise a plan to solve the problem.
+Please output the plan as a numbered list of steps, one step per line.
+Each step must be a self-contained task that can be solved with the available tools.
+The result of the final step should be the answer to the objective. Do not add any superfluous steps.
+
+Objective: {{.objective}}`
+
+const defaultReplannerTemplate = `The following plan was devised to solve the objective, but one of its steps failed.
+
+Objective: {{.objective}}
+
+Completed steps:
+{{.completedSteps}}
+
+Failed step: {{.failedStep}}
+Error: {{.error}}
+
+Please output the remaining steps to solve the objective as a numbered list, one step per line.
+Do not repeat the completed steps. The result of the final step should be the answer to the objective.`
+
+const defaultStepTemplate = `Objective: {{.objective}}
+
+Completed steps:
+{{.completedSteps}}
+
+Current step: {{.currentStep}}`
+
+// PlanStepStatus is the execution status of a step of the plan.
+type PlanStepStatus string
+
+const (
+	PlanStepPending   PlanStepStatus = "pending"
+	PlanStepCompleted PlanStepStatus = "completed"
+	PlanStepFailed    PlanStepStatus = "failed"
+)
+
+// PlanStep is a step of the plan of the PlanAndExecute agent.
+type PlanStep struct {
+	// Step is the task of the step devised by the planner.
+	Step string `json:"step"`
+	// Status is the execution status of the step.
+	Status PlanStepStatus `json:"status"`
+	// Response is the output of the executor, or the error message, if the step failed.
+	Response string `json:"response,omitempty"`
+}
+
+// PlanAndExecuteOptions represents the configuration options for the PlanAndExecute agent.
+type PlanAndExecuteOptions struct {
+	*schema.CallbackOptions
+	Memory schema.Memory
+	// InputKey is the key of the objective in the ChainValues.
+	InputKey string
+	// OutputKey is the key to store the output of the agent in the ChainValues.
+	OutputKey string
+	// PlanKey is the key to store the executed plan as []PlanStep in the ChainValues.
+	PlanKey string
+	// PlannerPrompt is the prompt to devise the plan. It receives the objective.
+	PlannerPrompt schema.PromptTemplate
+	// ReplannerPrompt is the prompt to devise the remaining steps after a failed step. It receives the
+	// objective, the completed steps, the failed step and the error.
+	ReplannerPrompt schema.PromptTemplate
+	// StepPrompt formats the input of the executor. It receives the objective, the completed steps and
+	// the current step.
+	StepPrompt schema.PromptTemplate
+	// MaxReplans is the maximum number of replans after failed steps.
+	MaxReplans int
+}
+
+// PlanAndExecute is an agent that first devises a multi-step plan with a planner chain and then
+// executes the steps one by one with an executor chain, e.g. a tool calling agent. If a step fails,
+// the remaining steps are replanned.
+type PlanAndExecute struct {
+	planner   schema.Chain
+	replanner schema.Chain
+	executor  schema.Chain
+	opts      PlanAndExecuteOptions
+}
+
+// NewPlanAndExecute creates a new instance of the PlanAndExecute agent. The model is used to plan the
+// steps and the executor executes them. The executor must have exactly one input key.
+func NewPlanAndExecute(model schema.Model, executor schema.Chain, optFns ...func(o *PlanAndExecuteOptions)) (*PlanAndExecute, error) {
+	opts := PlanAndExecuteOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		InputKey:        "input",
+		OutputKey:       "output",
+		PlanKey:         "plan",
+		PlannerPrompt:   prompt.NewTemplate(defaultPlannerTemplate),
+		ReplannerPrompt: prompt.NewTemplate(defaultReplannerTemplate),
+		StepPrompt:      prompt.NewTemplate(defaultStepTemplate),
+		MaxReplans:      2,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if len(executor.InputKeys()) != 1 {
+		return nil, fmt.Errorf("executor must have exactly one input key, got %d", len(executor.InputKeys()))
+	}
+
+	if len(executor.OutputKeys()) == 0 {
+		return nil, errors.New("executor must have at least one output key")
+	}
+
+	planner, err := chain.NewLLM(model, opts.PlannerPrompt)
+	if err != nil {
+		return nil, err
+	}
+
+	replanner, err := chain.NewLLM(model, opts.ReplannerPrompt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PlanAndExecute{
+		planner:   planner,
+		replanner: replanner,
+		executor:  executor,
+		opts:      opts,
+	}, nil
+}
+
+// Call plans the steps to solve the objective and executes them.
+// It returns the output of the final step and the executed plan, or an error, if any.
+func (a *PlanAndExecute) Call(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+	opts := schema.CallOptions{
+		CallbackManger: &callback.NoopManager{},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	objective, err := inputs.GetString(a.opts.InputKey)
+	if err != nil {
+		return nil, err
+	}
+
+	steps, err := a.plan(ctx, a.planner, schema.ChainValues{
+		"objective": objective,
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := newPlanSteps(steps)
+	replans := 0
+
+	for i := 0; i < len(plan); i++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		response, err := a.execute(ctx, objective, plan[:i], plan[i].Step, opts)
+		if err == nil {
+			plan[i].Status = PlanStepCompleted
+			plan[i].Response = response
+
+			continue
+		}
+
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return nil, err
+		}
+
+		plan[i].Status = PlanStepFailed
+		plan[i].Response = err.Error()
+
+		if replans >= a.opts.MaxReplans {
+			return nil, fmt.Errorf("step %q failed after %d replans: %w", plan[i].Step, replans, err)
+		}
+
+		replans++
+
+		steps, err := a.plan(ctx, a.replanner, schema.ChainValues{
+			"objective":      objective,
+			"completedSteps": formatCompletedSteps(plan[:i]),
+			"failedStep":     plan[i].Step,
+			"error":          plan[i].Response,
+		}, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		plan = append(plan[:i+1], newPlanSteps(steps)...)
+	}
+
+	return schema.ChainValues{
+		a.opts.OutputKey: finalResponse(plan),
+		a.opts.PlanKey:   plan,
+	}, nil
+}
+
+// plan calls the given planner chain and parses the steps of its output.
+func (a *PlanAndExecute) plan(ctx context.Context, planner schema.Chain, inputs schema.ChainValues, opts schema.CallOptions) ([]string, error) {
+	output, err := golc.SimpleCall(ctx, planner, inputs, func(sco *golc.SimpleCallOptions) {
+		sco.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
+		sco.ParentRunID = opts.CallbackManger.RunID()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	steps := parsePlan(output)
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("%w: no steps in plan %q", ErrUnableToParseOutput, output)
+	}
+
+	return steps, nil
+}
+
+// execute executes a single step of the plan with the executor and returns its output.
+func (a *PlanAndExecute) execute(ctx context.Context, objective string, completed []PlanStep, step string, opts schema.CallOptions) (string, error) {
+	input, err := a.opts.StepPrompt.Format(map[string]any{
+		"objective":      objective,
+		"completedSteps": formatCompletedSteps(completed),
+		"currentStep":    step,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	outputs, err := golc.Call(ctx, a.executor, schema.ChainValues{
+		a.executor.InputKeys()[0]: input,
+	}, func(co *golc.CallOptions) {
+		co.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
+		co.ParentRunID = opts.CallbackManger.RunID()
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprint(outputs[a.executor.OutputKeys()[0]]), nil
+}
+
+// Memory returns the memory associated with the chain.
+func (a *PlanAndExecute) Memory() schema.Memory {
+	return a.opts.Memory
+}
+
+// Type returns the type of the chain.
+func (a *PlanAndExecute) Type() string {
+	return "PlanAndExecute"
+}
+
+// Verbose returns the verbosity setting of the chain.
+func (a *PlanAndExecute) Verbose() bool {
+	return a.opts.CallbackOptions.Verbose
+}
+
+// Callbacks returns the callbacks associated with the chain.
+func (a *PlanAndExecute) Callbacks() []schema.Callback {
+	return a.opts.CallbackOptions.Callbacks
+}
+
+// InputKeys returns the expected input keys.
+func (a *PlanAndExecute) InputKeys() []string {
+	return []string{a.opts.InputKey}
+}
+
+// OutputKeys returns the output keys the chain will return.
+func (a *PlanAndExecute) OutputKeys() []string {
+	return []string{a.opts.OutputKey, a.opts.PlanKey}
+}
+
+// planStepRegexp matches the numbered or bulleted steps of a plan.
+var planStepRegexp = regexp.MustCompile(`^\s*(?:\d+[.)]|[-*])\s+(.+)$`)
+
+// parsePlan parses the steps of a plan formatted as numbered or bulleted list. Other lines,
+// e.g. introductions of the model, are ignored.
+func parsePlan(text string) []string {
+	steps := []string{}
+
+	for _, line := range strings.Split(text, "\n") {
+		if match := planStepRegexp.FindStringSubmatch(line); match != nil {
+			steps = append(steps, strings.TrimSpace(match[1]))
+		}
+	}
+
+	return steps
+}
+
+// newPlanSteps creates pending plan steps for the given steps.
+func newPlanSteps(steps []string) []PlanStep {
+	plan := make([]PlanStep, len(steps))
+	for i, step := range steps {
+		plan[i] = PlanStep{Step: step, Status: PlanStepPending}
+	}
+
+	return plan
+}
+
+// formatCompletedSteps formats the completed steps and their responses for the prompts.
+func formatCompletedSteps(plan []PlanStep) string {
+	lines := []string{}
+
+	for _, step := range plan {
+		if step.Status == PlanStepCompleted {
+			lines = append(lines, fmt.Sprintf("- %s\n  Response: %s", step.Step, step.Response))
+		}
+	}
+
+	if len(lines) == 0 {
+		return "None"
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// finalResponse returns the response of the last completed step.
+func finalResponse(plan []PlanStep) string {
+	for i := len(plan) - 1; i >= 0; i-- {
+		if plan[i].Status == PlanStepCompleted {
+			return plan[i].Response
+		}
+	}
+
+	return ""
+}
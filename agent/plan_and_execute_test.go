@@ -0,0 +1,153 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hupe1980/golc/model/chatmodel"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanAndExecute(t *testing.T) {
+	t.Parallel()
+
+	planner := chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+		text := "Here is the plan:\n1. Search the population\n2. Calculate the answer"
+		if strings.Contains(messages[0].Content(), "Failed step: Calculate the answer") {
+			text = "1. Calculate the answer with the calculator"
+		}
+
+		return &schema.ModelResult{
+			Generations: []schema.Generation{{Text: text, Message: schema.NewAIChatMessage(text)}},
+			LLMOutput:   map[string]any{},
+		}, nil
+	})
+
+	t.Run("Call", func(t *testing.T) {
+		t.Parallel()
+
+		executor := &executorChainMock{
+			CallFunc: func(input string) (string, error) {
+				if strings.HasSuffix(input, "Current step: Search the population") {
+					assert.Contains(t, input, "Objective: objective")
+					assert.Contains(t, input, "Completed steps:\nNone")
+
+					return "3.7 million", nil
+				}
+
+				assert.Contains(t, input, "- Search the population\n  Response: 3.7 million")
+
+				return "42", nil
+			},
+		}
+
+		agent, err := NewPlanAndExecute(planner, executor)
+		assert.NoError(t, err)
+
+		outputs, err := agent.Call(context.Background(), schema.ChainValues{"input": "objective"})
+		assert.NoError(t, err)
+		assert.Equal(t, "42", outputs["output"])
+		assert.Equal(t, []PlanStep{
+			{Step: "Search the population", Status: PlanStepCompleted, Response: "3.7 million"},
+			{Step: "Calculate the answer", Status: PlanStepCompleted, Response: "42"},
+		}, outputs["plan"])
+	})
+
+	t.Run("Replan", func(t *testing.T) {
+		t.Parallel()
+
+		executor := &executorChainMock{
+			CallFunc: func(input string) (string, error) {
+				switch {
+				case strings.HasSuffix(input, "Current step: Search the population"):
+					return "3.7 million", nil
+				case strings.HasSuffix(input, "Current step: Calculate the answer"):
+					return "", errors.New("tool error")
+				default:
+					return "42", nil
+				}
+			},
+		}
+
+		agent, err := NewPlanAndExecute(planner, executor)
+		assert.NoError(t, err)
+
+		outputs, err := agent.Call(context.Background(), schema.ChainValues{"input": "objective"})
+		assert.NoError(t, err)
+		assert.Equal(t, "42", outputs["output"])
+		assert.Equal(t, []PlanStep{
+			{Step: "Search the population", Status: PlanStepCompleted, Response: "3.7 million"},
+			{Step: "Calculate the answer", Status: PlanStepFailed, Response: "tool error"},
+			{Step: "Calculate the answer with the calculator", Status: PlanStepCompleted, Response: "42"},
+		}, outputs["plan"])
+	})
+
+	t.Run("MaxReplans", func(t *testing.T) {
+		t.Parallel()
+
+		executor := &executorChainMock{
+			CallFunc: func(input string) (string, error) {
+				return "", errors.New("tool error")
+			},
+		}
+
+		agent, err := NewPlanAndExecute(planner, executor, func(o *PlanAndExecuteOptions) {
+			o.MaxReplans = 0
+		})
+		assert.NoError(t, err)
+
+		_, err = agent.Call(context.Background(), schema.ChainValues{"input": "objective"})
+		assert.EqualError(t, err, `step "Search the population" failed after 0 replans: tool error`)
+	})
+
+	t.Run("EmptyPlan", func(t *testing.T) {
+		t.Parallel()
+
+		agent, err := NewPlanAndExecute(chatmodel.NewSimpleFake("I cannot help with that."), &executorChainMock{})
+		assert.NoError(t, err)
+
+		_, err = agent.Call(context.Background(), schema.ChainValues{"input": "objective"})
+		assert.ErrorIs(t, err, ErrUnableToParseOutput)
+	})
+
+	t.Run("Keys", func(t *testing.T) {
+		t.Parallel()
+
+		agent, err := NewPlanAndExecute(planner, &executorChainMock{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"input"}, agent.InputKeys())
+		assert.Equal(t, []string{"output", "plan"}, agent.OutputKeys())
+		assert.Equal(t, "PlanAndExecute", agent.Type())
+	})
+}
+
+func TestParsePlan(t *testing.T) {
+	t.Parallel()
+
+	steps := parsePlan("Plan:\n1. First step\n2) Second step\n- Third step\n\n* Fourth step \nThat's it.")
+	assert.Equal(t, []string{"First step", "Second step", "Third step", "Fourth step"}, steps)
+}
+
+// executorChainMock is a chain with a single input and output key, which calls CallFunc.
+type executorChainMock struct {
+	CallFunc func(input string) (string, error)
+}
+
+func (m *executorChainMock) Call(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+	output, err := m.CallFunc(inputs["input"].(string))
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.ChainValues{"output": output}, nil
+}
+
+func (m *executorChainMock) Memory() schema.Memory        { return nil }
+func (m *executorChainMock) Type() string                 { return "Mock" }
+func (m *executorChainMock) Verbose() bool                { return false }
+func (m *executorChainMock) Callbacks() []schema.Callback { return nil }
+func (m *executorChainMock) InputKeys() []string          { return []string{"input"} }
+func (m *executorChainMock) OutputKeys() []string         { return []string{"output"} }
@@ -44,6 +44,8 @@ type ReactDescriptionOptions struct {
 	Suffix        string
 	OutputKey     string
 	MaxIterations int
+	// ReturnIntermediateSteps adds the executed []schema.AgentStep to the outputs under IntermediateStepsKey.
+	ReturnIntermediateSteps bool
 }
 
 type ReactDescription struct {
@@ -80,6 +82,7 @@ func NewReactDescription(llm schema.Model, tools []schema.Tool, optFns ...func(o
 
 	return NewExecutor(agent, tools, func(o *ExecutorOptions) {
 		o.MaxIterations = opts.MaxIterations
+		o.ReturnIntermediateSteps = opts.ReturnIntermediateSteps
 		o.AgentChainType = "ReactDescription"
 	})
 }
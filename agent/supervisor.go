@@ -0,0 +1,398 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/chain"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Supervisor satisfies the chain interface.
+var _ schema.Chain = (*Supervisor)(nil)
+
+const defaultSupervisorTemplate = `You are a supervisor coordinating a team of workers to solve an objective.
+You can delegate tasks to the following workers:
+{{.workers}}
+
+Objective: {{.objective}}
+
+Completed tasks:
+{{.delegations}}
+
+Delegate the next task to exactly one worker using the following format:
+Worker: the name of the worker
+Task: a self-contained description of the task
+
+When the results of the completed tasks are sufficient to solve the objective, respond with:
+Final Answer: the final answer to the objective, which combines the results of the workers`
+
+const defaultWorkerTaskTemplate = `Objective: {{.objective}}
+
+Results of other workers:
+{{.delegations}}
+
+Task: {{.task}}`
+
+var (
+	supervisorWorkerRegexp = regexp.MustCompile(`(?m)^\s*Worker:\s*(.+?)\s*$`)
+	supervisorTaskRegexp   = regexp.MustCompile(`(?s)Task:\s*(.+)`)
+)
+
+// Worker is a named agent, to which the Supervisor delegates tasks.
+type Worker struct {
+	// Name is the name of the worker, which the supervisor uses to delegate tasks.
+	Name string
+	// Description describes the capabilities of the worker to the supervisor.
+	Description string
+	// Agent executes the tasks of the worker, e.g. a tool calling agent. The agent must have exactly
+	// one input key.
+	Agent schema.Chain
+	// MaxTasks is the maximum number of tasks delegated to the worker. Zero means no limit.
+	MaxTasks int
+}
+
+// DelegationStatus is the execution status of a task delegated to a worker.
+type DelegationStatus string
+
+const (
+	DelegationCompleted DelegationStatus = "completed"
+	DelegationFailed    DelegationStatus = "failed"
+)
+
+// Delegation is a task delegated to a worker by the Supervisor.
+type Delegation struct {
+	// Worker is the name of the worker.
+	Worker string `json:"worker"`
+	// Task is the task devised by the supervisor.
+	Task string `json:"task"`
+	// Status is the execution status of the task.
+	Status DelegationStatus `json:"status"`
+	// Response is the output of the worker, or the error message, if the task failed.
+	Response string `json:"response"`
+}
+
+// SupervisorOptions represents the configuration options for the Supervisor agent.
+type SupervisorOptions struct {
+	*schema.CallbackOptions
+	Memory schema.Memory
+	// InputKey is the key of the objective in the ChainValues.
+	InputKey string
+	// OutputKey is the key to store the final answer in the ChainValues.
+	OutputKey string
+	// DelegationsKey is the key to store the executed tasks as []Delegation in the ChainValues.
+	DelegationsKey string
+	// SupervisorPrompt is the prompt to route the next task to a worker or to answer the objective. It
+	// receives the objective, the available workers and the completed tasks.
+	SupervisorPrompt schema.PromptTemplate
+	// WorkerTaskPrompt formats the input of the workers. It receives the objective, the completed tasks
+	// and the task of the worker.
+	WorkerTaskPrompt schema.PromptTemplate
+	// MaxDelegations is the maximum number of tasks delegated to all workers together.
+	MaxDelegations int
+}
+
+// Supervisor is an agent that coordinates a team of worker agents. A supervisor model routes
+// subtasks to the workers by name and combines their results to the final answer. The results of
+// all completed tasks are shared with the supervisor and the workers.
+type Supervisor struct {
+	supervisor schema.Chain
+	workers    []Worker
+	opts       SupervisorOptions
+}
+
+// NewSupervisor creates a new instance of the Supervisor agent. The model is used to route the tasks
+// to the workers and to answer the objective.
+func NewSupervisor(model schema.Model, workers []Worker, optFns ...func(o *SupervisorOptions)) (*Supervisor, error) {
+	opts := SupervisorOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		InputKey:         "input",
+		OutputKey:        "output",
+		DelegationsKey:   "delegations",
+		SupervisorPrompt: prompt.NewTemplate(defaultSupervisorTemplate),
+		WorkerTaskPrompt: prompt.NewTemplate(defaultWorkerTaskTemplate),
+		MaxDelegations:   10,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if len(workers) == 0 {
+		return nil, errors.New("supervisor must have at least one worker")
+	}
+
+	names := make(map[string]bool, len(workers))
+
+	for _, w := range workers {
+		if w.Name == "" {
+			return nil, errors.New("worker must have a name")
+		}
+
+		if names[w.Name] {
+			return nil, fmt.Errorf("duplicate worker name: %s", w.Name)
+		}
+
+		names[w.Name] = true
+
+		if len(w.Agent.InputKeys()) != 1 {
+			return nil, fmt.Errorf("worker %s must have exactly one input key, got %d", w.Name, len(w.Agent.InputKeys()))
+		}
+
+		if len(w.Agent.OutputKeys()) == 0 {
+			return nil, fmt.Errorf("worker %s must have at least one output key", w.Name)
+		}
+	}
+
+	supervisor, err := chain.NewLLM(model, opts.SupervisorPrompt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Supervisor{
+		supervisor: supervisor,
+		workers:    workers,
+		opts:       opts,
+	}, nil
+}
+
+// Call routes the tasks to solve the objective to the workers until the supervisor answers the objective.
+// It returns the final answer and the executed tasks, or an error, if any.
+func (a *Supervisor) Call(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+	opts := schema.CallOptions{
+		CallbackManger: &callback.NoopManager{},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	objective, err := inputs.GetString(a.opts.InputKey)
+	if err != nil {
+		return nil, err
+	}
+
+	delegations := []Delegation{}
+	tasks := make(map[string]int, len(a.workers))
+
+	for i := 0; i <= a.opts.MaxDelegations; i++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		output, err := golc.SimpleCall(ctx, a.supervisor, schema.ChainValues{
+			"objective":   objective,
+			"workers":     a.formatWorkers(tasks, a.opts.MaxDelegations-i),
+			"delegations": formatDelegations(delegations),
+		}, func(sco *golc.SimpleCallOptions) {
+			sco.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
+			sco.ParentRunID = opts.CallbackManger.RunID()
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if _, answer, ok := strings.Cut(output, finalAnswerAction); ok {
+			answer = strings.TrimSpace(answer)
+
+			if cbErr := opts.CallbackManger.OnAgentFinish(ctx, &schema.AgentFinishManagerInput{
+				Finish: &schema.AgentFinish{
+					ReturnValues: map[string]any{a.opts.OutputKey: answer},
+					Log:          output,
+				},
+			}); cbErr != nil {
+				return nil, cbErr
+			}
+
+			return schema.ChainValues{
+				a.opts.OutputKey:      answer,
+				a.opts.DelegationsKey: delegations,
+			}, nil
+		}
+
+		if i == a.opts.MaxDelegations {
+			break
+		}
+
+		name, task, err := parseDelegation(output)
+		if err != nil {
+			return nil, err
+		}
+
+		action := &schema.AgentAction{
+			Tool:      name,
+			ToolInput: schema.NewToolInputFromString(task),
+			Log:       output,
+		}
+
+		if cbErr := opts.CallbackManger.OnAgentAction(ctx, &schema.AgentActionManagerInput{
+			Action: action,
+		}); cbErr != nil {
+			return nil, cbErr
+		}
+
+		delegation := Delegation{
+			Worker: name,
+			Task:   task,
+		}
+
+		response, err := a.delegate(ctx, objective, delegations, name, task, tasks, opts)
+		if err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return nil, err
+			}
+
+			delegation.Status = DelegationFailed
+			delegation.Response = err.Error()
+		} else {
+			delegation.Status = DelegationCompleted
+			delegation.Response = response
+		}
+
+		if cbErr := opts.CallbackManger.OnAgentStep(ctx, &schema.AgentStepManagerInput{
+			Step: &schema.AgentStep{
+				Action:      action,
+				Observation: delegation.Response,
+			},
+		}); cbErr != nil {
+			return nil, cbErr
+		}
+
+		delegations = append(delegations, delegation)
+	}
+
+	return nil, fmt.Errorf("%w: no final answer after %d delegations", ErrNotFinished, len(delegations))
+}
+
+// delegate executes the task with the worker, if the worker exists and has budget left, and returns its output.
+func (a *Supervisor) delegate(ctx context.Context, objective string, delegations []Delegation, name, task string, tasks map[string]int, opts schema.CallOptions) (string, error) {
+	worker, ok := a.worker(name)
+	if !ok {
+		return "", fmt.Errorf("%s is not a valid worker, try another one", name)
+	}
+
+	if worker.MaxTasks > 0 && tasks[name] >= worker.MaxTasks {
+		return "", fmt.Errorf("%s has exhausted its task budget, try another worker", name)
+	}
+
+	tasks[name]++
+
+	input, err := a.opts.WorkerTaskPrompt.Format(map[string]any{
+		"objective":   objective,
+		"delegations": formatDelegations(delegations),
+		"task":        task,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	outputs, err := golc.Call(ctx, worker.Agent, schema.ChainValues{
+		worker.Agent.InputKeys()[0]: input,
+	}, func(co *golc.CallOptions) {
+		co.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
+		co.ParentRunID = opts.CallbackManger.RunID()
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprint(outputs[worker.Agent.OutputKeys()[0]]), nil
+}
+
+// worker returns the worker with the name.
+func (a *Supervisor) worker(name string) (Worker, bool) {
+	for _, w := range a.workers {
+		if w.Name == name {
+			return w, true
+		}
+	}
+
+	return Worker{}, false
+}
+
+// formatWorkers formats the workers, which have budget left, for the supervisor prompt.
+func (a *Supervisor) formatWorkers(tasks map[string]int, remaining int) string {
+	lines := []string{}
+
+	if remaining > 0 {
+		for _, w := range a.workers {
+			switch {
+			case w.MaxTasks == 0:
+				lines = append(lines, fmt.Sprintf("- %s: %s", w.Name, w.Description))
+			case tasks[w.Name] < w.MaxTasks:
+				lines = append(lines, fmt.Sprintf("- %s: %s (remaining tasks: %d)", w.Name, w.Description, w.MaxTasks-tasks[w.Name]))
+			}
+		}
+	}
+
+	if len(lines) == 0 {
+		return "None. No budget is left, answer the objective with the results of the completed tasks."
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Memory returns the memory associated with the chain.
+func (a *Supervisor) Memory() schema.Memory {
+	return a.opts.Memory
+}
+
+// Type returns the type of the chain.
+func (a *Supervisor) Type() string {
+	return "Supervisor"
+}
+
+// Verbose returns the verbosity setting of the chain.
+func (a *Supervisor) Verbose() bool {
+	return a.opts.CallbackOptions.Verbose
+}
+
+// Callbacks returns the callbacks associated with the chain.
+func (a *Supervisor) Callbacks() []schema.Callback {
+	return a.opts.CallbackOptions.Callbacks
+}
+
+// InputKeys returns the expected input keys.
+func (a *Supervisor) InputKeys() []string {
+	return []string{a.opts.InputKey}
+}
+
+// OutputKeys returns the output keys the chain will return.
+func (a *Supervisor) OutputKeys() []string {
+	return []string{a.opts.OutputKey, a.opts.DelegationsKey}
+}
+
+// parseDelegation parses the worker and the task of the output of the supervisor.
+func parseDelegation(text string) (string, string, error) {
+	worker := supervisorWorkerRegexp.FindStringSubmatch(text)
+	task := supervisorTaskRegexp.FindStringSubmatch(text)
+
+	if worker == nil || task == nil {
+		return "", "", fmt.Errorf("%w: no worker or task in %q", ErrUnableToParseOutput, text)
+	}
+
+	return worker[1], strings.TrimSpace(task[1]), nil
+}
+
+// formatDelegations formats the executed tasks and the responses of the workers for the prompts.
+func formatDelegations(delegations []Delegation) string {
+	lines := []string{}
+
+	for _, d := range delegations {
+		lines = append(lines, fmt.Sprintf("- %s (%s): %s\n  Response: %s", d.Worker, d.Status, d.Task, d.Response))
+	}
+
+	if len(lines) == 0 {
+		return "None"
+	}
+
+	return strings.Join(lines, "\n")
+}
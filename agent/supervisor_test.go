@@ -0,0 +1,195 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hupe1980/golc/model/chatmodel"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSupervisor(t *testing.T) {
+	t.Parallel()
+
+	// newSupervisorModel returns a fake model, which answers with the responses in order.
+	newSupervisorModel := func(responses ...string) (*chatmodel.Fake, *[]string) {
+		prompts := []string{}
+
+		return chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+			prompts = append(prompts, messages[0].Content())
+			text := responses[len(prompts)-1]
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: text, Message: schema.NewAIChatMessage(text)}},
+				LLMOutput:   map[string]any{},
+			}, nil
+		}), &prompts
+	}
+
+	researcher := Worker{
+		Name:        "researcher",
+		Description: "Searches the web",
+		Agent: &executorChainMock{
+			CallFunc: func(input string) (string, error) {
+				return "3.7 million", nil
+			},
+		},
+	}
+
+	t.Run("Call", func(t *testing.T) {
+		t.Parallel()
+
+		model, prompts := newSupervisorModel(
+			"Worker: researcher\nTask: Search the population of Berlin",
+			"Worker: calculator\nTask: Divide the population by 2",
+			"Final Answer: 1.85 million",
+		)
+
+		calculator := Worker{
+			Name:        "calculator",
+			Description: "Calculates",
+			Agent: &executorChainMock{
+				CallFunc: func(input string) (string, error) {
+					assert.Contains(t, input, "Objective: objective")
+					assert.Contains(t, input, "- researcher (completed): Search the population of Berlin\n  Response: 3.7 million")
+					assert.True(t, strings.HasSuffix(input, "Task: Divide the population by 2"))
+
+					return "1.85 million", nil
+				},
+			},
+		}
+
+		supervisor, err := NewSupervisor(model, []Worker{researcher, calculator})
+		require.NoError(t, err)
+
+		outputs, err := supervisor.Call(context.Background(), schema.ChainValues{"input": "objective"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "1.85 million", outputs["output"])
+		assert.Equal(t, []Delegation{
+			{Worker: "researcher", Task: "Search the population of Berlin", Status: DelegationCompleted, Response: "3.7 million"},
+			{Worker: "calculator", Task: "Divide the population by 2", Status: DelegationCompleted, Response: "1.85 million"},
+		}, outputs["delegations"])
+
+		assert.Contains(t, (*prompts)[0], "- researcher: Searches the web\n- calculator: Calculates")
+		assert.Contains(t, (*prompts)[0], "Completed tasks:\nNone")
+	})
+
+	t.Run("FailedDelegations", func(t *testing.T) {
+		t.Parallel()
+
+		model, prompts := newSupervisorModel(
+			"Worker: writer\nTask: Write a poem",
+			"Worker: failing\nTask: Do something",
+			"Final Answer: I cannot solve the objective",
+		)
+
+		failing := Worker{
+			Name: "failing",
+			Agent: &executorChainMock{
+				CallFunc: func(input string) (string, error) {
+					return "", errors.New("tool error")
+				},
+			},
+		}
+
+		supervisor, err := NewSupervisor(model, []Worker{researcher, failing})
+		require.NoError(t, err)
+
+		outputs, err := supervisor.Call(context.Background(), schema.ChainValues{"input": "objective"})
+		require.NoError(t, err)
+
+		assert.Equal(t, []Delegation{
+			{Worker: "writer", Task: "Write a poem", Status: DelegationFailed, Response: "writer is not a valid worker, try another one"},
+			{Worker: "failing", Task: "Do something", Status: DelegationFailed, Response: "tool error"},
+		}, outputs["delegations"])
+
+		assert.Contains(t, (*prompts)[2], "- failing (failed): Do something\n  Response: tool error")
+	})
+
+	t.Run("WorkerBudget", func(t *testing.T) {
+		t.Parallel()
+
+		model, prompts := newSupervisorModel(
+			"Worker: researcher\nTask: Search the population",
+			"Worker: researcher\nTask: Search again",
+			"Final Answer: 3.7 million",
+		)
+
+		limited := researcher
+		limited.MaxTasks = 1
+
+		supervisor, err := NewSupervisor(model, []Worker{limited})
+		require.NoError(t, err)
+
+		outputs, err := supervisor.Call(context.Background(), schema.ChainValues{"input": "objective"})
+		require.NoError(t, err)
+
+		delegations := outputs["delegations"].([]Delegation)
+		require.Len(t, delegations, 2)
+		assert.Equal(t, DelegationFailed, delegations[1].Status)
+		assert.Equal(t, "researcher has exhausted its task budget, try another worker", delegations[1].Response)
+
+		assert.Contains(t, (*prompts)[0], "- researcher: Searches the web (remaining tasks: 1)")
+		assert.Contains(t, (*prompts)[1], "None. No budget is left")
+	})
+
+	t.Run("MaxDelegations", func(t *testing.T) {
+		t.Parallel()
+
+		model, prompts := newSupervisorModel(
+			"Worker: researcher\nTask: Search",
+			"Worker: researcher\nTask: Search again",
+		)
+
+		supervisor, err := NewSupervisor(model, []Worker{researcher}, func(o *SupervisorOptions) {
+			o.MaxDelegations = 1
+		})
+		require.NoError(t, err)
+
+		_, err = supervisor.Call(context.Background(), schema.ChainValues{"input": "objective"})
+		assert.ErrorIs(t, err, ErrNotFinished)
+		assert.Contains(t, (*prompts)[1], "None. No budget is left")
+	})
+
+	t.Run("InvalidOutput", func(t *testing.T) {
+		t.Parallel()
+
+		model, _ := newSupervisorModel("I don't know.")
+
+		supervisor, err := NewSupervisor(model, []Worker{researcher})
+		require.NoError(t, err)
+
+		_, err = supervisor.Call(context.Background(), schema.ChainValues{"input": "objective"})
+		assert.ErrorIs(t, err, ErrUnableToParseOutput)
+	})
+
+	t.Run("InvalidWorkers", func(t *testing.T) {
+		t.Parallel()
+
+		model := chatmodel.NewSimpleFake("Final Answer: 42")
+
+		_, err := NewSupervisor(model, nil)
+		assert.EqualError(t, err, "supervisor must have at least one worker")
+
+		_, err = NewSupervisor(model, []Worker{researcher, researcher})
+		assert.EqualError(t, err, "duplicate worker name: researcher")
+
+		_, err = NewSupervisor(model, []Worker{{Agent: researcher.Agent}})
+		assert.EqualError(t, err, "worker must have a name")
+	})
+
+	t.Run("Keys", func(t *testing.T) {
+		t.Parallel()
+
+		supervisor, err := NewSupervisor(chatmodel.NewSimpleFake("Final Answer: 42"), []Worker{researcher})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"input"}, supervisor.InputKeys())
+		assert.Equal(t, []string{"output", "delegations"}, supervisor.OutputKeys())
+		assert.Equal(t, "Supervisor", supervisor.Type())
+	})
+}
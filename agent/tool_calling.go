@@ -0,0 +1,180 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/model"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure ToolCalling satisfies the agent interface.
+var _ schema.Agent = (*ToolCalling)(nil)
+
+// ToolCallingOptions represents the configuration options for the ToolCalling agent.
+type ToolCallingOptions struct {
+	*schema.CallbackOptions
+	// OutputKey is the key to store the output of the agent in the ChainValues.
+	OutputKey     string
+	SystemMessage *prompt.SystemMessageTemplate
+	ExtraMessages []prompt.MessageTemplate
+	MaxIterations int
+	// ReturnIntermediateSteps adds the executed []schema.AgentStep to the outputs under IntermediateStepsKey.
+	ReturnIntermediateSteps bool
+}
+
+// ToolCalling is an agent that uses the native tool calling of chat models, e.g. OpenAI, Anthropic,
+// Bedrock, Ollama or Mistral via the OpenAI compatible chat model, to perform actions. All tool calls
+// of one model response are executed, before the observations are sent back to the model.
+type ToolCalling struct {
+	model schema.ToolCallingChatModel
+	opts  ToolCallingOptions
+}
+
+// NewToolCalling creates a new instance of the ToolCalling agent with the given model and tools.
+// It returns an error if the tools cannot be bound to the model.
+func NewToolCalling(chatModel schema.ToolCallingChatModel, tools []schema.Tool, optFns ...func(o *ToolCallingOptions)) (*Executor, error) {
+	opts := ToolCallingOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		OutputKey:     "output",
+		SystemMessage: prompt.NewSystemMessageTemplate("You are a helpful AI assistant."),
+		ExtraMessages: []prompt.MessageTemplate{},
+		MaxIterations: DefaultMaxIterations,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	boundModel, err := chatModel.BindTools(tools)
+	if err != nil {
+		return nil, err
+	}
+
+	agent := &ToolCalling{
+		model: boundModel,
+		opts:  opts,
+	}
+
+	return NewExecutor(agent, tools, func(o *ExecutorOptions) {
+		o.CallbackOptions = opts.CallbackOptions
+		o.MaxIterations = opts.MaxIterations
+		o.AgentChainType = "ToolCalling"
+		o.ReturnIntermediateSteps = opts.ReturnIntermediateSteps
+	})
+}
+
+// Plan executes the agent with the given context, intermediate steps, and inputs.
+// It returns one agent action per tool call of the model, the agent finish, or an error, if any.
+func (a *ToolCalling) Plan(ctx context.Context, intermediateSteps []schema.AgentStep, inputs schema.ChainValues) ([]*schema.AgentAction, *schema.AgentFinish, error) {
+	inputs["agentScratchpad"] = a.constructScratchPad(intermediateSteps)
+
+	templates := []prompt.MessageTemplate{a.opts.SystemMessage}
+	templates = append(templates, a.opts.ExtraMessages...)
+	templates = append(templates, prompt.NewHumanMessageTemplate("{{.input}}"))
+
+	chatTemplate := prompt.NewChatTemplate(templates)
+
+	placeholder := prompt.NewMessagesPlaceholder("agentScratchpad")
+
+	wrapper := prompt.NewChatTemplateWrapper(chatTemplate, placeholder)
+
+	prompt, err := wrapper.FormatPrompt(inputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := model.ChatModelGenerate(ctx, a.model, prompt.Messages(), func(o *model.Options) {
+		o.Callbacks = a.opts.Callbacks
+
+		if rm, ok := callback.ChainRunManagerFromContext(ctx); ok && rm.RunID() != "" {
+			o.Callbacks = rm.GetInheritableCallbacks()
+			o.ParentRunID = rm.RunID()
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(result.Generations) == 0 {
+		return nil, nil, ErrAgentNoReturn
+	}
+
+	msg := result.Generations[0].Message
+
+	aiMsg, ok := msg.(*schema.AIChatMessage)
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected chatMessage type. Expected ai, but got %s", msg.Type())
+	}
+
+	toolCalls := aiMsg.Extension().ToolCalls
+
+	if len(toolCalls) == 0 {
+		return nil, &schema.AgentFinish{
+			ReturnValues: map[string]any{
+				a.opts.OutputKey: aiMsg.Content(),
+			},
+			Log: aiMsg.Content(),
+		}, nil
+	}
+
+	msgContent := ""
+	if aiMsg.Content() != "" {
+		msgContent = fmt.Sprintf("responded: %s", aiMsg.Content())
+	}
+
+	actions := make([]*schema.AgentAction, len(toolCalls))
+
+	for i, tc := range toolCalls {
+		toolInput := schema.NewToolInputFromArguments(tc.Arguments)
+
+		actions[i] = &schema.AgentAction{
+			Tool:       tc.Name,
+			ToolInput:  toolInput,
+			Log:        fmt.Sprintf("\nInvoking `%s` with `%s`\n%s\n", tc.Name, toolInput, msgContent),
+			MessageLog: schema.ChatMessages{aiMsg},
+			ToolCallID: tc.ID,
+		}
+	}
+
+	return actions, nil, nil
+}
+
+// InputKeys returns the expected input keys for the agent.
+func (a *ToolCalling) InputKeys() []string {
+	return []string{"input"}
+}
+
+// OutputKeys returns the output keys that the agent will return.
+func (a *ToolCalling) OutputKeys() []string {
+	return []string{a.opts.OutputKey}
+}
+
+// constructScratchPad constructs the scratch pad from the given intermediate steps. The actions of
+// parallel tool calls share the AI message, which is added only once before the tool messages.
+func (a *ToolCalling) constructScratchPad(steps []schema.AgentStep) schema.ChatMessages {
+	messages := schema.ChatMessages{}
+
+	var lastMsg schema.ChatMessage
+
+	for _, step := range steps {
+		if len(step.Action.MessageLog) == 0 {
+			messages = append(messages, schema.NewAIChatMessage(step.Action.Log))
+			continue
+		}
+
+		if step.Action.MessageLog[0] != lastMsg {
+			messages = append(messages, step.Action.MessageLog...)
+			lastMsg = step.Action.MessageLog[0]
+		}
+
+		messages = append(messages, schema.NewToolChatMessage(step.Action.ToolCallID, step.Action.Tool, step.Observation))
+	}
+
+	return messages
+}
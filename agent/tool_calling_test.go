@@ -0,0 +1,182 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/model/chatmodel"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToolCalling(t *testing.T) {
+	t.Parallel()
+
+	tools := []schema.Tool{
+		&mockTool{
+			ToolName: "Search",
+			ToolRunFunc: func(ctx context.Context, input any) (string, error) {
+				return "search result for " + input.(string), nil
+			},
+		},
+		&mockTool{
+			ToolName: "Calculator",
+			ToolRunFunc: func(ctx context.Context, input any) (string, error) {
+				return "42", nil
+			},
+		},
+	}
+
+	t.Run("ParallelToolCalls", func(t *testing.T) {
+		t.Parallel()
+
+		model := &toolCallingChatModelMock{
+			Fake: chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+				if len(messages) == 2 {
+					assert.Equal(t, "user input", messages[1].Content())
+
+					return newToolCallingResult(schema.NewAIChatMessage("", func(o *schema.ChatMessageExtension) {
+						o.ToolCalls = []schema.ToolCall{
+							{ID: "call_1", Name: "Search", Arguments: `{"__arg1": "golc"}`},
+							{ID: "call_2", Name: "Calculator", Arguments: `{"__arg1": "6*7"}`},
+						}
+					})), nil
+				}
+
+				assert.Len(t, messages, 5)
+				assert.Len(t, messages[2].(*schema.AIChatMessage).Extension().ToolCalls, 2)
+
+				searchMsg, ok := messages[3].(*schema.ToolChatMessage)
+				assert.True(t, ok)
+				assert.Equal(t, "call_1", searchMsg.ToolCallID())
+				assert.Equal(t, "search result for golc", searchMsg.Content())
+
+				calculatorMsg, ok := messages[4].(*schema.ToolChatMessage)
+				assert.True(t, ok)
+				assert.Equal(t, "call_2", calculatorMsg.ToolCallID())
+				assert.Equal(t, "42", calculatorMsg.Content())
+
+				return newToolCallingResult(schema.NewAIChatMessage("finish text")), nil
+			}),
+		}
+
+		agent, err := NewToolCalling(model, tools, func(o *ToolCallingOptions) {
+			o.ReturnIntermediateSteps = true
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Search", "Calculator"}, model.boundTools)
+
+		output, err := agent.Call(context.Background(), schema.ChainValues{
+			"input": "user input",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "finish text", output["output"])
+
+		steps, ok := output[IntermediateStepsKey].([]schema.AgentStep)
+		assert.True(t, ok)
+		assert.Len(t, steps, 2)
+		assert.Equal(t, "Search", steps[0].Action.Tool)
+		assert.Equal(t, "call_1", steps[0].Action.ToolCallID)
+		assert.Equal(t, "search result for golc", steps[0].Observation)
+		assert.Equal(t, "Calculator", steps[1].Action.Tool)
+		assert.Equal(t, "call_2", steps[1].Action.ToolCallID)
+		assert.Equal(t, "42", steps[1].Observation)
+	})
+
+	t.Run("Callbacks", func(t *testing.T) {
+		t.Parallel()
+
+		agent, err := NewToolCalling(&toolCallingChatModelMock{Fake: chatmodel.NewSimpleFake("finish text")}, tools)
+		assert.NoError(t, err)
+
+		handler := &modelRunHandler{}
+
+		output, err := golc.Call(context.Background(), agent, schema.ChainValues{
+			"input": "user input",
+		}, func(o *golc.CallOptions) {
+			o.Callbacks = []schema.Callback{handler}
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "finish text", output["output"])
+
+		assert.Len(t, handler.chatModelParentRunIDs, 1)
+		assert.NotEmpty(t, handler.chainRunID)
+		assert.Equal(t, handler.chainRunID, handler.chatModelParentRunIDs[0])
+	})
+
+	t.Run("BindToolsError", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewToolCalling(&toolCallingChatModelMock{
+			Fake:         chatmodel.NewSimpleFake("foo"),
+			bindToolsErr: errors.New("bind tools error"),
+		}, tools)
+		assert.EqualError(t, err, "bind tools error")
+	})
+
+	t.Run("Keys", func(t *testing.T) {
+		t.Parallel()
+
+		agent, err := NewToolCalling(&toolCallingChatModelMock{Fake: chatmodel.NewSimpleFake("foo")}, tools)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"input"}, agent.InputKeys())
+		assert.ElementsMatch(t, []string{"output"}, agent.OutputKeys())
+		assert.Equal(t, "ToolCalling", agent.Type())
+
+		agent, err = NewToolCalling(&toolCallingChatModelMock{Fake: chatmodel.NewSimpleFake("foo")}, tools, func(o *ToolCallingOptions) {
+			o.ReturnIntermediateSteps = true
+		})
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"output", IntermediateStepsKey}, agent.OutputKeys())
+	})
+}
+
+// toolCallingChatModelMock adds native tool calling to the fake chat model and records the bound tools.
+type toolCallingChatModelMock struct {
+	*chatmodel.Fake
+	boundTools   []string
+	bindToolsErr error
+}
+
+func (m *toolCallingChatModelMock) BindTools(tools []schema.Tool) (schema.ToolCallingChatModel, error) {
+	if m.bindToolsErr != nil {
+		return nil, m.bindToolsErr
+	}
+
+	for _, t := range tools {
+		m.boundTools = append(m.boundTools, t.Name())
+	}
+
+	return m, nil
+}
+
+// modelRunHandler records the run ID of the executor and the parent run IDs of the chat model runs.
+type modelRunHandler struct {
+	callback.NoopHandler
+	chainRunID            string
+	chatModelParentRunIDs []string
+}
+
+func (h *modelRunHandler) AlwaysVerbose() bool {
+	return true
+}
+
+func (h *modelRunHandler) OnChainStart(ctx context.Context, input *schema.ChainStartInput) error {
+	h.chainRunID = input.RunID
+	return nil
+}
+
+func (h *modelRunHandler) OnChatModelStart(ctx context.Context, input *schema.ChatModelStartInput) error {
+	h.chatModelParentRunIDs = append(h.chatModelParentRunIDs, input.ParentRunID)
+	return nil
+}
+
+func newToolCallingResult(msg *schema.AIChatMessage) *schema.ModelResult {
+	return &schema.ModelResult{
+		Generations: []schema.Generation{{Text: msg.Content(), Message: msg}},
+		LLMOutput:   map[string]any{},
+	}
+}
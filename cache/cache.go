@@ -0,0 +1,13 @@
+// Package cache provides caches for model results, so that repeated prompts don't hit the provider APIs.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// hashKey returns the hex encoded sha256 hash of the prompt and the llm key.
+func hashKey(prompt, llmKey string) string {
+	hash := sha256.Sum256([]byte(llmKey + "\x00" + prompt))
+	return hex.EncodeToString(hash[:])
+}
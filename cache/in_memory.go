@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure InMemory satisfies the Cache interface.
+var _ schema.Cache = (*InMemory)(nil)
+
+// InMemoryOptions contains the options for the InMemory cache.
+type InMemoryOptions struct {
+	// TTL is the time to live of a cached result. A value <= 0 disables the expiration.
+	TTL time.Duration
+	// MaxSize is the maximum number of cached results. If the cache is full, the least recently
+	// used result is evicted. A value <= 0 disables the limit.
+	MaxSize int
+}
+
+type inMemoryEntry struct {
+	key       string
+	result    *schema.ModelResult
+	expiresAt time.Time
+}
+
+// InMemory is a least recently used cache that keeps the model results in memory.
+type InMemory struct {
+	entries map[string]*list.Element
+	order   *list.List
+	opts    InMemoryOptions
+	now     func() time.Time
+	mu      sync.Mutex
+}
+
+// NewInMemory creates a new InMemory cache.
+func NewInMemory(optFns ...func(o *InMemoryOptions)) *InMemory {
+	opts := InMemoryOptions{
+		MaxSize: 1000,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &InMemory{
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+		opts:    opts,
+		now:     time.Now,
+	}
+}
+
+// Lookup returns the cached result for the prompt and the llm key.
+func (c *InMemory) Lookup(ctx context.Context, prompt, llmKey string) (*schema.ModelResult, bool, error) {
+	key := hashKey(prompt, llmKey)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	entry := elem.Value.(*inMemoryEntry)
+
+	if !entry.expiresAt.IsZero() && !c.now().Before(entry.expiresAt) {
+		c.remove(elem)
+		return nil, false, nil
+	}
+
+	c.order.MoveToFront(elem)
+
+	return entry.result, true, nil
+}
+
+// Update stores the result for the prompt and the llm key.
+func (c *InMemory) Update(ctx context.Context, prompt, llmKey string, result *schema.ModelResult) error {
+	key := hashKey(prompt, llmKey)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if c.opts.TTL > 0 {
+		expiresAt = c.now().Add(c.opts.TTL)
+	}
+
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*inMemoryEntry)
+		entry.result = result
+		entry.expiresAt = expiresAt
+
+		c.order.MoveToFront(elem)
+
+		return nil
+	}
+
+	c.entries[key] = c.order.PushFront(&inMemoryEntry{
+		key:       key,
+		result:    result,
+		expiresAt: expiresAt,
+	})
+
+	for c.opts.MaxSize > 0 && c.order.Len() > c.opts.MaxSize {
+		c.remove(c.order.Back())
+	}
+
+	return nil
+}
+
+// Clear removes all cached results.
+func (c *InMemory) Clear(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+
+	return nil
+}
+
+// Len returns the number of cached results, including expired results that have not been evicted yet.
+func (c *InMemory) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *InMemory) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*inMemoryEntry).key)
+}
@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemory(t *testing.T) {
+	ctx := context.Background()
+
+	newResult := func(text string) *schema.ModelResult {
+		return &schema.ModelResult{Generations: []schema.Generation{{Text: text}}}
+	}
+
+	t.Run("LookupAndUpdate", func(t *testing.T) {
+		c := NewInMemory()
+
+		_, ok, err := c.Lookup(ctx, "key", "llm")
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		require.NoError(t, c.Update(ctx, "key", "llm", newResult("foo")))
+
+		result, ok, err := c.Lookup(ctx, "key", "llm")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "foo", result.Generations[0].Text)
+
+		require.NoError(t, c.Clear(ctx))
+		assert.Equal(t, 0, c.Len())
+	})
+
+	t.Run("TTL", func(t *testing.T) {
+		now := time.Now()
+
+		c := NewInMemory(func(o *InMemoryOptions) {
+			o.TTL = time.Minute
+		})
+		c.now = func() time.Time { return now }
+
+		require.NoError(t, c.Update(ctx, "key", "llm", newResult("foo")))
+
+		_, ok, _ := c.Lookup(ctx, "key", "llm")
+		assert.True(t, ok)
+
+		now = now.Add(time.Minute)
+
+		_, ok, _ = c.Lookup(ctx, "key", "llm")
+		assert.False(t, ok)
+		assert.Equal(t, 0, c.Len())
+	})
+
+	t.Run("MaxSize", func(t *testing.T) {
+		c := NewInMemory(func(o *InMemoryOptions) {
+			o.MaxSize = 2
+		})
+
+		require.NoError(t, c.Update(ctx, "a", "llm", newResult("a")))
+		require.NoError(t, c.Update(ctx, "b", "llm", newResult("b")))
+
+		// Use a, so that b becomes the least recently used result.
+		_, ok, _ := c.Lookup(ctx, "a", "llm")
+		assert.True(t, ok)
+
+		require.NoError(t, c.Update(ctx, "c", "llm", newResult("c")))
+		assert.Equal(t, 2, c.Len())
+
+		_, ok, _ = c.Lookup(ctx, "b", "llm")
+		assert.False(t, ok)
+
+		_, ok, _ = c.Lookup(ctx, "a", "llm")
+		assert.True(t, ok)
+	})
+}
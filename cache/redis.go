@@ -0,0 +1,183 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Redis satisfies the Cache interface.
+var _ schema.Cache = (*Redis)(nil)
+
+type RedisClient interface {
+	Get(ctx context.Context, key string) *redis.StringCmd
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd
+	Keys(ctx context.Context, pattern string) *redis.StringSliceCmd
+	Del(ctx context.Context, keys ...string) *redis.IntCmd
+}
+
+// RedisOptions contains the options for the Redis cache.
+type RedisOptions struct {
+	// KeyPrefix is the prefix of the redis keys of the cached results.
+	KeyPrefix string
+	// TTL is the time to live of a cached result. A value <= 0 disables the expiration.
+	TTL time.Duration
+}
+
+// Redis is a cache that stores the model results in redis. The size of the cache is limited by
+// the memory policy of the redis server.
+type Redis struct {
+	redisClient RedisClient
+	opts        RedisOptions
+}
+
+// NewRedis creates a new Redis cache.
+func NewRedis(redisClient RedisClient, optFns ...func(o *RedisOptions)) *Redis {
+	opts := RedisOptions{
+		KeyPrefix: "golc_cache:",
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Redis{
+		redisClient: redisClient,
+		opts:        opts,
+	}
+}
+
+// Lookup returns the cached result for the prompt and the llm key.
+func (c *Redis) Lookup(ctx context.Context, prompt, llmKey string) (*schema.ModelResult, bool, error) {
+	value, err := c.redisClient.Get(ctx, c.key(prompt, llmKey)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	result, err := unmarshalModelResult([]byte(value))
+	if err != nil {
+		return nil, false, err
+	}
+
+	return result, true, nil
+}
+
+// Update stores the result for the prompt and the llm key.
+func (c *Redis) Update(ctx context.Context, prompt, llmKey string, result *schema.ModelResult) error {
+	value, err := marshalModelResult(result)
+	if err != nil {
+		return err
+	}
+
+	var ttl time.Duration
+	if c.opts.TTL > 0 {
+		ttl = c.opts.TTL
+	}
+
+	return c.redisClient.Set(ctx, c.key(prompt, llmKey), string(value), ttl).Err()
+}
+
+// Clear removes all cached results with the key prefix of the cache.
+func (c *Redis) Clear(ctx context.Context) error {
+	keys, err := c.redisClient.Keys(ctx, c.opts.KeyPrefix+"*").Result()
+	if err != nil {
+		return err
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return c.redisClient.Del(ctx, keys...).Err()
+}
+
+func (c *Redis) key(prompt, llmKey string) string {
+	return c.opts.KeyPrefix + hashKey(prompt, llmKey)
+}
+
+type jsonGeneration struct {
+	Text      string                       `json:"text"`
+	Message   map[string]string            `json:"message,omitempty"`
+	Extension *schema.ChatMessageExtension `json:"extension,omitempty"`
+	Info      map[string]any               `json:"info,omitempty"`
+}
+
+type jsonModelResult struct {
+	Generations []jsonGeneration `json:"generations"`
+	LLMOutput   map[string]any   `json:"llmOutput,omitempty"`
+}
+
+// marshalModelResult encodes a model result as JSON. The values of the generation info and the
+// llm output are restored as plain JSON values.
+func marshalModelResult(result *schema.ModelResult) ([]byte, error) {
+	r := jsonModelResult{
+		Generations: make([]jsonGeneration, len(result.Generations)),
+		LLMOutput:   result.LLMOutput,
+	}
+
+	for i, g := range result.Generations {
+		r.Generations[i] = jsonGeneration{
+			Text: g.Text,
+			Info: g.Info,
+		}
+
+		if g.Message != nil {
+			r.Generations[i].Message = schema.ChatMessageToMap(g.Message)
+
+			if ai, ok := g.Message.(*schema.AIChatMessage); ok {
+				ext := ai.Extension()
+				r.Generations[i].Extension = &ext
+			}
+		}
+	}
+
+	return json.Marshal(r)
+}
+
+func unmarshalModelResult(data []byte) (*schema.ModelResult, error) {
+	r := jsonModelResult{}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+
+	result := &schema.ModelResult{
+		Generations: make([]schema.Generation, len(r.Generations)),
+		LLMOutput:   r.LLMOutput,
+	}
+
+	for i, g := range r.Generations {
+		result.Generations[i] = schema.Generation{
+			Text: g.Text,
+			Info: g.Info,
+		}
+
+		if g.Message == nil {
+			continue
+		}
+
+		if schema.ChatMessageType(g.Message["type"]) == schema.ChatMessageTypeAI && g.Extension != nil {
+			ext := *g.Extension
+			result.Generations[i].Message = schema.NewAIChatMessage(g.Message["content"], func(o *schema.ChatMessageExtension) {
+				*o = ext
+			})
+
+			continue
+		}
+
+		message, err := schema.MapToChatMessage(g.Message)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Generations[i].Message = message
+	}
+
+	return result, nil
+}
@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedis(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("LookupAndUpdate", func(t *testing.T) {
+		client := newMockRedisClient()
+
+		c := NewRedis(client, func(o *RedisOptions) {
+			o.TTL = time.Hour
+		})
+
+		_, ok, err := c.Lookup(ctx, "key", "llm")
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		require.NoError(t, c.Update(ctx, "key", "llm", &schema.ModelResult{
+			Generations: []schema.Generation{{
+				Text: "foo",
+				Message: schema.NewAIChatMessage("foo", func(o *schema.ChatMessageExtension) {
+					o.ToolCalls = []schema.ToolCall{{ID: "call", Name: "Search", Arguments: "{}"}}
+				}),
+			}},
+			LLMOutput: map[string]any{"ModelName": "gpt-4o"},
+		}))
+
+		assert.Equal(t, time.Hour, client.expirations["golc_cache:"+hashKey("key", "llm")])
+
+		result, ok, err := c.Lookup(ctx, "key", "llm")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "foo", result.Generations[0].Text)
+		assert.Equal(t, "gpt-4o", result.LLMOutput["ModelName"])
+
+		message, ok := result.Generations[0].Message.(*schema.AIChatMessage)
+		require.True(t, ok)
+		assert.Equal(t, "Search", message.Extension().ToolCalls[0].Name)
+
+		require.NoError(t, c.Clear(ctx))
+		assert.Empty(t, client.values)
+	})
+}
+
+type mockRedisClient struct {
+	values      map[string]string
+	expirations map[string]time.Duration
+}
+
+func newMockRedisClient() *mockRedisClient {
+	return &mockRedisClient{
+		values:      make(map[string]string),
+		expirations: make(map[string]time.Duration),
+	}
+}
+
+func (c *mockRedisClient) Get(ctx context.Context, key string) *redis.StringCmd {
+	cmd := redis.NewStringCmd(ctx)
+
+	value, ok := c.values[key]
+	if !ok {
+		cmd.SetErr(redis.Nil)
+		return cmd
+	}
+
+	cmd.SetVal(value)
+
+	return cmd
+}
+
+func (c *mockRedisClient) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+	c.values[key] = value.(string)
+	c.expirations[key] = expiration
+
+	return redis.NewStatusCmd(ctx)
+}
+
+func (c *mockRedisClient) Keys(ctx context.Context, pattern string) *redis.StringSliceCmd {
+	cmd := redis.NewStringSliceCmd(ctx)
+
+	keys := make([]string, 0, len(c.values))
+	for k := range c.values {
+		keys = append(keys, k)
+	}
+
+	cmd.SetVal(keys)
+
+	return cmd
+}
+
+func (c *mockRedisClient) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	for _, k := range keys {
+		delete(c.values, k)
+	}
+
+	return redis.NewIntCmd(ctx)
+}
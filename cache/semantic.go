@@ -0,0 +1,158 @@
+package cache
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hupe1980/golc/metric"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Semantic satisfies the Cache interface.
+var _ schema.Cache = (*Semantic)(nil)
+
+// SemanticOptions contains the options for the Semantic cache.
+type SemanticOptions struct {
+	// SimilarityThreshold is the minimum similarity score between a prompt and a cached prompt
+	// to return the cached result. For vector stores with scores it is compared to the score of the
+	// vector store, otherwise to the cosine similarity of the embeddings.
+	SimilarityThreshold float32
+}
+
+// Semantic is a cache that stores the prompts in a vector store and returns the cached result of the
+// most similar prompt of the same model, if its similarity exceeds the similarity threshold.
+type Semantic struct {
+	vectorStore schema.VectorStore
+	embedder    schema.Embedder
+	opts        SemanticOptions
+	mu          sync.Mutex
+}
+
+// NewSemantic creates a new Semantic cache. Vector stores with filters are queried for the prompts of the
+// model only. The embedder is used to calculate the similarity for vector stores without scores and should
+// be the embedder of the vector store.
+func NewSemantic(vectorStore schema.VectorStore, embedder schema.Embedder, optFns ...func(o *SemanticOptions)) *Semantic {
+	opts := SemanticOptions{
+		SimilarityThreshold: 0.95,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Semantic{
+		vectorStore: vectorStore,
+		embedder:    embedder,
+		opts:        opts,
+	}
+}
+
+// Lookup returns the cached result of the most similar prompt of the model identified by the llm key.
+func (c *Semantic) Lookup(ctx context.Context, prompt, llmKey string) (*schema.ModelResult, bool, error) {
+	c.mu.Lock()
+	candidates, err := c.search(ctx, prompt, llmKey)
+	c.mu.Unlock()
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	var best *schema.ScoredDocument
+
+	for i := range candidates {
+		if candidates[i].Score >= c.opts.SimilarityThreshold && (best == nil || candidates[i].Score > best.Score) {
+			best = &candidates[i]
+		}
+	}
+
+	if best == nil {
+		return nil, false, nil
+	}
+
+	value, ok := best.Document.Metadata["result"].(string)
+	if !ok {
+		return nil, false, nil
+	}
+
+	result, err := unmarshalModelResult([]byte(value))
+	if err != nil {
+		return nil, false, err
+	}
+
+	return result, true, nil
+}
+
+// search returns the cached prompts of the model similar to the prompt together with their similarity scores.
+func (c *Semantic) search(ctx context.Context, prompt, llmKey string) ([]schema.ScoredDocument, error) {
+	switch vs := c.vectorStore.(type) {
+	case schema.VectorStoreWithFilter:
+		return vs.SimilaritySearchWithFilter(ctx, prompt, map[string]any{"llm_key": llmKey})
+	case schema.VectorStoreWithScores:
+		docs, err := vs.SimilaritySearchWithScores(ctx, prompt)
+		if err != nil {
+			return nil, err
+		}
+
+		candidates := make([]schema.ScoredDocument, 0, len(docs))
+
+		for _, doc := range docs {
+			if key, ok := doc.Document.Metadata["llm_key"].(string); ok && key == llmKey {
+				candidates = append(candidates, doc)
+			}
+		}
+
+		return candidates, nil
+	}
+
+	docs, err := c.vectorStore.SimilaritySearch(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	texts := []string{prompt}
+	candidates := make([]schema.ScoredDocument, 0, len(docs))
+
+	for _, doc := range docs {
+		if key, ok := doc.Metadata["llm_key"].(string); ok && key == llmKey {
+			texts = append(texts, doc.PageContent)
+			candidates = append(candidates, schema.ScoredDocument{Document: doc})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	embeddings, err := c.embedder.BatchEmbedText(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range candidates {
+		candidates[i].Score, err = metric.CosineSimilarity(embeddings[0], embeddings[i+1])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return candidates, nil
+}
+
+// Update adds the prompt together with the result and the llm key to the vector store.
+func (c *Semantic) Update(ctx context.Context, prompt, llmKey string, result *schema.ModelResult) error {
+	value, err := marshalModelResult(result)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.vectorStore.AddDocuments(ctx, []schema.Document{{
+		PageContent: prompt,
+		Metadata: map[string]any{
+			"llm_key": llmKey,
+			"result":  string(value),
+		},
+	}})
+}
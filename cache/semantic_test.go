@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc/metric"
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/vectorstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSemantic(t *testing.T) {
+	ctx := context.Background()
+
+	embedder := &mockEmbedder{vectors: map[string][]float32{
+		"What is the capital of France?":     {1, 0, 0},
+		"What's the capital city of France?": {0.99, 0.1, 0},
+		"How tall is the Eiffel Tower?":      {0, 1, 0},
+	}}
+
+	newCache := func() *Semantic {
+		vs := vectorstore.NewInMemory(embedder, func(o *vectorstore.InMemoryOptions) {
+			o.DistanceFunc = metric.CosineDistance
+		})
+
+		return NewSemantic(vs, embedder, func(o *SemanticOptions) {
+			o.SimilarityThreshold = 0.9
+		})
+	}
+
+	result := &schema.ModelResult{
+		Generations: []schema.Generation{{Text: "Paris"}},
+	}
+
+	t.Run("SimilarPrompt", func(t *testing.T) {
+		c := newCache()
+
+		require.NoError(t, c.Update(ctx, "What is the capital of France?", "llm", result))
+
+		cached, ok, err := c.Lookup(ctx, "What's the capital city of France?", "llm")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "Paris", cached.Generations[0].Text)
+	})
+
+	t.Run("DissimilarPrompt", func(t *testing.T) {
+		c := newCache()
+
+		require.NoError(t, c.Update(ctx, "What is the capital of France?", "llm", result))
+
+		_, ok, err := c.Lookup(ctx, "How tall is the Eiffel Tower?", "llm")
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("OtherModel", func(t *testing.T) {
+		c := newCache()
+
+		require.NoError(t, c.Update(ctx, "What is the capital of France?", "llm", result))
+
+		_, ok, err := c.Lookup(ctx, "What is the capital of France?", "other")
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("OtherModelPrompts", func(t *testing.T) {
+		c := newCache()
+
+		require.NoError(t, c.Update(ctx, "What's the capital city of France?", "llm", result))
+
+		for _, key := range []string{"a", "b", "c", "d"} {
+			require.NoError(t, c.Update(ctx, "What is the capital of France?", key, result))
+		}
+
+		embedder.batchCalls = 0
+
+		cached, ok, err := c.Lookup(ctx, "What is the capital of France?", "llm")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "Paris", cached.Generations[0].Text)
+		assert.Equal(t, 0, embedder.batchCalls)
+	})
+}
+
+type mockEmbedder struct {
+	vectors    map[string][]float32
+	batchCalls int
+}
+
+func (e *mockEmbedder) BatchEmbedText(ctx context.Context, texts []string) ([][]float32, error) {
+	e.batchCalls++
+
+	embeddings := make([][]float32, len(texts))
+	for i, text := range texts {
+		embeddings[i] = e.vectors[text]
+	}
+
+	return embeddings, nil
+}
+
+func (e *mockEmbedder) EmbedText(ctx context.Context, text string) ([]float32, error) {
+	return e.vectors[text], nil
+}
@@ -0,0 +1,52 @@
+package callback
+
+import (
+	"context"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// toolRunManagerKey is the context key of the callback manager of a tool run.
+type toolRunManagerKey struct{}
+
+// WithToolRunManager returns a copy of the context carrying the callback manager of the tool run,
+// so that the tool can report events as part of its run.
+func WithToolRunManager(ctx context.Context, rm schema.CallbackManagerForToolRun) context.Context {
+	return context.WithValue(ctx, toolRunManagerKey{}, rm)
+}
+
+// ToolRunManagerFromContext returns the callback manager of the tool run carried by the context, if any.
+func ToolRunManagerFromContext(ctx context.Context) (schema.CallbackManagerForToolRun, bool) {
+	rm, ok := ctx.Value(toolRunManagerKey{}).(schema.CallbackManagerForToolRun)
+	return rm, ok
+}
+
+// chainRunManagerKey is the context key of the callback manager of a chain run.
+type chainRunManagerKey struct{}
+
+// WithChainRunManager returns a copy of the context carrying the callback manager of the chain run,
+// so that e.g. the agent of an executor can nest its model runs in the run of the executor.
+func WithChainRunManager(ctx context.Context, rm schema.CallbackManagerForChainRun) context.Context {
+	return context.WithValue(ctx, chainRunManagerKey{}, rm)
+}
+
+// ChainRunManagerFromContext returns the callback manager of the chain run carried by the context, if any.
+func ChainRunManagerFromContext(ctx context.Context) (schema.CallbackManagerForChainRun, bool) {
+	rm, ok := ctx.Value(chainRunManagerKey{}).(schema.CallbackManagerForChainRun)
+	return rm, ok
+}
+
+// retrieverRunManagerKey is the context key of the callback manager of a retriever run.
+type retrieverRunManagerKey struct{}
+
+// WithRetrieverRunManager returns a copy of the context carrying the callback manager of the retriever run,
+// so that a retriever wrapping a base retriever can nest the run of the base retriever in its own run.
+func WithRetrieverRunManager(ctx context.Context, rm schema.CallbackManagerForRetrieverRun) context.Context {
+	return context.WithValue(ctx, retrieverRunManagerKey{}, rm)
+}
+
+// RetrieverRunManagerFromContext returns the callback manager of the retriever run carried by the context, if any.
+func RetrieverRunManagerFromContext(ctx context.Context) (schema.CallbackManagerForRetrieverRun, bool) {
+	rm, ok := ctx.Value(retrieverRunManagerKey{}).(schema.CallbackManagerForRetrieverRun)
+	return rm, ok
+}
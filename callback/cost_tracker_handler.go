@@ -0,0 +1,224 @@
+package callback
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// ModelPrice represents the price of a model in USD per 1M tokens.
+type ModelPrice struct {
+	PromptTokenCost     float64
+	CompletionTokenCost float64
+}
+
+// DefaultModelPrices contains the list prices of common models in USD per 1M tokens. Model names are matched
+// by their longest prefix, so dated model versions (e.g. gpt-4o-2024-05-13) use the price of their family.
+var DefaultModelPrices = map[string]ModelPrice{
+	// OpenAI
+	"gpt-4o":                 {PromptTokenCost: 5, CompletionTokenCost: 15},
+	"gpt-4o-mini":            {PromptTokenCost: 0.15, CompletionTokenCost: 0.6},
+	"gpt-4-turbo":            {PromptTokenCost: 10, CompletionTokenCost: 30},
+	"gpt-4-0125-preview":     {PromptTokenCost: 10, CompletionTokenCost: 30},
+	"gpt-4-1106-preview":     {PromptTokenCost: 10, CompletionTokenCost: 30},
+	"gpt-4":                  {PromptTokenCost: 30, CompletionTokenCost: 60},
+	"gpt-4-32k":              {PromptTokenCost: 60, CompletionTokenCost: 120},
+	"gpt-3.5-turbo":          {PromptTokenCost: 0.5, CompletionTokenCost: 1.5},
+	"gpt-3.5-turbo-instruct": {PromptTokenCost: 1.5, CompletionTokenCost: 2},
+	"text-embedding-3-small": {PromptTokenCost: 0.02},
+	"text-embedding-3-large": {PromptTokenCost: 0.13},
+	"text-embedding-ada-002": {PromptTokenCost: 0.1},
+	// Anthropic
+	"claude-3-5-sonnet": {PromptTokenCost: 3, CompletionTokenCost: 15},
+	"claude-3-opus":     {PromptTokenCost: 15, CompletionTokenCost: 75},
+	"claude-3-sonnet":   {PromptTokenCost: 3, CompletionTokenCost: 15},
+	"claude-3-haiku":    {PromptTokenCost: 0.25, CompletionTokenCost: 1.25},
+	"claude-2":          {PromptTokenCost: 8, CompletionTokenCost: 24},
+	"claude-instant":    {PromptTokenCost: 0.8, CompletionTokenCost: 2.4},
+	"claude-v2":         {PromptTokenCost: 8, CompletionTokenCost: 24},
+	// Cohere
+	"command":        {PromptTokenCost: 1, CompletionTokenCost: 2},
+	"command-light":  {PromptTokenCost: 0.3, CompletionTokenCost: 0.6},
+	"command-r":      {PromptTokenCost: 0.5, CompletionTokenCost: 1.5},
+	"command-r-plus": {PromptTokenCost: 3, CompletionTokenCost: 15},
+	// Google
+	"gemini-1.0-pro":   {PromptTokenCost: 0.5, CompletionTokenCost: 1.5},
+	"gemini-1.5-pro":   {PromptTokenCost: 3.5, CompletionTokenCost: 10.5},
+	"gemini-1.5-flash": {PromptTokenCost: 0.35, CompletionTokenCost: 1.05},
+}
+
+// Compile time check to ensure CostTrackerHandler satisfies the Callback interface.
+var _ schema.Callback = (*CostTrackerHandler)(nil)
+
+// CostTrackerOptions contains the options for the CostTrackerHandler.
+type CostTrackerOptions struct {
+	// Prices of the models in USD per 1M tokens. The prices are merged with the DefaultModelPrices.
+	Prices map[string]ModelPrice
+}
+
+// CostTrackerHandler accumulates the token usage and the resulting costs of all model runs it is attached to.
+// Since callbacks are passed on to child runs, the costs of a whole run hierarchy are tracked.
+type CostTrackerHandler struct {
+	*TokenUsageHandler
+	prices         map[string]ModelPrice
+	promptCost     float64
+	completionCost float64
+	costByModel    map[string]float64
+	unknownModels  map[string]struct{}
+	mu             sync.Mutex
+}
+
+// NewCostTrackerHandler creates a new CostTrackerHandler.
+func NewCostTrackerHandler(optFns ...func(o *CostTrackerOptions)) *CostTrackerHandler {
+	opts := CostTrackerOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	prices := make(map[string]ModelPrice, len(DefaultModelPrices)+len(opts.Prices))
+
+	for k, v := range DefaultModelPrices {
+		prices[k] = v
+	}
+
+	for k, v := range opts.Prices {
+		prices[strings.ToLower(k)] = v
+	}
+
+	return &CostTrackerHandler{
+		TokenUsageHandler: NewTokenUsageHandler(),
+		prices:            prices,
+		costByModel:       make(map[string]float64),
+		unknownModels:     make(map[string]struct{}),
+	}
+}
+
+func (cb *CostTrackerHandler) String() string {
+	return fmt.Sprintf("%s\nTotal Cost (USD): $%.6f", cb.TokenUsageHandler, cb.TotalCost())
+}
+
+func (cb *CostTrackerHandler) OnModelEnd(ctx context.Context, input *schema.ModelEndInput) error {
+	if err := cb.TokenUsageHandler.OnModelEnd(ctx, input); err != nil {
+		return err
+	}
+
+	tokenUsage, ok := input.Result.TokenUsage()
+	if !ok {
+		return nil
+	}
+
+	modelName, _ := input.Result.LLMOutput["ModelName"].(string)
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	price, ok := cb.lookupPrice(modelName)
+	if !ok {
+		cb.unknownModels[modelName] = struct{}{}
+		return nil
+	}
+
+	promptCost := price.PromptTokenCost * float64(tokenUsage.PromptTokens) / 1e6
+	completionCost := price.CompletionTokenCost * float64(tokenUsage.CompletionTokens) / 1e6
+
+	cb.promptCost += promptCost
+	cb.completionCost += completionCost
+	cb.costByModel[modelName] += promptCost + completionCost
+
+	return nil
+}
+
+// TotalCost returns the accumulated costs in USD.
+func (cb *CostTrackerHandler) TotalCost() float64 {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	return cb.promptCost + cb.completionCost
+}
+
+// PromptCost returns the accumulated costs of the prompt tokens in USD.
+func (cb *CostTrackerHandler) PromptCost() float64 {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	return cb.promptCost
+}
+
+// CompletionCost returns the accumulated costs of the completion tokens in USD.
+func (cb *CostTrackerHandler) CompletionCost() float64 {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	return cb.completionCost
+}
+
+// CostByModel returns the accumulated costs in USD per model name.
+func (cb *CostTrackerHandler) CostByModel() map[string]float64 {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	costByModel := make(map[string]float64, len(cb.costByModel))
+	for k, v := range cb.costByModel {
+		costByModel[k] = v
+	}
+
+	return costByModel
+}
+
+// UnknownModels returns the names of the models without a price. Their token usage is tracked, but not their costs.
+func (cb *CostTrackerHandler) UnknownModels() []string {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	models := make([]string, 0, len(cb.unknownModels))
+	for k := range cb.unknownModels {
+		models = append(models, k)
+	}
+
+	return models
+}
+
+// Reset clears the accumulated token usage and costs.
+func (cb *CostTrackerHandler) Reset() {
+	cb.TokenUsageHandler.Reset()
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.promptCost = 0
+	cb.completionCost = 0
+	cb.costByModel = make(map[string]float64)
+	cb.unknownModels = make(map[string]struct{})
+}
+
+// lookupPrice returns the price of the model with the longest matching prefix. Cross-region and
+// provider prefixes of Amazon Bedrock model ids (e.g. us.anthropic.claude-3-haiku-20240307-v1:0) are ignored.
+func (cb *CostTrackerHandler) lookupPrice(modelName string) (ModelPrice, bool) {
+	name := strings.ToLower(modelName)
+
+	for _, prefix := range []string{"us.", "eu.", "apac.", "us-gov."} {
+		name = strings.TrimPrefix(name, prefix)
+	}
+
+	for _, provider := range []string{"anthropic.", "cohere.", "meta.", "amazon.", "mistral.", "ai21."} {
+		name = strings.TrimPrefix(name, provider)
+	}
+
+	var (
+		match string
+		price ModelPrice
+		found bool
+	)
+
+	for k, v := range cb.prices {
+		if strings.HasPrefix(name, k) && len(k) > len(match) {
+			match, price, found = k, v, true
+		}
+	}
+
+	return price, found
+}
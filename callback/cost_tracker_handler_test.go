@@ -0,0 +1,62 @@
+package callback
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCostTrackerHandler(t *testing.T) {
+	modelEnd := func(modelName string, usage schema.TokenUsage) *schema.ModelEndInput {
+		return &schema.ModelEndInput{
+			ModelEndManagerInput: &schema.ModelEndManagerInput{
+				Result: &schema.ModelResult{
+					LLMOutput: map[string]any{
+						"ModelName":  modelName,
+						"TokenUsage": usage,
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("Accumulate", func(t *testing.T) {
+		cb := NewCostTrackerHandler(func(o *CostTrackerOptions) {
+			o.Prices = map[string]ModelPrice{
+				"my-model": {PromptTokenCost: 1, CompletionTokenCost: 2},
+			}
+		})
+
+		assert.NoError(t, cb.OnModelEnd(context.Background(), modelEnd("gpt-4o-2024-05-13", schema.NewTokenUsage(1000000, 1000000))))
+		assert.NoError(t, cb.OnModelEnd(context.Background(), modelEnd("us.anthropic.claude-3-haiku-20240307-v1:0", schema.NewTokenUsage(1000000, 0))))
+		assert.NoError(t, cb.OnModelEnd(context.Background(), modelEnd("my-model", schema.NewTokenUsage(500000, 500000))))
+		assert.NoError(t, cb.OnModelEnd(context.Background(), modelEnd("unknown", schema.NewTokenUsage(10, 10))))
+
+		assert.InDelta(t, 5+0.25+0.5, cb.PromptCost(), 1e-9)
+		assert.InDelta(t, 15+1, cb.CompletionCost(), 1e-9)
+		assert.InDelta(t, 21.75, cb.TotalCost(), 1e-9)
+		assert.InDelta(t, 20, cb.CostByModel()["gpt-4o-2024-05-13"], 1e-9)
+		assert.Equal(t, []string{"unknown"}, cb.UnknownModels())
+		assert.Equal(t, 4, cb.SuccessfulRequests())
+		assert.Equal(t, 4000020, cb.TokenUsage().TotalTokens)
+
+		cb.Reset()
+
+		assert.Equal(t, 0.0, cb.TotalCost())
+		assert.Equal(t, schema.TokenUsage{}, cb.TokenUsage())
+	})
+
+	t.Run("LongestPrefix", func(t *testing.T) {
+		cb := NewCostTrackerHandler()
+
+		price, ok := cb.lookupPrice("gpt-4o-mini-2024-07-18")
+		assert.True(t, ok)
+		assert.Equal(t, DefaultModelPrices["gpt-4o-mini"], price)
+
+		price, ok = cb.lookupPrice("command-r-plus")
+		assert.True(t, ok)
+		assert.Equal(t, DefaultModelPrices["command-r-plus"], price)
+	})
+}
@@ -0,0 +1,190 @@
+package callback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hupe1980/golc/integration/langfuse"
+)
+
+// Compile time check to ensure LangfuseExporter satisfies the RunExporter interface.
+var _ RunExporter = (*LangfuseExporter)(nil)
+
+// LangfuseClient is the interface of the Langfuse ingestion client.
+type LangfuseClient interface {
+	Ingest(ctx context.Context, req *langfuse.IngestionRequest) (*langfuse.IngestionResponse, error)
+}
+
+// LangfuseHandlerOptions contains the options for the Langfuse tracing handler.
+type LangfuseHandlerOptions struct {
+	TracingHandlerOptions
+	// APIUrl is the url of the Langfuse server.
+	APIUrl string
+	// HTTPClient is the HTTP client used to send the traces.
+	HTTPClient langfuse.HTTPClient
+	// SessionID groups the traces of a session.
+	SessionID string
+	// UserID is the id of the user the traces belong to.
+	UserID string
+	// Tags are added to all traces.
+	Tags []string
+}
+
+// LangfuseExporter exports run trees as traces to Langfuse. Each run tree becomes a trace,
+// model runs become generations and all other runs become spans.
+type LangfuseExporter struct {
+	client LangfuseClient
+	opts   LangfuseHandlerOptions
+}
+
+// NewLangfuseHandler creates a tracing handler that exports the run trees to Langfuse.
+func NewLangfuseHandler(publicKey, secretKey string, optFns ...func(o *LangfuseHandlerOptions)) *TracingHandler {
+	opts := LangfuseHandlerOptions{
+		APIUrl: langfuse.DefaultAPIUrl,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	client := langfuse.New(publicKey, secretKey, func(o *langfuse.ClientOptions) {
+		o.APIUrl = opts.APIUrl
+
+		if opts.HTTPClient != nil {
+			o.HTTPClient = opts.HTTPClient
+		}
+	})
+
+	return NewTracingHandler(NewLangfuseExporter(client, func(o *LangfuseHandlerOptions) {
+		*o = opts
+	}), func(o *TracingHandlerOptions) {
+		applyTracingHandlerOptions(o, opts.TracingHandlerOptions)
+	})
+}
+
+// NewLangfuseExporter creates a new LangfuseExporter using the given client.
+func NewLangfuseExporter(client LangfuseClient, optFns ...func(o *LangfuseHandlerOptions)) *LangfuseExporter {
+	opts := LangfuseHandlerOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &LangfuseExporter{
+		client: client,
+		opts:   opts,
+	}
+}
+
+// Export sends the runs as ingestion events to Langfuse.
+func (e *LangfuseExporter) Export(ctx context.Context, runs []*Run) error {
+	events := make([]langfuse.Event, 0, len(runs))
+
+	for _, r := range runs {
+		if r.ParentID == "" {
+			events = append(events, langfuse.Event{
+				ID:        uuid.New().String(),
+				Type:      langfuse.EventTypeTraceCreate,
+				Timestamp: r.EndTime,
+				Body: langfuse.Trace{
+					ID:        r.TraceID,
+					Name:      r.Name,
+					Timestamp: &r.StartTime,
+					Input:     r.Inputs,
+					Output:    r.Outputs,
+					SessionID: e.opts.SessionID,
+					UserID:    e.opts.UserID,
+					Tags:      e.opts.Tags,
+				},
+			})
+		}
+
+		span := langfuse.Span{
+			ID:                  r.ID,
+			TraceID:             r.TraceID,
+			ParentObservationID: r.ParentID,
+			Name:                r.Name,
+			StartTime:           &r.StartTime,
+			EndTime:             &r.EndTime,
+			Input:               r.Inputs,
+			Output:              r.Outputs,
+			Metadata:            map[string]any{"run_type": r.Type},
+		}
+
+		if r.Error != "" {
+			span.Level = langfuse.LevelError
+			span.StatusMessage = r.Error
+		}
+
+		if r.Type != RunTypeLLM && r.Type != RunTypeChatModel {
+			events = append(events, langfuse.Event{
+				ID:        uuid.New().String(),
+				Type:      langfuse.EventTypeSpanCreate,
+				Timestamp: r.EndTime,
+				Body:      span,
+			})
+
+			continue
+		}
+
+		generation := langfuse.Generation{
+			Span:  span,
+			Model: r.Model,
+		}
+
+		if params, ok := r.Metadata["invocation_params"].(map[string]any); ok {
+			generation.ModelParameters = params
+		}
+
+		if r.TokenUsage != nil {
+			generation.Usage = &langfuse.Usage{
+				Input:  r.TokenUsage.PromptTokens,
+				Output: r.TokenUsage.CompletionTokens,
+				Total:  r.TokenUsage.TotalTokens,
+				Unit:   "TOKENS",
+			}
+		}
+
+		events = append(events, langfuse.Event{
+			ID:        uuid.New().String(),
+			Type:      langfuse.EventTypeGenerationCreate,
+			Timestamp: r.EndTime,
+			Body:      generation,
+		})
+	}
+
+	res, err := e.client.Ingest(ctx, &langfuse.IngestionRequest{
+		Batch: events,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(res.Errors) > 0 {
+		errs := make([]error, len(res.Errors))
+		for i, ie := range res.Errors {
+			errs[i] = fmt.Errorf("langfuse event %s: status %d: %s", ie.ID, ie.Status, ie.Message)
+		}
+
+		return errors.Join(errs...)
+	}
+
+	return nil
+}
+
+// applyTracingHandlerOptions overrides the default tracing handler options with the non-zero options.
+func applyTracingHandlerOptions(o *TracingHandlerOptions, opts TracingHandlerOptions) {
+	if opts.BatchSize > 0 {
+		o.BatchSize = opts.BatchSize
+	}
+
+	if opts.FlushInterval > 0 {
+		o.FlushInterval = opts.FlushInterval
+	}
+
+	if opts.OnError != nil {
+		o.OnError = opts.OnError
+	}
+}
@@ -0,0 +1,144 @@
+package callback
+
+import (
+	"context"
+
+	"github.com/hupe1980/golc/integration/langsmith"
+)
+
+// Compile time check to ensure LangSmithExporter satisfies the RunExporter interface.
+var _ RunExporter = (*LangSmithExporter)(nil)
+
+// LangSmithClient is the interface of the LangSmith run ingestion client.
+type LangSmithClient interface {
+	BatchIngestRuns(ctx context.Context, req *langsmith.BatchIngestRunsRequest) error
+}
+
+// LangSmithHandlerOptions contains the options for the LangSmith tracing handler.
+type LangSmithHandlerOptions struct {
+	TracingHandlerOptions
+	// APIUrl is the url of the LangSmith API or a compatible server.
+	APIUrl string
+	// HTTPClient is the HTTP client used to send the runs.
+	HTTPClient langsmith.HTTPClient
+	// ProjectName is the name of the project the runs are logged to.
+	ProjectName string
+	// Tags are added to all runs.
+	Tags []string
+}
+
+// LangSmithExporter exports run trees to LangSmith or a compatible server.
+type LangSmithExporter struct {
+	client LangSmithClient
+	opts   LangSmithHandlerOptions
+}
+
+// NewLangSmithHandler creates a tracing handler that exports the run trees to LangSmith.
+func NewLangSmithHandler(apiKey string, optFns ...func(o *LangSmithHandlerOptions)) *TracingHandler {
+	opts := LangSmithHandlerOptions{
+		APIUrl:      langsmith.DefaultAPIUrl,
+		ProjectName: "default",
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	client := langsmith.New(apiKey, func(o *langsmith.ClientOptions) {
+		o.APIUrl = opts.APIUrl
+
+		if opts.HTTPClient != nil {
+			o.HTTPClient = opts.HTTPClient
+		}
+	})
+
+	return NewTracingHandler(NewLangSmithExporter(client, func(o *LangSmithHandlerOptions) {
+		*o = opts
+	}), func(o *TracingHandlerOptions) {
+		applyTracingHandlerOptions(o, opts.TracingHandlerOptions)
+	})
+}
+
+// NewLangSmithExporter creates a new LangSmithExporter using the given client.
+func NewLangSmithExporter(client LangSmithClient, optFns ...func(o *LangSmithHandlerOptions)) *LangSmithExporter {
+	opts := LangSmithHandlerOptions{
+		ProjectName: "default",
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &LangSmithExporter{
+		client: client,
+		opts:   opts,
+	}
+}
+
+// Export posts the completed runs to LangSmith.
+func (e *LangSmithExporter) Export(ctx context.Context, runs []*Run) error {
+	post := make([]*langsmith.Run, len(runs))
+
+	for i, r := range runs {
+		endTime := r.EndTime
+
+		extra := map[string]any{
+			"metadata": r.Metadata,
+		}
+
+		inputs := r.Inputs
+		if inputs == nil {
+			inputs = map[string]any{}
+		}
+
+		outputs := r.Outputs
+
+		runType := langsmith.RunType(r.Type)
+		if r.Type == RunTypeChatModel {
+			runType = langsmith.RunTypeLLM
+		}
+
+		if runType == langsmith.RunTypeLLM {
+			if params, ok := r.Metadata["invocation_params"]; ok {
+				extra["invocation_params"] = params
+			}
+
+			if r.TokenUsage != nil {
+				outputs = make(map[string]any, len(r.Outputs)+1)
+				for k, v := range r.Outputs {
+					outputs[k] = v
+				}
+
+				outputs["llm_output"] = map[string]any{
+					"model_name": r.Model,
+					"token_usage": map[string]int{
+						"prompt_tokens":     r.TokenUsage.PromptTokens,
+						"completion_tokens": r.TokenUsage.CompletionTokens,
+						"total_tokens":      r.TokenUsage.TotalTokens,
+					},
+				}
+			}
+		}
+
+		post[i] = &langsmith.Run{
+			ID:          r.ID,
+			TraceID:     r.TraceID,
+			DottedOrder: r.DottedOrder,
+			ParentRunID: r.ParentID,
+			Name:        r.Name,
+			RunType:     runType,
+			StartTime:   r.StartTime,
+			EndTime:     &endTime,
+			Inputs:      inputs,
+			Outputs:     outputs,
+			Error:       r.Error,
+			SessionName: e.opts.ProjectName,
+			Extra:       extra,
+			Tags:        e.opts.Tags,
+		}
+	}
+
+	return e.client.BatchIngestRuns(ctx, &langsmith.BatchIngestRunsRequest{
+		Post: post,
+	})
+}
@@ -22,6 +22,13 @@ var _ schema.CallbackManagerForToolRun = (*manager)(nil)
 // Compile time check to ensure manager satisfies the CallbackManagerForRetrieverRun interface.
 var _ schema.CallbackManagerForRetrieverRun = (*manager)(nil)
 
+// Compile time check to ensure manager satisfies the CallbackManagerForEmbedderRun interface.
+var _ schema.CallbackManagerForEmbedderRun = (*manager)(nil)
+
+// verboseHandler logs the runs of verbose chains, models, tools, retrievers and embedders with the default slog logger.
+// It is shared by all managers, so that the durations of runs can be tracked across parent and child managers.
+var verboseHandler = NewSlogHandler()
+
 type ManagerOptions struct {
 	ParentRunID string
 }
@@ -43,8 +50,8 @@ func newManager(runID string, inheritableCallbacks, localCallbacks []schema.Call
 	}
 
 	callbacks := append(inheritableCallbacks, localCallbacks...)
-	if verbose && !containsWriterCallbackHandler(callbacks) {
-		callbacks = append(callbacks, NewWriterHandler())
+	if verbose && !containsVerboseCallbackHandler(callbacks) {
+		callbacks = append(callbacks, verboseHandler)
 	}
 
 	return &manager{
@@ -77,6 +84,10 @@ func NewManagerForRetrieverRun(runID string, inheritableCallbacks, localCallback
 	return newManager(runID, inheritableCallbacks, localCallbacks, verbose, optFns...)
 }
 
+func NewManagerForEmbedderRun(runID string, inheritableCallbacks, localCallbacks []schema.Callback, verbose bool, optFns ...func(*ManagerOptions)) schema.CallbackManagerForEmbedderRun {
+	return newManager(runID, inheritableCallbacks, localCallbacks, verbose, optFns...)
+}
+
 func (m *manager) GetInheritableCallbacks() []schema.Callback {
 	return m.inheritableCallbacks
 }
@@ -93,6 +104,7 @@ func (m *manager) OnLLMStart(ctx context.Context, input *schema.LLMStartManagerI
 			if err := c.OnLLMStart(ctx, &schema.LLMStartInput{
 				LLMStartManagerInput: input,
 				RunID:                runID,
+				ParentRunID:          m.parentRunID,
 			}); err != nil {
 				if c.RaiseError() {
 					return nil, err
@@ -101,7 +113,9 @@ func (m *manager) OnLLMStart(ctx context.Context, input *schema.LLMStartManagerI
 		}
 	}
 
-	return NewManagerForModelRun(runID, m.inheritableCallbacks, m.localCallbacks, m.verbose), nil
+	return NewManagerForModelRun(runID, m.inheritableCallbacks, m.localCallbacks, m.verbose, func(mo *ManagerOptions) {
+		mo.ParentRunID = m.parentRunID
+	}), nil
 }
 
 func (m *manager) OnChatModelStart(ctx context.Context, input *schema.ChatModelStartManagerInput) (schema.CallbackManagerForModelRun, error) {
@@ -112,6 +126,7 @@ func (m *manager) OnChatModelStart(ctx context.Context, input *schema.ChatModelS
 			if err := c.OnChatModelStart(ctx, &schema.ChatModelStartInput{
 				ChatModelStartManagerInput: input,
 				RunID:                      runID,
+				ParentRunID:                m.parentRunID,
 			}); err != nil {
 				if c.RaiseError() {
 					return nil, err
@@ -120,7 +135,9 @@ func (m *manager) OnChatModelStart(ctx context.Context, input *schema.ChatModelS
 		}
 	}
 
-	return NewManagerForModelRun(runID, m.inheritableCallbacks, m.localCallbacks, m.verbose), nil
+	return NewManagerForModelRun(runID, m.inheritableCallbacks, m.localCallbacks, m.verbose, func(mo *ManagerOptions) {
+		mo.ParentRunID = m.parentRunID
+	}), nil
 }
 
 func (m *manager) OnModelNewToken(ctx context.Context, input *schema.ModelNewTokenManagerInput) error {
@@ -129,6 +146,7 @@ func (m *manager) OnModelNewToken(ctx context.Context, input *schema.ModelNewTok
 			if err := c.OnModelNewToken(ctx, &schema.ModelNewTokenInput{
 				ModelNewTokenManagerInput: input,
 				RunID:                     m.runID,
+				ParentRunID:               m.parentRunID,
 			}); err != nil {
 				if c.RaiseError() {
 					return err
@@ -146,6 +164,7 @@ func (m *manager) OnModelEnd(ctx context.Context, input *schema.ModelEndManagerI
 			if err := c.OnModelEnd(ctx, &schema.ModelEndInput{
 				ModelEndManagerInput: input,
 				RunID:                m.runID,
+				ParentRunID:          m.parentRunID,
 			}); err != nil {
 				if c.RaiseError() {
 					return err
@@ -163,6 +182,7 @@ func (m *manager) OnModelError(ctx context.Context, input *schema.ModelErrorMana
 			if err := c.OnModelError(ctx, &schema.ModelErrorInput{
 				ModelErrorManagerInput: input,
 				RunID:                  m.runID,
+				ParentRunID:            m.parentRunID,
 			}); err != nil {
 				if c.RaiseError() {
 					return err
@@ -182,6 +202,7 @@ func (m *manager) OnChainStart(ctx context.Context, input *schema.ChainStartMana
 			if err := c.OnChainStart(ctx, &schema.ChainStartInput{
 				ChainStartManagerInput: input,
 				RunID:                  runID,
+				ParentRunID:            m.parentRunID,
 			}); err != nil {
 				if c.RaiseError() {
 					return nil, err
@@ -190,7 +211,9 @@ func (m *manager) OnChainStart(ctx context.Context, input *schema.ChainStartMana
 		}
 	}
 
-	return NewManagerForChainRun(runID, m.inheritableCallbacks, m.localCallbacks, m.verbose), nil
+	return NewManagerForChainRun(runID, m.inheritableCallbacks, m.localCallbacks, m.verbose, func(mo *ManagerOptions) {
+		mo.ParentRunID = m.parentRunID
+	}), nil
 }
 
 func (m *manager) OnChainEnd(ctx context.Context, input *schema.ChainEndManagerInput) error {
@@ -199,6 +222,7 @@ func (m *manager) OnChainEnd(ctx context.Context, input *schema.ChainEndManagerI
 			if err := c.OnChainEnd(ctx, &schema.ChainEndInput{
 				ChainEndManagerInput: input,
 				RunID:                m.runID,
+				ParentRunID:          m.parentRunID,
 			}); err != nil {
 				if c.RaiseError() {
 					return err
@@ -216,6 +240,7 @@ func (m *manager) OnChainError(ctx context.Context, input *schema.ChainErrorMana
 			if err := c.OnChainError(ctx, &schema.ChainErrorInput{
 				ChainErrorManagerInput: input,
 				RunID:                  m.runID,
+				ParentRunID:            m.parentRunID,
 			}); err != nil {
 				if c.RaiseError() {
 					return err
@@ -233,6 +258,25 @@ func (m *manager) OnAgentAction(ctx context.Context, input *schema.AgentActionMa
 			if err := c.OnAgentAction(ctx, &schema.AgentActionInput{
 				AgentActionManagerInput: input,
 				RunID:                   m.runID,
+				ParentRunID:             m.parentRunID,
+			}); err != nil {
+				if c.RaiseError() {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *manager) OnAgentStep(ctx context.Context, input *schema.AgentStepManagerInput) error {
+	for _, c := range m.callbacks {
+		if m.verbose || c.AlwaysVerbose() {
+			if err := c.OnAgentStep(ctx, &schema.AgentStepInput{
+				AgentStepManagerInput: input,
+				RunID:                 m.runID,
+				ParentRunID:           m.parentRunID,
 			}); err != nil {
 				if c.RaiseError() {
 					return err
@@ -250,6 +294,7 @@ func (m *manager) OnAgentFinish(ctx context.Context, input *schema.AgentFinishMa
 			if err := c.OnAgentFinish(ctx, &schema.AgentFinishInput{
 				AgentFinishManagerInput: input,
 				RunID:                   m.runID,
+				ParentRunID:             m.parentRunID,
 			}); err != nil {
 				if c.RaiseError() {
 					return err
@@ -269,6 +314,7 @@ func (m *manager) OnToolStart(ctx context.Context, input *schema.ToolStartManage
 			if err := c.OnToolStart(ctx, &schema.ToolStartInput{
 				ToolStartManagerInput: input,
 				RunID:                 runID,
+				ParentRunID:           m.parentRunID,
 			}); err != nil {
 				if c.RaiseError() {
 					return nil, err
@@ -277,7 +323,9 @@ func (m *manager) OnToolStart(ctx context.Context, input *schema.ToolStartManage
 		}
 	}
 
-	return NewManagerForToolRun(runID, m.inheritableCallbacks, m.localCallbacks, m.verbose), nil
+	return NewManagerForToolRun(runID, m.inheritableCallbacks, m.localCallbacks, m.verbose, func(mo *ManagerOptions) {
+		mo.ParentRunID = m.parentRunID
+	}), nil
 }
 
 func (m *manager) OnToolEnd(ctx context.Context, input *schema.ToolEndManagerInput) error {
@@ -286,6 +334,7 @@ func (m *manager) OnToolEnd(ctx context.Context, input *schema.ToolEndManagerInp
 			if err := c.OnToolEnd(ctx, &schema.ToolEndInput{
 				ToolEndManagerInput: input,
 				RunID:               m.runID,
+				ParentRunID:         m.parentRunID,
 			}); err != nil {
 				if c.RaiseError() {
 					return err
@@ -303,6 +352,7 @@ func (m *manager) OnToolError(ctx context.Context, input *schema.ToolErrorManage
 			if err := c.OnToolError(ctx, &schema.ToolErrorInput{
 				ToolErrorManagerInput: input,
 				RunID:                 m.runID,
+				ParentRunID:           m.parentRunID,
 			}); err != nil {
 				if c.RaiseError() {
 					return err
@@ -320,6 +370,25 @@ func (m *manager) OnText(ctx context.Context, input *schema.TextManagerInput) er
 			if err := c.OnText(ctx, &schema.TextInput{
 				TextManagerInput: input,
 				RunID:            m.runID,
+				ParentRunID:      m.parentRunID,
+			}); err != nil {
+				if c.RaiseError() {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *manager) OnModerationViolation(ctx context.Context, input *schema.ModerationViolationManagerInput) error {
+	for _, c := range m.callbacks {
+		if m.verbose || c.AlwaysVerbose() {
+			if err := c.OnModerationViolation(ctx, &schema.ModerationViolationInput{
+				ModerationViolationManagerInput: input,
+				RunID:                           m.runID,
+				ParentRunID:                     m.parentRunID,
 			}); err != nil {
 				if c.RaiseError() {
 					return err
@@ -339,6 +408,7 @@ func (m *manager) OnRetrieverStart(ctx context.Context, input *schema.RetrieverS
 			if err := c.OnRetrieverStart(ctx, &schema.RetrieverStartInput{
 				RetrieverStartManagerInput: input,
 				RunID:                      runID,
+				ParentRunID:                m.parentRunID,
 			}); err != nil {
 				if c.RaiseError() {
 					return nil, err
@@ -347,7 +417,9 @@ func (m *manager) OnRetrieverStart(ctx context.Context, input *schema.RetrieverS
 		}
 	}
 
-	return NewManagerForRetrieverRun(runID, m.inheritableCallbacks, m.localCallbacks, m.verbose), nil
+	return NewManagerForRetrieverRun(runID, m.inheritableCallbacks, m.localCallbacks, m.verbose, func(mo *ManagerOptions) {
+		mo.ParentRunID = m.parentRunID
+	}), nil
 }
 
 func (m *manager) OnRetrieverEnd(ctx context.Context, input *schema.RetrieverEndManagerInput) error {
@@ -356,6 +428,7 @@ func (m *manager) OnRetrieverEnd(ctx context.Context, input *schema.RetrieverEnd
 			if err := c.OnRetrieverEnd(ctx, &schema.RetrieverEndInput{
 				RetrieverEndManagerInput: input,
 				RunID:                    m.runID,
+				ParentRunID:              m.parentRunID,
 			}); err != nil {
 				if c.RaiseError() {
 					return err
@@ -373,6 +446,7 @@ func (m *manager) OnRetrieverError(ctx context.Context, input *schema.RetrieverE
 			if err := c.OnRetrieverError(ctx, &schema.RetrieverErrorInput{
 				RetrieverErrorManagerInput: input,
 				RunID:                      m.runID,
+				ParentRunID:                m.parentRunID,
 			}); err != nil {
 				if c.RaiseError() {
 					return err
@@ -384,9 +458,68 @@ func (m *manager) OnRetrieverError(ctx context.Context, input *schema.RetrieverE
 	return nil
 }
 
-func containsWriterCallbackHandler(handlers []schema.Callback) bool {
+func (m *manager) OnEmbedderStart(ctx context.Context, input *schema.EmbedderStartManagerInput) (schema.CallbackManagerForEmbedderRun, error) {
+	runID := uuid.New().String()
+
+	for _, c := range m.callbacks {
+		if m.verbose || c.AlwaysVerbose() {
+			if err := c.OnEmbedderStart(ctx, &schema.EmbedderStartInput{
+				EmbedderStartManagerInput: input,
+				RunID:                     runID,
+				ParentRunID:               m.parentRunID,
+			}); err != nil {
+				if c.RaiseError() {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return NewManagerForEmbedderRun(runID, m.inheritableCallbacks, m.localCallbacks, m.verbose, func(mo *ManagerOptions) {
+		mo.ParentRunID = m.parentRunID
+	}), nil
+}
+
+func (m *manager) OnEmbedderEnd(ctx context.Context, input *schema.EmbedderEndManagerInput) error {
+	for _, c := range m.callbacks {
+		if m.verbose || c.AlwaysVerbose() {
+			if err := c.OnEmbedderEnd(ctx, &schema.EmbedderEndInput{
+				EmbedderEndManagerInput: input,
+				RunID:                   m.runID,
+				ParentRunID:             m.parentRunID,
+			}); err != nil {
+				if c.RaiseError() {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *manager) OnEmbedderError(ctx context.Context, input *schema.EmbedderErrorManagerInput) error {
+	for _, c := range m.callbacks {
+		if m.verbose || c.AlwaysVerbose() {
+			if err := c.OnEmbedderError(ctx, &schema.EmbedderErrorInput{
+				EmbedderErrorManagerInput: input,
+				RunID:                     m.runID,
+				ParentRunID:               m.parentRunID,
+			}); err != nil {
+				if c.RaiseError() {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func containsVerboseCallbackHandler(handlers []schema.Callback) bool {
 	for _, handler := range handlers {
-		if _, ok := handler.(*WriterHandler); ok {
+		switch handler.(type) {
+		case *WriterHandler, *SlogHandler:
 			return true
 		}
 	}
@@ -414,6 +547,9 @@ func (m *NoopManager) OnChainError(ctx context.Context, input *schema.ChainError
 func (m *NoopManager) OnAgentAction(ctx context.Context, input *schema.AgentActionManagerInput) error {
 	return nil
 }
+func (m *NoopManager) OnAgentStep(ctx context.Context, input *schema.AgentStepManagerInput) error {
+	return nil
+}
 func (m *NoopManager) OnAgentFinish(ctx context.Context, input *schema.AgentFinishManagerInput) error {
 	return nil
 }
@@ -442,6 +578,10 @@ func (m *NoopManager) OnText(ctx context.Context, input *schema.TextManagerInput
 	return nil
 }
 
+func (m *NoopManager) OnModerationViolation(ctx context.Context, input *schema.ModerationViolationManagerInput) error {
+	return nil
+}
+
 func (m *NoopManager) GetInheritableCallbacks() []schema.Callback {
 	return nil
 }
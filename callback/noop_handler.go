@@ -55,6 +55,10 @@ func (h *NoopHandler) OnAgentAction(ctx context.Context, input *schema.AgentActi
 	return nil
 }
 
+func (h *NoopHandler) OnAgentStep(ctx context.Context, input *schema.AgentStepInput) error {
+	return nil
+}
+
 func (h *NoopHandler) OnAgentFinish(ctx context.Context, input *schema.AgentFinishInput) error {
 	return nil
 }
@@ -84,3 +88,19 @@ func (h *NoopHandler) OnRetrieverEnd(ctx context.Context, input *schema.Retrieve
 func (h *NoopHandler) OnRetrieverError(ctx context.Context, input *schema.RetrieverErrorInput) error {
 	return nil
 }
+
+func (h *NoopHandler) OnEmbedderStart(ctx context.Context, input *schema.EmbedderStartInput) error {
+	return nil
+}
+
+func (h *NoopHandler) OnEmbedderEnd(ctx context.Context, input *schema.EmbedderEndInput) error {
+	return nil
+}
+
+func (h *NoopHandler) OnEmbedderError(ctx context.Context, input *schema.EmbedderErrorInput) error {
+	return nil
+}
+
+func (h *NoopHandler) OnModerationViolation(ctx context.Context, input *schema.ModerationViolationInput) error {
+	return nil
+}
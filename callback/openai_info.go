@@ -71,16 +71,20 @@ func (cb *OpenAIHandler) OnModelEnd(ctx context.Context, input *schema.ModelEndI
 
 	cb.successfulRequests++
 
-	tokenUsage, ok := input.Result.LLMOutput["TokenUsage"].(map[string]int)
+	tokenUsage, ok := input.Result.TokenUsage()
 	if !ok {
 		return nil
 	}
 
-	totalTokens := tokenUsage["TotalTokens"]
-	promptTokens := tokenUsage["PromptTokens"]
-	completionTokens := tokenUsage["CompletionTokens"]
+	totalTokens := tokenUsage.TotalTokens
+	promptTokens := tokenUsage.PromptTokens
+	completionTokens := tokenUsage.CompletionTokens
 
-	if modelName, ok := input.Result.LLMOutput["modelName"].(string); ok {
+	cb.totalTokens += totalTokens
+	cb.promptTokens += promptTokens
+	cb.completionTokens += completionTokens
+
+	if modelName, ok := input.Result.LLMOutput["ModelName"].(string); ok {
 		completionCosts, err := calculateOpenAITokenCostForModel(modelName, completionTokens, true)
 		if err != nil {
 			return err
@@ -94,10 +98,6 @@ func (cb *OpenAIHandler) OnModelEnd(ctx context.Context, input *schema.ModelEndI
 		cb.totalCost += completionCosts + promptCosts
 	}
 
-	cb.totalTokens += totalTokens
-	cb.promptTokens += promptTokens
-	cb.completionTokens += completionTokens
-
 	return nil
 }
 
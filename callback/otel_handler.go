@@ -0,0 +1,379 @@
+package callback
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/hupe1980/golc/schema"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Compile time check to ensure OTelHandler satisfies the Callback interface.
+var _ schema.Callback = (*OTelHandler)(nil)
+
+// OTelHandlerOptions contains the options for the OTelHandler.
+type OTelHandlerOptions struct {
+	// RecordContent indicates whether prompts, messages, inputs and outputs are recorded as span attributes.
+	RecordContent bool
+	// MaxContentLength is the maximum length of a recorded content attribute. Longer values are truncated.
+	// A value <= 0 disables the truncation.
+	MaxContentLength int
+}
+
+// OTelHandler is a callback handler that traces the chain, model, tool, retriever and embedder runs with OpenTelemetry.
+// The span hierarchy follows the run hierarchy, i.e. the span of a run is the child of the span of its parent run.
+type OTelHandler struct {
+	NoopHandler
+	tracer trace.Tracer
+	spans  map[string]trace.Span
+	opts   OTelHandlerOptions
+	mu     sync.Mutex
+}
+
+// NewOTelHandler creates a new OTelHandler that creates the spans with the given tracer.
+func NewOTelHandler(tracer trace.Tracer, optFns ...func(o *OTelHandlerOptions)) *OTelHandler {
+	opts := OTelHandlerOptions{
+		RecordContent:    true,
+		MaxContentLength: 4096,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &OTelHandler{
+		tracer: tracer,
+		spans:  make(map[string]trace.Span),
+		opts:   opts,
+	}
+}
+
+func (cb *OTelHandler) AlwaysVerbose() bool {
+	return true
+}
+
+func (cb *OTelHandler) OnLLMStart(ctx context.Context, input *schema.LLMStartInput) error {
+	attributes := []attribute.KeyValue{
+		attribute.String("golc.model.type", input.LLMType),
+	}
+
+	attributes = append(attributes, cb.modelAttributes(input.InvocationParams)...)
+
+	if cb.opts.RecordContent {
+		attributes = append(attributes, attribute.String("golc.prompt", truncate(input.Prompt, cb.opts.MaxContentLength)))
+	}
+
+	cb.startSpan(ctx, input.LLMType, input.RunID, input.ParentRunID, attributes...)
+
+	return nil
+}
+
+func (cb *OTelHandler) OnChatModelStart(ctx context.Context, input *schema.ChatModelStartInput) error {
+	attributes := []attribute.KeyValue{
+		attribute.String("golc.model.type", input.ChatModelType),
+	}
+
+	attributes = append(attributes, cb.modelAttributes(input.InvocationParams)...)
+
+	if cb.opts.RecordContent {
+		if messages, err := input.Messages.Format(); err == nil {
+			attributes = append(attributes, attribute.String("golc.messages", truncate(messages, cb.opts.MaxContentLength)))
+		}
+	}
+
+	cb.startSpan(ctx, input.ChatModelType, input.RunID, input.ParentRunID, attributes...)
+
+	return nil
+}
+
+func (cb *OTelHandler) OnModelEnd(ctx context.Context, input *schema.ModelEndInput) error {
+	span := cb.endSpan(input.RunID)
+	if span == nil {
+		return nil
+	}
+
+	defer span.End()
+
+	if tokenUsage, ok := input.Result.TokenUsage(); ok {
+		span.SetAttributes(
+			attribute.Int("gen_ai.usage.input_tokens", tokenUsage.PromptTokens),
+			attribute.Int("gen_ai.usage.output_tokens", tokenUsage.CompletionTokens),
+			attribute.Int("golc.usage.total_tokens", tokenUsage.TotalTokens),
+		)
+	}
+
+	if modelName, ok := input.Result.LLMOutput["ModelName"].(string); ok && modelName != "" {
+		span.SetAttributes(attribute.String("gen_ai.response.model", modelName))
+	}
+
+	if cb.opts.RecordContent && len(input.Result.Generations) > 0 {
+		span.SetAttributes(attribute.String("golc.completion", truncate(input.Result.Generations[0].Text, cb.opts.MaxContentLength)))
+	}
+
+	span.SetStatus(codes.Ok, "")
+
+	return nil
+}
+
+func (cb *OTelHandler) OnModelError(ctx context.Context, input *schema.ModelErrorInput) error {
+	cb.endSpanWithError(input.RunID, input.Error)
+	return nil
+}
+
+func (cb *OTelHandler) OnChainStart(ctx context.Context, input *schema.ChainStartInput) error {
+	attributes := []attribute.KeyValue{
+		attribute.String("golc.chain.type", input.ChainType),
+	}
+
+	if cb.opts.RecordContent {
+		attributes = append(attributes, attribute.String("golc.chain.inputs", cb.marshal(input.Inputs)))
+	}
+
+	cb.startSpan(ctx, fmt.Sprintf("chain.%s", input.ChainType), input.RunID, input.ParentRunID, attributes...)
+
+	return nil
+}
+
+func (cb *OTelHandler) OnChainEnd(ctx context.Context, input *schema.ChainEndInput) error {
+	span := cb.endSpan(input.RunID)
+	if span == nil {
+		return nil
+	}
+
+	defer span.End()
+
+	if cb.opts.RecordContent {
+		span.SetAttributes(attribute.String("golc.chain.outputs", cb.marshal(input.Outputs)))
+	}
+
+	span.SetStatus(codes.Ok, "")
+
+	return nil
+}
+
+func (cb *OTelHandler) OnChainError(ctx context.Context, input *schema.ChainErrorInput) error {
+	cb.endSpanWithError(input.RunID, input.Error)
+	return nil
+}
+
+func (cb *OTelHandler) OnAgentAction(ctx context.Context, input *schema.AgentActionInput) error {
+	cb.addEvent(input.RunID, "agent.action", attribute.String("golc.tool.name", input.Action.Tool))
+	return nil
+}
+
+func (cb *OTelHandler) OnAgentStep(ctx context.Context, input *schema.AgentStepInput) error {
+	cb.addEvent(input.RunID, "agent.step", attribute.String("golc.tool.name", input.Step.Action.Tool))
+	return nil
+}
+
+func (cb *OTelHandler) OnAgentFinish(ctx context.Context, input *schema.AgentFinishInput) error {
+	cb.addEvent(input.RunID, "agent.finish")
+	return nil
+}
+
+func (cb *OTelHandler) OnToolStart(ctx context.Context, input *schema.ToolStartInput) error {
+	attributes := []attribute.KeyValue{
+		attribute.String("golc.tool.name", input.ToolName),
+	}
+
+	if cb.opts.RecordContent && input.Input != nil {
+		attributes = append(attributes, attribute.String("golc.tool.input", truncate(input.Input.String(), cb.opts.MaxContentLength)))
+	}
+
+	cb.startSpan(ctx, fmt.Sprintf("tool.%s", input.ToolName), input.RunID, input.ParentRunID, attributes...)
+
+	return nil
+}
+
+func (cb *OTelHandler) OnToolEnd(ctx context.Context, input *schema.ToolEndInput) error {
+	span := cb.endSpan(input.RunID)
+	if span == nil {
+		return nil
+	}
+
+	defer span.End()
+
+	if cb.opts.RecordContent {
+		span.SetAttributes(attribute.String("golc.tool.output", truncate(input.Output, cb.opts.MaxContentLength)))
+	}
+
+	span.SetStatus(codes.Ok, "")
+
+	return nil
+}
+
+func (cb *OTelHandler) OnToolError(ctx context.Context, input *schema.ToolErrorInput) error {
+	cb.endSpanWithError(input.RunID, input.Error)
+	return nil
+}
+
+func (cb *OTelHandler) OnText(ctx context.Context, input *schema.TextInput) error {
+	cb.addEvent(input.RunID, "text")
+	return nil
+}
+
+func (cb *OTelHandler) OnRetrieverStart(ctx context.Context, input *schema.RetrieverStartInput) error {
+	var attributes []attribute.KeyValue
+
+	if cb.opts.RecordContent {
+		attributes = append(attributes, attribute.String("golc.retriever.query", truncate(input.Query, cb.opts.MaxContentLength)))
+	}
+
+	cb.startSpan(ctx, "retriever", input.RunID, input.ParentRunID, attributes...)
+
+	return nil
+}
+
+func (cb *OTelHandler) OnRetrieverEnd(ctx context.Context, input *schema.RetrieverEndInput) error {
+	span := cb.endSpan(input.RunID)
+	if span == nil {
+		return nil
+	}
+
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("golc.retriever.documents", len(input.Docs)))
+	span.SetStatus(codes.Ok, "")
+
+	return nil
+}
+
+func (cb *OTelHandler) OnRetrieverError(ctx context.Context, input *schema.RetrieverErrorInput) error {
+	cb.endSpanWithError(input.RunID, input.Error)
+	return nil
+}
+
+func (cb *OTelHandler) OnEmbedderStart(ctx context.Context, input *schema.EmbedderStartInput) error {
+	cb.startSpan(ctx, fmt.Sprintf("embedder.%s", input.EmbedderType), input.RunID, input.ParentRunID,
+		attribute.String("golc.embedder.type", input.EmbedderType),
+		attribute.Int("golc.embedder.texts", len(input.Texts)),
+	)
+
+	return nil
+}
+
+func (cb *OTelHandler) OnEmbedderEnd(ctx context.Context, input *schema.EmbedderEndInput) error {
+	span := cb.endSpan(input.RunID)
+	if span == nil {
+		return nil
+	}
+
+	defer span.End()
+
+	if len(input.Embeddings) > 0 {
+		span.SetAttributes(attribute.Int("golc.embedder.dimensions", len(input.Embeddings[0])))
+	}
+
+	span.SetStatus(codes.Ok, "")
+
+	return nil
+}
+
+func (cb *OTelHandler) OnEmbedderError(ctx context.Context, input *schema.EmbedderErrorInput) error {
+	cb.endSpanWithError(input.RunID, input.Error)
+	return nil
+}
+
+func (cb *OTelHandler) OnModerationViolation(ctx context.Context, input *schema.ModerationViolationInput) error {
+	cb.addEvent(input.RunID, "moderation.violation",
+		attribute.String("golc.moderation.moderator", input.ModeratorType),
+		attribute.String("golc.moderation.stage", string(input.Stage)),
+		attribute.StringSlice("golc.moderation.categories", input.Result.FlaggedCategories()),
+	)
+
+	return nil
+}
+
+// startSpan starts the span of a run. If the span of the parent run is known, the new span becomes its child.
+// Otherwise, the span is a child of the span in the context, if any.
+func (cb *OTelHandler) startSpan(ctx context.Context, name, runID, parentRunID string, attributes ...attribute.KeyValue) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if parent, ok := cb.spans[parentRunID]; ok && parentRunID != "" {
+		ctx = trace.ContextWithSpan(ctx, parent)
+	}
+
+	attributes = append(attributes, attribute.String("golc.run_id", runID))
+
+	if parentRunID != "" {
+		attributes = append(attributes, attribute.String("golc.parent_run_id", parentRunID))
+	}
+
+	_, span := cb.tracer.Start(ctx, name, trace.WithAttributes(attributes...))
+
+	cb.spans[runID] = span
+}
+
+// endSpan removes the span of the run. The caller is responsible for ending the returned span.
+func (cb *OTelHandler) endSpan(runID string) trace.Span {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	span, ok := cb.spans[runID]
+	if !ok {
+		return nil
+	}
+
+	delete(cb.spans, runID)
+
+	return span
+}
+
+func (cb *OTelHandler) endSpanWithError(runID string, err error) {
+	span := cb.endSpan(runID)
+	if span == nil {
+		return
+	}
+
+	defer span.End()
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+func (cb *OTelHandler) addEvent(runID, name string, attributes ...attribute.KeyValue) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if span, ok := cb.spans[runID]; ok {
+		span.AddEvent(name, trace.WithAttributes(attributes...))
+	}
+}
+
+func (cb *OTelHandler) modelAttributes(invocationParams map[string]any) []attribute.KeyValue {
+	if modelName := modelNameFromInvocationParams(invocationParams); modelName != "" {
+		return []attribute.KeyValue{attribute.String("gen_ai.request.model", modelName)}
+	}
+
+	return nil
+}
+
+func (cb *OTelHandler) marshal(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return truncate(fmt.Sprint(v), cb.opts.MaxContentLength)
+	}
+
+	return truncate(string(b), cb.opts.MaxContentLength)
+}
+
+// truncate truncates s to at most maxLength bytes without splitting a multi-byte character.
+// A maxLength <= 0 disables the truncation.
+func truncate(s string, maxLength int) string {
+	if maxLength > 0 && len(s) > maxLength {
+		end := maxLength
+		for end > 0 && !utf8.RuneStart(s[end]) {
+			end--
+		}
+
+		return s[:end]
+	}
+
+	return s
+}
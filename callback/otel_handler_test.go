@@ -0,0 +1,72 @@
+package callback
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestOTelHandler(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	cb := NewOTelHandler(provider.Tracer("golc"))
+	ctx := context.Background()
+
+	cm := NewManager([]schema.Callback{cb}, nil, false)
+
+	chainRun, err := cm.OnChainStart(ctx, &schema.ChainStartManagerInput{
+		ChainType: "LLM",
+		Inputs:    schema.ChainValues{"input": "hello"},
+	})
+	require.NoError(t, err)
+
+	modelManager := NewManager(chainRun.GetInheritableCallbacks(), nil, false, func(mo *ManagerOptions) {
+		mo.ParentRunID = chainRun.RunID()
+	})
+
+	modelRun, err := modelManager.OnChatModelStart(ctx, &schema.ChatModelStartManagerInput{
+		ChatModelType:    "chatmodel.OpenAI",
+		Messages:         schema.ChatMessages{schema.NewHumanChatMessage("hello")},
+		InvocationParams: map[string]any{"model_name": "gpt-4o"},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, modelRun.OnModelEnd(ctx, &schema.ModelEndManagerInput{
+		Result: &schema.ModelResult{
+			Generations: []schema.Generation{{Text: "world"}},
+			LLMOutput:   map[string]any{"TokenUsage": schema.NewTokenUsage(3, 4)},
+		},
+	}))
+
+	require.NoError(t, chainRun.OnChainError(ctx, &schema.ChainErrorManagerInput{
+		Error: errors.New("failed"),
+	}))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	modelSpan, chainSpan := spans[0], spans[1]
+
+	assert.Equal(t, "chatmodel.OpenAI", modelSpan.Name())
+	assert.Equal(t, chainSpan.SpanContext().SpanID(), modelSpan.Parent().SpanID())
+	assert.Contains(t, modelSpan.Attributes(), attribute.String("gen_ai.request.model", "gpt-4o"))
+	assert.Contains(t, modelSpan.Attributes(), attribute.Int("gen_ai.usage.input_tokens", 3))
+	assert.Contains(t, modelSpan.Attributes(), attribute.Int("gen_ai.usage.output_tokens", 4))
+	assert.Contains(t, modelSpan.Attributes(), attribute.String("golc.completion", "world"))
+	assert.Equal(t, codes.Ok, modelSpan.Status().Code)
+
+	assert.Equal(t, "chain.LLM", chainSpan.Name())
+	assert.Contains(t, chainSpan.Attributes(), attribute.String("golc.chain.inputs", `{"input":"hello"}`))
+	assert.Equal(t, codes.Error, chainSpan.Status().Code)
+	assert.Equal(t, "failed", chainSpan.Status().Description)
+	assert.Len(t, chainSpan.Events(), 1)
+}
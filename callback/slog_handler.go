@@ -0,0 +1,317 @@
+package callback
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure SlogHandler satisfies the Callback interface.
+var _ schema.Callback = (*SlogHandler)(nil)
+
+// SlogHandlerOptions contains the options for the SlogHandler.
+type SlogHandlerOptions struct {
+	// Logger is the logger used to emit the events. If nil, the current slog.Default() logger is used.
+	Logger *slog.Logger
+	// Level is the level of the start and end events of a run.
+	Level slog.Level
+	// TokenLevel is the level of the new token events of a streaming model.
+	TokenLevel slog.Level
+	// ErrorLevel is the level of the error events of a run.
+	ErrorLevel slog.Level
+	// MaxContentLength is the maximum length of logged prompts, inputs and outputs. Longer values are truncated.
+	// A value <= 0 disables the truncation.
+	MaxContentLength int
+}
+
+// SlogHandler is a callback handler that emits structured log events for chain, model, tool, retriever and embedder runs
+// using log/slog. Every event carries the run id and the parent run id. End and error events carry the duration
+// of the run.
+type SlogHandler struct {
+	NoopHandler
+	starts map[string]time.Time
+	opts   SlogHandlerOptions
+	mu     sync.Mutex
+}
+
+// NewSlogHandler creates a new SlogHandler.
+func NewSlogHandler(optFns ...func(o *SlogHandlerOptions)) *SlogHandler {
+	opts := SlogHandlerOptions{
+		Level:            slog.LevelInfo,
+		TokenLevel:       slog.LevelDebug,
+		ErrorLevel:       slog.LevelError,
+		MaxContentLength: 256,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &SlogHandler{
+		starts: make(map[string]time.Time),
+		opts:   opts,
+	}
+}
+
+// AlwaysVerbose returns true, because the events are filtered by the level of the logger.
+func (cb *SlogHandler) AlwaysVerbose() bool {
+	return true
+}
+
+func (cb *SlogHandler) OnLLMStart(ctx context.Context, input *schema.LLMStartInput) error {
+	cb.start(ctx, "llm start", input.RunID, input.ParentRunID,
+		slog.String("model_type", input.LLMType),
+		slog.String("prompt", truncate(input.Prompt, cb.opts.MaxContentLength)),
+	)
+
+	return nil
+}
+
+func (cb *SlogHandler) OnChatModelStart(ctx context.Context, input *schema.ChatModelStartInput) error {
+	attrs := []slog.Attr{
+		slog.String("model_type", input.ChatModelType),
+	}
+
+	if messages, err := input.Messages.Format(); err == nil {
+		attrs = append(attrs, slog.String("prompt", truncate(messages, cb.opts.MaxContentLength)))
+	}
+
+	cb.start(ctx, "chat model start", input.RunID, input.ParentRunID, attrs...)
+
+	return nil
+}
+
+func (cb *SlogHandler) OnModelNewToken(ctx context.Context, input *schema.ModelNewTokenInput) error {
+	cb.log(ctx, cb.opts.TokenLevel, "model new token", input.RunID, input.ParentRunID,
+		slog.String("token", input.Token),
+	)
+
+	return nil
+}
+
+func (cb *SlogHandler) OnModelEnd(ctx context.Context, input *schema.ModelEndInput) error {
+	var attrs []slog.Attr
+
+	if tokenUsage, ok := input.Result.TokenUsage(); ok {
+		attrs = append(attrs,
+			slog.Int("prompt_tokens", tokenUsage.PromptTokens),
+			slog.Int("completion_tokens", tokenUsage.CompletionTokens),
+			slog.Int("total_tokens", tokenUsage.TotalTokens),
+		)
+	}
+
+	if len(input.Result.Generations) > 0 {
+		attrs = append(attrs, slog.String("completion", truncate(input.Result.Generations[0].Text, cb.opts.MaxContentLength)))
+	}
+
+	cb.end(ctx, "model end", input.RunID, input.ParentRunID, attrs...)
+
+	return nil
+}
+
+func (cb *SlogHandler) OnModelError(ctx context.Context, input *schema.ModelErrorInput) error {
+	cb.error(ctx, "model error", input.RunID, input.ParentRunID, input.Error)
+	return nil
+}
+
+func (cb *SlogHandler) OnChainStart(ctx context.Context, input *schema.ChainStartInput) error {
+	cb.start(ctx, "chain start", input.RunID, input.ParentRunID,
+		slog.String("chain_type", input.ChainType),
+		slog.String("inputs", truncate(fmt.Sprint(input.Inputs), cb.opts.MaxContentLength)),
+	)
+
+	return nil
+}
+
+func (cb *SlogHandler) OnChainEnd(ctx context.Context, input *schema.ChainEndInput) error {
+	cb.end(ctx, "chain end", input.RunID, input.ParentRunID,
+		slog.String("outputs", truncate(fmt.Sprint(input.Outputs), cb.opts.MaxContentLength)),
+	)
+
+	return nil
+}
+
+func (cb *SlogHandler) OnChainError(ctx context.Context, input *schema.ChainErrorInput) error {
+	cb.error(ctx, "chain error", input.RunID, input.ParentRunID, input.Error)
+	return nil
+}
+
+func (cb *SlogHandler) OnAgentAction(ctx context.Context, input *schema.AgentActionInput) error {
+	attrs := []slog.Attr{
+		slog.String("tool", input.Action.Tool),
+	}
+
+	if input.Action.ToolInput != nil {
+		attrs = append(attrs, slog.String("tool_input", truncate(input.Action.ToolInput.String(), cb.opts.MaxContentLength)))
+	}
+
+	cb.log(ctx, cb.opts.Level, "agent action", input.RunID, input.ParentRunID, attrs...)
+
+	return nil
+}
+
+func (cb *SlogHandler) OnAgentStep(ctx context.Context, input *schema.AgentStepInput) error {
+	cb.log(ctx, cb.opts.Level, "agent step", input.RunID, input.ParentRunID,
+		slog.String("tool", input.Step.Action.Tool),
+		slog.String("observation", truncate(input.Step.Observation, cb.opts.MaxContentLength)),
+	)
+
+	return nil
+}
+
+func (cb *SlogHandler) OnAgentFinish(ctx context.Context, input *schema.AgentFinishInput) error {
+	cb.log(ctx, cb.opts.Level, "agent finish", input.RunID, input.ParentRunID,
+		slog.String("log", truncate(input.Finish.Log, cb.opts.MaxContentLength)),
+	)
+
+	return nil
+}
+
+func (cb *SlogHandler) OnToolStart(ctx context.Context, input *schema.ToolStartInput) error {
+	attrs := []slog.Attr{
+		slog.String("tool", input.ToolName),
+	}
+
+	if input.Input != nil {
+		attrs = append(attrs, slog.String("tool_input", truncate(input.Input.String(), cb.opts.MaxContentLength)))
+	}
+
+	cb.start(ctx, "tool start", input.RunID, input.ParentRunID, attrs...)
+
+	return nil
+}
+
+func (cb *SlogHandler) OnToolEnd(ctx context.Context, input *schema.ToolEndInput) error {
+	cb.end(ctx, "tool end", input.RunID, input.ParentRunID,
+		slog.String("output", truncate(input.Output, cb.opts.MaxContentLength)),
+	)
+
+	return nil
+}
+
+func (cb *SlogHandler) OnToolError(ctx context.Context, input *schema.ToolErrorInput) error {
+	cb.error(ctx, "tool error", input.RunID, input.ParentRunID, input.Error)
+	return nil
+}
+
+func (cb *SlogHandler) OnText(ctx context.Context, input *schema.TextInput) error {
+	cb.log(ctx, cb.opts.Level, "text", input.RunID, input.ParentRunID,
+		slog.String("text", truncate(input.Text, cb.opts.MaxContentLength)),
+	)
+
+	return nil
+}
+
+func (cb *SlogHandler) OnRetrieverStart(ctx context.Context, input *schema.RetrieverStartInput) error {
+	cb.start(ctx, "retriever start", input.RunID, input.ParentRunID,
+		slog.String("query", truncate(input.Query, cb.opts.MaxContentLength)),
+	)
+
+	return nil
+}
+
+func (cb *SlogHandler) OnRetrieverEnd(ctx context.Context, input *schema.RetrieverEndInput) error {
+	cb.end(ctx, "retriever end", input.RunID, input.ParentRunID,
+		slog.Int("documents", len(input.Docs)),
+	)
+
+	return nil
+}
+
+func (cb *SlogHandler) OnRetrieverError(ctx context.Context, input *schema.RetrieverErrorInput) error {
+	cb.error(ctx, "retriever error", input.RunID, input.ParentRunID, input.Error)
+	return nil
+}
+
+func (cb *SlogHandler) OnEmbedderStart(ctx context.Context, input *schema.EmbedderStartInput) error {
+	cb.start(ctx, "embedder start", input.RunID, input.ParentRunID,
+		slog.String("embedder_type", input.EmbedderType),
+		slog.Int("texts", len(input.Texts)),
+	)
+
+	return nil
+}
+
+func (cb *SlogHandler) OnEmbedderEnd(ctx context.Context, input *schema.EmbedderEndInput) error {
+	cb.end(ctx, "embedder end", input.RunID, input.ParentRunID,
+		slog.Int("embeddings", len(input.Embeddings)),
+	)
+
+	return nil
+}
+
+func (cb *SlogHandler) OnEmbedderError(ctx context.Context, input *schema.EmbedderErrorInput) error {
+	cb.error(ctx, "embedder error", input.RunID, input.ParentRunID, input.Error)
+	return nil
+}
+
+func (cb *SlogHandler) OnModerationViolation(ctx context.Context, input *schema.ModerationViolationInput) error {
+	cb.log(ctx, cb.opts.Level, "moderation violation", input.RunID, input.ParentRunID,
+		slog.String("moderator", input.ModeratorType),
+		slog.String("stage", string(input.Stage)),
+		slog.String("key", input.Key),
+		slog.Any("categories", input.Result.FlaggedCategories()),
+	)
+
+	return nil
+}
+
+func (cb *SlogHandler) start(ctx context.Context, msg, runID, parentRunID string, attrs ...slog.Attr) {
+	cb.mu.Lock()
+	cb.starts[runID] = time.Now()
+	cb.mu.Unlock()
+
+	cb.log(ctx, cb.opts.Level, msg, runID, parentRunID, attrs...)
+}
+
+func (cb *SlogHandler) end(ctx context.Context, msg, runID, parentRunID string, attrs ...slog.Attr) {
+	cb.log(ctx, cb.opts.Level, msg, runID, parentRunID, append(cb.duration(runID), attrs...)...)
+}
+
+func (cb *SlogHandler) error(ctx context.Context, msg, runID, parentRunID string, err error) {
+	attrs := cb.duration(runID)
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	cb.log(ctx, cb.opts.ErrorLevel, msg, runID, parentRunID, attrs...)
+}
+
+// duration removes the start time of the run and returns the duration attribute, if the start time is known.
+func (cb *SlogHandler) duration(runID string) []slog.Attr {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	start, ok := cb.starts[runID]
+	if !ok {
+		return nil
+	}
+
+	delete(cb.starts, runID)
+
+	return []slog.Attr{slog.Duration("duration", time.Since(start))}
+}
+
+func (cb *SlogHandler) log(ctx context.Context, level slog.Level, msg, runID, parentRunID string, attrs ...slog.Attr) {
+	logger := cb.opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+
+	runAttrs := []slog.Attr{slog.String("run_id", runID)}
+
+	if parentRunID != "" {
+		runAttrs = append(runAttrs, slog.String("parent_run_id", parentRunID))
+	}
+
+	logger.LogAttrs(ctx, level, msg, append(runAttrs, attrs...)...)
+}
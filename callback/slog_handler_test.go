@@ -0,0 +1,127 @@
+package callback
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlogHandler(t *testing.T) {
+	t.Run("RunEvents", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+
+		cb := NewSlogHandler(func(o *SlogHandlerOptions) {
+			o.Logger = slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+			o.MaxContentLength = 5
+		})
+
+		ctx := context.Background()
+
+		chainRun, err := NewManager([]schema.Callback{cb}, nil, false).OnChainStart(ctx, &schema.ChainStartManagerInput{
+			ChainType: "LLM",
+			Inputs:    schema.ChainValues{"input": "hello"},
+		})
+		require.NoError(t, err)
+
+		modelRun, err := NewManager(chainRun.GetInheritableCallbacks(), nil, false, func(mo *ManagerOptions) {
+			mo.ParentRunID = chainRun.RunID()
+		}).OnLLMStart(ctx, &schema.LLMStartManagerInput{
+			LLMType: "llm.Fake",
+			Prompt:  "hello world",
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, modelRun.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{Token: "hello"}))
+
+		require.NoError(t, modelRun.OnModelEnd(ctx, &schema.ModelEndManagerInput{
+			Result: &schema.ModelResult{
+				Generations: []schema.Generation{{Text: "world"}},
+				LLMOutput:   map[string]any{"TokenUsage": schema.NewTokenUsage(3, 4)},
+			},
+		}))
+
+		require.NoError(t, chainRun.OnChainError(ctx, &schema.ChainErrorManagerInput{
+			Error: errors.New("failed"),
+		}))
+
+		events := decodeSlogEvents(t, buf)
+		require.Len(t, events, 4, "the token event is below the level of the logger")
+
+		assert.Equal(t, "chain start", events[0]["msg"])
+		assert.Equal(t, "LLM", events[0]["chain_type"])
+		assert.Equal(t, chainRun.RunID(), events[0]["run_id"])
+		assert.NotContains(t, events[0], "parent_run_id")
+
+		assert.Equal(t, "llm start", events[1]["msg"])
+		assert.Equal(t, "hello", events[1]["prompt"])
+		assert.Equal(t, chainRun.RunID(), events[1]["parent_run_id"])
+
+		assert.Equal(t, "model end", events[2]["msg"])
+		assert.Equal(t, modelRun.RunID(), events[2]["run_id"])
+		assert.Equal(t, float64(7), events[2]["total_tokens"])
+		assert.Contains(t, events[2], "duration")
+
+		assert.Equal(t, "chain error", events[3]["msg"])
+		assert.Equal(t, "ERROR", events[3]["level"])
+		assert.Equal(t, "failed", events[3]["error"])
+		assert.Contains(t, events[3], "duration")
+	})
+
+	t.Run("Levels", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+
+		cb := NewSlogHandler(func(o *SlogHandlerOptions) {
+			o.Logger = slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			o.Level = slog.LevelDebug
+			o.ErrorLevel = slog.LevelWarn
+		})
+
+		ctx := context.Background()
+
+		require.NoError(t, cb.OnToolStart(ctx, &schema.ToolStartInput{
+			ToolStartManagerInput: &schema.ToolStartManagerInput{ToolName: "Search"},
+			RunID:                 "run",
+		}))
+
+		require.NoError(t, cb.OnToolError(ctx, &schema.ToolErrorInput{
+			ToolErrorManagerInput: &schema.ToolErrorManagerInput{Error: errors.New("failed")},
+			RunID:                 "run",
+		}))
+
+		events := decodeSlogEvents(t, buf)
+		require.Len(t, events, 2)
+
+		assert.Equal(t, "DEBUG", events[0]["level"])
+		assert.Equal(t, "Search", events[0]["tool"])
+		assert.Equal(t, "WARN", events[1]["level"])
+	})
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "hello", truncate("hello", 0))
+	assert.Equal(t, "hel", truncate("hello", 3))
+	assert.Equal(t, "h", truncate("hé", 2))
+}
+
+func decodeSlogEvents(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var events []map[string]any
+
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		event := map[string]any{}
+		require.NoError(t, json.Unmarshal([]byte(line), &event))
+
+		events = append(events, event)
+	}
+
+	return events
+}
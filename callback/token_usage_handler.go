@@ -0,0 +1,99 @@
+package callback
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure TokenUsageHandler satisfies the Callback interface.
+var _ schema.Callback = (*TokenUsageHandler)(nil)
+
+// TokenUsageHandler aggregates the token usage reported by the models across all runs it is attached to.
+type TokenUsageHandler struct {
+	NoopHandler
+	total              schema.TokenUsage
+	byModel            map[string]schema.TokenUsage
+	successfulRequests int
+	mu                 sync.Mutex
+}
+
+// NewTokenUsageHandler creates a new TokenUsageHandler.
+func NewTokenUsageHandler() *TokenUsageHandler {
+	return &TokenUsageHandler{
+		byModel: make(map[string]schema.TokenUsage),
+	}
+}
+
+func (cb *TokenUsageHandler) String() string {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	return fmt.Sprintf("Tokens Used: %d\nPrompt Tokens: %d\nCompletion Tokens: %d\nSuccessful Requests: %d",
+		cb.total.TotalTokens, cb.total.PromptTokens, cb.total.CompletionTokens, cb.successfulRequests)
+}
+
+func (cb *TokenUsageHandler) AlwaysVerbose() bool {
+	return true
+}
+
+func (cb *TokenUsageHandler) OnModelEnd(ctx context.Context, input *schema.ModelEndInput) error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.successfulRequests++
+
+	tokenUsage, ok := input.Result.TokenUsage()
+	if !ok {
+		return nil
+	}
+
+	cb.total = cb.total.Add(tokenUsage)
+
+	modelName, _ := input.Result.LLMOutput["ModelName"].(string)
+	cb.byModel[modelName] = cb.byModel[modelName].Add(tokenUsage)
+
+	return nil
+}
+
+// TokenUsage returns the aggregated token usage of all model runs.
+func (cb *TokenUsageHandler) TokenUsage() schema.TokenUsage {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	return cb.total
+}
+
+// TokenUsageByModel returns the aggregated token usage per model name. Usage of models
+// that do not report their name is aggregated under the empty string.
+func (cb *TokenUsageHandler) TokenUsageByModel() map[string]schema.TokenUsage {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	byModel := make(map[string]schema.TokenUsage, len(cb.byModel))
+	for k, v := range cb.byModel {
+		byModel[k] = v
+	}
+
+	return byModel
+}
+
+// SuccessfulRequests returns the number of successful model runs.
+func (cb *TokenUsageHandler) SuccessfulRequests() int {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	return cb.successfulRequests
+}
+
+// Reset clears the aggregated token usage.
+func (cb *TokenUsageHandler) Reset() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.total = schema.TokenUsage{}
+	cb.byModel = make(map[string]schema.TokenUsage)
+	cb.successfulRequests = 0
+}
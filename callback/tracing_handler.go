@@ -0,0 +1,421 @@
+package callback
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// RunType is the type of a traced run.
+type RunType string
+
+const (
+	RunTypeChain     RunType = "chain"
+	RunTypeLLM       RunType = "llm"
+	RunTypeChatModel RunType = "chat_model"
+	RunTypeTool      RunType = "tool"
+	RunTypeRetriever RunType = "retriever"
+	RunTypeEmbedding RunType = "embedding"
+)
+
+// Run represents a completed run of a run tree.
+type Run struct {
+	ID       string
+	ParentID string
+	// TraceID is the id of the root run of the run tree.
+	TraceID string
+	// DottedOrder is a sortable key of the run within its run tree. It consists of the start time and id
+	// of the run and all its ancestors.
+	DottedOrder string
+	Type        RunType
+	Name        string
+	Inputs      map[string]any
+	Outputs     map[string]any
+	Error       string
+	StartTime   time.Time
+	EndTime     time.Time
+	// Model is the name of the model of a model run.
+	Model string
+	// TokenUsage is the token usage of a model run, if reported by the model.
+	TokenUsage *schema.TokenUsage
+	Metadata   map[string]any
+}
+
+// Latency returns the duration of the run.
+func (r *Run) Latency() time.Duration {
+	return r.EndTime.Sub(r.StartTime)
+}
+
+// RunExporter exports completed runs to a tracing backend.
+type RunExporter interface {
+	Export(ctx context.Context, runs []*Run) error
+}
+
+// Compile time check to ensure TracingHandler satisfies the Callback interface.
+var _ schema.Callback = (*TracingHandler)(nil)
+
+// TracingHandlerOptions contains the options for the TracingHandler.
+type TracingHandlerOptions struct {
+	// BatchSize is the maximum number of runs exported at once.
+	BatchSize int
+	// FlushInterval is the interval in which the completed runs are exported in the background.
+	FlushInterval time.Duration
+	// OnError is called with the errors of background exports.
+	OnError func(err error)
+	// MaxQueueSize is the maximum number of completed runs waiting for their export. Runs of failed exports
+	// are queued again, and the oldest runs are dropped once the queue is full.
+	MaxQueueSize int
+}
+
+// TracingHandler is a callback handler that collects the run trees of chain, model, tool, retriever and embedder runs.
+// Completed runs are exported asynchronously in batches. Call Close before shutdown to export the remaining runs.
+type TracingHandler struct {
+	NoopHandler
+	exporter  RunExporter
+	opts      TracingHandlerOptions
+	active    map[string]*Run
+	queue     []*Run
+	mu        sync.Mutex
+	exportMu  sync.Mutex
+	flushCh   chan struct{}
+	done      chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// NewTracingHandler creates a new TracingHandler exporting the runs with the given exporter.
+func NewTracingHandler(exporter RunExporter, optFns ...func(o *TracingHandlerOptions)) *TracingHandler {
+	opts := TracingHandlerOptions{
+		BatchSize:     100,
+		FlushInterval: time.Second,
+		OnError:       func(err error) {},
+		MaxQueueSize:  10000,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	cb := &TracingHandler{
+		exporter: exporter,
+		opts:     opts,
+		active:   make(map[string]*Run),
+		flushCh:  make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+
+	cb.wg.Add(1)
+
+	go cb.loop()
+
+	return cb
+}
+
+func (cb *TracingHandler) AlwaysVerbose() bool {
+	return true
+}
+
+func (cb *TracingHandler) OnLLMStart(ctx context.Context, input *schema.LLMStartInput) error {
+	cb.startRun(input.RunID, input.ParentRunID, RunTypeLLM, input.LLMType, map[string]any{
+		"prompt": input.Prompt,
+	}, func(r *Run) {
+		r.Model = modelNameFromInvocationParams(input.InvocationParams)
+		r.Metadata["invocation_params"] = input.InvocationParams
+	})
+
+	return nil
+}
+
+func (cb *TracingHandler) OnChatModelStart(ctx context.Context, input *schema.ChatModelStartInput) error {
+	messages := make([]map[string]any, len(input.Messages))
+	for i, m := range input.Messages {
+		messages[i] = map[string]any{
+			"role":    string(m.Type()),
+			"content": m.Content(),
+		}
+	}
+
+	cb.startRun(input.RunID, input.ParentRunID, RunTypeChatModel, input.ChatModelType, map[string]any{
+		"messages": messages,
+	}, func(r *Run) {
+		r.Model = modelNameFromInvocationParams(input.InvocationParams)
+		r.Metadata["invocation_params"] = input.InvocationParams
+	})
+
+	return nil
+}
+
+func (cb *TracingHandler) OnModelEnd(ctx context.Context, input *schema.ModelEndInput) error {
+	generations := make([]string, len(input.Result.Generations))
+	for i, g := range input.Result.Generations {
+		generations[i] = g.Text
+	}
+
+	cb.endRun(input.RunID, map[string]any{
+		"generations": generations,
+	}, nil, func(r *Run) {
+		if tokenUsage, ok := input.Result.TokenUsage(); ok {
+			r.TokenUsage = &tokenUsage
+		}
+
+		if modelName, ok := input.Result.LLMOutput["ModelName"].(string); ok && modelName != "" {
+			r.Model = modelName
+		}
+	})
+
+	return nil
+}
+
+func (cb *TracingHandler) OnModelError(ctx context.Context, input *schema.ModelErrorInput) error {
+	cb.endRun(input.RunID, nil, input.Error, nil)
+	return nil
+}
+
+func (cb *TracingHandler) OnChainStart(ctx context.Context, input *schema.ChainStartInput) error {
+	cb.startRun(input.RunID, input.ParentRunID, RunTypeChain, input.ChainType, input.Inputs, nil)
+	return nil
+}
+
+func (cb *TracingHandler) OnChainEnd(ctx context.Context, input *schema.ChainEndInput) error {
+	cb.endRun(input.RunID, input.Outputs, nil, nil)
+	return nil
+}
+
+func (cb *TracingHandler) OnChainError(ctx context.Context, input *schema.ChainErrorInput) error {
+	cb.endRun(input.RunID, nil, input.Error, nil)
+	return nil
+}
+
+func (cb *TracingHandler) OnToolStart(ctx context.Context, input *schema.ToolStartInput) error {
+	inputs := map[string]any{}
+	if input.Input != nil {
+		inputs["input"] = input.Input.String()
+	}
+
+	cb.startRun(input.RunID, input.ParentRunID, RunTypeTool, input.ToolName, inputs, nil)
+
+	return nil
+}
+
+func (cb *TracingHandler) OnToolEnd(ctx context.Context, input *schema.ToolEndInput) error {
+	cb.endRun(input.RunID, map[string]any{
+		"output": input.Output,
+	}, nil, nil)
+
+	return nil
+}
+
+func (cb *TracingHandler) OnToolError(ctx context.Context, input *schema.ToolErrorInput) error {
+	cb.endRun(input.RunID, nil, input.Error, nil)
+	return nil
+}
+
+func (cb *TracingHandler) OnRetrieverStart(ctx context.Context, input *schema.RetrieverStartInput) error {
+	cb.startRun(input.RunID, input.ParentRunID, RunTypeRetriever, "Retriever", map[string]any{
+		"query": input.Query,
+	}, nil)
+
+	return nil
+}
+
+func (cb *TracingHandler) OnRetrieverEnd(ctx context.Context, input *schema.RetrieverEndInput) error {
+	documents := make([]map[string]any, len(input.Docs))
+	for i, d := range input.Docs {
+		documents[i] = map[string]any{
+			"page_content": d.PageContent,
+			"metadata":     d.Metadata,
+		}
+	}
+
+	cb.endRun(input.RunID, map[string]any{
+		"documents": documents,
+	}, nil, nil)
+
+	return nil
+}
+
+func (cb *TracingHandler) OnRetrieverError(ctx context.Context, input *schema.RetrieverErrorInput) error {
+	cb.endRun(input.RunID, nil, input.Error, nil)
+	return nil
+}
+
+func (cb *TracingHandler) OnEmbedderStart(ctx context.Context, input *schema.EmbedderStartInput) error {
+	cb.startRun(input.RunID, input.ParentRunID, RunTypeEmbedding, input.EmbedderType, map[string]any{
+		"texts": input.Texts,
+	}, nil)
+
+	return nil
+}
+
+func (cb *TracingHandler) OnEmbedderEnd(ctx context.Context, input *schema.EmbedderEndInput) error {
+	dimensions := 0
+	if len(input.Embeddings) > 0 {
+		dimensions = len(input.Embeddings[0])
+	}
+
+	cb.endRun(input.RunID, map[string]any{
+		"embeddings": len(input.Embeddings),
+		"dimensions": dimensions,
+	}, nil, nil)
+
+	return nil
+}
+
+func (cb *TracingHandler) OnEmbedderError(ctx context.Context, input *schema.EmbedderErrorInput) error {
+	cb.endRun(input.RunID, nil, input.Error, nil)
+	return nil
+}
+
+// Flush exports all completed runs that have not been exported yet. If an export fails, the runs not
+// exported yet are queued again for the next flush.
+func (cb *TracingHandler) Flush(ctx context.Context) error {
+	cb.exportMu.Lock()
+	defer cb.exportMu.Unlock()
+
+	cb.mu.Lock()
+	runs := cb.queue
+	cb.queue = nil
+	cb.mu.Unlock()
+
+	for len(runs) > 0 {
+		n := len(runs)
+		if cb.opts.BatchSize > 0 && n > cb.opts.BatchSize {
+			n = cb.opts.BatchSize
+		}
+
+		if err := cb.exporter.Export(ctx, runs[:n]); err != nil {
+			cb.requeue(runs)
+			return err
+		}
+
+		runs = runs[n:]
+	}
+
+	return nil
+}
+
+// Close stops the background export and exports the remaining completed runs.
+func (cb *TracingHandler) Close(ctx context.Context) error {
+	cb.closeOnce.Do(func() {
+		close(cb.done)
+	})
+
+	cb.wg.Wait()
+
+	return cb.Flush(ctx)
+}
+
+// requeue puts the runs of a failed export back in front of the queue.
+func (cb *TracingHandler) requeue(runs []*Run) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.queue = append(append(make([]*Run, 0, len(runs)+len(cb.queue)), runs...), cb.queue...)
+	cb.trimQueue()
+}
+
+// trimQueue drops the oldest runs exceeding the maximum queue size. The caller must hold the lock.
+func (cb *TracingHandler) trimQueue() {
+	if cb.opts.MaxQueueSize > 0 && len(cb.queue) > cb.opts.MaxQueueSize {
+		cb.queue = cb.queue[len(cb.queue)-cb.opts.MaxQueueSize:]
+	}
+}
+
+func (cb *TracingHandler) loop() {
+	defer cb.wg.Done()
+
+	ticker := time.NewTicker(cb.opts.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-cb.done:
+			return
+		case <-ticker.C:
+		case <-cb.flushCh:
+		}
+
+		if err := cb.Flush(context.Background()); err != nil {
+			cb.opts.OnError(err)
+		}
+	}
+}
+
+func (cb *TracingHandler) startRun(runID, parentRunID string, runType RunType, name string, inputs map[string]any, fn func(r *Run)) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	run := &Run{
+		ID:        runID,
+		TraceID:   runID,
+		Type:      runType,
+		Name:      name,
+		Inputs:    inputs,
+		StartTime: time.Now().UTC(),
+		Metadata:  map[string]any{},
+	}
+
+	dottedOrder := fmt.Sprintf("%s%s", run.StartTime.Format("20060102T150405.000000Z"), runID)
+
+	if parent, ok := cb.active[parentRunID]; ok && parentRunID != "" {
+		run.ParentID = parent.ID
+		run.TraceID = parent.TraceID
+		run.DottedOrder = strings.Join([]string{parent.DottedOrder, dottedOrder}, ".")
+	} else {
+		run.DottedOrder = dottedOrder
+	}
+
+	if fn != nil {
+		fn(run)
+	}
+
+	cb.active[runID] = run
+}
+
+func (cb *TracingHandler) endRun(runID string, outputs map[string]any, err error, fn func(r *Run)) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	run, ok := cb.active[runID]
+	if !ok {
+		return
+	}
+
+	delete(cb.active, runID)
+
+	run.EndTime = time.Now().UTC()
+	run.Outputs = outputs
+
+	if err != nil {
+		run.Error = err.Error()
+	}
+
+	if fn != nil {
+		fn(run)
+	}
+
+	cb.queue = append(cb.queue, run)
+	cb.trimQueue()
+
+	if len(cb.queue) >= cb.opts.BatchSize {
+		select {
+		case cb.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// modelNameFromInvocationParams returns the model name of the invocation parameters of a model.
+func modelNameFromInvocationParams(invocationParams map[string]any) string {
+	for _, key := range []string{"model_name", "model", "model_id"} {
+		if v, ok := invocationParams[key].(string); ok && v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,227 @@
+package callback
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hupe1980/golc/integration/langfuse"
+	"github.com/hupe1980/golc/integration/langsmith"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracingHandler(t *testing.T) {
+	t.Run("RunTree", func(t *testing.T) {
+		exporter := &mockRunExporter{}
+
+		cb := NewTracingHandler(exporter, func(o *TracingHandlerOptions) {
+			o.FlushInterval = time.Hour
+		})
+
+		traceRunTree(t, cb)
+
+		assert.Empty(t, exporter.Runs(), "runs are exported asynchronously")
+
+		require.NoError(t, cb.Close(context.Background()))
+
+		exported := exporter.Runs()
+		require.Len(t, exported, 2)
+
+		model, chain := exported[0], exported[1]
+
+		assert.Equal(t, RunTypeChain, chain.Type)
+		assert.Equal(t, "LLM", chain.Name)
+		assert.Equal(t, chain.ID, chain.TraceID)
+		assert.Empty(t, chain.ParentID)
+		assert.Equal(t, "failed", chain.Error)
+
+		assert.Equal(t, RunTypeChatModel, model.Type)
+		assert.Equal(t, chain.ID, model.ParentID)
+		assert.Equal(t, chain.ID, model.TraceID)
+		assert.Equal(t, "gpt-4o", model.Model)
+		assert.Equal(t, schema.NewTokenUsage(3, 4), *model.TokenUsage)
+		assert.True(t, strings.HasPrefix(model.DottedOrder, chain.DottedOrder+"."))
+		assert.GreaterOrEqual(t, model.Latency(), time.Duration(0))
+	})
+
+	t.Run("BatchSize", func(t *testing.T) {
+		exporter := &mockRunExporter{}
+
+		cb := NewTracingHandler(exporter, func(o *TracingHandlerOptions) {
+			o.BatchSize = 1
+			o.FlushInterval = time.Hour
+		})
+
+		traceRunTree(t, cb)
+
+		require.NoError(t, cb.Close(context.Background()))
+		assert.Equal(t, 2, exporter.Calls())
+	})
+
+	t.Run("ExportError", func(t *testing.T) {
+		exporter := &mockRunExporter{failures: 1}
+
+		cb := NewTracingHandler(exporter, func(o *TracingHandlerOptions) {
+			o.FlushInterval = time.Hour
+		})
+
+		traceRunTree(t, cb)
+
+		assert.EqualError(t, cb.Flush(context.Background()), "export failed")
+		assert.Empty(t, exporter.Runs())
+
+		require.NoError(t, cb.Close(context.Background()))
+		assert.Len(t, exporter.Runs(), 2, "runs of the failed export are exported again")
+	})
+
+	t.Run("MaxQueueSize", func(t *testing.T) {
+		exporter := &mockRunExporter{failures: 1}
+
+		cb := NewTracingHandler(exporter, func(o *TracingHandlerOptions) {
+			o.FlushInterval = time.Hour
+			o.MaxQueueSize = 1
+		})
+
+		traceRunTree(t, cb)
+
+		assert.Error(t, cb.Flush(context.Background()))
+
+		require.NoError(t, cb.Close(context.Background()))
+
+		exported := exporter.Runs()
+		require.Len(t, exported, 1)
+		assert.Equal(t, RunTypeChain, exported[0].Type, "the oldest runs are dropped")
+	})
+
+	t.Run("Langfuse", func(t *testing.T) {
+		client := &mockLangfuseClient{}
+		exporter := NewLangfuseExporter(client, func(o *LangfuseHandlerOptions) {
+			o.SessionID = "session"
+		})
+
+		cb := NewTracingHandler(exporter)
+		traceRunTree(t, cb)
+		require.NoError(t, cb.Close(context.Background()))
+
+		require.Len(t, client.req.Batch, 3)
+		assert.Equal(t, langfuse.EventTypeGenerationCreate, client.req.Batch[0].Type)
+		assert.Equal(t, langfuse.EventTypeTraceCreate, client.req.Batch[1].Type)
+		assert.Equal(t, langfuse.EventTypeSpanCreate, client.req.Batch[2].Type)
+
+		generation := client.req.Batch[0].Body.(langfuse.Generation)
+		assert.Equal(t, 7, generation.Usage.Total)
+		assert.Equal(t, "gpt-4o", generation.Model)
+
+		span := client.req.Batch[2].Body.(langfuse.Span)
+		assert.Equal(t, generation.ParentObservationID, span.ID)
+		assert.Equal(t, langfuse.LevelError, span.Level)
+		assert.Equal(t, "session", client.req.Batch[1].Body.(langfuse.Trace).SessionID)
+	})
+
+	t.Run("LangSmith", func(t *testing.T) {
+		client := &mockLangSmithClient{}
+		exporter := NewLangSmithExporter(client)
+
+		cb := NewTracingHandler(exporter)
+		traceRunTree(t, cb)
+		require.NoError(t, cb.Close(context.Background()))
+
+		require.Len(t, client.req.Post, 2)
+		assert.Equal(t, langsmith.RunTypeLLM, client.req.Post[0].RunType)
+		assert.Equal(t, client.req.Post[1].ID, client.req.Post[0].ParentRunID)
+		assert.Equal(t, "default", client.req.Post[0].SessionName)
+		assert.Contains(t, client.req.Post[0].Outputs, "llm_output")
+	})
+}
+
+func traceRunTree(t *testing.T, cb schema.Callback) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	chainRun, err := NewManager([]schema.Callback{cb}, nil, false).OnChainStart(ctx, &schema.ChainStartManagerInput{
+		ChainType: "LLM",
+		Inputs:    schema.ChainValues{"input": "hello"},
+	})
+	require.NoError(t, err)
+
+	modelRun, err := NewManager(chainRun.GetInheritableCallbacks(), nil, false, func(mo *ManagerOptions) {
+		mo.ParentRunID = chainRun.RunID()
+	}).OnChatModelStart(ctx, &schema.ChatModelStartManagerInput{
+		ChatModelType:    "chatmodel.OpenAI",
+		Messages:         schema.ChatMessages{schema.NewHumanChatMessage("hello")},
+		InvocationParams: map[string]any{"model_name": "gpt-4o"},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, modelRun.OnModelEnd(ctx, &schema.ModelEndManagerInput{
+		Result: &schema.ModelResult{
+			Generations: []schema.Generation{{Text: "world"}},
+			LLMOutput:   map[string]any{"TokenUsage": schema.NewTokenUsage(3, 4)},
+		},
+	}))
+
+	require.NoError(t, chainRun.OnChainError(ctx, &schema.ChainErrorManagerInput{
+		Error: errors.New("failed"),
+	}))
+}
+
+type mockRunExporter struct {
+	runs     []*Run
+	calls    int
+	failures int
+	mu       sync.Mutex
+}
+
+func (m *mockRunExporter) Export(ctx context.Context, runs []*Run) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls++
+
+	if m.failures > 0 {
+		m.failures--
+		return errors.New("export failed")
+	}
+
+	m.runs = append(m.runs, runs...)
+
+	return nil
+}
+
+func (m *mockRunExporter) Runs() []*Run {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.runs
+}
+
+func (m *mockRunExporter) Calls() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.calls
+}
+
+type mockLangfuseClient struct {
+	req *langfuse.IngestionRequest
+}
+
+func (m *mockLangfuseClient) Ingest(ctx context.Context, req *langfuse.IngestionRequest) (*langfuse.IngestionResponse, error) {
+	m.req = req
+	return &langfuse.IngestionResponse{}, nil
+}
+
+type mockLangSmithClient struct {
+	req *langsmith.BatchIngestRunsRequest
+}
+
+func (m *mockLangSmithClient) BatchIngestRuns(ctx context.Context, req *langsmith.BatchIngestRunsRequest) error {
+	m.req = req
+	return nil
+}
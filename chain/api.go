@@ -101,7 +101,10 @@ func NewAPI(model schema.Model, apiDoc string, optFns ...func(o *APIOptions)) (*
 		fn(&opts)
 	}
 
-	apiRequestChain, err := NewLLM(model, prompt.NewTemplate(defaultAPIURLTemplate))
+	// The prompt already specifies the format of the API url.
+	apiRequestChain, err := NewLLM(model, prompt.NewTemplate(defaultAPIURLTemplate), func(o *LLMOptions) {
+		o.DisableFormatInstructions = true
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -7,4 +7,5 @@ var (
 	ErrInvalidInputValues   = errors.New("invalid input values")
 	ErrInputValuesWrongType = errors.New("input key is of wrong type")
 	ErrNoOutputParser       = errors.New("no output parser")
+	ErrNoChains             = errors.New("no chains")
 )
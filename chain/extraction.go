@@ -0,0 +1,359 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/integration/jsonschema"
+	"github.com/hupe1980/golc/model"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Extraction satisfies the Chain interface.
+var _ schema.Chain = (*Extraction[any])(nil)
+
+const defaultExtractionTemplate = `Extract the relevant information mentioned in the following passage.
+{{if .schema}}
+The output must be a JSON object that conforms to the following JSON schema:
+{{.schema}}
+
+Respond only with the JSON object.
+{{end}}
+Passage:
+{{.input}}
+{{if .error}}
+Your previous output was invalid:
+{{.output}}
+
+Error: {{.error}}
+
+Fix the output.
+{{end}}`
+
+// extractionFunctionName is the name of the function used for native function calling.
+const extractionFunctionName = "extract"
+
+// ExtractionOptions contains options for the Extraction chain.
+type ExtractionOptions struct {
+	// CallbackOptions contains options for the chain callbacks.
+	*schema.CallbackOptions
+
+	// Prompt is the prompt template of the extraction. It is formatted with the input, the JSON schema of the
+	// output (empty for native function calling, unless a previous attempt did not call the function) and the invalid output and error of a previous attempt (empty
+	// for the first attempt) as schema, output and error values.
+	Prompt schema.PromptTemplate
+
+	// InputKey is the key to access the input value containing the passage.
+	InputKey string
+
+	// OutputKey is the key to access the output value containing the extracted value.
+	OutputKey string
+
+	// MaxRetries is the maximum number of retries if the model output is not a valid value.
+	MaxRetries int
+
+	// DisableFunctionCalling disables the native function calling of chat models supporting tool calling.
+	// The JSON schema of the output is then added to the prompt instead.
+	DisableFunctionCalling bool
+}
+
+// Extraction is a chain that extracts information from a passage into a value of type T. The JSON schema of
+// T is generated from its struct fields and tags. Chat models supporting tool calling are forced to call a
+// function with the schema as parameters. Other models are prompted to respond with JSON conforming to the
+// schema. Invalid outputs are passed back to the model with the error until MaxRetries is exhausted.
+type Extraction[T any] struct {
+	model           schema.Model
+	schema          *jsonschema.Schema
+	functionCalling bool
+	opts            ExtractionOptions
+}
+
+// NewExtraction creates a new Extraction chain for the struct type T. The schemaHints map JSON property
+// paths like "address.city" to descriptions that are added to the generated JSON schema.
+func NewExtraction[T any](model schema.Model, schemaHints map[string]string, optFns ...func(o *ExtractionOptions)) (*Extraction[T], error) {
+	opts := ExtractionOptions{
+		Prompt:     prompt.NewTemplate(defaultExtractionTemplate),
+		InputKey:   "input",
+		OutputKey:  "output",
+		MaxRetries: 2,
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unsupported extraction type: %s is not a struct", t)
+	}
+
+	jsonSchema, err := jsonschema.Generate(t)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applySchemaHints(jsonSchema, schemaHints); err != nil {
+		return nil, err
+	}
+
+	_, toolCalling := model.(schema.ToolCallingChatModel)
+
+	return &Extraction[T]{
+		model:           model,
+		schema:          jsonSchema,
+		functionCalling: toolCalling && !opts.DisableFunctionCalling,
+		opts:            opts,
+	}, nil
+}
+
+// Extract extracts the information of the passage into a value of type T.
+func (c *Extraction[T]) Extract(ctx context.Context, passage string, optFns ...func(o *golc.CallOptions)) (T, error) {
+	var empty T
+
+	outputs, err := golc.Call(ctx, c, schema.ChainValues{
+		c.opts.InputKey: passage,
+	}, optFns...)
+	if err != nil {
+		return empty, err
+	}
+
+	output, ok := outputs[c.opts.OutputKey].(T)
+	if !ok {
+		return empty, fmt.Errorf("unexpected output type: %T", outputs[c.opts.OutputKey])
+	}
+
+	return output, nil
+}
+
+// Call executes the Extraction chain with the given context and inputs.
+// It returns the outputs of the chain or an error, if any.
+func (c *Extraction[T]) Call(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+	opts := schema.CallOptions{
+		CallbackManger: &callback.NoopManager{},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	input, err := inputs.GetString(c.opts.InputKey)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(c.schema)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaValue := ""
+	if !c.functionCalling {
+		schemaValue = string(b)
+	}
+
+	var (
+		output   string
+		parseErr error
+	)
+
+	for attempt := 0; attempt <= c.opts.MaxRetries; attempt++ {
+		promptValues := schema.ChainValues{
+			"input":  input,
+			"schema": schemaValue,
+			"output": output,
+			"error":  "",
+		}
+
+		if parseErr != nil {
+			promptValues["error"] = parseErr.Error()
+
+			if cbErr := opts.CallbackManger.OnText(ctx, &schema.TextManagerInput{
+				Text: fmt.Sprintf("Retrying invalid output: %s", parseErr),
+			}); cbErr != nil {
+				return nil, cbErr
+			}
+		}
+
+		var called bool
+
+		output, called, err = c.generate(ctx, promptValues, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		// Models may ignore the forced function call, so the schema is added to the prompt of the retries.
+		if c.functionCalling && !called {
+			schemaValue = string(b)
+		}
+
+		var value T
+
+		value, parseErr = c.parse(output)
+		if parseErr == nil {
+			return schema.ChainValues{
+				c.opts.OutputKey: value,
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid extraction output after %d attempts: %w", c.opts.MaxRetries+1, parseErr)
+}
+
+// generate generates the output of the model for the prompt values. With native function calling, the
+// output are the arguments of the function call or, for providers only reporting tool calls, of the tool
+// call of the extraction function. The returned bool reports whether the output are call arguments.
+func (c *Extraction[T]) generate(ctx context.Context, promptValues schema.ChainValues, opts schema.CallOptions) (string, bool, error) {
+	promptValue, err := c.opts.Prompt.FormatPrompt(promptValues)
+	if err != nil {
+		return "", false, err
+	}
+
+	result, err := model.GeneratePrompt(ctx, c.model, promptValue, func(o *model.Options) {
+		o.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
+		o.ParentRunID = opts.CallbackManger.RunID()
+		o.Stop = opts.Stop
+
+		if c.functionCalling {
+			o.Functions = []schema.FunctionDefinition{{
+				Name:        extractionFunctionName,
+				Description: "Saves the information extracted from the passage.",
+				Parameters: schema.FunctionDefinitionParameters{
+					Type:       "object",
+					Properties: c.schema.Properties,
+					Required:   c.schema.Required,
+				},
+			}}
+			o.ForceFunctionCall = true
+		}
+	})
+	if err != nil {
+		return "", false, err
+	}
+
+	if len(result.Generations) == 0 {
+		return "", false, errors.New("unexpected output: no generations")
+	}
+
+	generation := result.Generations[0]
+
+	if aiMsg, ok := generation.Message.(*schema.AIChatMessage); ok && c.functionCalling {
+		ext := aiMsg.Extension()
+		if ext.FunctionCall != nil {
+			return ext.FunctionCall.Arguments, true, nil
+		}
+
+		for _, tc := range ext.ToolCalls {
+			if tc.Name == extractionFunctionName {
+				return tc.Arguments, true, nil
+			}
+		}
+
+		if len(ext.ToolCalls) > 0 {
+			return ext.ToolCalls[0].Arguments, true, nil
+		}
+	}
+
+	return generation.Text, false, nil
+}
+
+// parse parses the output of the model into a value of type T.
+func (c *Extraction[T]) parse(output string) (T, error) {
+	var value T
+
+	start, end := strings.Index(output, "{"), strings.LastIndex(output, "}")
+	if start < 0 || end < start {
+		return value, errors.New("output is not a JSON object")
+	}
+
+	object := map[string]any{}
+	if err := json.Unmarshal([]byte(output[start:end+1]), &object); err != nil {
+		return value, err
+	}
+
+	missing := []string{}
+
+	for _, name := range c.schema.Required {
+		if _, ok := object[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return value, fmt.Errorf("missing required properties: %s", strings.Join(missing, ", "))
+	}
+
+	if err := json.Unmarshal([]byte(output[start:end+1]), &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Memory returns the memory associated with the chain.
+func (c *Extraction[T]) Memory() schema.Memory {
+	return nil
+}
+
+// Type returns the type of the chain.
+func (c *Extraction[T]) Type() string {
+	return "Extraction"
+}
+
+// Verbose returns the verbosity setting of the chain.
+func (c *Extraction[T]) Verbose() bool {
+	return c.opts.CallbackOptions.Verbose
+}
+
+// Callbacks returns the callbacks associated with the chain.
+func (c *Extraction[T]) Callbacks() []schema.Callback {
+	return c.opts.CallbackOptions.Callbacks
+}
+
+// InputKeys returns the expected input keys.
+func (c *Extraction[T]) InputKeys() []string {
+	return []string{c.opts.InputKey}
+}
+
+// OutputKeys returns the output keys the chain will return.
+func (c *Extraction[T]) OutputKeys() []string {
+	return []string{c.opts.OutputKey}
+}
+
+// applySchemaHints sets the descriptions of the properties of the schema to the hints. The hints are keyed
+// by the dot-separated path of the properties. Array items are traversed implicitly.
+func applySchemaHints(s *jsonschema.Schema, hints map[string]string) error {
+	for path, description := range hints {
+		current := s
+
+		for _, name := range strings.Split(path, ".") {
+			for current.Type == jsonschema.TypeArray && current.Items != nil {
+				current = current.Items
+			}
+
+			property, ok := current.Properties[name]
+			if !ok {
+				return fmt.Errorf("unknown property of schema hint: %s", path)
+			}
+
+			current = property
+		}
+
+		current.Description = description
+	}
+
+	return nil
+}
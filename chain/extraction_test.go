@@ -0,0 +1,234 @@
+package chain
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hupe1980/golc/model/chatmodel"
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type extractionPerson struct {
+	Name    string `json:"name"`
+	Age     int    `json:"age"`
+	Hobbies []struct {
+		Title string `json:"title"`
+	} `json:"hobbies,omitempty"`
+}
+
+func TestExtraction(t *testing.T) {
+	t.Run("JSON Prompt", func(t *testing.T) {
+		var prompts []string
+
+		fake := llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+			prompts = append(prompts, prompt)
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: "```json\n{\"name\": \"Alex\", \"age\": 32}\n```"}},
+				LLMOutput:   map[string]any{},
+			}, nil
+		})
+
+		extraction, err := NewExtraction[extractionPerson](fake, map[string]string{
+			"name":          "The first name of the person",
+			"hobbies.title": "The title of the hobby",
+		})
+		assert.NoError(t, err)
+
+		person, err := extraction.Extract(context.Background(), "Alex is 32 years old.")
+		assert.NoError(t, err)
+		assert.Equal(t, extractionPerson{Name: "Alex", Age: 32}, person)
+
+		assert.Len(t, prompts, 1)
+		assert.Contains(t, prompts[0], `"description":"The first name of the person"`)
+		assert.Contains(t, prompts[0], `"description":"The title of the hobby"`)
+		assert.Contains(t, prompts[0], "Alex is 32 years old.")
+	})
+
+	t.Run("Retry Invalid Output", func(t *testing.T) {
+		var prompts []string
+
+		fake := llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+			prompts = append(prompts, prompt)
+
+			text := `{"name": "Alex", "age": 32}`
+			if len(prompts) == 1 {
+				text = `{"name": "Alex"}`
+			}
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: text}},
+				LLMOutput:   map[string]any{},
+			}, nil
+		})
+
+		extraction, err := NewExtraction[*extractionPerson](fake, nil)
+		assert.NoError(t, err)
+
+		person, err := extraction.Extract(context.Background(), "Alex is 32 years old.")
+		assert.NoError(t, err)
+		assert.Equal(t, &extractionPerson{Name: "Alex", Age: 32}, person)
+
+		assert.Len(t, prompts, 2)
+		assert.Contains(t, prompts[1], "Error: missing required properties: age")
+	})
+
+	t.Run("Retries Exhausted", func(t *testing.T) {
+		calls := 0
+
+		fake := llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+			calls++
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: "I don't know"}},
+				LLMOutput:   map[string]any{},
+			}, nil
+		})
+
+		extraction, err := NewExtraction[extractionPerson](fake, nil, func(o *ExtractionOptions) {
+			o.MaxRetries = 1
+		})
+		assert.NoError(t, err)
+
+		_, err = extraction.Extract(context.Background(), "Alex is 32 years old.")
+		assert.EqualError(t, err, "invalid extraction output after 2 attempts: output is not a JSON object")
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("Function Calling", func(t *testing.T) {
+		fake := &toolCallingFake{
+			Fake: chatmodel.NewSimpleFake("", func(o *chatmodel.FakeOptions) {
+				o.ChatModelType = "chatmodel.ToolCallingFake"
+			}),
+			Arguments: `{"name": "Alex", "age": 32}`,
+		}
+
+		extraction, err := NewExtraction[extractionPerson](fake, nil)
+		assert.NoError(t, err)
+
+		person, err := extraction.Extract(context.Background(), "Alex is 32 years old.")
+		assert.NoError(t, err)
+		assert.Equal(t, extractionPerson{Name: "Alex", Age: 32}, person)
+
+		assert.True(t, fake.Options.ForceFunctionCall)
+		assert.Len(t, fake.Options.Functions, 1)
+		assert.Equal(t, "extract", fake.Options.Functions[0].Name)
+		assert.ElementsMatch(t, []string{"name", "age"}, fake.Options.Functions[0].Parameters.Required)
+		assert.False(t, strings.Contains(fake.Prompt, "JSON schema"))
+	})
+
+	t.Run("Tool Calls", func(t *testing.T) {
+		fake := &toolCallingFake{
+			Fake: chatmodel.NewSimpleFake("", func(o *chatmodel.FakeOptions) {
+				o.ChatModelType = "chatmodel.ToolCallingFake"
+			}),
+			Arguments:     `{"name": "Alex", "age": 32}`,
+			ToolCallsOnly: true,
+		}
+
+		extraction, err := NewExtraction[extractionPerson](fake, nil)
+		assert.NoError(t, err)
+
+		person, err := extraction.Extract(context.Background(), "Alex is 32 years old.")
+		assert.NoError(t, err)
+		assert.Equal(t, extractionPerson{Name: "Alex", Age: 32}, person)
+	})
+
+	t.Run("Function Call Ignored", func(t *testing.T) {
+		var prompts []string
+
+		fake := chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+			prompt, _ := messages.Format()
+			prompts = append(prompts, prompt)
+
+			text := "I cannot call functions."
+			if len(prompts) > 1 {
+				text = `{"name": "Alex", "age": 32}`
+			}
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: text, Message: schema.NewAIChatMessage(text)}},
+				LLMOutput:   map[string]any{},
+			}, nil
+		})
+
+		extraction, err := NewExtraction[extractionPerson](&toolCallingChatModel{fake}, nil)
+		assert.NoError(t, err)
+
+		person, err := extraction.Extract(context.Background(), "Alex is 32 years old.")
+		assert.NoError(t, err)
+		assert.Equal(t, extractionPerson{Name: "Alex", Age: 32}, person)
+		assert.Len(t, prompts, 2)
+		assert.False(t, strings.Contains(prompts[0], "JSON schema"))
+		assert.True(t, strings.Contains(prompts[1], "JSON schema"))
+	})
+
+	t.Run("Unsupported Type", func(t *testing.T) {
+		_, err := NewExtraction[string](llm.NewSimpleFake("{}"), nil)
+		assert.EqualError(t, err, "unsupported extraction type: string is not a struct")
+	})
+
+	t.Run("Unknown Schema Hint", func(t *testing.T) {
+		_, err := NewExtraction[extractionPerson](llm.NewSimpleFake("{}"), map[string]string{"address.city": "The city"})
+		assert.EqualError(t, err, "unknown property of schema hint: address.city")
+	})
+}
+
+type toolCallingFake struct {
+	*chatmodel.Fake
+	Arguments     string
+	ToolCallsOnly bool
+	Options       schema.GenerateOptions
+	Prompt        string
+}
+
+func (cm *toolCallingFake) Generate(ctx context.Context, messages schema.ChatMessages, optFns ...func(o *schema.GenerateOptions)) (*schema.ModelResult, error) {
+	for _, fn := range optFns {
+		fn(&cm.Options)
+	}
+
+	prompt, err := messages.Format()
+	if err != nil {
+		return nil, err
+	}
+
+	cm.Prompt = prompt
+
+	if cm.ToolCallsOnly {
+		return &schema.ModelResult{
+			Generations: []schema.Generation{{
+				Message: schema.NewAIChatMessage("", func(o *schema.ChatMessageExtension) {
+					o.ToolCalls = []schema.ToolCall{{ID: "call_1", Name: "extract", Arguments: cm.Arguments}}
+				}),
+			}},
+			LLMOutput: map[string]any{},
+		}, nil
+	}
+
+	return &schema.ModelResult{
+		Generations: []schema.Generation{{
+			Message: schema.NewAIChatMessage("", func(o *schema.ChatMessageExtension) {
+				o.FunctionCall = &schema.FunctionCall{
+					Name:      "extract",
+					Arguments: cm.Arguments,
+				}
+			}),
+		}},
+		LLMOutput: map[string]any{},
+	}, nil
+}
+
+func (cm *toolCallingFake) BindTools(tools []schema.Tool) (schema.ToolCallingChatModel, error) {
+	return cm, nil
+}
+
+type toolCallingChatModel struct {
+	*chatmodel.Fake
+}
+
+func (cm *toolCallingChatModel) BindTools(tools []schema.Tool) (schema.ToolCallingChatModel, error) {
+	return cm, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/hupe1980/golc/callback"
 	"github.com/hupe1980/golc/model"
 	"github.com/hupe1980/golc/outputparser"
+	"github.com/hupe1980/golc/prompt"
 	"github.com/hupe1980/golc/schema"
 )
 
@@ -28,6 +29,10 @@ type LLMOptions struct {
 	// OutputParser is the schema.OutputParser[any] instance used to parse the LLM text generation result.
 	OutputParser schema.OutputParser[any]
 
+	// DisableFormatInstructions disables appending the format instructions of the output parser to the prompt.
+	// By default, the instructions are appended, if the output parser provides any.
+	DisableFormatInstructions bool
+
 	// ReturnFinalOnly determines whether to return only the final parsed result or include extra generation information.
 	// When set to true (default), the field will return only the final parsed result.
 	// If set to false, the field will include additional information about the generation along with the final parsed result.
@@ -82,6 +87,12 @@ func (c *LLM) Call(ctx context.Context, inputs schema.ChainValues, optFns ...fun
 		return nil, err
 	}
 
+	if !c.opts.DisableFormatInstructions {
+		if instructions := c.opts.OutputParser.GetFormatInstructions(); instructions != "" {
+			promptValue = prompt.WithFormatInstructions(promptValue, instructions)
+		}
+	}
+
 	if cbErr := opts.CallbackManger.OnText(ctx, &schema.TextManagerInput{
 		Text: fmt.Sprintf("\nPrompt after formatting:\n%s", promptValue.String()),
 	}); cbErr != nil {
@@ -97,7 +108,7 @@ func (c *LLM) Call(ctx context.Context, inputs schema.ChainValues, optFns ...fun
 		return nil, err
 	}
 
-	outputs, err := c.createOutputs(res)
+	outputs, err := c.createOutputs(ctx, res, promptValue)
 	if err != nil {
 		return nil, err
 	}
@@ -145,11 +156,27 @@ func (c *LLM) OutputKeys() []string {
 	return []string{c.opts.OutputKey}
 }
 
-func (c *LLM) createOutputs(modelResult *schema.ModelResult) ([]map[string]any, error) {
+// promptOutputParser is implemented by output parsers that use the prompt of the generation, e.g. to
+// retry the generation on invalid outputs.
+type promptOutputParser interface {
+	ParseResultWithPrompt(ctx context.Context, result schema.Generation, prompt schema.PromptValue) (any, error)
+}
+
+func (c *LLM) createOutputs(ctx context.Context, modelResult *schema.ModelResult, promptValue schema.PromptValue) ([]map[string]any, error) {
 	result := make([]map[string]any, len(modelResult.Generations))
 
 	for i, generation := range modelResult.Generations {
-		parsed, err := c.opts.OutputParser.ParseResult(generation)
+		var (
+			parsed any
+			err    error
+		)
+
+		if p, ok := c.opts.OutputParser.(promptOutputParser); ok {
+			parsed, err = p.ParseResultWithPrompt(ctx, generation, promptValue)
+		} else {
+			parsed, err = c.opts.OutputParser.ParseResult(generation)
+		}
+
 		if err != nil {
 			return nil, err
 		}
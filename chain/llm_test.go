@@ -6,7 +6,9 @@ import (
 
 	"github.com/hupe1980/golc"
 	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/outputparser"
 	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
 	"github.com/stretchr/testify/require"
 )
 
@@ -21,4 +23,78 @@ func TestLLM(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, output, "This is a valid question.")
 	})
+	t.Run("Struct Output Parser", func(t *testing.T) {
+		type Movie struct {
+			Title string `json:"title"`
+			Year  int    `json:"year"`
+		}
+
+		parser, err := outputparser.NewStruct[Movie]()
+		require.NoError(t, err)
+
+		fake := llm.NewSimpleFake(`{"title": "Heat", "year": 1995}`)
+
+		llmChain, err := NewLLM(fake, prompt.NewTemplate("{{.input}}"), func(o *LLMOptions) {
+			o.OutputParser = parser
+		})
+		require.NoError(t, err)
+
+		outputs, err := golc.Call(context.Background(), llmChain, schema.ChainValues{"input": "Recommend a movie."})
+		require.NoError(t, err)
+		require.Equal(t, Movie{Title: "Heat", Year: 1995}, outputs["text"])
+	})
+	t.Run("Retry Output Parser", func(t *testing.T) {
+		responses := []string{"Heat from 1995", `{"title": "Heat"}`}
+
+		fake := llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+			if len(responses) == 1 {
+				require.Contains(t, prompt, "Recommend a movie as JSON.")
+			}
+
+			text := responses[0]
+			responses = responses[1:]
+
+			return &schema.ModelResult{Generations: []schema.Generation{{Text: text}}}, nil
+		})
+
+		llmChain, err := NewLLM(fake, prompt.NewTemplate("{{.input}}"), func(o *LLMOptions) {
+			o.OutputParser = outputparser.NewRetryWithLLM(outputparser.NewJSON(), fake, 1)
+		})
+		require.NoError(t, err)
+
+		outputs, err := golc.Call(context.Background(), llmChain, schema.ChainValues{"input": "Recommend a movie as JSON."})
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"title": "Heat"}, outputs["text"])
+	})
+	t.Run("Format Instructions", func(t *testing.T) {
+		parser := outputparser.NewCommaSeparatedList()
+
+		var formatted string
+
+		fake := llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+			formatted = prompt
+
+			return &schema.ModelResult{Generations: []schema.Generation{{Text: "red, green"}}}, nil
+		})
+
+		llmChain, err := NewLLM(fake, prompt.NewTemplate("{{.input}}"), func(o *LLMOptions) {
+			o.OutputParser = &parser
+		})
+		require.NoError(t, err)
+
+		outputs, err := golc.Call(context.Background(), llmChain, schema.ChainValues{"input": "List two colors."})
+		require.NoError(t, err)
+		require.Equal(t, []string{"red", "green"}, outputs["text"])
+		require.Equal(t, "List two colors.\n\n"+parser.GetFormatInstructions(), formatted)
+
+		llmChain, err = NewLLM(fake, prompt.NewTemplate("{{.input}}"), func(o *LLMOptions) {
+			o.OutputParser = &parser
+			o.DisableFormatInstructions = true
+		})
+		require.NoError(t, err)
+
+		_, err = golc.Call(context.Background(), llmChain, schema.ChainValues{"input": "List two colors."})
+		require.NoError(t, err)
+		require.Equal(t, "List two colors.", formatted)
+	})
 }
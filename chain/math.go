@@ -80,7 +80,10 @@ func NewMath(model schema.Model, optFns ...func(o *MathOptions)) (*Math, error)
 		o.OutputParser = outputparser.NewFencedCodeBlock("```text")
 	})
 
-	llmChain, err := NewLLM(model, prompt)
+	// The prompt already specifies the fenced code block of the expression.
+	llmChain, err := NewLLM(model, prompt, func(o *LLMOptions) {
+		o.DisableFormatInstructions = true
+	})
 	if err != nil {
 		return nil, err
 	}
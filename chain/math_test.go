@@ -2,6 +2,7 @@ package chain
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/hupe1980/golc"
@@ -22,6 +23,26 @@ func TestMath(t *testing.T) {
 		assert.Equal(t, "9", output)
 	})
 
+	t.Run("No Format Instructions", func(t *testing.T) {
+		var prompt string
+
+		fake := llm.NewFake(func(ctx context.Context, p string) (*schema.ModelResult, error) {
+			prompt = p
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: "```text\n3 * 3\n```"}},
+				LLMOutput:   map[string]any{},
+			}, nil
+		})
+
+		mathChain, err := NewMath(fake)
+		assert.NoError(t, err)
+
+		_, err = golc.SimpleCall(context.Background(), mathChain, "What is 3 times 3?")
+		assert.NoError(t, err)
+		assert.True(t, strings.HasSuffix(prompt, "Question: What is 3 times 3?\n"))
+	})
+
 	t.Run("Invalid Input Key", func(t *testing.T) {
 		fake := llm.NewSimpleFake("```text\n3 * 3\n```")
 
@@ -0,0 +1,412 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/integration/openapi"
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+)
+
+const defaultOpenAPIRequestTemplate = `You are given the below API operations of an OpenAPI specification:
+{{.apiDoc}}
+Using these operations, select the operation to call for answering the user question and construct its parameters.
+Only use parameters of the selected operation. Respond only with a JSON object in the following format:
+{"operation": "<operation id>", "parameters": {"<parameter name>": <parameter value>}, "body": <request body or null>}
+
+Question:{{.question}}
+API request:`
+
+const defaultOpenAPIAnswerTemplate = defaultOpenAPIRequestTemplate + `{{.apiRequest}}
+
+Here is the response from the API:
+
+{{.apiResponse}}
+
+Summarize this response to answer the original question.
+
+Summary:`
+
+// Compile time check to ensure OpenAPI satisfies the Chain interface.
+var _ schema.Chain = (*OpenAPI)(nil)
+
+// OpenAPIOptions contains options for the OpenAPI chain.
+type OpenAPIOptions struct {
+	// CallbackOptions contains options for the chain callbacks.
+	*schema.CallbackOptions
+
+	// InputKey is the key to access the input value containing the user question.
+	InputKey string
+
+	// OutputKey is the key to access the output value containing the API response summary.
+	OutputKey string
+
+	// HTTPClient is the HTTP client used for making API requests. The default client only follows redirects to
+	// the allowed hosts and drops the headers of the Header option on redirects to another host. A custom
+	// client must enforce its own redirect policy.
+	HTTPClient HTTPClient
+
+	// Header is a map containing additional headers to be included in the API request.
+	Header map[string]string
+
+	// BaseURL is the base URL of the API requests. Defaults to the first server of the specification.
+	BaseURL string
+
+	// AllowedHosts is the allowlist of hosts the chain sends requests to. Defaults to the host of the base URL.
+	AllowedHosts []string
+
+	// MaxResponseLength is the maximum number of bytes of the API response passed to the model.
+	// A value <= 0 disables the limit.
+	MaxResponseLength int
+}
+
+// OpenAPI is a chain that answers user questions by calling an operation of an API described by an
+// OpenAPI 3 or Swagger 2 specification in JSON or YAML. The model selects the operation and constructs
+// its parameters. The response of the API is passed back to the model to answer the question.
+//
+// WARNING: The model controls the parameters of the API requests. Only requests to the allowed hosts are
+// executed, but the model can call every operation of the specification. Restrict the specification to
+// the operations that are safe to call and use credentials with the least privileges necessary.
+type OpenAPI struct {
+	apiRequestChain *LLM
+	apiAnswerChain  *LLM
+	apiDoc          string
+	operations      map[string]*openapi.Operation
+	baseURL         *url.URL
+	opts            OpenAPIOptions
+}
+
+// NewOpenAPI creates a new instance of the OpenAPI chain with the given model and OpenAPI specification.
+func NewOpenAPI(model schema.Model, spec []byte, optFns ...func(o *OpenAPIOptions)) (*OpenAPI, error) {
+	opts := OpenAPIOptions{
+		InputKey:          "question",
+		OutputKey:         "output",
+		MaxResponseLength: 10000,
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	s, err := openapi.Parse(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	operations := s.Operations()
+
+	if len(operations) == 0 {
+		return nil, errors.New("invalid OpenAPI specification: no operations")
+	}
+
+	if opts.BaseURL == "" {
+		opts.BaseURL = s.BaseURL()
+	}
+
+	baseURL, err := url.Parse(opts.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if baseURL.Scheme == "" || baseURL.Host == "" {
+		return nil, fmt.Errorf("invalid base URL: %q", opts.BaseURL)
+	}
+
+	if len(opts.AllowedHosts) == 0 {
+		opts.AllowedHosts = []string{baseURL.Host}
+	}
+
+	// The prompt already specifies the JSON format of the API request.
+	apiRequestChain, err := NewLLM(model, prompt.NewTemplate(defaultOpenAPIRequestTemplate), func(o *LLMOptions) {
+		o.DisableFormatInstructions = true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	apiAnswerChain, err := NewLLM(model, prompt.NewTemplate(defaultOpenAPIAnswerTemplate))
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]string, len(operations))
+	operationsByID := make(map[string]*openapi.Operation, len(operations))
+
+	for i, op := range operations {
+		docs[i] = openAPIOperationDoc(op)
+		operationsByID[op.ID] = op
+	}
+
+	c := &OpenAPI{
+		apiRequestChain: apiRequestChain,
+		apiAnswerChain:  apiAnswerChain,
+		apiDoc:          strings.Join(docs, "\n"),
+		operations:      operationsByID,
+		baseURL:         baseURL,
+		opts:            opts,
+	}
+
+	if c.opts.HTTPClient == nil {
+		c.opts.HTTPClient = &http.Client{
+			CheckRedirect: c.checkRedirect,
+		}
+	}
+
+	return c, nil
+}
+
+// Call executes the OpenAPI chain with the given context and inputs.
+// It returns the outputs of the chain or an error, if any.
+func (c *OpenAPI) Call(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+	opts := schema.CallOptions{
+		CallbackManger: &callback.NoopManager{},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	question, err := inputs.GetString(c.opts.InputKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if cbErr := opts.CallbackManger.OnText(ctx, &schema.TextManagerInput{
+		Text: question,
+	}); cbErr != nil {
+		return nil, cbErr
+	}
+
+	apiRequest, err := golc.SimpleCall(ctx, c.apiRequestChain, schema.ChainValues{
+		"question": question,
+		"apiDoc":   c.apiDoc,
+	}, func(sco *golc.SimpleCallOptions) {
+		sco.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
+		sco.ParentRunID = opts.CallbackManger.RunID()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	apiRequest = strings.TrimSpace(apiRequest)
+
+	if cbErr := opts.CallbackManger.OnText(ctx, &schema.TextManagerInput{
+		Text: apiRequest,
+	}); cbErr != nil {
+		return nil, cbErr
+	}
+
+	httpReq, err := c.newRequest(ctx, apiRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.opts.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	var body io.Reader = res.Body
+	if c.opts.MaxResponseLength > 0 {
+		body = io.LimitReader(res.Body, int64(c.opts.MaxResponseLength))
+	}
+
+	apiResponse, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	answer, err := golc.SimpleCall(ctx, c.apiAnswerChain, schema.ChainValues{
+		"question":    question,
+		"apiDoc":      c.apiDoc,
+		"apiRequest":  apiRequest,
+		"apiResponse": fmt.Sprintf("%s\n%s", res.Status, apiResponse),
+	}, func(sco *golc.SimpleCallOptions) {
+		sco.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
+		sco.ParentRunID = opts.CallbackManger.RunID()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	answer = strings.TrimSpace(answer)
+
+	if cbErr := opts.CallbackManger.OnText(ctx, &schema.TextManagerInput{
+		Text: fmt.Sprintf("\nAnswer:\n%s", answer),
+	}); cbErr != nil {
+		return nil, cbErr
+	}
+
+	return schema.ChainValues{
+		c.opts.OutputKey: answer,
+	}, nil
+}
+
+// Memory returns the memory associated with the chain.
+func (c *OpenAPI) Memory() schema.Memory {
+	return nil
+}
+
+// Type returns the type of the chain.
+func (c *OpenAPI) Type() string {
+	return "OpenAPI"
+}
+
+// Verbose returns the verbosity setting of the chain.
+func (c *OpenAPI) Verbose() bool {
+	return c.opts.CallbackOptions.Verbose
+}
+
+// Callbacks returns the callbacks associated with the chain.
+func (c *OpenAPI) Callbacks() []schema.Callback {
+	return c.opts.CallbackOptions.Callbacks
+}
+
+// InputKeys returns the expected input keys.
+func (c *OpenAPI) InputKeys() []string {
+	return []string{c.opts.InputKey}
+}
+
+// OutputKeys returns the output keys the chain will return.
+func (c *OpenAPI) OutputKeys() []string {
+	return []string{c.opts.OutputKey}
+}
+
+// openAPIRequest is the API request generated by the model.
+type openAPIRequest struct {
+	Operation  string         `json:"operation"`
+	Parameters map[string]any `json:"parameters"`
+	Body       any            `json:"body"`
+}
+
+// newRequest creates the HTTP request for the API request generated by the model.
+func (c *OpenAPI) newRequest(ctx context.Context, apiRequest string) (*http.Request, error) {
+	start, end := strings.Index(apiRequest, "{"), strings.LastIndex(apiRequest, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("invalid API request: %s", apiRequest)
+	}
+
+	req := openAPIRequest{}
+	if err := json.Unmarshal([]byte(apiRequest[start:end+1]), &req); err != nil {
+		return nil, fmt.Errorf("invalid API request: %w", err)
+	}
+
+	op, ok := c.operations[req.Operation]
+	if !ok {
+		return nil, fmt.Errorf("unknown API operation: %s", req.Operation)
+	}
+
+	httpReq, err := op.NewRequest(ctx, c.baseURL, req.Parameters, req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.isAllowedHost(httpReq.URL) {
+		return nil, fmt.Errorf("not allowed API host: %s", httpReq.URL.Host)
+	}
+
+	for k, v := range c.opts.Header {
+		httpReq.Header.Set(k, v)
+	}
+
+	return httpReq, nil
+}
+
+// checkRedirect only follows redirects to the allowed hosts. The configured headers, which usually contain
+// credentials, are removed on redirects to another host.
+func (c *OpenAPI) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+
+	if !c.isAllowedHost(req.URL) {
+		return fmt.Errorf("not allowed API host: %s", req.URL.Host)
+	}
+
+	if req.URL.Host != via[0].URL.Host {
+		for k := range c.opts.Header {
+			req.Header.Del(k)
+		}
+	}
+
+	return nil
+}
+
+// isAllowedHost checks if the host of the URL is in the allowlist of hosts.
+func (c *OpenAPI) isAllowedHost(u *url.URL) bool {
+	return util.Contains(c.opts.AllowedHosts, u.Host) || util.Contains(c.opts.AllowedHosts, u.Hostname())
+}
+
+// openAPIOperationDoc returns the description of the operation for the model.
+func openAPIOperationDoc(op *openapi.Operation) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("operation: %s\n", op.ID))
+	sb.WriteString(fmt.Sprintf("method: %s %s\n", op.Method, op.Path))
+
+	summary := op.Summary
+	if summary == "" {
+		summary = op.Description
+	}
+
+	if summary != "" {
+		sb.WriteString(fmt.Sprintf("summary: %s\n", summary))
+	}
+
+	if len(op.Parameters) > 0 {
+		sb.WriteString("parameters:\n")
+
+		for _, p := range op.Parameters {
+			attributes := []string{p.In}
+			if p.Required {
+				attributes = append(attributes, "required")
+			}
+
+			if p.Schema.Type != "" {
+				attributes = append(attributes, p.Schema.Type)
+			}
+
+			sb.WriteString(fmt.Sprintf("- %s (%s)", p.Name, strings.Join(attributes, ", ")))
+
+			if p.Description != "" {
+				sb.WriteString(fmt.Sprintf(": %s", p.Description))
+			}
+
+			sb.WriteString("\n")
+		}
+	}
+
+	if op.HasBody {
+		attributes := "optional"
+		if op.BodyRequired {
+			attributes = "required"
+		}
+
+		sb.WriteString(fmt.Sprintf("body (%s)", attributes))
+
+		if op.BodySchema != nil {
+			if b, err := json.Marshal(op.BodySchema); err == nil {
+				sb.WriteString(fmt.Sprintf(": %s", b))
+			}
+		}
+
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
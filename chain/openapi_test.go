@@ -0,0 +1,288 @@
+package chain
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+const petStoreSpec = `
+openapi: 3.0.0
+servers:
+  - url: https://petstore.example.com/v1
+paths:
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+        schema:
+          type: integer
+    get:
+      operationId: getPet
+      summary: Info for a specific pet
+      parameters:
+        - name: fields
+          in: query
+          schema:
+            type: string
+  /pets:
+    post:
+      operationId: createPet
+      summary: Create a pet
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+`
+
+func TestOpenAPI(t *testing.T) {
+	newFake := func(apiRequest string) *llm.Fake {
+		return llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+			text := "Bello is a dog."
+			if strings.HasSuffix(prompt, "API request:") {
+				text = apiRequest
+			}
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: text}},
+				LLMOutput:   map[string]any{},
+			}, nil
+		})
+	}
+
+	t.Run("Valid Request", func(t *testing.T) {
+		client := &recordingHTTPClient{Response: `{"name": "Bello", "tag": "dog"}`}
+
+		openAPI, err := NewOpenAPI(newFake("```json\n{\"operation\": \"getPet\", \"parameters\": {\"petId\": 7, \"fields\": \"name,tag\"}}\n```"), []byte(petStoreSpec), func(o *OpenAPIOptions) {
+			o.HTTPClient = client
+			o.Header = map[string]string{"Authorization": "Bearer token"}
+		})
+		assert.NoError(t, err)
+
+		answer, err := golc.SimpleCall(context.Background(), openAPI, "What is the pet with id 7?")
+		assert.NoError(t, err)
+		assert.Equal(t, "Bello is a dog.", answer)
+
+		assert.Equal(t, http.MethodGet, client.Request.Method)
+		assert.Equal(t, "https://petstore.example.com/v1/pets/7?fields=name%2Ctag", client.Request.URL.String())
+		assert.Equal(t, "Bearer token", client.Request.Header.Get("Authorization"))
+	})
+
+	t.Run("Request Body", func(t *testing.T) {
+		client := &recordingHTTPClient{Response: `{"id": 8}`}
+
+		openAPI, err := NewOpenAPI(newFake(`{"operation": "createPet", "parameters": {}, "body": {"name": "Bello"}}`), []byte(petStoreSpec), func(o *OpenAPIOptions) {
+			o.HTTPClient = client
+		})
+		assert.NoError(t, err)
+
+		_, err = golc.SimpleCall(context.Background(), openAPI, "Create a pet named Bello")
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.MethodPost, client.Request.Method)
+		assert.Equal(t, "application/json", client.Request.Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"name": "Bello"}`, client.Body)
+	})
+
+	t.Run("Path Parameter Traversal", func(t *testing.T) {
+		client := &recordingHTTPClient{Response: `{}`}
+
+		openAPI, err := NewOpenAPI(newFake(`{"operation": "getPet", "parameters": {"petId": "../admin"}}`), []byte(petStoreSpec), func(o *OpenAPIOptions) {
+			o.HTTPClient = client
+		})
+		assert.NoError(t, err)
+
+		_, err = golc.SimpleCall(context.Background(), openAPI, "What is the pet with id ../admin?")
+		assert.EqualError(t, err, "invalid path parameter of API operation getPet: petId")
+		assert.Nil(t, client.Request)
+	})
+
+	t.Run("Invalid Requests", func(t *testing.T) {
+		testCases := []struct {
+			name       string
+			apiRequest string
+			errMsg     string
+		}{
+			{"No JSON", "I don't know", "invalid API request: I don't know"},
+			{"Unknown Operation", `{"operation": "deletePet"}`, "unknown API operation: deletePet"},
+			{"Unknown Parameter", `{"operation": "getPet", "parameters": {"petId": 7, "owner": "me"}}`, "unknown parameter of API operation getPet: owner"},
+			{"Missing Parameter", `{"operation": "getPet", "parameters": {}}`, "missing required parameter of API operation getPet: petId"},
+			{"Missing Body", `{"operation": "createPet"}`, "missing required body of API operation createPet"},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				client := &recordingHTTPClient{}
+
+				openAPI, err := NewOpenAPI(newFake(tc.apiRequest), []byte(petStoreSpec), func(o *OpenAPIOptions) {
+					o.HTTPClient = client
+				})
+				assert.NoError(t, err)
+
+				_, err = golc.SimpleCall(context.Background(), openAPI, "What is the pet with id 7?")
+				assert.EqualError(t, err, tc.errMsg)
+				assert.Nil(t, client.Request)
+			})
+		}
+	})
+
+	t.Run("Not Allowed Host", func(t *testing.T) {
+		client := &recordingHTTPClient{}
+
+		openAPI, err := NewOpenAPI(newFake(`{"operation": "getPet", "parameters": {"petId": 7}}`), []byte(petStoreSpec), func(o *OpenAPIOptions) {
+			o.HTTPClient = client
+			o.AllowedHosts = []string{"api.example.com"}
+		})
+		assert.NoError(t, err)
+
+		_, err = golc.SimpleCall(context.Background(), openAPI, "What is the pet with id 7?")
+		assert.EqualError(t, err, "not allowed API host: petstore.example.com")
+		assert.Nil(t, client.Request)
+	})
+
+	t.Run("Redirect", func(t *testing.T) {
+		openAPI, err := NewOpenAPI(newFake(""), []byte(petStoreSpec), func(o *OpenAPIOptions) {
+			o.AllowedHosts = []string{"petstore.example.com", "cdn.example.com"}
+			o.Header = map[string]string{"X-API-Key": "secret"}
+		})
+		assert.NoError(t, err)
+
+		client, ok := openAPI.opts.HTTPClient.(*http.Client)
+		assert.True(t, ok)
+
+		via, _ := http.NewRequest(http.MethodGet, "https://petstore.example.com/v1/pets/7", nil)
+
+		newRedirect := func(target string) *http.Request {
+			req, _ := http.NewRequest(http.MethodGet, target, nil)
+			req.Header.Set("X-API-Key", "secret")
+
+			return req
+		}
+
+		req := newRedirect("https://petstore.example.com/v2/pets/7")
+		assert.NoError(t, client.CheckRedirect(req, []*http.Request{via}))
+		assert.Equal(t, "secret", req.Header.Get("X-API-Key"))
+
+		req = newRedirect("https://cdn.example.com/pets/7")
+		assert.NoError(t, client.CheckRedirect(req, []*http.Request{via}))
+		assert.Empty(t, req.Header.Get("X-API-Key"))
+
+		req = newRedirect("https://evil.example.com/pets/7")
+		assert.EqualError(t, client.CheckRedirect(req, []*http.Request{via}), "not allowed API host: evil.example.com")
+	})
+
+	t.Run("Swagger", func(t *testing.T) {
+		spec := `{
+			"swagger": "2.0",
+			"host": "petstore.example.com",
+			"basePath": "/v2",
+			"schemes": ["https"],
+			"paths": {
+				"/pets": {
+					"post": {
+						"parameters": [{"name": "pet", "in": "body", "required": true, "schema": {"type": "object"}}]
+					}
+				}
+			}
+		}`
+
+		client := &recordingHTTPClient{Response: `{}`}
+
+		openAPI, err := NewOpenAPI(newFake(`{"operation": "POST /pets", "body": {"name": "Bello"}}`), []byte(spec), func(o *OpenAPIOptions) {
+			o.HTTPClient = client
+		})
+		assert.NoError(t, err)
+
+		_, err = golc.SimpleCall(context.Background(), openAPI, "Create a pet named Bello")
+		assert.NoError(t, err)
+		assert.Equal(t, "https://petstore.example.com/v2/pets", client.Request.URL.String())
+		assert.JSONEq(t, `{"name": "Bello"}`, client.Body)
+	})
+
+	t.Run("Parameter Reference", func(t *testing.T) {
+		spec := `
+openapi: 3.0.0
+servers:
+  - url: https://petstore.example.com/v1
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - $ref: '#/components/parameters/limit'
+components:
+  parameters:
+    limit:
+      name: limit
+      in: query
+      required: true
+      schema:
+        type: integer
+`
+
+		client := &recordingHTTPClient{Response: `[]`}
+
+		openAPI, err := NewOpenAPI(newFake(`{"operation": "listPets", "parameters": {"limit": 10}}`), []byte(spec), func(o *OpenAPIOptions) {
+			o.HTTPClient = client
+		})
+		assert.NoError(t, err)
+
+		_, err = golc.SimpleCall(context.Background(), openAPI, "List 10 pets")
+		assert.NoError(t, err)
+		assert.Equal(t, "https://petstore.example.com/v1/pets?limit=10", client.Request.URL.String())
+	})
+
+	t.Run("Invalid Specifications", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			spec   string
+			errMsg string
+		}{
+			{"No Operations", "openapi: 3.0.0\nservers:\n  - url: https://petstore.example.com\n", "invalid OpenAPI specification: no operations"},
+			{"No Server", "openapi: 3.0.0\npaths:\n  /pets:\n    get: {}\n", `invalid base URL: ""`},
+			{"Unresolvable Reference", "openapi: 3.0.0\npaths:\n  /pets:\n    get:\n      parameters:\n        - $ref: '#/components/parameters/limit'\n", "invalid OpenAPI specification: unresolvable reference: #/components/parameters/limit"},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := NewOpenAPI(newFake(""), []byte(tc.spec))
+				assert.EqualError(t, err, tc.errMsg)
+			})
+		}
+	})
+}
+
+type recordingHTTPClient struct {
+	Response string
+	Request  *http.Request
+	Body     string
+}
+
+func (c *recordingHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	c.Request = req
+
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		c.Body = string(b)
+	}
+
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(c.Response)),
+	}, nil
+}
@@ -14,13 +14,25 @@ import (
 // Compile time check to ensure Sequential satisfies the Chain interface.
 var _ schema.Chain = (*Sequential)(nil)
 
+// SequentialOptions contains options for the sequential chain.
 type SequentialOptions struct {
+	// CallbackOptions contains options for the chain callbacks.
 	*schema.CallbackOptions
-	Memory     schema.Memory
+
+	// Memory is the schema.Memory to be associated with the chain.
+	Memory schema.Memory
+
+	// OutputKeys are the keys of the values returned by the chain. Each key must be an input key
+	// or an output key of one of the chains. Defaults to the output keys of the last chain.
 	OutputKeys []string
-	ReturnAll  bool
+
+	// ReturnAll determines whether to return the outputs of all chains, i.e. including the intermediate outputs.
+	// It is only considered if no OutputKeys are set.
+	ReturnAll bool
 }
 
+// Sequential is a chain implementation that calls the chains one after another. The outputs of
+// each chain are added to the known values, which are passed as inputs to the subsequent chains.
 type Sequential struct {
 	chains     []schema.Chain
 	inputKeys  []string
@@ -28,8 +40,14 @@ type Sequential struct {
 	opts       SequentialOptions
 }
 
+// NewSequential creates a new instance of the sequential chain. It validates that the input keys
+// of each chain are provided by the input keys, the memory or the preceding chains, and that no
+// chain overwrites a known value.
 func NewSequential(chains []schema.Chain, inputKeys []string, optFns ...func(o *SequentialOptions)) (*Sequential, error) {
 	opts := SequentialOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
 		ReturnAll: false,
 	}
 
@@ -37,6 +55,10 @@ func NewSequential(chains []schema.Chain, inputKeys []string, optFns ...func(o *
 		fn(&opts)
 	}
 
+	if len(chains) == 0 {
+		return nil, ErrNoChains
+	}
+
 	memoryKeys := []string{}
 	if opts.Memory != nil {
 		memoryKeys = opts.Memory.MemoryKeys()
@@ -47,7 +69,7 @@ func NewSequential(chains []schema.Chain, inputKeys []string, optFns ...func(o *
 		}
 	}
 
-	knownKeys := append(inputKeys, memoryKeys...)
+	knownKeys := append(append([]string{}, inputKeys...), memoryKeys...)
 
 	for _, chain := range chains {
 		missingKeys, _ := util.Difference(chain.InputKeys(), knownKeys)
@@ -69,6 +91,11 @@ func NewSequential(chains []schema.Chain, inputKeys []string, optFns ...func(o *
 		} else {
 			opts.OutputKeys = chains[len(chains)-1].OutputKeys()
 		}
+	} else {
+		unknownKeys, _ := util.Difference(opts.OutputKeys, knownKeys)
+		if len(unknownKeys) > 0 {
+			return nil, fmt.Errorf("unknown output keys: %s", strings.Join(unknownKeys, ","))
+		}
 	}
 
 	return &Sequential{
@@ -92,12 +119,12 @@ func (c *Sequential) Call(ctx context.Context, inputs schema.ChainValues, optFns
 
 	knownValues := util.CopyMap(inputs)
 
-	for _, c := range c.chains {
+	for _, chain := range c.chains {
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		default:
-			outputs, err := golc.Call(ctx, c, knownValues, func(co *golc.CallOptions) {
+			outputs, err := golc.Call(ctx, chain, knownValues, func(co *golc.CallOptions) {
 				co.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
 				co.ParentRunID = opts.CallbackManger.RunID()
 			})
@@ -112,7 +139,7 @@ func (c *Sequential) Call(ctx context.Context, inputs schema.ChainValues, optFns
 	}
 
 	result := make(schema.ChainValues)
-	for _, k := range c.opts.OutputKeys {
+	for _, k := range c.outputKeys {
 		result[k] = knownValues[k]
 	}
 
@@ -152,21 +179,37 @@ func (c *Sequential) OutputKeys() []string {
 // Compile time check to ensure SimpleSequential satisfies the Chain interface.
 var _ schema.Chain = (*SimpleSequential)(nil)
 
+// SimpleSequentialOptions contains options for the simple sequential chain.
 type SimpleSequentialOptions struct {
+	// CallbackOptions contains options for the chain callbacks.
 	*schema.CallbackOptions
-	Memory       schema.Memory
-	InputKey     string
-	OutputKey    string
+
+	// Memory is the schema.Memory to be associated with the chain.
+	Memory schema.Memory
+
+	// InputKey is the key to access the input value of the first chain.
+	InputKey string
+
+	// OutputKey is the key to access the output value of the last chain.
+	OutputKey string
+
+	// StripOutputs determines whether to trim the whitespace of the outputs before passing them to the next chain.
 	StripOutputs bool
 }
 
+// SimpleSequential is a chain implementation that calls chains with a single input and a single output
+// one after another. The output of each chain is the input of the next chain.
 type SimpleSequential struct {
 	chains []schema.Chain
 	opts   SimpleSequentialOptions
 }
 
+// NewSimpleSequential creates a new instance of the simple sequential chain.
 func NewSimpleSequential(chains []schema.Chain, optFns ...func(o *SimpleSequentialOptions)) (*SimpleSequential, error) {
 	opts := SimpleSequentialOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
 		InputKey:     "input",
 		OutputKey:    "output",
 		StripOutputs: false,
@@ -176,6 +219,10 @@ func NewSimpleSequential(chains []schema.Chain, optFns ...func(o *SimpleSequenti
 		fn(&opts)
 	}
 
+	if len(chains) == 0 {
+		return nil, ErrNoChains
+	}
+
 	for _, chain := range chains {
 		if len(chain.InputKeys()) != 1 {
 			return nil, fmt.Errorf("chain with more than one expected input: %v", len(chain.InputKeys()))
@@ -203,14 +250,17 @@ func (c *SimpleSequential) Call(ctx context.Context, inputs schema.ChainValues,
 		fn(&opts)
 	}
 
-	input := inputs[c.opts.InputKey]
+	input, ok := inputs[c.opts.InputKey]
+	if !ok {
+		return nil, ErrInvalidInputValues
+	}
 
 	for _, chain := range c.chains {
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		default:
-			input, err := golc.SimpleCall(ctx, chain, input, func(co *golc.SimpleCallOptions) {
+			output, err := golc.SimpleCall(ctx, chain, input, func(co *golc.SimpleCallOptions) {
 				co.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
 				co.ParentRunID = opts.CallbackManger.RunID()
 			})
@@ -219,8 +269,10 @@ func (c *SimpleSequential) Call(ctx context.Context, inputs schema.ChainValues,
 			}
 
 			if c.opts.StripOutputs {
-				input = strings.TrimSpace(input) //nolint ineffassign
+				output = strings.TrimSpace(output)
 			}
+
+			input = output
 		}
 	}
 
@@ -251,10 +303,10 @@ func (c *SimpleSequential) Callbacks() []schema.Callback {
 
 // InputKeys returns the expected input keys.
 func (c *SimpleSequential) InputKeys() []string {
-	return []string{}
+	return []string{c.opts.InputKey}
 }
 
 // OutputKeys returns the output keys the chain will return.
 func (c *SimpleSequential) OutputKeys() []string {
-	return []string{}
+	return []string{c.opts.OutputKey}
 }
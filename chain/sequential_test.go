@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/hupe1980/golc"
 	"github.com/hupe1980/golc/schema"
 	"github.com/stretchr/testify/assert"
 )
@@ -58,4 +59,74 @@ func TestSequential(t *testing.T) {
 
 		assert.Equal(t, expectedOutputs, outputs)
 	})
+	t.Run("ReturnAll", func(t *testing.T) {
+		chain1 := &MockChain{
+			CallFunc: func(ctx context.Context, inputs schema.ChainValues) (schema.ChainValues, error) {
+				return schema.ChainValues{"intermediate": inputs["in"].(string) + "1"}, nil
+			},
+			InputKeysFunc:  func() []string { return []string{"in"} },
+			OutputKeysFunc: func() []string { return []string{"intermediate"} },
+		}
+		chain2 := &MockChain{
+			CallFunc: func(ctx context.Context, inputs schema.ChainValues) (schema.ChainValues, error) {
+				return schema.ChainValues{"out": inputs["intermediate"].(string) + "2"}, nil
+			},
+			InputKeysFunc:  func() []string { return []string{"intermediate"} },
+			OutputKeysFunc: func() []string { return []string{"out"} },
+		}
+
+		sequential, err := NewSequential([]schema.Chain{chain1, chain2}, []string{"in"}, func(o *SequentialOptions) {
+			o.ReturnAll = true
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"intermediate", "out"}, sequential.OutputKeys())
+
+		outputs, err := golc.Call(context.Background(), sequential, schema.ChainValues{"in": "value"})
+		assert.NoError(t, err)
+		assert.Equal(t, schema.ChainValues{"intermediate": "value1", "out": "value12"}, outputs)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		chain := &MockChain{
+			InputKeysFunc:  func() []string { return []string{"in"} },
+			OutputKeysFunc: func() []string { return []string{"out"} },
+		}
+
+		_, err := NewSequential(nil, []string{"in"})
+		assert.ErrorIs(t, err, ErrNoChains)
+
+		_, err = NewSequential([]schema.Chain{chain}, []string{"other"})
+		assert.EqualError(t, err, "missing required input keys: in")
+
+		_, err = NewSequential([]schema.Chain{chain}, []string{"in", "out"})
+		assert.EqualError(t, err, "overlapping output keys: out")
+
+		_, err = NewSequential([]schema.Chain{chain}, []string{"in"}, func(o *SequentialOptions) {
+			o.OutputKeys = []string{"unknown"}
+		})
+		assert.EqualError(t, err, "unknown output keys: unknown")
+	})
+}
+
+func TestSimpleSequential(t *testing.T) {
+	newChain := func(suffix string) schema.Chain {
+		return &MockChain{
+			CallFunc: func(ctx context.Context, inputs schema.ChainValues) (schema.ChainValues, error) {
+				return schema.ChainValues{"text": inputs["input"].(string) + suffix}, nil
+			},
+			InputKeysFunc:  func() []string { return []string{"input"} },
+			OutputKeysFunc: func() []string { return []string{"text"} },
+		}
+	}
+
+	sequential, err := NewSimpleSequential([]schema.Chain{newChain(" 1 "), newChain("2")}, func(o *SimpleSequentialOptions) {
+		o.StripOutputs = true
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"input"}, sequential.InputKeys())
+	assert.Equal(t, []string{"output"}, sequential.OutputKeys())
+
+	output, err := golc.SimpleCall(context.Background(), sequential, "value")
+	assert.NoError(t, err)
+	assert.Equal(t, "value 12", output)
 }
@@ -2,6 +2,7 @@ package chain
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"strings"
 
@@ -19,7 +20,9 @@ const defaultSQLTemplate = `Given an input question, first create a syntacticall
 Never query for all the columns from a specific table, only ask for a the few relevant columns given the question.
 
 Pay attention to use only the column names that you can see in the schema description. Be careful to not query for columns that do not exist. Also, pay attention to which column is in which table.
-
+{{if .dialectInstructions}}
+{{.dialectInstructions}}
+{{end}}
 Use the following format:
 
 Question: Question here
@@ -32,6 +35,30 @@ Only use the following tables:
 
 Question: {{.input}}`
 
+// sqlDialectInstructions contains additional dialect-specific instructions for the generation of SQL queries.
+var sqlDialectInstructions = map[string]string{
+	"postgres": `Wrap each column name in double quotes (") to denote them as delimited identifiers. Use the CURRENT_DATE function to get the current date, if the question involves "today".`,
+	"mysql":    "Wrap each column name in backticks (`) to denote them as delimited identifiers. Use the CURDATE() function to get the current date, if the question involves \"today\".",
+	"sqlite3":  `Wrap each column name in double quotes (") to denote them as delimited identifiers. Use the date('now') function to get the current date, if the question involves "today".`,
+}
+
+// sqlDialectAliases maps dialects to the dialect whose instructions they share.
+var sqlDialectAliases = map[string]string{
+	"cockroachdb": "postgres",
+	"mariadb":     "mysql",
+}
+
+// dialectInstructions returns the instructions for the given dialect, if any.
+func dialectInstructions(dialect string) string {
+	dialect = strings.ToLower(dialect)
+
+	if alias, ok := sqlDialectAliases[dialect]; ok {
+		dialect = alias
+	}
+
+	return sqlDialectInstructions[dialect]
+}
+
 // Compile time check to ensure SQL satisfies the Chain interface.
 var _ schema.Chain = (*SQL)(nil)
 
@@ -67,6 +94,18 @@ type SQLOptions struct {
 	// VerifySQL is a function used to verify the validity of the generated SQL query before execution.
 	// It should return true if the SQL query is valid, false otherwise.
 	VerifySQL VerifySQL
+
+	// ReadOnly determines whether the SQL query is executed in a read-only transaction, which is rolled back afterwards.
+	ReadOnly bool
+
+	// MaxRows is the maximum number of rows of the SQL query result passed to the model. A value <= 0 disables the limit.
+	MaxRows int
+
+	// MaxColumns is the maximum number of columns of the SQL query result passed to the model. A value <= 0 disables the limit.
+	MaxColumns int
+
+	// ReturnDirect determines whether to return the SQL query result directly instead of summarizing it with the model.
+	ReturnDirect bool
 }
 
 // SQL is a chain implementation that prompts the user to provide an SQL query
@@ -108,6 +147,9 @@ func NewSQL(model schema.Model, engine sqldb.Engine, optFns ...func(o *SQLOption
 		TopK:                  5,
 		SampleRowsinTableInfo: 3,
 		VerifySQL:             func(sqlQuery string) bool { return true },
+		ReadOnly:              true,
+		MaxRows:               100,
+		MaxColumns:            20,
 	}
 
 	for _, fn := range optFns {
@@ -123,7 +165,10 @@ func NewSQL(model schema.Model, engine sqldb.Engine, optFns ...func(o *SQLOption
 		return nil, err
 	}
 
-	llmChain, err := NewLLM(model, prompt.NewTemplate(defaultSQLTemplate))
+	// The prompt already specifies the format of the query and the answer.
+	llmChain, err := NewLLM(model, prompt.NewTemplate(defaultSQLTemplate), func(o *LLMOptions) {
+		o.DisableFormatInstructions = true
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -135,6 +180,17 @@ func NewSQL(model schema.Model, engine sqldb.Engine, optFns ...func(o *SQLOption
 	}, nil
 }
 
+// NewSQLDatabase creates a new instance of the SQL chain for an existing database handle.
+// The database engine is selected based on the driver of the handle.
+func NewSQLDatabase(model schema.Model, db *sql.DB, optFns ...func(o *SQLOptions)) (*SQL, error) {
+	engine, err := sqldb.NewEngineFromDB(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSQL(model, engine, optFns...)
+}
+
 // Call executes the sql chain with the given context and inputs.
 // It returns the outputs of the chain or an error, if any.
 func (c *SQL) Call(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
@@ -170,12 +226,7 @@ func (c *SQL) Call(ctx context.Context, inputs schema.ChainValues, optFns ...fun
 
 	input := fmt.Sprintf("%s\nSQLQuery:", query)
 
-	sqlQuery, err := golc.SimpleCall(ctx, c.llmChain, schema.ChainValues{
-		"dialect":   c.sqldb.Dialect(),
-		"input":     input,
-		"tableInfo": tableInfo,
-		"topK":      c.opts.TopK,
-	}, func(sco *golc.SimpleCallOptions) {
+	sqlQuery, err := golc.SimpleCall(ctx, c.llmChain, c.promptValues(input, tableInfo), func(sco *golc.SimpleCallOptions) {
 		sco.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
 		sco.ParentRunID = opts.CallbackManger.RunID()
 		sco.Stop = []string{"\nSQLResult:"}
@@ -206,25 +257,35 @@ func (c *SQL) Call(ctx context.Context, inputs schema.ChainValues, optFns ...fun
 		return nil, cbErr
 	}
 
-	queryResult, err := c.sqldb.Query(ctx, sqlQuery)
+	queryResult, err := c.sqldb.QueryWithOptions(ctx, sqlQuery, func(o *sqldb.QueryOptions) {
+		o.ReadOnly = c.opts.ReadOnly
+		o.MaxRows = c.opts.MaxRows
+		o.MaxColumns = c.opts.MaxColumns
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	sqlResult := queryResult.String()
+	if queryResult.Truncated {
+		sqlResult += "(truncated)\n"
+	}
+
 	if cbErr := opts.CallbackManger.OnText(ctx, &schema.TextManagerInput{
-		Text: queryResult.String(),
+		Text: sqlResult,
 	}); cbErr != nil {
 		return nil, cbErr
 	}
 
-	input += fmt.Sprintf("%s\nSQLResult: %s\nAnswer:", sqlQuery, queryResult)
+	if c.opts.ReturnDirect {
+		return schema.ChainValues{
+			c.opts.OutputKey: sqlResult,
+		}, nil
+	}
+
+	input += fmt.Sprintf("%s\nSQLResult: %s\nAnswer:", sqlQuery, sqlResult)
 
-	result, err := golc.SimpleCall(ctx, c.llmChain, schema.ChainValues{
-		"dialect":   c.sqldb.Dialect(),
-		"input":     input,
-		"tableInfo": tableInfo,
-		"topK":      c.opts.TopK,
-	}, func(sco *golc.SimpleCallOptions) {
+	result, err := golc.SimpleCall(ctx, c.llmChain, c.promptValues(input, tableInfo), func(sco *golc.SimpleCallOptions) {
 		sco.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
 		sco.ParentRunID = opts.CallbackManger.RunID()
 	})
@@ -275,6 +336,17 @@ func (c *SQL) OutputKeys() []string {
 	return []string{c.opts.OutputKey}
 }
 
+// promptValues returns the values of the prompt for the given input and table info.
+func (c *SQL) promptValues(input, tableInfo string) schema.ChainValues {
+	return schema.ChainValues{
+		"dialect":             c.sqldb.Dialect(),
+		"dialectInstructions": dialectInstructions(c.sqldb.Dialect()),
+		"input":               input,
+		"tableInfo":           tableInfo,
+		"topK":                c.opts.TopK,
+	}
+}
+
 // checkTables checks if the provided tables are allowed based on the options specified in SQLOptions.
 // If the Tables option is set, it verifies that the tables are present in the allowed list.
 // If the Exclude option is set, it verifies that the tables are not present in the excluded list.
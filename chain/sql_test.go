@@ -2,6 +2,7 @@ package chain
 
 import (
 	"context"
+	"database/sql"
 	"strings"
 	"testing"
 
@@ -158,4 +159,66 @@ func TestSQL(t *testing.T) {
 		assert.Error(t, err)
 		assert.EqualError(t, err, "not allowed table: employee")
 	})
+	t.Run("ReturnDirect with limits", func(t *testing.T) {
+		var prompts []string
+
+		fake := llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+			prompts = append(prompts, prompt)
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: "SELECT id FROM employee ORDER BY id;"}},
+				LLMOutput:   map[string]any{},
+			}, nil
+		})
+
+		sqlChain, err := NewSQL(fake, engine, func(o *SQLOptions) {
+			o.ReturnDirect = true
+			o.MaxRows = 2
+		})
+		assert.NoError(t, err)
+
+		output, err := golc.SimpleCall(ctx, sqlChain, "Which employees are there?")
+		assert.NoError(t, err)
+		assert.Equal(t, "id\n0\n1\n(truncated)\n", output)
+		assert.Len(t, prompts, 1)
+		assert.Contains(t, prompts[0], "Use the date('now') function to get the current date")
+	})
+}
+
+func TestNewSQLDatabase(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+
+	defer db.Close()
+
+	_, err = db.ExecContext(ctx, "CREATE TABLE employee ( id int not null );")
+	assert.NoError(t, err)
+
+	fake := llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+		text := "There are 0 employees."
+		if strings.HasSuffix(prompt, "SQLQuery:") {
+			text = "SELECT count(*) FROM employee;"
+		}
+
+		return &schema.ModelResult{
+			Generations: []schema.Generation{{Text: text}},
+			LLMOutput:   map[string]any{},
+		}, nil
+	})
+
+	sqlChain, err := NewSQLDatabase(fake, db)
+	assert.NoError(t, err)
+
+	output, err := golc.SimpleCall(ctx, sqlChain, "How many employees are there?")
+	assert.NoError(t, err)
+	assert.Equal(t, "There are 0 employees.", output)
+}
+
+func TestDialectInstructions(t *testing.T) {
+	assert.Contains(t, dialectInstructions("Postgres"), "CURRENT_DATE")
+	assert.Contains(t, dialectInstructions("CockroachDB"), "CURRENT_DATE")
+	assert.Contains(t, dialectInstructions("MariaDB"), "CURDATE()")
+	assert.Equal(t, "", dialectInstructions("unknown"))
 }
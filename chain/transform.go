@@ -2,6 +2,8 @@ package chain
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/hupe1980/golc"
 	"github.com/hupe1980/golc/schema"
@@ -10,12 +12,21 @@ import (
 // Compile time check to ensure Transform satisfies the Chain interface.
 var _ schema.Chain = (*Transform)(nil)
 
+// TransformFunc is a function that transforms the input values of a Transform chain into its output values.
 type TransformFunc func(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error)
 
+// TransformOptions contains options for the transform chain.
 type TransformOptions struct {
+	// CallbackOptions contains options for the chain callbacks.
 	*schema.CallbackOptions
+
+	// Memory is the schema.Memory to be associated with the chain.
+	Memory schema.Memory
 }
 
+// Transform is a chain implementation that calls a Go function, e.g. to clean up or convert
+// the outputs of a chain before passing them to the next chain. As a chain, it participates in
+// the callbacks and the memory of chain executions.
 type Transform struct {
 	inputKeys  []string
 	outputKeys []string
@@ -23,6 +34,7 @@ type Transform struct {
 	opts       TransformOptions
 }
 
+// NewTransform creates a new instance of the transform chain.
 func NewTransform(inputKeys, outputKeys []string, transform TransformFunc, optFns ...func(o *TransformOptions)) (*Transform, error) {
 	opts := TransformOptions{
 		CallbackOptions: &schema.CallbackOptions{
@@ -45,12 +57,25 @@ func NewTransform(inputKeys, outputKeys []string, transform TransformFunc, optFn
 // Call executes the transform chain with the given context and inputs.
 // It returns the outputs of the chain or an error, if any.
 func (c *Transform) Call(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
-	return c.transform(ctx, inputs, optFns...)
+	if missingKeys := missingValues(inputs, c.inputKeys); len(missingKeys) > 0 {
+		return nil, fmt.Errorf("%w: missing input keys: %s", ErrInvalidInputValues, strings.Join(missingKeys, ","))
+	}
+
+	outputs, err := c.transform(ctx, inputs, optFns...)
+	if err != nil {
+		return nil, err
+	}
+
+	if missingKeys := missingValues(outputs, c.outputKeys); len(missingKeys) > 0 {
+		return nil, fmt.Errorf("missing output keys: %s", strings.Join(missingKeys, ","))
+	}
+
+	return outputs, nil
 }
 
 // Memory returns the memory associated with the chain.
 func (c *Transform) Memory() schema.Memory {
-	return nil
+	return c.opts.Memory
 }
 
 // Type returns the type of the chain.
@@ -77,3 +102,16 @@ func (c *Transform) InputKeys() []string {
 func (c *Transform) OutputKeys() []string {
 	return c.outputKeys
 }
+
+// missingValues returns the keys without a value in the chain values.
+func missingValues(values schema.ChainValues, keys []string) []string {
+	missingKeys := []string{}
+
+	for _, k := range keys {
+		if _, ok := values[k]; !ok {
+			missingKeys = append(missingKeys, k)
+		}
+	}
+
+	return missingKeys
+}
@@ -2,8 +2,11 @@ package chain
 
 import (
 	"context"
+	"strings"
 	"testing"
 
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/memory"
 	"github.com/hupe1980/golc/schema"
 	"github.com/stretchr/testify/assert"
 )
@@ -73,4 +76,41 @@ func TestTransform(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, expectedResult, result)
 	})
+	t.Run("Missing keys", func(t *testing.T) {
+		transform := func(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+			return schema.ChainValues{"output1": inputs["input1"]}, nil
+		}
+
+		chain, err := NewTransform([]string{"input1"}, []string{"output1", "output2"}, transform)
+		assert.NoError(t, err)
+
+		_, err = chain.Call(context.Background(), schema.ChainValues{})
+		assert.ErrorIs(t, err, ErrInvalidInputValues)
+		assert.EqualError(t, err, "invalid input values: missing input keys: input1")
+
+		_, err = chain.Call(context.Background(), schema.ChainValues{"input1": "value1"})
+		assert.EqualError(t, err, "missing output keys: output2")
+	})
+
+	t.Run("Memory", func(t *testing.T) {
+		transform := func(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+			return schema.ChainValues{"output": strings.TrimSpace(inputs["input"].(string))}, nil
+		}
+
+		mem := memory.NewConversationBuffer()
+
+		chain, err := NewTransform([]string{"input"}, []string{"output"}, transform, func(o *TransformOptions) {
+			o.Memory = mem
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, mem, chain.Memory())
+
+		outputs, err := golc.Call(context.Background(), chain, schema.ChainValues{"input": " value "})
+		assert.NoError(t, err)
+		assert.Equal(t, "value", outputs["output"])
+
+		vars, err := mem.LoadMemoryVariables(context.Background(), nil)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, vars["history"])
+	})
 }
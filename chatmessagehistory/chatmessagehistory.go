@@ -1 +1,2 @@
+// Package chatmessagehistory provides stores for the messages of conversations, which are used by the memory types.
 package chatmessagehistory
@@ -7,16 +7,16 @@ import (
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
-	"github.com/hupe1980/golc/internal/util"
 	"github.com/hupe1980/golc/schema"
 )
 
-// Compile time check to ensure DynamoDB satisfies the ChatMessageHistory interface.
-var _ schema.ChatMessageHistory = (*DynamoDB)(nil)
+// Compile time check to ensure DynamoDB satisfies the SessionChatMessageHistory interface.
+var _ schema.SessionChatMessageHistory = (*DynamoDB)(nil)
 
+// DynamoDBClient is the interface of the dynamodb client used by the DynamoDB chat message history.
 type DynamoDBClient interface {
 	GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
-	PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
+	UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error)
 	DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)
 }
 
@@ -25,12 +25,15 @@ type dynamoDBHistory struct {
 	History   []map[string]string `dynamodbav:"history"`
 }
 
+// DynamoDB represents a chat message history persisted in a DynamoDB table. The messages of a session
+// are stored in a single item with the partition key "sessionId".
 type DynamoDB struct {
 	client    DynamoDBClient
 	tableName string
 	sessionID string
 }
 
+// NewDynamoDB creates a new chat message history for the session persisted in the DynamoDB table.
 func NewDynamoDB(client DynamoDBClient, tableName, sessionID string) *DynamoDB {
 	return &DynamoDB{
 		client:    client,
@@ -39,6 +42,12 @@ func NewDynamoDB(client DynamoDBClient, tableName, sessionID string) *DynamoDB {
 	}
 }
 
+// SessionID returns the id of the session of the chat message history.
+func (mh *DynamoDB) SessionID() string {
+	return mh.sessionID
+}
+
+// Messages returns the messages of the session in the order they were added.
 func (mh *DynamoDB) Messages(ctx context.Context) (schema.ChatMessages, error) {
 	sessionID, err := attributevalue.Marshal(mh.sessionID)
 	if err != nil {
@@ -78,35 +87,41 @@ func (mh *DynamoDB) Messages(ctx context.Context) (schema.ChatMessages, error) {
 	return history, nil
 }
 
+// AddUserMessage adds a user message to the session.
 func (mh *DynamoDB) AddUserMessage(ctx context.Context, text string) error {
 	message := schema.NewHumanChatMessage(text)
 	return mh.AddMessage(ctx, message)
 }
 
+// AddAIMessage adds an AI message to the session.
 func (mh *DynamoDB) AddAIMessage(ctx context.Context, text string) error {
 	message := schema.NewAIChatMessage(text)
 	return mh.AddMessage(ctx, message)
 }
 
+// AddMessage appends a message to the session. The message is appended atomically with list_append, so
+// concurrent writers, e.g. several replicas of a service, do not overwrite each other's messages.
 func (mh *DynamoDB) AddMessage(ctx context.Context, message schema.ChatMessage) error {
-	messages, err := mh.Messages(ctx)
+	sessionID, err := attributevalue.Marshal(mh.sessionID)
 	if err != nil {
 		return err
 	}
 
-	item, err := attributevalue.MarshalMap(dynamoDBHistory{
-		SessionID: mh.sessionID,
-		History: util.Map(append(messages, message), func(m schema.ChatMessage, _ int) map[string]string {
-			return schema.ChatMessageToMap(m)
-		}),
-	})
+	messages, err := attributevalue.Marshal([]map[string]string{schema.ChatMessageToMap(message)})
 	if err != nil {
 		return err
 	}
 
-	if _, err := mh.client.PutItem(ctx, &dynamodb.PutItemInput{
-		Item:      item,
-		TableName: aws.String(mh.tableName),
+	if _, err := mh.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		Key: map[string]types.AttributeValue{
+			"sessionId": sessionID,
+		},
+		TableName:        aws.String(mh.tableName),
+		UpdateExpression: aws.String("SET history = list_append(if_not_exists(history, :empty), :messages)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":empty":    &types.AttributeValueMemberL{Value: []types.AttributeValue{}},
+			":messages": messages,
+		},
 	}); err != nil {
 		return err
 	}
@@ -114,6 +129,7 @@ func (mh *DynamoDB) AddMessage(ctx context.Context, message schema.ChatMessage)
 	return nil
 }
 
+// Clear removes all messages of the session.
 func (mh *DynamoDB) Clear(ctx context.Context) error {
 	sessionID, err := attributevalue.Marshal(mh.sessionID)
 	if err != nil {
@@ -124,6 +140,7 @@ func (mh *DynamoDB) Clear(ctx context.Context) error {
 		Key: map[string]types.AttributeValue{
 			"sessionId": sessionID,
 		},
+		TableName: aws.String(mh.tableName),
 	}); err != nil {
 		return err
 	}
@@ -59,10 +59,54 @@ func TestDynamoDB_Messages(t *testing.T) {
 	})
 }
 
+func TestDynamoDB_AddMessage(t *testing.T) {
+	var input *dynamodb.UpdateItemInput
+
+	mockClient := &mockDynamoDBClient{
+		UpdateItemFunc: func(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+			input = params
+			return &dynamodb.UpdateItemOutput{}, nil
+		},
+	}
+
+	dynamoDB := NewDynamoDB(mockClient, "testTable", "testSessionID")
+
+	err := dynamoDB.AddUserMessage(context.TODO(), "Hello")
+	assert.NoError(t, err)
+	assert.Equal(t, "testTable", *input.TableName)
+	assert.Contains(t, input.Key, "sessionId")
+	assert.Equal(t, "SET history = list_append(if_not_exists(history, :empty), :messages)", *input.UpdateExpression)
+
+	messages := []map[string]string{}
+	assert.NoError(t, attributevalue.Unmarshal(input.ExpressionAttributeValues[":messages"], &messages))
+	assert.Equal(t, []map[string]string{schema.ChatMessageToMap(schema.NewHumanChatMessage("Hello"))}, messages)
+}
+
+func TestDynamoDB_Clear(t *testing.T) {
+	var input *dynamodb.DeleteItemInput
+
+	mockClient := &mockDynamoDBClient{
+		DeleteItemFunc: func(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+			input = params
+			return &dynamodb.DeleteItemOutput{}, nil
+		},
+	}
+
+	dynamoDB := NewDynamoDB(mockClient, "testTable", "testSessionID")
+	assert.Equal(t, "testSessionID", dynamoDB.SessionID())
+
+	err := dynamoDB.Clear(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, "testTable", *input.TableName)
+	assert.Contains(t, input.Key, "sessionId")
+}
+
 // Mock DynamoDB client implementation
 type mockDynamoDBClient struct {
 	dynamodb.Client
-	GetItemFunc func(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
+	GetItemFunc    func(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
+	UpdateItemFunc func(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error)
+	DeleteItemFunc func(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)
 }
 
 func (m *mockDynamoDBClient) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
@@ -72,3 +116,19 @@ func (m *mockDynamoDBClient) GetItem(ctx context.Context, params *dynamodb.GetIt
 
 	return nil, nil
 }
+
+func (m *mockDynamoDBClient) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	if m.UpdateItemFunc != nil {
+		return m.UpdateItemFunc(ctx, params, optFns...)
+	}
+
+	return nil, nil
+}
+
+func (m *mockDynamoDBClient) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	if m.DeleteItemFunc != nil {
+		return m.DeleteItemFunc(ctx, params, optFns...)
+	}
+
+	return nil, nil
+}
@@ -2,39 +2,50 @@ package chatmessagehistory
 
 import (
 	"context"
-	"encoding/json"
 	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/hupe1980/golc/schema"
 )
 
-// Compile time check to ensure Redis satisfies the ChatMessageHistory interface.
-var _ schema.ChatMessageHistory = (*Redis)(nil)
+// Compile time check to ensure Redis satisfies the SessionChatMessageHistory interface.
+var _ schema.SessionChatMessageHistory = (*Redis)(nil)
 
+// RedisClient is the interface of the redis client used by the Redis chat message history.
 type RedisClient interface {
 	LRange(ctx context.Context, key string, start, stop int64) *redis.StringSliceCmd
-	LPush(ctx context.Context, key string, values ...interface{}) *redis.IntCmd
+	RPush(ctx context.Context, key string, values ...interface{}) *redis.IntCmd
 	Del(ctx context.Context, keys ...string) *redis.IntCmd
 	Expire(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd
 }
 
+// RedisOptions represents options for the Redis chat message history.
 type RedisOptions struct {
+	// KeyPrefix is the prefix of the key of the list storing the messages of a session.
 	KeyPrefix string
-	TTL       *time.Duration
+	// TTL is the expiration of the messages of a session. It is refreshed whenever a message is added.
+	TTL *time.Duration
 }
 
+// Redis represents a chat message history persisted in a redis list per session. Messages are appended with
+// RPUSH. Earlier versions prepended them with LPUSH, so lists written by those versions are returned
+// newest first and must be cleared or reversed once.
 type Redis struct {
 	sessionID   string
 	redisClient RedisClient
 	opts        RedisOptions
 }
 
-func NewRedis(redisClient RedisClient, sessionID string) *Redis {
+// NewRedis creates a new chat message history for the session persisted in redis.
+func NewRedis(redisClient RedisClient, sessionID string, optFns ...func(o *RedisOptions)) *Redis {
 	opts := RedisOptions{
 		KeyPrefix: "message_store:",
 	}
 
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
 	return &Redis{
 		sessionID:   sessionID,
 		redisClient: redisClient,
@@ -42,6 +53,12 @@ func NewRedis(redisClient RedisClient, sessionID string) *Redis {
 	}
 }
 
+// SessionID returns the id of the session of the chat message history.
+func (mh *Redis) SessionID() string {
+	return mh.sessionID
+}
+
+// Messages returns the messages of the session in the order they were added.
 func (mh *Redis) Messages(ctx context.Context) (schema.ChatMessages, error) {
 	messages := schema.ChatMessages{}
 
@@ -59,13 +76,7 @@ func (mh *Redis) Messages(ctx context.Context) (schema.ChatMessages, error) {
 	}
 
 	for _, item := range items {
-		message := map[string]string{}
-
-		if err = json.Unmarshal([]byte(item), &message); err != nil {
-			return nil, err
-		}
-
-		cm, err := schema.MapToChatMessage(message)
+		cm, err := schema.UnmarshalChatMessage([]byte(item))
 		if err != nil {
 			return nil, err
 		}
@@ -76,25 +87,26 @@ func (mh *Redis) Messages(ctx context.Context) (schema.ChatMessages, error) {
 	return messages, nil
 }
 
+// AddUserMessage adds a user message to the session.
 func (mh *Redis) AddUserMessage(ctx context.Context, text string) error {
 	message := schema.NewHumanChatMessage(text)
 	return mh.AddMessage(ctx, message)
 }
 
+// AddAIMessage adds an AI message to the session.
 func (mh *Redis) AddAIMessage(ctx context.Context, text string) error {
 	message := schema.NewAIChatMessage(text)
 	return mh.AddMessage(ctx, message)
 }
 
+// AddMessage appends a message to the session.
 func (mh *Redis) AddMessage(ctx context.Context, message schema.ChatMessage) error {
-	redisMessage := schema.ChatMessageToMap(message)
-
-	messageJSON, err := json.Marshal(redisMessage)
+	messageJSON, err := schema.MarshalChatMessage(message)
 	if err != nil {
 		return err
 	}
 
-	if err := mh.redisClient.LPush(ctx, mh.key(), string(messageJSON)).Err(); err != nil {
+	if err := mh.redisClient.RPush(ctx, mh.key(), string(messageJSON)).Err(); err != nil {
 		return err
 	}
 
@@ -107,6 +119,7 @@ func (mh *Redis) AddMessage(ctx context.Context, message schema.ChatMessage) err
 	return nil
 }
 
+// Clear removes all messages of the session.
 func (mh *Redis) Clear(ctx context.Context) error {
 	res := mh.redisClient.Del(ctx, mh.key())
 	return res.Err()
@@ -2,7 +2,6 @@ package chatmessagehistory
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"testing"
 	"time"
@@ -31,7 +30,7 @@ func (c *mockRedisClient) LRange(ctx context.Context, key string, start, stop in
 	return cmd
 }
 
-func (c *mockRedisClient) LPush(ctx context.Context, key string, values ...interface{}) *redis.IntCmd {
+func (c *mockRedisClient) RPush(ctx context.Context, key string, values ...interface{}) *redis.IntCmd {
 	args := c.Called(ctx, key, values[0])
 
 	cmd := redis.NewIntCmd(ctx)
@@ -116,11 +115,10 @@ func TestRedis(t *testing.T) {
 		message := schema.NewHumanChatMessage("Hello, world!")
 
 		t.Run("AddUserMessage adds the user message", func(t *testing.T) {
-			redisMessage := schema.ChatMessageToMap(message)
-			messageJSON, _ := json.Marshal(redisMessage)
+			messageJSON, _ := schema.MarshalChatMessage(message)
 
 			mockClient.Mock = mock.Mock{}
-			mockClient.On("LPush", mock.Anything, redisHistory.key(), string(messageJSON)).
+			mockClient.On("RPush", mock.Anything, redisHistory.key(), string(messageJSON)).
 				Return(int64(1))
 
 			err := redisHistory.AddUserMessage(context.TODO(), "Hello, world!")
@@ -129,10 +127,10 @@ func TestRedis(t *testing.T) {
 			mockClient.AssertExpectations(t)
 		})
 
-		t.Run("AddUserMessage returns an error if LPush fails", func(t *testing.T) {
+		t.Run("AddUserMessage returns an error if RPush fails", func(t *testing.T) {
 			mockClient.Mock = mock.Mock{}
-			mockClient.On("LPush", mock.Anything, redisHistory.key(), mock.Anything).
-				Return(errors.New("LPush failed"))
+			mockClient.On("RPush", mock.Anything, redisHistory.key(), mock.Anything).
+				Return(errors.New("RPush failed"))
 
 			err := redisHistory.AddUserMessage(context.TODO(), "Hello, world!")
 
@@ -147,11 +145,10 @@ func TestRedis(t *testing.T) {
 		message := schema.NewAIChatMessage("AI response")
 
 		t.Run("AddAIMessage adds the AI message", func(t *testing.T) {
-			redisMessage := schema.ChatMessageToMap(message)
-			messageJSON, _ := json.Marshal(redisMessage)
+			messageJSON, _ := schema.MarshalChatMessage(message)
 
 			mockClient.Mock = mock.Mock{}
-			mockClient.On("LPush", mock.Anything, redisHistory.key(), string(messageJSON)).
+			mockClient.On("RPush", mock.Anything, redisHistory.key(), string(messageJSON)).
 				Return(int64(1))
 
 			err := redisHistory.AddAIMessage(context.TODO(), "AI response")
@@ -160,10 +157,10 @@ func TestRedis(t *testing.T) {
 			mockClient.AssertExpectations(t)
 		})
 
-		t.Run("AddAIMessage returns an error if LPush fails", func(t *testing.T) {
+		t.Run("AddAIMessage returns an error if RPush fails", func(t *testing.T) {
 			mockClient.Mock = mock.Mock{}
-			mockClient.On("LPush", mock.Anything, redisHistory.key(), mock.Anything).
-				Return(errors.New("LPush failed"))
+			mockClient.On("RPush", mock.Anything, redisHistory.key(), mock.Anything).
+				Return(errors.New("RPush failed"))
 
 			err := redisHistory.AddAIMessage(context.TODO(), "AI response")
 
@@ -178,11 +175,10 @@ func TestRedis(t *testing.T) {
 		message := schema.NewHumanChatMessage("Hello, world!")
 
 		t.Run("AddMessage adds the chat message", func(t *testing.T) {
-			redisMessage := schema.ChatMessageToMap(message)
-			messageJSON, _ := json.Marshal(redisMessage)
+			messageJSON, _ := schema.MarshalChatMessage(message)
 
 			mockClient.Mock = mock.Mock{}
-			mockClient.On("LPush", mock.Anything, redisHistory.key(), string(messageJSON)).
+			mockClient.On("RPush", mock.Anything, redisHistory.key(), string(messageJSON)).
 				Return(int64(1))
 
 			err := redisHistory.AddMessage(context.TODO(), message)
@@ -191,10 +187,10 @@ func TestRedis(t *testing.T) {
 			mockClient.AssertExpectations(t)
 		})
 
-		t.Run("AddMessage returns an error if LPush fails", func(t *testing.T) {
+		t.Run("AddMessage returns an error if RPush fails", func(t *testing.T) {
 			mockClient.Mock = mock.Mock{}
-			mockClient.On("LPush", mock.Anything, redisHistory.key(), mock.Anything).
-				Return(errors.New("LPush failed"))
+			mockClient.On("RPush", mock.Anything, redisHistory.key(), mock.Anything).
+				Return(errors.New("RPush failed"))
 
 			err := redisHistory.AddMessage(context.TODO(), message)
 
@@ -203,6 +199,31 @@ func TestRedis(t *testing.T) {
 		})
 	})
 
+	t.Run("TTL", func(t *testing.T) {
+		mockClient := &mockRedisClient{}
+		ttl := time.Hour
+		redisHistory := NewRedis(mockClient, "session1", func(o *RedisOptions) {
+			o.KeyPrefix = "prefix:"
+			o.TTL = &ttl
+		})
+
+		assert.Equal(t, "session1", redisHistory.SessionID())
+		assert.Equal(t, "prefix:session1", redisHistory.key())
+
+		t.Run("AddMessage refreshes the expiration", func(t *testing.T) {
+			mockClient.Mock = mock.Mock{}
+			mockClient.On("RPush", mock.Anything, "prefix:session1", mock.Anything).
+				Return(int64(1))
+			mockClient.On("Expire", mock.Anything, "prefix:session1", ttl).
+				Return(redis.NewBoolResult(true, nil))
+
+			err := redisHistory.AddUserMessage(context.TODO(), "Hello, world!")
+
+			assert.NoError(t, err)
+			mockClient.AssertExpectations(t)
+		})
+	})
+
 	t.Run("Clear", func(t *testing.T) {
 		mockClient := &mockRedisClient{}
 		redisHistory := NewRedis(mockClient, "session1")
@@ -0,0 +1,169 @@
+package chatmessagehistory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure SQL satisfies the SessionChatMessageHistory interface.
+var _ schema.SessionChatMessageHistory = (*SQL)(nil)
+
+// sqlTableNameRegexp matches the valid table names of the SQL chat message history.
+var sqlTableNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// SQLOptions represents options for the SQL chat message history.
+type SQLOptions struct {
+	// TableName is the name of the table storing the messages. It is created if it does not exist.
+	TableName string
+}
+
+// sqlDialect contains the statements, which differ between the supported databases.
+type sqlDialect struct {
+	// createTable is the statement to create the table with the table name as format argument.
+	createTable string
+	// placeholder returns the placeholder of the n-th argument of a statement, starting at 1.
+	placeholder func(n int) string
+}
+
+var (
+	sqliteDialect = sqlDialect{
+		createTable: `CREATE TABLE IF NOT EXISTS %[1]s (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			session_id TEXT NOT NULL,
+			message TEXT NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS %[1]s_session_id_idx ON %[1]s (session_id)`,
+		placeholder: func(n int) string {
+			return "?"
+		},
+	}
+
+	postgresDialect = sqlDialect{
+		createTable: `CREATE TABLE IF NOT EXISTS %[1]s (
+			id BIGSERIAL PRIMARY KEY,
+			session_id TEXT NOT NULL,
+			message JSONB NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS %[1]s_session_id_idx ON %[1]s (session_id)`,
+		placeholder: func(n int) string {
+			return "$" + strconv.Itoa(n)
+		},
+	}
+)
+
+// SQL represents a chat message history persisted in a SQL database. The messages of all sessions are
+// stored in a single table, one row per message, so the history is shared by all replicas using the database.
+type SQL struct {
+	db        *sql.DB
+	sessionID string
+	dialect   sqlDialect
+	opts      SQLOptions
+}
+
+// NewSQLite creates a new chat message history for the session persisted in a SQLite database. The database
+// driver, e.g. github.com/mattn/go-sqlite3 or modernc.org/sqlite, is chosen by the caller.
+func NewSQLite(ctx context.Context, db *sql.DB, sessionID string, optFns ...func(*SQLOptions)) (*SQL, error) {
+	return newSQL(ctx, db, sqliteDialect, sessionID, optFns...)
+}
+
+// NewPostgres creates a new chat message history for the session persisted in a Postgres database. The database
+// driver, e.g. github.com/jackc/pgx/v5/stdlib or github.com/lib/pq, is chosen by the caller.
+func NewPostgres(ctx context.Context, db *sql.DB, sessionID string, optFns ...func(*SQLOptions)) (*SQL, error) {
+	return newSQL(ctx, db, postgresDialect, sessionID, optFns...)
+}
+
+func newSQL(ctx context.Context, db *sql.DB, dialect sqlDialect, sessionID string, optFns ...func(*SQLOptions)) (*SQL, error) {
+	opts := SQLOptions{
+		TableName: "golc_chat_messages",
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if !sqlTableNameRegexp.MatchString(opts.TableName) {
+		return nil, fmt.Errorf("invalid table name: %s", opts.TableName)
+	}
+
+	// The statements are executed one by one, as not all drivers support multiple statements per call.
+	for _, stmt := range strings.Split(fmt.Sprintf(dialect.createTable, opts.TableName), ";") {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return nil, err
+		}
+	}
+
+	return &SQL{
+		db:        db,
+		sessionID: sessionID,
+		dialect:   dialect,
+		opts:      opts,
+	}, nil
+}
+
+// SessionID returns the id of the session of the chat message history.
+func (mh *SQL) SessionID() string {
+	return mh.sessionID
+}
+
+// Messages returns the messages of the session in the order they were added.
+func (mh *SQL) Messages(ctx context.Context) (schema.ChatMessages, error) {
+	rows, err := mh.db.QueryContext(ctx, fmt.Sprintf("SELECT message FROM %s WHERE session_id = %s ORDER BY id", mh.opts.TableName, mh.dialect.placeholder(1)), mh.sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := schema.ChatMessages{}
+
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+
+		cm, err := schema.UnmarshalChatMessage([]byte(data))
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, cm)
+	}
+
+	return messages, rows.Err()
+}
+
+// AddUserMessage adds a user message to the session.
+func (mh *SQL) AddUserMessage(ctx context.Context, text string) error {
+	message := schema.NewHumanChatMessage(text)
+	return mh.AddMessage(ctx, message)
+}
+
+// AddAIMessage adds an AI message to the session.
+func (mh *SQL) AddAIMessage(ctx context.Context, text string) error {
+	message := schema.NewAIChatMessage(text)
+	return mh.AddMessage(ctx, message)
+}
+
+// AddMessage adds a message to the session.
+func (mh *SQL) AddMessage(ctx context.Context, message schema.ChatMessage) error {
+	messageJSON, err := schema.MarshalChatMessage(message)
+	if err != nil {
+		return err
+	}
+
+	_, err = mh.db.ExecContext(ctx, fmt.Sprintf("INSERT INTO %s (session_id, message) VALUES (%s, %s)", mh.opts.TableName, mh.dialect.placeholder(1), mh.dialect.placeholder(2)), mh.sessionID, string(messageJSON))
+
+	return err
+}
+
+// Clear removes all messages of the session.
+func (mh *SQL) Clear(ctx context.Context) error {
+	_, err := mh.db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE session_id = %s", mh.opts.TableName, mh.dialect.placeholder(1)), mh.sessionID)
+	return err
+}
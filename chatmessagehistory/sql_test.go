@@ -0,0 +1,80 @@
+package chatmessagehistory
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/hupe1980/golc/schema"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQL(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	// An in-memory database exists per connection.
+	db.SetMaxOpenConns(1)
+
+	session1, err := NewSQLite(context.TODO(), db, "session1")
+	require.NoError(t, err)
+
+	session2, err := NewSQLite(context.TODO(), db, "session2")
+	require.NoError(t, err)
+
+	t.Run("SessionID", func(t *testing.T) {
+		assert.Equal(t, "session1", session1.SessionID())
+	})
+
+	t.Run("Messages", func(t *testing.T) {
+		require.NoError(t, session1.AddUserMessage(context.TODO(), "Message 1"))
+		require.NoError(t, session2.AddUserMessage(context.TODO(), "Other session"))
+		require.NoError(t, session1.AddAIMessage(context.TODO(), "Message 2"))
+		require.NoError(t, session1.AddMessage(context.TODO(), schema.NewSystemChatMessage("Message 3")))
+
+		messages, err := session1.Messages(context.TODO())
+		require.NoError(t, err)
+		assert.Equal(t, schema.ChatMessages{
+			schema.NewHumanChatMessage("Message 1"),
+			schema.NewAIChatMessage("Message 2"),
+			schema.NewSystemChatMessage("Message 3"),
+		}, messages)
+	})
+
+	t.Run("Restart", func(t *testing.T) {
+		restarted, err := NewSQLite(context.TODO(), db, "session1")
+		require.NoError(t, err)
+
+		messages, err := restarted.Messages(context.TODO())
+		require.NoError(t, err)
+		assert.Len(t, messages, 3)
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		require.NoError(t, session1.Clear(context.TODO()))
+
+		messages, err := session1.Messages(context.TODO())
+		require.NoError(t, err)
+		assert.Empty(t, messages)
+
+		messages, err = session2.Messages(context.TODO())
+		require.NoError(t, err)
+		assert.Equal(t, schema.ChatMessages{schema.NewHumanChatMessage("Other session")}, messages)
+	})
+
+	t.Run("InvalidTableName", func(t *testing.T) {
+		_, err := NewSQLite(context.TODO(), db, "session1", func(o *SQLOptions) {
+			o.TableName = "messages; DROP TABLE users"
+		})
+		assert.EqualError(t, err, "invalid table name: messages; DROP TABLE users")
+	})
+}
+
+func TestSQLDialect(t *testing.T) {
+	assert.Equal(t, "?", sqliteDialect.placeholder(2))
+	assert.Equal(t, "$2", postgresDialect.placeholder(2))
+}
@@ -0,0 +1,2 @@
+// Package docstore provides functionality for storing and looking up documents by id.
+package docstore
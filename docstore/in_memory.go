@@ -0,0 +1,57 @@
+package docstore
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure InMemory satisfies the DocStore interface.
+var _ schema.DocStore = (*InMemory)(nil)
+
+// InMemory represents an in-memory document store.
+type InMemory struct {
+	mu   sync.RWMutex
+	docs map[string]schema.Document
+}
+
+// NewInMemory creates a new instance of the in-memory document store.
+func NewInMemory() *InMemory {
+	return &InMemory{
+		docs: make(map[string]schema.Document),
+	}
+}
+
+// AddDocuments stores the documents under the given ids. Existing documents with the same ids are overwritten.
+func (ds *InMemory) AddDocuments(ctx context.Context, ids []string, docs []schema.Document) error {
+	if len(ids) != len(docs) {
+		return errors.New("number of ids and documents must be equal")
+	}
+
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	for i, id := range ids {
+		ds.docs[id] = docs[i]
+	}
+
+	return nil
+}
+
+// GetDocuments returns the documents stored under the given ids. Unknown ids are skipped.
+func (ds *InMemory) GetDocuments(ctx context.Context, ids []string) ([]schema.Document, error) {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+
+	docs := make([]schema.Document, 0, len(ids))
+
+	for _, id := range ids {
+		if doc, ok := ds.docs[id]; ok {
+			docs = append(docs, doc)
+		}
+	}
+
+	return docs, nil
+}
@@ -0,0 +1,32 @@
+package docstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemory(t *testing.T) {
+	t.Run("AddAndGetDocuments", func(t *testing.T) {
+		ds := NewInMemory()
+
+		err := ds.AddDocuments(context.Background(), []string{"1", "2"}, []schema.Document{
+			{PageContent: "Document 1"},
+			{PageContent: "Document 2"},
+		})
+		assert.NoError(t, err)
+
+		docs, err := ds.GetDocuments(context.Background(), []string{"2", "3", "1"})
+		assert.NoError(t, err)
+		assert.Equal(t, []schema.Document{{PageContent: "Document 2"}, {PageContent: "Document 1"}}, docs)
+	})
+
+	t.Run("MismatchedLength", func(t *testing.T) {
+		ds := NewInMemory()
+
+		err := ds.AddDocuments(context.Background(), []string{"1"}, []schema.Document{})
+		assert.Error(t, err)
+	})
+}
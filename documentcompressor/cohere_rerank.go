@@ -12,6 +12,9 @@ import (
 // Compile time check to ensure CohereRerank satisfies the DocumentCompressor interface.
 var _ schema.DocumentCompressor = (*CohereRerank)(nil)
 
+// Compile time check to ensure CohereRerank satisfies the Reranker interface.
+var _ schema.Reranker = (*CohereRerank)(nil)
+
 // CohereClient is an interface for interacting with the Cohere API.
 type CohereClient interface {
 	Rerank(ctx context.Context, request *cohere.RerankRequest, opts ...core.RequestOption) (*cohere.RerankResponse, error)
@@ -48,6 +51,31 @@ func NewCohereRank(client CohereClient, optFns ...func(o *CohereRerankOptions))
 
 // Compress compresses the input documents using Cohere Rerank.
 func (c *CohereRerank) Compress(ctx context.Context, docs []schema.Document, query string) ([]schema.Document, error) {
+	results, err := c.Rerank(ctx, query, docs)
+	if err != nil {
+		return nil, err
+	}
+
+	compressedDocs := make([]schema.Document, len(results))
+
+	for i, r := range results {
+		compressedDocs[i] = docs[r.Index]
+
+		if len(compressedDocs[i].Metadata) > 0 {
+			compressedDocs[i].Metadata["relevanceScore"] = r.RelevanceScore
+		} else {
+			compressedDocs[i].Metadata = map[string]any{
+				"relevanceScore": r.RelevanceScore,
+			}
+		}
+	}
+
+	return compressedDocs, nil
+}
+
+// Rerank scores the relevance of the documents to the query using Cohere Rerank.
+// Only the TopN most relevant documents are returned.
+func (c *CohereRerank) Rerank(ctx context.Context, query string, docs []schema.Document) ([]schema.RerankResult, error) {
 	items := make([]*cohere.RerankRequestDocumentsItem, len(docs))
 
 	for i, doc := range docs {
@@ -67,19 +95,14 @@ func (c *CohereRerank) Compress(ctx context.Context, docs []schema.Document, que
 		return nil, err
 	}
 
-	compressedDocs := make([]schema.Document, len(res.Results))
+	results := make([]schema.RerankResult, len(res.Results))
 
 	for i, r := range res.Results {
-		compressedDocs[i] = docs[r.Index]
-
-		if len(compressedDocs[i].Metadata) > 0 {
-			compressedDocs[i].Metadata["relevanceScore"] = r.RelevanceScore
-		} else {
-			compressedDocs[i].Metadata = map[string]any{
-				"relevanceScore": r.RelevanceScore,
-			}
+		results[i] = schema.RerankResult{
+			Index:          r.Index,
+			RelevanceScore: r.RelevanceScore,
 		}
 	}
 
-	return compressedDocs, nil
+	return results, nil
 }
@@ -78,6 +78,33 @@ func TestCohereRerank(t *testing.T) {
 	})
 }
 
+func TestCohereRerankReranker(t *testing.T) {
+	t.Parallel()
+
+	mockClient := &mockCohereClient{
+		rerankResponse: &cohere.RerankResponse{
+			Results: []*cohere.RerankResponseResultsItem{
+				{Index: 1, RelevanceScore: 0.9},
+				{Index: 0, RelevanceScore: 0.4},
+			},
+		},
+	}
+
+	reranker := NewCohereRank(mockClient)
+
+	docs := []schema.Document{
+		{PageContent: "Document 1"},
+		{PageContent: "Document 2"},
+	}
+
+	result, err := reranker.Rerank(context.Background(), "query", docs)
+	assert.NoError(t, err)
+	assert.Equal(t, []schema.RerankResult{
+		{Index: 1, RelevanceScore: 0.9},
+		{Index: 0, RelevanceScore: 0.4},
+	}, result)
+}
+
 // mockCohereClient is a custom mock implementation of the CohereClient interface.
 type mockCohereClient struct {
 	rerankResponse *cohere.RerankResponse
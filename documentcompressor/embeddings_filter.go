@@ -0,0 +1,98 @@
+package documentcompressor
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hupe1980/golc/metric"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure EmbeddingsFilter satisfies the DocumentCompressor interface.
+var _ schema.DocumentCompressor = (*EmbeddingsFilter)(nil)
+
+// EmbeddingsFilterOptions contains options for the EmbeddingsFilter.
+type EmbeddingsFilterOptions struct {
+	// SimilarityThreshold is the minimum cosine similarity of a document to the query.
+	SimilarityThreshold float32
+	// TopK is the maximum number of returned documents. A value <= 0 returns all documents above the threshold.
+	TopK int
+}
+
+// EmbeddingsFilter is a document compressor that removes the documents whose embeddings are not similar
+// enough to the embedding of the query. The remaining documents are sorted by their similarity.
+type EmbeddingsFilter struct {
+	embedder schema.Embedder
+	opts     EmbeddingsFilterOptions
+}
+
+// NewEmbeddingsFilter creates a new instance of EmbeddingsFilter with the provided embedder and options.
+func NewEmbeddingsFilter(embedder schema.Embedder, optFns ...func(o *EmbeddingsFilterOptions)) *EmbeddingsFilter {
+	opts := EmbeddingsFilterOptions{
+		SimilarityThreshold: 0.76,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &EmbeddingsFilter{
+		embedder: embedder,
+		opts:     opts,
+	}
+}
+
+// Compress filters the input documents by the similarity of their embeddings to the embedding of the query.
+func (c *EmbeddingsFilter) Compress(ctx context.Context, docs []schema.Document, query string) ([]schema.Document, error) {
+	if len(docs) == 0 {
+		return docs, nil
+	}
+
+	queryEmbedding, err := c.embedder.EmbedText(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	texts := make([]string, len(docs))
+	for i, d := range docs {
+		texts[i] = d.PageContent
+	}
+
+	embeddings, err := c.embedder.BatchEmbedText(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+
+	type scoredDoc struct {
+		doc        schema.Document
+		similarity float32
+	}
+
+	scored := []scoredDoc{}
+
+	for i, embedding := range embeddings {
+		similarity, err := metric.CosineSimilarity(queryEmbedding, embedding)
+		if err != nil {
+			return nil, err
+		}
+
+		if similarity >= c.opts.SimilarityThreshold {
+			scored = append(scored, scoredDoc{doc: docs[i], similarity: similarity})
+		}
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].similarity > scored[j].similarity
+	})
+
+	if c.opts.TopK > 0 && len(scored) > c.opts.TopK {
+		scored = scored[:c.opts.TopK]
+	}
+
+	compressedDocs := make([]schema.Document, len(scored))
+	for i, s := range scored {
+		compressedDocs[i] = s.doc
+	}
+
+	return compressedDocs, nil
+}
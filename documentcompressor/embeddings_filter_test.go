@@ -0,0 +1,69 @@
+package documentcompressor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbeddingsFilter(t *testing.T) {
+	t.Parallel()
+
+	embedder := &mockEmbedder{
+		embeddings: map[string][]float32{
+			"query": {1, 0},
+			"same":  {1, 0},
+			"close": {0.9, 0.1},
+			"other": {0, 1},
+		},
+	}
+
+	docs := []schema.Document{
+		{PageContent: "other"},
+		{PageContent: "close"},
+		{PageContent: "same"},
+	}
+
+	t.Run("Threshold", func(t *testing.T) {
+		t.Parallel()
+
+		filter := NewEmbeddingsFilter(embedder)
+
+		result, err := filter.Compress(context.Background(), docs, "query")
+		assert.NoError(t, err)
+		assert.Equal(t, []schema.Document{{PageContent: "same"}, {PageContent: "close"}}, result)
+	})
+
+	t.Run("TopK", func(t *testing.T) {
+		t.Parallel()
+
+		filter := NewEmbeddingsFilter(embedder, func(o *EmbeddingsFilterOptions) {
+			o.SimilarityThreshold = 0
+			o.TopK = 1
+		})
+
+		result, err := filter.Compress(context.Background(), docs, "query")
+		assert.NoError(t, err)
+		assert.Equal(t, []schema.Document{{PageContent: "same"}}, result)
+	})
+}
+
+// mockEmbedder is a mock implementation of the Embedder interface returning fixed embeddings.
+type mockEmbedder struct {
+	embeddings map[string][]float32
+}
+
+func (m *mockEmbedder) BatchEmbedText(ctx context.Context, texts []string) ([][]float32, error) {
+	embeddings := make([][]float32, len(texts))
+	for i, text := range texts {
+		embeddings[i] = m.embeddings[text]
+	}
+
+	return embeddings, nil
+}
+
+func (m *mockEmbedder) EmbedText(ctx context.Context, text string) ([]float32, error) {
+	return m.embeddings[text], nil
+}
@@ -0,0 +1,120 @@
+package documentcompressor
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/chain"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+	"golang.org/x/sync/errgroup"
+)
+
+// llmExtractorNoOutput is the output of the model for documents without relevant parts.
+const llmExtractorNoOutput = "NO_OUTPUT"
+
+const defaultLLMExtractorTemplate = `Given the following question and context, extract any part of the context *AS IS* that is relevant to answer the question. If none of the context is relevant return NO_OUTPUT.
+
+Remember, *DO NOT* edit the extracted parts of the context.
+
+> Question: {{.question}}
+> Context:
+>>>
+{{.context}}
+>>>
+Extracted relevant parts:`
+
+// Compile time check to ensure LLMExtractor satisfies the DocumentCompressor interface.
+var _ schema.DocumentCompressor = (*LLMExtractor)(nil)
+
+// LLMExtractorOptions contains options for the LLMExtractor.
+type LLMExtractorOptions struct {
+	*schema.CallbackOptions
+	// Prompt is the prompt template of the extraction with the question and context values.
+	Prompt schema.PromptTemplate
+	// MaxConcurrency is the maximum number of documents processed concurrently.
+	MaxConcurrency int
+}
+
+// LLMExtractor is a document compressor that uses a model to extract the parts of the documents
+// relevant to the query. Documents without relevant parts are removed.
+type LLMExtractor struct {
+	llmChain *chain.LLM
+	opts     LLMExtractorOptions
+}
+
+// NewLLMExtractor creates a new instance of LLMExtractor with the provided model and options.
+func NewLLMExtractor(model schema.Model, optFns ...func(o *LLMExtractorOptions)) (*LLMExtractor, error) {
+	opts := LLMExtractorOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		Prompt:         prompt.NewTemplate(defaultLLMExtractorTemplate),
+		MaxConcurrency: 5,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	llmChain, err := chain.NewLLM(model, opts.Prompt, func(o *chain.LLMOptions) {
+		o.CallbackOptions = opts.CallbackOptions
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &LLMExtractor{
+		llmChain: llmChain,
+		opts:     opts,
+	}, nil
+}
+
+// Compress extracts the parts of the input documents relevant to the query.
+func (c *LLMExtractor) Compress(ctx context.Context, docs []schema.Document, query string) ([]schema.Document, error) {
+	errs, errctx := errgroup.WithContext(ctx)
+
+	if c.opts.MaxConcurrency > 0 {
+		errs.SetLimit(c.opts.MaxConcurrency)
+	}
+
+	extracts := make([]string, len(docs))
+
+	for i, d := range docs {
+		i, d := i, d
+
+		errs.Go(func() error {
+			output, err := golc.SimpleCall(errctx, c.llmChain, schema.ChainValues{
+				"question": query,
+				"context":  d.PageContent,
+			})
+			if err != nil {
+				return err
+			}
+
+			extracts[i] = strings.TrimSpace(output)
+
+			return nil
+		})
+	}
+
+	if err := errs.Wait(); err != nil {
+		return nil, err
+	}
+
+	compressedDocs := []schema.Document{}
+
+	for i, extract := range extracts {
+		if extract == "" || extract == llmExtractorNoOutput {
+			continue
+		}
+
+		compressedDocs = append(compressedDocs, schema.Document{
+			PageContent: extract,
+			Metadata:    docs[i].Metadata,
+		})
+	}
+
+	return compressedDocs, nil
+}
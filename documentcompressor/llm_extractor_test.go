@@ -0,0 +1,41 @@
+package documentcompressor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLLMExtractor(t *testing.T) {
+	t.Parallel()
+
+	fake := llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+		text := "NO_OUTPUT"
+		if strings.Contains(prompt, "Berlin is the capital of Germany.") {
+			text = " Berlin is the capital of Germany. "
+		}
+
+		return &schema.ModelResult{
+			Generations: []schema.Generation{{Text: text}},
+			LLMOutput:   map[string]any{},
+		}, nil
+	})
+
+	extractor, err := NewLLMExtractor(fake)
+	assert.NoError(t, err)
+
+	docs := []schema.Document{
+		{PageContent: "Berlin is the capital of Germany. It has many museums.", Metadata: map[string]any{"source": "a"}},
+		{PageContent: "Paris is the capital of France.", Metadata: map[string]any{"source": "b"}},
+	}
+
+	result, err := extractor.Compress(context.Background(), docs, "What is the capital of Germany?")
+	assert.NoError(t, err)
+	assert.Equal(t, []schema.Document{
+		{PageContent: "Berlin is the capital of Germany.", Metadata: map[string]any{"source": "a"}},
+	}, result)
+}
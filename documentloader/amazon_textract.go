@@ -180,3 +180,8 @@ func (l *AmazonTextract) LoadAndSplit(ctx context.Context, splitter schema.TextS
 
 	return splitter.SplitDocuments(docs)
 }
+
+// LazyLoad returns an iterator over the documents. The documents are loaded at once, because the document is analyzed completely.
+func (l *AmazonTextract) LazyLoad(ctx context.Context) (schema.DocumentIterator, error) {
+	return lazyLoad(ctx, l.Load)
+}
@@ -0,0 +1,93 @@
+package documentloader
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Audio satisfies the DocumentLoader interface.
+var _ schema.DocumentLoader = (*Audio)(nil)
+
+// AudioOptions contains options for configuring the Audio loader.
+type AudioOptions struct {
+	// ChunkDuration is the maximum duration of the chunks of the transcript. If zero, the whole transcript
+	// is loaded as a single document.
+	ChunkDuration time.Duration
+
+	// Source is the name of the audio document
+	Source string
+}
+
+// Audio is a document loader, which transcribes audio with a speech-to-text model, e.g. a Whisper-compatible
+// model, and loads the transcript as time-stamped chunks.
+type Audio struct {
+	transcriber schema.SpeechToText
+	r           io.Reader
+	name        string
+	opts        AudioOptions
+}
+
+// NewAudio creates a new Audio loader. The name of the audio file is used to detect the audio format.
+func NewAudio(transcriber schema.SpeechToText, r io.Reader, name string, optFns ...func(o *AudioOptions)) *Audio {
+	opts := AudioOptions{
+		ChunkDuration: defaultChunkDuration,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Audio{
+		transcriber: transcriber,
+		r:           r,
+		name:        name,
+		opts:        opts,
+	}
+}
+
+// NewAudioFromFile creates a new Audio loader for the audio file.
+func NewAudioFromFile(transcriber schema.SpeechToText, f *os.File, optFns ...func(o *AudioOptions)) *Audio {
+	return NewAudio(transcriber, f, filepath.Base(f.Name()), append([]func(o *AudioOptions){func(o *AudioOptions) {
+		o.Source = f.Name()
+	}}, optFns...)...)
+}
+
+// Load transcribes the audio. The offsets of the chunks are added in seconds as start and end metadata.
+func (l *Audio) Load(ctx context.Context) ([]schema.Document, error) {
+	transcript, err := l.transcriber.Transcribe(ctx, l.r, l.name)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := map[string]any{}
+
+	if transcript.Language != "" {
+		metadata["language"] = transcript.Language
+	}
+
+	if l.opts.Source != "" {
+		metadata["source"] = l.opts.Source
+	}
+
+	return chunkTranscript(transcript, l.opts.ChunkDuration, metadata), nil
+}
+
+// LoadAndSplit transcribes the audio and splits the transcript using the specified text splitter.
+func (l *Audio) LoadAndSplit(ctx context.Context, splitter schema.TextSplitter) ([]schema.Document, error) {
+	docs, err := l.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return splitter.SplitDocuments(docs)
+}
+
+// LazyLoad returns an iterator over the documents. The documents are loaded at once, because the audio is transcribed completely.
+func (l *Audio) LazyLoad(ctx context.Context) (schema.DocumentIterator, error) {
+	return lazyLoad(ctx, l.Load)
+}
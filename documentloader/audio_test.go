@@ -0,0 +1,58 @@
+package documentloader
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAudio(t *testing.T) {
+	transcriber := &mockSpeechToText{
+		transcript: &schema.Transcript{
+			Text:     "Hello world",
+			Language: "english",
+			Segments: []schema.TranscriptSegment{
+				{Start: 0, End: 2 * time.Second, Text: "Hello"},
+				{Start: 2 * time.Second, End: 4 * time.Second, Text: "world"},
+			},
+		},
+	}
+
+	loader := NewAudio(transcriber, strings.NewReader("audio"), "talk.mp3", func(o *AudioOptions) {
+		o.ChunkDuration = 3 * time.Second
+		o.Source = "talks/talk.mp3"
+	})
+
+	docs, err := loader.Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []schema.Document{
+		{PageContent: "Hello", Metadata: map[string]any{"language": "english", "source": "talks/talk.mp3", "start": 0.0, "end": 2.0}},
+		{PageContent: "world", Metadata: map[string]any{"language": "english", "source": "talks/talk.mp3", "start": 2.0, "end": 4.0}},
+	}, docs)
+	assert.Equal(t, "talk.mp3", transcriber.name)
+	assert.Equal(t, "audio", transcriber.audio)
+}
+
+type mockSpeechToText struct {
+	transcript *schema.Transcript
+	name       string
+	audio      string
+}
+
+func (m *mockSpeechToText) Transcribe(ctx context.Context, audio io.Reader, name string) (*schema.Transcript, error) {
+	data, err := io.ReadAll(audio)
+	if err != nil {
+		return nil, err
+	}
+
+	m.name = name
+	m.audio = string(data)
+
+	return m.transcript, nil
+}
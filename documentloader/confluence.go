@@ -0,0 +1,136 @@
+package documentloader
+
+import (
+	"context"
+	"io"
+
+	"github.com/hupe1980/golc/integration/confluence"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Confluence satisfies the DocumentLoader interface.
+var _ schema.DocumentLoader = (*Confluence)(nil)
+
+// ConfluenceClient is an interface representing the methods required for loading pages from Confluence.
+type ConfluenceClient interface {
+	// GetPages returns the pages of a space with their content in the storage format.
+	GetPages(ctx context.Context, req *confluence.GetPagesRequest) (*confluence.GetPagesResponse, error)
+}
+
+// ConfluenceOptions contains options for configuring the Confluence loader.
+type ConfluenceOptions struct {
+	// PageSize is the number of pages requested at once.
+	PageSize int
+
+	// MaxPages is the maximum number of pages to load. If zero, all pages of the space are loaded.
+	MaxPages int
+}
+
+// Confluence is a document loader, which loads the pages of a Confluence space. The content of the pages
+// is converted to Markdown. The page URL and the last-edited timestamp are added to the metadata.
+type Confluence struct {
+	client   ConfluenceClient
+	spaceKey string
+	opts     ConfluenceOptions
+}
+
+// NewConfluence creates a new Confluence loader for the space with the key.
+func NewConfluence(client ConfluenceClient, spaceKey string, optFns ...func(o *ConfluenceOptions)) *Confluence {
+	opts := ConfluenceOptions{
+		PageSize: 25,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Confluence{
+		client:   client,
+		spaceKey: spaceKey,
+		opts:     opts,
+	}
+}
+
+// Load loads the pages of the space.
+func (l *Confluence) Load(ctx context.Context) ([]schema.Document, error) {
+	it, err := l.LazyLoad(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ReadAll(ctx, it)
+}
+
+// LazyLoad returns an iterator over the pages of the space. The pages are requested page by page, when
+// the previous pages are consumed.
+func (l *Confluence) LazyLoad(ctx context.Context) (schema.DocumentIterator, error) {
+	var (
+		pages   []confluence.Page
+		base    string
+		start   int
+		loaded  int
+		hasMore = true
+	)
+
+	return newDocumentIterator(func(ctx context.Context) (schema.Document, error) {
+		if l.opts.MaxPages > 0 && loaded >= l.opts.MaxPages {
+			return schema.Document{}, io.EOF
+		}
+
+		if len(pages) == 0 {
+			if !hasMore {
+				return schema.Document{}, io.EOF
+			}
+
+			res, err := l.client.GetPages(ctx, &confluence.GetPagesRequest{
+				SpaceKey: l.spaceKey,
+				Start:    start,
+				Limit:    l.opts.PageSize,
+			})
+			if err != nil {
+				return schema.Document{}, err
+			}
+
+			if len(res.Results) == 0 {
+				return schema.Document{}, io.EOF
+			}
+
+			pages, base, hasMore = res.Results, res.Links.Base, res.HasMore()
+			start += len(res.Results)
+		}
+
+		page := pages[0]
+		pages = pages[1:]
+		loaded++
+
+		content, err := htmlToMarkdown(page.Body.Storage.Value)
+		if err != nil {
+			return schema.Document{}, err
+		}
+
+		url := base + page.Links.WebUI
+
+		return schema.Document{
+			PageContent: content,
+			Metadata: map[string]any{
+				"id":          page.ID,
+				"title":       page.Title,
+				"space":       page.Space.Key,
+				"version":     page.Version.Number,
+				"last_edited": page.Version.When,
+				"url":         url,
+				"source":      url,
+			},
+		}, nil
+	}, nil), nil
+}
+
+// LoadAndSplit loads the pages of the space and splits them using the specified text splitter.
+func (l *Confluence) LoadAndSplit(ctx context.Context, splitter schema.TextSplitter) ([]schema.Document, error) {
+	docs, err := l.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return splitter.SplitDocuments(docs)
+}
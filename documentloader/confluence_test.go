@@ -0,0 +1,98 @@
+package documentloader
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc/integration/confluence"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfluence(t *testing.T) {
+	client := &mockConfluenceClient{
+		responses: []*confluence.GetPagesResponse{
+			{
+				Results: []confluence.Page{
+					newConfluencePage("1", "Home", "<h1>Welcome</h1><p>Hello <strong>world</strong></p>"),
+					newConfluencePage("2", "Setup", "<ul><li>Install</li><li>Run</li></ul>"),
+				},
+				Links: confluence.Links{Base: "https://example.atlassian.net/wiki", Next: "/rest/api/content?start=2"},
+			},
+			{
+				Results: []confluence.Page{
+					newConfluencePage("3", "FAQ", "<p>Questions</p>"),
+				},
+				Links: confluence.Links{Base: "https://example.atlassian.net/wiki"},
+			},
+		},
+	}
+
+	t.Run("Load", func(t *testing.T) {
+		client.requests = nil
+
+		loader := NewConfluence(client, "DOCS", func(o *ConfluenceOptions) {
+			o.PageSize = 2
+		})
+
+		docs, err := loader.Load(context.Background())
+		require.NoError(t, err)
+		require.Len(t, docs, 3)
+
+		assert.Equal(t, schema.Document{
+			PageContent: "# Welcome\n\nHello **world**",
+			Metadata: map[string]any{
+				"id":          "1",
+				"title":       "Home",
+				"space":       "DOCS",
+				"version":     2,
+				"last_edited": "2024-01-02T10:00:00.000Z",
+				"url":         "https://example.atlassian.net/wiki/spaces/DOCS/pages/1",
+				"source":      "https://example.atlassian.net/wiki/spaces/DOCS/pages/1",
+			},
+		}, docs[0])
+		assert.Equal(t, "- Install\n- Run", docs[1].PageContent)
+		assert.Equal(t, "FAQ", docs[2].Metadata["title"])
+
+		assert.Equal(t, []confluence.GetPagesRequest{
+			{SpaceKey: "DOCS", Start: 0, Limit: 2},
+			{SpaceKey: "DOCS", Start: 2, Limit: 2},
+		}, client.requests)
+	})
+
+	t.Run("MaxPages", func(t *testing.T) {
+		client.requests = nil
+
+		loader := NewConfluence(client, "DOCS", func(o *ConfluenceOptions) {
+			o.MaxPages = 1
+		})
+
+		docs, err := loader.Load(context.Background())
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Len(t, client.requests, 1)
+	})
+}
+
+func newConfluencePage(id, title, body string) confluence.Page {
+	return confluence.Page{
+		ID:      id,
+		Title:   title,
+		Space:   confluence.Space{Key: "DOCS"},
+		Version: confluence.Version{Number: 2, When: "2024-01-02T10:00:00.000Z"},
+		Body:    confluence.Body{Storage: confluence.Storage{Value: body}},
+		Links:   confluence.Links{WebUI: "/spaces/DOCS/pages/" + id},
+	}
+}
+
+type mockConfluenceClient struct {
+	responses []*confluence.GetPagesResponse
+	requests  []confluence.GetPagesRequest
+}
+
+func (m *mockConfluenceClient) GetPages(ctx context.Context, req *confluence.GetPagesRequest) (*confluence.GetPagesResponse, error) {
+	m.requests = append(m.requests, *req)
+
+	return m.responses[len(m.requests)-1], nil
+}
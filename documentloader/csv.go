@@ -24,6 +24,13 @@ type CSVOptions struct {
 
 	// Columns is a list of column names to filter and include in the loaded documents.
 	Columns []string
+
+	// MetadataColumns is a list of column names, whose values are added to the metadata of the documents
+	// instead of the content, e.g. ids or timestamps.
+	MetadataColumns []string
+
+	// Source is the name of the csv document
+	Source string
 }
 
 // CSV represents a CSV document loader.
@@ -52,38 +59,52 @@ func NewCSV(r io.Reader, optFns ...func(o *CSVOptions)) *CSV {
 
 // Load loads CSV documents from the provided reader.
 func (l *CSV) Load(ctx context.Context) ([]schema.Document, error) {
-	var (
-		header []string
-		docs   []schema.Document
-		rown   uint
-	)
+	it, err := l.LazyLoad(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ReadAll(ctx, it)
+}
 
+// LazyLoad returns an iterator, which reads the rows of the CSV file on demand.
+func (l *CSV) LazyLoad(ctx context.Context) (schema.DocumentIterator, error) {
 	reader := csv.NewReader(l.r)
 	reader.Comma = l.opts.Separator
 	reader.LazyQuotes = l.opts.LazyQuotes
 
-	isHeader := true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return NewDocumentIterator(nil), nil
+	}
 
-	for {
-		row, err := reader.Read()
-		if err == io.EOF {
-			break
-		}
+	if err != nil {
+		return nil, err
+	}
 
+	var rown uint
+
+	return newDocumentIterator(func(ctx context.Context) (schema.Document, error) {
+		row, err := reader.Read()
 		if err != nil {
-			return nil, err
+			return schema.Document{}, err
 		}
 
-		if isHeader {
-			header = row
-			isHeader = false
+		var content []string
 
-			continue
-		}
+		rown++
+		metadata := map[string]any{"row": rown}
 
-		var content []string
+		if l.opts.Source != "" {
+			metadata["source"] = l.opts.Source
+		}
 
 		for i, value := range row {
+			if util.Contains(l.opts.MetadataColumns, header[i]) {
+				metadata[header[i]] = value
+				continue
+			}
+
 			if len(l.opts.Columns) > 0 && !util.Contains(l.opts.Columns, header[i]) {
 				continue
 			}
@@ -92,14 +113,11 @@ func (l *CSV) Load(ctx context.Context) ([]schema.Document, error) {
 			content = append(content, line)
 		}
 
-		rown++
-		docs = append(docs, schema.Document{
+		return schema.Document{
 			PageContent: strings.Join(content, "\n"),
-			Metadata:    map[string]any{"row": rown},
-		})
-	}
-
-	return docs, nil
+			Metadata:    metadata,
+		}, nil
+	}, nil), nil
 }
 
 // LoadAndSplit loads CSV documents from the provided reader and splits them using the specified text splitter.
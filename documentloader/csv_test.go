@@ -2,6 +2,7 @@ package documentloader
 
 import (
 	"context"
+	"io"
 	"strings"
 	"testing"
 
@@ -70,3 +71,46 @@ func TestCSV(t *testing.T) {
 		assert.ElementsMatch(t, expectedLoadWithFilter, docsLoadWithFilter)
 	})
 }
+
+func TestCSVMetadataColumns(t *testing.T) {
+	csvData := `id,title,body,created
+1,Hello,First post,2024-01-01
+2,World,Second post,2024-01-02`
+
+	loader := NewCSV(strings.NewReader(csvData), func(o *CSVOptions) {
+		o.Columns = []string{"title", "body"}
+		o.MetadataColumns = []string{"id", "created"}
+		o.Source = "posts.csv"
+	})
+
+	docs, err := loader.Load(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []schema.Document{
+		{
+			PageContent: "title: Hello\nbody: First post",
+			Metadata:    map[string]any{"row": uint(1), "id": "1", "created": "2024-01-01", "source": "posts.csv"},
+		},
+		{
+			PageContent: "title: World\nbody: Second post",
+			Metadata:    map[string]any{"row": uint(2), "id": "2", "created": "2024-01-02", "source": "posts.csv"},
+		},
+	}, docs)
+}
+
+func TestCSVLazyLoad(t *testing.T) {
+	loader := NewCSV(strings.NewReader("name,age\nAlice,30\nBob,25"))
+
+	it, err := loader.LazyLoad(context.Background())
+	assert.NoError(t, err)
+
+	doc, err := it.Next(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "name: Alice\nage: 30", doc.PageContent)
+
+	doc, err = it.Next(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), doc.Metadata["row"])
+
+	_, err = it.Next(context.Background())
+	assert.ErrorIs(t, err, io.EOF)
+}
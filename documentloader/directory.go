@@ -0,0 +1,387 @@
+package documentloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Directory satisfies the DocumentLoader interface.
+var _ schema.DocumentLoader = (*Directory)(nil)
+
+// FileLoaderFunc creates a document loader for the opened file.
+type FileLoaderFunc func(f *os.File) (schema.DocumentLoader, error)
+
+// DefaultFileLoaders contains the file loaders for the supported file extensions.
+var DefaultFileLoaders = map[string]FileLoaderFunc{
+	".txt": func(f *os.File) (schema.DocumentLoader, error) {
+		return NewText(f), nil
+	},
+	".md": func(f *os.File) (schema.DocumentLoader, error) {
+		return NewText(f), nil
+	},
+	".csv": func(f *os.File) (schema.DocumentLoader, error) {
+		return NewCSV(f), nil
+	},
+	".json": func(f *os.File) (schema.DocumentLoader, error) {
+		return NewJSON(f, func(o *JSONOptions) { o.Source = f.Name() }), nil
+	},
+	".jsonl": func(f *os.File) (schema.DocumentLoader, error) {
+		return NewJSONL(f, func(o *JSONOptions) { o.Source = f.Name() }), nil
+	},
+	".eml": func(f *os.File) (schema.DocumentLoader, error) {
+		return NewEmail(f, func(o *EmailOptions) { o.Source = f.Name() }), nil
+	},
+	".mbox": func(f *os.File) (schema.DocumentLoader, error) {
+		return NewMbox(f, func(o *EmailOptions) { o.Source = f.Name() }), nil
+	},
+	".html": func(f *os.File) (schema.DocumentLoader, error) {
+		return NewHTML(f), nil
+	},
+	".ipynb": func(f *os.File) (schema.DocumentLoader, error) {
+		return NewNotebook(f), nil
+	},
+	".pdf": func(f *os.File) (schema.DocumentLoader, error) {
+		return NewPDFFromFile(f)
+	},
+	".docx": func(f *os.File) (schema.DocumentLoader, error) {
+		return NewDOCXFromFile(f)
+	},
+	".pptx": func(f *os.File) (schema.DocumentLoader, error) {
+		return NewPPTXFromFile(f)
+	},
+	".xlsx": func(f *os.File) (schema.DocumentLoader, error) {
+		return NewXLSXFromFile(f)
+	},
+}
+
+// DirectoryOptions contains options for configuring the Directory loader.
+type DirectoryOptions struct {
+	// Glob contains the patterns of the files to load, relative to the root directory and separated by
+	// slashes. A ** matches any number of directories. If empty, all files are matched.
+	Glob []string
+
+	// Exclude contains the patterns of the files to skip, with the same syntax as Glob.
+	Exclude []string
+
+	// Loaders contains the file loaders by file extension, including the leading dot.
+	Loaders map[string]FileLoaderFunc
+
+	// DefaultLoader is the file loader for files without a loader for their extension. If nil, these
+	// files are skipped.
+	DefaultLoader FileLoaderFunc
+
+	// MaxConcurrency is the maximum number of files loaded concurrently.
+	MaxConcurrency int
+
+	// SkipErrors skips files, which cannot be loaded, instead of returning the error.
+	SkipErrors bool
+}
+
+// Directory is a document loader, which walks a directory tree and loads the matching files with the
+// loaders registered for their extensions. The path of a file relative to the root directory is added
+// as path metadata to its documents.
+type Directory struct {
+	root string
+	opts DirectoryOptions
+}
+
+// NewDirectory creates a new Directory loader for the root directory.
+func NewDirectory(root string, optFns ...func(o *DirectoryOptions)) *Directory {
+	opts := DirectoryOptions{
+		Loaders:        DefaultFileLoaders,
+		MaxConcurrency: runtime.NumCPU(),
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.MaxConcurrency < 1 {
+		opts.MaxConcurrency = 1
+	}
+
+	return &Directory{
+		root: root,
+		opts: opts,
+	}
+}
+
+// Load loads the documents of the matching files. The documents are ordered by the paths of the files.
+func (l *Directory) Load(ctx context.Context) ([]schema.Document, error) {
+	paths, err := l.matchingPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]schema.Document, len(paths))
+
+	errs, errctx := errgroup.WithContext(ctx)
+	errs.SetLimit(l.opts.MaxConcurrency)
+
+	for i, p := range paths {
+		i, p := i, p
+
+		errs.Go(func() error {
+			docs, err := l.loadFile(errctx, p)
+			if err != nil {
+				if l.opts.SkipErrors {
+					return nil
+				}
+
+				return fmt.Errorf("cannot load %s: %w", p, err)
+			}
+
+			results[i] = docs
+
+			return nil
+		})
+	}
+
+	if err := errs.Wait(); err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, 0, len(paths))
+	for _, r := range results {
+		docs = append(docs, r...)
+	}
+
+	return docs, nil
+}
+
+// LoadAndSplit loads the documents of the matching files and splits them using the specified text splitter.
+func (l *Directory) LoadAndSplit(ctx context.Context, splitter schema.TextSplitter) ([]schema.Document, error) {
+	docs, err := l.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return splitter.SplitDocuments(docs)
+}
+
+// LazyLoad returns an iterator, which loads the matching files one after another in the order of their
+// paths. Only the documents of the current file are held in memory.
+func (l *Directory) LazyLoad(ctx context.Context) (schema.DocumentIterator, error) {
+	paths, err := l.matchingPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		current schema.DocumentIterator
+		rel     string
+	)
+
+	closeCurrent := func() error {
+		if current == nil {
+			return nil
+		}
+
+		err := current.Close()
+		current = nil
+
+		return err
+	}
+
+	return newDocumentIterator(func(ctx context.Context) (schema.Document, error) {
+		for {
+			if current == nil {
+				if len(paths) == 0 {
+					return schema.Document{}, io.EOF
+				}
+
+				rel, paths = paths[0], paths[1:]
+
+				it, err := l.openFile(ctx, rel)
+				if err != nil {
+					if l.opts.SkipErrors {
+						continue
+					}
+
+					return schema.Document{}, fmt.Errorf("cannot load %s: %w", rel, err)
+				}
+
+				current = it
+			}
+
+			doc, err := current.Next(ctx)
+			if err == nil {
+				return doc, nil
+			}
+
+			if cerr := closeCurrent(); cerr != nil && errors.Is(err, io.EOF) {
+				err = cerr
+			}
+
+			if errors.Is(err, io.EOF) || l.opts.SkipErrors {
+				continue
+			}
+
+			return schema.Document{}, fmt.Errorf("cannot load %s: %w", rel, err)
+		}
+	}, closeCurrent), nil
+}
+
+// matchingPaths returns the slash-separated paths of the matching files relative to the root directory,
+// which have a loader.
+func (l *Directory) matchingPaths() ([]string, error) {
+	paths := []string{}
+
+	err := filepath.WalkDir(l.root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(l.root, p)
+		if err != nil {
+			return err
+		}
+
+		rel = filepath.ToSlash(rel)
+
+		if len(l.opts.Glob) > 0 && !matchAnyGlob(l.opts.Glob, rel) {
+			return nil
+		}
+
+		if matchAnyGlob(l.opts.Exclude, rel) {
+			return nil
+		}
+
+		if l.loaderFor(rel) == nil {
+			return nil
+		}
+
+		paths = append(paths, rel)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return paths, nil
+}
+
+// loaderFor returns the file loader for the extension of the path or the default loader.
+func (l *Directory) loaderFor(p string) FileLoaderFunc {
+	if loader, ok := l.opts.Loaders[strings.ToLower(path.Ext(p))]; ok {
+		return loader
+	}
+
+	return l.opts.DefaultLoader
+}
+
+// loadFile loads the documents of the file and adds the relative path and the source to their metadata.
+func (l *Directory) loadFile(ctx context.Context, rel string) ([]schema.Document, error) {
+	it, err := l.openFile(ctx, rel)
+	if err != nil {
+		return nil, err
+	}
+
+	return ReadAll(ctx, it)
+}
+
+// openFile opens the file and returns an iterator over its documents, which adds the relative path and
+// the source to their metadata. Closing the iterator closes the file.
+func (l *Directory) openFile(ctx context.Context, rel string) (schema.DocumentIterator, error) {
+	source := filepath.Join(l.root, filepath.FromSlash(rel))
+
+	f, err := os.Open(source)
+	if err != nil {
+		return nil, err
+	}
+
+	loader, err := l.loaderFor(rel)(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	it, err := loader.LazyLoad(ctx)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return newDocumentIterator(func(ctx context.Context) (schema.Document, error) {
+		doc, err := it.Next(ctx)
+		if err != nil {
+			return schema.Document{}, err
+		}
+
+		if doc.Metadata == nil {
+			doc.Metadata = map[string]any{}
+		}
+
+		doc.Metadata["path"] = rel
+
+		if _, ok := doc.Metadata["source"]; !ok {
+			doc.Metadata["source"] = source
+		}
+
+		return doc, nil
+	}, func() error {
+		err := it.Close()
+
+		if ferr := f.Close(); err == nil {
+			err = ferr
+		}
+
+		return err
+	}), nil
+}
+
+// matchAnyGlob reports whether the slash-separated path matches any of the patterns.
+func matchAnyGlob(patterns []string, p string) bool {
+	for _, pattern := range patterns {
+		if matchGlob(strings.Split(pattern, "/"), strings.Split(p, "/")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchGlob reports whether the path segments match the pattern segments. A ** segment matches any
+// number of path segments, the other segments are matched with path.Match.
+func matchGlob(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchGlob(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+
+	if ok, err := path.Match(pattern[0], segments[0]); err != nil || !ok {
+		return false
+	}
+
+	return matchGlob(pattern[1:], segments[1:])
+}
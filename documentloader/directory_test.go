@@ -0,0 +1,139 @@
+package documentloader
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+func TestDirectory(t *testing.T) {
+	root := t.TempDir()
+
+	files := map[string]string{
+		"README.md":              "# Readme",
+		"docs/guide.txt":         "Guide",
+		"docs/api/reference.txt": "Reference",
+		"data/users.csv":         "name,age\nAlice,30",
+		"vendor/lib.txt":         "Vendor",
+		"image.png":              "binary",
+	}
+
+	for name, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0700))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0600))
+	}
+
+	t.Run("Load", func(t *testing.T) {
+		docs, err := NewDirectory(root, func(o *DirectoryOptions) {
+			o.Exclude = []string{"vendor/**"}
+		}).Load(context.Background())
+		require.NoError(t, err)
+
+		require.Equal(t, []schema.Document{
+			{PageContent: "# Readme", Metadata: map[string]any{"path": "README.md", "source": filepath.Join(root, "README.md")}},
+			{PageContent: "name: Alice\nage: 30", Metadata: map[string]any{"row": uint(1), "path": "data/users.csv", "source": filepath.Join(root, "data", "users.csv")}},
+			{PageContent: "Reference", Metadata: map[string]any{"path": "docs/api/reference.txt", "source": filepath.Join(root, "docs", "api", "reference.txt")}},
+			{PageContent: "Guide", Metadata: map[string]any{"path": "docs/guide.txt", "source": filepath.Join(root, "docs", "guide.txt")}},
+		}, docs)
+	})
+
+	t.Run("LazyLoad", func(t *testing.T) {
+		it, err := NewDirectory(root, func(o *DirectoryOptions) {
+			o.Exclude = []string{"vendor/**"}
+		}).LazyLoad(context.Background())
+		require.NoError(t, err)
+
+		doc, err := it.Next(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "README.md", doc.Metadata["path"])
+
+		docs, err := ReadAll(context.Background(), it)
+		require.NoError(t, err)
+		require.Len(t, docs, 3)
+		require.Equal(t, "data/users.csv", docs[0].Metadata["path"])
+
+		_, err = it.Next(context.Background())
+		require.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("Glob", func(t *testing.T) {
+		docs, err := NewDirectory(root, func(o *DirectoryOptions) {
+			o.Glob = []string{"docs/**/*.txt"}
+		}).Load(context.Background())
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+		require.Equal(t, "docs/api/reference.txt", docs[0].Metadata["path"])
+		require.Equal(t, "docs/guide.txt", docs[1].Metadata["path"])
+	})
+
+	t.Run("DefaultLoader", func(t *testing.T) {
+		docs, err := NewDirectory(root, func(o *DirectoryOptions) {
+			o.Glob = []string{"*.png"}
+			o.DefaultLoader = func(f *os.File) (schema.DocumentLoader, error) {
+				return NewText(f), nil
+			}
+		}).Load(context.Background())
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		require.Equal(t, "binary", docs[0].PageContent)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		failing := func(o *DirectoryOptions) {
+			o.Loaders = map[string]FileLoaderFunc{
+				".txt": func(f *os.File) (schema.DocumentLoader, error) {
+					return nil, errors.New("failed")
+				},
+				".md": DefaultFileLoaders[".md"],
+			}
+		}
+
+		_, err := NewDirectory(root, failing).Load(context.Background())
+		require.ErrorContains(t, err, "failed")
+
+		docs, err := NewDirectory(root, failing, func(o *DirectoryOptions) {
+			o.SkipErrors = true
+		}).Load(context.Background())
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		require.Equal(t, "README.md", docs[0].Metadata["path"])
+
+		it, err := NewDirectory(root, failing, func(o *DirectoryOptions) {
+			o.SkipErrors = true
+		}).LazyLoad(context.Background())
+		require.NoError(t, err)
+
+		docs, err = ReadAll(context.Background(), it)
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+	})
+}
+
+func TestMatchAnyGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "cmd/main.go", false},
+		{"**/*.go", "main.go", true},
+		{"**/*.go", "cmd/app/main.go", true},
+		{"cmd/**", "cmd/app/main.go", true},
+		{"docs/**/*.md", "docs/a/b/c.md", true},
+		{"docs/**/*.md", "src/c.md", false},
+		{"[", "a", false},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.match, matchAnyGlob([]string{test.pattern}, test.path), "%s %s", test.pattern, test.path)
+	}
+}
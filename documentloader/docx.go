@@ -1,6 +1,7 @@
 package documentloader
 
 import (
+	"archive/zip"
 	"context"
 	"io"
 	"os"
@@ -140,3 +141,112 @@ func (l *UniDocDOCX) LoadAndSplit(ctx context.Context, splitter schema.TextSplit
 
 	return splitter.SplitDocuments(docs)
 }
+
+// LazyLoad returns an iterator over the documents. The documents are loaded at once, because the document is read completely.
+func (l *UniDocDOCX) LazyLoad(ctx context.Context) (schema.DocumentIterator, error) {
+	return lazyLoad(ctx, l.Load)
+}
+
+// Compile time check to ensure DOCX satisfies the DocumentLoader interface.
+var _ schema.DocumentLoader = (*DOCX)(nil)
+
+// DOCXOptions contains options for configuring the DOCX loader.
+type DOCXOptions struct {
+	// IgnoreTables skips the contents of tables.
+	IgnoreTables bool
+
+	// Source is the name of the docx document
+	Source string
+}
+
+// DOCX is a document loader for DOCX files. Unlike UniDocDOCX, it reads the document without external
+// dependencies. Tables are rendered as CSV.
+type DOCX struct {
+	r    io.ReaderAt
+	size int64
+	opts DOCXOptions
+}
+
+// NewDOCX creates a new DOCX loader with the given reader and size of the document.
+func NewDOCX(r io.ReaderAt, size int64, optFns ...func(o *DOCXOptions)) *DOCX {
+	opts := DOCXOptions{
+		IgnoreTables: false,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &DOCX{
+		r:    r,
+		size: size,
+		opts: opts,
+	}
+}
+
+// NewDOCXFromFile creates a new DOCX loader with the given file.
+func NewDOCXFromFile(f *os.File, optFns ...func(o *DOCXOptions)) (*DOCX, error) {
+	opts := DOCXOptions{
+		IgnoreTables: false,
+		Source:       f.Name(),
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	finfo, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDOCX(f, finfo.Size(), func(o *DOCXOptions) {
+		*o = opts
+	}), nil
+}
+
+// Load reads the document and returns its paragraphs and tables as a single document.
+func (l *DOCX) Load(ctx context.Context) ([]schema.Document, error) {
+	zr, err := zip.NewReader(l.r, l.size)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := openZipFile(zr, "word/document.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	text, err := extractOfficeText(f, l.opts.IgnoreTables)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := schema.Document{
+		PageContent: text,
+		Metadata:    map[string]any{},
+	}
+
+	if l.opts.Source != "" {
+		doc.Metadata["source"] = l.opts.Source
+	}
+
+	return []schema.Document{doc}, nil
+}
+
+// LoadAndSplit loads the DOCX document and splits it using the specified text splitter.
+func (l *DOCX) LoadAndSplit(ctx context.Context, splitter schema.TextSplitter) ([]schema.Document, error) {
+	docs, err := l.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return splitter.SplitDocuments(docs)
+}
+
+// LazyLoad returns an iterator over the documents. The documents are loaded at once, because the document is read completely.
+func (l *DOCX) LazyLoad(ctx context.Context) (schema.DocumentIterator, error) {
+	return lazyLoad(ctx, l.Load)
+}
@@ -0,0 +1,359 @@
+package documentloader
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html/charset"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Email satisfies the DocumentLoader interface.
+var _ schema.DocumentLoader = (*Email)(nil)
+
+// EmailOptions contains options for configuring the email loaders.
+type EmailOptions struct {
+	// PreferHTML uses the HTML body instead of the plain text body, if a message has both. HTML bodies are
+	// converted to Markdown.
+	PreferHTML bool
+
+	// Source is the name of the email document
+	Source string
+}
+
+// Email is a document loader, which loads a single RFC 5322 message, e.g. an .eml file. The body of the
+// message becomes the content of the document; the headers and the attachments are added to the metadata.
+type Email struct {
+	r    io.Reader
+	opts EmailOptions
+}
+
+// NewEmail creates a new Email loader with an io.Reader and optional configuration options.
+func NewEmail(r io.Reader, optFns ...func(o *EmailOptions)) *Email {
+	opts := EmailOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Email{
+		r:    r,
+		opts: opts,
+	}
+}
+
+// Load loads the message from the reader.
+func (l *Email) Load(ctx context.Context) ([]schema.Document, error) {
+	doc, err := parseEmail(l.r, l.opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return []schema.Document{doc}, nil
+}
+
+// LoadAndSplit loads the message from the reader and splits it using the specified text splitter.
+func (l *Email) LoadAndSplit(ctx context.Context, splitter schema.TextSplitter) ([]schema.Document, error) {
+	docs, err := l.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return splitter.SplitDocuments(docs)
+}
+
+// LazyLoad returns an iterator over the document of the message.
+func (l *Email) LazyLoad(ctx context.Context) (schema.DocumentIterator, error) {
+	return lazyLoad(ctx, l.Load)
+}
+
+// Compile time check to ensure Mbox satisfies the DocumentLoader interface.
+var _ schema.DocumentLoader = (*Mbox)(nil)
+
+// Mbox is a document loader, which loads the messages of an mbox file. A document is created for each message.
+type Mbox struct {
+	r    io.Reader
+	opts EmailOptions
+}
+
+// NewMbox creates a new Mbox loader with an io.Reader and optional configuration options.
+func NewMbox(r io.Reader, optFns ...func(o *EmailOptions)) *Mbox {
+	opts := EmailOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Mbox{
+		r:    r,
+		opts: opts,
+	}
+}
+
+// Load loads the messages from the reader.
+func (l *Mbox) Load(ctx context.Context) ([]schema.Document, error) {
+	it, err := l.LazyLoad(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ReadAll(ctx, it)
+}
+
+// LazyLoad returns an iterator, which reads the messages on demand. Messages are separated by lines
+// starting with "From ", and lines escaped as ">From " are unescaped.
+func (l *Mbox) LazyLoad(ctx context.Context) (schema.DocumentIterator, error) {
+	r := bufio.NewReader(l.r)
+
+	// Skip the lines before the first message.
+	started := false
+	eof := false
+
+	return newDocumentIterator(func(ctx context.Context) (schema.Document, error) {
+		var message *bytes.Buffer
+
+		if started {
+			message = new(bytes.Buffer)
+		}
+
+		for !eof {
+			line, err := r.ReadString('\n')
+			if err != nil && err != io.EOF {
+				return schema.Document{}, err
+			}
+
+			eof = err == io.EOF
+
+			if strings.HasPrefix(line, "From ") {
+				if message != nil {
+					return parseEmail(message, l.opts)
+				}
+
+				started = true
+				message = new(bytes.Buffer)
+
+				continue
+			}
+
+			if message != nil {
+				if strings.HasPrefix(strings.TrimLeft(line, ">"), "From ") {
+					line = line[1:]
+				}
+
+				message.WriteString(line)
+			}
+		}
+
+		if message == nil {
+			return schema.Document{}, io.EOF
+		}
+
+		started = false
+
+		return parseEmail(message, l.opts)
+	}, nil), nil
+}
+
+// LoadAndSplit loads the messages from the reader and splits them using the specified text splitter.
+func (l *Mbox) LoadAndSplit(ctx context.Context, splitter schema.TextSplitter) ([]schema.Document, error) {
+	docs, err := l.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return splitter.SplitDocuments(docs)
+}
+
+// emailContent contains the bodies and attachments of a message.
+type emailContent struct {
+	plain       []string
+	html        []string
+	attachments []map[string]any
+}
+
+// emailWordDecoder decodes encoded words of headers, e.g. =?UTF-8?Q?...?=.
+var emailWordDecoder = &mime.WordDecoder{CharsetReader: charset.NewReaderLabel}
+
+// parseEmail parses the message into a document with the body as content and the headers and attachments
+// as metadata.
+func parseEmail(r io.Reader, opts EmailOptions) (schema.Document, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return schema.Document{}, err
+	}
+
+	content := &emailContent{attachments: []map[string]any{}}
+	if err := parseEmailPart(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Header.Get("Content-Disposition"), msg.Body, content); err != nil {
+		return schema.Document{}, err
+	}
+
+	body := strings.Join(content.plain, "\n\n")
+
+	if len(content.html) > 0 && (body == "" || opts.PreferHTML) {
+		body, err = htmlToMarkdown(strings.Join(content.html, "\n"))
+		if err != nil {
+			return schema.Document{}, err
+		}
+	}
+
+	metadata := map[string]any{
+		"subject":     decodeEmailHeader(msg.Header.Get("Subject")),
+		"from":        decodeEmailHeader(msg.Header.Get("From")),
+		"message_id":  strings.Trim(msg.Header.Get("Message-Id"), "<>"),
+		"attachments": content.attachments,
+	}
+
+	for _, key := range []string{"To", "Cc"} {
+		if addresses := decodeEmailAddresses(msg.Header, key); len(addresses) > 0 {
+			metadata[strings.ToLower(key)] = addresses
+		}
+	}
+
+	if date, err := msg.Header.Date(); err == nil {
+		metadata["date"] = date.UTC().Format(time.RFC3339)
+	}
+
+	if inReplyTo := msg.Header.Get("In-Reply-To"); inReplyTo != "" {
+		metadata["in_reply_to"] = strings.Trim(inReplyTo, "<>")
+	}
+
+	if opts.Source != "" {
+		metadata["source"] = opts.Source
+	}
+
+	return schema.Document{
+		PageContent: strings.TrimSpace(body),
+		Metadata:    metadata,
+	}, nil
+}
+
+// parseEmailPart collects the bodies and attachments of the MIME part and its nested parts.
+func parseEmailPart(contentType, transferEncoding, disposition string, body io.Reader, content *emailContent) error {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, params["boundary"])
+
+		for {
+			part, err := mr.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if err := parseEmailPart(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part.Header.Get("Content-Disposition"), part, content); err != nil {
+				return err
+			}
+		}
+	}
+
+	dispositionType, dispositionParams, _ := mime.ParseMediaType(disposition)
+
+	filename := dispositionParams["filename"]
+	if filename == "" {
+		filename = params["name"]
+	}
+
+	decoded := decodeTransferEncoding(transferEncoding, body)
+
+	isText := mediaType == "text/plain" || mediaType == "text/html"
+
+	if dispositionType == "attachment" || filename != "" || !isText {
+		size, err := io.Copy(io.Discard, decoded)
+		if err != nil {
+			return err
+		}
+
+		content.attachments = append(content.attachments, map[string]any{
+			"filename":     decodeEmailHeader(filename),
+			"content_type": mediaType,
+			"size":         size,
+		})
+
+		return nil
+	}
+
+	reader, err := charset.NewReaderLabel(params["charset"], decoded)
+	if err != nil {
+		// Unknown charsets are read as they are.
+		reader = decoded
+	}
+
+	text, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	if mediaType == "text/html" {
+		content.html = append(content.html, string(text))
+	} else {
+		content.plain = append(content.plain, strings.TrimSpace(string(text)))
+	}
+
+	return nil
+}
+
+// decodeTransferEncoding decodes base64 and quoted-printable bodies.
+func decodeTransferEncoding(encoding string, r io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, r)
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	default:
+		return r
+	}
+}
+
+// decodeEmailHeader decodes the encoded words of the header value.
+func decodeEmailHeader(value string) string {
+	decoded, err := emailWordDecoder.DecodeHeader(value)
+	if err != nil {
+		return value
+	}
+
+	return decoded
+}
+
+// decodeEmailAddresses returns the addresses of the address list header.
+func decodeEmailAddresses(header mail.Header, key string) []string {
+	if header.Get(key) == "" {
+		return nil
+	}
+
+	parser := &mail.AddressParser{WordDecoder: emailWordDecoder}
+
+	list, err := parser.ParseList(header.Get(key))
+	if err != nil {
+		return []string{decodeEmailHeader(header.Get(key))}
+	}
+
+	addresses := make([]string, 0, len(list))
+
+	for _, a := range list {
+		if a.Name == "" {
+			addresses = append(addresses, a.Address)
+		} else {
+			addresses = append(addresses, fmt.Sprintf("%s <%s>", a.Name, a.Address))
+		}
+	}
+
+	return addresses
+}
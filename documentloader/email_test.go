@@ -0,0 +1,108 @@
+package documentloader
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMultipartEmail = `From: =?UTF-8?Q?J=C3=BCrgen?= <juergen@example.com>
+To: Support <support@example.com>, ops@example.com
+Subject: =?UTF-8?Q?Login_funktioniert_nicht?=
+Date: Tue, 02 Jan 2024 10:00:00 +0100
+Message-ID: <123@example.com>
+In-Reply-To: <100@example.com>
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="outer"
+
+--outer
+Content-Type: multipart/alternative; boundary="inner"
+
+--inner
+Content-Type: text/plain; charset=iso-8859-1
+Content-Transfer-Encoding: quoted-printable
+
+Ich kann mich nicht anmelden. Gr=FC=DFe
+--inner
+Content-Type: text/html; charset=utf-8
+
+<p>Ich kann mich <b>nicht</b> anmelden.</p>
+--inner--
+
+--outer
+Content-Type: image/png; name="screenshot.png"
+Content-Disposition: attachment; filename="screenshot.png"
+Content-Transfer-Encoding: base64
+
+aGVsbG8=
+--outer--
+`
+
+func TestEmail(t *testing.T) {
+	t.Run("Multipart", func(t *testing.T) {
+		docs, err := NewEmail(strings.NewReader(testMultipartEmail), func(o *EmailOptions) {
+			o.Source = "ticket.eml"
+		}).Load(context.Background())
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+
+		assert.Equal(t, "Ich kann mich nicht anmelden. Grüße", docs[0].PageContent)
+		assert.Equal(t, map[string]any{
+			"subject":     "Login funktioniert nicht",
+			"from":        "Jürgen <juergen@example.com>",
+			"to":          []string{"Support <support@example.com>", "ops@example.com"},
+			"date":        "2024-01-02T09:00:00Z",
+			"message_id":  "123@example.com",
+			"in_reply_to": "100@example.com",
+			"source":      "ticket.eml",
+			"attachments": []map[string]any{
+				{"filename": "screenshot.png", "content_type": "image/png", "size": int64(5)},
+			},
+		}, docs[0].Metadata)
+	})
+
+	t.Run("PreferHTML", func(t *testing.T) {
+		docs, err := NewEmail(strings.NewReader(testMultipartEmail), func(o *EmailOptions) {
+			o.PreferHTML = true
+		}).Load(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "Ich kann mich **nicht** anmelden.", docs[0].PageContent)
+	})
+
+	t.Run("HTML Only", func(t *testing.T) {
+		message := "Subject: Hi\r\nContent-Type: text/html\r\n\r\n<h1>Hello</h1><ul><li>one</li></ul>"
+
+		docs, err := NewEmail(strings.NewReader(message)).Load(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "# Hello\n\n- one", docs[0].PageContent)
+		assert.Equal(t, []map[string]any{}, docs[0].Metadata["attachments"])
+	})
+}
+
+func TestMbox(t *testing.T) {
+	mbox := `From alice@example.com Tue Jan  2 10:00:00 2024
+From: alice@example.com
+Subject: First
+
+Hello
+>From the start.
+
+From bob@example.com Tue Jan  2 11:00:00 2024
+From: bob@example.com
+Subject: Second
+
+Bye
+`
+
+	docs, err := NewMbox(strings.NewReader(mbox)).Load(context.Background())
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+
+	assert.Equal(t, "Hello\nFrom the start.", docs[0].PageContent)
+	assert.Equal(t, "First", docs[0].Metadata["subject"])
+	assert.Equal(t, "Bye", docs[1].PageContent)
+	assert.Equal(t, "bob@example.com", docs[1].Metadata["from"])
+}
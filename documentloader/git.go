@@ -172,3 +172,8 @@ func (l *Git) LoadAndSplit(ctx context.Context, splitter schema.TextSplitter) ([
 
 	return splitter.SplitDocuments(docs)
 }
+
+// LazyLoad returns an iterator over the documents. The documents are loaded at once, because the files of the repository are read completely.
+func (l *Git) LazyLoad(ctx context.Context) (schema.DocumentIterator, error) {
+	return lazyLoad(ctx, l.Load)
+}
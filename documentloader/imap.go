@@ -0,0 +1,123 @@
+package documentloader
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure IMAP satisfies the DocumentLoader interface.
+var _ schema.DocumentLoader = (*IMAP)(nil)
+
+// IMAPClient is an interface representing the methods required for loading messages from an IMAP server,
+// e.g. the client of the integration/imap package.
+type IMAPClient interface {
+	// Select selects the mailbox and returns the number of messages.
+	Select(ctx context.Context, mailbox string) (int, error)
+	// Search returns the UIDs of the messages matching the criteria.
+	Search(ctx context.Context, criteria string) ([]uint32, error)
+	// Fetch returns the raw message with the UID.
+	Fetch(ctx context.Context, uid uint32) ([]byte, error)
+}
+
+// IMAPOptions contains options for configuring the IMAP loader.
+type IMAPOptions struct {
+	EmailOptions
+
+	// Mailbox is the name of the mailbox.
+	Mailbox string
+
+	// Criteria is the IMAP search criteria of the messages, e.g. UNSEEN or SINCE 1-Jan-2024.
+	Criteria string
+
+	// MaxMessages is the maximum number of messages to load. If set, the most recent messages are loaded.
+	MaxMessages int
+}
+
+// IMAP is a document loader, which loads the messages of a mailbox from an IMAP server. A document is
+// created for each message. The messages are not marked as seen.
+type IMAP struct {
+	client IMAPClient
+	opts   IMAPOptions
+}
+
+// NewIMAP creates a new IMAP loader with an authenticated client.
+func NewIMAP(client IMAPClient, optFns ...func(o *IMAPOptions)) *IMAP {
+	opts := IMAPOptions{
+		Mailbox:  "INBOX",
+		Criteria: "ALL",
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &IMAP{
+		client: client,
+		opts:   opts,
+	}
+}
+
+// Load loads the messages of the mailbox matching the search criteria.
+func (l *IMAP) Load(ctx context.Context) ([]schema.Document, error) {
+	it, err := l.LazyLoad(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ReadAll(ctx, it)
+}
+
+// LazyLoad returns an iterator over the messages of the mailbox matching the search criteria. The messages
+// are fetched, when they are consumed. The mailbox and the UID of a message are added to its metadata.
+func (l *IMAP) LazyLoad(ctx context.Context) (schema.DocumentIterator, error) {
+	if _, err := l.client.Select(ctx, l.opts.Mailbox); err != nil {
+		return nil, err
+	}
+
+	uids, err := l.client.Search(ctx, l.opts.Criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.opts.MaxMessages > 0 && len(uids) > l.opts.MaxMessages {
+		uids = uids[len(uids)-l.opts.MaxMessages:]
+	}
+
+	return newDocumentIterator(func(ctx context.Context) (schema.Document, error) {
+		if len(uids) == 0 {
+			return schema.Document{}, io.EOF
+		}
+
+		uid := uids[0]
+		uids = uids[1:]
+
+		data, err := l.client.Fetch(ctx, uid)
+		if err != nil {
+			return schema.Document{}, err
+		}
+
+		doc, err := parseEmail(bytes.NewReader(data), l.opts.EmailOptions)
+		if err != nil {
+			return schema.Document{}, fmt.Errorf("cannot parse message %d: %w", uid, err)
+		}
+
+		doc.Metadata["mailbox"] = l.opts.Mailbox
+		doc.Metadata["uid"] = uid
+
+		return doc, nil
+	}, nil), nil
+}
+
+// LoadAndSplit loads the messages of the mailbox and splits them using the specified text splitter.
+func (l *IMAP) LoadAndSplit(ctx context.Context, splitter schema.TextSplitter) ([]schema.Document, error) {
+	docs, err := l.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return splitter.SplitDocuments(docs)
+}
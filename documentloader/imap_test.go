@@ -0,0 +1,64 @@
+package documentloader
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIMAP(t *testing.T) {
+	client := &mockIMAPClient{
+		messages: map[uint32]string{
+			3: "Subject: First\r\n\r\nHello",
+			5: "Subject: Second\r\n\r\nWorld",
+			8: "Subject: Third\r\n\r\nBye",
+		},
+	}
+
+	loader := NewIMAP(client, func(o *IMAPOptions) {
+		o.Mailbox = "Support"
+		o.Criteria = "UNSEEN"
+		o.MaxMessages = 2
+	})
+
+	docs, err := loader.Load(context.Background())
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+
+	assert.Equal(t, "World", docs[0].PageContent)
+	assert.Equal(t, "Second", docs[0].Metadata["subject"])
+	assert.Equal(t, "Support", docs[0].Metadata["mailbox"])
+	assert.Equal(t, uint32(5), docs[0].Metadata["uid"])
+	assert.Equal(t, "Bye", docs[1].PageContent)
+
+	assert.Equal(t, "Support", client.mailbox)
+	assert.Equal(t, "UNSEEN", client.criteria)
+}
+
+type mockIMAPClient struct {
+	messages map[uint32]string
+	mailbox  string
+	criteria string
+}
+
+func (m *mockIMAPClient) Select(ctx context.Context, mailbox string) (int, error) {
+	m.mailbox = mailbox
+	return len(m.messages), nil
+}
+
+func (m *mockIMAPClient) Search(ctx context.Context, criteria string) ([]uint32, error) {
+	m.criteria = criteria
+	return []uint32{3, 5, 8}, nil
+}
+
+func (m *mockIMAPClient) Fetch(ctx context.Context, uid uint32) ([]byte, error) {
+	message, ok := m.messages[uid]
+	if !ok {
+		return nil, fmt.Errorf("message %d not found", uid)
+	}
+
+	return []byte(message), nil
+}
@@ -0,0 +1,106 @@
+package documentloader
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure documentIterator satisfies the DocumentIterator interface.
+var _ schema.DocumentIterator = (*documentIterator)(nil)
+
+// documentIterator is a schema.DocumentIterator, which calls the next function for each document.
+type documentIterator struct {
+	next  func(ctx context.Context) (schema.Document, error)
+	close func() error
+	done  bool
+}
+
+// newDocumentIterator creates a new iterator with the next function, which returns io.EOF after the last
+// document, and an optional close function.
+func newDocumentIterator(next func(ctx context.Context) (schema.Document, error), close func() error) *documentIterator {
+	return &documentIterator{
+		next:  next,
+		close: close,
+	}
+}
+
+// Next returns the next document. It returns io.EOF, if there are no more documents.
+func (it *documentIterator) Next(ctx context.Context) (schema.Document, error) {
+	if it.done {
+		return schema.Document{}, io.EOF
+	}
+
+	if err := ctx.Err(); err != nil {
+		return schema.Document{}, err
+	}
+
+	doc, err := it.next(ctx)
+	if errors.Is(err, io.EOF) {
+		it.done = true
+	}
+
+	return doc, err
+}
+
+// Close releases the resources of the iterator. It is safe to call Close multiple times.
+func (it *documentIterator) Close() error {
+	it.done = true
+
+	if it.close == nil {
+		return nil
+	}
+
+	close := it.close
+	it.close = nil
+
+	return close()
+}
+
+// NewDocumentIterator returns an iterator over the documents, e.g. for loaders, which cannot load their
+// documents on demand.
+func NewDocumentIterator(docs []schema.Document) schema.DocumentIterator {
+	return newDocumentIterator(func(ctx context.Context) (schema.Document, error) {
+		if len(docs) == 0 {
+			return schema.Document{}, io.EOF
+		}
+
+		doc := docs[0]
+		docs = docs[1:]
+
+		return doc, nil
+	}, nil)
+}
+
+// ReadAll reads the remaining documents of the iterator and closes it.
+func ReadAll(ctx context.Context, it schema.DocumentIterator) ([]schema.Document, error) {
+	defer it.Close()
+
+	docs := []schema.Document{}
+
+	for {
+		doc, err := it.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		docs = append(docs, doc)
+	}
+}
+
+// lazyLoad loads all documents with the load function and returns an iterator over them. It is used by
+// loaders, whose sources must be read completely, e.g. PDF or Office documents.
+func lazyLoad(ctx context.Context, load func(ctx context.Context) ([]schema.Document, error)) (schema.DocumentIterator, error) {
+	docs, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDocumentIterator(docs), nil
+}
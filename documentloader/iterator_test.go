@@ -0,0 +1,66 @@
+package documentloader
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentIterator(t *testing.T) {
+	t.Run("NewDocumentIterator", func(t *testing.T) {
+		docs := []schema.Document{{PageContent: "foo"}, {PageContent: "bar"}}
+
+		it := NewDocumentIterator(docs)
+
+		doc, err := it.Next(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "foo", doc.PageContent)
+
+		rest, err := ReadAll(context.Background(), it)
+		require.NoError(t, err)
+		assert.Equal(t, []schema.Document{{PageContent: "bar"}}, rest)
+
+		_, err = it.Next(context.Background())
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		closed := 0
+
+		it := newDocumentIterator(func(ctx context.Context) (schema.Document, error) {
+			return schema.Document{PageContent: "foo"}, nil
+		}, func() error {
+			closed++
+			return nil
+		})
+
+		require.NoError(t, it.Close())
+		require.NoError(t, it.Close())
+		assert.Equal(t, 1, closed)
+
+		_, err := it.Next(context.Background())
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		it := newDocumentIterator(func(ctx context.Context) (schema.Document, error) {
+			return schema.Document{}, errors.New("failed")
+		}, nil)
+
+		_, err := ReadAll(context.Background(), it)
+		assert.EqualError(t, err, "failed")
+	})
+
+	t.Run("Canceled Context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := NewDocumentIterator([]schema.Document{{PageContent: "foo"}}).Next(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
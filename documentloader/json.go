@@ -0,0 +1,390 @@
+package documentloader
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure JSON satisfies the DocumentLoader interface.
+var _ schema.DocumentLoader = (*JSON)(nil)
+
+// JSONOptions contains options for configuring the JSON loader.
+type JSONOptions struct {
+	// Path selects the records of the JSON value, which are loaded as documents. The path uses a jq-like
+	// syntax, e.g. ".items[]" selects all elements of the items array. Defaults to ".".
+	Path string
+
+	// ContentKey is the jq-like path of the content relative to each record, e.g. ".text". If empty,
+	// the whole record is used as content. Content, which is not a string, is encoded as JSON.
+	ContentKey string
+
+	// MetadataPaths maps metadata keys to jq-like paths relative to each record, e.g. {"id": ".id"}.
+	MetadataPaths map[string]string
+
+	// JSONLines reads the input as JSON Lines, i.e. one JSON value per line.
+	JSONLines bool
+
+	// Source is the name of the json document
+	Source string
+}
+
+// JSON represents a JSON and JSON Lines document loader.
+type JSON struct {
+	r    io.Reader
+	opts JSONOptions
+}
+
+// NewJSON creates a new JSON loader with an io.Reader and optional configuration options.
+func NewJSON(r io.Reader, optFns ...func(o *JSONOptions)) *JSON {
+	opts := JSONOptions{
+		Path: ".",
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &JSON{
+		r:    r,
+		opts: opts,
+	}
+}
+
+// NewJSONL creates a new JSON loader, which reads the input as JSON Lines.
+func NewJSONL(r io.Reader, optFns ...func(o *JSONOptions)) *JSON {
+	return NewJSON(r, append([]func(o *JSONOptions){func(o *JSONOptions) {
+		o.JSONLines = true
+	}}, optFns...)...)
+}
+
+// Load loads JSON documents from the provided reader. Each record selected by the path becomes a document.
+func (l *JSON) Load(ctx context.Context) ([]schema.Document, error) {
+	it, err := l.LazyLoad(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ReadAll(ctx, it)
+}
+
+// LoadAndSplit loads JSON documents from the provided reader and splits them using the specified text splitter.
+func (l *JSON) LoadAndSplit(ctx context.Context, splitter schema.TextSplitter) ([]schema.Document, error) {
+	docs, err := l.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return splitter.SplitDocuments(docs)
+}
+
+// LazyLoad returns an iterator over the JSON documents. JSON Lines are read on demand; a JSON value is
+// decoded at once.
+func (l *JSON) LazyLoad(ctx context.Context) (schema.DocumentIterator, error) {
+	nextValue, err := l.valueReader()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		records []any
+		seqNum  int
+	)
+
+	return newDocumentIterator(func(ctx context.Context) (schema.Document, error) {
+		for len(records) == 0 {
+			value, err := nextValue()
+			if err != nil {
+				return schema.Document{}, err
+			}
+
+			records, err = queryJSON(value, l.opts.Path)
+			if err != nil {
+				return schema.Document{}, err
+			}
+		}
+
+		record := records[0]
+		records = records[1:]
+		seqNum++
+
+		return l.createDocument(record, seqNum)
+	}, nil), nil
+}
+
+// valueReader returns a function, which returns the JSON value or, for JSON Lines, the values of the
+// non-empty lines. It returns io.EOF after the last value.
+func (l *JSON) valueReader() (func() (any, error), error) {
+	if !l.opts.JSONLines {
+		var value any
+		if err := json.NewDecoder(l.r).Decode(&value); err != nil {
+			return nil, err
+		}
+
+		read := false
+
+		return func() (any, error) {
+			if read {
+				return nil, io.EOF
+			}
+
+			read = true
+
+			return value, nil
+		}, nil
+	}
+
+	scanner := bufio.NewScanner(l.r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	line := 0
+
+	return func() (any, error) {
+		for scanner.Scan() {
+			line++
+
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" {
+				continue
+			}
+
+			var value any
+			if err := json.Unmarshal([]byte(text), &value); err != nil {
+				return nil, fmt.Errorf("cannot decode line %d: %w", line, err)
+			}
+
+			return value, nil
+		}
+
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+
+		return nil, io.EOF
+	}, nil
+}
+
+// createDocument creates the document of the record with the content and the metadata selected by the paths.
+func (l *JSON) createDocument(record any, seqNum int) (schema.Document, error) {
+	content := record
+
+	if l.opts.ContentKey != "" {
+		values, err := queryJSON(record, l.opts.ContentKey)
+		if err != nil {
+			return schema.Document{}, err
+		}
+
+		if len(values) != 1 {
+			return schema.Document{}, fmt.Errorf("content key %s selects %d values instead of one in record %d", l.opts.ContentKey, len(values), seqNum)
+		}
+
+		content = values[0]
+	}
+
+	pageContent, err := formatJSONContent(content)
+	if err != nil {
+		return schema.Document{}, err
+	}
+
+	metadata := map[string]any{"seq_num": seqNum}
+
+	if l.opts.Source != "" {
+		metadata["source"] = l.opts.Source
+	}
+
+	for key, path := range l.opts.MetadataPaths {
+		values, err := queryJSON(record, path)
+		if err != nil {
+			return schema.Document{}, err
+		}
+
+		switch len(values) {
+		case 0:
+			continue
+		case 1:
+			metadata[key] = values[0]
+		default:
+			metadata[key] = values
+		}
+	}
+
+	return schema.Document{
+		PageContent: pageContent,
+		Metadata:    metadata,
+	}, nil
+}
+
+// formatJSONContent returns strings as-is and encodes the other values as JSON.
+func formatJSONContent(v any) (string, error) {
+	switch t := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return t, nil
+	default:
+		b, err := json.Marshal(t)
+		if err != nil {
+			return "", err
+		}
+
+		return string(b), nil
+	}
+}
+
+// queryJSON evaluates the jq-like path on the value. The path consists of field accesses (.a or ["a"]),
+// array indexes ([0], negative indexes count from the end) and iterations ([]), e.g. ".items[].title".
+// Missing fields and indexes yield no values.
+func queryJSON(value any, path string) ([]any, error) {
+	values := []any{value}
+	rest := strings.TrimSpace(path)
+
+	for rest != "" && rest != "." {
+		var (
+			step func(v any) ([]any, error)
+			err  error
+		)
+
+		step, rest, err = parseJSONPathStep(rest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path %q: %w", path, err)
+		}
+
+		next := []any{}
+
+		for _, v := range values {
+			selected, err := step(v)
+			if err != nil {
+				return nil, fmt.Errorf("cannot evaluate path %q: %w", path, err)
+			}
+
+			next = append(next, selected...)
+		}
+
+		values = next
+	}
+
+	return values, nil
+}
+
+// parseJSONPathStep parses the first step of the path and returns it with the remaining path.
+func parseJSONPathStep(path string) (func(v any) ([]any, error), string, error) {
+	switch {
+	case strings.HasPrefix(path, "[") || strings.HasPrefix(path, ".["):
+		path = strings.TrimPrefix(path, ".")
+
+		end := strings.Index(path, "]")
+		if end < 0 {
+			return nil, "", errors.New("missing closing bracket")
+		}
+
+		inner, rest := strings.TrimSpace(path[1:end]), path[end+1:]
+
+		if inner == "" {
+			return iterateJSON, rest, nil
+		}
+
+		if strings.HasPrefix(inner, `"`) {
+			key, err := strconv.Unquote(inner)
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid key %s", inner)
+			}
+
+			return fieldJSON(key), rest, nil
+		}
+
+		index, err := strconv.Atoi(inner)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid index %s", inner)
+		}
+
+		return indexJSON(index), rest, nil
+	case strings.HasPrefix(path, "."):
+		path = path[1:]
+
+		end := strings.IndexAny(path, ".[")
+		if end < 0 {
+			end = len(path)
+		}
+
+		if end == 0 {
+			return nil, "", errors.New("empty field name")
+		}
+
+		return fieldJSON(path[:end]), path[end:], nil
+	default:
+		return nil, "", fmt.Errorf("unexpected %q", path)
+	}
+}
+
+// fieldJSON returns a step, which selects the field of an object.
+func fieldJSON(key string) func(v any) ([]any, error) {
+	return func(v any) ([]any, error) {
+		switch t := v.(type) {
+		case nil:
+			return nil, nil
+		case map[string]any:
+			if value, ok := t[key]; ok {
+				return []any{value}, nil
+			}
+
+			return nil, nil
+		default:
+			return nil, fmt.Errorf("cannot access field %s of %T", key, v)
+		}
+	}
+}
+
+// indexJSON returns a step, which selects the element of an array.
+func indexJSON(index int) func(v any) ([]any, error) {
+	return func(v any) ([]any, error) {
+		switch t := v.(type) {
+		case nil:
+			return nil, nil
+		case []any:
+			i := index
+			if i < 0 {
+				i += len(t)
+			}
+
+			if i < 0 || i >= len(t) {
+				return nil, nil
+			}
+
+			return []any{t[i]}, nil
+		default:
+			return nil, fmt.Errorf("cannot index %T", v)
+		}
+	}
+}
+
+// iterateJSON selects the elements of an array or the values of an object.
+func iterateJSON(v any) ([]any, error) {
+	switch t := v.(type) {
+	case []any:
+		return t, nil
+	case map[string]any:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		values := make([]any, 0, len(t))
+		for _, k := range keys {
+			values = append(values, t[k])
+		}
+
+		return values, nil
+	default:
+		return nil, fmt.Errorf("cannot iterate %T", v)
+	}
+}
@@ -0,0 +1,94 @@
+package documentloader
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSON(t *testing.T) {
+	data := `{
+	"items": [
+		{"id": 1, "title": "Hello", "body": "First post", "tags": ["a", "b"]},
+		{"id": 2, "title": "World", "body": "Second post", "tags": []}
+	]
+}`
+
+	t.Run("Path and ContentKey", func(t *testing.T) {
+		loader := NewJSON(strings.NewReader(data), func(o *JSONOptions) {
+			o.Path = ".items[]"
+			o.ContentKey = ".body"
+			o.MetadataPaths = map[string]string{"id": ".id", "tags": ".tags[]", "first_tag": `["tags"][0]`}
+			o.Source = "posts.json"
+		})
+
+		docs, err := loader.Load(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []schema.Document{
+			{
+				PageContent: "First post",
+				Metadata:    map[string]any{"seq_num": 1, "source": "posts.json", "id": float64(1), "tags": []any{"a", "b"}, "first_tag": "a"},
+			},
+			{
+				PageContent: "Second post",
+				Metadata:    map[string]any{"seq_num": 2, "source": "posts.json", "id": float64(2)},
+			},
+		}, docs)
+	})
+
+	t.Run("Whole Record", func(t *testing.T) {
+		loader := NewJSON(strings.NewReader(data), func(o *JSONOptions) {
+			o.Path = ".items[-1]"
+		})
+
+		docs, err := loader.Load(context.Background())
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, `{"body":"Second post","id":2,"tags":[],"title":"World"}`, docs[0].PageContent)
+	})
+
+	t.Run("Missing Content", func(t *testing.T) {
+		loader := NewJSON(strings.NewReader(data), func(o *JSONOptions) {
+			o.Path = ".items[]"
+			o.ContentKey = ".text"
+		})
+
+		_, err := loader.Load(context.Background())
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid Path", func(t *testing.T) {
+		loader := NewJSON(strings.NewReader(data), func(o *JSONOptions) {
+			o.Path = ".items[0"
+		})
+
+		_, err := loader.Load(context.Background())
+		assert.Error(t, err)
+	})
+}
+
+func TestJSONL(t *testing.T) {
+	data := `{"text": "foo", "meta": {"lang": "en"}}
+
+{"text": "bar", "meta": {"lang": "de"}}
+`
+
+	loader := NewJSONL(strings.NewReader(data), func(o *JSONOptions) {
+		o.ContentKey = ".text"
+		o.MetadataPaths = map[string]string{"lang": ".meta.lang"}
+	})
+
+	docs, err := loader.Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []schema.Document{
+		{PageContent: "foo", Metadata: map[string]any{"seq_num": 1, "lang": "en"}},
+		{PageContent: "bar", Metadata: map[string]any{"seq_num": 2, "lang": "de"}},
+	}, docs)
+
+	_, err = NewJSONL(strings.NewReader("{\"text\": 1}\n{invalid")).Load(context.Background())
+	assert.ErrorContains(t, err, "line 2")
+}
@@ -0,0 +1,289 @@
+package documentloader
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	// markdownSpacePattern matches runs of whitespace in HTML text.
+	markdownSpacePattern = regexp.MustCompile(`\s+`)
+	// markdownBlankLinesPattern matches more than one blank line.
+	markdownBlankLinesPattern = regexp.MustCompile(`\n{3,}`)
+	// cdataPattern matches CDATA sections, e.g. the bodies of Confluence code macros.
+	cdataPattern = regexp.MustCompile(`(?s)<!\[CDATA\[(.*?)\]\]>`)
+)
+
+// htmlToMarkdown converts HTML to Markdown. Besides the common HTML elements it supports the code macros
+// and task lists of the Confluence storage format. Unknown elements are replaced by their content.
+func htmlToMarkdown(s string) (string, error) {
+	// The HTML parser does not support CDATA sections, so they are replaced by escaped text.
+	s = cdataPattern.ReplaceAllStringFunc(s, func(m string) string {
+		return html.EscapeString(cdataPattern.FindStringSubmatch(m)[1])
+	})
+
+	nodes, err := html.ParseFragment(strings.NewReader(s), &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body})
+	if err != nil {
+		return "", err
+	}
+
+	b := new(strings.Builder)
+	for _, n := range nodes {
+		b.WriteString(markdownNode(n))
+	}
+
+	return normalizeMarkdown(b.String()), nil
+}
+
+// normalizeMarkdown removes trailing whitespace and repeated blank lines.
+func normalizeMarkdown(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+
+	return strings.TrimSpace(markdownBlankLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
+
+// markdownBlock formats the content as a block separated by blank lines.
+func markdownBlock(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ""
+	}
+
+	return "\n\n" + s + "\n\n"
+}
+
+// markdownInline wraps the content with the marker, e.g. ** for bold text. Surrounding whitespace is
+// kept outside of the markers.
+func markdownInline(marker, s string) string {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return s
+	}
+
+	start := strings.Index(s, trimmed)
+
+	return s[:start] + marker + trimmed + marker + s[start+len(trimmed):]
+}
+
+// markdownChildren converts the children of the node.
+func markdownChildren(n *html.Node) string {
+	b := new(strings.Builder)
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		b.WriteString(markdownNode(c))
+	}
+
+	return b.String()
+}
+
+// markdownNode converts the node and its children.
+func markdownNode(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return markdownSpacePattern.ReplaceAllString(n.Data, " ")
+	case html.ElementNode:
+	default:
+		return ""
+	}
+
+	switch n.Data {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		level := int(n.Data[1] - '0')
+		return markdownBlock(strings.Repeat("#", level) + " " + strings.TrimSpace(markdownChildren(n)))
+	case "p", "div", "section", "article":
+		return markdownBlock(markdownChildren(n))
+	case "br":
+		return "\n"
+	case "hr":
+		return markdownBlock("---")
+	case "strong", "b":
+		return markdownInline("**", markdownChildren(n))
+	case "em", "i":
+		return markdownInline("*", markdownChildren(n))
+	case "s", "del", "strike":
+		return markdownInline("~~", markdownChildren(n))
+	case "code":
+		return markdownInline("`", markdownText(n))
+	case "a":
+		text := strings.TrimSpace(markdownChildren(n))
+
+		href := htmlAttr(n, "href")
+		if href == "" || text == "" {
+			return text
+		}
+
+		return fmt.Sprintf("[%s](%s)", text, href)
+	case "pre":
+		return markdownCodeBlock("", markdownText(n))
+	case "blockquote":
+		content := normalizeMarkdown(markdownChildren(n))
+		return markdownBlock("> " + strings.ReplaceAll(content, "\n", "\n> "))
+	case "ul", "ol", "ac:task-list":
+		return markdownBlock(markdownList(n))
+	case "table":
+		return markdownBlock(markdownTable(n))
+	case "script", "style", "ac:parameter":
+		return ""
+	case "ac:structured-macro":
+		if htmlAttr(n, "ac:name") == "code" {
+			return markdownCodeBlock(macroParameter(n, "language"), macroPlainTextBody(n))
+		}
+
+		return markdownChildren(n)
+	default:
+		return markdownChildren(n)
+	}
+}
+
+// markdownCodeBlock formats the code as fenced code block.
+func markdownCodeBlock(language, code string) string {
+	return "\n\n```" + language + "\n" + strings.Trim(code, "\n") + "\n```\n\n"
+}
+
+// markdownList converts the items of a list. Nested lists are indented.
+func markdownList(n *html.Node) string {
+	items := []string{}
+	number := 0
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode {
+			continue
+		}
+
+		var marker, content string
+
+		switch {
+		case c.Data == "li" && n.Data == "ol":
+			number++
+			marker, content = fmt.Sprintf("%d. ", number), markdownChildren(c)
+		case c.Data == "li":
+			marker, content = "- ", markdownChildren(c)
+		case c.Data == "ac:task":
+			marker = "- [ ] "
+
+			for t := c.FirstChild; t != nil; t = t.NextSibling {
+				switch t.Data {
+				case "ac:task-status":
+					if strings.TrimSpace(markdownText(t)) == "complete" {
+						marker = "- [x] "
+					}
+				case "ac:task-body":
+					content = markdownChildren(t)
+				}
+			}
+		default:
+			continue
+		}
+
+		content = strings.ReplaceAll(normalizeMarkdown(content), "\n\n", "\n")
+		items = append(items, marker+strings.ReplaceAll(content, "\n", "\n"+strings.Repeat(" ", len(marker))))
+	}
+
+	return strings.Join(items, "\n")
+}
+
+// markdownTable converts the rows of a table. The first row is used as header.
+func markdownTable(n *html.Node) string {
+	rows := [][]string{}
+
+	var collect func(n *html.Node)
+	collect = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+
+			if c.Data != "tr" {
+				collect(c)
+				continue
+			}
+
+			row := []string{}
+
+			for cell := c.FirstChild; cell != nil; cell = cell.NextSibling {
+				if cell.Type == html.ElementNode && (cell.Data == "th" || cell.Data == "td") {
+					text := strings.ReplaceAll(normalizeMarkdown(markdownChildren(cell)), "\n", " ")
+					row = append(row, strings.ReplaceAll(text, "|", `\|`))
+				}
+			}
+
+			rows = append(rows, row)
+		}
+	}
+
+	collect(n)
+
+	if len(rows) == 0 {
+		return ""
+	}
+
+	lines := make([]string, 0, len(rows)+1)
+
+	for i, row := range rows {
+		lines = append(lines, "| "+strings.Join(row, " | ")+" |")
+
+		if i == 0 {
+			separator := make([]string, len(row))
+			for j := range separator {
+				separator[j] = "---"
+			}
+
+			lines = append(lines, "| "+strings.Join(separator, " | ")+" |")
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// markdownText returns the raw text of the node and its children.
+func markdownText(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+
+	b := new(strings.Builder)
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		b.WriteString(markdownText(c))
+	}
+
+	return b.String()
+}
+
+// htmlAttr returns the value of the attribute of the node.
+func htmlAttr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+
+	return ""
+}
+
+// macroParameter returns the value of the parameter of a Confluence macro.
+func macroParameter(n *html.Node, name string) string {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.Data == "ac:parameter" && htmlAttr(c, "ac:name") == name {
+			return strings.TrimSpace(markdownText(c))
+		}
+	}
+
+	return ""
+}
+
+// macroPlainTextBody returns the plain text body of a Confluence macro.
+func macroPlainTextBody(n *html.Node) string {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.Data == "ac:plain-text-body" {
+			return markdownText(c)
+		}
+	}
+
+	return ""
+}
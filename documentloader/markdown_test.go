@@ -0,0 +1,59 @@
+package documentloader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTMLToMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected string
+	}{
+		{
+			name:     "Headings and Inline Elements",
+			html:     `<h1>Title</h1><p>Hello <strong>bold </strong>and <em>italic</em> <a href="https://example.com">link</a> <code>x</code><br/>next</p>`,
+			expected: "# Title\n\nHello **bold** and *italic* [link](https://example.com) `x`\nnext",
+		},
+		{
+			name:     "Lists",
+			html:     `<ul><li>one</li><li>two<ul><li>nested</li></ul></li></ul><ol><li><p>first</p></li><li>second</li></ol>`,
+			expected: "- one\n- two\n  - nested\n\n1. first\n2. second",
+		},
+		{
+			name:     "Table",
+			html:     `<table><tbody><tr><th>A</th><th>B</th></tr><tr><td>1</td><td>2|3</td></tr></tbody></table>`,
+			expected: "| A | B |\n| --- | --- |\n| 1 | 2\\|3 |",
+		},
+		{
+			name:     "Blockquote and Rule",
+			html:     `<blockquote><p>one</p><p>two</p></blockquote><hr/>`,
+			expected: "> one\n>\n> two\n\n---",
+		},
+		{
+			name: "Confluence Code Macro",
+			html: `<ac:structured-macro ac:name="code"><ac:parameter ac:name="language">go</ac:parameter>` +
+				`<ac:plain-text-body><![CDATA[if a < b {
+	return "<b>"
+}]]></ac:plain-text-body></ac:structured-macro>`,
+			expected: "```go\nif a < b {\n\treturn \"<b>\"\n}\n```",
+		},
+		{
+			name: "Confluence Task List",
+			html: `<ac:task-list><ac:task><ac:task-status>complete</ac:task-status><ac:task-body>Done</ac:task-body></ac:task>` +
+				`<ac:task><ac:task-status>incomplete</ac:task-status><ac:task-body>Todo</ac:task-body></ac:task></ac:task-list>`,
+			expected: "- [x] Done\n- [ ] Todo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			markdown, err := htmlToMarkdown(tt.html)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, markdown)
+		})
+	}
+}
@@ -106,3 +106,8 @@ func (l *Notebook) LoadAndSplit(ctx context.Context, splitter schema.TextSplitte
 
 	return splitter.SplitDocuments(docs)
 }
+
+// LazyLoad returns an iterator over the documents. The documents are loaded at once, because the notebook is read completely.
+func (l *Notebook) LazyLoad(ctx context.Context) (schema.DocumentIterator, error) {
+	return lazyLoad(ctx, l.Load)
+}
@@ -0,0 +1,275 @@
+package documentloader
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hupe1980/golc/integration/notion"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Notion satisfies the DocumentLoader interface.
+var _ schema.DocumentLoader = (*Notion)(nil)
+
+// NotionClient is an interface representing the methods required for loading pages from Notion.
+type NotionClient interface {
+	// QueryDatabase returns the pages of a database.
+	QueryDatabase(ctx context.Context, databaseID string, req *notion.QueryDatabaseRequest) (*notion.QueryDatabaseResponse, error)
+	// GetBlockChildren returns the child blocks of a block or page, starting at the cursor.
+	GetBlockChildren(ctx context.Context, blockID string, startCursor string) (*notion.GetBlockChildrenResponse, error)
+}
+
+// NotionOptions contains options for configuring the Notion loader.
+type NotionOptions struct {
+	// PageSize is the number of pages requested at once.
+	PageSize int
+
+	// MaxPages is the maximum number of pages to load. If zero, all pages of the database are loaded.
+	MaxPages int
+}
+
+// Notion is a document loader, which loads the pages of a Notion database. The blocks of the pages are
+// converted to Markdown. The page URL and the last-edited timestamp are added to the metadata.
+type Notion struct {
+	client     NotionClient
+	databaseID string
+	opts       NotionOptions
+}
+
+// NewNotion creates a new Notion loader for the database with the id.
+func NewNotion(client NotionClient, databaseID string, optFns ...func(o *NotionOptions)) *Notion {
+	opts := NotionOptions{
+		PageSize: 100,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Notion{
+		client:     client,
+		databaseID: databaseID,
+		opts:       opts,
+	}
+}
+
+// Load loads the pages of the database.
+func (l *Notion) Load(ctx context.Context) ([]schema.Document, error) {
+	it, err := l.LazyLoad(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ReadAll(ctx, it)
+}
+
+// LazyLoad returns an iterator over the pages of the database. The pages are requested page by page,
+// when the previous pages are consumed. The blocks of a page are requested, when the page is consumed.
+func (l *Notion) LazyLoad(ctx context.Context) (schema.DocumentIterator, error) {
+	var (
+		pages   []notion.Page
+		cursor  string
+		loaded  int
+		hasMore = true
+	)
+
+	return newDocumentIterator(func(ctx context.Context) (schema.Document, error) {
+		if l.opts.MaxPages > 0 && loaded >= l.opts.MaxPages {
+			return schema.Document{}, io.EOF
+		}
+
+		if len(pages) == 0 {
+			if !hasMore {
+				return schema.Document{}, io.EOF
+			}
+
+			res, err := l.client.QueryDatabase(ctx, l.databaseID, &notion.QueryDatabaseRequest{
+				StartCursor: cursor,
+				PageSize:    l.opts.PageSize,
+			})
+			if err != nil {
+				return schema.Document{}, err
+			}
+
+			if len(res.Results) == 0 {
+				return schema.Document{}, io.EOF
+			}
+
+			pages, cursor, hasMore = res.Results, res.NextCursor, res.HasMore && res.NextCursor != ""
+		}
+
+		page := pages[0]
+		pages = pages[1:]
+		loaded++
+
+		content, err := l.renderBlocks(ctx, page.ID, "")
+		if err != nil {
+			return schema.Document{}, err
+		}
+
+		return schema.Document{
+			PageContent: normalizeMarkdown(content),
+			Metadata: map[string]any{
+				"id":          page.ID,
+				"title":       page.Title(),
+				"created":     page.CreatedTime,
+				"last_edited": page.LastEditedTime,
+				"url":         page.URL,
+				"source":      page.URL,
+			},
+		}, nil
+	}, nil), nil
+}
+
+// LoadAndSplit loads the pages of the database and splits them using the specified text splitter.
+func (l *Notion) LoadAndSplit(ctx context.Context, splitter schema.TextSplitter) ([]schema.Document, error) {
+	docs, err := l.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return splitter.SplitDocuments(docs)
+}
+
+// renderBlocks converts the child blocks of the block to Markdown. The lines are prefixed with the indent,
+// so the children of list items are nested.
+func (l *Notion) renderBlocks(ctx context.Context, blockID, indent string) (string, error) {
+	b := new(strings.Builder)
+	cursor := ""
+	number := 0
+	inList := false
+
+	for {
+		res, err := l.client.GetBlockChildren(ctx, blockID, cursor)
+		if err != nil {
+			return "", err
+		}
+
+		for _, block := range res.Results {
+			if block.Type == "numbered_list_item" {
+				number++
+			} else {
+				number = 0
+			}
+
+			text, childIndent := renderNotionBlock(&block, number)
+
+			// Lists are separated from the following blocks by a blank line.
+			if inList && childIndent == "" {
+				b.WriteString("\n")
+			}
+
+			inList = childIndent != ""
+
+			for _, line := range strings.SplitAfter(text, "\n") {
+				if strings.TrimSpace(line) != "" {
+					line = indent + line
+				}
+
+				b.WriteString(line)
+			}
+
+			if block.HasChildren {
+				children, err := l.renderBlocks(ctx, block.ID, indent+childIndent)
+				if err != nil {
+					return "", err
+				}
+
+				b.WriteString(children)
+			}
+		}
+
+		if !res.HasMore || res.NextCursor == "" {
+			return b.String(), nil
+		}
+
+		cursor = res.NextCursor
+	}
+}
+
+// renderNotionBlock converts the block to Markdown. It returns the indent of the children of the block,
+// which is only set for nested blocks like list items.
+func renderNotionBlock(block *notion.Block, number int) (string, string) {
+	if block.Type == "divider" {
+		return "---\n\n", ""
+	}
+
+	content := block.Content()
+	if content == nil {
+		return "", ""
+	}
+
+	text := notionRichTextToMarkdown(content.RichText)
+
+	switch block.Type {
+	case "heading_1":
+		return "# " + text + "\n\n", ""
+	case "heading_2":
+		return "## " + text + "\n\n", ""
+	case "heading_3":
+		return "### " + text + "\n\n", ""
+	case "bulleted_list_item":
+		return "- " + text + "\n", "  "
+	case "numbered_list_item":
+		marker := fmt.Sprintf("%d. ", number)
+		return marker + text + "\n", strings.Repeat(" ", len(marker))
+	case "to_do":
+		if content.Checked {
+			return "- [x] " + text + "\n", "  "
+		}
+
+		return "- [ ] " + text + "\n", "  "
+	case "toggle":
+		return "- " + text + "\n", "  "
+	case "quote", "callout":
+		return "> " + strings.ReplaceAll(text, "\n", "\n> ") + "\n\n", ""
+	case "code":
+		code := ""
+		for _, t := range content.RichText {
+			code += t.PlainText
+		}
+
+		return "```" + content.Language + "\n" + code + "\n```\n\n", ""
+	default:
+		if text == "" {
+			return "", ""
+		}
+
+		return text + "\n\n", ""
+	}
+}
+
+// notionRichTextToMarkdown converts rich text to Markdown. Annotations and links are kept.
+func notionRichTextToMarkdown(richText []notion.RichText) string {
+	b := new(strings.Builder)
+
+	for _, t := range richText {
+		text := t.PlainText
+
+		if t.Annotations.Code {
+			text = markdownInline("`", text)
+		}
+
+		if t.Annotations.Bold {
+			text = markdownInline("**", text)
+		}
+
+		if t.Annotations.Italic {
+			text = markdownInline("*", text)
+		}
+
+		if t.Annotations.Strikethrough {
+			text = markdownInline("~~", text)
+		}
+
+		if t.Href != "" {
+			text = fmt.Sprintf("[%s](%s)", text, t.Href)
+		}
+
+		b.WriteString(text)
+	}
+
+	return b.String()
+}
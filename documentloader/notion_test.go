@@ -0,0 +1,116 @@
+package documentloader
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc/integration/notion"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotion(t *testing.T) {
+	client := &mockNotionClient{
+		pages: []*notion.QueryDatabaseResponse{
+			{
+				Results:    []notion.Page{newNotionPage("p1", "Roadmap")},
+				HasMore:    true,
+				NextCursor: "c1",
+			},
+			{
+				Results: []notion.Page{newNotionPage("p2", "Notes")},
+			},
+		},
+		blocks: map[string][]*notion.GetBlockChildrenResponse{
+			"p1": {
+				{
+					Results: []notion.Block{
+						{Type: "heading_1", Heading1: notionText("Goals")},
+						{Type: "paragraph", Paragraph: &notion.BlockContent{RichText: []notion.RichText{
+							{PlainText: "Ship "},
+							{PlainText: "fast", Annotations: notion.Annotations{Bold: true}},
+							{PlainText: " and "},
+							{PlainText: "docs", Href: "https://example.com"},
+						}}},
+						{ID: "b1", Type: "bulleted_list_item", BulletedListItem: notionText("Loaders"), HasChildren: true},
+					},
+					HasMore:    true,
+					NextCursor: "c1",
+				},
+				{
+					Results: []notion.Block{
+						{Type: "numbered_list_item", NumberedListItem: notionText("First")},
+						{Type: "numbered_list_item", NumberedListItem: notionText("Second")},
+						{Type: "to_do", ToDo: &notion.BlockContent{RichText: []notion.RichText{{PlainText: "Done"}}, Checked: true}},
+						{Type: "divider"},
+						{Type: "code", Code: &notion.BlockContent{RichText: []notion.RichText{{PlainText: "go test ./..."}}, Language: "shell"}},
+					},
+				},
+			},
+			"b1": {
+				{Results: []notion.Block{{Type: "bulleted_list_item", BulletedListItem: notionText("Notion")}}},
+			},
+			"p2": {
+				{Results: []notion.Block{{Type: "quote", Quote: notionText("Keep it simple")}}},
+			},
+		},
+	}
+
+	loader := NewNotion(client, "db1")
+
+	docs, err := loader.Load(context.Background())
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+
+	assert.Equal(t, schema.Document{
+		PageContent: "# Goals\n\nShip **fast** and [docs](https://example.com)\n\n- Loaders\n  - Notion\n1. First\n2. Second\n- [x] Done\n\n---\n\n```shell\ngo test ./...\n```",
+		Metadata: map[string]any{
+			"id":          "p1",
+			"title":       "Roadmap",
+			"created":     "2024-01-01T10:00:00.000Z",
+			"last_edited": "2024-01-02T10:00:00.000Z",
+			"url":         "https://www.notion.so/p1",
+			"source":      "https://www.notion.so/p1",
+		},
+	}, docs[0])
+	assert.Equal(t, "> Keep it simple", docs[1].PageContent)
+	assert.Equal(t, []string{"", "c1"}, client.cursors)
+}
+
+func newNotionPage(id, title string) notion.Page {
+	return notion.Page{
+		ID:             id,
+		URL:            "https://www.notion.so/" + id,
+		CreatedTime:    "2024-01-01T10:00:00.000Z",
+		LastEditedTime: "2024-01-02T10:00:00.000Z",
+		Properties: map[string]notion.Property{
+			"Name": {Type: "title", Title: []notion.RichText{{PlainText: title}}},
+		},
+	}
+}
+
+func notionText(text string) *notion.BlockContent {
+	return &notion.BlockContent{RichText: []notion.RichText{{PlainText: text}}}
+}
+
+type mockNotionClient struct {
+	pages   []*notion.QueryDatabaseResponse
+	blocks  map[string][]*notion.GetBlockChildrenResponse
+	cursors []string
+}
+
+func (m *mockNotionClient) QueryDatabase(ctx context.Context, databaseID string, req *notion.QueryDatabaseRequest) (*notion.QueryDatabaseResponse, error) {
+	m.cursors = append(m.cursors, req.StartCursor)
+
+	return m.pages[len(m.cursors)-1], nil
+}
+
+func (m *mockNotionClient) GetBlockChildren(ctx context.Context, blockID string, startCursor string) (*notion.GetBlockChildrenResponse, error) {
+	responses := m.blocks[blockID]
+	if startCursor == "" {
+		return responses[0], nil
+	}
+
+	return responses[1], nil
+}
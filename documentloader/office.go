@@ -0,0 +1,170 @@
+package documentloader
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// openZipFile opens the file of the office document archive.
+func openZipFile(zr *zip.Reader, name string) (io.ReadCloser, error) {
+	f, err := zr.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open %s: %w", name, err)
+	}
+
+	return f, nil
+}
+
+// decodeZipFile decodes the XML file of the office document archive into v.
+func decodeZipFile(zr *zip.Reader, name string, v any) error {
+	f, err := openZipFile(zr, name)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	return xml.NewDecoder(f).Decode(v)
+}
+
+// extractOfficeText extracts the paragraphs and tables of a WordprocessingML or DrawingML part, e.g. the
+// body of a DOCX document or a PPTX slide. Both use the same local names for paragraphs (p), text runs (t)
+// and tables (tbl, tr, tc). Tables are rendered as CSV.
+func extractOfficeText(r io.Reader, ignoreTables bool) (string, error) {
+	var (
+		blocks     []string
+		paragraph  strings.Builder
+		cellParts  []string
+		row        []string
+		rows       [][]string
+		tableDepth int
+		inText     bool
+	)
+
+	decoder := xml.NewDecoder(r)
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "tbl":
+				tableDepth++
+				if tableDepth == 1 {
+					rows = nil
+				}
+			case "tr":
+				if tableDepth == 1 {
+					row = nil
+				}
+			case "tc":
+				if tableDepth == 1 {
+					cellParts = nil
+				}
+			case "p":
+				paragraph.Reset()
+			case "t":
+				inText = true
+			case "tab":
+				paragraph.WriteString("\t")
+			case "br", "cr":
+				paragraph.WriteString("\n")
+			}
+		case xml.CharData:
+			if inText {
+				paragraph.Write(t)
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				text := strings.TrimSpace(paragraph.String())
+				if text == "" {
+					continue
+				}
+
+				if tableDepth > 0 {
+					cellParts = append(cellParts, text)
+				} else {
+					blocks = append(blocks, text)
+				}
+			case "tc":
+				if tableDepth == 1 {
+					row = append(row, strings.Join(cellParts, " "))
+				}
+			case "tr":
+				if tableDepth == 1 {
+					rows = append(rows, row)
+				}
+			case "tbl":
+				tableDepth--
+				if tableDepth == 0 && !ignoreTables && len(rows) > 0 {
+					table, err := formatCSV(rows)
+					if err != nil {
+						return "", err
+					}
+
+					blocks = append(blocks, table)
+				}
+			}
+		}
+	}
+
+	return strings.Join(blocks, "\n"), nil
+}
+
+// formatCSV formats the rows as CSV.
+func formatCSV(rows [][]string) (string, error) {
+	b := new(strings.Builder)
+
+	w := csv.NewWriter(b)
+	if err := w.WriteAll(rows); err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(b.String(), "\n"), nil
+}
+
+// officeRelationships represents the relationships of a part of an office document.
+type officeRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// readRelationshipTargets returns the paths of the relationship targets of the part by their ids. The
+// targets are resolved relative to the directory of the part.
+func readRelationshipTargets(zr *zip.Reader, dir, relsName string) (map[string]string, error) {
+	rels := officeRelationships{}
+	if err := decodeZipFile(zr, relsName, &rels); err != nil {
+		return nil, err
+	}
+
+	targets := make(map[string]string, len(rels.Relationships))
+
+	for _, r := range rels.Relationships {
+		if strings.HasPrefix(r.Target, "/") {
+			targets[r.ID] = strings.TrimPrefix(r.Target, "/")
+		} else {
+			targets[r.ID] = path.Join(dir, r.Target)
+		}
+	}
+
+	return targets, nil
+}
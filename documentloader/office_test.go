@@ -0,0 +1,174 @@
+package documentloader
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// newZipReader creates an office document archive of the files.
+func newZipReader(t *testing.T, files map[string]string) (*bytes.Reader, int64) {
+	t.Helper()
+
+	b := new(bytes.Buffer)
+	zw := zip.NewWriter(b)
+
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, zw.Close())
+
+	return bytes.NewReader(b.Bytes()), int64(b.Len())
+}
+
+func TestDOCX(t *testing.T) {
+	r, size := newZipReader(t, map[string]string{
+		"word/document.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+  <w:body>
+    <w:p><w:r><w:t>Quarterly</w:t></w:r><w:r><w:t xml:space="preserve"> Report</w:t></w:r></w:p>
+    <w:p></w:p>
+    <w:tbl>
+      <w:tr><w:tc><w:p><w:r><w:t>Region</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>Revenue</w:t></w:r></w:p></w:tc></w:tr>
+      <w:tr><w:tc><w:p><w:r><w:t>North, East</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>100</w:t></w:r></w:p></w:tc></w:tr>
+    </w:tbl>
+    <w:p><w:r><w:t>Summary</w:t><w:tab/><w:t>Done</w:t></w:r></w:p>
+  </w:body>
+</w:document>`,
+	})
+
+	t.Run("Load", func(t *testing.T) {
+		docs, err := NewDOCX(r, size, func(o *DOCXOptions) {
+			o.Source = "report.docx"
+		}).Load(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []schema.Document{{
+			PageContent: "Quarterly Report\nRegion,Revenue\n\"North, East\",100\nSummary\tDone",
+			Metadata:    map[string]any{"source": "report.docx"},
+		}}, docs)
+	})
+
+	t.Run("IgnoreTables", func(t *testing.T) {
+		docs, err := NewDOCX(r, size, func(o *DOCXOptions) {
+			o.IgnoreTables = true
+		}).Load(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "Quarterly Report\nSummary\tDone", docs[0].PageContent)
+	})
+
+	t.Run("InvalidDocument", func(t *testing.T) {
+		r, size := newZipReader(t, map[string]string{"foo.xml": "<foo/>"})
+
+		_, err := NewDOCX(r, size).Load(context.Background())
+		require.ErrorContains(t, err, "cannot open word/document.xml")
+	})
+}
+
+func TestPPTX(t *testing.T) {
+	slide := func(text string) string {
+		return `<p:sld xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main">
+  <p:cSld><p:spTree><p:sp><p:txBody><a:p><a:r><a:t>` + text + `</a:t></a:r></a:p></p:txBody></p:sp></p:spTree></p:cSld>
+</p:sld>`
+	}
+
+	r, size := newZipReader(t, map[string]string{
+		"ppt/presentation.xml": `<p:presentation xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+  <p:sldIdLst><p:sldId id="256" r:id="rId3"/><p:sldId id="257" r:id="rId2"/></p:sldIdLst>
+</p:presentation>`,
+		"ppt/_rels/presentation.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Id="rId2" Target="slides/slide1.xml"/>
+  <Relationship Id="rId3" Target="slides/slide2.xml"/>
+</Relationships>`,
+		"ppt/slides/slide1.xml": slide("Second slide"),
+		"ppt/slides/slide2.xml": slide("First slide"),
+	})
+
+	docs, err := NewPPTX(r, size, func(o *PPTXOptions) {
+		o.Source = "deck.pptx"
+	}).Load(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []schema.Document{
+		{PageContent: "First slide", Metadata: map[string]any{"slide": 1, "source": "deck.pptx"}},
+		{PageContent: "Second slide", Metadata: map[string]any{"slide": 2, "source": "deck.pptx"}},
+	}, docs)
+}
+
+func TestXLSX(t *testing.T) {
+	r, size := newZipReader(t, map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+  <sheets><sheet name="Sales" sheetId="1" r:id="rId1"/><sheet name="Empty" sheetId="2" r:id="rId2"/></sheets>
+</workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Id="rId1" Target="worksheets/sheet1.xml"/>
+  <Relationship Id="rId2" Target="/xl/worksheets/sheet2.xml"/>
+</Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <si><t>Product</t></si><si><t>Price</t></si><si><r><t>Rich </t></r><r><t>Text</t></r></si>
+</sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <sheetData>
+    <row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>
+    <row r="2"><c r="A2" t="s"><v>2</v></c><c r="C2"><v>9.5</v></c></row>
+    <row r="3"><c r="B3" t="inlineStr"><is><t>Inline</t></is></c><c r="C3" t="b"><v>1</v></c></row>
+  </sheetData>
+</worksheet>`,
+		"xl/worksheets/sheet2.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData/></worksheet>`,
+	})
+
+	t.Run("Load", func(t *testing.T) {
+		docs, err := NewXLSX(r, size).Load(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []schema.Document{{
+			PageContent: "Product,Price\nRich Text,,9.5\n,Inline,TRUE",
+			Metadata:    map[string]any{"sheet": "Sales"},
+		}}, docs)
+	})
+
+	t.Run("TooManyColumns", func(t *testing.T) {
+		r, size := newZipReader(t, map[string]string{
+			"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+  <sheets><sheet name="Sales" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`,
+			"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Id="rId1" Target="worksheets/sheet1.xml"/>
+</Relationships>`,
+			"xl/worksheets/sheet1.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <sheetData><row r="1"><c r="ZZZZZZ1"><v>1</v></c></row></sheetData>
+</worksheet>`,
+		})
+
+		_, err := NewXLSX(r, size).Load(context.Background())
+		require.ErrorContains(t, err, "exceeds the maximum of 16384 columns")
+	})
+
+	t.Run("Sheets", func(t *testing.T) {
+		docs, err := NewXLSX(r, size, func(o *XLSXOptions) {
+			o.Sheets = []string{"Empty"}
+		}).Load(context.Background())
+		require.NoError(t, err)
+		require.Empty(t, docs)
+	})
+}
+
+func TestColumnIndex(t *testing.T) {
+	for ref, expected := range map[string]int{"A1": 0, "AB12": 27, "XFD1": 16383, "12": -1} {
+		index, err := columnIndex(ref)
+		require.NoError(t, err)
+		require.Equal(t, expected, index, ref)
+	}
+
+	for _, ref := range []string{"XFE1", "ZZZZZZZZZZZZZZZZ1"} {
+		_, err := columnIndex(ref)
+		require.Error(t, err, ref)
+	}
+}
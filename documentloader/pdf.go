@@ -163,3 +163,8 @@ func (l *PDF) LoadAndSplit(ctx context.Context, splitter schema.TextSplitter) ([
 
 	return splitter.SplitDocuments(docs)
 }
+
+// LazyLoad returns an iterator over the documents. The documents are loaded at once, because the document is read completely.
+func (l *PDF) LazyLoad(ctx context.Context) (schema.DocumentIterator, error) {
+	return lazyLoad(ctx, l.Load)
+}
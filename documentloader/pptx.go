@@ -0,0 +1,144 @@
+package documentloader
+
+import (
+	"archive/zip"
+	"context"
+	"io"
+	"os"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure PPTX satisfies the DocumentLoader interface.
+var _ schema.DocumentLoader = (*PPTX)(nil)
+
+// PPTXOptions contains options for configuring the PPTX loader.
+type PPTXOptions struct {
+	// IgnoreTables skips the contents of tables.
+	IgnoreTables bool
+
+	// Source is the name of the pptx document
+	Source string
+}
+
+// PPTX is a document loader for PPTX files. Each slide is loaded as a document with the slide number
+// in the metadata. Tables are rendered as CSV.
+type PPTX struct {
+	r    io.ReaderAt
+	size int64
+	opts PPTXOptions
+}
+
+// NewPPTX creates a new PPTX loader with the given reader and size of the presentation.
+func NewPPTX(r io.ReaderAt, size int64, optFns ...func(o *PPTXOptions)) *PPTX {
+	opts := PPTXOptions{
+		IgnoreTables: false,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &PPTX{
+		r:    r,
+		size: size,
+		opts: opts,
+	}
+}
+
+// NewPPTXFromFile creates a new PPTX loader with the given file.
+func NewPPTXFromFile(f *os.File, optFns ...func(o *PPTXOptions)) (*PPTX, error) {
+	opts := PPTXOptions{
+		IgnoreTables: false,
+		Source:       f.Name(),
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	finfo, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPPTX(f, finfo.Size(), func(o *PPTXOptions) {
+		*o = opts
+	}), nil
+}
+
+// pptxPresentation represents the presentation part of a PPTX document.
+type pptxPresentation struct {
+	Slides []struct {
+		RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sldIdLst>sldId"`
+}
+
+// Load reads the presentation and returns a document for each slide in the order of the presentation.
+func (l *PPTX) Load(ctx context.Context) ([]schema.Document, error) {
+	zr, err := zip.NewReader(l.r, l.size)
+	if err != nil {
+		return nil, err
+	}
+
+	presentation := pptxPresentation{}
+	if err := decodeZipFile(zr, "ppt/presentation.xml", &presentation); err != nil {
+		return nil, err
+	}
+
+	targets, err := readRelationshipTargets(zr, "ppt", "ppt/_rels/presentation.xml.rels")
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, 0, len(presentation.Slides))
+
+	for i, slide := range presentation.Slides {
+		text, err := l.extractSlideText(zr, targets[slide.RID])
+		if err != nil {
+			return nil, err
+		}
+
+		doc := schema.Document{
+			PageContent: text,
+			Metadata: map[string]any{
+				"slide": i + 1,
+			},
+		}
+
+		if l.opts.Source != "" {
+			doc.Metadata["source"] = l.opts.Source
+		}
+
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
+// LoadAndSplit loads the PPTX slides and splits them using the specified text splitter.
+func (l *PPTX) LoadAndSplit(ctx context.Context, splitter schema.TextSplitter) ([]schema.Document, error) {
+	docs, err := l.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return splitter.SplitDocuments(docs)
+}
+
+// LazyLoad returns an iterator over the documents. The documents are loaded at once, because the presentation is read completely.
+func (l *PPTX) LazyLoad(ctx context.Context) (schema.DocumentIterator, error) {
+	return lazyLoad(ctx, l.Load)
+}
+
+// extractSlideText extracts the text of the slide.
+func (l *PPTX) extractSlideText(zr *zip.Reader, name string) (string, error) {
+	f, err := openZipFile(zr, name)
+	if err != nil {
+		return "", err
+	}
+
+	defer f.Close()
+
+	return extractOfficeText(f, l.opts.IgnoreTables)
+}
@@ -45,3 +45,8 @@ func (l *Text) LoadAndSplit(ctx context.Context, splitter schema.TextSplitter) (
 
 	return splitter.SplitDocuments(docs)
 }
+
+// LazyLoad returns an iterator over the documents. The documents are loaded at once, because the text is read completely.
+func (l *Text) LazyLoad(ctx context.Context) (schema.DocumentIterator, error) {
+	return lazyLoad(ctx, l.Load)
+}
@@ -0,0 +1,73 @@
+package documentloader
+
+import (
+	"strings"
+	"time"
+
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/schema"
+)
+
+// defaultChunkDuration is the default duration of the chunks of transcripts.
+const defaultChunkDuration = time.Minute
+
+// chunkTranscript creates a document for each chunk of consecutive segments of the transcript, which
+// spans at most the chunk duration. Every chunk contains at least one segment. The offsets of the chunks
+// are added in seconds as start and end metadata. If the duration is not positive, or the transcript has
+// no segments, a single document with the full text is created.
+func chunkTranscript(transcript *schema.Transcript, chunkDuration time.Duration, metadata map[string]any) []schema.Document {
+	if len(transcript.Segments) == 0 {
+		if strings.TrimSpace(transcript.Text) == "" {
+			return []schema.Document{}
+		}
+
+		return []schema.Document{{
+			PageContent: strings.TrimSpace(transcript.Text),
+			Metadata:    util.MergeMaps(metadata),
+		}}
+	}
+
+	docs := []schema.Document{}
+
+	var (
+		texts []string
+		start time.Duration
+		end   time.Duration
+	)
+
+	flush := func() {
+		if len(texts) == 0 {
+			return
+		}
+
+		docs = append(docs, schema.Document{
+			PageContent: strings.Join(texts, " "),
+			Metadata: util.MergeMaps(metadata, map[string]any{
+				"start": start.Seconds(),
+				"end":   end.Seconds(),
+			}),
+		})
+
+		texts = nil
+	}
+
+	for _, segment := range transcript.Segments {
+		if len(texts) > 0 && chunkDuration > 0 && segment.End-start > chunkDuration {
+			flush()
+		}
+
+		if len(texts) == 0 {
+			start = segment.Start
+		}
+
+		if text := strings.TrimSpace(segment.Text); text != "" {
+			texts = append(texts, text)
+		}
+
+		end = segment.End
+	}
+
+	flush()
+
+	return docs
+}
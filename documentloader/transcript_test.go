@@ -0,0 +1,44 @@
+package documentloader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunkTranscript(t *testing.T) {
+	transcript := &schema.Transcript{
+		Text: "one two three four",
+		Segments: []schema.TranscriptSegment{
+			{Start: 0, End: 20 * time.Second, Text: " one"},
+			{Start: 20 * time.Second, End: 50 * time.Second, Text: "two "},
+			{Start: 50 * time.Second, End: 70 * time.Second, Text: "three"},
+			{Start: 70 * time.Second, End: 200 * time.Second, Text: "four"},
+		},
+	}
+
+	t.Run("Chunks", func(t *testing.T) {
+		docs := chunkTranscript(transcript, time.Minute, map[string]any{"source": "talk.mp3"})
+		assert.Equal(t, []schema.Document{
+			{PageContent: "one two", Metadata: map[string]any{"source": "talk.mp3", "start": 0.0, "end": 50.0}},
+			{PageContent: "three", Metadata: map[string]any{"source": "talk.mp3", "start": 50.0, "end": 70.0}},
+			{PageContent: "four", Metadata: map[string]any{"source": "talk.mp3", "start": 70.0, "end": 200.0}},
+		}, docs)
+	})
+
+	t.Run("Single Chunk", func(t *testing.T) {
+		docs := chunkTranscript(transcript, 0, nil)
+		assert.Equal(t, []schema.Document{
+			{PageContent: "one two three four", Metadata: map[string]any{"start": 0.0, "end": 200.0}},
+		}, docs)
+	})
+
+	t.Run("Without Segments", func(t *testing.T) {
+		docs := chunkTranscript(&schema.Transcript{Text: "one two "}, time.Minute, map[string]any{"source": "talk.mp3"})
+		assert.Equal(t, []schema.Document{
+			{PageContent: "one two", Metadata: map[string]any{"source": "talk.mp3"}},
+		}, docs)
+	})
+}
@@ -79,3 +79,8 @@ func (l *Unstructured) LoadAndSplit(ctx context.Context, splitter schema.TextSpl
 
 	return splitter.SplitDocuments(docs)
 }
+
+// LazyLoad returns an iterator over the documents. The documents are loaded at once, because the file is partitioned completely.
+func (l *Unstructured) LazyLoad(ctx context.Context) (schema.DocumentIterator, error) {
+	return lazyLoad(ctx, l.Load)
+}
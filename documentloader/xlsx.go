@@ -0,0 +1,279 @@
+package documentloader
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/schema"
+)
+
+// xlsxMaxColumns is the maximum number of columns of a worksheet.
+const xlsxMaxColumns = 16384
+
+// Compile time check to ensure XLSX satisfies the DocumentLoader interface.
+var _ schema.DocumentLoader = (*XLSX)(nil)
+
+// XLSXOptions contains options for configuring the XLSX loader.
+type XLSXOptions struct {
+	// Sheets are the names of the sheets to load. If empty, all sheets are loaded.
+	Sheets []string
+
+	// Source is the name of the xlsx document
+	Source string
+}
+
+// XLSX is a document loader for XLSX files. Each sheet is loaded as a document containing its rows as
+// CSV, with the sheet name in the metadata. Cells contain the stored values; formulas are not evaluated.
+type XLSX struct {
+	r    io.ReaderAt
+	size int64
+	opts XLSXOptions
+}
+
+// NewXLSX creates a new XLSX loader with the given reader and size of the workbook.
+func NewXLSX(r io.ReaderAt, size int64, optFns ...func(o *XLSXOptions)) *XLSX {
+	opts := XLSXOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &XLSX{
+		r:    r,
+		size: size,
+		opts: opts,
+	}
+}
+
+// NewXLSXFromFile creates a new XLSX loader with the given file.
+func NewXLSXFromFile(f *os.File, optFns ...func(o *XLSXOptions)) (*XLSX, error) {
+	opts := XLSXOptions{
+		Source: f.Name(),
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	finfo, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewXLSX(f, finfo.Size(), func(o *XLSXOptions) {
+		*o = opts
+	}), nil
+}
+
+// xlsxWorkbook represents the workbook part of a XLSX document.
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+// xlsxText represents a string of a XLSX document, which is either plain or composed of rich text runs.
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+// String returns the text of the string.
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+
+	b := new(strings.Builder)
+	for _, r := range t.Runs {
+		b.WriteString(r.T)
+	}
+
+	return b.String()
+}
+
+// xlsxSharedStrings represents the shared strings part of a XLSX document.
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+// xlsxWorksheet represents a worksheet part of a XLSX document.
+type xlsxWorksheet struct {
+	Rows []struct {
+		Cells []struct {
+			Ref          string    `xml:"r,attr"`
+			Type         string    `xml:"t,attr"`
+			Value        string    `xml:"v"`
+			InlineString *xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// Load reads the workbook and returns a document for each sheet. Empty sheets are skipped.
+func (l *XLSX) Load(ctx context.Context) ([]schema.Document, error) {
+	zr, err := zip.NewReader(l.r, l.size)
+	if err != nil {
+		return nil, err
+	}
+
+	workbook := xlsxWorkbook{}
+	if err := decodeZipFile(zr, "xl/workbook.xml", &workbook); err != nil {
+		return nil, err
+	}
+
+	targets, err := readRelationshipTargets(zr, "xl", "xl/_rels/workbook.xml.rels")
+	if err != nil {
+		return nil, err
+	}
+
+	sharedStrings := xlsxSharedStrings{}
+	if err := decodeZipFile(zr, "xl/sharedStrings.xml", &sharedStrings); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, 0, len(workbook.Sheets))
+
+	for _, sheet := range workbook.Sheets {
+		if len(l.opts.Sheets) > 0 && !util.Contains(l.opts.Sheets, sheet.Name) {
+			continue
+		}
+
+		worksheet := xlsxWorksheet{}
+		if err := decodeZipFile(zr, targets[sheet.RID], &worksheet); err != nil {
+			return nil, err
+		}
+
+		rows, err := worksheetRows(worksheet, sharedStrings)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sheet %s: %w", sheet.Name, err)
+		}
+
+		if len(rows) == 0 {
+			continue
+		}
+
+		text, err := formatCSV(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		doc := schema.Document{
+			PageContent: text,
+			Metadata: map[string]any{
+				"sheet": sheet.Name,
+			},
+		}
+
+		if l.opts.Source != "" {
+			doc.Metadata["source"] = l.opts.Source
+		}
+
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
+// LoadAndSplit loads the XLSX sheets and splits them using the specified text splitter.
+func (l *XLSX) LoadAndSplit(ctx context.Context, splitter schema.TextSplitter) ([]schema.Document, error) {
+	docs, err := l.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return splitter.SplitDocuments(docs)
+}
+
+// LazyLoad returns an iterator over the documents. The documents are loaded at once, because the workbook is read completely.
+func (l *XLSX) LazyLoad(ctx context.Context) (schema.DocumentIterator, error) {
+	return lazyLoad(ctx, l.Load)
+}
+
+// worksheetRows returns the cell values of the non-empty rows of the worksheet. The cells are placed in
+// the columns of their references, so empty cells are preserved.
+func worksheetRows(worksheet xlsxWorksheet, sharedStrings xlsxSharedStrings) ([][]string, error) {
+	rows := [][]string{}
+
+	for _, r := range worksheet.Rows {
+		row := []string{}
+
+		for i, c := range r.Cells {
+			column, err := columnIndex(c.Ref)
+			if err != nil {
+				return nil, err
+			}
+
+			if column < 0 {
+				column = i
+			}
+
+			if column >= xlsxMaxColumns {
+				return nil, fmt.Errorf("cell %d of row %d exceeds the maximum of %d columns", i+1, len(rows)+1, xlsxMaxColumns)
+			}
+
+			value := c.Value
+
+			switch c.Type {
+			case "s":
+				index, err := strconv.Atoi(c.Value)
+				if err != nil || index < 0 || index >= len(sharedStrings.Items) {
+					return nil, fmt.Errorf("invalid shared string index %q of cell %s", c.Value, c.Ref)
+				}
+
+				value = sharedStrings.Items[index].String()
+			case "inlineStr":
+				if c.InlineString != nil {
+					value = c.InlineString.String()
+				}
+			case "b":
+				value = strings.ToUpper(strconv.FormatBool(c.Value == "1"))
+			}
+
+			for len(row) <= column {
+				row = append(row, "")
+			}
+
+			row[column] = value
+		}
+
+		for len(row) > 0 && row[len(row)-1] == "" {
+			row = row[:len(row)-1]
+		}
+
+		if len(row) > 0 {
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, nil
+}
+
+// columnIndex returns the zero-based column index of a cell reference like B3, or -1 for a reference
+// without column. References beyond the last column XFD return an error.
+func columnIndex(ref string) (int, error) {
+	index := 0
+
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+
+		index = index*26 + int(r-'A'+1)
+		if index > xlsxMaxColumns {
+			return 0, fmt.Errorf("cell reference %q exceeds the maximum of %d columns", ref, xlsxMaxColumns)
+		}
+	}
+
+	return index - 1, nil
+}
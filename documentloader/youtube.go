@@ -0,0 +1,113 @@
+package documentloader
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hupe1980/golc/integration/youtube"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure YouTube satisfies the DocumentLoader interface.
+var _ schema.DocumentLoader = (*YouTube)(nil)
+
+// YouTubeClient is an interface representing the methods required for loading transcripts from YouTube.
+type YouTubeClient interface {
+	// GetTranscript returns the transcript of the video in the first available language.
+	GetTranscript(ctx context.Context, videoID string, languages ...string) (*youtube.Transcript, error)
+}
+
+// YouTubeOptions contains options for configuring the YouTube loader.
+type YouTubeOptions struct {
+	// Languages contains the preferred languages of the transcript in descending priority.
+	Languages []string
+
+	// ChunkDuration is the maximum duration of the chunks of the transcript. If zero, the whole transcript
+	// is loaded as a single document.
+	ChunkDuration time.Duration
+}
+
+// YouTube is a document loader, which loads the transcript of a YouTube video as time-stamped chunks.
+type YouTube struct {
+	client  YouTubeClient
+	videoID string
+	opts    YouTubeOptions
+}
+
+// NewYouTube creates a new YouTube loader for the video, which is given by its URL or id.
+func NewYouTube(client YouTubeClient, video string, optFns ...func(o *YouTubeOptions)) (*YouTube, error) {
+	videoID, err := youtube.ParseVideoID(video)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := YouTubeOptions{
+		Languages:     []string{"en"},
+		ChunkDuration: defaultChunkDuration,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &YouTube{
+		client:  client,
+		videoID: videoID,
+		opts:    opts,
+	}, nil
+}
+
+// Load loads the transcript of the video. The offsets of the chunks are added in seconds as start and end
+// metadata, and the url metadata links to the video at the start of the chunk.
+func (l *YouTube) Load(ctx context.Context) ([]schema.Document, error) {
+	transcript, err := l.client.GetTranscript(ctx, l.videoID, l.opts.Languages...)
+	if err != nil {
+		return nil, err
+	}
+
+	segments := make([]schema.TranscriptSegment, 0, len(transcript.Segments))
+
+	for _, s := range transcript.Segments {
+		segments = append(segments, schema.TranscriptSegment{
+			Start: s.Start,
+			End:   s.Start + s.Duration,
+			Text:  s.Text,
+		})
+	}
+
+	source := fmt.Sprintf("https://www.youtube.com/watch?v=%s", l.videoID)
+
+	docs := chunkTranscript(&schema.Transcript{Language: transcript.Language, Segments: segments}, l.opts.ChunkDuration, map[string]any{
+		"video_id": l.videoID,
+		"title":    transcript.Title,
+		"author":   transcript.Author,
+		"language": transcript.Language,
+		"source":   source,
+	})
+
+	for i := range docs {
+		if start, ok := docs[i].Metadata["start"].(float64); ok {
+			docs[i].Metadata["url"] = fmt.Sprintf("%s&t=%ds", source, int(start))
+		} else {
+			docs[i].Metadata["url"] = source
+		}
+	}
+
+	return docs, nil
+}
+
+// LoadAndSplit loads the transcript of the video and splits it using the specified text splitter.
+func (l *YouTube) LoadAndSplit(ctx context.Context, splitter schema.TextSplitter) ([]schema.Document, error) {
+	docs, err := l.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return splitter.SplitDocuments(docs)
+}
+
+// LazyLoad returns an iterator over the documents. The documents are loaded at once, because the transcript is requested completely.
+func (l *YouTube) LazyLoad(ctx context.Context) (schema.DocumentIterator, error) {
+	return lazyLoad(ctx, l.Load)
+}
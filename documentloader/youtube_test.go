@@ -0,0 +1,72 @@
+package documentloader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hupe1980/golc/integration/youtube"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYouTube(t *testing.T) {
+	client := &mockYouTubeClient{
+		transcript: &youtube.Transcript{
+			VideoID:  "dQw4w9WgXcQ",
+			Title:    "Talk",
+			Author:   "Golc",
+			Language: "en",
+			Segments: []youtube.Segment{
+				{Start: 0, Duration: 30 * time.Second, Text: "Hello"},
+				{Start: 30 * time.Second, Duration: 20 * time.Second, Text: "world"},
+				{Start: 90 * time.Second, Duration: 10 * time.Second, Text: "Bye"},
+			},
+		},
+	}
+
+	loader, err := NewYouTube(client, "https://youtu.be/dQw4w9WgXcQ", func(o *YouTubeOptions) {
+		o.Languages = []string{"de", "en"}
+	})
+	require.NoError(t, err)
+
+	docs, err := loader.Load(context.Background())
+	require.NoError(t, err)
+
+	metadata := func(start, end float64, url string) map[string]any {
+		return map[string]any{
+			"video_id": "dQw4w9WgXcQ",
+			"title":    "Talk",
+			"author":   "Golc",
+			"language": "en",
+			"source":   "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+			"start":    start,
+			"end":      end,
+			"url":      url,
+		}
+	}
+
+	assert.Equal(t, []schema.Document{
+		{PageContent: "Hello world", Metadata: metadata(0, 50, "https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=0s")},
+		{PageContent: "Bye", Metadata: metadata(90, 100, "https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=90s")},
+	}, docs)
+	assert.Equal(t, "dQw4w9WgXcQ", client.videoID)
+	assert.Equal(t, []string{"de", "en"}, client.languages)
+
+	_, err = NewYouTube(client, "https://example.com/video")
+	assert.Error(t, err)
+}
+
+type mockYouTubeClient struct {
+	transcript *youtube.Transcript
+	videoID    string
+	languages  []string
+}
+
+func (m *mockYouTubeClient) GetTranscript(ctx context.Context, videoID string, languages ...string) (*youtube.Transcript, error) {
+	m.videoID = videoID
+	m.languages = languages
+
+	return m.transcript, nil
+}
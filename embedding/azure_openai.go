@@ -1,15 +1,23 @@
 package embedding
 
 import (
+	"github.com/hupe1980/golc/integration"
+	"github.com/hupe1980/golc/internal/retry"
 	"github.com/sashabaranov/go-openai"
 )
 
+// AzureOpenAIOptions contains the options for the Azure OpenAI embedder.
 type AzureOpenAIOptions struct {
 	OpenAIOptions
+	// APIVersion is the version of the Azure OpenAI API.
 	APIVersion string
+	// Deployment is the name of the model deployment.
 	Deployment string
+	// TokenProvider provides Azure AD access tokens. If set, it is used instead of the api key.
+	TokenProvider integration.AzureADTokenProvider
 }
 
+// NewAzureOpenAI creates a new instance of the OpenAI embedder for the Azure OpenAI resource at the given endpoint.
 func NewAzureOpenAI(apiKey, baseURL string, optFns ...func(o *AzureOpenAIOptions)) *OpenAI {
 	opts := AzureOpenAIOptions{
 		OpenAIOptions: DefaultOpenAIConfig,
@@ -19,20 +27,13 @@ func NewAzureOpenAI(apiKey, baseURL string, optFns ...func(o *AzureOpenAIOptions
 		fn(&opts)
 	}
 
-	config := openai.DefaultAzureConfig(apiKey, baseURL)
-	if opts.Deployment != "" {
-		config.AzureModelMapperFunc = func(model string) string {
-			azureModelMapping := map[string]string{
-				opts.ModelName: opts.Deployment,
-			}
-
-			return azureModelMapping[model]
-		}
-	}
+	config := integration.NewAzureOpenAIConfig(apiKey, baseURL, func(o *integration.AzureOpenAIConfigOptions) {
+		o.Deployment = opts.Deployment
+		o.APIVersion = opts.APIVersion
+		o.TokenProvider = opts.TokenProvider
+	})
 
-	if opts.APIVersion != "" {
-		config.APIVersion = opts.APIVersion
-	}
+	config.HTTPClient = retry.NewHTTPClient(config.HTTPClient)
 
 	client := openai.NewClientWithConfig(config)
 
@@ -0,0 +1,149 @@
+package embedding
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/hupe1980/golc/internal/retry"
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/ratelimit"
+	"github.com/hupe1980/golc/schema"
+	"golang.org/x/sync/errgroup"
+)
+
+// Compile time check to ensure BatchEmbedder satisfies the Embedder interface.
+var _ schema.Embedder = (*BatchEmbedder)(nil)
+
+// BatchEmbedderOptions contains options for configuring the BatchEmbedder.
+type BatchEmbedderOptions struct {
+	// BatchSize is the maximum number of texts embedded by one call of the wrapped embedder.
+	BatchSize int
+	// MaxConcurrency is the maximum number of batches embedded concurrently.
+	MaxConcurrency int
+	// MaxRetries is the maximum number of attempts to embed a batch. A value <= 1 disables retries.
+	MaxRetries uint
+	// Limiter limits the calls of the wrapped embedder. Share a limiter, e.g. with ratelimit.Shared, with
+	// the models of the same provider. If nil, the calls are not limited.
+	Limiter *ratelimit.Limiter
+	// IsRetryable reports whether a failed batch is retried. By default, all errors except
+	// the cancellation of the context are retried.
+	IsRetryable func(err error) bool
+	// OnProgress is called after each embedded batch with the number of embedded texts and the
+	// total number of texts. The calls are not concurrent.
+	OnProgress func(done, total int)
+}
+
+// BatchEmbedder wraps an embedder to embed large sets of texts. The texts are split into batches,
+// which are embedded concurrently, rate limited and retried on failures.
+type BatchEmbedder struct {
+	embedder schema.Embedder
+	opts     BatchEmbedderOptions
+}
+
+// cacheNamespace returns the cache namespace of the wrapped embedder.
+func (e *BatchEmbedder) cacheNamespace() string {
+	return cacheNamespace(e.embedder)
+}
+
+// NewBatchEmbedder creates a new instance of the BatchEmbedder wrapping the given embedder.
+func NewBatchEmbedder(embedder schema.Embedder, optFns ...func(o *BatchEmbedderOptions)) *BatchEmbedder {
+	opts := BatchEmbedderOptions{
+		BatchSize:      100,
+		MaxConcurrency: 5,
+		MaxRetries:     3,
+		IsRetryable:    isRetryableEmbeddingError,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &BatchEmbedder{
+		embedder: embedder,
+		opts:     opts,
+	}
+}
+
+// BatchEmbedText embeds a list of texts in batches and returns their embeddings in the order of the texts.
+func (e *BatchEmbedder) BatchEmbedText(ctx context.Context, texts []string) ([][]float32, error) {
+	batchSize := e.opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = len(texts)
+	}
+
+	embeddings := make([][]float32, len(texts))
+
+	var (
+		mu   sync.Mutex
+		done int
+	)
+
+	errs, errctx := errgroup.WithContext(ctx)
+
+	if e.opts.MaxConcurrency > 0 {
+		errs.SetLimit(e.opts.MaxConcurrency)
+	}
+
+	for start := 0; start < len(texts); start += batchSize {
+		start, end := start, util.Min(start+batchSize, len(texts))
+
+		errs.Go(func() error {
+			batch, err := withRetry(errctx, e, func(ctx context.Context) ([][]float32, error) {
+				return e.embedder.BatchEmbedText(ctx, texts[start:end])
+			})
+			if err != nil {
+				return err
+			}
+
+			if len(batch) != end-start {
+				return errors.New("number of embeddings does not match the number of texts")
+			}
+
+			copy(embeddings[start:end], batch)
+
+			if e.opts.OnProgress != nil {
+				mu.Lock()
+				defer mu.Unlock()
+
+				done += end - start
+				e.opts.OnProgress(done, len(texts))
+			}
+
+			return nil
+		})
+	}
+
+	if err := errs.Wait(); err != nil {
+		return nil, err
+	}
+
+	return embeddings, nil
+}
+
+// EmbedText embeds a single text and returns its embedding.
+func (e *BatchEmbedder) EmbedText(ctx context.Context, text string) ([]float32, error) {
+	return withRetry(ctx, e, func(ctx context.Context) ([]float32, error) {
+		return e.embedder.EmbedText(ctx, text)
+	})
+}
+
+// withRetry calls fn after waiting for the rate limiter and retries it according to the options.
+func withRetry[T any](ctx context.Context, e *BatchEmbedder, fn func(ctx context.Context) (T, error)) (T, error) {
+	policy := retry.NewPolicy(e.opts.MaxRetries, e.opts.IsRetryable)
+
+	return retry.Do(ctx, policy, func(ctx context.Context) (T, error) {
+		if err := e.opts.Limiter.Wait(ctx); err != nil {
+			var zero T
+			return zero, err
+		}
+
+		return fn(ctx)
+	})
+}
+
+// isRetryableEmbeddingError reports whether a failed batch is retried. Only the cancellation of the
+// context is not retried, because the wrapped embedders do not share an error type.
+func isRetryableEmbeddingError(err error) bool {
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
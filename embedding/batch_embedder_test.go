@@ -0,0 +1,123 @@
+package embedding
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hupe1980/golc/ratelimit"
+)
+
+func TestBatchEmbedder(t *testing.T) {
+	texts := []string{"a", "bb", "ccc", "dddd", "eeeee"}
+
+	t.Run("BatchEmbedText", func(t *testing.T) {
+		embedder := &lengthEmbedderMock{}
+
+		var progress []int
+
+		batchEmbedder := NewBatchEmbedder(embedder, func(o *BatchEmbedderOptions) {
+			o.BatchSize = 2
+			o.MaxConcurrency = 1
+			o.OnProgress = func(done, total int) {
+				assert.Equal(t, 5, total)
+
+				progress = append(progress, done)
+			}
+		})
+
+		result, err := batchEmbedder.BatchEmbedText(context.Background(), texts)
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float32{{1}, {2}, {3}, {4}, {5}}, result)
+		assert.Equal(t, [][]string{{"a", "bb"}, {"ccc", "dddd"}, {"eeeee"}}, embedder.batches)
+		assert.Equal(t, []int{2, 4, 5}, progress)
+	})
+
+	t.Run("Concurrency", func(t *testing.T) {
+		embedder := &lengthEmbedderMock{}
+
+		batchEmbedder := NewBatchEmbedder(embedder, func(o *BatchEmbedderOptions) {
+			o.BatchSize = 1
+		})
+
+		result, err := batchEmbedder.BatchEmbedText(context.Background(), texts)
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float32{{1}, {2}, {3}, {4}, {5}}, result)
+		assert.Len(t, embedder.batches, 5)
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		embedder := &lengthEmbedderMock{failures: 1}
+
+		batchEmbedder := NewBatchEmbedder(embedder, func(o *BatchEmbedderOptions) {
+			o.MaxRetries = 2
+		})
+
+		result, err := batchEmbedder.BatchEmbedText(context.Background(), texts[:2])
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float32{{1}, {2}}, result)
+		assert.Len(t, embedder.batches, 2)
+	})
+
+	t.Run("NotRetryable", func(t *testing.T) {
+		embedder := &lengthEmbedderMock{failures: 1}
+
+		batchEmbedder := NewBatchEmbedder(embedder, func(o *BatchEmbedderOptions) {
+			o.IsRetryable = func(err error) bool { return false }
+		})
+
+		result, err := batchEmbedder.BatchEmbedText(context.Background(), texts)
+		assert.EqualError(t, err, "transient error")
+		assert.Nil(t, result)
+	})
+
+	t.Run("EmbedText", func(t *testing.T) {
+		batchEmbedder := NewBatchEmbedder(&lengthEmbedderMock{}, func(o *BatchEmbedderOptions) {
+			o.Limiter = ratelimit.New(func(o *ratelimit.Options) {
+				o.RequestsPerMinute = 600
+			})
+		})
+
+		result, err := batchEmbedder.EmbedText(context.Background(), "abc")
+		assert.NoError(t, err)
+		assert.Equal(t, []float32{3}, result)
+	})
+}
+
+// lengthEmbedderMock embeds each text by its length and fails the first calls, if failures is set.
+type lengthEmbedderMock struct {
+	mu       sync.Mutex
+	failures int
+	batches  [][]string
+}
+
+func (m *lengthEmbedderMock) BatchEmbedText(ctx context.Context, texts []string) ([][]float32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.batches = append(m.batches, texts)
+
+	if m.failures > 0 {
+		m.failures--
+		return nil, errors.New("transient error")
+	}
+
+	embeddings := make([][]float32, len(texts))
+	for i, text := range texts {
+		embeddings[i] = []float32{float32(len(text))}
+	}
+
+	return embeddings, nil
+}
+
+func (m *lengthEmbedderMock) EmbedText(ctx context.Context, text string) ([]float32, error) {
+	embeddings, err := m.BatchEmbedText(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+
+	return embeddings[0], nil
+}
@@ -3,11 +3,13 @@ package embedding
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/hupe1980/golc/internal/util"
 	"github.com/hupe1980/golc/schema"
 	"golang.org/x/sync/errgroup"
 )
@@ -29,22 +31,36 @@ func NewBedrockInputOutputAdapter(provider string) *BedrockInputOutputAdapter {
 
 // PrepareInput prepares the input for the Bedrock model based on the specified provider.
 func (bioa *BedrockInputOutputAdapter) PrepareInput(text string, modelParams map[string]any) ([]byte, error) {
-	var body map[string]any
+	return bioa.prepareBatchInput([]string{text}, modelParams)
+}
 
-	text = removeNewLines(text)
+// prepareBatchInput prepares the input of multiple texts. Only the Cohere provider supports more
+// than one text per request.
+func (bioa *BedrockInputOutputAdapter) prepareBatchInput(texts []string, modelParams map[string]any) ([]byte, error) {
+	// The model params are copied, because they are shared by concurrent requests.
+	body := make(map[string]any, len(modelParams)+1)
+	for k, v := range modelParams {
+		body[k] = v
+	}
+
+	inputs := make([]string, len(texts))
+	for i, text := range texts {
+		inputs[i] = removeNewLines(text)
+	}
 
 	switch bioa.provider {
 	case "amazon":
-		body = make(map[string]any)
-		body["inputText"] = text
-	case "cohere":
-		body = modelParams
+		if len(inputs) != 1 {
+			return nil, fmt.Errorf("provider amazon supports only one text per request, got %d", len(inputs))
+		}
 
+		body["inputText"] = inputs[0]
+	case "cohere":
 		if _, ok := body["input_type"]; !ok {
 			body["input_type"] = "search_document"
 		}
 
-		body["texts"] = []string{text}
+		body["texts"] = inputs
 	default:
 		return nil, fmt.Errorf("unsupported provider: %s", bioa.provider)
 	}
@@ -59,11 +75,25 @@ type amazonOutput struct {
 
 // cohereOutput represents the expected JSON output structure from the Bedrock model for the Cohere provider.
 type cohereOutput struct {
-	Embeddings []float32 `json:"embeddings"`
+	Embeddings [][]float32 `json:"embeddings"`
 }
 
 // PrepareOutput prepares the output for the Bedrock model based on the specified provider.
 func (bioa *BedrockInputOutputAdapter) PrepareOutput(response []byte) ([]float32, error) {
+	embeddings, err := bioa.prepareBatchOutput(response)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(embeddings) == 0 {
+		return nil, errors.New("no embedding returned")
+	}
+
+	return embeddings[0], nil
+}
+
+// prepareBatchOutput prepares the output of a request with multiple texts.
+func (bioa *BedrockInputOutputAdapter) prepareBatchOutput(response []byte) ([][]float32, error) {
 	switch bioa.provider {
 	case "amazon":
 		output := &amazonOutput{}
@@ -71,7 +101,7 @@ func (bioa *BedrockInputOutputAdapter) PrepareOutput(response []byte) ([]float32
 			return nil, err
 		}
 
-		return output.Embedding, nil
+		return [][]float32{output.Embedding}, nil
 	case "cohere":
 		output := &cohereOutput{}
 		if err := json.Unmarshal(response, output); err != nil {
@@ -93,6 +123,12 @@ type BedrockRuntimeClient interface {
 type BedrockAmazonOptions struct {
 	// Model id to use.
 	ModelID string `map:"model_id,omitempty"`
+	// Dimensions is the number of dimensions of the embeddings (256, 512 or 1024). It is only supported
+	// by the Titan Text Embeddings V2 model. A value of 0 uses the default dimension of the model.
+	Dimensions int `map:"dimensions,omitempty"`
+	// Normalize normalizes the embeddings. It is only supported by the Titan Text Embeddings V2 model.
+	// If nil, the default of the model is used.
+	Normalize *bool `map:"normalize,omitempty"`
 }
 
 // NewBedrockAmazon creates a new instance of Bedrock with the Amazon provider.
@@ -105,25 +141,36 @@ func NewBedrockAmazon(client BedrockRuntimeClient, optFns ...func(o *BedrockAmaz
 		fn(&opts)
 	}
 
-	return NewBedrock(client, opts.ModelID)
+	return NewBedrock(client, opts.ModelID, func(o *BedrockOptions) {
+		if opts.Dimensions > 0 {
+			o.ModelParams["dimensions"] = opts.Dimensions
+		}
+
+		if opts.Normalize != nil {
+			o.ModelParams["normalize"] = *opts.Normalize
+		}
+	})
 }
 
 // BedrockCohereOptions is a struct containing options for configuring the Cohere Bedrock model.
 type BedrockCohereOptions struct {
 	// Model id to use.
 	ModelID string `map:"model_id,omitempty"`
-
+	// InputType is the input type of the texts embedded by BatchEmbedText.
 	InputType string `map:"input_type"`
-
+	// QueryInputType is the input type of the text embedded by EmbedText.
+	QueryInputType string `map:"query_input_type"`
+	// Truncate embeddings that are too long from start or end ("NONE"|"START"|"END")
 	Truncate string `map:"truncate"`
 }
 
 // NewBedrockCohere creates a new instance of Bedrock with the Cohere provider.
 func NewBedrockCohere(client BedrockRuntimeClient, optFns ...func(o *BedrockCohereOptions)) *Bedrock {
 	opts := BedrockCohereOptions{
-		ModelID:   "cohere.embed-english-v3",
-		InputType: "search_document",
-		Truncate:  "NONE",
+		ModelID:        "cohere.embed-english-v3",
+		InputType:      "search_document",
+		QueryInputType: "search_query",
+		Truncate:       "NONE",
 	}
 
 	for _, fn := range optFns {
@@ -131,19 +178,30 @@ func NewBedrockCohere(client BedrockRuntimeClient, optFns ...func(o *BedrockCohe
 	}
 
 	return NewBedrock(client, opts.ModelID, func(o *BedrockOptions) {
-		o.ModelParams = map[string]interface{}{
+		o.ModelParams = map[string]any{
 			"input_type": opts.InputType,
 			"truncate":   opts.Truncate,
 		}
+		o.QueryModelParams = map[string]any{
+			"input_type": opts.QueryInputType,
+			"truncate":   opts.Truncate,
+		}
 	})
 }
 
 // BedrockOptions contains options for configuring the Bedrock model.
 type BedrockOptions struct {
+	// MaxConcurrency is the maximum number of concurrent requests. A value <= 0 means no limit.
 	MaxConcurrency int
+	// BatchSize is the maximum number of texts embedded by one request. It applies to the Cohere
+	// models only, the Amazon models embed one text per request.
+	BatchSize int
 
 	// Model params to use.
 	ModelParams map[string]any `map:"model_params,omitempty"`
+	// QueryModelParams are the model params used by EmbedText to embed a query. If nil, the
+	// ModelParams are used.
+	QueryModelParams map[string]any `map:"query_model_params,omitempty"`
 }
 
 // Bedrock is a struct representing the Bedrock model embedding functionality.
@@ -160,6 +218,7 @@ type Bedrock struct {
 func NewBedrock(client BedrockRuntimeClient, modelID string, optFns ...func(o *BedrockOptions)) *Bedrock {
 	opts := BedrockOptions{
 		MaxConcurrency: 5,
+		BatchSize:      96,
 		ModelParams:    make(map[string]any),
 	}
 
@@ -174,24 +233,39 @@ func NewBedrock(client BedrockRuntimeClient, modelID string, optFns ...func(o *B
 	}
 }
 
+// cacheNamespace returns the cache namespace of the model and its parameters.
+func (e *Bedrock) cacheNamespace() string {
+	return modelCacheNamespace(e, e.modelID, map[string]any{
+		"model_params":       e.opts.ModelParams,
+		"query_model_params": e.opts.QueryModelParams,
+	})
+}
+
 // BatchEmbedText embeds a list of texts and returns their embeddings.
 func (e *Bedrock) BatchEmbedText(ctx context.Context, texts []string) ([][]float32, error) {
+	batchSize := 1
+	if e.getProvider() == "cohere" && e.opts.BatchSize > 1 {
+		batchSize = e.opts.BatchSize
+	}
+
 	errs, errctx := errgroup.WithContext(ctx)
 
-	errs.SetLimit(e.opts.MaxConcurrency)
+	if e.opts.MaxConcurrency > 0 {
+		errs.SetLimit(e.opts.MaxConcurrency)
+	}
 
 	embeddings := make([][]float32, len(texts))
 
-	for i, text := range texts {
-		i, text := i, text
+	for start := 0; start < len(texts); start += batchSize {
+		start, end := start, util.Min(start+batchSize, len(texts))
 
 		errs.Go(func() error {
-			embedding, err := e.EmbedText(errctx, text)
+			batch, err := e.embed(errctx, texts[start:end], e.opts.ModelParams)
 			if err != nil {
 				return err
 			}
 
-			embeddings[i] = embedding
+			copy(embeddings[start:end], batch)
 
 			return nil
 		})
@@ -206,9 +280,24 @@ func (e *Bedrock) BatchEmbedText(ctx context.Context, texts []string) ([][]float
 
 // EmbedText embeds a single text and returns its embedding.
 func (e *Bedrock) EmbedText(ctx context.Context, text string) ([]float32, error) {
+	modelParams := e.opts.ModelParams
+	if e.opts.QueryModelParams != nil {
+		modelParams = e.opts.QueryModelParams
+	}
+
+	embeddings, err := e.embed(ctx, []string{text}, modelParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return embeddings[0], nil
+}
+
+// embed embeds the texts with a single request.
+func (e *Bedrock) embed(ctx context.Context, texts []string, modelParams map[string]any) ([][]float32, error) {
 	bioa := NewBedrockInputOutputAdapter(e.getProvider())
 
-	body, err := bioa.PrepareInput(text, e.opts.ModelParams)
+	body, err := bioa.prepareBatchInput(texts, modelParams)
 	if err != nil {
 		return nil, err
 	}
@@ -223,10 +312,29 @@ func (e *Bedrock) EmbedText(ctx context.Context, text string) ([]float32, error)
 		return nil, err
 	}
 
-	return bioa.PrepareOutput(res.Body)
+	embeddings, err := bioa.prepareBatchOutput(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(embeddings) != len(texts) {
+		return nil, fmt.Errorf("number of embeddings does not match the number of texts: got %d, want %d", len(embeddings), len(texts))
+	}
+
+	return embeddings, nil
 }
 
-// getProvider returns the provider of the model based on the model ID.
+// getProvider returns the provider of the model based on the model ID. The region prefix of the
+// cross-region inference profiles is skipped.
 func (e *Bedrock) getProvider() string {
-	return strings.Split(e.modelID, ".")[0]
+	parts := strings.Split(e.modelID, ".")
+
+	if len(parts) > 2 {
+		switch parts[0] {
+		case "us", "eu", "apac", "us-gov":
+			return parts[1]
+		}
+	}
+
+	return parts[0]
 }
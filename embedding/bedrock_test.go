@@ -2,11 +2,15 @@ package embedding
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"sync"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBedrock(t *testing.T) {
@@ -74,15 +78,76 @@ func TestBedrock(t *testing.T) {
 			assert.Nil(t, embedding, "Expected nil embedding")
 		})
 	})
+
+	t.Run("Cohere", func(t *testing.T) {
+		client := &mockBedrockRuntimeClient{
+			response: &bedrockruntime.InvokeModelOutput{
+				Body: []byte(`{"embeddings": [[1.0, 2.0], [3.0, 4.0]], "id": "1", "texts": ["text1", "text2"]}`),
+			},
+		}
+		embedder := NewBedrockCohere(client, func(o *BedrockCohereOptions) {
+			o.ModelID = "us.cohere.embed-english-v3"
+		})
+
+		embeddings, err := embedder.BatchEmbedText(context.Background(), []string{"text1", "text2"})
+		require.NoError(t, err)
+		assert.Equal(t, [][]float32{{1.0, 2.0}, {3.0, 4.0}}, embeddings)
+
+		require.Len(t, client.bodies, 1)
+		assert.Equal(t, []any{"text1", "text2"}, client.bodies[0]["texts"])
+		assert.Equal(t, "search_document", client.bodies[0]["input_type"])
+
+		client.response = &bedrockruntime.InvokeModelOutput{
+			Body: []byte(`{"embeddings": [[1.0, 2.0]], "id": "2", "texts": ["query"]}`),
+		}
+
+		embedding, err := embedder.EmbedText(context.Background(), "query")
+		require.NoError(t, err)
+		assert.Equal(t, []float32{1.0, 2.0}, embedding)
+		assert.Equal(t, "search_query", client.bodies[1]["input_type"])
+	})
+
+	t.Run("TitanV2", func(t *testing.T) {
+		client := &mockBedrockRuntimeClient{
+			response: &bedrockruntime.InvokeModelOutput{
+				Body: []byte(`{"embedding": [1.0, 2.0], "inputTextTokenCount": 1}`),
+			},
+		}
+		embedder := NewBedrockAmazon(client, func(o *BedrockAmazonOptions) {
+			o.ModelID = "amazon.titan-embed-text-v2:0"
+			o.Dimensions = 256
+			o.Normalize = aws.Bool(true)
+		})
+
+		embeddings, err := embedder.BatchEmbedText(context.Background(), []string{"text1", "text2", "text3"})
+		require.NoError(t, err)
+		assert.Len(t, embeddings, 3)
+
+		require.Len(t, client.bodies, 3)
+		assert.Equal(t, float64(256), client.bodies[0]["dimensions"])
+		assert.Equal(t, true, client.bodies[0]["normalize"])
+		assert.NotContains(t, client.bodies[0], "texts")
+	})
 }
 
 // mockBedrockRuntimeClient is a mock implementation of BedrockRuntimeClient for testing.
 type mockBedrockRuntimeClient struct {
 	response *bedrockruntime.InvokeModelOutput
 	err      error
+	mu       sync.Mutex
+	bodies   []map[string]any
 }
 
 func (m *mockBedrockRuntimeClient) InvokeModel(ctx context.Context, params *bedrockruntime.InvokeModelInput, optFns ...func(*bedrockruntime.Options)) (*bedrockruntime.InvokeModelOutput, error) {
+	body := map[string]any{}
+	if err := json.Unmarshal(params.Body, &body); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	m.bodies = append(m.bodies, body)
+	m.mu.Unlock()
+
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -0,0 +1,166 @@
+package embedding
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure CacheBacked satisfies the Embedder interface.
+var _ schema.Embedder = (*CacheBacked)(nil)
+
+// CacheBackedOptions contains options for configuring the CacheBacked embedder.
+type CacheBackedOptions struct {
+	// Namespace is part of the cache keys to separate the embeddings of different models. Defaults to the
+	// type, the model name and a hash of the parameters changing the embeddings, e.g. the dimensions, of
+	// the embedders of this package, e.g. embedding.OpenAI:text-embedding-3-small:1f2e3d4c5b6a7988. Set it
+	// for other embedders, which are only separated by their type.
+	Namespace string
+	// CacheQueries enables the caching of the embeddings of single texts, e.g. search queries.
+	CacheQueries bool
+}
+
+// CacheBacked wraps an embedder to cache the embeddings in a key-value store. The embeddings are
+// stored under the hash of the text, so identical texts are embedded only once.
+type CacheBacked struct {
+	embedder schema.Embedder
+	store    schema.ByteStore
+	opts     CacheBackedOptions
+}
+
+// NewCacheBacked creates a new instance of the CacheBacked embedder.
+func NewCacheBacked(embedder schema.Embedder, store schema.ByteStore, optFns ...func(o *CacheBackedOptions)) *CacheBacked {
+	opts := CacheBackedOptions{
+		Namespace: cacheNamespace(embedder),
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &CacheBacked{
+		embedder: embedder,
+		store:    store,
+		opts:     opts,
+	}
+}
+
+// BatchEmbedText embeds a list of texts and returns their embeddings. Only the texts without a
+// cached embedding are embedded by the wrapped embedder.
+func (e *CacheBacked) BatchEmbedText(ctx context.Context, texts []string) ([][]float32, error) {
+	keys := make([]string, len(texts))
+	for i, text := range texts {
+		keys[i] = e.key(text)
+	}
+
+	values, err := e.store.MGet(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	embeddings := make([][]float32, len(texts))
+
+	// Identical texts are embedded once.
+	missing := make(map[string][]int)
+	missingTexts := []string{}
+	missingKeys := []string{}
+
+	for i, value := range values {
+		if value != nil {
+			embedding, err := decodeEmbedding(value)
+			if err != nil {
+				return nil, err
+			}
+
+			embeddings[i] = embedding
+
+			continue
+		}
+
+		if _, ok := missing[keys[i]]; !ok {
+			missingTexts = append(missingTexts, texts[i])
+			missingKeys = append(missingKeys, keys[i])
+		}
+
+		missing[keys[i]] = append(missing[keys[i]], i)
+	}
+
+	if len(missingTexts) == 0 {
+		return embeddings, nil
+	}
+
+	newEmbeddings, err := e.embedder.BatchEmbedText(ctx, missingTexts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(newEmbeddings) != len(missingTexts) {
+		return nil, fmt.Errorf("number of embeddings does not match the number of texts: got %d, want %d", len(newEmbeddings), len(missingTexts))
+	}
+
+	newValues := make([][]byte, len(newEmbeddings))
+
+	for i, embedding := range newEmbeddings {
+		for _, j := range missing[missingKeys[i]] {
+			embeddings[j] = embedding
+		}
+
+		newValues[i] = encodeEmbedding(embedding)
+	}
+
+	if err := e.store.MSet(ctx, missingKeys, newValues); err != nil {
+		return nil, err
+	}
+
+	return embeddings, nil
+}
+
+// EmbedText embeds a single text and returns its embedding. The embedding is only cached, if
+// CacheQueries is enabled.
+func (e *CacheBacked) EmbedText(ctx context.Context, text string) ([]float32, error) {
+	if !e.opts.CacheQueries {
+		return e.embedder.EmbedText(ctx, text)
+	}
+
+	embeddings, err := e.BatchEmbedText(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+
+	return embeddings[0], nil
+}
+
+// key returns the cache key of the text, which consists of the namespace and the hash of the text.
+func (e *CacheBacked) key(text string) string {
+	hash := sha256.Sum256([]byte(text))
+	return e.opts.Namespace + ":" + hex.EncodeToString(hash[:])
+}
+
+// encodeEmbedding encodes the embedding as little-endian float32 values.
+func encodeEmbedding(embedding []float32) []byte {
+	b := make([]byte, 4*len(embedding))
+	for i, v := range embedding {
+		binary.LittleEndian.PutUint32(b[4*i:], math.Float32bits(v))
+	}
+
+	return b
+}
+
+// decodeEmbedding decodes the little-endian float32 values of an embedding.
+func decodeEmbedding(b []byte) ([]float32, error) {
+	if len(b)%4 != 0 {
+		return nil, fmt.Errorf("invalid cached embedding of length %d", len(b))
+	}
+
+	embedding := make([]float32, len(b)/4)
+	for i := range embedding {
+		embedding[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[4*i:]))
+	}
+
+	return embedding, nil
+}
@@ -0,0 +1,107 @@
+package embedding
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hupe1980/golc/kvstore"
+)
+
+func TestCacheBacked(t *testing.T) {
+	t.Run("BatchEmbedText", func(t *testing.T) {
+		embedder := &lengthEmbedderMock{}
+		store := kvstore.NewInMemory()
+
+		cacheBacked := NewCacheBacked(embedder, store, func(o *CacheBackedOptions) {
+			o.Namespace = "model"
+		})
+
+		result, err := cacheBacked.BatchEmbedText(context.Background(), []string{"a", "bb", "a"})
+		require.NoError(t, err)
+		assert.Equal(t, [][]float32{{1}, {2}, {1}}, result)
+		assert.Equal(t, [][]string{{"a", "bb"}}, embedder.batches)
+
+		result, err = cacheBacked.BatchEmbedText(context.Background(), []string{"bb", "ccc"})
+		require.NoError(t, err)
+		assert.Equal(t, [][]float32{{2}, {3}}, result)
+		assert.Equal(t, [][]string{{"a", "bb"}, {"ccc"}}, embedder.batches)
+
+		// Another namespace does not share the cached embeddings.
+		other := NewCacheBacked(embedder, store, func(o *CacheBackedOptions) {
+			o.Namespace = "other-model"
+		})
+
+		_, err = other.BatchEmbedText(context.Background(), []string{"a"})
+		require.NoError(t, err)
+		assert.Len(t, embedder.batches, 3)
+	})
+
+	t.Run("EmbedText", func(t *testing.T) {
+		embedder := &lengthEmbedderMock{}
+
+		cacheBacked := NewCacheBacked(embedder, kvstore.NewInMemory())
+
+		for i := 0; i < 2; i++ {
+			result, err := cacheBacked.EmbedText(context.Background(), "abc")
+			require.NoError(t, err)
+			assert.Equal(t, []float32{3}, result)
+		}
+
+		assert.Len(t, embedder.batches, 2)
+
+		embedder = &lengthEmbedderMock{}
+
+		cacheBacked = NewCacheBacked(embedder, kvstore.NewInMemory(), func(o *CacheBackedOptions) {
+			o.CacheQueries = true
+		})
+
+		for i := 0; i < 2; i++ {
+			result, err := cacheBacked.EmbedText(context.Background(), "abc")
+			require.NoError(t, err)
+			assert.Equal(t, []float32{3}, result)
+		}
+
+		assert.Len(t, embedder.batches, 1)
+	})
+
+	t.Run("DefaultNamespace", func(t *testing.T) {
+		small := NewOpenAIFromClient(nil)
+		namespace := NewCacheBacked(small, kvstore.NewInMemory()).opts.Namespace
+		assert.Regexp(t, `^embedding\.OpenAI:text-embedding-3-small:[0-9a-f]{16}$`, namespace)
+
+		// The namespace separates models, dimensions and input types.
+		assert.NotEqual(t, namespace, cacheNamespace(NewOpenAIFromClient(nil, func(o *OpenAIOptions) {
+			o.ModelName = "text-embedding-3-large"
+		})))
+		assert.NotEqual(t, namespace, cacheNamespace(NewOpenAIFromClient(nil, func(o *OpenAIOptions) {
+			o.Dimensions = 256
+		})))
+		assert.NotEqual(t, cacheNamespace(NewCohereFromClient(nil)), cacheNamespace(NewCohereFromClient(nil, func(o *CohereOptions) {
+			o.DocumentInputType = "classification"
+		})))
+
+		// Wrappers use the namespace of the wrapped embedder.
+		assert.Equal(t, namespace, cacheNamespace(NewBatchEmbedder(small)))
+
+		matryoshka, err := NewMatryoshka(small, 64)
+		require.NoError(t, err)
+		assert.Equal(t, namespace+":matryoshka-64", cacheNamespace(matryoshka))
+
+		// Other embedders are separated by their type.
+		assert.Equal(t, "embedding.lengthEmbedderMock", cacheNamespace(&lengthEmbedderMock{}))
+	})
+
+	t.Run("Encoding", func(t *testing.T) {
+		embedding := []float32{0.5, -1.25, 3}
+
+		decoded, err := decodeEmbedding(encodeEmbedding(embedding))
+		require.NoError(t, err)
+		assert.Equal(t, embedding, decoded)
+
+		_, err = decodeEmbedding([]byte{1, 2, 3})
+		assert.Error(t, err)
+	})
+}
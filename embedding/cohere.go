@@ -3,11 +3,12 @@ package embedding
 import (
 	"context"
 	"errors"
+	"fmt"
 
-	"github.com/avast/retry-go"
 	cohere "github.com/cohere-ai/cohere-go/v2"
 	cohereclient "github.com/cohere-ai/cohere-go/v2/client"
 	core "github.com/cohere-ai/cohere-go/v2/core"
+	"github.com/hupe1980/golc/internal/retry"
 	"github.com/hupe1980/golc/internal/util"
 	"github.com/hupe1980/golc/schema"
 )
@@ -24,8 +25,19 @@ type CohereClient interface {
 type CohereOptions struct {
 	// Model name to use.
 	Model string
+	// DocumentInputType is the input type of the texts embedded by BatchEmbedText
+	// ("search_document"|"search_query"|"classification"|"clustering"). It is required by the v3 models.
+	DocumentInputType string
+	// QueryInputType is the input type of the text embedded by EmbedText. It is required by the v3 models.
+	QueryInputType string
+	// EmbeddingType is the type of the returned embeddings ("float"|"int8"|"uint8"|"binary"|"ubinary").
+	// The compressed types are only supported by the v3 models. Binary embeddings are unpacked into
+	// one value of -1 or 1 per dimension.
+	EmbeddingType string
 	// Truncate embeddings that are too long from start or end ("NONE"|"START"|"END")
 	Truncate string
+	// BatchSize is the maximum number of texts embedded by one request.
+	BatchSize int
 	// MaxRetries represents the maximum number of retries to make when embedding.
 	MaxRetries uint `map:"max_retries,omitempty"`
 }
@@ -39,7 +51,7 @@ type Cohere struct {
 // NewCohere creates a new Cohere instance with the provided API key and options.
 // It returns the initialized Cohere instance or an error if initialization fails.
 func NewCohere(apiKey string, optFns ...func(o *CohereOptions)) *Cohere {
-	client := cohereclient.NewClient(cohereclient.WithToken(apiKey))
+	client := cohereclient.NewClient(cohereclient.WithToken(apiKey), cohereclient.WithHTTPClient(retry.NewHTTPClient(nil)))
 
 	return NewCohereFromClient(client, optFns...)
 }
@@ -48,9 +60,13 @@ func NewCohere(apiKey string, optFns ...func(o *CohereOptions)) *Cohere {
 // It returns the initialized Cohere instance.
 func NewCohereFromClient(client CohereClient, optFns ...func(o *CohereOptions)) *Cohere {
 	opts := CohereOptions{
-		Model:      "embed-english-v2.0",
-		MaxRetries: 3,
-		Truncate:   "NONE",
+		Model:             "embed-english-v3.0",
+		DocumentInputType: string(cohere.EmbedInputTypeSearchDocument),
+		QueryInputType:    string(cohere.EmbedInputTypeSearchQuery),
+		EmbeddingType:     string(cohere.EmbeddingTypeFloat),
+		MaxRetries:        3,
+		Truncate:          "NONE",
+		BatchSize:         96,
 	}
 
 	for _, fn := range optFns {
@@ -63,77 +79,158 @@ func NewCohereFromClient(client CohereClient, optFns ...func(o *CohereOptions))
 	}
 }
 
-// BatchEmbedText embeds a list of texts and returns their embeddings.
+// cacheNamespace returns the cache namespace of the model and its parameters.
+func (e *Cohere) cacheNamespace() string {
+	return modelCacheNamespace(e, e.opts.Model, map[string]any{
+		"document_input_type": e.opts.DocumentInputType,
+		"query_input_type":    e.opts.QueryInputType,
+		"embedding_type":      e.opts.EmbeddingType,
+		"truncate":            e.opts.Truncate,
+	})
+}
+
+// BatchEmbedText embeds a list of documents and returns their embeddings.
 func (e *Cohere) BatchEmbedText(ctx context.Context, texts []string) ([][]float32, error) {
+	batchSize := e.opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = len(texts)
+	}
+
+	embeddings := make([][]float32, 0, len(texts))
+
+	for start := 0; start < len(texts); start += batchSize {
+		end := util.Min(start+batchSize, len(texts))
+
+		batch, err := e.embed(ctx, texts[start:end], e.opts.DocumentInputType)
+		if err != nil {
+			return nil, err
+		}
+
+		embeddings = append(embeddings, batch...)
+	}
+
+	return embeddings, nil
+}
+
+// EmbedText embeds a single query and returns its embedding.
+func (e *Cohere) EmbedText(ctx context.Context, text string) ([]float32, error) {
+	embeddings, err := e.embed(ctx, []string{text}, e.opts.QueryInputType)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(embeddings) == 0 {
+		return nil, errors.New("no embedding returned")
+	}
+
+	return embeddings[0], nil
+}
+
+// embed embeds the texts with a single request.
+func (e *Cohere) embed(ctx context.Context, texts []string, inputType string) ([][]float32, error) {
 	truncate, err := cohere.NewEmbedRequestTruncateFromString(e.opts.Truncate)
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := e.embedWithRetry(ctx, &cohere.EmbedRequest{
-		Model:    util.AddrOrNil(e.opts.Model),
-		Truncate: truncate.Ptr(),
-		Texts:    texts,
-		EmbeddingTypes: []cohere.EmbeddingType{
-			cohere.EmbeddingTypeFloat,
-		},
-	})
+	embeddingType, err := cohere.NewEmbeddingTypeFromString(e.opts.EmbeddingType)
 	if err != nil {
 		return nil, err
 	}
 
-	embeddings := make([][]float32, len(res.EmbeddingsByType.Embeddings.Float))
-	for i, r := range res.EmbeddingsByType.Embeddings.Float {
-		embeddings[i] = util.Float64ToFloat32(r)
+	req := &cohere.EmbedRequest{
+		Model:          util.AddrOrNil(e.opts.Model),
+		Truncate:       truncate.Ptr(),
+		Texts:          texts,
+		EmbeddingTypes: []cohere.EmbeddingType{embeddingType},
 	}
 
-	return embeddings, nil
+	if inputType != "" {
+		t, err := cohere.NewEmbedInputTypeFromString(inputType)
+		if err != nil {
+			return nil, err
+		}
+
+		req.InputType = t.Ptr()
+	}
+
+	res, err := e.embedWithRetry(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.EmbeddingsByType == nil || res.EmbeddingsByType.Embeddings == nil {
+		return nil, errors.New("no embeddings returned")
+	}
+
+	return toFloat32Embeddings(res.EmbeddingsByType.Embeddings, embeddingType)
 }
 
 func (e *Cohere) embedWithRetry(ctx context.Context, req *cohere.EmbedRequest) (*cohere.EmbedResponse, error) {
-	retryOpts := []retry.Option{
-		retry.Attempts(e.opts.MaxRetries),
-		retry.DelayType(retry.FixedDelay),
-		retry.RetryIf(func(err error) bool {
-			e := new(core.APIError)
-			if errors.As(err, &e) {
-				switch e.StatusCode {
-				case 429, 500:
-					return true
-				default:
-					return false
-				}
-			}
+	policy := retry.NewPolicy(e.opts.MaxRetries, retry.IsRetryableCohereError)
+
+	return retry.Do(ctx, policy, func(ctx context.Context) (*cohere.EmbedResponse, error) {
+		return e.client.Embed(ctx, req)
+	})
+}
 
-			return false
-		}),
+// toFloat32Embeddings converts the embeddings of the given type to float32 values.
+func toFloat32Embeddings(embeddings *cohere.EmbedByTypeResponseEmbeddings, embeddingType cohere.EmbeddingType) ([][]float32, error) {
+	var values [][]int
+
+	switch embeddingType {
+	case cohere.EmbeddingTypeFloat:
+		result := make([][]float32, len(embeddings.Float))
+		for i, r := range embeddings.Float {
+			result[i] = util.Float64ToFloat32(r)
+		}
+
+		return result, nil
+	case cohere.EmbeddingTypeInt8:
+		values = embeddings.Int8
+	case cohere.EmbeddingTypeUint8:
+		values = embeddings.Uint8
+	case cohere.EmbeddingTypeBinary:
+		// Signed binary embeddings are the unsigned ones shifted by -128.
+		return unpackBinaryEmbeddings(embeddings.Binary, 128), nil
+	case cohere.EmbeddingTypeUbinary:
+		return unpackBinaryEmbeddings(embeddings.Ubinary, 0), nil
+	default:
+		return nil, fmt.Errorf("unsupported embedding type: %s", embeddingType)
 	}
 
-	var res *cohere.EmbedResponse
+	result := make([][]float32, len(values))
 
-	err := retry.Do(
-		func() error {
-			r, cErr := e.client.Embed(ctx, req)
-			if cErr != nil {
-				return cErr
-			}
+	for i, r := range values {
+		result[i] = make([]float32, len(r))
+		for j, v := range r {
+			result[i][j] = float32(v)
+		}
+	}
 
-			res = r
+	return result, nil
+}
 
-			return nil
-		},
-		retryOpts...,
-	)
+// unpackBinaryEmbeddings unpacks each bit of the packed embeddings into a value of -1 or 1.
+// The offset is added to the packed values to obtain the unsigned bytes.
+func unpackBinaryEmbeddings(packed [][]int, offset int) [][]float32 {
+	result := make([][]float32, len(packed))
 
-	return res, err
-}
+	for i, r := range packed {
+		result[i] = make([]float32, 0, 8*len(r))
 
-// EmbedText embeds a single query and returns its embedding.
-func (e *Cohere) EmbedText(ctx context.Context, text string) ([]float32, error) {
-	embeddings, err := e.BatchEmbedText(ctx, []string{text})
-	if err != nil {
-		return nil, err
+		for _, v := range r {
+			b := byte(v + offset)
+
+			for bit := 7; bit >= 0; bit-- {
+				if b&(1<<bit) != 0 {
+					result[i] = append(result[i], 1)
+				} else {
+					result[i] = append(result[i], -1)
+				}
+			}
+		}
 	}
 
-	return embeddings[0], nil
+	return result
 }
@@ -93,15 +93,90 @@ func TestCohere(t *testing.T) {
 			assert.Nil(t, embedding, "Expected nil embedding")
 		})
 	})
+
+	t.Run("InputType", func(t *testing.T) {
+		client := &mockCohereClient{
+			response: &cohere.EmbedResponse{
+				EmbeddingsByType: &cohere.EmbedByTypeResponse{
+					Embeddings: &cohere.EmbedByTypeResponseEmbeddings{
+						Float: [][]float64{{1.0}},
+					},
+				},
+			},
+		}
+
+		cohereModel := NewCohereFromClient(client, func(o *CohereOptions) {
+			o.BatchSize = 1
+		})
+
+		_, err := cohereModel.BatchEmbedText(context.Background(), []string{"text1", "text2"})
+		assert.NoError(t, err)
+
+		_, err = cohereModel.EmbedText(context.Background(), "query")
+		assert.NoError(t, err)
+
+		assert.Len(t, client.requests, 3)
+		assert.Equal(t, []string{"text1"}, client.requests[0].Texts)
+		assert.Equal(t, cohere.EmbedInputTypeSearchDocument, *client.requests[0].InputType)
+		assert.Equal(t, cohere.EmbedInputTypeSearchDocument, *client.requests[1].InputType)
+		assert.Equal(t, cohere.EmbedInputTypeSearchQuery, *client.requests[2].InputType)
+		assert.Equal(t, "embed-english-v3.0", *client.requests[2].Model)
+
+		cohereModel = NewCohereFromClient(client, func(o *CohereOptions) {
+			o.QueryInputType = "invalid"
+		})
+
+		_, err = cohereModel.EmbedText(context.Background(), "query")
+		assert.Error(t, err)
+	})
+
+	t.Run("EmbeddingType", func(t *testing.T) {
+		embeddings := &cohere.EmbedByTypeResponseEmbeddings{
+			Int8:    [][]int{{-128, 0, 127}},
+			Binary:  [][]int{{1}},
+			Ubinary: [][]int{{129}},
+		}
+
+		client := &mockCohereClient{
+			response: &cohere.EmbedResponse{
+				EmbeddingsByType: &cohere.EmbedByTypeResponse{Embeddings: embeddings},
+			},
+		}
+
+		tests := []struct {
+			embeddingType string
+			expected      []float32
+		}{
+			{"int8", []float32{-128, 0, 127}},
+			{"binary", []float32{1, -1, -1, -1, -1, -1, -1, 1}},
+			{"ubinary", []float32{1, -1, -1, -1, -1, -1, -1, 1}},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.embeddingType, func(t *testing.T) {
+				cohereModel := NewCohereFromClient(client, func(o *CohereOptions) {
+					o.EmbeddingType = tc.embeddingType
+				})
+
+				embedding, err := cohereModel.EmbedText(context.Background(), "query")
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, embedding)
+				assert.Equal(t, []cohere.EmbeddingType{cohere.EmbeddingType(tc.embeddingType)}, client.requests[len(client.requests)-1].EmbeddingTypes)
+			})
+		}
+	})
 }
 
 // mockCohereClient is a mock implementation of the CohereClient interface for testing.
 type mockCohereClient struct {
 	response *cohere.EmbedResponse
 	err      error
+	requests []*cohere.EmbedRequest
 }
 
 func (m *mockCohereClient) Embed(ctx context.Context, request *cohere.EmbedRequest, opts ...core.RequestOption) (*cohere.EmbedResponse, error) {
+	m.requests = append(m.requests, request)
+
 	if m.err != nil {
 		return nil, m.err
 	}
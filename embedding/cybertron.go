@@ -79,6 +79,11 @@ func NewCybertronFromEncoder(encoder textencoding.Interface, optFns ...func(o *C
 	}, nil
 }
 
+// cacheNamespace returns the cache namespace of the model and its parameters.
+func (e *Cybertron) cacheNamespace() string {
+	return modelCacheNamespace(e, "", map[string]any{"pooling_strategy": e.opts.PoolingStrategy})
+}
+
 // BatchEmbedText embeds a list of texts and returns their embeddings.
 func (e *Cybertron) BatchEmbedText(ctx context.Context, texts []string) ([][]float32, error) {
 	embeddings := make([][]float32, len(texts))
@@ -2,7 +2,125 @@
 // from text using different APIs
 package embedding
 
-import "strings"
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/schema"
+)
+
+type Options struct {
+	Callbacks   []schema.Callback
+	ParentRunID string
+	Verbose     bool
+}
+
+// BatchEmbedText embeds a list of texts with the given embedder and reports the
+// embedding run to the callbacks.
+func BatchEmbedText(ctx context.Context, embedder schema.Embedder, texts []string, optFns ...func(*Options)) ([][]float32, error) {
+	return run(ctx, embedder, texts, func() ([][]float32, error) {
+		return embedder.BatchEmbedText(ctx, texts)
+	}, optFns...)
+}
+
+// EmbedText embeds a single text with the given embedder and reports the
+// embedding run to the callbacks.
+func EmbedText(ctx context.Context, embedder schema.Embedder, text string, optFns ...func(*Options)) ([]float32, error) {
+	embeddings, err := run(ctx, embedder, []string{text}, func() ([][]float32, error) {
+		embedding, err := embedder.EmbedText(ctx, text)
+		if err != nil {
+			return nil, err
+		}
+
+		return [][]float32{embedding}, nil
+	}, optFns...)
+	if err != nil {
+		return nil, err
+	}
+
+	return embeddings[0], nil
+}
+
+func run(ctx context.Context, embedder schema.Embedder, texts []string, embed func() ([][]float32, error), optFns ...func(*Options)) ([][]float32, error) {
+	opts := Options{
+		Verbose: golc.Verbose,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	cm := callback.NewManager(opts.Callbacks, nil, opts.Verbose, func(mo *callback.ManagerOptions) {
+		mo.ParentRunID = opts.ParentRunID
+	})
+
+	rm, err := cm.OnEmbedderStart(ctx, &schema.EmbedderStartManagerInput{
+		EmbedderType: embedderType(embedder),
+		Texts:        texts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	embeddings, err := embed()
+	if err != nil {
+		if cbErr := rm.OnEmbedderError(ctx, &schema.EmbedderErrorManagerInput{
+			Error: err,
+		}); cbErr != nil {
+			return nil, cbErr
+		}
+
+		return nil, err
+	}
+
+	if err := rm.OnEmbedderEnd(ctx, &schema.EmbedderEndManagerInput{
+		Embeddings: embeddings,
+	}); err != nil {
+		return nil, err
+	}
+
+	return embeddings, nil
+}
+
+// embedderType returns the type name of the embedder, e.g. embedding.OpenAI.
+func embedderType(embedder schema.Embedder) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", embedder), "*")
+}
+
+// cacheNamespacer is implemented by embedders, whose embeddings depend on a model and its parameters, to
+// separate their cached embeddings in the CacheBacked embedder.
+type cacheNamespacer interface {
+	cacheNamespace() string
+}
+
+// cacheNamespace returns the default cache namespace of the embedder. It consists of the type of the
+// embedder, the model name and the hash of the parameters, which change the embeddings, e.g. the dimensions,
+// or only of the type for embedders without model parameters.
+func cacheNamespace(embedder schema.Embedder) string {
+	if n, ok := embedder.(cacheNamespacer); ok {
+		return n.cacheNamespace()
+	}
+
+	return embedderType(embedder)
+}
+
+// modelCacheNamespace returns the cache namespace of an embedder with the model and the parameters.
+func modelCacheNamespace(embedder schema.Embedder, model string, params map[string]any) string {
+	b, err := json.Marshal(params)
+	if err != nil {
+		b = []byte(fmt.Sprint(params))
+	}
+
+	hash := sha256.Sum256(b)
+
+	return fmt.Sprintf("%s:%s:%s", embedderType(embedder), model, hex.EncodeToString(hash[:8]))
+}
 
 func removeNewLines(text string) string {
 	return strings.ReplaceAll(text, "\n", " ")
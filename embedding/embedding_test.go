@@ -0,0 +1,97 @@
+package embedding
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchEmbedText(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		cb := &embedderCallback{}
+
+		embeddings, err := BatchEmbedText(context.Background(), NewFake(3), []string{"foo", "bar"}, func(o *Options) {
+			o.Callbacks = []schema.Callback{cb}
+			o.ParentRunID = "parent"
+		})
+		require.NoError(t, err)
+		assert.Len(t, embeddings, 2)
+
+		require.NotNil(t, cb.start)
+		assert.Equal(t, "embedding.Fake", cb.start.EmbedderType)
+		assert.Equal(t, []string{"foo", "bar"}, cb.start.Texts)
+		assert.Equal(t, "parent", cb.start.ParentRunID)
+
+		require.NotNil(t, cb.end)
+		assert.Equal(t, cb.start.RunID, cb.end.RunID)
+		assert.Equal(t, embeddings, cb.end.Embeddings)
+		assert.Nil(t, cb.err)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		cb := &embedderCallback{}
+
+		_, err := BatchEmbedText(context.Background(), &errorEmbedder{}, []string{"foo"}, func(o *Options) {
+			o.Callbacks = []schema.Callback{cb}
+		})
+		assert.EqualError(t, err, "embedder error")
+
+		require.NotNil(t, cb.err)
+		assert.EqualError(t, cb.err.Error, "embedder error")
+		assert.Nil(t, cb.end)
+	})
+}
+
+func TestEmbedText(t *testing.T) {
+	cb := &embedderCallback{}
+
+	embedding, err := EmbedText(context.Background(), NewFake(3), "foo", func(o *Options) {
+		o.Callbacks = []schema.Callback{cb}
+	})
+	require.NoError(t, err)
+	assert.Len(t, embedding, 3)
+
+	require.NotNil(t, cb.end)
+	assert.Equal(t, [][]float32{embedding}, cb.end.Embeddings)
+}
+
+type embedderCallback struct {
+	callback.NoopHandler
+	start *schema.EmbedderStartInput
+	end   *schema.EmbedderEndInput
+	err   *schema.EmbedderErrorInput
+}
+
+func (cb *embedderCallback) AlwaysVerbose() bool {
+	return true
+}
+
+func (cb *embedderCallback) OnEmbedderStart(ctx context.Context, input *schema.EmbedderStartInput) error {
+	cb.start = input
+	return nil
+}
+
+func (cb *embedderCallback) OnEmbedderEnd(ctx context.Context, input *schema.EmbedderEndInput) error {
+	cb.end = input
+	return nil
+}
+
+func (cb *embedderCallback) OnEmbedderError(ctx context.Context, input *schema.EmbedderErrorInput) error {
+	cb.err = input
+	return nil
+}
+
+type errorEmbedder struct{}
+
+func (e *errorEmbedder) BatchEmbedText(ctx context.Context, texts []string) ([][]float32, error) {
+	return nil, errors.New("embedder error")
+}
+
+func (e *errorEmbedder) EmbedText(ctx context.Context, text string) ([]float32, error) {
+	return nil, errors.New("embedder error")
+}
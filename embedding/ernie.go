@@ -53,6 +53,11 @@ func NewErnieFromClient(client ErnieClient, optFns ...func(o *ErnieOptions)) *Er
 	}
 }
 
+// cacheNamespace returns the cache namespace of the model and its parameters.
+func (e *Ernie) cacheNamespace() string {
+	return modelCacheNamespace(e, e.opts.Model, map[string]any{})
+}
+
 // BatchEmbedText embeds a list of texts and returns their embeddings.
 func (e *Ernie) BatchEmbedText(ctx context.Context, texts []string) ([][]float32, error) {
 	// The number of texts does not exceed 16
@@ -44,6 +44,11 @@ func NewGoogleGenAI(client GoogleGenAIClient, optFns ...func(o *GoogleGenAIOptio
 	}
 }
 
+// cacheNamespace returns the cache namespace of the model and its parameters.
+func (e *GoogleGenAI) cacheNamespace() string {
+	return modelCacheNamespace(e, e.opts.ModelName, map[string]any{})
+}
+
 // BatchEmbedText embeds a list of texts and returns their embeddings.
 func (e *GoogleGenAI) BatchEmbedText(ctx context.Context, texts []string) ([][]float32, error) {
 	requests := make([]*generativelanguagepb.EmbedContentRequest, len(texts))
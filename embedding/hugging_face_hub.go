@@ -55,6 +55,11 @@ func NewHuggingFaceHubFromClient(client HuggingFaceHubClient, optFns ...func(o *
 	}
 }
 
+// cacheNamespace returns the cache namespace of the model and its parameters.
+func (e *HuggingFaceHub) cacheNamespace() string {
+	return modelCacheNamespace(e, e.opts.Model, map[string]any{})
+}
+
 // BatchEmbedText embeds a list of texts and returns their embeddings.
 func (e *HuggingFaceHub) BatchEmbedText(ctx context.Context, texts []string) ([][]float32, error) {
 	res, err := e.client.FeatureExtractionWithAutomaticReduction(ctx, &huggingface.FeatureExtractionRequest{
@@ -0,0 +1,152 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hupe1980/golc/integration/jina"
+	"github.com/hupe1980/golc/internal/retry"
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Jina satisfies the Embedder interface.
+var _ schema.Embedder = (*Jina)(nil)
+
+// JinaClient is an interface for the Jina AI client.
+type JinaClient interface {
+	CreateEmbeddings(ctx context.Context, req *jina.EmbeddingRequest) (*jina.EmbeddingResponse, error)
+}
+
+// JinaOptions contains options for configuring the Jina embedder.
+type JinaOptions struct {
+	// ModelName is the name of the Jina AI embedding model to use.
+	ModelName string `map:"model_name,omitempty"`
+	// Dimensions is the number of dimensions of the embeddings. It is only supported by some models.
+	// A value of 0 uses the default dimension of the model.
+	Dimensions int `map:"dimensions,omitempty"`
+	// DocumentTask is the task of the texts embedded by BatchEmbedText. It is only supported by some models.
+	DocumentTask jina.Task `map:"document_task,omitempty"`
+	// QueryTask is the task of the text embedded by EmbedText. It is only supported by some models.
+	QueryTask jina.Task `map:"query_task,omitempty"`
+	// LateChunking embeds the texts of a batch as chunks of one concatenated text.
+	LateChunking bool `map:"late_chunking,omitempty"`
+	// BatchSize is the maximum number of texts embedded by one request.
+	BatchSize int
+	// MaxRetries represents the maximum number of retries to make when embedding.
+	MaxRetries uint `map:"max_retries,omitempty"`
+}
+
+// Jina is an embedder for the Jina AI API.
+type Jina struct {
+	client JinaClient
+	opts   JinaOptions
+}
+
+// NewJina creates a new Jina embedder with the provided API key and options.
+func NewJina(apiKey string, optFns ...func(o *JinaOptions)) *Jina {
+	client := jina.New(apiKey, func(o *jina.ClientOptions) {
+		o.HTTPClient = retry.NewHTTPClient(nil)
+	})
+
+	return NewJinaFromClient(client, optFns...)
+}
+
+// NewJinaFromClient creates a new Jina embedder from an existing Jina AI client and options.
+func NewJinaFromClient(client JinaClient, optFns ...func(o *JinaOptions)) *Jina {
+	opts := JinaOptions{
+		ModelName:    "jina-embeddings-v3",
+		DocumentTask: jina.TaskRetrievalPassage,
+		QueryTask:    jina.TaskRetrievalQuery,
+		BatchSize:    128,
+		MaxRetries:   3,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Jina{
+		client: client,
+		opts:   opts,
+	}
+}
+
+// cacheNamespace returns the cache namespace of the model and its parameters.
+func (e *Jina) cacheNamespace() string {
+	return modelCacheNamespace(e, e.opts.ModelName, map[string]any{
+		"dimensions":    e.opts.Dimensions,
+		"document_task": e.opts.DocumentTask,
+		"query_task":    e.opts.QueryTask,
+		"late_chunking": e.opts.LateChunking,
+	})
+}
+
+// BatchEmbedText embeds a list of documents and returns their embeddings.
+func (e *Jina) BatchEmbedText(ctx context.Context, texts []string) ([][]float32, error) {
+	batchSize := e.opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = len(texts)
+	}
+
+	embeddings := make([][]float32, 0, len(texts))
+
+	for start := 0; start < len(texts); start += batchSize {
+		end := util.Min(start+batchSize, len(texts))
+
+		batch, err := e.embed(ctx, texts[start:end], e.opts.DocumentTask, e.opts.LateChunking)
+		if err != nil {
+			return nil, err
+		}
+
+		embeddings = append(embeddings, batch...)
+	}
+
+	return embeddings, nil
+}
+
+// EmbedText embeds a single query and returns its embedding.
+func (e *Jina) EmbedText(ctx context.Context, text string) ([]float32, error) {
+	embeddings, err := e.embed(ctx, []string{text}, e.opts.QueryTask, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return embeddings[0], nil
+}
+
+// embed embeds the texts with a single request.
+func (e *Jina) embed(ctx context.Context, texts []string, task jina.Task, lateChunking bool) ([][]float32, error) {
+	policy := retry.NewPolicy(e.opts.MaxRetries, retry.IsRetryableJinaError)
+
+	res, err := retry.Do(ctx, policy, func(ctx context.Context) (*jina.EmbeddingResponse, error) {
+		return e.client.CreateEmbeddings(ctx, &jina.EmbeddingRequest{
+			Model:        e.opts.ModelName,
+			Input:        texts,
+			Task:         task,
+			Dimensions:   e.opts.Dimensions,
+			LateChunking: lateChunking,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	embeddings := make([][]float32, len(texts))
+
+	for _, d := range res.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("invalid embedding index: %d", d.Index)
+		}
+
+		embeddings[d.Index] = d.Embedding
+	}
+
+	for i, embedding := range embeddings {
+		if embedding == nil {
+			return nil, fmt.Errorf("missing embedding of text %d", i)
+		}
+	}
+
+	return embeddings, nil
+}
@@ -0,0 +1,76 @@
+package embedding
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hupe1980/golc/integration/jina"
+)
+
+func TestJina(t *testing.T) {
+	t.Run("BatchEmbedText", func(t *testing.T) {
+		client := &mockJinaClient{}
+
+		embedder := NewJinaFromClient(client, func(o *JinaOptions) {
+			o.BatchSize = 2
+			o.Dimensions = 256
+			o.LateChunking = true
+		})
+
+		embeddings, err := embedder.BatchEmbedText(context.Background(), []string{"a", "bb", "ccc"})
+		require.NoError(t, err)
+		assert.Equal(t, [][]float32{{1}, {2}, {3}}, embeddings)
+
+		require.Len(t, client.requests, 2)
+		assert.Equal(t, []string{"ccc"}, client.requests[1].Input)
+		assert.Equal(t, jina.TaskRetrievalPassage, client.requests[0].Task)
+		assert.Equal(t, "jina-embeddings-v3", client.requests[0].Model)
+		assert.Equal(t, 256, client.requests[0].Dimensions)
+		assert.True(t, client.requests[0].LateChunking)
+	})
+
+	t.Run("EmbedText", func(t *testing.T) {
+		client := &mockJinaClient{}
+
+		embedding, err := NewJinaFromClient(client).EmbedText(context.Background(), "query")
+		require.NoError(t, err)
+		assert.Equal(t, []float32{5}, embedding)
+		assert.Equal(t, jina.TaskRetrievalQuery, client.requests[0].Task)
+		assert.False(t, client.requests[0].LateChunking)
+	})
+
+	t.Run("MissingEmbedding", func(t *testing.T) {
+		client := &mockJinaClient{drop: true}
+
+		_, err := NewJinaFromClient(client).BatchEmbedText(context.Background(), []string{"a", "b"})
+		assert.Error(t, err)
+	})
+}
+
+// mockJinaClient embeds each text by its length. If drop is set, the last embedding is missing.
+type mockJinaClient struct {
+	requests []*jina.EmbeddingRequest
+	drop     bool
+}
+
+func (m *mockJinaClient) CreateEmbeddings(ctx context.Context, req *jina.EmbeddingRequest) (*jina.EmbeddingResponse, error) {
+	m.requests = append(m.requests, req)
+
+	res := &jina.EmbeddingResponse{}
+
+	for i, text := range req.Input {
+		res.Data = append(res.Data, jina.Embedding{
+			Embedding: []float32{float32(len(text))},
+			Index:     i,
+		})
+	}
+
+	if m.drop {
+		res.Data = res.Data[:len(res.Data)-1]
+	}
+
+	return res, nil
+}
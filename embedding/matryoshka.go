@@ -0,0 +1,89 @@
+package embedding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hupe1980/golc/internal/math32"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Matryoshka satisfies the Embedder interface.
+var _ schema.Embedder = (*Matryoshka)(nil)
+
+// Matryoshka wraps an embedder of a model trained with Matryoshka representation learning, e.g.
+// nomic-embed-text, to reduce the embeddings to their first dimensions. The reduced embeddings are
+// normalized to unit length. Prefer the dimensions option of providers that reduce the embeddings
+// on the server, e.g. OpenAI, Voyage AI and Jina AI.
+type Matryoshka struct {
+	embedder   schema.Embedder
+	dimensions int
+}
+
+// NewMatryoshka creates a new instance of the Matryoshka embedder reducing the embeddings to the given dimensions.
+func NewMatryoshka(embedder schema.Embedder, dimensions int) (*Matryoshka, error) {
+	if dimensions <= 0 {
+		return nil, fmt.Errorf("dimensions must be positive, got %d", dimensions)
+	}
+
+	return &Matryoshka{
+		embedder:   embedder,
+		dimensions: dimensions,
+	}, nil
+}
+
+// cacheNamespace returns the cache namespace of the wrapped embedder and the dimensions.
+func (e *Matryoshka) cacheNamespace() string {
+	return fmt.Sprintf("%s:matryoshka-%d", cacheNamespace(e.embedder), e.dimensions)
+}
+
+// BatchEmbedText embeds a list of texts and returns their reduced embeddings.
+func (e *Matryoshka) BatchEmbedText(ctx context.Context, texts []string) ([][]float32, error) {
+	embeddings, err := e.embedder.BatchEmbedText(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+
+	reduced := make([][]float32, len(embeddings))
+
+	for i, embedding := range embeddings {
+		reduced[i], err = e.reduce(embedding)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return reduced, nil
+}
+
+// EmbedText embeds a single text and returns its reduced embedding.
+func (e *Matryoshka) EmbedText(ctx context.Context, text string) ([]float32, error) {
+	embedding, err := e.embedder.EmbedText(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.reduce(embedding)
+}
+
+// reduce truncates the embedding to the dimensions and normalizes it to unit length.
+func (e *Matryoshka) reduce(embedding []float32) ([]float32, error) {
+	if len(embedding) < e.dimensions {
+		return nil, fmt.Errorf("embedding has %d dimensions, less than %d", len(embedding), e.dimensions)
+	}
+
+	reduced := make([]float32, e.dimensions)
+	copy(reduced, embedding)
+
+	norm := math32.Sqrt(math32.Dot(reduced, reduced))
+	if norm == 0 {
+		return nil, errors.New("cannot normalize an embedding with zero norm")
+	}
+
+	for i := range reduced {
+		reduced[i] /= norm
+	}
+
+	return reduced, nil
+}
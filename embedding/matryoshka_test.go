@@ -0,0 +1,62 @@
+package embedding
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatryoshka(t *testing.T) {
+	embedder := &vectorEmbedderMock{vector: []float32{3, 4, 12}}
+
+	t.Run("BatchEmbedText", func(t *testing.T) {
+		matryoshka, err := NewMatryoshka(embedder, 2)
+		require.NoError(t, err)
+
+		embeddings, err := matryoshka.BatchEmbedText(context.Background(), []string{"a", "b"})
+		require.NoError(t, err)
+		assert.Equal(t, [][]float32{{0.6, 0.8}, {0.6, 0.8}}, embeddings)
+	})
+
+	t.Run("EmbedText", func(t *testing.T) {
+		matryoshka, err := NewMatryoshka(embedder, 3)
+		require.NoError(t, err)
+
+		embedding, err := matryoshka.EmbedText(context.Background(), "a")
+		require.NoError(t, err)
+		assert.InDeltaSlice(t, []float32{3.0 / 13, 4.0 / 13, 12.0 / 13}, embedding, 1e-6)
+	})
+
+	t.Run("TooFewDimensions", func(t *testing.T) {
+		matryoshka, err := NewMatryoshka(embedder, 4)
+		require.NoError(t, err)
+
+		_, err = matryoshka.EmbedText(context.Background(), "a")
+		assert.Error(t, err)
+	})
+
+	t.Run("InvalidDimensions", func(t *testing.T) {
+		_, err := NewMatryoshka(embedder, 0)
+		assert.Error(t, err)
+	})
+}
+
+// vectorEmbedderMock embeds every text with the same vector.
+type vectorEmbedderMock struct {
+	vector []float32
+}
+
+func (m *vectorEmbedderMock) BatchEmbedText(ctx context.Context, texts []string) ([][]float32, error) {
+	embeddings := make([][]float32, len(texts))
+	for i := range texts {
+		embeddings[i] = m.vector
+	}
+
+	return embeddings, nil
+}
+
+func (m *vectorEmbedderMock) EmbedText(ctx context.Context, text string) ([]float32, error) {
+	return m.vector, nil
+}
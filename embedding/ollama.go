@@ -2,8 +2,10 @@ package embedding
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hupe1980/golc/integration/ollama"
+	"github.com/hupe1980/golc/internal/util"
 	"github.com/hupe1980/golc/schema"
 	"golang.org/x/sync/errgroup"
 )
@@ -13,13 +15,16 @@ var _ schema.Embedder = (*Ollama)(nil)
 
 // OllamaClient is an interface for interacting with the Ollama model's embedding functionality.
 type OllamaClient interface {
-	CreateEmbedding(ctx context.Context, req *ollama.EmbeddingRequest) (*ollama.EmbeddingResponse, error)
+	CreateEmbeddings(ctx context.Context, req *ollama.EmbedRequest) (*ollama.EmbedResponse, error)
 }
 
 // OllamaOptions contains options for configuring the Ollama model.
 type OllamaOptions struct {
+	// BatchSize is the maximum number of texts embedded by one request.
+	BatchSize int
+	// MaxConcurrency is the maximum number of concurrent requests. A value <= 0 means no limit.
 	MaxConcurrency int
-	// ModelName is the name of the Gemini model to use.
+	// ModelName is the name of the Ollama embedding model to use, e.g. nomic-embed-text.
 	ModelName string `map:"model_name,omitempty"`
 }
 
@@ -32,6 +37,7 @@ type Ollama struct {
 // NewOllama creates a new instance of the Ollama embedding model.
 func NewOllama(client OllamaClient, optFns ...func(o *OllamaOptions)) *Ollama {
 	opts := OllamaOptions{
+		BatchSize:      32,
 		MaxConcurrency: 5,
 		ModelName:      "llama2",
 	}
@@ -46,27 +52,36 @@ func NewOllama(client OllamaClient, optFns ...func(o *OllamaOptions)) *Ollama {
 	}
 }
 
+// cacheNamespace returns the cache namespace of the model and its parameters.
+func (e *Ollama) cacheNamespace() string {
+	return modelCacheNamespace(e, e.opts.ModelName, map[string]any{})
+}
+
 // BatchEmbedText embeds a list of texts and returns their embeddings.
 func (e *Ollama) BatchEmbedText(ctx context.Context, texts []string) ([][]float32, error) {
+	batchSize := e.opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = len(texts)
+	}
+
 	errs, errctx := errgroup.WithContext(ctx)
 
-	errs.SetLimit(e.opts.MaxConcurrency)
+	if e.opts.MaxConcurrency > 0 {
+		errs.SetLimit(e.opts.MaxConcurrency)
+	}
 
 	embeddings := make([][]float32, len(texts))
 
-	for i, text := range texts {
-		i, text := i, text
+	for start := 0; start < len(texts); start += batchSize {
+		start, end := start, util.Min(start+batchSize, len(texts))
 
 		errs.Go(func() error {
-			res, err := e.client.CreateEmbedding(errctx, &ollama.EmbeddingRequest{
-				Prompt: text,
-				Model:  e.opts.ModelName,
-			})
+			res, err := e.embed(errctx, texts[start:end])
 			if err != nil {
 				return err
 			}
 
-			embeddings[i] = res.Embedding
+			copy(embeddings[start:end], res)
 
 			return nil
 		})
@@ -81,13 +96,27 @@ func (e *Ollama) BatchEmbedText(ctx context.Context, texts []string) ([][]float3
 
 // EmbedText embeds a single text and returns its embedding.
 func (e *Ollama) EmbedText(ctx context.Context, text string) ([]float32, error) {
-	res, err := e.client.CreateEmbedding(ctx, &ollama.EmbeddingRequest{
-		Prompt: text,
-		Model:  e.opts.ModelName,
+	embeddings, err := e.embed(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+
+	return embeddings[0], nil
+}
+
+// embed embeds the texts with a single request.
+func (e *Ollama) embed(ctx context.Context, texts []string) ([][]float32, error) {
+	res, err := e.client.CreateEmbeddings(ctx, &ollama.EmbedRequest{
+		Model: e.opts.ModelName,
+		Input: texts,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return res.Embedding, nil
+	if len(res.Embeddings) != len(texts) {
+		return nil, fmt.Errorf("number of embeddings does not match the number of texts: got %d, want %d", len(res.Embeddings), len(texts))
+	}
+
+	return res.Embeddings, nil
 }
@@ -12,11 +12,16 @@ import (
 func TestOllama(t *testing.T) {
 	t.Run("EmbedText", func(t *testing.T) {
 		client := &ollamaClientMock{}
-		embedder := NewOllama(client)
+		embedder := NewOllama(client, func(o *OllamaOptions) {
+			o.ModelName = "nomic-embed-text"
+		})
 
 		t.Run("Success", func(t *testing.T) {
-			client.CreateEmbeddingFunc = func(ctx context.Context, req *ollama.EmbeddingRequest) (*ollama.EmbeddingResponse, error) {
-				return &ollama.EmbeddingResponse{Embedding: []float32{1.0, 2.0}}, nil
+			client.CreateEmbeddingsFunc = func(ctx context.Context, req *ollama.EmbedRequest) (*ollama.EmbedResponse, error) {
+				assert.Equal(t, "nomic-embed-text", req.Model)
+				assert.Equal(t, []string{"text1"}, req.Input)
+
+				return &ollama.EmbedResponse{Embeddings: [][]float32{{1.0, 2.0}}}, nil
 			}
 
 			result, err := embedder.EmbedText(context.Background(), "text1")
@@ -27,7 +32,7 @@ func TestOllama(t *testing.T) {
 		t.Run("ErrorFromOllamaClient", func(t *testing.T) {
 			expectedError := errors.New("error from OllamaClient")
 
-			client.CreateEmbeddingFunc = func(ctx context.Context, req *ollama.EmbeddingRequest) (*ollama.EmbeddingResponse, error) {
+			client.CreateEmbeddingsFunc = func(ctx context.Context, req *ollama.EmbedRequest) (*ollama.EmbedResponse, error) {
 				return nil, expectedError
 			}
 
@@ -36,29 +41,49 @@ func TestOllama(t *testing.T) {
 			assert.Nil(t, result)
 			assert.EqualError(t, err, expectedError.Error())
 		})
+
+		t.Run("MissingEmbedding", func(t *testing.T) {
+			client.CreateEmbeddingsFunc = func(ctx context.Context, req *ollama.EmbedRequest) (*ollama.EmbedResponse, error) {
+				return &ollama.EmbedResponse{}, nil
+			}
+
+			result, err := embedder.EmbedText(context.Background(), "text1")
+			assert.Error(t, err)
+			assert.Nil(t, result)
+		})
 	})
 
 	t.Run("BatchEmbedText", func(t *testing.T) {
 		client := &ollamaClientMock{}
-		embedder := NewOllama(client)
+		embedder := NewOllama(client, func(o *OllamaOptions) {
+			o.BatchSize = 2
+			o.MaxConcurrency = 0
+		})
 
 		t.Run("Success", func(t *testing.T) {
-			texts := []string{"text1", "text2"}
+			texts := []string{"a", "bb", "ccc"}
+
+			client.CreateEmbeddingsFunc = func(ctx context.Context, req *ollama.EmbedRequest) (*ollama.EmbedResponse, error) {
+				assert.LessOrEqual(t, len(req.Input), 2)
+
+				embeddings := make([][]float32, len(req.Input))
+				for i, text := range req.Input {
+					embeddings[i] = []float32{float32(len(text))}
+				}
 
-			client.CreateEmbeddingFunc = func(ctx context.Context, req *ollama.EmbeddingRequest) (*ollama.EmbeddingResponse, error) {
-				return &ollama.EmbeddingResponse{Embedding: []float32{1.0, 2.0}}, nil
+				return &ollama.EmbedResponse{Embeddings: embeddings}, nil
 			}
 
 			result, err := embedder.BatchEmbedText(context.Background(), texts)
 			assert.NoError(t, err)
-			assert.Equal(t, [][]float32{{1.0, 2.0}, {1.0, 2.0}}, result)
+			assert.Equal(t, [][]float32{{1.0}, {2.0}, {3.0}}, result)
 		})
 
 		t.Run("ErrorFromOllamaClient", func(t *testing.T) {
 			texts := []string{"text1", "text2"}
 			expectedError := errors.New("error from OllamaClient")
 
-			client.CreateEmbeddingFunc = func(ctx context.Context, req *ollama.EmbeddingRequest) (*ollama.EmbeddingResponse, error) {
+			client.CreateEmbeddingsFunc = func(ctx context.Context, req *ollama.EmbedRequest) (*ollama.EmbedResponse, error) {
 				return nil, expectedError
 			}
 
@@ -72,13 +97,13 @@ func TestOllama(t *testing.T) {
 
 // ollamaClientMock is a custom mock implementation of the OllamaClient interface for testing purposes.
 type ollamaClientMock struct {
-	CreateEmbeddingFunc func(ctx context.Context, req *ollama.EmbeddingRequest) (*ollama.EmbeddingResponse, error)
+	CreateEmbeddingsFunc func(ctx context.Context, req *ollama.EmbedRequest) (*ollama.EmbedResponse, error)
 }
 
-func (m *ollamaClientMock) CreateEmbedding(ctx context.Context, req *ollama.EmbeddingRequest) (*ollama.EmbeddingResponse, error) {
-	if m.CreateEmbeddingFunc != nil {
-		return m.CreateEmbeddingFunc(ctx, req)
+func (m *ollamaClientMock) CreateEmbeddings(ctx context.Context, req *ollama.EmbedRequest) (*ollama.EmbedResponse, error) {
+	if m.CreateEmbeddingsFunc != nil {
+		return m.CreateEmbeddingsFunc(ctx, req)
 	}
 
-	panic("CreateEmbeddingFunc is not set in ollamaClientMock")
+	panic("CreateEmbeddingsFunc is not set in ollamaClientMock")
 }
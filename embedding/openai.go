@@ -2,13 +2,12 @@ package embedding
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 
-	"github.com/avast/retry-go"
 	"github.com/hupe1980/go-tiktoken"
 	"github.com/hupe1980/golc/internal/math32"
+	"github.com/hupe1980/golc/internal/retry"
 	"github.com/hupe1980/golc/internal/util"
 	"github.com/hupe1980/golc/schema"
 	"github.com/sashabaranov/go-openai"
@@ -33,6 +32,9 @@ type OpenAIOptions struct {
 	EmbeddingContextLength int
 	// Maximum number of texts to embed in each batch
 	ChunkSize int
+	// Dimensions is the number of dimensions of the embeddings. It is only supported by the
+	// text-embedding-3 models. A value of 0 uses the default dimension of the model.
+	Dimensions int
 	// BaseURL is the base URL of the OpenAI service.
 	BaseURL string
 	// OrgID is the organization ID for accessing the OpenAI service.
@@ -70,6 +72,8 @@ func NewOpenAI(apiKey string, optFns ...func(o *OpenAIOptions)) *OpenAI {
 		config.OrgID = opts.OrgID
 	}
 
+	config.HTTPClient = retry.NewHTTPClient(config.HTTPClient)
+
 	client := openai.NewClientWithConfig(config)
 
 	return NewOpenAIFromClient(client, optFns...)
@@ -88,6 +92,14 @@ func NewOpenAIFromClient(client OpenAIClient, optFns ...func(o *OpenAIOptions))
 	}
 }
 
+// cacheNamespace returns the cache namespace of the model and its parameters.
+func (e *OpenAI) cacheNamespace() string {
+	return modelCacheNamespace(e, e.opts.ModelName, map[string]any{
+		"dimensions": e.opts.Dimensions,
+		"base_url":   e.opts.BaseURL,
+	})
+}
+
 // BatchEmbedText embeds a list of texts and returns their embeddings.
 func (e *OpenAI) BatchEmbedText(ctx context.Context, texts []string) ([][]float32, error) {
 	return e.getLenSafeEmbeddings(ctx, texts)
@@ -111,8 +123,9 @@ func (e *OpenAI) EmbedText(ctx context.Context, text string) ([]float32, error)
 	}
 
 	res, err := e.createEmbeddingsWithRetry(ctx, openai.EmbeddingRequest{
-		Model: nameToOpenAIModel[e.opts.ModelName],
-		Input: []string{text},
+		Model:      nameToOpenAIModel[e.opts.ModelName],
+		Input:      []string{text},
+		Dimensions: e.opts.Dimensions,
 	})
 	if err != nil {
 		return nil, err
@@ -122,41 +135,11 @@ func (e *OpenAI) EmbedText(ctx context.Context, text string) ([]float32, error)
 }
 
 func (e *OpenAI) createEmbeddingsWithRetry(ctx context.Context, request openai.EmbeddingRequestConverter) (openai.EmbeddingResponse, error) {
-	retryOpts := []retry.Option{
-		retry.Attempts(e.opts.MaxRetries),
-		retry.DelayType(retry.FixedDelay),
-		retry.RetryIf(func(err error) bool {
-			e := &openai.APIError{}
-			if errors.As(err, &e) {
-				switch e.HTTPStatusCode {
-				case 429, 500:
-					return true
-				default:
-					return false
-				}
-			}
-
-			return false
-		}),
-	}
-
-	var res openai.EmbeddingResponse
-
-	err := retry.Do(
-		func() error {
-			r, cErr := e.client.CreateEmbeddings(ctx, request)
-			if cErr != nil {
-				return cErr
-			}
+	policy := retry.NewPolicy(e.opts.MaxRetries, retry.IsRetryableOpenAIError)
 
-			res = r
-
-			return nil
-		},
-		retryOpts...,
-	)
-
-	return res, err
+	return retry.Do(ctx, policy, func(ctx context.Context) (openai.EmbeddingResponse, error) {
+		return e.client.CreateEmbeddings(ctx, request)
+	})
 }
 
 func (e *OpenAI) getLenSafeEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
@@ -204,8 +187,9 @@ func (e *OpenAI) getLenSafeEmbeddings(ctx context.Context, texts []string) ([][]
 		}
 
 		res, err := e.client.CreateEmbeddings(ctx, openai.EmbeddingRequest{
-			Model: nameToOpenAIModel[e.opts.ModelName],
-			Input: tokens[i:limit],
+			Model:      nameToOpenAIModel[e.opts.ModelName],
+			Input:      tokens[i:limit],
+			Dimensions: e.opts.Dimensions,
 		})
 		if err != nil {
 			return nil, err
@@ -234,8 +218,9 @@ func (e *OpenAI) getLenSafeEmbeddings(ctx context.Context, texts []string) ([][]
 
 		if len(result) == 0 {
 			res, err := e.client.CreateEmbeddings(ctx, openai.EmbeddingRequest{
-				Model: nameToOpenAIModel[e.opts.ModelName],
-				Input: []string{""},
+				Model:      nameToOpenAIModel[e.opts.ModelName],
+				Input:      []string{""},
+				Dimensions: e.opts.Dimensions,
 			})
 			if err != nil {
 				return nil, err
@@ -104,16 +104,44 @@ func TestOpenAI(t *testing.T) {
 			assert.Nil(t, embeddings, "Expected nil embeddings")
 		})
 	})
+
+	t.Run("Dimensions", func(t *testing.T) {
+		mockClient := &mockOpenAIClient{
+			response: openai.EmbeddingResponse{
+				Data: []openai.Embedding{{Embedding: []float32{0.6, 0.8}}},
+			},
+		}
+
+		openAIModel := NewOpenAIFromClient(mockClient, func(o *OpenAIOptions) {
+			o.ModelName = "text-embedding-3-large"
+			o.Dimensions = 256
+		})
+
+		_, err := openAIModel.EmbedText(context.Background(), "text1")
+		assert.NoError(t, err)
+
+		_, err = openAIModel.BatchEmbedText(context.Background(), []string{"text1"})
+		assert.NoError(t, err)
+
+		assert.Len(t, mockClient.requests, 2)
+
+		for _, req := range mockClient.requests {
+			assert.Equal(t, 256, req.Dimensions)
+		}
+	})
 }
 
 // mockOpenAIClient is a custom mock implementation of the OpenAI client interface.
 type mockOpenAIClient struct {
 	response openai.EmbeddingResponse
 	err      error
+	requests []openai.EmbeddingRequest
 }
 
 // CreateEmbeddings mocks the CreateEmbeddings method of the OpenAI client.
 func (m *mockOpenAIClient) CreateEmbeddings(ctx context.Context, conv openai.EmbeddingRequestConverter) (openai.EmbeddingResponse, error) {
+	m.requests = append(m.requests, conv.Convert())
+
 	if m.err != nil {
 		return openai.EmbeddingResponse{}, m.err
 	}
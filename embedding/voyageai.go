@@ -0,0 +1,145 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hupe1980/golc/integration/voyageai"
+	"github.com/hupe1980/golc/internal/retry"
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure VoyageAI satisfies the Embedder interface.
+var _ schema.Embedder = (*VoyageAI)(nil)
+
+// VoyageAIClient is an interface for the Voyage AI client.
+type VoyageAIClient interface {
+	CreateEmbeddings(ctx context.Context, req *voyageai.EmbeddingRequest) (*voyageai.EmbeddingResponse, error)
+}
+
+// VoyageAIOptions contains options for configuring the VoyageAI embedder.
+type VoyageAIOptions struct {
+	// ModelName is the name of the Voyage AI embedding model to use.
+	ModelName string `map:"model_name,omitempty"`
+	// OutputDimension is the number of dimensions of the embeddings, e.g. 256, 512, 1024 or 2048.
+	// It is only supported by some models. A value of 0 uses the default dimension of the model.
+	OutputDimension int `map:"output_dimension,omitempty"`
+	// Truncation truncates texts exceeding the context length of the model. If false, an error is returned.
+	Truncation bool `map:"truncation"`
+	// BatchSize is the maximum number of texts embedded by one request.
+	BatchSize int
+	// MaxRetries represents the maximum number of retries to make when embedding.
+	MaxRetries uint `map:"max_retries,omitempty"`
+}
+
+// VoyageAI is an embedder for the Voyage AI API.
+type VoyageAI struct {
+	client VoyageAIClient
+	opts   VoyageAIOptions
+}
+
+// NewVoyageAI creates a new VoyageAI embedder with the provided API key and options.
+func NewVoyageAI(apiKey string, optFns ...func(o *VoyageAIOptions)) *VoyageAI {
+	client := voyageai.New(apiKey, func(o *voyageai.ClientOptions) {
+		o.HTTPClient = retry.NewHTTPClient(nil)
+	})
+
+	return NewVoyageAIFromClient(client, optFns...)
+}
+
+// NewVoyageAIFromClient creates a new VoyageAI embedder from an existing Voyage AI client and options.
+func NewVoyageAIFromClient(client VoyageAIClient, optFns ...func(o *VoyageAIOptions)) *VoyageAI {
+	opts := VoyageAIOptions{
+		ModelName:  "voyage-3",
+		Truncation: true,
+		BatchSize:  128,
+		MaxRetries: 3,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &VoyageAI{
+		client: client,
+		opts:   opts,
+	}
+}
+
+// cacheNamespace returns the cache namespace of the model and its parameters.
+func (e *VoyageAI) cacheNamespace() string {
+	return modelCacheNamespace(e, e.opts.ModelName, map[string]any{
+		"output_dimension": e.opts.OutputDimension,
+		"truncation":       e.opts.Truncation,
+	})
+}
+
+// BatchEmbedText embeds a list of documents and returns their embeddings.
+func (e *VoyageAI) BatchEmbedText(ctx context.Context, texts []string) ([][]float32, error) {
+	batchSize := e.opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = len(texts)
+	}
+
+	embeddings := make([][]float32, 0, len(texts))
+
+	for start := 0; start < len(texts); start += batchSize {
+		end := util.Min(start+batchSize, len(texts))
+
+		batch, err := e.embed(ctx, texts[start:end], voyageai.InputTypeDocument)
+		if err != nil {
+			return nil, err
+		}
+
+		embeddings = append(embeddings, batch...)
+	}
+
+	return embeddings, nil
+}
+
+// EmbedText embeds a single query and returns its embedding.
+func (e *VoyageAI) EmbedText(ctx context.Context, text string) ([]float32, error) {
+	embeddings, err := e.embed(ctx, []string{text}, voyageai.InputTypeQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return embeddings[0], nil
+}
+
+// embed embeds the texts with a single request.
+func (e *VoyageAI) embed(ctx context.Context, texts []string, inputType voyageai.InputType) ([][]float32, error) {
+	policy := retry.NewPolicy(e.opts.MaxRetries, retry.IsRetryableVoyageAIError)
+
+	res, err := retry.Do(ctx, policy, func(ctx context.Context) (*voyageai.EmbeddingResponse, error) {
+		return e.client.CreateEmbeddings(ctx, &voyageai.EmbeddingRequest{
+			Input:           texts,
+			Model:           e.opts.ModelName,
+			InputType:       inputType,
+			Truncation:      &e.opts.Truncation,
+			OutputDimension: e.opts.OutputDimension,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	embeddings := make([][]float32, len(texts))
+
+	for _, d := range res.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("invalid embedding index: %d", d.Index)
+		}
+
+		embeddings[d.Index] = d.Embedding
+	}
+
+	for i, embedding := range embeddings {
+		if embedding == nil {
+			return nil, fmt.Errorf("missing embedding of text %d", i)
+		}
+	}
+
+	return embeddings, nil
+}
@@ -0,0 +1,90 @@
+package embedding
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hupe1980/golc/integration/voyageai"
+)
+
+func TestVoyageAI(t *testing.T) {
+	t.Run("BatchEmbedText", func(t *testing.T) {
+		client := &mockVoyageAIClient{}
+
+		embedder := NewVoyageAIFromClient(client, func(o *VoyageAIOptions) {
+			o.BatchSize = 2
+			o.OutputDimension = 512
+		})
+
+		embeddings, err := embedder.BatchEmbedText(context.Background(), []string{"a", "bb", "ccc"})
+		require.NoError(t, err)
+		assert.Equal(t, [][]float32{{1}, {2}, {3}}, embeddings)
+
+		require.Len(t, client.requests, 2)
+		assert.Equal(t, []string{"a", "bb"}, client.requests[0].Input)
+		assert.Equal(t, voyageai.InputTypeDocument, client.requests[0].InputType)
+		assert.Equal(t, "voyage-3", client.requests[0].Model)
+		assert.Equal(t, 512, client.requests[0].OutputDimension)
+		assert.True(t, *client.requests[0].Truncation)
+	})
+
+	t.Run("EmbedText", func(t *testing.T) {
+		client := &mockVoyageAIClient{}
+
+		embedding, err := NewVoyageAIFromClient(client).EmbedText(context.Background(), "query")
+		require.NoError(t, err)
+		assert.Equal(t, []float32{5}, embedding)
+		assert.Equal(t, voyageai.InputTypeQuery, client.requests[0].InputType)
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		client := &mockVoyageAIClient{
+			errs: []error{&voyageai.APIError{StatusCode: http.StatusTooManyRequests}},
+		}
+
+		embedding, err := NewVoyageAIFromClient(client).EmbedText(context.Background(), "query")
+		require.NoError(t, err)
+		assert.Equal(t, []float32{5}, embedding)
+		assert.Len(t, client.requests, 2)
+
+		client = &mockVoyageAIClient{
+			errs: []error{&voyageai.APIError{StatusCode: http.StatusBadRequest}},
+		}
+
+		_, err = NewVoyageAIFromClient(client).EmbedText(context.Background(), "query")
+		assert.Error(t, err)
+		assert.Len(t, client.requests, 1)
+	})
+}
+
+// mockVoyageAIClient embeds each text by its length and returns the embeddings in reverse order.
+type mockVoyageAIClient struct {
+	requests []*voyageai.EmbeddingRequest
+	errs     []error
+}
+
+func (m *mockVoyageAIClient) CreateEmbeddings(ctx context.Context, req *voyageai.EmbeddingRequest) (*voyageai.EmbeddingResponse, error) {
+	m.requests = append(m.requests, req)
+
+	if len(m.errs) > 0 {
+		err := m.errs[0]
+		m.errs = m.errs[1:]
+
+		return nil, err
+	}
+
+	res := &voyageai.EmbeddingResponse{}
+
+	for i := len(req.Input) - 1; i >= 0; i-- {
+		res.Data = append(res.Data, voyageai.Embedding{
+			Embedding: []float32{float32(len(req.Input[i]))},
+			Index:     i,
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,122 @@
+package evaluation
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCriteriaEvaluator(t *testing.T) {
+	judge := func(output string, prompts *[]string) *llm.Fake {
+		return llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+			*prompts = append(*prompts, prompt)
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: output}},
+				LLMOutput:   map[string]any{},
+			}, nil
+		})
+	}
+
+	t.Run("Binary", func(t *testing.T) {
+		var prompts []string
+
+		evaluator, err := NewCriteriaEvaluator(judge("The answer is short.\nIt has no filler.\nSCORE: Y", &prompts), CriterionConciseness)
+		require.NoError(t, err)
+
+		result, err := evaluator.EvaluateStrings(context.Background(), &Input{
+			Input:      "What is the capital of France?",
+			Prediction: "Paris",
+		})
+		require.NoError(t, err)
+		require.Equal(t, &Result{
+			Key:       "conciseness",
+			Score:     1,
+			Value:     "Y",
+			Reasoning: "The answer is short.\nIt has no filler.",
+		}, result)
+
+		require.Contains(t, prompts[0], "[Submission]: Paris")
+		require.Contains(t, prompts[0], "[Criterion]: conciseness: Is the submission concise and to the point?")
+		require.NotContains(t, prompts[0], "[Reference]")
+	})
+
+	t.Run("Reference", func(t *testing.T) {
+		var prompts []string
+
+		evaluator, err := NewCorrectnessEvaluator(judge("Berlin is not Paris.\nN", &prompts))
+		require.NoError(t, err)
+
+		_, err = evaluator.EvaluateStrings(context.Background(), &Input{Input: "Capital of France?", Prediction: "Berlin"})
+		require.ErrorIs(t, err, ErrReferenceRequired)
+
+		result, err := evaluator.EvaluateStrings(context.Background(), &Input{Input: "Capital of France?", Prediction: "Berlin", Reference: "Paris"})
+		require.NoError(t, err)
+		require.Equal(t, 0.0, result.Score)
+		require.Equal(t, "Berlin is not Paris.", result.Reasoning)
+		require.Contains(t, prompts[0], "[Reference]: Paris")
+	})
+
+	t.Run("Scale", func(t *testing.T) {
+		var prompts []string
+
+		evaluator, err := NewCriteriaEvaluator(judge("Mostly helpful.\n**Score:** 4", &prompts), Criterion{
+			Name:        "friendliness",
+			Description: "Is the submission friendly?",
+		}, func(o *CriteriaEvaluatorOptions) {
+			o.Scale = 5
+		})
+		require.NoError(t, err)
+
+		result, err := evaluator.EvaluateStrings(context.Background(), &Input{Input: "Hi", Prediction: "Hello there!"})
+		require.NoError(t, err)
+		require.Equal(t, "friendliness", result.Key)
+		require.Equal(t, 0.75, result.Score)
+		require.True(t, strings.Contains(prompts[0], "scale of 1 to 5"))
+
+		_, err = NewCriteriaEvaluator(judge("", &prompts), CriterionHelpfulness, func(o *CriteriaEvaluatorOptions) {
+			o.Scale = 1
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("InvalidVerdict", func(t *testing.T) {
+		var prompts []string
+
+		evaluator, err := NewCriteriaEvaluator(judge("I cannot decide.\nSCORE: maybe", &prompts), CriterionHarmfulness)
+		require.NoError(t, err)
+
+		_, err = evaluator.EvaluateStrings(context.Background(), &Input{Input: "Hi", Prediction: "Hello"})
+		require.ErrorContains(t, err, "unexpected verdict")
+	})
+}
+
+func TestEvaluateStrings(t *testing.T) {
+	evaluator, err := NewCriteriaEvaluator(llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+		verdict := "N"
+		if strings.Contains(prompt, "[Submission]: Paris") {
+			verdict = "Y"
+		}
+
+		return &schema.ModelResult{
+			Generations: []schema.Generation{{Text: "SCORE: " + verdict}},
+			LLMOutput:   map[string]any{},
+		}, nil
+	}), CriterionCorrectness)
+	require.NoError(t, err)
+
+	results, err := EvaluateStrings(context.Background(), evaluator, []*Input{
+		{Input: "Capital of France?", Prediction: "Paris", Reference: "Paris"},
+		{Input: "Capital of France?", Prediction: "Berlin", Reference: "Paris"},
+		{Input: "Capital of France?", Prediction: "Paris", Reference: "Paris"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	require.Equal(t, 1.0, results[0].Score)
+	require.Equal(t, 0.0, results[1].Score)
+	require.InDelta(t, 2.0/3, MeanScore(results), 1e-9)
+}
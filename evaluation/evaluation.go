@@ -1,2 +1,101 @@
 // Package evaluation provides utilities for evaluating and assessing the performance of generative models.
 package evaluation
+
+import (
+	"context"
+	"errors"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ErrReferenceRequired is returned by evaluators, which compare the prediction with a reference, if the
+// reference is missing.
+var ErrReferenceRequired = errors.New("evaluation requires a reference")
+
+// Input contains the data of an example to evaluate.
+type Input struct {
+	// Input is the input of the evaluated chain or model, e.g. a question.
+	Input string `json:"input"`
+	// Prediction is the output of the evaluated chain or model.
+	Prediction string `json:"prediction"`
+	// Reference is the expected output, e.g. the true answer. It is optional for most evaluators.
+	Reference string `json:"reference,omitempty"`
+}
+
+// Result is the result of an evaluation.
+type Result struct {
+	// Key is the name of the evaluated property, e.g. correctness.
+	Key string `json:"key"`
+	// Score is the normalized score between 0 and 1.
+	Score float64 `json:"score"`
+	// Value is the verdict of the evaluator, e.g. Y or N.
+	Value string `json:"value,omitempty"`
+	// Reasoning is the explanation of the verdict, if the evaluator provides one.
+	Reasoning string `json:"reasoning,omitempty"`
+}
+
+// StringEvaluator evaluates the prediction of a chain or model for an input.
+type StringEvaluator interface {
+	// EvaluateStrings evaluates the prediction and returns the result.
+	EvaluateStrings(ctx context.Context, input *Input) (*Result, error)
+}
+
+// BatchOptions contains options for evaluating a batch of inputs.
+type BatchOptions struct {
+	// MaxConcurrency is the maximum number of concurrent evaluations.
+	MaxConcurrency int
+}
+
+// EvaluateStrings evaluates the inputs concurrently and returns the results in the order of the inputs.
+func EvaluateStrings(ctx context.Context, evaluator StringEvaluator, inputs []*Input, optFns ...func(o *BatchOptions)) ([]*Result, error) {
+	opts := BatchOptions{
+		MaxConcurrency: 5,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	errs, errctx := errgroup.WithContext(ctx)
+
+	if opts.MaxConcurrency > 0 {
+		errs.SetLimit(opts.MaxConcurrency)
+	}
+
+	results := make([]*Result, len(inputs))
+
+	for i, input := range inputs {
+		i, input := i, input
+
+		errs.Go(func() error {
+			result, err := evaluator.EvaluateStrings(errctx, input)
+			if err != nil {
+				return err
+			}
+
+			results[i] = result
+
+			return nil
+		})
+	}
+
+	if err := errs.Wait(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// MeanScore returns the mean score of the results, e.g. to assert a minimum quality in tests.
+func MeanScore(results []*Result) float64 {
+	if len(results) == 0 {
+		return 0
+	}
+
+	sum := 0.0
+	for _, r := range results {
+		sum += r.Score
+	}
+
+	return sum / float64(len(results))
+}
@@ -0,0 +1,276 @@
+package evaluation
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/hupe1980/golc/retriever"
+	"github.com/hupe1980/golc/schema"
+)
+
+// RetrievalExample is a query labeled with the ids of the relevant documents.
+type RetrievalExample struct {
+	// Query is the query of the retriever.
+	Query string `json:"query"`
+	// RelevantIDs are the ids of the documents relevant for the query.
+	RelevantIDs []string `json:"relevant_ids"`
+}
+
+// RetrievalQueryResult contains the metrics of a query.
+type RetrievalQueryResult struct {
+	// Query is the query of the retriever.
+	Query string `json:"query"`
+	// RetrievedIDs are the ids of the top k retrieved documents in the order of the retriever.
+	RetrievedIDs []string `json:"retrieved_ids"`
+	// Hit indicates, whether a relevant document has been retrieved.
+	Hit bool `json:"hit"`
+	// ReciprocalRank is the reciprocal of the rank of the first relevant document or 0.
+	ReciprocalRank float64 `json:"reciprocal_rank"`
+	// NDCG is the normalized discounted cumulative gain.
+	NDCG float64 `json:"ndcg"`
+	// Recall is the fraction of the relevant documents, which have been retrieved.
+	Recall float64 `json:"recall"`
+}
+
+// RetrievalReport contains the metrics of a retriever averaged over the queries of a dataset.
+type RetrievalReport struct {
+	// K is the number of retrieved documents considered per query.
+	K int `json:"k"`
+	// HitRate is the fraction of the queries with a relevant document in the top k (hit@k).
+	HitRate float64 `json:"hit_rate"`
+	// MRR is the mean reciprocal rank of the first relevant document.
+	MRR float64 `json:"mrr"`
+	// NDCG is the mean normalized discounted cumulative gain at k.
+	NDCG float64 `json:"ndcg"`
+	// Recall is the mean recall at k.
+	Recall float64 `json:"recall"`
+	// Queries contains the metrics of the queries.
+	Queries []RetrievalQueryResult `json:"queries"`
+}
+
+// RetrievalEvaluatorOptions contains options for configuring the RetrievalEvaluator.
+type RetrievalEvaluatorOptions struct {
+	// CallbackOptions contains options for the retriever callbacks.
+	*schema.CallbackOptions
+	// K is the number of retrieved documents considered per query.
+	K int
+	// IDKey is the metadata key of the document ids.
+	IDKey string
+	// DocumentIDFunc returns the id of a document. It overrides the IDKey.
+	DocumentIDFunc func(doc schema.Document) string
+	// MaxConcurrency is the maximum number of concurrent queries.
+	MaxConcurrency int
+}
+
+// RetrievalEvaluator scores a retriever against a dataset of queries labeled with the relevant documents.
+// It reports the hit rate, the mean reciprocal rank (MRR) and the normalized discounted cumulative gain
+// (nDCG) at k, so chunking or embedding changes can be compared quantitatively.
+type RetrievalEvaluator struct {
+	retriever schema.Retriever
+	opts      RetrievalEvaluatorOptions
+}
+
+// NewRetrievalEvaluator creates a new RetrievalEvaluator for the retriever.
+func NewRetrievalEvaluator(retriever schema.Retriever, optFns ...func(o *RetrievalEvaluatorOptions)) (*RetrievalEvaluator, error) {
+	opts := RetrievalEvaluatorOptions{
+		CallbackOptions: &schema.CallbackOptions{},
+		K:               5,
+		IDKey:           "id",
+		MaxConcurrency:  5,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.K <= 0 {
+		return nil, fmt.Errorf("k must be positive, got %d", opts.K)
+	}
+
+	if opts.DocumentIDFunc == nil {
+		opts.DocumentIDFunc = func(doc schema.Document) string {
+			if id, ok := doc.Metadata[opts.IDKey]; ok {
+				return fmt.Sprint(id)
+			}
+
+			return ""
+		}
+	}
+
+	return &RetrievalEvaluator{
+		retriever: retriever,
+		opts:      opts,
+	}, nil
+}
+
+// Evaluate retrieves the documents of the queries and returns the report.
+func (e *RetrievalEvaluator) Evaluate(ctx context.Context, examples []RetrievalExample) (*RetrievalReport, error) {
+	errs, errctx := errgroup.WithContext(ctx)
+
+	if e.opts.MaxConcurrency > 0 {
+		errs.SetLimit(e.opts.MaxConcurrency)
+	}
+
+	queries := make([]RetrievalQueryResult, len(examples))
+
+	for i, example := range examples {
+		i, example := i, example
+
+		errs.Go(func() error {
+			docs, err := retriever.Run(errctx, e.retriever, example.Query, func(o *retriever.Options) {
+				o.Callbacks = e.opts.Callbacks
+			})
+			if err != nil {
+				return err
+			}
+
+			retrieved := make([]string, 0, e.opts.K)
+			for _, doc := range docs {
+				if len(retrieved) == e.opts.K {
+					break
+				}
+
+				retrieved = append(retrieved, e.opts.DocumentIDFunc(doc))
+			}
+
+			queries[i] = RetrievalQueryResult{
+				Query:          example.Query,
+				RetrievedIDs:   retrieved,
+				Hit:            HitAtK(retrieved, example.RelevantIDs, e.opts.K),
+				ReciprocalRank: ReciprocalRank(retrieved, example.RelevantIDs, e.opts.K),
+				NDCG:           NDCGAtK(retrieved, example.RelevantIDs, e.opts.K),
+				Recall:         RecallAtK(retrieved, example.RelevantIDs, e.opts.K),
+			}
+
+			return nil
+		})
+	}
+
+	if err := errs.Wait(); err != nil {
+		return nil, err
+	}
+
+	report := &RetrievalReport{
+		K:       e.opts.K,
+		Queries: queries,
+	}
+
+	if len(queries) == 0 {
+		return report, nil
+	}
+
+	for _, q := range queries {
+		if q.Hit {
+			report.HitRate++
+		}
+
+		report.MRR += q.ReciprocalRank
+		report.NDCG += q.NDCG
+		report.Recall += q.Recall
+	}
+
+	n := float64(len(queries))
+
+	report.HitRate /= n
+	report.MRR /= n
+	report.NDCG /= n
+	report.Recall /= n
+
+	return report, nil
+}
+
+// HitAtK reports whether one of the top k retrieved ids is relevant.
+func HitAtK(retrieved, relevant []string, k int) bool {
+	return ReciprocalRank(retrieved, relevant, k) > 0
+}
+
+// ReciprocalRank returns the reciprocal of the rank of the first relevant id in the top k retrieved ids,
+// or 0 if none of them is relevant.
+func ReciprocalRank(retrieved, relevant []string, k int) float64 {
+	set := idSet(relevant)
+
+	for i, id := range topK(retrieved, k) {
+		if _, ok := set[id]; ok {
+			return 1 / float64(i+1)
+		}
+	}
+
+	return 0
+}
+
+// RecallAtK returns the fraction of the relevant ids in the top k retrieved ids.
+func RecallAtK(retrieved, relevant []string, k int) float64 {
+	set := idSet(relevant)
+	if len(set) == 0 {
+		return 0
+	}
+
+	found := 0
+
+	for _, id := range topK(retrieved, k) {
+		if _, ok := set[id]; ok {
+			found++
+
+			delete(set, id)
+		}
+	}
+
+	return float64(found) / float64(len(idSet(relevant)))
+}
+
+// NDCGAtK returns the normalized discounted cumulative gain of the top k retrieved ids with binary
+// relevance. The gain of a relevant id at rank i is 1/log2(i+1); the sum is normalized by the gain of
+// the ideal ranking. Repeated ids count only once. It returns 0, if k <= 0 or no id is relevant.
+func NDCGAtK(retrieved, relevant []string, k int) float64 {
+	set := idSet(relevant)
+	if len(set) == 0 || k <= 0 {
+		return 0
+	}
+
+	dcg := 0.0
+
+	for i, id := range topK(retrieved, k) {
+		if _, ok := set[id]; ok {
+			dcg += 1 / math.Log2(float64(i+2))
+
+			delete(set, id)
+		}
+	}
+
+	idcg := 0.0
+	for i := 0; i < len(idSet(relevant)) && i < k; i++ {
+		idcg += 1 / math.Log2(float64(i+2))
+	}
+
+	if idcg == 0 {
+		return 0
+	}
+
+	return dcg / idcg
+}
+
+// topK returns the first k ids, or none if k <= 0.
+func topK(ids []string, k int) []string {
+	if k <= 0 {
+		return nil
+	}
+
+	if len(ids) > k {
+		return ids[:k]
+	}
+
+	return ids
+}
+
+// idSet returns the set of the ids.
+func idSet(ids []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+
+	return set
+}
@@ -0,0 +1,137 @@
+package evaluation
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetrievalMetrics(t *testing.T) {
+	retrieved := []string{"a", "b", "c", "d"}
+
+	t.Run("HitAtK", func(t *testing.T) {
+		require.True(t, HitAtK(retrieved, []string{"c"}, 3))
+		require.False(t, HitAtK(retrieved, []string{"d"}, 3))
+		require.False(t, HitAtK(retrieved, nil, 3))
+	})
+
+	t.Run("ReciprocalRank", func(t *testing.T) {
+		require.Equal(t, 1.0, ReciprocalRank(retrieved, []string{"a", "c"}, 4))
+		require.Equal(t, 1.0/3, ReciprocalRank(retrieved, []string{"c"}, 4))
+		require.Equal(t, 0.0, ReciprocalRank(retrieved, []string{"d"}, 3))
+	})
+
+	t.Run("RecallAtK", func(t *testing.T) {
+		require.Equal(t, 0.5, RecallAtK(retrieved, []string{"b", "x"}, 4))
+		require.Equal(t, 0.5, RecallAtK([]string{"b", "b"}, []string{"b", "x"}, 4))
+		require.Equal(t, 0.0, RecallAtK(retrieved, nil, 4))
+		require.Equal(t, 0.0, RecallAtK(retrieved, []string{"a"}, -1))
+	})
+
+	t.Run("NDCGAtK", func(t *testing.T) {
+		require.Equal(t, 1.0, NDCGAtK(retrieved, []string{"a", "b"}, 4))
+		require.InDelta(t, 0.5, NDCGAtK(retrieved, []string{"c"}, 4), 1e-9)
+		require.InDelta(t, (1/math.Log2(3))/(1+1/math.Log2(3)), NDCGAtK(retrieved, []string{"b", "x"}, 4), 1e-9)
+		require.Equal(t, 0.0, NDCGAtK(retrieved, []string{"d"}, 3))
+		require.Equal(t, 0.0, NDCGAtK(retrieved, []string{"a"}, 0))
+		require.Equal(t, 0.0, NDCGAtK(retrieved, []string{"a"}, -1))
+	})
+}
+
+func TestRetrievalEvaluator(t *testing.T) {
+	r := &mockRetriever{
+		docs: map[string][]schema.Document{
+			"golang": {
+				{PageContent: "Go", Metadata: map[string]any{"id": 1}},
+				{PageContent: "Gopher", Metadata: map[string]any{"id": 2}},
+			},
+			"python": {
+				{PageContent: "Snake", Metadata: map[string]any{"id": 3}},
+				{PageContent: "Python", Metadata: map[string]any{"id": 4}},
+			},
+			"rust": {
+				{PageContent: "Crab", Metadata: map[string]any{"id": 5}},
+			},
+		},
+	}
+
+	t.Run("Evaluate", func(t *testing.T) {
+		evaluator, err := NewRetrievalEvaluator(r, func(o *RetrievalEvaluatorOptions) {
+			o.K = 2
+		})
+		require.NoError(t, err)
+
+		report, err := evaluator.Evaluate(context.Background(), []RetrievalExample{
+			{Query: "golang", RelevantIDs: []string{"1"}},
+			{Query: "python", RelevantIDs: []string{"4"}},
+			{Query: "rust", RelevantIDs: []string{"6"}},
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, 2, report.K)
+		require.Len(t, report.Queries, 3)
+		require.Equal(t, []string{"3", "4"}, report.Queries[1].RetrievedIDs)
+		require.Equal(t, 0.5, report.Queries[1].ReciprocalRank)
+		require.False(t, report.Queries[2].Hit)
+		require.InDelta(t, 2.0/3, report.HitRate, 1e-9)
+		require.InDelta(t, 0.5, report.MRR, 1e-9)
+		require.InDelta(t, (1+1/math.Log2(3))/3, report.NDCG, 1e-9)
+		require.InDelta(t, 2.0/3, report.Recall, 1e-9)
+	})
+
+	t.Run("DocumentIDFunc", func(t *testing.T) {
+		evaluator, err := NewRetrievalEvaluator(r, func(o *RetrievalEvaluatorOptions) {
+			o.DocumentIDFunc = func(doc schema.Document) string {
+				return doc.PageContent
+			}
+		})
+		require.NoError(t, err)
+
+		report, err := evaluator.Evaluate(context.Background(), []RetrievalExample{
+			{Query: "golang", RelevantIDs: []string{"Gopher"}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 0.5, report.MRR)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		evaluator, err := NewRetrievalEvaluator(&mockRetriever{err: errors.New("retriever error")})
+		require.NoError(t, err)
+
+		_, err = evaluator.Evaluate(context.Background(), []RetrievalExample{{Query: "golang"}})
+		require.EqualError(t, err, "retriever error")
+	})
+
+	t.Run("InvalidK", func(t *testing.T) {
+		_, err := NewRetrievalEvaluator(r, func(o *RetrievalEvaluatorOptions) {
+			o.K = 0
+		})
+		require.Error(t, err)
+	})
+}
+
+// mockRetriever is a mock implementation of the schema.Retriever interface.
+type mockRetriever struct {
+	docs map[string][]schema.Document
+	err  error
+}
+
+func (m *mockRetriever) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	return m.docs[query], nil
+}
+
+func (m *mockRetriever) Verbose() bool {
+	return false
+}
+
+func (m *mockRetriever) Callbacks() []schema.Callback {
+	return nil
+}
@@ -0,0 +1,524 @@
+package evaluation
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/agent"
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Example is an example of a dataset.
+type Example struct {
+	// ID is the optional id of the example.
+	ID string `json:"id,omitempty"`
+	// Inputs are the inputs of the chain.
+	Inputs schema.ChainValues `json:"inputs"`
+	// Reference is the expected output, e.g. the true answer.
+	Reference string `json:"reference,omitempty"`
+}
+
+// LoadDataset reads a dataset of examples in the JSON Lines format, one example per line. Blank lines are skipped.
+func LoadDataset(r io.Reader) ([]Example, error) {
+	examples := []Example{}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	line := 0
+
+	for scanner.Scan() {
+		line++
+
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		example := Example{}
+		if err := json.Unmarshal([]byte(text), &example); err != nil {
+			return nil, fmt.Errorf("invalid example in line %d: %w", line, err)
+		}
+
+		examples = append(examples, example)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return examples, nil
+}
+
+// ExampleResult is the result of running and evaluating an example.
+type ExampleResult struct {
+	// ID is the id of the example.
+	ID string `json:"id,omitempty"`
+	// RunID is the id of the root run of the chain. It links the result to the trace.
+	RunID string `json:"run_id"`
+	// Inputs are the inputs of the chain.
+	Inputs schema.ChainValues `json:"inputs"`
+	// Reference is the expected output of the example.
+	Reference string `json:"reference,omitempty"`
+	// Outputs are the outputs of the chain.
+	Outputs schema.ChainValues `json:"outputs,omitempty"`
+	// Prediction is the output of the chain passed to the evaluators.
+	Prediction string `json:"prediction"`
+	// Results are the results of the evaluators.
+	Results []*Result `json:"results,omitempty"`
+	// Error is the error of the chain or an evaluator, if any.
+	Error string `json:"error,omitempty"`
+	// Latency is the duration of the chain run.
+	Latency time.Duration `json:"latency"`
+	// Trace contains the runs of the chain run in the order of their start.
+	Trace []*callback.Run `json:"trace,omitempty"`
+}
+
+// Score returns the score of the evaluator with the key.
+func (r *ExampleResult) Score(key string) (float64, bool) {
+	for _, result := range r.Results {
+		if result.Key == key {
+			return result.Score, true
+		}
+	}
+
+	return 0, false
+}
+
+// Report is the aggregate report of a dataset run.
+type Report struct {
+	// NumExamples is the number of examples.
+	NumExamples int `json:"num_examples"`
+	// NumErrors is the number of examples, which failed.
+	NumErrors int `json:"num_errors"`
+	// MeanScores are the mean scores of the evaluators by key. Failed examples are not included.
+	MeanScores map[string]float64 `json:"mean_scores"`
+	// MeanLatency is the mean latency of the chain runs.
+	MeanLatency time.Duration `json:"mean_latency"`
+	// Examples are the results of the examples in the order of the dataset.
+	Examples []*ExampleResult `json:"examples"`
+}
+
+// Keys returns the sorted keys of the evaluators.
+func (r *Report) Keys() []string {
+	keys := make([]string, 0, len(r.MeanScores))
+	for k := range r.MeanScores {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// WriteJSON writes the report including the traces as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(r)
+}
+
+// WriteCSV writes a row per example with the scores of the evaluators as columns.
+func (r *Report) WriteCSV(w io.Writer) error {
+	keys := r.Keys()
+
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(append([]string{"id", "run_id", "input", "reference", "prediction", "error", "latency_ms"}, keys...)); err != nil {
+		return err
+	}
+
+	for _, e := range r.Examples {
+		record := []string{e.ID, e.RunID, formatInputs(e.Inputs), e.Reference, e.Prediction, e.Error, strconv.FormatInt(e.Latency.Milliseconds(), 10)}
+
+		for _, k := range keys {
+			score := ""
+			if s, ok := e.Score(k); ok {
+				score = strconv.FormatFloat(s, 'f', -1, 64)
+			}
+
+			record = append(record, score)
+		}
+
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// WriteMarkdown writes a summary table and a table of the examples as markdown.
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	keys := r.Keys()
+
+	b := &strings.Builder{}
+
+	b.WriteString("# Evaluation Report\n\n")
+	b.WriteString("| Metric | Value |\n| --- | --- |\n")
+	fmt.Fprintf(b, "| Examples | %d |\n", r.NumExamples)
+	fmt.Fprintf(b, "| Errors | %d |\n", r.NumErrors)
+	fmt.Fprintf(b, "| Mean latency | %s |\n", r.MeanLatency.Round(time.Millisecond))
+
+	for _, k := range keys {
+		fmt.Fprintf(b, "| %s | %.3f |\n", escapeMarkdown(k), r.MeanScores[k])
+	}
+
+	b.WriteString("\n## Examples\n\n| ID | Run ID | Input | Prediction |")
+
+	for _, k := range keys {
+		fmt.Fprintf(b, " %s |", escapeMarkdown(k))
+	}
+
+	b.WriteString(" Error |\n|" + strings.Repeat(" --- |", len(keys)+5) + "\n")
+
+	for _, e := range r.Examples {
+		fmt.Fprintf(b, "| %s | %s | %s | %s |", escapeMarkdown(e.ID), e.RunID, escapeMarkdown(formatInputs(e.Inputs)), escapeMarkdown(e.Prediction))
+
+		for _, k := range keys {
+			if s, ok := e.Score(k); ok {
+				fmt.Fprintf(b, " %.3f |", s)
+			} else {
+				b.WriteString(" |")
+			}
+		}
+
+		fmt.Fprintf(b, " %s |\n", escapeMarkdown(e.Error))
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// DatasetRunnerOptions contains options for configuring the DatasetRunner.
+type DatasetRunnerOptions struct {
+	// Callbacks are passed to the chain runs, e.g. to export the traces to a tracing backend.
+	Callbacks []schema.Callback
+	// Evaluators evaluate the predictions of the chain.
+	Evaluators []StringEvaluator
+	// TrajectoryEvaluators evaluate the intermediate steps of agents. The steps are read from the outputs
+	// under the StepsKey, i.e. the executor must be created with ReturnIntermediateSteps.
+	TrajectoryEvaluators []TrajectoryEvaluator
+	// StepsKey is the output key of the intermediate steps of agents.
+	StepsKey string
+	// InputKey is the key of the input passed to the evaluators. If it is empty and the chain has multiple
+	// input keys, the inputs are passed as JSON.
+	InputKey string
+	// PredictionKey is the output key of the prediction passed to the evaluators.
+	PredictionKey string
+	// MaxConcurrency is the maximum number of concurrent examples.
+	MaxConcurrency int
+	// SkipTraces disables recording the traces of the examples.
+	SkipTraces bool
+}
+
+// DatasetRunner runs a chain over the examples of a dataset concurrently, evaluates the predictions with
+// the configured evaluators and aggregates the results in a report. The runs of each example are recorded
+// as trace, which is linked to the result by the run id of the chain.
+type DatasetRunner struct {
+	chain schema.Chain
+	opts  DatasetRunnerOptions
+}
+
+// NewDatasetRunner creates a new DatasetRunner for the chain.
+func NewDatasetRunner(chain schema.Chain, optFns ...func(o *DatasetRunnerOptions)) (*DatasetRunner, error) {
+	opts := DatasetRunnerOptions{
+		StepsKey:       agent.IntermediateStepsKey,
+		MaxConcurrency: 5,
+	}
+
+	if inputKeys := chain.InputKeys(); len(inputKeys) == 1 {
+		opts.InputKey = inputKeys[0]
+	}
+
+	if outputKeys := outputKeysWithoutSteps(chain.OutputKeys()); len(outputKeys) == 1 {
+		opts.PredictionKey = outputKeys[0]
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.PredictionKey == "" {
+		return nil, fmt.Errorf("prediction key is required for chains with multiple output keys: %v", chain.OutputKeys())
+	}
+
+	return &DatasetRunner{
+		chain: chain,
+		opts:  opts,
+	}, nil
+}
+
+// Run runs and evaluates the examples and returns the report. Errors of the chain or the evaluators are
+// recorded in the results of the examples; Run only fails, if the context is canceled.
+func (r *DatasetRunner) Run(ctx context.Context, examples []Example) (*Report, error) {
+	errs, errctx := errgroup.WithContext(ctx)
+
+	if r.opts.MaxConcurrency > 0 {
+		errs.SetLimit(r.opts.MaxConcurrency)
+	}
+
+	results := make([]*ExampleResult, len(examples))
+
+	for i, example := range examples {
+		i, example := i, example
+
+		errs.Go(func() error {
+			result, err := r.runExample(errctx, example)
+			if err != nil {
+				return err
+			}
+
+			results[i] = result
+
+			return nil
+		})
+	}
+
+	if err := errs.Wait(); err != nil {
+		return nil, err
+	}
+
+	return newReport(results), nil
+}
+
+// runExample runs the chain with the inputs of the example and evaluates the prediction.
+func (r *DatasetRunner) runExample(ctx context.Context, example Example) (*ExampleResult, error) {
+	result := &ExampleResult{
+		ID:        example.ID,
+		Inputs:    example.Inputs,
+		Reference: example.Reference,
+	}
+
+	// Copy the inputs, because the chain may add memory variables.
+	inputs := make(schema.ChainValues, len(example.Inputs))
+	for k, v := range example.Inputs {
+		inputs[k] = v
+	}
+
+	exporter := &traceRecorder{}
+	tracer := callback.NewTracingHandler(exporter, func(o *callback.TracingHandlerOptions) {
+		o.FlushInterval = time.Hour
+	})
+
+	start := time.Now()
+
+	outputs, err := golc.Call(ctx, r.chain, inputs, func(o *golc.CallOptions) {
+		o.Callbacks = append([]schema.Callback{tracer}, r.opts.Callbacks...)
+	})
+
+	result.Latency = time.Since(start)
+
+	if cErr := tracer.Close(ctx); cErr != nil {
+		return nil, cErr
+	}
+
+	result.RunID, result.Trace = exporter.trace()
+
+	if r.opts.SkipTraces {
+		result.Trace = nil
+	}
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		result.Error = err.Error()
+
+		return result, nil
+	}
+
+	result.Outputs = outputs
+
+	prediction, ok := outputs[r.opts.PredictionKey]
+	if !ok {
+		result.Error = fmt.Sprintf("missing prediction key %q in outputs", r.opts.PredictionKey)
+		return result, nil
+	}
+
+	result.Prediction = fmt.Sprint(prediction)
+
+	input := &Input{
+		Prediction: result.Prediction,
+		Reference:  example.Reference,
+	}
+
+	if r.opts.InputKey != "" {
+		input.Input = fmt.Sprint(example.Inputs[r.opts.InputKey])
+	} else {
+		input.Input = formatInputs(example.Inputs)
+	}
+
+	for _, evaluator := range r.opts.Evaluators {
+		evalResult, err := evaluator.EvaluateStrings(ctx, input)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			result.Error = fmt.Sprintf("evaluator %T: %s", evaluator, err)
+
+			return result, nil
+		}
+
+		result.Results = append(result.Results, evalResult)
+	}
+
+	if len(r.opts.TrajectoryEvaluators) == 0 {
+		return result, nil
+	}
+
+	steps, ok := outputs[r.opts.StepsKey].([]schema.AgentStep)
+	if !ok {
+		result.Error = fmt.Sprintf("missing intermediate steps key %q in outputs", r.opts.StepsKey)
+		return result, nil
+	}
+
+	trajectory := &TrajectoryInput{
+		Input:      input.Input,
+		Prediction: input.Prediction,
+		Steps:      steps,
+		Reference:  input.Reference,
+	}
+
+	for _, evaluator := range r.opts.TrajectoryEvaluators {
+		evalResult, err := evaluator.EvaluateAgentTrajectory(ctx, trajectory)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			result.Error = fmt.Sprintf("evaluator %T: %s", evaluator, err)
+
+			return result, nil
+		}
+
+		overall := evalResult.Result
+		result.Results = append(result.Results, &overall)
+		result.Results = append(result.Results, evalResult.Criteria...)
+	}
+
+	return result, nil
+}
+
+// outputKeysWithoutSteps returns the output keys without the key of the intermediate steps of agents.
+func outputKeysWithoutSteps(keys []string) []string {
+	filtered := make([]string, 0, len(keys))
+
+	for _, k := range keys {
+		if k != agent.IntermediateStepsKey {
+			filtered = append(filtered, k)
+		}
+	}
+
+	return filtered
+}
+
+// newReport aggregates the results of the examples.
+func newReport(results []*ExampleResult) *Report {
+	report := &Report{
+		NumExamples: len(results),
+		MeanScores:  map[string]float64{},
+		Examples:    results,
+	}
+
+	counts := map[string]int{}
+
+	var latency time.Duration
+
+	for _, result := range results {
+		latency += result.Latency
+
+		if result.Error != "" {
+			report.NumErrors++
+			continue
+		}
+
+		for _, r := range result.Results {
+			report.MeanScores[r.Key] += r.Score
+			counts[r.Key]++
+		}
+	}
+
+	for k, n := range counts {
+		report.MeanScores[k] /= float64(n)
+	}
+
+	if len(results) > 0 {
+		report.MeanLatency = latency / time.Duration(len(results))
+	}
+
+	return report
+}
+
+// traceRecorder is a run exporter, which records the runs of a single chain run.
+type traceRecorder struct {
+	mu   sync.Mutex
+	runs []*callback.Run
+}
+
+// Export records the runs.
+func (tr *traceRecorder) Export(ctx context.Context, runs []*callback.Run) error {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	tr.runs = append(tr.runs, runs...)
+
+	return nil
+}
+
+// trace returns the id of the root run and the runs in the order of their start.
+func (tr *traceRecorder) trace() (string, []*callback.Run) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	runs := append([]*callback.Run{}, tr.runs...)
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].DottedOrder < runs[j].DottedOrder
+	})
+
+	runID := ""
+
+	for _, run := range runs {
+		if run.ParentID == "" {
+			runID = run.ID
+			break
+		}
+	}
+
+	return runID, runs
+}
+
+// formatInputs returns the inputs as JSON.
+func formatInputs(inputs schema.ChainValues) string {
+	b, err := json.Marshal(inputs)
+	if err != nil {
+		return fmt.Sprint(inputs)
+	}
+
+	return string(b)
+}
+
+// escapeMarkdown escapes a value of a markdown table cell.
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>").Replace(s)
+}
@@ -0,0 +1,138 @@
+package evaluation
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hupe1980/golc/chain"
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDataset(t *testing.T) {
+	examples, err := LoadDataset(strings.NewReader(`{"id": "1", "inputs": {"question": "Capital of France?"}, "reference": "Paris"}
+
+{"inputs": {"question": "Capital of Germany?"}}
+`))
+	require.NoError(t, err)
+	require.Equal(t, []Example{
+		{ID: "1", Inputs: schema.ChainValues{"question": "Capital of France?"}, Reference: "Paris"},
+		{Inputs: schema.ChainValues{"question": "Capital of Germany?"}},
+	}, examples)
+
+	_, err = LoadDataset(strings.NewReader("{\"inputs\": {}}\n{invalid"))
+	require.ErrorContains(t, err, "line 2")
+}
+
+func TestDatasetRunner(t *testing.T) {
+	model := llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+		switch {
+		case strings.Contains(prompt, "France"):
+			return &schema.ModelResult{Generations: []schema.Generation{{Text: "Paris"}}, LLMOutput: map[string]any{}}, nil
+		case strings.Contains(prompt, "Germany"):
+			return &schema.ModelResult{Generations: []schema.Generation{{Text: "Munich"}}, LLMOutput: map[string]any{}}, nil
+		default:
+			return nil, errors.New("model error")
+		}
+	})
+
+	llmChain, err := chain.NewLLM(model, prompt.NewTemplate("Answer the question: {{.question}}"))
+	require.NoError(t, err)
+
+	runner, err := NewDatasetRunner(llmChain, func(o *DatasetRunnerOptions) {
+		o.Evaluators = []StringEvaluator{&exactMatchEvaluator{}}
+	})
+	require.NoError(t, err)
+
+	report, err := runner.Run(context.Background(), []Example{
+		{ID: "france", Inputs: schema.ChainValues{"question": "Capital of France?"}, Reference: "Paris"},
+		{ID: "germany", Inputs: schema.ChainValues{"question": "Capital of Germany?"}, Reference: "Berlin"},
+		{ID: "italy", Inputs: schema.ChainValues{"question": "Capital of Italy?"}, Reference: "Rome"},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 3, report.NumExamples)
+	require.Equal(t, 1, report.NumErrors)
+	require.Equal(t, map[string]float64{"exact_match": 0.5}, report.MeanScores)
+
+	france := report.Examples[0]
+	require.Equal(t, "Paris", france.Prediction)
+	require.Equal(t, []*Result{{Key: "exact_match", Score: 1, Value: "Capital of France?"}}, france.Results)
+	require.NotEmpty(t, france.RunID)
+	require.Len(t, france.Trace, 2)
+	require.Equal(t, france.RunID, france.Trace[0].ID)
+	require.Equal(t, france.RunID, france.Trace[1].TraceID)
+
+	italy := report.Examples[2]
+	require.Equal(t, "model error", italy.Error)
+	require.NotEmpty(t, italy.RunID)
+	require.Equal(t, "model error", italy.Trace[0].Error)
+	require.NotEqual(t, france.RunID, italy.RunID)
+
+	t.Run("JSON", func(t *testing.T) {
+		b := &bytes.Buffer{}
+		require.NoError(t, report.WriteJSON(b))
+
+		decoded := Report{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), &decoded))
+		require.Equal(t, report.MeanScores, decoded.MeanScores)
+		require.Equal(t, france.RunID, decoded.Examples[0].RunID)
+		require.Len(t, decoded.Examples[0].Trace, 2)
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		b := &bytes.Buffer{}
+		require.NoError(t, report.WriteCSV(b))
+
+		records, err := csv.NewReader(b).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 4)
+		require.Equal(t, []string{"id", "run_id", "input", "reference", "prediction", "error", "latency_ms", "exact_match"}, records[0])
+		require.Equal(t, []string{"germany", report.Examples[1].RunID, `{"question":"Capital of Germany?"}`, "Berlin", "Munich", ""}, records[2][:6])
+		require.Equal(t, "0", records[2][7])
+		require.Equal(t, "", records[3][7])
+	})
+
+	t.Run("Markdown", func(t *testing.T) {
+		b := &bytes.Buffer{}
+		require.NoError(t, report.WriteMarkdown(b))
+
+		md := b.String()
+		require.Contains(t, md, "| exact_match | 0.500 |")
+		require.Contains(t, md, "| ID | Run ID | Input | Prediction | exact_match | Error |")
+		require.Contains(t, md, "| france | "+france.RunID+" |")
+		require.Contains(t, md, "| model error |")
+	})
+
+	t.Run("SkipTraces", func(t *testing.T) {
+		runner, err := NewDatasetRunner(llmChain, func(o *DatasetRunnerOptions) {
+			o.SkipTraces = true
+		})
+		require.NoError(t, err)
+
+		report, err := runner.Run(context.Background(), []Example{{Inputs: schema.ChainValues{"question": "Capital of France?"}}})
+		require.NoError(t, err)
+		require.NotEmpty(t, report.Examples[0].RunID)
+		require.Nil(t, report.Examples[0].Trace)
+		require.Empty(t, report.MeanScores)
+	})
+}
+
+// exactMatchEvaluator scores whether the prediction equals the reference. The value is the input.
+type exactMatchEvaluator struct{}
+
+func (e *exactMatchEvaluator) EvaluateStrings(ctx context.Context, input *Input) (*Result, error) {
+	score := 0.0
+	if input.Prediction == input.Reference {
+		score = 1
+	}
+
+	return &Result{Key: "exact_match", Score: score, Value: input.Input}, nil
+}
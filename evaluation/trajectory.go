@@ -0,0 +1,274 @@
+package evaluation
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/chain"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure AgentTrajectoryEvaluator satisfies the TrajectoryEvaluator interface.
+var _ TrajectoryEvaluator = (*AgentTrajectoryEvaluator)(nil)
+
+// TrajectoryInput contains the recorded run of an agent to evaluate.
+type TrajectoryInput struct {
+	// Input is the input of the agent, e.g. a question.
+	Input string
+	// Prediction is the final answer of the agent.
+	Prediction string
+	// Steps are the intermediate steps of the agent, e.g. returned by an executor with ReturnIntermediateSteps.
+	Steps []schema.AgentStep
+	// Reference is the expected final answer. It is optional.
+	Reference string
+}
+
+// TrajectoryResult is the result of a trajectory evaluation. The score is the mean score of the criteria.
+type TrajectoryResult struct {
+	Result
+	// Criteria are the results of the criteria of the rubric.
+	Criteria []*Result `json:"criteria"`
+}
+
+// TrajectoryEvaluator evaluates the intermediate steps and the final answer of an agent.
+type TrajectoryEvaluator interface {
+	// EvaluateAgentTrajectory evaluates the trajectory and returns the result.
+	EvaluateAgentTrajectory(ctx context.Context, input *TrajectoryInput) (*TrajectoryResult, error)
+}
+
+var (
+	// CriterionToolSelection assesses whether the agent chose appropriate tools with sensible inputs.
+	CriterionToolSelection = Criterion{
+		Name:        "tool_selection",
+		Description: "Are the chosen tools appropriate for the task, and are the tool inputs sensible?",
+	}
+	// CriterionEfficiency assesses whether the agent avoided redundant tool calls.
+	CriterionEfficiency = Criterion{
+		Name:        "efficiency",
+		Description: "Does the agent reach the answer without redundant, repeated, or unnecessary tool calls?",
+	}
+	// CriterionGrounding assesses whether the final answer is supported by the observations of the tools.
+	CriterionGrounding = Criterion{
+		Name:        "grounding",
+		Description: "Is the final answer grounded in the observations of the tool calls rather than invented?",
+	}
+
+	// DefaultTrajectoryRubric is the default rubric of the AgentTrajectoryEvaluator.
+	DefaultTrajectoryRubric = []Criterion{CriterionToolSelection, CriterionEfficiency, CriterionGrounding}
+)
+
+const trajectoryEvalTemplate = `You are assessing the trajectory of an AI agent, i.e. the tools it called to solve a task and its final answer. Here is the data:
+[BEGIN DATA]
+***
+[Input]: {{.input}}
+***
+{{- if .tools}}
+[Available Tools]:
+{{.tools}}
+***
+{{- end}}
+[Trajectory]:
+{{.trajectory}}
+***
+[Repeated Tool Calls]: {{.repeated}}
+***
+[Final Answer]: {{.prediction}}
+***
+{{- if .reference}}
+[Reference]: {{.reference}}
+***
+{{- end}}
+[END DATA]
+Assess the trajectory based on the following rubric:
+{{.rubric}}
+First, write out in a step by step manner your reasoning about each criterion of the rubric. Then rate each criterion on a scale of 1 to {{.scale}}, where 1 means not at all and {{.scale}} means completely. Print each rating on a separate line at the end in the format "<criterion>: <rating>", e.g. "{{.example}}".`
+
+// AgentTrajectoryEvaluatorOptions contains options for configuring the AgentTrajectoryEvaluator.
+type AgentTrajectoryEvaluatorOptions struct {
+	// Prompt is the prompt of the judge. It receives the input, tools, trajectory, repeated, prediction,
+	// reference, rubric, scale and example and must ask for a rating line per criterion.
+	Prompt schema.PromptTemplate
+	// Rubric contains the criteria assessed by the judge.
+	Rubric []Criterion
+	// Scale is the maximum rating of the judge.
+	Scale int
+	// Tools are the tools available to the agent. Their descriptions help the judge to assess the tool selection.
+	Tools []schema.Tool
+	// Key is the key of the overall result.
+	Key string
+}
+
+// AgentTrajectoryEvaluator is an LLM-as-judge evaluator, which assesses the intermediate steps of an agent
+// with a rubric. By default, the judge rates the tool selection, the efficiency, i.e. the absence of
+// redundant calls, and the grounding of the final answer in the observations.
+type AgentTrajectoryEvaluator struct {
+	llmChain *chain.LLM
+	opts     AgentTrajectoryEvaluatorOptions
+}
+
+// NewAgentTrajectoryEvaluator creates a new AgentTrajectoryEvaluator, which assesses the trajectories with the model.
+func NewAgentTrajectoryEvaluator(model schema.Model, optFns ...func(o *AgentTrajectoryEvaluatorOptions)) (*AgentTrajectoryEvaluator, error) {
+	opts := AgentTrajectoryEvaluatorOptions{
+		Rubric: DefaultTrajectoryRubric,
+		Scale:  5,
+		Key:    "trajectory",
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.Prompt == nil {
+		opts.Prompt = prompt.NewTemplate(trajectoryEvalTemplate)
+	}
+
+	if len(opts.Rubric) == 0 {
+		return nil, fmt.Errorf("rubric must contain at least one criterion")
+	}
+
+	if opts.Scale <= 1 {
+		return nil, fmt.Errorf("invalid scale %d, must be greater than one", opts.Scale)
+	}
+
+	llmChain, err := chain.NewLLM(model, opts.Prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AgentTrajectoryEvaluator{
+		llmChain: llmChain,
+		opts:     opts,
+	}, nil
+}
+
+// EvaluateAgentTrajectory asks the judge to rate the trajectory for each criterion of the rubric.
+func (e *AgentTrajectoryEvaluator) EvaluateAgentTrajectory(ctx context.Context, input *TrajectoryInput) (*TrajectoryResult, error) {
+	rubric := make([]string, len(e.opts.Rubric))
+	for i, c := range e.opts.Rubric {
+		rubric[i] = fmt.Sprintf("%s: %s", c.Name, c.Description)
+	}
+
+	tools := make([]string, len(e.opts.Tools))
+	for i, t := range e.opts.Tools {
+		tools[i] = fmt.Sprintf("%s: %s", t.Name(), t.Description())
+	}
+
+	text, err := golc.SimpleCall(ctx, e.llmChain, schema.ChainValues{
+		"input":      input.Input,
+		"tools":      strings.Join(tools, "\n"),
+		"trajectory": formatTrajectory(input.Steps),
+		"repeated":   strconv.Itoa(CountRepeatedToolCalls(input.Steps)),
+		"prediction": input.Prediction,
+		"reference":  input.Reference,
+		"rubric":     strings.Join(rubric, "\n"),
+		"scale":      strconv.Itoa(e.opts.Scale),
+		"example":    fmt.Sprintf("%s: %d", e.opts.Rubric[0].Name, e.opts.Scale),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	first := len(lines)
+
+	result := &TrajectoryResult{
+		Result: Result{
+			Key: e.opts.Key,
+		},
+		Criteria: make([]*Result, len(e.opts.Rubric)),
+	}
+
+	for i, c := range e.opts.Rubric {
+		idx, value, err := findRating(lines, c.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		rating, err := strconv.ParseFloat(value, 64)
+		if err != nil || rating < 1 || rating > float64(e.opts.Scale) {
+			return nil, fmt.Errorf("unexpected rating %q for criterion %s", value, c.Name)
+		}
+
+		if idx < first {
+			first = idx
+		}
+
+		result.Criteria[i] = &Result{
+			Key:   c.Name,
+			Score: (rating - 1) / float64(e.opts.Scale-1),
+			Value: value,
+		}
+
+		result.Score += result.Criteria[i].Score
+	}
+
+	result.Score /= float64(len(result.Criteria))
+	result.Reasoning = strings.TrimSpace(strings.Join(lines[:first], "\n"))
+
+	return result, nil
+}
+
+// findRating returns the index and the value of the last rating line of the criterion.
+func findRating(lines []string, name string) (int, string, error) {
+	pattern := regexp.MustCompile(`(?i)^[\s*\-#]*` + regexp.QuoteMeta(name) + `[\s*]*:[\s*]*([0-9]+(?:\.[0-9]+)?)`)
+
+	for i := len(lines) - 1; i >= 0; i-- {
+		if m := pattern.FindStringSubmatch(lines[i]); m != nil {
+			return i, m[1], nil
+		}
+	}
+
+	return 0, "", fmt.Errorf("missing rating for criterion %s", name)
+}
+
+// formatTrajectory formats the steps of an agent for the judge.
+func formatTrajectory(steps []schema.AgentStep) string {
+	if len(steps) == 0 {
+		return "The agent did not call any tools."
+	}
+
+	b := &strings.Builder{}
+
+	for i, step := range steps {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		fmt.Fprintf(b, "Step %d:\nTool: %s\nTool Input: %s\nObservation: %s\n", i+1, step.Action.Tool, toolInputString(step.Action.ToolInput), step.Observation)
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+// CountRepeatedToolCalls returns the number of tool calls, which repeat a previous call with the same tool and input.
+func CountRepeatedToolCalls(steps []schema.AgentStep) int {
+	seen := map[string]struct{}{}
+	repeated := 0
+
+	for _, step := range steps {
+		key := step.Action.Tool + "\x00" + toolInputString(step.Action.ToolInput)
+
+		if _, ok := seen[key]; ok {
+			repeated++
+			continue
+		}
+
+		seen[key] = struct{}{}
+	}
+
+	return repeated
+}
+
+// toolInputString returns the tool input as string.
+func toolInputString(input *schema.ToolInput) string {
+	if input == nil {
+		return ""
+	}
+
+	return input.String()
+}
@@ -0,0 +1,148 @@
+package evaluation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc/chain"
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentTrajectoryEvaluator(t *testing.T) {
+	steps := []schema.AgentStep{
+		{Action: &schema.AgentAction{Tool: "search", ToolInput: schema.NewToolInputFromString("capital of France")}, Observation: "Paris is the capital of France."},
+		{Action: &schema.AgentAction{Tool: "search", ToolInput: schema.NewToolInputFromString("capital of France")}, Observation: "Paris is the capital of France."},
+	}
+
+	judge := func(output string, prompts *[]string) *llm.Fake {
+		return llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+			*prompts = append(*prompts, prompt)
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: output}},
+				LLMOutput:   map[string]any{},
+			}, nil
+		})
+	}
+
+	t.Run("DefaultRubric", func(t *testing.T) {
+		var prompts []string
+
+		evaluator, err := NewAgentTrajectoryEvaluator(judge("The search tool fits the task.\nThe second call repeats the first.\nThe answer is supported.\n\ntool_selection: 5\n**Efficiency**: 3\n- grounding: 5", &prompts), func(o *AgentTrajectoryEvaluatorOptions) {
+			o.Tools = []schema.Tool{&mockTool{name: "search", description: "Searches the web."}}
+		})
+		require.NoError(t, err)
+
+		result, err := evaluator.EvaluateAgentTrajectory(context.Background(), &TrajectoryInput{
+			Input:      "What is the capital of France?",
+			Prediction: "Paris",
+			Steps:      steps,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "trajectory", result.Key)
+		require.InDelta(t, 2.5/3, result.Score, 1e-9)
+		require.Equal(t, "The search tool fits the task.\nThe second call repeats the first.\nThe answer is supported.", result.Reasoning)
+		require.Equal(t, []*Result{
+			{Key: "tool_selection", Score: 1, Value: "5"},
+			{Key: "efficiency", Score: 0.5, Value: "3"},
+			{Key: "grounding", Score: 1, Value: "5"},
+		}, result.Criteria)
+
+		require.Contains(t, prompts[0], "search: Searches the web.")
+		require.Contains(t, prompts[0], "Step 2:\nTool: search\nTool Input: capital of France\nObservation: Paris is the capital of France.")
+		require.Contains(t, prompts[0], "[Repeated Tool Calls]: 1")
+		require.Contains(t, prompts[0], "[Final Answer]: Paris")
+		require.NotContains(t, prompts[0], "[Reference]")
+	})
+
+	t.Run("CustomRubric", func(t *testing.T) {
+		var prompts []string
+
+		evaluator, err := NewAgentTrajectoryEvaluator(judge("safety: 2", &prompts), func(o *AgentTrajectoryEvaluatorOptions) {
+			o.Rubric = []Criterion{{Name: "safety", Description: "Does the agent avoid destructive tools?"}}
+			o.Scale = 3
+		})
+		require.NoError(t, err)
+
+		result, err := evaluator.EvaluateAgentTrajectory(context.Background(), &TrajectoryInput{Input: "Clean up", Prediction: "Done"})
+		require.NoError(t, err)
+		require.Equal(t, 0.5, result.Score)
+		require.Empty(t, result.Reasoning)
+		require.Contains(t, prompts[0], "The agent did not call any tools.")
+		require.Contains(t, prompts[0], "scale of 1 to 3")
+	})
+
+	t.Run("InvalidRating", func(t *testing.T) {
+		var prompts []string
+
+		evaluator, err := NewAgentTrajectoryEvaluator(judge("tool_selection: 5\nefficiency: 9\ngrounding: 5", &prompts))
+		require.NoError(t, err)
+
+		_, err = evaluator.EvaluateAgentTrajectory(context.Background(), &TrajectoryInput{Steps: steps})
+		require.ErrorContains(t, err, "unexpected rating")
+
+		evaluator, err = NewAgentTrajectoryEvaluator(judge("tool_selection: 5", &prompts))
+		require.NoError(t, err)
+
+		_, err = evaluator.EvaluateAgentTrajectory(context.Background(), &TrajectoryInput{Steps: steps})
+		require.ErrorContains(t, err, "missing rating for criterion efficiency")
+
+		_, err = NewAgentTrajectoryEvaluator(judge("", &prompts), func(o *AgentTrajectoryEvaluatorOptions) {
+			o.Rubric = nil
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("DatasetRunner", func(t *testing.T) {
+		var prompts []string
+
+		evaluator, err := NewAgentTrajectoryEvaluator(judge("tool_selection: 5\nefficiency: 1\ngrounding: 5", &prompts))
+		require.NoError(t, err)
+
+		agentChain, err := chain.NewTransform([]string{"input"}, []string{"output", "intermediateSteps"}, func(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+			return schema.ChainValues{"output": "Paris", "intermediateSteps": steps}, nil
+		})
+		require.NoError(t, err)
+
+		runner, err := NewDatasetRunner(agentChain, func(o *DatasetRunnerOptions) {
+			o.TrajectoryEvaluators = []TrajectoryEvaluator{evaluator}
+			o.SkipTraces = true
+		})
+		require.NoError(t, err)
+
+		report, err := runner.Run(context.Background(), []Example{{Inputs: schema.ChainValues{"input": "What is the capital of France?"}}})
+		require.NoError(t, err)
+		require.Empty(t, report.Examples[0].Error)
+		require.Equal(t, "Paris", report.Examples[0].Prediction)
+		require.Equal(t, []string{"efficiency", "grounding", "tool_selection", "trajectory"}, report.Keys())
+		require.InDelta(t, 2.0/3, report.MeanScores["trajectory"], 1e-9)
+		require.Contains(t, prompts[0], "[Input]: What is the capital of France?")
+	})
+}
+
+func TestCountRepeatedToolCalls(t *testing.T) {
+	step := func(tool, input string) schema.AgentStep {
+		return schema.AgentStep{Action: &schema.AgentAction{Tool: tool, ToolInput: schema.NewToolInputFromString(input)}}
+	}
+
+	require.Equal(t, 0, CountRepeatedToolCalls(nil))
+	require.Equal(t, 0, CountRepeatedToolCalls([]schema.AgentStep{step("search", "a"), step("search", "b"), step("calculator", "a")}))
+	require.Equal(t, 2, CountRepeatedToolCalls([]schema.AgentStep{step("search", "a"), step("search", "a"), step("search", "b"), step("search", "a")}))
+}
+
+// mockTool is a mock implementation of the schema.Tool interface.
+type mockTool struct {
+	schema.Tool
+	name        string
+	description string
+}
+
+func (m *mockTool) Name() string {
+	return m.name
+}
+
+func (m *mockTool) Description() string {
+	return m.description
+}
@@ -11,14 +11,16 @@ import (
 
 // Start ollama
 // docker run -d -v ollama:/root/.ollama -p 11434:11434 --name ollama ollama/ollama
-// docker exec -it ollama ollama run llama2
+// docker exec -it ollama ollama pull nomic-embed-text
 
 func main() {
 	client := ollama.New("http://localhost:11434")
 
-	embedder := embedding.NewOllama(client)
+	embedder := embedding.NewOllama(client, func(o *embedding.OllamaOptions) {
+		o.ModelName = "nomic-embed-text"
+	})
 
-	e, err := embedder.EmbedText(context.Background(), "Hello llama2!")
+	e, err := embedder.EmbedText(context.Background(), "Hello ollama!")
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -16,9 +16,7 @@ func main() {
 	openai := embedding.NewOpenAI(os.Getenv("OPENAI_API_KEY"))
 
 	client, err := pinecone.NewRestClient(os.Getenv("PINECONE_API_KEY"), pinecone.Endpoint{
-		IndexName:   "golc",
-		ProjectName: os.Getenv("PINECONE_PROJECT"),
-		Environment: "us-west1-gcp-free",
+		Host: os.Getenv("PINECONE_HOST"),
 	})
 	if err != nil {
 		log.Fatal(err)
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/model"
+	"github.com/hupe1980/golc/model/chatmodel"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+)
+
+func main() {
+	usage := callback.NewTokenUsageHandler()
+
+	openAI, err := chatmodel.NewOpenAI(os.Getenv("OPENAI_API_KEY"), func(o *chatmodel.OpenAIOptions) {
+		o.Callbacks = []schema.Callback{usage}
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, question := range []string{"What is the capital of France?", "What is the capital of Germany?"} {
+		pv, err := prompt.NewHumanMessageTemplate(question).FormatPrompt(nil)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		result, err := model.GeneratePrompt(context.Background(), openAI, pv)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Println(result.Generations[0].Text)
+	}
+
+	fmt.Println(usage)
+	fmt.Println(usage.TokenUsageByModel())
+}
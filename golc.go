@@ -136,12 +136,58 @@ type BatchCallOptions struct {
 	ParentRunID    string
 	IncludeRunInfo bool
 	Stop           []string
+	// MaxConcurrency is the maximum number of concurrent calls. A value <= 0 disables the limit.
 	MaxConcurrency int
+	// ReturnExceptions indicates whether a failed call aborts the batch or is reported
+	// alongside the successful calls. If true, all inputs are processed and the errors of
+	// the failed calls are returned as a *BatchError.
+	ReturnExceptions bool
+}
+
+// BatchError is returned by BatchCall in the ReturnExceptions mode, if at least one call failed.
+type BatchError struct {
+	// Errors contains the error of each input in the same order as the inputs.
+	// The entries of the successful calls are nil.
+	Errors []error
+}
+
+// Error returns the error message of the BatchError.
+func (e *BatchError) Error() string {
+	failed := 0
+
+	var first error
+
+	for _, err := range e.Errors {
+		if err != nil {
+			if first == nil {
+				first = err
+			}
+
+			failed++
+		}
+	}
+
+	return fmt.Sprintf("%d of %d calls failed: %v", failed, len(e.Errors), first)
+}
+
+// Unwrap returns the errors of the failed calls.
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+
+	for _, err := range e.Errors {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
 }
 
 // BatchCall executes multiple calls to the chain.Call function concurrently and collects
 // the results in the same order as the inputs. It utilizes the errgroup package to manage
-// the concurrent execution and handle any errors that may occur.
+// the concurrent execution and handle any errors that may occur. By default, the first error
+// cancels the remaining calls. In the ReturnExceptions mode, the outputs of the successful calls
+// are returned together with a *BatchError describing the failed calls, whose outputs are nil.
 func BatchCall(ctx context.Context, chain schema.Chain, inputs []schema.ChainValues, optFns ...func(*BatchCallOptions)) ([]schema.ChainValues, error) {
 	opts := BatchCallOptions{
 		MaxConcurrency: 5,
@@ -153,9 +199,12 @@ func BatchCall(ctx context.Context, chain schema.Chain, inputs []schema.ChainVal
 
 	errs, errctx := errgroup.WithContext(ctx)
 
-	errs.SetLimit(opts.MaxConcurrency)
+	if opts.MaxConcurrency > 0 {
+		errs.SetLimit(opts.MaxConcurrency)
+	}
 
 	chainValues := make([]schema.ChainValues, len(inputs))
+	callErrors := make([]error, len(inputs))
 
 	for i, input := range inputs {
 		i, input := i, input
@@ -168,6 +217,11 @@ func BatchCall(ctx context.Context, chain schema.Chain, inputs []schema.ChainVal
 				o.Stop = opts.Stop
 			})
 			if err != nil {
+				if opts.ReturnExceptions {
+					callErrors[i] = err
+					return nil
+				}
+
 				return err
 			}
 
@@ -181,5 +235,11 @@ func BatchCall(ctx context.Context, chain schema.Chain, inputs []schema.ChainVal
 		return nil, err
 	}
 
+	for _, err := range callErrors {
+		if err != nil {
+			return chainValues, &BatchError{Errors: callErrors}
+		}
+	}
+
 	return chainValues, nil
 }
@@ -3,7 +3,9 @@ package golc
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/hupe1980/golc/schema"
 	"github.com/stretchr/testify/assert"
@@ -119,6 +121,72 @@ func TestBatchCall(t *testing.T) {
 			assert.Equal(t, tc.expectedError, err)
 		})
 	}
+
+	t.Run("ReturnExceptions", func(t *testing.T) {
+		chain := mockChain{
+			CallFunc: func(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+				if _, ok := inputs["fail"]; ok {
+					return nil, errors.New("error occurred during chain.Call")
+				}
+
+				return inputs, nil
+			},
+		}
+
+		result, err := BatchCall(context.TODO(), chain, []schema.ChainValues{
+			{"foo1": "bar1"}, {"fail": true}, {"foo3": "bar3"},
+		}, func(o *BatchCallOptions) {
+			o.ReturnExceptions = true
+		})
+
+		var batchErr *BatchError
+
+		assert.ErrorAs(t, err, &batchErr)
+		assert.EqualError(t, err, "1 of 3 calls failed: error occurred during chain.Call")
+		assert.Equal(t, []schema.ChainValues{{"foo1": "bar1"}, nil, {"foo3": "bar3"}}, result)
+		assert.NoError(t, batchErr.Errors[0])
+		assert.EqualError(t, batchErr.Errors[1], "error occurred during chain.Call")
+		assert.NoError(t, batchErr.Errors[2])
+	})
+
+	t.Run("MaxConcurrency", func(t *testing.T) {
+		var (
+			mu      sync.Mutex
+			running int
+			peak    int
+		)
+
+		chain := mockChain{
+			CallFunc: func(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+				mu.Lock()
+				running++
+				if running > peak {
+					peak = running
+				}
+				mu.Unlock()
+
+				time.Sleep(10 * time.Millisecond)
+
+				mu.Lock()
+				running--
+				mu.Unlock()
+
+				return inputs, nil
+			},
+		}
+
+		inputs := make([]schema.ChainValues, 10)
+		for i := range inputs {
+			inputs[i] = schema.ChainValues{"i": i}
+		}
+
+		result, err := BatchCall(context.TODO(), chain, inputs, func(o *BatchCallOptions) {
+			o.MaxConcurrency = 2
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, inputs, result)
+		assert.LessOrEqual(t, peak, 2)
+	})
 }
 
 // mockChain is a mock implementation of the schema.Chain interface
@@ -135,7 +203,7 @@ type mockChain struct {
 // Call is the mock implementation of the Call method
 func (m mockChain) Call(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
 	if m.CallFunc != nil {
-		return m.CallFunc(ctx, inputs)
+		return m.CallFunc(ctx, inputs, optFns...)
 	}
 
 	return schema.ChainValues{}, nil
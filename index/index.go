@@ -0,0 +1,347 @@
+// Package index provides an ingestion pipeline that keeps a vector store in sync with its source documents.
+package index
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/schema"
+)
+
+// ErrKeysGroupIDsMismatch is returned when the number of keys and group ids differs.
+var ErrKeysGroupIDsMismatch = errors.New("number of keys and group ids must be equal")
+
+// CleanupMode determines which outdated documents are deleted from the vector store.
+type CleanupMode string
+
+const (
+	// CleanupModeNone keeps all documents written before.
+	CleanupModeNone CleanupMode = "none"
+	// CleanupModeIncremental deletes the outdated documents of the sources of the indexed documents at the
+	// end of the run, so sources spanning several batches are cleaned up once they are indexed completely.
+	// Sources that are no longer indexed are kept.
+	CleanupModeIncremental CleanupMode = "incremental"
+	// CleanupModeFull deletes all documents that were not indexed by the run. The indexed documents
+	// must cover the complete data set.
+	CleanupModeFull CleanupMode = "full"
+)
+
+// Options contains options for the Indexer.
+type Options struct {
+	// Transformers transform the documents before they are split, e.g. to redact PII.
+	Transformers []schema.DocumentTransformer
+	// TextSplitter splits the documents into chunks before they are indexed. If nil, the documents are indexed as is.
+	TextSplitter schema.TextSplitter
+	// CleanupMode determines which outdated documents are deleted.
+	CleanupMode CleanupMode
+	// SourceIDKey is the metadata key of the source id of a document. It is required by the incremental cleanup.
+	SourceIDKey string
+	// BatchSize is the number of documents written to or deleted from the vector store at once.
+	BatchSize int
+}
+
+// Result contains the statistics of an indexing run.
+type Result struct {
+	// NumAdded is the number of documents added to the vector store.
+	NumAdded int
+	// NumSkipped is the number of unchanged or duplicate documents.
+	NumSkipped int
+	// NumDeleted is the number of outdated documents deleted from the vector store.
+	NumDeleted int
+}
+
+// Indexer writes documents into a vector store and records them in a record manager. Each document is
+// identified by the hash of its content and metadata, so unchanged documents are not embedded and
+// written again when the indexing is repeated, and outdated documents can be cleaned up.
+type Indexer struct {
+	vectorStore   schema.VectorStore
+	recordManager RecordManager
+	opts          Options
+}
+
+// New creates a new Indexer for the given vector store and record manager.
+func New(vectorStore schema.VectorStore, recordManager RecordManager, optFns ...func(o *Options)) (*Indexer, error) {
+	opts := Options{
+		CleanupMode: CleanupModeNone,
+		SourceIDKey: "source",
+		BatchSize:   100,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	switch opts.CleanupMode {
+	case CleanupModeNone, CleanupModeFull:
+	case CleanupModeIncremental:
+		if opts.SourceIDKey == "" {
+			return nil, errors.New("incremental cleanup requires a source id key")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported cleanup mode: %s", opts.CleanupMode)
+	}
+
+	if opts.BatchSize <= 0 {
+		return nil, fmt.Errorf("batch size must be positive, got %d", opts.BatchSize)
+	}
+
+	return &Indexer{
+		vectorStore:   vectorStore,
+		recordManager: recordManager,
+		opts:          opts,
+	}, nil
+}
+
+// IndexLoader loads the documents of the loader lazily and indexes them in batches, so only a batch of
+// documents is held in memory at once.
+func (ix *Indexer) IndexLoader(ctx context.Context, loader schema.DocumentLoader) (*Result, error) {
+	it, err := loader.LazyLoad(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer it.Close()
+
+	return ix.IndexIterator(ctx, it)
+}
+
+// IndexIterator indexes the documents of the iterator in batches.
+func (ix *Indexer) IndexIterator(ctx context.Context, it schema.DocumentIterator) (*Result, error) {
+	return ix.index(ctx, func() ([]schema.Document, error) {
+		batch := make([]schema.Document, 0, ix.opts.BatchSize)
+
+		for len(batch) < ix.opts.BatchSize {
+			doc, err := it.Next(ctx)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			batch = append(batch, doc)
+		}
+
+		return batch, nil
+	})
+}
+
+// Index splits the documents, adds the new chunks to the vector store and deletes the outdated
+// chunks according to the cleanup mode.
+func (ix *Indexer) Index(ctx context.Context, docs []schema.Document) (*Result, error) {
+	return ix.index(ctx, func() ([]schema.Document, error) {
+		batch := docs[:util.Min(ix.opts.BatchSize, len(docs))]
+		docs = docs[len(batch):]
+
+		return batch, nil
+	})
+}
+
+// index indexes the batches of documents returned by nextBatch until it returns an empty batch.
+func (ix *Indexer) index(ctx context.Context, nextBatch func() ([]schema.Document, error)) (*Result, error) {
+	indexStartTime, err := ix.recordManager.GetTime(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	seen := map[string]struct{}{}
+	sourceIDs := map[string]struct{}{}
+
+	for {
+		docs, err := nextBatch()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(docs) == 0 {
+			break
+		}
+
+		for _, t := range ix.opts.Transformers {
+			docs, err = t.Transform(ctx, docs)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if ix.opts.TextSplitter != nil {
+			docs, err = ix.opts.TextSplitter.SplitDocuments(docs)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for start := 0; start < len(docs); start += ix.opts.BatchSize {
+			end := util.Min(start+ix.opts.BatchSize, len(docs))
+
+			if err := ix.indexBatch(ctx, docs[start:end], indexStartTime, seen, sourceIDs, result); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	switch ix.opts.CleanupMode {
+	case CleanupModeIncremental:
+		if len(sourceIDs) == 0 {
+			break
+		}
+
+		ids := make([]string, 0, len(sourceIDs))
+		for id := range sourceIDs {
+			ids = append(ids, id)
+		}
+
+		sort.Strings(ids)
+
+		n, err := ix.cleanup(ctx, indexStartTime, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		result.NumDeleted += n
+	case CleanupModeFull:
+		n, err := ix.cleanup(ctx, indexStartTime, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		result.NumDeleted += n
+	}
+
+	return result, nil
+}
+
+// indexBatch adds the new documents of the batch to the vector store, updates the records of all documents
+// of the batch and collects their source ids for the incremental cleanup.
+func (ix *Indexer) indexBatch(ctx context.Context, docs []schema.Document, indexStartTime time.Time, seen, sourceIDs map[string]struct{}, result *Result) error {
+	keys := []string{}
+	groupIDs := []string{}
+	batch := []schema.Document{}
+
+	for _, doc := range docs {
+		key, err := hashDocument(doc)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := seen[key]; ok {
+			result.NumSkipped++
+			continue
+		}
+
+		seen[key] = struct{}{}
+
+		sourceID, err := ix.sourceID(doc)
+		if err != nil {
+			return err
+		}
+
+		keys = append(keys, key)
+		groupIDs = append(groupIDs, sourceID)
+		batch = append(batch, doc)
+		sourceIDs[sourceID] = struct{}{}
+	}
+
+	exists, err := ix.recordManager.Exists(ctx, keys)
+	if err != nil {
+		return err
+	}
+
+	newKeys := []string{}
+	newDocs := []schema.Document{}
+
+	for i, doc := range batch {
+		if exists[i] {
+			result.NumSkipped++
+			continue
+		}
+
+		newKeys = append(newKeys, keys[i])
+		newDocs = append(newDocs, doc)
+	}
+
+	if len(newDocs) > 0 {
+		if err := ix.vectorStore.AddDocumentsWithIDs(ctx, newKeys, newDocs); err != nil {
+			return err
+		}
+
+		result.NumAdded += len(newDocs)
+	}
+
+	// The records of unchanged documents are updated as well, so they are not cleaned up.
+	return ix.recordManager.Update(ctx, keys, groupIDs, indexStartTime)
+}
+
+// cleanup deletes the documents written before the index start time, optionally restricted to the
+// given source ids, and returns their number.
+func (ix *Indexer) cleanup(ctx context.Context, indexStartTime time.Time, sourceIDs []string) (int, error) {
+	keys, err := ix.recordManager.ListKeys(ctx, indexStartTime, sourceIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	numDeleted := 0
+
+	// The keys are deleted in batches, as vector stores limit the number of ids per request.
+	for _, batch := range util.ChunkBy(keys, ix.opts.BatchSize) {
+		if err := ix.vectorStore.Delete(ctx, batch); err != nil {
+			return numDeleted, err
+		}
+
+		if err := ix.recordManager.DeleteKeys(ctx, batch); err != nil {
+			return numDeleted, err
+		}
+
+		numDeleted += len(batch)
+	}
+
+	return numDeleted, nil
+}
+
+// sourceID returns the source id of the document. It is required by the incremental cleanup.
+func (ix *Indexer) sourceID(doc schema.Document) (string, error) {
+	if ix.opts.SourceIDKey == "" {
+		return "", nil
+	}
+
+	value, ok := doc.Metadata[ix.opts.SourceIDKey]
+	if !ok {
+		if ix.opts.CleanupMode == CleanupModeIncremental {
+			return "", fmt.Errorf("source id key %s not found in metadata of document", ix.opts.SourceIDKey)
+		}
+
+		return "", nil
+	}
+
+	return fmt.Sprint(value), nil
+}
+
+// hashDocument returns a UUID derived from the hash of the page content and metadata of the document.
+// UUIDs are accepted as ids by all vector stores.
+func hashDocument(doc schema.Document) (string, error) {
+	metadata, err := json.Marshal(doc.Metadata)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write([]byte(doc.PageContent))
+	h.Write([]byte{0})
+	h.Write(metadata)
+
+	return uuid.NewSHA1(uuid.NameSpaceOID, h.Sum(nil)).String(), nil
+}
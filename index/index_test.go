@@ -0,0 +1,335 @@
+package index
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/textsplitter"
+)
+
+func TestIndexer(t *testing.T) {
+	docA := schema.Document{PageContent: "alpha", Metadata: map[string]any{"source": "a.txt"}}
+	docB := schema.Document{PageContent: "beta", Metadata: map[string]any{"source": "b.txt"}}
+	docA2 := schema.Document{PageContent: "alpha v2", Metadata: map[string]any{"source": "a.txt"}}
+
+	t.Run("Deduplication", func(t *testing.T) {
+		vs := newMockVectorStore()
+		rm := newTestRecordManager()
+
+		ix, err := New(vs, rm)
+		require.NoError(t, err)
+
+		result, err := ix.Index(context.Background(), []schema.Document{docA, docB, docA})
+		require.NoError(t, err)
+		assert.Equal(t, &Result{NumAdded: 2, NumSkipped: 1}, result)
+
+		result, err = ix.Index(context.Background(), []schema.Document{docA, docB})
+		require.NoError(t, err)
+		assert.Equal(t, &Result{NumSkipped: 2}, result)
+		assert.Equal(t, []string{"alpha", "beta"}, vs.contents())
+	})
+
+	t.Run("CleanupNone", func(t *testing.T) {
+		vs := newMockVectorStore()
+		rm := newTestRecordManager()
+
+		ix, err := New(vs, rm)
+		require.NoError(t, err)
+
+		_, err = ix.Index(context.Background(), []schema.Document{docA, docB})
+		require.NoError(t, err)
+
+		result, err := ix.Index(context.Background(), []schema.Document{docA2})
+		require.NoError(t, err)
+		assert.Equal(t, &Result{NumAdded: 1}, result)
+		assert.Equal(t, []string{"alpha", "alpha v2", "beta"}, vs.contents())
+	})
+
+	t.Run("CleanupIncremental", func(t *testing.T) {
+		vs := newMockVectorStore()
+		rm := newTestRecordManager()
+
+		ix, err := New(vs, rm, func(o *Options) {
+			o.CleanupMode = CleanupModeIncremental
+		})
+		require.NoError(t, err)
+
+		_, err = ix.Index(context.Background(), []schema.Document{docA, docB})
+		require.NoError(t, err)
+
+		result, err := ix.Index(context.Background(), []schema.Document{docA2})
+		require.NoError(t, err)
+		assert.Equal(t, &Result{NumAdded: 1, NumDeleted: 1}, result)
+		assert.Equal(t, []string{"alpha v2", "beta"}, vs.contents())
+
+		_, err = ix.Index(context.Background(), []schema.Document{{PageContent: "no source"}})
+		assert.Error(t, err)
+	})
+
+	t.Run("CleanupIncrementalLargeSource", func(t *testing.T) {
+		vs := newMockVectorStore()
+		rm := newTestRecordManager()
+
+		ix, err := New(vs, rm, func(o *Options) {
+			o.CleanupMode = CleanupModeIncremental
+			o.BatchSize = 2
+		})
+		require.NoError(t, err)
+
+		chunks := func(contents ...string) []schema.Document {
+			docs := make([]schema.Document, len(contents))
+			for i, c := range contents {
+				docs[i] = schema.Document{PageContent: c, Metadata: map[string]any{"source": "large.txt"}}
+			}
+
+			return docs
+		}
+
+		result, err := ix.Index(context.Background(), chunks("c1", "c2", "c3", "c4", "c5"))
+		require.NoError(t, err)
+		assert.Equal(t, &Result{NumAdded: 5}, result)
+
+		// The chunks of later batches are not deleted by the cleanup of the first batch.
+		result, err = ix.Index(context.Background(), chunks("c1", "c2", "c3", "c4", "c5"))
+		require.NoError(t, err)
+		assert.Equal(t, &Result{NumSkipped: 5}, result)
+
+		result, err = ix.Index(context.Background(), chunks("c1", "c2", "c3", "c4 v2", "c5"))
+		require.NoError(t, err)
+		assert.Equal(t, &Result{NumAdded: 1, NumSkipped: 4, NumDeleted: 1}, result)
+		assert.Equal(t, []string{"c1", "c2", "c3", "c4 v2", "c5"}, vs.contents())
+	})
+
+	t.Run("CleanupFull", func(t *testing.T) {
+		vs := newMockVectorStore()
+		rm := newTestRecordManager()
+
+		ix, err := New(vs, rm, func(o *Options) {
+			o.CleanupMode = CleanupModeFull
+			o.BatchSize = 1
+		})
+		require.NoError(t, err)
+
+		_, err = ix.Index(context.Background(), []schema.Document{docA, docB})
+		require.NoError(t, err)
+
+		result, err := ix.Index(context.Background(), []schema.Document{docA2, docB})
+		require.NoError(t, err)
+		assert.Equal(t, &Result{NumAdded: 1, NumSkipped: 1, NumDeleted: 1}, result)
+		assert.Equal(t, []string{"alpha v2", "beta"}, vs.contents())
+
+		keys, err := rm.ListKeys(context.Background(), rm.now().Add(time.Hour), nil)
+		require.NoError(t, err)
+		assert.Len(t, keys, 2)
+	})
+
+	t.Run("CleanupFullBatches", func(t *testing.T) {
+		vs := newMockVectorStore()
+		rm := newTestRecordManager()
+
+		ix, err := New(vs, rm, func(o *Options) {
+			o.CleanupMode = CleanupModeFull
+			o.BatchSize = 2
+		})
+		require.NoError(t, err)
+
+		docs := make([]schema.Document, 5)
+		for i := range docs {
+			docs[i] = schema.Document{PageContent: fmt.Sprintf("doc %d", i)}
+		}
+
+		_, err = ix.Index(context.Background(), docs)
+		require.NoError(t, err)
+
+		result, err := ix.Index(context.Background(), []schema.Document{docA})
+		require.NoError(t, err)
+		assert.Equal(t, &Result{NumAdded: 1, NumDeleted: 5}, result)
+		assert.Equal(t, []string{"alpha"}, vs.contents())
+		assert.Equal(t, []int{2, 2, 1}, vs.deleteSizes)
+	})
+
+	t.Run("TextSplitter", func(t *testing.T) {
+		vs := newMockVectorStore()
+
+		ix, err := New(vs, newTestRecordManager(), func(o *Options) {
+			o.TextSplitter = textsplitter.NewCharacterTextSplitter(func(o *textsplitter.CharacterTextSplitterOptions) {
+				o.Separator = " "
+				o.ChunkSize = 5
+				o.ChunkOverlap = 0
+			})
+		})
+		require.NoError(t, err)
+
+		result, err := ix.Index(context.Background(), []schema.Document{{PageContent: "one two one", Metadata: map[string]any{"source": "x"}}})
+		require.NoError(t, err)
+		assert.Equal(t, &Result{NumAdded: 2, NumSkipped: 1}, result)
+	})
+
+	t.Run("Transformers", func(t *testing.T) {
+		vs := newMockVectorStore()
+
+		ix, err := New(vs, newTestRecordManager(), func(o *Options) {
+			o.Transformers = []schema.DocumentTransformer{upperCaseTransformer{}}
+		})
+		require.NoError(t, err)
+
+		_, err = ix.Index(context.Background(), []schema.Document{docA, docB})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ALPHA", "BETA"}, vs.contents())
+	})
+
+	t.Run("IndexLoader", func(t *testing.T) {
+		vs := newMockVectorStore()
+
+		ix, err := New(vs, newTestRecordManager(), func(o *Options) {
+			o.BatchSize = 2
+		})
+		require.NoError(t, err)
+
+		loader := &mockLazyLoader{docs: []schema.Document{docA, docB, docA2, docA}, vs: vs}
+
+		result, err := ix.IndexLoader(context.Background(), loader)
+		require.NoError(t, err)
+		assert.Equal(t, &Result{NumAdded: 3, NumSkipped: 1}, result)
+		assert.Equal(t, []string{"alpha", "alpha v2", "beta"}, vs.contents())
+		assert.Equal(t, []int{0, 2}, loader.stored)
+		assert.True(t, loader.closed)
+	})
+
+	t.Run("InvalidCleanupMode", func(t *testing.T) {
+		_, err := New(newMockVectorStore(), newTestRecordManager(), func(o *Options) {
+			o.CleanupMode = "partial"
+		})
+		assert.Error(t, err)
+	})
+}
+
+// newTestRecordManager returns an in-memory record manager with a clock advancing by a second per call.
+func newTestRecordManager() *InMemoryRecordManager {
+	rm := NewInMemoryRecordManager()
+
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	rm.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	return rm
+}
+
+// mockVectorStore is a mock implementation of the VectorStore interface storing the documents by id.
+type mockVectorStore struct {
+	docs        map[string]schema.Document
+	deleteSizes []int
+}
+
+func newMockVectorStore() *mockVectorStore {
+	return &mockVectorStore{docs: make(map[string]schema.Document)}
+}
+
+func (m *mockVectorStore) contents() []string {
+	contents := []string{}
+	for _, doc := range m.docs {
+		contents = append(contents, doc.PageContent)
+	}
+
+	sort.Strings(contents)
+
+	return contents
+}
+
+func (m *mockVectorStore) AddDocuments(ctx context.Context, docs []schema.Document) error {
+	return nil
+}
+
+func (m *mockVectorStore) AddDocumentsWithIDs(ctx context.Context, ids []string, docs []schema.Document) error {
+	for i, id := range ids {
+		m.docs[id] = docs[i]
+	}
+
+	return nil
+}
+
+func (m *mockVectorStore) Delete(ctx context.Context, ids []string) error {
+	m.deleteSizes = append(m.deleteSizes, len(ids))
+
+	for _, id := range ids {
+		delete(m.docs, id)
+	}
+
+	return nil
+}
+
+func (m *mockVectorStore) DeleteByFilter(ctx context.Context, filter map[string]any) error {
+	return nil
+}
+
+func (m *mockVectorStore) SimilaritySearch(ctx context.Context, query string) ([]schema.Document, error) {
+	return nil, nil
+}
+
+// upperCaseTransformer is a document transformer, which converts the page contents to upper case.
+type upperCaseTransformer struct{}
+
+func (t upperCaseTransformer) Transform(ctx context.Context, docs []schema.Document) ([]schema.Document, error) {
+	transformed := make([]schema.Document, len(docs))
+
+	for i, doc := range docs {
+		doc.PageContent = strings.ToUpper(doc.PageContent)
+		transformed[i] = doc
+	}
+
+	return transformed, nil
+}
+
+// mockLazyLoader is a mock implementation of the DocumentLoader interface, which only supports lazy loading.
+// It records the number of documents in the vector store, when a batch of two documents is read.
+type mockLazyLoader struct {
+	docs   []schema.Document
+	vs     *mockVectorStore
+	stored []int
+	next   int
+	closed bool
+}
+
+func (m *mockLazyLoader) Load(ctx context.Context) ([]schema.Document, error) {
+	return nil, errors.New("not supported")
+}
+
+func (m *mockLazyLoader) LoadAndSplit(ctx context.Context, splitter schema.TextSplitter) ([]schema.Document, error) {
+	return nil, errors.New("not supported")
+}
+
+func (m *mockLazyLoader) LazyLoad(ctx context.Context) (schema.DocumentIterator, error) {
+	return m, nil
+}
+
+func (m *mockLazyLoader) Next(ctx context.Context) (schema.Document, error) {
+	if m.next == len(m.docs) {
+		return schema.Document{}, io.EOF
+	}
+
+	if m.next%2 == 0 {
+		m.stored = append(m.stored, len(m.vs.docs))
+	}
+
+	doc := m.docs[m.next]
+	m.next++
+
+	return doc, nil
+}
+
+func (m *mockLazyLoader) Close() error {
+	m.closed = true
+	return nil
+}
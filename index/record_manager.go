@@ -0,0 +1,163 @@
+package index
+
+import (
+	"context"
+	"encoding/gob"
+	"io"
+	"sync"
+	"time"
+)
+
+// RecordManager keeps track of the documents written to a vector store by their keys. Each record
+// contains the group id of the document, e.g. its source, and the time it was last written.
+type RecordManager interface {
+	// GetTime returns the current time of the record manager.
+	GetTime(ctx context.Context) (time.Time, error)
+	// Update creates or updates the records of the keys with the given group ids. The update time of
+	// the records is at least timeAtLeast.
+	Update(ctx context.Context, keys []string, groupIDs []string, timeAtLeast time.Time) error
+	// Exists reports for each key whether a record exists.
+	Exists(ctx context.Context, keys []string) ([]bool, error)
+	// ListKeys returns the keys of the records updated before the given time. If groupIDs is not nil,
+	// only the keys of the records with one of the group ids are returned.
+	ListKeys(ctx context.Context, before time.Time, groupIDs []string) ([]string, error)
+	// DeleteKeys deletes the records of the keys.
+	DeleteKeys(ctx context.Context, keys []string) error
+}
+
+// Compile time check to ensure InMemoryRecordManager satisfies the RecordManager interface.
+var _ RecordManager = (*InMemoryRecordManager)(nil)
+
+// Record represents the record of a document key.
+type Record struct {
+	GroupID   string
+	UpdatedAt time.Time
+}
+
+// InMemoryRecordManager represents an in-memory record manager. The records can be saved and loaded
+// to keep track of the indexed documents between runs.
+type InMemoryRecordManager struct {
+	mu      sync.RWMutex
+	records map[string]Record
+	now     func() time.Time
+}
+
+// NewInMemoryRecordManager creates a new instance of the in-memory record manager.
+func NewInMemoryRecordManager() *InMemoryRecordManager {
+	return &InMemoryRecordManager{
+		records: make(map[string]Record),
+		now:     time.Now,
+	}
+}
+
+// GetTime returns the current time.
+func (rm *InMemoryRecordManager) GetTime(ctx context.Context) (time.Time, error) {
+	return rm.now(), nil
+}
+
+// Update creates or updates the records of the keys with the given group ids.
+func (rm *InMemoryRecordManager) Update(ctx context.Context, keys []string, groupIDs []string, timeAtLeast time.Time) error {
+	if len(keys) != len(groupIDs) {
+		return ErrKeysGroupIDsMismatch
+	}
+
+	updatedAt := rm.now()
+	if updatedAt.Before(timeAtLeast) {
+		updatedAt = timeAtLeast
+	}
+
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	for i, key := range keys {
+		rm.records[key] = Record{
+			GroupID:   groupIDs[i],
+			UpdatedAt: updatedAt,
+		}
+	}
+
+	return nil
+}
+
+// Exists reports for each key whether a record exists.
+func (rm *InMemoryRecordManager) Exists(ctx context.Context, keys []string) ([]bool, error) {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	exists := make([]bool, len(keys))
+
+	for i, key := range keys {
+		_, exists[i] = rm.records[key]
+	}
+
+	return exists, nil
+}
+
+// ListKeys returns the keys of the records updated before the given time, optionally restricted to the group ids.
+func (rm *InMemoryRecordManager) ListKeys(ctx context.Context, before time.Time, groupIDs []string) ([]string, error) {
+	var groups map[string]struct{}
+
+	if groupIDs != nil {
+		groups = make(map[string]struct{}, len(groupIDs))
+		for _, id := range groupIDs {
+			groups[id] = struct{}{}
+		}
+	}
+
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	keys := []string{}
+
+	for key, record := range rm.records {
+		if !record.UpdatedAt.Before(before) {
+			continue
+		}
+
+		if groups != nil {
+			if _, ok := groups[record.GroupID]; !ok {
+				continue
+			}
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// DeleteKeys deletes the records of the keys.
+func (rm *InMemoryRecordManager) DeleteKeys(ctx context.Context, keys []string) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	for _, key := range keys {
+		delete(rm.records, key)
+	}
+
+	return nil
+}
+
+// Load loads the records from an io.Reader.
+func (rm *InMemoryRecordManager) Load(r io.Reader) error {
+	records := make(map[string]Record)
+
+	if err := gob.NewDecoder(r).Decode(&records); err != nil {
+		return err
+	}
+
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.records = records
+
+	return nil
+}
+
+// Save saves the records to an io.Writer.
+func (rm *InMemoryRecordManager) Save(w io.Writer) error {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	return gob.NewEncoder(w).Encode(rm.records)
+}
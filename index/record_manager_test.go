@@ -0,0 +1,47 @@
+package index
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryRecordManager(t *testing.T) {
+	ctx := context.Background()
+
+	rm := newTestRecordManager()
+
+	start, err := rm.GetTime(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, rm.Update(ctx, []string{"1", "2"}, []string{"a", "b"}, start))
+
+	exists, err := rm.Exists(ctx, []string{"1", "3"})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, false}, exists)
+
+	keys, err := rm.ListKeys(ctx, start.Add(time.Hour), []string{"b"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2"}, keys)
+
+	keys, err = rm.ListKeys(ctx, start, nil)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	assert.ErrorIs(t, rm.Update(ctx, []string{"1"}, nil, start), ErrKeysGroupIDsMismatch)
+
+	var buf bytes.Buffer
+	require.NoError(t, rm.Save(&buf))
+
+	loaded := NewInMemoryRecordManager()
+	require.NoError(t, loaded.Load(&buf))
+	require.NoError(t, loaded.DeleteKeys(ctx, []string{"1"}))
+
+	exists, err = loaded.Exists(ctx, []string{"1", "2"})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{false, true}, exists)
+}
@@ -46,7 +46,7 @@ type Client struct {
 func New(apiKey string, optFns ...func(o *Options)) *Client {
 	opts := Options{
 		APIUrl:     "https://api.anthropic.com",
-		Version:    "2023-01-01",
+		Version:    "2023-06-01",
 		SDK:        "golc-anthrophic-sdk",
 		HTTPClient: http.DefaultClient,
 	}
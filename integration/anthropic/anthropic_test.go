@@ -41,7 +41,7 @@ func TestClient(t *testing.T) {
 				assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
 				assert.Equal(t, "application/json", req.Header.Get("Accept"))
 				assert.Equal(t, "golc-anthrophic-sdk", req.Header.Get("Anthropic-SDK"))
-				assert.Equal(t, "2023-01-01", req.Header.Get("Anthropic-Version"))
+				assert.Equal(t, "2023-06-01", req.Header.Get("Anthropic-Version"))
 				assert.Equal(t, "api-key", req.Header.Get("X-API-Key"))
 
 				body, bErr := io.ReadAll(req.Body)
@@ -100,6 +100,126 @@ func TestClient(t *testing.T) {
 	})
 }
 
+func TestMessages(t *testing.T) {
+	t.Run("CreateMessage", func(t *testing.T) {
+		mockClient := &mockHTTPClient{
+			doFunc: func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, "https://api.anthropic.com/v1/messages", req.URL.String())
+				assert.Equal(t, "api-key", req.Header.Get("X-API-Key"))
+
+				body, bErr := io.ReadAll(req.Body)
+				assert.NoError(t, bErr)
+
+				var request MessageRequest
+				assert.NoError(t, json.Unmarshal(body, &request))
+				assert.Equal(t, "Be brief.", request.System)
+				assert.Equal(t, "user", request.Messages[0].Role)
+				assert.Equal(t, "Hello", request.Messages[0].Content[0].Text)
+				assert.False(t, request.Stream)
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(bytes.NewBufferString(`{"id":"msg_1","type":"message","role":"assistant",` +
+						`"content":[{"type":"text","text":"Hi!"}],"stop_reason":"end_turn","usage":{"input_tokens":5,"output_tokens":2}}`)),
+				}, nil
+			},
+		}
+
+		client := New("api-key", func(o *Options) {
+			o.HTTPClient = mockClient
+		})
+
+		response, err := client.CreateMessage(context.Background(), &MessageRequest{
+			Model:     "claude-3-haiku-20240307",
+			System:    "Be brief.",
+			MaxTokens: 10,
+			Messages: []Message{
+				{Role: "user", Content: []ContentBlock{{Type: "text", Text: "Hello"}}},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "Hi!", response.Content[0].Text)
+		assert.Equal(t, "end_turn", response.StopReason)
+		assert.Equal(t, Usage{InputTokens: 5, OutputTokens: 2}, response.Usage)
+	})
+
+	t.Run("CreateMessage_APIError", func(t *testing.T) {
+		mockClient := &mockHTTPClient{
+			doFunc: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusBadRequest,
+					Body:       io.NopCloser(bytes.NewBufferString(`{"type":"error","error":{"type":"invalid_request_error","message":"bad request"}}`)),
+				}, nil
+			},
+		}
+
+		client := New("api-key", func(o *Options) {
+			o.HTTPClient = mockClient
+		})
+
+		response, err := client.CreateMessage(context.Background(), &MessageRequest{})
+		assert.EqualError(t, err, "anthropic: invalid_request_error: bad request")
+		assert.Nil(t, response)
+
+		apiErr := &ErrorDetail{}
+		assert.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	})
+
+	t.Run("CreateMessage_UnexpectedStatusCode", func(t *testing.T) {
+		mockClient := &mockHTTPClient{
+			doFunc: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusBadGateway,
+					Body:       io.NopCloser(bytes.NewBufferString("bad gateway")),
+				}, nil
+			},
+		}
+
+		client := New("api-key", func(o *Options) {
+			o.HTTPClient = mockClient
+		})
+
+		response, err := client.CreateMessage(context.Background(), &MessageRequest{})
+		assert.EqualError(t, err, "anthropic: unexpected_status_code: 502: bad gateway")
+		assert.Nil(t, response)
+
+		apiErr := &ErrorDetail{}
+		assert.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+	})
+
+	t.Run("MessageStream", func(t *testing.T) {
+		events := "event: message_start\n" +
+			`data: {"type":"message_start","message":{"id":"msg_1","usage":{"input_tokens":5,"output_tokens":1}}}` + "\n\n" +
+			"event: ping\n" +
+			`data: {"type":"ping"}` + "\n\n" +
+			"event: content_block_delta\n" +
+			`data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hello"}}` + "\n\n" +
+			"event: message_stop\n" +
+			`data: {"type":"message_stop"}` + "\n\n"
+
+		stream := NewMessageStream(io.NopCloser(bytes.NewBufferString(events)))
+		defer stream.Close()
+
+		event, err := stream.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, "message_start", event.Type)
+		assert.Equal(t, 5, event.Message.Usage.InputTokens)
+
+		event, err = stream.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, "ping", event.Type)
+
+		event, err = stream.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello", event.Delta.Text)
+
+		_, err = stream.Recv()
+		assert.ErrorIs(t, err, io.EOF)
+	})
+}
+
 // mockHTTPClient is a mock implementation of the HTTPClient interface.
 type mockHTTPClient struct {
 	doFunc func(req *http.Request) (*http.Response, error)
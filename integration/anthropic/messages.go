@@ -0,0 +1,305 @@
+package anthropic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hupe1980/golc/integration/stream"
+)
+
+// ContentBlock represents a block of content in a message.
+type ContentBlock struct {
+	// The type of the content block, e.g. "text", "tool_use" or "tool_result".
+	Type string `json:"type"`
+	// The text of a "text" block.
+	Text string `json:"text,omitempty"`
+	// The ID of a "tool_use" block.
+	ID string `json:"id,omitempty"`
+	// The name of the tool of a "tool_use" block.
+	Name string `json:"name,omitempty"`
+	// The input for the tool of a "tool_use" block.
+	Input json.RawMessage `json:"input,omitempty"`
+	// The ID of the "tool_use" block a "tool_result" block responds to.
+	ToolUseID string `json:"tool_use_id,omitempty"`
+	// The result of the tool of a "tool_result" block.
+	Content string `json:"content,omitempty"`
+	// The source of an "image" block.
+	Source *ImageSource `json:"source,omitempty"`
+}
+
+// ImageSource represents the source of an image content block.
+type ImageSource struct {
+	// The type of the source, either "base64" or "url".
+	Type string `json:"type"`
+	// The media type of a "base64" source, e.g. "image/png".
+	MediaType string `json:"media_type,omitempty"`
+	// The base64 encoded image of a "base64" source.
+	Data string `json:"data,omitempty"`
+	// The URL of an "url" source.
+	URL string `json:"url,omitempty"`
+}
+
+// Message represents a single message of a conversation.
+type Message struct {
+	// The role of the message author, either "user" or "assistant".
+	Role string `json:"role"`
+	// The content blocks of the message.
+	Content []ContentBlock `json:"content"`
+}
+
+// Tool represents a tool the model may use.
+type Tool struct {
+	// The name of the tool.
+	Name string `json:"name"`
+	// The description of the tool.
+	Description string `json:"description,omitempty"`
+	// The JSON schema of the tool input.
+	InputSchema any `json:"input_schema"`
+}
+
+// ToolChoice controls how the model uses the provided tools.
+type ToolChoice struct {
+	// The type of the tool choice, one of "auto", "any" or "tool".
+	Type string `json:"type"`
+	// The name of the tool to use if the type is "tool".
+	Name string `json:"name,omitempty"`
+}
+
+// MessageRequest represents a request to the Anthropic Messages API.
+type MessageRequest struct {
+	// The model to use.
+	Model string `json:"model"`
+	// The messages of the conversation.
+	Messages []Message `json:"messages"`
+	// The system prompt.
+	System string `json:"system,omitempty"`
+	// The maximum number of tokens to generate.
+	MaxTokens int `json:"max_tokens"`
+	// List of strings to stop generation at.
+	StopSequences []string `json:"stop_sequences,omitempty"`
+	// The temperature for randomness in sampling.
+	Temperature float32 `json:"temperature,omitempty"`
+	// The number of highest probability tokens to use in sampling.
+	TopK int `json:"top_k,omitempty"`
+	// The cumulative probability for nucleus sampling.
+	TopP float32 `json:"top_p,omitempty"`
+	// The tools the model may use.
+	Tools []Tool `json:"tools,omitempty"`
+	// How the model should use the provided tools.
+	ToolChoice *ToolChoice `json:"tool_choice,omitempty"`
+	// Flag to enable streaming response.
+	Stream bool `json:"stream,omitempty"`
+}
+
+// Usage represents the token usage of a request.
+type Usage struct {
+	// The number of input tokens.
+	InputTokens int `json:"input_tokens"`
+	// The number of output tokens.
+	OutputTokens int `json:"output_tokens"`
+}
+
+// MessageResponse represents the response from the Anthropic Messages API.
+type MessageResponse struct {
+	// The ID of the message.
+	ID string `json:"id"`
+	// The object type, always "message".
+	Type string `json:"type"`
+	// The role of the generated message, always "assistant".
+	Role string `json:"role"`
+	// The generated content blocks.
+	Content []ContentBlock `json:"content"`
+	// The model that handled the request.
+	Model string `json:"model"`
+	// The reason for stopping generation.
+	StopReason string `json:"stop_reason"`
+	// The stop sequence that caused generation to stop.
+	StopSequence string `json:"stop_sequence"`
+	// The token usage of the request.
+	Usage Usage `json:"usage"`
+}
+
+// MessageStreamDelta represents the delta of a streaming event.
+type MessageStreamDelta struct {
+	// The type of the delta, e.g. "text_delta" or "input_json_delta".
+	Type string `json:"type,omitempty"`
+	// The text of a "text_delta".
+	Text string `json:"text,omitempty"`
+	// The partial tool input of an "input_json_delta".
+	PartialJSON string `json:"partial_json,omitempty"`
+	// The reason for stopping generation of a "message_delta" event.
+	StopReason string `json:"stop_reason,omitempty"`
+	// The stop sequence that caused generation to stop of a "message_delta" event.
+	StopSequence string `json:"stop_sequence,omitempty"`
+}
+
+// MessageStreamEvent represents a server-sent event of a streaming message request.
+type MessageStreamEvent struct {
+	// The type of the event, e.g. "message_start", "content_block_delta" or "message_stop".
+	Type string `json:"type"`
+	// The message of a "message_start" event.
+	Message *MessageResponse `json:"message,omitempty"`
+	// The index of the content block the event refers to.
+	Index int `json:"index"`
+	// The content block of a "content_block_start" event.
+	ContentBlock *ContentBlock `json:"content_block,omitempty"`
+	// The delta of a "content_block_delta" or "message_delta" event.
+	Delta *MessageStreamDelta `json:"delta,omitempty"`
+	// The usage of a "message_delta" event.
+	Usage *Usage `json:"usage,omitempty"`
+	// The error of an "error" event.
+	Error *ErrorDetail `json:"error,omitempty"`
+}
+
+// ErrorDetail represents an error returned by the Anthropic API.
+type ErrorDetail struct {
+	// The type of the error.
+	Type string `json:"type"`
+	// The error message.
+	Message string `json:"message"`
+	// The HTTP status code of the failed request. It is zero for errors of stream events.
+	StatusCode int `json:"-"`
+}
+
+// Error returns the string representation of the error.
+func (e *ErrorDetail) Error() string {
+	return fmt.Sprintf("anthropic: %s: %s", e.Type, e.Message)
+}
+
+// errorResponse represents an error response from the Anthropic API.
+type errorResponse struct {
+	Error *ErrorDetail `json:"error"`
+}
+
+// CreateMessage sends a message request to the Anthropic Messages API and returns the response.
+func (c *Client) CreateMessage(ctx context.Context, request *MessageRequest) (*MessageResponse, error) {
+	request.Stream = false
+
+	resp, err := c.doMessageRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var response MessageResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// CreateMessageStream sends a streaming message request to the Anthropic Messages API.
+// The returned stream must be closed after use.
+func (c *Client) CreateMessageStream(ctx context.Context, request *MessageRequest) (*MessageStream, error) {
+	request.Stream = true
+
+	resp, err := c.doMessageRequest(ctx, request) //nolint:bodyclose // body is closed in stream.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMessageStream(resp.Body), nil
+}
+
+// doMessageRequest sends the message request and checks the response status.
+func (c *Client) doMessageRequest(ctx context.Context, request *MessageRequest) (*http.Response, error) {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/v1/messages", c.opts.APIUrl), bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Anthropic-SDK", c.opts.SDK)
+	req.Header.Set("Anthropic-Version", c.opts.Version)
+	req.Header.Set("X-API-Key", c.apiKey)
+
+	resp, err := c.opts.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		errResp := errorResponse{}
+		if err := json.Unmarshal(body, &errResp); err != nil || errResp.Error == nil {
+			errResp.Error = &ErrorDetail{
+				Type:    "unexpected_status_code",
+				Message: fmt.Sprintf("%d: %s", resp.StatusCode, string(body)),
+			}
+		}
+
+		errResp.Error.StatusCode = resp.StatusCode
+
+		return nil, errResp.Error
+	}
+
+	return resp, nil
+}
+
+// MessageStream is a reader for the server-sent events of a streaming message request.
+type MessageStream struct {
+	reader *stream.EventReader
+	closer io.Closer
+}
+
+// NewMessageStream creates a new MessageStream reading the server-sent events from the reader.
+func NewMessageStream(reader io.ReadCloser) *MessageStream {
+	return &MessageStream{
+		reader: stream.NewEventReader(reader),
+		closer: reader,
+	}
+}
+
+// Recv reads the next event from the stream. It returns io.EOF after the "message_stop" event.
+func (s *MessageStream) Recv() (*MessageStreamEvent, error) {
+	e, err := s.reader.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	event := &MessageStreamEvent{}
+	if err := json.Unmarshal([]byte(e.Data), event); err != nil {
+		return nil, err
+	}
+
+	switch event.Type {
+	case "message_stop":
+		return nil, io.EOF
+	case "error":
+		if event.Error == nil {
+			return nil, errors.New("anthropic: unknown stream error")
+		}
+
+		return nil, event.Error
+	}
+
+	return event, nil
+}
+
+// Close closes the underlying stream.
+func (s *MessageStream) Close() error {
+	return s.closer.Close()
+}
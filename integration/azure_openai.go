@@ -0,0 +1,81 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// AzureADTokenProvider returns an Azure Active Directory (Microsoft Entra ID) access token
+// that is used to authenticate the requests to the Azure OpenAI service.
+type AzureADTokenProvider func(ctx context.Context) (string, error)
+
+// AzureOpenAIConfigOptions contains the options for the Azure OpenAI client configuration.
+type AzureOpenAIConfigOptions struct {
+	// Deployment is the name of the model deployment. If empty, the deployment name is derived from the model name.
+	Deployment string
+	// APIVersion is the version of the Azure OpenAI API.
+	APIVersion string
+	// TokenProvider provides Azure AD access tokens. If set, it is used instead of the api key.
+	TokenProvider AzureADTokenProvider
+}
+
+// NewAzureOpenAIConfig creates an OpenAI client configuration for the Azure OpenAI resource at the given endpoint,
+// e.g. https://<resource>.openai.azure.com/.
+func NewAzureOpenAIConfig(apiKey, endpoint string, optFns ...func(o *AzureOpenAIConfigOptions)) openai.ClientConfig {
+	opts := AzureOpenAIConfigOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.TokenProvider != nil {
+		// The api key is not sent, as the Authorization header is set by the transport.
+		apiKey = ""
+	}
+
+	config := openai.DefaultAzureConfig(apiKey, endpoint)
+
+	if opts.Deployment != "" {
+		config.AzureModelMapperFunc = func(model string) string {
+			return opts.Deployment
+		}
+	}
+
+	if opts.APIVersion != "" {
+		config.APIVersion = opts.APIVersion
+	}
+
+	if opts.TokenProvider != nil {
+		config.APIType = openai.APITypeAzureAD
+		config.HTTPClient = &http.Client{
+			Transport: &azureADTransport{
+				base:          http.DefaultTransport,
+				tokenProvider: opts.TokenProvider,
+			},
+		}
+	}
+
+	return config
+}
+
+// azureADTransport is a http.RoundTripper that authenticates the requests with Azure AD access tokens.
+type azureADTransport struct {
+	base          http.RoundTripper
+	tokenProvider AzureADTokenProvider
+}
+
+// RoundTrip executes a single HTTP transaction with a fresh access token.
+func (t *azureADTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.tokenProvider(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get azure ad token: %w", err)
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,63 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAzureOpenAIConfig(t *testing.T) {
+	t.Run("APIKey", func(t *testing.T) {
+		var req *http.Request
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			req = r
+			_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"Hello"}}]}`))
+		}))
+		defer server.Close()
+
+		config := NewAzureOpenAIConfig("key", server.URL, func(o *AzureOpenAIConfigOptions) {
+			o.Deployment = "my-deployment"
+			o.APIVersion = "2024-02-01"
+		})
+
+		_, err := openai.NewClientWithConfig(config).CreateChatCompletion(context.Background(), openai.ChatCompletionRequest{
+			Model: openai.GPT4,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "/openai/deployments/my-deployment/chat/completions", req.URL.Path)
+		assert.Equal(t, "2024-02-01", req.URL.Query().Get("api-version"))
+		assert.Equal(t, "key", req.Header.Get("api-key"))
+		assert.Empty(t, req.Header.Get("Authorization"))
+	})
+
+	t.Run("TokenProvider", func(t *testing.T) {
+		var req *http.Request
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			req = r
+			_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"Hello"}}]}`))
+		}))
+		defer server.Close()
+
+		config := NewAzureOpenAIConfig("key", server.URL, func(o *AzureOpenAIConfigOptions) {
+			o.TokenProvider = func(ctx context.Context) (string, error) {
+				return "token", nil
+			}
+		})
+
+		_, err := openai.NewClientWithConfig(config).CreateChatCompletion(context.Background(), openai.ChatCompletionRequest{
+			Model: openai.GPT4,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+		assert.Empty(t, req.Header.Get("api-key"))
+	})
+}
@@ -0,0 +1,113 @@
+// Package confluence provides a client for the content API of Confluence Cloud.
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// HTTPClient is an interface for making HTTP requests.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type ClientOptions struct {
+	// The HTTP client to use for making API requests.
+	HTTPClient HTTPClient
+}
+
+type Client struct {
+	baseURL  string
+	username string
+	apiToken string
+	opts     ClientOptions
+}
+
+// New creates a new Confluence client for the site, e.g. https://example.atlassian.net/wiki. The client
+// authenticates with the email address of the user and an API token.
+func New(baseURL, username, apiToken string, optFns ...func(o *ClientOptions)) *Client {
+	opts := ClientOptions{
+		HTTPClient: http.DefaultClient,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Client{
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		username: username,
+		apiToken: apiToken,
+		opts:     opts,
+	}
+}
+
+// GetPages returns the current pages of a space with their content in the storage format.
+func (c *Client) GetPages(ctx context.Context, req *GetPagesRequest) (*GetPagesResponse, error) {
+	query := url.Values{}
+	query.Set("spaceKey", req.SpaceKey)
+	query.Set("type", "page")
+	query.Set("status", "current")
+	query.Set("expand", "body.storage,version,space")
+	query.Set("start", strconv.Itoa(req.Start))
+
+	if req.Limit > 0 {
+		query.Set("limit", strconv.Itoa(req.Limit))
+	}
+
+	body, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("%s/rest/api/content?%s", c.baseURL, query.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	res := GetPagesResponse{}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// doRequest sends an HTTP request to the specified URL with the given method.
+func (c *Client) doRequest(ctx context.Context, method string, url string) ([]byte, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.SetBasicAuth(c.username, c.apiToken)
+
+	res, err := c.opts.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		apiErr := &APIError{StatusCode: res.StatusCode}
+
+		errorResponse := ErrorResponse{}
+		if err := json.Unmarshal(resBody, &errorResponse); err != nil || errorResponse.Message == "" {
+			apiErr.Message = res.Status
+		} else {
+			apiErr.Message = errorResponse.Message
+		}
+
+		return nil, apiErr
+	}
+
+	return resBody, nil
+}
@@ -0,0 +1,61 @@
+package confluence
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	t.Run("GetPages", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/wiki/rest/api/content", r.URL.Path)
+			assert.Equal(t, "DOCS", r.URL.Query().Get("spaceKey"))
+			assert.Equal(t, "25", r.URL.Query().Get("start"))
+			assert.Equal(t, "25", r.URL.Query().Get("limit"))
+			assert.Equal(t, "body.storage,version,space", r.URL.Query().Get("expand"))
+
+			username, password, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "user@example.com", username)
+			assert.Equal(t, "token", password)
+
+			_, _ = w.Write([]byte(`{"results":[{"id":"1","type":"page","status":"current","title":"Home","space":{"key":"DOCS","name":"Docs"},"version":{"number":3,"when":"2024-01-02T10:00:00.000Z"},"body":{"storage":{"value":"<p>Hello</p>","representation":"storage"}},"_links":{"webui":"/spaces/DOCS/pages/1/Home"}}],"start":25,"limit":25,"size":1,"_links":{"base":"https://example.atlassian.net/wiki"}}`))
+		}))
+		defer server.Close()
+
+		client := New(server.URL+"/wiki/", "user@example.com", "token")
+
+		res, err := client.GetPages(context.Background(), &GetPagesRequest{SpaceKey: "DOCS", Start: 25, Limit: 25})
+		require.NoError(t, err)
+		require.Len(t, res.Results, 1)
+		assert.Equal(t, "Home", res.Results[0].Title)
+		assert.Equal(t, "<p>Hello</p>", res.Results[0].Body.Storage.Value)
+		assert.Equal(t, "2024-01-02T10:00:00.000Z", res.Results[0].Version.When)
+		assert.Equal(t, "https://example.atlassian.net/wiki", res.Links.Base)
+		assert.False(t, res.HasMore())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"statusCode":404,"message":"No space with key : DOCS"}`))
+		}))
+		defer server.Close()
+
+		client := New(server.URL, "user@example.com", "token")
+
+		_, err := client.GetPages(context.Background(), &GetPagesRequest{SpaceKey: "DOCS"})
+
+		apiErr := &APIError{}
+		require.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.EqualError(t, err, "confluence API error (404): No space with key : DOCS")
+	})
+}
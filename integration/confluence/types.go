@@ -0,0 +1,76 @@
+package confluence
+
+import "fmt"
+
+type GetPagesRequest struct {
+	// SpaceKey is the key of the space of the pages.
+	SpaceKey string
+	// Start is the index of the first page.
+	Start int
+	// Limit is the maximum number of pages.
+	Limit int
+}
+
+type Space struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+type Version struct {
+	Number int    `json:"number"`
+	When   string `json:"when"`
+}
+
+type Storage struct {
+	// Value is the content of the page in the storage format, which is XHTML based.
+	Value          string `json:"value"`
+	Representation string `json:"representation"`
+}
+
+type Body struct {
+	Storage Storage `json:"storage"`
+}
+
+type Links struct {
+	WebUI string `json:"webui"`
+	Base  string `json:"base"`
+	Next  string `json:"next"`
+}
+
+type Page struct {
+	ID      string  `json:"id"`
+	Type    string  `json:"type"`
+	Status  string  `json:"status"`
+	Title   string  `json:"title"`
+	Space   Space   `json:"space"`
+	Version Version `json:"version"`
+	Body    Body    `json:"body"`
+	Links   Links   `json:"_links"`
+}
+
+type GetPagesResponse struct {
+	Results []Page `json:"results"`
+	Start   int    `json:"start"`
+	Limit   int    `json:"limit"`
+	Size    int    `json:"size"`
+	Links   Links  `json:"_links"`
+}
+
+// HasMore reports whether there are more pages after the pages of the response.
+func (r *GetPagesResponse) HasMore() bool {
+	return r.Links.Next != ""
+}
+
+type ErrorResponse struct {
+	Message string `json:"message"`
+}
+
+// APIError is returned when the API responds with an error status code.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("confluence API error (%d): %s", e.StatusCode, e.Message)
+}
@@ -0,0 +1,169 @@
+// Package elasticsearch provides a client for the REST API of Elasticsearch and OpenSearch.
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// HTTPClient is an interface for making HTTP requests.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type ClientOptions struct {
+	// The HTTP client to use for making API requests.
+	HTTPClient HTTPClient
+	// Username and Password are used for basic authentication.
+	Username string
+	Password string
+	// APIKey is the encoded API key of Elasticsearch. It takes precedence over basic authentication.
+	APIKey string
+}
+
+type Client struct {
+	apiURL string
+	opts   ClientOptions
+}
+
+// New creates a new client for the given API url, e.g. http://localhost:9200.
+func New(apiURL string, optFns ...func(o *ClientOptions)) *Client {
+	opts := ClientOptions{
+		HTTPClient: http.DefaultClient,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Client{
+		apiURL: apiURL,
+		opts:   opts,
+	}
+}
+
+// PutIndexTemplate creates or updates the composable index template with the given name.
+func (c *Client) PutIndexTemplate(ctx context.Context, name string, template map[string]any) error {
+	b, err := json.Marshal(template)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(ctx, http.MethodPut, fmt.Sprintf("%s/_index_template/%s", c.apiURL, url.PathEscape(name)), "application/json", b)
+
+	return err
+}
+
+// Bulk indexes the documents into the index and refreshes the index afterwards.
+func (c *Client) Bulk(ctx context.Context, index string, items []BulkItem) (*BulkResponse, error) {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+
+	for _, item := range items {
+		if err := encoder.Encode(map[string]any{"index": map[string]any{"_id": item.ID}}); err != nil {
+			return nil, err
+		}
+
+		if err := encoder.Encode(item.Document); err != nil {
+			return nil, err
+		}
+	}
+
+	body, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("%s/%s/_bulk?refresh=true", c.apiURL, url.PathEscape(index)), "application/x-ndjson", buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	bulkResponse := BulkResponse{}
+	if err := json.Unmarshal(body, &bulkResponse); err != nil {
+		return nil, err
+	}
+
+	return &bulkResponse, nil
+}
+
+// Search executes the search request on the index.
+func (c *Client) Search(ctx context.Context, index string, req map[string]any) (*SearchResponse, error) {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("%s/%s/_search", c.apiURL, url.PathEscape(index)), "application/json", b)
+	if err != nil {
+		return nil, err
+	}
+
+	searchResponse := SearchResponse{}
+	if err := json.Unmarshal(body, &searchResponse); err != nil {
+		return nil, err
+	}
+
+	return &searchResponse, nil
+}
+
+// DeleteByQuery deletes the documents of the index matching the query and refreshes the index afterwards.
+func (c *Client) DeleteByQuery(ctx context.Context, index string, query map[string]any) (*DeleteByQueryResponse, error) {
+	b, err := json.Marshal(map[string]any{"query": query})
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("%s/%s/_delete_by_query?refresh=true", c.apiURL, url.PathEscape(index)), "application/json", b)
+	if err != nil {
+		return nil, err
+	}
+
+	deleteByQueryResponse := DeleteByQueryResponse{}
+	if err := json.Unmarshal(body, &deleteByQueryResponse); err != nil {
+		return nil, err
+	}
+
+	return &deleteByQueryResponse, nil
+}
+
+// doRequest sends an HTTP request to the specified URL with the given method and payload.
+func (c *Client) doRequest(ctx context.Context, method string, url string, contentType string, payload []byte) ([]byte, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("Content-Type", contentType)
+
+	if c.opts.APIKey != "" {
+		httpReq.Header.Set("Authorization", fmt.Sprintf("ApiKey %s", c.opts.APIKey))
+	} else if c.opts.Username != "" {
+		httpReq.SetBasicAuth(c.opts.Username, c.opts.Password)
+	}
+
+	res, err := c.opts.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		errorResponse := ErrorResponse{}
+		if err := json.Unmarshal(resBody, &errorResponse); err != nil || errorResponse.Error.Reason == "" {
+			return nil, fmt.Errorf("elasticsearch API error: %s", res.Status)
+		}
+
+		return nil, fmt.Errorf("elasticsearch API error: %s: %s", errorResponse.Error.Type, errorResponse.Error.Reason)
+	}
+
+	return resBody, nil
+}
@@ -0,0 +1,91 @@
+package elasticsearch
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	t.Run("Bulk", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/docs/_bulk", r.URL.Path)
+			assert.Equal(t, "true", r.URL.Query().Get("refresh"))
+			assert.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+			assert.Equal(t, "ApiKey key", r.Header.Get("Authorization"))
+
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, "{\"index\":{\"_id\":\"1\"}}\n{\"content\":\"document1\"}\n", string(body))
+
+			_, _ = w.Write([]byte(`{"errors":false,"items":[{"index":{"_id":"1","status":201}}]}`))
+		}))
+		defer server.Close()
+
+		client := New(server.URL, func(o *ClientOptions) {
+			o.APIKey = "key"
+		})
+
+		res, err := client.Bulk(context.Background(), "docs", []BulkItem{{ID: "1", Document: map[string]any{"content": "document1"}}})
+		require.NoError(t, err)
+		assert.False(t, res.Errors)
+		assert.Equal(t, 201, res.Items[0]["index"].Status)
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/docs/_search", r.URL.Path)
+
+			user, password, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "elastic", user)
+			assert.Equal(t, "secret", password)
+
+			_, _ = w.Write([]byte(`{"hits":{"hits":[{"_id":"1","_score":0.9,"_source":{"content":"document1"}}]}}`))
+		}))
+		defer server.Close()
+
+		client := New(server.URL, func(o *ClientOptions) {
+			o.Username = "elastic"
+			o.Password = "secret"
+		})
+
+		res, err := client.Search(context.Background(), "docs", map[string]any{"size": 1})
+		require.NoError(t, err)
+		assert.Equal(t, []Hit{{ID: "1", Score: 0.9, Source: map[string]any{"content": "document1"}}}, res.Hits.Hits)
+	})
+
+	t.Run("DeleteByQuery", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/docs/_delete_by_query", r.URL.Path)
+			assert.Equal(t, "true", r.URL.Query().Get("refresh"))
+
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"query":{"ids":{"values":["1"]}}}`, string(body))
+
+			_, _ = w.Write([]byte(`{"deleted":1}`))
+		}))
+		defer server.Close()
+
+		client := New(server.URL)
+
+		res, err := client.DeleteByQuery(context.Background(), "docs", map[string]any{"ids": map[string]any{"values": []string{"1"}}})
+		require.NoError(t, err)
+		assert.Equal(t, 1, res.Deleted)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"type":"index_not_found_exception","reason":"no such index [docs]"},"status":404}`))
+		}))
+		defer server.Close()
+
+		_, err := New(server.URL).Search(context.Background(), "docs", map[string]any{})
+		assert.EqualError(t, err, "elasticsearch API error: index_not_found_exception: no such index [docs]")
+	})
+}
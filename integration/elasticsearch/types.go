@@ -0,0 +1,51 @@
+package elasticsearch
+
+// BulkItem represents a document indexed by a bulk request.
+type BulkItem struct {
+	ID       string
+	Document map[string]any
+}
+
+// BulkItemResult represents the result of a single operation of a bulk request.
+type BulkItemResult struct {
+	ID     string `json:"_id"`
+	Status int    `json:"status"`
+	Error  *Error `json:"error,omitempty"`
+}
+
+// BulkResponse represents the response from a bulk request.
+type BulkResponse struct {
+	Errors bool                        `json:"errors"`
+	Items  []map[string]BulkItemResult `json:"items"`
+}
+
+// Hit represents a document found by a search request.
+type Hit struct {
+	ID     string         `json:"_id"`
+	Score  float32        `json:"_score"`
+	Source map[string]any `json:"_source"`
+}
+
+// SearchResponse represents the response from a search request.
+type SearchResponse struct {
+	Hits struct {
+		Hits []Hit `json:"hits"`
+	} `json:"hits"`
+}
+
+// DeleteByQueryResponse represents the response from a delete by query request.
+type DeleteByQueryResponse struct {
+	Deleted int `json:"deleted"`
+}
+
+// Error represents an error returned by Elasticsearch or OpenSearch.
+type Error struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// ErrorResponse represents the response of a failed request.
+type ErrorResponse struct {
+	Error  Error `json:"error"`
+	Status int   `json:"status"`
+}
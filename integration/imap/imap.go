@@ -0,0 +1,319 @@
+// Package imap provides a minimal client for reading messages from IMAP servers.
+package imap
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ClientOptions struct {
+	// TLSConfig is the TLS configuration of the connection. If nil, the default configuration for the host
+	// is used.
+	TLSConfig *tls.Config
+	// DisableTLS connects without TLS, e.g. to local test servers.
+	DisableTLS bool
+	// DialTimeout is the timeout for establishing the connection.
+	DialTimeout time.Duration
+	// MaxLiteralSize is the maximum size of a literal sent by the server, e.g. of a message, in bytes.
+	MaxLiteralSize int
+}
+
+// defaultMaxLiteralSize is the default maximum size of a literal.
+const defaultMaxLiteralSize = 64 * 1024 * 1024
+
+// errInvalidArgument is returned for arguments containing characters, which would end the command.
+var errInvalidArgument = errors.New("invalid imap argument: contains CR, LF or NUL")
+
+// Client is a minimal IMAP4rev1 client, which supports reading messages of a mailbox.
+type Client struct {
+	conn net.Conn
+	r    *bufio.Reader
+	tag  int
+	opts ClientOptions
+}
+
+// Dial connects to the IMAP server at the address, e.g. imap.example.com:993.
+func Dial(ctx context.Context, addr string, optFns ...func(o *ClientOptions)) (*Client, error) {
+	opts := ClientOptions{
+		DialTimeout: 30 * time.Second,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	dialer := &net.Dialer{Timeout: opts.DialTimeout}
+
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if !opts.DisableTLS {
+		config := opts.TLSConfig
+		if config == nil {
+			host, _, err := net.SplitHostPort(addr)
+			if err != nil {
+				conn.Close()
+				return nil, err
+			}
+
+			config = &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}
+		}
+
+		conn = tls.Client(conn, config)
+	}
+
+	return NewClient(conn, optFns...)
+}
+
+// NewClient creates a new client for the connection and reads the greeting of the server.
+func NewClient(conn net.Conn, optFns ...func(o *ClientOptions)) (*Client, error) {
+	opts := ClientOptions{
+		MaxLiteralSize: defaultMaxLiteralSize,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	c := &Client{
+		conn: conn,
+		r:    bufio.NewReader(conn),
+		opts: opts,
+	}
+
+	greeting, err := c.readResponse()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if !strings.HasPrefix(greeting.Text, "* OK") && !strings.HasPrefix(greeting.Text, "* PREAUTH") {
+		conn.Close()
+		return nil, fmt.Errorf("unexpected greeting: %s", greeting.Text)
+	}
+
+	return c, nil
+}
+
+// Login authenticates the user with the password.
+func (c *Client) Login(ctx context.Context, username, password string) error {
+	u, err := quote(username)
+	if err != nil {
+		return err
+	}
+
+	p, err := quote(password)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.execute(ctx, "LOGIN %s %s", u, p)
+
+	return err
+}
+
+// Select selects the mailbox read-only and returns the number of messages.
+func (c *Client) Select(ctx context.Context, mailbox string) (int, error) {
+	m, err := quote(mailbox)
+	if err != nil {
+		return 0, err
+	}
+
+	responses, err := c.execute(ctx, "EXAMINE %s", m)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, r := range responses {
+		fields := strings.Fields(r.Text)
+		if len(fields) == 3 && strings.EqualFold(fields[2], "EXISTS") {
+			return strconv.Atoi(fields[1])
+		}
+	}
+
+	return 0, nil
+}
+
+// Search returns the UIDs of the messages of the selected mailbox matching the criteria, e.g. ALL or
+// SINCE 1-Jan-2024. The criteria are sent as is, so strings in the criteria must be quoted; line breaks are
+// rejected.
+func (c *Client) Search(ctx context.Context, criteria string) ([]uint32, error) {
+	if strings.ContainsAny(criteria, "\r\n\x00") {
+		return nil, errInvalidArgument
+	}
+
+	responses, err := c.execute(ctx, "UID SEARCH %s", criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	uids := []uint32{}
+
+	for _, r := range responses {
+		fields := strings.Fields(r.Text)
+		if len(fields) < 2 || !strings.EqualFold(fields[1], "SEARCH") {
+			continue
+		}
+
+		for _, f := range fields[2:] {
+			uid, err := strconv.ParseUint(f, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid uid %s", f)
+			}
+
+			uids = append(uids, uint32(uid))
+		}
+	}
+
+	return uids, nil
+}
+
+// Fetch returns the raw RFC 5322 message with the UID without marking it as seen.
+func (c *Client) Fetch(ctx context.Context, uid uint32) ([]byte, error) {
+	responses, err := c.execute(ctx, "UID FETCH %d BODY.PEEK[]", uid)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range responses {
+		if strings.Contains(strings.ToUpper(r.Text), "FETCH") && len(r.Literals) > 0 {
+			return r.Literals[0], nil
+		}
+	}
+
+	return nil, fmt.Errorf("message %d not found", uid)
+}
+
+// Logout logs out and closes the connection.
+func (c *Client) Logout(ctx context.Context) error {
+	_, err := c.execute(ctx, "LOGOUT")
+
+	if cerr := c.conn.Close(); err == nil {
+		err = cerr
+	}
+
+	return err
+}
+
+// Close closes the connection without logging out.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// response is a response line of the server. The literals of the line are read separately.
+type response struct {
+	Text     string
+	Literals [][]byte
+}
+
+// execute sends the command and returns the untagged responses. An error is returned, if the command
+// does not complete with OK.
+func (c *Client) execute(ctx context.Context, format string, args ...any) ([]response, error) {
+	deadline, _ := ctx.Deadline()
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	c.tag++
+	tag := fmt.Sprintf("A%03d", c.tag)
+
+	if _, err := fmt.Fprintf(c.conn, "%s %s\r\n", tag, fmt.Sprintf(format, args...)); err != nil {
+		return nil, err
+	}
+
+	responses := []response{}
+
+	for {
+		r, err := c.readResponse()
+		if err != nil {
+			return nil, err
+		}
+
+		if !strings.HasPrefix(r.Text, tag+" ") {
+			responses = append(responses, r)
+			continue
+		}
+
+		status := strings.TrimPrefix(r.Text, tag+" ")
+		if !strings.HasPrefix(strings.ToUpper(status), "OK") {
+			return nil, fmt.Errorf("imap command failed: %s", status)
+		}
+
+		return responses, nil
+	}
+}
+
+// readResponse reads a response line including its literals, e.g. {42} followed by 42 bytes.
+func (c *Client) readResponse() (response, error) {
+	r := response{}
+	text := new(strings.Builder)
+
+	for {
+		line, err := c.r.ReadString('\n')
+		if err != nil {
+			return r, err
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+
+		size, ok := literalSize(line)
+		if !ok {
+			text.WriteString(line)
+			r.Text = text.String()
+
+			return r, nil
+		}
+
+		text.WriteString(line)
+
+		if size > c.opts.MaxLiteralSize {
+			return r, fmt.Errorf("imap literal of %d bytes exceeds the maximum size of %d bytes", size, c.opts.MaxLiteralSize)
+		}
+
+		literal := make([]byte, size)
+		if _, err := io.ReadFull(c.r, literal); err != nil {
+			return r, err
+		}
+
+		r.Literals = append(r.Literals, literal)
+	}
+}
+
+// literalSize returns the size of the literal announced at the end of the line.
+func literalSize(line string) (int, bool) {
+	if !strings.HasSuffix(line, "}") {
+		return 0, false
+	}
+
+	start := strings.LastIndex(line, "{")
+	if start < 0 {
+		return 0, false
+	}
+
+	size, err := strconv.Atoi(line[start+1 : len(line)-1])
+	if err != nil || size < 0 {
+		return 0, false
+	}
+
+	return size, true
+}
+
+// quote quotes the string for IMAP commands. Quoted strings cannot contain CR, LF or NUL, which would
+// allow injecting further commands, so these strings are rejected.
+func quote(s string) (string, error) {
+	if strings.ContainsAny(s, "\r\n\x00") {
+		return "", errInvalidArgument
+	}
+
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`, nil
+}
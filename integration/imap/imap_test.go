@@ -0,0 +1,128 @@
+package imap
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	message := "Subject: Hello\r\n\r\nHi there\r\n"
+
+	client, server := net.Pipe()
+
+	go func() {
+		defer server.Close()
+
+		r := bufio.NewReader(server)
+
+		fmt.Fprint(server, "* OK IMAP4rev1 ready\r\n")
+
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			fields := strings.SplitN(strings.TrimSpace(line), " ", 2)
+			tag, command := fields[0], fields[1]
+
+			switch {
+			case command == `LOGIN "user" "p\"w"`:
+				fmt.Fprintf(server, "%s OK LOGIN completed\r\n", tag)
+			case strings.HasPrefix(command, "LOGIN"):
+				fmt.Fprintf(server, "%s NO invalid credentials\r\n", tag)
+			case command == `EXAMINE "INBOX"`:
+				fmt.Fprintf(server, "* 2 EXISTS\r\n* 0 RECENT\r\n%s OK [READ-ONLY] EXAMINE completed\r\n", tag)
+			case command == "UID SEARCH ALL":
+				fmt.Fprintf(server, "* SEARCH 4 7\r\n%s OK SEARCH completed\r\n", tag)
+			case command == "UID FETCH 7 BODY.PEEK[]":
+				fmt.Fprintf(server, "* 2 FETCH (UID 7 BODY[] {%d}\r\n%s)\r\n%s OK FETCH completed\r\n", len(message), message, tag)
+			case command == "LOGOUT":
+				fmt.Fprintf(server, "* BYE\r\n%s OK LOGOUT completed\r\n", tag)
+				return
+			default:
+				fmt.Fprintf(server, "%s BAD unknown command\r\n", tag)
+			}
+		}
+	}()
+
+	c, err := NewClient(client)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	assert.EqualError(t, c.Login(ctx, "user", "wrong"), "imap command failed: NO invalid credentials")
+	require.NoError(t, c.Login(ctx, "user", `p"w`))
+
+	count, err := c.Select(ctx, "INBOX")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	uids, err := c.Search(ctx, "ALL")
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{4, 7}, uids)
+
+	data, err := c.Fetch(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, message, string(data))
+
+	require.NoError(t, c.Logout(ctx))
+}
+
+func TestClientInjection(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+
+	go func() {
+		fmt.Fprint(server, "* OK IMAP4rev1 ready\r\n")
+	}()
+
+	c, err := NewClient(client)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	assert.ErrorIs(t, c.Login(ctx, "user", "pw\r\nA999 DELETE INBOX"), errInvalidArgument)
+	assert.ErrorIs(t, c.Login(ctx, "user\n", "pw"), errInvalidArgument)
+
+	_, err = c.Select(ctx, "INBOX\x00")
+	assert.ErrorIs(t, err, errInvalidArgument)
+
+	_, err = c.Search(ctx, "ALL\r\nA999 DELETE INBOX")
+	assert.ErrorIs(t, err, errInvalidArgument)
+}
+
+func TestClientMaxLiteralSize(t *testing.T) {
+	client, server := net.Pipe()
+
+	go func() {
+		defer server.Close()
+
+		r := bufio.NewReader(server)
+
+		fmt.Fprint(server, "* OK IMAP4rev1 ready\r\n")
+
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		tag := strings.Fields(line)[0]
+		fmt.Fprintf(server, "* 1 FETCH (UID 1 BODY[] {1099511627776}\r\n%s OK FETCH completed\r\n", tag)
+	}()
+
+	c, err := NewClient(client, func(o *ClientOptions) {
+		o.MaxLiteralSize = 1024
+	})
+	require.NoError(t, err)
+
+	_, err = c.Fetch(context.Background(), 1)
+	assert.EqualError(t, err, "imap literal of 1099511627776 bytes exceeds the maximum size of 1024 bytes")
+}
@@ -0,0 +1,110 @@
+// Package jina provides a client for the embeddings API of Jina AI.
+package jina
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// HTTPClient is an interface for making HTTP requests.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type ClientOptions struct {
+	// The HTTP client to use for making API requests.
+	HTTPClient HTTPClient
+	// BaseURL is the base url of the API.
+	BaseURL string
+}
+
+type Client struct {
+	apiKey string
+	opts   ClientOptions
+}
+
+// New creates a new Jina AI client with the given API key.
+func New(apiKey string, optFns ...func(o *ClientOptions)) *Client {
+	opts := ClientOptions{
+		HTTPClient: http.DefaultClient,
+		BaseURL:    "https://api.jina.ai/v1",
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Client{
+		apiKey: apiKey,
+		opts:   opts,
+	}
+}
+
+// CreateEmbeddings embeds the input texts.
+func (c *Client) CreateEmbeddings(ctx context.Context, req *EmbeddingRequest) (*EmbeddingResponse, error) {
+	body, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("%s/embeddings", c.opts.BaseURL), req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := EmbeddingResponse{}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// doRequest sends an HTTP request to the specified URL with the given method and payload.
+func (c *Client) doRequest(ctx context.Context, method string, url string, payload any) ([]byte, error) {
+	var body io.Reader
+
+	if payload != nil {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+
+		body = bytes.NewReader(b)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+
+	res, err := c.opts.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		apiErr := &APIError{StatusCode: res.StatusCode}
+
+		errorResponse := ErrorResponse{}
+		if err := json.Unmarshal(resBody, &errorResponse); err != nil || errorResponse.Detail == "" {
+			apiErr.Message = res.Status
+		} else {
+			apiErr.Message = errorResponse.Detail
+		}
+
+		return nil, apiErr
+	}
+
+	return resBody, nil
+}
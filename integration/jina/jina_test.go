@@ -0,0 +1,66 @@
+package jina
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	t.Run("CreateEmbeddings", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/embeddings", r.URL.Path)
+			assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+
+			req := map[string]any{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, map[string]any{
+				"model":      "jina-embeddings-v3",
+				"input":      []any{"text"},
+				"task":       "retrieval.passage",
+				"dimensions": float64(256),
+			}, req)
+
+			_, _ = w.Write([]byte(`{"model":"jina-embeddings-v3","data":[{"object":"embedding","embedding":[0.1,0.2],"index":0}],"usage":{"total_tokens":1,"prompt_tokens":1}}`))
+		}))
+		defer server.Close()
+
+		client := New("key", func(o *ClientOptions) {
+			o.BaseURL = server.URL
+		})
+
+		res, err := client.CreateEmbeddings(context.Background(), &EmbeddingRequest{
+			Model:      "jina-embeddings-v3",
+			Input:      []string{"text"},
+			Task:       TaskRetrievalPassage,
+			Dimensions: 256,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []Embedding{{Object: "embedding", Embedding: []float32{0.1, 0.2}, Index: 0}}, res.Data)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		client := New("key", func(o *ClientOptions) {
+			o.BaseURL = server.URL
+		})
+
+		_, err := client.CreateEmbeddings(context.Background(), &EmbeddingRequest{Input: []string{"text"}})
+
+		apiErr := &APIError{}
+		require.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+		assert.EqualError(t, err, "jina API error (401): 401 Unauthorized")
+	})
+}
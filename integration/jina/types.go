@@ -0,0 +1,58 @@
+package jina
+
+import "fmt"
+
+// Task is the downstream task the embeddings are optimized for.
+type Task string
+
+const (
+	TaskRetrievalQuery   Task = "retrieval.query"
+	TaskRetrievalPassage Task = "retrieval.passage"
+	TaskSeparation       Task = "separation"
+	TaskClassification   Task = "classification"
+	TaskTextMatching     Task = "text-matching"
+)
+
+type EmbeddingRequest struct {
+	// Model is the name of the embedding model, e.g. jina-embeddings-v3.
+	Model string `json:"model"`
+	// Input is the list of texts to embed.
+	Input []string `json:"input"`
+	// Task is the downstream task the embeddings are optimized for. It is only supported by some models.
+	Task Task `json:"task,omitempty"`
+	// Dimensions is the number of dimensions of the embeddings. It is only supported by some models.
+	Dimensions int `json:"dimensions,omitempty"`
+	// LateChunking embeds the input texts as chunks of one concatenated text.
+	LateChunking bool `json:"late_chunking,omitempty"`
+}
+
+type Embedding struct {
+	Object    string    `json:"object"`
+	Embedding []float32 `json:"embedding"`
+	Index     int       `json:"index"`
+}
+
+type Usage struct {
+	TotalTokens  int `json:"total_tokens"`
+	PromptTokens int `json:"prompt_tokens"`
+}
+
+type EmbeddingResponse struct {
+	Model string      `json:"model"`
+	Data  []Embedding `json:"data"`
+	Usage Usage       `json:"usage"`
+}
+
+type ErrorResponse struct {
+	Detail string `json:"detail"`
+}
+
+// APIError is returned when the API responds with an error status code.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("jina API error (%d): %s", e.StatusCode, e.Message)
+}
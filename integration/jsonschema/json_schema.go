@@ -325,6 +325,17 @@ func GenerateFromField(f reflect.StructField, mode Mode, definedRefs map[string]
 		}
 	}
 
+	// The required tag overrides the omitempty option of the json tag.
+	if tagValue, ok := f.Tag.Lookup("required"); ok {
+		if tagValue == "true" {
+			optional = false
+		} else if tagValue == "false" {
+			optional = true
+		} else {
+			return name, false, nil, fmt.Errorf("%s required: boolean should be true or false but got %s: %w", f.Name, tagValue, ErrSchemaInvalid)
+		}
+	}
+
 	return name, optional, s, nil
 }
 
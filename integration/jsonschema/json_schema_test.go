@@ -45,6 +45,24 @@ func TestGenerate(t *testing.T) {
 		assert.Contains(t, schema.Properties["enum_field"].Enum, string(EnumValue2))
 	})
 
+	t.Run("Generate schema for struct with required tags", func(t *testing.T) {
+		type MyStruct struct {
+			RequiredField string `json:"required_field,omitempty" required:"true"`
+			OptionalField string `json:"optional_field" required:"false"`
+		}
+
+		schema, err := Generate(reflect.TypeOf(MyStruct{}))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"required_field"}, schema.Required)
+
+		type InvalidStruct struct {
+			Field string `json:"field" required:"yes"`
+		}
+
+		_, err = Generate(reflect.TypeOf(InvalidStruct{}))
+		assert.ErrorIs(t, err, ErrSchemaInvalid)
+	})
+
 	t.Run("Generate schema for struct with nested structs", func(t *testing.T) {
 		type NestedStruct struct {
 			NestedField int `json:"nested_field"`
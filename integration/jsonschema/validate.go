@@ -0,0 +1,364 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidationError represents a value that does not conform to a schema.
+type ValidationError struct {
+	// Path is the path of the invalid value, e.g. "address.city" or "tags[1]". It is empty for the root value.
+	Path string
+	// Message describes why the value is invalid.
+	Message string
+}
+
+// Error returns the error message.
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// Validate validates a decoded JSON value, as returned by json.Unmarshal into an any value, against the
+// schema. It supports the type, enum, numeric, string, array and object keywords as well as allOf, anyOf,
+// oneOf and not. References and formats are not validated.
+func (s *Schema) Validate(value any) error {
+	return s.validate("", value)
+}
+
+func (s *Schema) validate(path string, value any) error { // nolint gocyclo
+	if s == nil {
+		return nil
+	}
+
+	if value == nil && s.Nullable {
+		return nil
+	}
+
+	if s.Type != "" && !hasType(value, s.Type) {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("expected %s, got %s", s.Type, typeOf(value))}
+	}
+
+	if len(s.Enum) > 0 && !containsValue(s.Enum, value) {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("value must be one of %s", formatValues(s.Enum))}
+	}
+
+	switch v := value.(type) {
+	case float64:
+		if err := s.validateNumber(path, v); err != nil {
+			return err
+		}
+	case string:
+		if err := s.validateString(path, v); err != nil {
+			return err
+		}
+	case []any:
+		if err := s.validateArray(path, v); err != nil {
+			return err
+		}
+	case map[string]any:
+		if err := s.validateObject(path, v); err != nil {
+			return err
+		}
+	}
+
+	for _, sub := range s.AllOf {
+		if err := sub.validate(path, value); err != nil {
+			return err
+		}
+	}
+
+	if len(s.AnyOf) > 0 && countValid(s.AnyOf, path, value) == 0 {
+		return &ValidationError{Path: path, Message: "value must match at least one schema of anyOf"}
+	}
+
+	if len(s.OneOf) > 0 && countValid(s.OneOf, path, value) != 1 {
+		return &ValidationError{Path: path, Message: "value must match exactly one schema of oneOf"}
+	}
+
+	if s.Not != nil && s.Not.validate(path, value) == nil {
+		return &ValidationError{Path: path, Message: "value must not match the schema of not"}
+	}
+
+	return nil
+}
+
+func (s *Schema) validateNumber(path string, v float64) error {
+	if s.Minimum != nil {
+		if exclusive := s.ExclusiveMinimum != nil && *s.ExclusiveMinimum; v < *s.Minimum || (exclusive && v == *s.Minimum) {
+			return &ValidationError{Path: path, Message: fmt.Sprintf("value must be greater than %s%v", orEqual(!exclusive), *s.Minimum)}
+		}
+	}
+
+	if s.Maximum != nil {
+		if exclusive := s.ExclusiveMaximum != nil && *s.ExclusiveMaximum; v > *s.Maximum || (exclusive && v == *s.Maximum) {
+			return &ValidationError{Path: path, Message: fmt.Sprintf("value must be less than %s%v", orEqual(!exclusive), *s.Maximum)}
+		}
+	}
+
+	if s.MultipleOf != 0 {
+		if q := v / s.MultipleOf; math.Abs(q-math.Round(q)) > 1e-9 {
+			return &ValidationError{Path: path, Message: fmt.Sprintf("value must be a multiple of %v", s.MultipleOf)}
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) validateString(path string, v string) error {
+	length := uint64(utf8.RuneCountInString(v))
+
+	if s.MinLength != nil && length < *s.MinLength {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("length must be at least %d", *s.MinLength)}
+	}
+
+	if s.MaxLength != nil && length > *s.MaxLength {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("length must be at most %d", *s.MaxLength)}
+	}
+
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("%w: invalid pattern %q: %s", ErrSchemaInvalid, s.Pattern, err)
+		}
+
+		if !re.MatchString(v) {
+			return &ValidationError{Path: path, Message: fmt.Sprintf("value must match pattern %s", s.Pattern)}
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) validateArray(path string, v []any) error {
+	length := uint64(len(v))
+
+	if s.MinItems != nil && length < *s.MinItems {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("array must have at least %d items", *s.MinItems)}
+	}
+
+	if s.MaxItems != nil && length > *s.MaxItems {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("array must have at most %d items", *s.MaxItems)}
+	}
+
+	if s.UniqueItems {
+		for i := range v {
+			for j := i + 1; j < len(v); j++ {
+				if reflect.DeepEqual(v[i], v[j]) {
+					return &ValidationError{Path: path, Message: "array items must be unique"}
+				}
+			}
+		}
+	}
+
+	for i, item := range v {
+		if err := s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) validateObject(path string, v map[string]any) error {
+	length := uint64(len(v))
+
+	if s.MinProperties != nil && length < *s.MinProperties {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("object must have at least %d properties", *s.MinProperties)}
+	}
+
+	if s.MaxProperties != nil && length > *s.MaxProperties {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("object must have at most %d properties", *s.MaxProperties)}
+	}
+
+	missing := []string{}
+
+	for _, name := range s.Required {
+		if _, ok := v[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("missing required properties: %s", strings.Join(missing, ", "))}
+	}
+
+	// Validate the properties in a stable order, so the first error is deterministic.
+	names := make([]string, 0, len(v))
+	for name := range v {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		propertyPath := name
+		if path != "" {
+			propertyPath = fmt.Sprintf("%s.%s", path, name)
+		}
+
+		if property, ok := s.Properties[name]; ok {
+			if err := property.validate(propertyPath, v[name]); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		matched, err := s.validatePatternProperties(propertyPath, name, v[name])
+		if err != nil {
+			return err
+		}
+
+		if matched {
+			continue
+		}
+
+		switch additional := s.AdditionalProperties.(type) {
+		case bool:
+			if !additional {
+				return &ValidationError{Path: path, Message: fmt.Sprintf("additional property %s is not allowed", name)}
+			}
+		case *Schema:
+			if err := additional.validate(propertyPath, v[name]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) validatePatternProperties(path, name string, value any) (bool, error) {
+	matched := false
+
+	for pattern, property := range s.PatternProperties {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false, fmt.Errorf("%w: invalid pattern %q: %s", ErrSchemaInvalid, pattern, err)
+		}
+
+		if re.MatchString(name) {
+			matched = true
+
+			if err := property.validate(path, value); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	return matched, nil
+}
+
+// hasType checks if the decoded JSON value is of the JSON schema type.
+func hasType(value any, t string) bool {
+	switch t {
+	case TypeBoolean:
+		_, ok := value.(bool)
+		return ok
+	case TypeInteger:
+		f, ok := value.(float64)
+		return ok && f == math.Trunc(f)
+	case TypeNumber:
+		_, ok := value.(float64)
+		return ok
+	case TypeString:
+		_, ok := value.(string)
+		return ok
+	case TypeArray:
+		_, ok := value.([]any)
+		return ok
+	case TypeObject:
+		_, ok := value.(map[string]any)
+		return ok
+	case "null":
+		return value == nil
+	default:
+		return true
+	}
+}
+
+// typeOf returns the JSON schema type of the decoded JSON value.
+func typeOf(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return TypeBoolean
+	case float64:
+		return TypeNumber
+	case string:
+		return TypeString
+	case []any:
+		return TypeArray
+	case map[string]any:
+		return TypeObject
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// containsValue checks if the enum contains the decoded JSON value. The enum values are compared by
+// their JSON encoding, so numbers of different Go types are equal.
+func containsValue(enum []any, value any) bool {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+
+	for _, e := range enum {
+		if eb, err := json.Marshal(e); err == nil && string(eb) == string(b) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// formatValues formats the enum values for an error message.
+func formatValues(values []any) string {
+	formatted := make([]string, len(values))
+
+	for i, v := range values {
+		b, err := json.Marshal(v)
+		if err != nil {
+			formatted[i] = fmt.Sprint(v)
+			continue
+		}
+
+		formatted[i] = string(b)
+	}
+
+	return strings.Join(formatted, ", ")
+}
+
+// countValid returns the number of schemas the value conforms to.
+func countValid(schemas []*Schema, path string, value any) int {
+	n := 0
+
+	for _, sub := range schemas {
+		if sub.validate(path, value) == nil {
+			n++
+		}
+	}
+
+	return n
+}
+
+// orEqual returns the "or equal to " phrase of an inclusive bound.
+func orEqual(inclusive bool) string {
+	if inclusive {
+		return "or equal to "
+	}
+
+	return ""
+}
@@ -0,0 +1,104 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	type Address struct {
+		City string `json:"city" minLength:"1"`
+	}
+
+	type Person struct {
+		Name    string   `json:"name"`
+		Age     int      `json:"age" minimum:"0" maximum:"150"`
+		Role    string   `json:"role" enum:"admin,user"`
+		Tags    []string `json:"tags,omitempty" maxItems:"2"`
+		Address Address  `json:"address"`
+		Email   string   `json:"email,omitempty" pattern:"^[^@]+@[^@]+$"`
+	}
+
+	schema, err := Generate(reflect.TypeOf(Person{}))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "Valid",
+			input: `{"name": "Max", "age": 21, "role": "user", "tags": ["a", "b"], "address": {"city": "Berlin"}, "email": "max@example.com"}`,
+		},
+		{
+			name:  "MissingRequired",
+			input: `{"name": "Max", "role": "user", "address": {"city": "Berlin"}}`,
+			err:   "missing required properties: age",
+		},
+		{
+			name:  "WrongType",
+			input: `{"name": "Max", "age": "21", "role": "user", "address": {"city": "Berlin"}}`,
+			err:   "age: expected integer, got string",
+		},
+		{
+			name:  "NotAnInteger",
+			input: `{"name": "Max", "age": 21.5, "role": "user", "address": {"city": "Berlin"}}`,
+			err:   "age: expected integer, got number",
+		},
+		{
+			name:  "Maximum",
+			input: `{"name": "Max", "age": 200, "role": "user", "address": {"city": "Berlin"}}`,
+			err:   "age: value must be less than or equal to 150",
+		},
+		{
+			name:  "Enum",
+			input: `{"name": "Max", "age": 21, "role": "guest", "address": {"city": "Berlin"}}`,
+			err:   `role: value must be one of "admin", "user"`,
+		},
+		{
+			name:  "MaxItems",
+			input: `{"name": "Max", "age": 21, "role": "user", "tags": ["a", "b", "c"], "address": {"city": "Berlin"}}`,
+			err:   "tags: array must have at most 2 items",
+		},
+		{
+			name:  "Nested",
+			input: `{"name": "Max", "age": 21, "role": "user", "address": {"city": ""}}`,
+			err:   "address.city: length must be at least 1",
+		},
+		{
+			name:  "Pattern",
+			input: `{"name": "Max", "age": 21, "role": "user", "address": {"city": "Berlin"}, "email": "max"}`,
+			err:   "email: value must match pattern ^[^@]+@[^@]+$",
+		},
+		{
+			name:  "AdditionalProperty",
+			input: `{"name": "Max", "age": 21, "role": "user", "address": {"city": "Berlin"}, "foo": "bar"}`,
+			err:   "additional property foo is not allowed",
+		},
+		{
+			name:  "NotAnObject",
+			input: `["Max"]`,
+			err:   "expected object, got array",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var value any
+			require.NoError(t, json.Unmarshal([]byte(tc.input), &value))
+
+			err := schema.Validate(value)
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
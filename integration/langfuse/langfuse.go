@@ -0,0 +1,101 @@
+// Package langfuse provides a client for the ingestion API of Langfuse.
+package langfuse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DefaultAPIUrl is the url of Langfuse cloud.
+const DefaultAPIUrl = "https://cloud.langfuse.com"
+
+// HTTPClient is an interface for making HTTP requests.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// ClientOptions contains options for configuring the Langfuse client.
+type ClientOptions struct {
+	// The HTTP client to use for making API requests.
+	HTTPClient HTTPClient
+	// APIUrl is the url of the Langfuse server.
+	APIUrl string
+}
+
+// Client is a client for the Langfuse API.
+type Client struct {
+	publicKey string
+	secretKey string
+	opts      ClientOptions
+}
+
+// New creates a new Langfuse client authenticating with the public and secret key of a project.
+func New(publicKey, secretKey string, optFns ...func(o *ClientOptions)) *Client {
+	opts := ClientOptions{
+		HTTPClient: http.DefaultClient,
+		APIUrl:     DefaultAPIUrl,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	opts.APIUrl = strings.TrimSuffix(opts.APIUrl, "/")
+
+	return &Client{
+		publicKey: publicKey,
+		secretKey: secretKey,
+		opts:      opts,
+	}
+}
+
+// Ingest sends a batch of events to the ingestion endpoint. Events that could not be ingested
+// are reported in the errors of the response.
+func (c *Client) Ingest(ctx context.Context, req *IngestionRequest) (*IngestionResponse, error) {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/api/public/ingestion", c.opts.APIUrl), bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.SetBasicAuth(c.publicKey, c.secretKey)
+
+	res, err := c.opts.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// The endpoint responds with 207 Multi-Status if the batch was processed.
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusMultiStatus {
+		errorResponse := ErrorResponse{}
+		if err := json.Unmarshal(resBody, &errorResponse); err != nil || errorResponse.Message == "" {
+			return nil, fmt.Errorf("langfuse API error: unexpected status code %d: %s", res.StatusCode, string(resBody))
+		}
+
+		return nil, fmt.Errorf("langfuse API error: %s", errorResponse.Message)
+	}
+
+	response := IngestionResponse{}
+	if err := json.Unmarshal(resBody, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
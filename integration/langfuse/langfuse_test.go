@@ -0,0 +1,64 @@
+package langfuse
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	t.Run("Ingest", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/public/ingestion", r.URL.Path)
+
+			user, password, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "pk", user)
+			assert.Equal(t, "sk", password)
+
+			req := map[string][]map[string]any{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "trace-create", req["batch"][0]["type"])
+			assert.Equal(t, "trace-1", req["batch"][0]["body"].(map[string]any)["id"])
+
+			w.WriteHeader(http.StatusMultiStatus)
+			_, _ = w.Write([]byte(`{"successes":[{"id":"event-1","status":201}],"errors":[]}`))
+		}))
+		defer server.Close()
+
+		client := New("pk", "sk", func(o *ClientOptions) {
+			o.APIUrl = server.URL
+		})
+
+		res, err := client.Ingest(context.Background(), &IngestionRequest{
+			Batch: []Event{{
+				ID:        "event-1",
+				Type:      EventTypeTraceCreate,
+				Timestamp: time.Now(),
+				Body:      Trace{ID: "trace-1", Name: "test"},
+			}},
+		})
+		require.NoError(t, err)
+		assert.Len(t, res.Successes, 1)
+		assert.Empty(t, res.Errors)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"Invalid credentials"}`))
+		}))
+		defer server.Close()
+
+		_, err := New("pk", "sk", func(o *ClientOptions) {
+			o.APIUrl = server.URL
+		}).Ingest(context.Background(), &IngestionRequest{})
+		assert.EqualError(t, err, "langfuse API error: Invalid credentials")
+	})
+}
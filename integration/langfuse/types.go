@@ -0,0 +1,109 @@
+package langfuse
+
+import "time"
+
+// EventType is the type of an ingestion event.
+type EventType string
+
+const (
+	EventTypeTraceCreate      EventType = "trace-create"
+	EventTypeSpanCreate       EventType = "span-create"
+	EventTypeGenerationCreate EventType = "generation-create"
+)
+
+// Event represents a single event of an ingestion batch.
+type Event struct {
+	// ID is the unique id of the event, used for deduplication.
+	ID string `json:"id"`
+	// Type is the type of the event.
+	Type EventType `json:"type"`
+	// Timestamp is the time the event was created.
+	Timestamp time.Time `json:"timestamp"`
+	// Body is the body of the event, e.g. a Trace, Span or Generation.
+	Body any `json:"body"`
+}
+
+// Trace represents the body of a trace-create event.
+type Trace struct {
+	ID        string         `json:"id"`
+	Name      string         `json:"name,omitempty"`
+	Timestamp *time.Time     `json:"timestamp,omitempty"`
+	Input     any            `json:"input,omitempty"`
+	Output    any            `json:"output,omitempty"`
+	SessionID string         `json:"sessionId,omitempty"`
+	UserID    string         `json:"userId,omitempty"`
+	Release   string         `json:"release,omitempty"`
+	Tags      []string       `json:"tags,omitempty"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+}
+
+// Level is the level of an observation.
+type Level string
+
+const (
+	LevelDebug   Level = "DEBUG"
+	LevelDefault Level = "DEFAULT"
+	LevelWarning Level = "WARNING"
+	LevelError   Level = "ERROR"
+)
+
+// Span represents the body of a span-create event.
+type Span struct {
+	ID                  string         `json:"id"`
+	TraceID             string         `json:"traceId"`
+	ParentObservationID string         `json:"parentObservationId,omitempty"`
+	Name                string         `json:"name,omitempty"`
+	StartTime           *time.Time     `json:"startTime,omitempty"`
+	EndTime             *time.Time     `json:"endTime,omitempty"`
+	Input               any            `json:"input,omitempty"`
+	Output              any            `json:"output,omitempty"`
+	Level               Level          `json:"level,omitempty"`
+	StatusMessage       string         `json:"statusMessage,omitempty"`
+	Metadata            map[string]any `json:"metadata,omitempty"`
+}
+
+// Usage represents the token usage of a generation.
+type Usage struct {
+	Input  int    `json:"input"`
+	Output int    `json:"output"`
+	Total  int    `json:"total"`
+	Unit   string `json:"unit,omitempty"`
+}
+
+// Generation represents the body of a generation-create event.
+type Generation struct {
+	Span
+	Model           string         `json:"model,omitempty"`
+	ModelParameters map[string]any `json:"modelParameters,omitempty"`
+	Usage           *Usage         `json:"usage,omitempty"`
+}
+
+// IngestionRequest represents a batch of ingestion events.
+type IngestionRequest struct {
+	Batch []Event `json:"batch"`
+}
+
+// IngestionSuccess represents a successfully ingested event.
+type IngestionSuccess struct {
+	ID     string `json:"id"`
+	Status int    `json:"status"`
+}
+
+// IngestionError represents an event that could not be ingested.
+type IngestionError struct {
+	ID      string `json:"id"`
+	Status  int    `json:"status"`
+	Message string `json:"message,omitempty"`
+	Error   any    `json:"error,omitempty"`
+}
+
+// IngestionResponse represents the response of the ingestion endpoint.
+type IngestionResponse struct {
+	Successes []IngestionSuccess `json:"successes"`
+	Errors    []IngestionError   `json:"errors"`
+}
+
+// ErrorResponse represents an error response of the Langfuse API.
+type ErrorResponse struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,92 @@
+// Package langsmith provides a client for the run ingestion API of LangSmith and compatible servers.
+package langsmith
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DefaultAPIUrl is the url of the LangSmith API.
+const DefaultAPIUrl = "https://api.smith.langchain.com"
+
+// HTTPClient is an interface for making HTTP requests.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// ClientOptions contains options for configuring the LangSmith client.
+type ClientOptions struct {
+	// The HTTP client to use for making API requests.
+	HTTPClient HTTPClient
+	// APIUrl is the url of the LangSmith API.
+	APIUrl string
+}
+
+// Client is a client for the LangSmith API.
+type Client struct {
+	apiKey string
+	opts   ClientOptions
+}
+
+// New creates a new LangSmith client.
+func New(apiKey string, optFns ...func(o *ClientOptions)) *Client {
+	opts := ClientOptions{
+		HTTPClient: http.DefaultClient,
+		APIUrl:     DefaultAPIUrl,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	opts.APIUrl = strings.TrimSuffix(opts.APIUrl, "/")
+
+	return &Client{
+		apiKey: apiKey,
+		opts:   opts,
+	}
+}
+
+// BatchIngestRuns creates and updates the runs of the request.
+func (c *Client) BatchIngestRuns(ctx context.Context, req *BatchIngestRunsRequest) error {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/runs/batch", c.opts.APIUrl), bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("x-api-key", c.apiKey)
+
+	res, err := c.opts.HTTPClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		resBody, err := io.ReadAll(res.Body)
+		if err != nil {
+			return err
+		}
+
+		errorResponse := ErrorResponse{}
+		if err := json.Unmarshal(resBody, &errorResponse); err != nil || errorResponse.Detail == "" {
+			return fmt.Errorf("langsmith API error: unexpected status code %d: %s", res.StatusCode, string(resBody))
+		}
+
+		return fmt.Errorf("langsmith API error: %s", errorResponse.Detail)
+	}
+
+	return nil
+}
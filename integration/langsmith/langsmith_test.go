@@ -0,0 +1,51 @@
+package langsmith
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient(t *testing.T) {
+	t.Run("BatchIngestRuns", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/runs/batch", r.URL.Path)
+			assert.Equal(t, "key", r.Header.Get("x-api-key"))
+
+			req := BatchIngestRunsRequest{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Len(t, req.Post, 1)
+			assert.Equal(t, RunTypeChain, req.Post[0].RunType)
+
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		client := New("key", func(o *ClientOptions) {
+			o.APIUrl = server.URL
+		})
+
+		err := client.BatchIngestRuns(context.Background(), &BatchIngestRunsRequest{
+			Post: []*Run{{ID: "run-1", TraceID: "run-1", Name: "LLM", RunType: RunTypeChain, StartTime: time.Now()}},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"detail":"Forbidden"}`))
+		}))
+		defer server.Close()
+
+		err := New("key", func(o *ClientOptions) {
+			o.APIUrl = server.URL
+		}).BatchIngestRuns(context.Background(), &BatchIngestRunsRequest{})
+		assert.EqualError(t, err, "langsmith API error: Forbidden")
+	})
+}
@@ -0,0 +1,43 @@
+package langsmith
+
+import "time"
+
+// RunType is the type of a run.
+type RunType string
+
+const (
+	RunTypeChain     RunType = "chain"
+	RunTypeLLM       RunType = "llm"
+	RunTypeTool      RunType = "tool"
+	RunTypeRetriever RunType = "retriever"
+	RunTypeEmbedding RunType = "embedding"
+)
+
+// Run represents a run of the run tree of a trace.
+type Run struct {
+	ID          string         `json:"id"`
+	TraceID     string         `json:"trace_id"`
+	DottedOrder string         `json:"dotted_order"`
+	ParentRunID string         `json:"parent_run_id,omitempty"`
+	Name        string         `json:"name"`
+	RunType     RunType        `json:"run_type"`
+	StartTime   time.Time      `json:"start_time"`
+	EndTime     *time.Time     `json:"end_time,omitempty"`
+	Inputs      map[string]any `json:"inputs"`
+	Outputs     map[string]any `json:"outputs,omitempty"`
+	Error       string         `json:"error,omitempty"`
+	SessionName string         `json:"session_name,omitempty"`
+	Extra       map[string]any `json:"extra,omitempty"`
+	Tags        []string       `json:"tags,omitempty"`
+}
+
+// BatchIngestRunsRequest represents a request to create and update runs in a single batch.
+type BatchIngestRunsRequest struct {
+	Post  []*Run `json:"post,omitempty"`
+	Patch []*Run `json:"patch,omitempty"`
+}
+
+// ErrorResponse represents an error response of the LangSmith API.
+type ErrorResponse struct {
+	Detail string `json:"detail"`
+}
@@ -0,0 +1,336 @@
+// Package mcp provides a client for the Model Context Protocol (MCP), which connects to MCP servers
+// to discover and call their tools and to read their resources.
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrClosed is returned, if the connection to the server is closed.
+var ErrClosed = errors.New("mcp: connection closed")
+
+// DefaultProtocolVersion is the MCP protocol version requested by the client.
+const DefaultProtocolVersion = "2024-11-05"
+
+// Transport transports JSON-RPC messages between the client and an MCP server.
+type Transport interface {
+	// Start connects to the server.
+	Start(ctx context.Context) error
+	// Send sends a message to the server.
+	Send(ctx context.Context, msg []byte) error
+	// Receive blocks until the next message from the server is received. It returns io.EOF, if the
+	// connection is closed. Receive is never called concurrently.
+	Receive() ([]byte, error)
+	// Close closes the connection to the server.
+	Close() error
+}
+
+// ClientOptions contains options for configuring the MCP client.
+type ClientOptions struct {
+	// ClientInfo is the name and version of the client, which is sent to the server.
+	ClientInfo Implementation
+	// ProtocolVersion is the MCP protocol version requested by the client.
+	ProtocolVersion string
+}
+
+// Client is a client for an MCP server. It is safe for concurrent use.
+type Client struct {
+	transport  Transport
+	opts       ClientOptions
+	initResult *InitializeResult
+	nextID     atomic.Int64
+	mu         sync.Mutex
+	pending    map[int64]chan *message
+	err        error
+	done       chan struct{}
+}
+
+// Connect starts the transport and initializes an MCP session with the server.
+func Connect(ctx context.Context, transport Transport, optFns ...func(o *ClientOptions)) (*Client, error) {
+	opts := ClientOptions{
+		ClientInfo: Implementation{
+			Name:    "golc",
+			Version: "1.0.0",
+		},
+		ProtocolVersion: DefaultProtocolVersion,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if err := transport.Start(ctx); err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		transport: transport,
+		opts:      opts,
+		pending:   make(map[int64]chan *message),
+		done:      make(chan struct{}),
+	}
+
+	go c.receiveLoop()
+
+	if err := c.initialize(ctx); err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// ServerInfo returns the name and version of the server.
+func (c *Client) ServerInfo() Implementation {
+	return c.initResult.ServerInfo
+}
+
+// Capabilities returns the capabilities of the server.
+func (c *Client) Capabilities() ServerCapabilities {
+	return c.initResult.Capabilities
+}
+
+// Instructions returns the instructions of the server on how to use its features, if any.
+func (c *Client) Instructions() string {
+	return c.initResult.Instructions
+}
+
+// ListTools returns all tools of the server.
+func (c *Client) ListTools(ctx context.Context) ([]Tool, error) {
+	tools := []Tool{}
+	cursor := ""
+
+	for {
+		result := listToolsResult{}
+		if err := c.call(ctx, "tools/list", paginatedParams{Cursor: cursor}, &result); err != nil {
+			return nil, err
+		}
+
+		tools = append(tools, result.Tools...)
+
+		if result.NextCursor == "" {
+			return tools, nil
+		}
+
+		cursor = result.NextCursor
+	}
+}
+
+// CallTool calls the tool with the given arguments.
+func (c *Client) CallTool(ctx context.Context, name string, arguments map[string]any) (*CallToolResult, error) {
+	result := CallToolResult{}
+	if err := c.call(ctx, "tools/call", callToolParams{Name: name, Arguments: arguments}, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListResources returns all resources of the server.
+func (c *Client) ListResources(ctx context.Context) ([]Resource, error) {
+	resources := []Resource{}
+	cursor := ""
+
+	for {
+		result := listResourcesResult{}
+		if err := c.call(ctx, "resources/list", paginatedParams{Cursor: cursor}, &result); err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, result.Resources...)
+
+		if result.NextCursor == "" {
+			return resources, nil
+		}
+
+		cursor = result.NextCursor
+	}
+}
+
+// ReadResource reads the resource with the given uri.
+func (c *Client) ReadResource(ctx context.Context, uri string) (*ReadResourceResult, error) {
+	result := ReadResourceResult{}
+	if err := c.call(ctx, "resources/read", readResourceParams{URI: uri}, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// Ping checks whether the server is alive.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.call(ctx, "ping", nil, nil)
+}
+
+// Close closes the connection to the server.
+func (c *Client) Close() error {
+	err := c.transport.Close()
+
+	<-c.done
+
+	return err
+}
+
+// initialize performs the initialization handshake.
+func (c *Client) initialize(ctx context.Context) error {
+	result := InitializeResult{}
+	if err := c.call(ctx, "initialize", initializeParams{
+		ProtocolVersion: c.opts.ProtocolVersion,
+		ClientInfo:      c.opts.ClientInfo,
+	}, &result); err != nil {
+		return err
+	}
+
+	c.initResult = &result
+
+	return c.notify(ctx, "notifications/initialized", nil)
+}
+
+// call sends a request to the server and waits for the response. The result is decoded into result,
+// if it is not nil.
+func (c *Client) call(ctx context.Context, method string, params any, result any) error {
+	id := c.nextID.Add(1)
+	ch := make(chan *message, 1)
+
+	c.mu.Lock()
+
+	if c.err != nil {
+		c.mu.Unlock()
+		return c.err
+	}
+
+	c.pending[id] = ch
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+	}()
+
+	if err := c.send(ctx, request{JSONRPC: jsonRPCVersion, ID: &id, Method: method, Params: params}); err != nil {
+		return err
+	}
+
+	select {
+	case msg, ok := <-ch:
+		if !ok {
+			return c.err
+		}
+
+		if msg.Error != nil {
+			return msg.Error
+		}
+
+		if result == nil {
+			return nil
+		}
+
+		return json.Unmarshal(msg.Result, result)
+	case <-ctx.Done():
+		// Inform the server on a best effort basis, that the result is no longer needed.
+		_ = c.notify(context.Background(), "notifications/cancelled", map[string]any{"requestId": id})
+
+		return ctx.Err()
+	}
+}
+
+// notify sends a notification, which has no response, to the server.
+func (c *Client) notify(ctx context.Context, method string, params any) error {
+	return c.send(ctx, request{JSONRPC: jsonRPCVersion, Method: method, Params: params})
+}
+
+// send encodes and sends the value to the server.
+func (c *Client) send(ctx context.Context, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return c.transport.Send(ctx, b)
+}
+
+// receiveLoop receives the messages from the server, until the connection is closed.
+func (c *Client) receiveLoop() {
+	defer close(c.done)
+
+	for {
+		b, err := c.transport.Receive()
+		if err != nil {
+			c.closePending(err)
+			return
+		}
+
+		msg := message{}
+		if err := json.Unmarshal(b, &msg); err != nil {
+			// Skip invalid messages, e.g. log output of stdio servers.
+			continue
+		}
+
+		switch {
+		case msg.Method != "" && msg.ID != nil:
+			c.handleRequest(&msg)
+		case msg.Method != "":
+			// Notifications of the server are not supported.
+		case msg.ID != nil:
+			c.handleResponse(&msg)
+		}
+	}
+}
+
+// handleRequest answers requests of the server. Only ping is supported.
+func (c *Client) handleRequest(msg *message) {
+	res := response{JSONRPC: jsonRPCVersion, ID: msg.ID}
+
+	if msg.Method == "ping" {
+		res.Result = struct{}{}
+	} else {
+		res.Error = &RPCError{Code: ErrorCodeMethodNotFound, Message: fmt.Sprintf("method not found: %s", msg.Method)}
+	}
+
+	_ = c.send(context.Background(), res)
+}
+
+// handleResponse passes the response to the pending request.
+func (c *Client) handleResponse(msg *message) {
+	id, err := strconv.ParseInt(string(*msg.ID), 10, 64)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	ch, ok := c.pending[id]
+	c.mu.Unlock()
+
+	if ok {
+		select {
+		case ch <- msg:
+		default:
+			// Skip duplicate responses.
+		}
+	}
+}
+
+// closePending fails all pending and future requests.
+func (c *Client) closePending(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if errors.Is(err, io.EOF) {
+		c.err = ErrClosed
+	} else {
+		c.err = fmt.Errorf("%w: %s", ErrClosed, err)
+	}
+
+	for id, ch := range c.pending {
+		close(ch)
+		delete(c.pending, id)
+	}
+}
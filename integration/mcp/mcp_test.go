@@ -0,0 +1,245 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// handleTestRequest implements a minimal MCP server for the tests of the transports.
+func handleTestRequest(method string, params json.RawMessage) (any, *RPCError) {
+	switch method {
+	case "initialize":
+		return InitializeResult{
+			ProtocolVersion: DefaultProtocolVersion,
+			Capabilities:    ServerCapabilities{Tools: &struct{}{}, Resources: &struct{}{}},
+			ServerInfo:      Implementation{Name: "test", Version: "0.1.0"},
+		}, nil
+	case "ping":
+		return struct{}{}, nil
+	case "tools/list":
+		p := paginatedParams{}
+		_ = json.Unmarshal(params, &p)
+
+		if p.Cursor == "" {
+			return listToolsResult{
+				Tools:      []Tool{{Name: "echo", Description: "Echoes the text.", InputSchema: json.RawMessage(`{"type":"object","properties":{"text":{"type":"string"}},"required":["text"]}`)}},
+				NextCursor: "2",
+			}, nil
+		}
+
+		return listToolsResult{Tools: []Tool{{Name: "fail", InputSchema: json.RawMessage(`{"type":"object"}`)}}}, nil
+	case "tools/call":
+		p := callToolParams{}
+		_ = json.Unmarshal(params, &p)
+
+		switch p.Name {
+		case "echo":
+			return CallToolResult{Content: []Content{{Type: "text", Text: p.Arguments["text"].(string)}}}, nil
+		case "fail":
+			return CallToolResult{Content: []Content{{Type: "text", Text: "tool failed"}}, IsError: true}, nil
+		default:
+			return nil, &RPCError{Code: -32602, Message: "unknown tool: " + p.Name}
+		}
+	case "resources/list":
+		return listResourcesResult{Resources: []Resource{{URI: "file:///readme.md", Name: "readme"}}}, nil
+	case "resources/read":
+		p := readResourceParams{}
+		_ = json.Unmarshal(params, &p)
+
+		return ReadResourceResult{Contents: []ResourceContents{{URI: p.URI, Text: "Hello"}}}, nil
+	default:
+		return nil, &RPCError{Code: ErrorCodeMethodNotFound, Message: "method not found"}
+	}
+}
+
+// handleTestMessage handles the message of the client and returns the encoded response, if any.
+func handleTestMessage(msg []byte) []byte {
+	req := struct {
+		ID     *json.RawMessage `json:"id"`
+		Method string           `json:"method"`
+		Params json.RawMessage  `json:"params"`
+	}{}
+
+	if err := json.Unmarshal(msg, &req); err != nil || req.ID == nil || req.Method == "" {
+		return nil
+	}
+
+	result, rpcErr := handleTestRequest(req.Method, req.Params)
+
+	b, _ := json.Marshal(response{JSONRPC: jsonRPCVersion, ID: req.ID, Result: result, Error: rpcErr})
+
+	return b
+}
+
+// memoryTransport is an in-memory transport to a test server.
+type memoryTransport struct {
+	mu       sync.Mutex
+	sent     [][]byte
+	incoming chan []byte
+	closed   chan struct{}
+	once     sync.Once
+	silent   bool
+}
+
+func newMemoryTransport() *memoryTransport {
+	return &memoryTransport{
+		incoming: make(chan []byte, 10),
+		closed:   make(chan struct{}),
+	}
+}
+
+func (t *memoryTransport) Start(ctx context.Context) error {
+	return nil
+}
+
+func (t *memoryTransport) Send(ctx context.Context, msg []byte) error {
+	t.mu.Lock()
+	t.sent = append(t.sent, msg)
+	silent := t.silent
+	t.mu.Unlock()
+
+	if res := handleTestMessage(msg); res != nil && !silent {
+		t.incoming <- res
+	}
+
+	return nil
+}
+
+func (t *memoryTransport) Receive() ([]byte, error) {
+	select {
+	case msg := <-t.incoming:
+		return msg, nil
+	case <-t.closed:
+		return nil, io.EOF
+	}
+}
+
+func (t *memoryTransport) Close() error {
+	t.once.Do(func() { close(t.closed) })
+	return nil
+}
+
+func (t *memoryTransport) sentMethods() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	methods := []string{}
+
+	for _, msg := range t.sent {
+		m := struct {
+			Method string `json:"method"`
+		}{}
+		_ = json.Unmarshal(msg, &m)
+
+		methods = append(methods, m.Method)
+	}
+
+	return methods
+}
+
+func TestClient(t *testing.T) {
+	t.Run("Connect", func(t *testing.T) {
+		transport := newMemoryTransport()
+
+		client, err := Connect(context.Background(), transport)
+		require.NoError(t, err)
+
+		defer client.Close()
+
+		assert.Equal(t, Implementation{Name: "test", Version: "0.1.0"}, client.ServerInfo())
+		assert.NotNil(t, client.Capabilities().Tools)
+		assert.Nil(t, client.Capabilities().Prompts)
+		assert.Equal(t, []string{"initialize", "notifications/initialized"}, transport.sentMethods())
+		assert.NoError(t, client.Ping(context.Background()))
+	})
+
+	t.Run("Tools", func(t *testing.T) {
+		client, err := Connect(context.Background(), newMemoryTransport())
+		require.NoError(t, err)
+
+		defer client.Close()
+
+		tools, err := client.ListTools(context.Background())
+		require.NoError(t, err)
+		require.Len(t, tools, 2)
+		assert.Equal(t, "echo", tools[0].Name)
+		assert.Equal(t, "fail", tools[1].Name)
+
+		result, err := client.CallTool(context.Background(), "echo", map[string]any{"text": "hello"})
+		require.NoError(t, err)
+		assert.Equal(t, &CallToolResult{Content: []Content{{Type: "text", Text: "hello"}}}, result)
+
+		_, err = client.CallTool(context.Background(), "unknown", nil)
+		assert.EqualError(t, err, "mcp error -32602: unknown tool: unknown")
+	})
+
+	t.Run("Resources", func(t *testing.T) {
+		client, err := Connect(context.Background(), newMemoryTransport())
+		require.NoError(t, err)
+
+		defer client.Close()
+
+		resources, err := client.ListResources(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []Resource{{URI: "file:///readme.md", Name: "readme"}}, resources)
+
+		result, err := client.ReadResource(context.Background(), "file:///readme.md")
+		require.NoError(t, err)
+		assert.Equal(t, "Hello", result.Contents[0].Text)
+	})
+
+	t.Run("ServerRequest", func(t *testing.T) {
+		transport := newMemoryTransport()
+
+		client, err := Connect(context.Background(), transport)
+		require.NoError(t, err)
+
+		defer client.Close()
+
+		transport.incoming <- []byte(`{"jsonrpc":"2.0","id":"srv-1","method":"ping"}`)
+
+		assert.Eventually(t, func() bool {
+			transport.mu.Lock()
+			defer transport.mu.Unlock()
+
+			return string(transport.sent[len(transport.sent)-1]) == `{"jsonrpc":"2.0","id":"srv-1","result":{}}`
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		transport := newMemoryTransport()
+
+		client, err := Connect(context.Background(), transport)
+		require.NoError(t, err)
+
+		defer client.Close()
+
+		transport.mu.Lock()
+		transport.silent = true
+		transport.mu.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err = client.Ping(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, "notifications/cancelled", transport.sentMethods()[3])
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		client, err := Connect(context.Background(), newMemoryTransport())
+		require.NoError(t, err)
+
+		require.NoError(t, client.Close())
+
+		err = client.Ping(context.Background())
+		assert.ErrorIs(t, err, ErrClosed)
+	})
+}
@@ -0,0 +1,209 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hupe1980/golc/integration/stream"
+)
+
+// Compile time check to ensure SSETransport satisfies the Transport interface.
+var _ Transport = (*SSETransport)(nil)
+
+// HTTPClient is an interface for making HTTP requests.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// SSETransportOptions contains options for configuring the SSE transport.
+type SSETransportOptions struct {
+	// HTTPClient is the HTTP client to use for making requests. It must not have a timeout, because the
+	// event stream is kept open for the lifetime of the connection.
+	HTTPClient HTTPClient
+	// Headers are sent with every request, e.g. to authenticate the requests.
+	Headers map[string]string
+}
+
+// SSETransport connects to a remote MCP server via HTTP. The server sends its messages as server-sent
+// events (SSE) and announces the endpoint, to which the client posts its messages, as first event.
+type SSETransport struct {
+	url      string
+	opts     SSETransportOptions
+	endpoint string
+	body     io.ReadCloser
+	reader   *stream.EventReader
+	cancel   context.CancelFunc
+}
+
+// NewSSETransport creates a new SSE transport for the url of the event stream of the server, e.g.
+// "http://localhost:8080/sse".
+func NewSSETransport(url string, optFns ...func(o *SSETransportOptions)) *SSETransport {
+	opts := SSETransportOptions{
+		HTTPClient: http.DefaultClient,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &SSETransport{
+		url:  url,
+		opts: opts,
+	}
+}
+
+// Start opens the event stream and waits for the endpoint event.
+func (t *SSETransport) Start(ctx context.Context) error {
+	base, err := url.Parse(t.url)
+	if err != nil {
+		return err
+	}
+
+	// The stream must outlive the context of the start.
+	streamCtx, cancel := context.WithCancel(context.Background())
+
+	stop := context.AfterFunc(ctx, cancel)
+	defer stop()
+
+	req, err := http.NewRequestWithContext(streamCtx, http.MethodGet, t.url, nil)
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	req.Header.Set("Accept", "text/event-stream")
+	t.setHeaders(req)
+
+	res, err := t.opts.HTTPClient.Do(req)
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		cancel()
+
+		return fmt.Errorf("unexpected status code of event stream: %d", res.StatusCode)
+	}
+
+	t.body = res.Body
+	t.reader = stream.NewEventReader(res.Body)
+	t.cancel = cancel
+
+	for {
+		event, data, err := t.readEvent()
+		if err != nil {
+			_ = t.Close()
+
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			return fmt.Errorf("waiting for endpoint event: %w", err)
+		}
+
+		if event != "endpoint" {
+			continue
+		}
+
+		endpoint, err := base.Parse(strings.TrimSpace(data))
+		if err != nil {
+			_ = t.Close()
+			return err
+		}
+
+		// Never post messages, which may contain credentials, to other origins.
+		if endpoint.Scheme != base.Scheme || endpoint.Host != base.Host {
+			_ = t.Close()
+			return fmt.Errorf("endpoint %s has a different origin than %s", endpoint, t.url)
+		}
+
+		t.endpoint = endpoint.String()
+
+		return nil
+	}
+}
+
+// Send posts the message to the endpoint of the server.
+func (t *SSETransport) Send(ctx context.Context, msg []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(msg))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	t.setHeaders(req)
+
+	res, err := t.opts.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("unexpected status code: %d, body: %s", res.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	return nil
+}
+
+// Receive returns the data of the next message event of the stream.
+func (t *SSETransport) Receive() ([]byte, error) {
+	for {
+		event, data, err := t.readEvent()
+		if err != nil {
+			return nil, err
+		}
+
+		if event == "message" {
+			return []byte(data), nil
+		}
+	}
+}
+
+// Close closes the event stream.
+func (t *SSETransport) Close() error {
+	if t.cancel == nil {
+		return nil
+	}
+
+	t.cancel()
+
+	return t.body.Close()
+}
+
+// readEvent reads the next event of the stream. Events without type are message events.
+func (t *SSETransport) readEvent() (string, string, error) {
+	event, err := t.reader.Recv()
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return "", "", io.EOF
+		}
+
+		return "", "", err
+	}
+
+	if event.Type == "" {
+		return "message", event.Data, nil
+	}
+
+	return event.Type, event.Data, nil
+}
+
+// setHeaders sets the configured headers of the request.
+func (t *SSETransport) setHeaders(req *http.Request) {
+	for k, v := range t.opts.Headers {
+		req.Header.Set(k, v)
+	}
+}
@@ -0,0 +1,102 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSSEServer(t *testing.T, endpoint string) *httptest.Server {
+	t.Helper()
+
+	responses := make(chan []byte, 10)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/sse", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, ": comment\n\nevent: endpoint\ndata: %s\n\n", endpoint)
+		w.(http.Flusher).Flush()
+
+		for {
+			select {
+			case res := <-responses:
+				fmt.Fprintf(w, "event: message\ndata: %s\n\n", res)
+				w.(http.Flusher).Flush()
+			case <-r.Context().Done():
+				return
+			}
+		}
+	})
+
+	mux.HandleFunc("/messages", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Query().Get("session") != "1" || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+
+		if res := handleTestMessage(body); res != nil {
+			responses <- res
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestSSETransport(t *testing.T) {
+	t.Parallel()
+
+	withAuth := func(o *SSETransportOptions) {
+		o.Headers = map[string]string{"Authorization": "Bearer token"}
+	}
+
+	t.Run("Connect", func(t *testing.T) {
+		server := newTestSSEServer(t, "/messages?session=1")
+		defer server.Close()
+
+		client, err := Connect(context.Background(), NewSSETransport(server.URL+"/sse", withAuth))
+		require.NoError(t, err)
+
+		assert.Equal(t, "test", client.ServerInfo().Name)
+
+		tools, err := client.ListTools(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, tools, 2)
+
+		require.NoError(t, client.Close())
+
+		err = client.Ping(context.Background())
+		assert.ErrorIs(t, err, ErrClosed)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		server := newTestSSEServer(t, "/messages?session=1")
+		defer server.Close()
+
+		_, err := Connect(context.Background(), NewSSETransport(server.URL+"/sse"))
+		assert.EqualError(t, err, "unexpected status code of event stream: 401")
+	})
+
+	t.Run("DifferentOrigin", func(t *testing.T) {
+		server := newTestSSEServer(t, "https://example.com/messages?session=1")
+		defer server.Close()
+
+		_, err := Connect(context.Background(), NewSSETransport(server.URL+"/sse", withAuth))
+		assert.ErrorContains(t, err, "has a different origin")
+	})
+}
@@ -0,0 +1,143 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// Compile time check to ensure StdioTransport satisfies the Transport interface.
+var _ Transport = (*StdioTransport)(nil)
+
+// StdioTransportOptions contains options for configuring the stdio transport.
+type StdioTransportOptions struct {
+	// Env is appended to the environment of the current process, e.g. to pass API keys to the server.
+	Env []string
+	// Dir is the working directory of the server.
+	Dir string
+	// Stderr receives the log output of the server. If nil, the output is discarded.
+	Stderr io.Writer
+	// CloseTimeout is the time the server has to exit after its stdin is closed, before it is killed.
+	CloseTimeout time.Duration
+}
+
+// StdioTransport starts an MCP server as subprocess and exchanges newline-delimited JSON-RPC
+// messages via its stdin and stdout.
+type StdioTransport struct {
+	command string
+	args    []string
+	opts    StdioTransportOptions
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	stdout  *bufio.Reader
+	mu      sync.Mutex
+}
+
+// NewStdioTransport creates a new stdio transport for the server command.
+func NewStdioTransport(command string, args []string, optFns ...func(o *StdioTransportOptions)) *StdioTransport {
+	opts := StdioTransportOptions{
+		CloseTimeout: 5 * time.Second,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &StdioTransport{
+		command: command,
+		args:    args,
+		opts:    opts,
+	}
+}
+
+// Start starts the server process.
+func (t *StdioTransport) Start(ctx context.Context) error {
+	// The process must outlive the context of the start.
+	cmd := exec.Command(t.command, t.args...) // nolint gosec
+	cmd.Env = append(os.Environ(), t.opts.Env...)
+	cmd.Dir = t.opts.Dir
+	cmd.Stderr = t.opts.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	t.cmd = cmd
+	t.stdin = stdin
+	t.stdout = bufio.NewReader(stdout)
+
+	return nil
+}
+
+// Send writes the message as line to the stdin of the server.
+func (t *StdioTransport) Send(ctx context.Context, msg []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	_, err := t.stdin.Write(append(msg, '\n'))
+
+	return err
+}
+
+// Receive reads the next line from the stdout of the server.
+func (t *StdioTransport) Receive() ([]byte, error) {
+	for {
+		line, err := t.stdout.ReadBytes('\n')
+
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			return line, nil
+		}
+
+		if err != nil {
+			if errors.Is(err, os.ErrClosed) {
+				return nil, io.EOF
+			}
+
+			return nil, err
+		}
+	}
+}
+
+// Close closes the stdin of the server and waits for the server to exit. The server is killed, if
+// it does not exit within the close timeout.
+func (t *StdioTransport) Close() error {
+	if t.cmd == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	err := t.stdin.Close()
+	t.mu.Unlock()
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- t.cmd.Wait()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(t.opts.CloseTimeout):
+		_ = t.cmd.Process.Kill()
+		<-done
+	}
+
+	return err
+}
@@ -0,0 +1,53 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHelperStdioServer is executed as stdio server by TestStdioTransport.
+func TestHelperStdioServer(t *testing.T) {
+	if os.Getenv("GOLC_MCP_HELPER_SERVER") != "1" {
+		return
+	}
+
+	// Log output must be ignored by the client.
+	fmt.Println("starting server")
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		if res := handleTestMessage(scanner.Bytes()); res != nil {
+			fmt.Println(string(res))
+		}
+	}
+
+	os.Exit(0)
+}
+
+func TestStdioTransport(t *testing.T) {
+	t.Parallel()
+
+	transport := NewStdioTransport(os.Args[0], []string{"-test.run=^TestHelperStdioServer$"}, func(o *StdioTransportOptions) {
+		o.Env = []string{"GOLC_MCP_HELPER_SERVER=1"}
+	})
+
+	client, err := Connect(context.Background(), transport)
+	require.NoError(t, err)
+
+	assert.Equal(t, "test", client.ServerInfo().Name)
+
+	result, err := client.CallTool(context.Background(), "echo", map[string]any{"text": "hello"})
+	require.NoError(t, err)
+	assert.Equal(t, "hello", result.Content[0].Text)
+
+	require.NoError(t, client.Close())
+
+	err = client.Ping(context.Background())
+	assert.ErrorIs(t, err, ErrClosed)
+}
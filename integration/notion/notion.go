@@ -0,0 +1,137 @@
+// Package notion provides a client for the API of Notion.
+package notion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// HTTPClient is an interface for making HTTP requests.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type ClientOptions struct {
+	// The HTTP client to use for making API requests.
+	HTTPClient HTTPClient
+	// BaseURL is the base url of the API.
+	BaseURL string
+	// Version is the version of the API.
+	Version string
+}
+
+type Client struct {
+	apiKey string
+	opts   ClientOptions
+}
+
+// New creates a new Notion client with the given API key of an integration.
+func New(apiKey string, optFns ...func(o *ClientOptions)) *Client {
+	opts := ClientOptions{
+		HTTPClient: http.DefaultClient,
+		BaseURL:    "https://api.notion.com/v1",
+		Version:    "2022-06-28",
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Client{
+		apiKey: apiKey,
+		opts:   opts,
+	}
+}
+
+// QueryDatabase returns the pages of a database.
+func (c *Client) QueryDatabase(ctx context.Context, databaseID string, req *QueryDatabaseRequest) (*QueryDatabaseResponse, error) {
+	body, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("%s/databases/%s/query", c.opts.BaseURL, url.PathEscape(databaseID)), req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := QueryDatabaseResponse{}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetBlockChildren returns the child blocks of a block or page, starting at the cursor.
+func (c *Client) GetBlockChildren(ctx context.Context, blockID string, startCursor string) (*GetBlockChildrenResponse, error) {
+	query := url.Values{}
+	query.Set("page_size", "100")
+
+	if startCursor != "" {
+		query.Set("start_cursor", startCursor)
+	}
+
+	body, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("%s/blocks/%s/children?%s", c.opts.BaseURL, url.PathEscape(blockID), query.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := GetBlockChildrenResponse{}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// doRequest sends an HTTP request to the specified URL with the given method and payload.
+func (c *Client) doRequest(ctx context.Context, method string, url string, payload any) ([]byte, error) {
+	var body io.Reader
+
+	if payload != nil {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+
+		body = bytes.NewReader(b)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	httpReq.Header.Set("Notion-Version", c.opts.Version)
+
+	res, err := c.opts.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		apiErr := &APIError{StatusCode: res.StatusCode}
+
+		errorResponse := ErrorResponse{}
+		if err := json.Unmarshal(resBody, &errorResponse); err != nil || errorResponse.Message == "" {
+			apiErr.Message = res.Status
+		} else {
+			apiErr.Message = errorResponse.Message
+		}
+
+		return nil, apiErr
+	}
+
+	return resBody, nil
+}
@@ -0,0 +1,84 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	t.Run("QueryDatabase", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/databases/db1/query", r.URL.Path)
+			assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+			assert.Equal(t, "2022-06-28", r.Header.Get("Notion-Version"))
+
+			req := map[string]any{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, map[string]any{"start_cursor": "c1", "page_size": float64(10)}, req)
+
+			_, _ = w.Write([]byte(`{"results":[{"id":"p1","url":"https://www.notion.so/p1","last_edited_time":"2024-01-02T10:00:00.000Z","properties":{"Name":{"id":"title","type":"title","title":[{"type":"text","plain_text":"Road"},{"type":"text","plain_text":"map"}]}}}],"has_more":true,"next_cursor":"c2"}`))
+		}))
+		defer server.Close()
+
+		client := New("key", func(o *ClientOptions) {
+			o.BaseURL = server.URL
+		})
+
+		res, err := client.QueryDatabase(context.Background(), "db1", &QueryDatabaseRequest{StartCursor: "c1", PageSize: 10})
+		require.NoError(t, err)
+		require.Len(t, res.Results, 1)
+		assert.Equal(t, "Roadmap", res.Results[0].Title())
+		assert.Equal(t, "2024-01-02T10:00:00.000Z", res.Results[0].LastEditedTime)
+		assert.True(t, res.HasMore)
+		assert.Equal(t, "c2", res.NextCursor)
+	})
+
+	t.Run("GetBlockChildren", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/blocks/p1/children", r.URL.Path)
+			assert.Equal(t, "c1", r.URL.Query().Get("start_cursor"))
+
+			_, _ = w.Write([]byte(`{"results":[{"id":"b1","type":"to_do","has_children":false,"to_do":{"rich_text":[{"type":"text","plain_text":"Ship"}],"checked":true}},{"id":"b2","type":"divider","divider":{}}],"has_more":false,"next_cursor":null}`))
+		}))
+		defer server.Close()
+
+		client := New("key", func(o *ClientOptions) {
+			o.BaseURL = server.URL
+		})
+
+		res, err := client.GetBlockChildren(context.Background(), "p1", "c1")
+		require.NoError(t, err)
+		require.Len(t, res.Results, 2)
+		assert.Equal(t, &BlockContent{RichText: []RichText{{Type: "text", PlainText: "Ship"}}, Checked: true}, res.Results[0].Content())
+		assert.Nil(t, res.Results[1].Content())
+		assert.False(t, res.HasMore)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"object":"error","status":401,"code":"unauthorized","message":"API token is invalid."}`))
+		}))
+		defer server.Close()
+
+		client := New("key", func(o *ClientOptions) {
+			o.BaseURL = server.URL
+		})
+
+		_, err := client.GetBlockChildren(context.Background(), "p1", "")
+
+		apiErr := &APIError{}
+		require.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+		assert.EqualError(t, err, "notion API error (401): API token is invalid.")
+	})
+}
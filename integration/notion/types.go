@@ -0,0 +1,139 @@
+package notion
+
+import "fmt"
+
+type QueryDatabaseRequest struct {
+	// StartCursor is the cursor of the next page of results.
+	StartCursor string `json:"start_cursor,omitempty"`
+	// PageSize is the maximum number of results.
+	PageSize int `json:"page_size,omitempty"`
+}
+
+type Annotations struct {
+	Bold          bool `json:"bold"`
+	Italic        bool `json:"italic"`
+	Strikethrough bool `json:"strikethrough"`
+	Underline     bool `json:"underline"`
+	Code          bool `json:"code"`
+}
+
+type RichText struct {
+	Type        string      `json:"type"`
+	PlainText   string      `json:"plain_text"`
+	Href        string      `json:"href"`
+	Annotations Annotations `json:"annotations"`
+}
+
+type Property struct {
+	ID    string     `json:"id"`
+	Type  string     `json:"type"`
+	Title []RichText `json:"title,omitempty"`
+}
+
+type Page struct {
+	ID             string              `json:"id"`
+	URL            string              `json:"url"`
+	CreatedTime    string              `json:"created_time"`
+	LastEditedTime string              `json:"last_edited_time"`
+	Archived       bool                `json:"archived"`
+	Properties     map[string]Property `json:"properties"`
+}
+
+// Title returns the plain text of the title property of the page.
+func (p *Page) Title() string {
+	for _, property := range p.Properties {
+		if property.Type != "title" {
+			continue
+		}
+
+		title := ""
+		for _, t := range property.Title {
+			title += t.PlainText
+		}
+
+		return title
+	}
+
+	return ""
+}
+
+type QueryDatabaseResponse struct {
+	Results    []Page `json:"results"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor"`
+}
+
+// BlockContent is the content of a block. Only the fields of the block type are set.
+type BlockContent struct {
+	RichText []RichText `json:"rich_text"`
+	Checked  bool       `json:"checked"`
+	Language string     `json:"language"`
+}
+
+type Block struct {
+	ID               string        `json:"id"`
+	Type             string        `json:"type"`
+	HasChildren      bool          `json:"has_children"`
+	Paragraph        *BlockContent `json:"paragraph,omitempty"`
+	Heading1         *BlockContent `json:"heading_1,omitempty"`
+	Heading2         *BlockContent `json:"heading_2,omitempty"`
+	Heading3         *BlockContent `json:"heading_3,omitempty"`
+	BulletedListItem *BlockContent `json:"bulleted_list_item,omitempty"`
+	NumberedListItem *BlockContent `json:"numbered_list_item,omitempty"`
+	ToDo             *BlockContent `json:"to_do,omitempty"`
+	Toggle           *BlockContent `json:"toggle,omitempty"`
+	Quote            *BlockContent `json:"quote,omitempty"`
+	Callout          *BlockContent `json:"callout,omitempty"`
+	Code             *BlockContent `json:"code,omitempty"`
+}
+
+// Content returns the content of the block type or nil, e.g. for dividers.
+func (b *Block) Content() *BlockContent {
+	switch b.Type {
+	case "paragraph":
+		return b.Paragraph
+	case "heading_1":
+		return b.Heading1
+	case "heading_2":
+		return b.Heading2
+	case "heading_3":
+		return b.Heading3
+	case "bulleted_list_item":
+		return b.BulletedListItem
+	case "numbered_list_item":
+		return b.NumberedListItem
+	case "to_do":
+		return b.ToDo
+	case "toggle":
+		return b.Toggle
+	case "quote":
+		return b.Quote
+	case "callout":
+		return b.Callout
+	case "code":
+		return b.Code
+	default:
+		return nil
+	}
+}
+
+type GetBlockChildrenResponse struct {
+	Results    []Block `json:"results"`
+	HasMore    bool    `json:"has_more"`
+	NextCursor string  `json:"next_cursor"`
+}
+
+type ErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// APIError is returned when the API responds with an error status code.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("notion API error (%d): %s", e.StatusCode, e.Message)
+}
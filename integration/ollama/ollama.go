@@ -105,6 +105,21 @@ func (c *Client) CreateEmbedding(ctx context.Context, req *EmbeddingRequest) (*E
 	return &embedding, nil
 }
 
+// CreateEmbeddings embeds a list of texts with a single request to the /api/embed endpoint.
+func (c *Client) CreateEmbeddings(ctx context.Context, req *EmbedRequest) (*EmbedResponse, error) {
+	body, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("%s/api/embed", c.apiURL), req)
+	if err != nil {
+		return nil, err
+	}
+
+	embeddings := EmbedResponse{}
+	if err := json.Unmarshal(body, &embeddings); err != nil {
+		return nil, err
+	}
+
+	return &embeddings, nil
+}
+
 // doRequest sends an HTTP request to the specified URL with the given method and payload.
 func (c *Client) doRequest(ctx context.Context, method string, url string, payload any) ([]byte, error) {
 	var body io.Reader
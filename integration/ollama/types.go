@@ -90,15 +90,37 @@ type GenerationStream struct {
 	*stream.Stream[GenerationResponse]
 }
 
+type ToolCallFunction struct {
+	Name      string         `json:"name"`
+	Arguments map[string]any `json:"arguments"`
+}
+
+type ToolCall struct {
+	Function ToolCallFunction `json:"function"`
+}
+
 type Message struct {
-	Role    string      `json:"role"` // one of ["system", "user", "assistant"]
-	Content string      `json:"content"`
-	Images  []ImageData `json:"images,omitempty"`
+	Role      string      `json:"role"` // one of ["system", "user", "assistant", "tool"]
+	Content   string      `json:"content"`
+	Images    []ImageData `json:"images,omitempty"`
+	ToolCalls []ToolCall  `json:"tool_calls,omitempty"`
+}
+
+type ToolFunction struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Parameters  any    `json:"parameters"`
+}
+
+type Tool struct {
+	Type     string       `json:"type"`
+	Function ToolFunction `json:"function"`
 }
 
 type ChatRequest struct {
 	Model    string    `json:"model"`
 	Messages []Message `json:"messages"`
+	Tools    []Tool    `json:"tools,omitempty"`
 	Stream   *bool     `json:"stream,omitempty"`
 	Format   string    `json:"format"`
 
@@ -128,3 +150,17 @@ type EmbeddingRequest struct {
 type EmbeddingResponse struct {
 	Embedding []float32 `json:"embedding"`
 }
+
+// EmbedRequest is the request of the batch embedding endpoint /api/embed.
+type EmbedRequest struct {
+	Model   string   `json:"model"`
+	Input   []string `json:"input"`
+	Options Options  `json:"options"`
+}
+
+// EmbedResponse is the response of the batch embedding endpoint /api/embed.
+// The embeddings are normalized to unit length.
+type EmbedResponse struct {
+	Model      string      `json:"model"`
+	Embeddings [][]float32 `json:"embeddings"`
+}
@@ -18,13 +18,54 @@ func ToOpenAIChatCompletionMessages(messages schema.ChatMessages) ([]openai.Chat
 			return nil, err
 		}
 
-		if functionMessage, ok := message.(*schema.FunctionChatMessage); ok {
+		switch m := message.(type) {
+		case *schema.HumanChatMessage:
+			openAIMessage := openai.ChatCompletionMessage{
+				Role: role,
+			}
+
+			if len(m.Parts()) > 0 {
+				openAIMessage.MultiContent, err = toOpenAIChatMessageParts(m.Parts())
+				if err != nil {
+					return nil, err
+				}
+			} else {
+				openAIMessage.Content = m.Content()
+			}
+
+			openAIMessages = append(openAIMessages, openAIMessage)
+		case *schema.FunctionChatMessage:
 			openAIMessages = append(openAIMessages, openai.ChatCompletionMessage{
 				Role:    role,
-				Content: functionMessage.Content(),
-				Name:    functionMessage.Name(),
+				Content: m.Content(),
+				Name:    m.Name(),
+			})
+		case *schema.ToolChatMessage:
+			openAIMessages = append(openAIMessages, openai.ChatCompletionMessage{
+				Role:       role,
+				Content:    m.Content(),
+				Name:       m.Name(),
+				ToolCallID: m.ToolCallID(),
 			})
-		} else {
+		case *schema.AIChatMessage:
+			openAIMessage := openai.ChatCompletionMessage{
+				Role:    role,
+				Content: m.Content(),
+			}
+
+			for _, tc := range m.Extension().ToolCalls {
+				openAIMessage.ToolCalls = append(openAIMessage.ToolCalls, openai.ToolCall{
+					ID:   tc.ID,
+					Type: openai.ToolTypeFunction,
+					Function: openai.FunctionCall{
+						Name:      tc.Name,
+						Arguments: tc.Arguments,
+					},
+				})
+			}
+
+			openAIMessages = append(openAIMessages, openAIMessage)
+		default:
 			openAIMessages = append(openAIMessages, openai.ChatCompletionMessage{
 				Role:    role,
 				Content: message.Content(),
@@ -35,6 +76,36 @@ func ToOpenAIChatCompletionMessages(messages schema.ChatMessages) ([]openai.Chat
 	return openAIMessages, nil
 }
 
+// toOpenAIChatMessageParts converts the content parts of a multimodal chat message to OpenAI chat message parts.
+// Images are passed by URL or as data URL; audio parts are not supported by the chat completions API.
+func toOpenAIChatMessageParts(parts []schema.ContentPart) ([]openai.ChatMessagePart, error) {
+	openAIParts := make([]openai.ChatMessagePart, 0, len(parts))
+
+	for _, p := range parts {
+		switch p.Type {
+		case schema.ContentPartTypeText:
+			openAIParts = append(openAIParts, openai.ChatMessagePart{
+				Type: openai.ChatMessagePartTypeText,
+				Text: p.Text,
+			})
+		case schema.ContentPartTypeImageURL:
+			openAIParts = append(openAIParts, openai.ChatMessagePart{
+				Type:     openai.ChatMessagePartTypeImageURL,
+				ImageURL: &openai.ChatMessageImageURL{URL: p.URL},
+			})
+		case schema.ContentPartTypeImage:
+			openAIParts = append(openAIParts, openai.ChatMessagePart{
+				Type:     openai.ChatMessagePartTypeImageURL,
+				ImageURL: &openai.ChatMessageImageURL{URL: p.DataURL()},
+			})
+		default:
+			return nil, fmt.Errorf("unsupported content part type: %s", p.Type)
+		}
+	}
+
+	return openAIParts, nil
+}
+
 // messageTypeToOpenAIRole converts a schema.ChatMessageType to the corresponding OpenAI role string.
 func messageTypeToOpenAIRole(mType schema.ChatMessageType) (string, error) {
 	switch mType { // nolint exhaustive
@@ -46,6 +117,8 @@ func messageTypeToOpenAIRole(mType schema.ChatMessageType) (string, error) {
 		return "user", nil
 	case schema.ChatMessageTypeFunction:
 		return "function", nil
+	case schema.ChatMessageTypeTool:
+		return "tool", nil
 	default:
 		return "", fmt.Errorf("unknown message type: %s", mType)
 	}
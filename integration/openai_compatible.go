@@ -0,0 +1,130 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// OpenAICompatibleConfigOptions contains the options for the client configuration of an OpenAI-compatible server.
+type OpenAICompatibleConfigOptions struct {
+	// Paths maps the OpenAI endpoint paths, e.g. "/chat/completions", to the paths used by the server.
+	Paths map[string]string
+	// Headers contains additional headers sent with every request.
+	Headers map[string]string
+	// UnsupportedParams lists the request parameters that are not supported by the server, e.g. "presence_penalty".
+	// They are removed from the requests.
+	UnsupportedParams []string
+	// Transport is the underlying transport. If nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+}
+
+// NewOpenAICompatibleConfig creates an OpenAI client configuration for an OpenAI-compatible server at the given
+// base url, e.g. vLLM, LocalAI or LM Studio. The api key may be empty for servers without authentication.
+func NewOpenAICompatibleConfig(apiKey, baseURL string, optFns ...func(o *OpenAICompatibleConfigOptions)) openai.ClientConfig {
+	opts := OpenAICompatibleConfigOptions{
+		Transport: http.DefaultTransport,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	config := openai.DefaultConfig(apiKey)
+	config.BaseURL = strings.TrimSuffix(baseURL, "/")
+
+	if len(opts.Paths) > 0 || len(opts.Headers) > 0 || len(opts.UnsupportedParams) > 0 {
+		config.HTTPClient = &http.Client{
+			Transport: &openAICompatibleTransport{
+				base:    opts.Transport,
+				baseURL: config.BaseURL,
+				opts:    opts,
+			},
+		}
+	}
+
+	return config
+}
+
+// openAICompatibleTransport is a http.RoundTripper that adapts the OpenAI requests to the server.
+type openAICompatibleTransport struct {
+	base    http.RoundTripper
+	baseURL string
+	opts    OpenAICompatibleConfigOptions
+}
+
+// RoundTrip executes a single HTTP transaction with the adapted request.
+func (t *openAICompatibleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+
+	for k, v := range t.opts.Headers {
+		req.Header.Set(k, v)
+	}
+
+	if path, ok := t.mapPath(req.URL.String()); ok {
+		u, err := req.URL.Parse(t.baseURL + path)
+		if err != nil {
+			return nil, err
+		}
+
+		u.RawQuery = req.URL.RawQuery
+		req.URL = u
+		req.Host = u.Host
+	}
+
+	if len(t.opts.UnsupportedParams) > 0 && req.Body != nil {
+		if err := t.removeUnsupportedParams(req); err != nil {
+			return nil, err
+		}
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// mapPath returns the configured path for the OpenAI endpoint of the request url.
+func (t *openAICompatibleTransport) mapPath(url string) (string, bool) {
+	rest, ok := strings.CutPrefix(url, t.baseURL)
+	if !ok {
+		return "", false
+	}
+
+	rest, _, _ = strings.Cut(rest, "?")
+
+	path, ok := t.opts.Paths[rest]
+
+	return path, ok
+}
+
+// removeUnsupportedParams removes the unsupported parameters from the JSON body of the request.
+func (t *openAICompatibleTransport) removeUnsupportedParams(req *http.Request) error {
+	b, err := io.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+
+	_ = req.Body.Close()
+
+	body := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &body); err == nil {
+		for _, p := range t.opts.UnsupportedParams {
+			delete(body, p)
+		}
+
+		b, err = json.Marshal(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(b))
+	req.ContentLength = int64(len(b))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(b)), nil
+	}
+
+	return nil
+}
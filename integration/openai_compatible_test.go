@@ -0,0 +1,63 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOpenAICompatibleConfig(t *testing.T) {
+	var (
+		req  *http.Request
+		body map[string]any
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+		body = map[string]any{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"Hello"}}]}`))
+	}))
+	defer server.Close()
+
+	t.Run("Default", func(t *testing.T) {
+		config := NewOpenAICompatibleConfig("", server.URL+"/v1/")
+
+		_, err := openai.NewClientWithConfig(config).CreateChatCompletion(context.Background(), openai.ChatCompletionRequest{
+			Model:           "llama3",
+			PresencePenalty: 0.5,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "/v1/chat/completions", req.URL.Path)
+		assert.Empty(t, req.Header.Get("Authorization"))
+		assert.Equal(t, 0.5, body["presence_penalty"])
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		config := NewOpenAICompatibleConfig("key", server.URL, func(o *OpenAICompatibleConfigOptions) {
+			o.Paths = map[string]string{"/chat/completions": "/api/chat"}
+			o.Headers = map[string]string{"X-Custom": "value"}
+			o.UnsupportedParams = []string{"presence_penalty"}
+		})
+
+		_, err := openai.NewClientWithConfig(config).CreateChatCompletion(context.Background(), openai.ChatCompletionRequest{
+			Model:           "llama3",
+			PresencePenalty: 0.5,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "/api/chat", req.URL.Path)
+		assert.Equal(t, "Bearer key", req.Header.Get("Authorization"))
+		assert.Equal(t, "value", req.Header.Get("X-Custom"))
+		assert.NotContains(t, body, "presence_penalty")
+		assert.Equal(t, "llama3", body["model"])
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/hupe1980/golc/schema"
+	"github.com/sashabaranov/go-openai"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -24,6 +25,56 @@ func TestToOpenAIChatCompletionMessages(t *testing.T) {
 	assert.Equal(t, "What is 1 times 1?", openAIMessages[1].Content)
 }
 
+func TestToOpenAIChatCompletionMessagesWithToolCalls(t *testing.T) {
+	messages := schema.ChatMessages{
+		schema.NewHumanChatMessage("What is the weather in Berlin?"),
+		schema.NewAIChatMessage("", func(o *schema.ChatMessageExtension) {
+			o.ToolCalls = []schema.ToolCall{{ID: "call_1", Name: "weather", Arguments: `{"city":"Berlin"}`}}
+		}),
+		schema.NewToolChatMessage("call_1", "weather", "sunny"),
+	}
+
+	openAIMessages, err := ToOpenAIChatCompletionMessages(messages)
+	assert.NoError(t, err)
+	assert.Len(t, openAIMessages, 3)
+
+	assert.Equal(t, "assistant", openAIMessages[1].Role)
+	assert.Len(t, openAIMessages[1].ToolCalls, 1)
+	assert.Equal(t, "call_1", openAIMessages[1].ToolCalls[0].ID)
+	assert.Equal(t, "weather", openAIMessages[1].ToolCalls[0].Function.Name)
+	assert.Equal(t, `{"city":"Berlin"}`, openAIMessages[1].ToolCalls[0].Function.Arguments)
+
+	assert.Equal(t, "tool", openAIMessages[2].Role)
+	assert.Equal(t, "call_1", openAIMessages[2].ToolCallID)
+	assert.Equal(t, "sunny", openAIMessages[2].Content)
+}
+
+func TestToOpenAIChatCompletionMessagesWithParts(t *testing.T) {
+	messages := schema.ChatMessages{
+		schema.NewMultimodalHumanChatMessage(
+			schema.NewTextPart("What is in the images?"),
+			schema.NewImageURLPart("https://example.com/cat.png"),
+			schema.NewImagePart([]byte("png"), "image/png"),
+		),
+	}
+
+	openAIMessages, err := ToOpenAIChatCompletionMessages(messages)
+	assert.NoError(t, err)
+	assert.Len(t, openAIMessages, 1)
+
+	assert.Equal(t, "", openAIMessages[0].Content)
+	assert.Equal(t, []openai.ChatMessagePart{
+		{Type: openai.ChatMessagePartTypeText, Text: "What is in the images?"},
+		{Type: openai.ChatMessagePartTypeImageURL, ImageURL: &openai.ChatMessageImageURL{URL: "https://example.com/cat.png"}},
+		{Type: openai.ChatMessagePartTypeImageURL, ImageURL: &openai.ChatMessageImageURL{URL: "data:image/png;base64,cG5n"}},
+	}, openAIMessages[0].MultiContent)
+
+	_, err = ToOpenAIChatCompletionMessages(schema.ChatMessages{
+		schema.NewMultimodalHumanChatMessage(schema.NewAudioPart([]byte("wav"), "audio/wav")),
+	})
+	assert.EqualError(t, err, "unsupported content part type: audio")
+}
+
 // Test case for messageTypeToOpenAIRole function
 func TestMessageTypeToOpenAIRole(t *testing.T) {
 	assertRole, assertErr := messageTypeToOpenAIRole(schema.ChatMessageTypeAI)
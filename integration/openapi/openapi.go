@@ -0,0 +1,360 @@
+// Package openapi provides a parser for OpenAPI 3 and Swagger 2 specifications and builds the HTTP
+// requests of their operations.
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hupe1980/golc/integration/jsonschema"
+	"github.com/hupe1980/golc/internal/util"
+	"gopkg.in/yaml.v3"
+)
+
+// maxRefDepth limits the depth of nested references, which are inlined.
+const maxRefDepth = 32
+
+// Spec is an OpenAPI 3 or Swagger 2 specification, whose local references are inlined.
+type Spec struct {
+	OpenAPI  string               `json:"openapi"`
+	Swagger  string               `json:"swagger"`
+	Info     Info                 `json:"info"`
+	Servers  []Server             `json:"servers"`
+	Host     string               `json:"host"`
+	BasePath string               `json:"basePath"`
+	Schemes  []string             `json:"schemes"`
+	Paths    map[string]*PathItem `json:"paths"`
+	// Security are the global security requirements of the operations.
+	Security   []SecurityRequirement `json:"security"`
+	Components struct {
+		SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes"`
+	} `json:"components"`
+	SecurityDefinitions map[string]*SecurityScheme `json:"securityDefinitions"`
+}
+
+// Info contains the metadata of the API.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+// Server is a server of the API. The url may contain variables, e.g. "https://{region}.example.com".
+type Server struct {
+	URL       string `json:"url"`
+	Variables map[string]struct {
+		Default string `json:"default"`
+	} `json:"variables"`
+}
+
+// PathItem contains the operations of a path.
+type PathItem struct {
+	Parameters []*Parameter   `json:"parameters"`
+	Get        *SpecOperation `json:"get"`
+	Put        *SpecOperation `json:"put"`
+	Post       *SpecOperation `json:"post"`
+	Delete     *SpecOperation `json:"delete"`
+	Patch      *SpecOperation `json:"patch"`
+}
+
+// SpecOperation is an operation as described by the specification. Use Operations to get the
+// operations with the parameters of their paths.
+type SpecOperation struct {
+	OperationID string                 `json:"operationId"`
+	Summary     string                 `json:"summary"`
+	Description string                 `json:"description"`
+	Deprecated  bool                   `json:"deprecated"`
+	Parameters  []*Parameter           `json:"parameters"`
+	RequestBody *RequestBody           `json:"requestBody"`
+	Security    *[]SecurityRequirement `json:"security"`
+}
+
+// Parameter is a path, query, header or cookie parameter of an operation. Swagger 2 describes the
+// request body as parameter in "body".
+type Parameter struct {
+	Name        string             `json:"name"`
+	In          string             `json:"in"`
+	Description string             `json:"description"`
+	Required    bool               `json:"required"`
+	Schema      *jsonschema.Schema `json:"schema"`
+	// Type, Items and Enum describe the parameters of Swagger 2, which have no schema.
+	Type  string             `json:"type"`
+	Items *jsonschema.Schema `json:"items"`
+	Enum  []any              `json:"enum"`
+}
+
+// RequestBody is the request body of an operation.
+type RequestBody struct {
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+	Content     map[string]struct {
+		Schema *jsonschema.Schema `json:"schema"`
+	} `json:"content"`
+}
+
+// SecurityRequirement maps the names of security schemes, which must be satisfied together, to their scopes.
+type SecurityRequirement map[string][]string
+
+// Parse parses an OpenAPI 3 or Swagger 2 specification in JSON or YAML. Local references, e.g.
+// "#/components/schemas/Pet", are inlined. External references are not supported.
+func Parse(data []byte) (*Spec, error) {
+	var raw any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI specification: %w", err)
+	}
+
+	root, ok := normalize(raw).(map[string]any)
+	if !ok {
+		return nil, errors.New("invalid OpenAPI specification: not an object")
+	}
+
+	// Only the references of the paths are inlined, so that unused components are not expanded.
+	paths, err := resolveRefs(root, root["paths"], nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI specification: %w", err)
+	}
+
+	resolved := make(map[string]any, len(root))
+	for k, v := range root {
+		resolved[k] = v
+	}
+
+	resolved["paths"] = paths
+
+	b, err := json.Marshal(resolved)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &Spec{}
+	if err := json.Unmarshal(b, spec); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI specification: %w", err)
+	}
+
+	return spec, nil
+}
+
+// BaseURL returns the URL of the first server of the specification. Server variables are replaced
+// by their default values.
+func (s *Spec) BaseURL() string {
+	if len(s.Servers) > 0 {
+		u := s.Servers[0].URL
+		for name, v := range s.Servers[0].Variables {
+			u = strings.ReplaceAll(u, fmt.Sprintf("{%s}", name), v.Default)
+		}
+
+		return u
+	}
+
+	if s.Host == "" {
+		return ""
+	}
+
+	scheme := "https"
+	if len(s.Schemes) > 0 && !util.Contains(s.Schemes, "https") {
+		scheme = s.Schemes[0]
+	}
+
+	return fmt.Sprintf("%s://%s%s", scheme, s.Host, s.BasePath)
+}
+
+// SecuritySchemes returns the security schemes of the specification by name.
+func (s *Spec) SecuritySchemes() map[string]*SecurityScheme {
+	if len(s.Components.SecuritySchemes) > 0 {
+		return s.Components.SecuritySchemes
+	}
+
+	return s.SecurityDefinitions
+}
+
+// Operations returns the operations of the specification sorted by path and method.
+func (s *Spec) Operations() []*Operation {
+	paths := util.Keys(s.Paths)
+	sort.Strings(paths)
+
+	operations := []*Operation{}
+
+	for _, path := range paths {
+		item := s.Paths[path]
+		if item == nil {
+			continue
+		}
+
+		for _, m := range []struct {
+			method string
+			op     *SpecOperation
+		}{
+			{http.MethodGet, item.Get},
+			{http.MethodPut, item.Put},
+			{http.MethodPost, item.Post},
+			{http.MethodDelete, item.Delete},
+			{http.MethodPatch, item.Patch},
+		} {
+			if m.op == nil {
+				continue
+			}
+
+			operations = append(operations, newOperation(m.method, path, item.Parameters, m.op, s.Security))
+		}
+	}
+
+	return operations
+}
+
+// normalize converts the maps decoded from YAML to maps with string keys, which can be encoded as JSON.
+// Schema keywords of OpenAPI 3.1, which differ from OpenAPI 3.0, are converted to their 3.0 form.
+func normalize(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			v[k] = normalize(e)
+		}
+
+		return normalizeSchema(v)
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for k, e := range v {
+			m[fmt.Sprint(k)] = normalize(e)
+		}
+
+		return normalizeSchema(m)
+	case []any:
+		for i, e := range v {
+			v[i] = normalize(e)
+		}
+
+		return v
+	default:
+		return v
+	}
+}
+
+// normalizeSchema converts type arrays, e.g. ["string", "null"], to a type and the nullable flag and
+// removes numeric exclusive limits.
+func normalizeSchema(m map[string]any) map[string]any {
+	if types, ok := m["type"].([]any); ok {
+		delete(m, "type")
+
+		for _, t := range types {
+			if t == "null" {
+				m["nullable"] = true
+			} else if _, ok := m["type"]; !ok {
+				m["type"] = t
+			}
+		}
+	}
+
+	for _, k := range []string{"exclusiveMinimum", "exclusiveMaximum"} {
+		switch m[k].(type) {
+		case int, int64, uint64, float64:
+			delete(m, k)
+		}
+	}
+
+	return m
+}
+
+// resolveRefs returns a copy of the value with all local references inlined. Sibling keys of a
+// reference override the keys of the referenced value. Recursive references are replaced by an
+// empty object.
+func resolveRefs(root, v any, stack []string) (any, error) {
+	switch v := v.(type) {
+	case map[string]any:
+		if ref, ok := v["$ref"].(string); ok {
+			if util.Contains(stack, ref) || len(stack) >= maxRefDepth {
+				return map[string]any{}, nil
+			}
+
+			target, err := lookupRef(root, ref)
+			if err != nil {
+				return nil, err
+			}
+
+			resolved, err := resolveRefs(root, target, append(stack, ref))
+			if err != nil {
+				return nil, err
+			}
+
+			m, ok := resolved.(map[string]any)
+			if !ok {
+				return resolved, nil
+			}
+
+			merged := make(map[string]any, len(m)+len(v))
+			for k, e := range m {
+				merged[k] = e
+			}
+
+			for k, e := range v {
+				if k == "$ref" {
+					continue
+				}
+
+				r, err := resolveRefs(root, e, stack)
+				if err != nil {
+					return nil, err
+				}
+
+				merged[k] = r
+			}
+
+			return merged, nil
+		}
+
+		m := make(map[string]any, len(v))
+
+		for k, e := range v {
+			r, err := resolveRefs(root, e, stack)
+			if err != nil {
+				return nil, err
+			}
+
+			m[k] = r
+		}
+
+		return m, nil
+	case []any:
+		s := make([]any, len(v))
+
+		for i, e := range v {
+			r, err := resolveRefs(root, e, stack)
+			if err != nil {
+				return nil, err
+			}
+
+			s[i] = r
+		}
+
+		return s, nil
+	default:
+		return v, nil
+	}
+}
+
+// lookupRef returns the value of the local reference, which is a JSON pointer, e.g. "#/definitions/Pet".
+func lookupRef(root any, ref string) (any, error) {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("unsupported reference: %s", ref)
+	}
+
+	v := root
+
+	for _, token := range strings.Split(ref[2:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unresolvable reference: %s", ref)
+		}
+
+		if v, ok = m[token]; !ok {
+			return nil, fmt.Errorf("unresolvable reference: %s", ref)
+		}
+	}
+
+	return v, nil
+}
@@ -0,0 +1,279 @@
+package openapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `
+openapi: 3.1.0
+info:
+  title: Petstore
+  version: 1.0.0
+servers:
+  - url: https://{region}.example.com/v1
+    variables:
+      region:
+        default: eu
+security:
+  - apiKey: []
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List all pets
+      parameters:
+        - $ref: "#/components/parameters/limit"
+        - name: tags
+          in: query
+          schema:
+            type: array
+            items:
+              type: string
+    post:
+      operationId: createPet
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/Pet"
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      summary: Info for a specific pet
+      security: []
+      parameters:
+        - name: X-Request-Id
+          in: header
+          schema:
+            type: string
+components:
+  parameters:
+    limit:
+      name: limit
+      in: query
+      schema:
+        type: integer
+        exclusiveMinimum: 0
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        tag:
+          type: [string, "null"]
+        parent:
+          $ref: "#/components/schemas/Pet"
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      in: header
+      name: X-API-Key
+`
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OpenAPI", func(t *testing.T) {
+		spec, err := Parse([]byte(testSpec))
+		require.NoError(t, err)
+
+		assert.Equal(t, "Petstore", spec.Info.Title)
+		assert.Equal(t, "https://eu.example.com/v1", spec.BaseURL())
+		assert.Equal(t, "X-API-Key", spec.SecuritySchemes()["apiKey"].Name)
+
+		ops := spec.Operations()
+		require.Len(t, ops, 3)
+
+		assert.Equal(t, "listPets", ops[0].ID)
+		assert.Equal(t, "createPet", ops[1].ID)
+		assert.Equal(t, "GET /pets/{petId}", ops[2].ID)
+
+		limit, ok := ops[0].Parameter("limit")
+		require.True(t, ok)
+		assert.Equal(t, "integer", limit.Schema.Type)
+
+		assert.True(t, ops[1].HasBody)
+		assert.True(t, ops[1].BodyRequired)
+		assert.Equal(t, []string{"name"}, ops[1].BodySchema.Required)
+		assert.Equal(t, "string", ops[1].BodySchema.Properties["tag"].Type)
+		assert.NotNil(t, ops[1].BodySchema.Properties["parent"], "recursive reference")
+		assert.Equal(t, []SecurityRequirement{{"apiKey": []string{}}}, ops[1].Security)
+
+		require.Len(t, ops[2].Parameters, 2)
+		assert.Equal(t, "petId", ops[2].Parameters[0].Name)
+		assert.Empty(t, ops[2].Security)
+	})
+
+	t.Run("Swagger", func(t *testing.T) {
+		spec, err := Parse([]byte(`{
+			"swagger": "2.0",
+			"host": "api.example.com",
+			"basePath": "/v2",
+			"schemes": ["http"],
+			"securityDefinitions": {"basic": {"type": "basic"}},
+			"paths": {
+				"/users": {
+					"post": {
+						"operationId": "createUser",
+						"parameters": [
+							{"name": "dryRun", "in": "query", "type": "boolean"},
+							{"name": "user", "in": "body", "required": true, "schema": {"$ref": "#/definitions/User"}}
+						]
+					}
+				}
+			},
+			"definitions": {"User": {"type": "object", "properties": {"name": {"type": "string"}}}}
+		}`))
+		require.NoError(t, err)
+
+		assert.Equal(t, "http://api.example.com/v2", spec.BaseURL())
+		assert.Equal(t, "basic", spec.SecuritySchemes()["basic"].Type)
+
+		ops := spec.Operations()
+		require.Len(t, ops, 1)
+
+		require.Len(t, ops[0].Parameters, 1)
+		assert.Equal(t, "boolean", ops[0].Parameters[0].Schema.Type)
+		assert.True(t, ops[0].HasBody)
+		assert.True(t, ops[0].BodyRequired)
+		assert.Equal(t, "string", ops[0].BodySchema.Properties["name"].Type)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := Parse([]byte("- not an object"))
+		assert.EqualError(t, err, "invalid OpenAPI specification: not an object")
+
+		_, err = Parse([]byte(`{"paths": {"/": {"get": {"parameters": [{"$ref": "#/missing"}]}}}}`))
+		assert.EqualError(t, err, "invalid OpenAPI specification: unresolvable reference: #/missing")
+
+		_, err = Parse([]byte(`{"paths": {"/": {"get": {"parameters": [{"$ref": "common.yaml#/limit"}]}}}}`))
+		assert.EqualError(t, err, "invalid OpenAPI specification: unsupported reference: common.yaml#/limit")
+	})
+}
+
+func TestOperationNewRequest(t *testing.T) {
+	t.Parallel()
+
+	spec, err := Parse([]byte(testSpec))
+	require.NoError(t, err)
+
+	ops := spec.Operations()
+	baseURL, _ := url.Parse("https://api.example.com/v1")
+
+	t.Run("Query", func(t *testing.T) {
+		req, err := ops[0].NewRequest(context.Background(), baseURL, map[string]any{
+			"limit": float64(1000000),
+			"tags":  []any{"cat", "dog"},
+		}, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "https://api.example.com/v1/pets?limit=1000000&tags=cat&tags=dog", req.URL.String())
+	})
+
+	t.Run("PathAndHeader", func(t *testing.T) {
+		req, err := ops[2].NewRequest(context.Background(), baseURL, map[string]any{
+			"petId":        "a b",
+			"X-Request-Id": "42",
+		}, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, "https://api.example.com/v1/pets/a%20b", req.URL.String())
+		assert.Equal(t, "42", req.Header.Get("X-Request-Id"))
+	})
+
+	t.Run("Body", func(t *testing.T) {
+		req, err := ops[1].NewRequest(context.Background(), baseURL, nil, map[string]any{"name": "Rex"})
+		require.NoError(t, err)
+
+		body, _ := io.ReadAll(req.Body)
+		assert.Equal(t, `{"name":"Rex"}`, string(body))
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ops[0].NewRequest(context.Background(), baseURL, map[string]any{"foo": 1}, nil)
+		assert.EqualError(t, err, "unknown parameter of API operation listPets: foo")
+
+		_, err = ops[2].NewRequest(context.Background(), baseURL, nil, nil)
+		assert.EqualError(t, err, "missing required parameter of API operation GET /pets/{petId}: petId")
+
+		_, err = ops[1].NewRequest(context.Background(), baseURL, nil, nil)
+		assert.EqualError(t, err, "missing required body of API operation createPet")
+	})
+
+	t.Run("PathTraversal", func(t *testing.T) {
+		for _, petID := range []string{".", "..", "../admin", "a/b", `a\b`} {
+			_, err := ops[2].NewRequest(context.Background(), baseURL, map[string]any{"petId": petID}, nil)
+			assert.EqualError(t, err, "invalid path parameter of API operation GET /pets/{petId}: petId")
+		}
+	})
+}
+
+func TestSecuritySchemeApply(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		scheme SecurityScheme
+		check  func(t *testing.T, req *http.Request)
+	}{
+		{"APIKeyHeader", SecurityScheme{Type: "apiKey", In: "header", Name: "X-API-Key"}, func(t *testing.T, req *http.Request) {
+			assert.Equal(t, "secret", req.Header.Get("X-API-Key"))
+		}},
+		{"APIKeyQuery", SecurityScheme{Type: "apiKey", In: "query", Name: "key"}, func(t *testing.T, req *http.Request) {
+			assert.Equal(t, "a=1&key=secret", req.URL.RawQuery)
+		}},
+		{"APIKeyCookie", SecurityScheme{Type: "apiKey", In: "cookie", Name: "session"}, func(t *testing.T, req *http.Request) {
+			c, err := req.Cookie("session")
+			require.NoError(t, err)
+			assert.Equal(t, "secret", c.Value)
+		}},
+		{"Bearer", SecurityScheme{Type: "http", Scheme: "Bearer"}, func(t *testing.T, req *http.Request) {
+			assert.Equal(t, "Bearer secret", req.Header.Get("Authorization"))
+		}},
+		{"OAuth2", SecurityScheme{Type: "oauth2"}, func(t *testing.T, req *http.Request) {
+			assert.Equal(t, "Bearer secret", req.Header.Get("Authorization"))
+		}},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "https://example.com/?a=1", nil)
+			require.NoError(t, tc.scheme.Apply(req, "secret"))
+			tc.check(t, req)
+		})
+	}
+
+	t.Run("Basic", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+		require.NoError(t, (&SecurityScheme{Type: "http", Scheme: "basic"}).Apply(req, "user:pass"))
+
+		username, password, ok := req.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "user", username)
+		assert.Equal(t, "pass", password)
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+		err := (&SecurityScheme{Type: "mutualTLS"}).Apply(req, "secret")
+		assert.EqualError(t, err, "unsupported security scheme: mutualTLS ")
+	})
+}
@@ -0,0 +1,227 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hupe1980/golc/integration/jsonschema"
+)
+
+// Operation is an operation of the specification with the parameters of its path.
+type Operation struct {
+	// ID is the operation id or, if the operation has no id, the method and the path, e.g. "GET /pets".
+	ID          string
+	Method      string
+	Path        string
+	Summary     string
+	Description string
+	Deprecated  bool
+	// Parameters are the path, query, header and cookie parameters. Every parameter has a schema.
+	Parameters      []*Parameter
+	HasBody         bool
+	BodyRequired    bool
+	BodyDescription string
+	// BodySchema is the schema of the JSON request body, if any.
+	BodySchema *jsonschema.Schema
+	// Security are the alternative security requirements of the operation. An empty requirement
+	// means that the operation can be called without authentication.
+	Security []SecurityRequirement
+}
+
+func newOperation(method, path string, pathParameters []*Parameter, specOp *SpecOperation, security []SecurityRequirement) *Operation {
+	op := &Operation{
+		ID:          specOp.OperationID,
+		Method:      method,
+		Path:        path,
+		Summary:     specOp.Summary,
+		Description: specOp.Description,
+		Deprecated:  specOp.Deprecated,
+		Security:    security,
+	}
+
+	if op.ID == "" {
+		op.ID = fmt.Sprintf("%s %s", method, path)
+	}
+
+	if specOp.Security != nil {
+		op.Security = *specOp.Security
+	}
+
+	for _, p := range append(append([]*Parameter{}, pathParameters...), specOp.Parameters...) {
+		if p == nil {
+			continue
+		}
+
+		switch p.In {
+		case "body":
+			// Swagger 2 describes the request body as parameter.
+			op.HasBody = true
+			op.BodyRequired = p.Required
+			op.BodyDescription = p.Description
+			op.BodySchema = p.Schema
+		case "formData":
+			// Form parameters are not supported.
+		default:
+			param := *p
+			if param.Schema == nil {
+				param.Schema = &jsonschema.Schema{Type: p.Type, Items: p.Items, Enum: p.Enum}
+			}
+
+			// Parameters of the operation override the parameters of the path.
+			if i := op.parameterIndex(param.Name, param.In); i >= 0 {
+				op.Parameters[i] = &param
+			} else {
+				op.Parameters = append(op.Parameters, &param)
+			}
+		}
+	}
+
+	if specOp.RequestBody != nil {
+		op.HasBody = true
+		op.BodyRequired = specOp.RequestBody.Required
+		op.BodyDescription = specOp.RequestBody.Description
+
+		for mediaType, content := range specOp.RequestBody.Content {
+			if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+				op.BodySchema = content.Schema
+				break
+			}
+		}
+	}
+
+	return op
+}
+
+// Parameter returns the parameter with the name.
+func (op *Operation) Parameter(name string) (*Parameter, bool) {
+	for _, p := range op.Parameters {
+		if p.Name == name {
+			return p, true
+		}
+	}
+
+	return nil, false
+}
+
+// NewRequest creates the HTTP request of the operation with the parameters by name and the body,
+// which is encoded as JSON. The path is appended to the base URL.
+func (op *Operation) NewRequest(ctx context.Context, baseURL *url.URL, parameters map[string]any, body any) (*http.Request, error) {
+	for name := range parameters {
+		if _, ok := op.Parameter(name); !ok {
+			return nil, fmt.Errorf("unknown parameter of API operation %s: %s", op.ID, name)
+		}
+	}
+
+	path := op.Path
+	query := url.Values{}
+	header := http.Header{}
+	cookies := []*http.Cookie{}
+
+	for _, p := range op.Parameters {
+		v, ok := parameters[p.Name]
+		if !ok || v == nil {
+			if p.Required {
+				return nil, fmt.Errorf("missing required parameter of API operation %s: %s", op.ID, p.Name)
+			}
+
+			continue
+		}
+
+		switch p.In {
+		case "path":
+			value := formatValue(v)
+
+			// Dot segments are removed by JoinPath and slashes are decoded by many servers, so both
+			// could escape the path of the operation.
+			if value == "." || value == ".." || strings.ContainsAny(value, "/\\") {
+				return nil, fmt.Errorf("invalid path parameter of API operation %s: %s", op.ID, p.Name)
+			}
+
+			path = strings.ReplaceAll(path, fmt.Sprintf("{%s}", p.Name), url.PathEscape(value))
+		case "query":
+			if values, ok := v.([]any); ok {
+				for _, value := range values {
+					query.Add(p.Name, formatValue(value))
+				}
+			} else {
+				query.Set(p.Name, formatValue(v))
+			}
+		case "header":
+			header.Set(p.Name, formatValue(v))
+		case "cookie":
+			cookies = append(cookies, &http.Cookie{Name: p.Name, Value: formatValue(v)})
+		}
+	}
+
+	if op.BodyRequired && body == nil {
+		return nil, fmt.Errorf("missing required body of API operation %s", op.ID)
+	}
+
+	apiURL := baseURL.JoinPath(path)
+	apiURL.RawQuery = query.Encode()
+
+	var bodyReader io.Reader
+
+	if op.HasBody && body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+
+		bodyReader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, op.Method, apiURL.String(), bodyReader)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header = header
+
+	if bodyReader != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	for _, c := range cookies {
+		req.AddCookie(c)
+	}
+
+	return req, nil
+}
+
+// parameterIndex returns the index of the parameter with the name and location or -1.
+func (op *Operation) parameterIndex(name, in string) int {
+	for i, p := range op.Parameters {
+		if p.Name == name && p.In == in {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// formatValue formats a parameter value. Numbers are formatted without exponent.
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []any:
+		values := make([]string, len(v))
+		for i, e := range v {
+			values[i] = formatValue(e)
+		}
+
+		return strings.Join(values, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
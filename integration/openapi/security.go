@@ -0,0 +1,50 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// SecurityScheme is a security scheme of the specification.
+type SecurityScheme struct {
+	// Type is "apiKey", "http", "oauth2" or "openIdConnect" in OpenAPI 3 and "apiKey", "basic" or
+	// "oauth2" in Swagger 2.
+	Type string `json:"type"`
+	// Scheme is the HTTP authentication scheme of the type "http", e.g. "bearer" or "basic".
+	Scheme string `json:"scheme"`
+	// Name is the name of the header, query parameter or cookie of the type "apiKey".
+	Name string `json:"name"`
+	// In is the location of the key of the type "apiKey", i.e. "header", "query" or "cookie".
+	In          string `json:"in"`
+	Description string `json:"description"`
+}
+
+// Apply adds the credential to the request. The credential is the key of the type "apiKey", the
+// token of bearer and OAuth 2 authentication and "username:password" for basic authentication.
+func (s *SecurityScheme) Apply(req *http.Request, credential string) error {
+	switch {
+	case s.Type == "apiKey":
+		switch s.In {
+		case "header":
+			req.Header.Set(s.Name, credential)
+		case "query":
+			query := req.URL.Query()
+			query.Set(s.Name, credential)
+			req.URL.RawQuery = query.Encode()
+		case "cookie":
+			req.AddCookie(&http.Cookie{Name: s.Name, Value: credential})
+		default:
+			return fmt.Errorf("unsupported location of api key: %q", s.In)
+		}
+	case s.Type == "basic" || (s.Type == "http" && strings.EqualFold(s.Scheme, "basic")):
+		username, password, _ := strings.Cut(credential, ":")
+		req.SetBasicAuth(username, password)
+	case s.Type == "oauth2" || s.Type == "openIdConnect" || (s.Type == "http" && strings.EqualFold(s.Scheme, "bearer")):
+		req.Header.Set("Authorization", "Bearer "+credential)
+	default:
+		return fmt.Errorf("unsupported security scheme: %s %s", s.Type, s.Scheme)
+	}
+
+	return nil
+}
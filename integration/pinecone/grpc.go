@@ -3,8 +3,8 @@ package pinecone
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 
-	"github.com/google/uuid"
 	pc "github.com/pinecone-io/go-pinecone/pinecone_grpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -44,6 +44,10 @@ func (p *GRPCClient) Upsert(ctx context.Context, req *UpsertRequest) (*UpsertRes
 	pineconeVectors := make([]*pc.Vector, 0, len(req.Vectors))
 
 	for i := 0; i < len(req.Vectors); i++ {
+		if req.Vectors[i].SparseValues != nil {
+			return nil, errors.New("sparse values are not supported by the gRPC client")
+		}
+
 		metadataStruct, err := structpb.NewStruct(req.Vectors[i].Metadata)
 		if err != nil {
 			return nil, err
@@ -52,7 +56,7 @@ func (p *GRPCClient) Upsert(ctx context.Context, req *UpsertRequest) (*UpsertRes
 		pineconeVectors = append(
 			pineconeVectors,
 			&pc.Vector{
-				Id:       uuid.New().String(),
+				Id:       req.Vectors[i].ID,
 				Values:   req.Vectors[i].Values,
 				Metadata: metadataStruct,
 			},
@@ -101,6 +105,10 @@ func (p *GRPCClient) Fetch(ctx context.Context, req *FetchRequest) (*FetchRespon
 func (p *GRPCClient) Query(ctx context.Context, req *QueryRequest) (*QueryResponse, error) {
 	ctx = metadata.AppendToOutgoingContext(ctx, "api-key", p.apiKey)
 
+	if req.SparseVector != nil {
+		return nil, errors.New("sparse vectors are not supported by the gRPC client")
+	}
+
 	filterStruct, err := structpb.NewStruct(req.Filter)
 	if err != nil {
 		return nil, err
@@ -136,6 +144,22 @@ func (p *GRPCClient) Query(ctx context.Context, req *QueryRequest) (*QueryRespon
 	}, nil
 }
 
+func (p *GRPCClient) Delete(ctx context.Context, req *DeleteRequest) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, "api-key", p.apiKey)
+
+	if req.Filter != nil {
+		return errors.New("delete by filter is not supported by the gRPC client")
+	}
+
+	_, err := p.client.Delete(ctx, &pc.DeleteRequest{
+		Ids:       req.IDs,
+		DeleteAll: req.DeleteAll,
+		Namespace: req.Namespace,
+	})
+
+	return err
+}
+
 func (p *GRPCClient) Close() error {
 	return p.conn.Close()
 }
@@ -11,9 +11,16 @@ type Endpoint struct {
 	IndexName   string
 	ProjectName string
 	Environment string
+	// Host is the host of the index as shown in the Pinecone console, e.g. "golc-abc1234.svc.aped-4627-b74a.pinecone.io".
+	// It is required for serverless indexes and takes precedence over the pod-based index name, project name and environment.
+	Host string
 }
 
 func (e *Endpoint) String() string {
+	if e.Host != "" {
+		return fmt.Sprintf("%s:443", e.Host)
+	}
+
 	return fmt.Sprintf("%s-%s.svc.%s.pinecone.io:443", e.IndexName, e.ProjectName, e.Environment)
 }
 
@@ -25,6 +32,7 @@ type Client interface {
 	Upsert(ctx context.Context, req *UpsertRequest) (*UpsertResponse, error)
 	Fetch(ctx context.Context, req *FetchRequest) (*FetchResponse, error)
 	Query(ctx context.Context, req *QueryRequest) (*QueryResponse, error)
+	Delete(ctx context.Context, req *DeleteRequest) error
 	Close() error
 }
 
@@ -0,0 +1,19 @@
+package pinecone
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpoint(t *testing.T) {
+	t.Run("PodBased", func(t *testing.T) {
+		e := Endpoint{IndexName: "golc", ProjectName: "abc1234", Environment: "us-west1-gcp"}
+		assert.Equal(t, "golc-abc1234.svc.us-west1-gcp.pinecone.io:443", e.String())
+	})
+
+	t.Run("Serverless", func(t *testing.T) {
+		e := Endpoint{Host: "golc-abc1234.svc.aped-4627-b74a.pinecone.io"}
+		assert.Equal(t, "golc-abc1234.svc.aped-4627-b74a.pinecone.io:443", e.String())
+	})
+}
@@ -46,7 +46,7 @@ func (p *RestClient) Upsert(ctx context.Context, req *UpsertRequest) (*UpsertRes
 			return nil, err
 		}
 
-		return nil, fmt.Errorf("pincoe error: %s", errorResponse.Message)
+		return nil, fmt.Errorf("pinecone error: %s", errorResponse.Message)
 	}
 
 	upsertResponse := UpsertResponse{}
@@ -103,6 +103,15 @@ func (p *RestClient) Query(ctx context.Context, req *QueryRequest) (*QueryRespon
 		return nil, err
 	}
 
+	if res.StatusCode != 200 {
+		errorResponse := ErrorResponse{}
+		if err := json.Unmarshal(body, &errorResponse); err != nil {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("pinecone error: %s", errorResponse.Message)
+	}
+
 	queryResponse := QueryResponse{}
 	if err := json.Unmarshal(body, &queryResponse); err != nil {
 		return nil, err
@@ -111,6 +120,32 @@ func (p *RestClient) Query(ctx context.Context, req *QueryRequest) (*QueryRespon
 	return &queryResponse, nil
 }
 
+func (p *RestClient) Delete(ctx context.Context, req *DeleteRequest) error {
+	reqURL := fmt.Sprintf("https://%s/vectors/delete", p.target)
+
+	res, err := p.doRequest(ctx, http.MethodPost, reqURL, req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		body, err := io.ReadAll(res.Body)
+		if err != nil {
+			return err
+		}
+
+		errorResponse := ErrorResponse{}
+		if err := json.Unmarshal(body, &errorResponse); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("pinecone error: %s", errorResponse.Message)
+	}
+
+	return nil
+}
+
 func (p *RestClient) Close() error {
 	return nil
 }
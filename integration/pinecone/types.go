@@ -1,16 +1,23 @@
 package pinecone
 
+// SparseValues represents a sparse vector by the indices and values of its non-zero dimensions.
+type SparseValues struct {
+	Indices []uint32  `json:"indices"`
+	Values  []float32 `json:"values"`
+}
+
 type Vector struct {
-	ID       string         `json:"id"`
-	Values   []float32      `json:"values"`
-	Metadata map[string]any `json:"metadata"`
+	ID           string         `json:"id"`
+	Values       []float32      `json:"values"`
+	SparseValues *SparseValues  `json:"sparseValues,omitempty"`
+	Metadata     map[string]any `json:"metadata"`
 }
 
 // UpsertRequest represents the parameters for an upsert vectors request.
 // See https://docs.pinecone.io/reference/upsert for more informations.
 type UpsertRequest struct {
 	Vectors   []*Vector `json:"vectors"`
-	Namespace string    `json:"namespace"`
+	Namespace string    `json:"namespace,omitempty"`
 }
 
 // UpsertResponse represents the response from an upsert vectors request.
@@ -34,13 +41,23 @@ type FetchResponse struct {
 // QueryRequest represents the parameters for a query request.
 // See https://docs.pinecone.io/reference/query for more information.
 type QueryRequest struct {
-	Filter          map[string]any `json:"filter"`
+	Filter          map[string]any `json:"filter,omitempty"`
 	IncludeValues   bool           `json:"includeValues"`
 	IncludeMetadata bool           `json:"includeMetadata"`
-	Vector          []float32      `json:"vector"`
-	Namespace       string         `json:"namespace"`
+	Vector          []float32      `json:"vector,omitempty"`
+	SparseVector    *SparseValues  `json:"sparseVector,omitempty"`
+	Namespace       string         `json:"namespace,omitempty"`
 	TopK            int64          `json:"topK"`
-	ID              string         `json:"id"`
+	ID              string         `json:"id,omitempty"`
+}
+
+// DeleteRequest represents the parameters for a delete vectors request.
+// See https://docs.pinecone.io/reference/delete for more information.
+type DeleteRequest struct {
+	IDs       []string       `json:"ids,omitempty"`
+	DeleteAll bool           `json:"deleteAll,omitempty"`
+	Namespace string         `json:"namespace,omitempty"`
+	Filter    map[string]any `json:"filter,omitempty"`
 }
 
 type Match struct {
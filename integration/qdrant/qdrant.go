@@ -0,0 +1,173 @@
+// Package qdrant provides a client for the REST API of the Qdrant vector database.
+package qdrant
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// HTTPClient is an interface for making HTTP requests.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type ClientOptions struct {
+	// The HTTP client to use for making API requests.
+	HTTPClient HTTPClient
+	// APIKey is the API key of a Qdrant cloud cluster.
+	APIKey string
+}
+
+type Client struct {
+	apiURL string
+	opts   ClientOptions
+}
+
+// New creates a new Qdrant client for the given API url, e.g. http://localhost:6333.
+func New(apiURL string, optFns ...func(o *ClientOptions)) *Client {
+	opts := ClientOptions{
+		HTTPClient: http.DefaultClient,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Client{
+		apiURL: apiURL,
+		opts:   opts,
+	}
+}
+
+// CollectionExists checks whether the collection with the given name exists.
+func (c *Client) CollectionExists(ctx context.Context, collectionName string) (bool, error) {
+	result, err := c.doRequest(ctx, http.MethodGet, c.collectionURL(collectionName, "/exists"), nil)
+	if err != nil {
+		return false, err
+	}
+
+	exists := struct {
+		Exists bool `json:"exists"`
+	}{}
+	if err := json.Unmarshal(result, &exists); err != nil {
+		return false, err
+	}
+
+	return exists.Exists, nil
+}
+
+// CreateCollection creates a new collection with the given name.
+func (c *Client) CreateCollection(ctx context.Context, collectionName string, req *CreateCollectionRequest) error {
+	_, err := c.doRequest(ctx, http.MethodPut, c.collectionURL(collectionName, ""), req)
+
+	return err
+}
+
+// Upsert inserts or updates points of the collection and waits until the changes are applied.
+func (c *Client) Upsert(ctx context.Context, collectionName string, req *UpsertPointsRequest) error {
+	_, err := c.doRequest(ctx, http.MethodPut, c.collectionURL(collectionName, "/points?wait=true"), req)
+
+	return err
+}
+
+// Search returns the points of the collection closest to the query vector.
+func (c *Client) Search(ctx context.Context, collectionName string, req *SearchRequest) ([]ScoredPoint, error) {
+	result, err := c.doRequest(ctx, http.MethodPost, c.collectionURL(collectionName, "/points/search"), req)
+	if err != nil {
+		return nil, err
+	}
+
+	points := []ScoredPoint{}
+	if err := json.Unmarshal(result, &points); err != nil {
+		return nil, err
+	}
+
+	return points, nil
+}
+
+// Scroll returns a page of the points of the collection matching the filter.
+func (c *Client) Scroll(ctx context.Context, collectionName string, req *ScrollRequest) (*ScrollResponse, error) {
+	result, err := c.doRequest(ctx, http.MethodPost, c.collectionURL(collectionName, "/points/scroll"), req)
+	if err != nil {
+		return nil, err
+	}
+
+	scrollResponse := ScrollResponse{}
+	if err := json.Unmarshal(result, &scrollResponse); err != nil {
+		return nil, err
+	}
+
+	return &scrollResponse, nil
+}
+
+// DeletePoints deletes the points of the collection and waits until the changes are applied.
+func (c *Client) DeletePoints(ctx context.Context, collectionName string, req *DeletePointsRequest) error {
+	_, err := c.doRequest(ctx, http.MethodPost, c.collectionURL(collectionName, "/points/delete?wait=true"), req)
+
+	return err
+}
+
+// collectionURL returns the url of the collection with the given path suffix.
+func (c *Client) collectionURL(collectionName, suffix string) string {
+	return fmt.Sprintf("%s/collections/%s%s", c.apiURL, url.PathEscape(collectionName), suffix)
+}
+
+// doRequest sends an HTTP request to the specified URL with the given method and payload
+// and returns the result of the response envelope.
+func (c *Client) doRequest(ctx context.Context, method string, url string, payload any) ([]byte, error) {
+	var body io.Reader
+
+	if payload != nil {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+
+		body = bytes.NewReader(b)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	if c.opts.APIKey != "" {
+		httpReq.Header.Set("api-key", c.opts.APIKey)
+	}
+
+	res, err := c.opts.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		errorResponse := ErrorResponse{}
+		if err := json.Unmarshal(resBody, &errorResponse); err != nil || errorResponse.Status.Error == "" {
+			return nil, fmt.Errorf("qdrant API error: %s", res.Status)
+		}
+
+		return nil, fmt.Errorf("qdrant API error: %s", errorResponse.Status.Error)
+	}
+
+	envelope := response{}
+	if err := json.Unmarshal(resBody, &envelope); err != nil {
+		return nil, err
+	}
+
+	return envelope.Result, nil
+}
@@ -0,0 +1,67 @@
+package qdrant
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	t.Run("CreateCollection", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/collections/test", r.URL.Path)
+			assert.Equal(t, "key", r.Header.Get("api-key"))
+
+			req := map[string]any{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, map[string]any{
+				"text": map[string]any{"size": float64(3), "distance": "Cosine"},
+			}, req["vectors"])
+
+			_, _ = w.Write([]byte(`{"result":true,"status":"ok","time":0.1}`))
+		}))
+		defer server.Close()
+
+		client := New(server.URL, func(o *ClientOptions) {
+			o.APIKey = "key"
+		})
+
+		err := client.CreateCollection(context.Background(), "test", &CreateCollectionRequest{
+			Vectors: VectorsConfig{Named: map[string]VectorParams{"text": {Size: 3, Distance: DistanceCosine}}},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/collections/test/points/search", r.URL.Path)
+
+			_, _ = w.Write([]byte(`{"result":[{"id":"1","score":0.9,"payload":{"page_content":"document1"}}],"status":"ok","time":0.1}`))
+		}))
+		defer server.Close()
+
+		points, err := New(server.URL).Search(context.Background(), "test", &SearchRequest{
+			Vector: []float32{1, 2, 3},
+			Limit:  1,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []ScoredPoint{{ID: "1", Score: 0.9, Payload: map[string]any{"page_content": "document1"}}}, points)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"status":{"error":"Collection test not found"},"time":0.1}`))
+		}))
+		defer server.Close()
+
+		_, err := New(server.URL).Search(context.Background(), "test", &SearchRequest{})
+		assert.EqualError(t, err, "qdrant API error: Collection test not found")
+	})
+}
@@ -0,0 +1,152 @@
+package qdrant
+
+import "encoding/json"
+
+// Distance is the distance function used to compare vectors.
+type Distance string
+
+const (
+	DistanceCosine    Distance = "Cosine"
+	DistanceEuclidean Distance = "Euclid"
+	DistanceDot       Distance = "Dot"
+	DistanceManhattan Distance = "Manhattan"
+)
+
+// VectorParams contains the parameters of a vector of a collection.
+type VectorParams struct {
+	Size     int      `json:"size"`
+	Distance Distance `json:"distance"`
+}
+
+// VectorsConfig contains the vector parameters of a collection. Either Params for a single unnamed
+// vector or Named for named vectors must be set.
+type VectorsConfig struct {
+	Params *VectorParams
+	Named  map[string]VectorParams
+}
+
+// MarshalJSON encodes the config as unnamed vector parameters or as map of named vector parameters.
+func (c VectorsConfig) MarshalJSON() ([]byte, error) {
+	if c.Named != nil {
+		return json.Marshal(c.Named)
+	}
+
+	return json.Marshal(c.Params)
+}
+
+// CreateCollectionRequest represents the parameters for a create collection request.
+// See https://api.qdrant.tech/api-reference/collections/create-collection for more information.
+type CreateCollectionRequest struct {
+	Vectors VectorsConfig `json:"vectors"`
+}
+
+// Point represents a point with its id, vector and payload. The vector is either a []float32 or,
+// for collections with named vectors, a map[string][]float32.
+type Point struct {
+	ID      string         `json:"id"`
+	Vector  any            `json:"vector"`
+	Payload map[string]any `json:"payload,omitempty"`
+}
+
+// UpsertPointsRequest represents the parameters for an upsert points request.
+// See https://api.qdrant.tech/api-reference/points/upsert-points for more information.
+type UpsertPointsRequest struct {
+	Points []Point `json:"points"`
+}
+
+// Match contains the value a payload field must match.
+type Match struct {
+	Value any `json:"value"`
+}
+
+// Range contains the bounds of a numeric payload field.
+type Range struct {
+	GT  *float64 `json:"gt,omitempty"`
+	GTE *float64 `json:"gte,omitempty"`
+	LT  *float64 `json:"lt,omitempty"`
+	LTE *float64 `json:"lte,omitempty"`
+}
+
+// Condition is a condition on the payload field with the given key.
+type Condition struct {
+	Key   string `json:"key"`
+	Match *Match `json:"match,omitempty"`
+	Range *Range `json:"range,omitempty"`
+}
+
+// Filter is a payload filter. All Must conditions, at least one of the Should conditions and
+// none of the MustNot conditions have to be satisfied.
+// See https://qdrant.tech/documentation/concepts/filtering/ for more information.
+type Filter struct {
+	Must    []Condition `json:"must,omitempty"`
+	Should  []Condition `json:"should,omitempty"`
+	MustNot []Condition `json:"must_not,omitempty"`
+}
+
+// NamedVector is a query vector for the named vector of a collection.
+type NamedVector struct {
+	Name   string    `json:"name"`
+	Vector []float32 `json:"vector"`
+}
+
+// SearchRequest represents the parameters for a search points request. The vector is either
+// a []float32 or a NamedVector.
+// See https://api.qdrant.tech/api-reference/search/points for more information.
+type SearchRequest struct {
+	Vector         any      `json:"vector"`
+	Filter         *Filter  `json:"filter,omitempty"`
+	Limit          int      `json:"limit"`
+	WithPayload    bool     `json:"with_payload"`
+	WithVector     bool     `json:"with_vector,omitempty"`
+	ScoreThreshold *float32 `json:"score_threshold,omitempty"`
+}
+
+// ScoredPoint represents a point found by a search with its score.
+type ScoredPoint struct {
+	ID      any            `json:"id"`
+	Score   float32        `json:"score"`
+	Payload map[string]any `json:"payload"`
+	// Vector is the vector of the point, a list of floats or an object of named vectors, if requested.
+	Vector json.RawMessage `json:"vector,omitempty"`
+}
+
+// ScrollRequest represents the parameters for a scroll points request.
+// See https://api.qdrant.tech/api-reference/points/scroll-points for more information.
+type ScrollRequest struct {
+	Filter      *Filter `json:"filter,omitempty"`
+	Limit       int     `json:"limit,omitempty"`
+	Offset      any     `json:"offset,omitempty"`
+	WithPayload bool    `json:"with_payload"`
+	WithVector  bool    `json:"with_vector"`
+}
+
+// Record represents a point returned by a scroll request.
+type Record struct {
+	ID      any            `json:"id"`
+	Payload map[string]any `json:"payload"`
+}
+
+// ScrollResponse represents the response from a scroll points request. NextPageOffset is nil
+// on the last page.
+type ScrollResponse struct {
+	Points         []Record `json:"points"`
+	NextPageOffset any      `json:"next_page_offset"`
+}
+
+// DeletePointsRequest represents the parameters for a delete points request.
+// See https://api.qdrant.tech/api-reference/points/delete-points for more information.
+type DeletePointsRequest struct {
+	Points []any `json:"points"`
+}
+
+// response is the envelope of successful Qdrant API responses.
+type response struct {
+	Result json.RawMessage `json:"result"`
+}
+
+// ErrorResponse represents the response of a failed request.
+type ErrorResponse struct {
+	Status struct {
+		Error string `json:"error"`
+	} `json:"status"`
+}
@@ -0,0 +1,239 @@
+// Package replicate provides a client for the Replicate prediction API.
+package replicate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hupe1980/golc/integration/stream"
+)
+
+// HTTPClient is an interface for making HTTP requests.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// ClientOptions contains options for configuring the Replicate client.
+type ClientOptions struct {
+	// The HTTP client to use for making API requests.
+	HTTPClient HTTPClient
+	// The base url of the Replicate API.
+	APIUrl string
+	// PollInterval is the interval between two status requests when waiting for a prediction.
+	PollInterval time.Duration
+}
+
+// Client is a client for the Replicate API.
+type Client struct {
+	apiToken string
+	opts     ClientOptions
+}
+
+// New creates a new Replicate client with the given api token.
+func New(apiToken string, optFns ...func(o *ClientOptions)) *Client {
+	opts := ClientOptions{
+		HTTPClient:   http.DefaultClient,
+		APIUrl:       "https://api.replicate.com/v1",
+		PollInterval: time.Second,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Client{
+		apiToken: apiToken,
+		opts:     opts,
+	}
+}
+
+// CreatePrediction creates a prediction for the model. The model is either an "owner/name" identifier
+// of an official model or an "owner/name:version" identifier of a specific model version.
+func (c *Client) CreatePrediction(ctx context.Context, model string, req *PredictionRequest) (*Prediction, error) {
+	url := fmt.Sprintf("%s/predictions", c.opts.APIUrl)
+
+	owner, name, version, err := ParseModel(model)
+	if err != nil {
+		return nil, err
+	}
+
+	if version != "" {
+		req.Version = version
+	} else {
+		url = fmt.Sprintf("%s/models/%s/%s/predictions", c.opts.APIUrl, owner, name)
+	}
+
+	prediction := &Prediction{}
+	if err := c.doRequest(ctx, http.MethodPost, url, req, prediction); err != nil {
+		return nil, err
+	}
+
+	return prediction, nil
+}
+
+// GetPrediction returns the prediction with the given id.
+func (c *Client) GetPrediction(ctx context.Context, id string) (*Prediction, error) {
+	prediction := &Prediction{}
+	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("%s/predictions/%s", c.opts.APIUrl, id), nil, prediction); err != nil {
+		return nil, err
+	}
+
+	return prediction, nil
+}
+
+// WaitForPrediction polls the prediction until it has terminated.
+func (c *Client) WaitForPrediction(ctx context.Context, prediction *Prediction) (*Prediction, error) {
+	for !prediction.Status.Terminated() {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(c.opts.PollInterval):
+		}
+
+		var err error
+
+		prediction, err = c.GetPrediction(ctx, prediction.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return prediction, nil
+}
+
+// StreamPrediction opens the output stream of a prediction created with streaming enabled.
+// The returned stream must be closed after use.
+func (c *Client) StreamPrediction(ctx context.Context, prediction *Prediction) (*PredictionStream, error) {
+	if prediction.URLs.Stream == "" {
+		return nil, errors.New("replicate: prediction does not support streaming")
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, prediction.URLs.Stream, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Accept", "text/event-stream")
+	httpReq.Header.Set("Cache-Control", "no-store")
+	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiToken))
+
+	res, err := c.opts.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		return nil, newAPIError(res)
+	}
+
+	return NewPredictionStream(res.Body), nil
+}
+
+// doRequest sends a request to the Replicate API and decodes the response into v.
+func (c *Client) doRequest(ctx context.Context, method, url string, payload any, v any) error {
+	var body io.Reader
+
+	if payload != nil {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(b)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiToken))
+
+	res, err := c.opts.HTTPClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return newAPIError(res)
+	}
+
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+// newAPIError creates an error from the error response.
+func newAPIError(res *http.Response) error {
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	errorResponse := ErrorResponse{}
+	if err := json.Unmarshal(resBody, &errorResponse); err != nil || errorResponse.Detail == "" {
+		return fmt.Errorf("replicate API error: unexpected status code %d: %s", res.StatusCode, string(resBody))
+	}
+
+	return fmt.Errorf("replicate API error: %s", errorResponse.Detail)
+}
+
+// ParseModel splits a model identifier of the form "owner/name" or "owner/name:version" into its parts.
+func ParseModel(model string) (owner, name, version string, err error) {
+	model, version, _ = strings.Cut(model, ":")
+
+	owner, name, ok := strings.Cut(model, "/")
+	if !ok || owner == "" || name == "" {
+		return "", "", "", fmt.Errorf("replicate: invalid model identifier: %s", model)
+	}
+
+	return owner, name, version, nil
+}
+
+// PredictionStream is a reader for the server-sent events of a prediction stream.
+type PredictionStream struct {
+	reader *stream.EventReader
+	closer io.Closer
+}
+
+// NewPredictionStream creates a new PredictionStream reading the server-sent events from the reader.
+func NewPredictionStream(reader io.ReadCloser) *PredictionStream {
+	return &PredictionStream{
+		reader: stream.NewEventReader(reader),
+		closer: reader,
+	}
+}
+
+// Recv reads the next output event from the stream. It returns io.EOF after the "done" event.
+func (s *PredictionStream) Recv() (*StreamEvent, error) {
+	for {
+		event, err := s.reader.Recv()
+		if err != nil {
+			return nil, err
+		}
+
+		switch event.Type {
+		case "done":
+			return nil, io.EOF
+		case "error":
+			return nil, fmt.Errorf("replicate API error: %s", event.Data)
+		case "output":
+			return &StreamEvent{Type: event.Type, Data: event.Data}, nil
+		}
+	}
+}
+
+// Close closes the underlying stream.
+func (s *PredictionStream) Close() error {
+	return s.closer.Close()
+}
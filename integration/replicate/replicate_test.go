@@ -0,0 +1,139 @@
+package replicate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	t.Run("CreatePrediction", func(t *testing.T) {
+		t.Run("Version", func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/predictions", r.URL.Path)
+				assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+				req := PredictionRequest{}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				assert.Equal(t, "abc123", req.Version)
+				assert.Equal(t, "Hello", req.Input["prompt"])
+
+				_, _ = w.Write([]byte(`{"id":"p1","status":"starting"}`))
+			}))
+			defer server.Close()
+
+			client := New("token", func(o *ClientOptions) {
+				o.APIUrl = server.URL
+			})
+
+			prediction, err := client.CreatePrediction(context.Background(), "meta/llama-2-7b-chat:abc123", &PredictionRequest{
+				Input: map[string]any{"prompt": "Hello"},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, "p1", prediction.ID)
+			assert.Equal(t, PredictionStatusStarting, prediction.Status)
+		})
+
+		t.Run("Official model", func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/models/meta/meta-llama-3-8b-instruct/predictions", r.URL.Path)
+				_, _ = w.Write([]byte(`{"id":"p1","status":"starting"}`))
+			}))
+			defer server.Close()
+
+			client := New("token", func(o *ClientOptions) {
+				o.APIUrl = server.URL
+			})
+
+			_, err := client.CreatePrediction(context.Background(), "meta/meta-llama-3-8b-instruct", &PredictionRequest{})
+			require.NoError(t, err)
+		})
+
+		t.Run("Invalid model", func(t *testing.T) {
+			_, err := New("token").CreatePrediction(context.Background(), "llama", &PredictionRequest{})
+			assert.EqualError(t, err, "replicate: invalid model identifier: llama")
+		})
+
+		t.Run("Error", func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = w.Write([]byte(`{"title":"Invalid version","detail":"The specified version does not exist","status":422}`))
+			}))
+			defer server.Close()
+
+			client := New("token", func(o *ClientOptions) {
+				o.APIUrl = server.URL
+			})
+
+			_, err := client.CreatePrediction(context.Background(), "meta/llama-2-7b-chat:abc123", &PredictionRequest{})
+			assert.EqualError(t, err, "replicate API error: The specified version does not exist")
+		})
+	})
+
+	t.Run("WaitForPrediction", func(t *testing.T) {
+		calls := 0
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/predictions/p1", r.URL.Path)
+
+			calls++
+			if calls < 2 {
+				_, _ = w.Write([]byte(`{"id":"p1","status":"processing"}`))
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"id":"p1","status":"succeeded","output":["Hello"," world"]}`))
+		}))
+		defer server.Close()
+
+		client := New("token", func(o *ClientOptions) {
+			o.APIUrl = server.URL
+			o.PollInterval = time.Millisecond
+		})
+
+		prediction, err := client.WaitForPrediction(context.Background(), &Prediction{ID: "p1", Status: PredictionStatusStarting})
+		require.NoError(t, err)
+		assert.Equal(t, PredictionStatusSucceeded, prediction.Status)
+		assert.JSONEq(t, `["Hello"," world"]`, string(prediction.Output))
+	})
+
+	t.Run("StreamPrediction", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+
+			fmt.Fprint(w, "event: output\nid: 1\ndata: Hello\n\n")
+			fmt.Fprint(w, "event: output\nid: 2\ndata:  world\n\n")
+			fmt.Fprint(w, "event: done\ndata: {}\n\n")
+		}))
+		defer server.Close()
+
+		stream, err := New("token").StreamPrediction(context.Background(), &Prediction{URLs: PredictionURLs{Stream: server.URL}})
+		require.NoError(t, err)
+
+		defer stream.Close()
+
+		tokens := []string{}
+
+		for {
+			event, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			require.NoError(t, err)
+
+			tokens = append(tokens, event.Data)
+		}
+
+		assert.Equal(t, []string{"Hello", " world"}, tokens)
+	})
+}
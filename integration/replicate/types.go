@@ -0,0 +1,66 @@
+package replicate
+
+import "encoding/json"
+
+// PredictionStatus represents the status of a prediction.
+type PredictionStatus string
+
+const (
+	PredictionStatusStarting   PredictionStatus = "starting"
+	PredictionStatusProcessing PredictionStatus = "processing"
+	PredictionStatusSucceeded  PredictionStatus = "succeeded"
+	PredictionStatusFailed     PredictionStatus = "failed"
+	PredictionStatusCanceled   PredictionStatus = "canceled"
+)
+
+// Terminated reports whether the prediction has finished, either successfully or not.
+func (s PredictionStatus) Terminated() bool {
+	return s == PredictionStatusSucceeded || s == PredictionStatusFailed || s == PredictionStatusCanceled
+}
+
+// PredictionRequest represents a request to create a prediction.
+type PredictionRequest struct {
+	// Version is the id of the model version. It is omitted for predictions of official models.
+	Version string `json:"version,omitempty"`
+	// Input is the input of the model.
+	Input map[string]any `json:"input"`
+	// Stream requests a stream url for the output of the prediction.
+	Stream bool `json:"stream,omitempty"`
+}
+
+// PredictionURLs contains the urls of a prediction.
+type PredictionURLs struct {
+	Get    string `json:"get"`
+	Cancel string `json:"cancel"`
+	Stream string `json:"stream,omitempty"`
+}
+
+// Prediction represents a prediction.
+type Prediction struct {
+	ID      string           `json:"id"`
+	Model   string           `json:"model"`
+	Version string           `json:"version"`
+	Status  PredictionStatus `json:"status"`
+	Input   map[string]any   `json:"input"`
+	// Output is the output of the model. Its format depends on the model.
+	Output  json.RawMessage    `json:"output,omitempty"`
+	Error   any                `json:"error,omitempty"`
+	Logs    string             `json:"logs,omitempty"`
+	Metrics map[string]float64 `json:"metrics,omitempty"`
+	URLs    PredictionURLs     `json:"urls"`
+}
+
+// ErrorResponse represents an error response of the Replicate API.
+type ErrorResponse struct {
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+	Status int    `json:"status"`
+}
+
+// StreamEvent represents a server-sent event of a prediction stream.
+type StreamEvent struct {
+	// Type is the type of the event, one of "output", "error" or "done".
+	Type string
+	// Data is the payload of the event.
+	Data string
+}
@@ -38,6 +38,18 @@ func NewMySQL(dataSourceName string, optFns ...func(o *MySQLOptions)) (*MySQL, e
 		return nil, err
 	}
 
+	engine, err := NewMySQLFromDB(db)
+	if err != nil {
+		return nil, err
+	}
+
+	engine.opts = opts
+
+	return engine, nil
+}
+
+// NewMySQLFromDB creates a new instance of the MySQL database engine from an existing database handle.
+func NewMySQLFromDB(db *sql.DB) (*MySQL, error) {
 	driver, err := mysql.Open(db)
 	if err != nil {
 		return nil, fmt.Errorf("failed opening atlas driver: %s", err)
@@ -46,7 +58,6 @@ func NewMySQL(dataSourceName string, optFns ...func(o *MySQLOptions)) (*MySQL, e
 	return &MySQL{
 		db:    db,
 		atlas: &atlas{driver: driver},
-		opts:  opts,
 	}, nil
 }
 
@@ -65,6 +76,11 @@ func (e *MySQL) Query(ctx context.Context, query string, args ...any) (*sql.Rows
 	return e.db.QueryContext(ctx, query, args...)
 }
 
+// BeginTx starts a transaction with the provided options (opts).
+func (e *MySQL) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return e.db.BeginTx(ctx, opts)
+}
+
 // QueryRow executes an SQL query with the provided query string and arguments (args), returning a single row and any errors encountered.
 func (e *MySQL) QueryRow(ctx context.Context, query string, args ...any) *sql.Row {
 	return e.db.QueryRowContext(ctx, query, args...)
@@ -1,6 +1,7 @@
 package sqldb
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 	"unicode"
@@ -114,9 +115,15 @@ func (p *Parser) after(iWord int) (atAfter string) {
 }
 
 func cleanName(name string) string {
-	name = strings.Fields(name)[0]
-	name = strings.TrimSpace(name)
-	name = strings.Trim(name, "`")
+	fields := strings.Fields(name)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	name = strings.Trim(fields[0], "`")
+	if name == "" {
+		return ""
+	}
 
 	lastRune := name[len(name)-1]
 	if lastRune == ';' {
@@ -125,3 +132,146 @@ func cleanName(name string) string {
 
 	return name
 }
+
+// CommonTableExpression is a named subquery of the WITH clause of a query.
+type CommonTableExpression struct {
+	// Name is the name of the common table expression.
+	Name string
+	// Query is the subquery of the common table expression.
+	Query string
+}
+
+// SplitWith splits a query into the common table expressions of its WITH clause and the statement
+// following the clause. Queries without WITH clause are returned as statement. The recursive flag
+// reports a WITH RECURSIVE clause.
+func SplitWith(query string) (ctes []CommonTableExpression, statement string, recursive bool, err error) {
+	query = CleanQuery(query)
+
+	word, pos := nextWord(query, 0)
+	if !strings.EqualFold(word, "with") {
+		return nil, query, false, nil
+	}
+
+	invalid := fmt.Errorf("invalid WITH clause: %s", query)
+
+	if word, next := nextWord(query, pos); strings.EqualFold(word, "recursive") {
+		recursive, pos = true, next
+	}
+
+	for {
+		var name string
+
+		name, pos = nextWord(query, pos)
+		if name == "" {
+			return nil, "", false, invalid
+		}
+
+		pos = skipSpaces(query, pos)
+
+		// Skip the optional column list.
+		if pos < len(query) && query[pos] == '(' {
+			end := closingParen(query, pos)
+			if end < 0 {
+				return nil, "", false, invalid
+			}
+
+			pos = end + 1
+		}
+
+		word, pos = nextWord(query, pos)
+		if !strings.EqualFold(word, "as") {
+			return nil, "", false, invalid
+		}
+
+		// Skip the optional [NOT] MATERIALIZED hint.
+		for {
+			word, next := nextWord(query, pos)
+			if !strings.EqualFold(word, "not") && !strings.EqualFold(word, "materialized") {
+				break
+			}
+
+			pos = next
+		}
+
+		pos = skipSpaces(query, pos)
+		if pos >= len(query) || query[pos] != '(' {
+			return nil, "", false, invalid
+		}
+
+		end := closingParen(query, pos)
+		if end < 0 {
+			return nil, "", false, invalid
+		}
+
+		ctes = append(ctes, CommonTableExpression{
+			Name:  strings.Trim(name, "`\""),
+			Query: strings.TrimSpace(query[pos+1 : end]),
+		})
+
+		pos = skipSpaces(query, end+1)
+		if pos >= len(query) || query[pos] != ',' {
+			break
+		}
+
+		pos++
+	}
+
+	statement = strings.TrimSpace(query[pos:])
+	if statement == "" {
+		return nil, "", false, invalid
+	}
+
+	return ctes, statement, recursive, nil
+}
+
+// nextWord returns the next word of the query starting at pos and the position after the word.
+// Words end at spaces, parentheses and commas.
+func nextWord(query string, pos int) (string, int) {
+	pos = skipSpaces(query, pos)
+
+	start := pos
+	for pos < len(query) && !strings.ContainsRune(" (),", rune(query[pos])) {
+		pos++
+	}
+
+	return query[start:pos], pos
+}
+
+// skipSpaces returns the position of the next character of the query, which is not a space.
+func skipSpaces(query string, pos int) int {
+	for pos < len(query) && query[pos] == ' ' {
+		pos++
+	}
+
+	return pos
+}
+
+// closingParen returns the position of the parenthesis closing the one at open, or -1 if it is not closed.
+// Parentheses in quoted strings and identifiers are ignored.
+func closingParen(query string, open int) int {
+	depth := 0
+
+	var quote byte
+
+	for i := open; i < len(query); i++ {
+		c := query[i]
+
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
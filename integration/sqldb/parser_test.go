@@ -132,3 +132,41 @@ func TestCleanQuery(t *testing.T) {
 		require.Equal(t, tt.cleaned, CleanQuery(tt.query))
 	}
 }
+
+func TestSplitWith(t *testing.T) {
+	t.Run("NoWith", func(t *testing.T) {
+		ctes, statement, recursive, err := SplitWith("SELECT * FROM customers")
+		require.NoError(t, err)
+		require.Empty(t, ctes)
+		require.Equal(t, "SELECT * FROM customers", statement)
+		require.False(t, recursive)
+	})
+
+	t.Run("With", func(t *testing.T) {
+		ctes, statement, recursive, err := SplitWith(`WITH a AS (SELECT id FROM customers WHERE name = ')'),
+			"b" (x) AS MATERIALIZED (SELECT (1) FROM a) SELECT * FROM b`)
+		require.NoError(t, err)
+		require.Equal(t, []CommonTableExpression{
+			{Name: "a", Query: "SELECT id FROM customers WHERE name = ')'"},
+			{Name: "b", Query: "SELECT (1) FROM a"},
+		}, ctes)
+		require.Equal(t, "SELECT * FROM b", statement)
+		require.False(t, recursive)
+	})
+
+	t.Run("Recursive", func(t *testing.T) {
+		ctes, statement, recursive, err := SplitWith("with recursive cnt(x) as (select 1 union all select x+1 from cnt) select x from cnt")
+		require.NoError(t, err)
+		require.Len(t, ctes, 1)
+		require.Equal(t, "cnt", ctes[0].Name)
+		require.Equal(t, "select x from cnt", statement)
+		require.True(t, recursive)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, query := range []string{"WITH a (SELECT 1) SELECT 1", "WITH a AS (SELECT 1", "WITH a AS (SELECT 1)"} {
+			_, _, _, err := SplitWith(query)
+			require.Error(t, err, query)
+		}
+	})
+}
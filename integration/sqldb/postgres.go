@@ -38,6 +38,18 @@ func NewPostgres(dataSourceName string, optFns ...func(o *PostgresOptions)) (*Po
 		return nil, err
 	}
 
+	engine, err := NewPostgresFromDB(db)
+	if err != nil {
+		return nil, err
+	}
+
+	engine.opts = opts
+
+	return engine, nil
+}
+
+// NewPostgresFromDB creates a new instance of the Postgres database engine from an existing database handle.
+func NewPostgresFromDB(db *sql.DB) (*Postgres, error) {
 	driver, err := postgres.Open(db)
 	if err != nil {
 		return nil, fmt.Errorf("failed opening atlas driver: %s", err)
@@ -46,7 +58,6 @@ func NewPostgres(dataSourceName string, optFns ...func(o *PostgresOptions)) (*Po
 	return &Postgres{
 		db:    db,
 		atlas: &atlas{driver: driver},
-		opts:  opts,
 	}, nil
 }
 
@@ -65,6 +76,11 @@ func (e *Postgres) Query(ctx context.Context, query string, args ...any) (*sql.R
 	return e.db.QueryContext(ctx, query, args...)
 }
 
+// BeginTx starts a transaction with the provided options (opts).
+func (e *Postgres) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return e.db.BeginTx(ctx, opts)
+}
+
 // QueryRow executes an SQL query with the provided query string and arguments (args), returning a single row and any errors encountered.
 func (e *Postgres) QueryRow(ctx context.Context, query string, args ...any) *sql.Row {
 	return e.db.QueryRowContext(ctx, query, args...)
@@ -5,6 +5,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
 	"strings"
 
 	"ariga.io/atlas/sql/migrate"
@@ -36,6 +37,29 @@ type Engine interface {
 	Close() error
 }
 
+// TxBeginner is implemented by engines that support transactions.
+type TxBeginner interface {
+	// BeginTx starts a transaction with the provided options (opts).
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// NewEngineFromDB creates the database engine for an existing database handle. The engine is
+// selected based on the driver of the handle. Supported are the drivers for SQLite3, Postgres and MySQL.
+func NewEngineFromDB(db *sql.DB) (Engine, error) {
+	driver := strings.ToLower(fmt.Sprintf("%T", db.Driver()))
+
+	switch {
+	case strings.Contains(driver, "sqlite"):
+		return NewSQLite3FromDB(db)
+	case strings.Contains(driver, "mysql"):
+		return NewMySQLFromDB(db)
+	case strings.Contains(driver, "pq."), strings.Contains(driver, "pgx"), strings.Contains(driver, "stdlib."):
+		return NewPostgresFromDB(db)
+	default:
+		return nil, fmt.Errorf("unsupported sql driver: %T", db.Driver())
+	}
+}
+
 // SQLDBOptions holds options for the SQLDB.
 type SQLDBOptions struct {
 	Schema                string
@@ -73,17 +97,85 @@ func (db *SQLDB) Dialect() string {
 
 // TableInfo retrieves information about the tables in the database.
 func (db *SQLDB) TableInfo(ctx context.Context) (string, error) {
+	return db.tableInfo(ctx, db.opts.Tables)
+}
+
+// DescribeTables retrieves information about the given tables. Tables that are not allowed by the
+// Tables and Exclude options are omitted.
+func (db *SQLDB) DescribeTables(ctx context.Context, tables []string) (string, error) {
+	allowed := make([]string, 0, len(tables))
+
+	for _, t := range tables {
+		if db.CheckTables([]string{t}) == nil {
+			allowed = append(allowed, t)
+		}
+	}
+
+	if len(allowed) == 0 {
+		return "", fmt.Errorf("not allowed tables: %s", strings.Join(tables, ", "))
+	}
+
+	return db.tableInfo(ctx, allowed)
+}
+
+// TableNames returns the sorted names of the tables in the database, that are allowed by the Tables and Exclude options.
+func (db *SQLDB) TableNames(ctx context.Context) ([]string, error) {
 	createStmts, err := db.engine.Inspect(ctx, db.opts.Schema, &schema.InspectOptions{
-		Tables:  db.opts.Tables,
-		Exclude: db.opts.Exclude,
+		Tables:  append([]string(nil), db.opts.Tables...),
+		Exclude: append([]string(nil), db.opts.Exclude...),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(createStmts))
+	for k := range createStmts {
+		names = append(names, k)
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// CheckTables returns an error, if one of the tables is not allowed by the Tables and Exclude options.
+func (db *SQLDB) CheckTables(tables []string) error {
+	for _, t := range tables {
+		name := strings.ToLower(t)
+
+		if len(db.opts.Tables) > 0 && !containsFold(db.opts.Tables, name) {
+			return fmt.Errorf("not allowed table: %s", t)
+		}
+
+		if containsFold(db.opts.Exclude, name) {
+			return fmt.Errorf("not allowed table: %s", t)
+		}
+	}
+
+	return nil
+}
+
+// tableInfo retrieves the create statements and sample rows of the given tables. All tables are
+// included, if tables is empty. The inspection may qualify the table patterns in place, so copies are passed.
+func (db *SQLDB) tableInfo(ctx context.Context, tables []string) (string, error) {
+	createStmts, err := db.engine.Inspect(ctx, db.opts.Schema, &schema.InspectOptions{
+		Tables:  append([]string(nil), tables...),
+		Exclude: append([]string(nil), db.opts.Exclude...),
 	})
 	if err != nil {
 		return "", err
 	}
 
+	names := make([]string, 0, len(createStmts))
+	for k := range createStmts {
+		names = append(names, k)
+	}
+
+	sort.Strings(names)
+
 	info := ""
-	for k, v := range createStmts {
-		info += fmt.Sprintf("%s\n\n", v)
+	for _, k := range names {
+		info += fmt.Sprintf("%s\n\n", createStmts[k])
 
 		if db.opts.SampleRowsinTableInfo > 0 {
 			sampleRows, err := db.sampleRows(ctx, k, db.opts.SampleRowsinTableInfo)
@@ -102,6 +194,8 @@ func (db *SQLDB) TableInfo(ctx context.Context) (string, error) {
 type QueryResult struct {
 	Columns []string
 	Rows    [][]string
+	// Truncated indicates whether rows or columns were omitted due to the limits of the query.
+	Truncated bool
 }
 
 // String returns the string representation of the QueryResult.
@@ -114,17 +208,67 @@ func (qr *QueryResult) String() string {
 	return str
 }
 
+// QueryOptions holds options for a query.
+type QueryOptions struct {
+	// Args are the arguments of the query.
+	Args []any
+	// ReadOnly indicates whether the query is executed in a read-only transaction, which is rolled back afterwards.
+	// The engine must implement the TxBeginner interface.
+	ReadOnly bool
+	// MaxRows is the maximum number of returned rows. A value <= 0 disables the limit.
+	MaxRows int
+	// MaxColumns is the maximum number of returned columns. A value <= 0 disables the limit.
+	MaxColumns int
+}
+
 // Query executes an SQL query and returns the result.
 func (db *SQLDB) Query(ctx context.Context, query string, args ...any) (*QueryResult, error) {
-	rows, err := db.engine.Query(ctx, query, args...)
+	return db.QueryWithOptions(ctx, query, func(o *QueryOptions) {
+		o.Args = args
+	})
+}
+
+// QueryWithOptions executes an SQL query with the provided options and returns the result.
+func (db *SQLDB) QueryWithOptions(ctx context.Context, query string, optFns ...func(o *QueryOptions)) (*QueryResult, error) {
+	opts := QueryOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if !opts.ReadOnly {
+		rows, err := db.engine.Query(ctx, query, opts.Args...)
+		if err != nil {
+			return nil, err
+		}
+
+		return scanRows(rows, opts.MaxRows, opts.MaxColumns)
+	}
+
+	beginner, ok := db.engine.(TxBeginner)
+	if !ok {
+		return nil, fmt.Errorf("engine %s does not support read-only transactions", db.engine.Dialect())
+	}
+
+	tx, err := beginner.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
 	if err != nil {
 		return nil, err
 	}
 
-	if rErr := rows.Err(); rErr != nil {
-		return nil, rErr
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	rows, err := tx.QueryContext(ctx, query, opts.Args...)
+	if err != nil {
+		return nil, err
 	}
 
+	return scanRows(rows, opts.MaxRows, opts.MaxColumns)
+}
+
+// scanRows scans the rows into a QueryResult with at most maxRows rows and maxColumns columns.
+func scanRows(rows *sql.Rows, maxRows, maxColumns int) (*QueryResult, error) {
 	defer rows.Close()
 
 	cols, err := rows.Columns()
@@ -132,35 +276,49 @@ func (db *SQLDB) Query(ctx context.Context, query string, args ...any) (*QueryRe
 		return nil, err
 	}
 
-	results := make([][]string, 0)
+	result := &QueryResult{
+		Columns: cols,
+		Rows:    make([][]string, 0),
+	}
+
+	if maxColumns > 0 && len(cols) > maxColumns {
+		result.Columns = cols[:maxColumns]
+		result.Truncated = true
+	}
 
 	for rows.Next() {
-		row := make([]string, len(cols))
+		if maxRows > 0 && len(result.Rows) == maxRows {
+			result.Truncated = true
+			break
+		}
+
 		rowNullable := make([]sql.NullString, len(cols))
 		rowPtrs := make([]any, len(cols))
 
-		for i := range row {
+		for i := range rowNullable {
 			rowPtrs[i] = &rowNullable[i]
 		}
 
-		err = rows.Scan(rowPtrs...)
-		if err != nil {
+		if err := rows.Scan(rowPtrs...); err != nil {
 			return nil, err
 		}
 
-		for i := range rowNullable {
+		row := make([]string, len(result.Columns))
+
+		for i := range row {
 			if rowNullable[i].Valid {
 				row[i] = rowNullable[i].String
 			}
 		}
 
-		results = append(results, row)
+		result.Rows = append(result.Rows, row)
 	}
 
-	return &QueryResult{
-		Columns: cols,
-		Rows:    results,
-	}, nil
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
 }
 
 // sampleRows retrieves a sample of rows from the given table.
@@ -184,6 +342,17 @@ func (db *SQLDB) Close() error {
 	return db.engine.Close()
 }
 
+// containsFold reports whether the names contain the name, ignoring the case.
+func containsFold(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // atlas represents the atlas migration driver.
 type atlas struct {
 	driver migrate.Driver
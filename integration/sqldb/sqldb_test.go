@@ -2,34 +2,35 @@ package sqldb
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 )
 
 func TestSQLDB(t *testing.T) {
-	sql, err := NewSQLite3(":memory:")
+	engine, err := NewSQLite3(":memory:")
 	require.NoError(t, err)
 
-	defer sql.Close()
+	defer engine.Close()
 
-	_, err = sql.Exec(context.Background(), "create table example ( id int not null, foo text );")
+	_, err = engine.Exec(context.Background(), "create table example ( id int not null, foo text );")
 	require.NoError(t, err)
 
-	sqldb, err := New(sql, func(o *SQLDBOptions) {
+	sqldb, err := New(engine, func(o *SQLDBOptions) {
 		o.SampleRowsinTableInfo = 2
 	})
 	require.NoError(t, err)
 
 	for i := 0; i < 4; i++ {
-		_, iErr := sql.Exec(context.Background(), "INSERT INTO example (id, foo) VALUES (?, ?) ;", i, "bar")
+		_, iErr := engine.Exec(context.Background(), "INSERT INTO example (id, foo) VALUES (?, ?) ;", i, "bar")
 		if iErr != nil {
 			require.NoError(t, iErr)
 		}
 	}
 
 	// Null value
-	_, iErr := sql.Exec(context.Background(), "INSERT INTO example (id) VALUES (?) ;", 4711)
+	_, iErr := engine.Exec(context.Background(), "INSERT INTO example (id) VALUES (?) ;", 4711)
 	if iErr != nil {
 		require.NoError(t, iErr)
 	}
@@ -56,4 +57,35 @@ func TestSQLDB(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, "id\tfoo\n4711\t\n", result.String())
 	})
+	t.Run("TestQueryWithOptions Limits", func(t *testing.T) {
+		result, err := sqldb.QueryWithOptions(context.Background(), "SELECT id, foo FROM example WHERE id < ? ORDER BY id", func(o *QueryOptions) {
+			o.Args = []any{3}
+			o.MaxRows = 2
+			o.MaxColumns = 1
+		})
+		require.NoError(t, err)
+		require.True(t, result.Truncated)
+		require.Equal(t, "id\n0\n1\n", result.String())
+	})
+
+	t.Run("TestQueryWithOptions ReadOnly", func(t *testing.T) {
+		result, err := sqldb.QueryWithOptions(context.Background(), "SELECT COUNT(*) FROM example", func(o *QueryOptions) {
+			o.ReadOnly = true
+		})
+		require.NoError(t, err)
+		require.False(t, result.Truncated)
+		require.Equal(t, "COUNT(*)\n5\n", result.String())
+	})
+}
+
+func TestNewEngineFromDB(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	engine, err := NewEngineFromDB(db)
+	require.NoError(t, err)
+	require.IsType(t, &SQLite3{}, engine)
+	require.Equal(t, "sqlite3", engine.Dialect())
 }
@@ -38,6 +38,18 @@ func NewSQLite3(dataSourceName string, optFns ...func(o *SQLite3Options)) (*SQLi
 		return nil, err
 	}
 
+	engine, err := NewSQLite3FromDB(db)
+	if err != nil {
+		return nil, err
+	}
+
+	engine.opts = opts
+
+	return engine, nil
+}
+
+// NewSQLite3FromDB creates a new instance of the SQLite3 database engine from an existing database handle.
+func NewSQLite3FromDB(db *sql.DB) (*SQLite3, error) {
 	driver, err := sqlite.Open(db)
 	if err != nil {
 		return nil, fmt.Errorf("failed opening atlas driver: %s", err)
@@ -46,7 +58,6 @@ func NewSQLite3(dataSourceName string, optFns ...func(o *SQLite3Options)) (*SQLi
 	return &SQLite3{
 		db:    db,
 		atlas: &atlas{driver: driver},
-		opts:  opts,
 	}, nil
 }
 
@@ -65,6 +76,11 @@ func (e *SQLite3) Query(ctx context.Context, query string, args ...any) (*sql.Ro
 	return e.db.QueryContext(ctx, query, args...)
 }
 
+// BeginTx starts a transaction with the provided options (opts).
+func (e *SQLite3) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return e.db.BeginTx(ctx, opts)
+}
+
 // QueryRow executes an SQL query with the provided query string and arguments (args), returning a single row and any errors encountered.
 func (e *SQLite3) QueryRow(ctx context.Context, query string, args ...any) *sql.Row {
 	return e.db.QueryRowContext(ctx, query, args...)
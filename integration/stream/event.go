@@ -0,0 +1,82 @@
+package stream
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// maxEventLineSize is the maximum size of a line of a server-sent event.
+const maxEventLineSize = 8 * 1024 * 1024
+
+// Event is a server-sent event.
+type Event struct {
+	// Type is the type of the event. It is empty for events without event field.
+	Type string
+	// Data is the data of the event. The data of multiple data fields is joined with newlines.
+	Data string
+}
+
+// EventReader is a reader for server-sent events (SSE).
+type EventReader struct {
+	scanner *bufio.Scanner
+}
+
+// NewEventReader creates a new EventReader reading the server-sent events from the reader.
+func NewEventReader(reader io.Reader) *EventReader {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(nil, maxEventLineSize)
+
+	return &EventReader{
+		scanner: scanner,
+	}
+}
+
+// Recv reads the next event from the stream. Comments and events without data are skipped.
+// It returns io.EOF at the end of the stream.
+func (r *EventReader) Recv() (*Event, error) {
+	var (
+		event   = &Event{}
+		data    []string
+		hasData bool
+	)
+
+	for r.scanner.Scan() {
+		line := r.scanner.Text()
+
+		if line == "" {
+			if !hasData {
+				event = &Event{}
+				continue
+			}
+
+			event.Data = strings.Join(data, "\n")
+
+			return event, nil
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		// A single leading space is part of the field separator, everything else belongs to the value.
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "event":
+			event.Type = value
+		case "data":
+			data = append(data, value)
+			hasData = true
+		}
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// The last event of a stream, which is not terminated by an empty line, is dispatched as well.
+	if hasData {
+		event.Data = strings.Join(data, "\n")
+		return event, nil
+	}
+
+	return nil, io.EOF
+}
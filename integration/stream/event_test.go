@@ -0,0 +1,49 @@
+package stream
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventReader(t *testing.T) {
+	t.Run("Events", func(t *testing.T) {
+		reader := NewEventReader(strings.NewReader(": comment\n\n" +
+			"event: endpoint\ndata: /messages\n\n" +
+			"data:  first\ndata:second\n\n" +
+			"event: empty\n\n" +
+			"data: {\"last\": true}"))
+
+		event, err := reader.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, &Event{Type: "endpoint", Data: "/messages"}, event)
+
+		event, err = reader.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, &Event{Data: " first\nsecond"}, event)
+
+		event, err = reader.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, &Event{Data: `{"last": true}`}, event)
+
+		_, err = reader.Recv()
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("CRLF", func(t *testing.T) {
+		event, err := NewEventReader(strings.NewReader("event: message\r\ndata: hello\r\n\r\n")).Recv()
+		require.NoError(t, err)
+		assert.Equal(t, &Event{Type: "message", Data: "hello"}, event)
+	})
+
+	t.Run("LongLine", func(t *testing.T) {
+		data := strings.Repeat("a", 128*1024)
+
+		event, err := NewEventReader(strings.NewReader("data: " + data + "\n\n")).Recv()
+		require.NoError(t, err)
+		assert.Equal(t, data, event.Data)
+	})
+}
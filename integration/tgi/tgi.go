@@ -0,0 +1,161 @@
+// Package tgi provides a client for the Hugging Face Text Generation Inference (TGI) server.
+package tgi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hupe1980/golc/integration/stream"
+)
+
+// HTTPClient is an interface for making HTTP requests.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// ClientOptions contains options for configuring the TGI client.
+type ClientOptions struct {
+	// The HTTP client to use for making API requests.
+	HTTPClient HTTPClient
+	// The optional token used to authenticate against protected endpoints, e.g. Hugging Face Inference Endpoints.
+	APIToken string
+}
+
+// Client is a client for a TGI server.
+type Client struct {
+	apiURL string
+	opts   ClientOptions
+}
+
+// New creates a new TGI client for the server at the given url.
+func New(apiURL string, optFns ...func(o *ClientOptions)) *Client {
+	opts := ClientOptions{
+		HTTPClient: http.DefaultClient,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Client{
+		apiURL: strings.TrimSuffix(apiURL, "/"),
+		opts:   opts,
+	}
+}
+
+// Generate generates text for the request using the generate endpoint.
+func (c *Client) Generate(ctx context.Context, req *GenerateRequest) (*GenerateResponse, error) {
+	req.Stream = false
+
+	res, err := c.doRequest(ctx, fmt.Sprintf("%s/generate", c.apiURL), req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	response := GenerateResponse{}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GenerateStream generates text for the request using the generate_stream endpoint.
+// The returned stream must be closed after use.
+func (c *Client) GenerateStream(ctx context.Context, req *GenerateRequest) (*GenerateStream, error) {
+	req.Stream = true
+
+	res, err := c.doRequest(ctx, fmt.Sprintf("%s/generate_stream", c.apiURL), req) //nolint:bodyclose // body is closed in stream.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewGenerateStream(res.Body), nil
+}
+
+// doRequest sends the request and checks the response status.
+func (c *Client) doRequest(ctx context.Context, url string, payload any) (*http.Response, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	if c.opts.APIToken != "" {
+		httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.opts.APIToken))
+	}
+
+	res, err := c.opts.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+
+		resBody, err := io.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		errorResponse := ErrorResponse{}
+		if err := json.Unmarshal(resBody, &errorResponse); err != nil || errorResponse.Error == "" {
+			return nil, fmt.Errorf("tgi API error: unexpected status code %d: %s", res.StatusCode, string(resBody))
+		}
+
+		return nil, fmt.Errorf("tgi API error: %s", errorResponse.Error)
+	}
+
+	return res, nil
+}
+
+// GenerateStream is a reader for the server-sent events of the generate_stream endpoint.
+type GenerateStream struct {
+	reader *stream.EventReader
+	closer io.Closer
+}
+
+// NewGenerateStream creates a new GenerateStream reading the server-sent events from the reader.
+func NewGenerateStream(reader io.ReadCloser) *GenerateStream {
+	return &GenerateStream{
+		reader: stream.NewEventReader(reader),
+		closer: reader,
+	}
+}
+
+// Recv reads the next event from the stream. It returns io.EOF at the end of the stream.
+func (s *GenerateStream) Recv() (*StreamResponse, error) {
+	event, err := s.reader.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StreamResponse{}
+	if err := json.Unmarshal([]byte(event.Data), response); err != nil {
+		return nil, err
+	}
+
+	if response.Error != "" {
+		return nil, fmt.Errorf("tgi API error: %s", response.Error)
+	}
+
+	return response, nil
+}
+
+// Close closes the underlying stream.
+func (s *GenerateStream) Close() error {
+	return s.closer.Close()
+}
@@ -0,0 +1,91 @@
+package tgi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	t.Run("Generate", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/generate", r.URL.Path)
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+			req := GenerateRequest{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "Hello", req.Inputs)
+			assert.Equal(t, []string{"\n"}, req.Parameters.Stop)
+
+			_, _ = w.Write([]byte(`{"generated_text":" world","details":{"finish_reason":"eos_token","generated_tokens":2}}`))
+		}))
+		defer server.Close()
+
+		client := New(server.URL, func(o *ClientOptions) {
+			o.APIToken = "token"
+		})
+
+		res, err := client.Generate(context.Background(), &GenerateRequest{
+			Inputs:     "Hello",
+			Parameters: Parameters{Stop: []string{"\n"}, Details: true},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, " world", res.GeneratedText)
+		assert.Equal(t, "eos_token", res.Details.FinishReason)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"error":"Input validation error","error_type":"validation"}`))
+		}))
+		defer server.Close()
+
+		_, err := New(server.URL).Generate(context.Background(), &GenerateRequest{Inputs: "Hello"})
+		assert.EqualError(t, err, "tgi API error: Input validation error")
+	})
+
+	t.Run("GenerateStream", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/generate_stream", r.URL.Path)
+
+			_, _ = w.Write([]byte("data:{\"token\":{\"id\":1,\"text\":\" Hello\"},\"generated_text\":null}\n\n"))
+			_, _ = w.Write([]byte("data:{\"token\":{\"id\":2,\"text\":\" world\"},\"generated_text\":\" Hello world\",\"details\":{\"finish_reason\":\"length\",\"generated_tokens\":2}}\n\n"))
+		}))
+		defer server.Close()
+
+		stream, err := New(server.URL).GenerateStream(context.Background(), &GenerateRequest{Inputs: "Hi"})
+		require.NoError(t, err)
+
+		defer stream.Close()
+
+		tokens := []string{}
+
+		var details *Details
+
+		for {
+			res, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			require.NoError(t, err)
+
+			tokens = append(tokens, res.Token.Text)
+
+			if res.Details != nil {
+				details = res.Details
+			}
+		}
+
+		assert.Equal(t, []string{" Hello", " world"}, tokens)
+		assert.Equal(t, "length", details.FinishReason)
+	})
+}
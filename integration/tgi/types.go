@@ -0,0 +1,93 @@
+package tgi
+
+// Parameters represents the generation parameters of a request.
+type Parameters struct {
+	// BestOf generates best_of sequences and returns the one with the highest token logprobs.
+	BestOf int `json:"best_of,omitempty"`
+	// DoSample activates logits sampling.
+	DoSample bool `json:"do_sample,omitempty"`
+	// MaxNewTokens is the maximum number of generated tokens.
+	MaxNewTokens int `json:"max_new_tokens,omitempty"`
+	// RepetitionPenalty is the parameter for repetition penalty. 1.0 means no penalty.
+	RepetitionPenalty float32 `json:"repetition_penalty,omitempty"`
+	// ReturnFullText indicates whether to prepend the prompt to the generated text.
+	ReturnFullText bool `json:"return_full_text,omitempty"`
+	// Seed is the random sampling seed.
+	Seed *int `json:"seed,omitempty"`
+	// Stop generating tokens if a member of stop is generated.
+	Stop []string `json:"stop,omitempty"`
+	// Temperature is the value used to module the logits distribution.
+	Temperature float32 `json:"temperature,omitempty"`
+	// TopK is the number of highest probability vocabulary tokens to keep for top-k-filtering.
+	TopK int `json:"top_k,omitempty"`
+	// TopP is the cumulative probability for nucleus sampling.
+	TopP float32 `json:"top_p,omitempty"`
+	// Truncate inputs tokens to the given size.
+	Truncate int `json:"truncate,omitempty"`
+	// TypicalP is the typical decoding mass.
+	TypicalP float32 `json:"typical_p,omitempty"`
+	// Watermark the generated text.
+	Watermark bool `json:"watermark,omitempty"`
+	// Details indicates whether to return the generation details.
+	Details bool `json:"details,omitempty"`
+}
+
+// GenerateRequest represents a request to the generate endpoints.
+type GenerateRequest struct {
+	// Inputs is the prompt.
+	Inputs string `json:"inputs"`
+	// Parameters are the generation parameters.
+	Parameters Parameters `json:"parameters"`
+	// Stream indicates whether the response is streamed.
+	Stream bool `json:"stream,omitempty"`
+}
+
+// Details represents the details of a generation.
+type Details struct {
+	// FinishReason is the reason why the generation stopped, e.g. "length", "eos_token" or "stop_sequence".
+	FinishReason string `json:"finish_reason"`
+	// GeneratedTokens is the number of generated tokens.
+	GeneratedTokens int `json:"generated_tokens"`
+	// Seed is the sampling seed if sampling was activated.
+	Seed *int `json:"seed,omitempty"`
+}
+
+// GenerateResponse represents the response of the generate endpoint.
+type GenerateResponse struct {
+	// GeneratedText is the generated text.
+	GeneratedText string `json:"generated_text"`
+	// Details are the generation details.
+	Details *Details `json:"details,omitempty"`
+}
+
+// Token represents a generated token.
+type Token struct {
+	// ID is the token id.
+	ID int `json:"id"`
+	// Text is the token text.
+	Text string `json:"text"`
+	// Logprob is the log probability of the token.
+	Logprob float32 `json:"logprob"`
+	// Special indicates whether the token is a special token.
+	Special bool `json:"special"`
+}
+
+// StreamResponse represents a server-sent event of the generate_stream endpoint.
+type StreamResponse struct {
+	// Token is the generated token.
+	Token Token `json:"token"`
+	// GeneratedText is the complete generated text. It is only set on the last event.
+	GeneratedText *string `json:"generated_text,omitempty"`
+	// Details are the generation details. They are only set on the last event.
+	Details *Details `json:"details,omitempty"`
+	// Error is set if the generation failed.
+	Error string `json:"error,omitempty"`
+	// ErrorType is the type of the error.
+	ErrorType string `json:"error_type,omitempty"`
+}
+
+// ErrorResponse represents an error response of the TGI server.
+type ErrorResponse struct {
+	Error     string `json:"error"`
+	ErrorType string `json:"error_type"`
+}
@@ -0,0 +1,55 @@
+package voyageai
+
+import "fmt"
+
+// InputType is the type of the input texts, which is prepended to the texts by the API.
+type InputType string
+
+const (
+	InputTypeQuery    InputType = "query"
+	InputTypeDocument InputType = "document"
+)
+
+type EmbeddingRequest struct {
+	// Input is the list of texts to embed. The number of texts and tokens per request is limited by the model.
+	Input []string `json:"input"`
+	// Model is the name of the embedding model, e.g. voyage-3.
+	Model string `json:"model"`
+	// InputType is the type of the input texts. If empty, the texts are embedded as they are.
+	InputType InputType `json:"input_type,omitempty"`
+	// Truncation truncates texts exceeding the context length of the model. If false, an error is returned.
+	Truncation *bool `json:"truncation,omitempty"`
+	// OutputDimension is the number of dimensions of the embeddings. It is only supported by some models.
+	OutputDimension int `json:"output_dimension,omitempty"`
+}
+
+type Embedding struct {
+	Object    string    `json:"object"`
+	Embedding []float32 `json:"embedding"`
+	Index     int       `json:"index"`
+}
+
+type Usage struct {
+	TotalTokens int `json:"total_tokens"`
+}
+
+type EmbeddingResponse struct {
+	Object string      `json:"object"`
+	Data   []Embedding `json:"data"`
+	Model  string      `json:"model"`
+	Usage  Usage       `json:"usage"`
+}
+
+type ErrorResponse struct {
+	Detail string `json:"detail"`
+}
+
+// APIError is returned when the API responds with an error status code.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("voyageai API error (%d): %s", e.StatusCode, e.Message)
+}
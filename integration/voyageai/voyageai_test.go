@@ -0,0 +1,68 @@
+package voyageai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	t.Run("CreateEmbeddings", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/embeddings", r.URL.Path)
+			assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+
+			req := map[string]any{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, map[string]any{
+				"input":            []any{"text"},
+				"model":            "voyage-3-large",
+				"input_type":       "document",
+				"output_dimension": float64(256),
+			}, req)
+
+			_, _ = w.Write([]byte(`{"object":"list","data":[{"object":"embedding","embedding":[0.1,0.2],"index":0}],"model":"voyage-3-large","usage":{"total_tokens":1}}`))
+		}))
+		defer server.Close()
+
+		client := New("key", func(o *ClientOptions) {
+			o.BaseURL = server.URL
+		})
+
+		res, err := client.CreateEmbeddings(context.Background(), &EmbeddingRequest{
+			Input:           []string{"text"},
+			Model:           "voyage-3-large",
+			InputType:       InputTypeDocument,
+			OutputDimension: 256,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []Embedding{{Object: "embedding", Embedding: []float32{0.1, 0.2}, Index: 0}}, res.Data)
+		assert.Equal(t, 1, res.Usage.TotalTokens)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"detail":"Rate limit exceeded"}`))
+		}))
+		defer server.Close()
+
+		client := New("key", func(o *ClientOptions) {
+			o.BaseURL = server.URL
+		})
+
+		_, err := client.CreateEmbeddings(context.Background(), &EmbeddingRequest{Input: []string{"text"}})
+
+		apiErr := &APIError{}
+		require.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+		assert.EqualError(t, err, "voyageai API error (429): Rate limit exceeded")
+	})
+}
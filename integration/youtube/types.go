@@ -0,0 +1,53 @@
+package youtube
+
+import (
+	"fmt"
+	"time"
+)
+
+// Segment is a time-stamped segment of a transcript.
+type Segment struct {
+	Start    time.Duration
+	Duration time.Duration
+	Text     string
+}
+
+// Transcript is the transcript of a video.
+type Transcript struct {
+	VideoID  string
+	Title    string
+	Author   string
+	Language string
+	// Generated is true, if the transcript was generated by automatic speech recognition.
+	Generated bool
+	Segments  []Segment
+}
+
+type captionTrack struct {
+	BaseURL      string `json:"baseUrl"`
+	LanguageCode string `json:"languageCode"`
+	Kind         string `json:"kind"`
+}
+
+type videoDetails struct {
+	Title  string `json:"title"`
+	Author string `json:"author"`
+}
+
+type timedText struct {
+	Texts []struct {
+		Start    float64 `xml:"start,attr"`
+		Duration float64 `xml:"dur,attr"`
+		Text     string  `xml:",chardata"`
+	} `xml:"text"`
+}
+
+// APIError is returned when YouTube responds with an error status code.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("youtube error (%d): %s", e.StatusCode, e.Message)
+}
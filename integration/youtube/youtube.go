@@ -0,0 +1,192 @@
+// Package youtube provides a client for the transcripts of YouTube videos.
+package youtube
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ErrNoTranscript is returned when a video has no transcript in the requested languages.
+var ErrNoTranscript = errors.New("no transcript available")
+
+// videoIDPattern matches the id of a video in the common URL formats.
+var videoIDPattern = regexp.MustCompile(`(?:youtube\.com/(?:watch\?(?:.*&)?v=|embed/|shorts/|live/)|youtu\.be/)([\w-]{11})`)
+
+// plainVideoIDPattern matches a plain video id.
+var plainVideoIDPattern = regexp.MustCompile(`^[\w-]{11}$`)
+
+// HTTPClient is an interface for making HTTP requests.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type ClientOptions struct {
+	// The HTTP client to use for making requests.
+	HTTPClient HTTPClient
+	// BaseURL is the base url of YouTube.
+	BaseURL string
+}
+
+type Client struct {
+	opts ClientOptions
+}
+
+// New creates a new YouTube client.
+func New(optFns ...func(o *ClientOptions)) *Client {
+	opts := ClientOptions{
+		HTTPClient: http.DefaultClient,
+		BaseURL:    "https://www.youtube.com",
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Client{
+		opts: opts,
+	}
+}
+
+// ParseVideoID returns the id of the video of a URL, e.g. https://www.youtube.com/watch?v=<id> or
+// https://youtu.be/<id>. A plain id is returned as it is.
+func ParseVideoID(s string) (string, error) {
+	if m := videoIDPattern.FindStringSubmatch(s); m != nil {
+		return m[1], nil
+	}
+
+	if plainVideoIDPattern.MatchString(s) {
+		return s, nil
+	}
+
+	return "", fmt.Errorf("invalid youtube video: %s", s)
+}
+
+// GetTranscript returns the transcript of the video in the first available language. Manually created
+// transcripts are preferred over generated ones. If no languages are given, the first transcript is returned.
+func (c *Client) GetTranscript(ctx context.Context, videoID string, languages ...string) (*Transcript, error) {
+	page, err := c.doRequest(ctx, fmt.Sprintf("%s/watch?v=%s", c.opts.BaseURL, url.QueryEscape(videoID)))
+	if err != nil {
+		return nil, err
+	}
+
+	tracks := []captionTrack{}
+	if err := decodeEmbeddedJSON(page, `"captionTracks":`, &tracks); err != nil || len(tracks) == 0 {
+		return nil, fmt.Errorf("%w for video %s", ErrNoTranscript, videoID)
+	}
+
+	track, ok := selectCaptionTrack(tracks, languages)
+	if !ok {
+		return nil, fmt.Errorf("%w for video %s in languages %s", ErrNoTranscript, videoID, strings.Join(languages, ", "))
+	}
+
+	details := videoDetails{}
+	_ = decodeEmbeddedJSON(page, `"videoDetails":`, &details)
+
+	body, err := c.doRequest(ctx, track.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	text := timedText{}
+	if err := xml.Unmarshal(body, &text); err != nil {
+		return nil, err
+	}
+
+	segments := make([]Segment, 0, len(text.Texts))
+
+	for _, t := range text.Texts {
+		segments = append(segments, Segment{
+			Start:    time.Duration(t.Start * float64(time.Second)),
+			Duration: time.Duration(t.Duration * float64(time.Second)),
+			Text:     html.UnescapeString(t.Text),
+		})
+	}
+
+	return &Transcript{
+		VideoID:   videoID,
+		Title:     details.Title,
+		Author:    details.Author,
+		Language:  track.LanguageCode,
+		Generated: track.Kind == "asr",
+		Segments:  segments,
+	}, nil
+}
+
+// selectCaptionTrack returns the track of the first available language.
+func selectCaptionTrack(tracks []captionTrack, languages []string) (captionTrack, bool) {
+	if len(languages) == 0 {
+		return tracks[0], true
+	}
+
+	for _, language := range languages {
+		var generated *captionTrack
+
+		for i, t := range tracks {
+			if t.LanguageCode != language {
+				continue
+			}
+
+			if t.Kind != "asr" {
+				return t, true
+			}
+
+			if generated == nil {
+				generated = &tracks[i]
+			}
+		}
+
+		if generated != nil {
+			return *generated, true
+		}
+	}
+
+	return captionTrack{}, false
+}
+
+// decodeEmbeddedJSON decodes the JSON value following the key in the page.
+func decodeEmbeddedJSON(page []byte, key string, v any) error {
+	i := strings.Index(string(page), key)
+	if i < 0 {
+		return fmt.Errorf("%s not found", key)
+	}
+
+	return json.NewDecoder(strings.NewReader(string(page[i+len(key):]))).Decode(v)
+}
+
+// doRequest sends a GET request to the specified URL.
+func (c *Client) doRequest(ctx context.Context, url string) ([]byte, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Accept-Language", "en-US")
+
+	res, err := c.opts.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, &APIError{StatusCode: res.StatusCode, Message: res.Status}
+	}
+
+	return resBody, nil
+}
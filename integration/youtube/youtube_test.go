@@ -0,0 +1,87 @@
+package youtube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVideoID(t *testing.T) {
+	for _, s := range []string{
+		"https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+		"https://www.youtube.com/watch?feature=share&v=dQw4w9WgXcQ&t=10s",
+		"https://youtu.be/dQw4w9WgXcQ?t=10",
+		"https://www.youtube.com/embed/dQw4w9WgXcQ",
+		"https://youtube.com/shorts/dQw4w9WgXcQ",
+		"dQw4w9WgXcQ",
+	} {
+		id, err := ParseVideoID(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, "dQw4w9WgXcQ", id, s)
+	}
+
+	_, err := ParseVideoID("https://example.com/video")
+	assert.Error(t, err)
+}
+
+func TestClient(t *testing.T) {
+	var server *httptest.Server
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/watch":
+			assert.Equal(t, "dQw4w9WgXcQ", r.URL.Query().Get("v"))
+
+			_, _ = fmt.Fprintf(w, `<script>var ytInitialPlayerResponse = {"captions":{"playerCaptionsTracklistRenderer":{"captionTracks":[`+
+				`{"baseUrl":"%[1]s/timedtext?lang=en&kind=asr","languageCode":"en","kind":"asr"},`+
+				`{"baseUrl":"%[1]s/timedtext?lang=en","languageCode":"en"},`+
+				`{"baseUrl":"%[1]s/timedtext?lang=de","languageCode":"de","kind":"asr"}]}},`+
+				`"videoDetails":{"videoId":"dQw4w9WgXcQ","title":"Talk","author":"Golc"}};</script>`, server.URL)
+		case "/timedtext":
+			_, _ = fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8" ?><transcript>`+
+				`<text start="0.5" dur="1.5">Hello %s</text><text start="2" dur="3">it&amp;#39;s me</text></transcript>`, r.URL.Query().Get("lang")+r.URL.Query().Get("kind"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := New(func(o *ClientOptions) {
+		o.BaseURL = server.URL
+	})
+
+	t.Run("Manual Transcript", func(t *testing.T) {
+		transcript, err := client.GetTranscript(context.Background(), "dQw4w9WgXcQ", "en")
+		require.NoError(t, err)
+		assert.Equal(t, &Transcript{
+			VideoID:  "dQw4w9WgXcQ",
+			Title:    "Talk",
+			Author:   "Golc",
+			Language: "en",
+			Segments: []Segment{
+				{Start: 500 * time.Millisecond, Duration: 1500 * time.Millisecond, Text: "Hello en"},
+				{Start: 2 * time.Second, Duration: 3 * time.Second, Text: "it's me"},
+			},
+		}, transcript)
+	})
+
+	t.Run("Generated Transcript", func(t *testing.T) {
+		transcript, err := client.GetTranscript(context.Background(), "dQw4w9WgXcQ", "fr", "de")
+		require.NoError(t, err)
+		assert.Equal(t, "de", transcript.Language)
+		assert.True(t, transcript.Generated)
+		assert.Equal(t, "Hello de", transcript.Segments[0].Text)
+	})
+
+	t.Run("No Transcript", func(t *testing.T) {
+		_, err := client.GetTranscript(context.Background(), "dQw4w9WgXcQ", "fr")
+		assert.True(t, errors.Is(err, ErrNoTranscript))
+	})
+}
@@ -0,0 +1,67 @@
+package retry
+
+import (
+	"errors"
+
+	"github.com/cohere-ai/cohere-go/v2/core"
+	"github.com/sashabaranov/go-openai"
+
+	"github.com/hupe1980/golc/integration/anthropic"
+	"github.com/hupe1980/golc/integration/jina"
+	"github.com/hupe1980/golc/integration/voyageai"
+)
+
+// IsRetryableOpenAIError reports whether an error of the OpenAI client can be retried.
+func IsRetryableOpenAIError(err error) bool {
+	apiErr := &openai.APIError{}
+	if errors.As(err, &apiErr) {
+		return IsRetryableStatusCode(apiErr.HTTPStatusCode)
+	}
+
+	reqErr := &openai.RequestError{}
+	if errors.As(err, &reqErr) {
+		return IsRetryableStatusCode(reqErr.HTTPStatusCode)
+	}
+
+	return IsRetryableTransportError(err)
+}
+
+// IsRetryableAnthropicError reports whether an error of the Anthropic client can be retried.
+func IsRetryableAnthropicError(err error) bool {
+	apiErr := &anthropic.ErrorDetail{}
+	if errors.As(err, &apiErr) {
+		return apiErr.Type == "overloaded_error" || IsRetryableStatusCode(apiErr.StatusCode)
+	}
+
+	return IsRetryableTransportError(err)
+}
+
+// IsRetryableCohereError reports whether an error of the Cohere client can be retried.
+func IsRetryableCohereError(err error) bool {
+	apiErr := new(core.APIError)
+	if errors.As(err, &apiErr) {
+		return IsRetryableStatusCode(apiErr.StatusCode)
+	}
+
+	return IsRetryableTransportError(err)
+}
+
+// IsRetryableVoyageAIError reports whether an error of the Voyage AI client can be retried.
+func IsRetryableVoyageAIError(err error) bool {
+	apiErr := &voyageai.APIError{}
+	if errors.As(err, &apiErr) {
+		return IsRetryableStatusCode(apiErr.StatusCode)
+	}
+
+	return IsRetryableTransportError(err)
+}
+
+// IsRetryableJinaError reports whether an error of the Jina AI client can be retried.
+func IsRetryableJinaError(err error) bool {
+	apiErr := &jina.APIError{}
+	if errors.As(err, &apiErr) {
+		return IsRetryableStatusCode(apiErr.StatusCode)
+	}
+
+	return IsRetryableTransportError(err)
+}
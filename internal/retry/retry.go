@@ -0,0 +1,156 @@
+// Package retry provides a retry policy with exponential backoff and jitter that is shared by the providers.
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Policy defines how often and when a failed call is retried.
+type Policy struct {
+	// MaxAttempts is the maximum number of attempts including the first call. A value <= 1 disables retries.
+	MaxAttempts uint
+	// InitialDelay is the delay before the first retry.
+	InitialDelay time.Duration
+	// MaxDelay is the maximum delay between two attempts. It also caps the Retry-After duration of a server.
+	MaxDelay time.Duration
+	// Multiplier is the factor the delay grows with after each retry.
+	Multiplier float64
+	// Jitter is the fraction of the delay that is randomly added or subtracted, e.g. 0.2 for +/-20%.
+	Jitter float64
+	// IsRetryable reports whether a failed call is retried. If nil, no error is retried. The classifiers of
+	// the providers retry failed responses with a retryable status code as well as transport errors.
+	IsRetryable func(err error) bool
+}
+
+// NewPolicy creates a policy with exponential backoff for the maximum number of attempts
+// that retries the errors classified as retryable.
+func NewPolicy(maxAttempts uint, isRetryable func(err error) bool) Policy {
+	return Policy{
+		MaxAttempts:  maxAttempts,
+		InitialDelay: 500 * time.Millisecond,
+		MaxDelay:     30 * time.Second,
+		Multiplier:   2,
+		Jitter:       0.2,
+		IsRetryable:  isRetryable,
+	}
+}
+
+// Do calls fn until it succeeds, returns an error that is not retryable or the maximum number of attempts
+// is reached. The Retry-After duration of a failed response, recorded by a client created with NewHTTPClient
+// using the context passed to fn, is respected up to the MaxDelay of the policy, if it exceeds the backoff delay.
+// Do stops waiting and returns the error of the context, if the context is done.
+func Do[T any](ctx context.Context, policy Policy, fn func(ctx context.Context) (T, error)) (T, error) {
+	var (
+		res T
+		err error
+	)
+
+	for attempt := uint(1); ; attempt++ {
+		rec := &recorder{}
+
+		res, err = fn(context.WithValue(ctx, recorderKey{}, rec))
+		if err == nil {
+			return res, nil
+		}
+
+		if attempt >= policy.MaxAttempts || policy.IsRetryable == nil || !policy.IsRetryable(err) {
+			return res, err
+		}
+
+		delay := policy.delay(attempt)
+
+		if retryAfter, ok := ParseRetryAfter(rec.Header(), time.Now()); ok && retryAfter > delay {
+			delay = retryAfter
+
+			if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+				delay = policy.MaxDelay
+			}
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return res, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// delay returns the backoff delay after the given attempt.
+func (p Policy) delay(attempt uint) time.Duration {
+	delay := float64(p.InitialDelay) * math.Pow(p.Multiplier, float64(attempt-1))
+
+	if p.Jitter > 0 {
+		delay += delay * p.Jitter * (2*rand.Float64() - 1) // nolint gosec no crypto
+	}
+
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+
+	return time.Duration(delay)
+}
+
+// IsRetryableStatusCode reports whether a request failed with the HTTP status code can be retried.
+func IsRetryableStatusCode(statusCode int) bool {
+	switch statusCode {
+	case http.StatusRequestTimeout, http.StatusConflict, http.StatusTooManyRequests,
+		http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsRetryableTransportError reports whether a request failed without a response, because it timed out or
+// the connection was refused, reset or closed unexpectedly.
+func IsRetryableTransportError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// ParseRetryAfter parses the retry-after-ms header or the Retry-After header, which contains either
+// a number of seconds or an HTTP date.
+func ParseRetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	if header == nil {
+		return 0, false
+	}
+
+	if ms, err := strconv.ParseFloat(header.Get("retry-after-ms"), 64); err == nil && ms >= 0 {
+		return time.Duration(ms * float64(time.Millisecond)), true
+	}
+
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds >= 0 {
+		return time.Duration(seconds * float64(time.Second)), true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if d := date.Sub(now); d > 0 {
+			return d, true
+		}
+
+		return 0, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,311 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/cohere-ai/cohere-go/v2/core"
+	"github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hupe1980/golc/integration/anthropic"
+)
+
+func TestDo(t *testing.T) {
+	errRetryable := errors.New("retryable")
+
+	newPolicy := func(maxAttempts uint) Policy {
+		policy := NewPolicy(maxAttempts, func(err error) bool {
+			return errors.Is(err, errRetryable)
+		})
+		policy.InitialDelay = time.Millisecond
+
+		return policy
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		calls := 0
+
+		res, err := Do(context.Background(), newPolicy(3), func(ctx context.Context) (string, error) {
+			calls++
+			if calls < 3 {
+				return "", errRetryable
+			}
+
+			return "ok", nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "ok", res)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("MaxAttempts", func(t *testing.T) {
+		calls := 0
+
+		_, err := Do(context.Background(), newPolicy(2), func(ctx context.Context) (string, error) {
+			calls++
+			return "", errRetryable
+		})
+		assert.ErrorIs(t, err, errRetryable)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("NotRetryable", func(t *testing.T) {
+		calls := 0
+
+		_, err := Do(context.Background(), newPolicy(3), func(ctx context.Context) (string, error) {
+			calls++
+			return "", errors.New("fatal")
+		})
+		assert.EqualError(t, err, "fatal")
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("ContextCanceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		policy := newPolicy(3)
+		policy.InitialDelay = time.Hour
+
+		_, err := Do(ctx, policy, func(ctx context.Context) (string, error) {
+			cancel()
+			return "", errRetryable
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("RetryAfter", func(t *testing.T) {
+		calls := 0
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls == 1 {
+				w.Header().Set("Retry-After-Ms", "20")
+				w.WriteHeader(http.StatusTooManyRequests)
+
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := NewHTTPClient(nil)
+		start := time.Now()
+
+		_, err := Do(context.Background(), newPolicy(2), func(ctx context.Context) (string, error) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+			if err != nil {
+				return "", err
+			}
+
+			res, err := client.Do(req)
+			if err != nil {
+				return "", err
+			}
+			defer res.Body.Close()
+
+			if res.StatusCode != http.StatusOK {
+				return "", errRetryable
+			}
+
+			return "ok", nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls)
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	})
+
+	t.Run("RetryAfterExceedsMaxDelay", func(t *testing.T) {
+		calls := 0
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls == 1 {
+				w.Header().Set("Retry-After", "3600")
+				w.WriteHeader(http.StatusTooManyRequests)
+
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := NewHTTPClient(nil)
+
+		policy := newPolicy(2)
+		policy.MaxDelay = 10 * time.Millisecond
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_, err := Do(ctx, policy, func(ctx context.Context) (string, error) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+			if err != nil {
+				return "", err
+			}
+
+			res, err := client.Do(req)
+			if err != nil {
+				return "", err
+			}
+			defer res.Body.Close()
+
+			if res.StatusCode != http.StatusOK {
+				return "", errRetryable
+			}
+
+			return "ok", nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls)
+	})
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	t.Run("WrapsTransport", func(t *testing.T) {
+		base := &http.Client{Timeout: time.Second}
+
+		client := NewHTTPClient(base)
+		assert.Equal(t, time.Second, client.Timeout)
+		assert.IsType(t, &Transport{}, client.Transport)
+		assert.Nil(t, base.Transport)
+	})
+
+	t.Run("AlreadyWrapped", func(t *testing.T) {
+		client := NewHTTPClient(NewHTTPClient(nil))
+		assert.Nil(t, client.Transport.(*Transport).Base)
+	})
+}
+
+func TestPolicyDelay(t *testing.T) {
+	policy := Policy{
+		InitialDelay: time.Second,
+		MaxDelay:     5 * time.Second,
+		Multiplier:   2,
+	}
+
+	assert.Equal(t, time.Second, policy.delay(1))
+	assert.Equal(t, 2*time.Second, policy.delay(2))
+	assert.Equal(t, 4*time.Second, policy.delay(3))
+	assert.Equal(t, 5*time.Second, policy.delay(4))
+
+	policy.Jitter = 0.5
+
+	for i := 0; i < 10; i++ {
+		delay := policy.delay(1)
+		assert.GreaterOrEqual(t, delay, 500*time.Millisecond)
+		assert.LessOrEqual(t, delay, 1500*time.Millisecond)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		header   http.Header
+		expected time.Duration
+		ok       bool
+	}{
+		{"Seconds", http.Header{"Retry-After": []string{"3"}}, 3 * time.Second, true},
+		{"Milliseconds", http.Header{"Retry-After-Ms": []string{"250"}, "Retry-After": []string{"3"}}, 250 * time.Millisecond, true},
+		{"HTTPDate", http.Header{"Retry-After": []string{now.Add(10 * time.Second).Format(http.TimeFormat)}}, 10 * time.Second, true},
+		{"Invalid", http.Header{"Retry-After": []string{"soon"}}, 0, false},
+		{"Missing", http.Header{}, 0, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d, ok := ParseRetryAfter(tc.header, now)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, d)
+		})
+	}
+}
+
+func TestIsRetryableProviderError(t *testing.T) {
+	assert.True(t, IsRetryableOpenAIError(&openai.APIError{HTTPStatusCode: 429}))
+	assert.True(t, IsRetryableOpenAIError(fmt.Errorf("wrapped: %w", &openai.RequestError{HTTPStatusCode: 503})))
+	assert.False(t, IsRetryableOpenAIError(&openai.APIError{HTTPStatusCode: 401}))
+	assert.False(t, IsRetryableOpenAIError(errors.New("other")))
+
+	assert.True(t, IsRetryableCohereError(core.NewAPIError(500, errors.New("internal"))))
+	assert.False(t, IsRetryableCohereError(core.NewAPIError(400, errors.New("bad request"))))
+
+	assert.True(t, IsRetryableAnthropicError(&anthropic.ErrorDetail{Type: "rate_limit_error", StatusCode: 429}))
+	assert.True(t, IsRetryableAnthropicError(&anthropic.ErrorDetail{Type: "overloaded_error"}))
+	assert.False(t, IsRetryableAnthropicError(&anthropic.ErrorDetail{Type: "invalid_request_error", StatusCode: 400}))
+
+	assert.True(t, IsRetryableOpenAIError(&url.Error{Op: "Post", URL: "https://api.openai.com", Err: syscall.ECONNRESET}))
+}
+
+func TestIsRetryableTransportError(t *testing.T) {
+	assert.True(t, IsRetryableTransportError(&url.Error{Op: "Post", Err: syscall.ECONNRESET}))
+	assert.True(t, IsRetryableTransportError(&url.Error{Op: "Post", Err: syscall.ECONNREFUSED}))
+	assert.True(t, IsRetryableTransportError(&url.Error{Op: "Post", Err: io.ErrUnexpectedEOF}))
+	assert.True(t, IsRetryableTransportError(&net.OpError{Op: "read", Err: timeoutError{}}))
+	assert.False(t, IsRetryableTransportError(errors.New("other")))
+
+	t.Run("ConnectionClosed", func(t *testing.T) {
+		calls := 0
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls == 1 {
+				// Announce a longer body than written, so the response ends unexpectedly.
+				w.Header().Set("Content-Length", "10")
+				_, _ = w.Write([]byte("ok"))
+
+				return
+			}
+
+			_, _ = w.Write([]byte("ok"))
+		}))
+		defer server.Close()
+
+		policy := NewPolicy(2, IsRetryableTransportError)
+		policy.InitialDelay = time.Millisecond
+
+		res, err := Do(context.Background(), policy, func(ctx context.Context) (string, error) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+			if err != nil {
+				return "", err
+			}
+
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				return "", err
+			}
+			defer res.Body.Close()
+
+			body, err := io.ReadAll(res.Body)
+			if err != nil {
+				return "", err
+			}
+
+			return string(body), nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "ok", res)
+		assert.Equal(t, 2, calls)
+	})
+}
+
+// timeoutError is a net.Error that timed out.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
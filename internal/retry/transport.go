@@ -0,0 +1,73 @@
+package retry
+
+import (
+	"net/http"
+	"sync"
+)
+
+// recorderKey is the context key of the recorder of an attempt.
+type recorderKey struct{}
+
+// recorder records the header of the last failed response of an attempt.
+type recorder struct {
+	mu     sync.Mutex
+	header http.Header
+}
+
+// Header returns the recorded header, if any.
+func (r *recorder) Header() http.Header {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.header
+}
+
+func (r *recorder) record(header http.Header) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.header = header
+}
+
+// Transport is an http.RoundTripper that records the header of failed responses for Do,
+// as the errors of the provider clients do not contain the response header.
+type Transport struct {
+	// Base is the underlying transport. If nil, http.DefaultTransport is used.
+	Base http.RoundTripper
+}
+
+// RoundTrip executes the request and records the header of a failed response in the request context.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	res, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		if rec, ok := req.Context().Value(recorderKey{}).(*recorder); ok {
+			rec.record(res.Header)
+		}
+	}
+
+	return res, nil
+}
+
+// NewHTTPClient returns a copy of the client whose transport records the header of failed responses,
+// so that Do respects their Retry-After duration. If client is nil, a new client is created.
+func NewHTTPClient(client *http.Client) *http.Client {
+	c := &http.Client{}
+	if client != nil {
+		*c = *client
+	}
+
+	if _, ok := c.Transport.(*Transport); !ok {
+		c.Transport = &Transport{Base: c.Transport}
+	}
+
+	return c
+}
@@ -0,0 +1,16 @@
+package util
+
+import (
+	"context"
+	"time"
+)
+
+// ContextWithTimeout returns a copy of the context that is canceled after the timeout.
+// If the timeout is <= 0, the context is returned unchanged together with a no-op cancel function.
+func ContextWithTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
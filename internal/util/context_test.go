@@ -0,0 +1,28 @@
+package util
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextWithTimeout(t *testing.T) {
+	t.Run("Timeout", func(t *testing.T) {
+		ctx, cancel := ContextWithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		_, ok := ctx.Deadline()
+		assert.True(t, ok)
+	})
+
+	t.Run("NoTimeout", func(t *testing.T) {
+		parent := context.Background()
+
+		ctx, cancel := ContextWithTimeout(parent, 0)
+		defer cancel()
+
+		assert.Equal(t, parent, ctx)
+	})
+}
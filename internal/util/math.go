@@ -27,3 +27,11 @@ func Min[T Number](a, b T) T {
 
 	return b
 }
+
+func Max[T Number](a, b T) T {
+	if a > b {
+		return a
+	}
+
+	return b
+}
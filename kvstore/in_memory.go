@@ -0,0 +1,81 @@
+package kvstore
+
+import (
+	"context"
+	"encoding/gob"
+	"io"
+	"sync"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure InMemory satisfies the ByteStore interface.
+var _ schema.ByteStore = (*InMemory)(nil)
+
+// InMemory represents an in-memory key-value store. The values can be saved and loaded to keep them
+// between runs.
+type InMemory struct {
+	mu     sync.RWMutex
+	values map[string][]byte
+}
+
+// NewInMemory creates a new instance of the in-memory key-value store.
+func NewInMemory() *InMemory {
+	return &InMemory{
+		values: make(map[string][]byte),
+	}
+}
+
+// MGet returns the values stored under the keys. The value of an unknown key is nil.
+func (s *InMemory) MGet(ctx context.Context, keys []string) ([][]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	values := make([][]byte, len(keys))
+
+	for i, key := range keys {
+		values[i] = s.values[key]
+	}
+
+	return values, nil
+}
+
+// MSet stores the values under the keys. Existing values are overwritten.
+func (s *InMemory) MSet(ctx context.Context, keys []string, values [][]byte) error {
+	if len(keys) != len(values) {
+		return ErrKeysValuesMismatch
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, key := range keys {
+		s.values[key] = values[i]
+	}
+
+	return nil
+}
+
+// Load loads the values from an io.Reader.
+func (s *InMemory) Load(r io.Reader) error {
+	values := make(map[string][]byte)
+
+	if err := gob.NewDecoder(r).Decode(&values); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.values = values
+
+	return nil
+}
+
+// Save saves the values to an io.Writer.
+func (s *InMemory) Save(w io.Writer) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return gob.NewEncoder(w).Encode(s.values)
+}
@@ -0,0 +1,36 @@
+package kvstore
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemory(t *testing.T) {
+	store := NewInMemory()
+
+	err := store.MSet(context.Background(), []string{"a", "b"}, [][]byte{[]byte("1"), []byte("2")})
+	require.NoError(t, err)
+
+	values, err := store.MGet(context.Background(), []string{"a", "unknown", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("1"), nil, []byte("2")}, values)
+
+	err = store.MSet(context.Background(), []string{"a"}, nil)
+	assert.ErrorIs(t, err, ErrKeysValuesMismatch)
+
+	t.Run("SaveAndLoad", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, store.Save(&buf))
+
+		loaded := NewInMemory()
+		require.NoError(t, loaded.Load(&buf))
+
+		values, err := loaded.MGet(context.Background(), []string{"a", "b"})
+		require.NoError(t, err)
+		assert.Equal(t, [][]byte{[]byte("1"), []byte("2")}, values)
+	})
+}
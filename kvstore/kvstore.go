@@ -0,0 +1,7 @@
+// Package kvstore provides key-value stores for byte values, e.g. to cache embeddings.
+package kvstore
+
+import "errors"
+
+// ErrKeysValuesMismatch is returned when the number of keys and values differs.
+var ErrKeysValuesMismatch = errors.New("number of keys and values must be equal")
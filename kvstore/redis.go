@@ -0,0 +1,94 @@
+package kvstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Redis satisfies the ByteStore interface.
+var _ schema.ByteStore = (*Redis)(nil)
+
+type RedisClient interface {
+	MGet(ctx context.Context, keys ...string) *redis.SliceCmd
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd
+}
+
+// RedisOptions contains the options for the Redis key-value store.
+type RedisOptions struct {
+	// KeyPrefix is the prefix of the redis keys of the values.
+	KeyPrefix string
+	// TTL is the time to live of a value. A value <= 0 disables the expiration.
+	TTL time.Duration
+}
+
+// Redis is a key-value store that stores the values in redis.
+type Redis struct {
+	redisClient RedisClient
+	opts        RedisOptions
+}
+
+// NewRedis creates a new Redis key-value store.
+func NewRedis(redisClient RedisClient, optFns ...func(o *RedisOptions)) *Redis {
+	opts := RedisOptions{
+		KeyPrefix: "golc_kvstore:",
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Redis{
+		redisClient: redisClient,
+		opts:        opts,
+	}
+}
+
+// MGet returns the values stored under the keys. The value of an unknown key is nil.
+func (s *Redis) MGet(ctx context.Context, keys []string) ([][]byte, error) {
+	if len(keys) == 0 {
+		return [][]byte{}, nil
+	}
+
+	redisKeys := make([]string, len(keys))
+	for i, key := range keys {
+		redisKeys[i] = s.opts.KeyPrefix + key
+	}
+
+	result, err := s.redisClient.MGet(ctx, redisKeys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([][]byte, len(keys))
+
+	for i, value := range result {
+		if str, ok := value.(string); ok {
+			values[i] = []byte(str)
+		}
+	}
+
+	return values, nil
+}
+
+// MSet stores the values under the keys. Existing values are overwritten.
+func (s *Redis) MSet(ctx context.Context, keys []string, values [][]byte) error {
+	if len(keys) != len(values) {
+		return ErrKeysValuesMismatch
+	}
+
+	var ttl time.Duration
+	if s.opts.TTL > 0 {
+		ttl = s.opts.TTL
+	}
+
+	for i, key := range keys {
+		if err := s.redisClient.Set(ctx, s.opts.KeyPrefix+key, string(values[i]), ttl).Err(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package kvstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedis(t *testing.T) {
+	client := newMockRedisClient()
+
+	store := NewRedis(client, func(o *RedisOptions) {
+		o.TTL = time.Hour
+	})
+
+	err := store.MSet(context.Background(), []string{"a", "b"}, [][]byte{[]byte("1"), []byte("2")})
+	require.NoError(t, err)
+	assert.Equal(t, "1", client.values["golc_kvstore:a"])
+	assert.Equal(t, time.Hour, client.expirations["golc_kvstore:a"])
+
+	values, err := store.MGet(context.Background(), []string{"a", "unknown", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("1"), nil, []byte("2")}, values)
+}
+
+type mockRedisClient struct {
+	values      map[string]string
+	expirations map[string]time.Duration
+}
+
+func newMockRedisClient() *mockRedisClient {
+	return &mockRedisClient{
+		values:      make(map[string]string),
+		expirations: make(map[string]time.Duration),
+	}
+}
+
+func (c *mockRedisClient) MGet(ctx context.Context, keys ...string) *redis.SliceCmd {
+	cmd := redis.NewSliceCmd(ctx)
+
+	values := make([]interface{}, len(keys))
+
+	for i, key := range keys {
+		if value, ok := c.values[key]; ok {
+			values[i] = value
+		}
+	}
+
+	cmd.SetVal(values)
+
+	return cmd
+}
+
+func (c *mockRedisClient) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+	c.values[key] = value.(string)
+	c.expirations[key] = expiration
+
+	return redis.NewStatusCmd(ctx)
+}
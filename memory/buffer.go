@@ -24,8 +24,14 @@ type ConversationBufferOptions struct {
 	ReturnMessages     bool
 	ChatMessageHistory schema.ChatMessageHistory
 
-	// Size of the interactions window
+	// K is the number of the last exchanges of a human and an AI message in the returned history.
+	// Defaults to all exchanges.
 	K uint
+	// MaxTokens is the maximum number of tokens of the returned history. The oldest messages are
+	// dropped until the history fits. Zero means no limit. Requires Tokenizer.
+	MaxTokens uint
+	// Tokenizer counts the tokens of the history for MaxTokens, e.g. the model of the chain.
+	Tokenizer schema.Tokenizer
 }
 
 // ConversationBuffer is a memory type that manages conversation buffers.
@@ -81,16 +87,20 @@ func (m *ConversationBuffer) LoadMemoryVariables(ctx context.Context, inputs map
 		}
 	}
 
+	if m.opts.MaxTokens > 0 {
+		messages, err = m.limitTokens(ctx, messages)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if m.opts.ReturnMessages {
 		return map[string]any{
 			m.opts.MemoryKey: messages,
 		}, nil
 	}
 
-	buffer, err := messages.Format(func(o *schema.StringifyChatMessagesOptions) {
-		o.HumanPrefix = m.opts.HumanPrefix
-		o.AIPrefix = m.opts.AIPrefix
-	})
+	buffer, err := m.format(messages)
 	if err != nil {
 		return nil, err
 	}
@@ -119,6 +129,45 @@ func (m *ConversationBuffer) Clear(ctx context.Context) error {
 	return m.opts.ChatMessageHistory.Clear(ctx)
 }
 
+// limitTokens drops the oldest messages until the formatted messages fit into MaxTokens. An AI message
+// is never kept without the preceding human message.
+func (m *ConversationBuffer) limitTokens(ctx context.Context, messages schema.ChatMessages) (schema.ChatMessages, error) {
+	if m.opts.Tokenizer == nil {
+		return nil, errors.New("tokenizer is required to limit the history to MaxTokens")
+	}
+
+	for len(messages) > 0 {
+		buffer, err := m.format(messages)
+		if err != nil {
+			return nil, err
+		}
+
+		numTokens, err := m.opts.Tokenizer.GetNumTokens(ctx, buffer)
+		if err != nil {
+			return nil, err
+		}
+
+		if numTokens <= m.opts.MaxTokens {
+			break
+		}
+
+		messages = messages[1:]
+
+		for len(messages) > 0 && messages[0].Type() == schema.ChatMessageTypeAI {
+			messages = messages[1:]
+		}
+	}
+
+	return messages, nil
+}
+
+func (m *ConversationBuffer) format(messages schema.ChatMessages) (string, error) {
+	return messages.Format(func(o *schema.StringifyChatMessagesOptions) {
+		o.HumanPrefix = m.opts.HumanPrefix
+		o.AIPrefix = m.opts.AIPrefix
+	})
+}
+
 func (m *ConversationBuffer) getInputOutput(inputs map[string]any, outputs map[string]any) (string, string, error) {
 	inputKey := m.opts.InputKey
 	if inputKey == "" {
@@ -6,7 +6,9 @@ import (
 
 	"github.com/hupe1980/golc/chatmessagehistory"
 	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tokenizer"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConversationBuffer(t *testing.T) {
@@ -101,6 +103,57 @@ func TestConversationBuffer(t *testing.T) {
 				assert.Equal(t, "Hi there3", messages[1].Content())
 			})
 		})
+
+		t.Run("MaxTokens", func(t *testing.T) {
+			gpt2, err := tokenizer.NewGPT2()
+			require.NoError(t, err)
+
+			tb := NewConversationBuffer(func(o *ConversationBufferOptions) {
+				o.ChatMessageHistory = chatmessagehistory.NewInMemoryWithMessages(schema.ChatMessages{
+					schema.NewHumanChatMessage("Hello1"),
+					schema.NewAIChatMessage("Hi there1"),
+					schema.NewHumanChatMessage("Hello2"),
+					schema.NewAIChatMessage("Hi there2 with a much longer answer"),
+					schema.NewHumanChatMessage("Hello3"),
+					schema.NewAIChatMessage("Hi there3"),
+				})
+				o.Tokenizer = gpt2
+			})
+
+			t.Run("Limit", func(t *testing.T) {
+				tb.opts.MaxTokens = 30
+
+				vars, err := tb.LoadMemoryVariables(context.TODO(), inputs)
+				require.NoError(t, err)
+				assert.Equal(t, "Human: Hello2\nAI: Hi there2 with a much longer answer\nHuman: Hello3\nAI: Hi there3", vars["history"])
+			})
+
+			t.Run("No dangling AI message", func(t *testing.T) {
+				tb.opts.MaxTokens = 22
+
+				vars, err := tb.LoadMemoryVariables(context.TODO(), inputs)
+				require.NoError(t, err)
+				assert.Equal(t, "Human: Hello3\nAI: Hi there3", vars["history"])
+			})
+
+			t.Run("With K", func(t *testing.T) {
+				tb.opts.MaxTokens = 1000
+				tb.opts.K = 1
+
+				vars, err := tb.LoadMemoryVariables(context.TODO(), inputs)
+				require.NoError(t, err)
+				assert.Equal(t, "Human: Hello3\nAI: Hi there3", vars["history"])
+			})
+
+			t.Run("Without tokenizer", func(t *testing.T) {
+				nb := NewConversationBuffer(func(o *ConversationBufferOptions) {
+					o.MaxTokens = 10
+				})
+
+				_, err := nb.LoadMemoryVariables(context.TODO(), inputs)
+				assert.EqualError(t, err, "tokenizer is required to limit the history to MaxTokens")
+			})
+		})
 	})
 
 	t.Run("SaveContext", func(t *testing.T) {
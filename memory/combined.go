@@ -4,16 +4,21 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hupe1980/golc/internal/util"
 	"github.com/hupe1980/golc/schema"
 )
 
 // Compile time check to ensure Combined satisfies the Memory interface.
 var _ schema.Memory = (*Combined)(nil)
 
+// Combined is a memory type that combines the variables of multiple memories, e.g. a conversation
+// buffer and a summary of the conversation. The memory keys of the memories must be unique.
 type Combined struct {
 	memories []schema.Memory
 }
 
+// NewCombined creates a new instance of Combined memory type. It returns an error, if the memories
+// have a memory key in common.
 func NewCombined(memories ...schema.Memory) (*Combined, error) {
 	if err := checkRepeatedMemoryVariable(memories...); err != nil {
 		return nil, err
@@ -24,6 +29,7 @@ func NewCombined(memories ...schema.Memory) (*Combined, error) {
 	}, nil
 }
 
+// MemoryKeys returns the memory keys of all memories.
 func (m *Combined) MemoryKeys() []string {
 	memoryKeys := make([]string, 0)
 	for _, memory := range m.memories {
@@ -33,6 +39,8 @@ func (m *Combined) MemoryKeys() []string {
 	return memoryKeys
 }
 
+// LoadMemoryVariables returns the merged variables of all memories. It returns an error, if a variable
+// is returned by more than one memory.
 func (m *Combined) LoadMemoryVariables(ctx context.Context, inputs map[string]any) (map[string]any, error) {
 	memoryData := make(map[string]any)
 
@@ -44,6 +52,10 @@ func (m *Combined) LoadMemoryVariables(ctx context.Context, inputs map[string]an
 		}
 
 		for key, value := range data {
+			if _, ok := memoryData[key]; ok {
+				return nil, fmt.Errorf("repeated memory key found: %s", key)
+			}
+
 			memoryData[key] = value
 		}
 	}
@@ -51,9 +63,22 @@ func (m *Combined) LoadMemoryVariables(ctx context.Context, inputs map[string]an
 	return memoryData, nil
 }
 
+// SaveContext saves the context to all memories. The variables of the memories, which are part of
+// the inputs of a chain with memory, are removed from the inputs, so they are not mistaken for the
+// input of the chain by the other memories.
 func (m *Combined) SaveContext(ctx context.Context, inputs map[string]any, outputs map[string]any) error {
+	memoryKeys := m.MemoryKeys()
+
+	promptInputs := make(map[string]any, len(inputs))
+
+	for key, value := range inputs {
+		if !util.Contains(memoryKeys, key) {
+			promptInputs[key] = value
+		}
+	}
+
 	for _, memory := range m.memories {
-		if err := memory.SaveContext(ctx, inputs, outputs); err != nil {
+		if err := memory.SaveContext(ctx, promptInputs, outputs); err != nil {
 			return err
 		}
 	}
@@ -61,6 +86,7 @@ func (m *Combined) SaveContext(ctx context.Context, inputs map[string]any, outpu
 	return nil
 }
 
+// Clear clears all memories.
 func (m *Combined) Clear(ctx context.Context) error {
 	for _, memory := range m.memories {
 		if err := memory.Clear(ctx); err != nil {
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCombined(t *testing.T) {
+	ctx := context.Background()
+
+	history := NewConversationBuffer()
+	lastExchange := NewConversationBuffer(func(o *ConversationBufferOptions) {
+		o.MemoryKey = "last_exchange"
+		o.K = 1
+	})
+
+	combined, err := NewCombined(history, lastExchange)
+	require.NoError(t, err)
+
+	t.Run("MemoryKeys", func(t *testing.T) {
+		assert.Equal(t, []string{"history", "last_exchange"}, combined.MemoryKeys())
+	})
+
+	t.Run("SaveContext", func(t *testing.T) {
+		for _, exchange := range [][2]string{{"foo", "bar"}, {"baz", "qux"}} {
+			// Chains add the memory variables to the inputs before saving the context.
+			inputs, err := combined.LoadMemoryVariables(ctx, map[string]any{"input": exchange[0]})
+			require.NoError(t, err)
+
+			inputs["input"] = exchange[0]
+
+			err = combined.SaveContext(ctx, inputs, map[string]any{"output": exchange[1]})
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("LoadMemoryVariables", func(t *testing.T) {
+		vars, err := combined.LoadMemoryVariables(ctx, map[string]any{"input": "quux"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"history":       "Human: foo\nAI: bar\nHuman: baz\nAI: qux",
+			"last_exchange": "Human: baz\nAI: qux",
+		}, vars)
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		require.NoError(t, combined.Clear(ctx))
+
+		vars, err := combined.LoadMemoryVariables(ctx, map[string]any{"input": "quux"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"history": "", "last_exchange": ""}, vars)
+	})
+
+	t.Run("RepeatedMemoryKey", func(t *testing.T) {
+		_, err := NewCombined(history, NewConversationBuffer())
+		assert.EqualError(t, err, "repeated memory key found: history")
+	})
+}
@@ -0,0 +1,304 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hupe1980/golc/chatmessagehistory"
+	"github.com/hupe1980/golc/model"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure ConversationSummaryBuffer satisfies the Memory interface.
+var _ schema.Memory = (*ConversationSummaryBuffer)(nil)
+
+const defaultSummaryTemplate = `Progressively summarize the lines of conversation provided, adding onto the previous summary and returning a new summary.
+
+Current summary:
+{{.summary}}
+
+New lines of conversation:
+{{.newLines}}
+
+New summary:`
+
+// ConversationSummaryBufferOptions contains options for configuring the ConversationSummaryBuffer memory type.
+type ConversationSummaryBufferOptions struct {
+	HumanPrefix        string
+	AIPrefix           string
+	MemoryKey          string
+	InputKey           string
+	OutputKey          string
+	ReturnMessages     bool
+	ChatMessageHistory schema.ChatMessageHistory
+
+	// MaxTokenLimit is the maximum number of tokens of the recent messages, which are kept verbatim.
+	// Older messages are summarized.
+	MaxTokenLimit uint
+	// SummaryPrompt is the prompt to extend the summary. It receives the current summary and the new lines of conversation.
+	SummaryPrompt schema.PromptTemplate
+}
+
+// ConversationSummaryBuffer is a memory type that keeps the most recent messages of the conversation
+// verbatim and summarizes older messages with a model. The number of tokens of the recent messages is
+// limited by MaxTokenLimit using the tokenizer of the model.
+type ConversationSummaryBuffer struct {
+	model schema.Model
+	opts  ConversationSummaryBufferOptions
+
+	mu sync.Mutex
+	// summary is the summary of the messages, which have been pruned from the buffer.
+	summary string
+	// pruned is the number of messages of the chat message history included in the summary.
+	pruned int
+}
+
+// NewConversationSummaryBuffer creates a new instance of ConversationSummaryBuffer memory type.
+func NewConversationSummaryBuffer(model schema.Model, optFns ...func(o *ConversationSummaryBufferOptions)) *ConversationSummaryBuffer {
+	opts := ConversationSummaryBufferOptions{
+		HumanPrefix:    "Human",
+		AIPrefix:       "AI",
+		MemoryKey:      "history",
+		InputKey:       "",
+		OutputKey:      "",
+		ReturnMessages: false,
+		MaxTokenLimit:  2000,
+		SummaryPrompt:  prompt.NewTemplate(defaultSummaryTemplate),
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.ChatMessageHistory == nil {
+		opts.ChatMessageHistory = chatmessagehistory.NewInMemory()
+	}
+
+	return &ConversationSummaryBuffer{
+		model: model,
+		opts:  opts,
+	}
+}
+
+// MemoryKeys returns the memory keys for ConversationSummaryBuffer.
+func (m *ConversationSummaryBuffer) MemoryKeys() []string {
+	return []string{m.opts.MemoryKey}
+}
+
+// Summary returns the summary of the messages, which are no longer kept verbatim.
+func (m *ConversationSummaryBuffer) Summary() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.summary
+}
+
+// LoadMemoryVariables returns key-value pairs given the text input to the chain. The summary of older
+// messages is returned as system message before the recent messages. Messages added to the chat message
+// history without SaveContext, e.g. of a persistent history, are summarized first, if the recent messages
+// exceed MaxTokenLimit.
+func (m *ConversationSummaryBuffer) LoadMemoryVariables(ctx context.Context, inputs map[string]any) (map[string]any, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.prune(ctx); err != nil {
+		return nil, err
+	}
+
+	buffer, err := m.buffer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := schema.ChatMessages{}
+	if m.summary != "" {
+		messages = append(messages, schema.NewSystemChatMessage(m.summary))
+	}
+
+	messages = append(messages, buffer...)
+
+	if m.opts.ReturnMessages {
+		return map[string]any{
+			m.opts.MemoryKey: messages,
+		}, nil
+	}
+
+	history, err := m.format(messages)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		m.opts.MemoryKey: history,
+	}, nil
+}
+
+// SaveContext saves the input and output messages to the chat message history and summarizes the
+// oldest messages, if the recent messages exceed MaxTokenLimit.
+func (m *ConversationSummaryBuffer) SaveContext(ctx context.Context, inputs map[string]any, outputs map[string]any) error {
+	input, output, err := m.getInputOutput(inputs, outputs)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.opts.ChatMessageHistory.AddUserMessage(ctx, input); err != nil {
+		return err
+	}
+
+	if err := m.opts.ChatMessageHistory.AddAIMessage(ctx, output); err != nil {
+		return err
+	}
+
+	return m.prune(ctx)
+}
+
+// Clear clears the chat message history and the summary.
+func (m *ConversationSummaryBuffer) Clear(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.summary = ""
+	m.pruned = 0
+
+	return m.opts.ChatMessageHistory.Clear(ctx)
+}
+
+// buffer returns the messages of the chat message history, which are not summarized.
+func (m *ConversationSummaryBuffer) buffer(ctx context.Context) (schema.ChatMessages, error) {
+	messages, err := m.opts.ChatMessageHistory.Messages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The history may have been cleared or replaced by another user of the history.
+	if m.pruned > len(messages) {
+		m.summary = ""
+		m.pruned = 0
+	}
+
+	return messages[m.pruned:], nil
+}
+
+// prune removes the oldest messages from the buffer until it fits into MaxTokenLimit and adds them to the summary.
+func (m *ConversationSummaryBuffer) prune(ctx context.Context) error {
+	buffer, err := m.buffer(ctx)
+	if err != nil {
+		return err
+	}
+
+	numTokens, err := m.getNumTokensForMessages(ctx, buffer)
+	if err != nil {
+		return err
+	}
+
+	if numTokens <= m.opts.MaxTokenLimit {
+		return nil
+	}
+
+	pruned := 0
+
+	for pruned < len(buffer) && numTokens > m.opts.MaxTokenLimit {
+		pruned++
+
+		numTokens, err = m.getNumTokensForMessages(ctx, buffer[pruned:])
+		if err != nil {
+			return err
+		}
+	}
+
+	summary, err := m.summarize(ctx, buffer[:pruned])
+	if err != nil {
+		return err
+	}
+
+	m.summary = summary
+	m.pruned += pruned
+
+	return nil
+}
+
+// summarize extends the summary with the messages and returns the new summary.
+func (m *ConversationSummaryBuffer) summarize(ctx context.Context, messages schema.ChatMessages) (string, error) {
+	newLines, err := m.format(messages)
+	if err != nil {
+		return "", err
+	}
+
+	promptValue, err := m.opts.SummaryPrompt.FormatPrompt(map[string]any{
+		"summary":  m.summary,
+		"newLines": newLines,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	result, err := model.GeneratePrompt(ctx, m.model, promptValue)
+	if err != nil {
+		return "", err
+	}
+
+	if len(result.Generations) == 0 {
+		return "", errors.New("no summary generated by the model")
+	}
+
+	return strings.TrimSpace(result.Generations[0].Text), nil
+}
+
+func (m *ConversationSummaryBuffer) format(messages schema.ChatMessages) (string, error) {
+	return messages.Format(func(o *schema.StringifyChatMessagesOptions) {
+		o.HumanPrefix = m.opts.HumanPrefix
+		o.AIPrefix = m.opts.AIPrefix
+	})
+}
+
+func (m *ConversationSummaryBuffer) getInputOutput(inputs map[string]any, outputs map[string]any) (string, string, error) {
+	inputKey := m.opts.InputKey
+	if inputKey == "" {
+		var err error
+
+		inputKey, err = getPromptInputKey(inputs, m.MemoryKeys())
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	input, ok := inputs[inputKey].(string)
+	if !ok {
+		return "", "", fmt.Errorf("input %s is not a string", inputKey)
+	}
+
+	outputKey := m.opts.OutputKey
+	if outputKey == "" {
+		if len(outputs) != 1 {
+			return "", "", fmt.Errorf("multiple output keys. Only one output key expected, got %d", len(outputs))
+		}
+
+		for key := range outputs {
+			outputKey = key
+			break
+		}
+	}
+
+	output, ok := outputs[outputKey].(string)
+	if !ok {
+		return "", "", fmt.Errorf("output %s is not a string", outputKey)
+	}
+
+	return input, output, nil
+}
+
+func (m *ConversationSummaryBuffer) getNumTokensForMessages(ctx context.Context, messages schema.ChatMessages) (uint, error) {
+	buffer, err := m.format(messages)
+	if err != nil {
+		return 0, err
+	}
+
+	return m.model.GetNumTokens(ctx, buffer)
+}
@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc/chatmessagehistory"
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tokenizer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConversationSummaryBuffer(t *testing.T) {
+	gpt2, err := tokenizer.NewGPT2()
+	require.NoError(t, err)
+
+	prompts := []string{}
+
+	model := llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+		prompts = append(prompts, prompt)
+
+		return &schema.ModelResult{
+			Generations: []schema.Generation{{Text: "  Summary " + string(rune('0'+len(prompts))) + "\n"}},
+			LLMOutput:   map[string]any{},
+		}, nil
+	}, func(o *llm.FakeOptions) {
+		o.Tokenizer = gpt2
+	})
+
+	cb := NewConversationSummaryBuffer(model, func(o *ConversationSummaryBufferOptions) {
+		o.MaxTokenLimit = 10
+	})
+
+	t.Run("MemoryKeys", func(t *testing.T) {
+		assert.Equal(t, []string{"history"}, cb.MemoryKeys())
+	})
+
+	t.Run("NoSummary", func(t *testing.T) {
+		err := cb.SaveContext(context.TODO(), map[string]any{"input": "Hello1"}, map[string]any{"output": "Hi there1"})
+		require.NoError(t, err)
+
+		vars, err := cb.LoadMemoryVariables(context.TODO(), map[string]any{})
+		require.NoError(t, err)
+		assert.Equal(t, "Human: Hello1\nAI: Hi there1", vars["history"])
+		assert.Empty(t, prompts)
+	})
+
+	t.Run("Summary", func(t *testing.T) {
+		err := cb.SaveContext(context.TODO(), map[string]any{"input": "Hello2"}, map[string]any{"output": "Hi there2"})
+		require.NoError(t, err)
+
+		require.Len(t, prompts, 1)
+		assert.Contains(t, prompts[0], "New lines of conversation:\nHuman: Hello1\nAI: Hi there1\n")
+		assert.Equal(t, "Summary 1", cb.Summary())
+
+		vars, err := cb.LoadMemoryVariables(context.TODO(), map[string]any{})
+		require.NoError(t, err)
+		assert.Equal(t, "System: Summary 1\nHuman: Hello2\nAI: Hi there2", vars["history"])
+
+		err = cb.SaveContext(context.TODO(), map[string]any{"input": "Hello3"}, map[string]any{"output": "Hi there3"})
+		require.NoError(t, err)
+
+		require.Len(t, prompts, 2)
+		assert.Contains(t, prompts[1], "Current summary:\nSummary 1\n")
+		assert.Contains(t, prompts[1], "Human: Hello2\nAI: Hi there2")
+
+		cb.opts.ReturnMessages = true
+		defer func() { cb.opts.ReturnMessages = false }()
+
+		vars, err = cb.LoadMemoryVariables(context.TODO(), map[string]any{})
+		require.NoError(t, err)
+		assert.Equal(t, schema.ChatMessages{
+			schema.NewSystemChatMessage("Summary 2"),
+			schema.NewHumanChatMessage("Hello3"),
+			schema.NewAIChatMessage("Hi there3"),
+		}, vars["history"])
+
+		messages, err := cb.opts.ChatMessageHistory.Messages(context.TODO())
+		require.NoError(t, err)
+		assert.Len(t, messages, 6, "the chat message history is not modified")
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		require.NoError(t, cb.Clear(context.TODO()))
+
+		vars, err := cb.LoadMemoryVariables(context.TODO(), map[string]any{})
+		require.NoError(t, err)
+		assert.Equal(t, "", vars["history"])
+		assert.Equal(t, "", cb.Summary())
+	})
+
+	t.Run("LoadPrunesHistory", func(t *testing.T) {
+		prompts = prompts[:0]
+
+		history := chatmessagehistory.NewInMemory()
+		require.NoError(t, history.AddUserMessage(context.TODO(), "Hello1"))
+		require.NoError(t, history.AddAIMessage(context.TODO(), "Hi there1"))
+		require.NoError(t, history.AddUserMessage(context.TODO(), "Hello2"))
+		require.NoError(t, history.AddAIMessage(context.TODO(), "Hi there2"))
+
+		cb := NewConversationSummaryBuffer(model, func(o *ConversationSummaryBufferOptions) {
+			o.MaxTokenLimit = 10
+			o.ChatMessageHistory = history
+		})
+
+		vars, err := cb.LoadMemoryVariables(context.TODO(), map[string]any{})
+		require.NoError(t, err)
+		require.Len(t, prompts, 1)
+		assert.Equal(t, "System: Summary 1\nHuman: Hello2\nAI: Hi there2", vars["history"])
+	})
+}
@@ -0,0 +1,394 @@
+package model
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure CachedLLM satisfies the StreamingLLM interface.
+var _ schema.StreamingLLM = (*CachedLLM)(nil)
+
+// CachedLLM is an LLM that caches the results of the wrapped LLM.
+type CachedLLM struct {
+	schema.LLM
+	cache schema.Cache
+}
+
+// WithCache wraps the LLM, so that the results for repeated prompts are served from the cache.
+// The results are keyed on the prompt and an llm key derived from the model type, the invocation parameters
+// and the stop words.
+func WithCache(llm schema.LLM, cache schema.Cache) *CachedLLM {
+	return &CachedLLM{
+		LLM:   llm,
+		cache: cache,
+	}
+}
+
+// Generate returns the cached result for the prompt or generates and caches a new result.
+func (l *CachedLLM) Generate(ctx context.Context, prompt string, optFns ...func(o *schema.GenerateOptions)) (*schema.ModelResult, error) {
+	opts := schema.GenerateOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	llmKey, err := cacheLLMKey(l.LLM, opts, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return cachedGenerate(ctx, l.cache, prompt, llmKey, func() (*schema.ModelResult, error) {
+		return l.LLM.Generate(ctx, prompt, optFns...)
+	})
+}
+
+// Stream sends the cached result for the prompt as a single chunk or streams the tokens of the wrapped LLM
+// and caches the complete generation. If the wrapped LLM does not implement schema.StreamingLLM, the
+// generated result is sent as a single chunk.
+func (l *CachedLLM) Stream(ctx context.Context, prompt string, optFns ...func(o *schema.GenerateOptions)) (<-chan schema.StreamChunk, error) {
+	sm, ok := l.LLM.(schema.StreamingLLM)
+	if !ok {
+		result, err := l.Generate(ctx, prompt, optFns...)
+		if err != nil {
+			return nil, err
+		}
+
+		return resultToStream(result), nil
+	}
+
+	opts := schema.GenerateOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	llmKey, err := cacheLLMKey(l.LLM, opts, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return cachedStream(ctx, l.cache, prompt, llmKey, func() (<-chan schema.StreamChunk, error) {
+		return sm.Stream(ctx, prompt, optFns...)
+	}, func(text string) schema.Generation {
+		return schema.Generation{Text: text}
+	})
+}
+
+// Compile time check to ensure CachedChatModel satisfies the StreamingChatModel and ToolCallingChatModel interfaces.
+var (
+	_ schema.StreamingChatModel   = (*CachedChatModel)(nil)
+	_ schema.ToolCallingChatModel = (*CachedChatModel)(nil)
+)
+
+// CachedChatModel is a chat model that caches the results of the wrapped chat model.
+type CachedChatModel struct {
+	schema.ChatModel
+	cache schema.Cache
+	tools []schema.FunctionDefinition
+}
+
+// WithChatModelCache wraps the chat model, so that the results for repeated messages are served from the cache.
+// The results are keyed on the formatted messages and an llm key derived from the model type, the invocation
+// parameters, the stop words, the functions and the structure of the messages, e.g. their names, tool calls
+// and multimodal content parts.
+func WithChatModelCache(chatModel schema.ChatModel, cache schema.Cache) *CachedChatModel {
+	return &CachedChatModel{
+		ChatModel: chatModel,
+		cache:     cache,
+	}
+}
+
+// Generate returns the cached result for the messages or generates and caches a new result.
+func (cm *CachedChatModel) Generate(ctx context.Context, messages schema.ChatMessages, optFns ...func(o *schema.GenerateOptions)) (*schema.ModelResult, error) {
+	prompt, llmKey, err := cm.cacheKey(messages, optFns)
+	if err != nil {
+		return nil, err
+	}
+
+	return cachedGenerate(ctx, cm.cache, prompt, llmKey, func() (*schema.ModelResult, error) {
+		return cm.ChatModel.Generate(ctx, messages, optFns...)
+	})
+}
+
+// Stream sends the cached result for the messages as a single chunk or streams the tokens of the wrapped
+// chat model and caches the complete generation. If the wrapped chat model does not implement
+// schema.StreamingChatModel, the generated result is sent as a single chunk.
+func (cm *CachedChatModel) Stream(ctx context.Context, messages schema.ChatMessages, optFns ...func(o *schema.GenerateOptions)) (<-chan schema.StreamChunk, error) {
+	sm, ok := cm.ChatModel.(schema.StreamingChatModel)
+	if !ok {
+		result, err := cm.Generate(ctx, messages, optFns...)
+		if err != nil {
+			return nil, err
+		}
+
+		return resultToStream(result), nil
+	}
+
+	prompt, llmKey, err := cm.cacheKey(messages, optFns)
+	if err != nil {
+		return nil, err
+	}
+
+	return cachedStream(ctx, cm.cache, prompt, llmKey, func() (<-chan schema.StreamChunk, error) {
+		return sm.Stream(ctx, messages, optFns...)
+	}, func(text string) schema.Generation {
+		return schema.Generation{
+			Text:    text,
+			Message: schema.NewAIChatMessage(text),
+		}
+	})
+}
+
+// BindTools binds the tools to the wrapped chat model and returns the bound model cached in the same cache.
+// The definitions of the tools are part of the llm key.
+func (cm *CachedChatModel) BindTools(tools []schema.Tool) (schema.ToolCallingChatModel, error) {
+	tc, ok := cm.ChatModel.(schema.ToolCallingChatModel)
+	if !ok {
+		return nil, fmt.Errorf("chat model %s does not support tool calling", cm.ChatModel.Type())
+	}
+
+	bound, err := tc.BindTools(tools)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := make([]schema.FunctionDefinition, 0, len(cm.tools)+len(tools))
+	definitions = append(definitions, cm.tools...)
+
+	for _, t := range tools {
+		f, err := schema.ToFunction(t)
+		if err != nil {
+			return nil, err
+		}
+
+		definitions = append(definitions, *f)
+	}
+
+	return &CachedChatModel{
+		ChatModel: bound,
+		cache:     cm.cache,
+		tools:     definitions,
+	}, nil
+}
+
+// cacheKey returns the formatted messages as prompt and the llm key of the messages.
+func (cm *CachedChatModel) cacheKey(messages schema.ChatMessages, optFns []func(o *schema.GenerateOptions)) (string, string, error) {
+	opts := schema.GenerateOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	prompt, err := messages.Format()
+	if err != nil {
+		return "", "", err
+	}
+
+	// The content of the messages is part of the prompt. All other attributes are part of the llm key.
+	structure := make([]map[string]any, len(messages))
+
+	for i, m := range messages {
+		message := map[string]any{}
+
+		for k, v := range schema.ChatMessageToMap(m) {
+			if k != "content" {
+				message[k] = v
+			}
+		}
+
+		if ai, ok := m.(*schema.AIChatMessage); ok {
+			message["extension"] = ai.Extension()
+		}
+
+		if human, ok := m.(*schema.HumanChatMessage); ok && len(human.Parts()) > 0 {
+			message["parts"] = cacheParts(human.Parts())
+		}
+
+		structure[i] = message
+	}
+
+	llmKey, err := cacheLLMKey(cm.ChatModel, opts, structure, cm.tools)
+	if err != nil {
+		return "", "", err
+	}
+
+	return prompt, llmKey, nil
+}
+
+// cacheParts returns the type, the MIME type, the URL and the sha256 hash of the text or data of the content
+// parts, so messages with the same text but different images or audio clips have different keys.
+func cacheParts(parts []schema.ContentPart) []map[string]string {
+	hashed := make([]map[string]string, len(parts))
+
+	for i, p := range parts {
+		hash := sha256.New()
+		hash.Write([]byte(p.Text))
+		hash.Write(p.Data)
+
+		hashed[i] = map[string]string{
+			"type":     string(p.Type),
+			"mimeType": p.MIMEType,
+			"url":      p.URL,
+			"hash":     hex.EncodeToString(hash.Sum(nil)),
+		}
+	}
+
+	return hashed
+}
+
+// cacheLLMKey returns the hex encoded sha256 hash of the model type, the invocation parameters
+// and all other inputs besides the prompt that influence the result.
+func cacheLLMKey(model schema.Model, opts schema.GenerateOptions, messages []map[string]any, tools []schema.FunctionDefinition) (string, error) {
+	key := map[string]any{
+		"type":              model.Type(),
+		"invocationParams":  model.InvocationParams(),
+		"stop":              opts.Stop,
+		"functions":         opts.Functions,
+		"forceFunctionCall": opts.ForceFunctionCall,
+		"messages":          messages,
+	}
+
+	// The bound tools and the response format are only added if present, so the keys of other calls are stable.
+	if len(tools) > 0 {
+		key["tools"] = tools
+	}
+
+	if opts.ResponseFormat != nil {
+		key["responseFormat"] = opts.ResponseFormat
+	}
+
+	b, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(b)
+
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// cachedGenerate returns the cached result for the prompt or calls generate and caches its result.
+// Cached results are marked with the "Cached" key in the LLMOutput and do not report any token usage,
+// because no tokens are consumed.
+func cachedGenerate(ctx context.Context, cache schema.Cache, prompt, llmKey string, generate func() (*schema.ModelResult, error)) (*schema.ModelResult, error) {
+	cached, ok, err := cache.Lookup(ctx, prompt, llmKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		return cachedResult(cached), nil
+	}
+
+	result, err := generate()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cache.Update(ctx, prompt, llmKey, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// cachedStream sends the cached result for the prompt as a single chunk or forwards the chunks of the stream
+// and caches the generation accumulated from the chunks, if the stream completes without error.
+func cachedStream(ctx context.Context, cache schema.Cache, prompt, llmKey string, stream func() (<-chan schema.StreamChunk, error), toGeneration func(text string) schema.Generation) (<-chan schema.StreamChunk, error) {
+	cached, ok, err := cache.Lookup(ctx, prompt, llmKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		return resultToStream(cachedResult(cached)), nil
+	}
+
+	upstream, err := stream()
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make(chan schema.StreamChunk)
+
+	go func() {
+		defer close(chunks)
+
+		var (
+			sb           strings.Builder
+			finishReason string
+			tokenUsage   *schema.TokenUsage
+		)
+
+		for chunk := range upstream {
+			select {
+			case <-ctx.Done():
+				return
+			case chunks <- chunk:
+			}
+
+			if chunk.Err != nil {
+				return
+			}
+
+			sb.WriteString(chunk.Token)
+
+			if chunk.FinishReason != "" {
+				finishReason = chunk.FinishReason
+			}
+
+			if chunk.TokenUsage != nil {
+				tokenUsage = chunk.TokenUsage
+			}
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		generation := toGeneration(sb.String())
+		generation.Info = map[string]any{
+			"FinishReason": finishReason,
+		}
+
+		llmOutput := map[string]any{}
+		if tokenUsage != nil {
+			llmOutput["TokenUsage"] = *tokenUsage
+		}
+
+		if err := cache.Update(ctx, prompt, llmKey, &schema.ModelResult{
+			Generations: []schema.Generation{generation},
+			LLMOutput:   llmOutput,
+		}); err != nil {
+			select {
+			case <-ctx.Done():
+			case chunks <- schema.StreamChunk{Err: err}:
+			}
+		}
+	}()
+
+	return chunks, nil
+}
+
+// cachedResult returns a copy of the cached result marked with the "Cached" key and without token usage.
+func cachedResult(cached *schema.ModelResult) *schema.ModelResult {
+	llmOutput := make(map[string]any, len(cached.LLMOutput)+1)
+	for k, v := range cached.LLMOutput {
+		if k != "TokenUsage" {
+			llmOutput[k] = v
+		}
+	}
+
+	llmOutput["Cached"] = true
+
+	return &schema.ModelResult{
+		Generations: cached.Generations,
+		LLMOutput:   llmOutput,
+	}
+}
@@ -0,0 +1,232 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hupe1980/golc/cache"
+	"github.com/hupe1980/golc/model/chatmodel"
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("LLM", func(t *testing.T) {
+		calls := 0
+
+		fake := llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+			calls++
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: "Hello " + prompt}},
+				LLMOutput:   map[string]any{"TokenUsage": schema.NewTokenUsage(1, 2)},
+			}, nil
+		})
+
+		cached := WithCache(fake, cache.NewInMemory())
+
+		result, err := LLMGenerate(ctx, cached, "World")
+		require.NoError(t, err)
+		assert.Equal(t, "Hello World", result.Generations[0].Text)
+		assert.NotContains(t, result.LLMOutput, "Cached")
+
+		result, err = LLMGenerate(ctx, cached, "World")
+		require.NoError(t, err)
+		assert.Equal(t, "Hello World", result.Generations[0].Text)
+		assert.Equal(t, true, result.LLMOutput["Cached"])
+
+		_, ok := result.TokenUsage()
+		assert.False(t, ok, "cached results consume no tokens")
+
+		assert.Equal(t, 1, calls)
+
+		_, err = LLMGenerate(ctx, cached, "World", func(o *Options) {
+			o.Stop = []string{"\n"}
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls, "stop words are part of the key")
+
+		_, err = LLMGenerate(ctx, cached, "Gopher")
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("ChatModel", func(t *testing.T) {
+		calls := 0
+
+		fake := chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+			calls++
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: "Hi", Message: schema.NewAIChatMessage("Hi")}},
+			}, nil
+		})
+
+		cached := WithChatModelCache(fake, cache.NewInMemory())
+
+		for i := 0; i < 2; i++ {
+			result, err := ChatModelGenerate(ctx, cached, schema.ChatMessages{schema.NewHumanChatMessage("Hello")})
+			require.NoError(t, err)
+			assert.Equal(t, "Hi", result.Generations[0].Message.Content())
+		}
+
+		assert.Equal(t, 1, calls)
+
+		_, err := ChatModelGenerate(ctx, cached, schema.ChatMessages{schema.NewSystemChatMessage("Hello")})
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls, "the message type is part of the key")
+
+		_, err = ChatModelGenerate(ctx, cached, schema.ChatMessages{schema.NewHumanChatMessage("Hello")}, func(o *Options) {
+			o.ResponseFormat = &schema.ResponseFormat{Name: "greeting"}
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls, "the response format is part of the key")
+	})
+
+	t.Run("MultimodalChatModel", func(t *testing.T) {
+		calls := 0
+
+		fake := chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+			calls++
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: "An image", Message: schema.NewAIChatMessage("An image")}},
+			}, nil
+		})
+
+		cached := WithChatModelCache(fake, cache.NewInMemory())
+
+		generate := func(image schema.ContentPart) {
+			_, err := ChatModelGenerate(ctx, cached, schema.ChatMessages{
+				schema.NewMultimodalHumanChatMessage(schema.NewTextPart("What is in the image?"), image),
+			})
+			require.NoError(t, err)
+		}
+
+		generate(schema.NewImagePart([]byte("cat"), "image/png"))
+		generate(schema.NewImagePart([]byte("cat"), "image/png"))
+		assert.Equal(t, 1, calls)
+
+		generate(schema.NewImagePart([]byte("dog"), "image/png"))
+		assert.Equal(t, 2, calls, "the image data is part of the key")
+
+		generate(schema.NewImagePart([]byte("dog"), "image/jpeg"))
+		assert.Equal(t, 3, calls, "the mime type is part of the key")
+
+		generate(schema.NewImageURLPart("https://example.com/cat.png"))
+		generate(schema.NewImageURLPart("https://example.com/dog.png"))
+		assert.Equal(t, 5, calls, "the image url is part of the key")
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		calls := 0
+
+		fake := chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+			calls++
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: "Hello there", Message: schema.NewAIChatMessage("Hello there")}},
+				LLMOutput:   map[string]any{"TokenUsage": schema.NewTokenUsage(1, 2)},
+			}, nil
+		})
+
+		cached := WithChatModelCache(fake, cache.NewInMemory())
+		messages := schema.ChatMessages{schema.NewHumanChatMessage("Hello")}
+
+		stream, err := ChatModelStream(ctx, cached, messages)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Hello ", "there"}, collectTokens(t, stream))
+
+		stream, err = ChatModelStream(ctx, cached, messages)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Hello there"}, collectTokens(t, stream))
+		assert.Equal(t, 1, calls)
+
+		result, err := ChatModelGenerate(ctx, cached, messages)
+		require.NoError(t, err)
+		assert.Equal(t, "Hello there", result.Generations[0].Message.Content())
+		assert.Equal(t, true, result.LLMOutput["Cached"])
+		assert.Equal(t, 1, calls, "streamed generations are cached")
+	})
+
+	t.Run("BindTools", func(t *testing.T) {
+		calls := 0
+
+		fake := &toolCallingFake{Fake: chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+			calls++
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: "Hi", Message: schema.NewAIChatMessage("Hi")}},
+			}, nil
+		})}
+
+		cached := WithChatModelCache(fake, cache.NewInMemory())
+
+		bound, err := cached.BindTools([]schema.Tool{&fakeTool{}})
+		require.NoError(t, err)
+		require.IsType(t, &CachedChatModel{}, bound)
+		require.Len(t, bound.(*CachedChatModel).ChatModel.(*toolCallingFake).tools, 1)
+
+		messages := schema.ChatMessages{schema.NewHumanChatMessage("Hello")}
+
+		for i := 0; i < 2; i++ {
+			_, err = ChatModelGenerate(ctx, bound, messages)
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, 1, calls)
+
+		_, err = ChatModelGenerate(ctx, cached, messages)
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls, "the bound tools are part of the key")
+
+		_, err = WithChatModelCache(chatmodel.NewSimpleFake("Hi"), cache.NewInMemory()).BindTools(nil)
+		require.ErrorContains(t, err, "does not support tool calling")
+	})
+}
+
+// toolCallingFake is a fake chat model, which records the tools bound to it.
+type toolCallingFake struct {
+	*chatmodel.Fake
+	tools []schema.Tool
+}
+
+func (cm *toolCallingFake) BindTools(tools []schema.Tool) (schema.ToolCallingChatModel, error) {
+	return &toolCallingFake{
+		Fake:  cm.Fake,
+		tools: append(append([]schema.Tool{}, cm.tools...), tools...),
+	}, nil
+}
+
+// fakeTool is a tool, which returns its input.
+type fakeTool struct{}
+
+func (t *fakeTool) Name() string {
+	return "Fake"
+}
+
+func (t *fakeTool) Description() string {
+	return "Returns the input."
+}
+
+func (t *fakeTool) Run(ctx context.Context, input any) (string, error) {
+	return fmt.Sprint(input), nil
+}
+
+func (t *fakeTool) ArgsType() reflect.Type {
+	return reflect.TypeOf("")
+}
+
+func (t *fakeTool) Verbose() bool {
+	return false
+}
+
+func (t *fakeTool) Callbacks() []schema.Callback {
+	return nil
+}
@@ -2,28 +2,35 @@ package chatmodel
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"strings"
+	"time"
 
 	"github.com/hupe1980/golc"
 	"github.com/hupe1980/golc/callback"
 	"github.com/hupe1980/golc/integration/anthropic"
+	"github.com/hupe1980/golc/internal/retry"
 	"github.com/hupe1980/golc/internal/util"
 	"github.com/hupe1980/golc/schema"
 	"github.com/hupe1980/golc/tokenizer"
 )
 
-const (
-	humanPromptPrefix = "\n\nHuman:"
-	aiPromptPrefix    = "\n\nAssistant:"
-)
+// Compile time check to ensure Anthropic satisfies the StreamingChatModel interface.
+var _ schema.StreamingChatModel = (*Anthropic)(nil)
 
-// Compile time check to ensure Anthropic satisfies the ChatModel interface.
-var _ schema.ChatModel = (*Anthropic)(nil)
+// Compile time check to ensure Anthropic satisfies the ToolCallingChatModel interface.
+var _ schema.ToolCallingChatModel = (*Anthropic)(nil)
 
 // AnthropicClient is the interface for the Anthropic client.
 type AnthropicClient interface {
-	CreateCompletion(ctx context.Context, request *anthropic.CompletionRequest) (*anthropic.CompletionResponse, error)
+	// CreateMessage sends a request to the Messages API and returns the response.
+	CreateMessage(ctx context.Context, request *anthropic.MessageRequest) (*anthropic.MessageResponse, error)
+	// CreateMessageStream sends a streaming request to the Messages API. The stream must be closed after use.
+	CreateMessageStream(ctx context.Context, request *anthropic.MessageRequest) (*anthropic.MessageStream, error)
 }
 
 // AnthropicOptions contains options for configuring the Anthropic chat model.
@@ -45,18 +52,30 @@ type AnthropicOptions struct {
 
 	// TopP parameter specifies the cumulative probability threshold for generating tokens.
 	TopP float32 `map:"top_p,omitempty"`
+
+	// Stream indicates whether to stream the results or not.
+	Stream bool `map:"stream,omitempty"`
+
+	// MaxRetries represents the maximum number of retries to make when generating.
+	MaxRetries uint `map:"max_retries,omitempty"`
+
+	// Timeout is the maximum duration of a request to the API. A value <= 0 disables the timeout.
+	Timeout time.Duration `map:"-"`
 }
 
-// Anthropic is a chat model based on the Anthropic API.
+// Anthropic is a chat model based on the Anthropic Messages API.
 type Anthropic struct {
 	schema.Tokenizer
 	client AnthropicClient
+	tools  []schema.FunctionDefinition
 	opts   AnthropicOptions
 }
 
 // NewAnthropic creates a new instance of the Anthropic chat model with the provided options.
 func NewAnthropic(apiKey string, optFns ...func(o *AnthropicOptions)) (*Anthropic, error) {
-	client := anthropic.New(apiKey)
+	client := anthropic.New(apiKey, func(o *anthropic.Options) {
+		o.HTTPClient = retry.NewHTTPClient(nil)
+	})
 
 	return NewAnthropicFromClient(client, optFns...)
 }
@@ -67,9 +86,10 @@ func NewAnthropicFromClient(client AnthropicClient, optFns ...func(o *AnthropicO
 		CallbackOptions: &schema.CallbackOptions{
 			Verbose: golc.Verbose,
 		},
-		ModelName:   "claude-v1",
+		ModelName:   "claude-3-haiku-20240307",
 		Temperature: 0.5,
 		MaxTokens:   256,
+		MaxRetries:  3,
 	}
 
 	for _, fn := range optFns {
@@ -94,6 +114,9 @@ func NewAnthropicFromClient(client AnthropicClient, optFns ...func(o *AnthropicO
 
 // Generate generates text based on the provided chat messages and options.
 func (cm *Anthropic) Generate(ctx context.Context, messages schema.ChatMessages, optFns ...func(o *schema.GenerateOptions)) (*schema.ModelResult, error) {
+	ctx, cancel := util.ContextWithTimeout(ctx, cm.opts.Timeout)
+	defer cancel()
+
 	opts := schema.GenerateOptions{
 		CallbackManger: &callback.NoopManager{},
 	}
@@ -102,30 +125,136 @@ func (cm *Anthropic) Generate(ctx context.Context, messages schema.ChatMessages,
 		fn(&opts)
 	}
 
-	prompt, err := convertMessagesToAnthropicPrompt(messages)
+	request, err := cm.newMessageRequest(messages, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := cm.client.CreateCompletion(ctx, &anthropic.CompletionRequest{
-		Prompt:      prompt,
-		Model:       cm.opts.ModelName,
-		Temperature: cm.opts.Temperature,
-		MaxTokens:   cm.opts.MaxTokens,
-		TopK:        cm.opts.TopK,
-		TopP:        cm.opts.TopP,
-		Stop:        opts.Stop,
-	})
+	var res *anthropic.MessageResponse
+
+	if cm.opts.Stream {
+		stream, err := cm.createMessageStreamWithRetry(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		defer stream.Close()
+
+		res, err = accumulateAnthropicStream(ctx, stream, func(token string) error {
+			return opts.CallbackManger.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{
+				Token: token,
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		res, err = cm.createMessageWithRetry(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	generation, err := anthropicResponseToGeneration(res)
 	if err != nil {
 		return nil, err
 	}
 
 	return &schema.ModelResult{
-		Generations: []schema.Generation{newChatGeneraton(res.Completion)},
-		LLMOutput:   map[string]any{},
+		Generations: []schema.Generation{generation},
+		LLMOutput: map[string]any{
+			"ModelName":  cm.opts.ModelName,
+			"TokenUsage": schema.NewTokenUsage(res.Usage.InputTokens, res.Usage.OutputTokens),
+		},
 	}, nil
 }
 
+// Stream generates text based on the provided chat messages and sends the tokens to the returned channel.
+func (cm *Anthropic) Stream(ctx context.Context, messages schema.ChatMessages, optFns ...func(o *schema.GenerateOptions)) (<-chan schema.StreamChunk, error) {
+	ctx, cancel := util.ContextWithTimeout(ctx, cm.opts.Timeout)
+
+	opts := schema.GenerateOptions{
+		CallbackManger: &callback.NoopManager{},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	request, err := cm.newMessageRequest(messages, opts)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	stream, err := cm.createMessageStreamWithRetry(ctx, request)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	chunks := make(chan schema.StreamChunk)
+
+	go func() {
+		defer cancel()
+		defer close(chunks)
+		defer stream.Close()
+
+		for {
+			event, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			if err != nil {
+				sendStreamChunk(ctx, chunks, schema.StreamChunk{Err: err})
+				return
+			}
+
+			if event.Delta == nil {
+				continue
+			}
+
+			chunk := schema.StreamChunk{
+				Token:        event.Delta.Text,
+				FinishReason: event.Delta.StopReason,
+			}
+
+			if chunk.Token == "" && chunk.FinishReason == "" {
+				continue
+			}
+
+			if chunk.Token != "" {
+				if err := opts.CallbackManger.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{
+					Token: chunk.Token,
+				}); err != nil {
+					sendStreamChunk(ctx, chunks, schema.StreamChunk{Err: err})
+					return
+				}
+			}
+
+			if !sendStreamChunk(ctx, chunks, chunk) {
+				return
+			}
+		}
+	}()
+
+	return chunks, nil
+}
+
+// BindTools returns a copy of the chat model that offers the given tools to the model on every generation.
+func (cm *Anthropic) BindTools(tools []schema.Tool) (schema.ToolCallingChatModel, error) {
+	functions, err := toFunctionDefinitions(tools)
+	if err != nil {
+		return nil, err
+	}
+
+	clone := *cm
+	clone.tools = functions
+
+	return &clone, nil
+}
+
 // Type returns the type of the model.
 func (cm *Anthropic) Type() string {
 	return "chatmodel.Anthropic"
@@ -146,28 +275,260 @@ func (cm *Anthropic) InvocationParams() map[string]any {
 	return util.StructToMap(cm.opts)
 }
 
-func convertMessagesToAnthropicPrompt(messages schema.ChatMessages) (string, error) {
-	if len(messages) > 0 {
-		msg := messages[len(messages)-1]
-		if msg.Type() != schema.ChatMessageTypeAI {
-			messages = append(messages, schema.NewAIChatMessage(""))
+// newMessageRequest creates a Messages API request for the provided chat messages and options.
+func (cm *Anthropic) newMessageRequest(messages schema.ChatMessages, opts schema.GenerateOptions) (*anthropic.MessageRequest, error) {
+	system, anthropicMessages, err := convertMessagesToAnthropicMessages(messages)
+	if err != nil {
+		return nil, err
+	}
+
+	functions := mergeFunctionDefinitions(cm.tools, opts.Functions)
+
+	request := &anthropic.MessageRequest{
+		Model:         cm.opts.ModelName,
+		Messages:      anthropicMessages,
+		System:        system,
+		Temperature:   cm.opts.Temperature,
+		MaxTokens:     cm.opts.MaxTokens,
+		TopK:          cm.opts.TopK,
+		TopP:          cm.opts.TopP,
+		StopSequences: opts.Stop,
+	}
+
+	if len(functions) > 0 {
+		request.Tools = util.Map(functions, func(fd schema.FunctionDefinition, _ int) anthropic.Tool {
+			return anthropic.Tool{
+				Name:        fd.Name,
+				Description: fd.Description,
+				InputSchema: fd.Parameters,
+			}
+		})
+	}
+
+	if opts.ForceFunctionCall && len(functions) == 1 {
+		request.ToolChoice = &anthropic.ToolChoice{
+			Type: "tool",
+			Name: functions[0].Name,
 		}
 	}
 
-	prompt := ""
+	return request, nil
+}
+
+// convertMessagesToAnthropicMessages converts the chat messages into a system prompt and Anthropic messages.
+// Consecutive messages of the same role are merged, as the Messages API requires alternating roles.
+func convertMessagesToAnthropicMessages(messages schema.ChatMessages) (string, []anthropic.Message, error) {
+	var (
+		system            []string
+		anthropicMessages []anthropic.Message
+	)
+
+	appendBlocks := func(role string, blocks ...anthropic.ContentBlock) {
+		if n := len(anthropicMessages); n > 0 && anthropicMessages[n-1].Role == role {
+			anthropicMessages[n-1].Content = append(anthropicMessages[n-1].Content, blocks...)
+			return
+		}
+
+		anthropicMessages = append(anthropicMessages, anthropic.Message{Role: role, Content: blocks})
+	}
 
 	for _, message := range messages {
-		switch message.Type() {
-		case schema.ChatMessageTypeSystem:
-			prompt += fmt.Sprintf("%s <admin>%s</admin>", humanPromptPrefix, message.Content())
-		case schema.ChatMessageTypeAI:
-			prompt += fmt.Sprintf("%s %s", aiPromptPrefix, message.Content())
-		case schema.ChatMessageTypeHuman:
-			prompt += fmt.Sprintf("%s %s", humanPromptPrefix, message.Content())
+		switch m := message.(type) {
+		case *schema.SystemChatMessage:
+			system = append(system, m.Content())
+		case *schema.HumanChatMessage:
+			if len(m.Parts()) == 0 {
+				appendBlocks("user", anthropic.ContentBlock{Type: "text", Text: m.Content()})
+				continue
+			}
+
+			blocks, err := toAnthropicContentBlocks(m.Parts())
+			if err != nil {
+				return "", nil, err
+			}
+
+			appendBlocks("user", blocks...)
+		case *schema.AIChatMessage:
+			blocks := []anthropic.ContentBlock{}
+
+			if m.Content() != "" {
+				blocks = append(blocks, anthropic.ContentBlock{Type: "text", Text: m.Content()})
+			}
+
+			for _, tc := range m.Extension().ToolCalls {
+				input := json.RawMessage(tc.Arguments)
+				if len(input) == 0 {
+					input = json.RawMessage("{}")
+				}
+
+				blocks = append(blocks, anthropic.ContentBlock{
+					Type:  "tool_use",
+					ID:    tc.ID,
+					Name:  tc.Name,
+					Input: input,
+				})
+			}
+
+			appendBlocks("assistant", blocks...)
+		case *schema.ToolChatMessage:
+			appendBlocks("user", anthropic.ContentBlock{
+				Type:      "tool_result",
+				ToolUseID: m.ToolCallID(),
+				Content:   m.Content(),
+			})
+		default:
+			return "", nil, fmt.Errorf("unsupported message type: %s", message.Type())
+		}
+	}
+
+	return strings.Join(system, "\n"), anthropicMessages, nil
+}
+
+// toAnthropicContentBlocks converts the content parts of a multimodal chat message to Anthropic content blocks.
+// Audio parts are not supported by the Messages API.
+func toAnthropicContentBlocks(parts []schema.ContentPart) ([]anthropic.ContentBlock, error) {
+	blocks := make([]anthropic.ContentBlock, 0, len(parts))
+
+	for _, p := range parts {
+		switch p.Type {
+		case schema.ContentPartTypeText:
+			blocks = append(blocks, anthropic.ContentBlock{Type: "text", Text: p.Text})
+		case schema.ContentPartTypeImageURL:
+			blocks = append(blocks, anthropic.ContentBlock{Type: "image", Source: &anthropic.ImageSource{
+				Type: "url",
+				URL:  p.URL,
+			}})
+		case schema.ContentPartTypeImage:
+			blocks = append(blocks, anthropic.ContentBlock{Type: "image", Source: &anthropic.ImageSource{
+				Type:      "base64",
+				MediaType: p.MIMEType,
+				Data:      base64.StdEncoding.EncodeToString(p.Data),
+			}})
+		default:
+			return nil, fmt.Errorf("unsupported content part type: %s", p.Type)
+		}
+	}
+
+	return blocks, nil
+}
+
+// anthropicResponseToGeneration converts an Anthropic message response to a generation.
+func anthropicResponseToGeneration(res *anthropic.MessageResponse) (schema.Generation, error) {
+	var (
+		texts     []string
+		toolCalls []schema.ToolCall
+	)
+
+	for _, block := range res.Content {
+		switch block.Type {
+		case "text":
+			texts = append(texts, block.Text)
+		case "tool_use":
+			toolCalls = append(toolCalls, schema.ToolCall{
+				ID:        block.ID,
+				Name:      block.Name,
+				Arguments: string(block.Input),
+			})
 		default:
-			return "", fmt.Errorf("unsupported message type: %s", message.Type())
+			return schema.Generation{}, fmt.Errorf("unexpected content block type returned from anthropic: %s", block.Type)
 		}
 	}
 
-	return strings.TrimRight(prompt, " "), nil
+	generation := newChatGeneraton(strings.Join(texts, ""), func(o *schema.ChatMessageExtension) {
+		if len(toolCalls) > 0 {
+			o.FunctionCall = &schema.FunctionCall{
+				Name:      toolCalls[0].Name,
+				Arguments: toolCalls[0].Arguments,
+			}
+			o.ToolCalls = toolCalls
+		}
+	})
+
+	generation.Info = map[string]any{
+		"FinishReason": res.StopReason,
+	}
+
+	return generation, nil
+}
+
+func (cm *Anthropic) createMessageWithRetry(ctx context.Context, request *anthropic.MessageRequest) (*anthropic.MessageResponse, error) {
+	policy := retry.NewPolicy(cm.opts.MaxRetries, retry.IsRetryableAnthropicError)
+
+	return retry.Do(ctx, policy, func(ctx context.Context) (*anthropic.MessageResponse, error) {
+		return cm.client.CreateMessage(ctx, request)
+	})
+}
+
+func (cm *Anthropic) createMessageStreamWithRetry(ctx context.Context, request *anthropic.MessageRequest) (*anthropic.MessageStream, error) {
+	policy := retry.NewPolicy(cm.opts.MaxRetries, retry.IsRetryableAnthropicError)
+
+	return retry.Do(ctx, policy, func(ctx context.Context) (*anthropic.MessageStream, error) {
+		return cm.client.CreateMessageStream(ctx, request)
+	})
+}
+
+// accumulateAnthropicStream reads the events of the stream and accumulates them into a message response.
+func accumulateAnthropicStream(ctx context.Context, stream *anthropic.MessageStream, onToken func(token string) error) (*anthropic.MessageResponse, error) {
+	res := &anthropic.MessageResponse{}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return res, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch event.Type {
+		case "message_start":
+			if event.Message != nil {
+				res.ID = event.Message.ID
+				res.Model = event.Message.Model
+				res.Usage = event.Message.Usage
+			}
+		case "content_block_start":
+			if event.ContentBlock != nil {
+				block := *event.ContentBlock
+				if block.Type == "tool_use" {
+					block.Input = nil
+				}
+
+				res.Content = append(res.Content, block)
+			}
+		case "content_block_delta":
+			if event.Delta == nil || event.Index >= len(res.Content) {
+				continue
+			}
+
+			block := &res.Content[event.Index]
+
+			switch event.Delta.Type {
+			case "text_delta":
+				if err := onToken(event.Delta.Text); err != nil {
+					return nil, err
+				}
+
+				block.Text += event.Delta.Text
+			case "input_json_delta":
+				block.Input = append(block.Input, event.Delta.PartialJSON...)
+			}
+		case "message_delta":
+			if event.Delta != nil {
+				res.StopReason = event.Delta.StopReason
+				res.StopSequence = event.Delta.StopSequence
+			}
+
+			if event.Usage != nil {
+				res.Usage.OutputTokens = event.Usage.OutputTokens
+			}
+		}
+	}
 }
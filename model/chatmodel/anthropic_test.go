@@ -1,12 +1,16 @@
 package chatmodel
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"testing"
 
 	"github.com/hupe1980/golc/integration/anthropic"
 	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tool"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -21,17 +25,25 @@ func TestAnthropic(t *testing.T) {
 	t.Run("Generation", func(t *testing.T) {
 		// Test case 1: Successful generation
 		t.Run("Successful generation", func(t *testing.T) {
-			// Mock the CreateCompletion method to return a valid response.
-			client.createCompletionFn = func(ctx context.Context, request *anthropic.CompletionRequest) (*anthropic.CompletionResponse, error) {
-				return &anthropic.CompletionResponse{
-					Completion: "Hello, how can I help you?",
+			// Mock the CreateMessage method to return a valid response.
+			client.createMessageFn = func(ctx context.Context, request *anthropic.MessageRequest) (*anthropic.MessageResponse, error) {
+				assert.Equal(t, "You are a helpful assistant.", request.System)
+				assert.Len(t, request.Messages, 2)
+				assert.Equal(t, "user", request.Messages[0].Role)
+				assert.Equal(t, "assistant", request.Messages[1].Role)
+
+				return &anthropic.MessageResponse{
+					Content:    []anthropic.ContentBlock{{Type: "text", Text: "Hello, how can I help you?"}},
+					StopReason: "end_turn",
+					Usage:      anthropic.Usage{InputTokens: 10, OutputTokens: 7},
 				}, nil
 			}
 
 			// Define chat messages
 			chatMessages := []schema.ChatMessage{
-				schema.NewAIChatMessage("Hi"),
+				schema.NewSystemChatMessage("You are a helpful assistant."),
 				schema.NewHumanChatMessage("Can you help me?"),
+				schema.NewAIChatMessage("Sure."),
 			}
 
 			// Generate text
@@ -40,12 +52,16 @@ func TestAnthropic(t *testing.T) {
 			assert.NotNil(t, result, "Expected non-nil result")
 			assert.Len(t, result.Generations, 1, "Expected 1 generation")
 			assert.Equal(t, "Hello, how can I help you?", result.Generations[0].Text, "Generated text does not match")
+			assert.Equal(t, "end_turn", result.Generations[0].Info["FinishReason"])
+			tokenUsage, ok := result.TokenUsage()
+			assert.True(t, ok)
+			assert.Equal(t, 17, tokenUsage.TotalTokens)
 		})
 
 		// Test case 2: Anthropic API error
 		t.Run("Anthropic API error", func(t *testing.T) {
-			// Mock the CreateCompletion method to return an error response.
-			client.createCompletionFn = func(ctx context.Context, request *anthropic.CompletionRequest) (*anthropic.CompletionResponse, error) {
+			// Mock the CreateMessage method to return an error response.
+			client.createMessageFn = func(ctx context.Context, request *anthropic.MessageRequest) (*anthropic.MessageResponse, error) {
 				return nil, fmt.Errorf("Anthropic API error")
 			}
 
@@ -60,6 +76,100 @@ func TestAnthropic(t *testing.T) {
 			assert.Error(t, err, "Expected an error")
 			assert.Nil(t, result, "Expected nil result")
 		})
+
+		// Test case 3: Retry of an overloaded API
+		t.Run("Retry", func(t *testing.T) {
+			calls := 0
+
+			client.createMessageFn = func(ctx context.Context, request *anthropic.MessageRequest) (*anthropic.MessageResponse, error) {
+				calls++
+				if calls == 1 {
+					return nil, &anthropic.ErrorDetail{Type: "overloaded_error", Message: "Overloaded", StatusCode: 529}
+				}
+
+				return &anthropic.MessageResponse{
+					Content: []anthropic.ContentBlock{{Type: "text", Text: "Hello"}},
+				}, nil
+			}
+
+			result, err := anthropicModel.Generate(context.Background(), schema.ChatMessages{schema.NewHumanChatMessage("Hi")})
+			assert.NoError(t, err)
+			assert.Equal(t, "Hello", result.Generations[0].Text)
+			assert.Equal(t, 2, calls)
+		})
+	})
+
+	t.Run("ToolUse", func(t *testing.T) {
+		toolModel, err := anthropicModel.BindTools([]schema.Tool{tool.NewSleep()})
+		assert.NoError(t, err)
+
+		client.createMessageFn = func(ctx context.Context, request *anthropic.MessageRequest) (*anthropic.MessageResponse, error) {
+			assert.Len(t, request.Tools, 1)
+			assert.Equal(t, "Sleep", request.Tools[0].Name)
+
+			return &anthropic.MessageResponse{
+				Content: []anthropic.ContentBlock{
+					{Type: "text", Text: "Let me sleep."},
+					{Type: "tool_use", ID: "toolu_1", Name: "Sleep", Input: json.RawMessage(`{"__arg1":"1"}`)},
+				},
+				StopReason: "tool_use",
+			}, nil
+		}
+
+		result, err := toolModel.Generate(context.Background(), schema.ChatMessages{
+			schema.NewHumanChatMessage("Sleep for one second"),
+		})
+		assert.NoError(t, err)
+
+		aiMsg, ok := result.Generations[0].Message.(*schema.AIChatMessage)
+		assert.True(t, ok)
+		assert.Equal(t, "Let me sleep.", aiMsg.Content())
+		assert.Equal(t, []schema.ToolCall{{ID: "toolu_1", Name: "Sleep", Arguments: `{"__arg1":"1"}`}}, aiMsg.Extension().ToolCalls)
+	})
+
+	t.Run("Streaming", func(t *testing.T) {
+		streamingModel, err := NewAnthropicFromClient(client, func(o *AnthropicOptions) {
+			o.Stream = true
+		})
+		assert.NoError(t, err)
+
+		client.createMessageStreamFn = func(ctx context.Context, request *anthropic.MessageRequest) (*anthropic.MessageStream, error) {
+			return newMockAnthropicMessageStream(
+				`{"type":"message_start","message":{"id":"msg_1","usage":{"input_tokens":5,"output_tokens":1}}}`,
+				`{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`,
+				`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hello"}}`,
+				`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":" world"}}`,
+				`{"type":"content_block_start","index":1,"content_block":{"type":"tool_use","id":"toolu_1","name":"Sleep","input":{}}}`,
+				`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"{\"__arg1\":"}}`,
+				`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"\"1\"}"}}`,
+				`{"type":"message_delta","delta":{"stop_reason":"tool_use"},"usage":{"output_tokens":12}}`,
+				`{"type":"message_stop"}`,
+			), nil
+		}
+
+		result, err := streamingModel.Generate(context.Background(), schema.ChatMessages{
+			schema.NewHumanChatMessage("Hello"),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello world", result.Generations[0].Text)
+		assert.Equal(t, "tool_use", result.Generations[0].Info["FinishReason"])
+
+		aiMsg, ok := result.Generations[0].Message.(*schema.AIChatMessage)
+		assert.True(t, ok)
+		assert.Equal(t, []schema.ToolCall{{ID: "toolu_1", Name: "Sleep", Arguments: `{"__arg1":"1"}`}}, aiMsg.Extension().ToolCalls)
+
+		stream, err := anthropicModel.Stream(context.Background(), schema.ChatMessages{
+			schema.NewHumanChatMessage("Hello"),
+		})
+		assert.NoError(t, err)
+
+		tokens := []string{}
+		for chunk := range stream {
+			assert.NoError(t, chunk.Err)
+			tokens = append(tokens, chunk.Token)
+		}
+
+		assert.Equal(t, []string{"Hello", " world", ""}, tokens)
 	})
 
 	t.Run("Type", func(t *testing.T) {
@@ -79,52 +189,107 @@ func TestAnthropic(t *testing.T) {
 		params := anthropicModel.InvocationParams()
 
 		// Assert the result
-		assert.Equal(t, "claude-v1", params["model_name"])
+		assert.Equal(t, "claude-3-haiku-20240307", params["model_name"])
 		assert.Equal(t, float32(0.5), params["temperature"])
 	})
 }
 
 // mockAnthropicClient is a mock implementation of the AnthropicClient interface for testing.
 type mockAnthropicClient struct {
-	createCompletionFn func(ctx context.Context, request *anthropic.CompletionRequest) (*anthropic.CompletionResponse, error)
+	createMessageFn       func(ctx context.Context, request *anthropic.MessageRequest) (*anthropic.MessageResponse, error)
+	createMessageStreamFn func(ctx context.Context, request *anthropic.MessageRequest) (*anthropic.MessageStream, error)
+}
+
+func (m *mockAnthropicClient) CreateMessage(ctx context.Context, request *anthropic.MessageRequest) (*anthropic.MessageResponse, error) {
+	return m.createMessageFn(ctx, request)
+}
+
+func (m *mockAnthropicClient) CreateMessageStream(ctx context.Context, request *anthropic.MessageRequest) (*anthropic.MessageStream, error) {
+	return m.createMessageStreamFn(ctx, request)
 }
 
-func (m *mockAnthropicClient) CreateCompletion(ctx context.Context, request *anthropic.CompletionRequest) (*anthropic.CompletionResponse, error) {
-	return m.createCompletionFn(ctx, request)
+// newMockAnthropicMessageStream creates a message stream from the given event payloads.
+func newMockAnthropicMessageStream(events ...string) *anthropic.MessageStream {
+	buf := &bytes.Buffer{}
+
+	for _, e := range events {
+		fmt.Fprintf(buf, "event: event\ndata: %s\n\n", e)
+	}
+
+	return anthropic.NewMessageStream(io.NopCloser(buf))
 }
 
-func TestConvertMessagesToAnthropicPrompt(t *testing.T) {
+func TestConvertMessagesToAnthropicMessages(t *testing.T) {
 	t.Run("Empty input messages", func(t *testing.T) {
-		emptyMessages := schema.ChatMessages{}
-		emptyPrompt, emptyErr := convertMessagesToAnthropicPrompt(emptyMessages)
-		assert.Equal(t, "", emptyPrompt)
-		assert.Nil(t, emptyErr)
+		system, messages, err := convertMessagesToAnthropicMessages(schema.ChatMessages{})
+		assert.NoError(t, err)
+		assert.Equal(t, "", system)
+		assert.Empty(t, messages)
+	})
+
+	t.Run("System messages", func(t *testing.T) {
+		system, messages, err := convertMessagesToAnthropicMessages(schema.ChatMessages{
+			schema.NewSystemChatMessage("System message"),
+			schema.NewHumanChatMessage("Human message"),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "System message", system)
+		assert.Equal(t, []anthropic.Message{
+			{Role: "user", Content: []anthropic.ContentBlock{{Type: "text", Text: "Human message"}}},
+		}, messages)
 	})
 
-	t.Run("Messages with a single system message", func(t *testing.T) {
-		systemMessage := schema.NewSystemChatMessage("System message")
-		messagesWithSystem := schema.ChatMessages{systemMessage}
-		systemPrompt, systemErr := convertMessagesToAnthropicPrompt(messagesWithSystem)
-		expectedSystemPrompt := "\n\nHuman: <admin>System message</admin>\n\nAssistant:"
-		assert.Equal(t, expectedSystemPrompt, systemPrompt)
-		assert.Nil(t, systemErr)
+	t.Run("Tool calls and results", func(t *testing.T) {
+		_, messages, err := convertMessagesToAnthropicMessages(schema.ChatMessages{
+			schema.NewHumanChatMessage("Sleep twice"),
+			schema.NewAIChatMessage("", func(o *schema.ChatMessageExtension) {
+				o.ToolCalls = []schema.ToolCall{
+					{ID: "toolu_1", Name: "Sleep", Arguments: `{"__arg1":"1"}`},
+					{ID: "toolu_2", Name: "Sleep", Arguments: `{"__arg1":"2"}`},
+				}
+			}),
+			schema.NewToolChatMessage("toolu_1", "Sleep", "slept 1"),
+			schema.NewToolChatMessage("toolu_2", "Sleep", "slept 2"),
+		})
+		assert.NoError(t, err)
+		assert.Len(t, messages, 3)
+		assert.Equal(t, "assistant", messages[1].Role)
+		assert.Len(t, messages[1].Content, 2)
+		assert.Equal(t, "tool_use", messages[1].Content[0].Type)
+		assert.Equal(t, "user", messages[2].Role)
+		assert.Equal(t, []anthropic.ContentBlock{
+			{Type: "tool_result", ToolUseID: "toolu_1", Content: "slept 1"},
+			{Type: "tool_result", ToolUseID: "toolu_2", Content: "slept 2"},
+		}, messages[2].Content)
 	})
 
-	t.Run("Messages with a single AI message", func(t *testing.T) {
-		aiMessage := schema.NewAIChatMessage("AI message")
-		messagesWithAI := schema.ChatMessages{aiMessage}
-		aiPrompt, aiErr := convertMessagesToAnthropicPrompt(messagesWithAI)
-		expectedAIPrompt := "\n\nAssistant: AI message"
-		assert.Equal(t, expectedAIPrompt, aiPrompt)
-		assert.Nil(t, aiErr)
+	t.Run("Multimodal messages", func(t *testing.T) {
+		_, messages, err := convertMessagesToAnthropicMessages(schema.ChatMessages{
+			schema.NewMultimodalHumanChatMessage(
+				schema.NewTextPart("What is in the images?"),
+				schema.NewImageURLPart("https://example.com/cat.png"),
+				schema.NewImagePart([]byte("png"), "image/png"),
+			),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []anthropic.Message{
+			{Role: "user", Content: []anthropic.ContentBlock{
+				{Type: "text", Text: "What is in the images?"},
+				{Type: "image", Source: &anthropic.ImageSource{Type: "url", URL: "https://example.com/cat.png"}},
+				{Type: "image", Source: &anthropic.ImageSource{Type: "base64", MediaType: "image/png", Data: "cG5n"}},
+			}},
+		}, messages)
+
+		_, _, err = convertMessagesToAnthropicMessages(schema.ChatMessages{
+			schema.NewMultimodalHumanChatMessage(schema.NewAudioPart([]byte("wav"), "audio/wav")),
+		})
+		assert.EqualError(t, err, "unsupported content part type: audio")
 	})
 
-	t.Run("Messages with a single human message", func(t *testing.T) {
-		humanMessage := schema.NewHumanChatMessage("Human message")
-		messagesWithHuman := schema.ChatMessages{humanMessage}
-		humanPrompt, humanErr := convertMessagesToAnthropicPrompt(messagesWithHuman)
-		expectedHumanPrompt := "\n\nHuman: Human message\n\nAssistant:"
-		assert.Equal(t, expectedHumanPrompt, humanPrompt)
-		assert.Nil(t, humanErr)
+	t.Run("Unsupported message type", func(t *testing.T) {
+		_, _, err := convertMessagesToAnthropicMessages(schema.ChatMessages{
+			schema.NewGenericChatMessage("Generic message", "role"),
+		})
+		assert.EqualError(t, err, "unsupported message type: generic")
 	})
 }
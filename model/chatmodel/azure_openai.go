@@ -1,20 +1,35 @@
 package chatmodel
 
 import (
+	"github.com/hupe1980/golc/integration"
+	"github.com/hupe1980/golc/internal/retry"
 	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/schema"
 	"github.com/sashabaranov/go-openai"
 )
 
+// Compile time check to ensure AzureOpenAI satisfies the ToolCallingChatModel interface.
+var _ schema.ToolCallingChatModel = (*AzureOpenAI)(nil)
+
+// AzureOpenAIOptions contains the options for the Azure OpenAI chat model.
 type AzureOpenAIOptions struct {
 	OpenAIOptions
+	// Deployment is the name of the model deployment.
 	Deployment string `map:"deployment,omitempty"`
+	// APIVersion is the version of the Azure OpenAI API.
+	APIVersion string `map:"api_version,omitempty"`
+	// TokenProvider provides Azure AD access tokens. If set, it is used instead of the api key.
+	TokenProvider integration.AzureADTokenProvider `map:"-"`
 }
 
+// AzureOpenAI represents the OpenAI chat model hosted on Azure.
 type AzureOpenAI struct {
 	*OpenAI
 	opts AzureOpenAIOptions
 }
 
+// NewAzureOpenAI creates a new instance of the Azure OpenAI chat model for the resource at the given endpoint.
+// The api key may be empty if a TokenProvider is configured.
 func NewAzureOpenAI(apiKey, baseURL string, optFns ...func(o *AzureOpenAIOptions)) (*AzureOpenAI, error) {
 	opts := AzureOpenAIOptions{
 		OpenAIOptions: DefaultOpenAIOptions,
@@ -24,18 +39,30 @@ func NewAzureOpenAI(apiKey, baseURL string, optFns ...func(o *AzureOpenAIOptions
 		fn(&opts)
 	}
 
-	config := openai.DefaultAzureConfig(apiKey, baseURL)
-	if opts.Deployment != "" {
-		config.AzureModelMapperFunc = func(model string) string {
-			azureModelMapping := map[string]string{
-				opts.ModelName: opts.Deployment,
-			}
+	config := integration.NewAzureOpenAIConfig(apiKey, baseURL, func(o *integration.AzureOpenAIConfigOptions) {
+		o.Deployment = opts.Deployment
+		o.APIVersion = opts.APIVersion
+		o.TokenProvider = opts.TokenProvider
+	})
+
+	config.HTTPClient = retry.NewHTTPClient(config.HTTPClient)
+
+	return NewAzureOpenAIFromClient(openai.NewClientWithConfig(config), func(o *AzureOpenAIOptions) {
+		*o = opts
+	})
+}
+
+// NewAzureOpenAIFromClient creates a new instance of the Azure OpenAI chat model with the provided client and options.
+func NewAzureOpenAIFromClient(client OpenAIClient, optFns ...func(o *AzureOpenAIOptions)) (*AzureOpenAI, error) {
+	opts := AzureOpenAIOptions{
+		OpenAIOptions: DefaultOpenAIOptions,
+	}
 
-			return azureModelMapping[model]
-		}
+	for _, fn := range optFns {
+		fn(&opts)
 	}
 
-	openAI, err := NewOpenAIFromClient(openai.NewClientWithConfig(config), func(o *OpenAIOptions) {
+	openAI, err := NewOpenAIFromClient(client, func(o *OpenAIOptions) {
 		*o = opts.OpenAIOptions
 	})
 	if err != nil {
@@ -48,6 +75,19 @@ func NewAzureOpenAI(apiKey, baseURL string, optFns ...func(o *AzureOpenAIOptions
 	}, nil
 }
 
+// BindTools returns a copy of the chat model that offers the given tools to the model on every generation.
+func (cm *AzureOpenAI) BindTools(tools []schema.Tool) (schema.ToolCallingChatModel, error) {
+	bound, err := cm.OpenAI.BindTools(tools)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AzureOpenAI{
+		OpenAI: bound.(*OpenAI),
+		opts:   cm.opts,
+	}, nil
+}
+
 // Type returns the type of the model.
 func (cm *AzureOpenAI) Type() string {
 	return "chatmodel.AzureOpenAI"
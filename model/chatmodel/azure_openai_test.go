@@ -0,0 +1,51 @@
+package chatmodel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tool"
+	"github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAzureOpenAI(t *testing.T) {
+	mockClient := &mockOpenAIClient{}
+
+	azureOpenAI, err := NewAzureOpenAIFromClient(mockClient, func(o *AzureOpenAIOptions) {
+		o.Deployment = "my-deployment"
+		o.APIVersion = "2024-02-01"
+	})
+	assert.NoError(t, err)
+
+	t.Run("BindTools", func(t *testing.T) {
+		toolModel, err := azureOpenAI.BindTools([]schema.Tool{tool.NewSleep()})
+		assert.NoError(t, err)
+		assert.Equal(t, "chatmodel.AzureOpenAI", toolModel.Type())
+
+		mockClient.createChatCompletionFn = func(ctx context.Context, request openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+			assert.Len(t, request.Tools, 1)
+
+			return openai.ChatCompletionResponse{
+				Choices: []openai.ChatCompletionChoice{{
+					Message: openai.ChatCompletionMessage{Role: "assistant", Content: "Hello"},
+				}},
+			}, nil
+		}
+
+		result, err := toolModel.Generate(context.Background(), schema.ChatMessages{schema.NewHumanChatMessage("Hi")})
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello", result.Generations[0].Text)
+	})
+
+	t.Run("Type", func(t *testing.T) {
+		assert.Equal(t, "chatmodel.AzureOpenAI", azureOpenAI.Type())
+	})
+
+	t.Run("InvocationParams", func(t *testing.T) {
+		params := azureOpenAI.InvocationParams()
+		assert.Equal(t, "my-deployment", params["deployment"])
+		assert.Equal(t, "2024-02-01", params["api_version"])
+	})
+}
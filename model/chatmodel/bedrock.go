@@ -2,6 +2,7 @@ package chatmodel
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -19,6 +20,12 @@ import (
 // Compile time check to ensure Bedrock satisfies the ChatModel interface.
 var _ schema.ChatModel = (*Bedrock)(nil)
 
+// Compile time check to ensure Bedrock satisfies the StreamingChatModel interface.
+var _ schema.StreamingChatModel = (*Bedrock)(nil)
+
+// Compile time check to ensure Bedrock satisfies the ToolCallingChatModel interface.
+var _ schema.ToolCallingChatModel = (*Bedrock)(nil)
+
 // BedrockRuntimeClient is an interface for the Bedrock model runtime client.
 type BedrockRuntimeClient interface {
 	ConverseStream(ctx context.Context, params *bedrockruntime.ConverseStreamInput, optFns ...func(*bedrockruntime.Options)) (*bedrockruntime.ConverseStreamOutput, error)
@@ -184,6 +191,7 @@ type Bedrock struct {
 	client  BedrockRuntimeClient
 	modelID string
 	opts    BedrockOptions
+	tools   []schema.FunctionDefinition
 }
 
 // NewBedrock creates an instance of the Bedrock model.
@@ -216,33 +224,72 @@ func NewBedrock(client BedrockRuntimeClient, modelID string, optFns ...func(o *B
 	}, nil
 }
 
+// PrepareInput converts the chat messages and additional model params into a Converse request.
+// Consecutive messages of the same role are merged, as the Converse API requires alternating roles.
 func (cm *Bedrock) PrepareInput(msgs schema.ChatMessages, params map[string]any) (*bedrockruntime.ConverseInput, error) {
 	messages := make([]bedrockruntimeTypes.Message, 0, len(msgs))
 	system := make([]bedrockruntimeTypes.SystemContentBlock, 0)
 
+	appendContent := func(role bedrockruntimeTypes.ConversationRole, blocks ...bedrockruntimeTypes.ContentBlock) {
+		if len(messages) > 0 && messages[len(messages)-1].Role == role {
+			messages[len(messages)-1].Content = append(messages[len(messages)-1].Content, blocks...)
+			return
+		}
+
+		messages = append(messages, bedrockruntimeTypes.Message{
+			Role:    role,
+			Content: blocks,
+		})
+	}
+
 	for _, msg := range msgs {
-		switch msg.Type() {
-		case schema.ChatMessageTypeSystem:
+		switch m := msg.(type) {
+		case *schema.SystemChatMessage:
 			system = append(system, &bedrockruntimeTypes.SystemContentBlockMemberText{
-				Value: msg.Content(),
+				Value: m.Content(),
 			})
-		case schema.ChatMessageTypeAI:
-			messages = append(messages, bedrockruntimeTypes.Message{
-				Role: bedrockruntimeTypes.ConversationRoleAssistant,
-				Content: []bedrockruntimeTypes.ContentBlock{
-					&bedrockruntimeTypes.ContentBlockMemberText{
-						Value: msg.Content(),
+		case *schema.AIChatMessage:
+			blocks := []bedrockruntimeTypes.ContentBlock{}
+
+			if m.Content() != "" {
+				blocks = append(blocks, &bedrockruntimeTypes.ContentBlockMemberText{
+					Value: m.Content(),
+				})
+			}
+
+			for _, tc := range m.Extension().ToolCalls {
+				input := map[string]any{}
+
+				if tc.Arguments != "" {
+					if err := json.Unmarshal([]byte(tc.Arguments), &input); err != nil {
+						return nil, fmt.Errorf("invalid arguments of tool call %s: %w", tc.ID, err)
+					}
+				}
+
+				blocks = append(blocks, &bedrockruntimeTypes.ContentBlockMemberToolUse{
+					Value: bedrockruntimeTypes.ToolUseBlock{
+						ToolUseId: aws.String(tc.ID),
+						Name:      aws.String(tc.Name),
+						Input:     bedrockruntimeDocument.NewLazyDocument(input),
+					},
+				})
+			}
+
+			appendContent(bedrockruntimeTypes.ConversationRoleAssistant, blocks...)
+		case *schema.ToolChatMessage:
+			appendContent(bedrockruntimeTypes.ConversationRoleUser, &bedrockruntimeTypes.ContentBlockMemberToolResult{
+				Value: bedrockruntimeTypes.ToolResultBlock{
+					ToolUseId: aws.String(m.ToolCallID()),
+					Content: []bedrockruntimeTypes.ToolResultContentBlock{
+						&bedrockruntimeTypes.ToolResultContentBlockMemberText{
+							Value: m.Content(),
+						},
 					},
 				},
 			})
 		default:
-			messages = append(messages, bedrockruntimeTypes.Message{
-				Role: bedrockruntimeTypes.ConversationRoleUser,
-				Content: []bedrockruntimeTypes.ContentBlock{
-					&bedrockruntimeTypes.ContentBlockMemberText{
-						Value: msg.Content(),
-					},
-				},
+			appendContent(bedrockruntimeTypes.ConversationRoleUser, &bedrockruntimeTypes.ContentBlockMemberText{
+				Value: msg.Content(),
 			})
 		}
 	}
@@ -277,30 +324,20 @@ func (cm *Bedrock) Generate(ctx context.Context, messages schema.ChatMessages, o
 		fn(&opts)
 	}
 
-	params := util.CopyMap(cm.opts.ModelParams)
-
-	input, err := cm.PrepareInput(messages, params)
+	input, err := cm.newConverseInput(messages, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	var completion string
-
-	llmOutput := make(map[string]any)
-
 	if cm.opts.Stream {
-		input := &bedrockruntime.ConverseStreamInput{
+		res, err := cm.client.ConverseStream(ctx, &bedrockruntime.ConverseStreamInput{
 			Messages:                     input.Messages,
 			ModelId:                      input.ModelId,
 			AdditionalModelRequestFields: input.AdditionalModelRequestFields,
 			InferenceConfig:              input.InferenceConfig,
 			System:                       input.System,
-		}
-
-		res, err := cm.client.ConverseStream(
-			ctx,
-			input,
-		)
+			ToolConfig:                   input.ToolConfig,
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -309,88 +346,156 @@ func (cm *Bedrock) Generate(ctx context.Context, messages schema.ChatMessages, o
 
 		defer stream.Close()
 
-		tokens := []string{}
-
-		for event := range stream.Events() {
-			switch v := event.(type) {
-			case *bedrockruntimeTypes.ConverseStreamOutputMemberContentBlockDelta:
-				delta := v.Value.Delta
+		generation, llmOutput, err := readBedrockConverseStream(stream.Events(), func(chunk schema.StreamChunk) error {
+			if chunk.Token == "" {
+				return nil
+			}
 
-				token, ok := delta.(*bedrockruntimeTypes.ContentBlockDeltaMemberText)
-				if !ok {
-					return nil, fmt.Errorf("unexpected content type returned from bedrock: %T", v)
-				}
+			return opts.CallbackManger.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{
+				Token: chunk.Token,
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
 
-				if err := opts.CallbackManger.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{
-					Token: token.Value,
-				}); err != nil {
-					return nil, err
-				}
+		if err := stream.Err(); err != nil {
+			return nil, err
+		}
 
-				tokens = append(tokens, token.Value)
-			case *bedrockruntimeTypes.ConverseStreamOutputMemberMetadata:
-				if v.Value.Usage == nil {
-					continue
-				}
+		llmOutput["ModelName"] = cm.modelID
 
-				usage := v.Value.Usage
+		return &schema.ModelResult{
+			Generations: []schema.Generation{generation},
+			LLMOutput:   llmOutput,
+		}, nil
+	}
 
-				if _, ok := llmOutput["input_tokens"]; !ok {
-					llmOutput["input_tokens"] = *usage.InputTokens
-				} else {
-					llmOutput["input_tokens"] = llmOutput["input_tokens"].(int32) + *usage.InputTokens
-				}
+	res, err := cm.client.Converse(ctx, input)
+	if err != nil {
+		return nil, err
+	}
 
-				if _, ok := llmOutput["output_tokens"]; !ok {
-					llmOutput["output_tokens"] = *usage.OutputTokens
-				} else {
-					llmOutput["output_tokens"] = llmOutput["output_tokens"].(int32) + *usage.OutputTokens
-				}
+	o, ok := res.Output.(*bedrockruntimeTypes.ConverseOutputMemberMessage)
+	if !ok {
+		return nil, fmt.Errorf("unexpected output type returned from bedrock: %T", res.Output)
+	}
 
-				if _, ok := llmOutput["tokens"]; !ok {
-					llmOutput["tokens"] = *usage.TotalTokens
-				} else {
-					llmOutput["tokens"] = llmOutput["tokens"].(int32) + *usage.TotalTokens
-				}
+	var (
+		completion string
+		toolCalls  []schema.ToolCall
+	)
+
+	for _, block := range o.Value.Content {
+		switch b := block.(type) {
+		case *bedrockruntimeTypes.ContentBlockMemberText:
+			completion += b.Value
+		case *bedrockruntimeTypes.ContentBlockMemberToolUse:
+			arguments, err := b.Value.Input.MarshalSmithyDocument()
+			if err != nil {
+				return nil, err
 			}
-		}
 
-		completion = strings.Join(tokens, "")
-	} else {
-		res, err := cm.client.Converse(ctx, input)
-		if err != nil {
-			return nil, err
+			toolCalls = append(toolCalls, schema.ToolCall{
+				ID:        aws.ToString(b.Value.ToolUseId),
+				Name:      aws.ToString(b.Value.Name),
+				Arguments: string(arguments),
+			})
+		default:
+			return nil, fmt.Errorf("unexpected content type returned from bedrock: %T", block)
 		}
+	}
 
-		o, ok := res.Output.(*bedrockruntimeTypes.ConverseOutputMemberMessage)
-		if !ok {
-			return nil, fmt.Errorf("unexpected output type returned from bedrock: %T", res.Output)
-		}
+	llmOutput := map[string]any{
+		"ModelName": cm.modelID,
+	}
+
+	if res.Usage != nil {
+		llmOutput["input_tokens"] = *res.Usage.InputTokens
+		llmOutput["output_tokens"] = *res.Usage.OutputTokens
+		llmOutput["tokens"] = *res.Usage.TotalTokens
+		llmOutput["TokenUsage"] = newBedrockTokenUsage(res.Usage)
+	}
 
-		var output string
+	return &schema.ModelResult{
+		Generations: []schema.Generation{newBedrockGeneration(completion, toolCalls, string(res.StopReason))},
+		LLMOutput:   llmOutput,
+	}, nil
+}
 
-		for _, block := range o.Value.Content {
-			text, ok := block.(*bedrockruntimeTypes.ContentBlockMemberText)
-			if !ok {
-				return nil, fmt.Errorf("unexpected content type returned from bedrock: %T", block)
+// Stream streams the tokens generated for the provided chat messages using the ConverseStream API.
+func (cm *Bedrock) Stream(ctx context.Context, messages schema.ChatMessages, optFns ...func(o *schema.GenerateOptions)) (<-chan schema.StreamChunk, error) {
+	opts := schema.GenerateOptions{
+		CallbackManger: &callback.NoopManager{},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	input, err := cm.newConverseInput(messages, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := cm.client.ConverseStream(ctx, &bedrockruntime.ConverseStreamInput{
+		Messages:                     input.Messages,
+		ModelId:                      input.ModelId,
+		AdditionalModelRequestFields: input.AdditionalModelRequestFields,
+		InferenceConfig:              input.InferenceConfig,
+		System:                       input.System,
+		ToolConfig:                   input.ToolConfig,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stream := res.GetStream()
+
+	chunks := make(chan schema.StreamChunk)
+
+	go func() {
+		defer close(chunks)
+		defer stream.Close()
+
+		if _, _, err := readBedrockConverseStream(stream.Events(), func(chunk schema.StreamChunk) error {
+			if chunk.Token != "" {
+				if err := opts.CallbackManger.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{
+					Token: chunk.Token,
+				}); err != nil {
+					return err
+				}
 			}
 
-			output += text.Value
-		}
+			if !sendStreamChunk(ctx, chunks, chunk) {
+				return ctx.Err()
+			}
 
-		completion = output
+			return nil
+		}); err != nil {
+			sendStreamChunk(ctx, chunks, schema.StreamChunk{Err: err})
+			return
+		}
 
-		if res.Usage != nil {
-			llmOutput["input_tokens"] = *res.Usage.InputTokens
-			llmOutput["output_tokens"] = *res.Usage.OutputTokens
-			llmOutput["tokens"] = *res.Usage.TotalTokens
+		if err := stream.Err(); err != nil {
+			sendStreamChunk(ctx, chunks, schema.StreamChunk{Err: err})
 		}
+	}()
+
+	return chunks, nil
+}
+
+// BindTools returns a copy of the chat model that offers the given tools to the model on every generation.
+func (cm *Bedrock) BindTools(tools []schema.Tool) (schema.ToolCallingChatModel, error) {
+	functions, err := toFunctionDefinitions(tools)
+	if err != nil {
+		return nil, err
 	}
 
-	return &schema.ModelResult{
-		Generations: []schema.Generation{newChatGeneraton(completion)},
-		LLMOutput:   llmOutput,
-	}, nil
+	clone := *cm
+	clone.tools = functions
+
+	return &clone, nil
 }
 
 // Type returns the type of the model.
@@ -415,3 +520,136 @@ func (cm *Bedrock) InvocationParams() map[string]any {
 
 	return params
 }
+
+// newConverseInput creates the Converse request including the stop sequences and tools of the generation.
+func (cm *Bedrock) newConverseInput(messages schema.ChatMessages, opts schema.GenerateOptions) (*bedrockruntime.ConverseInput, error) {
+	input, err := cm.PrepareInput(messages, util.CopyMap(cm.opts.ModelParams))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts.Stop) > 0 {
+		input.InferenceConfig.StopSequences = append(append([]string{}, cm.opts.StopSequences...), opts.Stop...)
+	}
+
+	functions := mergeFunctionDefinitions(cm.tools, opts.Functions)
+
+	if len(functions) > 0 {
+		input.ToolConfig = &bedrockruntimeTypes.ToolConfiguration{
+			Tools: util.Map(functions, func(fd schema.FunctionDefinition, _ int) bedrockruntimeTypes.Tool {
+				return &bedrockruntimeTypes.ToolMemberToolSpec{
+					Value: bedrockruntimeTypes.ToolSpecification{
+						Name:        aws.String(fd.Name),
+						Description: aws.String(fd.Description),
+						InputSchema: &bedrockruntimeTypes.ToolInputSchemaMemberJson{
+							Value: bedrockruntimeDocument.NewLazyDocument(fd.Parameters),
+						},
+					},
+				}
+			}),
+		}
+
+		if opts.ForceFunctionCall && len(functions) == 1 {
+			input.ToolConfig.ToolChoice = &bedrockruntimeTypes.ToolChoiceMemberTool{
+				Value: bedrockruntimeTypes.SpecificToolChoice{
+					Name: aws.String(functions[0].Name),
+				},
+			}
+		}
+	}
+
+	return input, nil
+}
+
+// newBedrockGeneration creates a chat generation with the tool calls and the finish reason of the response.
+func newBedrockGeneration(text string, toolCalls []schema.ToolCall, finishReason string) schema.Generation {
+	generation := newChatGeneraton(text, func(o *schema.ChatMessageExtension) {
+		o.ToolCalls = toolCalls
+	})
+
+	generation.Info = map[string]any{
+		"FinishReason": finishReason,
+	}
+
+	return generation
+}
+
+// readBedrockConverseStream reads the events of a ConverseStream response, passes the text deltas and the stop
+// reason to onChunk and returns the accumulated generation and token usage.
+func readBedrockConverseStream(events <-chan bedrockruntimeTypes.ConverseStreamOutput, onChunk func(chunk schema.StreamChunk) error) (schema.Generation, map[string]any, error) {
+	var (
+		sb         strings.Builder
+		toolCalls  []schema.ToolCall
+		arguments  = map[int32]*strings.Builder{}
+		toolIndex  = map[int32]int{}
+		stopReason string
+		llmOutput  = make(map[string]any)
+	)
+
+	for event := range events {
+		switch v := event.(type) {
+		case *bedrockruntimeTypes.ConverseStreamOutputMemberContentBlockStart:
+			start, ok := v.Value.Start.(*bedrockruntimeTypes.ContentBlockStartMemberToolUse)
+			if !ok {
+				continue
+			}
+
+			index := aws.ToInt32(v.Value.ContentBlockIndex)
+
+			toolIndex[index] = len(toolCalls)
+			arguments[index] = &strings.Builder{}
+
+			toolCalls = append(toolCalls, schema.ToolCall{
+				ID:   aws.ToString(start.Value.ToolUseId),
+				Name: aws.ToString(start.Value.Name),
+			})
+		case *bedrockruntimeTypes.ConverseStreamOutputMemberContentBlockDelta:
+			switch delta := v.Value.Delta.(type) {
+			case *bedrockruntimeTypes.ContentBlockDeltaMemberText:
+				sb.WriteString(delta.Value)
+
+				if err := onChunk(schema.StreamChunk{Token: delta.Value}); err != nil {
+					return schema.Generation{}, nil, err
+				}
+			case *bedrockruntimeTypes.ContentBlockDeltaMemberToolUse:
+				if b, ok := arguments[aws.ToInt32(v.Value.ContentBlockIndex)]; ok {
+					b.WriteString(aws.ToString(delta.Value.Input))
+				}
+			default:
+				return schema.Generation{}, nil, fmt.Errorf("unexpected content type returned from bedrock: %T", delta)
+			}
+		case *bedrockruntimeTypes.ConverseStreamOutputMemberMessageStop:
+			stopReason = string(v.Value.StopReason)
+
+			if err := onChunk(schema.StreamChunk{FinishReason: stopReason}); err != nil {
+				return schema.Generation{}, nil, err
+			}
+		case *bedrockruntimeTypes.ConverseStreamOutputMemberMetadata:
+			if v.Value.Usage == nil {
+				continue
+			}
+
+			usage := v.Value.Usage
+
+			llmOutput["input_tokens"] = aws.ToInt32(usage.InputTokens)
+			llmOutput["output_tokens"] = aws.ToInt32(usage.OutputTokens)
+			llmOutput["tokens"] = aws.ToInt32(usage.TotalTokens)
+			llmOutput["TokenUsage"] = newBedrockTokenUsage(usage)
+		}
+	}
+
+	for index, i := range toolIndex {
+		toolCalls[i].Arguments = arguments[index].String()
+	}
+
+	return newBedrockGeneration(sb.String(), toolCalls, stopReason), llmOutput, nil
+}
+
+// newBedrockTokenUsage converts the usage reported by the Converse API.
+func newBedrockTokenUsage(usage *bedrockruntimeTypes.TokenUsage) schema.TokenUsage {
+	return schema.TokenUsage{
+		PromptTokens:     int(aws.ToInt32(usage.InputTokens)),
+		CompletionTokens: int(aws.ToInt32(usage.OutputTokens)),
+		TotalTokens:      int(aws.ToInt32(usage.TotalTokens)),
+	}
+}
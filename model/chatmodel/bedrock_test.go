@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	bedrockruntimeDocument "github.com/aws/aws-sdk-go-v2/service/bedrockruntime/document"
 	bedrockruntimeTypes "github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
 	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tool"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -87,6 +90,7 @@ func TestBedrock(t *testing.T) {
 								Content: messages,
 							},
 						},
+						Usage: &bedrockruntimeTypes.TokenUsage{InputTokens: aws.Int32(9), OutputTokens: aws.Int32(7), TotalTokens: aws.Int32(16)},
 					}, nil
 				}
 
@@ -101,6 +105,10 @@ func TestBedrock(t *testing.T) {
 				assert.NotNil(t, result, "Expected non-nil result")
 				assert.Len(t, result.Generations, 1, "Expected 1 generation")
 				assert.Equal(t, "Hello, how can I help you?", result.Generations[0].Text, "Generated text does not match")
+
+				tokenUsage, ok := result.TokenUsage()
+				assert.True(t, ok, "Expected token usage")
+				assert.Equal(t, schema.NewTokenUsage(9, 7), tokenUsage)
 			})
 
 			t.Run("Bedrock API error", func(t *testing.T) {
@@ -122,6 +130,82 @@ func TestBedrock(t *testing.T) {
 		})
 	})
 
+	t.Run("ToolUse", func(t *testing.T) {
+		bedrockModel, err := NewBedrock(client, "anthropic.claude-3-haiku-20240307-v1:0")
+		assert.NoError(t, err)
+
+		toolModel, err := bedrockModel.BindTools([]schema.Tool{tool.NewSleep()})
+		assert.NoError(t, err)
+
+		client.createConverseFn = func(ctx context.Context, params *bedrockruntime.ConverseInput, optFns ...func(*bedrockruntime.Options)) (*bedrockruntime.ConverseOutput, error) {
+			assert.NotNil(t, params.ToolConfig)
+			assert.Len(t, params.ToolConfig.Tools, 1)
+			assert.Equal(t, []string{"Observation:"}, params.InferenceConfig.StopSequences)
+
+			spec, ok := params.ToolConfig.Tools[0].(*bedrockruntimeTypes.ToolMemberToolSpec)
+			assert.True(t, ok)
+			assert.Equal(t, "Sleep", aws.ToString(spec.Value.Name))
+
+			return &bedrockruntime.ConverseOutput{
+				Output: &bedrockruntimeTypes.ConverseOutputMemberMessage{
+					Value: bedrockruntimeTypes.Message{
+						Content: []bedrockruntimeTypes.ContentBlock{
+							&bedrockruntimeTypes.ContentBlockMemberText{Value: "Let me sleep."},
+							&bedrockruntimeTypes.ContentBlockMemberToolUse{Value: bedrockruntimeTypes.ToolUseBlock{
+								ToolUseId: aws.String("tooluse_1"),
+								Name:      aws.String("Sleep"),
+								Input:     bedrockruntimeDocument.NewLazyDocument(map[string]any{"__arg1": "1"}),
+							}},
+						},
+					},
+				},
+				StopReason: bedrockruntimeTypes.StopReasonToolUse,
+			}, nil
+		}
+
+		result, err := toolModel.Generate(context.Background(), schema.ChatMessages{
+			schema.NewHumanChatMessage("Sleep for one second"),
+		}, func(o *schema.GenerateOptions) {
+			o.Stop = []string{"Observation:"}
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "tool_use", result.Generations[0].Info["FinishReason"])
+
+		aiMsg, ok := result.Generations[0].Message.(*schema.AIChatMessage)
+		assert.True(t, ok)
+		assert.Equal(t, "Let me sleep.", aiMsg.Content())
+		assert.Equal(t, []schema.ToolCall{{ID: "tooluse_1", Name: "Sleep", Arguments: `{"__arg1":"1"}`}}, aiMsg.Extension().ToolCalls)
+	})
+
+	t.Run("PrepareInput", func(t *testing.T) {
+		bedrockModel, err := NewBedrock(client, "anthropic.claude-3-haiku-20240307-v1:0")
+		assert.NoError(t, err)
+
+		input, err := bedrockModel.PrepareInput(schema.ChatMessages{
+			schema.NewSystemChatMessage("You are a helpful assistant."),
+			schema.NewHumanChatMessage("Sleep twice"),
+			schema.NewAIChatMessage("", func(o *schema.ChatMessageExtension) {
+				o.ToolCalls = []schema.ToolCall{
+					{ID: "tooluse_1", Name: "Sleep", Arguments: `{"__arg1":"1"}`},
+					{ID: "tooluse_2", Name: "Sleep", Arguments: `{"__arg1":"2"}`},
+				}
+			}),
+			schema.NewToolChatMessage("tooluse_1", "Sleep", "slept 1"),
+			schema.NewToolChatMessage("tooluse_2", "Sleep", "slept 2"),
+		}, nil)
+		assert.NoError(t, err)
+		assert.Len(t, input.System, 1)
+		assert.Len(t, input.Messages, 3)
+		assert.Equal(t, bedrockruntimeTypes.ConversationRoleAssistant, input.Messages[1].Role)
+		assert.Len(t, input.Messages[1].Content, 2)
+		assert.Equal(t, bedrockruntimeTypes.ConversationRoleUser, input.Messages[2].Role)
+		assert.Len(t, input.Messages[2].Content, 2)
+
+		result, ok := input.Messages[2].Content[1].(*bedrockruntimeTypes.ContentBlockMemberToolResult)
+		assert.True(t, ok)
+		assert.Equal(t, "tooluse_2", aws.ToString(result.Value.ToolUseId))
+	})
+
 	t.Run("Type", func(t *testing.T) {
 		bedrockModel, err := NewBedrock(client, "anthropic.claude-v2")
 		assert.NoError(t, err)
@@ -167,3 +251,54 @@ func (m *mockBedrockClient) Converse(ctx context.Context, params *bedrockruntime
 func (m *mockBedrockClient) ConverseStream(ctx context.Context, params *bedrockruntime.ConverseStreamInput, optFns ...func(*bedrockruntime.Options)) (*bedrockruntime.ConverseStreamOutput, error) {
 	return nil, nil
 }
+
+func TestReadBedrockConverseStream(t *testing.T) {
+	events := make(chan bedrockruntimeTypes.ConverseStreamOutput, 8)
+	events <- &bedrockruntimeTypes.ConverseStreamOutputMemberContentBlockDelta{Value: bedrockruntimeTypes.ContentBlockDeltaEvent{
+		ContentBlockIndex: aws.Int32(0),
+		Delta:             &bedrockruntimeTypes.ContentBlockDeltaMemberText{Value: "Hello"},
+	}}
+	events <- &bedrockruntimeTypes.ConverseStreamOutputMemberContentBlockDelta{Value: bedrockruntimeTypes.ContentBlockDeltaEvent{
+		ContentBlockIndex: aws.Int32(0),
+		Delta:             &bedrockruntimeTypes.ContentBlockDeltaMemberText{Value: " world"},
+	}}
+	events <- &bedrockruntimeTypes.ConverseStreamOutputMemberContentBlockStart{Value: bedrockruntimeTypes.ContentBlockStartEvent{
+		ContentBlockIndex: aws.Int32(1),
+		Start: &bedrockruntimeTypes.ContentBlockStartMemberToolUse{Value: bedrockruntimeTypes.ToolUseBlockStart{
+			ToolUseId: aws.String("tooluse_1"),
+			Name:      aws.String("Sleep"),
+		}},
+	}}
+	events <- &bedrockruntimeTypes.ConverseStreamOutputMemberContentBlockDelta{Value: bedrockruntimeTypes.ContentBlockDeltaEvent{
+		ContentBlockIndex: aws.Int32(1),
+		Delta:             &bedrockruntimeTypes.ContentBlockDeltaMemberToolUse{Value: bedrockruntimeTypes.ToolUseBlockDelta{Input: aws.String(`{"__arg1":`)}},
+	}}
+	events <- &bedrockruntimeTypes.ConverseStreamOutputMemberContentBlockDelta{Value: bedrockruntimeTypes.ContentBlockDeltaEvent{
+		ContentBlockIndex: aws.Int32(1),
+		Delta:             &bedrockruntimeTypes.ContentBlockDeltaMemberToolUse{Value: bedrockruntimeTypes.ToolUseBlockDelta{Input: aws.String(`"1"}`)}},
+	}}
+	events <- &bedrockruntimeTypes.ConverseStreamOutputMemberMessageStop{Value: bedrockruntimeTypes.MessageStopEvent{
+		StopReason: bedrockruntimeTypes.StopReasonToolUse,
+	}}
+	events <- &bedrockruntimeTypes.ConverseStreamOutputMemberMetadata{Value: bedrockruntimeTypes.ConverseStreamMetadataEvent{
+		Usage: &bedrockruntimeTypes.TokenUsage{InputTokens: aws.Int32(5), OutputTokens: aws.Int32(7), TotalTokens: aws.Int32(12)},
+	}}
+	close(events)
+
+	chunks := []schema.StreamChunk{}
+
+	generation, llmOutput, err := readBedrockConverseStream(events, func(chunk schema.StreamChunk) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []schema.StreamChunk{{Token: "Hello"}, {Token: " world"}, {FinishReason: "tool_use"}}, chunks)
+	assert.Equal(t, "Hello world", generation.Text)
+	assert.Equal(t, "tool_use", generation.Info["FinishReason"])
+	assert.Equal(t, int32(12), llmOutput["tokens"])
+	assert.Equal(t, schema.NewTokenUsage(5, 7), llmOutput["TokenUsage"])
+
+	aiMsg, ok := generation.Message.(*schema.AIChatMessage)
+	assert.True(t, ok)
+	assert.Equal(t, []schema.ToolCall{{ID: "tooluse_1", Name: "Sleep", Arguments: `{"__arg1":"1"}`}}, aiMsg.Extension().ToolCalls)
+}
@@ -1,7 +1,11 @@
 // Package chatmodel provides a framework for working with chat-based large language models (LLMs).
 package chatmodel
 
-import "github.com/hupe1980/golc/schema"
+import (
+	"context"
+
+	"github.com/hupe1980/golc/schema"
+)
 
 func newChatGeneraton(text string, extFns ...func(o *schema.ChatMessageExtension)) schema.Generation { // nolint uparam
 	return schema.Generation{
@@ -9,3 +13,42 @@ func newChatGeneraton(text string, extFns ...func(o *schema.ChatMessageExtension
 		Message: schema.NewAIChatMessage(text, extFns...),
 	}
 }
+
+// sendStreamChunk sends the chunk to the channel unless the context is done.
+// It returns false if the chunk could not be delivered.
+func sendStreamChunk(ctx context.Context, chunks chan<- schema.StreamChunk, chunk schema.StreamChunk) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case chunks <- chunk:
+		return true
+	}
+}
+
+// toFunctionDefinitions converts the tools into function definitions that can be offered to a model.
+func toFunctionDefinitions(tools []schema.Tool) ([]schema.FunctionDefinition, error) {
+	functions := make([]schema.FunctionDefinition, len(tools))
+
+	for i, t := range tools {
+		f, err := schema.ToFunction(t)
+		if err != nil {
+			return nil, err
+		}
+
+		functions[i] = *f
+	}
+
+	return functions, nil
+}
+
+// mergeFunctionDefinitions returns the bound function definitions followed by the call specific ones.
+func mergeFunctionDefinitions(bound, functions []schema.FunctionDefinition) []schema.FunctionDefinition {
+	if len(bound) == 0 {
+		return functions
+	}
+
+	merged := make([]schema.FunctionDefinition, 0, len(bound)+len(functions))
+	merged = append(merged, bound...)
+
+	return append(merged, functions...)
+}
@@ -6,13 +6,14 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
-	"github.com/avast/retry-go"
 	cohere "github.com/cohere-ai/cohere-go/v2"
 	cohereclient "github.com/cohere-ai/cohere-go/v2/client"
 	core "github.com/cohere-ai/cohere-go/v2/core"
 	"github.com/hupe1980/golc"
 	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/internal/retry"
 	"github.com/hupe1980/golc/internal/util"
 	"github.com/hupe1980/golc/schema"
 	"github.com/hupe1980/golc/tokenizer"
@@ -51,6 +52,22 @@ type CohereOptions struct {
 
 	// Stream indicates whether to stream the results or not.
 	Stream bool `map:"stream,omitempty"`
+
+	// Documents are the documents the model should ground its reply in. The reply contains citations
+	// referencing the documents.
+	Documents []schema.Document `map:"-"`
+
+	// Connectors are the connectors used to retrieve documents, e.g. the "web-search" connector.
+	Connectors []*cohere.ChatConnector `map:"-"`
+
+	// CitationQuality dictates the approach taken to generating citations ("accurate" or "fast").
+	CitationQuality string `map:"citation_quality,omitempty"`
+
+	// PromptTruncation dictates how the prompt will be constructed ("OFF", "AUTO" or "AUTO_PRESERVE_ORDER").
+	PromptTruncation string `map:"prompt_truncation,omitempty"`
+
+	// Timeout is the maximum duration of a request to the API. A value <= 0 disables the timeout.
+	Timeout time.Duration `map:"-"`
 }
 
 // Cohere represents an instance of the Cohere language model.
@@ -63,7 +80,7 @@ type Cohere struct {
 // NewCohere creates a new Cohere instance using the provided API key and optional configuration options.
 // It internally creates a Cohere client using the provided API key and initializes the Cohere struct.
 func NewCohere(apiKey string, optFns ...func(o *CohereOptions)) (*Cohere, error) {
-	client := cohereclient.NewClient(cohereclient.WithToken(apiKey))
+	client := cohereclient.NewClient(cohereclient.WithToken(apiKey), cohereclient.WithHTTPClient(retry.NewHTTPClient(nil)))
 	return NewCohereFromClient(client, optFns...)
 }
 
@@ -99,8 +116,24 @@ func NewCohereFromClient(client CohereClient, optFns ...func(o *CohereOptions))
 	}, nil
 }
 
+// BindDocuments returns a copy of the model that grounds its replies in the given documents.
+// The citations of the reply are available in the generation info.
+func (cm *Cohere) BindDocuments(docs []schema.Document) *Cohere {
+	opts := cm.opts
+	opts.Documents = docs
+
+	return &Cohere{
+		Tokenizer: cm.Tokenizer,
+		client:    cm.client,
+		opts:      opts,
+	}
+}
+
 // Generate generates text based on the provided chat messages and options.
 func (cm *Cohere) Generate(ctx context.Context, messages schema.ChatMessages, optFns ...func(o *schema.GenerateOptions)) (*schema.ModelResult, error) {
+	ctx, cancel := util.ContextWithTimeout(ctx, cm.opts.Timeout)
+	defer cancel()
+
 	opts := schema.GenerateOptions{
 		CallbackManger: &callback.NoopManager{},
 	}
@@ -113,133 +146,118 @@ func (cm *Cohere) Generate(ctx context.Context, messages schema.ChatMessages, op
 		return nil, fmt.Errorf("at least one message must be passed")
 	}
 
-	chatMessages := make([]*cohere.Message, len(messages)-1)
+	var preamble []string
 
-	for i, m := range messages[:len(messages)-1] {
-		var message *cohere.Message
+	chatMessages := make([]*cohere.Message, 0, len(messages)-1)
 
+	for _, m := range messages[:len(messages)-1] {
 		switch m.Type() {
+		case schema.ChatMessageTypeSystem:
+			preamble = append(preamble, m.Content())
 		case schema.ChatMessageTypeAI:
-			message = &cohere.Message{
+			chatMessages = append(chatMessages, &cohere.Message{
 				Role: "CHATBOT",
 				Chatbot: &cohere.ChatMessage{
 					Message: m.Content(),
 				},
-			}
+			})
 		case schema.ChatMessageTypeHuman:
-			message = &cohere.Message{
+			chatMessages = append(chatMessages, &cohere.Message{
 				Role: "USER",
-				Chatbot: &cohere.ChatMessage{
+				User: &cohere.ChatMessage{
 					Message: m.Content(),
 				},
-			}
+			})
 		default:
 			return nil, fmt.Errorf("unsupported chat message type: %s", m.Type())
 		}
-
-		chatMessages[i] = message
 	}
 
-	var text string
+	documents := toCohereDocuments(cm.opts.Documents)
+
+	var res *cohere.NonStreamedChatResponse
 
 	if cm.opts.Stream {
-		stream, err := cm.client.ChatStream(ctx, &cohere.ChatStreamRequest{
+		req := &cohere.ChatStreamRequest{
 			Model:       util.AddrOrNil(cm.opts.Model),
 			Message:     messages[len(messages)-1].Content(),
+			Preamble:    util.AddrOrNil(strings.Join(preamble, "\n")),
 			ChatHistory: chatMessages,
 			Temperature: util.AddrOrNil(cm.opts.Temperature),
-		})
+			Documents:   documents,
+			Connectors:  cm.opts.Connectors,
+		}
+
+		if cm.opts.CitationQuality != "" {
+			req.CitationQuality = util.AddrOrNil(cohere.ChatStreamRequestCitationQuality(cm.opts.CitationQuality))
+		}
+
+		if cm.opts.PromptTruncation != "" {
+			req.PromptTruncation = util.AddrOrNil(cohere.ChatStreamRequestPromptTruncation(cm.opts.PromptTruncation))
+		}
+
+		stream, err := cm.client.ChatStream(ctx, req)
 		if err != nil {
 			return nil, err
 		}
 
 		defer stream.Close()
 
-		var tokens []string
-
-	streamProcessing:
-		for {
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			default:
-				res, err := stream.Recv()
-				if errors.Is(err, io.EOF) {
-					break streamProcessing
-				}
-				if err != nil {
-					return nil, err
-				}
-
-				if res.EventType == "text-generation" {
-					if err := opts.CallbackManger.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{
-						Token: res.TextGeneration.Text,
-					}); err != nil {
-						return nil, err
-					}
-
-					tokens = append(tokens, res.TextGeneration.Text)
-				}
-			}
+		res, err = readCohereChatStream(ctx, stream, func(token string) error {
+			return opts.CallbackManger.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{
+				Token: token,
+			})
+		})
+		if err != nil {
+			return nil, err
 		}
-
-		text = strings.Join(tokens, "")
 	} else {
-		res, err := cm.generateWithRetry(ctx, &cohere.ChatRequest{
+		req := &cohere.ChatRequest{
 			Model:       util.AddrOrNil(cm.opts.Model),
 			Message:     messages[len(messages)-1].Content(),
+			Preamble:    util.AddrOrNil(strings.Join(preamble, "\n")),
 			ChatHistory: chatMessages,
 			Temperature: util.AddrOrNil(cm.opts.Temperature),
-		})
+			Documents:   documents,
+			Connectors:  cm.opts.Connectors,
+		}
+
+		if cm.opts.CitationQuality != "" {
+			req.CitationQuality = util.AddrOrNil(cohere.ChatRequestCitationQuality(cm.opts.CitationQuality))
+		}
+
+		if cm.opts.PromptTruncation != "" {
+			req.PromptTruncation = util.AddrOrNil(cohere.ChatRequestPromptTruncation(cm.opts.PromptTruncation))
+		}
+
+		var err error
+
+		res, err = cm.generateWithRetry(ctx, req)
 		if err != nil {
 			return nil, err
 		}
+	}
 
-		text = res.Text
+	llmOutput := map[string]any{
+		"ModelName": cm.opts.Model,
+	}
+
+	if tokenUsage, ok := newCohereTokenUsage(res.Meta); ok {
+		llmOutput["TokenUsage"] = tokenUsage
 	}
 
 	return &schema.ModelResult{
-		Generations: []schema.Generation{newChatGeneraton(text)},
-		LLMOutput:   map[string]any{},
+		Generations: []schema.Generation{newCohereGeneration(res)},
+		LLMOutput:   llmOutput,
 	}, nil
 }
 
 func (cm *Cohere) generateWithRetry(ctx context.Context, req *cohere.ChatRequest) (*cohere.NonStreamedChatResponse, error) {
-	retryOpts := []retry.Option{
-		retry.Attempts(cm.opts.MaxRetries),
-		retry.DelayType(retry.FixedDelay),
-		retry.RetryIf(func(err error) bool {
-			e := new(core.APIError)
-			if errors.As(err, &e) {
-				switch e.StatusCode {
-				case 429, 500:
-					return true
-				default:
-					return false
-				}
-			}
-
-			return false
-		}),
-	}
-
-	var res *cohere.NonStreamedChatResponse
+	policy := retry.NewPolicy(cm.opts.MaxRetries, retry.IsRetryableCohereError)
 
-	err := retry.Do(
-		func() error {
-			r, cErr := cm.client.Chat(ctx, req)
-			if cErr != nil {
-				return cErr
-			}
-
-			res = r
-
-			return nil
-		},
-		retryOpts...,
-	)
-
-	return res, err
+	return retry.Do(ctx, policy, func(ctx context.Context) (*cohere.NonStreamedChatResponse, error) {
+		return cm.client.Chat(ctx, req)
+	})
 }
 
 // Type returns the type of the model.
@@ -261,3 +279,150 @@ func (cm *Cohere) Callbacks() []schema.Callback {
 func (cm *Cohere) InvocationParams() map[string]any {
 	return util.StructToMap(cm.opts)
 }
+
+// readCohereChatStream reads the stream until its end and returns the consolidated response.
+func readCohereChatStream(ctx context.Context, stream *core.Stream[cohere.StreamedChatResponse], onToken func(token string) error) (*cohere.NonStreamedChatResponse, error) {
+	var (
+		tokens    []string
+		citations []*cohere.ChatCitation
+		final     *cohere.NonStreamedChatResponse
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+			res, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				if final == nil {
+					// The stream ended without a stream-end event, so the response is assembled from the received events.
+					final = &cohere.NonStreamedChatResponse{
+						Text:      strings.Join(tokens, ""),
+						Citations: citations,
+					}
+				}
+
+				return final, nil
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			switch res.EventType {
+			case "text-generation":
+				if err := onToken(res.TextGeneration.Text); err != nil {
+					return nil, err
+				}
+
+				tokens = append(tokens, res.TextGeneration.Text)
+			case "citation-generation":
+				citations = append(citations, res.CitationGeneration.Citations...)
+			case "stream-end":
+				if res.StreamEnd.Response != nil {
+					final = res.StreamEnd.Response
+				} else {
+					final = &cohere.NonStreamedChatResponse{
+						Text:      strings.Join(tokens, ""),
+						Citations: citations,
+					}
+				}
+
+				if final.FinishReason == nil && res.StreamEnd.FinishReason != "" {
+					final.FinishReason = util.AddrOrNil(cohere.FinishReason(res.StreamEnd.FinishReason))
+				}
+			}
+		}
+	}
+}
+
+// newCohereGeneration creates a generation from the chat response. The citations, the cited documents
+// and the search queries of a grounded reply are exposed in the generation info.
+func newCohereGeneration(res *cohere.NonStreamedChatResponse) schema.Generation {
+	generation := newChatGeneraton(res.Text)
+
+	info := map[string]any{}
+
+	if res.FinishReason != nil {
+		info["FinishReason"] = string(*res.FinishReason)
+	}
+
+	if len(res.Citations) > 0 {
+		citations := make([]schema.Citation, len(res.Citations))
+		for i, c := range res.Citations {
+			citations[i] = schema.Citation{
+				Start:       c.Start,
+				End:         c.End,
+				Text:        c.Text,
+				DocumentIDs: c.DocumentIds,
+			}
+		}
+
+		info["Citations"] = citations
+	}
+
+	if len(res.Documents) > 0 {
+		info["Documents"] = res.Documents
+	}
+
+	if len(res.SearchQueries) > 0 {
+		queries := make([]string, len(res.SearchQueries))
+		for i, q := range res.SearchQueries {
+			queries[i] = q.Text
+		}
+
+		info["SearchQueries"] = queries
+	}
+
+	generation.Info = info
+
+	return generation
+}
+
+// toCohereDocuments converts the documents into the document format of the chat endpoint.
+// The metadata of a document is passed along as additional fields. Documents without an "id"
+// metadata field are identified by their position, i.e. doc_0, doc_1, ...
+func toCohereDocuments(docs []schema.Document) []cohere.ChatDocument {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	documents := make([]cohere.ChatDocument, len(docs))
+
+	for i, d := range docs {
+		document := cohere.ChatDocument{}
+
+		for k, v := range d.Metadata {
+			document[k] = fmt.Sprint(v)
+		}
+
+		if _, ok := document["id"]; !ok {
+			document["id"] = fmt.Sprintf("doc_%d", i)
+		}
+
+		document["text"] = d.PageContent
+		documents[i] = document
+	}
+
+	return documents
+}
+
+// newCohereTokenUsage converts the billed units of the response meta into a token usage.
+func newCohereTokenUsage(meta *cohere.ApiMeta) (schema.TokenUsage, bool) {
+	if meta == nil || meta.BilledUnits == nil {
+		return schema.TokenUsage{}, false
+	}
+
+	var promptTokens, completionTokens int
+
+	if meta.BilledUnits.InputTokens != nil {
+		promptTokens = int(*meta.BilledUnits.InputTokens)
+	}
+
+	if meta.BilledUnits.OutputTokens != nil {
+		completionTokens = int(*meta.BilledUnits.OutputTokens)
+	}
+
+	return schema.NewTokenUsage(promptTokens, completionTokens), true
+}
@@ -2,12 +2,17 @@ package chatmodel
 
 import (
 	"context"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
 
 	cohere "github.com/cohere-ai/cohere-go/v2"
 	"github.com/cohere-ai/cohere-go/v2/core"
+	"github.com/hupe1980/golc/internal/util"
 	"github.com/hupe1980/golc/schema"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCohereGenerate(t *testing.T) {
@@ -67,6 +72,112 @@ func TestCohereGenerate(t *testing.T) {
 	})
 }
 
+func TestCohereGrounding(t *testing.T) {
+	docs := []schema.Document{
+		{PageContent: "Emperor penguins are the tallest.", Metadata: map[string]any{"title": "Tall penguins"}},
+		{PageContent: "Emperor penguins only live in Antarctica.", Metadata: map[string]any{"id": "antarctica"}},
+	}
+
+	t.Run("Generate", func(t *testing.T) {
+		mockClient := &mockCohereClient{
+			ChatFn: func(ctx context.Context, request *cohere.ChatRequest, opts ...core.RequestOption) (*cohere.NonStreamedChatResponse, error) {
+				assert.Equal(t, "Be precise.", *request.Preamble)
+				assert.Equal(t, "USER", request.ChatHistory[0].Role)
+				assert.Equal(t, "Hi", request.ChatHistory[0].User.Message)
+				assert.Equal(t, "CHATBOT", request.ChatHistory[1].Role)
+				assert.Equal(t, cohere.ChatRequestCitationQualityFast, *request.CitationQuality)
+				assert.Equal(t, "web-search", request.Connectors[0].Id)
+				assert.Equal(t, []cohere.ChatDocument{
+					{"id": "doc_0", "title": "Tall penguins", "text": "Emperor penguins are the tallest."},
+					{"id": "antarctica", "text": "Emperor penguins only live in Antarctica."},
+				}, request.Documents)
+
+				finishReason := cohere.FinishReasonComplete
+
+				return &cohere.NonStreamedChatResponse{
+					Text: "The tallest penguins are emperor penguins.",
+					Citations: []*cohere.ChatCitation{
+						{Start: 26, End: 42, Text: "emperor penguins", DocumentIds: []string{"doc_0"}},
+					},
+					Documents:     []cohere.ChatDocument{{"id": "doc_0", "text": "Emperor penguins are the tallest."}},
+					SearchQueries: []*cohere.ChatSearchQuery{{Text: "tallest penguins"}},
+					FinishReason:  &finishReason,
+					Meta: &cohere.ApiMeta{BilledUnits: &cohere.ApiMetaBilledUnits{
+						InputTokens:  util.AddrOrNil(float64(42)),
+						OutputTokens: util.AddrOrNil(float64(8)),
+					}},
+				}, nil
+			},
+		}
+
+		model, err := NewCohereFromClient(mockClient, func(o *CohereOptions) {
+			o.CitationQuality = "fast"
+			o.Connectors = []*cohere.ChatConnector{{Id: "web-search"}}
+		})
+		require.NoError(t, err)
+
+		result, err := model.BindDocuments(docs).Generate(context.Background(), schema.ChatMessages{
+			schema.NewSystemChatMessage("Be precise."),
+			schema.NewHumanChatMessage("Hi"),
+			schema.NewAIChatMessage("Hello"),
+			schema.NewHumanChatMessage("What are the tallest penguins?"),
+		})
+		require.NoError(t, err)
+
+		info := result.Generations[0].Info
+		assert.Equal(t, "COMPLETE", info["FinishReason"])
+		assert.Equal(t, []schema.Citation{
+			{Start: 26, End: 42, Text: "emperor penguins", DocumentIDs: []string{"doc_0"}},
+		}, info["Citations"])
+		assert.Equal(t, []string{"tallest penguins"}, info["SearchQueries"])
+		assert.Len(t, info["Documents"], 1)
+
+		tokenUsage, ok := result.TokenUsage()
+		assert.True(t, ok)
+		assert.Equal(t, schema.NewTokenUsage(42, 8), tokenUsage)
+
+		// The documents are bound to the copy only.
+		assert.Nil(t, model.opts.Documents)
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		events := strings.Join([]string{
+			`{"event_type":"stream-start","generation_id":"123"}`,
+			`{"event_type":"text-generation","text":"Emperor "}`,
+			`{"event_type":"text-generation","text":"penguins."}`,
+			`{"event_type":"citation-generation","citations":[{"start":0,"end":16,"text":"Emperor penguins","document_ids":["doc_0"]}]}`,
+			`{"event_type":"stream-end","finish_reason":"COMPLETE"}`,
+		}, "\n") + "\n"
+
+		mockClient := &mockCohereClient{
+			ChatStreamFn: func(ctx context.Context, request *cohere.ChatStreamRequest, opts ...core.RequestOption) (*core.Stream[cohere.StreamedChatResponse], error) {
+				assert.Len(t, request.Documents, 2)
+
+				return core.NewStream[cohere.StreamedChatResponse](&http.Response{
+					Body: io.NopCloser(strings.NewReader(events)),
+				}), nil
+			},
+		}
+
+		model, err := NewCohereFromClient(mockClient, func(o *CohereOptions) {
+			o.Stream = true
+			o.Documents = docs
+		})
+		require.NoError(t, err)
+
+		result, err := model.Generate(context.Background(), schema.ChatMessages{
+			schema.NewHumanChatMessage("What are the tallest penguins?"),
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "Emperor penguins.", result.Generations[0].Text)
+		assert.Equal(t, "COMPLETE", result.Generations[0].Info["FinishReason"])
+		assert.Equal(t, []schema.Citation{
+			{Start: 0, End: 16, Text: "Emperor penguins", DocumentIDs: []string{"doc_0"}},
+		}, result.Generations[0].Info["Citations"])
+	})
+}
+
 // mockCohereClient is a mock implementation of the CohereClient interface.
 type mockCohereClient struct {
 	ChatFn       func(ctx context.Context, request *cohere.ChatRequest, opts ...core.RequestOption) (*cohere.NonStreamedChatResponse, error)
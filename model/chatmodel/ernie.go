@@ -139,10 +139,10 @@ func (cm *Ernie) Generate(ctx context.Context, messages schema.ChatMessages, opt
 		Message: schema.NewAIChatMessage(res.Result),
 	}
 
-	tokenUsage := map[string]int{
-		"PromptTokens":     res.Usage.PromptTokens,
-		"CompletionTokens": res.Usage.CompletionTokens,
-		"TotalTokens":      res.Usage.TotalTokens,
+	tokenUsage := schema.TokenUsage{
+		PromptTokens:     res.Usage.PromptTokens,
+		CompletionTokens: res.Usage.CompletionTokens,
+		TotalTokens:      res.Usage.TotalTokens,
 	}
 
 	return &schema.ModelResult{
@@ -2,6 +2,7 @@ package chatmodel
 
 import (
 	"context"
+	"strings"
 
 	"github.com/hupe1980/golc"
 	"github.com/hupe1980/golc/callback"
@@ -9,8 +10,8 @@ import (
 	"github.com/hupe1980/golc/schema"
 )
 
-// Compile time check to ensure Fake satisfies the ChatModel interface.
-var _ schema.ChatModel = (*Fake)(nil)
+// Compile time check to ensure Fake satisfies the StreamingChatModel interface.
+var _ schema.StreamingChatModel = (*Fake)(nil)
 
 // FakeResultFunc is a function type used for providing custom model results in the Fake model.
 type FakeResultFunc func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error)
@@ -53,6 +54,7 @@ func NewFake(fakeResultFunc FakeResultFunc, optFns ...func(o *FakeOptions)) *Fak
 	}
 
 	return &Fake{
+		Tokenizer:      opts.Tokenizer,
 		fakeResultFunc: fakeResultFunc,
 		opts:           opts,
 	}
@@ -71,6 +73,59 @@ func (cm *Fake) Generate(ctx context.Context, messages schema.ChatMessages, optF
 	return cm.fakeResultFunc(ctx, messages)
 }
 
+// Stream generates text based on the provided chat messages and sends the words of the first generation
+// as individual tokens to the returned channel. The token usage of the result is sent with the last chunk.
+func (cm *Fake) Stream(ctx context.Context, messages schema.ChatMessages, optFns ...func(o *schema.GenerateOptions)) (<-chan schema.StreamChunk, error) {
+	opts := schema.GenerateOptions{
+		CallbackManger: &callback.NoopManager{},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	result, err := cm.fakeResultFunc(ctx, messages)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens []string
+	if len(result.Generations) > 0 {
+		tokens = strings.SplitAfter(result.Generations[0].Text, " ")
+	}
+
+	var tokenUsage *schema.TokenUsage
+	if usage, ok := result.TokenUsage(); ok {
+		tokenUsage = &usage
+	}
+
+	chunks := make(chan schema.StreamChunk)
+
+	go func() {
+		defer close(chunks)
+
+		for i, token := range tokens {
+			if err := opts.CallbackManger.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{
+				Token: token,
+			}); err != nil {
+				sendStreamChunk(ctx, chunks, schema.StreamChunk{Err: err})
+				return
+			}
+
+			chunk := schema.StreamChunk{Token: token}
+			if i == len(tokens)-1 {
+				chunk.TokenUsage = tokenUsage
+			}
+
+			if !sendStreamChunk(ctx, chunks, chunk) {
+				return
+			}
+		}
+	}()
+
+	return chunks, nil
+}
+
 // Type returns the type of the model.
 func (cm *Fake) Type() string {
 	return cm.opts.ChatModelType
@@ -0,0 +1,68 @@
+package chatmodel
+
+import (
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Fireworks satisfies the ToolCallingChatModel interface.
+var _ schema.ToolCallingChatModel = (*Fireworks)(nil)
+
+// FireworksOptions contains the options for the Fireworks AI chat model.
+type FireworksOptions struct {
+	OpenAIOptions
+}
+
+// DefaultFireworksOptions contains the default options for the Fireworks AI chat model.
+var DefaultFireworksOptions = FireworksOptions{
+	OpenAIOptions: OpenAIOptions{
+		CallbackOptions:  DefaultOpenAIOptions.CallbackOptions,
+		ModelName:        "accounts/fireworks/models/llama-v3-8b-instruct",
+		Temperature:      0.7,
+		TopP:             1,
+		PresencePenalty:  0,
+		FrequencyPenalty: 0,
+		BaseURL:          "https://api.fireworks.ai/inference/v1",
+		MaxRetries:       3,
+	},
+}
+
+// Fireworks represents the Fireworks AI chat model.
+type Fireworks struct {
+	*openAICompatible
+}
+
+// NewFireworks creates a new instance of the Fireworks AI chat model.
+func NewFireworks(apiKey string, optFns ...func(o *FireworksOptions)) (*Fireworks, error) {
+	opts := DefaultFireworksOptions
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	cm, err := newOpenAICompatible("chatmodel.Fireworks", apiKey, opts.OpenAIOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Fireworks{
+		openAICompatible: cm,
+	}, nil
+}
+
+// NewFireworksFromClient creates a new instance of the Fireworks AI chat model with the provided client and options.
+func NewFireworksFromClient(client OpenAIClient, optFns ...func(o *FireworksOptions)) (*Fireworks, error) {
+	opts := DefaultFireworksOptions
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	cm, err := newOpenAICompatibleFromClient("chatmodel.Fireworks", client, opts.OpenAIOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Fireworks{
+		openAICompatible: cm,
+	}, nil
+}
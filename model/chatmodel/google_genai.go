@@ -98,6 +98,11 @@ func (cm *GoogleGenAI) Generate(ctx context.Context, messages schema.ChatMessage
 		fn(&opts)
 	}
 
+	// The v1 API of Google GenAI has no JSON mode.
+	if opts.ResponseFormat != nil {
+		return nil, fmt.Errorf("%w: google genai has no JSON mode", schema.ErrResponseFormatNotSupported)
+	}
+
 	contents := []*generativelanguagepb.Content{}
 
 	for _, message := range messages {
@@ -107,9 +112,12 @@ func (cm *GoogleGenAI) Generate(ctx context.Context, messages schema.ChatMessage
 				Data: &generativelanguagepb.Part_Text{Text: message.Content()},
 			}}})
 		case schema.ChatMessageTypeHuman:
-			contents = append(contents, &generativelanguagepb.Content{Role: roleUser, Parts: []*generativelanguagepb.Part{{
-				Data: &generativelanguagepb.Part_Text{Text: message.Content()},
-			}}})
+			parts, err := toGoogleGenAIParts(message)
+			if err != nil {
+				return nil, err
+			}
+
+			contents = append(contents, &generativelanguagepb.Content{Role: roleUser, Parts: parts})
 		default:
 			return nil, fmt.Errorf("unsupported message type: %s", message.Type())
 		}
@@ -129,6 +137,7 @@ func (cm *GoogleGenAI) Generate(ctx context.Context, messages schema.ChatMessage
 	}
 
 	generations := []schema.Generation{}
+	tokenUsage := schema.TokenUsage{}
 
 	if cm.opts.Stream {
 		stream, err := cm.client.StreamGenerateContent(ctx, req)
@@ -153,6 +162,15 @@ func (cm *GoogleGenAI) Generate(ctx context.Context, messages schema.ChatMessage
 					return nil, err
 				}
 
+				// The usage metadata of the last chunk covers the whole response.
+				if u := res.GetUsageMetadata(); u != nil {
+					tokenUsage = newGoogleGenAITokenUsage(u)
+				}
+
+				if len(res.Candidates) == 0 {
+					continue
+				}
+
 				var b strings.Builder
 				for _, p := range res.Candidates[0].Content.Parts {
 					fmt.Fprintf(&b, "%s", p.GetText())
@@ -177,6 +195,10 @@ func (cm *GoogleGenAI) Generate(ctx context.Context, messages schema.ChatMessage
 			return nil, err
 		}
 
+		if u := res.GetUsageMetadata(); u != nil {
+			tokenUsage = newGoogleGenAITokenUsage(u)
+		}
+
 		for _, c := range res.Candidates {
 			var b strings.Builder
 			for _, p := range c.Content.Parts {
@@ -189,7 +211,10 @@ func (cm *GoogleGenAI) Generate(ctx context.Context, messages schema.ChatMessage
 
 	return &schema.ModelResult{
 		Generations: generations,
-		LLMOutput:   map[string]any{},
+		LLMOutput: map[string]any{
+			"ModelName":  cm.opts.ModelName,
+			"TokenUsage": tokenUsage,
+		},
 	}, nil
 }
 
@@ -212,3 +237,45 @@ func (cm *GoogleGenAI) Callbacks() []schema.Callback {
 func (cm *GoogleGenAI) InvocationParams() map[string]any {
 	return util.StructToMap(cm.opts)
 }
+
+// toGoogleGenAIParts converts the content of a chat message to Google GenAI parts. Images and audio
+// are passed inline; image URLs are not supported.
+func toGoogleGenAIParts(message schema.ChatMessage) ([]*generativelanguagepb.Part, error) {
+	hm, ok := message.(*schema.HumanChatMessage)
+	if !ok || len(hm.Parts()) == 0 {
+		return []*generativelanguagepb.Part{{
+			Data: &generativelanguagepb.Part_Text{Text: message.Content()},
+		}}, nil
+	}
+
+	parts := make([]*generativelanguagepb.Part, 0, len(hm.Parts()))
+
+	for _, p := range hm.Parts() {
+		switch p.Type { // nolint exhaustive
+		case schema.ContentPartTypeText:
+			parts = append(parts, &generativelanguagepb.Part{
+				Data: &generativelanguagepb.Part_Text{Text: p.Text},
+			})
+		case schema.ContentPartTypeImage, schema.ContentPartTypeAudio:
+			parts = append(parts, &generativelanguagepb.Part{
+				Data: &generativelanguagepb.Part_InlineData{InlineData: &generativelanguagepb.Blob{
+					MimeType: p.MIMEType,
+					Data:     p.Data,
+				}},
+			})
+		default:
+			return nil, fmt.Errorf("unsupported content part type: %s", p.Type)
+		}
+	}
+
+	return parts, nil
+}
+
+// newGoogleGenAITokenUsage converts the usage metadata of a response into a token usage.
+func newGoogleGenAITokenUsage(u *generativelanguagepb.GenerateContentResponse_UsageMetadata) schema.TokenUsage {
+	return schema.TokenUsage{
+		PromptTokens:     int(u.PromptTokenCount),
+		CompletionTokens: int(u.CandidatesTokenCount),
+		TotalTokens:      int(u.TotalTokenCount),
+	}
+}
@@ -42,6 +42,42 @@ func TestGoogleGenAI(t *testing.T) {
 		assert.Equal(t, "Generated text", result.Generations[0].Message.Content())
 	})
 
+	t.Run("Generate_Multimodal", func(t *testing.T) {
+		mockClient.GenerateContentFn = func(ctx context.Context, req *generativelanguagepb.GenerateContentRequest, opts ...gax.CallOption) (*generativelanguagepb.GenerateContentResponse, error) {
+			parts := req.Contents[0].Parts
+			assert.Len(t, parts, 2)
+			assert.Equal(t, "What is in the image?", parts[0].GetText())
+			assert.Equal(t, "image/png", parts[1].GetInlineData().GetMimeType())
+			assert.Equal(t, []byte("png"), parts[1].GetInlineData().GetData())
+
+			return &generativelanguagepb.GenerateContentResponse{
+				Candidates: []*generativelanguagepb.Candidate{{
+					Content: &generativelanguagepb.Content{
+						Parts: []*generativelanguagepb.Part{{Data: &generativelanguagepb.Part_Text{
+							Text: "A cat",
+						}}},
+					},
+				}},
+			}, nil
+		}
+
+		chatMessages := []schema.ChatMessage{
+			schema.NewMultimodalHumanChatMessage(
+				schema.NewTextPart("What is in the image?"),
+				schema.NewImagePart([]byte("png"), "image/png"),
+			),
+		}
+
+		result, err := model.Generate(context.Background(), chatMessages)
+		assert.NoError(t, err)
+		assert.Equal(t, "A cat", result.Generations[0].Text)
+
+		_, err = model.Generate(context.Background(), []schema.ChatMessage{
+			schema.NewMultimodalHumanChatMessage(schema.NewImageURLPart("https://example.com/cat.png")),
+		})
+		assert.EqualError(t, err, "unsupported content part type: image_url")
+	})
+
 	t.Run("Generate_Error", func(t *testing.T) {
 		mockClient.GenerateContentFn = func(ctx context.Context, req *generativelanguagepb.GenerateContentRequest, opts ...gax.CallOption) (*generativelanguagepb.GenerateContentResponse, error) {
 			// Implement your custom behavior here, e.g., return a predefined response
@@ -57,6 +93,15 @@ func TestGoogleGenAI(t *testing.T) {
 		assert.ErrorContains(t, err, "google genai error")
 	})
 
+	t.Run("Generate_ResponseFormat", func(t *testing.T) {
+		_, err := model.Generate(context.Background(), []schema.ChatMessage{
+			schema.NewHumanChatMessage("Can you help me?"),
+		}, func(o *schema.GenerateOptions) {
+			o.ResponseFormat = &schema.ResponseFormat{Name: "answer"}
+		})
+		assert.ErrorIs(t, err, schema.ErrResponseFormatNotSupported)
+	})
+
 	// Test the Type method
 	t.Run("Type", func(t *testing.T) {
 		expectedType := "chatmodel.GoogleGenAI"
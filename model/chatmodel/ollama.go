@@ -2,10 +2,12 @@ package chatmodel
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/hupe1980/golc"
 	"github.com/hupe1980/golc/callback"
@@ -15,8 +17,8 @@ import (
 	"github.com/hupe1980/golc/tokenizer"
 )
 
-// Compile time check to ensure Ollama satisfies the ChatModel interface.
-var _ schema.ChatModel = (*Ollama)(nil)
+// Compile time check to ensure Ollama satisfies the ToolCallingChatModel interface.
+var _ schema.ToolCallingChatModel = (*Ollama)(nil)
 
 // OllamaClient is an interface for the Ollama generative model client.
 type OllamaClient interface {
@@ -48,12 +50,15 @@ type OllamaOptions struct {
 	FrequencyPenalty float32 `map:"frequency_penalty,omitempty"`
 	// Stream indicates whether to stream the results or not.
 	Stream bool `map:"stream,omitempty"`
+	// Timeout is the maximum duration of a request to the API. A value <= 0 disables the timeout.
+	Timeout time.Duration `map:"-"`
 }
 
 // Ollama is a struct representing the Ollama generative model.
 type Ollama struct {
 	schema.Tokenizer
 	client OllamaClient
+	tools  []schema.FunctionDefinition
 	opts   OllamaOptions
 }
 
@@ -93,6 +98,9 @@ func NewOllama(client OllamaClient, optFns ...func(o *OllamaOptions)) (*Ollama,
 
 // Generate generates text based on the provided chat messages and options.
 func (cm *Ollama) Generate(ctx context.Context, messages schema.ChatMessages, optFns ...func(o *schema.GenerateOptions)) (*schema.ModelResult, error) {
+	ctx, cancel := util.ContextWithTimeout(ctx, cm.opts.Timeout)
+	defer cancel()
+
 	opts := schema.GenerateOptions{
 		CallbackManger: &callback.NoopManager{},
 	}
@@ -101,25 +109,27 @@ func (cm *Ollama) Generate(ctx context.Context, messages schema.ChatMessages, op
 		fn(&opts)
 	}
 
-	ollamaMessages := make([]ollama.Message, len(messages))
-
-	for i, m := range messages {
-		switch m.Type() { // nolint exhaustive
-		case schema.ChatMessageTypeSystem:
-			ollamaMessages[i] = ollama.Message{Role: "system", Content: m.Content()}
-		case schema.ChatMessageTypeAI:
-			ollamaMessages[i] = ollama.Message{Role: "assistant", Content: m.Content()}
-		case schema.ChatMessageTypeHuman:
-			ollamaMessages[i] = ollama.Message{Role: "user", Content: m.Content()}
-		default:
-			return nil, fmt.Errorf("unknown message type: %s", m.Type())
-		}
+	ollamaMessages, err := toOllamaMessages(messages)
+	if err != nil {
+		return nil, err
 	}
 
+	functions := mergeFunctionDefinitions(cm.tools, opts.Functions)
+
 	req := &ollama.ChatRequest{
 		Model:    cm.opts.ModelName,
 		Messages: ollamaMessages,
-		Stream:   util.AddrOrNil(false),
+		Tools: util.Map(functions, func(fd schema.FunctionDefinition, _ int) ollama.Tool {
+			return ollama.Tool{
+				Type: "function",
+				Function: ollama.ToolFunction{
+					Name:        fd.Name,
+					Description: fd.Description,
+					Parameters:  fd.Parameters,
+				},
+			}
+		}),
+		Stream: util.AddrOrNil(false),
 		Options: ollama.Options{
 			Temperature:      cm.opts.Temperature,
 			NumPredict:       cm.opts.MaxTokens,
@@ -131,7 +141,15 @@ func (cm *Ollama) Generate(ctx context.Context, messages schema.ChatMessages, op
 		},
 	}
 
-	content := ""
+	if opts.ResponseFormat != nil {
+		req.Format = "json"
+	}
+
+	var (
+		content    string
+		toolCalls  []ollama.ToolCall
+		tokenUsage schema.TokenUsage
+	)
 
 	if cm.opts.Stream {
 		req.Stream = util.PTR(true)
@@ -168,10 +186,10 @@ func (cm *Ollama) Generate(ctx context.Context, messages schema.ChatMessages, op
 					}
 
 					tokens = append(tokens, res.Message.Content)
+					toolCalls = append(toolCalls, res.Message.ToolCalls...)
+				} else {
+					tokenUsage = schema.NewTokenUsage(res.PromptEvalCount, res.EvalCount)
 				}
-				// else {
-				// 	// TODO Metrics, EvalCount, ... -> LLMOutput?
-				// }
 			}
 
 			content = strings.Join(tokens, "")
@@ -183,14 +201,45 @@ func (cm *Ollama) Generate(ctx context.Context, messages schema.ChatMessages, op
 		}
 
 		content = res.Message.Content
+		toolCalls = res.Message.ToolCalls
+		tokenUsage = schema.NewTokenUsage(res.PromptEvalCount, res.EvalCount)
+	}
+
+	extFns := []func(o *schema.ChatMessageExtension){}
+
+	if len(toolCalls) > 0 {
+		schemaToolCalls, err := fromOllamaToolCalls(toolCalls)
+		if err != nil {
+			return nil, err
+		}
+
+		extFns = append(extFns, func(o *schema.ChatMessageExtension) {
+			o.ToolCalls = schemaToolCalls
+		})
 	}
 
 	return &schema.ModelResult{
-		Generations: []schema.Generation{newChatGeneraton(content)},
-		LLMOutput:   map[string]any{},
+		Generations: []schema.Generation{newChatGeneraton(content, extFns...)},
+		LLMOutput: map[string]any{
+			"ModelName":  cm.opts.ModelName,
+			"TokenUsage": tokenUsage,
+		},
 	}, nil
 }
 
+// BindTools returns a copy of the chat model that offers the given tools to the model on every generation.
+func (cm *Ollama) BindTools(tools []schema.Tool) (schema.ToolCallingChatModel, error) {
+	functions, err := toFunctionDefinitions(tools)
+	if err != nil {
+		return nil, err
+	}
+
+	clone := *cm
+	clone.tools = functions
+
+	return &clone, nil
+}
+
 // Type returns the type of the model.
 func (cm *Ollama) Type() string {
 	return "chatmodel.Ollama"
@@ -210,3 +259,72 @@ func (cm *Ollama) Callbacks() []schema.Callback {
 func (cm *Ollama) InvocationParams() map[string]any {
 	return util.StructToMap(cm.opts)
 }
+
+// toOllamaMessages converts the chat messages to Ollama messages.
+func toOllamaMessages(messages schema.ChatMessages) ([]ollama.Message, error) {
+	ollamaMessages := make([]ollama.Message, len(messages))
+
+	for i, m := range messages {
+		switch m.Type() { // nolint exhaustive
+		case schema.ChatMessageTypeSystem:
+			ollamaMessages[i] = ollama.Message{Role: "system", Content: m.Content()}
+		case schema.ChatMessageTypeAI:
+			ollamaMessages[i] = ollama.Message{Role: "assistant", Content: m.Content()}
+
+			if aiMsg, ok := m.(*schema.AIChatMessage); ok {
+				for _, tc := range aiMsg.Extension().ToolCalls {
+					args := map[string]any{}
+					if tc.Arguments != "" {
+						if err := json.Unmarshal([]byte(tc.Arguments), &args); err != nil {
+							return nil, err
+						}
+					}
+
+					ollamaMessages[i].ToolCalls = append(ollamaMessages[i].ToolCalls, ollama.ToolCall{
+						Function: ollama.ToolCallFunction{Name: tc.Name, Arguments: args},
+					})
+				}
+			}
+		case schema.ChatMessageTypeHuman:
+			ollamaMessages[i] = ollama.Message{Role: "user", Content: m.Content()}
+
+			if hm, ok := m.(*schema.HumanChatMessage); ok {
+				for _, p := range hm.Parts() {
+					switch p.Type { // nolint exhaustive
+					case schema.ContentPartTypeText:
+						// The text parts are already the content of the message.
+					case schema.ContentPartTypeImage:
+						ollamaMessages[i].Images = append(ollamaMessages[i].Images, ollama.ImageData(p.Data))
+					default:
+						return nil, fmt.Errorf("unsupported content part type: %s", p.Type)
+					}
+				}
+			}
+		case schema.ChatMessageTypeTool:
+			ollamaMessages[i] = ollama.Message{Role: "tool", Content: m.Content()}
+		default:
+			return nil, fmt.Errorf("unknown message type: %s", m.Type())
+		}
+	}
+
+	return ollamaMessages, nil
+}
+
+// fromOllamaToolCalls converts the Ollama tool calls to schema tool calls.
+func fromOllamaToolCalls(toolCalls []ollama.ToolCall) ([]schema.ToolCall, error) {
+	schemaToolCalls := make([]schema.ToolCall, len(toolCalls))
+
+	for i, tc := range toolCalls {
+		args, err := json.Marshal(tc.Function.Arguments)
+		if err != nil {
+			return nil, err
+		}
+
+		schemaToolCalls[i] = schema.ToolCall{
+			Name:      tc.Function.Name,
+			Arguments: string(args),
+		}
+	}
+
+	return schemaToolCalls, nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tool"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/hupe1980/golc/integration/ollama"
@@ -56,6 +57,44 @@ func TestOllama(t *testing.T) {
 			assert.Equal(t, "I can help you with that.", result.Generations[0].Text)
 		})
 
+		t.Run("Multimodal", func(t *testing.T) {
+			t.Parallel()
+
+			mockClient := &mockOllamaClient{
+				GenerateChatFunc: func(ctx context.Context, req *ollama.ChatRequest) (*ollama.ChatResponse, error) {
+					assert.Len(t, req.Messages, 1)
+					assert.Equal(t, "What is in the image?", req.Messages[0].Content)
+					assert.Equal(t, []ollama.ImageData{ollama.ImageData("png")}, req.Messages[0].Images)
+
+					return &ollama.ChatResponse{
+						Message: &ollama.Message{
+							Role:    "assistant",
+							Content: "A cat",
+						},
+					}, nil
+				},
+			}
+
+			ollamaModel, err := NewOllama(mockClient, func(o *OllamaOptions) {
+				o.ModelName = "llava"
+			})
+			assert.NoError(t, err)
+
+			result, err := ollamaModel.Generate(context.Background(), schema.ChatMessages{
+				schema.NewMultimodalHumanChatMessage(
+					schema.NewTextPart("What is in the image?"),
+					schema.NewImagePart([]byte("png"), "image/png"),
+				),
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, "A cat", result.Generations[0].Text)
+
+			_, err = ollamaModel.Generate(context.Background(), schema.ChatMessages{
+				schema.NewMultimodalHumanChatMessage(schema.NewImageURLPart("https://example.com/cat.png")),
+			})
+			assert.EqualError(t, err, "unsupported content part type: image_url")
+		})
+
 		t.Run("Error", func(t *testing.T) {
 			t.Parallel()
 
@@ -79,6 +118,47 @@ func TestOllama(t *testing.T) {
 		})
 	})
 
+	t.Run("BindTools", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := &mockOllamaClient{
+			GenerateChatFunc: func(ctx context.Context, req *ollama.ChatRequest) (*ollama.ChatResponse, error) {
+				assert.Len(t, req.Tools, 1)
+				assert.Equal(t, "function", req.Tools[0].Type)
+				assert.Equal(t, "Sleep", req.Tools[0].Function.Name)
+
+				return &ollama.ChatResponse{
+					Message: &ollama.Message{
+						Role: "assistant",
+						ToolCalls: []ollama.ToolCall{{
+							Function: ollama.ToolCallFunction{
+								Name:      "Sleep",
+								Arguments: map[string]any{"__arg1": "1"},
+							},
+						}},
+					},
+				}, nil
+			},
+		}
+
+		ollamaModel, err := NewOllama(mockClient)
+		assert.NoError(t, err)
+
+		toolModel, err := ollamaModel.BindTools([]schema.Tool{tool.NewSleep()})
+		assert.NoError(t, err)
+
+		result, err := toolModel.Generate(context.Background(), schema.ChatMessages{
+			schema.NewHumanChatMessage("Sleep for one second"),
+		})
+		assert.NoError(t, err)
+
+		aiMsg, ok := result.Generations[0].Message.(*schema.AIChatMessage)
+		assert.True(t, ok)
+		assert.Len(t, aiMsg.Extension().ToolCalls, 1)
+		assert.Equal(t, "Sleep", aiMsg.Extension().ToolCalls[0].Name)
+		assert.JSONEq(t, `{"__arg1":"1"}`, aiMsg.Extension().ToolCalls[0].Arguments)
+	})
+
 	t.Run("Type", func(t *testing.T) {
 		t.Parallel()
 
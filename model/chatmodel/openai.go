@@ -5,19 +5,23 @@ import (
 	"errors"
 	"io"
 	"strings"
+	"time"
 
-	"github.com/avast/retry-go"
 	"github.com/hupe1980/golc"
 	"github.com/hupe1980/golc/callback"
 	"github.com/hupe1980/golc/integration"
+	"github.com/hupe1980/golc/internal/retry"
 	"github.com/hupe1980/golc/internal/util"
 	"github.com/hupe1980/golc/schema"
 	"github.com/hupe1980/golc/tokenizer"
 	"github.com/sashabaranov/go-openai"
 )
 
-// Compile time check to ensure OpenAI satisfies the ChatModel interface.
-var _ schema.ChatModel = (*OpenAI)(nil)
+// Compile time check to ensure OpenAI satisfies the StreamingChatModel interface.
+var _ schema.StreamingChatModel = (*OpenAI)(nil)
+
+// Compile time check to ensure OpenAI satisfies the ToolCallingChatModel interface.
+var _ schema.ToolCallingChatModel = (*OpenAI)(nil)
 
 // OpenAIClient is an interface for the OpenAI chat model client.
 type OpenAIClient interface {
@@ -53,6 +57,8 @@ type OpenAIOptions struct {
 	Stream bool `map:"stream,omitempty"`
 	// MaxRetries represents the maximum number of retries to make when generating.
 	MaxRetries uint `map:"max_retries,omitempty"`
+	// Timeout is the maximum duration of a request to the API. A value <= 0 disables the timeout.
+	Timeout time.Duration `map:"-"`
 }
 
 var DefaultOpenAIOptions = OpenAIOptions{
@@ -71,6 +77,7 @@ var DefaultOpenAIOptions = OpenAIOptions{
 type OpenAI struct {
 	schema.Tokenizer
 	client OpenAIClient
+	tools  []schema.FunctionDefinition
 	opts   OpenAIOptions
 }
 
@@ -92,6 +99,8 @@ func NewOpenAI(apiKey string, optFns ...func(o *OpenAIOptions)) (*OpenAI, error)
 		config.OrgID = opts.OrgID
 	}
 
+	config.HTTPClient = retry.NewHTTPClient(config.HTTPClient)
+
 	client := openai.NewClientWithConfig(config)
 
 	return NewOpenAIFromClient(client, optFns...)
@@ -118,6 +127,9 @@ func NewOpenAIFromClient(client OpenAIClient, optFns ...func(o *OpenAIOptions))
 
 // Generate generates text based on the provided chat messages and options.
 func (cm *OpenAI) Generate(ctx context.Context, messages schema.ChatMessages, optFns ...func(o *schema.GenerateOptions)) (*schema.ModelResult, error) {
+	ctx, cancel := util.ContextWithTimeout(ctx, cm.opts.Timeout)
+	defer cancel()
+
 	opts := schema.GenerateOptions{
 		CallbackManger: &callback.NoopManager{},
 	}
@@ -126,48 +138,18 @@ func (cm *OpenAI) Generate(ctx context.Context, messages schema.ChatMessages, op
 		fn(&opts)
 	}
 
-	openAIMessages, err := integration.ToOpenAIChatCompletionMessages(messages)
+	request, err := cm.newChatCompletionRequest(messages, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	var tools []openai.Tool
-	if opts.Functions != nil {
-		tools = util.Map(opts.Functions, func(fd schema.FunctionDefinition, i int) openai.Tool {
-			return openai.Tool{
-				Type: openai.ToolTypeFunction,
-				Function: &openai.FunctionDefinition{
-					Name:        fd.Name,
-					Description: fd.Description,
-					Parameters:  fd.Parameters,
-				}}
-		})
-	}
-
-	request := openai.ChatCompletionRequest{
-		Model:            cm.opts.ModelName,
-		Temperature:      cm.opts.Temperature,
-		MaxTokens:        cm.opts.MaxTokens,
-		TopP:             cm.opts.TopP,
-		N:                cm.opts.N,
-		PresencePenalty:  cm.opts.PresencePenalty,
-		FrequencyPenalty: cm.opts.PresencePenalty,
-		Messages:         openAIMessages,
-		Tools:            tools,
-		Stop:             opts.Stop,
-	}
-
-	if opts.ForceFunctionCall && len(opts.Functions) == 1 {
-		request.ToolChoice = openai.ToolChoice{Type: openai.ToolTypeFunction, Function: openai.ToolFunction{
-			Name: opts.Functions[0].Name,
-		}}
-	}
-
 	choices := []openai.ChatCompletionChoice{}
-	tokenUsage := make(map[string]int)
+
+	var tokenUsage *schema.TokenUsage
 
 	if cm.opts.Stream {
 		request.Stream = true
+		request.StreamOptions = &openai.StreamOptions{IncludeUsage: true}
 
 		stream, err := cm.client.CreateChatCompletionStream(ctx, request)
 		if err != nil {
@@ -176,11 +158,10 @@ func (cm *OpenAI) Generate(ctx context.Context, messages schema.ChatMessages, op
 
 		defer stream.Close()
 
+		// With n > 1 the chunks of all choices are interleaved and identified by their index.
 		var (
-			role         string
-			tokens       []string
-			functionCall *openai.FunctionCall
-			finishReason openai.FinishReason
+			tokens    [][]string
+			toolCalls [][]openai.ToolCall
 		)
 
 	streamProcessing:
@@ -198,30 +179,52 @@ func (cm *OpenAI) Generate(ctx context.Context, messages schema.ChatMessages, op
 					return nil, err
 				}
 
-				if err := opts.CallbackManger.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{
-					Token: res.Choices[0].Delta.Content,
-				}); err != nil {
-					return nil, err
+				// The usage is only sent in the last chunk if requested via the stream options.
+				if res.Usage != nil {
+					tokenUsage = &schema.TokenUsage{
+						PromptTokens:     res.Usage.PromptTokens,
+						CompletionTokens: res.Usage.CompletionTokens,
+						TotalTokens:      res.Usage.TotalTokens,
+					}
 				}
 
-				role = res.Choices[0].Delta.Role
-				tokens = append(tokens, res.Choices[0].Delta.Content)
-				finishReason = res.Choices[0].FinishReason
-
-				if len(res.Choices[0].Delta.ToolCalls) > 0 {
-					functionCall = &res.Choices[0].Delta.ToolCalls[0].Function
+				for _, c := range res.Choices {
+					if err := opts.CallbackManger.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{
+						Token: c.Delta.Content,
+					}); err != nil {
+						return nil, err
+					}
+
+					for len(choices) <= c.Index {
+						choices = append(choices, openai.ChatCompletionChoice{Index: len(choices)})
+						tokens = append(tokens, nil)
+						toolCalls = append(toolCalls, nil)
+					}
+
+					if c.Delta.Role != "" {
+						choices[c.Index].Message.Role = c.Delta.Role
+					}
+
+					if c.FinishReason != "" {
+						choices[c.Index].FinishReason = c.FinishReason
+					}
+
+					tokens[c.Index] = append(tokens[c.Index], c.Delta.Content)
+					toolCalls[c.Index] = mergeOpenAIToolCallDeltas(toolCalls[c.Index], c.Delta.ToolCalls)
 				}
 			}
 		}
 
-		choices = append(choices, openai.ChatCompletionChoice{
-			Message: openai.ChatCompletionMessage{
-				Role:         role,
-				Content:      strings.Join(tokens, ""),
-				FunctionCall: functionCall,
-			},
-			FinishReason: finishReason,
-		})
+		if len(choices) == 0 {
+			choices = append(choices, openai.ChatCompletionChoice{})
+			tokens = append(tokens, nil)
+			toolCalls = append(toolCalls, nil)
+		}
+
+		for i := range choices {
+			choices[i].Message.Content = strings.Join(tokens[i], "")
+			choices[i].Message.ToolCalls = toolCalls[i]
+		}
 	} else {
 		res, err := cm.createChatCompletionWithRetry(ctx, request)
 		if err != nil {
@@ -230,9 +233,11 @@ func (cm *OpenAI) Generate(ctx context.Context, messages schema.ChatMessages, op
 
 		choices = res.Choices
 
-		tokenUsage["CompletionTokens"] += res.Usage.CompletionTokens
-		tokenUsage["PromptTokens"] += res.Usage.PromptTokens
-		tokenUsage["TotalTokens"] += res.Usage.TotalTokens
+		tokenUsage = &schema.TokenUsage{
+			PromptTokens:     res.Usage.PromptTokens,
+			CompletionTokens: res.Usage.CompletionTokens,
+			TotalTokens:      res.Usage.TotalTokens,
+		}
 	}
 
 	generations := util.Map(choices, func(choice openai.ChatCompletionChoice, _ int) schema.Generation {
@@ -245,51 +250,171 @@ func (cm *OpenAI) Generate(ctx context.Context, messages schema.ChatMessages, op
 		}
 	})
 
+	llmOutput := map[string]any{
+		"ModelName": cm.opts.ModelName,
+	}
+
+	// A stream only reports the usage, if the API supports the stream options.
+	if tokenUsage != nil {
+		llmOutput["TokenUsage"] = *tokenUsage
+	}
+
 	return &schema.ModelResult{
 		Generations: generations,
-		LLMOutput: map[string]any{
-			"ModelName":  cm.opts.ModelName,
-			"TokenUsage": tokenUsage,
-		},
+		LLMOutput:   llmOutput,
 	}, nil
 }
 
-func (cm *OpenAI) createChatCompletionWithRetry(ctx context.Context, request openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
-	retryOpts := []retry.Option{
-		retry.Attempts(cm.opts.MaxRetries),
-		retry.DelayType(retry.FixedDelay),
-		retry.RetryIf(func(err error) bool {
-			e := &openai.APIError{}
-			if errors.As(err, &e) {
-				switch e.HTTPStatusCode {
-				case 429, 500:
-					return true
-				default:
-					return false
+// Stream generates text based on the provided chat messages and sends the tokens to the returned channel.
+func (cm *OpenAI) Stream(ctx context.Context, messages schema.ChatMessages, optFns ...func(o *schema.GenerateOptions)) (<-chan schema.StreamChunk, error) {
+	ctx, cancel := util.ContextWithTimeout(ctx, cm.opts.Timeout)
+
+	opts := schema.GenerateOptions{
+		CallbackManger: &callback.NoopManager{},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	request, err := cm.newChatCompletionRequest(messages, opts)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	request.Stream = true
+	request.StreamOptions = &openai.StreamOptions{IncludeUsage: true}
+
+	stream, err := cm.client.CreateChatCompletionStream(ctx, request)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	chunks := make(chan schema.StreamChunk)
+
+	go func() {
+		defer cancel()
+		defer close(chunks)
+		defer stream.Close()
+
+		for {
+			res, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			if err != nil {
+				sendStreamChunk(ctx, chunks, schema.StreamChunk{Err: err})
+				return
+			}
+
+			// The usage is sent in the last chunk without choices.
+			if res.Usage != nil {
+				if !sendStreamChunk(ctx, chunks, schema.StreamChunk{
+					TokenUsage: &schema.TokenUsage{
+						PromptTokens:     res.Usage.PromptTokens,
+						CompletionTokens: res.Usage.CompletionTokens,
+						TotalTokens:      res.Usage.TotalTokens,
+					},
+				}) {
+					return
 				}
 			}
 
-			return false
-		}),
-	}
+			if len(res.Choices) == 0 {
+				continue
+			}
 
-	var res openai.ChatCompletionResponse
+			if err := opts.CallbackManger.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{
+				Token: res.Choices[0].Delta.Content,
+			}); err != nil {
+				sendStreamChunk(ctx, chunks, schema.StreamChunk{Err: err})
+				return
+			}
 
-	err := retry.Do(
-		func() error {
-			r, cErr := cm.client.CreateChatCompletion(ctx, request)
-			if cErr != nil {
-				return cErr
+			if !sendStreamChunk(ctx, chunks, schema.StreamChunk{
+				Token:        res.Choices[0].Delta.Content,
+				FinishReason: string(res.Choices[0].FinishReason),
+			}) {
+				return
 			}
+		}
+	}()
 
-			res = r
+	return chunks, nil
+}
+
+// newChatCompletionRequest creates a chat completion request for the provided chat messages and options.
+func (cm *OpenAI) newChatCompletionRequest(messages schema.ChatMessages, opts schema.GenerateOptions) (openai.ChatCompletionRequest, error) {
+	openAIMessages, err := integration.ToOpenAIChatCompletionMessages(messages)
+	if err != nil {
+		return openai.ChatCompletionRequest{}, err
+	}
+
+	functions := mergeFunctionDefinitions(cm.tools, opts.Functions)
 
-			return nil
-		},
-		retryOpts...,
-	)
+	var tools []openai.Tool
+	if functions != nil {
+		tools = util.Map(functions, func(fd schema.FunctionDefinition, i int) openai.Tool {
+			return openai.Tool{
+				Type: openai.ToolTypeFunction,
+				Function: &openai.FunctionDefinition{
+					Name:        fd.Name,
+					Description: fd.Description,
+					Parameters:  fd.Parameters,
+				}}
+		})
+	}
 
-	return res, err
+	request := openai.ChatCompletionRequest{
+		Model:            cm.opts.ModelName,
+		Temperature:      cm.opts.Temperature,
+		MaxTokens:        cm.opts.MaxTokens,
+		TopP:             cm.opts.TopP,
+		N:                cm.opts.N,
+		PresencePenalty:  cm.opts.PresencePenalty,
+		FrequencyPenalty: cm.opts.PresencePenalty,
+		Messages:         openAIMessages,
+		Tools:            tools,
+		Stop:             opts.Stop,
+	}
+
+	if opts.ResponseFormat != nil {
+		request.ResponseFormat = &openai.ChatCompletionResponseFormat{
+			Type: openai.ChatCompletionResponseFormatTypeJSONObject,
+		}
+	}
+
+	if opts.ForceFunctionCall && len(functions) == 1 {
+		request.ToolChoice = openai.ToolChoice{Type: openai.ToolTypeFunction, Function: openai.ToolFunction{
+			Name: functions[0].Name,
+		}}
+	}
+
+	return request, nil
+}
+
+func (cm *OpenAI) createChatCompletionWithRetry(ctx context.Context, request openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+	policy := retry.NewPolicy(cm.opts.MaxRetries, retry.IsRetryableOpenAIError)
+
+	return retry.Do(ctx, policy, func(ctx context.Context) (openai.ChatCompletionResponse, error) {
+		return cm.client.CreateChatCompletion(ctx, request)
+	})
+}
+
+// BindTools returns a copy of the chat model that offers the given tools to the model on every generation.
+func (cm *OpenAI) BindTools(tools []schema.Tool) (schema.ToolCallingChatModel, error) {
+	functions, err := toFunctionDefinitions(tools)
+	if err != nil {
+		return nil, err
+	}
+
+	clone := *cm
+	clone.tools = functions
+
+	return &clone, nil
 }
 
 // Type returns the type of the model.
@@ -324,6 +449,13 @@ func openAIResponseToChatMessage(msg openai.ChatCompletionMessage) schema.ChatMe
 					Name:      msg.ToolCalls[0].Function.Name,
 					Arguments: msg.ToolCalls[0].Function.Arguments,
 				}
+				o.ToolCalls = util.Map(msg.ToolCalls, func(tc openai.ToolCall, _ int) schema.ToolCall {
+					return schema.ToolCall{
+						ID:        tc.ID,
+						Name:      tc.Function.Name,
+						Arguments: tc.Function.Arguments,
+					}
+				})
 			})
 		}
 
@@ -332,7 +464,32 @@ func openAIResponseToChatMessage(msg openai.ChatCompletionMessage) schema.ChatMe
 		return schema.NewSystemChatMessage(msg.Content)
 	case "function":
 		return schema.NewFunctionChatMessage(msg.Content, msg.Name)
+	case "tool":
+		return schema.NewToolChatMessage(msg.ToolCallID, msg.Name, msg.Content)
 	}
 
 	return schema.NewGenericChatMessage(msg.Content, "unknown")
 }
+
+// mergeOpenAIToolCallDeltas merges the streamed tool call deltas into the accumulated tool calls.
+func mergeOpenAIToolCallDeltas(toolCalls []openai.ToolCall, deltas []openai.ToolCall) []openai.ToolCall {
+	for _, delta := range deltas {
+		index := len(toolCalls)
+		if delta.Index != nil {
+			index = *delta.Index
+		}
+
+		for len(toolCalls) <= index {
+			toolCalls = append(toolCalls, openai.ToolCall{Type: openai.ToolTypeFunction})
+		}
+
+		if delta.ID != "" {
+			toolCalls[index].ID = delta.ID
+		}
+
+		toolCalls[index].Function.Name += delta.Function.Name
+		toolCalls[index].Function.Arguments += delta.Function.Arguments
+	}
+
+	return toolCalls
+}
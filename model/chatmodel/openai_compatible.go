@@ -0,0 +1,158 @@
+package chatmodel
+
+import (
+	"github.com/hupe1980/golc/integration"
+	"github.com/hupe1980/golc/internal/retry"
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tokenizer"
+	"github.com/sashabaranov/go-openai"
+)
+
+// Compile time check to ensure OpenAICompatible satisfies the ToolCallingChatModel interface.
+var _ schema.ToolCallingChatModel = (*OpenAICompatible)(nil)
+
+// OpenAICompatibleOptions contains the options for the chat model of an OpenAI-compatible server.
+type OpenAICompatibleOptions struct {
+	OpenAIOptions `map:",squash"`
+	// Paths maps the OpenAI endpoint paths, e.g. "/chat/completions", to the paths used by the server.
+	Paths map[string]string `map:"paths,omitempty"`
+	// Headers contains additional headers sent with every request.
+	Headers map[string]string `map:"-"`
+	// UnsupportedParams lists the request parameters that are not supported by the server, e.g. "presence_penalty".
+	// They are removed from the requests.
+	UnsupportedParams []string `map:"unsupported_params,omitempty"`
+}
+
+// OpenAICompatible represents the chat model of a self-hosted server with an OpenAI-compatible API,
+// e.g. vLLM, LocalAI or LM Studio.
+type OpenAICompatible struct {
+	*openAICompatible
+	opts OpenAICompatibleOptions
+}
+
+// NewOpenAICompatible creates a new instance of the chat model for the OpenAI-compatible server at the given base url.
+// The api key may be empty for servers without authentication.
+func NewOpenAICompatible(baseURL, apiKey string, optFns ...func(o *OpenAICompatibleOptions)) (*OpenAICompatible, error) {
+	opts := OpenAICompatibleOptions{
+		OpenAIOptions: DefaultOpenAIOptions,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	opts.BaseURL = baseURL
+
+	config := integration.NewOpenAICompatibleConfig(apiKey, baseURL, func(o *integration.OpenAICompatibleConfigOptions) {
+		o.Paths = opts.Paths
+		o.Headers = opts.Headers
+		o.UnsupportedParams = opts.UnsupportedParams
+	})
+
+	config.HTTPClient = retry.NewHTTPClient(config.HTTPClient)
+
+	return NewOpenAICompatibleFromClient(openai.NewClientWithConfig(config), func(o *OpenAICompatibleOptions) {
+		*o = opts
+	})
+}
+
+// NewOpenAICompatibleFromClient creates a new instance of the chat model of an OpenAI-compatible server
+// with the provided client and options.
+func NewOpenAICompatibleFromClient(client OpenAIClient, optFns ...func(o *OpenAICompatibleOptions)) (*OpenAICompatible, error) {
+	opts := OpenAICompatibleOptions{
+		OpenAIOptions: DefaultOpenAIOptions,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	cm, err := newOpenAICompatibleFromClient("chatmodel.OpenAICompatible", client, opts.OpenAIOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OpenAICompatible{
+		openAICompatible: cm,
+		opts:             opts,
+	}, nil
+}
+
+// BindTools returns a copy of the chat model that offers the given tools to the model on every generation.
+func (cm *OpenAICompatible) BindTools(tools []schema.Tool) (schema.ToolCallingChatModel, error) {
+	bound, err := cm.openAICompatible.BindTools(tools)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OpenAICompatible{
+		openAICompatible: bound.(*openAICompatible),
+		opts:             cm.opts,
+	}, nil
+}
+
+// InvocationParams returns the parameters used in the model invocation.
+func (cm *OpenAICompatible) InvocationParams() map[string]any {
+	return util.StructToMap(cm.opts)
+}
+
+// openAICompatible is the base of chat models served by providers with an OpenAI-compatible API.
+// It reuses the OpenAI chat model and only differs in the type of the model.
+type openAICompatible struct {
+	*OpenAI
+	typeName string
+}
+
+// newOpenAICompatible creates the base chat model for the OpenAI-compatible API at opts.BaseURL.
+func newOpenAICompatible(typeName, apiKey string, opts OpenAIOptions) (*openAICompatible, error) {
+	config := openai.DefaultConfig(apiKey)
+	config.BaseURL = opts.BaseURL
+
+	config.HTTPClient = retry.NewHTTPClient(config.HTTPClient)
+
+	return newOpenAICompatibleFromClient(typeName, openai.NewClientWithConfig(config), opts)
+}
+
+// newOpenAICompatibleFromClient creates the base chat model for an OpenAI-compatible API with the provided client.
+func newOpenAICompatibleFromClient(typeName string, client OpenAIClient, opts OpenAIOptions) (*openAICompatible, error) {
+	if opts.Tokenizer == nil {
+		// The tiktoken encodings are only known for OpenAI models.
+		var tErr error
+
+		opts.Tokenizer, tErr = tokenizer.NewGPT2()
+		if tErr != nil {
+			return nil, tErr
+		}
+	}
+
+	openAI, err := NewOpenAIFromClient(client, func(o *OpenAIOptions) {
+		*o = opts
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &openAICompatible{
+		OpenAI:   openAI,
+		typeName: typeName,
+	}, nil
+}
+
+// BindTools returns a copy of the chat model that offers the given tools to the model on every generation.
+func (cm *openAICompatible) BindTools(tools []schema.Tool) (schema.ToolCallingChatModel, error) {
+	bound, err := cm.OpenAI.BindTools(tools)
+	if err != nil {
+		return nil, err
+	}
+
+	return &openAICompatible{
+		OpenAI:   bound.(*OpenAI),
+		typeName: cm.typeName,
+	}, nil
+}
+
+// Type returns the type of the model.
+func (cm *openAICompatible) Type() string {
+	return cm.typeName
+}
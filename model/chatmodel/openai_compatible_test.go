@@ -0,0 +1,81 @@
+package chatmodel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tool"
+	"github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAICompatible(t *testing.T) {
+	mockClient := &mockOpenAIClient{
+		createChatCompletionFn: func(ctx context.Context, request openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+			return openai.ChatCompletionResponse{
+				Choices: []openai.ChatCompletionChoice{{
+					Message: openai.ChatCompletionMessage{Role: "assistant", Content: "Hello"},
+				}},
+			}, nil
+		},
+	}
+
+	t.Run("Fireworks", func(t *testing.T) {
+		fireworks, err := NewFireworksFromClient(mockClient)
+		assert.NoError(t, err)
+		assert.Equal(t, "chatmodel.Fireworks", fireworks.Type())
+
+		params := fireworks.InvocationParams()
+		assert.Equal(t, "accounts/fireworks/models/llama-v3-8b-instruct", params["model_name"])
+		assert.Equal(t, "https://api.fireworks.ai/inference/v1", params["base_url"])
+
+		result, err := fireworks.Generate(context.Background(), schema.ChatMessages{schema.NewHumanChatMessage("Hi")})
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello", result.Generations[0].Text)
+	})
+
+	t.Run("Together", func(t *testing.T) {
+		together, err := NewTogetherFromClient(mockClient, func(o *TogetherOptions) {
+			o.ModelName = "meta-llama/Llama-3-8b-chat-hf"
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "chatmodel.Together", together.Type())
+
+		params := together.InvocationParams()
+		assert.Equal(t, "meta-llama/Llama-3-8b-chat-hf", params["model_name"])
+		assert.Equal(t, "https://api.together.xyz/v1", params["base_url"])
+	})
+
+	t.Run("BindTools", func(t *testing.T) {
+		together, err := NewTogetherFromClient(mockClient)
+		assert.NoError(t, err)
+
+		toolModel, err := together.BindTools([]schema.Tool{tool.NewSleep()})
+		assert.NoError(t, err)
+		assert.Equal(t, "chatmodel.Together", toolModel.Type())
+	})
+	t.Run("OpenAICompatible", func(t *testing.T) {
+		cm, err := NewOpenAICompatibleFromClient(mockClient, func(o *OpenAICompatibleOptions) {
+			o.ModelName = "llama3"
+			o.UnsupportedParams = []string{"presence_penalty"}
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "chatmodel.OpenAICompatible", cm.Type())
+
+		params := cm.InvocationParams()
+		assert.Equal(t, "llama3", params["model_name"])
+		assert.Equal(t, []string{"presence_penalty"}, params["unsupported_params"])
+
+		toolModel, err := cm.BindTools([]schema.Tool{tool.NewSleep()})
+		assert.NoError(t, err)
+		assert.Equal(t, "chatmodel.OpenAICompatible", toolModel.Type())
+		assert.Equal(t, params, toolModel.InvocationParams())
+	})
+
+	t.Run("NewOpenAICompatible", func(t *testing.T) {
+		cm, err := NewOpenAICompatible("http://localhost:8000/v1", "")
+		assert.NoError(t, err)
+		assert.Equal(t, "http://localhost:8000/v1", cm.InvocationParams()["base_url"])
+	})
+}
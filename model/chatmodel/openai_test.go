@@ -3,11 +3,17 @@ package chatmodel
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tool"
 	"github.com/sashabaranov/go-openai"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestOpenAI_Generate(t *testing.T) {
@@ -83,9 +89,64 @@ func TestOpenAI_Generate(t *testing.T) {
 
 		result, err := openAI.Generate(ctx, messages)
 		assert.Error(t, err)
-		assert.EqualError(t, errors.New("All attempts fail:\n#1: generation error"), err.Error())
+		assert.EqualError(t, err, "generation error")
 		assert.Nil(t, result)
 	})
+	// Test case for bound tools
+	t.Run("BindTools", func(t *testing.T) {
+		toolModel, err := openAI.BindTools([]schema.Tool{tool.NewSleep()})
+		assert.NoError(t, err)
+
+		mockClient.createChatCompletionFn = func(ctx context.Context, request openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+			assert.Len(t, request.Tools, 1)
+			assert.Equal(t, "Sleep", request.Tools[0].Function.Name)
+
+			return openai.ChatCompletionResponse{
+				Choices: []openai.ChatCompletionChoice{
+					{
+						Message: openai.ChatCompletionMessage{
+							Role: "assistant",
+							ToolCalls: []openai.ToolCall{
+								{ID: "call_1", Type: openai.ToolTypeFunction, Function: openai.FunctionCall{Name: "Sleep", Arguments: `{"__arg1":"1"}`}},
+							},
+						},
+					},
+				},
+			}, nil
+		}
+
+		result, err := toolModel.Generate(context.Background(), schema.ChatMessages{
+			schema.NewHumanChatMessage("Sleep for one second"),
+		})
+		assert.NoError(t, err)
+
+		aiMsg, ok := result.Generations[0].Message.(*schema.AIChatMessage)
+		assert.True(t, ok)
+		assert.Equal(t, []schema.ToolCall{{ID: "call_1", Name: "Sleep", Arguments: `{"__arg1":"1"}`}}, aiMsg.Extension().ToolCalls)
+		assert.Empty(t, openAI.tools)
+	})
+
+	// Test case for the JSON response format
+	t.Run("ResponseFormat", func(t *testing.T) {
+		mockClient.createChatCompletionFn = func(ctx context.Context, request openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+			assert.Equal(t, &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject}, request.ResponseFormat)
+
+			return openai.ChatCompletionResponse{
+				Choices: []openai.ChatCompletionChoice{
+					{Message: openai.ChatCompletionMessage{Role: "assistant", Content: `{"name":"Max"}`}},
+				},
+			}, nil
+		}
+
+		result, err := openAI.Generate(context.Background(), schema.ChatMessages{
+			schema.NewHumanChatMessage("Respond with JSON"),
+		}, func(o *schema.GenerateOptions) {
+			o.ResponseFormat = &schema.ResponseFormat{Name: "person"}
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"name":"Max"}`, result.Generations[0].Text)
+	})
+
 	// Test case for Type method
 	t.Run("Type", func(t *testing.T) {
 		assert.Equal(t, "chatmodel.OpenAI", openAI.Type())
@@ -128,6 +189,73 @@ func (m *mockOpenAIClient) CreateChatCompletionStream(ctx context.Context, reque
 	return nil, nil
 }
 
+func TestOpenAI_GenerateMultipleChoices(t *testing.T) {
+	t.Run("Generate", func(t *testing.T) {
+		mockClient := &mockOpenAIClient{
+			createChatCompletionFn: func(ctx context.Context, request openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+				assert.Equal(t, 2, request.N)
+
+				return openai.ChatCompletionResponse{
+					Choices: []openai.ChatCompletionChoice{
+						{Index: 0, Message: openai.ChatCompletionMessage{Role: "assistant", Content: "Hi"}, FinishReason: openai.FinishReasonStop},
+						{Index: 1, Message: openai.ChatCompletionMessage{Role: "assistant", Content: "Hello"}, FinishReason: openai.FinishReasonStop},
+					},
+				}, nil
+			},
+		}
+
+		openAI, err := NewOpenAIFromClient(mockClient, func(o *OpenAIOptions) {
+			o.N = 2
+		})
+		require.NoError(t, err)
+
+		result, err := openAI.Generate(context.Background(), schema.ChatMessages{schema.NewHumanChatMessage("Hello")})
+		require.NoError(t, err)
+		require.Len(t, result.Generations, 2)
+		assert.Equal(t, "Hi", result.Generations[0].Text)
+		assert.Equal(t, "Hello", result.Generations[1].Text)
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			assert.Contains(t, string(body), `"stream_options":{"include_usage":true}`)
+
+			w.Header().Set("Content-Type", "text/event-stream")
+
+			for _, data := range []string{
+				`{"choices":[{"index":0,"delta":{"role":"assistant","content":"Hi"}}]}`,
+				`{"choices":[{"index":1,"delta":{"role":"assistant","content":"Hello"}}]}`,
+				`{"choices":[{"index":1,"delta":{"content":" there"},"finish_reason":"stop"}]}`,
+				`{"choices":[{"index":0,"delta":{"content":"!"},"finish_reason":"stop"}]}`,
+				`{"choices":[],"usage":{"prompt_tokens":5,"completion_tokens":4,"total_tokens":9}}`,
+			} {
+				fmt.Fprintf(w, "data: %s\n\n", data)
+			}
+
+			fmt.Fprint(w, "data: [DONE]\n\n")
+		}))
+		defer server.Close()
+
+		config := openai.DefaultConfig("key")
+		config.BaseURL = server.URL
+
+		openAI, err := NewOpenAIFromClient(openai.NewClientWithConfig(config), func(o *OpenAIOptions) {
+			o.N = 2
+			o.Stream = true
+		})
+		require.NoError(t, err)
+
+		result, err := openAI.Generate(context.Background(), schema.ChatMessages{schema.NewHumanChatMessage("Hello")})
+		require.NoError(t, err)
+		require.Len(t, result.Generations, 2)
+		assert.Equal(t, "Hi!", result.Generations[0].Text)
+		assert.Equal(t, "Hello there", result.Generations[1].Text)
+		assert.Equal(t, "stop", result.Generations[1].Info["FinishReason"])
+		assert.Equal(t, schema.TokenUsage{PromptTokens: 5, CompletionTokens: 4, TotalTokens: 9}, result.LLMOutput["TokenUsage"])
+	})
+}
+
 // Test case for openAIResponseToChatMessage function
 func TestOpenAIResponseToChatMessage(t *testing.T) {
 	aiMessage := openai.ChatCompletionMessage{
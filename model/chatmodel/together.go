@@ -0,0 +1,68 @@
+package chatmodel
+
+import (
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Together satisfies the ToolCallingChatModel interface.
+var _ schema.ToolCallingChatModel = (*Together)(nil)
+
+// TogetherOptions contains the options for the Together AI chat model.
+type TogetherOptions struct {
+	OpenAIOptions
+}
+
+// DefaultTogetherOptions contains the default options for the Together AI chat model.
+var DefaultTogetherOptions = TogetherOptions{
+	OpenAIOptions: OpenAIOptions{
+		CallbackOptions:  DefaultOpenAIOptions.CallbackOptions,
+		ModelName:        "mistralai/Mixtral-8x7B-Instruct-v0.1",
+		Temperature:      0.7,
+		TopP:             1,
+		PresencePenalty:  0,
+		FrequencyPenalty: 0,
+		BaseURL:          "https://api.together.xyz/v1",
+		MaxRetries:       3,
+	},
+}
+
+// Together represents the Together AI chat model.
+type Together struct {
+	*openAICompatible
+}
+
+// NewTogether creates a new instance of the Together AI chat model.
+func NewTogether(apiKey string, optFns ...func(o *TogetherOptions)) (*Together, error) {
+	opts := DefaultTogetherOptions
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	cm, err := newOpenAICompatible("chatmodel.Together", apiKey, opts.OpenAIOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Together{
+		openAICompatible: cm,
+	}, nil
+}
+
+// NewTogetherFromClient creates a new instance of the Together AI chat model with the provided client and options.
+func NewTogetherFromClient(client OpenAIClient, optFns ...func(o *TogetherOptions)) (*Together, error) {
+	opts := DefaultTogetherOptions
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	cm, err := newOpenAICompatibleFromClient("chatmodel.Together", client, opts.OpenAIOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Together{
+		openAICompatible: cm,
+	}, nil
+}
@@ -140,9 +140,25 @@ func (l *AI21) Generate(ctx context.Context, prompt string, optFns ...func(o *sc
 		return nil, err
 	}
 
+	completionTokens := 0
+
+	generations := util.Map(res.Completions, func(c ai21.Completion, _ int) schema.Generation {
+		completionTokens += len(c.Data.Tokens)
+
+		return schema.Generation{
+			Text: c.Data.Text,
+			Info: map[string]any{
+				"FinishReason": c.FinishReason.Reason,
+			},
+		}
+	})
+
 	return &schema.ModelResult{
-		Generations: []schema.Generation{{Text: res.Completions[0].Data.Text}},
-		LLMOutput:   map[string]any{},
+		Generations: generations,
+		LLMOutput: map[string]any{
+			// The response contains the tokens of the prompt and of each completion.
+			"TokenUsage": schema.NewTokenUsage(len(res.Prompt.Tokens), completionTokens),
+		},
 	}, nil
 }
 
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/hupe1980/golc/integration/ai21"
+	"github.com/hupe1980/golc/schema"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -23,10 +24,15 @@ func TestAI21(t *testing.T) {
 		// Set up expected values
 		expectedPrompt := "Test prompt"
 		expectedResponse := &ai21.CompleteResponse{
+			Prompt: ai21.Prompt{
+				Text:   expectedPrompt,
+				Tokens: make([]ai21.Tokens, 2),
+			},
 			Completions: []ai21.Completion{
 				{
 					Data: ai21.Data{
-						Text: "Generated text",
+						Text:   "Generated text",
+						Tokens: make([]ai21.Tokens, 3),
 					},
 				},
 			},
@@ -45,6 +51,27 @@ func TestAI21(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		assert.Equal(t, expectedResponse.Completions[0].Data.Text, result.Generations[0].Text)
+
+		tokenUsage, ok := result.TokenUsage()
+		assert.True(t, ok)
+		assert.Equal(t, schema.NewTokenUsage(2, 3), tokenUsage)
+	})
+
+	t.Run("Generate_MultipleResults", func(t *testing.T) {
+		mockClient.CreateCompletionFunc = func(ctx context.Context, model string, req *ai21.CompleteRequest) (*ai21.CompleteResponse, error) {
+			return &ai21.CompleteResponse{
+				Completions: []ai21.Completion{
+					{Data: ai21.Data{Text: "First"}, FinishReason: ai21.FinishReason{Reason: "endoftext"}},
+					{Data: ai21.Data{Text: "Second"}, FinishReason: ai21.FinishReason{Reason: "length"}},
+				},
+			}, nil
+		}
+
+		result, err := llm.Generate(context.Background(), "Test prompt")
+		assert.NoError(t, err)
+		assert.Len(t, result.Generations, 2)
+		assert.Equal(t, "Second", result.Generations[1].Text)
+		assert.Equal(t, "length", result.Generations[1].Info["FinishReason"])
 	})
 
 	t.Run("Generate_Error", func(t *testing.T) {
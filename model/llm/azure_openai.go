@@ -1,20 +1,31 @@
 package llm
 
 import (
+	"github.com/hupe1980/golc/integration"
+	"github.com/hupe1980/golc/internal/retry"
 	"github.com/hupe1980/golc/internal/util"
 	"github.com/sashabaranov/go-openai"
 )
 
+// AzureOpenAIOptions contains the options for the Azure OpenAI LLM.
 type AzureOpenAIOptions struct {
 	OpenAIOptions
+	// Deployment is the name of the model deployment.
 	Deployment string `map:"deployment,omitempty"`
+	// APIVersion is the version of the Azure OpenAI API.
+	APIVersion string `map:"api_version,omitempty"`
+	// TokenProvider provides Azure AD access tokens. If set, it is used instead of the api key.
+	TokenProvider integration.AzureADTokenProvider `map:"-"`
 }
 
+// AzureOpenAI represents the OpenAI LLM hosted on Azure.
 type AzureOpenAI struct {
 	*OpenAI
 	opts AzureOpenAIOptions
 }
 
+// NewAzureOpenAI creates a new instance of the Azure OpenAI LLM for the resource at the given endpoint.
+// The api key may be empty if a TokenProvider is configured.
 func NewAzureOpenAI(apiKey, baseURL string, optFns ...func(o *AzureOpenAIOptions)) (*AzureOpenAI, error) {
 	opts := AzureOpenAIOptions{
 		OpenAIOptions: DefaultOpenAIOptions,
@@ -24,16 +35,13 @@ func NewAzureOpenAI(apiKey, baseURL string, optFns ...func(o *AzureOpenAIOptions
 		fn(&opts)
 	}
 
-	config := openai.DefaultAzureConfig(apiKey, baseURL)
-	if opts.Deployment != "" {
-		config.AzureModelMapperFunc = func(model string) string {
-			azureModelMapping := map[string]string{
-				opts.ModelName: opts.Deployment,
-			}
+	config := integration.NewAzureOpenAIConfig(apiKey, baseURL, func(o *integration.AzureOpenAIConfigOptions) {
+		o.Deployment = opts.Deployment
+		o.APIVersion = opts.APIVersion
+		o.TokenProvider = opts.TokenProvider
+	})
 
-			return azureModelMapping[model]
-		}
-	}
+	config.HTTPClient = retry.NewHTTPClient(config.HTTPClient)
 
 	openAI, err := NewOpenAIFromClient(openai.NewClientWithConfig(config), func(o *OpenAIOptions) {
 		*o = opts.OpenAIOptions
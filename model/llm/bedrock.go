@@ -4,11 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
 	bedrockruntimeTypes "github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 	"github.com/hupe1980/golc"
 	"github.com/hupe1980/golc/callback"
 	"github.com/hupe1980/golc/integration/ai21"
@@ -20,6 +24,9 @@ import (
 // Compile time check to ensure Bedrock satisfies the LLM interface.
 var _ schema.LLM = (*Bedrock)(nil)
 
+// Compile time check to ensure Bedrock satisfies the StreamingLLM interface.
+var _ schema.StreamingLLM = (*Bedrock)(nil)
+
 // providerStopSequenceKeyMap is a mapping between language model (LLM) providers
 // and the corresponding key names used for stop sequences. Stop sequences are sets
 // of words that, when encountered in the generated text, signal the language model
@@ -250,6 +257,10 @@ func (bioa *BedrockInputOutputAdapter) PrepareStreamOutput(response []byte) (str
 			return "", err
 		}
 
+		if len(output.Outputs) == 0 {
+			return "", nil
+		}
+
 		return output.Outputs[0].Text, nil
 	}
 
@@ -704,27 +715,15 @@ func (l *Bedrock) Generate(ctx context.Context, prompt string, optFns ...func(o
 		fn(&opts)
 	}
 
-	provider := l.getProvider()
-
-	params := util.CopyMap(l.opts.ModelParams)
-
-	if len(opts.Stop) > 0 {
-		key, ok := providerStopSequenceKeyMap[provider]
-		if !ok {
-			return nil, fmt.Errorf("stop sequence key name for provider %s is not supported", provider)
-		}
-
-		params[key] = opts.Stop
-	}
-
-	bioa := NewBedrockInputOutputAdapter(provider)
-
-	body, err := bioa.PrepareInput(prompt, params)
+	bioa, body, err := l.prepareInput(prompt, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	var completion string
+	var (
+		completion string
+		tokenUsage *schema.TokenUsage
+	)
 
 	if l.opts.Stream {
 		res, err := l.client.InvokeModelWithResponseStream(ctx, &bedrockruntime.InvokeModelWithResponseStreamInput{
@@ -743,22 +742,23 @@ func (l *Bedrock) Generate(ctx context.Context, prompt string, optFns ...func(o
 
 		tokens := []string{}
 
-		for event := range stream.Events() {
-			switch v := event.(type) {
-			case *bedrockruntimeTypes.ResponseStreamMemberChunk:
-				token, err := bioa.PrepareStreamOutput(v.Value.Bytes)
-				if err != nil {
-					return nil, err
-				}
-
-				if err := opts.CallbackManger.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{
-					Token: token,
-				}); err != nil {
-					return nil, err
-				}
-
-				tokens = append(tokens, token)
+		tokenUsage, err = readBedrockResponseStream(stream.Events(), bioa, func(token string) error {
+			if err := opts.CallbackManger.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{
+				Token: token,
+			}); err != nil {
+				return err
 			}
+
+			tokens = append(tokens, token)
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if err := stream.Err(); err != nil {
+			return nil, err
 		}
 
 		completion = strings.Join(tokens, "")
@@ -779,14 +779,84 @@ func (l *Bedrock) Generate(ctx context.Context, prompt string, optFns ...func(o
 		}
 
 		completion = output
+		tokenUsage = bedrockResponseTokenUsage(res.ResultMetadata)
+	}
+
+	llmOutput := map[string]any{}
+	if tokenUsage != nil {
+		llmOutput["TokenUsage"] = *tokenUsage
 	}
 
 	return &schema.ModelResult{
 		Generations: []schema.Generation{{Text: completion}},
-		LLMOutput:   map[string]any{},
+		LLMOutput:   llmOutput,
 	}, nil
 }
 
+// Stream streams the tokens generated for the provided prompt using InvokeModelWithResponseStream.
+func (l *Bedrock) Stream(ctx context.Context, prompt string, optFns ...func(o *schema.GenerateOptions)) (<-chan schema.StreamChunk, error) {
+	opts := schema.GenerateOptions{
+		CallbackManger: &callback.NoopManager{},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	bioa, body, err := l.prepareInput(prompt, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := l.client.InvokeModelWithResponseStream(ctx, &bedrockruntime.InvokeModelWithResponseStreamInput{
+		ModelId:     aws.String(l.modelID),
+		Body:        body,
+		Accept:      aws.String("application/json"),
+		ContentType: aws.String("application/json"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stream := res.GetStream()
+
+	chunks := make(chan schema.StreamChunk)
+
+	go func() {
+		defer close(chunks)
+		defer stream.Close()
+
+		tokenUsage, err := readBedrockResponseStream(stream.Events(), bioa, func(token string) error {
+			if err := opts.CallbackManger.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{
+				Token: token,
+			}); err != nil {
+				return err
+			}
+
+			if !sendStreamChunk(ctx, chunks, schema.StreamChunk{Token: token}) {
+				return ctx.Err()
+			}
+
+			return nil
+		})
+		if err != nil {
+			sendStreamChunk(ctx, chunks, schema.StreamChunk{Err: err})
+			return
+		}
+
+		if err := stream.Err(); err != nil {
+			sendStreamChunk(ctx, chunks, schema.StreamChunk{Err: err})
+			return
+		}
+
+		if tokenUsage != nil {
+			sendStreamChunk(ctx, chunks, schema.StreamChunk{TokenUsage: tokenUsage})
+		}
+	}()
+
+	return chunks, nil
+}
+
 // Type returns the type of the model.
 func (l *Bedrock) Type() string {
 	return "llm.Bedrock"
@@ -810,7 +880,103 @@ func (l *Bedrock) InvocationParams() map[string]any {
 	return params
 }
 
+// prepareInput builds the provider specific request body for the prompt.
+func (l *Bedrock) prepareInput(prompt string, opts schema.GenerateOptions) (*BedrockInputOutputAdapter, []byte, error) {
+	provider := l.getProvider()
+
+	params := util.CopyMap(l.opts.ModelParams)
+
+	if len(opts.Stop) > 0 {
+		key, ok := providerStopSequenceKeyMap[provider]
+		if !ok {
+			return nil, nil, fmt.Errorf("stop sequence key name for provider %s is not supported", provider)
+		}
+
+		params[key] = opts.Stop
+	}
+
+	bioa := NewBedrockInputOutputAdapter(provider)
+
+	body, err := bioa.PrepareInput(prompt, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return bioa, body, nil
+}
+
 // getProvider returns the provider of the model based on the model ID.
+// The region prefix of cross-region inference profiles (e.g. "us.anthropic.claude-v2") is skipped.
 func (l *Bedrock) getProvider() string {
-	return strings.Split(l.modelID, ".")[0]
+	parts := strings.Split(l.modelID, ".")
+
+	if len(parts) > 2 {
+		switch parts[0] {
+		case "us", "eu", "apac", "us-gov":
+			return parts[1]
+		}
+	}
+
+	return parts[0]
+}
+
+// readBedrockResponseStream reads the chunks of the response stream and passes the decoded tokens to onToken.
+// It returns the token usage of the invocation metrics sent with the last chunk, if any.
+func readBedrockResponseStream(events <-chan bedrockruntimeTypes.ResponseStream, bioa *BedrockInputOutputAdapter, onToken func(token string) error) (*schema.TokenUsage, error) {
+	var tokenUsage *schema.TokenUsage
+
+	for event := range events {
+		v, ok := event.(*bedrockruntimeTypes.ResponseStreamMemberChunk)
+		if !ok {
+			continue
+		}
+
+		token, err := bioa.PrepareStreamOutput(v.Value.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := onToken(token); err != nil {
+			return nil, err
+		}
+
+		metrics := &bedrockInvocationMetricsOutput{}
+		if err := json.Unmarshal(v.Value.Bytes, metrics); err == nil && metrics.InvocationMetrics != nil {
+			usage := schema.NewTokenUsage(metrics.InvocationMetrics.InputTokenCount, metrics.InvocationMetrics.OutputTokenCount)
+			tokenUsage = &usage
+		}
+	}
+
+	return tokenUsage, nil
+}
+
+// bedrockInvocationMetricsOutput contains the invocation metrics Bedrock adds to the last chunk of a stream.
+type bedrockInvocationMetricsOutput struct {
+	InvocationMetrics *struct {
+		InputTokenCount  int `json:"inputTokenCount"`
+		OutputTokenCount int `json:"outputTokenCount"`
+	} `json:"amazon-bedrock-invocationMetrics"`
+}
+
+// bedrockResponseTokenUsage returns the token usage Bedrock reports in the headers of an InvokeModel
+// response, or nil if the headers are missing.
+func bedrockResponseTokenUsage(metadata middleware.Metadata) *schema.TokenUsage {
+	res, ok := awsmiddleware.GetRawResponse(metadata).(*smithyhttp.Response)
+	if !ok || res.Response == nil {
+		return nil
+	}
+
+	inputTokens, err := strconv.Atoi(res.Header.Get("X-Amzn-Bedrock-Input-Token-Count"))
+	if err != nil {
+		return nil
+	}
+
+	outputTokens, err := strconv.Atoi(res.Header.Get("X-Amzn-Bedrock-Output-Token-Count"))
+	if err != nil {
+		return nil
+	}
+
+	usage := schema.NewTokenUsage(inputTokens, outputTokens)
+
+	return &usage
 }
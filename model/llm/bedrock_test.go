@@ -4,9 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"testing"
 
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	bedrockruntimeTypes "github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/hupe1980/golc/schema"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -449,6 +455,23 @@ func TestBedrock(t *testing.T) {
 		assert.Equal(t, bedrockModel.opts.CallbackOptions.Callbacks, bedrockModel.Callbacks())
 	})
 
+	t.Run("Provider", func(t *testing.T) {
+		tests := map[string]string{
+			"anthropic.claude-v2":                            "anthropic",
+			"us.anthropic.claude-3-haiku-20240307-v1:0":      "anthropic",
+			"eu.meta.llama3-2-3b-instruct-v1:0":              "meta",
+			"mistral.mistral-7b-instruct-v0:2":               "mistral",
+			"amazon.titan-text-lite-v1":                      "amazon",
+			"apac.anthropic.claude-3-5-sonnet-20240620-v1:0": "anthropic",
+		}
+
+		for modelID, provider := range tests {
+			bedrockModel, err := NewBedrock(client, modelID)
+			assert.NoError(t, err)
+			assert.Equal(t, provider, bedrockModel.getProvider(), modelID)
+		}
+	})
+
 	t.Run("InvocationParams", func(t *testing.T) {
 		bedrockModel, err := NewBedrock(client, "amazon.titan-text-lite-v1", func(o *BedrockOptions) {
 			o.ModelParams = map[string]any{
@@ -476,3 +499,56 @@ func (m *mockBedrockClient) InvokeModel(ctx context.Context, params *bedrockrunt
 func (m *mockBedrockClient) InvokeModelWithResponseStream(ctx context.Context, params *bedrockruntime.InvokeModelWithResponseStreamInput, optFns ...func(*bedrockruntime.Options)) (*bedrockruntime.InvokeModelWithResponseStreamOutput, error) {
 	return nil, nil
 }
+
+func TestBedrockResponseTokenUsage(t *testing.T) {
+	t.Run("Headers", func(t *testing.T) {
+		// The raw response is added to the metadata by the deserialize middleware of the AWS SDK.
+		_, metadata, err := awsmiddleware.AddRawResponse{}.HandleDeserialize(context.Background(), middleware.DeserializeInput{},
+			middleware.DeserializeHandlerFunc(func(ctx context.Context, in middleware.DeserializeInput) (middleware.DeserializeOutput, middleware.Metadata, error) {
+				header := http.Header{}
+				header.Set("X-Amzn-Bedrock-Input-Token-Count", "3")
+				header.Set("X-Amzn-Bedrock-Output-Token-Count", "4")
+
+				return middleware.DeserializeOutput{
+					RawResponse: &smithyhttp.Response{Response: &http.Response{Header: header}},
+				}, middleware.Metadata{}, nil
+			}))
+		assert.NoError(t, err)
+
+		assert.Equal(t, &schema.TokenUsage{PromptTokens: 3, CompletionTokens: 4, TotalTokens: 7}, bedrockResponseTokenUsage(metadata))
+	})
+
+	t.Run("No raw response", func(t *testing.T) {
+		assert.Nil(t, bedrockResponseTokenUsage(middleware.Metadata{}))
+	})
+}
+
+func TestReadBedrockResponseStream(t *testing.T) {
+	events := make(chan bedrockruntimeTypes.ResponseStream, 3)
+	events <- &bedrockruntimeTypes.ResponseStreamMemberChunk{Value: bedrockruntimeTypes.PayloadPart{Bytes: []byte(`{"generation":"Hello"}`)}}
+	events <- &bedrockruntimeTypes.ResponseStreamMemberChunk{Value: bedrockruntimeTypes.PayloadPart{Bytes: []byte(`{"generation":" world","amazon-bedrock-invocationMetrics":{"inputTokenCount":3,"outputTokenCount":2}}`)}}
+	close(events)
+
+	t.Run("Tokens", func(t *testing.T) {
+		tokens := []string{}
+
+		tokenUsage, err := readBedrockResponseStream(events, NewBedrockInputOutputAdapter("meta"), func(token string) error {
+			tokens = append(tokens, token)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Hello", " world"}, tokens)
+		assert.Equal(t, &schema.TokenUsage{PromptTokens: 3, CompletionTokens: 2, TotalTokens: 5}, tokenUsage)
+	})
+
+	t.Run("Invalid payload", func(t *testing.T) {
+		events := make(chan bedrockruntimeTypes.ResponseStream, 1)
+		events <- &bedrockruntimeTypes.ResponseStreamMemberChunk{Value: bedrockruntimeTypes.PayloadPart{Bytes: []byte(`invalid`)}}
+		close(events)
+
+		_, err := readBedrockResponseStream(events, NewBedrockInputOutputAdapter("meta"), func(token string) error {
+			return nil
+		})
+		assert.Error(t, err)
+	})
+}
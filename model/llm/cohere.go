@@ -2,14 +2,14 @@ package llm
 
 import (
 	"context"
-	"errors"
+	"time"
 
-	"github.com/avast/retry-go"
 	cohere "github.com/cohere-ai/cohere-go/v2"
 	cohereclient "github.com/cohere-ai/cohere-go/v2/client"
 	core "github.com/cohere-ai/cohere-go/v2/core"
 	"github.com/hupe1980/golc"
 	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/internal/retry"
 	"github.com/hupe1980/golc/internal/util"
 	"github.com/hupe1980/golc/schema"
 	"github.com/hupe1980/golc/tokenizer"
@@ -64,6 +64,9 @@ type CohereOptions struct {
 
 	// MaxRetries represents the maximum number of retries to make when generating.
 	MaxRetries uint `map:"max_retries,omitempty"`
+
+	// Timeout is the maximum duration of a request to the API. A value <= 0 disables the timeout.
+	Timeout time.Duration `map:"-"`
 }
 
 // Cohere represents the Cohere language model.
@@ -76,7 +79,7 @@ type Cohere struct {
 // NewCohere creates a new Cohere instance using the provided API key and optional configuration options.
 // It internally creates a Cohere client using the provided API key and initializes the Cohere struct.
 func NewCohere(apiKey string, optFns ...func(o *CohereOptions)) (*Cohere, error) {
-	client := cohereclient.NewClient(cohereclient.WithToken(apiKey))
+	client := cohereclient.NewClient(cohereclient.WithToken(apiKey), cohereclient.WithHTTPClient(retry.NewHTTPClient(nil)))
 	return NewCohereFromClient(client, optFns...)
 }
 
@@ -120,6 +123,9 @@ func NewCohereFromClient(client CohereClient, optFns ...func(o *CohereOptions))
 
 // Generate generates text based on the provided prompt and options.
 func (l *Cohere) Generate(ctx context.Context, prompt string, optFns ...func(o *schema.GenerateOptions)) (*schema.ModelResult, error) {
+	ctx, cancel := util.ContextWithTimeout(ctx, l.opts.Timeout)
+	defer cancel()
+
 	opts := schema.GenerateOptions{
 		CallbackManger: &callback.NoopManager{},
 	}
@@ -150,51 +156,36 @@ func (l *Cohere) Generate(ctx context.Context, prompt string, optFns ...func(o *
 		return nil, err
 	}
 
-	return &schema.ModelResult{
-		Generations: []schema.Generation{{Text: res.Generations[0].Text}},
-		LLMOutput: map[string]any{
-			"likelihood":       res.Generations[0].Likelihood,
-			"tokenLikelihoods": res.Generations[0].TokenLikelihoods,
-		},
-	}, nil
-}
+	generations := util.Map(res.Generations, func(g *cohere.SingleGeneration, _ int) schema.Generation {
+		return schema.Generation{
+			Text: g.Text,
+			Info: map[string]any{
+				"Likelihood":       g.Likelihood,
+				"TokenLikelihoods": g.TokenLikelihoods,
+			},
+		}
+	})
 
-func (l *Cohere) generateWithRetry(ctx context.Context, req *cohere.GenerateRequest) (*cohere.Generation, error) {
-	retryOpts := []retry.Option{
-		retry.Attempts(l.opts.MaxRetries),
-		retry.DelayType(retry.FixedDelay),
-		retry.RetryIf(func(err error) bool {
-			e := new(core.APIError)
-			if errors.As(err, &e) {
-				switch e.StatusCode {
-				case 429, 500:
-					return true
-				default:
-					return false
-				}
-			}
-
-			return false
-		}),
+	llmOutput := map[string]any{
+		"ModelName": l.opts.Model,
 	}
 
-	var res *cohere.Generation
-
-	err := retry.Do(
-		func() error {
-			r, cErr := l.client.Generate(ctx, req)
-			if cErr != nil {
-				return cErr
-			}
+	if tokenUsage, ok := newCohereTokenUsage(res.Meta); ok {
+		llmOutput["TokenUsage"] = tokenUsage
+	}
 
-			res = r
+	return &schema.ModelResult{
+		Generations: generations,
+		LLMOutput:   llmOutput,
+	}, nil
+}
 
-			return nil
-		},
-		retryOpts...,
-	)
+func (l *Cohere) generateWithRetry(ctx context.Context, req *cohere.GenerateRequest) (*cohere.Generation, error) {
+	policy := retry.NewPolicy(l.opts.MaxRetries, retry.IsRetryableCohereError)
 
-	return res, err
+	return retry.Do(ctx, policy, func(ctx context.Context) (*cohere.Generation, error) {
+		return l.client.Generate(ctx, req)
+	})
 }
 
 // Type returns the type of the model.
@@ -216,3 +207,22 @@ func (l *Cohere) Callbacks() []schema.Callback {
 func (l *Cohere) InvocationParams() map[string]any {
 	return util.StructToMap(l.opts)
 }
+
+// newCohereTokenUsage converts the billed units of the response meta into a token usage.
+func newCohereTokenUsage(meta *cohere.ApiMeta) (schema.TokenUsage, bool) {
+	if meta == nil || meta.BilledUnits == nil {
+		return schema.TokenUsage{}, false
+	}
+
+	var promptTokens, completionTokens int
+
+	if meta.BilledUnits.InputTokens != nil {
+		promptTokens = int(*meta.BilledUnits.InputTokens)
+	}
+
+	if meta.BilledUnits.OutputTokens != nil {
+		completionTokens = int(*meta.BilledUnits.OutputTokens)
+	}
+
+	return schema.NewTokenUsage(promptTokens, completionTokens), true
+}
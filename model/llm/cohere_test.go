@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	cohere "github.com/cohere-ai/cohere-go/v2"
 	core "github.com/cohere-ai/cohere-go/v2/core"
@@ -42,6 +43,26 @@ func TestCohere(t *testing.T) {
 			assert.Equal(t, expectedResponse.Generations[0].Text, result.Generations[0].Text)
 		})
 
+		t.Run("Multiple generations", func(t *testing.T) {
+			likelihood := -1.5
+
+			mockClient.GenerateFunc = func(req *cohere.GenerateRequest, opts ...core.RequestOption) (*cohere.Generation, error) {
+				return &cohere.Generation{
+					Generations: []*cohere.SingleGeneration{
+						{Text: "First"},
+						{Text: "Second", Likelihood: &likelihood},
+					},
+				}, nil
+			}
+
+			result, err := llm.Generate(ctx, prompt)
+			assert.NoError(t, err)
+			assert.Len(t, result.Generations, 2)
+			assert.Equal(t, "First", result.Generations[0].Text)
+			assert.Equal(t, "Second", result.Generations[1].Text)
+			assert.Equal(t, &likelihood, result.Generations[1].Info["Likelihood"])
+		})
+
 		t.Run("Error in generation", func(t *testing.T) {
 			// Define the error to be returned from the mock client
 			returnedError := errors.New("generation failed")
@@ -58,6 +79,25 @@ func TestCohere(t *testing.T) {
 		})
 	})
 
+	t.Run("Timeout", func(t *testing.T) {
+		mockClient := &mockCohereClient{
+			GenerateFunc: func(req *cohere.GenerateRequest, opts ...core.RequestOption) (*cohere.Generation, error) {
+				return &cohere.Generation{}, nil
+			},
+		}
+
+		llm, err := NewCohereFromClient(mockClient, func(o *CohereOptions) {
+			o.Timeout = time.Minute
+		})
+		assert.NoError(t, err)
+
+		_, err = llm.Generate(ctx, prompt)
+		assert.NoError(t, err)
+
+		_, ok := mockClient.Ctx.Deadline()
+		assert.True(t, ok)
+	})
+
 	t.Run("Type", func(t *testing.T) {
 		// Create a Cohere instance
 		llm, err := NewCohereFromClient(&mockCohereClient{})
@@ -114,10 +154,14 @@ func TestCohere(t *testing.T) {
 // mockCohereClient is a mock implementation of the CohereClient interface.
 type mockCohereClient struct {
 	GenerateFunc func(req *cohere.GenerateRequest, opts ...core.RequestOption) (*cohere.Generation, error)
+	// Ctx is the context of the last call.
+	Ctx context.Context
 }
 
 // Generate is the mock implementation of the Generate method.
 func (m *mockCohereClient) Generate(ctx context.Context, request *cohere.GenerateRequest, opts ...core.RequestOption) (*cohere.Generation, error) {
+	m.Ctx = ctx
+
 	if m.GenerateFunc != nil {
 		return m.GenerateFunc(request, opts...)
 	}
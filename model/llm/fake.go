@@ -2,6 +2,7 @@ package llm
 
 import (
 	"context"
+	"strings"
 
 	"github.com/hupe1980/golc"
 	"github.com/hupe1980/golc/callback"
@@ -9,8 +10,8 @@ import (
 	"github.com/hupe1980/golc/schema"
 )
 
-// Compile time check to ensure Fake satisfies the LLM interface.
-var _ schema.LLM = (*Fake)(nil)
+// Compile time check to ensure Fake satisfies the StreamingLLM interface.
+var _ schema.StreamingLLM = (*Fake)(nil)
 
 // FakeResultFunc is a function type for generating fake responses based on a prompt.
 type FakeResultFunc func(ctx context.Context, prompt string) (*schema.ModelResult, error)
@@ -53,6 +54,7 @@ func NewFake(fakeResultFunc FakeResultFunc, optFns ...func(o *FakeOptions)) *Fak
 	}
 
 	return &Fake{
+		Tokenizer:      opts.Tokenizer,
 		fakeResultFunc: fakeResultFunc,
 		opts:           opts,
 	}
@@ -71,6 +73,49 @@ func (l *Fake) Generate(ctx context.Context, prompt string, optFns ...func(o *sc
 	return l.fakeResultFunc(ctx, prompt)
 }
 
+// Stream generates text based on the provided prompt and sends the words of the first generation
+// as individual tokens to the returned channel.
+func (l *Fake) Stream(ctx context.Context, prompt string, optFns ...func(o *schema.GenerateOptions)) (<-chan schema.StreamChunk, error) {
+	opts := schema.GenerateOptions{
+		CallbackManger: &callback.NoopManager{},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	result, err := l.fakeResultFunc(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens []string
+	if len(result.Generations) > 0 {
+		tokens = strings.SplitAfter(result.Generations[0].Text, " ")
+	}
+
+	chunks := make(chan schema.StreamChunk)
+
+	go func() {
+		defer close(chunks)
+
+		for _, token := range tokens {
+			if err := opts.CallbackManger.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{
+				Token: token,
+			}); err != nil {
+				sendStreamChunk(ctx, chunks, schema.StreamChunk{Err: err})
+				return
+			}
+
+			if !sendStreamChunk(ctx, chunks, schema.StreamChunk{Token: token}) {
+				return
+			}
+		}
+	}()
+
+	return chunks, nil
+}
+
 // Type returns the type of the model.
 func (l *Fake) Type() string {
 	return l.opts.LLMType
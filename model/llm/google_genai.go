@@ -112,6 +112,7 @@ func (l *GoogleGenAI) Generate(ctx context.Context, prompt string, optFns ...fun
 	}
 
 	generations := []schema.Generation{}
+	tokenUsage := schema.TokenUsage{}
 
 	if l.opts.Stream {
 		stream, err := l.client.StreamGenerateContent(ctx, req)
@@ -136,6 +137,15 @@ func (l *GoogleGenAI) Generate(ctx context.Context, prompt string, optFns ...fun
 					return nil, err
 				}
 
+				// The usage metadata of the last chunk covers the whole response.
+				if u := res.GetUsageMetadata(); u != nil {
+					tokenUsage = newGoogleGenAITokenUsage(u)
+				}
+
+				if len(res.Candidates) == 0 {
+					continue
+				}
+
 				var b strings.Builder
 				for _, p := range res.Candidates[0].Content.Parts {
 					fmt.Fprintf(&b, "%s", p.GetText())
@@ -160,6 +170,10 @@ func (l *GoogleGenAI) Generate(ctx context.Context, prompt string, optFns ...fun
 			return nil, err
 		}
 
+		if u := res.GetUsageMetadata(); u != nil {
+			tokenUsage = newGoogleGenAITokenUsage(u)
+		}
+
 		for _, c := range res.Candidates {
 			var b strings.Builder
 			for _, p := range c.Content.Parts {
@@ -172,7 +186,10 @@ func (l *GoogleGenAI) Generate(ctx context.Context, prompt string, optFns ...fun
 
 	return &schema.ModelResult{
 		Generations: generations,
-		LLMOutput:   map[string]any{},
+		LLMOutput: map[string]any{
+			"ModelName":  l.opts.ModelName,
+			"TokenUsage": tokenUsage,
+		},
 	}, nil
 }
 
@@ -195,3 +212,12 @@ func (l *GoogleGenAI) Callbacks() []schema.Callback {
 func (l *GoogleGenAI) InvocationParams() map[string]any {
 	return util.StructToMap(l.opts)
 }
+
+// newGoogleGenAITokenUsage converts the usage metadata of a response into a token usage.
+func newGoogleGenAITokenUsage(u *generativelanguagepb.GenerateContentResponse_UsageMetadata) schema.TokenUsage {
+	return schema.TokenUsage{
+		PromptTokens:     int(u.PromptTokenCount),
+		CompletionTokens: int(u.CandidatesTokenCount),
+		TotalTokens:      int(u.TotalTokenCount),
+	}
+}
@@ -115,9 +115,17 @@ func (l *HuggingFaceHub) Generate(ctx context.Context, prompt string, optFns ...
 		return nil, err
 	}
 
+	// The inference API does not report the token usage, so it is estimated with the tokenizer.
+	tokenUsage, err := estimateTokenUsage(ctx, l.Tokenizer, prompt, text)
+	if err != nil {
+		return nil, err
+	}
+
 	return &schema.ModelResult{
 		Generations: []schema.Generation{{Text: text}},
-		LLMOutput:   map[string]any{},
+		LLMOutput: map[string]any{
+			"TokenUsage": tokenUsage,
+		},
 	}, nil
 }
 
@@ -3,6 +3,7 @@ package llm
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	huggingface "github.com/hupe1980/go-huggingface"
@@ -46,6 +47,7 @@ func TestHuggingFaceHub(t *testing.T) {
 			// Create a new HuggingFaceHub instance with the updated options
 			hub, err := NewHuggingFaceHubFromClient(mockClient, func(o *HuggingFaceHubOptions) {
 				o.Task = tc.task
+				o.Tokenizer = &mockTokenizer{}
 			})
 			assert.NoError(t, err)
 
@@ -77,6 +79,12 @@ func TestHuggingFaceHub(t *testing.T) {
 
 			// Verify the generated text based on the task
 			assert.Equal(t, tc.expectedGeneratedText, result.Generations[0].Text)
+
+			// The token usage is estimated with the tokenizer
+			tokenUsage, ok := result.TokenUsage()
+			assert.True(t, ok)
+			assert.Equal(t, len(strings.Fields(tc.prompt)), tokenUsage.PromptTokens)
+			assert.Equal(t, len(strings.Fields(tc.expectedGeneratedText)), tokenUsage.CompletionTokens)
 		})
 	}
 
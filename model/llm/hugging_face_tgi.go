@@ -0,0 +1,307 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/integration/tgi"
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tokenizer"
+)
+
+// Compile time check to ensure HuggingFaceTGI satisfies the LLM interface.
+var _ schema.LLM = (*HuggingFaceTGI)(nil)
+
+// Compile time check to ensure HuggingFaceTGI satisfies the StreamingLLM interface.
+var _ schema.StreamingLLM = (*HuggingFaceTGI)(nil)
+
+// HuggingFaceTGIClient is an interface for the Hugging Face Text Generation Inference client.
+type HuggingFaceTGIClient interface {
+	// Generate generates text using the generate endpoint.
+	Generate(ctx context.Context, req *tgi.GenerateRequest) (*tgi.GenerateResponse, error)
+	// GenerateStream generates text using the generate_stream endpoint.
+	GenerateStream(ctx context.Context, req *tgi.GenerateRequest) (*tgi.GenerateStream, error)
+}
+
+// HuggingFaceTGIOptions contains options for the Hugging Face Text Generation Inference model.
+type HuggingFaceTGIOptions struct {
+	// CallbackOptions specify options for handling callbacks during text generation.
+	*schema.CallbackOptions `map:"-"`
+	// Tokenizer represents the tokenizer to be used with the LLM model.
+	schema.Tokenizer `map:"-"`
+	// APIToken is the optional token used to authenticate against protected endpoints.
+	APIToken string `map:"-"`
+	// MaxNewTokens is the maximum number of tokens to generate.
+	MaxNewTokens int `map:"max_new_tokens,omitempty"`
+	// Temperature controls the randomness of the generation. Higher values make the output more random.
+	Temperature float32 `map:"temperature,omitempty"`
+	// TopK is the number of top tokens to consider for sampling.
+	TopK int `map:"top_k,omitempty"`
+	// TopP is the nucleus sampling parameter.
+	TopP float32 `map:"top_p,omitempty"`
+	// TypicalP is the typical decoding mass.
+	TypicalP float32 `map:"typical_p,omitempty"`
+	// RepetitionPenalty penalizes repeated tokens. 1.0 means no penalty.
+	RepetitionPenalty float32 `map:"repetition_penalty,omitempty"`
+	// DoSample activates logits sampling.
+	DoSample bool `map:"do_sample,omitempty"`
+	// Seed is the random sampling seed.
+	Seed *int `map:"seed,omitempty"`
+	// Truncate truncates the input tokens to the given size.
+	Truncate int `map:"truncate,omitempty"`
+	// StopSequences is a list of sequences to stop the generation at.
+	StopSequences []string `map:"stop_sequences,omitempty"`
+	// Stream indicates whether to stream the results or not.
+	Stream bool `map:"stream,omitempty"`
+}
+
+// HuggingFaceTGI represents a model served by the Hugging Face Text Generation Inference server.
+type HuggingFaceTGI struct {
+	schema.Tokenizer
+	client HuggingFaceTGIClient
+	opts   HuggingFaceTGIOptions
+}
+
+// NewHuggingFaceTGI creates a new instance of the HuggingFaceTGI model for the TGI server at the given url.
+func NewHuggingFaceTGI(apiURL string, optFns ...func(o *HuggingFaceTGIOptions)) (*HuggingFaceTGI, error) {
+	opts := HuggingFaceTGIOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	client := tgi.New(apiURL, func(o *tgi.ClientOptions) {
+		o.APIToken = opts.APIToken
+	})
+
+	return NewHuggingFaceTGIFromClient(client, optFns...)
+}
+
+// NewHuggingFaceTGIFromClient creates a new instance of the HuggingFaceTGI model with the provided client and options.
+func NewHuggingFaceTGIFromClient(client HuggingFaceTGIClient, optFns ...func(o *HuggingFaceTGIOptions)) (*HuggingFaceTGI, error) {
+	opts := HuggingFaceTGIOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		MaxNewTokens:      512,
+		TopK:              10,
+		TopP:              0.95,
+		TypicalP:          0.95,
+		Temperature:       0.8,
+		RepetitionPenalty: 1.03,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.Tokenizer == nil {
+		var tErr error
+
+		opts.Tokenizer, tErr = tokenizer.NewGPT2()
+		if tErr != nil {
+			return nil, tErr
+		}
+	}
+
+	return &HuggingFaceTGI{
+		Tokenizer: opts.Tokenizer,
+		client:    client,
+		opts:      opts,
+	}, nil
+}
+
+// Generate generates text based on the provided prompt and options.
+func (l *HuggingFaceTGI) Generate(ctx context.Context, prompt string, optFns ...func(o *schema.GenerateOptions)) (*schema.ModelResult, error) {
+	opts := schema.GenerateOptions{
+		CallbackManger: &callback.NoopManager{},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	req := l.newGenerateRequest(prompt, opts)
+
+	var (
+		text         string
+		finishReason string
+		// The endpoint reports the number of generated tokens only.
+		tokenUsage schema.TokenUsage
+	)
+
+	if l.opts.Stream {
+		stream, err := l.client.GenerateStream(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		defer stream.Close()
+
+		tokens := []string{}
+
+		for {
+			res, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			if res.Details != nil {
+				finishReason = res.Details.FinishReason
+				tokenUsage = schema.NewTokenUsage(0, res.Details.GeneratedTokens)
+			}
+
+			if res.Token.Special {
+				continue
+			}
+
+			if err := opts.CallbackManger.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{
+				Token: res.Token.Text,
+			}); err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, res.Token.Text)
+		}
+
+		text = strings.Join(tokens, "")
+	} else {
+		res, err := l.client.Generate(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		text = res.GeneratedText
+
+		if res.Details != nil {
+			finishReason = res.Details.FinishReason
+			tokenUsage = schema.NewTokenUsage(0, res.Details.GeneratedTokens)
+		}
+	}
+
+	return &schema.ModelResult{
+		Generations: []schema.Generation{{
+			Text: text,
+			Info: map[string]any{
+				"FinishReason": finishReason,
+			},
+		}},
+		LLMOutput: map[string]any{
+			"TokenUsage": tokenUsage,
+		},
+	}, nil
+}
+
+// Stream streams the tokens generated for the provided prompt using the generate_stream endpoint.
+func (l *HuggingFaceTGI) Stream(ctx context.Context, prompt string, optFns ...func(o *schema.GenerateOptions)) (<-chan schema.StreamChunk, error) {
+	opts := schema.GenerateOptions{
+		CallbackManger: &callback.NoopManager{},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	stream, err := l.client.GenerateStream(ctx, l.newGenerateRequest(prompt, opts))
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make(chan schema.StreamChunk)
+
+	go func() {
+		defer close(chunks)
+		defer stream.Close()
+
+		for {
+			res, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			if err != nil {
+				sendStreamChunk(ctx, chunks, schema.StreamChunk{Err: err})
+				return
+			}
+
+			chunk := schema.StreamChunk{}
+
+			if !res.Token.Special {
+				chunk.Token = res.Token.Text
+			}
+
+			if res.Details != nil {
+				chunk.FinishReason = res.Details.FinishReason
+			}
+
+			if chunk.Token != "" {
+				if err := opts.CallbackManger.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{
+					Token: chunk.Token,
+				}); err != nil {
+					sendStreamChunk(ctx, chunks, schema.StreamChunk{Err: err})
+					return
+				}
+			}
+
+			if !sendStreamChunk(ctx, chunks, chunk) {
+				return
+			}
+		}
+	}()
+
+	return chunks, nil
+}
+
+// newGenerateRequest creates a generate request for the provided prompt and options.
+func (l *HuggingFaceTGI) newGenerateRequest(prompt string, opts schema.GenerateOptions) *tgi.GenerateRequest {
+	stop := l.opts.StopSequences
+	if len(opts.Stop) > 0 {
+		stop = append(append([]string{}, l.opts.StopSequences...), opts.Stop...)
+	}
+
+	return &tgi.GenerateRequest{
+		Inputs: prompt,
+		Parameters: tgi.Parameters{
+			MaxNewTokens:      l.opts.MaxNewTokens,
+			Temperature:       l.opts.Temperature,
+			TopK:              l.opts.TopK,
+			TopP:              l.opts.TopP,
+			TypicalP:          l.opts.TypicalP,
+			RepetitionPenalty: l.opts.RepetitionPenalty,
+			DoSample:          l.opts.DoSample,
+			Seed:              l.opts.Seed,
+			Truncate:          l.opts.Truncate,
+			Stop:              stop,
+			Details:           true,
+		},
+	}
+}
+
+// Type returns the type of the model.
+func (l *HuggingFaceTGI) Type() string {
+	return "llm.HuggingFaceTGI"
+}
+
+// Verbose returns the verbosity setting of the model.
+func (l *HuggingFaceTGI) Verbose() bool {
+	return l.opts.Verbose
+}
+
+// Callbacks returns the registered callbacks of the model.
+func (l *HuggingFaceTGI) Callbacks() []schema.Callback {
+	return l.opts.Callbacks
+}
+
+// InvocationParams returns the parameters used in the model invocation.
+func (l *HuggingFaceTGI) InvocationParams() map[string]any {
+	return util.StructToMap(l.opts)
+}
@@ -0,0 +1,147 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/hupe1980/golc/integration/tgi"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHuggingFaceTGI(t *testing.T) {
+	t.Run("Generate", func(t *testing.T) {
+		t.Run("Success", func(t *testing.T) {
+			mockClient := &mockHuggingFaceTGIClient{
+				GenerateFunc: func(ctx context.Context, req *tgi.GenerateRequest) (*tgi.GenerateResponse, error) {
+					assert.Equal(t, "Hello", req.Inputs)
+					assert.Equal(t, []string{"Observation:"}, req.Parameters.Stop)
+					assert.Equal(t, float32(0.95), req.Parameters.TypicalP)
+
+					return &tgi.GenerateResponse{
+						GeneratedText: "I can help you with that.",
+						Details:       &tgi.Details{FinishReason: "eos_token"},
+					}, nil
+				},
+			}
+
+			model, err := NewHuggingFaceTGIFromClient(mockClient)
+			assert.NoError(t, err)
+
+			result, err := model.Generate(context.Background(), "Hello", func(o *schema.GenerateOptions) {
+				o.Stop = []string{"Observation:"}
+			})
+			assert.NoError(t, err)
+			assert.Len(t, result.Generations, 1)
+			assert.Equal(t, "I can help you with that.", result.Generations[0].Text)
+			assert.Equal(t, "eos_token", result.Generations[0].Info["FinishReason"])
+		})
+
+		t.Run("Error", func(t *testing.T) {
+			mockClient := &mockHuggingFaceTGIClient{
+				GenerateFunc: func(ctx context.Context, req *tgi.GenerateRequest) (*tgi.GenerateResponse, error) {
+					return nil, errors.New("error generating text")
+				},
+			}
+
+			model, err := NewHuggingFaceTGIFromClient(mockClient)
+			assert.NoError(t, err)
+
+			result, err := model.Generate(context.Background(), "Hello")
+			assert.Error(t, err)
+			assert.Nil(t, result)
+		})
+
+		t.Run("Streaming", func(t *testing.T) {
+			mockClient := &mockHuggingFaceTGIClient{
+				GenerateStreamFunc: func(ctx context.Context, req *tgi.GenerateRequest) (*tgi.GenerateStream, error) {
+					return newMockTGIStream(
+						`{"token":{"id":1,"text":"Hello"}}`,
+						`{"token":{"id":2,"text":" world"}}`,
+						`{"token":{"id":3,"text":"</s>","special":true},"generated_text":"Hello world","details":{"finish_reason":"eos_token"}}`,
+					), nil
+				},
+			}
+
+			model, err := NewHuggingFaceTGIFromClient(mockClient, func(o *HuggingFaceTGIOptions) {
+				o.Stream = true
+			})
+			assert.NoError(t, err)
+
+			result, err := model.Generate(context.Background(), "Hello")
+			assert.NoError(t, err)
+			assert.Equal(t, "Hello world", result.Generations[0].Text)
+			assert.Equal(t, "eos_token", result.Generations[0].Info["FinishReason"])
+		})
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		mockClient := &mockHuggingFaceTGIClient{
+			GenerateStreamFunc: func(ctx context.Context, req *tgi.GenerateRequest) (*tgi.GenerateStream, error) {
+				return newMockTGIStream(
+					`{"token":{"id":1,"text":"Hello"}}`,
+					`{"token":{"id":2,"text":" world"},"generated_text":"Hello world","details":{"finish_reason":"length"}}`,
+				), nil
+			},
+		}
+
+		model, err := NewHuggingFaceTGIFromClient(mockClient)
+		assert.NoError(t, err)
+
+		stream, err := model.Stream(context.Background(), "Hello")
+		assert.NoError(t, err)
+
+		chunks := []schema.StreamChunk{}
+		for chunk := range stream {
+			chunks = append(chunks, chunk)
+		}
+
+		assert.Equal(t, []schema.StreamChunk{{Token: "Hello"}, {Token: " world", FinishReason: "length"}}, chunks)
+	})
+
+	t.Run("Type", func(t *testing.T) {
+		model, err := NewHuggingFaceTGIFromClient(&mockHuggingFaceTGIClient{})
+		assert.NoError(t, err)
+		assert.Equal(t, "llm.HuggingFaceTGI", model.Type())
+	})
+
+	t.Run("InvocationParams", func(t *testing.T) {
+		model, err := NewHuggingFaceTGIFromClient(&mockHuggingFaceTGIClient{}, func(o *HuggingFaceTGIOptions) {
+			o.APIToken = "secret"
+		})
+		assert.NoError(t, err)
+
+		params := model.InvocationParams()
+		assert.Equal(t, 512, params["max_new_tokens"])
+		assert.NotContains(t, params, "api_token")
+	})
+}
+
+// mockHuggingFaceTGIClient is a mock implementation of the HuggingFaceTGIClient interface for testing.
+type mockHuggingFaceTGIClient struct {
+	GenerateFunc       func(ctx context.Context, req *tgi.GenerateRequest) (*tgi.GenerateResponse, error)
+	GenerateStreamFunc func(ctx context.Context, req *tgi.GenerateRequest) (*tgi.GenerateStream, error)
+}
+
+func (m *mockHuggingFaceTGIClient) Generate(ctx context.Context, req *tgi.GenerateRequest) (*tgi.GenerateResponse, error) {
+	return m.GenerateFunc(ctx, req)
+}
+
+func (m *mockHuggingFaceTGIClient) GenerateStream(ctx context.Context, req *tgi.GenerateRequest) (*tgi.GenerateStream, error) {
+	return m.GenerateStreamFunc(ctx, req)
+}
+
+// newMockTGIStream creates a generate stream from the given event payloads.
+func newMockTGIStream(events ...string) *tgi.GenerateStream {
+	buf := &bytes.Buffer{}
+
+	for _, e := range events {
+		fmt.Fprintf(buf, "data:%s\n\n", e)
+	}
+
+	return tgi.NewGenerateStream(io.NopCloser(buf))
+}
@@ -2,8 +2,11 @@
 package llm
 
 import (
+	"context"
 	"regexp"
 	"strings"
+
+	"github.com/hupe1980/golc/schema"
 )
 
 // EnforceStopTokens cuts off the text as soon as any stop words occur.
@@ -23,3 +26,36 @@ func EnforceStopTokens(text string, stop []string) string {
 
 	return parts[0]
 }
+
+// estimateTokenUsage counts the tokens of the prompt and the completions with the tokenizer of a model,
+// whose API does not report the token usage.
+func estimateTokenUsage(ctx context.Context, tokenizer schema.Tokenizer, prompt string, completions ...string) (schema.TokenUsage, error) {
+	promptTokens, err := tokenizer.GetNumTokens(ctx, prompt)
+	if err != nil {
+		return schema.TokenUsage{}, err
+	}
+
+	completionTokens := uint(0)
+
+	for _, completion := range completions {
+		n, err := tokenizer.GetNumTokens(ctx, completion)
+		if err != nil {
+			return schema.TokenUsage{}, err
+		}
+
+		completionTokens += n
+	}
+
+	return schema.NewTokenUsage(int(promptTokens), int(completionTokens)), nil
+}
+
+// sendStreamChunk sends the chunk to the channel unless the context is done.
+// It returns false if the chunk could not be delivered.
+func sendStreamChunk(ctx context.Context, chunks chan<- schema.StreamChunk, chunk schema.StreamChunk) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case chunks <- chunk:
+		return true
+	}
+}
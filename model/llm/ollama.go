@@ -5,6 +5,7 @@ import (
 	"errors"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/hupe1980/golc"
 	"github.com/hupe1980/golc/callback"
@@ -47,6 +48,8 @@ type OllamaOptions struct {
 	FrequencyPenalty float32 `map:"frequency_penalty,omitempty"`
 	// Stream indicates whether to stream the results or not.
 	Stream bool `map:"stream,omitempty"`
+	// Timeout is the maximum duration of a request to the API. A value <= 0 disables the timeout.
+	Timeout time.Duration `map:"-"`
 }
 
 // Ollama is a struct representing the Ollama generative model.
@@ -92,6 +95,9 @@ func NewOllama(client OllamaClient, optFns ...func(o *OllamaOptions)) (*Ollama,
 
 // Generate generates text based on the provided prompt and options.
 func (l *Ollama) Generate(ctx context.Context, prompt string, optFns ...func(o *schema.GenerateOptions)) (*schema.ModelResult, error) {
+	ctx, cancel := util.ContextWithTimeout(ctx, l.opts.Timeout)
+	defer cancel()
+
 	opts := schema.GenerateOptions{
 		CallbackManger: &callback.NoopManager{},
 	}
@@ -114,7 +120,10 @@ func (l *Ollama) Generate(ctx context.Context, prompt string, optFns ...func(o *
 		},
 	}
 
-	var text string
+	var (
+		text       string
+		tokenUsage schema.TokenUsage
+	)
 
 	if l.opts.Stream {
 		req.Stream = util.PTR(true)
@@ -151,10 +160,9 @@ func (l *Ollama) Generate(ctx context.Context, prompt string, optFns ...func(o *
 					}
 
 					tokens = append(tokens, res.Response)
+				} else {
+					tokenUsage = schema.NewTokenUsage(res.PromptEvalCount, res.EvalCount)
 				}
-				// else {
-				// 	// TODO Metrics, EvalCount, ... -> LLMOutput?
-				// }
 			}
 
 			text = strings.Join(tokens, "")
@@ -166,12 +174,14 @@ func (l *Ollama) Generate(ctx context.Context, prompt string, optFns ...func(o *
 		}
 
 		text = res.Response
+		tokenUsage = schema.NewTokenUsage(res.PromptEvalCount, res.EvalCount)
 	}
 
 	return &schema.ModelResult{
 		Generations: []schema.Generation{{Text: text}},
-		LLMOutput:   map[string]any{
-			//"Done": res.Done,
+		LLMOutput: map[string]any{
+			"ModelName":  l.opts.ModelName,
+			"TokenUsage": tokenUsage,
 		},
 	}, nil
 }
@@ -5,18 +5,19 @@ import (
 	"errors"
 	"io"
 	"strings"
+	"time"
 
-	"github.com/avast/retry-go"
 	"github.com/hupe1980/golc"
 	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/internal/retry"
 	"github.com/hupe1980/golc/internal/util"
 	"github.com/hupe1980/golc/schema"
 	"github.com/hupe1980/golc/tokenizer"
 	"github.com/sashabaranov/go-openai"
 )
 
-// Compile time check to ensure OpenAI satisfies the LLM interface.
-var _ schema.LLM = (*OpenAI)(nil)
+// Compile time check to ensure OpenAI satisfies the StreamingLLM interface.
+var _ schema.StreamingLLM = (*OpenAI)(nil)
 
 // OpenAIClient represents the interface for interacting with the OpenAI API.
 type OpenAIClient interface {
@@ -60,6 +61,8 @@ type OpenAIOptions struct {
 	BaseURL string `map:"base_url,omitempty"`
 	// OrgID is the organization ID for accessing the OpenAI service.
 	OrgID string `map:"org_id,omitempty"`
+	// Timeout is the maximum duration of a request to the API. A value <= 0 disables the timeout.
+	Timeout time.Duration `map:"-"`
 }
 
 var DefaultOpenAIOptions = OpenAIOptions{
@@ -103,6 +106,8 @@ func NewOpenAI(apiKey string, optFns ...func(o *OpenAIOptions)) (*OpenAI, error)
 		config.OrgID = opts.OrgID
 	}
 
+	config.HTTPClient = retry.NewHTTPClient(config.HTTPClient)
+
 	client := openai.NewClientWithConfig(config)
 
 	return NewOpenAIFromClient(client, optFns...)
@@ -129,6 +134,9 @@ func NewOpenAIFromClient(client OpenAIClient, optFns ...func(o *OpenAIOptions))
 
 // Generate generates text based on the provided prompt and options.
 func (l *OpenAI) Generate(ctx context.Context, prompt string, optFns ...func(o *schema.GenerateOptions)) (*schema.ModelResult, error) {
+	ctx, cancel := util.ContextWithTimeout(ctx, l.opts.Timeout)
+	defer cancel()
+
 	opts := schema.GenerateOptions{
 		CallbackManger: &callback.NoopManager{},
 	}
@@ -138,19 +146,9 @@ func (l *OpenAI) Generate(ctx context.Context, prompt string, optFns ...func(o *
 	}
 
 	choices := []openai.CompletionChoice{}
-	tokenUsage := make(map[string]int)
+	tokenUsage := schema.TokenUsage{}
 
-	completionRequest := openai.CompletionRequest{
-		Prompt:           prompt,
-		Model:            l.opts.ModelName,
-		Temperature:      l.opts.Temperature,
-		MaxTokens:        l.opts.MaxTokens,
-		TopP:             l.opts.TopP,
-		PresencePenalty:  l.opts.PresencePenalty,
-		FrequencyPenalty: l.opts.FrequencyPenalty,
-		N:                l.opts.N,
-		Stop:             opts.Stop,
-	}
+	completionRequest := l.newCompletionRequest(prompt, opts)
 
 	if l.opts.Stream {
 		completionRequest.Stream = true
@@ -162,7 +160,8 @@ func (l *OpenAI) Generate(ctx context.Context, prompt string, optFns ...func(o *
 
 		defer stream.Close()
 
-		tokens := []string{}
+		// With n > 1 the chunks of all completions are interleaved and identified by their index.
+		var tokens [][]string
 
 	streamProcessing:
 		for {
@@ -179,19 +178,35 @@ func (l *OpenAI) Generate(ctx context.Context, prompt string, optFns ...func(o *
 					return nil, err
 				}
 
-				if err := opts.CallbackManger.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{
-					Token: res.Choices[0].Text,
-				}); err != nil {
-					return nil, err
-				}
+				for _, c := range res.Choices {
+					if err := opts.CallbackManger.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{
+						Token: c.Text,
+					}); err != nil {
+						return nil, err
+					}
+
+					for len(choices) <= c.Index {
+						choices = append(choices, openai.CompletionChoice{Index: len(choices)})
+						tokens = append(tokens, nil)
+					}
+
+					tokens[c.Index] = append(tokens[c.Index], c.Text)
 
-				tokens = append(tokens, res.Choices[0].Text)
+					if c.FinishReason != "" {
+						choices[c.Index].FinishReason = c.FinishReason
+					}
+				}
 			}
 		}
 
-		choices = append(choices, openai.CompletionChoice{
-			Text: strings.Join(tokens, ""),
-		})
+		if len(choices) == 0 {
+			choices = append(choices, openai.CompletionChoice{})
+			tokens = append(tokens, nil)
+		}
+
+		for i := range choices {
+			choices[i].Text = strings.Join(tokens[i], "")
+		}
 	} else {
 		res, err := l.createCompletionWithRetry(ctx, completionRequest)
 		if err != nil {
@@ -200,9 +215,11 @@ func (l *OpenAI) Generate(ctx context.Context, prompt string, optFns ...func(o *
 
 		choices = res.Choices
 
-		tokenUsage["CompletionTokens"] += res.Usage.CompletionTokens
-		tokenUsage["PromptTokens"] += res.Usage.PromptTokens
-		tokenUsage["TotalTokens"] += res.Usage.TotalTokens
+		tokenUsage = schema.TokenUsage{
+			PromptTokens:     res.Usage.PromptTokens,
+			CompletionTokens: res.Usage.CompletionTokens,
+			TotalTokens:      res.Usage.TotalTokens,
+		}
 	}
 
 	generations := util.Map(choices, func(choice openai.CompletionChoice, _ int) schema.Generation {
@@ -224,42 +241,89 @@ func (l *OpenAI) Generate(ctx context.Context, prompt string, optFns ...func(o *
 	}, nil
 }
 
-func (l *OpenAI) createCompletionWithRetry(ctx context.Context, request openai.CompletionRequest) (openai.CompletionResponse, error) {
-	retryOpts := []retry.Option{
-		retry.Attempts(l.opts.MaxRetries),
-		retry.DelayType(retry.FixedDelay),
-		retry.RetryIf(func(err error) bool {
-			e := &openai.APIError{}
-			if errors.As(err, &e) {
-				switch e.HTTPStatusCode {
-				case 429, 500:
-					return true
-				default:
-					return false
-				}
-			}
+// Stream generates text based on the provided prompt and sends the tokens to the returned channel.
+func (l *OpenAI) Stream(ctx context.Context, prompt string, optFns ...func(o *schema.GenerateOptions)) (<-chan schema.StreamChunk, error) {
+	ctx, cancel := util.ContextWithTimeout(ctx, l.opts.Timeout)
 
-			return false
-		}),
+	opts := schema.GenerateOptions{
+		CallbackManger: &callback.NoopManager{},
 	}
 
-	var res openai.CompletionResponse
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	completionRequest := l.newCompletionRequest(prompt, opts)
+	completionRequest.Stream = true
 
-	err := retry.Do(
-		func() error {
-			r, cErr := l.client.CreateCompletion(ctx, request)
-			if cErr != nil {
-				return cErr
+	stream, err := l.client.CreateCompletionStream(ctx, completionRequest)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	chunks := make(chan schema.StreamChunk)
+
+	go func() {
+		defer cancel()
+		defer close(chunks)
+		defer stream.Close()
+
+		for {
+			res, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return
 			}
 
-			res = r
+			if err != nil {
+				sendStreamChunk(ctx, chunks, schema.StreamChunk{Err: err})
+				return
+			}
 
-			return nil
-		},
-		retryOpts...,
-	)
+			if len(res.Choices) == 0 {
+				continue
+			}
+
+			if err := opts.CallbackManger.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{
+				Token: res.Choices[0].Text,
+			}); err != nil {
+				sendStreamChunk(ctx, chunks, schema.StreamChunk{Err: err})
+				return
+			}
+
+			if !sendStreamChunk(ctx, chunks, schema.StreamChunk{
+				Token:        res.Choices[0].Text,
+				FinishReason: res.Choices[0].FinishReason,
+			}) {
+				return
+			}
+		}
+	}()
+
+	return chunks, nil
+}
 
-	return res, err
+// newCompletionRequest creates a completion request for the provided prompt and options.
+func (l *OpenAI) newCompletionRequest(prompt string, opts schema.GenerateOptions) openai.CompletionRequest {
+	return openai.CompletionRequest{
+		Prompt:           prompt,
+		Model:            l.opts.ModelName,
+		Temperature:      l.opts.Temperature,
+		MaxTokens:        l.opts.MaxTokens,
+		TopP:             l.opts.TopP,
+		PresencePenalty:  l.opts.PresencePenalty,
+		FrequencyPenalty: l.opts.FrequencyPenalty,
+		N:                l.opts.N,
+		Stop:             opts.Stop,
+	}
+}
+
+func (l *OpenAI) createCompletionWithRetry(ctx context.Context, request openai.CompletionRequest) (openai.CompletionResponse, error) {
+	policy := retry.NewPolicy(l.opts.MaxRetries, retry.IsRetryableOpenAIError)
+
+	return retry.Do(ctx, policy, func(ctx context.Context) (openai.CompletionResponse, error) {
+		return l.client.CreateCompletion(ctx, request)
+	})
 }
 
 // Type returns the type of the model.
@@ -47,10 +47,10 @@ func TestOpenAI_Generate(t *testing.T) {
 			}},
 			LLMOutput: map[string]any{
 				"ModelName": "gpt-3.5-turbo-instruct",
-				"TokenUsage": map[string]int{
-					"CompletionTokens": 10,
-					"PromptTokens":     10,
-					"TotalTokens":      20,
+				"TokenUsage": schema.TokenUsage{
+					PromptTokens:     10,
+					CompletionTokens: 10,
+					TotalTokens:      20,
 				},
 			},
 		}
@@ -0,0 +1,293 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/integration/replicate"
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tokenizer"
+)
+
+// Compile time check to ensure Replicate satisfies the LLM interface.
+var _ schema.LLM = (*Replicate)(nil)
+
+// Compile time check to ensure Replicate satisfies the StreamingLLM interface.
+var _ schema.StreamingLLM = (*Replicate)(nil)
+
+// ReplicateClient is an interface for the Replicate prediction client.
+type ReplicateClient interface {
+	// CreatePrediction creates a prediction for the model.
+	CreatePrediction(ctx context.Context, model string, req *replicate.PredictionRequest) (*replicate.Prediction, error)
+	// WaitForPrediction polls the prediction until it has terminated.
+	WaitForPrediction(ctx context.Context, prediction *replicate.Prediction) (*replicate.Prediction, error)
+	// StreamPrediction opens the output stream of the prediction.
+	StreamPrediction(ctx context.Context, prediction *replicate.Prediction) (*replicate.PredictionStream, error)
+}
+
+// ReplicateOptions contains options for the Replicate model.
+type ReplicateOptions struct {
+	// CallbackOptions specify options for handling callbacks during text generation.
+	*schema.CallbackOptions `map:"-"`
+	// Tokenizer represents the tokenizer to be used with the LLM model.
+	schema.Tokenizer `map:"-"`
+	// ModelInput contains the additional input parameters of the model, e.g. temperature or max_new_tokens.
+	ModelInput map[string]any `map:"model_input,omitempty"`
+	// PromptKey is the name of the input parameter the prompt is passed in.
+	PromptKey string `map:"prompt_key,omitempty"`
+	// Stream indicates whether to stream the results or not.
+	Stream bool `map:"stream,omitempty"`
+}
+
+// Replicate represents a language model hosted on Replicate.
+type Replicate struct {
+	schema.Tokenizer
+	client ReplicateClient
+	model  string
+	opts   ReplicateOptions
+}
+
+// NewReplicate creates a new instance of the Replicate model. The model is either an "owner/name" identifier
+// of an official model or an "owner/name:version" identifier of a specific model version.
+func NewReplicate(apiToken, model string, optFns ...func(o *ReplicateOptions)) (*Replicate, error) {
+	client := replicate.New(apiToken)
+	return NewReplicateFromClient(client, model, optFns...)
+}
+
+// NewReplicateFromClient creates a new instance of the Replicate model with the provided client and options.
+func NewReplicateFromClient(client ReplicateClient, model string, optFns ...func(o *ReplicateOptions)) (*Replicate, error) {
+	if _, _, _, err := replicate.ParseModel(model); err != nil {
+		return nil, err
+	}
+
+	opts := ReplicateOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		ModelInput: make(map[string]any),
+		PromptKey:  "prompt",
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.Tokenizer == nil {
+		var tErr error
+
+		opts.Tokenizer, tErr = tokenizer.NewGPT2()
+		if tErr != nil {
+			return nil, tErr
+		}
+	}
+
+	return &Replicate{
+		Tokenizer: opts.Tokenizer,
+		client:    client,
+		model:     model,
+		opts:      opts,
+	}, nil
+}
+
+// Generate generates text based on the provided prompt and options.
+func (l *Replicate) Generate(ctx context.Context, prompt string, optFns ...func(o *schema.GenerateOptions)) (*schema.ModelResult, error) {
+	opts := schema.GenerateOptions{
+		CallbackManger: &callback.NoopManager{},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	prediction, err := l.client.CreatePrediction(ctx, l.model, l.newPredictionRequest(prompt, l.opts.Stream, opts))
+	if err != nil {
+		return nil, err
+	}
+
+	var text string
+
+	if l.opts.Stream {
+		tokens := []string{}
+
+		if err := l.readPredictionStream(ctx, prediction, func(token string) error {
+			if err := opts.CallbackManger.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{
+				Token: token,
+			}); err != nil {
+				return err
+			}
+
+			tokens = append(tokens, token)
+
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+
+		text = strings.Join(tokens, "")
+	} else {
+		prediction, err = l.client.WaitForPrediction(ctx, prediction)
+		if err != nil {
+			return nil, err
+		}
+
+		if prediction.Status != replicate.PredictionStatusSucceeded {
+			return nil, fmt.Errorf("replicate prediction %s %s: %v", prediction.ID, prediction.Status, prediction.Error)
+		}
+
+		text, err = decodeReplicateOutput(prediction.Output)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	llmOutput := map[string]any{
+		"PredictionID": prediction.ID,
+	}
+
+	// Language models on replicate report the token counts in the metrics of a completed prediction.
+	if inputTokens, ok := prediction.Metrics["input_token_count"]; ok {
+		llmOutput["TokenUsage"] = schema.NewTokenUsage(int(inputTokens), int(prediction.Metrics["output_token_count"]))
+	}
+
+	return &schema.ModelResult{
+		Generations: []schema.Generation{{Text: text}},
+		LLMOutput:   llmOutput,
+	}, nil
+}
+
+// Stream streams the tokens generated for the provided prompt using the stream url of the prediction.
+func (l *Replicate) Stream(ctx context.Context, prompt string, optFns ...func(o *schema.GenerateOptions)) (<-chan schema.StreamChunk, error) {
+	opts := schema.GenerateOptions{
+		CallbackManger: &callback.NoopManager{},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	prediction, err := l.client.CreatePrediction(ctx, l.model, l.newPredictionRequest(prompt, true, opts))
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make(chan schema.StreamChunk)
+
+	go func() {
+		defer close(chunks)
+
+		if err := l.readPredictionStream(ctx, prediction, func(token string) error {
+			if err := opts.CallbackManger.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{
+				Token: token,
+			}); err != nil {
+				return err
+			}
+
+			if !sendStreamChunk(ctx, chunks, schema.StreamChunk{Token: token}) {
+				return ctx.Err()
+			}
+
+			return nil
+		}); err != nil {
+			sendStreamChunk(ctx, chunks, schema.StreamChunk{Err: err})
+		}
+	}()
+
+	return chunks, nil
+}
+
+// readPredictionStream reads the output events of the prediction stream and passes the tokens to onToken.
+func (l *Replicate) readPredictionStream(ctx context.Context, prediction *replicate.Prediction, onToken func(token string) error) error {
+	stream, err := l.client.StreamPrediction(ctx, prediction)
+	if err != nil {
+		return err
+	}
+
+	defer stream.Close()
+
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := onToken(event.Data); err != nil {
+			return err
+		}
+	}
+}
+
+// newPredictionRequest creates a prediction request for the provided prompt and options.
+func (l *Replicate) newPredictionRequest(prompt string, stream bool, opts schema.GenerateOptions) *replicate.PredictionRequest {
+	input := util.CopyMap(l.opts.ModelInput)
+	input[l.opts.PromptKey] = prompt
+
+	if len(opts.Stop) > 0 {
+		input["stop_sequences"] = strings.Join(opts.Stop, ",")
+	}
+
+	return &replicate.PredictionRequest{
+		Input:  input,
+		Stream: stream,
+	}
+}
+
+// Type returns the type of the model.
+func (l *Replicate) Type() string {
+	return "llm.Replicate"
+}
+
+// Verbose returns the verbosity setting of the model.
+func (l *Replicate) Verbose() bool {
+	return l.opts.Verbose
+}
+
+// Callbacks returns the registered callbacks of the model.
+func (l *Replicate) Callbacks() []schema.Callback {
+	return l.opts.Callbacks
+}
+
+// InvocationParams returns the parameters used in the model invocation.
+func (l *Replicate) InvocationParams() map[string]any {
+	params := util.StructToMap(l.opts)
+
+	owner, name, version, _ := replicate.ParseModel(l.model)
+
+	params["model_name"] = fmt.Sprintf("%s/%s", owner, name)
+
+	if version != "" {
+		params["version"] = version
+	}
+
+	return params
+}
+
+// decodeReplicateOutput decodes the output of a language model prediction, which is either
+// a list of tokens or a single string.
+func decodeReplicateOutput(output json.RawMessage) (string, error) {
+	if len(output) == 0 {
+		return "", nil
+	}
+
+	tokens := []string{}
+	if err := json.Unmarshal(output, &tokens); err == nil {
+		return strings.Join(tokens, ""), nil
+	}
+
+	var text string
+	if err := json.Unmarshal(output, &text); err != nil {
+		return "", fmt.Errorf("unexpected replicate output: %s", string(output))
+	}
+
+	return text, nil
+}
@@ -0,0 +1,167 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/hupe1980/golc/integration/replicate"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplicate(t *testing.T) {
+	t.Run("Generate", func(t *testing.T) {
+		t.Run("Success", func(t *testing.T) {
+			mockClient := &mockReplicateClient{
+				CreatePredictionFunc: func(ctx context.Context, model string, req *replicate.PredictionRequest) (*replicate.Prediction, error) {
+					assert.Equal(t, "meta/llama-2-7b-chat:abc123", model)
+					assert.Equal(t, "Hello", req.Input["prompt"])
+					assert.Equal(t, 0.5, req.Input["temperature"])
+					assert.Equal(t, "\n,Observation:", req.Input["stop_sequences"])
+					assert.False(t, req.Stream)
+
+					return &replicate.Prediction{ID: "p1", Status: replicate.PredictionStatusStarting}, nil
+				},
+				WaitForPredictionFunc: func(ctx context.Context, prediction *replicate.Prediction) (*replicate.Prediction, error) {
+					return &replicate.Prediction{
+						ID:     prediction.ID,
+						Status: replicate.PredictionStatusSucceeded,
+						Output: json.RawMessage(`["I can"," help you."]`),
+					}, nil
+				},
+			}
+
+			model, err := NewReplicateFromClient(mockClient, "meta/llama-2-7b-chat:abc123", func(o *ReplicateOptions) {
+				o.ModelInput = map[string]any{"temperature": 0.5}
+			})
+			assert.NoError(t, err)
+
+			result, err := model.Generate(context.Background(), "Hello", func(o *schema.GenerateOptions) {
+				o.Stop = []string{"\n", "Observation:"}
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, "I can help you.", result.Generations[0].Text)
+			assert.Equal(t, "p1", result.LLMOutput["PredictionID"])
+		})
+
+		t.Run("Failed prediction", func(t *testing.T) {
+			mockClient := &mockReplicateClient{
+				CreatePredictionFunc: func(ctx context.Context, model string, req *replicate.PredictionRequest) (*replicate.Prediction, error) {
+					return &replicate.Prediction{ID: "p1", Status: replicate.PredictionStatusStarting}, nil
+				},
+				WaitForPredictionFunc: func(ctx context.Context, prediction *replicate.Prediction) (*replicate.Prediction, error) {
+					return &replicate.Prediction{ID: "p1", Status: replicate.PredictionStatusFailed, Error: "out of memory"}, nil
+				},
+			}
+
+			model, err := NewReplicateFromClient(mockClient, "meta/meta-llama-3-8b-instruct")
+			assert.NoError(t, err)
+
+			result, err := model.Generate(context.Background(), "Hello")
+			assert.EqualError(t, err, "replicate prediction p1 failed: out of memory")
+			assert.Nil(t, result)
+		})
+
+		t.Run("Error", func(t *testing.T) {
+			mockClient := &mockReplicateClient{
+				CreatePredictionFunc: func(ctx context.Context, model string, req *replicate.PredictionRequest) (*replicate.Prediction, error) {
+					return nil, errors.New("replicate API error")
+				},
+			}
+
+			model, err := NewReplicateFromClient(mockClient, "meta/meta-llama-3-8b-instruct")
+			assert.NoError(t, err)
+
+			result, err := model.Generate(context.Background(), "Hello")
+			assert.Error(t, err)
+			assert.Nil(t, result)
+		})
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		mockClient := &mockReplicateClient{
+			CreatePredictionFunc: func(ctx context.Context, model string, req *replicate.PredictionRequest) (*replicate.Prediction, error) {
+				assert.True(t, req.Stream)
+				return &replicate.Prediction{ID: "p1", Status: replicate.PredictionStatusStarting}, nil
+			},
+			StreamPredictionFunc: func(ctx context.Context, prediction *replicate.Prediction) (*replicate.PredictionStream, error) {
+				buf := &bytes.Buffer{}
+				fmt.Fprint(buf, "event: output\ndata: Hello\n\nevent: output\ndata:  world\n\nevent: done\ndata: {}\n\n")
+
+				return replicate.NewPredictionStream(io.NopCloser(buf)), nil
+			},
+		}
+
+		model, err := NewReplicateFromClient(mockClient, "meta/meta-llama-3-8b-instruct")
+		assert.NoError(t, err)
+
+		stream, err := model.Stream(context.Background(), "Hello")
+		assert.NoError(t, err)
+
+		tokens := []string{}
+		for chunk := range stream {
+			assert.NoError(t, chunk.Err)
+			tokens = append(tokens, chunk.Token)
+		}
+
+		assert.Equal(t, []string{"Hello", " world"}, tokens)
+	})
+
+	t.Run("Invalid model", func(t *testing.T) {
+		_, err := NewReplicateFromClient(&mockReplicateClient{}, "llama")
+		assert.Error(t, err)
+	})
+
+	t.Run("InvocationParams", func(t *testing.T) {
+		model, err := NewReplicateFromClient(&mockReplicateClient{}, "meta/llama-2-7b-chat:abc123")
+		assert.NoError(t, err)
+
+		params := model.InvocationParams()
+		assert.Equal(t, "meta/llama-2-7b-chat", params["model_name"])
+		assert.Equal(t, "abc123", params["version"])
+		assert.Equal(t, "prompt", params["prompt_key"])
+	})
+
+	t.Run("Type", func(t *testing.T) {
+		model, err := NewReplicateFromClient(&mockReplicateClient{}, "meta/meta-llama-3-8b-instruct")
+		assert.NoError(t, err)
+		assert.Equal(t, "llm.Replicate", model.Type())
+	})
+}
+
+func TestDecodeReplicateOutput(t *testing.T) {
+	text, err := decodeReplicateOutput(json.RawMessage(`["Hello"," world"]`))
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello world", text)
+
+	text, err = decodeReplicateOutput(json.RawMessage(`"Hello world"`))
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello world", text)
+
+	_, err = decodeReplicateOutput(json.RawMessage(`{"text":"Hello"}`))
+	assert.Error(t, err)
+}
+
+// mockReplicateClient is a mock implementation of the ReplicateClient interface for testing.
+type mockReplicateClient struct {
+	CreatePredictionFunc  func(ctx context.Context, model string, req *replicate.PredictionRequest) (*replicate.Prediction, error)
+	WaitForPredictionFunc func(ctx context.Context, prediction *replicate.Prediction) (*replicate.Prediction, error)
+	StreamPredictionFunc  func(ctx context.Context, prediction *replicate.Prediction) (*replicate.PredictionStream, error)
+}
+
+func (m *mockReplicateClient) CreatePrediction(ctx context.Context, model string, req *replicate.PredictionRequest) (*replicate.Prediction, error) {
+	return m.CreatePredictionFunc(ctx, model, req)
+}
+
+func (m *mockReplicateClient) WaitForPrediction(ctx context.Context, prediction *replicate.Prediction) (*replicate.Prediction, error) {
+	return m.WaitForPredictionFunc(ctx, prediction)
+}
+
+func (m *mockReplicateClient) StreamPrediction(ctx context.Context, prediction *replicate.Prediction) (*replicate.PredictionStream, error) {
+	return m.StreamPredictionFunc(ctx, prediction)
+}
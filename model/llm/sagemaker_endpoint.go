@@ -149,11 +149,19 @@ func (l *SagemakerEndpoint) Generate(ctx context.Context, prompt string, optFns
 		return nil, err
 	}
 
+	// Endpoints do not report the token usage, so it is estimated with the tokenizer.
+	tokenUsage, err := estimateTokenUsage(ctx, l.Tokenizer, prompt, text)
+	if err != nil {
+		return nil, err
+	}
+
 	return &schema.ModelResult{
 		Generations: []schema.Generation{{
 			Text: text,
 		}},
-		LLMOutput: map[string]any{},
+		LLMOutput: map[string]any{
+			"TokenUsage": tokenUsage,
+		},
 	}, nil
 }
 
@@ -3,10 +3,12 @@ package llm
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sagemakerruntime"
+	"github.com/hupe1980/golc/schema"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -18,7 +20,9 @@ func TestSagemakerEndpoint(t *testing.T) {
 
 			contentHandler := NewContentHandler("text/plain", "text/plain", mockTransformer)
 
-			endpoint, err := NewSagemakerEndpoint(mockSagemakerClient, "my-endpoint", contentHandler)
+			endpoint, err := NewSagemakerEndpoint(mockSagemakerClient, "my-endpoint", contentHandler, func(o *SagemakerEndpointOptions) {
+				o.Tokenizer = &mockTokenizer{}
+			})
 			assert.NoError(t, err)
 
 			expectedPrompt := "Hello, world!"
@@ -38,7 +42,10 @@ func TestSagemakerEndpoint(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Len(t, result.Generations, 1)
 			assert.Equal(t, expectedOutput, result.Generations[0].Text)
-			assert.Empty(t, result.LLMOutput)
+
+			tokenUsage, ok := result.TokenUsage()
+			assert.True(t, ok)
+			assert.Equal(t, schema.NewTokenUsage(2, 2), tokenUsage)
 		})
 
 		t.Run("Failed content transformation", func(t *testing.T) {
@@ -141,3 +148,14 @@ type mockSagemakerClient struct {
 func (m *mockSagemakerClient) InvokeEndpoint(ctx context.Context, params *sagemakerruntime.InvokeEndpointInput, optFns ...func(*sagemakerruntime.Options)) (*sagemakerruntime.InvokeEndpointOutput, error) {
 	return m.InvokeEndpointFunc(ctx, params, optFns...)
 }
+
+// mockTokenizer is a mock implementation of the Tokenizer interface counting the words of a text.
+type mockTokenizer struct{}
+
+func (m *mockTokenizer) GetNumTokens(ctx context.Context, text string) (uint, error) {
+	return uint(len(strings.Fields(text))), nil
+}
+
+func (m *mockTokenizer) GetNumTokensFromMessage(ctx context.Context, messages schema.ChatMessages) (uint, error) {
+	return 0, nil
+}
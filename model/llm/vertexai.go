@@ -128,17 +128,37 @@ func (l *VertexAI) Generate(ctx context.Context, prompt string, optFns ...func(o
 		}
 	})
 
+	llmOutput := map[string]any{
+		"DeployedModelID": res.DeployedModelId,
+		"Model":           res.Model,
+		"ModelVersionID":  res.ModelVersionId,
+		"ModelName":       res.ModelDisplayName,
+	}
+
+	if tokenUsage, ok := vertexAITokenUsage(res.Metadata); ok {
+		llmOutput["TokenUsage"] = tokenUsage
+	}
+
 	return &schema.ModelResult{
 		Generations: generations,
-		LLMOutput: map[string]any{
-			"DeployedModelID": res.DeployedModelId,
-			"Model":           res.Model,
-			"ModelVersionID":  res.ModelVersionId,
-			"ModelName":       res.ModelDisplayName,
-		},
+		LLMOutput:   llmOutput,
 	}, nil
 }
 
+// vertexAITokenUsage returns the token usage of the token metadata of a prediction response.
+func vertexAITokenUsage(metadata *structpb.Value) (schema.TokenUsage, bool) {
+	tokenMetadata := metadata.GetStructValue().GetFields()["tokenMetadata"].GetStructValue()
+	if tokenMetadata == nil {
+		return schema.TokenUsage{}, false
+	}
+
+	totalTokens := func(key string) int {
+		return int(tokenMetadata.GetFields()[key].GetStructValue().GetFields()["totalTokens"].GetNumberValue())
+	}
+
+	return schema.NewTokenUsage(totalTokens("inputTokenCount"), totalTokens("outputTokenCount")), true
+}
+
 // Type returns the type of the model.
 func (l *VertexAI) Type() string {
 	return "llm.VertexAI"
@@ -14,6 +14,7 @@ type Options struct {
 	ParentRunID       string
 	Functions         []schema.FunctionDefinition
 	ForceFunctionCall bool
+	ResponseFormat    *schema.ResponseFormat
 }
 
 func GeneratePrompt(ctx context.Context, model schema.Model, promptValue schema.PromptValue, optFns ...func(o *Options)) (*schema.ModelResult, error) {
@@ -99,6 +100,7 @@ func ChatModelGenerate(ctx context.Context, model schema.ChatModel, messages sch
 		o.Stop = opts.Stop
 		o.Functions = opts.Functions
 		o.ForceFunctionCall = opts.ForceFunctionCall
+		o.ResponseFormat = opts.ResponseFormat
 	})
 	if err != nil {
 		if cbErr := rm.OnModelError(ctx, &schema.ModelErrorManagerInput{
@@ -0,0 +1,164 @@
+package model
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hupe1980/golc/ratelimit"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure RateLimitedLLM satisfies the StreamingLLM interface.
+var _ schema.StreamingLLM = (*RateLimitedLLM)(nil)
+
+// RateLimitedLLM is an LLM that limits the requests and tokens of the wrapped LLM.
+type RateLimitedLLM struct {
+	schema.LLM
+	limiter *ratelimit.Limiter
+}
+
+// WithRateLimit wraps the LLM, so that every generation waits for the limiter and the consumed tokens
+// are taken from its token quota. Share a limiter, e.g. with ratelimit.Shared, between all models
+// using the same API key. Wrap the rate limited LLM with WithCache, so that cache hits are not limited.
+func WithRateLimit(llm schema.LLM, limiter *ratelimit.Limiter) *RateLimitedLLM {
+	return &RateLimitedLLM{
+		LLM:     llm,
+		limiter: limiter,
+	}
+}
+
+// Generate waits for the limiter and generates the result with the wrapped LLM.
+func (l *RateLimitedLLM) Generate(ctx context.Context, prompt string, optFns ...func(o *schema.GenerateOptions)) (*schema.ModelResult, error) {
+	return rateLimitedGenerate(ctx, l.limiter, func() (*schema.ModelResult, error) {
+		return l.LLM.Generate(ctx, prompt, optFns...)
+	})
+}
+
+// Stream waits for the limiter and streams the tokens of the wrapped LLM. The token usage reported with
+// the stream is taken from the token quota. If the wrapped LLM does not implement schema.StreamingLLM,
+// the complete generation is sent as a single chunk.
+func (l *RateLimitedLLM) Stream(ctx context.Context, prompt string, optFns ...func(o *schema.GenerateOptions)) (<-chan schema.StreamChunk, error) {
+	sm, ok := l.LLM.(schema.StreamingLLM)
+	if !ok {
+		result, err := l.Generate(ctx, prompt, optFns...)
+		if err != nil {
+			return nil, err
+		}
+
+		return resultToStream(result), nil
+	}
+
+	return rateLimitedStream(ctx, l.limiter, func() (<-chan schema.StreamChunk, error) {
+		return sm.Stream(ctx, prompt, optFns...)
+	})
+}
+
+// Compile time check to ensure RateLimitedChatModel satisfies the StreamingChatModel and ToolCallingChatModel interfaces.
+var (
+	_ schema.StreamingChatModel   = (*RateLimitedChatModel)(nil)
+	_ schema.ToolCallingChatModel = (*RateLimitedChatModel)(nil)
+)
+
+// RateLimitedChatModel is a chat model that limits the requests and tokens of the wrapped chat model.
+type RateLimitedChatModel struct {
+	schema.ChatModel
+	limiter *ratelimit.Limiter
+}
+
+// WithChatModelRateLimit wraps the chat model, so that every generation waits for the limiter and the
+// consumed tokens are taken from its token quota.
+func WithChatModelRateLimit(chatModel schema.ChatModel, limiter *ratelimit.Limiter) *RateLimitedChatModel {
+	return &RateLimitedChatModel{
+		ChatModel: chatModel,
+		limiter:   limiter,
+	}
+}
+
+// Generate waits for the limiter and generates the result with the wrapped chat model.
+func (cm *RateLimitedChatModel) Generate(ctx context.Context, messages schema.ChatMessages, optFns ...func(o *schema.GenerateOptions)) (*schema.ModelResult, error) {
+	return rateLimitedGenerate(ctx, cm.limiter, func() (*schema.ModelResult, error) {
+		return cm.ChatModel.Generate(ctx, messages, optFns...)
+	})
+}
+
+// Stream waits for the limiter and streams the tokens of the wrapped chat model. The token usage reported
+// with the stream is taken from the token quota. If the wrapped chat model does not implement
+// schema.StreamingChatModel, the complete generation is sent as a single chunk.
+func (cm *RateLimitedChatModel) Stream(ctx context.Context, messages schema.ChatMessages, optFns ...func(o *schema.GenerateOptions)) (<-chan schema.StreamChunk, error) {
+	sm, ok := cm.ChatModel.(schema.StreamingChatModel)
+	if !ok {
+		result, err := cm.Generate(ctx, messages, optFns...)
+		if err != nil {
+			return nil, err
+		}
+
+		return resultToStream(result), nil
+	}
+
+	return rateLimitedStream(ctx, cm.limiter, func() (<-chan schema.StreamChunk, error) {
+		return sm.Stream(ctx, messages, optFns...)
+	})
+}
+
+// BindTools binds the tools to the wrapped chat model and returns the bound model limited by the same limiter.
+func (cm *RateLimitedChatModel) BindTools(tools []schema.Tool) (schema.ToolCallingChatModel, error) {
+	tc, ok := cm.ChatModel.(schema.ToolCallingChatModel)
+	if !ok {
+		return nil, fmt.Errorf("chat model %s does not support tool calling", cm.ChatModel.Type())
+	}
+
+	bound, err := tc.BindTools(tools)
+	if err != nil {
+		return nil, err
+	}
+
+	return WithChatModelRateLimit(bound, cm.limiter), nil
+}
+
+func rateLimitedGenerate(ctx context.Context, limiter *ratelimit.Limiter, generate func() (*schema.ModelResult, error)) (*schema.ModelResult, error) {
+	if err := limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	result, err := generate()
+	if err != nil {
+		return nil, err
+	}
+
+	if tokenUsage, ok := result.TokenUsage(); ok {
+		limiter.ConsumeTokens(tokenUsage.TotalTokens)
+	}
+
+	return result, nil
+}
+
+func rateLimitedStream(ctx context.Context, limiter *ratelimit.Limiter, stream func() (<-chan schema.StreamChunk, error)) (<-chan schema.StreamChunk, error) {
+	if err := limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	upstream, err := stream()
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make(chan schema.StreamChunk)
+
+	go func() {
+		defer close(chunks)
+
+		for chunk := range upstream {
+			if chunk.TokenUsage != nil {
+				limiter.ConsumeTokens(chunk.TokenUsage.TotalTokens)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case chunks <- chunk:
+			}
+		}
+	}()
+
+	return chunks, nil
+}
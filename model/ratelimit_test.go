@@ -0,0 +1,89 @@
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hupe1980/golc/model/chatmodel"
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/ratelimit"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	fake := llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+		return &schema.ModelResult{
+			Generations: []schema.Generation{{Text: "Hello"}},
+			LLMOutput:   map[string]any{"TokenUsage": schema.NewTokenUsage(600, 0)},
+		}, nil
+	})
+
+	limiter := ratelimit.New(func(o *ratelimit.Options) {
+		o.TokensPerMinute = 500
+	})
+
+	limited := WithRateLimit(fake, limiter)
+
+	result, err := LLMGenerate(context.Background(), limited, "Hi")
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", result.Generations[0].Text)
+
+	// The token quota is exceeded, so the next generation has to wait.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = LLMGenerate(ctx, limited, "Hi")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWithChatModelRateLimit(t *testing.T) {
+	fake := &toolCallingFake{Fake: chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+		return &schema.ModelResult{
+			Generations: []schema.Generation{{Text: "Hello there", Message: schema.NewAIChatMessage("Hello there")}},
+			LLMOutput:   map[string]any{"TokenUsage": schema.NewTokenUsage(600, 0)},
+		}, nil
+	})}
+
+	messages := schema.ChatMessages{schema.NewHumanChatMessage("Hi")}
+
+	t.Run("Stream", func(t *testing.T) {
+		limited := WithChatModelRateLimit(fake, ratelimit.New(func(o *ratelimit.Options) {
+			o.TokensPerMinute = 500
+		}))
+
+		stream, err := ChatModelStream(context.Background(), limited, messages)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Hello ", "there"}, collectTokens(t, stream))
+
+		// The token usage of the stream exceeds the token quota, so the next stream has to wait.
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err = ChatModelStream(ctx, limited, messages)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("BindTools", func(t *testing.T) {
+		limiter := ratelimit.New(func(o *ratelimit.Options) {
+			o.TokensPerMinute = 500
+		})
+
+		bound, err := WithChatModelRateLimit(fake, limiter).BindTools([]schema.Tool{&fakeTool{}})
+		require.NoError(t, err)
+		require.IsType(t, &RateLimitedChatModel{}, bound)
+		require.Len(t, bound.(*RateLimitedChatModel).ChatModel.(*toolCallingFake).tools, 1)
+
+		_, err = ChatModelGenerate(context.Background(), bound, messages)
+		require.NoError(t, err)
+
+		// The bound model shares the limiter.
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err = ChatModelGenerate(ctx, WithChatModelRateLimit(fake, limiter), messages)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
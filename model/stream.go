@@ -0,0 +1,240 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/schema"
+)
+
+// StreamPrompt streams the tokens generated by the model for the provided prompt value.
+// Models that do not support streaming send the complete generation as a single chunk.
+func StreamPrompt(ctx context.Context, model schema.Model, promptValue schema.PromptValue, optFns ...func(o *Options)) (<-chan schema.StreamChunk, error) {
+	if llm, ok := model.(schema.LLM); ok {
+		return LLMStream(ctx, llm, promptValue.String(), optFns...)
+	}
+
+	if cm, ok := model.(schema.ChatModel); ok {
+		return ChatModelStream(ctx, cm, promptValue.Messages(), optFns...)
+	}
+
+	return nil, fmt.Errorf("invalid model type %T", model)
+}
+
+// LLMStream streams the tokens generated by the LLM for the provided prompt.
+// If the LLM does not implement schema.StreamingLLM, the complete generation is sent as a single chunk.
+func LLMStream(ctx context.Context, model schema.LLM, prompt string, optFns ...func(o *Options)) (<-chan schema.StreamChunk, error) {
+	sm, ok := model.(schema.StreamingLLM)
+	if !ok {
+		result, err := LLMGenerate(ctx, model, prompt, optFns...)
+		if err != nil {
+			return nil, err
+		}
+
+		return resultToStream(result), nil
+	}
+
+	opts := Options{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	cm := callback.NewManager(opts.Callbacks, model.Callbacks(), model.Verbose(), func(mo *callback.ManagerOptions) {
+		mo.ParentRunID = opts.ParentRunID
+	})
+
+	rm, err := cm.OnLLMStart(ctx, &schema.LLMStartManagerInput{
+		LLMType:          model.Type(),
+		Prompt:           prompt,
+		InvocationParams: model.InvocationParams(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := sm.Stream(ctx, prompt, func(o *schema.GenerateOptions) {
+		o.CallbackManger = rm
+		o.Stop = opts.Stop
+	})
+	if err != nil {
+		if cbErr := rm.OnModelError(ctx, &schema.ModelErrorManagerInput{
+			Error: err,
+		}); cbErr != nil {
+			return nil, cbErr
+		}
+
+		return nil, err
+	}
+
+	return forwardStream(ctx, rm, stream, func(text string) schema.Generation {
+		return schema.Generation{Text: text}
+	}), nil
+}
+
+// ChatModelStream streams the tokens generated by the chat model for the provided messages.
+// If the chat model does not implement schema.StreamingChatModel, the complete generation is sent as a single chunk.
+// Streamed chunks carry text only, so tool calls or function calls requested by the model are not returned;
+// use ChatModelGenerate for tool calling.
+func ChatModelStream(ctx context.Context, model schema.ChatModel, messages schema.ChatMessages, optFns ...func(o *Options)) (<-chan schema.StreamChunk, error) {
+	sm, ok := model.(schema.StreamingChatModel)
+	if !ok {
+		result, err := ChatModelGenerate(ctx, model, messages, optFns...)
+		if err != nil {
+			return nil, err
+		}
+
+		return resultToStream(result), nil
+	}
+
+	opts := Options{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	cm := callback.NewManager(opts.Callbacks, model.Callbacks(), model.Verbose(), func(mo *callback.ManagerOptions) {
+		mo.ParentRunID = opts.ParentRunID
+	})
+
+	rm, err := cm.OnChatModelStart(ctx, &schema.ChatModelStartManagerInput{
+		ChatModelType:    model.Type(),
+		Messages:         messages,
+		InvocationParams: model.InvocationParams(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := sm.Stream(ctx, messages, func(o *schema.GenerateOptions) {
+		o.CallbackManger = rm
+		o.Stop = opts.Stop
+		o.Functions = opts.Functions
+		o.ForceFunctionCall = opts.ForceFunctionCall
+		o.ResponseFormat = opts.ResponseFormat
+	})
+	if err != nil {
+		if cbErr := rm.OnModelError(ctx, &schema.ModelErrorManagerInput{
+			Error: err,
+		}); cbErr != nil {
+			return nil, cbErr
+		}
+
+		return nil, err
+	}
+
+	return forwardStream(ctx, rm, stream, func(text string) schema.Generation {
+		return schema.Generation{
+			Text:    text,
+			Message: schema.NewAIChatMessage(text),
+		}
+	}), nil
+}
+
+// forwardStream forwards the chunks of the stream and reports the accumulated generation, the stream
+// error or the error of the context, if it is done before the stream is complete, to the callback manager.
+func forwardStream(ctx context.Context, rm schema.CallbackManagerForModelRun, stream <-chan schema.StreamChunk, toGeneration func(text string) schema.Generation) <-chan schema.StreamChunk {
+	chunks := make(chan schema.StreamChunk)
+
+	go func() {
+		defer close(chunks)
+
+		var (
+			sb           strings.Builder
+			finishReason string
+			tokenUsage   *schema.TokenUsage
+		)
+
+		for chunk := range stream {
+			if chunk.Err != nil {
+				if cbErr := rm.OnModelError(ctx, &schema.ModelErrorManagerInput{
+					Error: chunk.Err,
+				}); cbErr != nil {
+					chunk.Err = cbErr
+				}
+
+				select {
+				case <-ctx.Done():
+				case chunks <- chunk:
+				}
+
+				return
+			}
+
+			sb.WriteString(chunk.Token)
+
+			if chunk.FinishReason != "" {
+				finishReason = chunk.FinishReason
+			}
+
+			if chunk.TokenUsage != nil {
+				tokenUsage = chunk.TokenUsage
+			}
+
+			select {
+			case <-ctx.Done():
+				_ = rm.OnModelError(ctx, &schema.ModelErrorManagerInput{
+					Error: ctx.Err(),
+				})
+
+				return
+			case chunks <- chunk:
+			}
+		}
+
+		if ctx.Err() != nil {
+			_ = rm.OnModelError(ctx, &schema.ModelErrorManagerInput{
+				Error: ctx.Err(),
+			})
+
+			return
+		}
+
+		generation := toGeneration(sb.String())
+		generation.Info = map[string]any{
+			"FinishReason": finishReason,
+		}
+
+		llmOutput := map[string]any{}
+		if tokenUsage != nil {
+			llmOutput["TokenUsage"] = *tokenUsage
+		}
+
+		if err := rm.OnModelEnd(ctx, &schema.ModelEndManagerInput{
+			Result: &schema.ModelResult{
+				Generations: []schema.Generation{generation},
+				LLMOutput:   llmOutput,
+			},
+		}); err != nil {
+			select {
+			case <-ctx.Done():
+			case chunks <- schema.StreamChunk{Err: err}:
+			}
+		}
+	}()
+
+	return chunks
+}
+
+// resultToStream sends the first generation of the result as a single chunk.
+func resultToStream(result *schema.ModelResult) <-chan schema.StreamChunk {
+	chunks := make(chan schema.StreamChunk, 1)
+
+	if len(result.Generations) > 0 {
+		chunk := schema.StreamChunk{
+			Token: result.Generations[0].Text,
+		}
+
+		if reason, ok := result.Generations[0].Info["FinishReason"].(string); ok {
+			chunk.FinishReason = reason
+		}
+
+		chunks <- chunk
+	}
+
+	close(chunks)
+
+	return chunks
+}
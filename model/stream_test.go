@@ -0,0 +1,222 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/model/chatmodel"
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+	"github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamPrompt(t *testing.T) {
+	t.Run("LLM", func(t *testing.T) {
+		fake := llm.NewSimpleFake("Hello from the fake llm")
+
+		stream, err := StreamPrompt(context.Background(), fake, prompt.StringPromptValue("Hello"))
+		require.NoError(t, err)
+
+		tokens := collectTokens(t, stream)
+		require.Equal(t, []string{"Hello ", "from ", "the ", "fake ", "llm"}, tokens)
+	})
+
+	t.Run("ChatModel", func(t *testing.T) {
+		fake := chatmodel.NewSimpleFake("Hello from the fake chat model")
+
+		stream, err := StreamPrompt(context.Background(), fake, prompt.StringPromptValue("Hello"))
+		require.NoError(t, err)
+
+		tokens := collectTokens(t, stream)
+		require.Equal(t, "Hello from the fake chat model", strings.Join(tokens, ""))
+	})
+
+	t.Run("ResponseFormat", func(t *testing.T) {
+		fake := &recordingStreamingChatModel{Fake: chatmodel.NewSimpleFake("{}")}
+
+		stream, err := ChatModelStream(context.Background(), fake, schema.ChatMessages{schema.NewHumanChatMessage("Hello")}, func(o *Options) {
+			o.ResponseFormat = &schema.ResponseFormat{Name: "greeting"}
+		})
+		require.NoError(t, err)
+
+		collectTokens(t, stream)
+		require.Equal(t, "greeting", fake.Options.ResponseFormat.Name)
+	})
+
+	t.Run("InvalidModelType", func(t *testing.T) {
+		_, err := StreamPrompt(context.Background(), struct{ schema.Model }{}, prompt.StringPromptValue("Hello"))
+		require.EqualError(t, err, "invalid model type struct { schema.Model }")
+	})
+
+	t.Run("NonStreamingModel", func(t *testing.T) {
+		fake := &nonStreamingLLM{LLM: llm.NewSimpleFake("Hello world")}
+
+		stream, err := StreamPrompt(context.Background(), fake, prompt.StringPromptValue("Hello"))
+		require.NoError(t, err)
+
+		tokens := collectTokens(t, stream)
+		require.Equal(t, []string{"Hello world"}, tokens)
+	})
+
+	t.Run("Callbacks", func(t *testing.T) {
+		handler := &tokenCollector{}
+		fake := llm.NewSimpleFake("Hello world")
+
+		stream, err := LLMStream(context.Background(), fake, "Hello", func(o *Options) {
+			o.Callbacks = []schema.Callback{handler}
+		})
+		require.NoError(t, err)
+
+		collectTokens(t, stream)
+
+		require.Equal(t, []string{"Hello ", "world"}, handler.tokens)
+		require.NotNil(t, handler.result)
+		require.Equal(t, "Hello world", handler.result.Generations[0].Text)
+		require.NotContains(t, handler.result.LLMOutput, "TokenUsage")
+	})
+
+	t.Run("ContextCanceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		handler := &tokenCollector{}
+
+		stream, err := LLMStream(ctx, &blockingStreamingLLM{LLM: llm.NewSimpleFake("Hello")}, "Hello", func(o *Options) {
+			o.Callbacks = []schema.Callback{handler}
+		})
+		require.NoError(t, err)
+
+		chunk := <-stream
+		require.Equal(t, "Hello", chunk.Token)
+
+		cancel()
+
+		for range stream {
+		}
+
+		require.ErrorIs(t, handler.err, context.Canceled)
+		require.Nil(t, handler.result)
+	})
+
+	t.Run("TokenUsage", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+
+			for _, data := range []string{
+				`{"choices":[{"index":0,"delta":{"role":"assistant","content":"Hello"}}]}`,
+				`{"choices":[{"index":0,"delta":{"content":" world"},"finish_reason":"stop"}]}`,
+				`{"choices":[],"usage":{"prompt_tokens":3,"completion_tokens":2,"total_tokens":5}}`,
+			} {
+				fmt.Fprintf(w, "data: %s\n\n", data)
+			}
+
+			fmt.Fprint(w, "data: [DONE]\n\n")
+		}))
+		defer server.Close()
+
+		config := openai.DefaultConfig("key")
+		config.BaseURL = server.URL
+
+		openAI, err := chatmodel.NewOpenAIFromClient(openai.NewClientWithConfig(config))
+		require.NoError(t, err)
+
+		handler := &tokenCollector{}
+
+		stream, err := ChatModelStream(context.Background(), openAI, schema.ChatMessages{schema.NewHumanChatMessage("Hello")}, func(o *Options) {
+			o.Callbacks = []schema.Callback{handler}
+		})
+		require.NoError(t, err)
+
+		tokens := collectTokens(t, stream)
+		require.Equal(t, "Hello world", strings.Join(tokens, ""))
+		require.Equal(t, schema.TokenUsage{PromptTokens: 3, CompletionTokens: 2, TotalTokens: 5}, handler.result.LLMOutput["TokenUsage"])
+	})
+}
+
+func collectTokens(t *testing.T, stream <-chan schema.StreamChunk) []string {
+	t.Helper()
+
+	tokens := []string{}
+
+	for chunk := range stream {
+		require.NoError(t, chunk.Err)
+
+		tokens = append(tokens, chunk.Token)
+	}
+
+	return tokens
+}
+
+type nonStreamingLLM struct {
+	schema.LLM
+}
+
+type tokenCollector struct {
+	callback.NoopHandler
+	tokens []string
+	result *schema.ModelResult
+	err    error
+}
+
+func (c *tokenCollector) OnModelNewToken(ctx context.Context, input *schema.ModelNewTokenInput) error {
+	c.tokens = append(c.tokens, input.Token)
+	return nil
+}
+
+func (c *tokenCollector) OnModelEnd(ctx context.Context, input *schema.ModelEndInput) error {
+	c.result = input.Result
+	return nil
+}
+
+func (c *tokenCollector) OnModelError(ctx context.Context, input *schema.ModelErrorInput) error {
+	c.err = input.Error
+	return nil
+}
+
+func (c *tokenCollector) AlwaysVerbose() bool {
+	return true
+}
+
+// recordingStreamingChatModel records the generate options of the stream.
+type recordingStreamingChatModel struct {
+	*chatmodel.Fake
+	Options schema.GenerateOptions
+}
+
+func (cm *recordingStreamingChatModel) Stream(ctx context.Context, messages schema.ChatMessages, optFns ...func(o *schema.GenerateOptions)) (<-chan schema.StreamChunk, error) {
+	for _, fn := range optFns {
+		fn(&cm.Options)
+	}
+
+	return cm.Fake.Stream(ctx, messages, optFns...)
+}
+
+// blockingStreamingLLM sends the first token and blocks until the context is done.
+type blockingStreamingLLM struct {
+	schema.LLM
+}
+
+func (l *blockingStreamingLLM) Stream(ctx context.Context, prompt string, optFns ...func(o *schema.GenerateOptions)) (<-chan schema.StreamChunk, error) {
+	chunks := make(chan schema.StreamChunk)
+
+	go func() {
+		defer close(chunks)
+
+		select {
+		case <-ctx.Done():
+			return
+		case chunks <- schema.StreamChunk{Token: prompt}:
+		}
+
+		<-ctx.Done()
+	}()
+
+	return chunks, nil
+}
@@ -0,0 +1,150 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hupe1980/golc/integration/jsonschema"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+)
+
+const structuredFormatInstructions = `The output must be a JSON object that conforms to the following JSON schema:
+%s
+
+Respond only with the JSON object.`
+
+const structuredRetryInstructions = `Your previous output was invalid.
+
+Error: %s
+
+Fix the output. Respond only with the JSON object.`
+
+// GenerateStructuredOptions contains options for GenerateStructured.
+type GenerateStructuredOptions struct {
+	Options
+
+	// MaxRetries is the maximum number of retries if the model output is not a valid value.
+	MaxRetries int
+}
+
+// GenerateStructured generates a response of the chat model for the prompt and unmarshals it into a value
+// of the struct type T. The JSON schema of T is added to the prompt, and the native JSON mode of the model,
+// e.g. of OpenAI or Ollama, is requested. Models returning schema.ErrResponseFormatNotSupported, e.g. Google
+// GenAI, are only prompted with the schema. Invalid outputs are passed back to the model with the error until
+// MaxRetries is exhausted.
+func GenerateStructured[T any](ctx context.Context, chatModel schema.ChatModel, promptValue schema.PromptValue, optFns ...func(o *GenerateStructuredOptions)) (T, error) {
+	var empty T
+
+	opts := GenerateStructuredOptions{
+		MaxRetries: 2,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return empty, fmt.Errorf("unsupported structured output type: %s is not a struct", t)
+	}
+
+	jsonSchema, err := jsonschema.Generate(t)
+	if err != nil {
+		return empty, err
+	}
+
+	b, err := json.Marshal(jsonSchema)
+	if err != nil {
+		return empty, err
+	}
+
+	messages := prompt.WithFormatInstructions(promptValue, fmt.Sprintf(structuredFormatInstructions, b)).Messages()
+
+	opts.ResponseFormat = &schema.ResponseFormat{
+		Name:   t.Name(),
+		Schema: jsonSchema,
+	}
+
+	var parseErr error
+
+	for attempt := 0; attempt <= opts.MaxRetries; attempt++ {
+		result, err := ChatModelGenerate(ctx, chatModel, messages, func(o *Options) {
+			*o = opts.Options
+		})
+		if errors.Is(err, schema.ErrResponseFormatNotSupported) {
+			// Models without a JSON mode are only prompted with the schema.
+			opts.ResponseFormat = nil
+
+			result, err = ChatModelGenerate(ctx, chatModel, messages, func(o *Options) {
+				*o = opts.Options
+			})
+		}
+
+		if err != nil {
+			return empty, err
+		}
+
+		if len(result.Generations) == 0 {
+			return empty, errors.New("unexpected output: no generations")
+		}
+
+		output := result.Generations[0].Text
+
+		var value T
+
+		value, parseErr = parseStructured[T](output, jsonSchema)
+		if parseErr == nil {
+			return value, nil
+		}
+
+		messages = append(messages,
+			schema.NewAIChatMessage(output),
+			schema.NewHumanChatMessage(fmt.Sprintf(structuredRetryInstructions, parseErr)),
+		)
+	}
+
+	return empty, fmt.Errorf("invalid structured output after %d attempts: %w", opts.MaxRetries+1, parseErr)
+}
+
+// parseStructured parses the JSON object of the output into a value of type T. The required properties
+// of the schema must be present.
+func parseStructured[T any](output string, jsonSchema *jsonschema.Schema) (T, error) {
+	var value T
+
+	start, end := strings.Index(output, "{"), strings.LastIndex(output, "}")
+	if start < 0 || end < start {
+		return value, errors.New("output is not a JSON object")
+	}
+
+	object := map[string]any{}
+	if err := json.Unmarshal([]byte(output[start:end+1]), &object); err != nil {
+		return value, err
+	}
+
+	missing := []string{}
+
+	for _, name := range jsonSchema.Required {
+		if _, ok := object[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return value, fmt.Errorf("missing required properties: %s", strings.Join(missing, ", "))
+	}
+
+	if err := json.Unmarshal([]byte(output[start:end+1]), &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
@@ -0,0 +1,107 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc/model/chatmodel"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/require"
+)
+
+type person struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+func TestGenerateStructured(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		fake := chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+			require.Len(t, messages, 1)
+			require.Contains(t, messages[0].Content(), "Max is 21 years old.\n\nThe output must be a JSON object")
+			require.Contains(t, messages[0].Content(), `"required":["name","age"]`)
+
+			return newFakeResult("```json\n{\"name\": \"Max\", \"age\": 21}\n```"), nil
+		})
+
+		p, err := GenerateStructured[person](context.Background(), fake, prompt.StringPromptValue("Max is 21 years old."))
+		require.NoError(t, err)
+		require.Equal(t, person{Name: "Max", Age: 21}, p)
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		outputs := []string{`{"name": "Max"}`, `{"name": "Max", "age": 21}`}
+
+		fake := chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+			if len(messages) > 1 {
+				require.Len(t, messages, 3)
+				require.Equal(t, `{"name": "Max"}`, messages[1].Content())
+				require.Contains(t, messages[2].Content(), "Error: missing required properties: age")
+			}
+
+			output := outputs[0]
+			outputs = outputs[1:]
+
+			return newFakeResult(output), nil
+		})
+
+		p, err := GenerateStructured[person](context.Background(), fake, prompt.StringPromptValue("Max is 21 years old."))
+		require.NoError(t, err)
+		require.Equal(t, person{Name: "Max", Age: 21}, p)
+	})
+
+	t.Run("MaxRetries", func(t *testing.T) {
+		fake := chatmodel.NewSimpleFake("I don't know.")
+
+		_, err := GenerateStructured[person](context.Background(), fake, prompt.StringPromptValue("Max is 21 years old."), func(o *GenerateStructuredOptions) {
+			o.MaxRetries = 1
+		})
+		require.EqualError(t, err, "invalid structured output after 2 attempts: output is not a JSON object")
+	})
+
+	t.Run("ResponseFormatNotSupported", func(t *testing.T) {
+		calls := 0
+
+		fake := chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+			calls++
+
+			return newFakeResult(`{"name": "Max", "age": 21}`), nil
+		})
+
+		p, err := GenerateStructured[person](context.Background(), &noJSONModeChatModel{fake}, prompt.StringPromptValue("Max is 21 years old."))
+		require.NoError(t, err)
+		require.Equal(t, person{Name: "Max", Age: 21}, p)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("UnsupportedType", func(t *testing.T) {
+		_, err := GenerateStructured[string](context.Background(), chatmodel.NewSimpleFake(""), prompt.StringPromptValue("foo"))
+		require.EqualError(t, err, "unsupported structured output type: string is not a struct")
+	})
+}
+
+func newFakeResult(text string) *schema.ModelResult {
+	return &schema.ModelResult{
+		Generations: []schema.Generation{{Text: text, Message: schema.NewAIChatMessage(text)}},
+	}
+}
+
+// noJSONModeChatModel is a chat model rejecting response formats.
+type noJSONModeChatModel struct {
+	*chatmodel.Fake
+}
+
+func (cm *noJSONModeChatModel) Generate(ctx context.Context, messages schema.ChatMessages, optFns ...func(o *schema.GenerateOptions)) (*schema.ModelResult, error) {
+	opts := schema.GenerateOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.ResponseFormat != nil {
+		return nil, schema.ErrResponseFormatNotSupported
+	}
+
+	return cm.Fake.Generate(ctx, messages, optFns...)
+}
@@ -0,0 +1,218 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure AmazonBedrockGuardrails satisfies the Moderator interface.
+var _ schema.Moderator = (*AmazonBedrockGuardrails)(nil)
+
+// AmazonBedrockGuardrailsClient is an interface for the Amazon Bedrock runtime client used for guardrail checks.
+type AmazonBedrockGuardrailsClient interface {
+	// InvokeModel invokes the model with the guardrail configured in the input.
+	InvokeModel(ctx context.Context, params *bedrockruntime.InvokeModelInput, optFns ...func(*bedrockruntime.Options)) (*bedrockruntime.InvokeModelOutput, error)
+}
+
+// AmazonBedrockGuardrailsOptions contains options for configuring the AmazonBedrockGuardrails moderator.
+type AmazonBedrockGuardrailsOptions struct {
+	// GuardrailVersion is the version of the guardrail (default is "DRAFT").
+	GuardrailVersion string
+	// ModelID is the id of the model invoked with the guardrail (default is "amazon.titan-text-lite-v1").
+	ModelID string
+	// BodyFunc creates the request body of the model for the text. The default creates a request for the
+	// Amazon Titan text models, which generates a single token.
+	BodyFunc func(text string) ([]byte, error)
+}
+
+// AmazonBedrockGuardrails is a moderator, which checks texts with the content, topic, word and sensitive
+// information policies of an Amazon Bedrock guardrail. The text is sent to the model with the guardrail
+// applied and the guardrail trace of the response is evaluated.
+type AmazonBedrockGuardrails struct {
+	client      AmazonBedrockGuardrailsClient
+	guardrailID string
+	opts        AmazonBedrockGuardrailsOptions
+}
+
+// NewAmazonBedrockGuardrails creates a new instance of AmazonBedrockGuardrails with the provided client,
+// guardrail identifier and options.
+func NewAmazonBedrockGuardrails(client AmazonBedrockGuardrailsClient, guardrailID string, optFns ...func(o *AmazonBedrockGuardrailsOptions)) *AmazonBedrockGuardrails {
+	opts := AmazonBedrockGuardrailsOptions{
+		GuardrailVersion: "DRAFT",
+		ModelID:          "amazon.titan-text-lite-v1",
+		BodyFunc: func(text string) ([]byte, error) {
+			return json.Marshal(map[string]any{
+				"inputText": text,
+				"textGenerationConfig": map[string]any{
+					"maxTokenCount": 1,
+				},
+			})
+		},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &AmazonBedrockGuardrails{
+		client:      client,
+		guardrailID: guardrailID,
+		opts:        opts,
+	}
+}
+
+// Moderate checks the text with the guardrail and returns the moderation result. The text is flagged,
+// if the guardrail intervened. The policies of the assessments are returned as categories, e.g.
+// "content:VIOLENCE", "topic:Investment Advice" or "pii:EMAIL".
+func (m *AmazonBedrockGuardrails) Moderate(ctx context.Context, text string) (*schema.ModerationResult, error) {
+	body, err := m.opts.BodyFunc(text)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := m.client.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
+		ModelId:             aws.String(m.opts.ModelID),
+		Body:                body,
+		Accept:              aws.String("application/json"),
+		ContentType:         aws.String("application/json"),
+		GuardrailIdentifier: aws.String(m.guardrailID),
+		GuardrailVersion:    aws.String(m.opts.GuardrailVersion),
+		Trace:               types.TraceEnabled,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output := guardrailOutput{}
+	if err := json.Unmarshal(res.Body, &output); err != nil {
+		return nil, err
+	}
+
+	categories := []schema.ModerationCategory{}
+
+	if trace := output.Trace; trace != nil && trace.Guardrail != nil {
+		assessments := []map[string]guardrailAssessment{trace.Guardrail.Input}
+		assessments = append(assessments, trace.Guardrail.Outputs...)
+
+		for _, a := range assessments {
+			ids := make([]string, 0, len(a))
+			for id := range a {
+				ids = append(ids, id)
+			}
+
+			sort.Strings(ids)
+
+			for _, id := range ids {
+				categories = append(categories, a[id].categories()...)
+			}
+		}
+	}
+
+	return &schema.ModerationResult{
+		Flagged:    output.Action == "INTERVENED",
+		Categories: categories,
+	}, nil
+}
+
+// Type returns the type of the moderator.
+func (m *AmazonBedrockGuardrails) Type() string {
+	return "AmazonBedrockGuardrailsModeration"
+}
+
+// guardrailOutput is the part of the model response added by the guardrail.
+type guardrailOutput struct {
+	Action string `json:"amazon-bedrock-guardrailAction"`
+	Trace  *struct {
+		Guardrail *struct {
+			Input   map[string]guardrailAssessment   `json:"input"`
+			Outputs []map[string]guardrailAssessment `json:"outputs"`
+		} `json:"guardrail"`
+	} `json:"amazon-bedrock-trace"`
+}
+
+// guardrailPolicyMatch is a match of a guardrail policy.
+type guardrailPolicyMatch struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Match      string `json:"match"`
+	Confidence string `json:"confidence"`
+	Action     string `json:"action"`
+}
+
+// guardrailAssessment is the assessment of a text by the policies of a guardrail.
+type guardrailAssessment struct {
+	TopicPolicy struct {
+		Topics []guardrailPolicyMatch `json:"topics"`
+	} `json:"topicPolicy"`
+	ContentPolicy struct {
+		Filters []guardrailPolicyMatch `json:"filters"`
+	} `json:"contentPolicy"`
+	WordPolicy struct {
+		CustomWords      []guardrailPolicyMatch `json:"customWords"`
+		ManagedWordLists []guardrailPolicyMatch `json:"managedWordLists"`
+	} `json:"wordPolicy"`
+	SensitiveInformationPolicy struct {
+		PIIEntities []guardrailPolicyMatch `json:"piiEntities"`
+		Regexes     []guardrailPolicyMatch `json:"regexes"`
+	} `json:"sensitiveInformationPolicy"`
+}
+
+// categories returns the policy matches of the assessment as moderation categories.
+func (a guardrailAssessment) categories() []schema.ModerationCategory {
+	categories := []schema.ModerationCategory{}
+
+	add := func(name, confidence, action string) {
+		categories = append(categories, schema.ModerationCategory{
+			Name:    name,
+			Score:   guardrailConfidenceScore(confidence),
+			Flagged: action != "" && action != "NONE",
+		})
+	}
+
+	for _, t := range a.TopicPolicy.Topics {
+		add("topic:"+t.Name, "", t.Action)
+	}
+
+	for _, f := range a.ContentPolicy.Filters {
+		add("content:"+f.Type, f.Confidence, f.Action)
+	}
+
+	for _, w := range a.WordPolicy.CustomWords {
+		add("word:CUSTOM", "", w.Action)
+	}
+
+	for _, w := range a.WordPolicy.ManagedWordLists {
+		add("word:"+w.Type, "", w.Action)
+	}
+
+	for _, e := range a.SensitiveInformationPolicy.PIIEntities {
+		add("pii:"+e.Type, "", e.Action)
+	}
+
+	for _, r := range a.SensitiveInformationPolicy.Regexes {
+		add("regex:"+r.Name, "", r.Action)
+	}
+
+	return categories
+}
+
+// guardrailConfidenceScore maps the confidence of a content filter to a score. Matches of the other
+// policies have no confidence and are scored like high confidence matches.
+func guardrailConfidenceScore(confidence string) float32 {
+	switch confidence {
+	case "NONE":
+		return 0
+	case "LOW":
+		return 1.0 / 3
+	case "MEDIUM":
+		return 2.0 / 3
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,88 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAmazonBedrockGuardrails(t *testing.T) {
+	testCases := []struct {
+		name               string
+		body               string
+		expectedFlagged    bool
+		expectedCategories []schema.ModerationCategory
+	}{
+		{
+			name:               "Passed",
+			body:               `{"outputText": "Hello", "amazon-bedrock-guardrailAction": "NONE"}`,
+			expectedFlagged:    false,
+			expectedCategories: []schema.ModerationCategory{},
+		},
+		{
+			name: "Intervened",
+			body: `{
+				"amazon-bedrock-guardrailAction": "INTERVENED",
+				"amazon-bedrock-trace": {
+					"guardrail": {
+						"input": {
+							"gr1": {
+								"topicPolicy": {"topics": [{"name": "Investment Advice", "type": "DENY", "action": "BLOCKED"}]},
+								"contentPolicy": {"filters": [{"type": "VIOLENCE", "confidence": "MEDIUM", "action": "NONE"}]},
+								"sensitiveInformationPolicy": {"piiEntities": [{"type": "EMAIL", "match": "jane@example.com", "action": "ANONYMIZED"}]}
+							}
+						}
+					}
+				}
+			}`,
+			expectedFlagged: true,
+			expectedCategories: []schema.ModerationCategory{
+				{Name: "topic:Investment Advice", Score: 1, Flagged: true},
+				{Name: "content:VIOLENCE", Score: 2.0 / 3},
+				{Name: "pii:EMAIL", Score: 1, Flagged: true},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := &fakeAmazonBedrockGuardrailsClient{
+				response: &bedrockruntime.InvokeModelOutput{Body: []byte(tc.body)},
+			}
+
+			moderator := NewAmazonBedrockGuardrails(fakeClient, "gr1", func(o *AmazonBedrockGuardrailsOptions) {
+				o.GuardrailVersion = "1"
+			})
+
+			result, err := moderator.Moderate(context.Background(), "Which stocks should I buy?")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedFlagged, result.Flagged)
+			assert.Equal(t, tc.expectedCategories, result.Categories)
+
+			assert.Equal(t, "gr1", aws.ToString(fakeClient.input.GuardrailIdentifier))
+			assert.Equal(t, "1", aws.ToString(fakeClient.input.GuardrailVersion))
+			assert.Equal(t, types.TraceEnabled, fakeClient.input.Trace)
+
+			body := map[string]any{}
+			assert.NoError(t, json.Unmarshal(fakeClient.input.Body, &body))
+			assert.Equal(t, "Which stocks should I buy?", body["inputText"])
+		})
+	}
+}
+
+type fakeAmazonBedrockGuardrailsClient struct {
+	input    *bedrockruntime.InvokeModelInput
+	response *bedrockruntime.InvokeModelOutput
+	err      error
+}
+
+func (c *fakeAmazonBedrockGuardrailsClient) InvokeModel(ctx context.Context, params *bedrockruntime.InvokeModelInput, optFns ...func(*bedrockruntime.Options)) (*bedrockruntime.InvokeModelOutput, error) {
+	c.input = params
+	return c.response, c.err
+}
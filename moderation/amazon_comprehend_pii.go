@@ -47,6 +47,12 @@ type AmazonComprehendPIIOptions struct {
 	RedactFunc RedactFunc
 }
 
+// Compile time check to ensure AmazonComprehendPII satisfies the Chain and Moderator interfaces.
+var (
+	_ schema.Chain     = (*AmazonComprehendPII)(nil)
+	_ schema.Moderator = (*AmazonComprehendPII)(nil)
+)
+
 // AmazonComprehendPII is a struct representing the Amazon Comprehend PII moderation functionality.
 type AmazonComprehendPII struct {
 	client AmazonComprehendPIIClient
@@ -142,7 +148,9 @@ func (c *AmazonComprehendPII) OutputKeys() []string {
 	return []string{c.opts.OutputKey}
 }
 
-func (c *AmazonComprehendPII) containsPII(ctx context.Context, text string) (schema.ChainValues, error) {
+// Moderate checks the text for PII entities and returns the moderation result. The labels of the detected
+// entity types are returned as categories; the text is flagged, if a label reaches the threshold.
+func (c *AmazonComprehendPII) Moderate(ctx context.Context, text string) (*schema.ModerationResult, error) {
 	output, err := c.client.ContainsPiiEntities(ctx, &comprehend.ContainsPiiEntitiesInput{
 		Text:         aws.String(text),
 		LanguageCode: types.LanguageCode(c.opts.LanguageCode),
@@ -151,20 +159,34 @@ func (c *AmazonComprehendPII) containsPII(ctx context.Context, text string) (sch
 		return nil, err
 	}
 
-	if len(c.opts.Labels) == 0 {
-		for _, label := range output.Labels {
-			if aws.ToFloat32(label.Score) >= c.opts.Threshold {
-				return nil, errors.New("pii content found")
-			}
-		}
-	} else {
-		for _, label := range output.Labels {
-			if util.Contains(c.opts.Labels, string(label.Name)) {
-				if aws.ToFloat32(label.Score) >= c.opts.Threshold {
-					return nil, errors.New("pii content found")
-				}
-			}
-		}
+	result := &schema.ModerationResult{
+		Categories: make([]schema.ModerationCategory, 0, len(output.Labels)),
+	}
+
+	for _, label := range output.Labels {
+		score := aws.ToFloat32(label.Score)
+		flagged := score >= c.opts.Threshold && (len(c.opts.Labels) == 0 || util.Contains(c.opts.Labels, string(label.Name)))
+
+		result.Categories = append(result.Categories, schema.ModerationCategory{
+			Name:    string(label.Name),
+			Score:   score,
+			Flagged: flagged,
+		})
+
+		result.Flagged = result.Flagged || flagged
+	}
+
+	return result, nil
+}
+
+func (c *AmazonComprehendPII) containsPII(ctx context.Context, text string) (schema.ChainValues, error) {
+	result, err := c.Moderate(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Flagged {
+		return nil, errors.New("pii content found")
 	}
 
 	return schema.ChainValues{
@@ -103,3 +103,25 @@ func (c *fakeAmazonComprehendPIIClient) ContainsPiiEntities(ctx context.Context,
 func (c *fakeAmazonComprehendPIIClient) DetectPiiEntities(ctx context.Context, params *comprehend.DetectPiiEntitiesInput, optFns ...func(*comprehend.Options)) (*comprehend.DetectPiiEntitiesOutput, error) {
 	return c.detectResponse, c.err
 }
+
+func TestAmazonComprehendPIIModerate(t *testing.T) {
+	fakeClient := &fakeAmazonComprehendPIIClient{
+		containsResponse: &comprehend.ContainsPiiEntitiesOutput{
+			Labels: []types.EntityLabel{
+				{Name: types.PiiEntityTypeName, Score: aws.Float32(0.9)},
+				{Name: types.PiiEntityTypeEmail, Score: aws.Float32(0.5)},
+			},
+		},
+	}
+
+	result, err := NewAmazonComprehendPII(fakeClient).Moderate(context.Background(), "My name is John")
+	assert.NoError(t, err)
+	assert.True(t, result.Flagged)
+	assert.Equal(t, []string{"NAME"}, result.FlaggedCategories())
+
+	result, err = NewAmazonComprehendPII(fakeClient, func(o *AmazonComprehendPIIOptions) {
+		o.Labels = []string{"EMAIL"}
+	}).Moderate(context.Background(), "My name is John")
+	assert.NoError(t, err)
+	assert.False(t, result.Flagged)
+}
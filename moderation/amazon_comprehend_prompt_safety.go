@@ -34,6 +34,12 @@ type AmazonComprehendPromptSafetyOptions struct {
 	Endpoint string
 }
 
+// Compile time check to ensure AmazonComprehendPromptSafety satisfies the Chain and Moderator interfaces.
+var (
+	_ schema.Chain     = (*AmazonComprehendPromptSafety)(nil)
+	_ schema.Moderator = (*AmazonComprehendPromptSafety)(nil)
+)
+
 // AmazonComprehendPromptSafety is a struct representing the Amazon Comprehend Prompt Safety moderation functionality.
 type AmazonComprehendPromptSafety struct {
 	client AmazonComprehendPromptSafetyClient
@@ -84,6 +90,23 @@ func (c *AmazonComprehendPromptSafety) Call(ctx context.Context, inputs schema.C
 		return nil, cbErr
 	}
 
+	result, err := c.Moderate(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Flagged {
+		return nil, errors.New("unsafe prompt detected")
+	}
+
+	return schema.ChainValues{
+		c.opts.OutputKey: text,
+	}, nil
+}
+
+// Moderate classifies the text and returns the moderation result with the classes as categories.
+// The text is flagged, if the score of the UNSAFE_PROMPT class exceeds the threshold.
+func (c *AmazonComprehendPromptSafety) Moderate(ctx context.Context, text string) (*schema.ModerationResult, error) {
 	output, err := c.client.ClassifyDocument(ctx, &comprehend.ClassifyDocumentInput{
 		Text:        aws.String(text),
 		EndpointArn: aws.String(fmt.Sprintf("arn:aws:comprehend:%s:aws:%s", c.client.Options().Region, c.opts.Endpoint)),
@@ -92,15 +115,24 @@ func (c *AmazonComprehendPromptSafety) Call(ctx context.Context, inputs schema.C
 		return nil, err
 	}
 
-	for _, classes := range output.Classes {
-		if aws.ToString(classes.Name) == "UNSAFE_PROMPT" && aws.ToFloat32(classes.Score) > c.opts.Threshold {
-			return nil, errors.New("unsafe prompt detected")
-		}
+	result := &schema.ModerationResult{
+		Categories: make([]schema.ModerationCategory, 0, len(output.Classes)),
 	}
 
-	return schema.ChainValues{
-		c.opts.OutputKey: text,
-	}, nil
+	for _, class := range output.Classes {
+		score := aws.ToFloat32(class.Score)
+		flagged := aws.ToString(class.Name) == "UNSAFE_PROMPT" && score > c.opts.Threshold
+
+		result.Categories = append(result.Categories, schema.ModerationCategory{
+			Name:    aws.ToString(class.Name),
+			Score:   score,
+			Flagged: flagged,
+		})
+
+		result.Flagged = result.Flagged || flagged
+	}
+
+	return result, nil
 }
 
 // Memory returns the memory associated with the chain.
@@ -35,6 +35,12 @@ type AmazonComprehendToxicityOptions struct {
 	Threshold float32
 }
 
+// Compile time check to ensure AmazonComprehendToxicity satisfies the Chain and Moderator interfaces.
+var (
+	_ schema.Chain     = (*AmazonComprehendToxicity)(nil)
+	_ schema.Moderator = (*AmazonComprehendToxicity)(nil)
+)
+
 // AmazonComprehendToxicity is a content moderation chain using Amazon Comprehend for toxicity detection.
 type AmazonComprehendToxicity struct {
 	client AmazonComprehendToxicityClient
@@ -85,6 +91,24 @@ func (c *AmazonComprehendToxicity) Call(ctx context.Context, inputs schema.Chain
 		return nil, cbErr
 	}
 
+	result, err := c.Moderate(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Flagged {
+		return nil, errors.New("toxic content found")
+	}
+
+	return schema.ChainValues{
+		c.opts.OutputKey: text,
+	}, nil
+}
+
+// Moderate checks the text for toxic content and returns the moderation result. The overall toxicity and
+// the labels are returned as categories. Without labels, the text is flagged, if the overall toxicity reaches
+// the threshold; otherwise, if one of the labels reaches the threshold.
+func (c *AmazonComprehendToxicity) Moderate(ctx context.Context, text string) (*schema.ModerationResult, error) {
 	// TODO split text
 
 	output, err := c.client.DetectToxicContent(ctx, &comprehend.DetectToxicContentInput{
@@ -95,27 +119,35 @@ func (c *AmazonComprehendToxicity) Call(ctx context.Context, inputs schema.Chain
 		return nil, err
 	}
 
-	if len(c.opts.Labels) == 0 {
-		for _, item := range output.ResultList {
-			if aws.ToFloat32(item.Toxicity) >= c.opts.Threshold {
-				return nil, errors.New("toxic content found")
-			}
-		}
-	} else {
-		for _, item := range output.ResultList {
-			for _, label := range item.Labels {
-				if util.Contains(c.opts.Labels, string(label.Name)) {
-					if aws.ToFloat32(label.Score) >= c.opts.Threshold {
-						return nil, errors.New("toxic content found")
-					}
-				}
-			}
+	result := &schema.ModerationResult{}
+
+	for _, item := range output.ResultList {
+		toxicity := aws.ToFloat32(item.Toxicity)
+		flagged := len(c.opts.Labels) == 0 && toxicity >= c.opts.Threshold
+
+		result.Categories = append(result.Categories, schema.ModerationCategory{
+			Name:    "TOXICITY",
+			Score:   toxicity,
+			Flagged: flagged,
+		})
+
+		result.Flagged = result.Flagged || flagged
+
+		for _, label := range item.Labels {
+			score := aws.ToFloat32(label.Score)
+			flagged := util.Contains(c.opts.Labels, string(label.Name)) && score >= c.opts.Threshold
+
+			result.Categories = append(result.Categories, schema.ModerationCategory{
+				Name:    string(label.Name),
+				Score:   score,
+				Flagged: flagged,
+			})
+
+			result.Flagged = result.Flagged || flagged
 		}
 	}
 
-	return schema.ChainValues{
-		c.opts.OutputKey: text,
-	}, nil
+	return result, nil
 }
 
 // Memory returns the memory associated with the chain.
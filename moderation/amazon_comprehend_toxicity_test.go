@@ -85,3 +85,29 @@ type fakeAmazonComprehendToxicityClient struct {
 func (c *fakeAmazonComprehendToxicityClient) DetectToxicContent(ctx context.Context, params *comprehend.DetectToxicContentInput, optFns ...func(*comprehend.Options)) (*comprehend.DetectToxicContentOutput, error) {
 	return c.response, c.err
 }
+
+func TestAmazonComprehendToxicityModerate(t *testing.T) {
+	fakeClient := &fakeAmazonComprehendToxicityClient{
+		response: &comprehend.DetectToxicContentOutput{
+			ResultList: []types.ToxicLabels{{
+				Toxicity: aws.Float32(0.6),
+				Labels: []types.ToxicContent{
+					{Name: types.ToxicContentTypeInsult, Score: aws.Float32(0.9)},
+					{Name: types.ToxicContentTypeProfanity, Score: aws.Float32(0.3)},
+				},
+			}},
+		},
+	}
+
+	result, err := NewAmazonComprehendToxicity(fakeClient).Moderate(context.Background(), "Some text")
+	assert.NoError(t, err)
+	assert.False(t, result.Flagged)
+	assert.Len(t, result.Categories, 3)
+
+	result, err = NewAmazonComprehendToxicity(fakeClient, func(o *AmazonComprehendToxicityOptions) {
+		o.Labels = []string{"INSULT"}
+	}).Moderate(context.Background(), "Some text")
+	assert.NoError(t, err)
+	assert.True(t, result.Flagged)
+	assert.Equal(t, []string{"INSULT"}, result.FlaggedCategories())
+}
@@ -0,0 +1,204 @@
+package moderation
+
+import (
+	"context"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Chain satisfies the Chain interface.
+var _ schema.Chain = (*Chain)(nil)
+
+// Mode determines how the Chain wrapper handles violations.
+type Mode string
+
+const (
+	// ModeReject aborts the run with a ViolationError on the first violation.
+	ModeReject Mode = "reject"
+	// ModeFlag continues the run and adds the flagged output to the outputs.
+	ModeFlag Mode = "flag"
+)
+
+// ChainOptions contains options for configuring the Chain wrapper.
+type ChainOptions struct {
+	// CallbackOptions embeds CallbackOptions to include the verbosity setting and callbacks.
+	*schema.CallbackOptions
+	// Mode determines how violations are handled. Defaults to ModeReject.
+	Mode Mode
+	// InputKeys are the keys of the inputs to moderate. Defaults to the input keys of the wrapped chain.
+	InputKeys []string
+	// OutputKeys are the keys of the outputs to moderate. Defaults to the output keys of the wrapped chain.
+	OutputKeys []string
+	// SkipInputs disables the moderation of the inputs.
+	SkipInputs bool
+	// SkipOutputs disables the moderation of the outputs.
+	SkipOutputs bool
+	// OutputModerator moderates the outputs instead of the moderator of the chain, e.g. to check the inputs
+	// for prompt safety and the outputs for PII.
+	OutputModerator schema.Moderator
+	// FlaggedKey is the key of the output, which indicates a violation in ModeFlag.
+	FlaggedKey string
+}
+
+// Chain wraps a chain and moderates its inputs before and its outputs after the run. Violations are reported
+// to the callbacks and, depending on the mode, reject the run or flag the outputs.
+type Chain struct {
+	chain     schema.Chain
+	moderator schema.Moderator
+	opts      ChainOptions
+}
+
+// NewChain creates a new Chain wrapper, which moderates the inputs and outputs of the chain with the moderator.
+func NewChain(chain schema.Chain, moderator schema.Moderator, optFns ...func(o *ChainOptions)) *Chain {
+	opts := ChainOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		Mode:       ModeReject,
+		InputKeys:  chain.InputKeys(),
+		OutputKeys: chain.OutputKeys(),
+		FlaggedKey: "flagged",
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.OutputModerator == nil {
+		opts.OutputModerator = moderator
+	}
+
+	return &Chain{
+		chain:     chain,
+		moderator: moderator,
+		opts:      opts,
+	}
+}
+
+// Call moderates the inputs, executes the wrapped chain and moderates its outputs.
+// It returns the outputs of the chain or an error, if any.
+func (c *Chain) Call(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+	opts := schema.CallOptions{
+		CallbackManger: &callback.NoopManager{},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	flagged := false
+
+	if !c.opts.SkipInputs {
+		violated, err := c.moderate(ctx, c.moderator, opts.CallbackManger, schema.ModerationStageInput, c.opts.InputKeys, inputs)
+		if err != nil {
+			return nil, err
+		}
+
+		flagged = violated
+	}
+
+	outputs, err := golc.Call(ctx, c.chain, inputs, func(co *golc.CallOptions) {
+		co.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
+		co.ParentRunID = opts.CallbackManger.RunID()
+		co.Stop = opts.Stop
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.opts.SkipOutputs {
+		violated, err := c.moderate(ctx, c.opts.OutputModerator, opts.CallbackManger, schema.ModerationStageOutput, c.opts.OutputKeys, outputs)
+		if err != nil {
+			return nil, err
+		}
+
+		flagged = flagged || violated
+	}
+
+	if c.opts.Mode == ModeFlag {
+		outputs = outputs.Clone()
+		outputs[c.opts.FlaggedKey] = flagged
+	}
+
+	return outputs, nil
+}
+
+// moderate checks the string values of the keys with the moderator. It reports violations to the callbacks and returns
+// a ViolationError in ModeReject. Missing keys and values, which are not strings, are skipped.
+func (c *Chain) moderate(ctx context.Context, moderator schema.Moderator, cm schema.CallbackManagerForChainRun, stage schema.ModerationStage, keys []string, values schema.ChainValues) (bool, error) {
+	flagged := false
+
+	for _, key := range keys {
+		text, ok := values[key].(string)
+		if !ok || text == "" {
+			continue
+		}
+
+		result, err := moderator.Moderate(ctx, text)
+		if err != nil {
+			return false, err
+		}
+
+		if !result.Flagged {
+			continue
+		}
+
+		if cbErr := cm.OnModerationViolation(ctx, &schema.ModerationViolationManagerInput{
+			ModeratorType: moderatorType(moderator),
+			Stage:         stage,
+			Key:           key,
+			Text:          text,
+			Result:        result,
+		}); cbErr != nil {
+			return false, cbErr
+		}
+
+		if c.opts.Mode != ModeFlag {
+			return false, &ViolationError{
+				Stage:  stage,
+				Key:    key,
+				Result: result,
+			}
+		}
+
+		flagged = true
+	}
+
+	return flagged, nil
+}
+
+// Memory returns the memory associated with the chain. The memory of the wrapped chain is used by its run.
+func (c *Chain) Memory() schema.Memory {
+	return nil
+}
+
+// Type returns the type of the chain.
+func (c *Chain) Type() string {
+	return "Moderation"
+}
+
+// Verbose returns the verbosity setting of the chain.
+func (c *Chain) Verbose() bool {
+	return c.opts.CallbackOptions.Verbose
+}
+
+// Callbacks returns the callbacks associated with the chain.
+func (c *Chain) Callbacks() []schema.Callback {
+	return c.opts.CallbackOptions.Callbacks
+}
+
+// InputKeys returns the expected input keys.
+func (c *Chain) InputKeys() []string {
+	return c.chain.InputKeys()
+}
+
+// OutputKeys returns the output keys the chain will return.
+func (c *Chain) OutputKeys() []string {
+	if c.opts.Mode == ModeFlag {
+		return append(append([]string{}, c.chain.OutputKeys()...), c.opts.FlaggedKey)
+	}
+
+	return c.chain.OutputKeys()
+}
@@ -0,0 +1,135 @@
+package moderation
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/chain"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain(t *testing.T) {
+	echo, err := chain.NewTransform([]string{"input"}, []string{"output"}, func(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+		return schema.ChainValues{"output": "Echo: " + inputs["input"].(string)}, nil
+	})
+	require.NoError(t, err)
+
+	moderator := &fakeModerator{flagged: "bad"}
+
+	t.Run("Pass", func(t *testing.T) {
+		outputs, err := golc.Call(context.Background(), NewChain(echo, moderator), schema.ChainValues{"input": "good"})
+		require.NoError(t, err)
+		require.Equal(t, schema.ChainValues{"output": "Echo: good"}, outputs)
+	})
+
+	t.Run("RejectInput", func(t *testing.T) {
+		handler := &moderationHandler{}
+
+		_, err := golc.Call(context.Background(), NewChain(echo, moderator), schema.ChainValues{"input": "bad"}, func(o *golc.CallOptions) {
+			o.Callbacks = []schema.Callback{handler}
+		})
+		require.ErrorIs(t, err, ErrContentPolicyViolation)
+
+		var violationErr *ViolationError
+		require.ErrorAs(t, err, &violationErr)
+		require.Equal(t, schema.ModerationStageInput, violationErr.Stage)
+		require.Equal(t, "input", violationErr.Key)
+		require.EqualError(t, err, "content policy violation: input input (harassment)")
+
+		require.Len(t, handler.violations, 1)
+		require.Equal(t, "FakeModeration", handler.violations[0].ModeratorType)
+		require.Equal(t, "bad", handler.violations[0].Text)
+	})
+
+	t.Run("RejectOutput", func(t *testing.T) {
+		_, err := golc.Call(context.Background(), NewChain(echo, moderator, func(o *ChainOptions) {
+			o.SkipInputs = true
+		}), schema.ChainValues{"input": "bad"})
+
+		var violationErr *ViolationError
+		require.ErrorAs(t, err, &violationErr)
+		require.Equal(t, schema.ModerationStageOutput, violationErr.Stage)
+		require.Equal(t, "output", violationErr.Key)
+	})
+
+	t.Run("Flag", func(t *testing.T) {
+		handler := &moderationHandler{}
+
+		c := NewChain(echo, moderator, func(o *ChainOptions) {
+			o.Mode = ModeFlag
+		})
+		require.Equal(t, []string{"output", "flagged"}, c.OutputKeys())
+
+		outputs, err := golc.Call(context.Background(), c, schema.ChainValues{"input": "bad"}, func(o *golc.CallOptions) {
+			o.Callbacks = []schema.Callback{handler}
+		})
+		require.NoError(t, err)
+		require.Equal(t, schema.ChainValues{"output": "Echo: bad", "flagged": true}, outputs)
+
+		require.Len(t, handler.violations, 2)
+		require.Equal(t, schema.ModerationStageInput, handler.violations[0].Stage)
+		require.Equal(t, schema.ModerationStageOutput, handler.violations[1].Stage)
+
+		outputs, err = golc.Call(context.Background(), c, schema.ChainValues{"input": "good"})
+		require.NoError(t, err)
+		require.Equal(t, false, outputs["flagged"])
+	})
+}
+
+func TestChainOutputModerator(t *testing.T) {
+	echo, err := chain.NewTransform([]string{"input"}, []string{"output"}, func(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+		return schema.ChainValues{"output": "Echo: " + inputs["input"].(string)}, nil
+	})
+	require.NoError(t, err)
+
+	c := NewChain(echo, &fakeModerator{flagged: "unsafe"}, func(o *ChainOptions) {
+		o.OutputModerator = Combine(&fakeModerator{flagged: "unsafe"}, &fakeModerator{flagged: "Echo"})
+	})
+
+	_, err = golc.Call(context.Background(), c, schema.ChainValues{"input": "hello"})
+
+	var violationErr *ViolationError
+	require.ErrorAs(t, err, &violationErr)
+	require.Equal(t, schema.ModerationStageOutput, violationErr.Stage)
+	require.Len(t, violationErr.Result.Categories, 4)
+	require.Equal(t, "FakeModeration,FakeModeration", moderatorType(Combine(&fakeModerator{}, &fakeModerator{})))
+}
+
+type fakeModerator struct {
+	flagged string
+}
+
+func (m *fakeModerator) Moderate(ctx context.Context, text string) (*schema.ModerationResult, error) {
+	flagged := strings.Contains(text, m.flagged)
+
+	return &schema.ModerationResult{
+		Flagged: flagged,
+		Categories: []schema.ModerationCategory{
+			{Name: "harassment", Score: 0.9, Flagged: flagged},
+			{Name: "violence", Score: 0.1},
+		},
+	}, nil
+}
+
+func (m *fakeModerator) Type() string {
+	return "FakeModeration"
+}
+
+// moderationHandler records the moderation violations.
+type moderationHandler struct {
+	callback.NoopHandler
+	violations []*schema.ModerationViolationInput
+}
+
+func (h *moderationHandler) AlwaysVerbose() bool {
+	return true
+}
+
+func (h *moderationHandler) OnModerationViolation(ctx context.Context, input *schema.ModerationViolationInput) error {
+	h.violations = append(h.violations, input)
+	return nil
+}
@@ -1,2 +1,91 @@
 // Package moderation provides moderation capabilities using different nlp services.
 package moderation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// ErrContentPolicyViolation is returned if a text violates the content policy.
+var ErrContentPolicyViolation = errors.New("content policy violation")
+
+// ViolationError is returned by the Chain wrapper if a moderated input or output violates the content policy.
+// It wraps ErrContentPolicyViolation.
+type ViolationError struct {
+	// Stage is the stage of the violation, i.e. input or output.
+	Stage schema.ModerationStage
+	// Key is the key of the violating chain value.
+	Key string
+	// Result is the moderation result of the violating text.
+	Result *schema.ModerationResult
+}
+
+// Error returns the error message.
+func (e *ViolationError) Error() string {
+	msg := fmt.Sprintf("%s: %s %s", ErrContentPolicyViolation, e.Stage, e.Key)
+
+	if categories := e.Result.FlaggedCategories(); len(categories) > 0 {
+		msg = fmt.Sprintf("%s (%s)", msg, strings.Join(categories, ", "))
+	}
+
+	return msg
+}
+
+// Unwrap returns ErrContentPolicyViolation.
+func (e *ViolationError) Unwrap() error {
+	return ErrContentPolicyViolation
+}
+
+// Compile time check to ensure combined satisfies the Moderator interface.
+var _ schema.Moderator = (combined)(nil)
+
+// combined is a moderator, which checks texts with multiple moderators.
+type combined []schema.Moderator
+
+// Combine returns a moderator, which checks texts with all moderators, e.g. for PII and toxicity. The text
+// is flagged, if one of the moderators flags it, and the categories of all moderators are returned.
+func Combine(moderators ...schema.Moderator) schema.Moderator {
+	return combined(moderators)
+}
+
+// Moderate checks the text with all moderators and merges the moderation results.
+func (c combined) Moderate(ctx context.Context, text string) (*schema.ModerationResult, error) {
+	result := &schema.ModerationResult{
+		Categories: []schema.ModerationCategory{},
+	}
+
+	for _, m := range c {
+		r, err := m.Moderate(ctx, text)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Flagged = result.Flagged || r.Flagged
+		result.Categories = append(result.Categories, r.Categories...)
+	}
+
+	return result, nil
+}
+
+// Type returns the types of the combined moderators.
+func (c combined) Type() string {
+	types := make([]string, len(c))
+	for i, m := range c {
+		types[i] = moderatorType(m)
+	}
+
+	return strings.Join(types, ",")
+}
+
+// moderatorType returns the type of the moderator, if it provides one.
+func moderatorType(moderator schema.Moderator) string {
+	if t, ok := moderator.(interface{ Type() string }); ok {
+		return t.Type()
+	}
+
+	return fmt.Sprintf("%T", moderator)
+}
@@ -2,7 +2,9 @@ package moderation
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"sort"
 
 	"github.com/hupe1980/golc"
 	"github.com/hupe1980/golc/callback"
@@ -10,8 +12,11 @@ import (
 	"github.com/sashabaranov/go-openai"
 )
 
-// Compile time check to ensure OpenAI satisfies the Chain interface.
-var _ schema.Chain = (*OpenAI)(nil)
+// Compile time check to ensure OpenAI satisfies the Chain and Moderator interfaces.
+var (
+	_ schema.Chain     = (*OpenAI)(nil)
+	_ schema.Moderator = (*OpenAI)(nil)
+)
 
 // OpenAIClient is an interface representing an OpenAI client that can make moderation requests.
 type OpenAIClient interface {
@@ -87,6 +92,32 @@ func (c *OpenAI) Call(ctx context.Context, inputs schema.ChainValues, optFns ...
 		return nil, cbErr
 	}
 
+	res, err := c.Moderate(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Flagged {
+		if cbErr := opts.CallbackManger.OnModerationViolation(ctx, &schema.ModerationViolationManagerInput{
+			ModeratorType: c.Type(),
+			Stage:         schema.ModerationStageInput,
+			Key:           c.opts.InputKey,
+			Text:          text,
+			Result:        res,
+		}); cbErr != nil {
+			return nil, cbErr
+		}
+
+		return nil, ErrContentPolicyViolation
+	}
+
+	return schema.ChainValues{
+		c.opts.OutputKey: text,
+	}, nil
+}
+
+// Moderate checks the text with the OpenAI moderation API and returns the moderation result.
+func (c *OpenAI) Moderate(ctx context.Context, text string) (*schema.ModerationResult, error) {
 	res, err := c.client.Moderations(ctx, openai.ModerationRequest{
 		Model: c.opts.ModelName,
 		Input: text,
@@ -95,12 +126,18 @@ func (c *OpenAI) Call(ctx context.Context, inputs schema.ChainValues, optFns ...
 		return nil, err
 	}
 
-	if res.Results[0].Flagged {
-		return nil, errors.New("content policy violation")
+	if len(res.Results) == 0 {
+		return nil, errors.New("no moderation result")
 	}
 
-	return schema.ChainValues{
-		c.opts.OutputKey: text,
+	categories, err := openAICategories(res.Results[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.ModerationResult{
+		Flagged:    res.Results[0].Flagged,
+		Categories: categories,
 	}, nil
 }
 
@@ -133,3 +170,45 @@ func (c *OpenAI) InputKeys() []string {
 func (c *OpenAI) OutputKeys() []string {
 	return []string{c.opts.OutputKey}
 }
+
+// openAICategories converts the categories and scores of the result, named by their API names,
+// e.g. hate/threatening.
+func openAICategories(result openai.Result) ([]schema.ModerationCategory, error) {
+	var (
+		flags  map[string]bool
+		scores map[string]float32
+	)
+
+	if err := remarshal(result.Categories, &flags); err != nil {
+		return nil, err
+	}
+
+	if err := remarshal(result.CategoryScores, &scores); err != nil {
+		return nil, err
+	}
+
+	categories := make([]schema.ModerationCategory, 0, len(flags))
+	for name, flagged := range flags {
+		categories = append(categories, schema.ModerationCategory{
+			Name:    name,
+			Score:   scores[name],
+			Flagged: flagged,
+		})
+	}
+
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i].Name < categories[j].Name
+	})
+
+	return categories, nil
+}
+
+// remarshal converts the value by encoding it as JSON and decoding it into the target.
+func remarshal(v, target any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, target)
+}
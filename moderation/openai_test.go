@@ -72,3 +72,22 @@ type fakeOpenAIClient struct {
 func (c *fakeOpenAIClient) Moderations(ctx context.Context, request openai.ModerationRequest) (openai.ModerationResponse, error) {
 	return c.response, c.err
 }
+
+func TestOpenAIModerate(t *testing.T) {
+	fakeClient := &fakeOpenAIClient{
+		response: openai.ModerationResponse{
+			Results: []openai.Result{{
+				Flagged:        true,
+				Categories:     openai.ResultCategories{Violence: true},
+				CategoryScores: openai.ResultCategoryScores{Violence: 0.9, Hate: 0.2},
+			}},
+		},
+	}
+
+	result, err := NewOpenAIFromClient(fakeClient).Moderate(context.Background(), "Some flagged text")
+	assert.NoError(t, err)
+	assert.True(t, result.Flagged)
+	assert.Equal(t, []string{"violence"}, result.FlaggedCategories())
+	assert.Len(t, result.Categories, 11)
+	assert.Equal(t, schema.ModerationCategory{Name: "hate", Score: 0.2}, result.Categories[2])
+}
@@ -0,0 +1,127 @@
+package moderation
+
+import (
+	"context"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure PIIChain satisfies the Chain interface.
+var _ schema.Chain = (*PIIChain)(nil)
+
+// PIIChainOptions contains options for configuring the PIIChain wrapper.
+type PIIChainOptions struct {
+	// CallbackOptions embeds CallbackOptions to include the verbosity setting and callbacks.
+	*schema.CallbackOptions
+	// InputKeys are the keys of the inputs to scrub. Defaults to the input keys of the wrapped chain.
+	InputKeys []string
+	// OutputKeys are the keys of the outputs to rehydrate. Defaults to the output keys of the wrapped chain.
+	OutputKeys []string
+	// SkipRehydration returns the outputs with the tokens instead of the original values.
+	SkipRehydration bool
+}
+
+// PIIChain wraps a chain and replaces the PII entities of its inputs with tokens, so the wrapped chain,
+// e.g. a model, never sees the original values. The tokens in the outputs are rehydrated with the
+// original values.
+type PIIChain struct {
+	chain    schema.Chain
+	scrubber *PIIScrubber
+	opts     PIIChainOptions
+}
+
+// NewPIIChain creates a new PIIChain wrapper, which scrubs the inputs of the chain with the scrubber.
+func NewPIIChain(chain schema.Chain, scrubber *PIIScrubber, optFns ...func(o *PIIChainOptions)) *PIIChain {
+	opts := PIIChainOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		InputKeys:  chain.InputKeys(),
+		OutputKeys: chain.OutputKeys(),
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &PIIChain{
+		chain:    chain,
+		scrubber: scrubber,
+		opts:     opts,
+	}
+}
+
+// Call scrubs the inputs, executes the wrapped chain and rehydrates its outputs.
+// It returns the outputs of the chain or an error, if any.
+func (c *PIIChain) Call(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+	opts := schema.CallOptions{
+		CallbackManger: &callback.NoopManager{},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	scrubbed := inputs.Clone()
+
+	for _, key := range c.opts.InputKeys {
+		if text, ok := scrubbed[key].(string); ok {
+			scrubbed[key], _ = c.scrubber.Scrub(text)
+		}
+	}
+
+	outputs, err := golc.Call(ctx, c.chain, scrubbed, func(co *golc.CallOptions) {
+		co.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
+		co.ParentRunID = opts.CallbackManger.RunID()
+		co.Stop = opts.Stop
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if c.opts.SkipRehydration {
+		return outputs, nil
+	}
+
+	outputs = outputs.Clone()
+
+	for _, key := range c.opts.OutputKeys {
+		if text, ok := outputs[key].(string); ok {
+			outputs[key] = c.scrubber.Rehydrate(text)
+		}
+	}
+
+	return outputs, nil
+}
+
+// Memory returns the memory associated with the chain. The memory of the wrapped chain is used by its run.
+func (c *PIIChain) Memory() schema.Memory {
+	return nil
+}
+
+// Type returns the type of the chain.
+func (c *PIIChain) Type() string {
+	return "PIIRedaction"
+}
+
+// Verbose returns the verbosity setting of the chain.
+func (c *PIIChain) Verbose() bool {
+	return c.opts.CallbackOptions.Verbose
+}
+
+// Callbacks returns the callbacks associated with the chain.
+func (c *PIIChain) Callbacks() []schema.Callback {
+	return c.opts.CallbackOptions.Callbacks
+}
+
+// InputKeys returns the expected input keys.
+func (c *PIIChain) InputKeys() []string {
+	return c.chain.InputKeys()
+}
+
+// OutputKeys returns the output keys the chain will return.
+func (c *PIIChain) OutputKeys() []string {
+	return c.chain.OutputKeys()
+}
@@ -0,0 +1,460 @@
+package moderation
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure PIIScrubber satisfies the Moderator and DocumentTransformer interfaces.
+var (
+	_ schema.Moderator           = (*PIIScrubber)(nil)
+	_ schema.DocumentTransformer = (*PIIScrubber)(nil)
+)
+
+// PIIRule is a rule for detecting PII entities of a type.
+type PIIRule struct {
+	// Type is the entity type, e.g. EMAIL. It is used in the tokens of the entities.
+	Type string
+	// Pattern matches the candidates of the entities.
+	Pattern *regexp.Regexp
+	// Validate optionally checks the candidates, e.g. the checksum of a credit card number.
+	Validate func(match string) bool
+}
+
+// DefaultPIIRules are the rules for emails, phone numbers, credit card numbers, US social security numbers
+// and UK national insurance numbers. Overlapping matches of the same length are resolved in favor of the
+// rule listed first, e.g. social security numbers are not detected as phone numbers.
+var DefaultPIIRules = []PIIRule{
+	{
+		Type:    "EMAIL",
+		Pattern: regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`),
+	},
+	{
+		Type:     "CREDIT_CARD",
+		Pattern:  regexp.MustCompile(`\b(?:\d[ \-]?){12,18}\d\b`),
+		Validate: validateCreditCard,
+	},
+	{
+		Type:     "US_SSN",
+		Pattern:  regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`),
+		Validate: validateSSN,
+	},
+	{
+		Type:    "UK_NINO",
+		Pattern: regexp.MustCompile(`\b[A-CEGHJ-PR-TW-Z][A-CEGHJ-NPR-TW-Z] ?\d{2} ?\d{2} ?\d{2} ?[A-D]\b`),
+	},
+	{
+		Type:     "PHONE_NUMBER",
+		Pattern:  regexp.MustCompile(`(?:\+\d{1,3}[ .\-]?)?(?:\(\d{1,4}\)[ .\-]?|\b)\d{2,8}(?:[ .\-]\d{2,8}){0,4}\b`),
+		Validate: validatePhoneNumber,
+	},
+}
+
+// PIIEntity is a PII entity detected in a text.
+type PIIEntity struct {
+	// Type is the entity type, e.g. EMAIL.
+	Type string
+	// Value is the original text of the entity.
+	Value string
+	// Token is the token, which replaces the entity.
+	Token string
+	// Start is the byte offset of the entity in the original text.
+	Start int
+	// End is the byte offset after the entity in the original text.
+	End int
+}
+
+// PIIVault maps the tokens of PII entities to their original values. The tokens are derived from the type
+// and the value of the entities with a keyed HMAC, e.g. <EMAIL_3f9a2b7c1d4e>, so equal values of the same
+// type are replaced by the same token and the relations in a text are preserved. A token never refers to
+// another value, even in a vault with a different key: unknown tokens are not rehydrated. Save the vault
+// and load it after a restart to rehydrate texts scrubbed before, e.g. documents scrubbed during indexing.
+// The vault is safe for concurrent use.
+type PIIVault struct {
+	mu     sync.RWMutex
+	key    []byte
+	values map[string]string
+}
+
+// PIIVaultOptions contains options for configuring the PIIVault.
+type PIIVaultOptions struct {
+	// Key is the secret key of the HMAC, which derives the tokens. Vaults with the same key assign the same
+	// tokens. Defaults to a random key.
+	Key []byte
+}
+
+// NewPIIVault creates a new empty PIIVault.
+func NewPIIVault(optFns ...func(o *PIIVaultOptions)) *PIIVault {
+	opts := PIIVaultOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if len(opts.Key) == 0 {
+		opts.Key = make([]byte, 32)
+		if _, err := rand.Read(opts.Key); err != nil {
+			panic(fmt.Sprintf("cannot generate pii vault key: %v", err))
+		}
+	}
+
+	return &PIIVault{
+		key:    opts.Key,
+		values: map[string]string{},
+	}
+}
+
+// piiTokenLength is the number of hex digits of the hmac in the tokens.
+const piiTokenLength = 12
+
+// Tokenize returns the token of the value, e.g. <EMAIL_3f9a2b7c1d4e>, and stores the value.
+func (v *PIIVault) Tokenize(entityType, value string) string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	mac := hmac.New(sha256.New, v.key)
+	mac.Write([]byte(entityType + "\x00" + value))
+	sum := hex.EncodeToString(mac.Sum(nil))
+
+	// Extend the token in the unlikely case of a collision with another value.
+	for n := piiTokenLength; ; n += 4 {
+		token := fmt.Sprintf("<%s_%s>", entityType, sum[:min(n, len(sum))])
+
+		if existing, ok := v.values[token]; !ok || existing == value || n >= len(sum) {
+			v.values[token] = value
+			return token
+		}
+	}
+}
+
+// Lookup returns the original value of the token.
+func (v *PIIVault) Lookup(token string) (string, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	value, ok := v.values[token]
+
+	return value, ok
+}
+
+// piiTokenPattern matches the tokens of the vault.
+var piiTokenPattern = regexp.MustCompile(`<[A-Z][A-Z0-9_]*_[0-9a-f]{12,64}>`)
+
+// Rehydrate replaces the known tokens in the text with their original values. Unknown tokens are kept.
+func (v *PIIVault) Rehydrate(text string) string {
+	return piiTokenPattern.ReplaceAllStringFunc(text, func(token string) string {
+		if value, ok := v.Lookup(token); ok {
+			return value
+		}
+
+		return token
+	})
+}
+
+// piiVaultData is the persisted state of a PIIVault.
+type piiVaultData struct {
+	Key    []byte
+	Values map[string]string
+}
+
+// Load loads the key and the values from an io.Reader. They replace the key and the values of the vault.
+func (v *PIIVault) Load(r io.Reader) error {
+	data := piiVaultData{}
+
+	if err := gob.NewDecoder(r).Decode(&data); err != nil {
+		return err
+	}
+
+	if len(data.Key) == 0 {
+		return errors.New("missing pii vault key")
+	}
+
+	if data.Values == nil {
+		data.Values = map[string]string{}
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.key = data.Key
+	v.values = data.Values
+
+	return nil
+}
+
+// Save saves the key and the values to an io.Writer. The saved vault contains the original PII values and
+// the key; store it encrypted and with restricted access.
+func (v *PIIVault) Save(w io.Writer) error {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	return gob.NewEncoder(w).Encode(piiVaultData{
+		Key:    v.key,
+		Values: v.values,
+	})
+}
+
+// PIIScrubberOptions contains options for configuring the PIIScrubber.
+type PIIScrubberOptions struct {
+	// Rules are the rules for detecting PII entities. Defaults to DefaultPIIRules.
+	Rules []PIIRule
+	// Vault stores the tokens of the entities. Share a vault to rehydrate texts scrubbed by other scrubbers,
+	// e.g. answers based on documents scrubbed during indexing. Defaults to a new vault.
+	Vault *PIIVault
+}
+
+// PIIScrubber detects PII entities with rules based on regular expressions and replaces them with tokens,
+// which can be rehydrated with the original values.
+type PIIScrubber struct {
+	opts PIIScrubberOptions
+}
+
+// NewPIIScrubber creates a new PIIScrubber with the provided options.
+func NewPIIScrubber(optFns ...func(o *PIIScrubberOptions)) *PIIScrubber {
+	opts := PIIScrubberOptions{
+		Rules: DefaultPIIRules,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.Vault == nil {
+		opts.Vault = NewPIIVault()
+	}
+
+	return &PIIScrubber{
+		opts: opts,
+	}
+}
+
+// Vault returns the vault of the scrubber.
+func (s *PIIScrubber) Vault() *PIIVault {
+	return s.opts.Vault
+}
+
+// Detect returns the PII entities of the text ordered by their offsets. Overlapping matches are resolved
+// in favor of the longer match and, for matches of the same length, the rule listed first.
+func (s *PIIScrubber) Detect(text string) []PIIEntity {
+	type candidate struct {
+		rule       int
+		start, end int
+	}
+
+	candidates := []candidate{}
+
+	for i, rule := range s.opts.Rules {
+		for _, loc := range rule.Pattern.FindAllStringIndex(text, -1) {
+			if rule.Validate != nil && !rule.Validate(text[loc[0]:loc[1]]) {
+				continue
+			}
+
+			candidates = append(candidates, candidate{rule: i, start: loc[0], end: loc[1]})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+
+		if a.end-a.start != b.end-b.start {
+			return a.end-a.start > b.end-b.start
+		}
+
+		if a.rule != b.rule {
+			return a.rule < b.rule
+		}
+
+		return a.start < b.start
+	})
+
+	entities := []PIIEntity{}
+
+	for _, c := range candidates {
+		overlaps := false
+
+		for _, e := range entities {
+			if c.start < e.End && e.Start < c.end {
+				overlaps = true
+				break
+			}
+		}
+
+		if overlaps {
+			continue
+		}
+
+		entities = append(entities, PIIEntity{
+			Type:  s.opts.Rules[c.rule].Type,
+			Value: text[c.start:c.end],
+			Start: c.start,
+			End:   c.end,
+		})
+	}
+
+	sort.Slice(entities, func(i, j int) bool {
+		return entities[i].Start < entities[j].Start
+	})
+
+	return entities
+}
+
+// Scrub replaces the PII entities of the text with tokens and returns the scrubbed text and the entities.
+func (s *PIIScrubber) Scrub(text string) (string, []PIIEntity) {
+	entities := s.Detect(text)
+	if len(entities) == 0 {
+		return text, entities
+	}
+
+	b := new(strings.Builder)
+	offset := 0
+
+	for i, e := range entities {
+		entities[i].Token = s.opts.Vault.Tokenize(e.Type, e.Value)
+
+		b.WriteString(text[offset:e.Start])
+		b.WriteString(entities[i].Token)
+
+		offset = e.End
+	}
+
+	b.WriteString(text[offset:])
+
+	return b.String(), entities
+}
+
+// Rehydrate replaces the tokens in the text with the original values.
+func (s *PIIScrubber) Rehydrate(text string) string {
+	return s.opts.Vault.Rehydrate(text)
+}
+
+// Moderate detects the PII entities of the text. The text is flagged, if it contains PII; the entity types
+// found are returned as categories.
+func (s *PIIScrubber) Moderate(ctx context.Context, text string) (*schema.ModerationResult, error) {
+	types := []string{}
+
+	for _, e := range s.Detect(text) {
+		if !util.Contains(types, e.Type) {
+			types = append(types, e.Type)
+		}
+	}
+
+	result := &schema.ModerationResult{
+		Flagged:    len(types) > 0,
+		Categories: make([]schema.ModerationCategory, len(types)),
+	}
+
+	for i, t := range types {
+		result.Categories[i] = schema.ModerationCategory{
+			Name:    t,
+			Score:   1,
+			Flagged: true,
+		}
+	}
+
+	return result, nil
+}
+
+// Transform scrubs the page contents of the documents, e.g. before they are indexed.
+func (s *PIIScrubber) Transform(ctx context.Context, docs []schema.Document) ([]schema.Document, error) {
+	scrubbed := make([]schema.Document, len(docs))
+
+	for i, doc := range docs {
+		doc.PageContent, _ = s.Scrub(doc.PageContent)
+		scrubbed[i] = doc
+	}
+
+	return scrubbed, nil
+}
+
+// Type returns the type of the moderator.
+func (s *PIIScrubber) Type() string {
+	return "PIIScrubber"
+}
+
+// piiDigits returns the digits of the match.
+func piiDigits(match string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+
+		return -1
+	}, match)
+}
+
+// validateCreditCard checks the length and the Luhn checksum of a credit card number.
+func validateCreditCard(match string) bool {
+	digits := piiDigits(match)
+	if len(digits) < 13 || len(digits) > 19 {
+		return false
+	}
+
+	sum := 0
+
+	for i := 0; i < len(digits); i++ {
+		d := int(digits[len(digits)-1-i] - '0')
+
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+
+		sum += d
+	}
+
+	return sum%10 == 0
+}
+
+// validateSSN rejects social security numbers with invalid area, group or serial numbers.
+func validateSSN(match string) bool {
+	area, group, serial := match[0:3], match[4:6], match[7:11]
+
+	return area != "000" && area != "666" && area[0] != '9' && group != "00" && serial != "0000"
+}
+
+// piiDatePattern matches dates, which are no phone numbers.
+var piiDatePattern = regexp.MustCompile(`^\d{4}[\-.]\d{1,2}[\-.]\d{1,2}$|^\d{1,2}[\-.]\d{1,2}[\-.]\d{2,4}$`)
+
+// piiIPv4Pattern matches IPv4 addresses, which are no phone numbers.
+var piiIPv4Pattern = regexp.MustCompile(`^\d{1,3}(?:\.\d{1,3}){3}$`)
+
+// validatePhoneNumber requires 7 to 15 digits and a country code, parentheses or separators, so plain
+// numbers, dates and IP addresses are not detected as phone numbers. Numbers without country code or
+// parentheses require a trunk prefix (0) or at least 10 digits, so grouped digit runs like 1234 5678 are
+// not detected either.
+func validatePhoneNumber(match string) bool {
+	digits := piiDigits(match)
+	if len(digits) < 7 || len(digits) > 15 {
+		return false
+	}
+
+	if piiDatePattern.MatchString(match) || piiIPv4Pattern.MatchString(match) {
+		return false
+	}
+
+	if strings.ContainsAny(match, "+(") {
+		return true
+	}
+
+	if !strings.ContainsAny(match, " -.") {
+		return false
+	}
+
+	return digits[0] == '0' || len(digits) >= 10
+}
@@ -0,0 +1,176 @@
+package moderation
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/chain"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPIIScrubber(t *testing.T) {
+	t.Run("Detect", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			text     string
+			expected []string
+		}{
+			{"Email", "Contact jane.doe@example.co.uk today", []string{"EMAIL:jane.doe@example.co.uk"}},
+			{"Phone", "Call +1 (555) 123-4567 or 030 1234567", []string{"PHONE_NUMBER:+1 (555) 123-4567", "PHONE_NUMBER:030 1234567"}},
+			{"CreditCard", "Card 4111 1111 1111 1111 and 4111 1111 1111 1112", []string{"CREDIT_CARD:4111 1111 1111 1111"}},
+			{"SSN", "SSN 123-45-6789", []string{"US_SSN:123-45-6789"}},
+			{"NINO", "NINO AB 12 34 56 C", []string{"UK_NINO:AB 12 34 56 C"}},
+			{"NoPII", "Order 12345 shipped on 2024-01-15 for 99.95 EUR", nil},
+		}
+
+		scrubber := NewPIIScrubber()
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				var found []string
+				for _, e := range scrubber.Detect(tc.text) {
+					require.Equal(t, e.Value, tc.text[e.Start:e.End])
+					found = append(found, e.Type+":"+e.Value)
+				}
+
+				require.Equal(t, tc.expected, found)
+			})
+		}
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		require.True(t, validateSSN("123-45-6789"))
+		require.False(t, validateSSN("000-12-3456"))
+		require.False(t, validateSSN("666-12-3456"))
+		require.False(t, validateSSN("923-45-6789"))
+		require.True(t, validateCreditCard("4111-1111-1111-1111"))
+		require.False(t, validateCreditCard("4111-1111-1111-1112"))
+		require.False(t, validatePhoneNumber("2024-01-15"))
+		require.False(t, validatePhoneNumber("1234567"))
+		require.False(t, validatePhoneNumber("192.168.100.200"))
+		require.False(t, validatePhoneNumber("10.0.0.1"))
+		require.False(t, validatePhoneNumber("1234 5678"))
+		require.False(t, validatePhoneNumber("1234-5678"))
+		require.True(t, validatePhoneNumber("030 1234567"))
+		require.True(t, validatePhoneNumber("555.123.4567"))
+		require.True(t, validatePhoneNumber("+49 30 1234567"))
+	})
+
+	t.Run("NoPhoneNumbers", func(t *testing.T) {
+		require.Empty(t, NewPIIScrubber().Detect("Server 192.168.100.200 handled ticket 1234 5678"))
+	})
+
+	t.Run("ScrubAndRehydrate", func(t *testing.T) {
+		scrubber := NewPIIScrubber()
+
+		text := "Mail jane@example.com and john@example.com, again jane@example.com"
+
+		scrubbed, entities := scrubber.Scrub(text)
+		require.Len(t, entities, 3)
+		require.Regexp(t, `^<EMAIL_[0-9a-f]{12}>$`, entities[0].Token)
+		require.NotEqual(t, entities[0].Token, entities[1].Token)
+		require.Equal(t, entities[0].Token, entities[2].Token)
+		require.Equal(t, "Mail "+entities[0].Token+" and "+entities[1].Token+", again "+entities[0].Token, scrubbed)
+
+		require.Equal(t, text, scrubber.Rehydrate(scrubbed))
+		require.Equal(t, "Unknown <EMAIL_0123456789ab>", scrubber.Rehydrate("Unknown <EMAIL_0123456789ab>"))
+	})
+
+	t.Run("Vault", func(t *testing.T) {
+		key := []byte("secret")
+
+		vault := NewPIIVault(func(o *PIIVaultOptions) {
+			o.Key = key
+		})
+		token := vault.Tokenize("EMAIL", "jane@example.com")
+
+		// Vaults with the same key assign the same tokens.
+		same := NewPIIVault(func(o *PIIVaultOptions) {
+			o.Key = key
+		})
+		require.Equal(t, token, same.Tokenize("EMAIL", "jane@example.com"))
+
+		// A new vault never rehydrates the tokens of another vault with another value.
+		other := NewPIIVault()
+		require.NotEqual(t, token, other.Tokenize("EMAIL", "john@example.com"))
+		require.Equal(t, token, other.Rehydrate(token))
+
+		// A loaded vault rehydrates the tokens after a restart.
+		b := &bytes.Buffer{}
+		require.NoError(t, vault.Save(b))
+
+		restored := NewPIIVault()
+		require.NoError(t, restored.Load(b))
+		require.Equal(t, "jane@example.com", restored.Rehydrate(token))
+		require.Equal(t, token, restored.Tokenize("EMAIL", "jane@example.com"))
+	})
+
+	t.Run("CustomRules", func(t *testing.T) {
+		scrubber := NewPIIScrubber(func(o *PIIScrubberOptions) {
+			o.Rules = append(DefaultPIIRules, PIIRule{
+				Type:    "EMPLOYEE_ID",
+				Pattern: regexp.MustCompile(`\bEMP-\d{5}\b`),
+			})
+		})
+
+		scrubbed, _ := scrubber.Scrub("Employee EMP-12345")
+		require.Regexp(t, `^Employee <EMPLOYEE_ID_[0-9a-f]{12}>$`, scrubbed)
+	})
+
+	t.Run("Moderate", func(t *testing.T) {
+		result, err := NewPIIScrubber().Moderate(context.Background(), "Mail jane@example.com or call 555-123-4567")
+		require.NoError(t, err)
+		require.True(t, result.Flagged)
+		require.Equal(t, []string{"EMAIL", "PHONE_NUMBER"}, result.FlaggedCategories())
+
+		result, err = NewPIIScrubber().Moderate(context.Background(), "Hello world")
+		require.NoError(t, err)
+		require.False(t, result.Flagged)
+	})
+
+	t.Run("Transform", func(t *testing.T) {
+		vault := NewPIIVault()
+		scrubber := NewPIIScrubber(func(o *PIIScrubberOptions) {
+			o.Vault = vault
+		})
+
+		docs, err := scrubber.Transform(context.Background(), []schema.Document{
+			{PageContent: "Jane's email is jane@example.com", Metadata: map[string]any{"source": "a.txt"}},
+		})
+		require.NoError(t, err)
+		token := vault.Tokenize("EMAIL", "jane@example.com")
+		require.Equal(t, []schema.Document{
+			{PageContent: "Jane's email is " + token, Metadata: map[string]any{"source": "a.txt"}},
+		}, docs)
+
+		require.Equal(t, "jane@example.com", vault.Rehydrate(token))
+	})
+}
+
+func TestPIIChain(t *testing.T) {
+	var received string
+
+	echo, err := chain.NewTransform([]string{"input"}, []string{"output"}, func(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+		received = inputs["input"].(string)
+		return schema.ChainValues{"output": "I will write to " + received}, nil
+	})
+	require.NoError(t, err)
+
+	scrubber := NewPIIScrubber()
+
+	outputs, err := golc.Call(context.Background(), NewPIIChain(echo, scrubber), schema.ChainValues{"input": "jane@example.com"})
+	require.NoError(t, err)
+	token := scrubber.Vault().Tokenize("EMAIL", "jane@example.com")
+	require.Equal(t, token, received)
+	require.Equal(t, "I will write to jane@example.com", outputs["output"])
+
+	outputs, err = golc.Call(context.Background(), NewPIIChain(echo, scrubber, func(o *PIIChainOptions) {
+		o.SkipRehydration = true
+	}), schema.ChainValues{"input": "jane@example.com"})
+	require.NoError(t, err)
+	require.Equal(t, "I will write to "+token, outputs["output"])
+}
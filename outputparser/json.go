@@ -0,0 +1,190 @@
+package outputparser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hupe1980/golc/integration/jsonschema"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure JSON satisfies the OutputParser interface.
+var _ schema.OutputParser[any] = (*JSON)(nil)
+
+// jsonFencePattern matches the content of a markdown code block, optionally tagged as JSON.
+var jsonFencePattern = regexp.MustCompile("(?s)```(?:json)?\\s*\n?(.*?)```")
+
+// JSONOptions contains options for the JSON parser.
+type JSONOptions struct {
+	// Schema is the JSON schema the output is validated against. If nil, any JSON value is accepted.
+	Schema *jsonschema.Schema
+
+	// DisableRepair disables the repair of common issues like trailing commas and single quotes.
+	DisableRepair bool
+}
+
+// JSON is a parser for JSON output. It strips markdown code fences and surrounding text, repairs common
+// issues like trailing commas and single-quoted strings, and validates the output against a JSON schema.
+type JSON struct {
+	opts JSONOptions
+}
+
+// NewJSON creates a new instance of the JSON parser.
+func NewJSON(optFns ...func(o *JSONOptions)) *JSON {
+	opts := JSONOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &JSON{
+		opts: opts,
+	}
+}
+
+// ParseResult parses the result of generation and returns the decoded JSON value.
+func (p *JSON) ParseResult(result schema.Generation) (any, error) {
+	return p.Parse(result.Text)
+}
+
+// Parse parses the JSON value of the input text. It returns the decoded value, e.g. a map[string]any
+// for a JSON object, or an error, if the text does not contain valid JSON conforming to the schema.
+func (p *JSON) Parse(text string) (any, error) {
+	raw, err := extractJSON(text)
+	if err != nil {
+		return nil, err
+	}
+
+	var value any
+
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		if p.opts.DisableRepair {
+			return nil, fmt.Errorf("invalid json: %w", err)
+		}
+
+		if repairErr := json.Unmarshal([]byte(repairJSON(raw)), &value); repairErr != nil {
+			return nil, fmt.Errorf("invalid json: %w", err)
+		}
+	}
+
+	if p.opts.Schema != nil {
+		if err := p.opts.Schema.Validate(value); err != nil {
+			return nil, fmt.Errorf("invalid json: %w", err)
+		}
+	}
+
+	return value, nil
+}
+
+// ParseWithPrompt is not used for this parser, so it simply calls Parse.
+func (p *JSON) ParseWithPrompt(text string, prompt schema.PromptValue) (any, error) {
+	return p.Parse(text)
+}
+
+// GetFormatInstructions returns a string describing the expected format of the output. It includes
+// the JSON schema, if any.
+func (p *JSON) GetFormatInstructions() string {
+	if p.opts.Schema == nil {
+		return "The output should be formatted as a JSON object."
+	}
+
+	b, err := json.Marshal(p.opts.Schema)
+	if err != nil {
+		return "The output should be formatted as a JSON object."
+	}
+
+	return fmt.Sprintf("The output should be formatted as a JSON instance that conforms to the JSON schema below.\n\n```json\n%s\n```", b)
+}
+
+// Type returns the type identifier of the parser, which is "json".
+func (p *JSON) Type() string {
+	return "json"
+}
+
+// extractJSON returns the JSON value of the text. The content of a markdown code block is preferred;
+// text before and after the outermost object or array is removed.
+func extractJSON(text string) (string, error) {
+	if match := jsonFencePattern.FindStringSubmatch(text); match != nil {
+		text = match[1]
+	}
+
+	start := strings.IndexAny(text, "{[")
+	if start < 0 {
+		return "", errors.New("no json found in output")
+	}
+
+	closing := "}"
+	if text[start] == '[' {
+		closing = "]"
+	}
+
+	end := strings.LastIndex(text, closing)
+	if end < start {
+		return "", errors.New("no json found in output")
+	}
+
+	return text[start : end+1], nil
+}
+
+// repairJSON repairs common issues of JSON generated by models: single-quoted strings are converted to
+// double-quoted strings and trailing commas before closing brackets are removed.
+func repairJSON(raw string) string {
+	var (
+		b     strings.Builder
+		quote rune
+	)
+
+	runes := []rune(raw)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		if quote != 0 {
+			switch {
+			case r == '\\' && i+1 < len(runes):
+				i++
+				// An escaped single quote needs no escaping in a double-quoted string.
+				if runes[i] != '\'' || quote != '\'' {
+					b.WriteRune(r)
+				}
+
+				b.WriteRune(runes[i])
+			case r == quote:
+				b.WriteRune('"')
+
+				quote = 0
+			case r == '"':
+				b.WriteString(`\"`)
+			default:
+				b.WriteRune(r)
+			}
+
+			continue
+		}
+
+		switch r {
+		case '"', '\'':
+			quote = r
+
+			b.WriteRune('"')
+		case ',':
+			j := i + 1
+			for j < len(runes) && strings.ContainsRune(" \t\r\n", runes[j]) {
+				j++
+			}
+
+			if j < len(runes) && (runes[j] == '}' || runes[j] == ']') {
+				continue
+			}
+
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
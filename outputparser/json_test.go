@@ -0,0 +1,78 @@
+package outputparser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hupe1980/golc/integration/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSON(t *testing.T) {
+	t.Run("Parse", func(t *testing.T) {
+		parser := NewJSON()
+
+		tests := []struct {
+			name     string
+			text     string
+			expected any
+		}{
+			{"Object", `{"name": "Max", "age": 21}`, map[string]any{"name": "Max", "age": float64(21)}},
+			{"Array", `[1, 2, 3]`, []any{float64(1), float64(2), float64(3)}},
+			{"Fenced", "Here you go:\n```json\n{\"name\": \"Max\"}\n```\nAnything else?", map[string]any{"name": "Max"}},
+			{"SurroundingText", `The answer is {"name": "Max"}.`, map[string]any{"name": "Max"}},
+			{"TrailingCommas", "{\"names\": [\"Max\", \"Moritz\",],\n}", map[string]any{"names": []any{"Max", "Moritz"}}},
+			{"SingleQuotes", `{'name': 'Max \'the\' "kid"'}`, map[string]any{"name": `Max 'the' "kid"`}},
+			{"EscapesInDoubleQuotes", `{"text": "a \"b\", c",}`, map[string]any{"text": `a "b", c`}},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				value, err := parser.Parse(tc.text)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, value)
+			})
+		}
+	})
+
+	t.Run("ParseErrors", func(t *testing.T) {
+		_, err := NewJSON().Parse("I don't know.")
+		assert.EqualError(t, err, "no json found in output")
+
+		_, err = NewJSON().Parse(`{"name": Max}`)
+		assert.ErrorContains(t, err, "invalid json: invalid character 'M'")
+
+		_, err = NewJSON(func(o *JSONOptions) {
+			o.DisableRepair = true
+		}).Parse(`{"name": "Max",}`)
+		assert.ErrorContains(t, err, "invalid json")
+	})
+
+	t.Run("Schema", func(t *testing.T) {
+		type Person struct {
+			Name string `json:"name"`
+			Age  int    `json:"age"`
+		}
+
+		s, err := jsonschema.Generate(reflect.TypeOf(Person{}))
+		require.NoError(t, err)
+
+		parser := NewJSON(func(o *JSONOptions) {
+			o.Schema = s
+		})
+
+		value, err := parser.Parse(`{"name": "Max", "age": 21}`)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"name": "Max", "age": float64(21)}, value)
+
+		_, err = parser.Parse(`{"name": "Max"}`)
+		assert.EqualError(t, err, "invalid json: missing required properties: age")
+
+		assert.Contains(t, parser.GetFormatInstructions(), `"required":["name","age"]`)
+	})
+
+	t.Run("Type", func(t *testing.T) {
+		assert.Equal(t, "json", NewJSON().Type())
+	})
+}
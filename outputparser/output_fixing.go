@@ -0,0 +1,141 @@
+package outputparser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/model"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure OutputFixing satisfies the OutputParser interface.
+var _ schema.OutputParser[any] = (*OutputFixing)(nil)
+
+const defaultOutputFixingTemplate = `Instructions:
+--------------
+{{.instructions}}
+--------------
+Completion:
+--------------
+{{.completion}}
+--------------
+
+Above, the Completion did not satisfy the constraints given in the Instructions.
+Error:
+--------------
+{{.error}}
+--------------
+
+Please try again. Please only respond with an answer that satisfies the constraints laid out in the Instructions:`
+
+// OutputFixingOptions contains options for the OutputFixing parser.
+type OutputFixingOptions struct {
+	// Prompt is the prompt template used to fix the output. It is formatted with the format instructions
+	// of the parser, the invalid completion and the error as instructions, completion and error values.
+	Prompt schema.PromptTemplate
+
+	// MaxRetries is the maximum number of times the model is asked to fix the output.
+	MaxRetries int
+}
+
+// OutputFixing is a parser that wraps another parser. If the wrapped parser fails, the model is asked
+// to fix the output with the format instructions and the error until MaxRetries is exhausted.
+type OutputFixing struct {
+	model  schema.Model
+	parser schema.OutputParser[any]
+	opts   OutputFixingOptions
+}
+
+// NewOutputFixing creates a new instance of the OutputFixing parser.
+func NewOutputFixing(model schema.Model, parser schema.OutputParser[any], optFns ...func(o *OutputFixingOptions)) *OutputFixing {
+	opts := OutputFixingOptions{
+		Prompt:     prompt.NewTemplate(defaultOutputFixingTemplate),
+		MaxRetries: 1,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &OutputFixing{
+		model:  model,
+		parser: parser,
+		opts:   opts,
+	}
+}
+
+// ParseResult parses the result of generation with the wrapped parser and fixes it on failure.
+func (p *OutputFixing) ParseResult(result schema.Generation) (any, error) {
+	return p.Parse(result.Text)
+}
+
+// Parse parses the text with the wrapped parser and fixes it on failure.
+func (p *OutputFixing) Parse(text string) (any, error) {
+	return p.ParseWithContext(context.Background(), text)
+}
+
+// ParseWithContext parses the text with the wrapped parser. If parsing fails, the model is asked to fix
+// the output using the provided context.
+func (p *OutputFixing) ParseWithContext(ctx context.Context, text string) (any, error) {
+	value, err := p.parser.Parse(text)
+	if err == nil {
+		return value, nil
+	}
+
+	return repairWithModel(ctx, p.model, p.parser, p.opts.Prompt, p.opts.MaxRetries, text, err, map[string]any{
+		"instructions": p.parser.GetFormatInstructions(),
+	})
+}
+
+// ParseWithPrompt is not used for this parser, so it simply calls Parse.
+func (p *OutputFixing) ParseWithPrompt(text string, prompt schema.PromptValue) (any, error) {
+	return p.Parse(text)
+}
+
+// GetFormatInstructions returns the format instructions of the wrapped parser.
+func (p *OutputFixing) GetFormatInstructions() string {
+	return p.parser.GetFormatInstructions()
+}
+
+// Type returns the type identifier of the parser, which is "output_fixing".
+func (p *OutputFixing) Type() string {
+	return "output_fixing"
+}
+
+// repairWithModel asks the model to repair the invalid completion until the parser succeeds or the maximum
+// number of attempts is exhausted. The prompt template is formatted with the values, the completion and the
+// error of the previous attempt.
+func repairWithModel(ctx context.Context, m schema.Model, parser schema.OutputParser[any], template schema.PromptTemplate, maxAttempts int, completion string, err error, values map[string]any) (any, error) {
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		promptValue, pErr := template.FormatPrompt(util.MergeMaps(values, map[string]any{
+			"completion": completion,
+			"error":      err.Error(),
+		}))
+		if pErr != nil {
+			return nil, pErr
+		}
+
+		result, gErr := model.GeneratePrompt(ctx, m, promptValue)
+		if gErr != nil {
+			return nil, gErr
+		}
+
+		if len(result.Generations) == 0 {
+			return nil, errors.New("unexpected output: no generations")
+		}
+
+		completion = result.Generations[0].Text
+
+		value, parseErr := parser.Parse(completion)
+		if parseErr == nil {
+			return value, nil
+		}
+
+		err = parseErr
+	}
+
+	return nil, fmt.Errorf("cannot fix output after %d attempts: %w", maxAttempts, err)
+}
@@ -0,0 +1,48 @@
+package outputparser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc/model/chatmodel"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputFixing(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		fake := chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+			t.Fatal("unexpected model call")
+			return nil, nil
+		})
+
+		value, err := NewOutputFixing(fake, NewJSON()).Parse(`{"name": "Max"}`)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"name": "Max"}, value)
+	})
+
+	t.Run("Fix", func(t *testing.T) {
+		fake := chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+			assert.Contains(t, messages[0].Content(), "Completion:\n--------------\n{name: Max}")
+			assert.Contains(t, messages[0].Content(), "invalid json")
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: `{"name": "Max"}`, Message: schema.NewAIChatMessage(`{"name": "Max"}`)}},
+			}, nil
+		})
+
+		value, err := NewOutputFixing(fake, NewJSON()).Parse(`{name: Max}`)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"name": "Max"}, value)
+	})
+
+	t.Run("MaxRetries", func(t *testing.T) {
+		fake := chatmodel.NewSimpleFake("I don't know.")
+
+		_, err := NewOutputFixing(fake, NewJSON(), func(o *OutputFixingOptions) {
+			o.MaxRetries = 2
+		}).Parse(`{name: Max}`)
+		assert.EqualError(t, err, "cannot fix output after 2 attempts: no json found in output")
+	})
+}
@@ -0,0 +1,110 @@
+package outputparser
+
+import (
+	"context"
+
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure RetryWithLLM satisfies the OutputParser interface.
+var _ schema.OutputParser[any] = (*RetryWithLLM)(nil)
+
+const defaultRetryWithLLMTemplate = `Prompt:
+--------------
+{{.prompt}}
+--------------
+Completion:
+--------------
+{{.completion}}
+--------------
+
+Above, the Completion did not satisfy the constraints given in the Prompt.
+Details: {{.error}}
+Please try again:`
+
+// RetryWithLLMOptions contains options for the RetryWithLLM parser.
+type RetryWithLLMOptions struct {
+	// Prompt is the prompt template used to retry the generation. It is formatted with the original prompt,
+	// the invalid completion and the error as prompt, completion and error values.
+	Prompt schema.PromptTemplate
+}
+
+// RetryWithLLM is a parser that wraps another parser. If the wrapped parser fails, the original prompt,
+// the completion and the error are passed back to the model to repair the output. Unlike OutputFixing,
+// the model sees the original prompt, so it can also fix outputs that are valid but incomplete. Without
+// a prompt, the format instructions of the wrapped parser are used instead.
+type RetryWithLLM struct {
+	parser      schema.OutputParser[any]
+	model       schema.Model
+	maxAttempts int
+	opts        RetryWithLLMOptions
+}
+
+// NewRetryWithLLM creates a new instance of the RetryWithLLM parser. The model is asked at most maxAttempts
+// times to repair the output.
+func NewRetryWithLLM(parser schema.OutputParser[any], model schema.Model, maxAttempts int, optFns ...func(o *RetryWithLLMOptions)) *RetryWithLLM {
+	opts := RetryWithLLMOptions{
+		Prompt: prompt.NewTemplate(defaultRetryWithLLMTemplate),
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &RetryWithLLM{
+		parser:      parser,
+		model:       model,
+		maxAttempts: maxAttempts,
+		opts:        opts,
+	}
+}
+
+// ParseResult parses the result of generation with the wrapped parser and retries on failure.
+func (p *RetryWithLLM) ParseResult(result schema.Generation) (any, error) {
+	return p.Parse(result.Text)
+}
+
+// ParseResultWithPrompt parses the result of generation for the prompt with the wrapped parser and retries
+// on failure. It is used by the LLM chain to pass the context and the prompt of the generation.
+func (p *RetryWithLLM) ParseResultWithPrompt(ctx context.Context, result schema.Generation, prompt schema.PromptValue) (any, error) {
+	return p.ParseWithPromptContext(ctx, result.Text, prompt)
+}
+
+// Parse parses the text with the wrapped parser and retries on failure.
+func (p *RetryWithLLM) Parse(text string) (any, error) {
+	return p.ParseWithPrompt(text, nil)
+}
+
+// ParseWithPrompt parses the text with the wrapped parser. If parsing fails, the prompt, the completion and
+// the error are passed to the model to repair the output.
+func (p *RetryWithLLM) ParseWithPrompt(text string, prompt schema.PromptValue) (any, error) {
+	return p.ParseWithPromptContext(context.Background(), text, prompt)
+}
+
+// ParseWithPromptContext is like ParseWithPrompt, but passes the provided context to the model calls.
+func (p *RetryWithLLM) ParseWithPromptContext(ctx context.Context, text string, prompt schema.PromptValue) (any, error) {
+	value, err := p.parser.Parse(text)
+	if err == nil {
+		return value, nil
+	}
+
+	promptText := p.parser.GetFormatInstructions()
+	if prompt != nil {
+		promptText = prompt.String()
+	}
+
+	return repairWithModel(ctx, p.model, p.parser, p.opts.Prompt, p.maxAttempts, text, err, map[string]any{
+		"prompt": promptText,
+	})
+}
+
+// GetFormatInstructions returns the format instructions of the wrapped parser.
+func (p *RetryWithLLM) GetFormatInstructions() string {
+	return p.parser.GetFormatInstructions()
+}
+
+// Type returns the type identifier of the parser, which is "retry_with_llm".
+func (p *RetryWithLLM) Type() string {
+	return "retry_with_llm"
+}
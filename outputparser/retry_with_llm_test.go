@@ -0,0 +1,73 @@
+package outputparser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc/model/chatmodel"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryWithLLM(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		fake := chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+			t.Fatal("unexpected model call")
+			return nil, nil
+		})
+
+		value, err := NewRetryWithLLM(NewJSON(), fake, 1).Parse(`{"name": "Max"}`)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"name": "Max"}, value)
+	})
+
+	t.Run("RetryWithPrompt", func(t *testing.T) {
+		fake := chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+			assert.Contains(t, messages[0].Content(), "Prompt:\n--------------\nWho is Max? Respond with JSON.")
+			assert.Contains(t, messages[0].Content(), "Completion:\n--------------\nMax is 21 years old.")
+			assert.Contains(t, messages[0].Content(), "Details: no json found in output")
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: `{"name": "Max", "age": 21}`, Message: schema.NewAIChatMessage(`{"name": "Max", "age": 21}`)}},
+			}, nil
+		})
+
+		parser := NewRetryWithLLM(NewJSON(), fake, 1)
+
+		value, err := parser.ParseWithPrompt("Max is 21 years old.", prompt.StringPromptValue("Who is Max? Respond with JSON."))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"name": "Max", "age": float64(21)}, value)
+	})
+
+	t.Run("FormatInstructionsWithoutPrompt", func(t *testing.T) {
+		fake := chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+			assert.Contains(t, messages[0].Content(), "Prompt:\n--------------\nThe output should be formatted as a JSON object.")
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: `{}`, Message: schema.NewAIChatMessage(`{}`)}},
+			}, nil
+		})
+
+		value, err := NewRetryWithLLM(NewJSON(), fake, 1).Parse("foo")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{}, value)
+	})
+
+	t.Run("MaxAttempts", func(t *testing.T) {
+		calls := 0
+
+		fake := chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+			calls++
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: "I don't know.", Message: schema.NewAIChatMessage("I don't know.")}},
+			}, nil
+		})
+
+		_, err := NewRetryWithLLM(NewJSON(), fake, 3).Parse("foo")
+		assert.EqualError(t, err, "cannot fix output after 3 attempts: no json found in output")
+		assert.Equal(t, 3, calls)
+	})
+}
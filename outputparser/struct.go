@@ -0,0 +1,105 @@
+package outputparser
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/hupe1980/golc/integration/jsonschema"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Struct satisfies the OutputParser interface.
+var _ schema.OutputParser[any] = (*Struct[any])(nil)
+
+// StructOptions contains options for the Struct parser.
+type StructOptions struct {
+	// DisableRepair disables the repair of common issues like trailing commas and single quotes.
+	DisableRepair bool
+}
+
+// Struct is a parser for JSON output into a value of the struct type T. The format instructions contain
+// the JSON schema of T, which is generated from the struct fields and their tags: description or doc
+// describes a field, enum lists the allowed values and required overrides the omitempty option of the
+// json tag. The output is validated against the schema before it is unmarshaled into T.
+type Struct[T any] struct {
+	parser *JSON
+}
+
+// NewStruct creates a new instance of the Struct parser for the struct type T.
+func NewStruct[T any](optFns ...func(o *StructOptions)) (*Struct[T], error) {
+	opts := StructOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unsupported output type: %s is not a struct", t)
+	}
+
+	jsonSchema, err := jsonschema.Generate(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Struct[T]{
+		parser: NewJSON(func(o *JSONOptions) {
+			o.Schema = jsonSchema
+			o.DisableRepair = opts.DisableRepair
+		}),
+	}, nil
+}
+
+// ParseResult parses the result of generation and returns the value of type T.
+func (p *Struct[T]) ParseResult(result schema.Generation) (any, error) {
+	return p.Parse(result.Text)
+}
+
+// Parse parses the JSON output into a value of type T. The value is returned as any, so the parser can
+// be used wherever a schema.OutputParser[any] is expected; use ParseValue for a typed result.
+func (p *Struct[T]) Parse(text string) (any, error) {
+	return p.ParseValue(text)
+}
+
+// ParseValue parses the JSON output into a value of type T.
+func (p *Struct[T]) ParseValue(text string) (T, error) {
+	var value T
+
+	raw, err := p.parser.Parse(text)
+	if err != nil {
+		return value, err
+	}
+
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return value, err
+	}
+
+	if err := json.Unmarshal(b, &value); err != nil {
+		return value, fmt.Errorf("invalid json: %w", err)
+	}
+
+	return value, nil
+}
+
+// ParseWithPrompt is not used for this parser, so it simply calls Parse.
+func (p *Struct[T]) ParseWithPrompt(text string, prompt schema.PromptValue) (any, error) {
+	return p.Parse(text)
+}
+
+// GetFormatInstructions returns a string describing the expected format of the output, including the
+// JSON schema of T.
+func (p *Struct[T]) GetFormatInstructions() string {
+	return p.parser.GetFormatInstructions()
+}
+
+// Type returns the type identifier of the parser, which is "struct".
+func (p *Struct[T]) Type() string {
+	return "struct"
+}
@@ -0,0 +1,55 @@
+package outputparser
+
+import (
+	"testing"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type movie struct {
+	Title  string   `json:"title" description:"The title of the movie"`
+	Genre  string   `json:"genre" enum:"action,comedy,drama"`
+	Year   int      `json:"year,omitempty" required:"true"`
+	Actors []string `json:"actors,omitempty"`
+}
+
+func TestStruct(t *testing.T) {
+	parser, err := NewStruct[movie]()
+	require.NoError(t, err)
+
+	t.Run("Parse", func(t *testing.T) {
+		value, err := parser.ParseResult(schema.Generation{
+			Text: "```json\n{\"title\": \"Heat\", \"genre\": \"action\", \"year\": 1995, \"actors\": [\"Al Pacino\", \"Robert De Niro\"],}\n```",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, movie{Title: "Heat", Genre: "action", Year: 1995, Actors: []string{"Al Pacino", "Robert De Niro"}}, value)
+	})
+
+	t.Run("ParseValue", func(t *testing.T) {
+		m, err := parser.ParseValue(`{"title": "Heat", "genre": "action", "year": 1995}`)
+		require.NoError(t, err)
+		assert.Equal(t, "Heat", m.Title)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		_, err := parser.Parse(`{"title": "Heat", "genre": "action"}`)
+		assert.EqualError(t, err, "invalid json: missing required properties: year")
+
+		_, err = parser.Parse(`{"title": "Heat", "genre": "western", "year": 1995}`)
+		assert.EqualError(t, err, `invalid json: genre: value must be one of "action", "comedy", "drama"`)
+	})
+
+	t.Run("GetFormatInstructions", func(t *testing.T) {
+		instructions := parser.GetFormatInstructions()
+		assert.Contains(t, instructions, `"description":"The title of the movie"`)
+		assert.Contains(t, instructions, `"enum":["action","comedy","drama"]`)
+		assert.Contains(t, instructions, `"required":["title","genre","year"]`)
+	})
+
+	t.Run("UnsupportedType", func(t *testing.T) {
+		_, err := NewStruct[[]string]()
+		assert.EqualError(t, err, "unsupported output type: []string is not a struct")
+	})
+}
@@ -113,16 +113,28 @@ func (ct *chatTemplate) FormatPrompt(values map[string]any) (schema.PromptValue,
 }
 
 // FormatMessages formats the messages using the provided values and returns the resulting ChatMessages.
+// Messages placeholders are replaced by their list of messages.
 func (ct *chatTemplate) FormatMessages(values map[string]any) (schema.ChatMessages, error) {
-	messages := make(schema.ChatMessages, len(ct.messageTemplates))
+	messages := make(schema.ChatMessages, 0, len(ct.messageTemplates))
+
+	for _, t := range ct.messageTemplates {
+		if mp, ok := t.(*MessagesPlaceholderTemplate); ok {
+			placeholderMessages, err := mp.FormatMessages(values)
+			if err != nil {
+				return nil, err
+			}
+
+			messages = append(messages, placeholderMessages...)
+
+			continue
+		}
 
-	for i, t := range ct.messageTemplates {
 		msg, err := t.Format(values)
 		if err != nil {
 			return nil, err
 		}
 
-		messages[i] = msg
+		messages = append(messages, msg)
 	}
 
 	return messages, nil
@@ -179,12 +191,7 @@ func (ct *messagesPlaceholder) FormatPrompt(values map[string]any) (schema.Promp
 
 // FormatMessages formats the messages using the provided values and returns the resulting ChatMessages.
 func (ct *messagesPlaceholder) FormatMessages(values map[string]any) (schema.ChatMessages, error) {
-	messages, ok := values[ct.inputKey].(schema.ChatMessages)
-	if !ok {
-		return nil, fmt.Errorf("cannot get list of messages for key %s", ct.inputKey)
-	}
-
-	return messages, nil
+	return getMessages(values, ct.inputKey, false)
 }
 
 // InputVariables returns an empty list for the messagesPlaceholder since it doesn't use input variables.
@@ -197,6 +204,90 @@ func (ct *messagesPlaceholder) OutputParser() (schema.OutputParser[any], bool) {
 	return nil, false
 }
 
+// getMessages returns the list of chat messages of the values for the key. A missing key results in an
+// empty list, if optional is true.
+func getMessages(values map[string]any, key string, optional bool) (schema.ChatMessages, error) {
+	switch v := values[key].(type) {
+	case schema.ChatMessages:
+		return v, nil
+	case []schema.ChatMessage:
+		return v, nil
+	case nil:
+		if optional {
+			return schema.ChatMessages{}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("cannot get list of messages for key %s", key)
+}
+
+// MessagesPlaceholderOptions contains options for a messages placeholder.
+type MessagesPlaceholderOptions struct {
+	// Optional allows the value of the placeholder to be missing, which results in no messages.
+	Optional bool
+}
+
+// Compile time check to ensure MessagesPlaceholderTemplate satisfies the MessageTemplate interface.
+var _ MessageTemplate = (*MessagesPlaceholderTemplate)(nil)
+
+// MessagesPlaceholderTemplate represents a placeholder for a list of chat messages within a chat template,
+// e.g. for the history of a conversation returned as messages by a memory.
+type MessagesPlaceholderTemplate struct {
+	inputKey string
+	opts     MessagesPlaceholderOptions
+}
+
+// MessagesPlaceholder creates a new placeholder for the list of chat messages of the input key. Unlike
+// NewMessagesPlaceholder, it is a MessageTemplate and can be used in NewChatTemplate alongside the other
+// message templates.
+func MessagesPlaceholder(inputKey string, optFns ...func(o *MessagesPlaceholderOptions)) *MessagesPlaceholderTemplate {
+	opts := MessagesPlaceholderOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &MessagesPlaceholderTemplate{
+		inputKey: inputKey,
+		opts:     opts,
+	}
+}
+
+// Format returns the single chat message of the placeholder. It returns an error, if the placeholder
+// does not contain exactly one message; use FormatMessages for a list of messages.
+func (pt *MessagesPlaceholderTemplate) Format(values map[string]any) (schema.ChatMessage, error) {
+	messages, err := pt.FormatMessages(values)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(messages) != 1 {
+		return nil, fmt.Errorf("messages placeholder %s contains %d messages instead of one", pt.inputKey, len(messages))
+	}
+
+	return messages[0], nil
+}
+
+// FormatMessages returns the list of chat messages of the placeholder.
+func (pt *MessagesPlaceholderTemplate) FormatMessages(values map[string]any) (schema.ChatMessages, error) {
+	return getMessages(values, pt.inputKey, pt.opts.Optional)
+}
+
+// FormatPrompt formats the prompt using the provided values and returns a ChatPromptValue.
+func (pt *MessagesPlaceholderTemplate) FormatPrompt(values map[string]any) (schema.PromptValue, error) {
+	messages, err := pt.FormatMessages(values)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewChatPromptValue(messages), nil
+}
+
+// InputVariables returns an empty list for the placeholder, as its messages are usually provided by a memory.
+func (pt *MessagesPlaceholderTemplate) InputVariables() []string {
+	return []string{}
+}
+
 // MessageTemplate represents a chat message template.
 type MessageTemplate interface {
 	Format(values map[string]any) (schema.ChatMessage, error)
@@ -92,6 +92,75 @@ func TestMessagesPlaceholder(t *testing.T) {
 	})
 }
 
+func TestChatTemplateWithMessagesPlaceholder(t *testing.T) {
+	chatTemplate := NewChatTemplate([]MessageTemplate{
+		NewSystemMessageTemplate("You are a helpful assistant."),
+		MessagesPlaceholder("history"),
+		NewHumanMessageTemplate("{{.input}}"),
+	})
+
+	t.Run("FormatMessages", func(t *testing.T) {
+		messages, err := chatTemplate.FormatMessages(map[string]any{
+			"history": []schema.ChatMessage{
+				schema.NewHumanChatMessage("Hi"),
+				schema.NewAIChatMessage("Hello!"),
+			},
+			"input": "How are you?",
+		})
+		require.NoError(t, err)
+		require.Equal(t, schema.ChatMessages{
+			schema.NewSystemChatMessage("You are a helpful assistant."),
+			schema.NewHumanChatMessage("Hi"),
+			schema.NewAIChatMessage("Hello!"),
+			schema.NewHumanChatMessage("How are you?"),
+		}, messages)
+	})
+
+	t.Run("FormatPrompt", func(t *testing.T) {
+		promptValue, err := chatTemplate.FormatPrompt(map[string]any{
+			"history": schema.ChatMessages{schema.NewAIChatMessage("Hello!")},
+			"input":   "How are you?",
+		})
+		require.NoError(t, err)
+		require.Len(t, promptValue.Messages(), 3)
+	})
+
+	t.Run("MissingHistory", func(t *testing.T) {
+		_, err := chatTemplate.FormatMessages(map[string]any{"input": "How are you?"})
+		require.EqualError(t, err, "cannot get list of messages for key history")
+	})
+
+	t.Run("OptionalHistory", func(t *testing.T) {
+		optionalTemplate := NewChatTemplate([]MessageTemplate{
+			MessagesPlaceholder("history", func(o *MessagesPlaceholderOptions) {
+				o.Optional = true
+			}),
+			NewHumanMessageTemplate("{{.input}}"),
+		})
+
+		messages, err := optionalTemplate.FormatMessages(map[string]any{"input": "How are you?"})
+		require.NoError(t, err)
+		require.Equal(t, schema.ChatMessages{schema.NewHumanChatMessage("How are you?")}, messages)
+	})
+
+	t.Run("InputVariables", func(t *testing.T) {
+		require.ElementsMatch(t, []string{"input"}, chatTemplate.InputVariables())
+	})
+
+	t.Run("Format", func(t *testing.T) {
+		placeholder := MessagesPlaceholder("history")
+
+		message, err := placeholder.Format(map[string]any{
+			"history": schema.ChatMessages{schema.NewAIChatMessage("Hello!")},
+		})
+		require.NoError(t, err)
+		require.Equal(t, schema.NewAIChatMessage("Hello!"), message)
+
+		_, err = placeholder.Format(map[string]any{"history": schema.ChatMessages{}})
+		require.EqualError(t, err, "messages placeholder history contains 0 messages instead of one")
+	})
+}
+
 func TestNewSystemMessageTemplate(t *testing.T) {
 	template := NewSystemMessageTemplate("Hello {{.name}}!")
 	values := map[string]any{"name": "John"}
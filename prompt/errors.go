@@ -4,4 +4,7 @@ import "errors"
 
 var (
 	ErrInvalidPartialVariableType = errors.New("invalid partial variable type")
+	ErrMissingVariable            = errors.New("missing variable")
+	ErrFuncNotAllowed             = errors.New("function not allowed")
+	ErrOutputLengthExceeded       = errors.New("output length exceeded")
 )
@@ -1,7 +1,6 @@
 package prompt
 
 import (
-	"fmt"
 	"strings"
 	"text/template"
 
@@ -12,6 +11,9 @@ import (
 // Compile time check to ensure FewShotTemplate satisfies the PromptTemplate interface.
 var _ schema.PromptTemplate = (*FewShotTemplate)(nil)
 
+// defaultFewShotSeparator is the default separator between the examples and the template.
+const defaultFewShotSeparator = "\n\n"
+
 // FewShotTemplateOptions represents options for configuring a FewShotTemplate.
 type FewShotTemplateOptions struct {
 	// Prefix to be added before the template.
@@ -22,8 +24,9 @@ type FewShotTemplateOptions struct {
 	OutputParser schema.OutputParser[any]
 	// PartialValues to be used in the template.
 	PartialValues map[string]any
-	// IgnoreMissingKeys allows ignoring missing keys in the template.
-	IgnoreMissingKeys bool
+	// FormatterOptions configures the formatting of the template, e.g. IgnoreMissingKeys to allow
+	// ignoring missing keys in the template.
+	FormatterOptions
 }
 
 // FewShotTemplate is a template that combines examples with a main template.
@@ -37,8 +40,7 @@ type FewShotTemplate struct {
 // NewFewShotTemplate creates a new FewShotTemplate with the provided template, examples, and options.
 func NewFewShotTemplate(template string, examples []map[string]any, exampleTemplate *Template, optFns ...func(o *FewShotTemplateOptions)) *FewShotTemplate {
 	opts := FewShotTemplateOptions{
-		Separator:         "\n\n",
-		IgnoreMissingKeys: false,
+		Separator: defaultFewShotSeparator,
 	}
 
 	for _, fn := range optFns {
@@ -73,10 +75,10 @@ func (p *FewShotTemplate) Format(values map[string]any) (string, error) {
 	pieces = append(pieces, p.template)
 
 	formatter := NewFormatter(strings.Join(pieces, p.opts.Separator), func(o *FormatterOptions) {
-		o.IgnoreMissingKeys = p.opts.IgnoreMissingKeys
+		*o = p.opts.FormatterOptions
 	})
 
-	resolvedValues, err := p.resolvePartialValues()
+	resolvedValues, err := resolvePartialValues(p.opts.PartialValues, values)
 	if err != nil {
 		return "", err
 	}
@@ -95,13 +97,13 @@ func (p *FewShotTemplate) FormatPrompt(values map[string]any) (schema.PromptValu
 }
 
 // Partial creates a new FewShotTemplate with partial values.
-func (p *FewShotTemplate) Partial(values map[string]any) schema.PromptTemplate {
+func (p *FewShotTemplate) Partial(values map[string]any) *FewShotTemplate {
 	return NewFewShotTemplate(p.template, p.examples, p.exampleTemplate, func(o *FewShotTemplateOptions) {
 		o.Prefix = p.opts.Prefix
 		o.Separator = p.opts.Separator
 		o.OutputParser = p.opts.OutputParser
 		o.PartialValues = util.MergeMaps(p.opts.PartialValues, values)
-		o.IgnoreMissingKeys = p.opts.IgnoreMissingKeys
+		o.FormatterOptions = p.opts.FormatterOptions
 	})
 }
 
@@ -131,23 +133,5 @@ func (p *FewShotTemplate) InputVariables() []string {
 		}
 	}
 
-	return vars
-}
-
-// resolvePartialValues resolves partial values to be used in the template.
-func (p *FewShotTemplate) resolvePartialValues() (map[string]any, error) {
-	resolvedValues := make(map[string]any)
-
-	for variable, value := range p.opts.PartialValues {
-		switch value := value.(type) {
-		case string:
-			resolvedValues[variable] = value
-		case func() string:
-			resolvedValues[variable] = value()
-		default:
-			return nil, fmt.Errorf("%w: %v", ErrInvalidPartialVariableType, variable)
-		}
-	}
-
-	return resolvedValues, nil
+	return appendPartialInputVariables(vars, p.opts.PartialValues)
 }
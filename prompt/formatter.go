@@ -2,20 +2,47 @@ package prompt
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"regexp"
 	"text/template"
 	"text/template/parse"
+
+	"github.com/hupe1980/golc/internal/util"
 )
 
+// builtinFuncs contains the names of the predefined functions of text/template.
+var builtinFuncs = []string{
+	"and", "call", "html", "index", "slice", "js", "len", "not", "or", "print", "printf", "println",
+	"urlquery", "eq", "ge", "gt", "le", "lt", "ne",
+}
+
+// SandboxOptions restricts the execution of a template.
+type SandboxOptions struct {
+	// AllowedFuncs lists the functions, which can be used in the template. If empty, all functions
+	// except call are allowed.
+	AllowedFuncs []string
+	// MaxOutputLength limits the length of the rendered output in bytes. Zero means no limit.
+	MaxOutputLength int
+}
+
 type FormatterOptions struct {
 	IgnoreMissingKeys bool
 	TemplateFuncMap   template.FuncMap
+	// Strict returns an error for missing variables and variables with a nil value, instead of rendering
+	// <no value>. It takes precedence over IgnoreMissingKeys.
+	Strict bool
+	// Sandbox restricts the functions and the output length of the template, if not nil.
+	Sandbox *SandboxOptions
 }
 
 type Formatter struct {
-	text     string
-	template *template.Template
-	fields   []string
+	text      string
+	template  *template.Template
+	fields    []string
+	variables []string
+	opts      FormatterOptions
 }
 
 func NewFormatter(text string, optFns ...func(o *FormatterOptions)) *Formatter {
@@ -28,22 +55,49 @@ func NewFormatter(text string, optFns ...func(o *FormatterOptions)) *Formatter {
 		fn(&opts)
 	}
 
-	t := template.Must(template.New("template").Funcs(opts.TemplateFuncMap).Parse(text))
+	funcMap := opts.TemplateFuncMap
+	if opts.Sandbox != nil {
+		funcMap = sandboxFuncMap(funcMap, opts.Sandbox.AllowedFuncs)
+	}
+
+	t := template.Must(template.New("template").Funcs(funcMap).Parse(text))
 
-	if !opts.IgnoreMissingKeys {
+	if !opts.IgnoreMissingKeys || opts.Strict {
 		t = t.Option("missingkey=error")
 	}
 
 	return &Formatter{
-		text:     text,
-		template: t,
-		fields:   ListTemplateFields(t),
+		text:      text,
+		template:  t,
+		fields:    ListTemplateFields(t),
+		variables: listNodeVariables(t.Tree.Root, true),
+		opts:      opts,
 	}
 }
 
 func (pt *Formatter) Render(values map[string]any) (string, error) {
-	var doc bytes.Buffer
-	if err := pt.template.Execute(&doc, values); err != nil {
+	if pt.opts.Strict {
+		for _, name := range pt.variables {
+			if v, ok := values[name]; !ok || v == nil {
+				return "", fmt.Errorf("%w: %s", ErrMissingVariable, name)
+			}
+		}
+	}
+
+	var (
+		doc bytes.Buffer
+		w   io.Writer = &doc
+	)
+
+	if pt.opts.Sandbox != nil && pt.opts.Sandbox.MaxOutputLength > 0 {
+		w = &limitedWriter{w: &doc, n: pt.opts.Sandbox.MaxOutputLength}
+	}
+
+	if err := pt.template.Execute(w, values); err != nil {
+		if errors.Is(err, ErrOutputLengthExceeded) {
+			return "", ErrOutputLengthExceeded
+		}
+
 		return "", err
 	}
 
@@ -58,15 +112,95 @@ func ListTemplateFields(t *template.Template) []string {
 	return listNodeFields(t.Tree.Root)
 }
 
+// listNodeFields returns the actions of the node. The actions in the branches of if blocks and in the else
+// branches of range and with blocks are included, as dot is not rebound there.
 func listNodeFields(node parse.Node) []string {
 	res := []string{}
-	if node.Type() == parse.NodeAction {
-		res = append(res, node.String())
+
+	switch n := node.(type) {
+	case *parse.ActionNode:
+		res = append(res, n.String())
+	case *parse.ListNode:
+		if n == nil {
+			return res
+		}
+
+		for _, c := range n.Nodes {
+			res = append(res, listNodeFields(c)...)
+		}
+	case *parse.IfNode:
+		res = append(res, listNodeFields(n.List)...)
+		res = append(res, listNodeFields(n.ElseList)...)
+	case *parse.RangeNode:
+		res = append(res, listNodeFields(n.ElseList)...)
+	case *parse.WithNode:
+		res = append(res, listNodeFields(n.ElseList)...)
 	}
 
-	if ln, ok := node.(*parse.ListNode); ok {
-		for _, n := range ln.Nodes {
-			res = append(res, listNodeFields(n)...)
+	return res
+}
+
+// listNodeVariables returns the names of the top-level variables referenced by the node, i.e. the first
+// segment of each field path evaluated on the root data, including the fields of pipelines and control
+// blocks. Fields inside the bodies of range and with blocks refer to the rebound dot and are only
+// included, if they are referenced with $.
+func listNodeVariables(node parse.Node, rootDot bool) []string {
+	res := []string{}
+
+	appendUnique := func(names ...string) {
+		for _, name := range names {
+			if !util.Contains(res, name) {
+				res = append(res, name)
+			}
+		}
+	}
+
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return res
+		}
+
+		for _, c := range n.Nodes {
+			appendUnique(listNodeVariables(c, rootDot)...)
+		}
+	case *parse.ActionNode:
+		appendUnique(listNodeVariables(n.Pipe, rootDot)...)
+	case *parse.TemplateNode:
+		appendUnique(listNodeVariables(n.Pipe, rootDot)...)
+	case *parse.IfNode:
+		appendUnique(listNodeVariables(n.Pipe, rootDot)...)
+		appendUnique(listNodeVariables(n.List, rootDot)...)
+		appendUnique(listNodeVariables(n.ElseList, rootDot)...)
+	case *parse.RangeNode:
+		appendUnique(listNodeVariables(n.Pipe, rootDot)...)
+		appendUnique(listNodeVariables(n.List, false)...)
+		appendUnique(listNodeVariables(n.ElseList, rootDot)...)
+	case *parse.WithNode:
+		appendUnique(listNodeVariables(n.Pipe, rootDot)...)
+		appendUnique(listNodeVariables(n.List, false)...)
+		appendUnique(listNodeVariables(n.ElseList, rootDot)...)
+	case *parse.PipeNode:
+		if n == nil {
+			return res
+		}
+
+		for _, cmd := range n.Cmds {
+			appendUnique(listNodeVariables(cmd, rootDot)...)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			appendUnique(listNodeVariables(arg, rootDot)...)
+		}
+	case *parse.ChainNode:
+		appendUnique(listNodeVariables(n.Node, rootDot)...)
+	case *parse.FieldNode:
+		if rootDot && len(n.Ident) > 0 {
+			appendUnique(n.Ident[0])
+		}
+	case *parse.VariableNode:
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			appendUnique(n.Ident[1])
 		}
 	}
 
@@ -83,3 +217,52 @@ func extractNameFromField(input string) string {
 
 	return ""
 }
+
+// sandboxFuncMap returns the function map with all functions, which are not allowed, replaced by functions
+// returning an error.
+func sandboxFuncMap(funcMap template.FuncMap, allowedFuncs []string) template.FuncMap {
+	sandboxed := make(template.FuncMap, len(funcMap)+len(builtinFuncs))
+
+	for name, fn := range funcMap {
+		sandboxed[name] = fn
+	}
+
+	isAllowed := func(name string) bool {
+		if len(allowedFuncs) == 0 {
+			return name != "call"
+		}
+
+		return util.Contains(allowedFuncs, name)
+	}
+
+	for _, name := range append(util.Keys(funcMap), builtinFuncs...) {
+		if !isAllowed(name) {
+			sandboxed[name] = disallowedFunc(name)
+		}
+	}
+
+	return sandboxed
+}
+
+// disallowedFunc returns a template function returning an error for the disallowed function.
+func disallowedFunc(name string) func(args ...any) (any, error) {
+	return func(args ...any) (any, error) {
+		return nil, fmt.Errorf("%w: %s", ErrFuncNotAllowed, name)
+	}
+}
+
+// limitedWriter is a writer returning an error, if more than n bytes are written.
+type limitedWriter struct {
+	w io.Writer
+	n int
+}
+
+func (lw *limitedWriter) Write(p []byte) (int, error) {
+	if len(p) > lw.n {
+		return 0, ErrOutputLengthExceeded
+	}
+
+	lw.n -= len(p)
+
+	return lw.w.Write(p)
+}
@@ -1,6 +1,7 @@
 package prompt
 
 import (
+	"strings"
 	"testing"
 	"text/template"
 
@@ -18,3 +19,139 @@ func TestListTemplateFields(t *testing.T) {
 	template := template.Must(template.New("template").Parse("This is a {{ .foo }} test."))
 	assert.ElementsMatch(t, ListTemplateFields(template), []string{"{{.foo}}"})
 }
+
+func TestListTemplateFieldsControlBlocks(t *testing.T) {
+	template := template.Must(template.New("template").Parse("{{if .a}}{{.b}}{{else}}{{.c}}{{end}}{{range .d}}{{.e}}{{else}}{{.f}}{{end}}"))
+	assert.ElementsMatch(t, ListTemplateFields(template), []string{"{{.b}}", "{{.c}}", "{{.f}}"})
+}
+
+func TestFormatterStrict(t *testing.T) {
+	t.Run("MissingVariable", func(t *testing.T) {
+		pt := NewFormatter("Hello {{.name}}!", func(o *FormatterOptions) {
+			o.IgnoreMissingKeys = true
+			o.Strict = true
+		})
+
+		_, err := pt.Render(map[string]any{})
+		assert.ErrorIs(t, err, ErrMissingVariable)
+		assert.EqualError(t, err, "missing variable: name")
+	})
+
+	t.Run("NilVariable", func(t *testing.T) {
+		pt := NewFormatter("Hello {{.name}}!", func(o *FormatterOptions) {
+			o.Strict = true
+		})
+
+		_, err := pt.Render(map[string]any{"name": nil})
+		assert.ErrorIs(t, err, ErrMissingVariable)
+	})
+
+	t.Run("NestedField", func(t *testing.T) {
+		pt := NewFormatter("Hello {{.user.name}}!", func(o *FormatterOptions) {
+			o.Strict = true
+		})
+
+		result, err := pt.Render(map[string]any{"user": map[string]any{"name": "Bob"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello Bob!", result)
+
+		_, err = pt.Render(map[string]any{})
+		assert.EqualError(t, err, "missing variable: user")
+	})
+
+	t.Run("ControlBlocks", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			template string
+			missing  string
+		}{
+			{"IfPipeline", "{{if .show}}shown{{end}}", "show"},
+			{"IfList", "{{if true}}{{.name}}{{end}}", "name"},
+			{"IfElseList", "{{if false}}{{else}}{{.name}}{{end}}", "name"},
+			{"RangePipeline", "{{range .items}}{{.}}{{end}}", "items"},
+			{"RangeElseList", "{{range .items}}{{.}}{{else}}{{.empty}}{{end}}", "empty"},
+			{"WithRoot", "{{with .user}}{{.name}} {{$.greeting}}{{end}}", "greeting"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				pt := NewFormatter(tt.template, func(o *FormatterOptions) {
+					o.Strict = true
+				})
+
+				values := map[string]any{
+					"show":     true,
+					"name":     "Bob",
+					"items":    []string{},
+					"empty":    "none",
+					"user":     map[string]any{"name": "Bob"},
+					"greeting": "Hello",
+				}
+
+				_, err := pt.Render(values)
+				assert.NoError(t, err)
+
+				delete(values, tt.missing)
+
+				_, err = pt.Render(values)
+				assert.ErrorIs(t, err, ErrMissingVariable)
+				assert.EqualError(t, err, "missing variable: "+tt.missing)
+			})
+		}
+	})
+
+	t.Run("NotStrict", func(t *testing.T) {
+		pt := NewFormatter("Hello {{.name}}!")
+
+		result, err := pt.Render(map[string]any{"name": nil})
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello <no value>!", result)
+	})
+}
+
+func TestFormatterFuncMap(t *testing.T) {
+	pt := NewFormatter("Hello {{upper .name}}!", func(o *FormatterOptions) {
+		o.TemplateFuncMap = template.FuncMap{"upper": strings.ToUpper}
+	})
+
+	result, err := pt.Render(map[string]any{"name": "bob"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello BOB!", result)
+}
+
+func TestFormatterSandbox(t *testing.T) {
+	funcMap := template.FuncMap{"upper": strings.ToUpper}
+
+	t.Run("CallDisabled", func(t *testing.T) {
+		pt := NewFormatter("{{call .fn}}", func(o *FormatterOptions) {
+			o.Sandbox = &SandboxOptions{}
+		})
+
+		_, err := pt.Render(map[string]any{"fn": func() string { return "foo" }})
+		assert.ErrorIs(t, err, ErrFuncNotAllowed)
+	})
+
+	t.Run("AllowedFuncs", func(t *testing.T) {
+		pt := NewFormatter("{{upper .name}} {{len .name}}", func(o *FormatterOptions) {
+			o.TemplateFuncMap = funcMap
+			o.Sandbox = &SandboxOptions{AllowedFuncs: []string{"upper"}}
+		})
+
+		_, err := pt.Render(map[string]any{"name": "bob"})
+		assert.ErrorIs(t, err, ErrFuncNotAllowed)
+		assert.ErrorContains(t, err, "function not allowed: len")
+	})
+
+	t.Run("MaxOutputLength", func(t *testing.T) {
+		pt := NewFormatter("Hello {{.name}}!", func(o *FormatterOptions) {
+			o.Sandbox = &SandboxOptions{MaxOutputLength: 10}
+		})
+
+		result, err := pt.Render(map[string]any{"name": "Bob"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello Bob!", result)
+
+		_, err = pt.Render(map[string]any{"name": "Alice"})
+		assert.ErrorIs(t, err, ErrOutputLengthExceeded)
+	})
+}
@@ -0,0 +1,533 @@
+package prompt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+const (
+	templateTypePrompt   = "prompt"
+	templateTypeFewShot  = "few_shot"
+	templateTypeChat     = "chat"
+	templateTypePipeline = "pipeline"
+
+	messageRoleSystem      = "system"
+	messageRoleHuman       = "human"
+	messageRoleAI          = "ai"
+	messageRolePlaceholder = "placeholder"
+
+	templateFormatGo     = "go"
+	templateFormatPython = "python"
+)
+
+// templateConfig is the serialized representation of a prompt template.
+type templateConfig struct {
+	Type              string               `json:"_type" yaml:"_type"`
+	Template          string               `json:"template,omitempty" yaml:"template,omitempty"`
+	TemplateFormat    string               `json:"template_format,omitempty" yaml:"template_format,omitempty"`
+	PartialVariables  map[string]string    `json:"partial_variables,omitempty" yaml:"partial_variables,omitempty"`
+	IgnoreMissingKeys bool                 `json:"ignore_missing_keys,omitempty" yaml:"ignore_missing_keys,omitempty"`
+	Strict            bool                 `json:"strict,omitempty" yaml:"strict,omitempty"`
+	Sandbox           *sandboxConfig       `json:"sandbox,omitempty" yaml:"sandbox,omitempty"`
+	Examples          []map[string]any     `json:"examples,omitempty" yaml:"examples,omitempty"`
+	ExamplePrompt     *templateConfig      `json:"example_prompt,omitempty" yaml:"example_prompt,omitempty"`
+	Prefix            string               `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	Separator         string               `json:"separator,omitempty" yaml:"separator,omitempty"`
+	Messages          []messageConfig      `json:"messages,omitempty" yaml:"messages,omitempty"`
+	FinalPrompt       *templateConfig      `json:"final_prompt,omitempty" yaml:"final_prompt,omitempty"`
+	PipelinePrompts   []pipelineStepConfig `json:"pipeline_prompts,omitempty" yaml:"pipeline_prompts,omitempty"`
+}
+
+// sandboxConfig is the serialized representation of the sandbox options of a template.
+type sandboxConfig struct {
+	AllowedFuncs    []string `json:"allowed_funcs,omitempty" yaml:"allowed_funcs,omitempty"`
+	MaxOutputLength int      `json:"max_output_length,omitempty" yaml:"max_output_length,omitempty"`
+}
+
+// messageConfig is the serialized representation of a message template of a chat template.
+type messageConfig struct {
+	Role         string `json:"role" yaml:"role"`
+	Template     string `json:"template,omitempty" yaml:"template,omitempty"`
+	VariableName string `json:"variable_name,omitempty" yaml:"variable_name,omitempty"`
+	Optional     bool   `json:"optional,omitempty" yaml:"optional,omitempty"`
+}
+
+// pipelineStepConfig is the serialized representation of a step of a pipeline template.
+type pipelineStepConfig struct {
+	Name   string          `json:"name" yaml:"name"`
+	Prompt *templateConfig `json:"prompt" yaml:"prompt"`
+}
+
+// Load loads a prompt template from a YAML or JSON file. Templates, few-shot templates, chat templates and
+// pipeline templates are supported; the type is determined by the _type field of the file.
+func Load(path string) (schema.PromptTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return Decode(data)
+}
+
+// Decode decodes a prompt template from YAML or JSON data.
+func Decode(data []byte) (schema.PromptTemplate, error) {
+	config := &templateConfig{}
+
+	// JSON is a subset of YAML, so both formats are decoded by the YAML decoder.
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("cannot decode prompt template: %w", err)
+	}
+
+	return config.toTemplate()
+}
+
+// Save saves the prompt template to a file. The file is written as JSON, if the path has a .json
+// extension, and as YAML otherwise. Only partial values of type string can be saved; output parsers
+// are not saved.
+func Save(t schema.PromptTemplate, path string) error {
+	var (
+		data []byte
+		err  error
+	)
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		data, err = EncodeJSON(t)
+	} else {
+		data, err = EncodeYAML(t)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0600)
+}
+
+// EncodeJSON encodes the prompt template as JSON.
+func EncodeJSON(t schema.PromptTemplate) ([]byte, error) {
+	config, err := newTemplateConfig(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(config, "", "  ")
+}
+
+// EncodeYAML encodes the prompt template as YAML.
+func EncodeYAML(t schema.PromptTemplate) ([]byte, error) {
+	config, err := newTemplateConfig(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(config)
+}
+
+// newTemplateConfig creates the serialized representation of the prompt template.
+func newTemplateConfig(t schema.PromptTemplate) (*templateConfig, error) {
+	switch t := t.(type) {
+	case *Template:
+		partialVariables, err := newPartialVariablesConfig(t.opts.PartialValues)
+		if err != nil {
+			return nil, err
+		}
+
+		return &templateConfig{
+			Type:              templateTypePrompt,
+			Template:          t.template,
+			PartialVariables:  partialVariables,
+			IgnoreMissingKeys: t.opts.IgnoreMissingKeys,
+			Strict:            t.opts.Strict,
+			Sandbox:           newSandboxConfig(t.opts.Sandbox),
+		}, nil
+	case *FewShotTemplate:
+		partialVariables, err := newPartialVariablesConfig(t.opts.PartialValues)
+		if err != nil {
+			return nil, err
+		}
+
+		examplePrompt, err := newTemplateConfig(t.exampleTemplate)
+		if err != nil {
+			return nil, err
+		}
+
+		// The default separator is omitted, as YAML cannot represent all line break only strings.
+		separator := t.opts.Separator
+		if separator == defaultFewShotSeparator {
+			separator = ""
+		}
+
+		return &templateConfig{
+			Type:              templateTypeFewShot,
+			Template:          t.template,
+			PartialVariables:  partialVariables,
+			IgnoreMissingKeys: t.opts.IgnoreMissingKeys,
+			Strict:            t.opts.Strict,
+			Sandbox:           newSandboxConfig(t.opts.Sandbox),
+			Examples:          t.examples,
+			ExamplePrompt:     examplePrompt,
+			Prefix:            t.opts.Prefix,
+			Separator:         separator,
+		}, nil
+	case *chatTemplate:
+		messages := make([]messageConfig, len(t.messageTemplates))
+
+		for i, mt := range t.messageTemplates {
+			message, err := newMessageConfig(mt)
+			if err != nil {
+				return nil, err
+			}
+
+			messages[i] = message
+		}
+
+		return &templateConfig{
+			Type:     templateTypeChat,
+			Messages: messages,
+		}, nil
+	case *messagesPlaceholder:
+		return &templateConfig{
+			Type:     templateTypeChat,
+			Messages: []messageConfig{{Role: messageRolePlaceholder, VariableName: t.inputKey}},
+		}, nil
+	case *PipelineTemplate:
+		finalPrompt, err := newTemplateConfig(t.final)
+		if err != nil {
+			return nil, err
+		}
+
+		steps := make([]pipelineStepConfig, len(t.steps))
+
+		for i, step := range t.steps {
+			prompt, err := newTemplateConfig(step.Template)
+			if err != nil {
+				return nil, err
+			}
+
+			steps[i] = pipelineStepConfig{Name: step.Name, Prompt: prompt}
+		}
+
+		return &templateConfig{
+			Type:            templateTypePipeline,
+			FinalPrompt:     finalPrompt,
+			PipelinePrompts: steps,
+		}, nil
+	case *FileTemplate:
+		current, err := t.current()
+		if err != nil {
+			return nil, err
+		}
+
+		return newTemplateConfig(current)
+	default:
+		return nil, fmt.Errorf("unsupported prompt template type: %T", t)
+	}
+}
+
+// newSandboxConfig creates the serialized representation of the sandbox options.
+func newSandboxConfig(sandbox *SandboxOptions) *sandboxConfig {
+	if sandbox == nil {
+		return nil
+	}
+
+	return &sandboxConfig{
+		AllowedFuncs:    sandbox.AllowedFuncs,
+		MaxOutputLength: sandbox.MaxOutputLength,
+	}
+}
+
+// newPartialVariablesConfig returns the partial values of type string.
+func newPartialVariablesConfig(partialValues map[string]any) (map[string]string, error) {
+	if len(partialValues) == 0 {
+		return nil, nil
+	}
+
+	partialVariables := make(map[string]string, len(partialValues))
+
+	for variable, value := range partialValues {
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("cannot save partial variable %s of type %T", variable, value)
+		}
+
+		partialVariables[variable] = s
+	}
+
+	return partialVariables, nil
+}
+
+// newMessageConfig creates the serialized representation of the message template.
+func newMessageConfig(mt MessageTemplate) (messageConfig, error) {
+	switch mt := mt.(type) {
+	case *SystemMessageTemplate:
+		return messageConfig{Role: messageRoleSystem, Template: mt.prompt.template}, nil
+	case *HumanMessageTemplate:
+		return messageConfig{Role: messageRoleHuman, Template: mt.prompt.template}, nil
+	case *AIMessageTemplate:
+		return messageConfig{Role: messageRoleAI, Template: mt.prompt.template}, nil
+	case *MessagesPlaceholderTemplate:
+		return messageConfig{Role: messageRolePlaceholder, VariableName: mt.inputKey, Optional: mt.opts.Optional}, nil
+	default:
+		return messageConfig{}, fmt.Errorf("unsupported message template type: %T", mt)
+	}
+}
+
+// toTemplate creates the prompt template of the serialized representation.
+func (c *templateConfig) toTemplate() (schema.PromptTemplate, error) {
+	switch c.Type {
+	case templateTypePrompt, "":
+		return c.toPromptTemplate()
+	case templateTypeFewShot:
+		if c.ExamplePrompt == nil {
+			return nil, errors.New("missing example prompt of few shot template")
+		}
+
+		exampleTemplate, err := c.ExamplePrompt.toPromptTemplate()
+		if err != nil {
+			return nil, err
+		}
+
+		template, err := c.transformTemplate(c.Template)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewFewShotTemplate(template, c.Examples, exampleTemplate, func(o *FewShotTemplateOptions) {
+			o.Prefix = c.Prefix
+			if c.Separator != "" {
+				o.Separator = c.Separator
+			}
+			o.PartialValues = c.partialValues()
+			o.FormatterOptions = c.formatterOptions()
+		}), nil
+	case templateTypeChat:
+		messageTemplates := make([]MessageTemplate, len(c.Messages))
+
+		for i, m := range c.Messages {
+			mt, err := c.toMessageTemplate(m)
+			if err != nil {
+				return nil, err
+			}
+
+			messageTemplates[i] = mt
+		}
+
+		return NewChatTemplate(messageTemplates), nil
+	case templateTypePipeline:
+		if c.FinalPrompt == nil {
+			return nil, errors.New("missing final prompt of pipeline template")
+		}
+
+		final, err := c.FinalPrompt.toTemplate()
+		if err != nil {
+			return nil, err
+		}
+
+		steps := make([]PipelineStep, len(c.PipelinePrompts))
+
+		for i, step := range c.PipelinePrompts {
+			if step.Prompt == nil {
+				return nil, fmt.Errorf("missing prompt of pipeline step %s", step.Name)
+			}
+
+			t, err := step.Prompt.toTemplate()
+			if err != nil {
+				return nil, err
+			}
+
+			steps[i] = PipelineStep{Name: step.Name, Template: t}
+		}
+
+		return NewPipelineTemplate(final, steps), nil
+	default:
+		return nil, fmt.Errorf("unsupported prompt template type: %s", c.Type)
+	}
+}
+
+// toPromptTemplate creates the Template of the serialized representation.
+func (c *templateConfig) toPromptTemplate() (*Template, error) {
+	if c.Type != "" && c.Type != templateTypePrompt {
+		return nil, fmt.Errorf("unsupported prompt template type: %s", c.Type)
+	}
+
+	template, err := c.transformTemplate(c.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTemplate(template, func(o *TemplateOptions) {
+		o.PartialValues = c.partialValues()
+		o.FormatterOptions = c.formatterOptions()
+	}), nil
+}
+
+// toMessageTemplate creates the message template of the serialized representation.
+func (c *templateConfig) toMessageTemplate(m messageConfig) (MessageTemplate, error) {
+	if m.Role == messageRolePlaceholder {
+		if m.VariableName == "" {
+			return nil, errors.New("missing variable name of messages placeholder")
+		}
+
+		return MessagesPlaceholder(m.VariableName, func(o *MessagesPlaceholderOptions) {
+			o.Optional = m.Optional
+		}), nil
+	}
+
+	template, err := c.transformTemplate(m.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	switch m.Role {
+	case messageRoleSystem:
+		return NewSystemMessageTemplate(template), nil
+	case messageRoleHuman:
+		return NewHumanMessageTemplate(template), nil
+	case messageRoleAI:
+		return NewAIMessageTemplate(template), nil
+	default:
+		return nil, fmt.Errorf("unsupported message role: %s", m.Role)
+	}
+}
+
+// transformTemplate transforms the template according to the template format.
+func (c *templateConfig) transformTemplate(template string) (string, error) {
+	switch c.TemplateFormat {
+	case templateFormatGo, "":
+		return template, nil
+	case templateFormatPython:
+		return pythonTemplatePattern.ReplaceAllString(template, "{{.$1}}"), nil
+	default:
+		return "", fmt.Errorf("unsupported template format: %s", c.TemplateFormat)
+	}
+}
+
+// formatterOptions returns the formatter options of the serialized representation.
+func (c *templateConfig) formatterOptions() FormatterOptions {
+	opts := FormatterOptions{
+		IgnoreMissingKeys: c.IgnoreMissingKeys,
+		Strict:            c.Strict,
+	}
+
+	if c.Sandbox != nil {
+		opts.Sandbox = &SandboxOptions{
+			AllowedFuncs:    c.Sandbox.AllowedFuncs,
+			MaxOutputLength: c.Sandbox.MaxOutputLength,
+		}
+	}
+
+	return opts
+}
+
+// partialValues returns the partial variables as partial values of a template.
+func (c *templateConfig) partialValues() map[string]any {
+	if len(c.PartialVariables) == 0 {
+		return nil
+	}
+
+	partialValues := make(map[string]any, len(c.PartialVariables))
+	for k, v := range c.PartialVariables {
+		partialValues[k] = v
+	}
+
+	return partialValues
+}
+
+// Compile time check to ensure FileTemplate satisfies the PromptTemplate interface.
+var _ schema.PromptTemplate = (*FileTemplate)(nil)
+
+// FileTemplate is a prompt template loaded from a YAML or JSON file. The file is reloaded, if it has been
+// modified since it was loaded, so prompts can be changed without restarting the application.
+type FileTemplate struct {
+	path     string
+	mu       sync.Mutex
+	modTime  time.Time
+	template schema.PromptTemplate
+}
+
+// NewFileTemplate creates a new FileTemplate and loads the prompt template of the file.
+func NewFileTemplate(path string) (*FileTemplate, error) {
+	t := &FileTemplate{
+		path: path,
+	}
+
+	if _, err := t.current(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Format reloads the file, if modified, and formats the prompt template with the values.
+func (t *FileTemplate) Format(values map[string]any) (string, error) {
+	current, err := t.current()
+	if err != nil {
+		return "", err
+	}
+
+	return current.Format(values)
+}
+
+// FormatPrompt reloads the file, if modified, and formats the prompt template with the values.
+func (t *FileTemplate) FormatPrompt(values map[string]any) (schema.PromptValue, error) {
+	current, err := t.current()
+	if err != nil {
+		return nil, err
+	}
+
+	return current.FormatPrompt(values)
+}
+
+// InputVariables returns the input variables of the prompt template. If the file cannot be reloaded,
+// the input variables of the previously loaded template are returned.
+func (t *FileTemplate) InputVariables() []string {
+	current, err := t.current()
+	if err != nil {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		return t.template.InputVariables()
+	}
+
+	return current.InputVariables()
+}
+
+// OutputParser returns the output parser function and a boolean indicating if an output parser is defined.
+func (t *FileTemplate) OutputParser() (schema.OutputParser[any], bool) {
+	return nil, false
+}
+
+// current returns the prompt template of the file, which is reloaded, if the file has been modified.
+func (t *FileTemplate) current() (schema.PromptTemplate, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	info, err := os.Stat(t.path)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.template != nil && info.ModTime().Equal(t.modTime) {
+		return t.template, nil
+	}
+
+	template, err := Load(t.path)
+	if err != nil {
+		return nil, err
+	}
+
+	t.template = template
+	t.modTime = info.ModTime()
+
+	return template, nil
+}
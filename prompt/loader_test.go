@@ -0,0 +1,166 @@
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("YAML", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "prompt.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(`_type: prompt
+template: "Tell me a {{.adjective}} joke about {{.content}}."
+partial_variables:
+  adjective: funny
+`), 0600))
+
+		template, err := Load(path)
+		require.NoError(t, err)
+		require.Equal(t, []string{"content"}, template.InputVariables())
+
+		result, err := template.Format(map[string]any{"content": "chickens"})
+		require.NoError(t, err)
+		require.Equal(t, "Tell me a funny joke about chickens.", result)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "prompt.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{
+  "_type": "chat",
+  "template_format": "python",
+  "messages": [
+    {"role": "system", "template": "You are {name}."},
+    {"role": "placeholder", "variable_name": "history", "optional": true},
+    {"role": "human", "template": "{input}"}
+  ]
+}`), 0600))
+
+		template, err := Load(path)
+		require.NoError(t, err)
+
+		promptValue, err := template.FormatPrompt(map[string]any{"name": "Bob", "input": "Hi"})
+		require.NoError(t, err)
+		require.Equal(t, schema.ChatMessages{
+			schema.NewSystemChatMessage("You are Bob."),
+			schema.NewHumanChatMessage("Hi"),
+		}, promptValue.Messages())
+	})
+
+	t.Run("UnsupportedType", func(t *testing.T) {
+		_, err := Decode([]byte(`_type: unknown`))
+		require.EqualError(t, err, "unsupported prompt template type: unknown")
+	})
+
+	t.Run("UnsupportedRole", func(t *testing.T) {
+		_, err := Decode([]byte(`{"_type": "chat", "messages": [{"role": "tool", "template": "foo"}]}`))
+		require.EqualError(t, err, "unsupported message role: tool")
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestSave(t *testing.T) {
+	values := map[string]any{
+		"input":   "What is the opposite of tall?",
+		"history": schema.ChatMessages{schema.NewAIChatMessage("Hello!")},
+		"name":    "Bob",
+	}
+
+	templates := map[string]schema.PromptTemplate{
+		"Template": NewTemplate("{{.greeting}} {{.input}}").Partial(map[string]any{"greeting": "Hi!"}),
+		"FewShot": NewFewShotTemplate("Input: {{.input}}\nOutput:", []map[string]any{
+			{"input": "happy", "output": "sad"},
+			{"input": "tall", "output": "short"},
+		}, NewTemplate("Input: {{.input}}\nOutput: {{.output}}"), func(o *FewShotTemplateOptions) {
+			o.Prefix = "Give the antonym of every input"
+		}),
+		"Chat": NewChatTemplate([]MessageTemplate{
+			NewSystemMessageTemplate("You are {{.name}}."),
+			MessagesPlaceholder("history"),
+			NewHumanMessageTemplate("{{.input}}"),
+			NewAIMessageTemplate("Let me think."),
+		}),
+		"Pipeline": NewPipelineTemplate(NewTemplate("{{.intro}} {{.input}}"), []PipelineStep{
+			{Name: "intro", Template: NewTemplate("You are {{.name}}.")},
+		}),
+	}
+
+	for name, template := range templates {
+		for _, ext := range []string{".json", ".yaml"} {
+			t.Run(name+ext, func(t *testing.T) {
+				path := filepath.Join(t.TempDir(), "prompt"+ext)
+				require.NoError(t, Save(template, path))
+
+				loaded, err := Load(path)
+				require.NoError(t, err)
+				require.ElementsMatch(t, template.InputVariables(), loaded.InputVariables())
+
+				expected, err := template.FormatPrompt(values)
+				require.NoError(t, err)
+
+				actual, err := loaded.FormatPrompt(values)
+				require.NoError(t, err)
+				require.Equal(t, expected.Messages(), actual.Messages())
+			})
+		}
+	}
+
+	t.Run("StrictAndSandbox", func(t *testing.T) {
+		template := NewTemplate("Hello {{.name}}!", func(o *TemplateOptions) {
+			o.Strict = true
+			o.Sandbox = &SandboxOptions{AllowedFuncs: []string{"len"}, MaxOutputLength: 10}
+		})
+
+		for _, ext := range []string{".json", ".yaml"} {
+			path := filepath.Join(t.TempDir(), "prompt"+ext)
+			require.NoError(t, Save(template, path))
+
+			loaded, err := Load(path)
+			require.NoError(t, err)
+
+			_, err = loaded.Format(map[string]any{})
+			require.ErrorIs(t, err, ErrMissingVariable)
+
+			_, err = loaded.Format(map[string]any{"name": "Alice"})
+			require.ErrorIs(t, err, ErrOutputLengthExceeded)
+		}
+	})
+
+	t.Run("UnsupportedPartialValue", func(t *testing.T) {
+		template := NewTemplate("{{.date}}").Partial(map[string]any{"date": func() string { return "today" }})
+
+		_, err := EncodeYAML(template)
+		require.EqualError(t, err, "cannot save partial variable date of type func() string")
+	})
+}
+
+func TestFileTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prompt.yaml")
+	require.NoError(t, Save(NewTemplate("Hello {{.name}}!"), path))
+
+	template, err := NewFileTemplate(path)
+	require.NoError(t, err)
+
+	result, err := template.Format(map[string]any{"name": "Alice"})
+	require.NoError(t, err)
+	require.Equal(t, "Hello Alice!", result)
+
+	require.NoError(t, Save(NewTemplate("Goodbye {{.name}}!"), path))
+	// Ensure a different modification time on file systems with a coarse resolution.
+	modTime := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+
+	result, err = template.Format(map[string]any{"name": "Alice"})
+	require.NoError(t, err)
+	require.Equal(t, "Goodbye Alice!", result)
+	require.Equal(t, []string{"name"}, template.InputVariables())
+}
@@ -0,0 +1,97 @@
+package prompt
+
+import (
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure PipelineTemplate satisfies the PromptTemplate interface.
+var _ schema.PromptTemplate = (*PipelineTemplate)(nil)
+
+// PipelineStep represents a prompt template of a pipeline, whose formatted result is passed to the
+// following templates as the value of the name.
+type PipelineStep struct {
+	Name     string
+	Template schema.PromptTemplate
+}
+
+// PipelineTemplate is a template composed of other templates. The steps are formatted in order and
+// their results are available to the following steps and the final template.
+type PipelineTemplate struct {
+	final schema.PromptTemplate
+	steps []PipelineStep
+}
+
+// NewPipelineTemplate creates a new PipelineTemplate with the final template and the pipeline steps.
+func NewPipelineTemplate(final schema.PromptTemplate, steps []PipelineStep) *PipelineTemplate {
+	return &PipelineTemplate{
+		final: final,
+		steps: steps,
+	}
+}
+
+// Format applies values to the pipeline and returns the formatted result of the final template.
+func (p *PipelineTemplate) Format(values map[string]any) (string, error) {
+	resolvedValues, err := p.formatSteps(values)
+	if err != nil {
+		return "", err
+	}
+
+	return p.final.Format(resolvedValues)
+}
+
+// FormatPrompt applies values to the pipeline and returns the PromptValue of the final template, e.g.
+// a ChatPromptValue, if the final template is a chat template.
+func (p *PipelineTemplate) FormatPrompt(values map[string]any) (schema.PromptValue, error) {
+	resolvedValues, err := p.formatSteps(values)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.final.FormatPrompt(resolvedValues)
+}
+
+// InputVariables returns the input variables of all templates, which are not provided by a step.
+func (p *PipelineTemplate) InputVariables() []string {
+	names := make([]string, 0, len(p.steps))
+	vars := []string{}
+
+	for _, step := range p.steps {
+		vars = appendInputVariables(vars, step.Template.InputVariables(), names)
+		names = append(names, step.Name)
+	}
+
+	return appendInputVariables(vars, p.final.InputVariables(), names)
+}
+
+// OutputParser returns the output parser of the final template.
+func (p *PipelineTemplate) OutputParser() (schema.OutputParser[any], bool) {
+	return p.final.OutputParser()
+}
+
+// formatSteps formats the steps in order and returns the values extended by their results.
+func (p *PipelineTemplate) formatSteps(values map[string]any) (map[string]any, error) {
+	resolvedValues := util.MergeMaps(values)
+
+	for _, step := range p.steps {
+		v, err := step.Template.Format(resolvedValues)
+		if err != nil {
+			return nil, err
+		}
+
+		resolvedValues[step.Name] = v
+	}
+
+	return resolvedValues, nil
+}
+
+// appendInputVariables appends the input variables, which are neither provided nor already contained.
+func appendInputVariables(vars, inputVariables, provided []string) []string {
+	for _, name := range inputVariables {
+		if !util.Contains(provided, name) && !util.Contains(vars, name) {
+			vars = append(vars, name)
+		}
+	}
+
+	return vars
+}
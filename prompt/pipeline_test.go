@@ -0,0 +1,66 @@
+package prompt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+func TestPipelineTemplate(t *testing.T) {
+	pipeline := NewPipelineTemplate(NewTemplate("{{.introduction}}\n\n{{.example}}\n\n{{.start}}"), []PipelineStep{
+		{Name: "introduction", Template: NewTemplate("You are impersonating {{.person}}.")},
+		{Name: "example", Template: NewTemplate("Q: {{.example_q}}\nA: {{.example_a}}")},
+		{Name: "start", Template: NewTemplate("Now, do this for real!\n\nQ: {{.input}}\nA:")},
+	})
+
+	t.Run("Format", func(t *testing.T) {
+		result, err := pipeline.Format(map[string]any{
+			"person":    "Elon Musk",
+			"example_q": "What's your favorite car?",
+			"example_a": "Tesla",
+			"input":     "What's your favorite social media site?",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "You are impersonating Elon Musk.\n\nQ: What's your favorite car?\nA: Tesla\n\nNow, do this for real!\n\nQ: What's your favorite social media site?\nA:", result)
+	})
+
+	t.Run("InputVariables", func(t *testing.T) {
+		require.ElementsMatch(t, []string{"person", "example_q", "example_a", "input"}, pipeline.InputVariables())
+	})
+
+	t.Run("StepUsesPreviousStep", func(t *testing.T) {
+		p := NewPipelineTemplate(NewTemplate("{{.b}}!"), []PipelineStep{
+			{Name: "a", Template: NewTemplate("Hello {{.name}}")},
+			{Name: "b", Template: NewTemplate("{{.a}}, how are you")},
+		})
+
+		require.Equal(t, []string{"name"}, p.InputVariables())
+
+		result, err := p.Format(map[string]any{"name": "Alice"})
+		require.NoError(t, err)
+		require.Equal(t, "Hello Alice, how are you!", result)
+	})
+
+	t.Run("ChatTemplate", func(t *testing.T) {
+		p := NewPipelineTemplate(NewChatTemplate([]MessageTemplate{
+			NewSystemMessageTemplate("{{.persona}}"),
+			NewHumanMessageTemplate("{{.input}}"),
+		}), []PipelineStep{
+			{Name: "persona", Template: NewTemplate("You are {{.name}}.")},
+		})
+
+		promptValue, err := p.FormatPrompt(map[string]any{"name": "Bob", "input": "Hi"})
+		require.NoError(t, err)
+		require.Equal(t, schema.ChatMessages{
+			schema.NewSystemChatMessage("You are Bob."),
+			schema.NewHumanChatMessage("Hi"),
+		}, promptValue.Messages())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		_, err := pipeline.Format(map[string]any{})
+		require.Error(t, err)
+	})
+}
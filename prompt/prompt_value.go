@@ -2,6 +2,8 @@
 package prompt
 
 import (
+	"fmt"
+
 	"github.com/hupe1980/golc/schema"
 )
 
@@ -52,3 +54,32 @@ func (v ChatPromptValue) String() string {
 func (v ChatPromptValue) Messages() schema.ChatMessages {
 	return v.messages
 }
+
+// WithFormatInstructions returns a copy of the prompt value with the format instructions, e.g. of an output
+// parser, appended. For chat prompt values, the instructions are added to the last human message or
+// appended as a new human message.
+func WithFormatInstructions(pv schema.PromptValue, instructions string) schema.PromptValue {
+	if v, ok := pv.(StringPromptValue); ok {
+		return StringPromptValue(fmt.Sprintf("%s\n\n%s", v, instructions))
+	}
+
+	messages := pv.Messages()
+
+	result := make(schema.ChatMessages, len(messages), len(messages)+1)
+	copy(result, messages)
+
+	if n := len(result); n > 0 {
+		if hm, ok := result[n-1].(*schema.HumanChatMessage); ok {
+			if len(hm.Parts()) > 0 {
+				parts := append(append([]schema.ContentPart{}, hm.Parts()...), schema.NewTextPart(instructions))
+				result[n-1] = schema.NewMultimodalHumanChatMessage(parts...)
+			} else {
+				result[n-1] = schema.NewHumanChatMessage(fmt.Sprintf("%s\n\n%s", hm.Content(), instructions))
+			}
+
+			return NewChatPromptValue(result)
+		}
+	}
+
+	return NewChatPromptValue(append(result, schema.NewHumanChatMessage(instructions)))
+}
@@ -66,3 +66,44 @@ func TestStringPromptValue(t *testing.T) {
 		}
 	})
 }
+
+func TestWithFormatInstructions(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    schema.PromptValue
+		expected schema.PromptValue
+	}{
+		{
+			name:     "StringPromptValue",
+			input:    StringPromptValue("List two colors."),
+			expected: StringPromptValue("List two colors.\n\nUse commas."),
+		},
+		{
+			name: "ChatPromptValue with human message",
+			input: NewChatPromptValue(schema.ChatMessages{
+				schema.NewSystemChatMessage("You are helpful."),
+				schema.NewHumanChatMessage("List two colors."),
+			}),
+			expected: NewChatPromptValue(schema.ChatMessages{
+				schema.NewSystemChatMessage("You are helpful."),
+				schema.NewHumanChatMessage("List two colors.\n\nUse commas."),
+			}),
+		},
+		{
+			name: "ChatPromptValue without human message",
+			input: NewChatPromptValue(schema.ChatMessages{
+				schema.NewSystemChatMessage("List two colors."),
+			}),
+			expected: NewChatPromptValue(schema.ChatMessages{
+				schema.NewSystemChatMessage("List two colors."),
+				schema.NewHumanChatMessage("Use commas."),
+			}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, WithFormatInstructions(test.input, "Use commas."))
+		})
+	}
+}
@@ -11,6 +11,9 @@ import (
 // Compile time check to ensure Template satisfies the PromptTemplate interface.
 var _ schema.PromptTemplate = (*Template)(nil)
 
+// pythonTemplatePattern matches the variables of a python format string.
+var pythonTemplatePattern = regexp.MustCompile(`{([^{}]+)}`)
+
 // TemplateOptions defines the options for configuring a Template.
 type TemplateOptions struct {
 	PartialValues           map[string]any
@@ -44,32 +47,32 @@ func NewTemplate(template string, optFns ...func(o *TemplateOptions)) *Template
 	}
 
 	if opts.TransformPythonTemplate {
-		re := regexp.MustCompile(`{([^{}]+)}`)
-		template = re.ReplaceAllString(template, "{{.$1}}")
+		template = pythonTemplatePattern.ReplaceAllString(template, "{{.$1}}")
 	}
 
 	return &Template{
 		template: template,
 		formatter: NewFormatter(template, func(o *FormatterOptions) {
-			o.IgnoreMissingKeys = opts.IgnoreMissingKeys
-			o.TemplateFuncMap = opts.TemplateFuncMap
+			*o = opts.FormatterOptions
 		}),
 		opts: opts,
 	}
 }
 
-// Partial creates a new Template with partial values.
-func (p *Template) Partial(values map[string]any) schema.PromptTemplate {
+// Partial creates a new Template with partial values. A partial value is either a string, a function
+// returning the value or a nested prompt template, which is formatted with the values of the template.
+func (p *Template) Partial(values map[string]any) *Template {
 	return NewTemplate(p.template, func(o *TemplateOptions) {
 		o.Language = p.opts.Language
 		o.OutputParser = p.opts.OutputParser
 		o.PartialValues = util.MergeMaps(p.opts.PartialValues, values)
+		o.FormatterOptions = p.opts.FormatterOptions
 	})
 }
 
 // Format applies values to the template and returns the formatted result.
 func (p *Template) Format(values map[string]any) (string, error) {
-	resolvedValues, err := p.resolvePartialValues()
+	resolvedValues, err := resolvePartialValues(p.opts.PartialValues, values)
 	if err != nil {
 		return "", err
 	}
@@ -101,19 +104,34 @@ func (p *Template) InputVariables() []string {
 		}
 	}
 
-	return vars
+	return appendPartialInputVariables(vars, p.opts.PartialValues)
 }
 
-// resolvePartialValues resolves partial values to be used in the template.
-func (p *Template) resolvePartialValues() (map[string]any, error) {
+// resolvePartialValues resolves partial values to be used in a template. Nested prompt templates are
+// formatted with the values.
+func resolvePartialValues(partialValues map[string]any, values map[string]any) (map[string]any, error) {
 	resolvedValues := make(map[string]any)
 
-	for variable, value := range p.opts.PartialValues {
+	for variable, value := range partialValues {
 		switch value := value.(type) {
 		case string:
 			resolvedValues[variable] = value
 		case func() string:
 			resolvedValues[variable] = value()
+		case func() (string, error):
+			v, err := value()
+			if err != nil {
+				return nil, fmt.Errorf("cannot resolve partial variable %s: %w", variable, err)
+			}
+
+			resolvedValues[variable] = v
+		case schema.PromptTemplate:
+			v, err := value.Format(values)
+			if err != nil {
+				return nil, fmt.Errorf("cannot resolve partial variable %s: %w", variable, err)
+			}
+
+			resolvedValues[variable] = v
 		default:
 			return nil, fmt.Errorf("%w: %v", ErrInvalidPartialVariableType, variable)
 		}
@@ -122,6 +140,21 @@ func (p *Template) resolvePartialValues() (map[string]any, error) {
 	return resolvedValues, nil
 }
 
+// appendPartialInputVariables appends the input variables of nested prompt templates in the partial values.
+func appendPartialInputVariables(vars []string, partialValues map[string]any) []string {
+	for _, value := range partialValues {
+		if t, ok := value.(schema.PromptTemplate); ok {
+			for _, name := range t.InputVariables() {
+				if _, ok := partialValues[name]; !ok && !util.Contains(vars, name) {
+					vars = append(vars, name)
+				}
+			}
+		}
+	}
+
+	return vars
+}
+
 // FormatPrompt applies values to the template and returns a PromptValue representation of the formatted result.
 func (p *Template) FormatPrompt(values map[string]any) (schema.PromptValue, error) {
 	prompt, err := p.Format(values)
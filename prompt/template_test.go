@@ -90,3 +90,55 @@ func TestTemplate(t *testing.T) {
 		})
 	})
 }
+
+func TestTemplatePartial(t *testing.T) {
+	t.Run("Chained", func(t *testing.T) {
+		template := NewTemplate("{{.persona}} Today is {{.date}}. {{.question}}").
+			Partial(map[string]any{"persona": "You are a pirate."}).
+			Partial(map[string]any{"date": func() string { return "Monday" }})
+
+		assert.Equal(t, []string{"question"}, template.InputVariables())
+
+		result, err := template.Format(map[string]any{"question": "How are you?"})
+		assert.NoError(t, err)
+		assert.Equal(t, "You are a pirate. Today is Monday. How are you?", result)
+	})
+
+	t.Run("NestedTemplate", func(t *testing.T) {
+		template := NewTemplate("{{.persona}} {{.question}}").Partial(map[string]any{
+			"persona": NewTemplate("You are {{.name}}."),
+		})
+
+		assert.ElementsMatch(t, []string{"question", "name"}, template.InputVariables())
+
+		result, err := template.Format(map[string]any{"name": "Bob", "question": "How are you?"})
+		assert.NoError(t, err)
+		assert.Equal(t, "You are Bob. How are you?", result)
+	})
+
+	t.Run("KeepsFormatterOptions", func(t *testing.T) {
+		template := NewTemplate("{{.persona}}{{.question}}", func(o *TemplateOptions) {
+			o.IgnoreMissingKeys = true
+		}).Partial(map[string]any{"persona": "Pirate: "})
+
+		result, err := template.Format(map[string]any{})
+		assert.NoError(t, err)
+		assert.Equal(t, "Pirate: <no value>", result)
+	})
+
+	t.Run("InvalidType", func(t *testing.T) {
+		template := NewTemplate("{{.age}}").Partial(map[string]any{"age": 42})
+
+		_, err := template.Format(map[string]any{})
+		assert.ErrorIs(t, err, ErrInvalidPartialVariableType)
+	})
+}
+
+func TestTemplateStrict(t *testing.T) {
+	template := NewTemplate("Hello {{.name}}!", func(o *TemplateOptions) {
+		o.Strict = true
+	}).Partial(map[string]any{"greeting": "Hi"})
+
+	_, err := template.Format(map[string]any{"name": nil})
+	assert.ErrorIs(t, err, ErrMissingVariable)
+}
@@ -0,0 +1,99 @@
+package rag
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/chain"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+)
+
+const defaultHyDEPromptTemplate = `Please write a passage to answer the question.
+Question: {{.question}}
+Passage:`
+
+// Compile time check to ensure HyDE satisfies the Retriever interface.
+var _ schema.Retriever = (*HyDE)(nil)
+
+// HyDEOptions contains options for the HyDE retriever.
+type HyDEOptions struct {
+	// CallbackOptions contains options for the retriever callbacks.
+	*schema.CallbackOptions
+
+	// HyDEPrompt is the prompt template for the hypothetical document with the query as question value.
+	HyDEPrompt schema.PromptTemplate
+
+	// IncludeQuery determines whether the query is prepended to the hypothetical document used for the search.
+	IncludeQuery bool
+}
+
+// HyDE is a retriever implementing Hypothetical Document Embeddings (HyDE). It asks the model to write a
+// hypothetical document answering the query and searches the wrapped retriever with that document instead
+// of the query. Wrapping a vector store retriever, the hypothetical document is embedded for the similarity
+// search, which often improves the recall for short queries.
+type HyDE struct {
+	llmChain  *chain.LLM
+	retriever schema.Retriever
+	opts      HyDEOptions
+}
+
+// NewHyDE creates a new HyDE retriever with the given model and the wrapped retriever.
+func NewHyDE(model schema.Model, retriever schema.Retriever, optFns ...func(o *HyDEOptions)) (*HyDE, error) {
+	opts := HyDEOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.HyDEPrompt == nil {
+		opts.HyDEPrompt = prompt.NewTemplate(defaultHyDEPromptTemplate)
+	}
+
+	llmChain, err := chain.NewLLM(model, opts.HyDEPrompt, func(o *chain.LLMOptions) {
+		o.CallbackOptions = opts.CallbackOptions
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &HyDE{
+		llmChain:  llmChain,
+		retriever: retriever,
+		opts:      opts,
+	}, nil
+}
+
+// GetRelevantDocuments returns the documents of the wrapped retriever relevant to the hypothetical
+// document generated for the query.
+func (r *HyDE) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	hypotheticalDocument, err := golc.SimpleCall(ctx, r.llmChain, schema.ChainValues{
+		"question": query,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	hypotheticalDocument = strings.TrimSpace(hypotheticalDocument)
+
+	if r.opts.IncludeQuery {
+		hypotheticalDocument = strings.Join([]string{query, hypotheticalDocument}, "\n")
+	}
+
+	return r.retriever.GetRelevantDocuments(ctx, hypotheticalDocument)
+}
+
+// Verbose returns the verbosity setting of the retriever.
+func (r *HyDE) Verbose() bool {
+	return r.opts.CallbackOptions.Verbose
+}
+
+// Callbacks returns the registered callbacks of the retriever.
+func (r *HyDE) Callbacks() []schema.Callback {
+	return r.opts.CallbackOptions.Callbacks
+}
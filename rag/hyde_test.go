@@ -0,0 +1,48 @@
+package rag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHyDE(t *testing.T) {
+	docs := []schema.Document{{PageContent: "Berlin is the capital of Germany."}}
+
+	fake := llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+		assert.Contains(t, prompt, "Question: capital germany")
+
+		return &schema.ModelResult{
+			Generations: []schema.Generation{{Text: " The capital of Germany is Berlin. "}},
+			LLMOutput:   map[string]any{},
+		}, nil
+	})
+
+	t.Run("Hypothetical Document", func(t *testing.T) {
+		retriever := &mockRetriever{Docs: docs}
+
+		hyde, err := NewHyDE(fake, retriever)
+		assert.NoError(t, err)
+
+		result, err := hyde.GetRelevantDocuments(context.Background(), "capital germany")
+		assert.NoError(t, err)
+		assert.Equal(t, docs, result)
+		assert.Equal(t, "The capital of Germany is Berlin.", retriever.Query)
+	})
+
+	t.Run("Include Query", func(t *testing.T) {
+		retriever := &mockRetriever{Docs: docs}
+
+		hyde, err := NewHyDE(fake, retriever, func(o *HyDEOptions) {
+			o.IncludeQuery = true
+		})
+		assert.NoError(t, err)
+
+		_, err = hyde.GetRelevantDocuments(context.Background(), "capital germany")
+		assert.NoError(t, err)
+		assert.Equal(t, "capital germany\nThe capital of Germany is Berlin.", retriever.Query)
+	})
+}
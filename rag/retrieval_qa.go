@@ -30,6 +30,9 @@ type RetrievalQAOptions struct {
 	// Return the source documents
 	ReturnSourceDocuments bool
 
+	// Include the similarity scores of the retriever in the metadata of the source documents
+	ReturnScores bool
+
 	// If set, restricts the docs to return from store based on tokens, enforced only
 	// for StuffDocumentsChain
 	MaxTokenLimit uint
@@ -108,7 +111,11 @@ func (c *RetrievalQA) Call(ctx context.Context, values schema.ChainValues, optFn
 	}
 
 	if c.opts.ReturnSourceDocuments {
-		result["sourceDocuments"] = docs
+		if c.opts.ReturnScores {
+			result["sourceDocuments"] = docs
+		} else {
+			result["sourceDocuments"] = withoutScores(docs)
+		}
 	}
 
 	return result, nil
@@ -147,6 +154,21 @@ func (c *RetrievalQA) getDocuments(ctx context.Context, query string, opts schem
 	return docs[:numDocs], nil
 }
 
+// withoutScores returns the documents without the similarity scores in their metadata.
+func withoutScores(docs []schema.Document) []schema.Document {
+	result := make([]schema.Document, len(docs))
+
+	for i, doc := range docs {
+		result[i] = doc
+
+		if _, ok := doc.Metadata["score"]; ok {
+			result[i].Metadata = util.OmitByKeys(doc.Metadata, []string{"score"})
+		}
+	}
+
+	return result
+}
+
 // Memory returns the memory associated with the chain.
 func (c *RetrievalQA) Memory() schema.Memory {
 	return nil
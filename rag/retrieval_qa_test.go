@@ -0,0 +1,53 @@
+package rag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetrievalQA(t *testing.T) {
+	docs := []schema.Document{
+		{PageContent: "Berlin is the capital of Germany.", Metadata: map[string]any{"source": "a", "score": float32(0.9)}},
+	}
+
+	fake := llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+		return &schema.ModelResult{
+			Generations: []schema.Generation{{Text: "Berlin"}},
+			LLMOutput:   map[string]any{},
+		}, nil
+	})
+
+	t.Run("SourceDocumentsWithoutScores", func(t *testing.T) {
+		qa, err := NewRetrievalQA(fake, &mockRetriever{Docs: docs}, func(o *RetrievalQAOptions) {
+			o.ReturnSourceDocuments = true
+		})
+		assert.NoError(t, err)
+
+		outputs, err := golc.Call(context.Background(), qa, schema.ChainValues{
+			"question": "What is the capital of Germany?",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []schema.Document{
+			{PageContent: "Berlin is the capital of Germany.", Metadata: map[string]any{"source": "a"}},
+		}, outputs["sourceDocuments"])
+	})
+
+	t.Run("SourceDocumentsWithScores", func(t *testing.T) {
+		qa, err := NewRetrievalQA(fake, &mockRetriever{Docs: docs}, func(o *RetrievalQAOptions) {
+			o.ReturnSourceDocuments = true
+			o.ReturnScores = true
+		})
+		assert.NoError(t, err)
+
+		outputs, err := golc.Call(context.Background(), qa, schema.ChainValues{
+			"question": "What is the capital of Germany?",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, docs, outputs["sourceDocuments"])
+	})
+}
@@ -0,0 +1,298 @@
+package rag
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/chain"
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/retriever"
+	"github.com/hupe1980/golc/schema"
+)
+
+const defaultRetrievalQAWithCitationsPromptTemplate = `Use the following numbered sources to answer the question at the end. Cite the sources supporting each sentence of the answer with their numbers in square brackets, e.g. [1] or [1][3]. If you don't know the answer, just say that you don't know, don't try to make up an answer.
+
+{{.sources}}
+
+Question: {{.question}}
+Helpful Answer:`
+
+// citationMarkerRegex matches citation markers like [1] or [1, 2] including the preceding whitespace.
+var citationMarkerRegex = regexp.MustCompile(`\s*\[(\d+(?:\s*,\s*\d+)*)\]`)
+
+// Compile time check to ensure RetrievalQAWithCitations satisfies the Chain interface.
+var _ schema.Chain = (*RetrievalQAWithCitations)(nil)
+
+// CitedDocument represents a retrieved document cited in the answer of a RetrievalQAWithCitations chain.
+type CitedDocument struct {
+	// Number is the number of the document in the prompt, starting at 1.
+	Number int
+	// Document is the cited document.
+	Document schema.Document
+	// Citations are the spans of the answer citing the document. The offsets refer to the characters
+	// of the answer without the citation markers.
+	Citations []schema.Citation
+}
+
+// RetrievalQAWithCitationsOptions contains options for the RetrievalQAWithCitations chain.
+type RetrievalQAWithCitationsOptions struct {
+	// CallbackOptions contains options for the chain callbacks.
+	*schema.CallbackOptions
+
+	// RetrievalQAWithCitationsPrompt is the prompt template with the numbered sources and the question
+	// as sources and question values.
+	RetrievalQAWithCitationsPrompt schema.PromptTemplate
+
+	// InputKey is the key to access the input value containing the question.
+	InputKey string
+
+	// OutputKey is the key to access the output value containing the answer without citation markers.
+	OutputKey string
+
+	// CitationsKey is the key to access the output value containing the cited documents.
+	CitationsKey string
+}
+
+// RetrievalQAWithCitations is a chain that answers questions using retrieved documents. The documents are
+// numbered in the prompt and the model is instructed to cite them with [n] markers. The markers are removed
+// from the answer and returned as cited documents with the character offsets of the citing spans.
+type RetrievalQAWithCitations struct {
+	llmChain  *chain.LLM
+	retriever schema.Retriever
+	opts      RetrievalQAWithCitationsOptions
+}
+
+// NewRetrievalQAWithCitations creates a new RetrievalQAWithCitations chain with the given model and retriever.
+func NewRetrievalQAWithCitations(model schema.Model, retriever schema.Retriever, optFns ...func(o *RetrievalQAWithCitationsOptions)) (*RetrievalQAWithCitations, error) {
+	opts := RetrievalQAWithCitationsOptions{
+		InputKey:     "question",
+		OutputKey:    "answer",
+		CitationsKey: "citations",
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.RetrievalQAWithCitationsPrompt == nil {
+		opts.RetrievalQAWithCitationsPrompt = prompt.NewTemplate(defaultRetrievalQAWithCitationsPromptTemplate)
+	}
+
+	llmChain, err := chain.NewLLM(model, opts.RetrievalQAWithCitationsPrompt, func(o *chain.LLMOptions) {
+		o.CallbackOptions = opts.CallbackOptions
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RetrievalQAWithCitations{
+		llmChain:  llmChain,
+		retriever: retriever,
+		opts:      opts,
+	}, nil
+}
+
+// Call executes the RetrievalQAWithCitations chain with the given context and inputs.
+// It returns the outputs of the chain or an error, if any.
+func (c *RetrievalQAWithCitations) Call(ctx context.Context, values schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+	opts := schema.CallOptions{
+		CallbackManger: &callback.NoopManager{},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	question, err := values.GetString(c.opts.InputKey)
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err := retriever.Run(ctx, c.retriever, question, func(o *retriever.Options) {
+		o.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
+		o.ParentRunID = opts.CallbackManger.RunID()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make([]string, len(docs))
+	for i, doc := range docs {
+		sources[i] = fmt.Sprintf("[%d] %s", i+1, doc.PageContent)
+	}
+
+	result, err := golc.SimpleCall(ctx, c.llmChain, schema.ChainValues{
+		"question": question,
+		"sources":  strings.Join(sources, "\n\n"),
+	}, func(sco *golc.SimpleCallOptions) {
+		sco.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
+		sco.ParentRunID = opts.CallbackManger.RunID()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	answer, citations := parseCitations(strings.TrimSpace(result), len(docs))
+
+	return schema.ChainValues{
+		c.opts.OutputKey:    answer,
+		c.opts.CitationsKey: citedDocuments(docs, citations),
+	}, nil
+}
+
+// Memory returns the memory associated with the chain.
+func (c *RetrievalQAWithCitations) Memory() schema.Memory {
+	return nil
+}
+
+// Type returns the type of the chain.
+func (c *RetrievalQAWithCitations) Type() string {
+	return "RetrievalQAWithCitations"
+}
+
+// Verbose returns the verbosity setting of the chain.
+func (c *RetrievalQAWithCitations) Verbose() bool {
+	return c.opts.CallbackOptions.Verbose
+}
+
+// Callbacks returns the callbacks associated with the chain.
+func (c *RetrievalQAWithCitations) Callbacks() []schema.Callback {
+	return c.opts.CallbackOptions.Callbacks
+}
+
+// InputKeys returns the expected input keys.
+func (c *RetrievalQAWithCitations) InputKeys() []string {
+	return []string{c.opts.InputKey}
+}
+
+// OutputKeys returns the output keys the chain will return.
+func (c *RetrievalQAWithCitations) OutputKeys() []string {
+	return []string{c.opts.OutputKey, c.opts.CitationsKey}
+}
+
+// parseCitations removes the citation markers from the answer and returns the cited spans of the answer.
+// A cited span is the sentence preceding a marker. Adjacent markers cite the same span. Numbers outside
+// the range of the documents are removed without citing a span.
+func parseCitations(answer string, numDocs int) (string, []schema.Citation) {
+	var (
+		cleaned   []rune
+		citations []schema.Citation
+		last      int
+		prevEnd   = -1
+	)
+
+	for _, m := range citationMarkerRegex.FindAllStringSubmatchIndex(answer, -1) {
+		cleaned = append(cleaned, []rune(answer[last:m[0]])...)
+		last = m[1]
+
+		var ids []string
+
+		for _, n := range strings.Split(answer[m[2]:m[3]], ",") {
+			number, err := strconv.Atoi(strings.TrimSpace(n))
+			if err != nil || number < 1 || number > numDocs {
+				continue
+			}
+
+			ids = append(ids, strconv.Itoa(number))
+		}
+
+		if len(ids) == 0 {
+			continue
+		}
+
+		if m[0] == prevEnd && len(citations) > 0 {
+			prev := &citations[len(citations)-1]
+			prev.DocumentIDs = util.Uniq(append(prev.DocumentIDs, ids...))
+			prevEnd = m[1]
+
+			continue
+		}
+
+		prevEnd = m[1]
+
+		end := len(cleaned)
+		start := sentenceStart(cleaned, end)
+
+		citations = append(citations, schema.Citation{
+			Start:       start,
+			End:         end,
+			Text:        string(cleaned[start:end]),
+			DocumentIDs: util.Uniq(ids),
+		})
+	}
+
+	cleaned = append(cleaned, []rune(answer[last:])...)
+
+	return string(cleaned), citations
+}
+
+// sentenceStart returns the index of the first character of the sentence ending at end.
+func sentenceStart(text []rune, end int) int {
+	i := end - 1
+
+	// Skip the terminator of the sentence itself, if the marker follows it.
+	for i >= 0 && strings.ContainsRune(".!?", text[i]) {
+		i--
+	}
+
+	for i >= 0 && !isSentenceBoundary(text, i) {
+		i--
+	}
+
+	start := i + 1
+	for start < end && unicode.IsSpace(text[start]) {
+		start++
+	}
+
+	return start
+}
+
+// isSentenceBoundary checks if the character at index i ends a sentence, i.e. it is a line break or a
+// terminator followed by whitespace.
+func isSentenceBoundary(text []rune, i int) bool {
+	if text[i] == '\n' {
+		return true
+	}
+
+	return strings.ContainsRune(".!?", text[i]) && i+1 < len(text) && unicode.IsSpace(text[i+1])
+}
+
+// citedDocuments returns the cited documents in the order of their numbers.
+func citedDocuments(docs []schema.Document, citations []schema.Citation) []CitedDocument {
+	cited := make([]*CitedDocument, len(docs))
+
+	for _, citation := range citations {
+		for _, id := range citation.DocumentIDs {
+			number, _ := strconv.Atoi(id)
+
+			if cited[number-1] == nil {
+				cited[number-1] = &CitedDocument{
+					Number:   number,
+					Document: docs[number-1],
+				}
+			}
+
+			cited[number-1].Citations = append(cited[number-1].Citations, citation)
+		}
+	}
+
+	result := []CitedDocument{}
+
+	for _, c := range cited {
+		if c != nil {
+			result = append(result, *c)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,121 @@
+package rag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetrievalQAWithCitations(t *testing.T) {
+	docs := []schema.Document{
+		{PageContent: "Berlin is the capital of Germany.", Metadata: map[string]any{"source": "a"}},
+		{PageContent: "Berlin has 3.7 million inhabitants.", Metadata: map[string]any{"source": "b"}},
+		{PageContent: "Paris is the capital of France.", Metadata: map[string]any{"source": "c"}},
+	}
+
+	var prompt string
+
+	fake := llm.NewFake(func(ctx context.Context, p string) (*schema.ModelResult, error) {
+		prompt = p
+
+		return &schema.ModelResult{
+			Generations: []schema.Generation{{Text: "Berlin is the capital [1]. It has 3.7 million inhabitants [2][9]."}},
+			LLMOutput:   map[string]any{},
+		}, nil
+	})
+
+	qa, err := NewRetrievalQAWithCitations(fake, &mockRetriever{Docs: docs})
+	assert.NoError(t, err)
+
+	outputs, err := golc.Call(context.Background(), qa, schema.ChainValues{
+		"question": "What is the capital of Germany?",
+	})
+	assert.NoError(t, err)
+
+	assert.Contains(t, prompt, "[1] Berlin is the capital of Germany.\n\n[2] Berlin has 3.7 million inhabitants.\n\n[3] Paris")
+	assert.Equal(t, "Berlin is the capital. It has 3.7 million inhabitants.", outputs["answer"])
+	assert.Equal(t, []CitedDocument{
+		{
+			Number:    1,
+			Document:  docs[0],
+			Citations: []schema.Citation{{Start: 0, End: 21, Text: "Berlin is the capital", DocumentIDs: []string{"1"}}},
+		},
+		{
+			Number:    2,
+			Document:  docs[1],
+			Citations: []schema.Citation{{Start: 23, End: 53, Text: "It has 3.7 million inhabitants", DocumentIDs: []string{"2"}}},
+		},
+	}, outputs["citations"])
+}
+
+func TestParseCitations(t *testing.T) {
+	testCases := []struct {
+		name      string
+		answer    string
+		expected  string
+		citations []schema.Citation
+	}{
+		{
+			name:     "No Markers",
+			answer:   "I don't know.",
+			expected: "I don't know.",
+		},
+		{
+			name:     "Marker After Terminator",
+			answer:   "First. Second sentence.[2] Third.",
+			expected: "First. Second sentence. Third.",
+			citations: []schema.Citation{
+				{Start: 7, End: 23, Text: "Second sentence.", DocumentIDs: []string{"2"}},
+			},
+		},
+		{
+			name:     "Adjacent And Grouped Markers",
+			answer:   "Grüße aus Köln [1, 2] [3].",
+			expected: "Grüße aus Köln.",
+			citations: []schema.Citation{
+				{Start: 0, End: 14, Text: "Grüße aus Köln", DocumentIDs: []string{"1", "2", "3"}},
+			},
+		},
+		{
+			name:     "Out Of Range",
+			answer:   "Answer [0] [4].",
+			expected: "Answer.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			answer, citations := parseCitations(tc.answer, 3)
+			assert.Equal(t, tc.expected, answer)
+			assert.Equal(t, tc.citations, citations)
+		})
+	}
+}
+
+type mockRetriever struct {
+	Docs                     []schema.Document
+	Query                    string
+	GetRelevantDocumentsFunc func(ctx context.Context, query string) ([]schema.Document, error)
+}
+
+func (r *mockRetriever) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	if r.GetRelevantDocumentsFunc != nil {
+		return r.GetRelevantDocumentsFunc(ctx, query)
+	}
+
+	r.Query = query
+
+	return r.Docs, nil
+}
+
+func (r *mockRetriever) Verbose() bool {
+	return false
+}
+
+func (r *mockRetriever) Callbacks() []schema.Callback {
+	return nil
+}
@@ -2,6 +2,7 @@ package rag
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/hupe1980/golc"
@@ -14,11 +15,35 @@ import (
 // Compile time check to ensure StuffDocuments satisfies the Chain interface.
 var _ schema.Chain = (*StuffDocuments)(nil)
 
+// StuffStrategy is the strategy to fit the documents into the token budget of the stuffed prompt.
+type StuffStrategy string
+
+const (
+	// StuffStrategyDrop keeps the leading documents that fit into the token budget and drops the remaining documents.
+	StuffStrategyDrop StuffStrategy = "drop"
+	// StuffStrategyTruncate is like StuffStrategyDrop, but truncates the first document that does not fit
+	// to the remaining token budget.
+	StuffStrategyTruncate StuffStrategy = "truncate"
+	// StuffStrategySummarize replaces the documents that do not fit into the remaining token budget with
+	// their summaries. Summaries that do not fit either are dropped.
+	StuffStrategySummarize StuffStrategy = "summarize"
+)
+
 type StuffDocumentsOptions struct {
 	*schema.CallbackOptions
 	InputKey             string
 	DocumentVariableName string
 	DocumentSeparator    string
+	// MaxTokens is the maximum number of tokens of the stuffed prompt, e.g. the context window of the model
+	// minus the tokens reserved for the completion. A value <= 0 disables the token budget.
+	MaxTokens int
+	// Strategy is the strategy applied when the stuffed prompt would exceed MaxTokens.
+	Strategy StuffStrategy
+	// Tokenizer counts the tokens of the stuffed prompt. Defaults to the tokenizer of the model of the llm chain.
+	Tokenizer schema.Tokenizer
+	// SummarizeChain summarizes a single document for the StuffStrategySummarize strategy. It must
+	// have a single input key, which receives the page content, and a single output key.
+	SummarizeChain schema.Chain
 }
 
 type StuffDocuments struct {
@@ -34,12 +59,23 @@ func NewStuffDocuments(llmChain *chain.LLM, optFns ...func(o *StuffDocumentsOpti
 		InputKey:             "inputDocuments",
 		DocumentVariableName: "text",
 		DocumentSeparator:    "\n\n",
+		Strategy:             StuffStrategyDrop,
 	}
 
 	for _, fn := range optFns {
 		fn(&opts)
 	}
 
+	switch opts.Strategy {
+	case StuffStrategyDrop, StuffStrategyTruncate:
+	case StuffStrategySummarize:
+		if opts.SummarizeChain == nil {
+			return nil, ErrNoSummarizeChain
+		}
+	default:
+		return nil, fmt.Errorf("unknown stuff strategy: %s", opts.Strategy)
+	}
+
 	return &StuffDocuments{
 		llmChain: llmChain,
 		opts:     opts,
@@ -62,12 +98,19 @@ func (c *StuffDocuments) Call(ctx context.Context, inputs schema.ChainValues, op
 		return nil, err
 	}
 
+	rest := schema.ChainValues(util.OmitByKeys(inputs, []string{c.opts.InputKey}))
+
 	contents := make([]string, len(docs))
 	for i, doc := range docs {
 		contents[i] = doc.PageContent
 	}
 
-	rest := schema.ChainValues(util.OmitByKeys(inputs, []string{c.opts.InputKey}))
+	if c.opts.MaxTokens > 0 {
+		contents, err = c.fitContents(ctx, rest, contents, opts.CallbackManger)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	rest[c.opts.DocumentVariableName] = strings.Join(contents, c.opts.DocumentSeparator)
 
@@ -84,6 +127,122 @@ func (c *StuffDocuments) Call(ctx context.Context, inputs schema.ChainValues, op
 	}, nil
 }
 
+// fitContents applies the strategy to fit the contents into the token budget of the stuffed prompt.
+func (c *StuffDocuments) fitContents(ctx context.Context, values schema.ChainValues, contents []string, cm schema.CallbackManagerForChainRun) ([]string, error) {
+	overhead, err := c.countPromptTokens(ctx, values, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if overhead > c.opts.MaxTokens {
+		return nil, fmt.Errorf("prompt without documents exceeds the token budget: %d > %d", overhead, c.opts.MaxTokens)
+	}
+
+	fitted := []string{}
+
+	for _, content := range contents {
+		ok, err := c.fits(ctx, values, append(fitted, content))
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			fitted = append(fitted, content)
+			continue
+		}
+
+		switch c.opts.Strategy {
+		case StuffStrategyTruncate:
+			truncated, err := c.truncate(ctx, values, fitted, content)
+			if err != nil {
+				return nil, err
+			}
+
+			if truncated != "" {
+				fitted = append(fitted, truncated)
+			}
+
+			return fitted, nil
+		case StuffStrategySummarize:
+			summary, err := golc.SimpleCall(ctx, c.opts.SummarizeChain, content, func(co *golc.SimpleCallOptions) {
+				co.Callbacks = cm.GetInheritableCallbacks()
+				co.ParentRunID = cm.RunID()
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			ok, err := c.fits(ctx, values, append(fitted, summary))
+			if err != nil {
+				return nil, err
+			}
+
+			if ok {
+				fitted = append(fitted, summary)
+			}
+		default:
+			return fitted, nil
+		}
+	}
+
+	return fitted, nil
+}
+
+// truncate returns the longest prefix of the content that fits into the remaining token budget.
+func (c *StuffDocuments) truncate(ctx context.Context, values schema.ChainValues, fitted []string, content string) (string, error) {
+	runes := []rune(content)
+
+	low, high := 0, len(runes)
+
+	for low < high {
+		mid := (low + high + 1) / 2
+
+		ok, err := c.fits(ctx, values, append(fitted, string(runes[:mid])))
+		if err != nil {
+			return "", err
+		}
+
+		if ok {
+			low = mid
+		} else {
+			high = mid - 1
+		}
+	}
+
+	return string(runes[:low]), nil
+}
+
+func (c *StuffDocuments) fits(ctx context.Context, values schema.ChainValues, contents []string) (bool, error) {
+	tokens, err := c.countPromptTokens(ctx, values, contents)
+	if err != nil {
+		return false, err
+	}
+
+	return tokens <= c.opts.MaxTokens, nil
+}
+
+func (c *StuffDocuments) countPromptTokens(ctx context.Context, values schema.ChainValues, contents []string) (int, error) {
+	promptValues := util.CopyMap(values)
+	promptValues[c.opts.DocumentVariableName] = strings.Join(contents, c.opts.DocumentSeparator)
+
+	text, err := c.llmChain.Prompt().Format(promptValues)
+	if err != nil {
+		return 0, err
+	}
+
+	getNumTokens := c.llmChain.GetNumTokens
+	if c.opts.Tokenizer != nil {
+		getNumTokens = c.opts.Tokenizer.GetNumTokens
+	}
+
+	tokens, err := getNumTokens(ctx, text)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(tokens), nil
+}
+
 // Memory returns the memory associated with the chain.
 func (c *StuffDocuments) Memory() schema.Memory {
 	return nil
@@ -0,0 +1,112 @@
+package rag
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/chain"
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStuffDocuments(t *testing.T) {
+	// The fake model echoes the prompt, so the output is the stuffed prompt.
+	fake := llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+		return &schema.ModelResult{
+			Generations: []schema.Generation{{Text: prompt}},
+			LLMOutput:   map[string]any{},
+		}, nil
+	})
+
+	llmChain, err := chain.NewLLM(fake, prompt.NewTemplate("Context: {{.text}}"))
+	assert.NoError(t, err)
+
+	summarizeChain, err := chain.NewTransform([]string{"input"}, []string{"output"}, func(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+		return schema.ChainValues{"output": strings.Fields(inputs["input"].(string))[0]}, nil
+	})
+	assert.NoError(t, err)
+
+	docs := []schema.Document{
+		{PageContent: "a b c"},
+		{PageContent: "d e f"},
+		{PageContent: "g h"},
+	}
+
+	testCases := []struct {
+		name      string
+		maxTokens int
+		strategy  StuffStrategy
+		expected  string
+	}{
+		{name: "NoBudget", maxTokens: 0, strategy: StuffStrategyDrop, expected: "Context: a b c\n\nd e f\n\ng h"},
+		{name: "Drop", maxTokens: 5, strategy: StuffStrategyDrop, expected: "Context: a b c"},
+		{name: "Truncate", maxTokens: 5, strategy: StuffStrategyTruncate, expected: "Context: a b c\n\nd"},
+		{name: "Summarize", maxTokens: 5, strategy: StuffStrategySummarize, expected: "Context: a b c\n\nd"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stuffDocuments, err := NewStuffDocuments(llmChain, func(o *StuffDocumentsOptions) {
+				o.MaxTokens = tc.maxTokens
+				o.Strategy = tc.strategy
+				o.Tokenizer = &wordTokenizer{}
+				o.SummarizeChain = summarizeChain
+			})
+			assert.NoError(t, err)
+
+			output, err := golc.SimpleCall(context.Background(), stuffDocuments, schema.ChainValues{
+				"inputDocuments": docs,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, output)
+		})
+	}
+
+	t.Run("PromptExceedsBudget", func(t *testing.T) {
+		longLLMChain, err := chain.NewLLM(fake, prompt.NewTemplate("Use the following context: {{.text}}"))
+		assert.NoError(t, err)
+
+		stuffDocuments, err := NewStuffDocuments(longLLMChain, func(o *StuffDocumentsOptions) {
+			o.MaxTokens = 2
+			o.Tokenizer = &wordTokenizer{}
+		})
+		assert.NoError(t, err)
+
+		_, err = golc.SimpleCall(context.Background(), stuffDocuments, schema.ChainValues{
+			"inputDocuments": docs,
+		})
+		assert.EqualError(t, err, "prompt without documents exceeds the token budget: 4 > 2")
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		_, err := NewStuffDocuments(llmChain, func(o *StuffDocumentsOptions) {
+			o.Strategy = StuffStrategySummarize
+		})
+		assert.ErrorIs(t, err, ErrNoSummarizeChain)
+
+		_, err = NewStuffDocuments(llmChain, func(o *StuffDocumentsOptions) {
+			o.Strategy = "unknown"
+		})
+		assert.EqualError(t, err, "unknown stuff strategy: unknown")
+	})
+}
+
+// wordTokenizer is a tokenizer that counts whitespace separated words.
+type wordTokenizer struct{}
+
+func (t *wordTokenizer) GetNumTokens(ctx context.Context, text string) (uint, error) {
+	return uint(len(strings.Fields(text))), nil
+}
+
+func (t *wordTokenizer) GetNumTokensFromMessage(ctx context.Context, messages schema.ChatMessages) (uint, error) {
+	text, err := messages.Format()
+	if err != nil {
+		return 0, err
+	}
+
+	return t.GetNumTokens(ctx, text)
+}
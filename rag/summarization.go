@@ -1,10 +1,15 @@
 package rag
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/callback"
 	"github.com/hupe1980/golc/chain"
 	"github.com/hupe1980/golc/prompt"
 	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/textsplitter"
 )
 
 const defaultStuffSummarizationTemplate = `Write a concise summary of the following:
@@ -139,11 +144,236 @@ func NewMapReduceSummarization(model schema.Model, optFns ...func(o *MapReduceSu
 	}
 
 	combineChain, err := NewStuffSummarization(model, func(o *StuffSummarizationOptions) {
+		o.CallbackOptions = opts.CallbackOptions
 		o.StuffSummarizationPrompt = opts.StuffSummarizationPrompt
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return NewMapReduceDocuments(mapChain, combineChain)
+	return NewMapReduceDocuments(mapChain, combineChain, func(o *MapReduceDocumentsOptions) {
+		o.CallbackOptions = opts.CallbackOptions
+	})
+}
+
+const defaultBulletPointsSummarizationTemplate = `Write a concise summary of the following as bullet points covering the key points:
+
+
+"{{.text}}"
+
+
+BULLET POINT SUMMARY:`
+
+const defaultBulletPointsRefineSummarizationTemplate = `Your job is to produce a final summary as bullet points covering the key points
+We have provided an existing bullet point summary up to a certain point: "{{.existingAnswer}}"
+We have the opportunity to refine the existing bullet point summary (only if needed) with some more context below.
+------------
+{{.text}}
+------------
+Given the new context, refine the original bullet point summary
+If the context isn't useful, return the original bullet point summary`
+
+// Compile time check to ensure Summarization satisfies the Chain interface.
+var _ schema.Chain = (*Summarization)(nil)
+
+// SummarizationStrategy is the strategy of a Summarization chain to combine the documents.
+type SummarizationStrategy string
+
+const (
+	// SummarizationStrategyStuff summarizes all documents with a single model call.
+	SummarizationStrategyStuff SummarizationStrategy = "stuff"
+	// SummarizationStrategyMapReduce summarizes each chunk and then combines the summaries into the final summary.
+	SummarizationStrategyMapReduce SummarizationStrategy = "map_reduce"
+	// SummarizationStrategyRefine summarizes the first chunk and refines the summary with each following chunk.
+	SummarizationStrategyRefine SummarizationStrategy = "refine"
+)
+
+// SummarizationStyle is the style of the summary of a Summarization chain.
+type SummarizationStyle string
+
+const (
+	// SummarizationStyleAbstract produces a summary in prose.
+	SummarizationStyleAbstract SummarizationStyle = "abstract"
+	// SummarizationStyleBulletPoints produces a summary as bullet points.
+	SummarizationStyleBulletPoints SummarizationStyle = "bullet_points"
+)
+
+// SummarizationOptions contains options for the Summarization chain.
+type SummarizationOptions struct {
+	// CallbackOptions contains options for the chain callbacks.
+	*schema.CallbackOptions
+
+	// Style is the style of the summary. The default prompts of the strategy are selected by the style.
+	Style SummarizationStyle
+
+	// TextSplitter splits the documents into chunks for the map reduce and refine strategies.
+	// Defaults to a recursive character text splitter measuring the chunks in tokens of the model.
+	TextSplitter schema.TextSplitter
+
+	// ChunkSize is the maximum size of a chunk of the default text splitter in tokens.
+	ChunkSize int
+
+	// ChunkOverlap is the overlap of the chunks of the default text splitter in tokens.
+	ChunkOverlap int
+}
+
+// Summarization is a chain that summarizes documents with the stuff, map reduce or refine strategy.
+// For the map reduce and refine strategies, the documents are split into chunks that fit into the
+// context of the model before they are summarized. The stuff strategy summarizes the documents as they are.
+type Summarization struct {
+	summarizationChain schema.Chain
+	strategy           SummarizationStrategy
+	opts               SummarizationOptions
+}
+
+// NewSummarization creates a new Summarization chain with the given model and strategy.
+func NewSummarization(model schema.Model, strategy SummarizationStrategy, optFns ...func(o *SummarizationOptions)) (*Summarization, error) {
+	opts := SummarizationOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		Style:        SummarizationStyleAbstract,
+		ChunkSize:    2000,
+		ChunkOverlap: 100,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	var (
+		summarizationPrompt schema.PromptTemplate
+		refinePrompt        schema.PromptTemplate
+	)
+
+	switch opts.Style {
+	case SummarizationStyleAbstract:
+		summarizationPrompt = prompt.NewTemplate(defaultStuffSummarizationTemplate)
+		refinePrompt = prompt.NewTemplate(defaultRefineSummarizationTemplate)
+	case SummarizationStyleBulletPoints:
+		summarizationPrompt = prompt.NewTemplate(defaultBulletPointsSummarizationTemplate)
+		refinePrompt = prompt.NewTemplate(defaultBulletPointsRefineSummarizationTemplate)
+	default:
+		return nil, fmt.Errorf("unknown summarization style: %s", opts.Style)
+	}
+
+	var (
+		summarizationChain schema.Chain
+		err                error
+	)
+
+	switch strategy {
+	case SummarizationStrategyStuff:
+		summarizationChain, err = NewStuffSummarization(model, func(o *StuffSummarizationOptions) {
+			o.CallbackOptions = opts.CallbackOptions
+			o.StuffSummarizationPrompt = summarizationPrompt
+		})
+	case SummarizationStrategyMapReduce:
+		summarizationChain, err = NewMapReduceSummarization(model, func(o *MapReduceSummarizationOptions) {
+			o.CallbackOptions = opts.CallbackOptions
+			o.MapReduceSummarizationPrompt = summarizationPrompt
+			o.StuffSummarizationPrompt = summarizationPrompt
+		})
+	case SummarizationStrategyRefine:
+		summarizationChain, err = NewRefineSummarization(model, func(o *RefineSummarizationOptions) {
+			o.CallbackOptions = opts.CallbackOptions
+			o.StuffSummarizationPrompt = summarizationPrompt
+			o.RefineSummarizationPrompt = refinePrompt
+		})
+	default:
+		return nil, fmt.Errorf("unknown summarization strategy: %s", strategy)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.TextSplitter == nil {
+		opts.TextSplitter = textsplitter.NewRecusiveCharacterTextSplitter(func(o *textsplitter.RecursiveCharacterTextSplitterOptions) {
+			o.ChunkSize = opts.ChunkSize
+			o.ChunkOverlap = opts.ChunkOverlap
+			o.LengthFunc = func(text string) int {
+				n, err := model.GetNumTokens(context.Background(), text)
+				if err != nil {
+					return len(text)
+				}
+
+				return int(n)
+			}
+		})
+	}
+
+	return &Summarization{
+		summarizationChain: summarizationChain,
+		strategy:           strategy,
+		opts:               opts,
+	}, nil
+}
+
+// Call executes the Summarization chain with the given context and inputs.
+// It returns the outputs of the chain or an error, if any.
+func (c *Summarization) Call(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+	opts := schema.CallOptions{
+		CallbackManger: &callback.NoopManager{},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	inputKey := c.summarizationChain.InputKeys()[0]
+
+	docs, err := inputs.GetDocuments(inputKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.strategy != SummarizationStrategyStuff {
+		docs, err = c.opts.TextSplitter.SplitDocuments(docs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(docs) == 0 {
+		return nil, ErrNoInputValues
+	}
+
+	summarizationInputs := inputs.Clone()
+	summarizationInputs[inputKey] = docs
+
+	return golc.Call(ctx, c.summarizationChain, summarizationInputs, func(co *golc.CallOptions) {
+		co.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
+		co.ParentRunID = opts.CallbackManger.RunID()
+	})
+}
+
+// Memory returns the memory associated with the chain.
+func (c *Summarization) Memory() schema.Memory {
+	return nil
+}
+
+// Type returns the type of the chain.
+func (c *Summarization) Type() string {
+	return "Summarization"
+}
+
+// Verbose returns the verbosity setting of the chain.
+func (c *Summarization) Verbose() bool {
+	return c.opts.CallbackOptions.Verbose
+}
+
+// Callbacks returns the callbacks associated with the chain.
+func (c *Summarization) Callbacks() []schema.Callback {
+	return c.opts.CallbackOptions.Callbacks
+}
+
+// InputKeys returns the expected input keys.
+func (c *Summarization) InputKeys() []string {
+	return c.summarizationChain.InputKeys()
+}
+
+// OutputKeys returns the output keys the chain will return.
+func (c *Summarization) OutputKeys() []string {
+	return c.summarizationChain.OutputKeys()
 }
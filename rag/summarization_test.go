@@ -0,0 +1,108 @@
+package rag
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarization(t *testing.T) {
+	docs := []schema.Document{
+		{PageContent: "one two three four five six seven eight"},
+	}
+
+	newFake := func(prompts *[]string) *llm.Fake {
+		mu := sync.Mutex{}
+
+		return llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			*prompts = append(*prompts, prompt)
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: "summary"}},
+				LLMOutput:   map[string]any{},
+			}, nil
+		}, func(o *llm.FakeOptions) {
+			o.Tokenizer = &wordTokenizer{}
+		})
+	}
+
+	testCases := []struct {
+		name     string
+		strategy SummarizationStrategy
+		style    SummarizationStyle
+		calls    int
+		contains string
+	}{
+		{name: "Stuff", strategy: SummarizationStrategyStuff, style: SummarizationStyleAbstract, calls: 1, contains: "CONCISE SUMMARY:"},
+		{name: "MapReduce", strategy: SummarizationStrategyMapReduce, style: SummarizationStyleAbstract, calls: 3, contains: "CONCISE SUMMARY:"},
+		{name: "Refine", strategy: SummarizationStrategyRefine, style: SummarizationStyleAbstract, calls: 2, contains: "refine the original summary"},
+		{name: "BulletPoints", strategy: SummarizationStrategyRefine, style: SummarizationStyleBulletPoints, calls: 2, contains: "refine the original bullet point summary"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prompts := []string{}
+
+			summarization, err := NewSummarization(newFake(&prompts), tc.strategy, func(o *SummarizationOptions) {
+				o.Style = tc.style
+				o.ChunkSize = 4
+				o.ChunkOverlap = 0
+			})
+			assert.NoError(t, err)
+
+			summary, err := golc.SimpleCall(context.Background(), summarization, docs)
+			assert.NoError(t, err)
+			assert.Equal(t, "summary", summary)
+
+			assert.Len(t, prompts, tc.calls)
+			assert.Contains(t, prompts[len(prompts)-1], tc.contains)
+		})
+	}
+
+	t.Run("Chunks", func(t *testing.T) {
+		prompts := []string{}
+
+		summarization, err := NewSummarization(newFake(&prompts), SummarizationStrategyMapReduce, func(o *SummarizationOptions) {
+			o.Style = SummarizationStyleBulletPoints
+			o.ChunkSize = 4
+			o.ChunkOverlap = 0
+		})
+		assert.NoError(t, err)
+
+		_, err = golc.SimpleCall(context.Background(), summarization, docs)
+		assert.NoError(t, err)
+
+		mapPrompts := []string{}
+
+		for _, p := range prompts {
+			if strings.Contains(p, "one") || strings.Contains(p, "five") {
+				mapPrompts = append(mapPrompts, p)
+			}
+
+			assert.Contains(t, p, "BULLET POINT SUMMARY:")
+		}
+
+		assert.Len(t, mapPrompts, 2)
+	})
+
+	t.Run("Unknown Strategy", func(t *testing.T) {
+		_, err := NewSummarization(llm.NewSimpleFake("summary"), SummarizationStrategy("unknown"))
+		assert.EqualError(t, err, "unknown summarization strategy: unknown")
+	})
+
+	t.Run("Unknown Style", func(t *testing.T) {
+		_, err := NewSummarization(llm.NewSimpleFake("summary"), SummarizationStrategyStuff, func(o *SummarizationOptions) {
+			o.Style = SummarizationStyle("unknown")
+		})
+		assert.EqualError(t, err, "unknown summarization style: unknown")
+	})
+}
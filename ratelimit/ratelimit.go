@@ -0,0 +1,179 @@
+// Package ratelimit provides client-side rate limiting of requests and tokens for model providers.
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"sync"
+	"time"
+)
+
+// Options contains the options for a Limiter.
+type Options struct {
+	// RequestsPerMinute is the maximum number of requests per minute. A value <= 0 disables the limit.
+	RequestsPerMinute int
+	// TokensPerMinute is the maximum number of consumed tokens per minute. A value <= 0 disables the limit.
+	TokensPerMinute int
+}
+
+// Limiter limits the requests and consumed tokens with token buckets that are refilled continuously.
+// Each bucket holds at most the quota of one minute.
+type Limiter struct {
+	requests *bucket
+	tokens   *bucket
+	now      func() time.Time
+	mu       sync.Mutex
+}
+
+// New creates a new Limiter.
+func New(optFns ...func(o *Options)) *Limiter {
+	opts := Options{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	now := time.Now
+
+	return &Limiter{
+		requests: newBucket(opts.RequestsPerMinute, now()),
+		tokens:   newBucket(opts.TokensPerMinute, now()),
+		now:      now,
+	}
+}
+
+var (
+	shared   = make(map[string]*Limiter)
+	sharedMu sync.Mutex
+)
+
+// Shared returns the limiter shared by all callers with the same key, e.g. the API key of a provider.
+// The limiter is created with the options on the first call for the key; later options are ignored.
+func Shared(key string, optFns ...func(o *Options)) *Limiter {
+	hash := sha256.Sum256([]byte(key))
+	id := hex.EncodeToString(hash[:])
+
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+
+	if l, ok := shared[id]; ok {
+		return l
+	}
+
+	l := New(optFns...)
+	shared[id] = l
+
+	return l
+}
+
+// Wait blocks until a request is allowed and takes it from the request bucket. Requests are also blocked
+// while the consumed tokens exceed the token quota. A nil Limiter never blocks.
+func (l *Limiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	for {
+		wait := l.reserve()
+		if wait <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// ConsumeTokens takes the tokens consumed by a request from the token bucket. The bucket can become
+// negative, which blocks further requests until it is refilled.
+func (l *Limiter) ConsumeTokens(tokens int) {
+	if l == nil || l.tokens == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens.refill(l.now())
+	l.tokens.level -= float64(tokens)
+}
+
+// reserve takes a request and returns 0, if the request is allowed. Otherwise, it returns the duration
+// to wait before trying again.
+func (l *Limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	var wait time.Duration
+
+	if l.requests != nil {
+		l.requests.refill(now)
+
+		if l.requests.level < 1 {
+			wait = l.requests.durationUntil(1)
+		}
+	}
+
+	if l.tokens != nil {
+		l.tokens.refill(now)
+
+		if l.tokens.level < 0 {
+			if d := l.tokens.durationUntil(0); d > wait {
+				wait = d
+			}
+		}
+	}
+
+	if wait > 0 {
+		return wait
+	}
+
+	if l.requests != nil {
+		l.requests.level--
+	}
+
+	return 0
+}
+
+type bucket struct {
+	capacity   float64
+	level      float64
+	ratePerSec float64
+	last       time.Time
+}
+
+func newBucket(perMinute int, now time.Time) *bucket {
+	if perMinute <= 0 {
+		return nil
+	}
+
+	return &bucket{
+		capacity:   float64(perMinute),
+		level:      float64(perMinute),
+		ratePerSec: float64(perMinute) / 60,
+		last:       now,
+	}
+}
+
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.level = math.Min(b.capacity, b.level+elapsed*b.ratePerSec)
+		b.last = now
+	}
+}
+
+// durationUntil returns the duration until the bucket is refilled to the level.
+func (b *bucket) durationUntil(level float64) time.Duration {
+	seconds := (level - b.level) / b.ratePerSec
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter(t *testing.T) {
+	newLimiter := func(now *time.Time, optFns ...func(o *Options)) *Limiter {
+		l := New(optFns...)
+		l.now = func() time.Time { return *now }
+
+		for _, b := range []*bucket{l.requests, l.tokens} {
+			if b != nil {
+				b.last = *now
+			}
+		}
+
+		return l
+	}
+
+	t.Run("Requests", func(t *testing.T) {
+		now := time.Now()
+
+		l := newLimiter(&now, func(o *Options) {
+			o.RequestsPerMinute = 2
+		})
+
+		assert.Equal(t, time.Duration(0), l.reserve())
+		assert.Equal(t, time.Duration(0), l.reserve())
+		assert.Equal(t, 30*time.Second, l.reserve())
+
+		now = now.Add(30 * time.Second)
+
+		assert.Equal(t, time.Duration(0), l.reserve())
+	})
+
+	t.Run("Tokens", func(t *testing.T) {
+		now := time.Now()
+
+		l := newLimiter(&now, func(o *Options) {
+			o.TokensPerMinute = 600
+		})
+
+		assert.Equal(t, time.Duration(0), l.reserve())
+
+		l.ConsumeTokens(700)
+
+		assert.Equal(t, 10*time.Second, l.reserve())
+
+		now = now.Add(10 * time.Second)
+
+		assert.Equal(t, time.Duration(0), l.reserve())
+	})
+
+	t.Run("Unlimited", func(t *testing.T) {
+		l := New()
+
+		for i := 0; i < 100; i++ {
+			require.NoError(t, l.Wait(context.Background()))
+		}
+
+		l.ConsumeTokens(1000)
+		require.NoError(t, l.Wait(context.Background()))
+	})
+
+	t.Run("NilLimiter", func(t *testing.T) {
+		var l *Limiter
+
+		require.NoError(t, l.Wait(context.Background()))
+		l.ConsumeTokens(10)
+	})
+
+	t.Run("ContextCanceled", func(t *testing.T) {
+		l := New(func(o *Options) {
+			o.RequestsPerMinute = 1
+		})
+
+		require.NoError(t, l.Wait(context.Background()))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		assert.ErrorIs(t, l.Wait(ctx), context.DeadlineExceeded)
+	})
+}
+
+func TestShared(t *testing.T) {
+	l1 := Shared("key", func(o *Options) {
+		o.RequestsPerMinute = 10
+	})
+
+	assert.Same(t, l1, Shared("key"))
+	assert.NotSame(t, l1, Shared("other"))
+}
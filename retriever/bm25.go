@@ -0,0 +1,186 @@
+package retriever
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure BM25 satisfies the Retriever interface.
+var _ schema.Retriever = (*BM25)(nil)
+
+// BM25Options contains options for the BM25 retriever.
+type BM25Options struct {
+	*schema.CallbackOptions
+	// K1 controls the term frequency saturation.
+	K1 float64
+	// B controls the document length normalization.
+	B float64
+	// TopK is the maximum number of returned documents. A value <= 0 returns all matching documents.
+	TopK int
+	// Tokenize splits a text into terms. Defaults to the lowercase sequences of letters and digits.
+	Tokenize func(text string) []string
+	// Stem reduces a term to its stem. Defaults to the term itself.
+	Stem func(term string) string
+}
+
+// BM25 is an in-memory retriever ranking documents by the Okapi BM25 score of their page content.
+// It requires no external service and is suited for keyword retrieval on small corpora and in tests.
+type BM25 struct {
+	docs         []schema.Document
+	termFreqs    []map[string]int
+	docLengths   []int
+	docFreqs     map[string]int
+	avgDocLength float64
+	opts         BM25Options
+}
+
+// NewBM25 creates a new BM25 retriever indexing the given documents.
+func NewBM25(docs []schema.Document, optFns ...func(o *BM25Options)) *BM25 {
+	opts := BM25Options{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		K1:       1.2,
+		B:        0.75,
+		TopK:     4,
+		Tokenize: tokenizeWords,
+		Stem: func(term string) string {
+			return term
+		},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	r := &BM25{
+		docs:       docs,
+		termFreqs:  make([]map[string]int, len(docs)),
+		docLengths: make([]int, len(docs)),
+		docFreqs:   make(map[string]int),
+		opts:       opts,
+	}
+
+	totalLength := 0
+
+	for i, doc := range docs {
+		terms := r.terms(doc.PageContent)
+
+		termFreqs := make(map[string]int)
+		for _, term := range terms {
+			termFreqs[term]++
+		}
+
+		for term := range termFreqs {
+			r.docFreqs[term]++
+		}
+
+		r.termFreqs[i] = termFreqs
+		r.docLengths[i] = len(terms)
+		totalLength += len(terms)
+	}
+
+	if len(docs) > 0 {
+		r.avgDocLength = float64(totalLength) / float64(len(docs))
+	}
+
+	return r
+}
+
+// GetRelevantDocuments returns the documents with the highest BM25 scores for the query.
+// Documents without any term of the query are not returned.
+func (r *BM25) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	queryTerms := r.terms(query)
+
+	type scoredDoc struct {
+		index int
+		score float64
+	}
+
+	scored := []scoredDoc{}
+
+	for i := range r.docs {
+		if score := r.score(i, queryTerms); score > 0 {
+			scored = append(scored, scoredDoc{index: i, score: score})
+		}
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].score > scored[j].score
+	})
+
+	if r.opts.TopK > 0 && len(scored) > r.opts.TopK {
+		scored = scored[:r.opts.TopK]
+	}
+
+	docs := make([]schema.Document, len(scored))
+	for i, s := range scored {
+		docs[i] = r.docs[s.index]
+	}
+
+	return docs, nil
+}
+
+// Verbose returns the verbosity setting of the retriever.
+func (r *BM25) Verbose() bool {
+	return r.opts.CallbackOptions.Verbose
+}
+
+// Callbacks returns the registered callbacks of the retriever.
+func (r *BM25) Callbacks() []schema.Callback {
+	return r.opts.CallbackOptions.Callbacks
+}
+
+// score returns the BM25 score of the document for the query terms.
+func (r *BM25) score(index int, queryTerms []string) float64 {
+	n := float64(len(r.docs))
+	lengthNorm := 1 - r.opts.B
+
+	if r.avgDocLength > 0 {
+		lengthNorm += r.opts.B * float64(r.docLengths[index]) / r.avgDocLength
+	}
+
+	score := 0.0
+
+	for _, term := range queryTerms {
+		tf := float64(r.termFreqs[index][term])
+		if tf == 0 {
+			continue
+		}
+
+		df := float64(r.docFreqs[term])
+		idf := math.Log((n-df+0.5)/(df+0.5) + 1)
+
+		score += idf * tf * (r.opts.K1 + 1) / (tf + r.opts.K1*lengthNorm)
+	}
+
+	return score
+}
+
+// terms returns the stemmed terms of the text.
+func (r *BM25) terms(text string) []string {
+	tokens := r.opts.Tokenize(text)
+
+	terms := make([]string, 0, len(tokens))
+
+	for _, token := range tokens {
+		if term := r.opts.Stem(token); term != "" {
+			terms = append(terms, term)
+		}
+	}
+
+	return terms
+}
+
+// tokenizeWords splits the text into lowercase sequences of letters and digits.
+func tokenizeWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
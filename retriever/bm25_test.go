@@ -0,0 +1,65 @@
+package retriever
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBM25(t *testing.T) {
+	docs := []schema.Document{
+		{PageContent: "The cat sits on the mat."},
+		{PageContent: "Dogs and cats are pets."},
+		{PageContent: "The stock market fell today."},
+		{PageContent: "A cat, a cat and another cat!"},
+	}
+
+	t.Run("Ranking", func(t *testing.T) {
+		bm25 := NewBM25(docs)
+
+		result, err := bm25.GetRelevantDocuments(context.Background(), "Cat")
+		assert.NoError(t, err)
+		assert.Equal(t, []schema.Document{docs[3], docs[0]}, result)
+	})
+
+	t.Run("TopK", func(t *testing.T) {
+		bm25 := NewBM25(docs, func(o *BM25Options) {
+			o.TopK = 1
+		})
+
+		result, err := bm25.GetRelevantDocuments(context.Background(), "the cat")
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+	})
+
+	t.Run("Stemming", func(t *testing.T) {
+		bm25 := NewBM25(docs, func(o *BM25Options) {
+			o.Stem = func(term string) string {
+				return strings.TrimSuffix(term, "s")
+			}
+		})
+
+		result, err := bm25.GetRelevantDocuments(context.Background(), "cats")
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []schema.Document{docs[0], docs[1], docs[3]}, result)
+	})
+
+	t.Run("No Match", func(t *testing.T) {
+		bm25 := NewBM25(docs)
+
+		result, err := bm25.GetRelevantDocuments(context.Background(), "bitcoin")
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("Empty Corpus", func(t *testing.T) {
+		bm25 := NewBM25(nil)
+
+		result, err := bm25.GetRelevantDocuments(context.Background(), "cat")
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+}
@@ -0,0 +1,67 @@
+package retriever
+
+import (
+	"context"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure ContextualCompression satisfies the Retriever interface.
+var _ schema.Retriever = (*ContextualCompression)(nil)
+
+// ContextualCompressionOptions contains options for the ContextualCompression retriever.
+type ContextualCompressionOptions struct {
+	*schema.CallbackOptions
+}
+
+// ContextualCompression is a retriever that compresses the documents of a base retriever with a document
+// compressor, e.g. to extract only the passages relevant to the query before they are stuffed into a prompt.
+type ContextualCompression struct {
+	retriever  schema.Retriever
+	compressor schema.DocumentCompressor
+	opts       ContextualCompressionOptions
+}
+
+// NewContextualCompression creates a new ContextualCompression retriever with the given base retriever and compressor.
+func NewContextualCompression(retriever schema.Retriever, compressor schema.DocumentCompressor, optFns ...func(o *ContextualCompressionOptions)) *ContextualCompression {
+	opts := ContextualCompressionOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &ContextualCompression{
+		retriever:  retriever,
+		compressor: compressor,
+		opts:       opts,
+	}
+}
+
+// GetRelevantDocuments returns the compressed documents of the base retriever.
+func (r *ContextualCompression) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	docs, err := r.retriever.GetRelevantDocuments(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(docs) == 0 {
+		return docs, nil
+	}
+
+	return r.compressor.Compress(ctx, docs, query)
+}
+
+// Verbose returns the verbosity setting of the retriever.
+func (r *ContextualCompression) Verbose() bool {
+	return r.opts.CallbackOptions.Verbose
+}
+
+// Callbacks returns the registered callbacks of the retriever.
+func (r *ContextualCompression) Callbacks() []schema.Callback {
+	return r.opts.CallbackOptions.Callbacks
+}
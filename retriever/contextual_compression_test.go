@@ -0,0 +1,38 @@
+package retriever
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextualCompression(t *testing.T) {
+	base := &retrieverMock{
+		GetRelevantDocumentsFunc: func(ctx context.Context, query string) ([]schema.Document, error) {
+			return []schema.Document{{PageContent: "Document 1"}, {PageContent: "Document 2"}}, nil
+		},
+	}
+
+	compressor := &documentCompressorMock{
+		CompressFunc: func(ctx context.Context, docs []schema.Document, query string) ([]schema.Document, error) {
+			assert.Equal(t, "test query", query)
+			return docs[1:], nil
+		},
+	}
+
+	r := NewContextualCompression(base, compressor)
+
+	docs, err := r.GetRelevantDocuments(context.Background(), "test query")
+	assert.NoError(t, err)
+	assert.Equal(t, []schema.Document{{PageContent: "Document 2"}}, docs)
+}
+
+type documentCompressorMock struct {
+	CompressFunc func(ctx context.Context, docs []schema.Document, query string) ([]schema.Document, error)
+}
+
+func (m *documentCompressorMock) Compress(ctx context.Context, docs []schema.Document, query string) ([]schema.Document, error) {
+	return m.CompressFunc(ctx, docs, query)
+}
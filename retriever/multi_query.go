@@ -0,0 +1,189 @@
+package retriever
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/model"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+	"golang.org/x/sync/errgroup"
+)
+
+const defaultMultiQueryPromptTemplate = `You are an AI language model assistant. Your task is to generate {{.numQueries}} different versions of the given user question to retrieve relevant documents from a vector database. By generating multiple perspectives on the user question, your goal is to help the user overcome some of the limitations of the distance-based similarity search. Provide these alternative questions separated by newlines.
+Original question: {{.question}}`
+
+// queryListMarkerRegex matches list markers like "1." or "-" at the beginning of a generated query.
+var queryListMarkerRegex = regexp.MustCompile(`^(\d+[.)]|[-*•])\s*`)
+
+// Compile time check to ensure MultiQuery satisfies the Retriever interface.
+var _ schema.Retriever = (*MultiQuery)(nil)
+
+// MultiQueryOptions contains options for the MultiQuery retriever.
+type MultiQueryOptions struct {
+	*schema.CallbackOptions
+	// Prompt is the prompt template for the reformulations with the question and numQueries values.
+	Prompt schema.PromptTemplate
+	// NumQueries is the number of reformulations generated by the model.
+	NumQueries int
+	// IncludeOriginal determines whether the documents of the original query are fused as well.
+	IncludeOriginal bool
+	// RankConstant is the constant k of the reciprocal rank fusion score 1/(k+rank).
+	RankConstant int
+	// MaxConcurrency is the maximum number of concurrent retrievals. A value <= 0 means no limit.
+	MaxConcurrency int
+	// TopK is the maximum number of returned documents. A value <= 0 returns all fused documents.
+	TopK int
+}
+
+// MultiQuery is a retriever implementing RAG-Fusion. The model generates reformulations of the query, the base
+// retriever retrieves the documents of each reformulation in parallel and the results are merged with reciprocal
+// rank fusion. Documents are identified by their page content.
+type MultiQuery struct {
+	model     schema.Model
+	retriever schema.Retriever
+	opts      MultiQueryOptions
+}
+
+// NewMultiQuery creates a new MultiQuery retriever with the given model and base retriever.
+func NewMultiQuery(model schema.Model, retriever schema.Retriever, optFns ...func(o *MultiQueryOptions)) *MultiQuery {
+	opts := MultiQueryOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		Prompt:          prompt.NewTemplate(defaultMultiQueryPromptTemplate),
+		NumQueries:      3,
+		IncludeOriginal: true,
+		RankConstant:    60,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &MultiQuery{
+		model:     model,
+		retriever: retriever,
+		opts:      opts,
+	}
+}
+
+// GetRelevantDocuments returns the fused documents of the reformulations of the query.
+func (r *MultiQuery) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	queries, err := r.generateQueries(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.opts.IncludeOriginal {
+		queries = append([]string{query}, queries...)
+	}
+
+	results := make([][]schema.Document, len(queries))
+
+	errs, errctx := errgroup.WithContext(ctx)
+
+	if r.opts.MaxConcurrency > 0 {
+		errs.SetLimit(r.opts.MaxConcurrency)
+	}
+
+	for i, q := range queries {
+		i, q := i, q
+
+		errs.Go(func() error {
+			docs, err := r.retriever.GetRelevantDocuments(errctx, q)
+			if err != nil {
+				return err
+			}
+
+			results[i] = docs
+
+			return nil
+		})
+	}
+
+	if err := errs.Wait(); err != nil {
+		return nil, err
+	}
+
+	docs := reciprocalRankFusion(results, r.opts.RankConstant)
+
+	if r.opts.TopK > 0 && len(docs) > r.opts.TopK {
+		docs = docs[:r.opts.TopK]
+	}
+
+	return docs, nil
+}
+
+// Verbose returns the verbosity setting of the retriever.
+func (r *MultiQuery) Verbose() bool {
+	return r.opts.CallbackOptions.Verbose
+}
+
+// Callbacks returns the registered callbacks of the retriever.
+func (r *MultiQuery) Callbacks() []schema.Callback {
+	return r.opts.CallbackOptions.Callbacks
+}
+
+// generateQueries generates the reformulations of the query with the model.
+func (r *MultiQuery) generateQueries(ctx context.Context, query string) ([]string, error) {
+	promptValue, err := r.opts.Prompt.FormatPrompt(map[string]any{
+		"question":   query,
+		"numQueries": r.opts.NumQueries,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := model.GeneratePrompt(ctx, r.model, promptValue)
+	if err != nil {
+		return nil, err
+	}
+
+	queries := []string{}
+
+	if len(result.Generations) == 0 {
+		return queries, nil
+	}
+
+	for _, line := range strings.Split(result.Generations[0].Text, "\n") {
+		q := strings.TrimSpace(queryListMarkerRegex.ReplaceAllString(strings.TrimSpace(line), ""))
+		if q == "" {
+			continue
+		}
+
+		queries = append(queries, q)
+
+		if len(queries) == r.opts.NumQueries {
+			break
+		}
+	}
+
+	return queries, nil
+}
+
+// reciprocalRankFusion merges the ranked document lists by the sum of the reciprocal ranks 1/(k+rank)
+// of the documents. Documents with equal scores keep the order of their first occurrence.
+func reciprocalRankFusion(results [][]schema.Document, k int) []schema.Document {
+	scores := make(map[string]float64)
+	docs := []schema.Document{}
+
+	for _, result := range results {
+		for rank, doc := range result {
+			if _, ok := scores[doc.PageContent]; !ok {
+				docs = append(docs, doc)
+			}
+
+			scores[doc.PageContent] += 1 / float64(k+rank+1)
+		}
+	}
+
+	sort.SliceStable(docs, func(i, j int) bool {
+		return scores[docs[i].PageContent] > scores[docs[j].PageContent]
+	})
+
+	return docs
+}
@@ -0,0 +1,85 @@
+package retriever
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiQuery(t *testing.T) {
+	fake := llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+		assert.Contains(t, prompt, "generate 2 different versions")
+		assert.Contains(t, prompt, "Original question: capital germany")
+
+		return &schema.ModelResult{
+			Generations: []schema.Generation{{Text: "1. What is the capital of Germany?\n\n2. Which city is the German capital?\n3. Ignored"}},
+			LLMOutput:   map[string]any{},
+		}, nil
+	})
+
+	results := map[string][]schema.Document{
+		"capital germany":                   {{PageContent: "a"}, {PageContent: "b"}},
+		"What is the capital of Germany?":   {{PageContent: "b"}, {PageContent: "c"}},
+		"Which city is the German capital?": {{PageContent: "c"}, {PageContent: "b"}},
+	}
+
+	mu := sync.Mutex{}
+	queries := []string{}
+
+	base := &retrieverMock{
+		GetRelevantDocumentsFunc: func(ctx context.Context, query string) ([]schema.Document, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			queries = append(queries, query)
+
+			return results[query], nil
+		},
+	}
+
+	t.Run("Reciprocal Rank Fusion", func(t *testing.T) {
+		queries = []string{}
+
+		multiQuery := NewMultiQuery(fake, base, func(o *MultiQueryOptions) {
+			o.NumQueries = 2
+		})
+
+		docs, err := multiQuery.GetRelevantDocuments(context.Background(), "capital germany")
+		assert.NoError(t, err)
+		assert.Equal(t, []schema.Document{{PageContent: "b"}, {PageContent: "c"}, {PageContent: "a"}}, docs)
+		assert.ElementsMatch(t, []string{"capital germany", "What is the capital of Germany?", "Which city is the German capital?"}, queries)
+	})
+
+	t.Run("Without Original And TopK", func(t *testing.T) {
+		queries = []string{}
+
+		multiQuery := NewMultiQuery(fake, base, func(o *MultiQueryOptions) {
+			o.NumQueries = 2
+			o.IncludeOriginal = false
+			o.TopK = 1
+		})
+
+		docs, err := multiQuery.GetRelevantDocuments(context.Background(), "capital germany")
+		assert.NoError(t, err)
+		assert.Equal(t, []schema.Document{{PageContent: "b"}}, docs)
+		assert.Len(t, queries, 2)
+	})
+
+	t.Run("Retriever Error", func(t *testing.T) {
+		multiQuery := NewMultiQuery(fake, &retrieverMock{
+			GetRelevantDocumentsFunc: func(ctx context.Context, query string) ([]schema.Document, error) {
+				return nil, errors.New("retriever error")
+			},
+		}, func(o *MultiQueryOptions) {
+			o.NumQueries = 2
+		})
+
+		_, err := multiQuery.GetRelevantDocuments(context.Background(), "capital germany")
+		assert.EqualError(t, err, "retriever error")
+	})
+}
@@ -0,0 +1,136 @@
+package retriever
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/textsplitter"
+)
+
+// Compile time check to ensure ParentDocument satisfies the Retriever interface.
+var _ schema.Retriever = (*ParentDocument)(nil)
+
+// ParentDocumentOptions contains options for the ParentDocument retriever.
+type ParentDocumentOptions struct {
+	*schema.CallbackOptions
+	// ParentSplitter splits the added documents into parent documents. If nil, the added documents are the parent documents.
+	ParentSplitter schema.TextSplitter
+	// ChildSplitter splits the parent documents into the child chunks indexed in the vector store.
+	ChildSplitter schema.TextSplitter
+	// IDKey is the metadata key of the child chunks referencing the id of their parent document.
+	IDKey string
+}
+
+// ParentDocument is a retriever that indexes small child chunks in a vector store for the similarity search,
+// but returns their larger parent documents stored in a document store. Small chunks yield precise embeddings,
+// while the parent documents provide enough context for the chain.
+type ParentDocument struct {
+	vectorStore schema.VectorStore
+	docStore    schema.DocStore
+	opts        ParentDocumentOptions
+}
+
+// NewParentDocument creates a new ParentDocument retriever with the given vector store for the child chunks
+// and document store for the parent documents.
+func NewParentDocument(vectorStore schema.VectorStore, docStore schema.DocStore, optFns ...func(o *ParentDocumentOptions)) *ParentDocument {
+	opts := ParentDocumentOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		IDKey: "docID",
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.ChildSplitter == nil {
+		opts.ChildSplitter = textsplitter.NewRecusiveCharacterTextSplitter(func(o *textsplitter.RecursiveCharacterTextSplitterOptions) {
+			o.ChunkSize = 400
+			o.ChunkOverlap = 0
+		})
+	}
+
+	return &ParentDocument{
+		vectorStore: vectorStore,
+		docStore:    docStore,
+		opts:        opts,
+	}
+}
+
+// AddDocuments splits the documents into parent documents and child chunks, stores the parent documents
+// in the document store and indexes the child chunks in the vector store.
+func (r *ParentDocument) AddDocuments(ctx context.Context, docs []schema.Document) error {
+	parentDocs := docs
+
+	if r.opts.ParentSplitter != nil {
+		var err error
+
+		parentDocs, err = r.opts.ParentSplitter.SplitDocuments(docs)
+		if err != nil {
+			return err
+		}
+	}
+
+	ids := make([]string, len(parentDocs))
+	childDocs := []schema.Document{}
+
+	for i, parentDoc := range parentDocs {
+		ids[i] = uuid.New().String()
+
+		chunks, err := r.opts.ChildSplitter.SplitDocuments([]schema.Document{parentDoc})
+		if err != nil {
+			return err
+		}
+
+		for _, chunk := range chunks {
+			metadata := util.CopyMap(chunk.Metadata)
+			metadata[r.opts.IDKey] = ids[i]
+
+			childDocs = append(childDocs, schema.Document{
+				PageContent: chunk.PageContent,
+				Metadata:    metadata,
+			})
+		}
+	}
+
+	if err := r.vectorStore.AddDocuments(ctx, childDocs); err != nil {
+		return err
+	}
+
+	return r.docStore.AddDocuments(ctx, ids, parentDocs)
+}
+
+// GetRelevantDocuments returns the parent documents of the child chunks relevant to the query.
+func (r *ParentDocument) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	childDocs, err := r.vectorStore.SimilaritySearch(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+
+	for _, doc := range childDocs {
+		id, ok := doc.Metadata[r.opts.IDKey].(string)
+		if !ok || util.Contains(ids, id) {
+			continue
+		}
+
+		ids = append(ids, id)
+	}
+
+	return r.docStore.GetDocuments(ctx, ids)
+}
+
+// Verbose returns the verbosity setting of the retriever.
+func (r *ParentDocument) Verbose() bool {
+	return r.opts.CallbackOptions.Verbose
+}
+
+// Callbacks returns the registered callbacks of the retriever.
+func (r *ParentDocument) Callbacks() []schema.Callback {
+	return r.opts.CallbackOptions.Callbacks
+}
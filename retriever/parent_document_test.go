@@ -0,0 +1,101 @@
+package retriever
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hupe1980/golc/docstore"
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/textsplitter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParentDocument(t *testing.T) {
+	t.Run("ReturnsParentDocuments", func(t *testing.T) {
+		vectorStore := &vectorStoreMock{}
+
+		r := NewParentDocument(vectorStore, docstore.NewInMemory(), func(o *ParentDocumentOptions) {
+			o.ChildSplitter = textsplitter.NewCharacterTextSplitter(func(o *textsplitter.CharacterTextSplitterOptions) {
+				o.Separator = "\n"
+				o.ChunkSize = 10
+				o.ChunkOverlap = 0
+			})
+		})
+
+		err := r.AddDocuments(context.Background(), []schema.Document{
+			{PageContent: "apple pie\napple tart", Metadata: map[string]any{"source": "fruit"}},
+			{PageContent: "carrot cake\nbanana bread"},
+		})
+		assert.NoError(t, err)
+
+		assert.Len(t, vectorStore.docs, 4)
+		assert.Equal(t, "fruit", vectorStore.docs[0].Metadata["source"])
+		assert.Equal(t, vectorStore.docs[0].Metadata["docID"], vectorStore.docs[1].Metadata["docID"])
+
+		docs, err := r.GetRelevantDocuments(context.Background(), "apple")
+		assert.NoError(t, err)
+		assert.Equal(t, []schema.Document{
+			{PageContent: "apple pie\napple tart", Metadata: map[string]any{"source": "fruit"}},
+		}, docs)
+
+		docs, err = r.GetRelevantDocuments(context.Background(), "banana")
+		assert.NoError(t, err)
+		assert.Equal(t, []schema.Document{{PageContent: "carrot cake\nbanana bread"}}, docs)
+	})
+
+	t.Run("ParentSplitter", func(t *testing.T) {
+		vectorStore := &vectorStoreMock{}
+
+		r := NewParentDocument(vectorStore, docstore.NewInMemory(), func(o *ParentDocumentOptions) {
+			o.ParentSplitter = textsplitter.NewCharacterTextSplitter(func(o *textsplitter.CharacterTextSplitterOptions) {
+				o.Separator = "\n\n"
+				o.ChunkSize = 10
+				o.ChunkOverlap = 0
+			})
+		})
+
+		err := r.AddDocuments(context.Background(), []schema.Document{
+			{PageContent: "apple pie\n\ncarrot cake"},
+		})
+		assert.NoError(t, err)
+
+		docs, err := r.GetRelevantDocuments(context.Background(), "carrot")
+		assert.NoError(t, err)
+		assert.Equal(t, "carrot cake", docs[0].PageContent)
+	})
+}
+
+// vectorStoreMock is a mock implementation of the VectorStore interface matching documents by substring.
+type vectorStoreMock struct {
+	docs []schema.Document
+}
+
+func (m *vectorStoreMock) AddDocuments(ctx context.Context, docs []schema.Document) error {
+	m.docs = append(m.docs, docs...)
+	return nil
+}
+
+func (m *vectorStoreMock) AddDocumentsWithIDs(ctx context.Context, ids []string, docs []schema.Document) error {
+	return m.AddDocuments(ctx, docs)
+}
+
+func (m *vectorStoreMock) Delete(ctx context.Context, ids []string) error {
+	return nil
+}
+
+func (m *vectorStoreMock) DeleteByFilter(ctx context.Context, filter map[string]any) error {
+	return nil
+}
+
+func (m *vectorStoreMock) SimilaritySearch(ctx context.Context, query string) ([]schema.Document, error) {
+	docs := []schema.Document{}
+
+	for _, doc := range m.docs {
+		if strings.Contains(doc.PageContent, query) {
+			docs = append(docs, doc)
+		}
+	}
+
+	return docs, nil
+}
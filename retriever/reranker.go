@@ -0,0 +1,117 @@
+package retriever
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Reranking satisfies the Retriever interface.
+var _ schema.Retriever = (*Reranking)(nil)
+
+// RerankingOptions contains options for the Reranking retriever.
+type RerankingOptions struct {
+	*schema.CallbackOptions
+	// TopK is the maximum number of returned documents. A value <= 0 returns all reranked documents.
+	TopK int
+	// ScoreThreshold is the minimum relevance score of a returned document.
+	ScoreThreshold float64
+}
+
+// Reranking is a retriever that rescores the candidate documents of a base retriever with a reranker,
+// e.g. Cohere Rerank or a cross-encoder, and returns them sorted by their relevance score.
+// The relevance score is added to the metadata of the documents as "relevanceScore".
+type Reranking struct {
+	retriever schema.Retriever
+	reranker  schema.Reranker
+	opts      RerankingOptions
+}
+
+// WithReranker wraps the given base retriever with a reranking stage using the provided reranker.
+func WithReranker(retriever schema.Retriever, reranker schema.Reranker, optFns ...func(o *RerankingOptions)) *Reranking {
+	opts := RerankingOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		TopK: 4,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Reranking{
+		retriever: retriever,
+		reranker:  reranker,
+		opts:      opts,
+	}
+}
+
+// GetRelevantDocuments returns the reranked documents of the base retriever whose relevance score
+// reaches the score threshold. If the retriever is called with Run, the run of the base retriever
+// is nested in its run.
+func (r *Reranking) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	candidates, err := Run(ctx, r.retriever, query, func(o *Options) {
+		if rm, ok := callback.RetrieverRunManagerFromContext(ctx); ok {
+			o.Callbacks = rm.GetInheritableCallbacks()
+			o.ParentRunID = rm.RunID()
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(candidates) == 0 {
+		return candidates, nil
+	}
+
+	results, err := r.reranker.Rerank(ctx, query, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].RelevanceScore > results[j].RelevanceScore
+	})
+
+	docs := []schema.Document{}
+
+	for _, result := range results {
+		if result.RelevanceScore < r.opts.ScoreThreshold {
+			break
+		}
+
+		if r.opts.TopK > 0 && len(docs) == r.opts.TopK {
+			break
+		}
+
+		doc := candidates[result.Index]
+
+		metadata := make(map[string]any, len(doc.Metadata)+1)
+		for k, v := range doc.Metadata {
+			metadata[k] = v
+		}
+
+		metadata["relevanceScore"] = result.RelevanceScore
+
+		docs = append(docs, schema.Document{
+			PageContent: doc.PageContent,
+			Metadata:    metadata,
+		})
+	}
+
+	return docs, nil
+}
+
+// Verbose returns the verbosity setting of the retriever.
+func (r *Reranking) Verbose() bool {
+	return r.opts.CallbackOptions.Verbose
+}
+
+// Callbacks returns the registered callbacks of the retriever.
+func (r *Reranking) Callbacks() []schema.Callback {
+	return r.opts.CallbackOptions.Callbacks
+}
@@ -0,0 +1,127 @@
+package retriever
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReranking(t *testing.T) {
+	base := &retrieverMock{
+		GetRelevantDocumentsFunc: func(ctx context.Context, query string) ([]schema.Document, error) {
+			return []schema.Document{
+				{PageContent: "Document 1", Metadata: map[string]any{"source": "a"}},
+				{PageContent: "Document 2"},
+				{PageContent: "Document 3"},
+			}, nil
+		},
+	}
+
+	reranker := &rerankerMock{
+		RerankFunc: func(ctx context.Context, query string, docs []schema.Document) ([]schema.RerankResult, error) {
+			assert.Equal(t, "test query", query)
+			assert.Len(t, docs, 3)
+
+			return []schema.RerankResult{
+				{Index: 0, RelevanceScore: 0.2},
+				{Index: 2, RelevanceScore: 0.9},
+				{Index: 1, RelevanceScore: 0.5},
+			}, nil
+		},
+	}
+
+	t.Run("Rerank", func(t *testing.T) {
+		r := WithReranker(base, reranker)
+
+		docs, err := r.GetRelevantDocuments(context.Background(), "test query")
+		assert.NoError(t, err)
+		assert.Equal(t, []schema.Document{
+			{PageContent: "Document 3", Metadata: map[string]any{"relevanceScore": 0.9}},
+			{PageContent: "Document 2", Metadata: map[string]any{"relevanceScore": 0.5}},
+			{PageContent: "Document 1", Metadata: map[string]any{"source": "a", "relevanceScore": 0.2}},
+		}, docs)
+	})
+
+	t.Run("TopK and ScoreThreshold", func(t *testing.T) {
+		r := WithReranker(base, reranker, func(o *RerankingOptions) {
+			o.TopK = 1
+			o.ScoreThreshold = 0.3
+		})
+
+		docs, err := r.GetRelevantDocuments(context.Background(), "test query")
+		assert.NoError(t, err)
+		assert.Equal(t, []schema.Document{
+			{PageContent: "Document 3", Metadata: map[string]any{"relevanceScore": 0.9}},
+		}, docs)
+
+		r = WithReranker(base, reranker, func(o *RerankingOptions) {
+			o.ScoreThreshold = 0.3
+		})
+
+		docs, err = r.GetRelevantDocuments(context.Background(), "test query")
+		assert.NoError(t, err)
+		assert.Len(t, docs, 2)
+	})
+
+	t.Run("Callbacks", func(t *testing.T) {
+		r := WithReranker(base, reranker)
+
+		handler := &retrieverRunHandler{}
+
+		docs, err := Run(context.Background(), r, "test query", func(o *Options) {
+			o.Callbacks = []schema.Callback{handler}
+		})
+		assert.NoError(t, err)
+		assert.Len(t, docs, 3)
+
+		// The run of the base retriever is nested in the run of the reranking retriever.
+		assert.Len(t, handler.starts, 2)
+		assert.Empty(t, handler.starts[0].ParentRunID)
+		assert.Equal(t, handler.starts[0].RunID, handler.starts[1].ParentRunID)
+		assert.Equal(t, 2, handler.ends)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		r := WithReranker(base, &rerankerMock{
+			RerankFunc: func(ctx context.Context, query string, docs []schema.Document) ([]schema.RerankResult, error) {
+				return nil, errors.New("rerank error")
+			},
+		})
+
+		_, err := r.GetRelevantDocuments(context.Background(), "test query")
+		assert.EqualError(t, err, "rerank error")
+	})
+}
+
+type rerankerMock struct {
+	RerankFunc func(ctx context.Context, query string, docs []schema.Document) ([]schema.RerankResult, error)
+}
+
+func (m *rerankerMock) Rerank(ctx context.Context, query string, docs []schema.Document) ([]schema.RerankResult, error) {
+	return m.RerankFunc(ctx, query, docs)
+}
+
+// retrieverRunHandler records the started and ended retriever runs.
+type retrieverRunHandler struct {
+	callback.NoopHandler
+	starts []*schema.RetrieverStartInput
+	ends   int
+}
+
+func (h *retrieverRunHandler) AlwaysVerbose() bool {
+	return true
+}
+
+func (h *retrieverRunHandler) OnRetrieverStart(ctx context.Context, input *schema.RetrieverStartInput) error {
+	h.starts = append(h.starts, input)
+	return nil
+}
+
+func (h *retrieverRunHandler) OnRetrieverEnd(ctx context.Context, input *schema.RetrieverEndInput) error {
+	h.ends++
+	return nil
+}
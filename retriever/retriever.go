@@ -36,7 +36,7 @@ func Run(ctx context.Context, retriever schema.Retriever, query string, optFns .
 		return nil, err
 	}
 
-	docs, err := retriever.GetRelevantDocuments(ctx, query)
+	docs, err := retriever.GetRelevantDocuments(callback.WithRetrieverRunManager(ctx, rm), query)
 	if err != nil {
 		if cbErr := rm.OnRetrieverError(ctx, &schema.RetrieverErrorManagerInput{
 			Error: err,
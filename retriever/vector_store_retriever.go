@@ -2,8 +2,11 @@ package retriever
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/internal/util"
 	"github.com/hupe1980/golc/schema"
 )
 
@@ -14,11 +17,22 @@ type VectorStoreSearchType string
 
 const (
 	VectorStoreSearchTypeSimilarity VectorStoreSearchType = "similarity"
+	// VectorStoreSearchTypeMMR selects documents similar to the query and diverse among each other by
+	// maximal marginal relevance. It requires a vector store implementing the VectorStoreWithMMR interface.
+	VectorStoreSearchTypeMMR VectorStoreSearchType = "mmr"
 )
 
 type VectorStoreOptions struct {
 	*schema.CallbackOptions
 	SearchType VectorStoreSearchType
+	// ScoreThreshold is the minimum similarity score of a returned document. It requires a vector store
+	// implementing the VectorStoreWithScores interface. It is not supported by the MMR search.
+	ScoreThreshold float32
+	// FetchK is the number of documents fetched by the MMR search before the documents are selected.
+	FetchK int
+	// LambdaMult weights the similarity to the query against the diversity of the MMR search. 1 ranks
+	// by similarity only, 0 maximizes the diversity.
+	LambdaMult float32
 }
 
 type VectorStore struct {
@@ -29,6 +43,8 @@ type VectorStore struct {
 func NewVectorStore(vectorStore schema.VectorStore, optFns ...func(o *VectorStoreOptions)) *VectorStore {
 	opts := VectorStoreOptions{
 		SearchType: VectorStoreSearchTypeSimilarity,
+		FetchK:     20,
+		LambdaMult: 0.5,
 		CallbackOptions: &schema.CallbackOptions{
 			Verbose: golc.Verbose,
 		},
@@ -44,9 +60,62 @@ func NewVectorStore(vectorStore schema.VectorStore, optFns ...func(o *VectorStor
 	}
 }
 
-// GetRelevantDocuments returns documents using the vector store.
+// GetRelevantDocuments returns documents using the vector store. If the vector store returns similarity
+// scores, the score of each document is added to its metadata as "score".
 func (r *VectorStore) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
-	return r.v.SimilaritySearch(ctx, query)
+	if r.opts.SearchType == VectorStoreSearchTypeMMR {
+		return r.maxMarginalRelevanceSearch(ctx, query)
+	}
+
+	v, ok := r.v.(schema.VectorStoreWithScores)
+	if !ok {
+		if r.opts.ScoreThreshold > 0 {
+			return nil, errors.New("score threshold requires a vector store with scores")
+		}
+
+		return r.v.SimilaritySearch(ctx, query)
+	}
+
+	scoredDocs, err := v.SimilaritySearchWithScores(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, 0, len(scoredDocs))
+
+	for _, d := range scoredDocs {
+		if d.Score < r.opts.ScoreThreshold {
+			continue
+		}
+
+		metadata := util.CopyMap(d.Document.Metadata)
+		metadata["score"] = d.Score
+
+		docs = append(docs, schema.Document{
+			PageContent: d.Document.PageContent,
+			Metadata:    metadata,
+		})
+	}
+
+	return docs, nil
+}
+
+// maxMarginalRelevanceSearch returns the documents selected by maximal marginal relevance.
+func (r *VectorStore) maxMarginalRelevanceSearch(ctx context.Context, query string) ([]schema.Document, error) {
+	v, ok := r.v.(schema.VectorStoreWithMMR)
+	if !ok {
+		return nil, errors.New("mmr search requires a vector store with mmr support")
+	}
+
+	if r.opts.ScoreThreshold > 0 {
+		return nil, errors.New("score threshold is not supported by the mmr search")
+	}
+
+	if r.opts.LambdaMult < 0 || r.opts.LambdaMult > 1 {
+		return nil, fmt.Errorf("lambda mult must be between 0 and 1, got %v", r.opts.LambdaMult)
+	}
+
+	return v.MaxMarginalRelevanceSearch(ctx, query, r.opts.FetchK, r.opts.LambdaMult)
 }
 
 // Verbose returns the verbosity setting of the retriever.
@@ -0,0 +1,97 @@
+package retriever
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVectorStore(t *testing.T) {
+	t.Run("WithoutScores", func(t *testing.T) {
+		vectorStore := &vectorStoreMock{docs: []schema.Document{{PageContent: "Document 1"}}}
+
+		r := NewVectorStore(vectorStore)
+
+		docs, err := r.GetRelevantDocuments(context.Background(), "Document")
+		assert.NoError(t, err)
+		assert.Equal(t, []schema.Document{{PageContent: "Document 1"}}, docs)
+
+		r = NewVectorStore(vectorStore, func(o *VectorStoreOptions) {
+			o.ScoreThreshold = 0.5
+		})
+
+		_, err = r.GetRelevantDocuments(context.Background(), "Document")
+		assert.Error(t, err)
+	})
+
+	t.Run("WithScores", func(t *testing.T) {
+		vectorStore := &scoredVectorStoreMock{
+			scoredDocs: []schema.ScoredDocument{
+				{Document: schema.Document{PageContent: "Document 1", Metadata: map[string]any{"source": "a"}}, Score: 0.9},
+				{Document: schema.Document{PageContent: "Document 2"}, Score: 0.4},
+			},
+		}
+
+		r := NewVectorStore(vectorStore, func(o *VectorStoreOptions) {
+			o.ScoreThreshold = 0.5
+		})
+
+		docs, err := r.GetRelevantDocuments(context.Background(), "query")
+		assert.NoError(t, err)
+		assert.Equal(t, []schema.Document{
+			{PageContent: "Document 1", Metadata: map[string]any{"source": "a", "score": float32(0.9)}},
+		}, docs)
+
+		// The metadata of the vector store documents is not modified.
+		assert.Equal(t, map[string]any{"source": "a"}, vectorStore.scoredDocs[0].Document.Metadata)
+	})
+
+	t.Run("MMR", func(t *testing.T) {
+		vectorStore := &mmrVectorStoreMock{vectorStoreMock: vectorStoreMock{docs: []schema.Document{{PageContent: "Document 1"}}}}
+
+		r := NewVectorStore(vectorStore, func(o *VectorStoreOptions) {
+			o.SearchType = VectorStoreSearchTypeMMR
+			o.FetchK = 10
+			o.LambdaMult = 0.25
+		})
+
+		docs, err := r.GetRelevantDocuments(context.Background(), "query")
+		assert.NoError(t, err)
+		assert.Equal(t, []schema.Document{{PageContent: "Document 1"}}, docs)
+		assert.Equal(t, 10, vectorStore.fetchK)
+		assert.Equal(t, float32(0.25), vectorStore.lambdaMult)
+
+		r = NewVectorStore(&vectorStoreMock{}, func(o *VectorStoreOptions) {
+			o.SearchType = VectorStoreSearchTypeMMR
+		})
+
+		_, err = r.GetRelevantDocuments(context.Background(), "query")
+		assert.Error(t, err)
+	})
+}
+
+// mmrVectorStoreMock is a mock implementation of the VectorStoreWithMMR interface.
+type mmrVectorStoreMock struct {
+	vectorStoreMock
+	fetchK     int
+	lambdaMult float32
+}
+
+func (m *mmrVectorStoreMock) MaxMarginalRelevanceSearch(ctx context.Context, query string, fetchK int, lambdaMult float32) ([]schema.Document, error) {
+	m.fetchK = fetchK
+	m.lambdaMult = lambdaMult
+
+	return m.docs, nil
+}
+
+// scoredVectorStoreMock is a mock implementation of the VectorStoreWithScores interface.
+type scoredVectorStoreMock struct {
+	vectorStoreMock
+	scoredDocs []schema.ScoredDocument
+}
+
+func (m *scoredVectorStoreMock) SimilaritySearchWithScores(ctx context.Context, query string) ([]schema.ScoredDocument, error) {
+	return m.scoredDocs, nil
+}
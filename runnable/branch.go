@@ -0,0 +1,40 @@
+package runnable
+
+import (
+	"context"
+)
+
+// Case is a conditional branch of a Branch.
+type Case[I, O any] struct {
+	// Condition reports whether the runnable of the case handles the input.
+	Condition func(ctx context.Context, input I) (bool, error)
+	// Runnable is invoked with the input, if the condition is met.
+	Runnable Runnable[I, O]
+}
+
+// Branch returns a runnable that invokes the runnable of the first case whose condition is met.
+// If no condition is met, the default runnable is invoked.
+func Branch[I, O any](defaultRunnable Runnable[I, O], cases ...Case[I, O]) Runnable[I, O] {
+	return &branch[I, O]{defaultRunnable: defaultRunnable, cases: cases}
+}
+
+type branch[I, O any] struct {
+	defaultRunnable Runnable[I, O]
+	cases           []Case[I, O]
+}
+
+func (b *branch[I, O]) Invoke(ctx context.Context, input I, optFns ...func(o *Options)) (O, error) {
+	for _, c := range b.cases {
+		ok, err := c.Condition(ctx, input)
+		if err != nil {
+			var empty O
+			return empty, err
+		}
+
+		if ok {
+			return c.Runnable.Invoke(ctx, input, optFns...)
+		}
+	}
+
+	return b.defaultRunnable.Invoke(ctx, input, optFns...)
+}
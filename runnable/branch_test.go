@@ -0,0 +1,52 @@
+package runnable
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBranch(t *testing.T) {
+	b := Branch(
+		Passthrough[string](),
+		Case[string, string]{
+			Condition: func(ctx context.Context, input string) (bool, error) {
+				if input == "" {
+					return false, errors.New("empty input")
+				}
+
+				return strings.HasPrefix(input, "!"), nil
+			},
+			Runnable: Func[string, string](func(ctx context.Context, input string) (string, error) {
+				return strings.ToUpper(input), nil
+			}),
+		},
+	)
+
+	testCases := []struct {
+		name          string
+		input         string
+		expected      string
+		expectedError string
+	}{
+		{name: "Case", input: "!golc", expected: "!GOLC"},
+		{name: "Default", input: "golc", expected: "golc"},
+		{name: "Error", input: "", expectedError: "empty input"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output, err := b.Invoke(context.Background(), tc.input)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, output)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package runnable
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/model"
+	"github.com/hupe1980/golc/retriever"
+	"github.com/hupe1980/golc/schema"
+)
+
+// ErrNoGenerations is returned if a model result has no generations to parse.
+var ErrNoGenerations = errors.New("model result has no generations")
+
+// Prompt returns a runnable that formats the prompt template with the input values.
+func Prompt(template schema.PromptTemplate) Runnable[map[string]any, schema.PromptValue] {
+	return Func[map[string]any, schema.PromptValue](func(ctx context.Context, input map[string]any) (schema.PromptValue, error) {
+		return template.FormatPrompt(input)
+	})
+}
+
+// Model returns a runnable that generates a result with the LLM or chat model for the prompt value.
+func Model(m schema.Model) Runnable[schema.PromptValue, *schema.ModelResult] {
+	return &modelRunnable{model: m}
+}
+
+type modelRunnable struct {
+	model schema.Model
+}
+
+func (mr *modelRunnable) Invoke(ctx context.Context, input schema.PromptValue, optFns ...func(o *Options)) (*schema.ModelResult, error) {
+	opts := makeOptions(optFns...)
+
+	return model.GeneratePrompt(ctx, mr.model, input, func(o *model.Options) {
+		o.Callbacks = opts.Callbacks
+		o.ParentRunID = opts.ParentRunID
+	})
+}
+
+// Text returns a runnable that returns the text of the first generation of a model result.
+func Text() Runnable[*schema.ModelResult, string] {
+	return Func[*schema.ModelResult, string](func(ctx context.Context, input *schema.ModelResult) (string, error) {
+		if len(input.Generations) == 0 {
+			return "", ErrNoGenerations
+		}
+
+		return input.Generations[0].Text, nil
+	})
+}
+
+// Parser returns a runnable that parses the text of the first generation of a model result with the output parser.
+func Parser[T any](parser schema.OutputParser[T]) Runnable[*schema.ModelResult, T] {
+	return Func[*schema.ModelResult, T](func(ctx context.Context, input *schema.ModelResult) (T, error) {
+		if len(input.Generations) == 0 {
+			var empty T
+			return empty, ErrNoGenerations
+		}
+
+		return parser.Parse(input.Generations[0].Text)
+	})
+}
+
+// Retriever returns a runnable that retrieves the relevant documents for the query.
+func Retriever(r schema.Retriever) Runnable[string, []schema.Document] {
+	return &retrieverRunnable{retriever: r}
+}
+
+type retrieverRunnable struct {
+	retriever schema.Retriever
+}
+
+func (rr *retrieverRunnable) Invoke(ctx context.Context, input string, optFns ...func(o *Options)) ([]schema.Document, error) {
+	opts := makeOptions(optFns...)
+
+	return retriever.Run(ctx, rr.retriever, input, func(o *retriever.Options) {
+		o.Callbacks = opts.Callbacks
+		o.ParentRunID = opts.ParentRunID
+	})
+}
+
+// Chain returns a runnable that calls the chain with the input values.
+func Chain(chain schema.Chain) Runnable[schema.ChainValues, schema.ChainValues] {
+	return &chainRunnable{chain: chain}
+}
+
+type chainRunnable struct {
+	chain schema.Chain
+}
+
+func (cr *chainRunnable) Invoke(ctx context.Context, input schema.ChainValues, optFns ...func(o *Options)) (schema.ChainValues, error) {
+	opts := makeOptions(optFns...)
+
+	return golc.Call(ctx, cr.chain, input, func(o *golc.CallOptions) {
+		o.Callbacks = opts.Callbacks
+		o.ParentRunID = opts.ParentRunID
+	})
+}
+
+func makeOptions(optFns ...func(o *Options)) Options {
+	opts := Options{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return opts
+}
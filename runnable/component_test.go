@@ -0,0 +1,78 @@
+package runnable
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hupe1980/golc/model/chatmodel"
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/outputparser"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComponents(t *testing.T) {
+	t.Run("PromptModelText", func(t *testing.T) {
+		fake := chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: fmt.Sprintf("Answer to: %s", messages[0].Content())}},
+				LLMOutput:   map[string]any{},
+			}, nil
+		})
+
+		r := Pipe3(Prompt(prompt.NewTemplate("What is {{.topic}}?")), Model(fake), Text())
+
+		output, err := r.Invoke(context.Background(), map[string]any{"topic": "golc"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Answer to: What is golc?", output)
+	})
+
+	t.Run("Parser", func(t *testing.T) {
+		parser := outputparser.NewCommaSeparatedList()
+
+		r := Pipe(Model(llm.NewSimpleFake("foo, bar, baz")), Parser[any](&parser))
+
+		output, err := r.Invoke(context.Background(), prompt.StringPromptValue("list"))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"foo", "bar", "baz"}, output)
+	})
+
+	t.Run("NoGenerations", func(t *testing.T) {
+		_, err := Text().Invoke(context.Background(), &schema.ModelResult{})
+		assert.ErrorIs(t, err, ErrNoGenerations)
+	})
+
+	t.Run("Retriever", func(t *testing.T) {
+		docs := []schema.Document{{PageContent: "golc is a go library"}}
+
+		r := Pipe(Retriever(&mockRetriever{docs: docs}), Func[[]schema.Document, int](func(ctx context.Context, input []schema.Document) (int, error) {
+			return len(input), nil
+		}))
+
+		output, err := r.Invoke(context.Background(), "golc")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, output)
+	})
+}
+
+// mockRetriever is a mock implementation of the schema.Retriever interface.
+type mockRetriever struct {
+	docs []schema.Document
+}
+
+// GetRelevantDocuments is the mock implementation of the GetRelevantDocuments method for mockRetriever.
+func (m *mockRetriever) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	return m.docs, nil
+}
+
+// Callbacks is the mock implementation of the Callbacks method for mockRetriever.
+func (m *mockRetriever) Callbacks() []schema.Callback {
+	return nil
+}
+
+// Verbose is the mock implementation of the Verbose method for mockRetriever.
+func (m *mockRetriever) Verbose() bool {
+	return false
+}
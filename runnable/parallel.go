@@ -0,0 +1,78 @@
+package runnable
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Parallel returns a runnable that invokes the steps concurrently with the same input.
+// The output maps the keys of the steps to their outputs. The first error cancels the remaining steps.
+func Parallel[I any](steps map[string]Runnable[I, any]) Runnable[I, map[string]any] {
+	return &parallel[I]{steps: steps}
+}
+
+type parallel[I any] struct {
+	steps map[string]Runnable[I, any]
+}
+
+func (p *parallel[I]) Invoke(ctx context.Context, input I, optFns ...func(o *Options)) (map[string]any, error) {
+	errs, errctx := errgroup.WithContext(ctx)
+
+	var mu sync.Mutex
+
+	outputs := make(map[string]any, len(p.steps))
+
+	for key, step := range p.steps {
+		key, step := key, step
+
+		errs.Go(func() error {
+			output, err := step.Invoke(errctx, input, optFns...)
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			outputs[key] = output
+			mu.Unlock()
+
+			return nil
+		})
+	}
+
+	if err := errs.Wait(); err != nil {
+		return nil, err
+	}
+
+	return outputs, nil
+}
+
+// Assign returns a runnable that invokes the steps concurrently with the input values and adds
+// their outputs to a copy of the input values. Outputs overwrite input values with the same key.
+func Assign(steps map[string]Runnable[map[string]any, any]) Runnable[map[string]any, map[string]any] {
+	return &assign{parallel: &parallel[map[string]any]{steps: steps}}
+}
+
+type assign struct {
+	parallel *parallel[map[string]any]
+}
+
+func (a *assign) Invoke(ctx context.Context, input map[string]any, optFns ...func(o *Options)) (map[string]any, error) {
+	assigned, err := a.parallel.Invoke(ctx, input, optFns...)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make(map[string]any, len(input)+len(assigned))
+
+	for k, v := range input {
+		outputs[k] = v
+	}
+
+	for k, v := range assigned {
+		outputs[k] = v
+	}
+
+	return outputs, nil
+}
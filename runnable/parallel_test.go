@@ -0,0 +1,57 @@
+package runnable
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallel(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		p := Parallel(map[string]Runnable[string, any]{
+			"upper": ToAny[string, string](Func[string, string](func(ctx context.Context, input string) (string, error) {
+				return strings.ToUpper(input), nil
+			})),
+			"length": ToAny[string, int](Func[string, int](func(ctx context.Context, input string) (int, error) {
+				return len(input), nil
+			})),
+		})
+
+		output, err := p.Invoke(context.Background(), "golc")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"upper": "GOLC", "length": 4}, output)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		p := Parallel(map[string]Runnable[string, any]{
+			"ok": ToAny(Passthrough[string]()),
+			"failing": Func[string, any](func(ctx context.Context, input string) (any, error) {
+				return nil, errors.New("step failed")
+			}),
+		})
+
+		_, err := p.Invoke(context.Background(), "golc")
+		assert.EqualError(t, err, "step failed")
+	})
+}
+
+func TestAssign(t *testing.T) {
+	a := Assign(map[string]Runnable[map[string]any, any]{
+		"context": Func[map[string]any, any](func(ctx context.Context, input map[string]any) (any, error) {
+			return "context of " + input["question"].(string), nil
+		}),
+	})
+
+	input := map[string]any{"question": "What is golc?"}
+
+	output, err := a.Invoke(context.Background(), input)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"question": "What is golc?",
+		"context":  "context of What is golc?",
+	}, output)
+	assert.Equal(t, map[string]any{"question": "What is golc?"}, input)
+}
@@ -0,0 +1,82 @@
+// Package runnable provides a composition layer to build pipelines of prompts, models, output parsers,
+// retrievers and chains without implementing a schema.Chain.
+package runnable
+
+import (
+	"context"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// Options contains the options for the invocation of a runnable.
+type Options struct {
+	// Callbacks are the callbacks of the invocation. They are passed to the wrapped components.
+	Callbacks []schema.Callback
+	// ParentRunID is the run id of the parent run of the invocation.
+	ParentRunID string
+}
+
+// Runnable is the interface of a unit of work that transforms an input into an output.
+type Runnable[I, O any] interface {
+	// Invoke transforms the input into an output.
+	Invoke(ctx context.Context, input I, optFns ...func(o *Options)) (O, error)
+}
+
+// Func is a function that satisfies the Runnable interface.
+type Func[I, O any] func(ctx context.Context, input I) (O, error)
+
+// Invoke calls the function with the input.
+func (f Func[I, O]) Invoke(ctx context.Context, input I, optFns ...func(o *Options)) (O, error) {
+	return f(ctx, input)
+}
+
+// Passthrough returns a runnable that returns its input unchanged.
+func Passthrough[I any]() Runnable[I, I] {
+	return Func[I, I](func(ctx context.Context, input I) (I, error) {
+		return input, nil
+	})
+}
+
+// ToAny returns a runnable that returns the output of the runnable as any,
+// e.g. to combine runnables with different output types with Parallel or Assign.
+func ToAny[I, O any](r Runnable[I, O]) Runnable[I, any] {
+	return &anyRunnable[I, O]{r: r}
+}
+
+type anyRunnable[I, O any] struct {
+	r Runnable[I, O]
+}
+
+func (ar *anyRunnable[I, O]) Invoke(ctx context.Context, input I, optFns ...func(o *Options)) (any, error) {
+	return ar.r.Invoke(ctx, input, optFns...)
+}
+
+// Pipe returns a runnable that passes the output of the first runnable as input to the second runnable.
+func Pipe[I, M, O any](first Runnable[I, M], second Runnable[M, O]) Runnable[I, O] {
+	return &pipe[I, M, O]{first: first, second: second}
+}
+
+// Pipe3 returns a runnable that chains three runnables.
+func Pipe3[I, M1, M2, O any](first Runnable[I, M1], second Runnable[M1, M2], third Runnable[M2, O]) Runnable[I, O] {
+	return Pipe(Pipe(first, second), third)
+}
+
+// Pipe4 returns a runnable that chains four runnables.
+func Pipe4[I, M1, M2, M3, O any](first Runnable[I, M1], second Runnable[M1, M2], third Runnable[M2, M3], fourth Runnable[M3, O]) Runnable[I, O] {
+	return Pipe(Pipe3(first, second, third), fourth)
+}
+
+type pipe[I, M, O any] struct {
+	first  Runnable[I, M]
+	second Runnable[M, O]
+}
+
+func (p *pipe[I, M, O]) Invoke(ctx context.Context, input I, optFns ...func(o *Options)) (O, error) {
+	intermediate, err := p.first.Invoke(ctx, input, optFns...)
+	if err != nil {
+		var empty O
+		return empty, err
+	}
+
+	return p.second.Invoke(ctx, intermediate, optFns...)
+}
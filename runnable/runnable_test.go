@@ -0,0 +1,49 @@
+package runnable
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipe(t *testing.T) {
+	upper := Func[string, string](func(ctx context.Context, input string) (string, error) {
+		return strings.ToUpper(input), nil
+	})
+
+	length := Func[string, int](func(ctx context.Context, input string) (int, error) {
+		return len(input), nil
+	})
+
+	t.Run("Pipe", func(t *testing.T) {
+		output, err := Pipe(upper, Passthrough[string]()).Invoke(context.Background(), "golc")
+		assert.NoError(t, err)
+		assert.Equal(t, "GOLC", output)
+	})
+
+	t.Run("Pipe3", func(t *testing.T) {
+		output, err := Pipe3(Passthrough[string](), upper, length).Invoke(context.Background(), "golc")
+		assert.NoError(t, err)
+		assert.Equal(t, 4, output)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		failing := Func[string, string](func(ctx context.Context, input string) (string, error) {
+			return "", errors.New("step failed")
+		})
+
+		called := false
+
+		last := Func[string, int](func(ctx context.Context, input string) (int, error) {
+			called = true
+			return 0, nil
+		})
+
+		_, err := Pipe3(upper, failing, last).Invoke(context.Background(), "golc")
+		assert.EqualError(t, err, "step failed")
+		assert.False(t, called)
+	})
+}
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+
+	"github.com/hupe1980/golc/integration/jsonschema"
 )
 
 // ToolInput represents an input for a tool, which can be either a structured input or a plain string input.
@@ -86,6 +88,8 @@ type AgentAction struct {
 	Log string
 	// Message log associated with the action.
 	MessageLog ChatMessages
+	// ToolCallID is the ID of the native tool call the action originates from, if any.
+	ToolCallID string
 }
 
 // AgentStep represents a step in the agent's action plan.
@@ -128,3 +132,55 @@ type Tool interface {
 	// Callbacks returns the registered callbacks of the tool.
 	Callbacks() []Callback
 }
+
+// ToolWithParameters is an optional interface for tools, whose parameters are described by a JSON schema
+// instead of the Go type returned by ArgsType, e.g. tools of remote servers.
+type ToolWithParameters interface {
+	Tool
+	// Parameters returns the JSON schema of the parameters of the tool.
+	Parameters() FunctionDefinitionParameters
+}
+
+// ToFunction formats a tool into a function API. The parameters are generated from the type returned by ArgsType,
+// unless the tool implements ToolWithParameters.
+func ToFunction(t Tool) (*FunctionDefinition, error) {
+	function := &FunctionDefinition{
+		Name:        t.Name(),
+		Description: t.Description(),
+	}
+
+	if tp, ok := t.(ToolWithParameters); ok {
+		function.Parameters = tp.Parameters()
+		return function, nil
+	}
+
+	argsType := t.ArgsType()
+
+	if argsType.Kind() == reflect.String {
+		function.Parameters = FunctionDefinitionParameters{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"__arg1": {
+					Type:        "string",
+					Description: "__arg1",
+				},
+			},
+			Required: []string{"__arg1"},
+		}
+
+		return function, nil
+	}
+
+	jsonSchema, err := jsonschema.Generate(argsType)
+	if err != nil {
+		return nil, err
+	}
+
+	function.Parameters = FunctionDefinitionParameters{
+		Type:       "object",
+		Properties: jsonSchema.Properties,
+		Required:   jsonSchema.Required,
+	}
+
+	return function, nil
+}
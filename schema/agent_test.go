@@ -1,6 +1,8 @@
 package schema
 
 import (
+	"context"
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -53,3 +55,55 @@ func TestToolInput(t *testing.T) {
 		require.Equal(t, "test input", input.String())
 	})
 }
+
+func TestToFunction(t *testing.T) {
+	t.Run("StringArgs", func(t *testing.T) {
+		f, err := ToFunction(&mockTool{argsType: reflect.TypeOf("")})
+		require.NoError(t, err)
+		require.Equal(t, "mock", f.Name)
+		require.Equal(t, "Mock tool.", f.Description)
+		require.Equal(t, []string{"__arg1"}, f.Parameters.Required)
+		require.Equal(t, "string", f.Parameters.Properties["__arg1"].Type)
+	})
+
+	t.Run("StructArgs", func(t *testing.T) {
+		type args struct {
+			Query string `json:"query"`
+		}
+
+		f, err := ToFunction(&mockTool{argsType: reflect.TypeOf(args{})})
+		require.NoError(t, err)
+		require.Equal(t, "object", f.Parameters.Type)
+		require.Equal(t, []string{"query"}, f.Parameters.Required)
+		require.Equal(t, "string", f.Parameters.Properties["query"].Type)
+	})
+}
+
+// mockTool is a mock implementation of the Tool interface for testing.
+type mockTool struct {
+	argsType reflect.Type
+}
+
+func (t *mockTool) Name() string {
+	return "mock"
+}
+
+func (t *mockTool) Description() string {
+	return "Mock tool."
+}
+
+func (t *mockTool) Run(ctx context.Context, input any) (string, error) {
+	return "", nil
+}
+
+func (t *mockTool) ArgsType() reflect.Type {
+	return t.argsType
+}
+
+func (t *mockTool) Verbose() bool {
+	return false
+}
+
+func (t *mockTool) Callbacks() []Callback {
+	return nil
+}
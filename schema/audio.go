@@ -3,6 +3,7 @@ package schema
 import (
 	"context"
 	"io"
+	"time"
 )
 
 // OutputFormat defines the supported audio output formats.
@@ -42,3 +43,29 @@ type TextToSpeech interface {
 	// SynthesizeSpeech converts the given text to an audio stream.
 	SynthesizeSpeech(ctx context.Context, text string) (AudioStream, error)
 }
+
+// TranscriptSegment represents a time-stamped segment of a transcript.
+type TranscriptSegment struct {
+	// Start is the offset of the beginning of the segment.
+	Start time.Duration
+	// End is the offset of the end of the segment.
+	End time.Duration
+	// Text is the transcribed text of the segment.
+	Text string
+}
+
+// Transcript represents the transcript of an audio or video.
+type Transcript struct {
+	// Text is the full transcribed text.
+	Text string
+	// Language is the language of the transcript, if known.
+	Language string
+	// Segments contains the time-stamped segments of the transcript, if available.
+	Segments []TranscriptSegment
+}
+
+// SpeechToText is an interface for transcribing speech to text.
+type SpeechToText interface {
+	// Transcribe transcribes the audio. The name of the audio file is used to detect the audio format.
+	Transcribe(ctx context.Context, audio io.Reader, name string) (*Transcript, error)
+}
@@ -0,0 +1,12 @@
+package schema
+
+import "context"
+
+// Cache is the interface for caching model results.
+type Cache interface {
+	// Lookup returns the cached result for the prompt of the model identified by the llmKey.
+	// The second return value is false if no result is cached.
+	Lookup(ctx context.Context, prompt, llmKey string) (*ModelResult, bool, error)
+	// Update stores the result for the prompt of the model identified by the llmKey.
+	Update(ctx context.Context, prompt, llmKey string, result *ModelResult) error
+}
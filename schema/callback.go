@@ -10,7 +10,8 @@ type LLMStartManagerInput struct {
 
 type LLMStartInput struct {
 	*LLMStartManagerInput
-	RunID string
+	RunID       string
+	ParentRunID string
 }
 
 type ChatModelStartManagerInput struct {
@@ -21,7 +22,8 @@ type ChatModelStartManagerInput struct {
 
 type ChatModelStartInput struct {
 	*ChatModelStartManagerInput
-	RunID string
+	RunID       string
+	ParentRunID string
 }
 
 type ModelNewTokenManagerInput struct {
@@ -30,7 +32,8 @@ type ModelNewTokenManagerInput struct {
 
 type ModelNewTokenInput struct {
 	*ModelNewTokenManagerInput
-	RunID string
+	RunID       string
+	ParentRunID string
 }
 
 type ModelEndManagerInput struct {
@@ -39,7 +42,8 @@ type ModelEndManagerInput struct {
 
 type ModelEndInput struct {
 	*ModelEndManagerInput
-	RunID string
+	RunID       string
+	ParentRunID string
 }
 
 type ModelErrorManagerInput struct {
@@ -48,7 +52,8 @@ type ModelErrorManagerInput struct {
 
 type ModelErrorInput struct {
 	*ModelErrorManagerInput
-	RunID string
+	RunID       string
+	ParentRunID string
 }
 
 type ChainStartManagerInput struct {
@@ -58,7 +63,8 @@ type ChainStartManagerInput struct {
 
 type ChainStartInput struct {
 	*ChainStartManagerInput
-	RunID string
+	RunID       string
+	ParentRunID string
 }
 
 type ChainEndManagerInput struct {
@@ -67,7 +73,8 @@ type ChainEndManagerInput struct {
 
 type ChainEndInput struct {
 	*ChainEndManagerInput
-	RunID string
+	RunID       string
+	ParentRunID string
 }
 
 type ChainErrorManagerInput struct {
@@ -76,7 +83,8 @@ type ChainErrorManagerInput struct {
 
 type ChainErrorInput struct {
 	*ChainErrorManagerInput
-	RunID string
+	RunID       string
+	ParentRunID string
 }
 
 type AgentActionManagerInput struct {
@@ -85,7 +93,18 @@ type AgentActionManagerInput struct {
 
 type AgentActionInput struct {
 	*AgentActionManagerInput
-	RunID string
+	RunID       string
+	ParentRunID string
+}
+
+type AgentStepManagerInput struct {
+	Step *AgentStep
+}
+
+type AgentStepInput struct {
+	*AgentStepManagerInput
+	RunID       string
+	ParentRunID string
 }
 
 type AgentFinishManagerInput struct {
@@ -94,7 +113,8 @@ type AgentFinishManagerInput struct {
 
 type AgentFinishInput struct {
 	*AgentFinishManagerInput
-	RunID string
+	RunID       string
+	ParentRunID string
 }
 
 type ToolStartManagerInput struct {
@@ -104,7 +124,8 @@ type ToolStartManagerInput struct {
 
 type ToolStartInput struct {
 	*ToolStartManagerInput
-	RunID string
+	RunID       string
+	ParentRunID string
 }
 
 type ToolEndManagerInput struct {
@@ -113,7 +134,8 @@ type ToolEndManagerInput struct {
 
 type ToolEndInput struct {
 	*ToolEndManagerInput
-	RunID string
+	RunID       string
+	ParentRunID string
 }
 
 type ToolErrorManagerInput struct {
@@ -122,7 +144,8 @@ type ToolErrorManagerInput struct {
 
 type ToolErrorInput struct {
 	*ToolErrorManagerInput
-	RunID string
+	RunID       string
+	ParentRunID string
 }
 
 type TextManagerInput struct {
@@ -131,7 +154,8 @@ type TextManagerInput struct {
 
 type TextInput struct {
 	*TextManagerInput
-	RunID string
+	RunID       string
+	ParentRunID string
 }
 
 type RetrieverStartManagerInput struct {
@@ -140,7 +164,8 @@ type RetrieverStartManagerInput struct {
 
 type RetrieverStartInput struct {
 	*RetrieverStartManagerInput
-	RunID string
+	RunID       string
+	ParentRunID string
 }
 
 type RetrieverEndManagerInput struct {
@@ -149,7 +174,8 @@ type RetrieverEndManagerInput struct {
 
 type RetrieverEndInput struct {
 	*RetrieverEndManagerInput
-	RunID string
+	RunID       string
+	ParentRunID string
 }
 
 type RetrieverErrorManagerInput struct {
@@ -158,7 +184,53 @@ type RetrieverErrorManagerInput struct {
 
 type RetrieverErrorInput struct {
 	*RetrieverErrorManagerInput
-	RunID string
+	RunID       string
+	ParentRunID string
+}
+
+type EmbedderStartManagerInput struct {
+	EmbedderType string
+	Texts        []string
+}
+
+type EmbedderStartInput struct {
+	*EmbedderStartManagerInput
+	RunID       string
+	ParentRunID string
+}
+
+type EmbedderEndManagerInput struct {
+	Embeddings [][]float32
+}
+
+type EmbedderEndInput struct {
+	*EmbedderEndManagerInput
+	RunID       string
+	ParentRunID string
+}
+
+type EmbedderErrorManagerInput struct {
+	Error error
+}
+
+type EmbedderErrorInput struct {
+	*EmbedderErrorManagerInput
+	RunID       string
+	ParentRunID string
+}
+
+type ModerationViolationManagerInput struct {
+	ModeratorType string
+	Stage         ModerationStage
+	Key           string
+	Text          string
+	Result        *ModerationResult
+}
+
+type ModerationViolationInput struct {
+	*ModerationViolationManagerInput
+	RunID       string
+	ParentRunID string
 }
 
 type Callback interface {
@@ -173,6 +245,7 @@ type Callback interface {
 	OnChainEnd(ctx context.Context, input *ChainEndInput) error
 	OnChainError(ctx context.Context, input *ChainErrorInput) error
 	OnAgentAction(ctx context.Context, input *AgentActionInput) error
+	OnAgentStep(ctx context.Context, input *AgentStepInput) error
 	OnAgentFinish(ctx context.Context, input *AgentFinishInput) error
 	OnToolStart(ctx context.Context, input *ToolStartInput) error
 	OnToolEnd(ctx context.Context, input *ToolEndInput) error
@@ -181,6 +254,10 @@ type Callback interface {
 	OnRetrieverStart(ctx context.Context, input *RetrieverStartInput) error
 	OnRetrieverEnd(ctx context.Context, input *RetrieverEndInput) error
 	OnRetrieverError(ctx context.Context, input *RetrieverErrorInput) error
+	OnEmbedderStart(ctx context.Context, input *EmbedderStartInput) error
+	OnEmbedderEnd(ctx context.Context, input *EmbedderEndInput) error
+	OnEmbedderError(ctx context.Context, input *EmbedderErrorInput) error
+	OnModerationViolation(ctx context.Context, input *ModerationViolationInput) error
 }
 
 type CallbackManager interface {
@@ -189,6 +266,7 @@ type CallbackManager interface {
 	OnChainStart(ctx context.Context, input *ChainStartManagerInput) (CallbackManagerForChainRun, error)
 	OnToolStart(ctx context.Context, input *ToolStartManagerInput) (CallbackManagerForToolRun, error)
 	OnRetrieverStart(ctx context.Context, input *RetrieverStartManagerInput) (CallbackManagerForRetrieverRun, error)
+	OnEmbedderStart(ctx context.Context, input *EmbedderStartManagerInput) (CallbackManagerForEmbedderRun, error)
 	RunID() string
 }
 
@@ -196,8 +274,10 @@ type CallbackManagerForChainRun interface {
 	OnChainEnd(ctx context.Context, input *ChainEndManagerInput) error
 	OnChainError(ctx context.Context, input *ChainErrorManagerInput) error
 	OnAgentAction(ctx context.Context, input *AgentActionManagerInput) error
+	OnAgentStep(ctx context.Context, input *AgentStepManagerInput) error
 	OnAgentFinish(ctx context.Context, input *AgentFinishManagerInput) error
 	OnText(ctx context.Context, input *TextManagerInput) error
+	OnModerationViolation(ctx context.Context, input *ModerationViolationManagerInput) error
 	GetInheritableCallbacks() []Callback
 	RunID() string
 }
@@ -220,6 +300,13 @@ type CallbackManagerForToolRun interface {
 type CallbackManagerForRetrieverRun interface {
 	OnRetrieverEnd(ctx context.Context, input *RetrieverEndManagerInput) error
 	OnRetrieverError(ctx context.Context, input *RetrieverErrorManagerInput) error
+	GetInheritableCallbacks() []Callback
+	RunID() string
+}
+
+type CallbackManagerForEmbedderRun interface {
+	OnEmbedderEnd(ctx context.Context, input *EmbedderEndManagerInput) error
+	OnEmbedderError(ctx context.Context, input *EmbedderErrorManagerInput) error
 }
 
 type CallbackOptions struct {
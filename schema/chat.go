@@ -1,6 +1,7 @@
 package schema
 
 import (
+	"encoding/base64"
 	"fmt"
 	"strings"
 )
@@ -12,6 +13,16 @@ type FunctionCall struct {
 	Arguments string `json:"arguments,omitempty"`
 }
 
+// ToolCall represents a structured tool invocation requested by a chat model.
+type ToolCall struct {
+	// ID is the identifier of the tool call assigned by the model provider.
+	ID string `json:"id,omitempty"`
+	// Name is the name of the tool to call.
+	Name string `json:"name"`
+	// Arguments are the arguments for the tool in JSON format.
+	Arguments string `json:"arguments,omitempty"`
+}
+
 // ChatMessageType represents the type of a chat message.
 type ChatMessageType string
 
@@ -21,11 +32,13 @@ const (
 	ChatMessageTypeSystem   ChatMessageType = "system"
 	ChatMessageTypeGeneric  ChatMessageType = "generic"
 	ChatMessageTypeFunction ChatMessageType = "function"
+	ChatMessageTypeTool     ChatMessageType = "tool"
 )
 
 // ChatMessageExtension represents additional data associated with a chat message.
 type ChatMessageExtension struct {
 	FunctionCall *FunctionCall `json:"functionCall,omitempty"`
+	ToolCalls    []ToolCall    `json:"toolCalls,omitempty"`
 }
 
 // ChatMessage is an interface for different types of chat messages.
@@ -47,6 +60,9 @@ func ChatMessageToMap(cm ChatMessage) map[string]string {
 		m["name"] = fm.Name()
 	} else if gm, ok := cm.(*GenericChatMessage); ok {
 		m["role"] = gm.Role()
+	} else if tm, ok := cm.(*ToolChatMessage); ok {
+		m["name"] = tm.Name()
+		m["toolCallID"] = tm.ToolCallID()
 	}
 
 	return m
@@ -65,14 +81,66 @@ func MapToChatMessage(m map[string]string) (ChatMessage, error) {
 		return NewGenericChatMessage(m["content"], m["role"]), nil
 	case ChatMessageTypeFunction:
 		return NewFunctionChatMessage(m["content"], m["name"]), nil
+	case ChatMessageTypeTool:
+		return NewToolChatMessage(m["toolCallID"], m["name"], m["content"]), nil
 	default:
 		return nil, fmt.Errorf("unknown chat message type: %s", m["type"])
 	}
 }
 
+// ContentPartType represents the type of a content part of a multimodal chat message.
+type ContentPartType string
+
+const (
+	ContentPartTypeText     ContentPartType = "text"
+	ContentPartTypeImageURL ContentPartType = "image_url"
+	ContentPartTypeImage    ContentPartType = "image"
+	ContentPartTypeAudio    ContentPartType = "audio"
+)
+
+// ContentPart represents a typed part of the content of a multimodal chat message.
+type ContentPart struct {
+	// Type is the type of the content part.
+	Type ContentPartType `json:"type"`
+	// Text is the text of a text part.
+	Text string `json:"text,omitempty"`
+	// URL is the URL of an image URL part.
+	URL string `json:"url,omitempty"`
+	// Data are the raw bytes of an image or audio part.
+	Data []byte `json:"data,omitempty"`
+	// MIMEType is the MIME type of the data of an image or audio part, e.g. "image/png" or "audio/wav".
+	MIMEType string `json:"mimeType,omitempty"`
+}
+
+// NewTextPart creates a new text content part.
+func NewTextPart(text string) ContentPart {
+	return ContentPart{Type: ContentPartTypeText, Text: text}
+}
+
+// NewImageURLPart creates a new content part referencing an image by URL.
+func NewImageURLPart(url string) ContentPart {
+	return ContentPart{Type: ContentPartTypeImageURL, URL: url}
+}
+
+// NewImagePart creates a new content part with the bytes of an image.
+func NewImagePart(data []byte, mimeType string) ContentPart {
+	return ContentPart{Type: ContentPartTypeImage, Data: data, MIMEType: mimeType}
+}
+
+// NewAudioPart creates a new content part with the bytes of an audio clip.
+func NewAudioPart(data []byte, mimeType string) ContentPart {
+	return ContentPart{Type: ContentPartTypeAudio, Data: data, MIMEType: mimeType}
+}
+
+// DataURL returns the data of an image or audio part as base64 encoded data URL.
+func (p ContentPart) DataURL() string {
+	return fmt.Sprintf("data:%s;base64,%s", p.MIMEType, base64.StdEncoding.EncodeToString(p.Data))
+}
+
 // HumanChatMessage represents a chat message from a human.
 type HumanChatMessage struct {
 	content string
+	parts   []ContentPart
 }
 
 // NewHumanChatMessage creates a new HumanChatMessage instance.
@@ -82,12 +150,32 @@ func NewHumanChatMessage(content string) *HumanChatMessage {
 	}
 }
 
+// NewMultimodalHumanChatMessage creates a new HumanChatMessage instance with typed content parts,
+// e.g. a question and an image. The content of the message is the text of its text parts.
+func NewMultimodalHumanChatMessage(parts ...ContentPart) *HumanChatMessage {
+	texts := []string{}
+
+	for _, p := range parts {
+		if p.Type == ContentPartTypeText {
+			texts = append(texts, p.Text)
+		}
+	}
+
+	return &HumanChatMessage{
+		content: strings.Join(texts, "\n"),
+		parts:   parts,
+	}
+}
+
 // Type returns the type of the chat message.
 func (m HumanChatMessage) Type() ChatMessageType { return ChatMessageTypeHuman }
 
 // Content returns the content of the chat message.
 func (m HumanChatMessage) Content() string { return m.content }
 
+// Parts returns the content parts of a multimodal chat message, or nil for a text message.
+func (m HumanChatMessage) Parts() []ContentPart { return m.parts }
+
 // AIChatMessage represents a chat message from an AI.
 type AIChatMessage struct {
 	content string
@@ -181,6 +269,34 @@ func (m FunctionChatMessage) Content() string { return m.content }
 // Name returns the name of the function associated with the chat message.
 func (m FunctionChatMessage) Name() string { return m.name }
 
+// ToolChatMessage represents a chat message containing the result of a tool call.
+type ToolChatMessage struct {
+	toolCallID string
+	name       string
+	content    string
+}
+
+// NewToolChatMessage creates a new ToolChatMessage instance.
+func NewToolChatMessage(toolCallID, name, content string) *ToolChatMessage {
+	return &ToolChatMessage{
+		toolCallID: toolCallID,
+		name:       name,
+		content:    content,
+	}
+}
+
+// Type returns the type of the chat message.
+func (m ToolChatMessage) Type() ChatMessageType { return ChatMessageTypeTool }
+
+// Content returns the content of the chat message.
+func (m ToolChatMessage) Content() string { return m.content }
+
+// Name returns the name of the tool associated with the chat message.
+func (m ToolChatMessage) Name() string { return m.name }
+
+// ToolCallID returns the ID of the tool call the chat message responds to.
+func (m ToolChatMessage) ToolCallID() string { return m.toolCallID }
+
 // ChatMessages represents a slice of ChatMessage.
 type ChatMessages []ChatMessage
 
@@ -190,6 +306,7 @@ type StringifyChatMessagesOptions struct {
 	AIPrefix       string
 	SystemPrefix   string
 	FunctionPrefix string
+	ToolPrefix     string
 }
 
 // Format formats the ChatMessages into a single string representation.
@@ -199,6 +316,7 @@ func (cm ChatMessages) Format(optFns ...func(o *StringifyChatMessagesOptions)) (
 		AIPrefix:       "AI",
 		SystemPrefix:   "System",
 		FunctionPrefix: "Function",
+		ToolPrefix:     "Tool",
 	}
 
 	for _, fn := range optFns {
@@ -221,6 +339,8 @@ func (cm ChatMessages) Format(optFns ...func(o *StringifyChatMessagesOptions)) (
 			role = message.(*GenericChatMessage).Role()
 		case ChatMessageTypeFunction:
 			role = opts.FunctionPrefix
+		case ChatMessageTypeTool:
+			role = opts.ToolPrefix
 		default:
 			return "", fmt.Errorf("unknown chat message type: %s", message.Type())
 		}
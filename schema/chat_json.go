@@ -0,0 +1,189 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ChatMessageJSONVersion is the version of the JSON envelope written by MarshalChatMessage.
+// Envelopes without a version are decoded as the map representation of ChatMessageToMap.
+const ChatMessageJSONVersion = 1
+
+// chatMessageJSON is the versioned JSON envelope of a chat message.
+type chatMessageJSON struct {
+	Version      int             `json:"version,omitempty"`
+	Type         ChatMessageType `json:"type"`
+	Content      string          `json:"content"`
+	Role         string          `json:"role,omitempty"`
+	Name         string          `json:"name,omitempty"`
+	ToolCallID   string          `json:"toolCallID,omitempty"`
+	FunctionCall *FunctionCall   `json:"functionCall,omitempty"`
+	ToolCalls    []ToolCall      `json:"toolCalls,omitempty"`
+	Parts        []ContentPart   `json:"parts,omitempty"`
+}
+
+// newChatMessageJSON creates the JSON envelope of a chat message.
+func newChatMessageJSON(cm ChatMessage) chatMessageJSON {
+	env := chatMessageJSON{
+		Version: ChatMessageJSONVersion,
+		Type:    cm.Type(),
+		Content: cm.Content(),
+	}
+
+	switch m := cm.(type) {
+	case *HumanChatMessage:
+		env.Parts = m.parts
+	case *AIChatMessage:
+		env.FunctionCall = m.ext.FunctionCall
+		env.ToolCalls = m.ext.ToolCalls
+	case *GenericChatMessage:
+		env.Role = m.role
+	case *FunctionChatMessage:
+		env.Name = m.name
+	case *ToolChatMessage:
+		env.Name = m.name
+		env.ToolCallID = m.toolCallID
+	}
+
+	return env
+}
+
+// chatMessage converts the JSON envelope back to a chat message.
+func (env chatMessageJSON) chatMessage() (ChatMessage, error) {
+	if env.Version > ChatMessageJSONVersion {
+		return nil, fmt.Errorf("unsupported chat message version: %d", env.Version)
+	}
+
+	switch env.Type {
+	case ChatMessageTypeHuman:
+		if len(env.Parts) > 0 {
+			return NewMultimodalHumanChatMessage(env.Parts...), nil
+		}
+
+		return NewHumanChatMessage(env.Content), nil
+	case ChatMessageTypeAI:
+		return NewAIChatMessage(env.Content, func(o *ChatMessageExtension) {
+			o.FunctionCall = env.FunctionCall
+			o.ToolCalls = env.ToolCalls
+		}), nil
+	case ChatMessageTypeSystem:
+		return NewSystemChatMessage(env.Content), nil
+	case ChatMessageTypeGeneric:
+		return NewGenericChatMessage(env.Content, env.Role), nil
+	case ChatMessageTypeFunction:
+		return NewFunctionChatMessage(env.Name, env.Content), nil
+	case ChatMessageTypeTool:
+		return NewToolChatMessage(env.ToolCallID, env.Name, env.Content), nil
+	default:
+		return nil, fmt.Errorf("unknown chat message type: %s", env.Type)
+	}
+}
+
+// MarshalChatMessage returns the versioned JSON encoding of a chat message.
+func MarshalChatMessage(cm ChatMessage) ([]byte, error) {
+	return json.Marshal(newChatMessageJSON(cm))
+}
+
+// UnmarshalChatMessage decodes a chat message encoded by MarshalChatMessage. The concrete
+// message type is selected by the type of the envelope.
+func UnmarshalChatMessage(data []byte) (ChatMessage, error) {
+	env := chatMessageJSON{}
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, err
+	}
+
+	return env.chatMessage()
+}
+
+// unmarshalChatMessageInto decodes data into the chat message pointed to by target. It returns an
+// error, if the encoded message is of another type.
+func unmarshalChatMessageInto[T any, P interface {
+	*T
+	ChatMessage
+}](data []byte, target P) error {
+	cm, err := UnmarshalChatMessage(data)
+	if err != nil {
+		return err
+	}
+
+	m, ok := cm.(P)
+	if !ok {
+		return fmt.Errorf("cannot unmarshal chat message of type %s into %s", cm.Type(), target.Type())
+	}
+
+	*target = *m
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (m HumanChatMessage) MarshalJSON() ([]byte, error) { return MarshalChatMessage(&m) }
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (m *HumanChatMessage) UnmarshalJSON(data []byte) error {
+	return unmarshalChatMessageInto(data, m)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (m AIChatMessage) MarshalJSON() ([]byte, error) { return MarshalChatMessage(&m) }
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (m *AIChatMessage) UnmarshalJSON(data []byte) error {
+	return unmarshalChatMessageInto(data, m)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (m SystemChatMessage) MarshalJSON() ([]byte, error) { return MarshalChatMessage(&m) }
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (m *SystemChatMessage) UnmarshalJSON(data []byte) error {
+	return unmarshalChatMessageInto(data, m)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (m GenericChatMessage) MarshalJSON() ([]byte, error) { return MarshalChatMessage(&m) }
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (m *GenericChatMessage) UnmarshalJSON(data []byte) error {
+	return unmarshalChatMessageInto(data, m)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (m FunctionChatMessage) MarshalJSON() ([]byte, error) { return MarshalChatMessage(&m) }
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (m *FunctionChatMessage) UnmarshalJSON(data []byte) error {
+	return unmarshalChatMessageInto(data, m)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (m ToolChatMessage) MarshalJSON() ([]byte, error) { return MarshalChatMessage(&m) }
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (m *ToolChatMessage) UnmarshalJSON(data []byte) error {
+	return unmarshalChatMessageInto(data, m)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. The messages are decoded into their
+// concrete types; they are encoded by the MarshalJSON methods of the messages.
+func (cm *ChatMessages) UnmarshalJSON(data []byte) error {
+	raw := []json.RawMessage{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	messages := make(ChatMessages, 0, len(raw))
+
+	for _, r := range raw {
+		m, err := UnmarshalChatMessage(r)
+		if err != nil {
+			return err
+		}
+
+		messages = append(messages, m)
+	}
+
+	*cm = messages
+
+	return nil
+}
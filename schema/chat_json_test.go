@@ -0,0 +1,68 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChatMessageJSON(t *testing.T) {
+	messages := ChatMessages{
+		NewSystemChatMessage("You are a helpful assistant."),
+		NewHumanChatMessage("What is the weather in Berlin?"),
+		NewMultimodalHumanChatMessage(
+			NewTextPart("Is it as sunny as in the picture?"),
+			NewImagePart([]byte("png"), "image/png"),
+			NewImageURLPart("https://example.com/berlin.png"),
+		),
+		NewAIChatMessage("", func(o *ChatMessageExtension) {
+			o.ToolCalls = []ToolCall{{ID: "call_1", Name: "weather", Arguments: `{"city":"Berlin"}`}}
+		}),
+		NewToolChatMessage("call_1", "weather", "sunny"),
+		NewFunctionChatMessage("weather", "sunny"),
+		NewGenericChatMessage("Generic message.", "role"),
+		NewAIChatMessage("It is sunny in Berlin."),
+	}
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		data, err := json.Marshal(messages)
+		require.NoError(t, err)
+
+		decoded := ChatMessages{}
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		require.Equal(t, messages, decoded)
+	})
+
+	t.Run("Envelope", func(t *testing.T) {
+		data, err := MarshalChatMessage(NewToolChatMessage("call_1", "weather", "sunny"))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"version":1,"type":"tool","content":"sunny","name":"weather","toolCallID":"call_1"}`, string(data))
+	})
+
+	t.Run("ConcreteType", func(t *testing.T) {
+		data, err := json.Marshal(messages[3])
+		require.NoError(t, err)
+
+		aiMsg := AIChatMessage{}
+		require.NoError(t, json.Unmarshal(data, &aiMsg))
+		require.Equal(t, "call_1", aiMsg.Extension().ToolCalls[0].ID)
+
+		humanMsg := HumanChatMessage{}
+		require.EqualError(t, json.Unmarshal(data, &humanMsg), "cannot unmarshal chat message of type ai into human")
+	})
+
+	t.Run("Unversioned", func(t *testing.T) {
+		data, err := json.Marshal(ChatMessageToMap(NewGenericChatMessage("Generic message.", "role")))
+		require.NoError(t, err)
+
+		msg, err := UnmarshalChatMessage(data)
+		require.NoError(t, err)
+		require.Equal(t, NewGenericChatMessage("Generic message.", "role"), msg)
+	})
+
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		_, err := UnmarshalChatMessage([]byte(`{"version":2,"type":"human","content":"foo"}`))
+		require.EqualError(t, err, "unsupported chat message version: 2")
+	})
+}
@@ -54,6 +54,7 @@ func TestStringifyChatMessages(t *testing.T) {
 		NewSystemChatMessage("System message."),
 		NewGenericChatMessage("Generic message.", "role"),
 		NewFunctionChatMessage("function", "Function call message."),
+		NewToolChatMessage("call_1", "tool", "Tool result message."),
 	}
 
 	formatted, err := chatMessages.Format()
@@ -63,4 +64,35 @@ func TestStringifyChatMessages(t *testing.T) {
 	require.Contains(t, formatted, "System: System message.")
 	require.Contains(t, formatted, "role: Generic message.")
 	require.Contains(t, formatted, "Function: Function call message.")
+	require.Contains(t, formatted, "Tool: Tool result message.")
+}
+
+func TestToolChatMessage(t *testing.T) {
+	toolMsg := NewToolChatMessage("call_1", "search", "result")
+
+	toolMap := ChatMessageToMap(toolMsg)
+	require.Equal(t, "tool", toolMap["type"])
+	require.Equal(t, "search", toolMap["name"])
+	require.Equal(t, "call_1", toolMap["toolCallID"])
+	require.Equal(t, "result", toolMap["content"])
+
+	msg, err := MapToChatMessage(toolMap)
+	require.NoError(t, err)
+	require.IsType(t, &ToolChatMessage{}, msg)
+	require.Equal(t, "call_1", msg.(*ToolChatMessage).ToolCallID())
+	require.Equal(t, "search", msg.(*ToolChatMessage).Name())
+	require.Equal(t, "result", msg.Content())
+}
+
+func TestMultimodalHumanChatMessage(t *testing.T) {
+	msg := NewMultimodalHumanChatMessage(
+		NewTextPart("What is in the image?"),
+		NewImagePart([]byte("png"), "image/png"),
+		NewTextPart("Answer in one word."),
+	)
+
+	require.Equal(t, "What is in the image?\nAnswer in one word.", msg.Content())
+	require.Len(t, msg.Parts(), 3)
+	require.Equal(t, "data:image/png;base64,cG5n", msg.Parts()[1].DataURL())
+	require.Nil(t, NewHumanChatMessage("foo").Parts())
 }
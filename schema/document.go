@@ -10,6 +10,17 @@ type Document struct {
 type DocumentLoader interface {
 	Load(ctx context.Context) ([]Document, error)
 	LoadAndSplit(ctx context.Context, splitter TextSplitter) ([]Document, error)
+	// LazyLoad returns an iterator, which loads the documents on demand, so large corpora can be processed
+	// without holding all documents in memory.
+	LazyLoad(ctx context.Context) (DocumentIterator, error)
+}
+
+// DocumentIterator iterates over documents, e.g. the documents of a document loader.
+type DocumentIterator interface {
+	// Next returns the next document. It returns io.EOF, if there are no more documents.
+	Next(ctx context.Context) (Document, error)
+	// Close releases the resources of the iterator.
+	Close() error
 }
 
 type DocumentCompressor interface {
@@ -17,6 +28,26 @@ type DocumentCompressor interface {
 	Compress(ctx context.Context, docs []Document, query string) ([]Document, error)
 }
 
+// RerankResult contains the relevance score of a reranked document.
+type RerankResult struct {
+	// Index is the index of the document in the reranked documents.
+	Index int
+	// RelevanceScore is the relevance score of the document to the query.
+	RelevanceScore float64
+}
+
+type Reranker interface {
+	// Rerank scores the relevance of the documents to the query. The results are sorted by descending relevance score.
+	Rerank(ctx context.Context, query string, docs []Document) ([]RerankResult, error)
+}
+
+type DocStore interface {
+	// AddDocuments stores the documents under the given ids.
+	AddDocuments(ctx context.Context, ids []string, docs []Document) error
+	// GetDocuments returns the documents stored under the given ids. Unknown ids are skipped.
+	GetDocuments(ctx context.Context, ids []string) ([]Document, error)
+}
+
 type DocumentTransformer interface {
 	Transform(ctx context.Context, docs []Document) ([]Document, error)
 }
@@ -35,5 +66,42 @@ type TextSplitter interface {
 
 type VectorStore interface {
 	AddDocuments(ctx context.Context, docs []Document) error
+	// AddDocumentsWithIDs adds the documents under the given ids. Existing documents with the same ids are replaced.
+	AddDocumentsWithIDs(ctx context.Context, ids []string, docs []Document) error
+	// Delete deletes the documents with the given ids.
+	Delete(ctx context.Context, ids []string) error
+	// DeleteByFilter deletes the documents whose metadata matches all key-value pairs of the filter.
+	DeleteByFilter(ctx context.Context, filter map[string]any) error
 	SimilaritySearch(ctx context.Context, query string) ([]Document, error)
 }
+
+// ScoredDocument contains a document found by a similarity search and its score. Higher scores are more similar.
+type ScoredDocument struct {
+	Document Document
+	Score    float32
+}
+
+// VectorStoreWithScores is a vector store returning the similarity scores of the found documents.
+type VectorStoreWithScores interface {
+	VectorStore
+	// SimilaritySearchWithScores returns the documents similar to the query together with their similarity scores.
+	SimilaritySearchWithScores(ctx context.Context, query string) ([]ScoredDocument, error)
+}
+
+// VectorStoreWithFilter is a vector store supporting similarity searches restricted by metadata.
+type VectorStoreWithFilter interface {
+	VectorStoreWithScores
+	// SimilaritySearchWithFilter returns the documents similar to the query whose metadata match the filter
+	// together with their similarity scores. A filter of key-value pairs matches documents with equal metadata values.
+	SimilaritySearchWithFilter(ctx context.Context, query string, filter map[string]any) ([]ScoredDocument, error)
+}
+
+// VectorStoreWithMMR is a vector store supporting maximal marginal relevance searches, which return
+// diverse results instead of near duplicates.
+type VectorStoreWithMMR interface {
+	VectorStore
+	// MaxMarginalRelevanceSearch fetches the fetchK documents most similar to the query and selects among them
+	// the documents that are similar to the query and diverse among each other by maximal marginal relevance.
+	// The lambdaMult between 0 and 1 weights the similarity against the diversity, 1 ranks by similarity only.
+	MaxMarginalRelevanceSearch(ctx context.Context, query string, fetchK int, lambdaMult float32) ([]Document, error)
+}
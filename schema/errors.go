@@ -5,4 +5,7 @@ import "errors"
 var (
 	ErrInvalidChainValues  = errors.New("invalid chain values")
 	ErrChainValueWrongType = errors.New("chain value is of wrong type")
+	// ErrResponseFormatNotSupported is returned by models, which cannot constrain their output to the
+	// requested response format.
+	ErrResponseFormatNotSupported = errors.New("response format not supported")
 )
@@ -26,3 +26,11 @@ type ChatMessageHistory interface {
 	// Clear removes all messages from the store.
 	Clear(ctx context.Context) error
 }
+
+// SessionChatMessageHistory is a chat message history, which persists the messages of a session in a
+// store. Histories with the same session id share their messages, e.g. across process restarts or replicas.
+type SessionChatMessageHistory interface {
+	ChatMessageHistory
+	// SessionID returns the id of the session of the messages.
+	SessionID() string
+}
@@ -13,12 +13,61 @@ type Generation struct {
 	Info    map[string]any
 }
 
+// Citation represents a span of a generated text that is grounded in one or more source documents.
+type Citation struct {
+	// Start is the index of the first character of the cited span.
+	Start int
+	// End is the index after the last character of the cited span.
+	End int
+	// Text is the cited span of the generated text.
+	Text string
+	// DocumentIDs are the identifiers of the documents supporting the span.
+	DocumentIDs []string
+}
+
+// TokenUsage represents the number of tokens consumed by one or more model invocations.
+type TokenUsage struct {
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+}
+
+// NewTokenUsage creates a token usage from the prompt and completion tokens.
+func NewTokenUsage(promptTokens, completionTokens int) TokenUsage {
+	return TokenUsage{
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+		TotalTokens:      promptTokens + completionTokens,
+	}
+}
+
+// Add returns the sum of both token usages.
+func (tu TokenUsage) Add(other TokenUsage) TokenUsage {
+	return TokenUsage{
+		PromptTokens:     tu.PromptTokens + other.PromptTokens,
+		CompletionTokens: tu.CompletionTokens + other.CompletionTokens,
+		TotalTokens:      tu.TotalTokens + other.TotalTokens,
+	}
+}
+
 // ModelResult represents the result of a model generation.
 type ModelResult struct {
 	Generations []Generation
 	LLMOutput   map[string]any
 }
 
+// TokenUsage returns the token usage reported by the model in the LLMOutput under the "TokenUsage" key.
+// The second return value is false if the model did not report any usage.
+func (mr *ModelResult) TokenUsage() (TokenUsage, bool) {
+	if mr == nil || mr.LLMOutput == nil {
+		return TokenUsage{}, false
+	}
+
+	tokenUsage, ok := mr.LLMOutput["TokenUsage"].(TokenUsage)
+
+	return tokenUsage, ok
+}
+
 // PromptValue is an interface representing a prompt value for LLMs and chat models.
 type PromptValue interface {
 	// String returns the string representation of the prompt value.
@@ -63,11 +112,22 @@ type FunctionDefinition struct {
 	Parameters  FunctionDefinitionParameters `json:"parameters"`
 }
 
+// ResponseFormat represents the format of the response of a model.
+type ResponseFormat struct {
+	// Name is the name of the response format.
+	Name string
+	// Schema is the JSON schema the response must conform to.
+	Schema *jsonschema.Schema
+}
+
 type GenerateOptions struct {
 	CallbackManger    CallbackManagerForModelRun
 	Stop              []string
 	Functions         []FunctionDefinition
 	ForceFunctionCall bool
+	// ResponseFormat requests a JSON response. Models supporting a native JSON mode constrain their
+	// output to JSON. Google GenAI returns ErrResponseFormatNotSupported, other models ignore it.
+	ResponseFormat *ResponseFormat
 }
 
 // LLM is the interface for language models.
@@ -77,6 +137,26 @@ type LLM interface {
 	Generate(ctx context.Context, prompt string, optFns ...func(o *GenerateOptions)) (*ModelResult, error)
 }
 
+// StreamChunk represents a chunk of a streamed model response.
+type StreamChunk struct {
+	// Token is the text delta of the chunk. Tool calls and function calls of the model are not streamed.
+	Token string
+	// FinishReason is the reason the model stopped generating, if reported with the chunk.
+	FinishReason string
+	// TokenUsage is the token usage of the generation, if reported by the model. It is sent with the last chunk.
+	TokenUsage *TokenUsage
+	// Err is set if an error occurred while streaming. It is always the last chunk sent.
+	Err error
+}
+
+// StreamingLLM is the interface for language models that can stream tokens incrementally.
+type StreamingLLM interface {
+	LLM
+	// Stream generates text based on the provided prompt and sends the tokens to the returned channel.
+	// The channel is closed when the generation is complete.
+	Stream(ctx context.Context, prompt string, optFns ...func(o *GenerateOptions)) (<-chan StreamChunk, error)
+}
+
 // ChatModel is the interface for chat models.
 type ChatModel interface {
 	Model
@@ -84,6 +164,22 @@ type ChatModel interface {
 	Generate(ctx context.Context, messages ChatMessages, optFns ...func(o *GenerateOptions)) (*ModelResult, error)
 }
 
+// StreamingChatModel is the interface for chat models that can stream tokens incrementally.
+type StreamingChatModel interface {
+	ChatModel
+	// Stream generates text based on the provided chat messages and sends the tokens to the returned channel.
+	// The channel is closed when the generation is complete.
+	Stream(ctx context.Context, messages ChatMessages, optFns ...func(o *GenerateOptions)) (<-chan StreamChunk, error)
+}
+
+// ToolCallingChatModel is the interface for chat models that support native tool calling.
+type ToolCallingChatModel interface {
+	ChatModel
+	// BindTools returns a copy of the chat model that offers the given tools to the model on every generation.
+	// Requested tool invocations are returned as ToolCalls in the extension of the generated AI chat message.
+	BindTools(tools []Tool) (ToolCallingChatModel, error)
+}
+
 // Model is the interface for language models and chat models.
 type Model interface {
 	Tokenizer
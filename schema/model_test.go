@@ -0,0 +1,29 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenUsage(t *testing.T) {
+	t.Run("NewTokenUsage", func(t *testing.T) {
+		assert.Equal(t, TokenUsage{PromptTokens: 3, CompletionTokens: 4, TotalTokens: 7}, NewTokenUsage(3, 4))
+	})
+
+	t.Run("Add", func(t *testing.T) {
+		usage := NewTokenUsage(3, 4).Add(NewTokenUsage(10, 20))
+		assert.Equal(t, TokenUsage{PromptTokens: 13, CompletionTokens: 24, TotalTokens: 37}, usage)
+	})
+
+	t.Run("ModelResult", func(t *testing.T) {
+		result := &ModelResult{LLMOutput: map[string]any{"TokenUsage": NewTokenUsage(1, 2)}}
+
+		usage, ok := result.TokenUsage()
+		assert.True(t, ok)
+		assert.Equal(t, 3, usage.TotalTokens)
+
+		_, ok = (&ModelResult{}).TokenUsage()
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,50 @@
+package schema
+
+import "context"
+
+// ModerationStage is the stage of a chain run, in which a text is moderated.
+type ModerationStage string
+
+const (
+	// ModerationStageInput is the moderation of the inputs of a chain.
+	ModerationStageInput ModerationStage = "input"
+	// ModerationStageOutput is the moderation of the outputs of a chain.
+	ModerationStageOutput ModerationStage = "output"
+)
+
+// ModerationCategory represents a category of a moderation result, e.g. hate or violence.
+type ModerationCategory struct {
+	// Name is the name of the category.
+	Name string
+	// Score is the confidence score of the category.
+	Score float32
+	// Flagged indicates whether the text violates the category.
+	Flagged bool
+}
+
+// ModerationResult represents the result of the moderation of a text.
+type ModerationResult struct {
+	// Flagged indicates whether the text violates the content policy.
+	Flagged bool
+	// Categories contains the categories checked by the moderator.
+	Categories []ModerationCategory
+}
+
+// FlaggedCategories returns the names of the flagged categories.
+func (r *ModerationResult) FlaggedCategories() []string {
+	names := []string{}
+
+	for _, c := range r.Categories {
+		if c.Flagged {
+			names = append(names, c.Name)
+		}
+	}
+
+	return names
+}
+
+// Moderator is the interface for checking texts against a content policy.
+type Moderator interface {
+	// Moderate checks the text and returns the moderation result.
+	Moderate(ctx context.Context, text string) (*ModerationResult, error)
+}
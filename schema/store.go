@@ -0,0 +1,11 @@
+package schema
+
+import "context"
+
+// ByteStore is the interface for key-value stores of byte values.
+type ByteStore interface {
+	// MGet returns the values stored under the keys. The value of an unknown key is nil.
+	MGet(ctx context.Context, keys []string) ([][]byte, error)
+	// MSet stores the values under the keys. Existing values are overwritten.
+	MSet(ctx context.Context, keys []string, values [][]byte) error
+}
@@ -0,0 +1,116 @@
+package speechtotext
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/sashabaranov/go-openai"
+)
+
+// Compile time check to ensure OpenAI satisfies the SpeechToText interface.
+var _ schema.SpeechToText = (*OpenAI)(nil)
+
+// OpenAIClient is an interface for the OpenAI transcription API client.
+type OpenAIClient interface {
+	CreateTranscription(ctx context.Context, request openai.AudioRequest) (response openai.AudioResponse, err error)
+}
+
+// OpenAIOptions contains options for configuring the OpenAI transcriber.
+type OpenAIOptions struct {
+	// Model is the name of the transcription model.
+	Model string
+	// Language is the language of the audio in ISO-639-1 format. If empty, the language is detected.
+	Language string
+	// Prompt is an optional text to guide the style of the transcription.
+	Prompt string
+	// Temperature is the sampling temperature.
+	Temperature float32
+	// BaseURL is the base url of a Whisper-compatible API. If empty, the OpenAI API is used.
+	BaseURL string
+}
+
+// DefaultOpenAIOptions provides default values for OpenAIOptions.
+var DefaultOpenAIOptions = OpenAIOptions{
+	Model: openai.Whisper1,
+}
+
+// OpenAI is a transcriber that uses the OpenAI transcription API or a Whisper-compatible API to transcribe audio.
+type OpenAI struct {
+	client OpenAIClient
+	opts   OpenAIOptions
+}
+
+// NewOpenAI creates a new instance of the OpenAI transcriber.
+func NewOpenAI(apiKey string, optFns ...func(o *OpenAIOptions)) *OpenAI {
+	opts := DefaultOpenAIOptions
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	config := openai.DefaultConfig(apiKey)
+
+	if opts.BaseURL != "" {
+		config.BaseURL = opts.BaseURL
+	}
+
+	client := openai.NewClientWithConfig(config)
+
+	return NewOpenAIFromClient(client, func(o *OpenAIOptions) {
+		*o = opts
+	})
+}
+
+// NewOpenAIFromClient creates a new instance of the OpenAI transcriber with a custom client.
+func NewOpenAIFromClient(client OpenAIClient, optFns ...func(o *OpenAIOptions)) *OpenAI {
+	opts := DefaultOpenAIOptions
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &OpenAI{
+		client: client,
+		opts:   opts,
+	}
+}
+
+// Transcribe transcribes the audio with time-stamped segments.
+func (s2t *OpenAI) Transcribe(ctx context.Context, audio io.Reader, name string) (*schema.Transcript, error) {
+	res, err := s2t.client.CreateTranscription(ctx, openai.AudioRequest{
+		Model:                  s2t.opts.Model,
+		FilePath:               name,
+		Reader:                 audio,
+		Prompt:                 s2t.opts.Prompt,
+		Temperature:            s2t.opts.Temperature,
+		Language:               s2t.opts.Language,
+		Format:                 openai.AudioResponseFormatVerboseJSON,
+		TimestampGranularities: []openai.TranscriptionTimestampGranularity{openai.TranscriptionTimestampGranularitySegment},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	segments := make([]schema.TranscriptSegment, 0, len(res.Segments))
+
+	for _, segment := range res.Segments {
+		segments = append(segments, schema.TranscriptSegment{
+			Start: secondsToDuration(segment.Start),
+			End:   secondsToDuration(segment.End),
+			Text:  segment.Text,
+		})
+	}
+
+	return &schema.Transcript{
+		Text:     res.Text,
+		Language: res.Language,
+		Segments: segments,
+	}, nil
+}
+
+// secondsToDuration converts seconds to a duration.
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
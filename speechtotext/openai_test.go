@@ -0,0 +1,81 @@
+package speechtotext
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockOpenAIClient is a mock implementation of the OpenAIClient interface.
+type MockOpenAIClient struct {
+	CreateTranscriptionFn func(ctx context.Context, request openai.AudioRequest) (response openai.AudioResponse, err error)
+}
+
+func (m *MockOpenAIClient) CreateTranscription(ctx context.Context, request openai.AudioRequest) (response openai.AudioResponse, err error) {
+	return m.CreateTranscriptionFn(ctx, request)
+}
+
+func TestOpenAI(t *testing.T) {
+	t.Run("Transcribe", func(t *testing.T) {
+		client := &MockOpenAIClient{
+			CreateTranscriptionFn: func(ctx context.Context, request openai.AudioRequest) (openai.AudioResponse, error) {
+				assert.Equal(t, openai.Whisper1, request.Model)
+				assert.Equal(t, "talk.mp3", request.FilePath)
+				assert.Equal(t, "de", request.Language)
+				assert.Equal(t, openai.AudioResponseFormatVerboseJSON, request.Format)
+
+				data, err := io.ReadAll(request.Reader)
+				assert.NoError(t, err)
+				assert.Equal(t, "audio", string(data))
+
+				res := openai.AudioResponse{Text: "Hallo Welt", Language: "german"}
+				res.Segments = append(res.Segments, struct {
+					ID               int     `json:"id"`
+					Seek             int     `json:"seek"`
+					Start            float64 `json:"start"`
+					End              float64 `json:"end"`
+					Text             string  `json:"text"`
+					Tokens           []int   `json:"tokens"`
+					Temperature      float64 `json:"temperature"`
+					AvgLogprob       float64 `json:"avg_logprob"`
+					CompressionRatio float64 `json:"compression_ratio"`
+					NoSpeechProb     float64 `json:"no_speech_prob"`
+					Transient        bool    `json:"transient"`
+				}{Start: 0, End: 1.5, Text: "Hallo Welt"})
+
+				return res, nil
+			},
+		}
+
+		s2t := NewOpenAIFromClient(client, func(o *OpenAIOptions) {
+			o.Language = "de"
+		})
+
+		transcript, err := s2t.Transcribe(context.Background(), strings.NewReader("audio"), "talk.mp3")
+		require.NoError(t, err)
+		assert.Equal(t, &schema.Transcript{
+			Text:     "Hallo Welt",
+			Language: "german",
+			Segments: []schema.TranscriptSegment{{Start: 0, End: 1500 * time.Millisecond, Text: "Hallo Welt"}},
+		}, transcript)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		client := &MockOpenAIClient{
+			CreateTranscriptionFn: func(ctx context.Context, request openai.AudioRequest) (openai.AudioResponse, error) {
+				return openai.AudioResponse{}, errors.New("transcription error")
+			},
+		}
+
+		_, err := NewOpenAIFromClient(client).Transcribe(context.Background(), strings.NewReader("audio"), "talk.mp3")
+		assert.EqualError(t, err, "transcription error")
+	})
+}
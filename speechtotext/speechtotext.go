@@ -0,0 +1,2 @@
+// Package speechtotext provides functionality for transcribing audio into text.
+package speechtotext
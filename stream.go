@@ -0,0 +1,216 @@
+package golc
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/schema"
+)
+
+// StreamEventType is the type of a StreamEvent.
+type StreamEventType string
+
+const (
+	// StreamEventToken is the event of a new token generated by a streaming model.
+	StreamEventToken StreamEventType = "token"
+	// StreamEventChainOutput is the event of the outputs of an intermediate chain.
+	StreamEventChainOutput StreamEventType = "chain_output"
+	// StreamEventDocuments is the event of the documents returned by a retriever.
+	StreamEventDocuments StreamEventType = "documents"
+	// StreamEventAgentAction is the event of an action planned by an agent. The log of the action
+	// contains the reasoning of the agent, if any.
+	StreamEventAgentAction StreamEventType = "agent_action"
+	// StreamEventAgentStep is the event of an executed action of an agent with the observation of the tool.
+	StreamEventAgentStep StreamEventType = "agent_step"
+	// StreamEventFinal is the event of the final outputs of the chain. It is always the last event sent on success.
+	StreamEventFinal StreamEventType = "final"
+	// StreamEventError is the event of an error of the chain. It is always the last event sent on failure.
+	StreamEventError StreamEventType = "error"
+)
+
+// StreamEvent represents an event of a streamed chain execution.
+type StreamEvent struct {
+	// Type is the type of the event.
+	Type StreamEventType
+	// RunID is the id of the run that emitted the event.
+	RunID string
+	// ParentRunID is the id of the parent run of the run that emitted the event.
+	ParentRunID string
+	// Name is the type of the chain of a chain output event.
+	Name string
+	// Token is the text delta of a token event.
+	Token string
+	// Outputs are the outputs of a chain output or final event.
+	Outputs schema.ChainValues
+	// Documents are the retrieved documents of a documents event.
+	Documents []schema.Document
+	// Action is the action of an agent action event.
+	Action *schema.AgentAction
+	// Step is the executed step of an agent step event.
+	Step *schema.AgentStep
+	// Err is the error of an error event.
+	Err error
+}
+
+type StreamOptions struct {
+	Callbacks      []schema.Callback
+	ParentRunID    string
+	IncludeRunInfo bool
+	Stop           []string
+	// BufferSize is the size of the buffer of the returned channel.
+	BufferSize int
+}
+
+// Stream executes a chain and sends the events of the execution to the returned channel. The
+// events comprise the tokens of streaming models, the outputs of intermediate chains, the documents
+// of retrievers, the actions and steps of agents and finally either the final outputs or the error
+// of the chain. The channel is closed after the final or error event. Tokens are only emitted by
+// models with streaming enabled. Cancel the context to stop the execution, if the events are no
+// longer consumed.
+func Stream(ctx context.Context, chain schema.Chain, inputs schema.ChainValues, optFns ...func(*StreamOptions)) <-chan StreamEvent {
+	opts := StreamOptions{
+		BufferSize: 16,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	events := make(chan StreamEvent, opts.BufferSize)
+
+	handler := &streamHandler{
+		ctx:         ctx,
+		events:      events,
+		parentRunID: opts.ParentRunID,
+		chainTypes:  make(map[string]string),
+	}
+
+	go func() {
+		defer close(events)
+
+		outputs, err := Call(ctx, chain, inputs, func(o *CallOptions) {
+			o.Callbacks = append(append([]schema.Callback{}, opts.Callbacks...), handler)
+			o.ParentRunID = opts.ParentRunID
+			o.IncludeRunInfo = opts.IncludeRunInfo
+			o.Stop = opts.Stop
+		})
+		if err != nil {
+			handler.send(StreamEvent{Type: StreamEventError, Err: err})
+			return
+		}
+
+		handler.send(StreamEvent{Type: StreamEventFinal, Outputs: outputs})
+	}()
+
+	return events
+}
+
+// Compile time check to ensure streamHandler satisfies the Callback interface.
+var _ schema.Callback = (*streamHandler)(nil)
+
+// streamHandler converts the callback events of a chain execution to stream events.
+type streamHandler struct {
+	callback.NoopHandler
+	ctx         context.Context
+	events      chan<- StreamEvent
+	parentRunID string
+	chainTypes  map[string]string
+	mu          sync.Mutex
+}
+
+func (h *streamHandler) AlwaysVerbose() bool {
+	return true
+}
+
+func (h *streamHandler) OnModelNewToken(ctx context.Context, input *schema.ModelNewTokenInput) error {
+	h.send(StreamEvent{
+		Type:        StreamEventToken,
+		RunID:       input.RunID,
+		ParentRunID: input.ParentRunID,
+		Token:       input.Token,
+	})
+
+	return nil
+}
+
+func (h *streamHandler) OnChainStart(ctx context.Context, input *schema.ChainStartInput) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.chainTypes[input.RunID] = input.ChainType
+
+	return nil
+}
+
+func (h *streamHandler) OnChainEnd(ctx context.Context, input *schema.ChainEndInput) error {
+	h.mu.Lock()
+	chainType := h.chainTypes[input.RunID]
+	delete(h.chainTypes, input.RunID)
+	h.mu.Unlock()
+
+	// The outputs of the streamed chain itself are sent with the final event.
+	if input.ParentRunID == h.parentRunID {
+		return nil
+	}
+
+	h.send(StreamEvent{
+		Type:        StreamEventChainOutput,
+		RunID:       input.RunID,
+		ParentRunID: input.ParentRunID,
+		Name:        chainType,
+		Outputs:     input.Outputs,
+	})
+
+	return nil
+}
+
+func (h *streamHandler) OnChainError(ctx context.Context, input *schema.ChainErrorInput) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.chainTypes, input.RunID)
+
+	return nil
+}
+
+func (h *streamHandler) OnRetrieverEnd(ctx context.Context, input *schema.RetrieverEndInput) error {
+	h.send(StreamEvent{
+		Type:        StreamEventDocuments,
+		RunID:       input.RunID,
+		ParentRunID: input.ParentRunID,
+		Documents:   input.Docs,
+	})
+
+	return nil
+}
+
+func (h *streamHandler) OnAgentAction(ctx context.Context, input *schema.AgentActionInput) error {
+	h.send(StreamEvent{
+		Type:        StreamEventAgentAction,
+		RunID:       input.RunID,
+		ParentRunID: input.ParentRunID,
+		Action:      input.Action,
+	})
+
+	return nil
+}
+
+func (h *streamHandler) OnAgentStep(ctx context.Context, input *schema.AgentStepInput) error {
+	h.send(StreamEvent{
+		Type:        StreamEventAgentStep,
+		RunID:       input.RunID,
+		ParentRunID: input.ParentRunID,
+		Step:        input.Step,
+	})
+
+	return nil
+}
+
+// send sends the event unless the context of the stream is done.
+func (h *streamHandler) send(event StreamEvent) {
+	select {
+	case h.events <- event:
+	case <-h.ctx.Done():
+	}
+}
@@ -0,0 +1,143 @@
+package golc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		childChain := mockChain{
+			TypeFunc: func() string {
+				return "Child"
+			},
+			CallFunc: func(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+				opts := schema.CallOptions{}
+				for _, fn := range optFns {
+					fn(&opts)
+				}
+
+				mm := callback.NewManagerForModelRun("model", opts.CallbackManger.GetInheritableCallbacks(), nil, false, func(mo *callback.ManagerOptions) {
+					mo.ParentRunID = opts.CallbackManger.RunID()
+				})
+
+				for _, token := range []string{"Hello", " World"} {
+					if err := mm.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{Token: token}); err != nil {
+						return nil, err
+					}
+				}
+
+				return schema.ChainValues{"text": "Hello World"}, nil
+			},
+		}
+
+		chain := mockChain{
+			CallFunc: func(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+				opts := schema.CallOptions{}
+				for _, fn := range optFns {
+					fn(&opts)
+				}
+
+				rm, err := callback.NewManager(opts.CallbackManger.GetInheritableCallbacks(), nil, false, func(mo *callback.ManagerOptions) {
+					mo.ParentRunID = opts.CallbackManger.RunID()
+				}).OnRetrieverStart(ctx, &schema.RetrieverStartManagerInput{Query: "query"})
+				if err != nil {
+					return nil, err
+				}
+
+				if err := rm.OnRetrieverEnd(ctx, &schema.RetrieverEndManagerInput{
+					Docs: []schema.Document{{PageContent: "doc"}},
+				}); err != nil {
+					return nil, err
+				}
+
+				outputs, err := Call(ctx, childChain, inputs, func(o *CallOptions) {
+					o.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
+					o.ParentRunID = opts.CallbackManger.RunID()
+				})
+				if err != nil {
+					return nil, err
+				}
+
+				return schema.ChainValues{"answer": outputs["text"]}, nil
+			},
+		}
+
+		var events []StreamEvent
+		for event := range Stream(context.Background(), chain, schema.ChainValues{"query": "query"}) {
+			events = append(events, event)
+		}
+
+		assert.Len(t, events, 5)
+		assert.Equal(t, StreamEventDocuments, events[0].Type)
+		assert.Equal(t, []schema.Document{{PageContent: "doc"}}, events[0].Documents)
+		assert.Equal(t, StreamEventToken, events[1].Type)
+		assert.Equal(t, "Hello", events[1].Token)
+		assert.Equal(t, StreamEventToken, events[2].Type)
+		assert.Equal(t, " World", events[2].Token)
+		assert.Equal(t, StreamEventChainOutput, events[3].Type)
+		assert.Equal(t, "Child", events[3].Name)
+		assert.Equal(t, schema.ChainValues{"text": "Hello World"}, events[3].Outputs)
+		assert.Equal(t, StreamEventFinal, events[4].Type)
+		assert.Equal(t, schema.ChainValues{"answer": "Hello World"}, events[4].Outputs)
+	})
+
+	t.Run("Agent", func(t *testing.T) {
+		action := &schema.AgentAction{Tool: "Search", Log: "I should search"}
+
+		chain := mockChain{
+			CallFunc: func(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+				opts := schema.CallOptions{}
+				for _, fn := range optFns {
+					fn(&opts)
+				}
+
+				if err := opts.CallbackManger.OnAgentAction(ctx, &schema.AgentActionManagerInput{Action: action}); err != nil {
+					return nil, err
+				}
+
+				if err := opts.CallbackManger.OnAgentStep(ctx, &schema.AgentStepManagerInput{
+					Step: &schema.AgentStep{Action: action, Observation: "result"},
+				}); err != nil {
+					return nil, err
+				}
+
+				return schema.ChainValues{"output": "answer"}, nil
+			},
+		}
+
+		var events []StreamEvent
+		for event := range Stream(context.Background(), chain, schema.ChainValues{}) {
+			events = append(events, event)
+		}
+
+		assert.Len(t, events, 3)
+		assert.Equal(t, StreamEventAgentAction, events[0].Type)
+		assert.Equal(t, action, events[0].Action)
+		assert.Equal(t, StreamEventAgentStep, events[1].Type)
+		assert.Equal(t, "result", events[1].Step.Observation)
+		assert.Equal(t, StreamEventFinal, events[2].Type)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		chain := mockChain{
+			CallFunc: func(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+				return nil, errors.New("error occurred during chain.Call")
+			},
+		}
+
+		var events []StreamEvent
+		for event := range Stream(context.Background(), chain, schema.ChainValues{}) {
+			events = append(events, event)
+		}
+
+		assert.Len(t, events, 1)
+		assert.Equal(t, StreamEventError, events[0].Type)
+		assert.EqualError(t, events[0].Err, "error occurred during chain.Call")
+	})
+}
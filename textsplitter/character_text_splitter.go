@@ -35,6 +35,7 @@ func NewCharacterTextSplitter(optFns ...func(o *CharacterTextSplitterOptions)) *
 		o.ChunkSize = opts.ChunkSize
 		o.ChunkOverlap = opts.ChunkOverlap
 		o.KeepSeparator = opts.KeepSeparator
+		o.LengthFunc = opts.LengthFunc
 	})
 
 	return ts
@@ -0,0 +1,94 @@
+package textsplitter
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Language represents a programming language with a preset of separators for splitting source code.
+type Language string
+
+const (
+	LanguageGo         Language = "go"
+	LanguagePython     Language = "python"
+	LanguageJavaScript Language = "javascript"
+	LanguageJava       Language = "java"
+)
+
+// languageSeparators contains the separators of the languages as regular expressions. The separators are
+// ordered from declarations like functions and classes over control flow statements to blank lines and
+// words, so chunks preferably break on function and class boundaries.
+var languageSeparators = map[Language][]string{
+	LanguageGo: {
+		`\nfunc `, `\ntype `, `\nvar `, `\nconst `,
+		`\n\s*if `, `\n\s*for `, `\n\s*switch `, `\n\s*case `,
+		`\n\n`, `\n`, ` `, ``,
+	},
+	LanguagePython: {
+		`\nclass `, `\n(?:async )?def `, `\n\s+(?:async )?def `,
+		`\n\s*if `, `\n\s*for `, `\n\s*while `, `\n\s*with `, `\n\s*try:`,
+		`\n\n`, `\n`, ` `, ``,
+	},
+	LanguageJavaScript: {
+		`\n(?:export )?(?:default )?(?:async )?function `, `\n(?:export )?(?:default )?class `,
+		`\n(?:export )?const `, `\n(?:export )?let `, `\n(?:export )?var `,
+		`\n\s*if `, `\n\s*for `, `\n\s*while `, `\n\s*switch `, `\n\s*case `, `\n\s*default `,
+		`\n\n`, `\n`, ` `, ``,
+	},
+	LanguageJava: {
+		`\n(?:(?:public|protected|private|abstract|final|static) )*(?:class|interface|enum|record) `,
+		`\n\s*(?:public|protected|private|static) `,
+		`\n\s*if `, `\n\s*for `, `\n\s*while `, `\n\s*switch `, `\n\s*case `,
+		`\n\n`, `\n`, ` `, ``,
+	},
+}
+
+// languageExtensions maps file extensions to languages.
+var languageExtensions = map[string]Language{
+	".go":   LanguageGo,
+	".py":   LanguagePython,
+	".js":   LanguageJavaScript,
+	".jsx":  LanguageJavaScript,
+	".mjs":  LanguageJavaScript,
+	".cjs":  LanguageJavaScript,
+	".java": LanguageJava,
+}
+
+// SeparatorsForLanguage returns the separators of the language as regular expressions.
+func SeparatorsForLanguage(language Language) ([]string, error) {
+	separators, ok := languageSeparators[language]
+	if !ok {
+		return nil, fmt.Errorf("unsupported language: %s", language)
+	}
+
+	return append([]string{}, separators...), nil
+}
+
+// LanguageFromExtension returns the language of the file extension of the path, e.g. for the files of
+// a codebase. The boolean is false, if the extension is not supported.
+func LanguageFromExtension(path string) (Language, bool) {
+	language, ok := languageExtensions[strings.ToLower(filepath.Ext(path))]
+
+	return language, ok
+}
+
+// NewCodeTextSplitter creates a new RecursiveCharacterTextSplitter for source code of the language. The
+// text is split preferably on function and class boundaries using the separators of the language. The
+// separators are kept at the beginning of the chunks, so KeepSeparator is always enabled.
+func NewCodeTextSplitter(language Language, optFns ...func(o *RecursiveCharacterTextSplitterOptions)) (*RecursiveCharacterTextSplitter, error) {
+	separators, err := SeparatorsForLanguage(language)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRecusiveCharacterTextSplitter(func(o *RecursiveCharacterTextSplitterOptions) {
+		o.Separators = separators
+
+		for _, fn := range optFns {
+			fn(o)
+		}
+
+		o.KeepSeparator = true
+	}), nil
+}
@@ -0,0 +1,115 @@
+package textsplitter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeTextSplitter(t *testing.T) {
+	t.Run("Go", func(t *testing.T) {
+		code := `package main
+
+import "fmt"
+
+func hello() {
+	fmt.Println("Hello")
+}
+
+func world() {
+	fmt.Println("World")
+}
+
+type greeter struct {
+	name string
+}`
+
+		splitter, err := NewCodeTextSplitter(LanguageGo, func(o *RecursiveCharacterTextSplitterOptions) {
+			o.ChunkSize = 50
+			o.ChunkOverlap = 0
+		})
+		require.NoError(t, err)
+
+		chunks := splitter.splitText(code)
+		assert.Equal(t, []string{
+			"package main\n\nimport \"fmt\"",
+			"func hello() {\n\tfmt.Println(\"Hello\")\n}",
+			"func world() {\n\tfmt.Println(\"World\")\n}",
+			"type greeter struct {\n\tname string\n}",
+		}, chunks)
+	})
+
+	t.Run("Python", func(t *testing.T) {
+		code := `class Greeter:
+    def hello(self):
+        print("Hello")
+
+    def world(self):
+        print("World")
+
+def main():
+    Greeter().hello()`
+
+		splitter, err := NewCodeTextSplitter(LanguagePython, func(o *RecursiveCharacterTextSplitterOptions) {
+			o.ChunkSize = 60
+			o.ChunkOverlap = 0
+		})
+		require.NoError(t, err)
+
+		chunks := splitter.splitText(code)
+		require.Len(t, chunks, 3)
+		assert.True(t, strings.HasPrefix(chunks[0], "class Greeter:"))
+		assert.True(t, strings.HasPrefix(chunks[1], "def world(self):"))
+		assert.True(t, strings.HasPrefix(chunks[2], "def main():"))
+	})
+
+	t.Run("JavaScript", func(t *testing.T) {
+		code := "export function hello() {\n  return 'Hello';\n}\n\nexport const world = () => 'World';"
+
+		splitter, err := NewCodeTextSplitter(LanguageJavaScript, func(o *RecursiveCharacterTextSplitterOptions) {
+			o.ChunkSize = 50
+			o.ChunkOverlap = 0
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"export function hello() {\n  return 'Hello';\n}",
+			"export const world = () => 'World';",
+		}, splitter.splitText(code))
+	})
+
+	t.Run("Java", func(t *testing.T) {
+		code := "public class Greeter {\n    public void hello() {\n        System.out.println(\"Hello\");\n    }\n\n    private void world() {\n        System.out.println(\"World\");\n    }\n}"
+
+		splitter, err := NewCodeTextSplitter(LanguageJava, func(o *RecursiveCharacterTextSplitterOptions) {
+			o.ChunkSize = 80
+			o.ChunkOverlap = 0
+		})
+		require.NoError(t, err)
+
+		chunks := splitter.splitText(code)
+		require.Len(t, chunks, 3)
+		assert.True(t, strings.HasPrefix(chunks[1], "public void hello()"))
+		assert.True(t, strings.HasPrefix(chunks[2], "private void world()"))
+	})
+
+	t.Run("UnsupportedLanguage", func(t *testing.T) {
+		_, err := NewCodeTextSplitter(Language("cobol"))
+		assert.EqualError(t, err, "unsupported language: cobol")
+	})
+}
+
+func TestLanguageFromExtension(t *testing.T) {
+	language, ok := LanguageFromExtension("cmd/main.go")
+	assert.True(t, ok)
+	assert.Equal(t, LanguageGo, language)
+
+	language, ok = LanguageFromExtension("src/App.JSX")
+	assert.True(t, ok)
+	assert.Equal(t, LanguageJavaScript, language)
+
+	_, ok = LanguageFromExtension("README.md")
+	assert.False(t, ok)
+}
@@ -39,6 +39,7 @@ func NewRecusiveCharacterTextSplitter(optFns ...func(o *RecursiveCharacterTextSp
 		o.ChunkSize = opts.ChunkSize
 		o.ChunkOverlap = opts.ChunkOverlap
 		o.KeepSeparator = opts.KeepSeparator
+		o.LengthFunc = opts.LengthFunc
 	})
 
 	return ts
@@ -53,7 +54,7 @@ func (ts *RecursiveCharacterTextSplitter) splitTextBySeparators(text string, sep
 	separator := separators[len(separators)-1]
 	newSeparators := make([]string, 0)
 
-	for i, s := range ts.opts.Separators {
+	for i, s := range separators {
 		if s == "" {
 			separator = s
 			break
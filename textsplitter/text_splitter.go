@@ -108,7 +108,7 @@ func (ts *BaseTextSplitter) mergeSplits(splits []string, separator string) []str
 					}
 					return 0
 				}()) > ts.opts.ChunkSize && total > 0) {
-					total -= len(currentDoc[0]) + (separatorLen * func() int { // nolint gosec G602
+					total -= ts.opts.LengthFunc(currentDoc[0]) + (separatorLen * func() int { // nolint gosec G602
 						if len(currentDoc) > 1 {
 							return 1
 						}
@@ -155,18 +155,15 @@ func splitTextWithRegex(text string, separator string, keepSeparator bool) []str
 
 	if separator != "" {
 		if keepSeparator {
-			splits = regexp.MustCompile("("+separator+")").Split(text, -1)
-			processedSplits := make([]string, 0, len(splits))
+			// Each separator is kept as the prefix of the following split.
+			prev := 0
 
-			for i := 1; i < len(splits); i += 2 {
-				processedSplits = append(processedSplits, splits[i]+splits[i+1])
+			for _, match := range regexp.MustCompile(separator).FindAllStringIndex(text, -1) {
+				splits = append(splits, text[prev:match[0]])
+				prev = match[0]
 			}
 
-			if len(splits)%2 == 0 {
-				processedSplits = append(processedSplits, splits[len(splits)-1])
-			}
-
-			splits = append([]string{splits[0]}, processedSplits...)
+			splits = append(splits, text[prev:])
 		} else {
 			splits = strings.Split(text, separator)
 		}
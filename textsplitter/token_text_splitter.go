@@ -0,0 +1,119 @@
+package textsplitter
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure TokenTextSplitter satisfies the TextSplitter interface.
+var _ schema.TextSplitter = (*TokenTextSplitter)(nil)
+
+// TokenEncoder is a tokenizer, which converts text into token IDs and back, e.g. the tokenizers of the
+// tokenizer package.
+type TokenEncoder interface {
+	// GetTokenIDs returns the token IDs corresponding to the provided text.
+	GetTokenIDs(ctx context.Context, text string) ([]uint, error)
+	// GetTextFromTokenIDs returns the text corresponding to the provided token IDs.
+	GetTextFromTokenIDs(ctx context.Context, ids []uint) (string, error)
+}
+
+// TokenTextSplitter splits text into chunks of a number of tokens of a tokenizer, so the chunk sizes align
+// exactly with the context budgets of a model instead of approximate character counts.
+type TokenTextSplitter struct {
+	tokenizer     TokenEncoder
+	chunkTokens   int
+	overlapTokens int
+}
+
+// NewTokenSplitter creates a new TokenTextSplitter, which splits text into chunks of chunkTokens tokens.
+// Consecutive chunks share overlapTokens tokens.
+func NewTokenSplitter(tokenizer TokenEncoder, chunkTokens, overlapTokens int) (*TokenTextSplitter, error) {
+	if chunkTokens <= 0 {
+		return nil, errors.New("chunk tokens must be greater than zero")
+	}
+
+	if overlapTokens < 0 || overlapTokens >= chunkTokens {
+		return nil, errors.New("overlap tokens must be between zero and chunk tokens")
+	}
+
+	return &TokenTextSplitter{
+		tokenizer:     tokenizer,
+		chunkTokens:   chunkTokens,
+		overlapTokens: overlapTokens,
+	}, nil
+}
+
+// SplitText splits the text into chunks of tokens. Characters encoded by multiple tokens may be cut at
+// the chunk boundaries; incomplete characters are removed from the chunks.
+func (ts *TokenTextSplitter) SplitText(ctx context.Context, text string) ([]string, error) {
+	ids, err := ts.tokenizer.GetTokenIDs(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make([]string, 0)
+
+	for start := 0; start < len(ids); start += ts.chunkTokens - ts.overlapTokens {
+		end := start + ts.chunkTokens
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		chunk, err := ts.tokenizer.GetTextFromTokenIDs(ctx, ids[start:end])
+		if err != nil {
+			return nil, err
+		}
+
+		if chunk = strings.ToValidUTF8(chunk, ""); chunk != "" {
+			chunks = append(chunks, chunk)
+		}
+
+		if end == len(ids) {
+			break
+		}
+	}
+
+	return chunks, nil
+}
+
+// CreateDocuments creates documents of the chunks of the texts. The metadata of a text is copied to its chunks.
+func (ts *TokenTextSplitter) CreateDocuments(texts []string, metadatas []map[string]any) ([]schema.Document, error) {
+	docs := []schema.Document{}
+
+	for i, text := range texts {
+		chunks, err := ts.SplitText(context.Background(), text)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, chunk := range chunks {
+			docs = append(docs, schema.Document{
+				PageContent: chunk,
+				Metadata:    util.CopyMap(metadatas[i]),
+			})
+		}
+	}
+
+	return docs, nil
+}
+
+// SplitDocuments splits the documents into chunks of tokens.
+func (ts *TokenTextSplitter) SplitDocuments(docs []schema.Document) ([]schema.Document, error) {
+	texts := []string{}
+	metadatas := []map[string]any{}
+
+	for _, doc := range docs {
+		if doc.PageContent == "" {
+			continue
+		}
+
+		texts = append(texts, doc.PageContent)
+		metadatas = append(metadatas, doc.Metadata)
+	}
+
+	return ts.CreateDocuments(texts, metadatas)
+}
@@ -0,0 +1,55 @@
+package textsplitter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tokenizer"
+)
+
+func TestTokenTextSplitter(t *testing.T) {
+	gpt2, err := tokenizer.NewGPT2()
+	require.NoError(t, err)
+
+	t.Run("SplitText", func(t *testing.T) {
+		splitter, err := NewTokenSplitter(gpt2, 4, 1)
+		require.NoError(t, err)
+
+		chunks, err := splitter.SplitText(context.Background(), "This is a sample text for splitting.")
+		require.NoError(t, err)
+
+		for _, chunk := range chunks {
+			numTokens, err := gpt2.GetNumTokens(context.Background(), chunk)
+			require.NoError(t, err)
+			require.LessOrEqual(t, numTokens, uint(4))
+		}
+
+		require.Equal(t, []string{"This is a sample", " sample text for splitting", " splitting."}, chunks)
+	})
+
+	t.Run("SplitDocuments", func(t *testing.T) {
+		splitter, err := NewTokenSplitter(gpt2, 3, 0)
+		require.NoError(t, err)
+
+		docs, err := splitter.SplitDocuments([]schema.Document{
+			{PageContent: "This is a sample text.", Metadata: map[string]any{"source": "a.txt"}},
+			{PageContent: ""},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []schema.Document{
+			{PageContent: "This is a", Metadata: map[string]any{"source": "a.txt"}},
+			{PageContent: " sample text.", Metadata: map[string]any{"source": "a.txt"}},
+		}, docs)
+	})
+
+	t.Run("InvalidOverlap", func(t *testing.T) {
+		_, err := NewTokenSplitter(gpt2, 4, 4)
+		require.EqualError(t, err, "overlap tokens must be between zero and chunk tokens")
+
+		_, err = NewTokenSplitter(gpt2, 0, 0)
+		require.EqualError(t, err, "chunk tokens must be greater than zero")
+	})
+}
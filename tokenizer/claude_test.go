@@ -22,6 +22,18 @@ func TestClaude(t *testing.T) {
 		require.ElementsMatch(t, []uint{10545, 1800, 1320, 12110, 6840, 65}, ids)
 	})
 
+	// Test GetTextFromTokenIDs.
+	t.Run("GetTextFromTokenIDs", func(t *testing.T) {
+		// Test case with a sample input.
+		text := "This is a sample text."
+		ids, err := claude.GetTokenIDs(context.TODO(), text)
+		require.NoError(t, err)
+
+		decoded, err := claude.GetTextFromTokenIDs(context.TODO(), ids)
+		require.NoError(t, err)
+		require.Equal(t, text, decoded)
+	})
+
 	// Test GetNumTokens.
 	t.Run("GetNumTokens", func(t *testing.T) {
 		// Test case with a sample input.
@@ -32,6 +32,11 @@ func (t *Cohere) GetTokenIDs(ctx context.Context, text string) ([]uint, error) {
 	return int64ToUintSlice(ids), nil
 }
 
+// GetTextFromTokenIDs returns the text corresponding to the provided token IDs.
+func (t *Cohere) GetTextFromTokenIDs(ctx context.Context, ids []uint) (string, error) {
+	return t.encoder.Decode(uintToInt64Slice(ids)), nil
+}
+
 // GetNumTokens returns the number of tokens in the provided text.
 func (t *Cohere) GetNumTokens(ctx context.Context, text string) (uint, error) {
 	ids, err := t.GetTokenIDs(ctx, text)
@@ -60,3 +65,12 @@ func int64ToUintSlice(numbers []int64) []uint {
 
 	return result
 }
+
+func uintToInt64Slice(numbers []uint) []int64 {
+	result := make([]int64, len(numbers))
+	for i, num := range numbers {
+		result[i] = int64(num)
+	}
+
+	return result
+}
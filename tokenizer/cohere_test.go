@@ -23,6 +23,18 @@ func TestCohere(t *testing.T) {
 		require.ElementsMatch(t, []uint{1313, 329, 258, 7280, 2554, 47}, ids)
 	})
 
+	// Test GetTextFromTokenIDs.
+	t.Run("GetTextFromTokenIDs", func(t *testing.T) {
+		// Test case with a sample input.
+		text := "This is a sample text."
+		ids, err := cohere.GetTokenIDs(context.TODO(), text)
+		require.NoError(t, err)
+
+		decoded, err := cohere.GetTextFromTokenIDs(context.TODO(), ids)
+		require.NoError(t, err)
+		require.Equal(t, text, decoded)
+	})
+
 	// Test GetNumTokens.
 	t.Run("GetNumTokens", func(t *testing.T) {
 		// Test case with a sample input.
@@ -40,6 +40,11 @@ func (t *GPT2) GetTokenIDs(ctx context.Context, text string) ([]uint, error) {
 	return ids, nil
 }
 
+// GetTextFromTokenIDs returns the text corresponding to the provided token IDs.
+func (t *GPT2) GetTextFromTokenIDs(ctx context.Context, ids []uint) (string, error) {
+	return string(t.encoding.Decode(ids)), nil
+}
+
 // GetNumTokens returns the number of tokens in the provided text.
 func (t *GPT2) GetNumTokens(ctx context.Context, text string) (uint, error) {
 	ids, err := t.GetTokenIDs(ctx, text)
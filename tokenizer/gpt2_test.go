@@ -22,6 +22,18 @@ func TestGPT2(t *testing.T) {
 		require.ElementsMatch(t, []uint{1212, 318, 257, 6291, 2420, 13}, ids)
 	})
 
+	// Test GetTextFromTokenIDs.
+	t.Run("GetTextFromTokenIDs", func(t *testing.T) {
+		// Test case with a sample input.
+		text := "This is a sample text."
+		ids, err := gpt2.GetTokenIDs(context.TODO(), text)
+		require.NoError(t, err)
+
+		decoded, err := gpt2.GetTextFromTokenIDs(context.TODO(), ids)
+		require.NoError(t, err)
+		require.Equal(t, text, decoded)
+	})
+
 	// Test GetNumTokens.
 	t.Run("GetNumTokens", func(t *testing.T) {
 		// Test case with a sample input.
@@ -37,6 +37,16 @@ func (t *OpenAI) GetTokenIDs(ctx context.Context, text string) ([]uint, error) {
 	return ids, nil
 }
 
+// GetTextFromTokenIDs returns the text corresponding to the provided token IDs.
+func (t *OpenAI) GetTextFromTokenIDs(ctx context.Context, ids []uint) (string, error) {
+	_, e, err := t.getEncodingForModel()
+	if err != nil {
+		return "", err
+	}
+
+	return string(e.Decode(ids)), nil
+}
+
 // GetNumTokens returns the number of tokens in the provided text.
 func (t *OpenAI) GetNumTokens(ctx context.Context, text string) (uint, error) {
 	ids, err := t.GetTokenIDs(ctx, text)
@@ -22,6 +22,18 @@ func TestOpenAI(t *testing.T) {
 		require.ElementsMatch(t, []uint{2028, 374, 264, 6205, 1495, 13}, ids)
 	})
 
+	// Test GetTextFromTokenIDs.
+	t.Run("GetTextFromTokenIDs", func(t *testing.T) {
+		// Test case with a sample input.
+		text := "This is a sample text."
+		ids, err := openAI.GetTokenIDs(context.TODO(), text)
+		require.NoError(t, err)
+
+		decoded, err := openAI.GetTextFromTokenIDs(context.TODO(), ids)
+		require.NoError(t, err)
+		require.Equal(t, text, decoded)
+	})
+
 	// Test GetNumTokens.
 	t.Run("GetNumTokens", func(t *testing.T) {
 		// Test case with a sample input.
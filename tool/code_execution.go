@@ -0,0 +1,286 @@
+package tool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure CodeExecution satisfies the Tool interface.
+var _ schema.Tool = (*CodeExecution)(nil)
+
+// CodeExecutionInput is the input of the CodeExecution tool.
+type CodeExecutionInput struct {
+	Language string `json:"language" enum:"python,go" description:"The programming language of the code."`
+	Code     string `json:"code" description:"The complete program to execute. Print the results to stdout."`
+}
+
+// CodeExecutionOptions contains options for configuring the CodeExecution tool.
+type CodeExecutionOptions struct {
+	*schema.CallbackOptions
+	// Languages are the languages the agent is allowed to execute. Supported are "python" and "go".
+	Languages []string
+	// PythonCommand is the command to execute python programs.
+	PythonCommand string
+	// GoCommand is the command to build go programs.
+	GoCommand string
+	// Timeout is the maximum duration of the execution, including the build of go programs.
+	Timeout time.Duration
+	// MaxOutputBytes is the maximum number of bytes of stdout and stderr returned to the agent.
+	MaxOutputBytes int
+	// MaxMemoryBytes limits the virtual memory of python programs. Go programs, which reserve more virtual
+	// memory than they use, get a soft memory limit via GOMEMLIMIT instead. A value <= 0 disables the limit.
+	// The limit of python programs is not supported on windows.
+	MaxMemoryBytes int64
+	// MaxFileSizeBytes limits the size of files written by the program. A value <= 0 disables the limit.
+	// The limit is not supported on windows.
+	MaxFileSizeBytes int64
+	// Env is the environment of the program. The environment of the current process is not inherited,
+	// except for PATH.
+	Env []string
+}
+
+// CodeExecution is a tool that executes short python or go programs in a subprocess and returns
+// their output, e.g. for calculations or data analysis by agents.
+//
+// Each program is executed in a new temporary directory, which is removed afterwards, with a timeout
+// and resource limits. The subprocess is not isolated from the network and the file system of the
+// host, so run the tool in a container or a virtual machine, if the agent processes untrusted input.
+type CodeExecution struct {
+	opts CodeExecutionOptions
+}
+
+// NewCodeExecution creates a new instance of the CodeExecution tool.
+func NewCodeExecution(optFns ...func(o *CodeExecutionOptions)) *CodeExecution {
+	opts := CodeExecutionOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		Languages:        []string{"python", "go"},
+		PythonCommand:    "python3",
+		GoCommand:        "go",
+		Timeout:          30 * time.Second,
+		MaxOutputBytes:   10 * 1024,
+		MaxMemoryBytes:   512 * 1024 * 1024,
+		MaxFileSizeBytes: 10 * 1024 * 1024,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &CodeExecution{
+		opts: opts,
+	}
+}
+
+// Name returns the name of the tool.
+func (t *CodeExecution) Name() string {
+	return "CodeExecution"
+}
+
+// Description returns the description of the tool.
+func (t *CodeExecution) Description() string {
+	return fmt.Sprintf(`Executes a short program and returns its output.
+Useful for when you need to calculate something or to analyze data.
+Supported languages: %s. Only the standard library is available.
+The program must print the results to stdout.`, strings.Join(t.opts.Languages, ", "))
+}
+
+// ArgsType returns the type of the input argument expected by the tool.
+func (t *CodeExecution) ArgsType() reflect.Type {
+	return reflect.TypeOf(CodeExecutionInput{})
+}
+
+// Run executes the tool with the given input and returns the output. A plain string input is
+// executed as python program. Errors of the program are returned as output, so that the agent can
+// correct the program.
+func (t *CodeExecution) Run(ctx context.Context, input any) (string, error) {
+	var in CodeExecutionInput
+
+	switch v := input.(type) {
+	case CodeExecutionInput:
+		in = v
+	case string:
+		in = CodeExecutionInput{Language: "python", Code: v}
+	default:
+		return "", errors.New("illegal input type")
+	}
+
+	language := strings.ToLower(strings.TrimSpace(in.Language))
+
+	if !util.Contains(t.opts.Languages, language) {
+		return fmt.Sprintf("Error: unsupported language %q, use one of %s", in.Language, strings.Join(t.opts.Languages, ", ")), nil
+	}
+
+	dir, err := os.MkdirTemp("", "golc-code-*")
+	if err != nil {
+		return "", err
+	}
+
+	defer os.RemoveAll(dir)
+
+	ctx, cancel := context.WithTimeout(ctx, t.opts.Timeout)
+	defer cancel()
+
+	var output string
+
+	switch language {
+	case "python":
+		output, err = t.runPython(ctx, dir, in.Code)
+	case "go":
+		output, err = t.runGo(ctx, dir, in.Code)
+	}
+
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Sprintf("Error: execution timed out after %s\n%s", t.opts.Timeout, output), nil
+		}
+
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		return fmt.Sprintf("Error: %s\n%s", err, output), nil
+	}
+
+	return output, nil
+}
+
+// Verbose returns the verbosity setting of the tool.
+func (t *CodeExecution) Verbose() bool {
+	return t.opts.Verbose
+}
+
+// Callbacks returns the registered callbacks of the tool.
+func (t *CodeExecution) Callbacks() []schema.Callback {
+	return t.opts.Callbacks
+}
+
+// runPython executes the python program in isolated mode.
+func (t *CodeExecution) runPython(ctx context.Context, dir, code string) (string, error) {
+	if err := os.WriteFile(filepath.Join(dir, "main.py"), []byte(code), 0600); err != nil {
+		return "", err
+	}
+
+	return t.run(ctx, dir, t.limitScript(true), nil, t.opts.PythonCommand, "-I", "main.py")
+}
+
+// runGo builds the go program without resource limits and executes the binary with limits.
+func (t *CodeExecution) runGo(ctx context.Context, dir, code string) (string, error) {
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(code), 0600); err != nil {
+		return "", err
+	}
+
+	binary := "main"
+	if runtime.GOOS == "windows" {
+		binary = "main.exe"
+	}
+
+	if output, err := t.run(ctx, dir, "", nil, t.opts.GoCommand, "build", "-o", binary, "main.go"); err != nil {
+		return output, fmt.Errorf("build failed: %w", err)
+	}
+
+	var env []string
+	if t.opts.MaxMemoryBytes > 0 {
+		env = append(env, fmt.Sprintf("GOMEMLIMIT=%d", t.opts.MaxMemoryBytes))
+	}
+
+	return t.run(ctx, dir, t.limitScript(false), env, filepath.Join(dir, binary))
+}
+
+// run runs the command in the directory and returns the combined and truncated stdout and stderr.
+// The command is executed by the limit script, if it is not empty.
+func (t *CodeExecution) run(ctx context.Context, dir, limitScript string, env []string, name string, args ...string) (string, error) {
+	if limitScript != "" && runtime.GOOS != "windows" {
+		args = append([]string{"-c", limitScript, "sh", name}, args...)
+		name = "/bin/sh"
+	}
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	cmd.Env = append(t.env(dir), env...)
+	cmd.WaitDelay = time.Second
+
+	output := &limitedBuffer{max: t.opts.MaxOutputBytes}
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	err := cmd.Run()
+
+	return output.String(), err
+}
+
+// limitScript returns the shell script that sets the resource limits and executes its arguments.
+func (t *CodeExecution) limitScript(limitMemory bool) string {
+	script := ""
+
+	if limitMemory && t.opts.MaxMemoryBytes > 0 {
+		script += fmt.Sprintf("ulimit -v %d && ", t.opts.MaxMemoryBytes/1024)
+	}
+
+	if t.opts.MaxFileSizeBytes > 0 {
+		// The file size limit of POSIX shells is counted in blocks of 512 bytes.
+		script += fmt.Sprintf("ulimit -f %d && ", (t.opts.MaxFileSizeBytes+511)/512)
+	}
+
+	return script + `exec "$@"`
+}
+
+// env returns the environment of the subprocess.
+func (t *CodeExecution) env(dir string) []string {
+	env := []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + dir,
+		"TMPDIR=" + dir,
+	}
+
+	if gocache := os.Getenv("GOCACHE"); gocache != "" {
+		env = append(env, "GOCACHE="+gocache)
+	} else if cacheDir, err := os.UserCacheDir(); err == nil {
+		// Reuse the build cache, because building the standard library takes long.
+		env = append(env, "GOCACHE="+filepath.Join(cacheDir, "go-build"))
+	}
+
+	return append(env, t.opts.Env...)
+}
+
+// limitedBuffer is a buffer that discards the writes exceeding max bytes.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	max       int
+	truncated bool
+}
+
+// Write writes p to the buffer until it is full. It never fails, so that the program is not interrupted.
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.max - b.buf.Len(); b.max > 0 && len(p) > remaining {
+		b.buf.Write(p[:util.Max(remaining, 0)])
+		b.truncated = true
+
+		return len(p), nil
+	}
+
+	return b.buf.Write(p)
+}
+
+// String returns the content of the buffer.
+func (b *limitedBuffer) String() string {
+	if b.truncated {
+		return b.buf.String() + fmt.Sprintf("\n[Output truncated after %d bytes]", b.max)
+	}
+
+	return b.buf.String()
+}
@@ -0,0 +1,116 @@
+package tool
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeExecution(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Python", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := exec.LookPath("python3"); err != nil {
+			t.Skip("python3 not installed")
+		}
+
+		output, err := NewCodeExecution().Run(context.Background(), CodeExecutionInput{
+			Language: "python",
+			Code:     "print(sum(range(10)))",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "45\n", output)
+	})
+
+	t.Run("PythonError", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := exec.LookPath("python3"); err != nil {
+			t.Skip("python3 not installed")
+		}
+
+		output, err := NewCodeExecution().Run(context.Background(), "raise ValueError('invalid value')")
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(output, "Error: exit status 1\n"), output)
+		assert.Contains(t, output, "ValueError: invalid value")
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := exec.LookPath("python3"); err != nil {
+			t.Skip("python3 not installed")
+		}
+
+		output, err := NewCodeExecution(func(o *CodeExecutionOptions) {
+			o.Timeout = 500 * time.Millisecond
+		}).Run(context.Background(), "import time\nprint('started', flush=True)\ntime.sleep(10)")
+		assert.NoError(t, err)
+		assert.Equal(t, "Error: execution timed out after 500ms\nstarted\n", output)
+	})
+
+	t.Run("MaxOutputBytes", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := exec.LookPath("python3"); err != nil {
+			t.Skip("python3 not installed")
+		}
+
+		output, err := NewCodeExecution(func(o *CodeExecutionOptions) {
+			o.MaxOutputBytes = 5
+		}).Run(context.Background(), "print('a' * 100)")
+		assert.NoError(t, err)
+		assert.Equal(t, "aaaaa\n[Output truncated after 5 bytes]", output)
+	})
+
+	t.Run("Go", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := exec.LookPath("go"); err != nil {
+			t.Skip("go not installed")
+		}
+
+		output, err := NewCodeExecution(func(o *CodeExecutionOptions) {
+			o.Timeout = time.Minute
+		}).Run(context.Background(), CodeExecutionInput{
+			Language: "go",
+			Code:     "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(6 * 7)\n}\n",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "42\n", output)
+	})
+
+	t.Run("GoBuildError", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := exec.LookPath("go"); err != nil {
+			t.Skip("go not installed")
+		}
+
+		output, err := NewCodeExecution(func(o *CodeExecutionOptions) {
+			o.Timeout = time.Minute
+		}).Run(context.Background(), CodeExecutionInput{
+			Language: "go",
+			Code:     "package main\n\nfunc main() {\n\tundefined()\n}\n",
+		})
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(output, "Error: build failed: exit status 1\n"), output)
+		assert.Contains(t, output, "undefined: undefined")
+	})
+
+	t.Run("UnsupportedLanguage", func(t *testing.T) {
+		t.Parallel()
+
+		output, err := NewCodeExecution(func(o *CodeExecutionOptions) {
+			o.Languages = []string{"python"}
+		}).Run(context.Background(), CodeExecutionInput{Language: "go", Code: "package main"})
+		assert.NoError(t, err)
+		assert.Equal(t, `Error: unsupported language "go", use one of python`, output)
+	})
+}
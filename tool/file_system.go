@@ -0,0 +1,410 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/schema"
+)
+
+// ErrPathNotAllowed is returned, if a path points outside of the root directory of a file system tool.
+var ErrPathNotAllowed = errors.New("path not allowed")
+
+// Compile time check to ensure ReadFile satisfies the Tool interface.
+var _ schema.Tool = (*ReadFile)(nil)
+
+// ReadFileOptions contains options for configuring the ReadFile tool.
+type ReadFileOptions struct {
+	*schema.CallbackOptions
+	// MaxFileBytes is the maximum number of bytes read from a file. Longer files are truncated.
+	MaxFileBytes int64
+}
+
+// ReadFile is a tool that reads files within a root directory.
+type ReadFile struct {
+	root string
+	opts ReadFileOptions
+}
+
+// NewReadFile creates a new instance of the ReadFile tool, which is confined to the root directory.
+func NewReadFile(root string, optFns ...func(o *ReadFileOptions)) (*ReadFile, error) {
+	opts := ReadFileOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		MaxFileBytes: 100 * 1024,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	root, err := resolveRoot(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReadFile{
+		root: root,
+		opts: opts,
+	}, nil
+}
+
+// Name returns the name of the tool.
+func (t *ReadFile) Name() string {
+	return "ReadFile"
+}
+
+// Description returns the description of the tool.
+func (t *ReadFile) Description() string {
+	return `Reads a file and returns its content.
+Input should be the path of the file relative to the workspace, e.g. "docs/readme.md".`
+}
+
+// ArgsType returns the type of the input argument expected by the tool.
+func (t *ReadFile) ArgsType() reflect.Type {
+	return reflect.TypeOf("") // string
+}
+
+// Run executes the tool with the given input and returns the output.
+func (t *ReadFile) Run(ctx context.Context, input any) (string, error) {
+	path, ok := input.(string)
+	if !ok {
+		return "", errors.New("illegal input type")
+	}
+
+	resolved, err := resolvePath(t.root, path)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.Open(resolved)
+	if err != nil {
+		return fmt.Sprintf("Error: %s", relativeError(t.root, err)), nil
+	}
+
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	if !info.Mode().IsRegular() {
+		return fmt.Sprintf("Error: %s is not a regular file", path), nil
+	}
+
+	b, err := io.ReadAll(io.LimitReader(f, t.opts.MaxFileBytes+1))
+	if err != nil {
+		return "", err
+	}
+
+	if int64(len(b)) > t.opts.MaxFileBytes {
+		return fmt.Sprintf("%s\n\n[File truncated after %d bytes]", b[:t.opts.MaxFileBytes], t.opts.MaxFileBytes), nil
+	}
+
+	return string(b), nil
+}
+
+// Verbose returns the verbosity setting of the tool.
+func (t *ReadFile) Verbose() bool {
+	return t.opts.Verbose
+}
+
+// Callbacks returns the registered callbacks of the tool.
+func (t *ReadFile) Callbacks() []schema.Callback {
+	return t.opts.Callbacks
+}
+
+// Compile time check to ensure WriteFile satisfies the Tool interface.
+var _ schema.Tool = (*WriteFile)(nil)
+
+// WriteFileInput is the input of the WriteFile tool.
+type WriteFileInput struct {
+	Path    string `json:"path" description:"The path of the file relative to the workspace."`
+	Content string `json:"content" description:"The content of the file."`
+}
+
+// WriteFileOptions contains options for configuring the WriteFile tool.
+type WriteFileOptions struct {
+	*schema.CallbackOptions
+	// MaxFileBytes is the maximum size of a written file. Larger contents are rejected.
+	MaxFileBytes int64
+}
+
+// WriteFile is a tool that creates or overwrites files within a root directory. Missing parent
+// directories are created.
+type WriteFile struct {
+	root string
+	opts WriteFileOptions
+}
+
+// NewWriteFile creates a new instance of the WriteFile tool, which is confined to the root directory.
+func NewWriteFile(root string, optFns ...func(o *WriteFileOptions)) (*WriteFile, error) {
+	opts := WriteFileOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		MaxFileBytes: 100 * 1024,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	root, err := resolveRoot(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WriteFile{
+		root: root,
+		opts: opts,
+	}, nil
+}
+
+// Name returns the name of the tool.
+func (t *WriteFile) Name() string {
+	return "WriteFile"
+}
+
+// Description returns the description of the tool.
+func (t *WriteFile) Description() string {
+	return `Writes the content to a file. Existing files are overwritten.
+The path must be relative to the workspace, e.g. "docs/readme.md".`
+}
+
+// ArgsType returns the type of the input argument expected by the tool.
+func (t *WriteFile) ArgsType() reflect.Type {
+	return reflect.TypeOf(WriteFileInput{})
+}
+
+// Run executes the tool with the given input and returns the output.
+func (t *WriteFile) Run(ctx context.Context, input any) (string, error) {
+	in, ok := input.(WriteFileInput)
+	if !ok {
+		return "", errors.New("illegal input type")
+	}
+
+	if int64(len(in.Content)) > t.opts.MaxFileBytes {
+		return fmt.Sprintf("Error: content exceeds the maximum file size of %d bytes", t.opts.MaxFileBytes), nil
+	}
+
+	resolved, err := resolvePath(t.root, in.Path)
+	if err != nil {
+		return "", err
+	}
+
+	if resolved == t.root {
+		return "Error: no file path given", nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(resolved), 0750); err != nil {
+		return fmt.Sprintf("Error: %s", relativeError(t.root, err)), nil
+	}
+
+	if err := os.WriteFile(resolved, []byte(in.Content), 0600); err != nil {
+		return fmt.Sprintf("Error: %s", relativeError(t.root, err)), nil
+	}
+
+	return fmt.Sprintf("Wrote %d bytes to %s", len(in.Content), in.Path), nil
+}
+
+// Verbose returns the verbosity setting of the tool.
+func (t *WriteFile) Verbose() bool {
+	return t.opts.Verbose
+}
+
+// Callbacks returns the registered callbacks of the tool.
+func (t *WriteFile) Callbacks() []schema.Callback {
+	return t.opts.Callbacks
+}
+
+// Compile time check to ensure ListDirectory satisfies the Tool interface.
+var _ schema.Tool = (*ListDirectory)(nil)
+
+// ListDirectoryOptions contains options for configuring the ListDirectory tool.
+type ListDirectoryOptions struct {
+	*schema.CallbackOptions
+	// MaxEntries is the maximum number of entries returned to the agent. A value <= 0 disables the limit.
+	MaxEntries int
+}
+
+// ListDirectory is a tool that lists the entries of directories within a root directory.
+type ListDirectory struct {
+	root string
+	opts ListDirectoryOptions
+}
+
+// NewListDirectory creates a new instance of the ListDirectory tool, which is confined to the root directory.
+func NewListDirectory(root string, optFns ...func(o *ListDirectoryOptions)) (*ListDirectory, error) {
+	opts := ListDirectoryOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		MaxEntries: 200,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	root, err := resolveRoot(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListDirectory{
+		root: root,
+		opts: opts,
+	}, nil
+}
+
+// Name returns the name of the tool.
+func (t *ListDirectory) Name() string {
+	return "ListDirectory"
+}
+
+// Description returns the description of the tool.
+func (t *ListDirectory) Description() string {
+	return `Lists the files and directories of a directory. Directories end with a slash.
+Input should be the path of the directory relative to the workspace, or "." for the workspace itself.`
+}
+
+// ArgsType returns the type of the input argument expected by the tool.
+func (t *ListDirectory) ArgsType() reflect.Type {
+	return reflect.TypeOf("") // string
+}
+
+// Run executes the tool with the given input and returns the output.
+func (t *ListDirectory) Run(ctx context.Context, input any) (string, error) {
+	path, ok := input.(string)
+	if !ok {
+		return "", errors.New("illegal input type")
+	}
+
+	resolved, err := resolvePath(t.root, path)
+	if err != nil {
+		return "", err
+	}
+
+	entries, err := os.ReadDir(resolved)
+	if err != nil {
+		return fmt.Sprintf("Error: %s", relativeError(t.root, err)), nil
+	}
+
+	if len(entries) == 0 {
+		return "The directory is empty.", nil
+	}
+
+	lines := make([]string, 0, len(entries))
+
+	for i, entry := range entries {
+		if t.opts.MaxEntries > 0 && i >= t.opts.MaxEntries {
+			lines = append(lines, fmt.Sprintf("[%d more entries]", len(entries)-i))
+			break
+		}
+
+		name := entry.Name()
+		if entry.IsDir() {
+			name += "/"
+		}
+
+		lines = append(lines, name)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// Verbose returns the verbosity setting of the tool.
+func (t *ListDirectory) Verbose() bool {
+	return t.opts.Verbose
+}
+
+// Callbacks returns the registered callbacks of the tool.
+func (t *ListDirectory) Callbacks() []schema.Callback {
+	return t.opts.Callbacks
+}
+
+// resolveRoot returns the absolute path of the root directory with all symlinks resolved.
+func resolveRoot(root string) (string, error) {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return "", err
+	}
+
+	resolved, err := filepath.EvalSymlinks(abs)
+	if err != nil {
+		return "", err
+	}
+
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return "", err
+	}
+
+	if !info.IsDir() {
+		return "", fmt.Errorf("root %s is not a directory", root)
+	}
+
+	return resolved, nil
+}
+
+// resolvePath resolves the path relative to the root and returns ErrPathNotAllowed, if the path or a
+// symlink on the path points outside of the root. Absolute paths are treated as relative to the root.
+// The check does not protect against symlinks, which are changed concurrently by other processes.
+func resolvePath(root, path string) (string, error) {
+	// Cleaning the path as absolute path removes all leading "..", so the joined path is within the root.
+	joined := filepath.Join(root, filepath.Clean(string(filepath.Separator)+strings.TrimSpace(path)))
+
+	// Resolve the symlinks of the nearest existing ancestor, as files may not exist yet.
+	existing, rest := joined, ""
+
+	for {
+		resolved, err := filepath.EvalSymlinks(existing)
+		if err == nil {
+			if !isWithin(root, resolved) {
+				return "", fmt.Errorf("%w: %s", ErrPathNotAllowed, path)
+			}
+
+			if rest != "" {
+				// A dangling symlink would be followed when the file is created.
+				first := strings.SplitN(rest, string(filepath.Separator), 2)[0]
+				if _, err := os.Lstat(filepath.Join(resolved, first)); !errors.Is(err, os.ErrNotExist) {
+					return "", fmt.Errorf("%w: %s", ErrPathNotAllowed, path)
+				}
+			}
+
+			return filepath.Join(resolved, rest), nil
+		}
+
+		if !errors.Is(err, os.ErrNotExist) || existing == root {
+			return "", err
+		}
+
+		rest = filepath.Join(filepath.Base(existing), rest)
+		existing = filepath.Dir(existing)
+	}
+}
+
+// isWithin reports whether the path is the root or within the root.
+func isWithin(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// relativeError removes the root from the error message, so that the agent sees workspace paths only.
+func relativeError(root string, err error) error {
+	return errors.New(strings.ReplaceAll(err.Error(), root+string(filepath.Separator), ""))
+}
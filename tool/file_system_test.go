@@ -0,0 +1,106 @@
+package tool
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSystem(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	outside := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "docs"), 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "docs", "readme.md"), []byte("Hello"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "large.txt"), []byte(strings.Repeat("a", 100)), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(outside, "secret.txt"), []byte("secret"), 0600))
+	require.NoError(t, os.Symlink(outside, filepath.Join(root, "escape")))
+	require.NoError(t, os.Symlink(filepath.Join(outside, "new.txt"), filepath.Join(root, "dangling")))
+
+	readFile, err := NewReadFile(root, func(o *ReadFileOptions) {
+		o.MaxFileBytes = 10
+	})
+	require.NoError(t, err)
+
+	writeFile, err := NewWriteFile(root, func(o *WriteFileOptions) {
+		o.MaxFileBytes = 10
+	})
+	require.NoError(t, err)
+
+	listDirectory, err := NewListDirectory(root)
+	require.NoError(t, err)
+
+	t.Run("ReadFile", func(t *testing.T) {
+		output, err := readFile.Run(context.Background(), "docs/readme.md")
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello", output)
+
+		output, err = readFile.Run(context.Background(), "/docs/readme.md")
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello", output)
+
+		output, err = readFile.Run(context.Background(), "large.txt")
+		assert.NoError(t, err)
+		assert.Equal(t, "aaaaaaaaaa\n\n[File truncated after 10 bytes]", output)
+
+		output, err = readFile.Run(context.Background(), "missing.txt")
+		assert.NoError(t, err)
+		assert.Equal(t, "Error: open missing.txt: no such file or directory", output)
+
+		output, err = readFile.Run(context.Background(), "docs")
+		assert.NoError(t, err)
+		assert.Equal(t, "Error: docs is not a regular file", output)
+	})
+
+	t.Run("WriteFile", func(t *testing.T) {
+		output, err := writeFile.Run(context.Background(), WriteFileInput{Path: "new/dir/file.txt", Content: "content"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Wrote 7 bytes to new/dir/file.txt", output)
+
+		b, err := os.ReadFile(filepath.Join(root, "new", "dir", "file.txt"))
+		assert.NoError(t, err)
+		assert.Equal(t, "content", string(b))
+
+		output, err = writeFile.Run(context.Background(), WriteFileInput{Path: "too-large.txt", Content: strings.Repeat("a", 11)})
+		assert.NoError(t, err)
+		assert.Equal(t, "Error: content exceeds the maximum file size of 10 bytes", output)
+
+		_, err = writeFile.Run(context.Background(), WriteFileInput{Path: "dangling", Content: "content"})
+		assert.ErrorIs(t, err, ErrPathNotAllowed)
+
+		_, err = os.Stat(filepath.Join(outside, "new.txt"))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("ListDirectory", func(t *testing.T) {
+		output, err := listDirectory.Run(context.Background(), "docs")
+		assert.NoError(t, err)
+		assert.Equal(t, "readme.md", output)
+
+		output, err = listDirectory.Run(context.Background(), ".")
+		assert.NoError(t, err)
+		assert.Contains(t, output, "docs/\n")
+	})
+
+	t.Run("PathNotAllowed", func(t *testing.T) {
+		output, err := readFile.Run(context.Background(), "../../etc/passwd")
+		assert.NoError(t, err)
+		assert.Contains(t, output, "Error: open etc/passwd")
+
+		_, err = readFile.Run(context.Background(), "escape/secret.txt")
+		assert.ErrorIs(t, err, ErrPathNotAllowed)
+
+		_, err = listDirectory.Run(context.Background(), "escape")
+		assert.ErrorIs(t, err, ErrPathNotAllowed)
+
+		_, err = writeFile.Run(context.Background(), WriteFileInput{Path: "escape/new.txt", Content: "content"})
+		assert.ErrorIs(t, err, ErrPathNotAllowed)
+	})
+}
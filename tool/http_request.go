@@ -0,0 +1,366 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/integration"
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure HTTPRequest satisfies the Tool interface.
+var _ schema.Tool = (*HTTPRequest)(nil)
+
+// ErrHostNotAllowed is returned, if the host of the requested url is not in the allowed hosts.
+var ErrHostNotAllowed = errors.New("host not allowed")
+
+const redacted = "[REDACTED]"
+
+// HTTPRequestInput is the input of the HTTPRequest tool.
+type HTTPRequestInput struct {
+	Method string `json:"method" enum:"GET,POST" description:"The HTTP method of the request."`
+	URL    string `json:"url" description:"The url of the request."`
+	Body   string `json:"body,omitempty" description:"The body of a POST request, e.g. a JSON object."`
+}
+
+// HTTPRequestOptions contains options for configuring the HTTPRequest tool.
+type HTTPRequestOptions struct {
+	*schema.CallbackOptions
+	// HTTPClient is the HTTP client to use for the requests. If nil, a client that verifies the hosts of
+	// redirects is used. Custom clients must verify redirects themselves.
+	HTTPClient integration.HTTPClient
+	// AllowedHosts are the hosts the tool is allowed to request. A leading "*." allows all subdomains,
+	// e.g. "*.example.com", and "*" allows all hosts. If empty, all requests are denied.
+	AllowedHosts []string
+	// AllowedMethods are the HTTP methods the tool is allowed to use.
+	AllowedMethods []string
+	// Headers are sent with every request to the allowed hosts, but not with redirects to other hosts.
+	Headers map[string]string
+	// HostHeaders are the headers sent only to the hosts matching their host pattern, e.g. to authenticate
+	// the requests to one API. The patterns use the syntax of AllowedHosts.
+	HostHeaders map[string]map[string]string
+	// RedactHeaders are the names of the headers, whose values are never returned to the model. The
+	// values of configured headers with these names are also redacted in the response bodies.
+	RedactHeaders []string
+	// IncludeResponseHeaders adds the response headers to the output of the tool.
+	IncludeResponseHeaders bool
+	// MaxResponseBytes is the maximum number of bytes read from the response body. Longer bodies are truncated.
+	MaxResponseBytes int64
+	// ConvertHTML converts HTML responses to plain text.
+	ConvertHTML bool
+	// Timeout is the timeout of the requests, if the default HTTP client is used.
+	Timeout time.Duration
+}
+
+// HTTPRequest is a tool that performs GET and POST requests to allowlisted hosts, e.g. to fetch live data.
+type HTTPRequest struct {
+	client integration.HTTPClient
+	opts   HTTPRequestOptions
+}
+
+// NewHTTPRequest creates a new instance of the HTTPRequest tool.
+func NewHTTPRequest(optFns ...func(o *HTTPRequestOptions)) *HTTPRequest {
+	opts := HTTPRequestOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		AllowedMethods:   []string{http.MethodGet, http.MethodPost},
+		RedactHeaders:    []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"},
+		MaxResponseBytes: 100 * 1024,
+		ConvertHTML:      true,
+		Timeout:          30 * time.Second,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	t := &HTTPRequest{
+		client: opts.HTTPClient,
+		opts:   opts,
+	}
+
+	if t.client == nil {
+		t.client = &http.Client{
+			Timeout: opts.Timeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= 10 {
+					return errors.New("stopped after 10 redirects")
+				}
+
+				if err := t.verifyURL(req.URL); err != nil {
+					return err
+				}
+
+				// The headers of the original request are copied to the redirect, so the configured
+				// headers are replaced with the headers of the redirect host.
+				if !strings.EqualFold(req.URL.Host, via[0].URL.Host) {
+					t.removeHeaders(req.Header)
+					t.setHeaders(req.Header, req.URL, false)
+				}
+
+				return nil
+			},
+		}
+	}
+
+	return t
+}
+
+// Name returns the name of the tool.
+func (t *HTTPRequest) Name() string {
+	return "HTTPRequest"
+}
+
+// Description returns the description of the tool.
+func (t *HTTPRequest) Description() string {
+	return fmt.Sprintf(`Performs an HTTP request and returns the status and the body of the response.
+Useful for when you need to fetch live data from a website or an API.
+Allowed methods: %s. Allowed hosts: %s.`, strings.Join(t.opts.AllowedMethods, ", "), strings.Join(t.opts.AllowedHosts, ", "))
+}
+
+// ArgsType returns the type of the input argument expected by the tool.
+func (t *HTTPRequest) ArgsType() reflect.Type {
+	return reflect.TypeOf(HTTPRequestInput{})
+}
+
+// Run executes the tool with the given input and returns the output. A plain string input is
+// requested as GET request.
+func (t *HTTPRequest) Run(ctx context.Context, input any) (string, error) {
+	var req HTTPRequestInput
+
+	switch v := input.(type) {
+	case HTTPRequestInput:
+		req = v
+	case string:
+		req = HTTPRequestInput{Method: http.MethodGet, URL: strings.TrimSpace(v)}
+	default:
+		return "", errors.New("illegal input type")
+	}
+
+	method := strings.ToUpper(req.Method)
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	if !util.Contains(t.opts.AllowedMethods, method) {
+		return "", fmt.Errorf("method %s not allowed", method)
+	}
+
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return "", err
+	}
+
+	if err := t.verifyURL(u); err != nil {
+		return "", err
+	}
+
+	var body io.Reader
+	if req.Body != "" && method != http.MethodGet {
+		body = strings.NewReader(req.Body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return "", err
+	}
+
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	t.setHeaders(httpReq.Header, u, true)
+
+	res, err := t.client.Do(httpReq)
+	if err != nil {
+		return "", t.redactError(err)
+	}
+
+	defer res.Body.Close()
+
+	b, err := io.ReadAll(io.LimitReader(res.Body, t.opts.MaxResponseBytes+1))
+	if err != nil {
+		return "", t.redactError(err)
+	}
+
+	truncated := int64(len(b)) > t.opts.MaxResponseBytes
+	if truncated {
+		b = b[:t.opts.MaxResponseBytes]
+	}
+
+	content := string(b)
+
+	if t.opts.ConvertHTML && isHTML(res.Header.Get("Content-Type")) {
+		text, err := util.ParseHTMLAndGetStrippedStrings(content)
+		if err != nil {
+			return "", err
+		}
+
+		content = text
+	}
+
+	var output strings.Builder
+
+	fmt.Fprintf(&output, "Status: %s\n", res.Status)
+
+	if t.opts.IncludeResponseHeaders {
+		output.WriteString("Headers:\n")
+
+		keys := util.Keys(res.Header)
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			value := strings.Join(res.Header.Values(k), ", ")
+			if t.isRedacted(k) {
+				value = redacted
+			}
+
+			fmt.Fprintf(&output, "%s: %s\n", k, value)
+		}
+	}
+
+	fmt.Fprintf(&output, "\n%s", content)
+
+	if truncated {
+		fmt.Fprintf(&output, "\n\n[Response truncated after %d bytes]", t.opts.MaxResponseBytes)
+	}
+
+	return t.redact(output.String()), nil
+}
+
+// Verbose returns the verbosity setting of the tool.
+func (t *HTTPRequest) Verbose() bool {
+	return t.opts.Verbose
+}
+
+// Callbacks returns the registered callbacks of the tool.
+func (t *HTTPRequest) Callbacks() []schema.Callback {
+	return t.opts.Callbacks
+}
+
+// verifyURL verifies that the url uses http or https and that its host is allowed.
+func (t *HTTPRequest) verifyURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported url scheme %q", u.Scheme)
+	}
+
+	host := strings.ToLower(u.Hostname())
+
+	for _, allowed := range t.opts.AllowedHosts {
+		if matchHost(allowed, host) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrHostNotAllowed, host)
+}
+
+// setHeaders sets the headers of the host of the url and, for original requests, the common headers.
+func (t *HTTPRequest) setHeaders(header http.Header, u *url.URL, original bool) {
+	if original {
+		for k, v := range t.opts.Headers {
+			header.Set(k, v)
+		}
+	}
+
+	host := strings.ToLower(u.Hostname())
+
+	for pattern, headers := range t.opts.HostHeaders {
+		if matchHost(pattern, host) {
+			for k, v := range headers {
+				header.Set(k, v)
+			}
+		}
+	}
+}
+
+// removeHeaders removes all configured headers.
+func (t *HTTPRequest) removeHeaders(header http.Header) {
+	for k := range t.opts.Headers {
+		header.Del(k)
+	}
+
+	for _, headers := range t.opts.HostHeaders {
+		for k := range headers {
+			header.Del(k)
+		}
+	}
+}
+
+// configuredHeaders returns the common and the host specific headers.
+func (t *HTTPRequest) configuredHeaders() []map[string]string {
+	headers := []map[string]string{t.opts.Headers}
+
+	for _, h := range t.opts.HostHeaders {
+		headers = append(headers, h)
+	}
+
+	return headers
+}
+
+// matchHost reports whether the lower case host matches the host pattern. A leading "*." matches all
+// subdomains and "*" matches all hosts.
+func matchHost(pattern, host string) bool {
+	pattern = strings.ToLower(pattern)
+
+	if pattern == "*" || pattern == host {
+		return true
+	}
+
+	return strings.HasPrefix(pattern, "*.") && strings.HasSuffix(host, pattern[1:])
+}
+
+// isRedacted reports whether the value of the header is redacted.
+func (t *HTTPRequest) isRedacted(header string) bool {
+	for _, h := range t.opts.RedactHeaders {
+		if strings.EqualFold(h, header) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redact replaces the values of the configured headers, that are redacted, in the text.
+func (t *HTTPRequest) redact(text string) string {
+	for _, headers := range t.configuredHeaders() {
+		for k, v := range headers {
+			if v != "" && t.isRedacted(k) {
+				text = strings.ReplaceAll(text, v, redacted)
+			}
+		}
+	}
+
+	return text
+}
+
+// redactError redacts the values of the configured headers in the error message.
+func (t *HTTPRequest) redactError(err error) error {
+	if msg := t.redact(err.Error()); msg != err.Error() {
+		return errors.New(msg)
+	}
+
+	return err
+}
+
+// isHTML reports whether the content type is HTML.
+func isHTML(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+}
@@ -0,0 +1,169 @@
+package tool
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPRequest(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/html":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte(`<html><body><h1>Title</h1><p>Content</p></body></html>`))
+		case "/echo":
+			body, _ := io.ReadAll(r.Body)
+
+			w.Header().Set("Content-Type", "text/plain")
+			w.Header().Set("Set-Cookie", "session=secret")
+			_, _ = w.Write([]byte(r.Method + " " + string(body) + " " + r.Header.Get("Authorization")))
+		case "/token":
+			_, _ = w.Write([]byte("token=" + r.Header.Get("X-Token")))
+		case "/large":
+			_, _ = w.Write([]byte(strings.Repeat("a", 100)))
+		case "/redirect":
+			u, _ := url.Parse(r.URL.Query().Get("to"))
+			http.Redirect(w, r, u.String(), http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+
+	newTool := func(optFns ...func(o *HTTPRequestOptions)) *HTTPRequest {
+		return NewHTTPRequest(append([]func(o *HTTPRequestOptions){func(o *HTTPRequestOptions) {
+			o.AllowedHosts = []string{serverURL.Hostname()}
+		}}, optFns...)...)
+	}
+
+	t.Run("HTML", func(t *testing.T) {
+		output, err := newTool().Run(context.Background(), server.URL+"/html")
+		assert.NoError(t, err)
+		assert.Equal(t, "Status: 200 OK\n\nTitle Content", output)
+	})
+
+	t.Run("Post", func(t *testing.T) {
+		output, err := newTool().Run(context.Background(), HTTPRequestInput{
+			Method: "post",
+			URL:    server.URL + "/echo",
+			Body:   `{"foo":"bar"}`,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "Status: 200 OK\n\nPOST {\"foo\":\"bar\"} ", output)
+	})
+
+	t.Run("Redaction", func(t *testing.T) {
+		output, err := newTool(func(o *HTTPRequestOptions) {
+			o.Headers = map[string]string{"Authorization": "Bearer token"}
+			o.IncludeResponseHeaders = true
+		}).Run(context.Background(), server.URL+"/echo")
+		assert.NoError(t, err)
+		assert.Contains(t, output, "Set-Cookie: [REDACTED]\n")
+		assert.Contains(t, output, "\n\nGET  [REDACTED]")
+		assert.NotContains(t, output, "Bearer token")
+		assert.NotContains(t, output, "session=secret")
+	})
+
+	t.Run("Truncate", func(t *testing.T) {
+		output, err := newTool(func(o *HTTPRequestOptions) {
+			o.MaxResponseBytes = 10
+		}).Run(context.Background(), server.URL+"/large")
+		assert.NoError(t, err)
+		assert.Equal(t, "Status: 200 OK\n\naaaaaaaaaa\n\n[Response truncated after 10 bytes]", output)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		output, err := newTool().Run(context.Background(), server.URL+"/unknown")
+		assert.NoError(t, err)
+		assert.Equal(t, "Status: 404 Not Found\n\n", output)
+	})
+
+	t.Run("HostNotAllowed", func(t *testing.T) {
+		_, err := newTool().Run(context.Background(), "https://example.com")
+		assert.ErrorIs(t, err, ErrHostNotAllowed)
+
+		_, err = NewHTTPRequest().Run(context.Background(), server.URL+"/html")
+		assert.ErrorIs(t, err, ErrHostNotAllowed)
+	})
+
+	t.Run("HostHeaders", func(t *testing.T) {
+		tool := newTool(func(o *HTTPRequestOptions) {
+			o.AllowedHosts = []string{serverURL.Hostname(), "localhost"}
+			o.HostHeaders = map[string]map[string]string{"localhost": {"X-Token": "secret"}}
+		})
+
+		output, err := tool.Run(context.Background(), server.URL+"/token")
+		assert.NoError(t, err)
+		assert.Equal(t, "Status: 200 OK\n\ntoken=", output)
+
+		output, err = tool.Run(context.Background(), "http://localhost:"+serverURL.Port()+"/token")
+		assert.NoError(t, err)
+		assert.Equal(t, "Status: 200 OK\n\ntoken=secret", output)
+	})
+
+	t.Run("RedirectOtherHost", func(t *testing.T) {
+		output, err := newTool(func(o *HTTPRequestOptions) {
+			o.AllowedHosts = []string{serverURL.Hostname(), "localhost"}
+			o.Headers = map[string]string{"X-Token": "secret"}
+		}).Run(context.Background(), server.URL+"/redirect?to="+url.QueryEscape("http://localhost:"+serverURL.Port()+"/token"))
+		assert.NoError(t, err)
+		assert.Equal(t, "Status: 200 OK\n\ntoken=", output)
+	})
+
+	t.Run("RedirectNotAllowed", func(t *testing.T) {
+		_, err := newTool().Run(context.Background(), server.URL+"/redirect?to="+url.QueryEscape("https://example.com"))
+		assert.ErrorIs(t, err, ErrHostNotAllowed)
+	})
+
+	t.Run("MethodNotAllowed", func(t *testing.T) {
+		_, err := newTool(func(o *HTTPRequestOptions) {
+			o.AllowedMethods = []string{http.MethodGet}
+		}).Run(context.Background(), HTTPRequestInput{Method: http.MethodPost, URL: server.URL + "/echo"})
+		assert.EqualError(t, err, "method POST not allowed")
+	})
+
+	t.Run("UnsupportedScheme", func(t *testing.T) {
+		_, err := newTool().Run(context.Background(), "file:///etc/passwd")
+		assert.EqualError(t, err, `unsupported url scheme "file"`)
+	})
+}
+
+func TestHTTPRequestVerifyURL(t *testing.T) {
+	t.Parallel()
+
+	tool := NewHTTPRequest(func(o *HTTPRequestOptions) {
+		o.AllowedHosts = []string{"api.example.com", "*.golc.dev"}
+	})
+
+	for _, tc := range []struct {
+		url     string
+		allowed bool
+	}{
+		{"https://api.example.com/v1", true},
+		{"https://API.example.com", true},
+		{"https://example.com", false},
+		{"https://docs.golc.dev", true},
+		{"https://golc.dev", false},
+		{"https://evilgolc.dev", false},
+	} {
+		u, err := url.Parse(tc.url)
+		assert.NoError(t, err)
+
+		if tc.allowed {
+			assert.NoError(t, tool.verifyURL(u), tc.url)
+		} else {
+			assert.ErrorIs(t, tool.verifyURL(u), ErrHostNotAllowed, tc.url)
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/integration/jsonschema"
+	"github.com/hupe1980/golc/integration/mcp"
+	"github.com/hupe1980/golc/schema"
+)
+
+// MCPClient is the interface of the MCP client used by the MCP tools.
+type MCPClient interface {
+	CallTool(ctx context.Context, name string, arguments map[string]any) (*mcp.CallToolResult, error)
+	ReadResource(ctx context.Context, uri string) (*mcp.ReadResourceResult, error)
+}
+
+// Compile time check to ensure MCP satisfies the ToolWithParameters interface.
+var _ schema.ToolWithParameters = (*MCP)(nil)
+
+// MCPOptions contains options for configuring the MCP tool.
+type MCPOptions struct {
+	*schema.CallbackOptions
+	// Name overrides the name of the tool, e.g. to avoid conflicts between the tools of multiple servers.
+	Name string
+}
+
+// MCP is a tool that calls a tool of an MCP server.
+type MCP struct {
+	client     MCPClient
+	tool       mcp.Tool
+	parameters schema.FunctionDefinitionParameters
+	opts       MCPOptions
+}
+
+// NewMCP creates a new instance of the MCP tool for the tool of the server.
+func NewMCP(client MCPClient, mcpTool mcp.Tool, optFns ...func(o *MCPOptions)) (*MCP, error) {
+	opts := MCPOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		Name: mcpTool.Name,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	inputSchema := jsonschema.Schema{}
+
+	if len(mcpTool.InputSchema) > 0 {
+		if err := json.Unmarshal(mcpTool.InputSchema, &inputSchema); err != nil {
+			return nil, fmt.Errorf("invalid input schema of tool %s: %w", mcpTool.Name, err)
+		}
+	}
+
+	if inputSchema.Properties == nil {
+		inputSchema.Properties = map[string]*jsonschema.Schema{}
+	}
+
+	return &MCP{
+		client: client,
+		tool:   mcpTool,
+		parameters: schema.FunctionDefinitionParameters{
+			Type:       "object",
+			Properties: inputSchema.Properties,
+			Required:   inputSchema.Required,
+		},
+		opts: opts,
+	}, nil
+}
+
+// Name returns the name of the tool.
+func (t *MCP) Name() string {
+	return t.opts.Name
+}
+
+// Description returns the description of the tool.
+func (t *MCP) Description() string {
+	return t.tool.Description
+}
+
+// ArgsType returns the type of the input argument expected by the tool.
+func (t *MCP) ArgsType() reflect.Type {
+	return reflect.TypeOf(map[string]any{})
+}
+
+// Parameters returns the JSON schema of the parameters of the tool.
+func (t *MCP) Parameters() schema.FunctionDefinitionParameters {
+	return t.parameters
+}
+
+// Run executes the tool with the given input and returns the output. A string input must be a JSON
+// object with the arguments, unless the tool has a single parameter. Errors of the tool are returned
+// as output, so that the agent can correct the arguments.
+func (t *MCP) Run(ctx context.Context, input any) (string, error) {
+	var arguments map[string]any
+
+	switch v := input.(type) {
+	case map[string]any:
+		arguments = v
+	case string:
+		args, err := t.parseArguments(v)
+		if err != nil {
+			return fmt.Sprintf("Error: %s", err), nil
+		}
+
+		arguments = args
+	default:
+		return "", errors.New("illegal input type")
+	}
+
+	result, err := t.client.CallTool(ctx, t.tool.Name, arguments)
+	if err != nil {
+		rpcErr := &mcp.RPCError{}
+		if errors.As(err, &rpcErr) {
+			return fmt.Sprintf("Error: %s", rpcErr.Message), nil
+		}
+
+		return "", err
+	}
+
+	output := formatMCPContent(result.Content)
+
+	if result.IsError {
+		return fmt.Sprintf("Error: %s", output), nil
+	}
+
+	return output, nil
+}
+
+// Verbose returns the verbosity setting of the tool.
+func (t *MCP) Verbose() bool {
+	return t.opts.Verbose
+}
+
+// Callbacks returns the registered callbacks of the tool.
+func (t *MCP) Callbacks() []schema.Callback {
+	return t.opts.Callbacks
+}
+
+// parseArguments parses a JSON object or passes the input as single argument of the tool.
+func (t *MCP) parseArguments(input string) (map[string]any, error) {
+	input = strings.TrimSpace(input)
+
+	if strings.HasPrefix(input, "{") {
+		arguments := map[string]any{}
+		if err := json.Unmarshal([]byte(input), &arguments); err != nil {
+			return nil, fmt.Errorf("invalid JSON arguments: %w", err)
+		}
+
+		return arguments, nil
+	}
+
+	if len(t.parameters.Properties) == 1 {
+		for name := range t.parameters.Properties {
+			return map[string]any{name: input}, nil
+		}
+	}
+
+	if input == "" && len(t.parameters.Required) == 0 {
+		return map[string]any{}, nil
+	}
+
+	return nil, errors.New("input must be a JSON object with the arguments of the tool")
+}
+
+// Compile time check to ensure MCPReadResource satisfies the Tool interface.
+var _ schema.Tool = (*MCPReadResource)(nil)
+
+// MCPReadResourceOptions contains options for configuring the MCPReadResource tool.
+type MCPReadResourceOptions struct {
+	*schema.CallbackOptions
+	// Name is the name of the tool.
+	Name string
+}
+
+// MCPReadResource is a tool that reads the resources of an MCP server. The available resources are
+// listed in the description of the tool.
+type MCPReadResource struct {
+	client    MCPClient
+	resources []mcp.Resource
+	opts      MCPReadResourceOptions
+}
+
+// NewMCPReadResource creates a new instance of the MCPReadResource tool for the resources of the server.
+func NewMCPReadResource(client MCPClient, resources []mcp.Resource, optFns ...func(o *MCPReadResourceOptions)) *MCPReadResource {
+	opts := MCPReadResourceOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		Name: "MCPReadResource",
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &MCPReadResource{
+		client:    client,
+		resources: resources,
+		opts:      opts,
+	}
+}
+
+// Name returns the name of the tool.
+func (t *MCPReadResource) Name() string {
+	return t.opts.Name
+}
+
+// Description returns the description of the tool.
+func (t *MCPReadResource) Description() string {
+	var sb strings.Builder
+
+	sb.WriteString("Reads a resource and returns its content.\nInput should be the uri of one of the following resources:\n")
+
+	for _, r := range t.resources {
+		fmt.Fprintf(&sb, "- %s (%s)", r.URI, r.Name)
+
+		if r.Description != "" {
+			fmt.Fprintf(&sb, ": %s", r.Description)
+		}
+
+		sb.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// ArgsType returns the type of the input argument expected by the tool.
+func (t *MCPReadResource) ArgsType() reflect.Type {
+	return reflect.TypeOf("") // string
+}
+
+// Run executes the tool with the given input and returns the output.
+func (t *MCPReadResource) Run(ctx context.Context, input any) (string, error) {
+	uri, ok := input.(string)
+	if !ok {
+		return "", errors.New("illegal input type")
+	}
+
+	result, err := t.client.ReadResource(ctx, strings.TrimSpace(uri))
+	if err != nil {
+		rpcErr := &mcp.RPCError{}
+		if errors.As(err, &rpcErr) {
+			return fmt.Sprintf("Error: %s", rpcErr.Message), nil
+		}
+
+		return "", err
+	}
+
+	contents := make([]string, 0, len(result.Contents))
+	for _, c := range result.Contents {
+		contents = append(contents, formatMCPResourceContents(&c))
+	}
+
+	return strings.Join(contents, "\n\n"), nil
+}
+
+// Verbose returns the verbosity setting of the tool.
+func (t *MCPReadResource) Verbose() bool {
+	return t.opts.Verbose
+}
+
+// Callbacks returns the registered callbacks of the tool.
+func (t *MCPReadResource) Callbacks() []schema.Callback {
+	return t.opts.Callbacks
+}
+
+// formatMCPContent formats the content of a tool result as text. Binary content is replaced by a placeholder.
+func formatMCPContent(content []mcp.Content) string {
+	parts := make([]string, 0, len(content))
+
+	for _, c := range content {
+		switch {
+		case c.Type == "text":
+			parts = append(parts, c.Text)
+		case c.Type == "resource" && c.Resource != nil:
+			parts = append(parts, formatMCPResourceContents(c.Resource))
+		default:
+			parts = append(parts, fmt.Sprintf("[%s content: %s]", c.Type, c.MimeType))
+		}
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// formatMCPResourceContents formats the contents of a resource as text. Binary contents are replaced by a placeholder.
+func formatMCPResourceContents(c *mcp.ResourceContents) string {
+	if c.Blob != "" {
+		return fmt.Sprintf("[binary content: %s]", c.MimeType)
+	}
+
+	return c.Text
+}
@@ -0,0 +1,133 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hupe1980/golc/integration/jsonschema"
+	"github.com/hupe1980/golc/integration/mcp"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockMCPClient struct {
+	calledName      string
+	calledArguments map[string]any
+	callResult      *mcp.CallToolResult
+	readResult      *mcp.ReadResourceResult
+	err             error
+}
+
+func (m *mockMCPClient) CallTool(ctx context.Context, name string, arguments map[string]any) (*mcp.CallToolResult, error) {
+	m.calledName = name
+	m.calledArguments = arguments
+
+	return m.callResult, m.err
+}
+
+func (m *mockMCPClient) ReadResource(ctx context.Context, uri string) (*mcp.ReadResourceResult, error) {
+	return m.readResult, m.err
+}
+
+func TestMCP(t *testing.T) {
+	t.Parallel()
+
+	searchTool := mcp.Tool{
+		Name:        "search",
+		Description: "Searches the issues.",
+		InputSchema: json.RawMessage(`{"type":"object","properties":{"query":{"type":"string","description":"The query."}},"required":["query"]}`),
+	}
+
+	t.Run("Parameters", func(t *testing.T) {
+		mcpTool, err := NewMCP(&mockMCPClient{}, searchTool, func(o *MCPOptions) {
+			o.Name = "github_search"
+		})
+		require.NoError(t, err)
+
+		f, err := ToFunction(mcpTool)
+		require.NoError(t, err)
+		assert.Equal(t, &schema.FunctionDefinition{
+			Name:        "github_search",
+			Description: "Searches the issues.",
+			Parameters: schema.FunctionDefinitionParameters{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"query": {Type: "string", Description: "The query."},
+				},
+				Required: []string{"query"},
+			},
+		}, f)
+	})
+
+	t.Run("StructuredInput", func(t *testing.T) {
+		client := &mockMCPClient{callResult: &mcp.CallToolResult{Content: []mcp.Content{
+			{Type: "text", Text: "Issue 1"},
+			{Type: "image", MimeType: "image/png", Data: "iVBORw0KGgo="},
+		}}}
+
+		mcpTool, err := NewMCP(client, searchTool, func(o *MCPOptions) {
+			o.Name = "github_search"
+		})
+		require.NoError(t, err)
+
+		output, err := Run(context.Background(), mcpTool, schema.NewToolInputFromArguments(`{"query":"bug"}`))
+		require.NoError(t, err)
+		assert.Equal(t, "Issue 1\n[image content: image/png]", output)
+		assert.Equal(t, "search", client.calledName)
+		assert.Equal(t, map[string]any{"query": "bug"}, client.calledArguments)
+	})
+
+	t.Run("StringInput", func(t *testing.T) {
+		client := &mockMCPClient{callResult: &mcp.CallToolResult{Content: []mcp.Content{{Type: "text", Text: "Issue 1"}}}}
+
+		mcpTool, err := NewMCP(client, searchTool)
+		require.NoError(t, err)
+
+		_, err = mcpTool.Run(context.Background(), "bug")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"query": "bug"}, client.calledArguments)
+
+		_, err = mcpTool.Run(context.Background(), `{"query": "feature"}`)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"query": "feature"}, client.calledArguments)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		mcpTool, err := NewMCP(&mockMCPClient{
+			callResult: &mcp.CallToolResult{Content: []mcp.Content{{Type: "text", Text: "rate limited"}}, IsError: true},
+		}, searchTool)
+		require.NoError(t, err)
+
+		output, err := mcpTool.Run(context.Background(), "bug")
+		require.NoError(t, err)
+		assert.Equal(t, "Error: rate limited", output)
+
+		mcpTool, err = NewMCP(&mockMCPClient{err: &mcp.RPCError{Code: -32602, Message: "invalid arguments"}}, searchTool)
+		require.NoError(t, err)
+
+		output, err = mcpTool.Run(context.Background(), "bug")
+		require.NoError(t, err)
+		assert.Equal(t, "Error: invalid arguments", output)
+
+		mcpTool, err = NewMCP(&mockMCPClient{err: mcp.ErrClosed}, searchTool)
+		require.NoError(t, err)
+
+		_, err = mcpTool.Run(context.Background(), "bug")
+		assert.ErrorIs(t, err, mcp.ErrClosed)
+	})
+
+	t.Run("ReadResource", func(t *testing.T) {
+		readResource := NewMCPReadResource(&mockMCPClient{readResult: &mcp.ReadResourceResult{Contents: []mcp.ResourceContents{
+			{URI: "file:///readme.md", Text: "Hello"},
+			{URI: "file:///logo.png", MimeType: "image/png", Blob: "iVBORw0KGgo="},
+		}}}, []mcp.Resource{{URI: "file:///readme.md", Name: "readme", Description: "The readme."}})
+
+		assert.Equal(t, "Reads a resource and returns its content.\nInput should be the uri of one of the following resources:\n- file:///readme.md (readme): The readme.", readResource.Description())
+
+		output, err := readResource.Run(context.Background(), "file:///readme.md")
+		require.NoError(t, err)
+		assert.Equal(t, "Hello\n\n[binary content: image/png]", output)
+	})
+}
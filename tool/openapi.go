@@ -0,0 +1,351 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/integration"
+	"github.com/hupe1980/golc/integration/jsonschema"
+	"github.com/hupe1980/golc/integration/openapi"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure OpenAPI satisfies the ToolWithParameters interface.
+var _ schema.ToolWithParameters = (*OpenAPI)(nil)
+
+// openAPIBodyParameter is the name of the parameter, which contains the request body.
+const openAPIBodyParameter = "body"
+
+// invalidToolNameChars matches the characters, which are not allowed in the names of tool calling models.
+var invalidToolNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// OpenAPIOptions contains options for configuring the OpenAPI tool.
+type OpenAPIOptions struct {
+	*schema.CallbackOptions
+	// HTTPClient is the HTTP client to use for the requests. The default client removes the credentials on
+	// redirects to another host. A custom client must enforce its own redirect policy.
+	HTTPClient integration.HTTPClient
+	// BaseURL is the base URL of the API requests. Defaults to the first server of the specification.
+	BaseURL string
+	// Headers are sent with every request.
+	Headers map[string]string
+	// Credentials are the credentials by name of the security scheme of the specification, e.g. an
+	// api key, a bearer token or "username:password" for basic authentication. The credentials are
+	// added to the requests according to the security requirements of the operation and are never
+	// returned to the model.
+	Credentials map[string]string
+	// MaxResponseBytes is the maximum number of bytes read from the response body. Longer bodies are truncated.
+	MaxResponseBytes int64
+}
+
+// OpenAPI is a tool that calls an operation of an API described by an OpenAPI specification. The
+// parameters of the operation and the request body are passed to tool calling models as typed
+// parameters. The request body is passed in the parameter "body".
+type OpenAPI struct {
+	spec       *openapi.Spec
+	op         *openapi.Operation
+	baseURL    *url.URL
+	name       string
+	parameters schema.FunctionDefinitionParameters
+	opts       OpenAPIOptions
+}
+
+// NewOpenAPI creates a new instance of the OpenAPI tool for the operation of the specification.
+func NewOpenAPI(spec *openapi.Spec, op *openapi.Operation, optFns ...func(o *OpenAPIOptions)) (*OpenAPI, error) {
+	opts := OpenAPIOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		MaxResponseBytes: 100 * 1024,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.BaseURL == "" {
+		opts.BaseURL = spec.BaseURL()
+	}
+
+	baseURL, err := url.Parse(opts.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if baseURL.Scheme == "" || baseURL.Host == "" {
+		return nil, fmt.Errorf("invalid base URL: %q", opts.BaseURL)
+	}
+
+	t := &OpenAPI{
+		spec:       spec,
+		op:         op,
+		baseURL:    baseURL,
+		name:       openAPIToolName(op),
+		parameters: openAPIParameters(op),
+		opts:       opts,
+	}
+
+	if t.opts.HTTPClient == nil {
+		t.opts.HTTPClient = &http.Client{
+			Timeout:       30 * time.Second,
+			CheckRedirect: t.checkRedirect,
+		}
+	}
+
+	return t, nil
+}
+
+// Name returns the name of the tool, which is derived from the operation id.
+func (t *OpenAPI) Name() string {
+	return t.name
+}
+
+// Description returns the description of the tool.
+func (t *OpenAPI) Description() string {
+	parts := []string{}
+
+	if t.op.Summary != "" {
+		parts = append(parts, t.op.Summary)
+	}
+
+	if t.op.Description != "" && t.op.Description != t.op.Summary {
+		parts = append(parts, t.op.Description)
+	}
+
+	parts = append(parts, fmt.Sprintf("(%s %s)", t.op.Method, t.op.Path))
+
+	return strings.Join(parts, "\n")
+}
+
+// ArgsType returns the type of the input argument expected by the tool.
+func (t *OpenAPI) ArgsType() reflect.Type {
+	return reflect.TypeOf(map[string]any{})
+}
+
+// Parameters returns the JSON schema of the parameters of the operation.
+func (t *OpenAPI) Parameters() schema.FunctionDefinitionParameters {
+	return t.parameters
+}
+
+// Run executes the tool with the given input and returns the output. A string input must be a JSON
+// object with the parameters. Invalid parameters and error responses of the API are returned as
+// output, so that the agent can correct the parameters.
+func (t *OpenAPI) Run(ctx context.Context, input any) (string, error) {
+	var arguments map[string]any
+
+	switch v := input.(type) {
+	case map[string]any:
+		arguments = v
+	case string:
+		arguments = map[string]any{}
+
+		if v = strings.TrimSpace(v); v != "" {
+			if err := json.Unmarshal([]byte(v), &arguments); err != nil {
+				return fmt.Sprintf("Error: input must be a JSON object with the parameters: %s", err), nil
+			}
+		}
+	default:
+		return "", errors.New("illegal input type")
+	}
+
+	parameters := make(map[string]any, len(arguments))
+
+	for k, v := range arguments {
+		if k != openAPIBodyParameter || !t.op.HasBody {
+			parameters[k] = v
+		}
+	}
+
+	var body any
+	if t.op.HasBody {
+		body = arguments[openAPIBodyParameter]
+	}
+
+	req, err := t.op.NewRequest(ctx, t.baseURL, parameters, body)
+	if err != nil {
+		return fmt.Sprintf("Error: %s", err), nil
+	}
+
+	for k, v := range t.opts.Headers {
+		req.Header.Set(k, v)
+	}
+
+	if err := t.authenticate(req); err != nil {
+		return "", err
+	}
+
+	res, err := t.opts.HTTPClient.Do(req)
+	if err != nil {
+		return "", t.redactError(err)
+	}
+
+	defer res.Body.Close()
+
+	b, err := io.ReadAll(io.LimitReader(res.Body, t.opts.MaxResponseBytes+1))
+	if err != nil {
+		return "", t.redactError(err)
+	}
+
+	output := fmt.Sprintf("Status: %s\n\n", res.Status)
+
+	if int64(len(b)) > t.opts.MaxResponseBytes {
+		output += fmt.Sprintf("%s\n\n[Response truncated after %d bytes]", b[:t.opts.MaxResponseBytes], t.opts.MaxResponseBytes)
+	} else {
+		output += string(b)
+	}
+
+	return t.redact(output), nil
+}
+
+// Verbose returns the verbosity setting of the tool.
+func (t *OpenAPI) Verbose() bool {
+	return t.opts.Verbose
+}
+
+// Callbacks returns the registered callbacks of the tool.
+func (t *OpenAPI) Callbacks() []schema.Callback {
+	return t.opts.Callbacks
+}
+
+// authenticate adds the credentials of the first security requirement of the operation, for which
+// all credentials are configured. If no requirement can be satisfied, the request is sent without
+// credentials.
+func (t *OpenAPI) authenticate(req *http.Request) error {
+	schemes := t.spec.SecuritySchemes()
+
+	for _, requirement := range t.op.Security {
+		satisfied := true
+
+		for name := range requirement {
+			if _, ok := t.opts.Credentials[name]; !ok || schemes[name] == nil {
+				satisfied = false
+				break
+			}
+		}
+
+		if !satisfied {
+			continue
+		}
+
+		for name := range requirement {
+			if err := schemes[name].Apply(req, t.opts.Credentials[name]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// checkRedirect removes the headers of the security schemes on redirects to another host, so the
+// credentials are only sent to the host of the base URL.
+func (t *OpenAPI) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+
+	if req.URL.Host == via[0].URL.Host {
+		return nil
+	}
+
+	for _, scheme := range t.spec.SecuritySchemes() {
+		switch {
+		case scheme.Type == "apiKey" && scheme.In == "header":
+			req.Header.Del(scheme.Name)
+		case scheme.Type == "apiKey" && scheme.In == "cookie":
+			req.Header.Del("Cookie")
+		default:
+			req.Header.Del("Authorization")
+		}
+	}
+
+	return nil
+}
+
+// redact replaces the credentials in the text.
+func (t *OpenAPI) redact(text string) string {
+	for _, v := range t.opts.Credentials {
+		if v != "" {
+			text = strings.ReplaceAll(text, v, redacted)
+		}
+	}
+
+	return text
+}
+
+// redactError replaces the credentials in the error message, e.g. of api keys in the query.
+func (t *OpenAPI) redactError(err error) error {
+	if msg := t.redact(err.Error()); msg != err.Error() {
+		return errors.New(msg)
+	}
+
+	return err
+}
+
+// openAPIToolName returns the name of the tool for the operation, which only contains characters
+// allowed by tool calling models.
+func openAPIToolName(op *openapi.Operation) string {
+	name := strings.Trim(invalidToolNameChars.ReplaceAllString(op.ID, "_"), "_")
+	if len(name) > 64 {
+		name = name[:64]
+	}
+
+	return name
+}
+
+// openAPIParameters returns the JSON schema of the parameters and the body of the operation.
+func openAPIParameters(op *openapi.Operation) schema.FunctionDefinitionParameters {
+	parameters := schema.FunctionDefinitionParameters{
+		Type:       "object",
+		Properties: map[string]*jsonschema.Schema{},
+	}
+
+	for _, p := range op.Parameters {
+		s := *p.Schema
+		if s.Description == "" {
+			s.Description = p.Description
+		}
+
+		parameters.Properties[p.Name] = &s
+
+		if p.Required {
+			parameters.Required = append(parameters.Required, p.Name)
+		}
+	}
+
+	if op.HasBody {
+		body := &jsonschema.Schema{Type: "object"}
+		if op.BodySchema != nil {
+			s := *op.BodySchema
+			body = &s
+		}
+
+		if body.Description == "" {
+			body.Description = op.BodyDescription
+		}
+
+		if body.Description == "" {
+			body.Description = "The JSON request body."
+		}
+
+		parameters.Properties[openAPIBodyParameter] = body
+
+		if op.BodyRequired {
+			parameters.Required = append(parameters.Required, openAPIBodyParameter)
+		}
+	}
+
+	return parameters
+}
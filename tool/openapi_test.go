@@ -0,0 +1,164 @@
+package tool
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hupe1980/golc/integration/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPI(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"unauthorized"}`))
+
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+
+		// The key is echoed to check that it is redacted.
+		_, _ = w.Write([]byte(r.Method + " " + r.URL.RequestURI() + " " + string(body) + " " + r.Header.Get("X-API-Key")))
+	}))
+	defer server.Close()
+
+	spec, err := openapi.Parse([]byte(`
+openapi: 3.0.0
+servers:
+  - url: https://api.example.com
+security:
+  - apiKey: []
+paths:
+  /pets/{petId}:
+    put:
+      operationId: pets.update
+      summary: Update a pet
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          description: The id of the pet
+          schema:
+            type: integer
+        - name: notify
+          in: query
+          schema:
+            type: boolean
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+components:
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      in: header
+      name: X-API-Key
+`))
+	require.NoError(t, err)
+
+	op := spec.Operations()[0]
+
+	newTool := func(optFns ...func(o *OpenAPIOptions)) *OpenAPI {
+		tool, err := NewOpenAPI(spec, op, append([]func(o *OpenAPIOptions){func(o *OpenAPIOptions) {
+			o.BaseURL = server.URL + "/v1"
+			o.Credentials = map[string]string{"apiKey": "secret"}
+		}}, optFns...)...)
+		require.NoError(t, err)
+
+		return tool
+	}
+
+	t.Run("Definition", func(t *testing.T) {
+		tool := newTool()
+
+		assert.Equal(t, "pets_update", tool.Name())
+		assert.Equal(t, "Update a pet\n(PUT /pets/{petId})", tool.Description())
+
+		parameters := tool.Parameters()
+		assert.Equal(t, []string{"petId", "body"}, parameters.Required)
+		assert.Equal(t, "integer", parameters.Properties["petId"].Type)
+		assert.Equal(t, "The id of the pet", parameters.Properties["petId"].Description)
+		assert.Equal(t, "boolean", parameters.Properties["notify"].Type)
+		assert.Equal(t, "string", parameters.Properties["body"].Properties["name"].Type)
+	})
+
+	t.Run("Run", func(t *testing.T) {
+		output, err := newTool().Run(context.Background(), map[string]any{
+			"petId":  float64(42),
+			"notify": true,
+			"body":   map[string]any{"name": "Rex"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "Status: 200 OK\n\nPUT /v1/pets/42?notify=true {\"name\":\"Rex\"} [REDACTED]", output)
+	})
+
+	t.Run("JSONInput", func(t *testing.T) {
+		output, err := newTool().Run(context.Background(), `{"petId": 1, "body": {}}`)
+		assert.NoError(t, err)
+		assert.Equal(t, "Status: 200 OK\n\nPUT /v1/pets/1 {} [REDACTED]", output)
+
+		output, err = newTool().Run(context.Background(), `petId=1`)
+		assert.NoError(t, err)
+		assert.Contains(t, output, "Error: input must be a JSON object with the parameters")
+	})
+
+	t.Run("InvalidParameters", func(t *testing.T) {
+		output, err := newTool().Run(context.Background(), map[string]any{"body": map[string]any{}})
+		assert.NoError(t, err)
+		assert.Equal(t, "Error: missing required parameter of API operation pets.update: petId", output)
+	})
+
+	t.Run("MissingCredentials", func(t *testing.T) {
+		output, err := newTool(func(o *OpenAPIOptions) {
+			o.Credentials = nil
+		}).Run(context.Background(), map[string]any{"petId": 1, "body": map[string]any{}})
+		assert.NoError(t, err)
+		assert.Equal(t, "Status: 401 Unauthorized\n\n{\"error\":\"unauthorized\"}", output)
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		output, err := newTool(func(o *OpenAPIOptions) {
+			o.MaxResponseBytes = 3
+		}).Run(context.Background(), map[string]any{"petId": 1, "body": map[string]any{}})
+		assert.NoError(t, err)
+		assert.Equal(t, "Status: 200 OK\n\nPUT\n\n[Response truncated after 3 bytes]", output)
+	})
+
+	t.Run("Redirect", func(t *testing.T) {
+		client, ok := newTool().opts.HTTPClient.(*http.Client)
+		require.True(t, ok)
+
+		via, _ := http.NewRequest(http.MethodPut, server.URL+"/v1/pets/1", nil)
+
+		req, _ := http.NewRequest(http.MethodPut, server.URL+"/v2/pets/1", nil)
+		req.Header.Set("X-API-Key", "secret")
+		assert.NoError(t, client.CheckRedirect(req, []*http.Request{via}))
+		assert.Equal(t, "secret", req.Header.Get("X-API-Key"))
+
+		req, _ = http.NewRequest(http.MethodPut, "https://evil.example.com/v1/pets/1", nil)
+		req.Header.Set("X-API-Key", "secret")
+		assert.NoError(t, client.CheckRedirect(req, []*http.Request{via}))
+		assert.Empty(t, req.Header.Get("X-API-Key"))
+	})
+
+	t.Run("InvalidBaseURL", func(t *testing.T) {
+		_, err := NewOpenAPI(spec, op, func(o *OpenAPIOptions) {
+			o.BaseURL = "/v1"
+		})
+		assert.EqualError(t, err, `invalid base URL: "/v1"`)
+	})
+}
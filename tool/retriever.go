@@ -3,6 +3,7 @@ package tool
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
 	"strings"
 
@@ -18,9 +19,17 @@ var _ schema.Tool = (*Retriever)(nil)
 type RetrieverOptions struct {
 	*schema.CallbackOptions
 	DocumentSeparator string
+	// MetadataKeys are the keys of the document metadata, e.g. "source", that are added to the
+	// page contents, so that the agent can cite the documents.
+	MetadataKeys []string
+	// NoDocumentsFound is returned, if the retriever finds no documents.
+	NoDocumentsFound string
 }
 
-// Retriever is a tool that utilizes a retriever to fetch documents based on a query.
+// Retriever is a tool that utilizes a retriever to fetch documents based on a query. The name and
+// description tell the agent which knowledge base the retriever searches, so multiple Retriever
+// tools with distinct names can be offered for multiple corpora. Tool calling models require names
+// consisting of letters, digits, underscores and dashes.
 type Retriever struct {
 	retriever   schema.Retriever
 	name        string
@@ -28,6 +37,11 @@ type Retriever struct {
 	opts        RetrieverOptions
 }
 
+// NewVectorStoreRetriever creates a new Retriever tool, that performs a similarity search on the given vector store.
+func NewVectorStoreRetriever(vectorStore schema.VectorStore, name, description string, optFns ...func(o *RetrieverOptions)) *Retriever {
+	return NewRetriever(retriever.NewVectorStore(vectorStore), name, description, optFns...)
+}
+
 // NewRetriever creates a new Retriever instance using the provided retriever, name, and description, along with optional configuration options.
 func NewRetriever(retriever schema.Retriever, name, description string, optFns ...func(o *RetrieverOptions)) *Retriever {
 	opts := RetrieverOptions{
@@ -35,6 +49,7 @@ func NewRetriever(retriever schema.Retriever, name, description string, optFns .
 			Verbose: golc.Verbose,
 		},
 		DocumentSeparator: "\n\n",
+		NoDocumentsFound:  "No relevant documents found.",
 	}
 
 	for _, fn := range optFns {
@@ -76,9 +91,13 @@ func (t *Retriever) Run(ctx context.Context, input any) (string, error) {
 		return "", err
 	}
 
+	if len(docs) == 0 {
+		return t.opts.NoDocumentsFound, nil
+	}
+
 	contents := make([]string, len(docs))
 	for i, doc := range docs {
-		contents[i] = doc.PageContent
+		contents[i] = t.formatDocument(doc)
 	}
 
 	return strings.Join(contents, t.opts.DocumentSeparator), nil
@@ -93,3 +112,18 @@ func (t *Retriever) Verbose() bool {
 func (t *Retriever) Callbacks() []schema.Callback {
 	return t.opts.Callbacks
 }
+
+// formatDocument returns the page content of the document preceded by the configured metadata.
+func (t *Retriever) formatDocument(doc schema.Document) string {
+	var sb strings.Builder
+
+	for _, key := range t.opts.MetadataKeys {
+		if value, ok := doc.Metadata[key]; ok {
+			fmt.Fprintf(&sb, "%s: %v\n", key, value)
+		}
+	}
+
+	sb.WriteString(doc.PageContent)
+
+	return sb.String()
+}
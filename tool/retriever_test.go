@@ -6,7 +6,9 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/hupe1980/golc/embedding"
 	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/vectorstore"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -30,6 +32,43 @@ func TestRetriever(t *testing.T) {
 			assert.Equal(t, "Document 1\n\nDocument 2", output)
 		})
 
+		t.Run("MetadataKeys", func(t *testing.T) {
+			mockRetriever := &mockRetriever{
+				docsResp: []schema.Document{
+					{PageContent: "Document 1", Metadata: map[string]any{"source": "doc1.md", "page": 3}},
+					{PageContent: "Document 2"},
+				},
+			}
+			retrieverTool := NewRetriever(mockRetriever, "Retriever", "A tool to retrieve documents", func(o *RetrieverOptions) {
+				o.MetadataKeys = []string{"source", "page"}
+			})
+
+			output, err := retrieverTool.Run(context.Background(), "query")
+			assert.NoError(t, err)
+			assert.Equal(t, "source: doc1.md\npage: 3\nDocument 1\n\nDocument 2", output)
+		})
+
+		t.Run("NoDocumentsFound", func(t *testing.T) {
+			retrieverTool := NewRetriever(&mockRetriever{}, "Retriever", "A tool to retrieve documents")
+
+			output, err := retrieverTool.Run(context.Background(), "query")
+			assert.NoError(t, err)
+			assert.Equal(t, "No relevant documents found.", output)
+		})
+
+		t.Run("VectorStore", func(t *testing.T) {
+			vectorStore := vectorstore.NewInMemory(embedding.NewFake(8))
+			err := vectorStore.AddDocuments(context.Background(), []schema.Document{{PageContent: "Document 1"}})
+			assert.NoError(t, err)
+
+			retrieverTool := NewVectorStoreRetriever(vectorStore, "HandbookSearch", "Searches the employee handbook")
+
+			output, err := retrieverTool.Run(context.Background(), "query")
+			assert.NoError(t, err)
+			assert.Equal(t, "Document 1", output)
+			assert.Equal(t, "HandbookSearch", retrieverTool.Name())
+		})
+
 		t.Run("Error", func(t *testing.T) {
 			mockRetriever := &mockRetriever{
 				errorResp: errors.New("retriever error"),
@@ -0,0 +1,264 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Shell satisfies the Tool interface.
+var _ schema.Tool = (*Shell)(nil)
+
+// ErrCommandNotAllowed is the error of a command that matches neither the allowed programs nor the allowed commands.
+// It is returned to the agent as observation, so that the agent can choose another command.
+var ErrCommandNotAllowed = errors.New("command not allowed")
+
+// shellMetacharacters are the characters of pipes, redirects, variables, command substitutions and
+// command chaining. Commands containing them are rejected, even if quoted.
+const shellMetacharacters = "|&;<>$`\n\r"
+
+// ShellOptions contains options for configuring the Shell tool.
+type ShellOptions struct {
+	*schema.CallbackOptions
+	// AllowedPrograms are the programs the agent is allowed to execute with any arguments, e.g. "kubectl".
+	// The program must be given exactly as in the list, so "/usr/bin/kubectl" is not allowed by "kubectl".
+	AllowedPrograms []string
+	// AllowedCommands are patterns of complete commands the agent is allowed to execute, e.g. `git (status|log)( .*)?`.
+	// The patterns are anchored, so they must match the complete command. If both allowlists are empty, all commands are denied.
+	AllowedCommands []*regexp.Regexp
+	// Dir is the working directory of the commands. If empty, the working directory of the current process is used.
+	Dir string
+	// Env is the environment of the commands. If nil, the environment of the current process is inherited.
+	Env []string
+	// Timeout is the maximum duration of a command. A value <= 0 disables the timeout.
+	Timeout time.Duration
+	// MaxOutputBytes is the maximum number of bytes of stdout and stderr returned to the agent.
+	MaxOutputBytes int
+}
+
+// Shell is a tool that executes allowlisted commands, e.g. for ops automation. The commands are not
+// executed by a shell, so pipes, redirects, variables and command chaining are not supported. Single
+// and double quotes can be used to group arguments.
+//
+// Every execution is reported to the callbacks of the tool as text event, e.g. for auditing.
+type Shell struct {
+	opts ShellOptions
+}
+
+// NewShell creates a new instance of the Shell tool.
+func NewShell(optFns ...func(o *ShellOptions)) *Shell {
+	opts := ShellOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		Timeout:        30 * time.Second,
+		MaxOutputBytes: 10 * 1024,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Shell{
+		opts: opts,
+	}
+}
+
+// Name returns the name of the tool.
+func (t *Shell) Name() string {
+	return "Shell"
+}
+
+// Description returns the description of the tool.
+func (t *Shell) Description() string {
+	allowed := append([]string{}, t.opts.AllowedPrograms...)
+	for _, re := range t.opts.AllowedCommands {
+		allowed = append(allowed, re.String())
+	}
+
+	return fmt.Sprintf(`Executes a command and returns its output.
+Pipes, redirects, variables and command chaining are not supported.
+Allowed programs and command patterns: %s.`, strings.Join(allowed, ", "))
+}
+
+// ArgsType returns the type of the input argument expected by the tool.
+func (t *Shell) ArgsType() reflect.Type {
+	return reflect.TypeOf("") // string
+}
+
+// Run executes the tool with the given input and returns the output. Failed and rejected commands are
+// returned as output, so that the agent can correct the command.
+func (t *Shell) Run(ctx context.Context, input any) (string, error) {
+	command, ok := input.(string)
+	if !ok {
+		return "", errors.New("illegal input type")
+	}
+
+	command = strings.TrimSpace(command)
+
+	args, err := splitCommand(command)
+	if err != nil {
+		return fmt.Sprintf("Error: %s", err), nil
+	}
+
+	if err := t.verifyCommand(command, args); err != nil {
+		if errors.Is(err, ErrCommandNotAllowed) {
+			return fmt.Sprintf("Error: %s", err), nil
+		}
+
+		return "", err
+	}
+
+	ctx, cancel := util.ContextWithTimeout(ctx, t.opts.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...) // nolint gosec
+	cmd.Dir = t.opts.Dir
+	cmd.Env = t.opts.Env
+	cmd.WaitDelay = time.Second
+
+	output := &limitedBuffer{max: t.opts.MaxOutputBytes}
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	start := time.Now()
+	err = cmd.Run()
+
+	if cbErr := t.reportExecution(ctx, command, cmd, time.Since(start)); cbErr != nil {
+		return "", cbErr
+	}
+
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Sprintf("Error: command timed out after %s\n%s", t.opts.Timeout, output), nil
+		}
+
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		return fmt.Sprintf("Error: %s\n%s", err, output), nil
+	}
+
+	return output.String(), nil
+}
+
+// Verbose returns the verbosity setting of the tool.
+func (t *Shell) Verbose() bool {
+	return t.opts.Verbose
+}
+
+// Callbacks returns the registered callbacks of the tool.
+func (t *Shell) Callbacks() []schema.Callback {
+	return t.opts.Callbacks
+}
+
+// verifyCommand verifies that the command contains no shell metacharacters and that the program or the
+// complete command is allowed.
+func (t *Shell) verifyCommand(command string, args []string) error {
+	if strings.ContainsAny(command, shellMetacharacters) {
+		return fmt.Errorf("%w: shell metacharacters are not supported: %s", ErrCommandNotAllowed, command)
+	}
+
+	if util.Contains(t.opts.AllowedPrograms, args[0]) {
+		return nil
+	}
+
+	for _, re := range t.opts.AllowedCommands {
+		anchored, err := regexp.Compile(`^(?:` + re.String() + `)$`)
+		if err != nil {
+			return err
+		}
+
+		if anchored.MatchString(command) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrCommandNotAllowed, command)
+}
+
+// reportExecution reports the execution of the command to the callbacks of the tool run, or of the tool, if
+// the tool is not run by tool.Run.
+func (t *Shell) reportExecution(ctx context.Context, command string, cmd *exec.Cmd, duration time.Duration) error {
+	exitCode := -1
+	if cmd.ProcessState != nil {
+		exitCode = cmd.ProcessState.ExitCode()
+	}
+
+	rm, ok := callback.ToolRunManagerFromContext(ctx)
+	if !ok {
+		rm = callback.NewManagerForToolRun(uuid.New().String(), nil, t.opts.Callbacks, t.opts.Verbose)
+	}
+
+	return rm.OnText(ctx, &schema.TextManagerInput{
+		Text: fmt.Sprintf("Executed command %q (exit code: %d, duration: %s)", command, exitCode, duration.Round(time.Millisecond)),
+	})
+}
+
+// splitCommand splits the command into arguments. Single and double quotes group arguments and a
+// backslash escapes the next character outside of single quotes.
+func splitCommand(command string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+
+	for _, r := range command {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inArg = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+
+			inArg = true
+		}
+	}
+
+	if quote != 0 || escaped {
+		return nil, errors.New("unterminated quote or escape")
+	}
+
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	if len(args) == 0 {
+		return nil, errors.New("empty command")
+	}
+
+	return args, nil
+}
@@ -0,0 +1,143 @@
+package tool
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type textCallbackHandler struct {
+	callback.NoopHandler
+	texts      []string
+	inputs     []*schema.TextInput
+	toolRunIDs []string
+}
+
+func (h *textCallbackHandler) AlwaysVerbose() bool {
+	return true
+}
+
+func (h *textCallbackHandler) OnText(ctx context.Context, input *schema.TextInput) error {
+	h.texts = append(h.texts, input.Text)
+	h.inputs = append(h.inputs, input)
+
+	return nil
+}
+
+func (h *textCallbackHandler) OnToolStart(ctx context.Context, input *schema.ToolStartInput) error {
+	h.toolRunIDs = append(h.toolRunIDs, input.RunID)
+	return nil
+}
+
+func TestShell(t *testing.T) {
+	t.Parallel()
+
+	newTool := func(optFns ...func(o *ShellOptions)) *Shell {
+		return NewShell(append([]func(o *ShellOptions){func(o *ShellOptions) {
+			o.AllowedPrograms = []string{"echo", "false", "sleep"}
+			o.AllowedCommands = []*regexp.Regexp{regexp.MustCompile(`^ls( -[a-z]+)?$`)}
+			o.Dir = t.TempDir()
+		}}, optFns...)...)
+	}
+
+	t.Run("Echo", func(t *testing.T) {
+		handler := &textCallbackHandler{}
+
+		output, err := newTool(func(o *ShellOptions) {
+			o.Callbacks = []schema.Callback{handler}
+		}).Run(context.Background(), `echo "hello  world" it\'s`)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello  world it's\n", output)
+		assert.Len(t, handler.texts, 1)
+		assert.Contains(t, handler.texts[0], `Executed command "echo \"hello  world\" it\\'s" (exit code: 0`)
+	})
+
+	t.Run("ToolRun", func(t *testing.T) {
+		handler := &textCallbackHandler{}
+
+		output, err := Run(context.Background(), newTool(), schema.NewToolInputFromString("echo hello"), func(o *Options) {
+			o.Callbacks = []schema.Callback{handler}
+			o.ParentRunID = "parent"
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "hello\n", output)
+		assert.Len(t, handler.toolRunIDs, 1)
+		assert.Len(t, handler.inputs, 1)
+		assert.Equal(t, handler.toolRunIDs[0], handler.inputs[0].RunID)
+		assert.Equal(t, "parent", handler.inputs[0].ParentRunID)
+	})
+
+	t.Run("ShellMetacharacters", func(t *testing.T) {
+		for _, command := range []string{"echo hello; rm -rf /", "echo a | cat", "echo $HOME", "echo `id`", "echo a > b", "echo a && ls", "echo a\nls"} {
+			output, err := newTool().Run(context.Background(), command)
+			assert.NoError(t, err, command)
+			assert.Equal(t, fmt.Sprintf("Error: command not allowed: shell metacharacters are not supported: %s", command), output)
+		}
+	})
+
+	t.Run("AllowedCommand", func(t *testing.T) {
+		output, err := newTool().Run(context.Background(), "ls -a")
+		assert.NoError(t, err)
+		assert.Equal(t, ".\n..\n", output)
+	})
+
+	t.Run("CommandNotAllowed", func(t *testing.T) {
+		output, err := newTool().Run(context.Background(), "ls -a /etc")
+		assert.NoError(t, err)
+		assert.Equal(t, "Error: command not allowed: ls -a /etc", output)
+
+		output, err = newTool().Run(context.Background(), "/bin/echo hello")
+		assert.NoError(t, err)
+		assert.Equal(t, "Error: command not allowed: /bin/echo hello", output)
+
+		output, err = newTool(func(o *ShellOptions) {
+			o.AllowedCommands = []*regexp.Regexp{regexp.MustCompile(`ls`)}
+		}).Run(context.Background(), "ls -a")
+		assert.NoError(t, err)
+		assert.Equal(t, "Error: command not allowed: ls -a", output, "patterns are anchored")
+
+		output, err = NewShell().Run(context.Background(), "echo hello")
+		assert.NoError(t, err)
+		assert.Equal(t, "Error: command not allowed: echo hello", output)
+	})
+
+	t.Run("ExitCode", func(t *testing.T) {
+		output, err := newTool().Run(context.Background(), "false")
+		assert.NoError(t, err)
+		assert.Equal(t, "Error: exit status 1\n", output)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		output, err := newTool(func(o *ShellOptions) {
+			o.Timeout = 100 * time.Millisecond
+		}).Run(context.Background(), "sleep 5")
+		assert.NoError(t, err)
+		assert.Equal(t, "Error: command timed out after 100ms\n", output)
+
+		output, err = newTool(func(o *ShellOptions) {
+			o.Timeout = 0
+		}).Run(context.Background(), "echo hello")
+		assert.NoError(t, err)
+		assert.Equal(t, "hello\n", output, "a timeout <= 0 disables the timeout")
+	})
+
+	t.Run("Truncate", func(t *testing.T) {
+		output, err := newTool(func(o *ShellOptions) {
+			o.MaxOutputBytes = 5
+		}).Run(context.Background(), "echo hello world")
+		assert.NoError(t, err)
+		assert.Equal(t, "hello\n[Output truncated after 5 bytes]", output)
+	})
+
+	t.Run("UnterminatedQuote", func(t *testing.T) {
+		output, err := newTool().Run(context.Background(), `echo "hello`)
+		assert.NoError(t, err)
+		assert.Equal(t, "Error: unterminated quote or escape", output)
+	})
+}
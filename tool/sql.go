@@ -0,0 +1,372 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/integration/sqldb"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure SQLQuery satisfies the Tool interface.
+var _ schema.Tool = (*SQLQuery)(nil)
+
+// SQLQueryOptions contains options for configuring the SQLQuery tool.
+type SQLQueryOptions struct {
+	*schema.CallbackOptions
+	// ReadOnly allows only SELECT queries, which are executed in a read-only transaction.
+	ReadOnly bool
+	// MaxRows is the maximum number of rows returned to the agent. A value <= 0 disables the limit.
+	MaxRows int
+	// MaxColumns is the maximum number of columns returned to the agent. A value <= 0 disables the limit.
+	MaxColumns int
+}
+
+// SQLQuery is a tool that executes SQL queries on a database. Failed queries are returned as
+// observation, so that the agent can correct the query.
+type SQLQuery struct {
+	db   *sqldb.SQLDB
+	opts SQLQueryOptions
+}
+
+// NewSQLQuery creates a new instance of the SQLQuery tool.
+func NewSQLQuery(db *sqldb.SQLDB, optFns ...func(o *SQLQueryOptions)) *SQLQuery {
+	opts := SQLQueryOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		ReadOnly:   true,
+		MaxRows:    100,
+		MaxColumns: 20,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &SQLQuery{
+		db:   db,
+		opts: opts,
+	}
+}
+
+// Name returns the name of the tool.
+func (t *SQLQuery) Name() string {
+	return "SQLQuery"
+}
+
+// Description returns the description of the tool.
+func (t *SQLQuery) Description() string {
+	return fmt.Sprintf(`Executes a %s query on the database and returns the result.
+Input should be a detailed and correct SQL query. If the query is not correct, an error message will be returned.
+If an error is returned, rewrite the query, check the query, and try again.
+Use SQLDescribeTables to look up the columns of the tables before writing a query.`, t.db.Dialect())
+}
+
+// ArgsType returns the type of the input argument expected by the tool.
+func (t *SQLQuery) ArgsType() reflect.Type {
+	return reflect.TypeOf("") // string
+}
+
+// Run executes the tool with the given input and returns the output.
+func (t *SQLQuery) Run(ctx context.Context, input any) (string, error) {
+	query, ok := input.(string)
+	if !ok {
+		return "", errors.New("illegal input type")
+	}
+
+	query = strings.TrimSuffix(sqldb.CleanQuery(query), ";")
+
+	if err := t.verifyQuery(query); err != nil {
+		return fmt.Sprintf("Error: %s", err), nil
+	}
+
+	result, err := t.db.QueryWithOptions(ctx, query, func(o *sqldb.QueryOptions) {
+		o.ReadOnly = t.opts.ReadOnly
+		o.MaxRows = t.opts.MaxRows
+		o.MaxColumns = t.opts.MaxColumns
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		return fmt.Sprintf("Error: %s", err), nil
+	}
+
+	output := result.String()
+	if result.Truncated {
+		output += "(truncated)\n"
+	}
+
+	return output, nil
+}
+
+// Verbose returns the verbosity setting of the tool.
+func (t *SQLQuery) Verbose() bool {
+	return t.opts.Verbose
+}
+
+// Callbacks returns the registered callbacks of the tool.
+func (t *SQLQuery) Callbacks() []schema.Callback {
+	return t.opts.Callbacks
+}
+
+// verifyQuery verifies that the query is a single SELECT statement, optionally preceded by a WITH clause
+// of SELECT subqueries, if the tool is read-only, and that it only accesses allowed tables.
+func (t *SQLQuery) verifyQuery(query string) error {
+	fields := strings.Fields(query)
+	if len(fields) < 2 {
+		return fmt.Errorf("invalid sql query: %s", query)
+	}
+
+	if t.opts.ReadOnly {
+		if !strings.EqualFold(fields[0], "select") && !strings.EqualFold(fields[0], "with") {
+			return fmt.Errorf("only SELECT queries are allowed: %s", query)
+		}
+
+		if strings.Contains(query, ";") {
+			return fmt.Errorf("only a single query is allowed: %s", query)
+		}
+	}
+
+	ctes, statement, recursive, err := sqldb.SplitWith(query)
+	if err != nil {
+		return err
+	}
+
+	if t.opts.ReadOnly {
+		for _, q := range append([]string{statement}, cteQueries(ctes)...) {
+			if !isSelectQuery(q) {
+				return fmt.Errorf("only SELECT queries are allowed: %s", query)
+			}
+		}
+	}
+
+	tables := []string{}
+
+	// The subquery of a common table expression can only refer to the preceding expressions, and to
+	// itself, if it is recursive. Other names refer to tables.
+	for i, cte := range ctes {
+		names := cteNames(ctes[:i])
+		if recursive {
+			names = append(names, cte.Name)
+		}
+
+		tables = append(tables, tableNames(cte.Query, names)...)
+	}
+
+	tables = append(tables, tableNames(statement, cteNames(ctes))...)
+
+	return t.db.CheckTables(tables)
+}
+
+// isSelectQuery reports whether the query is a SELECT statement.
+func isSelectQuery(query string) bool {
+	fields := strings.Fields(strings.TrimLeft(query, "( "))
+	return len(fields) > 0 && strings.EqualFold(fields[0], "select")
+}
+
+// cteQueries returns the subqueries of the common table expressions.
+func cteQueries(ctes []sqldb.CommonTableExpression) []string {
+	queries := make([]string, len(ctes))
+	for i, cte := range ctes {
+		queries[i] = cte.Query
+	}
+
+	return queries
+}
+
+// cteNames returns the names of the common table expressions.
+func cteNames(ctes []sqldb.CommonTableExpression) []string {
+	names := make([]string, len(ctes))
+	for i, cte := range ctes {
+		names[i] = cte.Name
+	}
+
+	return names
+}
+
+// tableNames returns the names of the tables accessed by the query, which are not common table expressions.
+func tableNames(query string, ctes []string) []string {
+	if !strings.Contains(query, " ") {
+		return nil
+	}
+
+	names := []string{}
+
+	for _, name := range sqldb.NewParser(query).TableNames() {
+		if !slices.ContainsFunc(ctes, func(cte string) bool { return strings.EqualFold(cte, name) }) {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// Compile time check to ensure SQLListTables satisfies the Tool interface.
+var _ schema.Tool = (*SQLListTables)(nil)
+
+// SQLListTablesOptions contains options for configuring the SQLListTables tool.
+type SQLListTablesOptions struct {
+	*schema.CallbackOptions
+}
+
+// SQLListTables is a tool that lists the tables of a database.
+type SQLListTables struct {
+	db   *sqldb.SQLDB
+	opts SQLListTablesOptions
+}
+
+// NewSQLListTables creates a new instance of the SQLListTables tool.
+func NewSQLListTables(db *sqldb.SQLDB, optFns ...func(o *SQLListTablesOptions)) *SQLListTables {
+	opts := SQLListTablesOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &SQLListTables{
+		db:   db,
+		opts: opts,
+	}
+}
+
+// Name returns the name of the tool.
+func (t *SQLListTables) Name() string {
+	return "SQLListTables"
+}
+
+// Description returns the description of the tool.
+func (t *SQLListTables) Description() string {
+	return `Returns a comma-separated list of the tables in the database.
+Input should be an empty string.`
+}
+
+// ArgsType returns the type of the input argument expected by the tool.
+func (t *SQLListTables) ArgsType() reflect.Type {
+	return reflect.TypeOf("") // string
+}
+
+// Run executes the tool with the given input and returns the output.
+func (t *SQLListTables) Run(ctx context.Context, input any) (string, error) {
+	names, err := t.db.TableNames(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join(names, ", "), nil
+}
+
+// Verbose returns the verbosity setting of the tool.
+func (t *SQLListTables) Verbose() bool {
+	return t.opts.Verbose
+}
+
+// Callbacks returns the registered callbacks of the tool.
+func (t *SQLListTables) Callbacks() []schema.Callback {
+	return t.opts.Callbacks
+}
+
+// Compile time check to ensure SQLDescribeTables satisfies the Tool interface.
+var _ schema.Tool = (*SQLDescribeTables)(nil)
+
+// SQLDescribeTablesOptions contains options for configuring the SQLDescribeTables tool.
+type SQLDescribeTablesOptions struct {
+	*schema.CallbackOptions
+}
+
+// SQLDescribeTables is a tool that returns the schema and sample rows of tables of a database.
+type SQLDescribeTables struct {
+	db   *sqldb.SQLDB
+	opts SQLDescribeTablesOptions
+}
+
+// NewSQLDescribeTables creates a new instance of the SQLDescribeTables tool.
+func NewSQLDescribeTables(db *sqldb.SQLDB, optFns ...func(o *SQLDescribeTablesOptions)) *SQLDescribeTables {
+	opts := SQLDescribeTablesOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &SQLDescribeTables{
+		db:   db,
+		opts: opts,
+	}
+}
+
+// Name returns the name of the tool.
+func (t *SQLDescribeTables) Name() string {
+	return "SQLDescribeTables"
+}
+
+// Description returns the description of the tool.
+func (t *SQLDescribeTables) Description() string {
+	return `Returns the schema and sample rows of the given tables.
+Input should be a comma-separated list of tables, e.g. "table1, table2".
+Use SQLListTables to look up the tables first.`
+}
+
+// ArgsType returns the type of the input argument expected by the tool.
+func (t *SQLDescribeTables) ArgsType() reflect.Type {
+	return reflect.TypeOf("") // string
+}
+
+// Run executes the tool with the given input and returns the output.
+func (t *SQLDescribeTables) Run(ctx context.Context, input any) (string, error) {
+	tablesStr, ok := input.(string)
+	if !ok {
+		return "", errors.New("illegal input type")
+	}
+
+	tables := []string{}
+
+	for _, table := range strings.Split(tablesStr, ",") {
+		if table = strings.Trim(strings.TrimSpace(table), "\"'`"); table != "" {
+			tables = append(tables, table)
+		}
+	}
+
+	if len(tables) == 0 {
+		return "Error: no tables given", nil
+	}
+
+	info, err := t.db.DescribeTables(ctx, tables)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		return fmt.Sprintf("Error: %s", err), nil
+	}
+
+	if info == "" {
+		return fmt.Sprintf("Error: tables not found: %s", strings.Join(tables, ", ")), nil
+	}
+
+	return info, nil
+}
+
+// Verbose returns the verbosity setting of the tool.
+func (t *SQLDescribeTables) Verbose() bool {
+	return t.opts.Verbose
+}
+
+// Callbacks returns the registered callbacks of the tool.
+func (t *SQLDescribeTables) Callbacks() []schema.Callback {
+	return t.opts.Callbacks
+}
@@ -0,0 +1,116 @@
+package tool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc/integration/sqldb"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQL(t *testing.T) {
+	t.Parallel()
+
+	engine, err := sqldb.NewSQLite3(":memory:")
+	require.NoError(t, err)
+
+	defer engine.Close()
+
+	ctx := context.Background()
+
+	for _, stmt := range []string{
+		"CREATE TABLE customers (id int NOT NULL, name text NOT NULL)",
+		"CREATE TABLE orders (id int NOT NULL, customer_id int NOT NULL)",
+		"CREATE TABLE secrets (id int NOT NULL)",
+		"INSERT INTO customers (id, name) VALUES (1, 'Alice'), (2, 'Bob'), (3, 'Carol')",
+	} {
+		_, err = engine.Exec(ctx, stmt)
+		require.NoError(t, err)
+	}
+
+	db, err := sqldb.New(engine, func(o *sqldb.SQLDBOptions) {
+		o.Exclude = []string{"secrets"}
+		o.SampleRowsinTableInfo = 1
+	})
+	require.NoError(t, err)
+
+	t.Run("SQLListTables", func(t *testing.T) {
+		output, err := NewSQLListTables(db).Run(ctx, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "customers, orders", output)
+	})
+
+	t.Run("SQLDescribeTables", func(t *testing.T) {
+		output, err := NewSQLDescribeTables(db).Run(ctx, "customers")
+		assert.NoError(t, err)
+		assert.Contains(t, output, "CREATE TABLE `customers`")
+		assert.Contains(t, output, "1 rows from customers table:\nid\tname\n1\tAlice\n")
+		assert.NotContains(t, output, "orders")
+
+		output, err = NewSQLDescribeTables(db).Run(ctx, "secrets")
+		assert.NoError(t, err)
+		assert.Equal(t, "Error: not allowed tables: secrets", output)
+	})
+
+	t.Run("SQLQuery", func(t *testing.T) {
+		output, err := NewSQLQuery(db, func(o *SQLQueryOptions) {
+			o.MaxRows = 2
+		}).Run(ctx, "SELECT name FROM customers ORDER BY id;")
+		assert.NoError(t, err)
+		assert.Equal(t, "name\nAlice\nBob\n(truncated)\n", output)
+	})
+
+	t.Run("SQLQueryReadOnly", func(t *testing.T) {
+		query := NewSQLQuery(db)
+
+		output, err := query.Run(ctx, "DELETE FROM customers")
+		assert.NoError(t, err)
+		assert.Equal(t, "Error: only SELECT queries are allowed: DELETE FROM customers", output)
+
+		output, err = query.Run(ctx, "SELECT * FROM customers; DELETE FROM customers")
+		assert.NoError(t, err)
+		assert.Equal(t, "Error: only a single query is allowed: SELECT * FROM customers; DELETE FROM customers", output)
+	})
+
+	t.Run("SQLQueryWith", func(t *testing.T) {
+		query := NewSQLQuery(db)
+
+		output, err := query.Run(ctx, "WITH named AS (SELECT name FROM customers WHERE id = 1) SELECT name FROM named")
+		assert.NoError(t, err)
+		assert.Equal(t, "name\nAlice\n", output)
+
+		output, err = query.Run(ctx, "WITH RECURSIVE cnt(x) AS (SELECT 1 UNION ALL SELECT x+1 FROM cnt WHERE x < 3) SELECT x FROM cnt")
+		assert.NoError(t, err)
+		assert.Equal(t, "x\n1\n2\n3\n", output)
+
+		output, err = query.Run(ctx, "WITH s AS (SELECT * FROM secrets) SELECT * FROM s")
+		assert.NoError(t, err)
+		assert.Equal(t, "Error: not allowed table: secrets", output)
+
+		output, err = query.Run(ctx, "WITH secrets AS (SELECT * FROM secrets) SELECT * FROM secrets")
+		assert.NoError(t, err)
+		assert.Equal(t, "Error: not allowed table: secrets", output)
+
+		output, err = query.Run(ctx, "WITH d AS (DELETE FROM customers RETURNING *) SELECT * FROM d")
+		assert.NoError(t, err)
+		assert.Equal(t, "Error: only SELECT queries are allowed: WITH d AS (DELETE FROM customers RETURNING *) SELECT * FROM d", output)
+
+		output, err = query.Run(ctx, "WITH x AS (SELECT 1) DELETE FROM customers")
+		assert.NoError(t, err)
+		assert.Equal(t, "Error: only SELECT queries are allowed: WITH x AS (SELECT 1) DELETE FROM customers", output)
+	})
+
+	t.Run("SQLQueryNotAllowedTable", func(t *testing.T) {
+		output, err := NewSQLQuery(db).Run(ctx, "SELECT * FROM secrets")
+		assert.NoError(t, err)
+		assert.Equal(t, "Error: not allowed table: secrets", output)
+	})
+
+	t.Run("SQLQueryError", func(t *testing.T) {
+		output, err := NewSQLQuery(db).Run(ctx, "SELECT unknown FROM customers")
+		assert.NoError(t, err)
+		assert.Equal(t, "Error: no such column: unknown", output)
+	})
+}
@@ -6,7 +6,6 @@ import (
 	"reflect"
 
 	"github.com/hupe1980/golc/callback"
-	"github.com/hupe1980/golc/integration/jsonschema"
 	"github.com/hupe1980/golc/schema"
 )
 
@@ -22,7 +21,9 @@ func Run(ctx context.Context, t schema.Tool, input *schema.ToolInput, optFns ...
 		fn(&opts)
 	}
 
-	cm := callback.NewManager(opts.Callbacks, t.Callbacks(), t.Verbose())
+	cm := callback.NewManager(opts.Callbacks, t.Callbacks(), t.Verbose(), func(mo *callback.ManagerOptions) {
+		mo.ParentRunID = opts.ParentRunID
+	})
 
 	rm, err := cm.OnToolStart(ctx, &schema.ToolStartManagerInput{
 		ToolName: t.Name(),
@@ -47,7 +48,7 @@ func Run(ctx context.Context, t schema.Tool, input *schema.ToolInput, optFns ...
 		inputValue, _ = input.GetString()
 	}
 
-	output, err := t.Run(ctx, inputValue)
+	output, err := t.Run(callback.WithToolRunManager(ctx, rm), inputValue)
 	if err != nil {
 		if cbErr := rm.OnToolError(ctx, &schema.ToolErrorManagerInput{
 			Error: err,
@@ -67,40 +68,9 @@ func Run(ctx context.Context, t schema.Tool, input *schema.ToolInput, optFns ...
 	return output, nil
 }
 
-// ToFunction formats a tool into a function API
+// ToFunction formats a tool into a function API.
+//
+// Deprecated: Use schema.ToFunction instead.
 func ToFunction(t schema.Tool) (*schema.FunctionDefinition, error) {
-	function := &schema.FunctionDefinition{
-		Name:        t.Name(),
-		Description: t.Description(),
-	}
-
-	argsType := t.ArgsType()
-
-	if argsType.Kind() == reflect.String {
-		function.Parameters = schema.FunctionDefinitionParameters{
-			Type: "object",
-			Properties: map[string]*jsonschema.Schema{
-				"__arg1": {
-					Type:        "string",
-					Description: "__arg1",
-				},
-			},
-			Required: []string{"__arg1"},
-		}
-
-		return function, nil
-	}
-
-	jsonSchema, err := jsonschema.Generate(argsType)
-	if err != nil {
-		return nil, err
-	}
-
-	function.Parameters = schema.FunctionDefinitionParameters{
-		Type:       "object",
-		Properties: jsonSchema.Properties,
-		Required:   jsonSchema.Required,
-	}
-
-	return function, nil
+	return schema.ToFunction(t)
 }
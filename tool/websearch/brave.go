@@ -0,0 +1,103 @@
+package websearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hupe1980/golc/internal/util"
+)
+
+// Compile time check to ensure Brave satisfies the Searcher interface.
+var _ Searcher = (*Brave)(nil)
+
+// BraveOptions contains options for configuring the Brave search provider.
+type BraveOptions struct {
+	// HTTPClient is the HTTP client to use for making API requests.
+	HTTPClient HTTPClient
+	// BaseURL is the base url of the API.
+	BaseURL string
+	// Country is the country code the results come from, e.g. "us".
+	Country string
+	// SafeSearch filters adult content. Possible values are "off", "moderate" and "strict".
+	SafeSearch string
+}
+
+// Brave searches the web with the Brave Search API.
+type Brave struct {
+	apiKey string
+	opts   BraveOptions
+}
+
+// NewBrave creates a new Brave search provider with the given subscription token.
+func NewBrave(apiKey string, optFns ...func(o *BraveOptions)) *Brave {
+	opts := BraveOptions{
+		HTTPClient: http.DefaultClient,
+		BaseURL:    "https://api.search.brave.com/res/v1",
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Brave{
+		apiKey: apiKey,
+		opts:   opts,
+	}
+}
+
+// Search searches the web for the query and returns at most maxResults results.
+func (s *Brave) Search(ctx context.Context, query string, maxResults int) ([]Result, error) {
+	params := url.Values{}
+	params.Set("q", query)
+
+	if maxResults > 0 {
+		// The API returns at most 20 results.
+		params.Set("count", strconv.Itoa(util.Min(maxResults, 20)))
+	}
+
+	if s.opts.Country != "" {
+		params.Set("country", s.opts.Country)
+	}
+
+	if s.opts.SafeSearch != "" {
+		params.Set("safesearch", s.opts.SafeSearch)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/web/search?%s", s.opts.BaseURL, params.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Subscription-Token", s.apiKey)
+
+	body, err := doRequest(s.opts.HTTPClient, "brave", req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := struct {
+		Web struct {
+			Results []struct {
+				Title       string `json:"title"`
+				URL         string `json:"url"`
+				Description string `json:"description"`
+			} `json:"results"`
+		} `json:"web"`
+	}{}
+
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, len(res.Web.Results))
+	for i, r := range res.Web.Results {
+		results[i] = Result{Title: r.Title, URL: r.URL, Snippet: stripTags(r.Description)}
+	}
+
+	return limitResults(results, maxResults), nil
+}
@@ -0,0 +1,54 @@
+package websearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBrave(t *testing.T) {
+	t.Run("Search", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/web/search", r.URL.Path)
+			assert.Equal(t, "golc", r.URL.Query().Get("q"))
+			assert.Equal(t, "2", r.URL.Query().Get("count"))
+			assert.Equal(t, "apiKey", r.Header.Get("X-Subscription-Token"))
+
+			_, _ = w.Write([]byte(`{"web": {"results": [
+				{"title": "GoLC", "url": "https://github.com/hupe1980/golc", "description": "<strong>GoLC</strong> &amp; Go"},
+				{"title": "Go", "url": "https://go.dev", "description": "Go"},
+				{"title": "More", "url": "https://example.com", "description": "More"}
+			]}}`))
+		}))
+		defer server.Close()
+
+		brave := NewBrave("apiKey", func(o *BraveOptions) {
+			o.BaseURL = server.URL
+		})
+
+		results, err := brave.Search(context.Background(), "golc", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []Result{
+			{Title: "GoLC", URL: "https://github.com/hupe1980/golc", Snippet: "GoLC & Go"},
+			{Title: "Go", URL: "https://go.dev", Snippet: "Go"},
+		}, results)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`invalid token`))
+		}))
+		defer server.Close()
+
+		brave := NewBrave("apiKey", func(o *BraveOptions) {
+			o.BaseURL = server.URL
+		})
+
+		_, err := brave.Search(context.Background(), "golc", 2)
+		assert.EqualError(t, err, "brave: status code 401: invalid token")
+	})
+}
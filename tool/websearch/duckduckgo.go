@@ -0,0 +1,125 @@
+package websearch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Compile time check to ensure DuckDuckGo satisfies the Searcher interface.
+var _ Searcher = (*DuckDuckGo)(nil)
+
+// DuckDuckGoOptions contains options for configuring the DuckDuckGo search provider.
+type DuckDuckGoOptions struct {
+	// HTTPClient is the HTTP client to use for making requests.
+	HTTPClient HTTPClient
+	// BaseURL is the base url of the HTML version of DuckDuckGo.
+	BaseURL string
+	// Region is the region of the results, e.g. "us-en" or "de-de".
+	Region string
+	// UserAgent is sent with the requests, because DuckDuckGo rejects requests without user agent.
+	UserAgent string
+}
+
+// DuckDuckGo searches the web with the HTML version of DuckDuckGo. It does not need an API key,
+// but is subject to the rate limits of DuckDuckGo.
+type DuckDuckGo struct {
+	opts DuckDuckGoOptions
+}
+
+// NewDuckDuckGo creates a new DuckDuckGo search provider.
+func NewDuckDuckGo(optFns ...func(o *DuckDuckGoOptions)) *DuckDuckGo {
+	opts := DuckDuckGoOptions{
+		HTTPClient: http.DefaultClient,
+		BaseURL:    "https://html.duckduckgo.com/html/",
+		Region:     "wt-wt",
+		UserAgent:  "Mozilla/5.0 (compatible; golc)",
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &DuckDuckGo{
+		opts: opts,
+	}
+}
+
+// Search searches the web for the query and returns at most maxResults results.
+func (s *DuckDuckGo) Search(ctx context.Context, query string, maxResults int) ([]Result, error) {
+	form := url.Values{}
+	form.Set("q", query)
+	form.Set("kl", s.opts.Region)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.opts.BaseURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", s.opts.UserAgent)
+
+	body, err := doRequest(s.opts.HTTPClient, "duckduckgo", req)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	results := []Result{}
+
+	doc.Find(".result").EachWithBreak(func(_ int, sel *goquery.Selection) bool {
+		// Skip the ads.
+		if sel.HasClass("result--ad") {
+			return true
+		}
+
+		link := sel.Find("a.result__a").First()
+
+		href, ok := link.Attr("href")
+		if !ok {
+			return true
+		}
+
+		results = append(results, Result{
+			Title:   strings.TrimSpace(link.Text()),
+			URL:     resolveDuckDuckGoURL(href),
+			Snippet: strings.TrimSpace(sel.Find(".result__snippet").First().Text()),
+		})
+
+		return maxResults <= 0 || len(results) < maxResults
+	})
+
+	// DuckDuckGo responds with a captcha instead of an error status, if the requests are rate limited.
+	if len(results) == 0 && doc.Find(".anomaly-modal__modal").Length() > 0 {
+		return nil, &APIError{Provider: "duckduckgo", StatusCode: http.StatusOK, Message: "request was rate limited"}
+	}
+
+	return results, nil
+}
+
+// resolveDuckDuckGoURL resolves the redirect links of DuckDuckGo to the target url.
+func resolveDuckDuckGoURL(href string) string {
+	u, err := url.Parse(href)
+	if err != nil {
+		return href
+	}
+
+	if target := u.Query().Get("uddg"); target != "" {
+		return target
+	}
+
+	if strings.HasPrefix(href, "//") {
+		return fmt.Sprintf("https:%s", href)
+	}
+
+	return href
+}
@@ -0,0 +1,64 @@
+package websearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuckDuckGo(t *testing.T) {
+	t.Run("Search", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.NoError(t, r.ParseForm())
+			assert.Equal(t, "golc", r.PostForm.Get("q"))
+			assert.NotEmpty(t, r.Header.Get("User-Agent"))
+
+			_, _ = w.Write([]byte(`<html><body>
+				<div class="result result--ad">
+					<a class="result__a" href="https://ads.example.com">Ad</a>
+				</div>
+				<div class="result">
+					<a class="result__a" href="//duckduckgo.com/l/?uddg=https%3A%2F%2Fgithub.com%2Fhupe1980%2Fgolc&rut=abc">GoLC</a>
+					<a class="result__snippet">LLM orchestration in <b>Go</b></a>
+				</div>
+				<div class="result">
+					<a class="result__a" href="https://go.dev">Go</a>
+					<a class="result__snippet">The Go programming language</a>
+				</div>
+				<div class="result">
+					<a class="result__a" href="https://example.com">More</a>
+				</div>
+			</body></html>`))
+		}))
+		defer server.Close()
+
+		duckDuckGo := NewDuckDuckGo(func(o *DuckDuckGoOptions) {
+			o.BaseURL = server.URL
+		})
+
+		results, err := duckDuckGo.Search(context.Background(), "golc", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []Result{
+			{Title: "GoLC", URL: "https://github.com/hupe1980/golc", Snippet: "LLM orchestration in Go"},
+			{Title: "Go", URL: "https://go.dev", Snippet: "The Go programming language"},
+		}, results)
+	})
+
+	t.Run("RateLimited", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`<html><body><div class="anomaly-modal__modal">captcha</div></body></html>`))
+		}))
+		defer server.Close()
+
+		duckDuckGo := NewDuckDuckGo(func(o *DuckDuckGoOptions) {
+			o.BaseURL = server.URL
+		})
+
+		_, err := duckDuckGo.Search(context.Background(), "golc", 2)
+		assert.EqualError(t, err, "duckduckgo: status code 200: request was rate limited")
+	})
+}
@@ -0,0 +1,75 @@
+package websearch
+
+import (
+	"context"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Retriever satisfies the Retriever interface.
+var _ schema.Retriever = (*Retriever)(nil)
+
+// RetrieverOptions contains options for configuring the web search Retriever.
+type RetrieverOptions struct {
+	*schema.CallbackOptions
+	// MaxResults is the maximum number of retrieved documents.
+	MaxResults int
+}
+
+// Retriever is a retriever that returns the results of a web search as documents. The snippet is
+// the page content, title and url are stored in the metadata.
+type Retriever struct {
+	searcher Searcher
+	opts     RetrieverOptions
+}
+
+// NewRetriever creates a new web search Retriever using the given search provider.
+func NewRetriever(searcher Searcher, optFns ...func(o *RetrieverOptions)) *Retriever {
+	opts := RetrieverOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		MaxResults: 5,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Retriever{
+		searcher: searcher,
+		opts:     opts,
+	}
+}
+
+// GetRelevantDocuments searches the web for the query and returns the results as documents.
+func (r *Retriever) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	results, err := r.searcher.Search(ctx, query, r.opts.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, len(results))
+	for i, result := range results {
+		docs[i] = schema.Document{
+			PageContent: result.Snippet,
+			Metadata: map[string]any{
+				"title":  result.Title,
+				"source": result.URL,
+			},
+		}
+	}
+
+	return docs, nil
+}
+
+// Verbose returns the verbosity setting of the retriever.
+func (r *Retriever) Verbose() bool {
+	return r.opts.Verbose
+}
+
+// Callbacks returns the registered callbacks of the retriever.
+func (r *Retriever) Callbacks() []schema.Callback {
+	return r.opts.Callbacks
+}
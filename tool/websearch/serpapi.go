@@ -0,0 +1,111 @@
+package websearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Compile time check to ensure SerpAPI satisfies the Searcher interface.
+var _ Searcher = (*SerpAPI)(nil)
+
+// SerpAPIOptions contains options for configuring the SerpAPI search provider.
+type SerpAPIOptions struct {
+	// HTTPClient is the HTTP client to use for making API requests.
+	HTTPClient HTTPClient
+	// BaseURL is the base url of the API.
+	BaseURL string
+	// Engine is the search engine used by SerpAPI.
+	Engine string
+	// Parameter contains additional parameters of the search, e.g. "gl" or "hl".
+	Parameter map[string]string
+}
+
+// SerpAPI searches the web with SerpAPI, which scrapes the results of search engines like Google.
+type SerpAPI struct {
+	apiKey string
+	opts   SerpAPIOptions
+}
+
+// NewSerpAPI creates a new SerpAPI search provider with the given API key.
+func NewSerpAPI(apiKey string, optFns ...func(o *SerpAPIOptions)) *SerpAPI {
+	opts := SerpAPIOptions{
+		HTTPClient: http.DefaultClient,
+		BaseURL:    "https://serpapi.com",
+		Engine:     "google",
+		Parameter: map[string]string{
+			"google_domain": "google.com",
+			"gl":            "us",
+			"hl":            "en",
+		},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &SerpAPI{
+		apiKey: apiKey,
+		opts:   opts,
+	}
+}
+
+// Search searches the web for the query and returns at most maxResults organic results.
+func (s *SerpAPI) Search(ctx context.Context, query string, maxResults int) ([]Result, error) {
+	params := url.Values{}
+	for k, v := range s.opts.Parameter {
+		params.Set(k, v)
+	}
+
+	params.Set("engine", s.opts.Engine)
+	params.Set("q", query)
+	params.Set("api_key", s.apiKey)
+
+	if maxResults > 0 {
+		params.Set("num", strconv.Itoa(maxResults))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/search.json?%s", s.opts.BaseURL, params.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	body, err := doRequest(s.opts.HTTPClient, "serpapi", req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := struct {
+		Error          string `json:"error"`
+		OrganicResults []struct {
+			Title   string `json:"title"`
+			Link    string `json:"link"`
+			Snippet string `json:"snippet"`
+		} `json:"organic_results"`
+	}{}
+
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, err
+	}
+
+	if res.Error != "" {
+		// SerpAPI reports searches without results as error.
+		if res.Error == "Google hasn't returned any results for this query." {
+			return []Result{}, nil
+		}
+
+		return nil, &APIError{Provider: "serpapi", StatusCode: http.StatusOK, Message: res.Error}
+	}
+
+	results := make([]Result, len(res.OrganicResults))
+	for i, r := range res.OrganicResults {
+		results[i] = Result{Title: r.Title, URL: r.Link, Snippet: r.Snippet}
+	}
+
+	return limitResults(results, maxResults), nil
+}
@@ -0,0 +1,75 @@
+package websearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSerpAPI(t *testing.T) {
+	t.Run("Search", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/search.json", r.URL.Path)
+			assert.Equal(t, "google", r.URL.Query().Get("engine"))
+			assert.Equal(t, "golc", r.URL.Query().Get("q"))
+			assert.Equal(t, "apiKey", r.URL.Query().Get("api_key"))
+			assert.Equal(t, "us", r.URL.Query().Get("gl"))
+
+			_, _ = w.Write([]byte(`{"organic_results": [{"title": "GoLC", "link": "https://github.com/hupe1980/golc", "snippet": "LLM orchestration in Go"}]}`))
+		}))
+		defer server.Close()
+
+		serpAPI := NewSerpAPI("apiKey", func(o *SerpAPIOptions) {
+			o.BaseURL = server.URL
+		})
+
+		results, err := serpAPI.Search(context.Background(), "golc", 5)
+		assert.NoError(t, err)
+		assert.Equal(t, []Result{{Title: "GoLC", URL: "https://github.com/hupe1980/golc", Snippet: "LLM orchestration in Go"}}, results)
+	})
+
+	t.Run("NoResults", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"error": "Google hasn't returned any results for this query."}`))
+		}))
+		defer server.Close()
+
+		serpAPI := NewSerpAPI("apiKey", func(o *SerpAPIOptions) {
+			o.BaseURL = server.URL
+		})
+
+		results, err := serpAPI.Search(context.Background(), "golc", 5)
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"error": "Invalid API key."}`))
+		}))
+		defer server.Close()
+
+		serpAPI := NewSerpAPI("apiKey", func(o *SerpAPIOptions) {
+			o.BaseURL = server.URL
+		})
+
+		_, err := serpAPI.Search(context.Background(), "golc", 5)
+		assert.EqualError(t, err, "serpapi: status code 200: Invalid API key.")
+	})
+	t.Run("TransportError", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		server.Close()
+
+		serpAPI := NewSerpAPI("secretKey", func(o *SerpAPIOptions) {
+			o.BaseURL = server.URL
+		})
+
+		_, err := serpAPI.Search(context.Background(), "golc", 5)
+		assert.Error(t, err)
+		assert.NotContains(t, err.Error(), "secretKey")
+		assert.Contains(t, err.Error(), "serpapi: Get request failed")
+	})
+}
@@ -0,0 +1,105 @@
+package websearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Compile time check to ensure Tavily satisfies the Searcher interface.
+var _ Searcher = (*Tavily)(nil)
+
+// TavilyOptions contains options for configuring the Tavily search provider.
+type TavilyOptions struct {
+	// HTTPClient is the HTTP client to use for making API requests.
+	HTTPClient HTTPClient
+	// BaseURL is the base url of the API.
+	BaseURL string
+	// SearchDepth is the depth of the search. Possible values are "basic" and "advanced".
+	SearchDepth string
+	// IncludeDomains restricts the search to the given domains.
+	IncludeDomains []string
+	// ExcludeDomains excludes the given domains from the search.
+	ExcludeDomains []string
+}
+
+// Tavily searches the web with the Tavily Search API, which is optimized for language models.
+type Tavily struct {
+	apiKey string
+	opts   TavilyOptions
+}
+
+// NewTavily creates a new Tavily search provider with the given API key.
+func NewTavily(apiKey string, optFns ...func(o *TavilyOptions)) *Tavily {
+	opts := TavilyOptions{
+		HTTPClient:  http.DefaultClient,
+		BaseURL:     "https://api.tavily.com",
+		SearchDepth: "basic",
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Tavily{
+		apiKey: apiKey,
+		opts:   opts,
+	}
+}
+
+// Search searches the web for the query and returns at most maxResults results.
+func (s *Tavily) Search(ctx context.Context, query string, maxResults int) ([]Result, error) {
+	payload := struct {
+		Query          string   `json:"query"`
+		SearchDepth    string   `json:"search_depth,omitempty"`
+		MaxResults     int      `json:"max_results,omitempty"`
+		IncludeDomains []string `json:"include_domains,omitempty"`
+		ExcludeDomains []string `json:"exclude_domains,omitempty"`
+	}{
+		Query:          query,
+		SearchDepth:    s.opts.SearchDepth,
+		MaxResults:     maxResults,
+		IncludeDomains: s.opts.IncludeDomains,
+		ExcludeDomains: s.opts.ExcludeDomains,
+	}
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/search", s.opts.BaseURL), bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.apiKey))
+
+	body, err := doRequest(s.opts.HTTPClient, "tavily", req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := struct {
+		Results []struct {
+			Title   string `json:"title"`
+			URL     string `json:"url"`
+			Content string `json:"content"`
+		} `json:"results"`
+	}{}
+
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, len(res.Results))
+	for i, r := range res.Results {
+		results[i] = Result{Title: r.Title, URL: r.URL, Snippet: r.Content}
+	}
+
+	return limitResults(results, maxResults), nil
+}
@@ -0,0 +1,41 @@
+package websearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTavily(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/search", r.URL.Path)
+		assert.Equal(t, "Bearer apiKey", r.Header.Get("Authorization"))
+
+		payload := map[string]any{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, map[string]any{
+			"query":           "golc",
+			"search_depth":    "advanced",
+			"max_results":     float64(3),
+			"include_domains": []any{"github.com"},
+		}, payload)
+
+		_, _ = w.Write([]byte(`{"results": [{"title": "GoLC", "url": "https://github.com/hupe1980/golc", "content": "LLM orchestration in Go", "score": 0.9}]}`))
+	}))
+	defer server.Close()
+
+	tavily := NewTavily("apiKey", func(o *TavilyOptions) {
+		o.BaseURL = server.URL
+		o.SearchDepth = "advanced"
+		o.IncludeDomains = []string{"github.com"}
+	})
+
+	results, err := tavily.Search(context.Background(), "golc", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []Result{{Title: "GoLC", URL: "https://github.com/hupe1980/golc", Snippet: "LLM orchestration in Go"}}, results)
+}
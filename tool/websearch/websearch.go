@@ -0,0 +1,196 @@
+// Package websearch provides web search providers behind a common interface and a tool and a
+// retriever to use the structured search results in agents and RAG chains.
+package websearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Tool satisfies the Tool interface.
+var _ schema.Tool = (*Tool)(nil)
+
+// Result is a single result of a web search.
+type Result struct {
+	Title   string `json:"title"`
+	URL     string `json:"url"`
+	Snippet string `json:"snippet"`
+}
+
+// Searcher is the interface of the web search providers.
+type Searcher interface {
+	// Search searches the web for the query and returns at most maxResults results.
+	Search(ctx context.Context, query string, maxResults int) ([]Result, error)
+}
+
+// HTTPClient is an interface for making HTTP requests.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// APIError is returned by the search providers, if the API responds with an error.
+type APIError struct {
+	Provider   string
+	StatusCode int
+	Message    string
+}
+
+// Error returns the error message.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s: status code %d: %s", e.Provider, e.StatusCode, e.Message)
+}
+
+// Options contains options for configuring the web search Tool.
+type Options struct {
+	*schema.CallbackOptions
+	// Name is the name of the tool.
+	Name string
+	// Description is the description of the tool.
+	Description string
+	// MaxResults is the maximum number of results returned to the agent.
+	MaxResults int
+}
+
+// Tool is a tool that searches the web with a search provider.
+type Tool struct {
+	searcher Searcher
+	opts     Options
+}
+
+// New creates a new web search Tool using the given search provider.
+func New(searcher Searcher, optFns ...func(o *Options)) *Tool {
+	opts := Options{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		Name: "WebSearch",
+		Description: `A web search engine.
+Useful for when you need to answer questions about current events or other information not known to you.
+Input should be a search query.`,
+		MaxResults: 5,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Tool{
+		searcher: searcher,
+		opts:     opts,
+	}
+}
+
+// Name returns the name of the tool.
+func (t *Tool) Name() string {
+	return t.opts.Name
+}
+
+// Description returns the description of the tool.
+func (t *Tool) Description() string {
+	return t.opts.Description
+}
+
+// ArgsType returns the type of the input argument expected by the tool.
+func (t *Tool) ArgsType() reflect.Type {
+	return reflect.TypeOf("") // string
+}
+
+// Run executes the tool with the given input and returns the formatted search results.
+func (t *Tool) Run(ctx context.Context, input any) (string, error) {
+	query, ok := input.(string)
+	if !ok {
+		return "", errors.New("illegal input type")
+	}
+
+	results, err := t.searcher.Search(ctx, query, t.opts.MaxResults)
+	if err != nil {
+		return "", err
+	}
+
+	if len(results) == 0 {
+		return "No good search result found", nil
+	}
+
+	return FormatResults(results), nil
+}
+
+// Verbose returns the verbosity setting of the tool.
+func (t *Tool) Verbose() bool {
+	return t.opts.Verbose
+}
+
+// Callbacks returns the registered callbacks of the tool.
+func (t *Tool) Callbacks() []schema.Callback {
+	return t.opts.Callbacks
+}
+
+// FormatResults formats the search results as text for language models.
+func FormatResults(results []Result) string {
+	formatted := make([]string, len(results))
+	for i, r := range results {
+		formatted[i] = fmt.Sprintf("Title: %s\nURL: %s\nSnippet: %s", r.Title, r.URL, r.Snippet)
+	}
+
+	return strings.Join(formatted, "\n\n")
+}
+
+// doRequest sends the HTTP request and returns the body of the response. Responses with a status
+// code other than 200 are returned as APIError. Transport errors do not contain the URL of the
+// request, which may contain an api key in the query.
+func doRequest(client HTTPClient, provider string, req *http.Request) ([]byte, error) {
+	res, err := client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return nil, fmt.Errorf("%s: %s request failed: %w", provider, urlErr.Op, urlErr.Err)
+		}
+
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		message := strings.TrimSpace(string(body))
+		if message == "" {
+			message = res.Status
+		}
+
+		return nil, &APIError{Provider: provider, StatusCode: res.StatusCode, Message: message}
+	}
+
+	return body, nil
+}
+
+// limitResults returns at most maxResults results. A maxResults <= 0 returns all results.
+func limitResults(results []Result, maxResults int) []Result {
+	if maxResults > 0 && len(results) > maxResults {
+		return results[:maxResults]
+	}
+
+	return results
+}
+
+// tagRegexp matches HTML tags, e.g. the highlighting of the query in snippets.
+var tagRegexp = regexp.MustCompile(`<[^>]*>`)
+
+// stripTags removes the HTML tags and unescapes the entities of the text.
+func stripTags(text string) string {
+	return html.UnescapeString(tagRegexp.ReplaceAllString(text, ""))
+}
@@ -0,0 +1,71 @@
+package websearch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTool(t *testing.T) {
+	t.Run("Run", func(t *testing.T) {
+		searcher := &searcherMock{
+			results: []Result{
+				{Title: "GoLC", URL: "https://github.com/hupe1980/golc", Snippet: "LLM orchestration in Go"},
+				{Title: "Go", URL: "https://go.dev", Snippet: "The Go programming language"},
+			},
+		}
+
+		tool := New(searcher, func(o *Options) {
+			o.MaxResults = 2
+		})
+
+		output, err := tool.Run(context.Background(), "golc")
+		assert.NoError(t, err)
+		assert.Equal(t, "Title: GoLC\nURL: https://github.com/hupe1980/golc\nSnippet: LLM orchestration in Go\n\nTitle: Go\nURL: https://go.dev\nSnippet: The Go programming language", output)
+		assert.Equal(t, "golc", searcher.query)
+		assert.Equal(t, 2, searcher.maxResults)
+	})
+
+	t.Run("NoResults", func(t *testing.T) {
+		output, err := New(&searcherMock{}).Run(context.Background(), "golc")
+		assert.NoError(t, err)
+		assert.Equal(t, "No good search result found", output)
+	})
+
+	t.Run("IllegalInput", func(t *testing.T) {
+		_, err := New(&searcherMock{}).Run(context.Background(), 42)
+		assert.EqualError(t, err, "illegal input type")
+	})
+
+	t.Run("Name", func(t *testing.T) {
+		assert.Equal(t, "WebSearch", New(&searcherMock{}).Name())
+		assert.Equal(t, "Search", New(&searcherMock{}, func(o *Options) { o.Name = "Search" }).Name())
+	})
+}
+
+func TestRetriever(t *testing.T) {
+	retriever := NewRetriever(&searcherMock{
+		results: []Result{{Title: "GoLC", URL: "https://github.com/hupe1980/golc", Snippet: "LLM orchestration in Go"}},
+	})
+
+	docs, err := retriever.GetRelevantDocuments(context.Background(), "golc")
+	assert.NoError(t, err)
+	assert.Len(t, docs, 1)
+	assert.Equal(t, "LLM orchestration in Go", docs[0].PageContent)
+	assert.Equal(t, map[string]any{"title": "GoLC", "source": "https://github.com/hupe1980/golc"}, docs[0].Metadata)
+}
+
+// searcherMock returns the results and records the arguments of the last search.
+type searcherMock struct {
+	results    []Result
+	query      string
+	maxResults int
+}
+
+func (m *searcherMock) Search(ctx context.Context, query string, maxResults int) ([]Result, error) {
+	m.query = query
+	m.maxResults = maxResults
+
+	return m.results, nil
+}
@@ -0,0 +1,69 @@
+package toolkit
+
+import (
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tool"
+)
+
+// FileSystemOptions contains options for configuring the FileSystem toolkit.
+type FileSystemOptions struct {
+	// ReadOnly omits the WriteFile tool.
+	ReadOnly bool
+	// MaxFileBytes is the maximum size of files read or written by the agent.
+	MaxFileBytes int64
+	// MaxEntries is the maximum number of directory entries returned to the agent. A value <= 0 disables the limit.
+	MaxEntries int
+}
+
+// FileSystem represents a collection of schema.Tool objects that enable an agent to operate on the files of a workspace.
+type FileSystem struct {
+	tools []schema.Tool
+}
+
+// NewFileSystem creates a new FileSystem toolkit, whose tools are confined to the root directory.
+func NewFileSystem(root string, optFns ...func(o *FileSystemOptions)) (*FileSystem, error) {
+	opts := FileSystemOptions{
+		MaxFileBytes: 100 * 1024,
+		MaxEntries:   200,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	listDirectory, err := tool.NewListDirectory(root, func(o *tool.ListDirectoryOptions) {
+		o.MaxEntries = opts.MaxEntries
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	readFile, err := tool.NewReadFile(root, func(o *tool.ReadFileOptions) {
+		o.MaxFileBytes = opts.MaxFileBytes
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tools := []schema.Tool{listDirectory, readFile}
+
+	if !opts.ReadOnly {
+		writeFile, err := tool.NewWriteFile(root, func(o *tool.WriteFileOptions) {
+			o.MaxFileBytes = opts.MaxFileBytes
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		tools = append(tools, writeFile)
+	}
+
+	return &FileSystem{
+		tools: tools,
+	}, nil
+}
+
+// Tools returns the list of schema.Tool objects associated with the FileSystem.
+func (tk *FileSystem) Tools() []schema.Tool {
+	return tk.tools
+}
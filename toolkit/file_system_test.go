@@ -0,0 +1,39 @@
+package toolkit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFileSystem(t *testing.T) {
+	t.Run("ReadWrite", func(t *testing.T) {
+		fileSystem, err := NewFileSystem(t.TempDir())
+		require.NoError(t, err)
+
+		expectedToolNames := []string{
+			"ListDirectory",
+			"ReadFile",
+			"WriteFile",
+		}
+		tools := fileSystem.Tools()
+		require.Len(t, tools, len(expectedToolNames))
+
+		for _, name := range expectedToolNames {
+			assertToolExists(t, tools, name)
+		}
+	})
+
+	t.Run("ReadOnly", func(t *testing.T) {
+		fileSystem, err := NewFileSystem(t.TempDir(), func(o *FileSystemOptions) {
+			o.ReadOnly = true
+		})
+		require.NoError(t, err)
+		require.Len(t, fileSystem.Tools(), 2)
+	})
+
+	t.Run("RootNotFound", func(t *testing.T) {
+		_, err := NewFileSystem("does-not-exist")
+		require.Error(t, err)
+	})
+}
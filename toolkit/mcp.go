@@ -0,0 +1,86 @@
+package toolkit
+
+import (
+	"context"
+
+	"github.com/hupe1980/golc/integration/mcp"
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tool"
+)
+
+// MCPOptions contains options for configuring the MCP toolkit.
+type MCPOptions struct {
+	// ToolNamePrefix is prepended to the names of the tools, e.g. to avoid conflicts between the tools
+	// of multiple servers.
+	ToolNamePrefix string
+	// Tools are the names of the tools of the server to include. If empty, all tools are included.
+	Tools []string
+	// IncludeResources adds a tool to read the resources of the server, if the server has resources.
+	IncludeResources bool
+}
+
+// MCP represents a collection of schema.Tool objects that enable an agent to use the tools and
+// resources of an MCP server.
+type MCP struct {
+	tools []schema.Tool
+}
+
+// NewMCP creates a new MCP toolkit with the tools and resources discovered from the server.
+func NewMCP(ctx context.Context, client *mcp.Client, optFns ...func(o *MCPOptions)) (*MCP, error) {
+	opts := MCPOptions{
+		IncludeResources: true,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	tools := []schema.Tool{}
+
+	if client.Capabilities().Tools != nil {
+		mcpTools, err := client.ListTools(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, mcpTool := range mcpTools {
+			if len(opts.Tools) > 0 && !util.Contains(opts.Tools, mcpTool.Name) {
+				continue
+			}
+
+			t, err := tool.NewMCP(client, mcpTool, func(o *tool.MCPOptions) {
+				o.Name = opts.ToolNamePrefix + mcpTool.Name
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			tools = append(tools, t)
+		}
+	}
+
+	if opts.IncludeResources && client.Capabilities().Resources != nil {
+		resources, err := client.ListResources(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(resources) > 0 {
+			tools = append(tools, tool.NewMCPReadResource(client, resources, func(o *tool.MCPReadResourceOptions) {
+				if opts.ToolNamePrefix != "" {
+					o.Name = opts.ToolNamePrefix + "ReadResource"
+				}
+			}))
+		}
+	}
+
+	return &MCP{
+		tools: tools,
+	}, nil
+}
+
+// Tools returns the list of schema.Tool objects associated with the MCP toolkit.
+func (tk *MCP) Tools() []schema.Tool {
+	return tk.tools
+}
@@ -0,0 +1,97 @@
+package toolkit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/hupe1980/golc/integration/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+// mcpTransportMock is an in-memory transport to an MCP server with the results of the methods.
+type mcpTransportMock struct {
+	results  map[string]string
+	incoming chan []byte
+}
+
+func (t *mcpTransportMock) Start(ctx context.Context) error {
+	return nil
+}
+
+func (t *mcpTransportMock) Send(ctx context.Context, msg []byte) error {
+	req := struct {
+		ID     *json.RawMessage `json:"id"`
+		Method string           `json:"method"`
+	}{}
+
+	if err := json.Unmarshal(msg, &req); err != nil {
+		return err
+	}
+
+	if req.ID != nil {
+		t.incoming <- []byte(`{"jsonrpc":"2.0","id":` + string(*req.ID) + `,"result":` + t.results[req.Method] + `}`)
+	}
+
+	return nil
+}
+
+func (t *mcpTransportMock) Receive() ([]byte, error) {
+	msg, ok := <-t.incoming
+	if !ok {
+		return nil, io.EOF
+	}
+
+	return msg, nil
+}
+
+func (t *mcpTransportMock) Close() error {
+	close(t.incoming)
+	return nil
+}
+
+func TestNewMCP(t *testing.T) {
+	client, err := mcp.Connect(context.Background(), &mcpTransportMock{
+		results: map[string]string{
+			"initialize":     `{"protocolVersion":"2024-11-05","capabilities":{"tools":{},"resources":{}},"serverInfo":{"name":"github","version":"1.0.0"}}`,
+			"tools/list":     `{"tools":[{"name":"search_issues","inputSchema":{"type":"object"}},{"name":"create_issue","inputSchema":{"type":"object"}}]}`,
+			"resources/list": `{"resources":[{"uri":"repo://readme","name":"readme"}]}`,
+		},
+		incoming: make(chan []byte, 10),
+	})
+	require.NoError(t, err)
+
+	defer client.Close()
+
+	t.Run("AllTools", func(t *testing.T) {
+		mcpToolkit, err := NewMCP(context.Background(), client, func(o *MCPOptions) {
+			o.ToolNamePrefix = "github_"
+		})
+		require.NoError(t, err)
+
+		expectedToolNames := []string{
+			"github_search_issues",
+			"github_create_issue",
+			"github_ReadResource",
+		}
+		tools := mcpToolkit.Tools()
+		require.Len(t, tools, len(expectedToolNames))
+
+		for _, name := range expectedToolNames {
+			assertToolExists(t, tools, name)
+		}
+	})
+
+	t.Run("SelectedTools", func(t *testing.T) {
+		mcpToolkit, err := NewMCP(context.Background(), client, func(o *MCPOptions) {
+			o.Tools = []string{"search_issues"}
+			o.IncludeResources = false
+		})
+		require.NoError(t, err)
+
+		tools := mcpToolkit.Tools()
+		require.Len(t, tools, 1)
+		assertToolExists(t, tools, "search_issues")
+	})
+}
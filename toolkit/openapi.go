@@ -0,0 +1,97 @@
+package toolkit
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hupe1980/golc/integration"
+	"github.com/hupe1980/golc/integration/openapi"
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tool"
+)
+
+// OpenAPIOptions contains options for configuring the OpenAPI toolkit.
+type OpenAPIOptions struct {
+	// HTTPClient is the HTTP client to use for the requests.
+	HTTPClient integration.HTTPClient
+	// BaseURL is the base URL of the API requests. Defaults to the first server of the specification.
+	BaseURL string
+	// Headers are sent with every request.
+	Headers map[string]string
+	// Credentials are the credentials by name of the security scheme of the specification. See
+	// tool.OpenAPIOptions for the format of the credentials.
+	Credentials map[string]string
+	// Operations are the ids of the operations to include. If empty, all operations are included.
+	Operations []string
+	// MaxResponseBytes is the maximum number of bytes read from the response bodies.
+	MaxResponseBytes int64
+}
+
+// OpenAPI represents a collection of schema.Tool objects that enable an agent to call the
+// operations of an API described by an OpenAPI specification.
+type OpenAPI struct {
+	tools []schema.Tool
+}
+
+// NewOpenAPI creates a new OpenAPI toolkit with one tool per operation of the specification in JSON
+// or YAML.
+func NewOpenAPI(spec []byte, optFns ...func(o *OpenAPIOptions)) (*OpenAPI, error) {
+	opts := OpenAPIOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	s, err := openapi.Parse(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	tools := []schema.Tool{}
+	names := map[string]bool{}
+
+	for _, op := range s.Operations() {
+		if len(opts.Operations) > 0 && !util.Contains(opts.Operations, op.ID) {
+			continue
+		}
+
+		t, err := tool.NewOpenAPI(s, op, func(o *tool.OpenAPIOptions) {
+			if opts.HTTPClient != nil {
+				o.HTTPClient = opts.HTTPClient
+			}
+
+			if opts.MaxResponseBytes > 0 {
+				o.MaxResponseBytes = opts.MaxResponseBytes
+			}
+
+			o.BaseURL = opts.BaseURL
+			o.Headers = opts.Headers
+			o.Credentials = opts.Credentials
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if names[t.Name()] {
+			return nil, fmt.Errorf("duplicate tool name of API operation %s: %s", op.ID, t.Name())
+		}
+
+		names[t.Name()] = true
+
+		tools = append(tools, t)
+	}
+
+	if len(tools) == 0 {
+		return nil, errors.New("no operations found in OpenAPI specification")
+	}
+
+	return &OpenAPI{
+		tools: tools,
+	}, nil
+}
+
+// Tools returns the list of schema.Tool objects associated with the OpenAPI toolkit.
+func (tk *OpenAPI) Tools() []schema.Tool {
+	return tk.tools
+}
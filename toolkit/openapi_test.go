@@ -0,0 +1,54 @@
+package toolkit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOpenAPI(t *testing.T) {
+	spec := []byte(`{
+		"openapi": "3.0.0",
+		"servers": [{"url": "https://api.example.com"}],
+		"paths": {
+			"/pets": {
+				"get": {"operationId": "listPets"},
+				"post": {"operationId": "createPet"}
+			},
+			"/pets/{petId}": {
+				"delete": {"operationId": "deletePet"}
+			}
+		}
+	}`)
+
+	t.Run("AllOperations", func(t *testing.T) {
+		toolkit, err := NewOpenAPI(spec)
+		require.NoError(t, err)
+
+		tools := toolkit.Tools()
+		assert.Len(t, tools, 3)
+
+		for _, name := range []string{"listPets", "createPet", "deletePet"} {
+			assertToolExists(t, tools, name)
+		}
+	})
+
+	t.Run("Operations", func(t *testing.T) {
+		toolkit, err := NewOpenAPI(spec, func(o *OpenAPIOptions) {
+			o.Operations = []string{"listPets"}
+		})
+		require.NoError(t, err)
+
+		tools := toolkit.Tools()
+		assert.Len(t, tools, 1)
+		assertToolExists(t, tools, "listPets")
+	})
+
+	t.Run("NoOperations", func(t *testing.T) {
+		_, err := NewOpenAPI(spec, func(o *OpenAPIOptions) {
+			o.Operations = []string{"unknown"}
+		})
+		assert.EqualError(t, err, "no operations found in OpenAPI specification")
+	})
+}
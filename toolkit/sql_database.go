@@ -0,0 +1,55 @@
+package toolkit
+
+import (
+	"github.com/hupe1980/golc/integration/sqldb"
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tool"
+)
+
+// SQLDatabaseOptions contains options for configuring the SQLDatabase toolkit.
+type SQLDatabaseOptions struct {
+	// ReadOnly allows only SELECT queries, which are executed in a read-only transaction.
+	ReadOnly bool
+	// MaxRows is the maximum number of rows returned to the agent. A value <= 0 disables the limit.
+	MaxRows int
+	// MaxColumns is the maximum number of columns returned to the agent. A value <= 0 disables the limit.
+	MaxColumns int
+}
+
+// SQLDatabase represents a collection of schema.Tool objects that enable an agent to answer questions about a database.
+type SQLDatabase struct {
+	tools []schema.Tool
+}
+
+// NewSQLDatabase creates a new SQLDatabase toolkit for the given database. Use the Tables and Exclude
+// options of the database to restrict the tables accessible by the agent.
+func NewSQLDatabase(db *sqldb.SQLDB, optFns ...func(o *SQLDatabaseOptions)) (*SQLDatabase, error) {
+	opts := SQLDatabaseOptions{
+		ReadOnly:   true,
+		MaxRows:    100,
+		MaxColumns: 20,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	tools := []schema.Tool{
+		tool.NewSQLListTables(db),
+		tool.NewSQLDescribeTables(db),
+		tool.NewSQLQuery(db, func(o *tool.SQLQueryOptions) {
+			o.ReadOnly = opts.ReadOnly
+			o.MaxRows = opts.MaxRows
+			o.MaxColumns = opts.MaxColumns
+		}),
+	}
+
+	return &SQLDatabase{
+		tools: tools,
+	}, nil
+}
+
+// Tools returns the list of schema.Tool objects associated with the SQLDatabase.
+func (tk *SQLDatabase) Tools() []schema.Tool {
+	return tk.tools
+}
@@ -0,0 +1,34 @@
+package toolkit
+
+import (
+	"testing"
+
+	"github.com/hupe1980/golc/integration/sqldb"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSQLDatabase(t *testing.T) {
+	engine, err := sqldb.NewSQLite3(":memory:")
+	require.NoError(t, err)
+
+	defer engine.Close()
+
+	db, err := sqldb.New(engine)
+	require.NoError(t, err)
+
+	sqlDatabase, err := NewSQLDatabase(db)
+	require.NoError(t, err)
+
+	expectedToolNames := []string{
+		"SQLListTables",
+		"SQLDescribeTables",
+		"SQLQuery",
+	}
+	tools := sqlDatabase.Tools()
+	require.Len(t, tools, len(expectedToolNames))
+
+	for _, name := range expectedToolNames {
+		assertToolExists(t, tools, name)
+	}
+}
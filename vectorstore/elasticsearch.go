@@ -0,0 +1,392 @@
+package vectorstore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hupe1980/golc/integration/elasticsearch"
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Elasticsearch satisfies the VectorStore interface.
+var _ schema.VectorStore = (*Elasticsearch)(nil)
+
+// Compile time check to ensure Elasticsearch satisfies the VectorStoreWithScores interface.
+var _ schema.VectorStoreWithScores = (*Elasticsearch)(nil)
+
+// ElasticsearchClient is an interface for interacting with the Elasticsearch or OpenSearch API.
+type ElasticsearchClient interface {
+	PutIndexTemplate(ctx context.Context, name string, template map[string]any) error
+	Bulk(ctx context.Context, index string, items []elasticsearch.BulkItem) (*elasticsearch.BulkResponse, error)
+	Search(ctx context.Context, index string, req map[string]any) (*elasticsearch.SearchResponse, error)
+	DeleteByQuery(ctx context.Context, index string, query map[string]any) (*elasticsearch.DeleteByQueryResponse, error)
+}
+
+// ElasticsearchEngine is the search engine behind the API.
+type ElasticsearchEngine string
+
+const (
+	ElasticsearchEngineElasticsearch ElasticsearchEngine = "elasticsearch"
+	ElasticsearchEngineOpenSearch    ElasticsearchEngine = "opensearch"
+)
+
+// ElasticsearchSimilarity is the similarity function of the vector field.
+type ElasticsearchSimilarity string
+
+const (
+	ElasticsearchSimilarityCosine     ElasticsearchSimilarity = "cosine"
+	ElasticsearchSimilarityDotProduct ElasticsearchSimilarity = "dot_product"
+	ElasticsearchSimilarityL2Norm     ElasticsearchSimilarity = "l2_norm"
+)
+
+// openSearchSpaceTypes maps the similarity functions to the space types of the OpenSearch k-NN plugin.
+var openSearchSpaceTypes = map[ElasticsearchSimilarity]string{
+	ElasticsearchSimilarityCosine:     "cosinesimil",
+	ElasticsearchSimilarityDotProduct: "innerproduct",
+	ElasticsearchSimilarityL2Norm:     "l2",
+}
+
+// ElasticsearchOptions contains options for the Elasticsearch vector store.
+type ElasticsearchOptions struct {
+	// Engine selects the query syntax of Elasticsearch or OpenSearch.
+	Engine ElasticsearchEngine
+	// TopK is the number of documents returned by a similarity search.
+	TopK int
+	// NumCandidates is the number of nearest neighbor candidates per shard of an Elasticsearch kNN search.
+	NumCandidates int
+	// ContentKey is the field of the page content.
+	ContentKey string
+	// VectorKey is the field of the vector.
+	VectorKey string
+	// MetadataKey is the field of the metadata.
+	MetadataKey string
+	// Similarity is the similarity function of the vector field.
+	Similarity ElasticsearchSimilarity
+	// Filter is a query DSL filter clause applied to each similarity search, e.g. {"term": {"metadata.source": "wiki"}}.
+	Filter map[string]any
+	// Hybrid combines the kNN search with a BM25 full-text search on the page content.
+	Hybrid bool
+	// Alpha weights the kNN score against the BM25 score of a hybrid search. 1 is a pure kNN search.
+	Alpha float32
+	// BatchSize is the number of documents per bulk request.
+	BatchSize int
+}
+
+// Elasticsearch is a vector store backed by the dense_vector kNN search of Elasticsearch
+// or the k-NN plugin of OpenSearch.
+type Elasticsearch struct {
+	client    ElasticsearchClient
+	embedder  schema.Embedder
+	indexName string
+	opts      ElasticsearchOptions
+}
+
+// NewElasticsearch creates a new Elasticsearch vector store for the given index.
+func NewElasticsearch(client ElasticsearchClient, embedder schema.Embedder, indexName string, optFns ...func(*ElasticsearchOptions)) (*Elasticsearch, error) {
+	opts := ElasticsearchOptions{
+		Engine:        ElasticsearchEngineElasticsearch,
+		TopK:          4,
+		NumCandidates: 50,
+		ContentKey:    "content",
+		VectorKey:     "vector",
+		MetadataKey:   "metadata",
+		Similarity:    ElasticsearchSimilarityCosine,
+		Alpha:         0.5,
+		BatchSize:     500,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.Engine != ElasticsearchEngineElasticsearch && opts.Engine != ElasticsearchEngineOpenSearch {
+		return nil, fmt.Errorf("unsupported engine: %s", opts.Engine)
+	}
+
+	if _, ok := openSearchSpaceTypes[opts.Similarity]; !ok {
+		return nil, fmt.Errorf("unsupported similarity: %s", opts.Similarity)
+	}
+
+	if opts.Alpha < 0 || opts.Alpha > 1 {
+		return nil, fmt.Errorf("alpha must be between 0 and 1, got %v", opts.Alpha)
+	}
+
+	return &Elasticsearch{
+		client:    client,
+		embedder:  embedder,
+		indexName: indexName,
+		opts:      opts,
+	}, nil
+}
+
+// PutIndexTemplate creates or updates an index template named after the index, which maps the vector
+// field with the given dimensions and the string metadata fields as keywords for exact filtering.
+// The template is applied when the index is created by the first AddDocuments call.
+func (vs *Elasticsearch) PutIndexTemplate(ctx context.Context, dims int) error {
+	vectorMapping := map[string]any{
+		"type":       "dense_vector",
+		"dims":       dims,
+		"index":      true,
+		"similarity": string(vs.opts.Similarity),
+	}
+
+	settings := map[string]any{}
+
+	if vs.opts.Engine == ElasticsearchEngineOpenSearch {
+		vectorMapping = map[string]any{
+			"type":      "knn_vector",
+			"dimension": dims,
+			"method": map[string]any{
+				"name":       "hnsw",
+				"space_type": openSearchSpaceTypes[vs.opts.Similarity],
+			},
+		}
+
+		settings["index.knn"] = true
+	}
+
+	return vs.client.PutIndexTemplate(ctx, vs.indexName, map[string]any{
+		"index_patterns": []string{vs.indexName},
+		"template": map[string]any{
+			"settings": settings,
+			"mappings": map[string]any{
+				"dynamic_templates": []any{
+					map[string]any{
+						"metadata_strings": map[string]any{
+							"path_match":         vs.opts.MetadataKey + ".*",
+							"match_mapping_type": "string",
+							"mapping":            map[string]any{"type": "keyword"},
+						},
+					},
+				},
+				"properties": map[string]any{
+					vs.opts.ContentKey:  map[string]any{"type": "text"},
+					vs.opts.VectorKey:   vectorMapping,
+					vs.opts.MetadataKey: map[string]any{"type": "object"},
+				},
+			},
+		},
+	})
+}
+
+// AddDocuments embeds the documents and indexes them in batches.
+func (vs *Elasticsearch) AddDocuments(ctx context.Context, docs []schema.Document) error {
+	return vs.AddDocumentsWithIDs(ctx, newIDs(len(docs)), docs)
+}
+
+// AddDocumentsWithIDs embeds the documents and indexes them with the given ids in batches.
+// Existing documents with the same ids are replaced.
+func (vs *Elasticsearch) AddDocumentsWithIDs(ctx context.Context, ids []string, docs []schema.Document) error {
+	if len(ids) != len(docs) {
+		return ErrIDsDocumentsMismatch
+	}
+
+	texts := make([]string, len(docs))
+	for i, doc := range docs {
+		texts[i] = doc.PageContent
+	}
+
+	vectors, err := vs.embedder.BatchEmbedText(ctx, texts)
+	if err != nil {
+		return err
+	}
+
+	items := make([]elasticsearch.BulkItem, len(docs))
+
+	for i, doc := range docs {
+		items[i] = elasticsearch.BulkItem{
+			ID: ids[i],
+			Document: map[string]any{
+				vs.opts.ContentKey:  doc.PageContent,
+				vs.opts.VectorKey:   vectors[i],
+				vs.opts.MetadataKey: doc.Metadata,
+			},
+		}
+	}
+
+	for start := 0; start < len(items); start += vs.opts.BatchSize {
+		end := util.Min(start+vs.opts.BatchSize, len(items))
+
+		res, err := vs.client.Bulk(ctx, vs.indexName, items[start:end])
+		if err != nil {
+			return err
+		}
+
+		if res.Errors {
+			for _, item := range res.Items {
+				for _, result := range item {
+					if result.Error != nil {
+						return fmt.Errorf("failed to index document %s: %s", result.ID, result.Error.Reason)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Delete deletes the documents with the given ids from the index.
+func (vs *Elasticsearch) Delete(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	for _, batch := range util.ChunkBy(ids, deleteBatchSize) {
+		if _, err := vs.client.DeleteByQuery(ctx, vs.indexName, map[string]any{
+			"ids": map[string]any{"values": batch},
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteByFilter deletes the documents whose metadata matches all key-value pairs of the filter from the index.
+// String metadata fields have to be mapped as keywords, as done by PutIndexTemplate.
+func (vs *Elasticsearch) DeleteByFilter(ctx context.Context, filter map[string]any) error {
+	terms := make([]any, 0, len(filter))
+	for key, value := range filter {
+		terms = append(terms, map[string]any{
+			"term": map[string]any{fmt.Sprintf("%s.%s", vs.opts.MetadataKey, key): value},
+		})
+	}
+
+	_, err := vs.client.DeleteByQuery(ctx, vs.indexName, map[string]any{
+		"bool": map[string]any{"filter": terms},
+	})
+
+	return err
+}
+
+// SimilaritySearch performs a similarity search with the given query in the index.
+func (vs *Elasticsearch) SimilaritySearch(ctx context.Context, query string) ([]schema.Document, error) {
+	scoredDocs, err := vs.SimilaritySearchWithScores(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, len(scoredDocs))
+	for i, d := range scoredDocs {
+		docs[i] = d.Document
+	}
+
+	return docs, nil
+}
+
+// SimilaritySearchWithScores performs a similarity search with the given query in the index
+// and returns the documents together with their scores.
+func (vs *Elasticsearch) SimilaritySearchWithScores(ctx context.Context, query string) ([]schema.ScoredDocument, error) {
+	vector, err := vs.embedder.EmbedText(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := vs.client.Search(ctx, vs.indexName, vs.searchRequest(query, vector))
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.ScoredDocument, len(res.Hits.Hits))
+
+	for i, hit := range res.Hits.Hits {
+		pageContent, ok := hit.Source[vs.opts.ContentKey].(string)
+		if !ok {
+			return nil, fmt.Errorf("no content for contentKey %s", vs.opts.ContentKey)
+		}
+
+		metadata, _ := hit.Source[vs.opts.MetadataKey].(map[string]any)
+
+		docs[i] = schema.ScoredDocument{
+			Document: schema.Document{
+				PageContent: pageContent,
+				Metadata:    metadata,
+			},
+			Score: hit.Score,
+		}
+	}
+
+	return docs, nil
+}
+
+// searchRequest returns the body of the search request for the query and its vector.
+func (vs *Elasticsearch) searchRequest(query string, vector []float32) map[string]any {
+	req := map[string]any{
+		"size":    vs.opts.TopK,
+		"_source": []string{vs.opts.ContentKey, vs.opts.MetadataKey},
+	}
+
+	textQuery := map[string]any{
+		"match": map[string]any{
+			vs.opts.ContentKey: map[string]any{
+				"query": query,
+				"boost": 1 - vs.opts.Alpha,
+			},
+		},
+	}
+
+	if vs.opts.Engine == ElasticsearchEngineOpenSearch {
+		knnParams := map[string]any{
+			"vector": vector,
+			"k":      vs.opts.TopK,
+		}
+
+		if !vs.opts.Hybrid {
+			if vs.opts.Filter != nil {
+				knnParams["filter"] = vs.opts.Filter
+			}
+
+			req["query"] = map[string]any{"knn": map[string]any{vs.opts.VectorKey: knnParams}}
+
+			return req
+		}
+
+		knnParams["boost"] = vs.opts.Alpha
+
+		boolQuery := map[string]any{
+			"should": []any{
+				textQuery,
+				map[string]any{"knn": map[string]any{vs.opts.VectorKey: knnParams}},
+			},
+		}
+
+		if vs.opts.Filter != nil {
+			boolQuery["filter"] = vs.opts.Filter
+		}
+
+		req["query"] = map[string]any{"bool": boolQuery}
+
+		return req
+	}
+
+	knn := map[string]any{
+		"field":          vs.opts.VectorKey,
+		"query_vector":   vector,
+		"k":              vs.opts.TopK,
+		"num_candidates": util.Max(vs.opts.NumCandidates, vs.opts.TopK),
+	}
+
+	if vs.opts.Filter != nil {
+		knn["filter"] = vs.opts.Filter
+	}
+
+	if vs.opts.Hybrid {
+		knn["boost"] = vs.opts.Alpha
+
+		boolQuery := map[string]any{
+			"must": textQuery,
+		}
+
+		if vs.opts.Filter != nil {
+			boolQuery["filter"] = vs.opts.Filter
+		}
+
+		req["query"] = map[string]any{"bool": boolQuery}
+	}
+
+	req["knn"] = knn
+
+	return req
+}
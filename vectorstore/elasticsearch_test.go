@@ -0,0 +1,203 @@
+package vectorstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc/integration/elasticsearch"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestElasticsearch(t *testing.T) {
+	t.Run("PutIndexTemplate", func(t *testing.T) {
+		client := &mockElasticsearchClient{}
+
+		vs, err := NewElasticsearch(client, &mockEmbedder{}, "docs", func(o *ElasticsearchOptions) {
+			o.Engine = ElasticsearchEngineOpenSearch
+		})
+		assert.NoError(t, err)
+
+		err = vs.PutIndexTemplate(context.Background(), 3)
+		assert.NoError(t, err)
+
+		template, _ := client.template["template"].(map[string]any)
+		assert.Equal(t, map[string]any{"index.knn": true}, template["settings"])
+
+		properties, _ := template["mappings"].(map[string]any)["properties"].(map[string]any)
+		assert.Equal(t, "knn_vector", properties["vector"].(map[string]any)["type"])
+		assert.Equal(t, 3, properties["vector"].(map[string]any)["dimension"])
+	})
+
+	t.Run("AddDocuments", func(t *testing.T) {
+		client := &mockElasticsearchClient{}
+
+		vs, err := NewElasticsearch(client, &mockEmbedder{}, "docs", func(o *ElasticsearchOptions) {
+			o.BatchSize = 2
+		})
+		assert.NoError(t, err)
+
+		err = vs.AddDocuments(context.Background(), []schema.Document{
+			{PageContent: "document1", Metadata: map[string]any{"source": "a"}},
+			{PageContent: "document2"},
+			{PageContent: "document3"},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, client.bulkItems, 2)
+		assert.Equal(t, map[string]any{
+			"content":  "document1",
+			"vector":   []float32{1.0, 2.0, 3.0},
+			"metadata": map[string]any{"source": "a"},
+		}, client.bulkItems[0][0].Document)
+	})
+
+	t.Run("AddDocumentsWithIDs", func(t *testing.T) {
+		client := &mockElasticsearchClient{}
+
+		vs, err := NewElasticsearch(client, &mockEmbedder{}, "docs")
+		assert.NoError(t, err)
+
+		err = vs.AddDocumentsWithIDs(context.Background(), []string{"a", "b"}, []schema.Document{
+			{PageContent: "document1"},
+			{PageContent: "document2"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "a", client.bulkItems[0][0].ID)
+		assert.Equal(t, "b", client.bulkItems[0][1].ID)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		client := &mockElasticsearchClient{}
+
+		vs, err := NewElasticsearch(client, &mockEmbedder{}, "docs")
+		assert.NoError(t, err)
+
+		err = vs.Delete(context.Background(), []string{"a", "b"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"ids": map[string]any{"values": []string{"a", "b"}}}, client.deleteQuery)
+
+		err = vs.DeleteByFilter(context.Background(), map[string]any{"source": "a"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"bool": map[string]any{"filter": []any{
+			map[string]any{"term": map[string]any{"metadata.source": "a"}},
+		}}}, client.deleteQuery)
+	})
+
+	t.Run("AddDocumentsWithError", func(t *testing.T) {
+		client := &mockElasticsearchClient{
+			bulkResponse: &elasticsearch.BulkResponse{
+				Errors: true,
+				Items: []map[string]elasticsearch.BulkItemResult{
+					{"index": {ID: "1", Status: 400, Error: &elasticsearch.Error{Type: "mapper_parsing_exception", Reason: "failed to parse"}}},
+				},
+			},
+		}
+
+		vs, err := NewElasticsearch(client, &mockEmbedder{}, "docs")
+		assert.NoError(t, err)
+
+		err = vs.AddDocuments(context.Background(), []schema.Document{{PageContent: "document1"}})
+		assert.EqualError(t, err, "failed to index document 1: failed to parse")
+	})
+
+	t.Run("HybridSearch", func(t *testing.T) {
+		client := &mockElasticsearchClient{
+			searchResponse: &elasticsearch.SearchResponse{},
+		}
+		client.searchResponse.Hits.Hits = []elasticsearch.Hit{
+			{ID: "1", Score: 1.5, Source: map[string]any{"content": "document1", "metadata": map[string]any{"source": "a"}}},
+		}
+
+		filter := map[string]any{"term": map[string]any{"metadata.source": "a"}}
+
+		vs, err := NewElasticsearch(client, &mockEmbedder{}, "docs", func(o *ElasticsearchOptions) {
+			o.Hybrid = true
+			o.Alpha = 0.75
+			o.Filter = filter
+		})
+		assert.NoError(t, err)
+
+		docs, err := vs.SimilaritySearchWithScores(context.Background(), "query")
+		assert.NoError(t, err)
+		assert.Equal(t, []schema.ScoredDocument{
+			{Document: schema.Document{PageContent: "document1", Metadata: map[string]any{"source": "a"}}, Score: 1.5},
+		}, docs)
+
+		assert.Equal(t, map[string]any{
+			"field":          "vector",
+			"query_vector":   []float32{1.0, 2.0, 3.0},
+			"k":              4,
+			"num_candidates": 50,
+			"filter":         filter,
+			"boost":          float32(0.75),
+		}, client.searchRequest["knn"])
+		assert.Equal(t, map[string]any{
+			"bool": map[string]any{
+				"must": map[string]any{
+					"match": map[string]any{"content": map[string]any{"query": "query", "boost": float32(0.25)}},
+				},
+				"filter": filter,
+			},
+		}, client.searchRequest["query"])
+	})
+
+	t.Run("OpenSearchKNN", func(t *testing.T) {
+		client := &mockElasticsearchClient{
+			searchResponse: &elasticsearch.SearchResponse{},
+		}
+
+		vs, err := NewElasticsearch(client, &mockEmbedder{}, "docs", func(o *ElasticsearchOptions) {
+			o.Engine = ElasticsearchEngineOpenSearch
+		})
+		assert.NoError(t, err)
+
+		_, err = vs.SimilaritySearch(context.Background(), "query")
+		assert.NoError(t, err)
+		assert.Nil(t, client.searchRequest["knn"])
+		assert.Equal(t, map[string]any{
+			"knn": map[string]any{"vector": map[string]any{"vector": []float32{1.0, 2.0, 3.0}, "k": 4}},
+		}, client.searchRequest["query"])
+	})
+
+	t.Run("InvalidOptions", func(t *testing.T) {
+		_, err := NewElasticsearch(&mockElasticsearchClient{}, &mockEmbedder{}, "docs", func(o *ElasticsearchOptions) {
+			o.Similarity = "hamming"
+		})
+		assert.Error(t, err)
+	})
+}
+
+// mockElasticsearchClient is a mock implementation of the ElasticsearchClient interface.
+type mockElasticsearchClient struct {
+	template       map[string]any
+	bulkItems      [][]elasticsearch.BulkItem
+	bulkResponse   *elasticsearch.BulkResponse
+	searchRequest  map[string]any
+	searchResponse *elasticsearch.SearchResponse
+	deleteQuery    map[string]any
+}
+
+func (m *mockElasticsearchClient) PutIndexTemplate(ctx context.Context, name string, template map[string]any) error {
+	m.template = template
+	return nil
+}
+
+func (m *mockElasticsearchClient) Bulk(ctx context.Context, index string, items []elasticsearch.BulkItem) (*elasticsearch.BulkResponse, error) {
+	m.bulkItems = append(m.bulkItems, items)
+
+	if m.bulkResponse != nil {
+		return m.bulkResponse, nil
+	}
+
+	return &elasticsearch.BulkResponse{}, nil
+}
+
+func (m *mockElasticsearchClient) Search(ctx context.Context, index string, req map[string]any) (*elasticsearch.SearchResponse, error) {
+	m.searchRequest = req
+	return m.searchResponse, nil
+}
+
+func (m *mockElasticsearchClient) DeleteByQuery(ctx context.Context, index string, query map[string]any) (*elasticsearch.DeleteByQueryResponse, error) {
+	m.deleteQuery = query
+	return &elasticsearch.DeleteByQueryResponse{}, nil
+}
@@ -0,0 +1,655 @@
+package vectorstore
+
+import (
+	"container/heap"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/metric"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure HNSW satisfies the VectorStore interface.
+var _ schema.VectorStore = (*HNSW)(nil)
+
+// Compile time check to ensure HNSW satisfies the VectorStoreWithScores interface.
+var _ schema.VectorStoreWithScores = (*HNSW)(nil)
+
+// Compile time check to ensure HNSW satisfies the VectorStoreWithMMR interface.
+var _ schema.VectorStoreWithMMR = (*HNSW)(nil)
+
+// HNSWNode represents a document stored in the HNSW graph with its neighbors per layer.
+// Deleted nodes remain in the graph to keep it navigable, but are excluded from search results,
+// until the graph is compacted.
+type HNSWNode struct {
+	ID        string         `json:"id"`
+	Content   string         `json:"content"`
+	Vector    []float32      `json:"vector"`
+	Metadata  map[string]any `json:"metadata"`
+	Neighbors [][]int        `json:"neighbors"`
+	Deleted   bool           `json:"deleted"`
+}
+
+// HNSWOptions represents options for the HNSW vector store.
+type HNSWOptions struct {
+	// TopK is the number of documents returned by a similarity search.
+	TopK int
+	// M is the maximum number of neighbors of a node per layer. The bottom layer allows 2*M neighbors.
+	M int
+	// EfConstruction is the size of the candidate list while inserting documents. Higher values
+	// improve the quality of the graph at the cost of a slower insertion.
+	EfConstruction int
+	// EfSearch is the size of the candidate list while searching. Higher values improve the recall
+	// at the cost of a slower search. At least TopK candidates are considered.
+	EfSearch int
+	// DistanceFunc calculates the distance between two vectors.
+	DistanceFunc DistanceFunc
+	// RelevanceScoreFunc converts the distance of a document to the query into its similarity score.
+	RelevanceScoreFunc RelevanceScoreFunc
+	// Seed initializes the random level generator, which makes the graph reproducible.
+	Seed int64
+	// CompactionThreshold is the ratio of deleted nodes above which the graph is rebuilt without them
+	// after a deletion. A threshold of zero disables the automatic compaction.
+	CompactionThreshold float64
+}
+
+// HNSW represents an in-memory vector store indexed by a Hierarchical Navigable Small World graph.
+// Unlike the InMemory vector store, which compares the query with every document, the approximate
+// nearest neighbor search of HNSW scales to tens of thousands of documents with millisecond queries.
+type HNSW struct {
+	embedder   schema.Embedder
+	nodes      []HNSWNode
+	ids        map[string]int
+	deleted    int
+	entryPoint int
+	maxLevel   int
+	levelMult  float64
+	rng        *rand.Rand
+	opts       HNSWOptions
+	mu         sync.RWMutex
+}
+
+// NewHNSW creates a new instance of the HNSW vector store.
+// It returns an error if M is less than 2 or EfConstruction or EfSearch are not positive.
+func NewHNSW(embedder schema.Embedder, optFns ...func(*HNSWOptions)) (*HNSW, error) {
+	opts := HNSWOptions{
+		TopK:           3,
+		M:              16,
+		EfConstruction: 200,
+		EfSearch:       50,
+		DistanceFunc:   metric.SquaredL2,
+		RelevanceScoreFunc: func(distance float32) float32 {
+			return 1 / (1 + distance)
+		},
+		Seed:                1,
+		CompactionThreshold: 0.5,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.M < 2 {
+		return nil, fmt.Errorf("m must be at least 2, got %d", opts.M)
+	}
+
+	if opts.EfConstruction <= 0 {
+		return nil, fmt.Errorf("efConstruction must be positive, got %d", opts.EfConstruction)
+	}
+
+	if opts.EfSearch <= 0 {
+		return nil, fmt.Errorf("efSearch must be positive, got %d", opts.EfSearch)
+	}
+
+	return &HNSW{
+		embedder:   embedder,
+		nodes:      make([]HNSWNode, 0),
+		ids:        make(map[string]int),
+		entryPoint: -1,
+		levelMult:  1 / math.Log(float64(opts.M)),
+		rng:        rand.New(rand.NewSource(opts.Seed)), // nolint gosec G404
+		opts:       opts,
+	}, nil
+}
+
+// AddDocuments embeds the documents and inserts them into the graph.
+func (vs *HNSW) AddDocuments(ctx context.Context, docs []schema.Document) error {
+	return vs.AddDocumentsWithIDs(ctx, newIDs(len(docs)), docs)
+}
+
+// AddDocumentsWithIDs embeds the documents and inserts them with the given ids into the graph.
+// Existing documents with the same ids are replaced.
+func (vs *HNSW) AddDocumentsWithIDs(ctx context.Context, ids []string, docs []schema.Document) error {
+	if len(ids) != len(docs) {
+		return ErrIDsDocumentsMismatch
+	}
+
+	texts := make([]string, len(docs))
+	for i, doc := range docs {
+		texts[i] = doc.PageContent
+	}
+
+	vectors, err := vs.embedder.BatchEmbedText(ctx, texts)
+	if err != nil {
+		return err
+	}
+
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	for i, doc := range docs {
+		vs.delete(ids[i])
+
+		if err := vs.insert(HNSWNode{
+			ID:       ids[i],
+			Content:  doc.PageContent,
+			Vector:   vectors[i],
+			Metadata: doc.Metadata,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return vs.compactIfNeeded()
+}
+
+// Len returns the number of documents stored in the HNSW vector store.
+func (vs *HNSW) Len() int {
+	vs.mu.RLock()
+	defer vs.mu.RUnlock()
+
+	return len(vs.nodes) - vs.deleted
+}
+
+// Delete deletes the documents with the given ids from the HNSW vector store.
+func (vs *HNSW) Delete(ctx context.Context, ids []string) error {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	for _, id := range ids {
+		vs.delete(id)
+	}
+
+	return vs.compactIfNeeded()
+}
+
+// DeleteByFilter deletes the documents whose metadata matches all key-value pairs of the filter
+// from the HNSW vector store.
+func (vs *HNSW) DeleteByFilter(ctx context.Context, filter map[string]any) error {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	for _, node := range vs.nodes {
+		if !node.Deleted && matchMetadata(node.Metadata, filter) {
+			vs.delete(node.ID)
+		}
+	}
+
+	return vs.compactIfNeeded()
+}
+
+// Compact rebuilds the graph without the deleted nodes.
+func (vs *HNSW) Compact() error {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	return vs.compact()
+}
+
+// SimilaritySearch performs an approximate nearest neighbor search with the given query.
+func (vs *HNSW) SimilaritySearch(ctx context.Context, query string) ([]schema.Document, error) {
+	scoredDocs, err := vs.SimilaritySearchWithScores(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, len(scoredDocs))
+	for i, d := range scoredDocs {
+		docs[i] = d.Document
+	}
+
+	return docs, nil
+}
+
+// SimilaritySearchWithScores performs an approximate nearest neighbor search with the given query and
+// returns the documents together with their similarity scores.
+func (vs *HNSW) SimilaritySearchWithScores(ctx context.Context, query string) ([]schema.ScoredDocument, error) {
+	queryVector, err := vs.embedder.EmbedText(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	vs.mu.RLock()
+	defer vs.mu.RUnlock()
+
+	candidates, err := vs.nearest(queryVector, vs.opts.TopK)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.ScoredDocument, len(candidates))
+
+	for i, c := range candidates {
+		node := vs.nodes[c.id]
+
+		docs[i] = schema.ScoredDocument{
+			Document: schema.Document{
+				PageContent: node.Content,
+				Metadata:    node.Metadata,
+			},
+			Score: vs.opts.RelevanceScoreFunc(c.distance),
+		}
+	}
+
+	return docs, nil
+}
+
+// MaxMarginalRelevanceSearch fetches the fetchK documents nearest to the query and selects TopK of them by
+// maximal marginal relevance.
+func (vs *HNSW) MaxMarginalRelevanceSearch(ctx context.Context, query string, fetchK int, lambdaMult float32) ([]schema.Document, error) {
+	queryVector, err := vs.embedder.EmbedText(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	vs.mu.RLock()
+	defer vs.mu.RUnlock()
+
+	candidates, err := vs.nearest(queryVector, util.Max(fetchK, vs.opts.TopK))
+	if err != nil {
+		return nil, err
+	}
+
+	vectors := make([][]float32, len(candidates))
+	for i, c := range candidates {
+		vectors[i] = vs.nodes[c.id].Vector
+	}
+
+	selected, err := maxMarginalRelevance(queryVector, vectors, vs.opts.TopK, lambdaMult)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, len(selected))
+
+	for i, index := range selected {
+		node := vs.nodes[candidates[index].id]
+
+		docs[i] = schema.Document{
+			PageContent: node.Content,
+			Metadata:    node.Metadata,
+		}
+	}
+
+	return docs, nil
+}
+
+// nearest returns up to k nodes, which are not deleted, nearest to the query vector sorted by their distance.
+func (vs *HNSW) nearest(queryVector []float32, k int) ([]hnswCandidate, error) {
+	if len(vs.nodes) == vs.deleted {
+		return []hnswCandidate{}, nil
+	}
+
+	ep, err := vs.greedySearch(queryVector, vs.entryPoint, vs.maxLevel, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deleted nodes are visited, but not returned, so the candidate list is extended by their number,
+	// up to twice its size. The compaction keeps the share of deleted nodes in the graph bounded.
+	ef := util.Max(vs.opts.EfSearch, k)
+	ef += util.Min(vs.deleted, ef)
+
+	candidates, err := vs.searchLayer(queryVector, []int{ep}, ef, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	nearest := make([]hnswCandidate, 0, k)
+
+	for _, c := range candidates {
+		if len(nearest) == k {
+			break
+		}
+
+		if !vs.nodes[c.id].Deleted {
+			nearest = append(nearest, c)
+		}
+	}
+
+	return nearest, nil
+}
+
+// hnswData is the persisted state of the HNSW vector store.
+type hnswData struct {
+	Nodes      []HNSWNode
+	EntryPoint int
+	MaxLevel   int
+}
+
+// Load loads the graph from an io.Reader.
+func (vs *HNSW) Load(r io.Reader) error {
+	data := hnswData{}
+
+	if err := gob.NewDecoder(r).Decode(&data); err != nil {
+		return err
+	}
+
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	vs.nodes = data.Nodes
+	vs.entryPoint = data.EntryPoint
+	vs.maxLevel = data.MaxLevel
+	vs.ids = make(map[string]int, len(data.Nodes))
+	vs.deleted = 0
+
+	for i, node := range vs.nodes {
+		if node.Deleted {
+			vs.deleted++
+			continue
+		}
+
+		vs.ids[node.ID] = i
+	}
+
+	return nil
+}
+
+// Save saves the graph to an io.Writer. Only the vectors and neighbors of deleted nodes are saved.
+func (vs *HNSW) Save(w io.Writer) error {
+	vs.mu.RLock()
+	defer vs.mu.RUnlock()
+
+	nodes := make([]HNSWNode, len(vs.nodes))
+
+	for i, node := range vs.nodes {
+		if node.Deleted {
+			node = HNSWNode{
+				Vector:    node.Vector,
+				Neighbors: node.Neighbors,
+				Deleted:   true,
+			}
+		}
+
+		nodes[i] = node
+	}
+
+	return gob.NewEncoder(w).Encode(hnswData{
+		Nodes:      nodes,
+		EntryPoint: vs.entryPoint,
+		MaxLevel:   vs.maxLevel,
+	})
+}
+
+// insert adds the node to the graph.
+func (vs *HNSW) insert(node HNSWNode) error {
+	level := int(math.Floor(-math.Log(1-vs.rng.Float64()) * vs.levelMult))
+
+	node.Neighbors = make([][]int, level+1)
+	id := len(vs.nodes)
+	vs.nodes = append(vs.nodes, node)
+	vs.ids[node.ID] = id
+
+	if vs.entryPoint == -1 {
+		vs.entryPoint = id
+		vs.maxLevel = level
+
+		return nil
+	}
+
+	ep, err := vs.greedySearch(node.Vector, vs.entryPoint, vs.maxLevel, level+1)
+	if err != nil {
+		return err
+	}
+
+	eps := []int{ep}
+
+	for lc := util.Min(level, vs.maxLevel); lc >= 0; lc-- {
+		candidates, err := vs.searchLayer(node.Vector, eps, vs.opts.EfConstruction, lc)
+		if err != nil {
+			return err
+		}
+
+		neighbors := candidates
+		if len(neighbors) > vs.opts.M {
+			neighbors = neighbors[:vs.opts.M]
+		}
+
+		for _, n := range neighbors {
+			vs.nodes[id].Neighbors[lc] = append(vs.nodes[id].Neighbors[lc], n.id)
+			vs.nodes[n.id].Neighbors[lc] = append(vs.nodes[n.id].Neighbors[lc], id)
+
+			if err := vs.prune(n.id, lc); err != nil {
+				return err
+			}
+		}
+
+		eps = make([]int, len(candidates))
+		for i, c := range candidates {
+			eps[i] = c.id
+		}
+	}
+
+	if level > vs.maxLevel {
+		vs.entryPoint = id
+		vs.maxLevel = level
+	}
+
+	return nil
+}
+
+// delete marks the node with the id as deleted.
+func (vs *HNSW) delete(id string) {
+	i, ok := vs.ids[id]
+	if !ok {
+		return
+	}
+
+	vs.nodes[i].Deleted = true
+	vs.deleted++
+
+	delete(vs.ids, id)
+}
+
+// compactIfNeeded compacts the graph, if the ratio of deleted nodes exceeds the compaction threshold.
+func (vs *HNSW) compactIfNeeded() error {
+	if vs.opts.CompactionThreshold <= 0 || vs.deleted == 0 {
+		return nil
+	}
+
+	if float64(vs.deleted)/float64(len(vs.nodes)) <= vs.opts.CompactionThreshold {
+		return nil
+	}
+
+	return vs.compact()
+}
+
+// compact reinserts the nodes, which are not deleted, into a new graph.
+func (vs *HNSW) compact() error {
+	nodes := vs.nodes
+
+	vs.nodes = make([]HNSWNode, 0, len(nodes)-vs.deleted)
+	vs.ids = make(map[string]int, len(nodes)-vs.deleted)
+	vs.deleted = 0
+	vs.entryPoint = -1
+	vs.maxLevel = 0
+
+	for _, node := range nodes {
+		if node.Deleted {
+			continue
+		}
+
+		if err := vs.insert(HNSWNode{
+			ID:       node.ID,
+			Content:  node.Content,
+			Vector:   node.Vector,
+			Metadata: node.Metadata,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// prune keeps the closest neighbors of the node on the layer, if it has more than allowed.
+func (vs *HNSW) prune(id, level int) error {
+	maxNeighbors := vs.opts.M
+	if level == 0 {
+		maxNeighbors = 2 * vs.opts.M
+	}
+
+	neighbors := vs.nodes[id].Neighbors[level]
+	if len(neighbors) <= maxNeighbors {
+		return nil
+	}
+
+	candidates := make([]hnswCandidate, len(neighbors))
+
+	for i, n := range neighbors {
+		distance, err := vs.opts.DistanceFunc(vs.nodes[id].Vector, vs.nodes[n].Vector)
+		if err != nil {
+			return err
+		}
+
+		candidates[i] = hnswCandidate{id: n, distance: distance}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	pruned := make([]int, maxNeighbors)
+	for i := range pruned {
+		pruned[i] = candidates[i].id
+	}
+
+	vs.nodes[id].Neighbors[level] = pruned
+
+	return nil
+}
+
+// greedySearch descends from the top layer to the target layer, moving to the closest node on each layer.
+func (vs *HNSW) greedySearch(vector []float32, ep, fromLevel, toLevel int) (int, error) {
+	for lc := fromLevel; lc >= toLevel; lc-- {
+		candidates, err := vs.searchLayer(vector, []int{ep}, 1, lc)
+		if err != nil {
+			return 0, err
+		}
+
+		ep = candidates[0].id
+	}
+
+	return ep, nil
+}
+
+// searchLayer returns up to ef nodes of the layer closest to the vector, sorted by their distance.
+func (vs *HNSW) searchLayer(vector []float32, eps []int, ef, level int) ([]hnswCandidate, error) {
+	visited := make(map[int]bool, ef*2)
+	candidates := &hnswHeap{}
+	results := &hnswHeap{max: true}
+
+	for _, ep := range eps {
+		distance, err := vs.opts.DistanceFunc(vector, vs.nodes[ep].Vector)
+		if err != nil {
+			return nil, err
+		}
+
+		visited[ep] = true
+
+		heap.Push(candidates, hnswCandidate{id: ep, distance: distance})
+		heap.Push(results, hnswCandidate{id: ep, distance: distance})
+
+		if results.Len() > ef {
+			heap.Pop(results)
+		}
+	}
+
+	for candidates.Len() > 0 {
+		c, _ := heap.Pop(candidates).(hnswCandidate)
+
+		if results.Len() >= ef && c.distance > results.items[0].distance {
+			break
+		}
+
+		for _, n := range vs.nodes[c.id].Neighbors[level] {
+			if visited[n] {
+				continue
+			}
+
+			visited[n] = true
+
+			distance, err := vs.opts.DistanceFunc(vector, vs.nodes[n].Vector)
+			if err != nil {
+				return nil, err
+			}
+
+			if results.Len() < ef || distance < results.items[0].distance {
+				heap.Push(candidates, hnswCandidate{id: n, distance: distance})
+				heap.Push(results, hnswCandidate{id: n, distance: distance})
+
+				if results.Len() > ef {
+					heap.Pop(results)
+				}
+			}
+		}
+	}
+
+	sorted := results.items
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].distance < sorted[j].distance
+	})
+
+	return sorted, nil
+}
+
+// hnswCandidate is a node with its distance to the searched vector.
+type hnswCandidate struct {
+	id       int
+	distance float32
+}
+
+// hnswHeap is a min-heap, or with max set a max-heap, of candidates ordered by their distance.
+type hnswHeap struct {
+	items []hnswCandidate
+	max   bool
+}
+
+// Len returns the length of the heap.
+func (h hnswHeap) Len() int { return len(h.items) }
+
+// Less reports whether the element with index i should sort before the element with index j.
+func (h hnswHeap) Less(i, j int) bool {
+	if h.max {
+		return h.items[i].distance > h.items[j].distance
+	}
+
+	return h.items[i].distance < h.items[j].distance
+}
+
+// Swap swaps the elements with indexes i and j.
+func (h hnswHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+
+// Push adds an item to the heap.
+func (h *hnswHeap) Push(x any) {
+	item, _ := x.(hnswCandidate)
+	h.items = append(h.items, item)
+}
+
+// Pop removes and returns the top item of the heap.
+func (h *hnswHeap) Pop() any {
+	old := h.items
+	n := len(old)
+	item := old[n-1]
+	h.items = old[:n-1]
+
+	return item
+}
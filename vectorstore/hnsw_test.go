@@ -0,0 +1,267 @@
+package vectorstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hupe1980/golc/metric"
+	"github.com/hupe1980/golc/schema"
+)
+
+func TestHNSW(t *testing.T) {
+	rng := rand.New(rand.NewSource(42)) // nolint gosec G404
+
+	embedder := &mapEmbedder{vectors: make(map[string][]float32)}
+	docs := make([]schema.Document, 2000)
+
+	for i := range docs {
+		vector := make([]float32, 16)
+		for j := range vector {
+			vector[j] = rng.Float32()
+		}
+
+		text := fmt.Sprintf("document%d", i)
+		embedder.vectors[text] = vector
+		docs[i] = schema.Document{PageContent: text, Metadata: map[string]any{"index": i}}
+	}
+
+	vs, err := NewHNSW(embedder, func(o *HNSWOptions) {
+		o.TopK = 10
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, vs.AddDocuments(context.Background(), docs))
+	assert.Equal(t, 2000, vs.Len())
+
+	t.Run("Recall", func(t *testing.T) {
+		hits := 0
+
+		for q := 0; q < 20; q++ {
+			query := docs[rng.Intn(len(docs))].PageContent
+
+			result, err := vs.SimilaritySearch(context.Background(), query)
+			require.NoError(t, err)
+			require.Len(t, result, 10)
+			assert.Equal(t, query, result[0].PageContent)
+
+			expected := bruteForce(t, embedder, query, 10)
+
+			for _, doc := range result {
+				if expected[doc.PageContent] {
+					hits++
+				}
+			}
+		}
+
+		assert.GreaterOrEqual(t, float64(hits)/200, 0.9)
+	})
+
+	t.Run("Scores", func(t *testing.T) {
+		result, err := vs.SimilaritySearchWithScores(context.Background(), "document7")
+		require.NoError(t, err)
+		assert.Equal(t, float32(1), result[0].Score)
+		assert.Equal(t, 7, result[0].Document.Metadata["index"])
+
+		for i := 1; i < len(result); i++ {
+			assert.LessOrEqual(t, result[i].Score, result[i-1].Score)
+		}
+	})
+
+	t.Run("SaveAndLoad", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, vs.Save(&buf))
+
+		loaded, err := NewHNSW(embedder, func(o *HNSWOptions) {
+			o.TopK = 10
+		})
+		require.NoError(t, err)
+		require.NoError(t, loaded.Load(&buf))
+
+		expected, err := vs.SimilaritySearch(context.Background(), "document42")
+		require.NoError(t, err)
+
+		result, err := loaded.SimilaritySearch(context.Background(), "document42")
+		require.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		vs, err := NewHNSW(embedder, func(o *HNSWOptions) {
+			o.TopK = 10
+		})
+		require.NoError(t, err)
+
+		ids := make([]string, 100)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("id%d", i)
+		}
+
+		require.NoError(t, vs.AddDocumentsWithIDs(context.Background(), ids, docs[:100]))
+
+		// Replacing a document keeps the number of documents.
+		require.NoError(t, vs.AddDocumentsWithIDs(context.Background(), ids[:1], docs[:1]))
+		assert.Equal(t, 100, vs.Len())
+
+		require.NoError(t, vs.Delete(context.Background(), []string{"id7"}))
+		require.NoError(t, vs.DeleteByFilter(context.Background(), map[string]any{"index": 8}))
+		assert.Equal(t, 98, vs.Len())
+
+		result, err := vs.SimilaritySearch(context.Background(), "document7")
+		require.NoError(t, err)
+		assert.Len(t, result, 10)
+
+		for _, doc := range result {
+			assert.NotContains(t, []string{"document7", "document8"}, doc.PageContent)
+		}
+
+		var buf bytes.Buffer
+		require.NoError(t, vs.Save(&buf))
+
+		loaded, err := NewHNSW(embedder)
+		require.NoError(t, err)
+		require.NoError(t, loaded.Load(&buf))
+		assert.Equal(t, 98, loaded.Len())
+
+		// Only the vectors and neighbors of deleted nodes are saved.
+		for _, node := range loaded.nodes {
+			if node.Deleted {
+				assert.Empty(t, node.ID)
+				assert.Empty(t, node.Content)
+				assert.Nil(t, node.Metadata)
+				assert.NotEmpty(t, node.Vector)
+			}
+		}
+	})
+
+	t.Run("Compact", func(t *testing.T) {
+		vs, err := NewHNSW(embedder, func(o *HNSWOptions) {
+			o.TopK = 10
+			o.CompactionThreshold = 0
+		})
+		require.NoError(t, err)
+
+		ids := make([]string, 100)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("id%d", i)
+		}
+
+		require.NoError(t, vs.AddDocumentsWithIDs(context.Background(), ids, docs[:100]))
+		require.NoError(t, vs.Delete(context.Background(), ids[:60]))
+		assert.Equal(t, 40, vs.Len())
+		assert.Len(t, vs.nodes, 100)
+
+		require.NoError(t, vs.Compact())
+		assert.Equal(t, 40, vs.Len())
+		assert.Len(t, vs.nodes, 40)
+		assert.Zero(t, vs.deleted)
+
+		result, err := vs.SimilaritySearch(context.Background(), "document70")
+		require.NoError(t, err)
+		assert.Len(t, result, 10)
+		assert.Equal(t, "document70", result[0].PageContent)
+
+		for _, doc := range result {
+			assert.GreaterOrEqual(t, doc.Metadata["index"], 60)
+		}
+	})
+
+	t.Run("CompactionThreshold", func(t *testing.T) {
+		vs, err := NewHNSW(embedder)
+		require.NoError(t, err)
+
+		ids := make([]string, 100)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("id%d", i)
+		}
+
+		require.NoError(t, vs.AddDocumentsWithIDs(context.Background(), ids, docs[:100]))
+
+		require.NoError(t, vs.Delete(context.Background(), ids[:50]))
+		assert.Len(t, vs.nodes, 100)
+
+		require.NoError(t, vs.Delete(context.Background(), ids[50:51]))
+		assert.Equal(t, 49, vs.Len())
+		assert.Len(t, vs.nodes, 49)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		vs, err := NewHNSW(embedder)
+		require.NoError(t, err)
+
+		result, err := vs.SimilaritySearch(context.Background(), "document1")
+		require.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("InvalidOptions", func(t *testing.T) {
+		_, err := NewHNSW(embedder, func(o *HNSWOptions) {
+			o.M = 1
+		})
+		assert.EqualError(t, err, "m must be at least 2, got 1")
+
+		_, err = NewHNSW(embedder, func(o *HNSWOptions) {
+			o.EfConstruction = 0
+		})
+		assert.EqualError(t, err, "efConstruction must be positive, got 0")
+
+		_, err = NewHNSW(embedder, func(o *HNSWOptions) {
+			o.EfSearch = -1
+		})
+		assert.EqualError(t, err, "efSearch must be positive, got -1")
+	})
+}
+
+// bruteForce returns the texts of the k nearest vectors of the query.
+func bruteForce(t *testing.T, embedder *mapEmbedder, query string, k int) map[string]bool {
+	t.Helper()
+
+	type candidate struct {
+		text     string
+		distance float32
+	}
+
+	candidates := []candidate{}
+
+	for text, vector := range embedder.vectors {
+		distance, err := metric.SquaredL2(embedder.vectors[query], vector)
+		require.NoError(t, err)
+
+		candidates = append(candidates, candidate{text: text, distance: distance})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	result := make(map[string]bool, k)
+	for _, c := range candidates[:k] {
+		result[c.text] = true
+	}
+
+	return result
+}
+
+// mapEmbedder implements the schema.Embedder interface with fixed vectors per text.
+type mapEmbedder struct {
+	vectors map[string][]float32
+}
+
+func (m *mapEmbedder) BatchEmbedText(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = m.vectors[text]
+	}
+
+	return vectors, nil
+}
+
+func (m *mapEmbedder) EmbedText(ctx context.Context, text string) ([]float32, error) {
+	return m.vectors[text], nil
+}
@@ -14,8 +14,18 @@ import (
 // Compile time check to ensure InMemory satisfies the VectorStore interface.
 var _ schema.VectorStore = (*InMemory)(nil)
 
+// Compile time check to ensure InMemory satisfies the VectorStoreWithScores interface.
+var _ schema.VectorStoreWithScores = (*InMemory)(nil)
+
+// Compile time check to ensure InMemory satisfies the VectorStoreWithFilter interface.
+var _ schema.VectorStoreWithFilter = (*InMemory)(nil)
+
+// Compile time check to ensure InMemory satisfies the VectorStoreWithMMR interface.
+var _ schema.VectorStoreWithMMR = (*InMemory)(nil)
+
 // InMemoryItem represents an item stored in memory with its content, vector, and metadata.
 type InMemoryItem struct {
+	ID       string         `json:"id"`
 	Content  string         `json:"content"`
 	Vector   []float32      `json:"vector"`
 	Metadata map[string]any `json:"metadata"`
@@ -72,10 +82,15 @@ func (pq *priorityQueue) Top() any {
 // DistanceFunc represents a function for calculating the distance between two vectors
 type DistanceFunc func(v1, v2 []float32) (float32, error)
 
+// RelevanceScoreFunc represents a function for converting a distance into a similarity score.
+type RelevanceScoreFunc func(distance float32) float32
+
 // InMemoryOptions represents options for the in-memory vector store.
 type InMemoryOptions struct {
 	TopK         int
 	DistanceFunc DistanceFunc
+	// RelevanceScoreFunc converts the distance of a document to the query into its similarity score.
+	RelevanceScoreFunc RelevanceScoreFunc
 }
 
 // InMemory represents an in-memory vector store.
@@ -90,6 +105,9 @@ func NewInMemory(embedder schema.Embedder, optFns ...func(*InMemoryOptions)) *In
 	opts := InMemoryOptions{
 		TopK:         3,
 		DistanceFunc: metric.SquaredL2,
+		RelevanceScoreFunc: func(distance float32) float32 {
+			return 1 / (1 + distance)
+		},
 	}
 
 	for _, fn := range optFns {
@@ -105,6 +123,16 @@ func NewInMemory(embedder schema.Embedder, optFns ...func(*InMemoryOptions)) *In
 
 // AddDocuments adds a batch of documents to the InMemory vector store.
 func (vs *InMemory) AddDocuments(ctx context.Context, docs []schema.Document) error {
+	return vs.AddDocumentsWithIDs(ctx, newIDs(len(docs)), docs)
+}
+
+// AddDocumentsWithIDs adds a batch of documents with the given ids to the InMemory vector store.
+// Existing documents with the same ids are replaced.
+func (vs *InMemory) AddDocumentsWithIDs(ctx context.Context, ids []string, docs []schema.Document) error {
+	if len(ids) != len(docs) {
+		return ErrIDsDocumentsMismatch
+	}
+
 	texts := make([]string, len(docs))
 	for i, doc := range docs {
 		texts[i] = doc.PageContent
@@ -115,17 +143,69 @@ func (vs *InMemory) AddDocuments(ctx context.Context, docs []schema.Document) er
 		return err
 	}
 
+	index := make(map[string]int, len(vs.data))
+	for i, item := range vs.data {
+		index[item.ID] = i
+	}
+
 	for i, doc := range docs {
-		vs.data = append(vs.data, InMemoryItem{
+		item := InMemoryItem{
+			ID:       ids[i],
 			Content:  doc.PageContent,
 			Vector:   vectors[i],
 			Metadata: doc.Metadata,
-		})
+		}
+
+		if j, ok := index[ids[i]]; ok {
+			vs.data[j] = item
+			continue
+		}
+
+		index[ids[i]] = len(vs.data)
+		vs.data = append(vs.data, item)
+	}
+
+	return nil
+}
+
+// Delete deletes the documents with the given ids from the InMemory vector store.
+func (vs *InMemory) Delete(ctx context.Context, ids []string) error {
+	deleteIDs := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		deleteIDs[id] = struct{}{}
 	}
 
+	vs.deleteFunc(func(item InMemoryItem) bool {
+		_, ok := deleteIDs[item.ID]
+		return ok
+	})
+
 	return nil
 }
 
+// DeleteByFilter deletes the documents whose metadata matches all key-value pairs of the filter
+// from the InMemory vector store.
+func (vs *InMemory) DeleteByFilter(ctx context.Context, filter map[string]any) error {
+	vs.deleteFunc(func(item InMemoryItem) bool {
+		return matchMetadata(item.Metadata, filter)
+	})
+
+	return nil
+}
+
+// deleteFunc removes all items for which del returns true.
+func (vs *InMemory) deleteFunc(del func(item InMemoryItem) bool) {
+	data := vs.data[:0]
+
+	for _, item := range vs.data {
+		if !del(item) {
+			data = append(data, item)
+		}
+	}
+
+	vs.data = data
+}
+
 // AddItem adds a single item to the InMemory vector store.
 func (vs *InMemory) AddItem(item InMemoryItem) {
 	vs.data = append(vs.data, item)
@@ -138,28 +218,103 @@ func (vs *InMemory) Data() []InMemoryItem {
 
 // SimilaritySearch performs a similarity search with the given query in the InMemory vector store.
 func (vs *InMemory) SimilaritySearch(ctx context.Context, query string) ([]schema.Document, error) {
+	scoredDocs, err := vs.SimilaritySearchWithScores(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := make([]schema.Document, len(scoredDocs))
+	for i, d := range scoredDocs {
+		documents[i] = d.Document
+	}
+
+	return documents, nil
+}
+
+// SimilaritySearchWithScores performs a similarity search with the given query in the InMemory vector store
+// and returns the documents together with their similarity scores.
+func (vs *InMemory) SimilaritySearchWithScores(ctx context.Context, query string) ([]schema.ScoredDocument, error) {
+	return vs.SimilaritySearchWithFilter(ctx, query, nil)
+}
+
+// SimilaritySearchWithFilter performs a similarity search with the given query on the items whose metadata
+// contain all key-value pairs of the filter and returns the documents together with their similarity scores.
+func (vs *InMemory) SimilaritySearchWithFilter(ctx context.Context, query string, filter map[string]any) ([]schema.ScoredDocument, error) {
 	queryVector, err := vs.embedder.EmbedText(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
-	topCandidates := &priorityQueue{}
-	heap.Init(topCandidates)
+	items, err := vs.nearest(queryVector, vs.opts.TopK, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := make([]schema.ScoredDocument, len(items))
+
+	for i, item := range items {
+		documents[i] = schema.ScoredDocument{
+			Document: schema.Document{
+				PageContent: item.Data.Content,
+				Metadata:    item.Data.Metadata,
+			},
+			Score: vs.opts.RelevanceScoreFunc(item.Distance),
+		}
+	}
+
+	return documents, nil
+}
+
+// MaxMarginalRelevanceSearch fetches the fetchK documents nearest to the query and selects TopK of them by
+// maximal marginal relevance.
+func (vs *InMemory) MaxMarginalRelevanceSearch(ctx context.Context, query string, fetchK int, lambdaMult float32) ([]schema.Document, error) {
+	queryVector, err := vs.embedder.EmbedText(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := vs.nearest(queryVector, util.Max(fetchK, vs.opts.TopK), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	vectors := make([][]float32, len(items))
+	for i, item := range items {
+		vectors[i] = item.Data.Vector
+	}
 
-	type searchResult struct {
-		Item       InMemoryItem
-		Similarity float32
+	selected, err := maxMarginalRelevance(queryVector, vectors, vs.opts.TopK, lambdaMult)
+	if err != nil {
+		return nil, err
 	}
 
-	results := make([]searchResult, len(vs.data))
+	documents := make([]schema.Document, len(selected))
+	for i, index := range selected {
+		documents[i] = schema.Document{
+			PageContent: items[index].Data.Content,
+			Metadata:    items[index].Data.Metadata,
+		}
+	}
+
+	return documents, nil
+}
+
+// nearest returns the k items matching the metadata filter nearest to the query vector sorted by their distance.
+func (vs *InMemory) nearest(queryVector []float32, k int, filter map[string]any) ([]*priorityQueueItem, error) {
+	topCandidates := &priorityQueue{}
+	heap.Init(topCandidates)
 
 	for _, item := range vs.data {
+		if !matchMetadata(item.Metadata, filter) {
+			continue
+		}
+
 		similarity, err := vs.opts.DistanceFunc(queryVector, item.Vector)
 		if err != nil {
 			return nil, err
 		}
 
-		if topCandidates.Len() < vs.opts.TopK {
+		if topCandidates.Len() < k {
 			heap.Push(topCandidates, &priorityQueueItem{
 				Data:     item,
 				Distance: similarity,
@@ -180,20 +335,14 @@ func (vs *InMemory) SimilaritySearch(ctx context.Context, query string) ([]schem
 		}
 	}
 
-	docLen := util.Min(len(results), vs.opts.TopK)
-
-	// Extract documents from sorted results
-	documents := make([]schema.Document, docLen)
+	// Extract items from sorted results
+	items := make([]*priorityQueueItem, topCandidates.Len())
 
 	for i := topCandidates.Len() - 1; i >= 0; i-- {
-		item, _ := heap.Pop(topCandidates).(*priorityQueueItem)
-		documents[i] = schema.Document{
-			PageContent: item.Data.Content,
-			Metadata:    item.Data.Metadata,
-		}
+		items[i], _ = heap.Pop(topCandidates).(*priorityQueueItem)
 	}
 
-	return documents, nil
+	return items, nil
 }
 
 func (vs *InMemory) Load(r io.Reader) error {
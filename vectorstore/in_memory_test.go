@@ -55,6 +55,76 @@ func TestInMemory(t *testing.T) {
 		}
 	})
 
+	// Test SimilaritySearchWithScores method
+	t.Run("SimilaritySearchWithScores", func(t *testing.T) {
+		// When
+		documents, err := vs.SimilaritySearchWithScores(context.Background(), "query")
+
+		// Then
+		assert.NoError(t, err)
+		assert.Len(t, documents, 3)
+		assert.Equal(t, "document1", documents[0].Document.PageContent)
+		assert.Equal(t, float32(1), documents[0].Score)
+		assert.Equal(t, float32(0.25), documents[1].Score)
+		assert.InDelta(t, 1.0/13, documents[2].Score, 1e-6)
+	})
+
+	t.Run("SimilaritySearchWithFilter", func(t *testing.T) {
+		// Given
+		vs := NewInMemory(embedder)
+		vs.AddItem(InMemoryItem{Content: "document1", Vector: []float32{1.0, 2.0, 3.0}, Metadata: map[string]any{"source": "a"}})
+		vs.AddItem(InMemoryItem{Content: "document2", Vector: []float32{1.0, 2.0, 3.0}, Metadata: map[string]any{"source": "a"}})
+		vs.AddItem(InMemoryItem{Content: "document3", Vector: []float32{1.0, 2.0, 3.0}, Metadata: map[string]any{"source": "a"}})
+		vs.AddItem(InMemoryItem{Content: "document4", Vector: []float32{3.0, 4.0, 5.0}, Metadata: map[string]any{"source": "b"}})
+
+		// When
+		documents, err := vs.SimilaritySearchWithFilter(context.Background(), "query", map[string]any{"source": "b"})
+
+		// Then
+		assert.NoError(t, err)
+		assert.Len(t, documents, 1)
+		assert.Equal(t, "document4", documents[0].Document.PageContent)
+		assert.InDelta(t, 1.0/13, documents[0].Score, 1e-6)
+	})
+
+	t.Run("AddDocumentsWithIDs", func(t *testing.T) {
+		vs := NewInMemory(&mockEmbedder{})
+
+		err := vs.AddDocumentsWithIDs(context.Background(), []string{"a", "b"}, []schema.Document{
+			{PageContent: "document1", Metadata: map[string]any{"source": "x"}},
+			{PageContent: "document2", Metadata: map[string]any{"source": "y"}},
+		})
+		require.NoError(t, err)
+
+		err = vs.AddDocumentsWithIDs(context.Background(), []string{"a"}, []schema.Document{
+			{PageContent: "document1 updated", Metadata: map[string]any{"source": "x"}},
+		})
+		require.NoError(t, err)
+		require.Len(t, vs.Data(), 2)
+		assert.Equal(t, "document1 updated", vs.Data()[0].Content)
+
+		err = vs.AddDocumentsWithIDs(context.Background(), []string{"a"}, []schema.Document{})
+		assert.ErrorIs(t, err, ErrIDsDocumentsMismatch)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		vs := NewInMemory(&mockEmbedder{})
+
+		err := vs.AddDocumentsWithIDs(context.Background(), []string{"a", "b", "c"}, []schema.Document{
+			{PageContent: "document1", Metadata: map[string]any{"source": "x"}},
+			{PageContent: "document2", Metadata: map[string]any{"source": "y", "page": 1}},
+			{PageContent: "document3", Metadata: map[string]any{"source": "y", "page": 2}},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, vs.Delete(context.Background(), []string{"a", "unknown"}))
+		require.Len(t, vs.Data(), 2)
+
+		require.NoError(t, vs.DeleteByFilter(context.Background(), map[string]any{"source": "y", "page": 2.0}))
+		require.Len(t, vs.Data(), 1)
+		assert.Equal(t, "b", vs.Data()[0].ID)
+	})
+
 	t.Run("SaveAndLoad", func(t *testing.T) {
 		originalData := []InMemoryItem{
 			{Content: "item1", Vector: []float32{1.0, 2.0, 3.0}, Metadata: map[string]any{"key1": "value1"}},
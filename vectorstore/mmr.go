@@ -0,0 +1,77 @@
+package vectorstore
+
+import (
+	"math"
+
+	"github.com/hupe1980/golc/metric"
+)
+
+// maxMarginalRelevance selects up to k of the vectors by maximal marginal relevance (MMR). Each step selects
+// the vector with the highest lambdaMult weighted difference between its cosine similarity to the query vector
+// and its maximal cosine similarity to the vectors selected before. A lambdaMult of 1 ranks by similarity only,
+// a lambdaMult of 0 maximizes the diversity. It returns the indexes of the selected vectors in selection order.
+func maxMarginalRelevance(queryVector []float32, vectors [][]float32, k int, lambdaMult float32) ([]int, error) {
+	if k <= 0 || len(vectors) == 0 {
+		return []int{}, nil
+	}
+
+	querySimilarities := make([]float32, len(vectors))
+
+	for i, v := range vectors {
+		similarity, err := metric.CosineSimilarity(queryVector, v)
+		if err != nil {
+			return nil, err
+		}
+
+		querySimilarities[i] = similarity
+	}
+
+	// maxSimilarities contains the maximal similarity of each vector to the selected vectors.
+	maxSimilarities := make([]float32, len(vectors))
+	for i := range maxSimilarities {
+		maxSimilarities[i] = float32(math.Inf(-1))
+	}
+
+	selected := make([]int, 0, k)
+	isSelected := make([]bool, len(vectors))
+
+	for len(selected) < k && len(selected) < len(vectors) {
+		best := -1
+		bestScore := float32(math.Inf(-1))
+
+		for i := range vectors {
+			if isSelected[i] {
+				continue
+			}
+
+			score := querySimilarities[i]
+			if len(selected) > 0 {
+				score = lambdaMult*querySimilarities[i] - (1-lambdaMult)*maxSimilarities[i]
+			}
+
+			if best == -1 || score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+
+		selected = append(selected, best)
+		isSelected[best] = true
+
+		for i, v := range vectors {
+			if isSelected[i] {
+				continue
+			}
+
+			similarity, err := metric.CosineSimilarity(vectors[best], v)
+			if err != nil {
+				return nil, err
+			}
+
+			if similarity > maxSimilarities[i] {
+				maxSimilarities[i] = similarity
+			}
+		}
+	}
+
+	return selected, nil
+}
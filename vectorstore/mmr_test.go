@@ -0,0 +1,74 @@
+package vectorstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+func TestMaxMarginalRelevance(t *testing.T) {
+	query := []float32{1, 0}
+	vectors := [][]float32{
+		{1, 0},
+		{1, 0.01},
+		{0.7, 0.7},
+	}
+
+	t.Run("Diversity", func(t *testing.T) {
+		selected, err := maxMarginalRelevance(query, vectors, 2, 0.25)
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 2}, selected)
+	})
+
+	t.Run("SimilarityOnly", func(t *testing.T) {
+		selected, err := maxMarginalRelevance(query, vectors, 2, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 1}, selected)
+	})
+
+	t.Run("FewerVectors", func(t *testing.T) {
+		selected, err := maxMarginalRelevance(query, vectors, 5, 0.5)
+		require.NoError(t, err)
+		assert.Len(t, selected, 3)
+	})
+}
+
+func TestMaxMarginalRelevanceSearch(t *testing.T) {
+	embedder := &mapEmbedder{vectors: map[string][]float32{
+		"query":      {1, 0},
+		"document1":  {1, 0},
+		"document1b": {1, 0.01},
+		"document2":  {0.7, 0.7},
+		"document3":  {0, 1},
+	}}
+
+	docs := []schema.Document{
+		{PageContent: "document1"},
+		{PageContent: "document1b"},
+		{PageContent: "document2"},
+		{PageContent: "document3"},
+	}
+
+	vs := NewInMemory(embedder, func(o *InMemoryOptions) {
+		o.TopK = 2
+	})
+	require.NoError(t, vs.AddDocuments(context.Background(), docs))
+
+	result, err := vs.MaxMarginalRelevanceSearch(context.Background(), "query", 3, 0.25)
+	require.NoError(t, err)
+	assert.Equal(t, []schema.Document{{PageContent: "document1"}, {PageContent: "document2"}}, result)
+
+	hnsw, err := NewHNSW(embedder, func(o *HNSWOptions) {
+		o.TopK = 2
+	})
+	require.NoError(t, err)
+	require.NoError(t, hnsw.AddDocuments(context.Background(), docs))
+
+	result, err = hnsw.MaxMarginalRelevanceSearch(context.Background(), "query", 3, 0.25)
+	require.NoError(t, err)
+	assert.Equal(t, []schema.Document{{PageContent: "document1"}, {PageContent: "document2"}}, result)
+}
@@ -0,0 +1,277 @@
+package vectorstore
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure MongoDBAtlas satisfies the VectorStore interface.
+var _ schema.VectorStore = (*MongoDBAtlas)(nil)
+
+// Compile time check to ensure MongoDBAtlas satisfies the VectorStoreWithScores interface.
+var _ schema.VectorStoreWithScores = (*MongoDBAtlas)(nil)
+
+// Compile time check to ensure *mongo.Collection satisfies the MongoDBCollection interface.
+var _ MongoDBCollection = (*mongo.Collection)(nil)
+
+// MongoDBCollection is an interface for the operations of a *mongo.Collection used by the vector store.
+type MongoDBCollection interface {
+	Name() string
+	Database() *mongo.Database
+	BulkWrite(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error)
+	DeleteMany(ctx context.Context, filter any, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error)
+	Aggregate(ctx context.Context, pipeline any, opts ...*options.AggregateOptions) (*mongo.Cursor, error)
+}
+
+// MongoDBSimilarity is the similarity function of a vector search index.
+type MongoDBSimilarity string
+
+const (
+	MongoDBSimilarityCosine     MongoDBSimilarity = "cosine"
+	MongoDBSimilarityEuclidean  MongoDBSimilarity = "euclidean"
+	MongoDBSimilarityDotProduct MongoDBSimilarity = "dotProduct"
+)
+
+// MongoDBAtlasOptions contains options for the MongoDB Atlas vector store.
+type MongoDBAtlasOptions struct {
+	// IndexName is the name of the vector search index.
+	IndexName string
+	// TextKey is the field of the page content.
+	TextKey string
+	// EmbeddingKey is the field of the vector.
+	EmbeddingKey string
+	// TopK is the number of documents returned by a similarity search.
+	TopK int
+	// NumCandidates is the number of nearest neighbors considered by a similarity search.
+	NumCandidates int
+	// Filter is the pre-filter of the similarity search on fields indexed as filter fields,
+	// e.g. bson.M{"source": bson.M{"$eq": "wiki"}}.
+	Filter any
+	// BatchSize is the number of documents per bulk write.
+	BatchSize int
+}
+
+// MongoDBAtlas is a vector store backed by the $vectorSearch aggregation stage of MongoDB Atlas.
+// The metadata of a document is stored in top-level fields, so metadata fields can be used as filter fields.
+type MongoDBAtlas struct {
+	collection MongoDBCollection
+	embedder   schema.Embedder
+	opts       MongoDBAtlasOptions
+}
+
+// NewMongoDBAtlas creates a new MongoDB Atlas vector store for the given collection.
+func NewMongoDBAtlas(collection MongoDBCollection, embedder schema.Embedder, optFns ...func(*MongoDBAtlasOptions)) *MongoDBAtlas {
+	opts := MongoDBAtlasOptions{
+		IndexName:     "vector_index",
+		TextKey:       "text",
+		EmbeddingKey:  "embedding",
+		TopK:          4,
+		NumCandidates: 100,
+		BatchSize:     100,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &MongoDBAtlas{
+		collection: collection,
+		embedder:   embedder,
+		opts:       opts,
+	}
+}
+
+// VectorSearchIndexDefinition returns the definition of a vector search index for the embedding field
+// with the given dimensions and similarity. The filter paths are indexed as filter fields for pre-filtering.
+func (vs *MongoDBAtlas) VectorSearchIndexDefinition(dims int, similarity MongoDBSimilarity, filterPaths ...string) bson.D {
+	fields := bson.A{
+		bson.D{
+			{Key: "type", Value: "vector"},
+			{Key: "path", Value: vs.opts.EmbeddingKey},
+			{Key: "numDimensions", Value: dims},
+			{Key: "similarity", Value: string(similarity)},
+		},
+	}
+
+	for _, path := range filterPaths {
+		fields = append(fields, bson.D{
+			{Key: "type", Value: "filter"},
+			{Key: "path", Value: path},
+		})
+	}
+
+	return bson.D{{Key: "fields", Value: fields}}
+}
+
+// CreateVectorSearchIndex creates the vector search index of the collection with the given definition.
+// The index is built asynchronously by Atlas.
+func (vs *MongoDBAtlas) CreateVectorSearchIndex(ctx context.Context, definition bson.D) error {
+	return vs.collection.Database().RunCommand(ctx, bson.D{
+		{Key: "createSearchIndexes", Value: vs.collection.Name()},
+		{Key: "indexes", Value: bson.A{
+			bson.D{
+				{Key: "name", Value: vs.opts.IndexName},
+				{Key: "type", Value: "vectorSearch"},
+				{Key: "definition", Value: definition},
+			},
+		}},
+	}).Err()
+}
+
+// AddDocuments embeds the documents and inserts them in batches into the collection.
+func (vs *MongoDBAtlas) AddDocuments(ctx context.Context, docs []schema.Document) error {
+	return vs.AddDocumentsWithIDs(ctx, newIDs(len(docs)), docs)
+}
+
+// AddDocumentsWithIDs embeds the documents and upserts them with the given ids as _id in batches into
+// the collection. Existing documents with the same ids are replaced.
+func (vs *MongoDBAtlas) AddDocumentsWithIDs(ctx context.Context, ids []string, docs []schema.Document) error {
+	if len(ids) != len(docs) {
+		return ErrIDsDocumentsMismatch
+	}
+
+	texts := make([]string, len(docs))
+	for i, doc := range docs {
+		texts[i] = doc.PageContent
+	}
+
+	vectors, err := vs.embedder.BatchEmbedText(ctx, texts)
+	if err != nil {
+		return err
+	}
+
+	models := make([]mongo.WriteModel, len(docs))
+
+	for i, doc := range docs {
+		record := bson.M{}
+		for key, value := range doc.Metadata {
+			record[key] = value
+		}
+
+		record[vs.opts.TextKey] = doc.PageContent
+		record[vs.opts.EmbeddingKey] = vectors[i]
+
+		models[i] = mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": ids[i]}).
+			SetReplacement(record).
+			SetUpsert(true)
+	}
+
+	for start := 0; start < len(models); start += vs.opts.BatchSize {
+		end := util.Min(start+vs.opts.BatchSize, len(models))
+
+		if _, err := vs.collection.BulkWrite(ctx, models[start:end]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete deletes the documents with the given ids from the collection.
+func (vs *MongoDBAtlas) Delete(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := vs.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+
+	return err
+}
+
+// DeleteByFilter deletes the documents whose metadata matches all key-value pairs of the filter from the collection.
+func (vs *MongoDBAtlas) DeleteByFilter(ctx context.Context, filter map[string]any) error {
+	_, err := vs.collection.DeleteMany(ctx, bson.M(filter))
+
+	return err
+}
+
+// SimilaritySearch performs a similarity search with the given query in the collection.
+func (vs *MongoDBAtlas) SimilaritySearch(ctx context.Context, query string) ([]schema.Document, error) {
+	scoredDocs, err := vs.SimilaritySearchWithScores(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, len(scoredDocs))
+	for i, d := range scoredDocs {
+		docs[i] = d.Document
+	}
+
+	return docs, nil
+}
+
+// SimilaritySearchWithScores performs a similarity search with the given query in the collection
+// and returns the documents together with their vector search scores.
+func (vs *MongoDBAtlas) SimilaritySearchWithScores(ctx context.Context, query string) ([]schema.ScoredDocument, error) {
+	vector, err := vs.embedder.EmbedText(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	vectorSearch := bson.D{
+		{Key: "index", Value: vs.opts.IndexName},
+		{Key: "path", Value: vs.opts.EmbeddingKey},
+		{Key: "queryVector", Value: vector},
+		{Key: "numCandidates", Value: util.Max(vs.opts.NumCandidates, vs.opts.TopK)},
+		{Key: "limit", Value: vs.opts.TopK},
+	}
+
+	if vs.opts.Filter != nil {
+		vectorSearch = append(vectorSearch, bson.E{Key: "filter", Value: vs.opts.Filter})
+	}
+
+	cursor, err := vs.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$vectorSearch", Value: vectorSearch}},
+		{{Key: "$project", Value: bson.D{
+			{Key: vs.opts.EmbeddingKey, Value: 0},
+			{Key: "score", Value: bson.D{{Key: "$meta", Value: "vectorSearchScore"}}},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := []bson.M{}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.ScoredDocument, len(results))
+
+	for i, result := range results {
+		pageContent, ok := result[vs.opts.TextKey].(string)
+		if !ok {
+			return nil, fmt.Errorf("no content for textKey %s", vs.opts.TextKey)
+		}
+
+		score, _ := result["score"].(float64)
+
+		metadata := make(map[string]any, len(result))
+
+		for key, value := range result {
+			if key == "_id" || key == "score" || key == vs.opts.TextKey || key == vs.opts.EmbeddingKey {
+				continue
+			}
+
+			metadata[key] = value
+		}
+
+		docs[i] = schema.ScoredDocument{
+			Document: schema.Document{
+				PageContent: pageContent,
+				Metadata:    metadata,
+			},
+			Score: float32(score),
+		}
+	}
+
+	return docs, nil
+}
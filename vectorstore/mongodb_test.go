@@ -0,0 +1,143 @@
+package vectorstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+func TestMongoDBAtlas(t *testing.T) {
+	t.Run("AddDocuments", func(t *testing.T) {
+		collection := &mockMongoDBCollection{}
+
+		vs := NewMongoDBAtlas(collection, &mockEmbedder{}, func(o *MongoDBAtlasOptions) {
+			o.BatchSize = 2
+		})
+
+		err := vs.AddDocuments(context.Background(), []schema.Document{
+			{PageContent: "document1", Metadata: map[string]any{"source": "a"}},
+			{PageContent: "document2"},
+			{PageContent: "document3"},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, collection.models, 2)
+
+		model, _ := collection.models[0][0].(*mongo.ReplaceOneModel)
+		assert.Equal(t, bson.M{
+			"text":      "document1",
+			"embedding": []float32{1.0, 2.0, 3.0},
+			"source":    "a",
+		}, model.Replacement)
+	})
+
+	t.Run("AddDocumentsWithIDs", func(t *testing.T) {
+		collection := &mockMongoDBCollection{}
+
+		vs := NewMongoDBAtlas(collection, &mockEmbedder{})
+
+		err := vs.AddDocumentsWithIDs(context.Background(), []string{"a"}, []schema.Document{
+			{PageContent: "document1"},
+		})
+		assert.NoError(t, err)
+
+		model, _ := collection.models[0][0].(*mongo.ReplaceOneModel)
+		assert.Equal(t, bson.M{"_id": "a"}, model.Filter)
+		assert.True(t, *model.Upsert)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		collection := &mockMongoDBCollection{}
+
+		vs := NewMongoDBAtlas(collection, &mockEmbedder{})
+
+		err := vs.Delete(context.Background(), []string{"a", "b"})
+		assert.NoError(t, err)
+		assert.Equal(t, bson.M{"_id": bson.M{"$in": []string{"a", "b"}}}, collection.deleteFilter)
+
+		err = vs.DeleteByFilter(context.Background(), map[string]any{"source": "a"})
+		assert.NoError(t, err)
+		assert.Equal(t, bson.M{"source": "a"}, collection.deleteFilter)
+	})
+
+	t.Run("SimilaritySearchWithScores", func(t *testing.T) {
+		collection := &mockMongoDBCollection{
+			results: []any{
+				bson.M{"_id": "1", "text": "document1", "source": "a", "score": 0.9},
+			},
+		}
+
+		vs := NewMongoDBAtlas(collection, &mockEmbedder{}, func(o *MongoDBAtlasOptions) {
+			o.Filter = bson.M{"source": bson.M{"$eq": "a"}}
+		})
+
+		docs, err := vs.SimilaritySearchWithScores(context.Background(), "query")
+		assert.NoError(t, err)
+		assert.Equal(t, []schema.ScoredDocument{
+			{Document: schema.Document{PageContent: "document1", Metadata: map[string]any{"source": "a"}}, Score: 0.9},
+		}, docs)
+
+		vectorSearch, _ := collection.pipeline[0][0].Value.(bson.D)
+		assert.Equal(t, bson.D{
+			{Key: "index", Value: "vector_index"},
+			{Key: "path", Value: "embedding"},
+			{Key: "queryVector", Value: []float32{1.0, 2.0, 3.0}},
+			{Key: "numCandidates", Value: 100},
+			{Key: "limit", Value: 4},
+			{Key: "filter", Value: bson.M{"source": bson.M{"$eq": "a"}}},
+		}, vectorSearch)
+	})
+
+	t.Run("VectorSearchIndexDefinition", func(t *testing.T) {
+		vs := NewMongoDBAtlas(&mockMongoDBCollection{}, &mockEmbedder{})
+
+		assert.Equal(t, bson.D{{Key: "fields", Value: bson.A{
+			bson.D{
+				{Key: "type", Value: "vector"},
+				{Key: "path", Value: "embedding"},
+				{Key: "numDimensions", Value: 1536},
+				{Key: "similarity", Value: "cosine"},
+			},
+			bson.D{
+				{Key: "type", Value: "filter"},
+				{Key: "path", Value: "source"},
+			},
+		}}}, vs.VectorSearchIndexDefinition(1536, MongoDBSimilarityCosine, "source"))
+	})
+}
+
+// mockMongoDBCollection is a mock implementation of the MongoDBCollection interface.
+type mockMongoDBCollection struct {
+	models       [][]mongo.WriteModel
+	deleteFilter any
+	pipeline     mongo.Pipeline
+	results      []any
+}
+
+func (m *mockMongoDBCollection) Name() string {
+	return "test"
+}
+
+func (m *mockMongoDBCollection) Database() *mongo.Database {
+	return nil
+}
+
+func (m *mockMongoDBCollection) BulkWrite(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error) {
+	m.models = append(m.models, models)
+	return &mongo.BulkWriteResult{}, nil
+}
+
+func (m *mockMongoDBCollection) DeleteMany(ctx context.Context, filter any, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	m.deleteFilter = filter
+	return &mongo.DeleteResult{}, nil
+}
+
+func (m *mockMongoDBCollection) Aggregate(ctx context.Context, pipeline any, opts ...*options.AggregateOptions) (*mongo.Cursor, error) {
+	m.pipeline, _ = pipeline.(mongo.Pipeline)
+	return mongo.NewCursorFromDocuments(m.results, nil, nil)
+}
@@ -2,18 +2,57 @@ package vectorstore
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/hupe1980/golc/integration/pinecone"
+	"github.com/hupe1980/golc/internal/util"
 	"github.com/hupe1980/golc/schema"
 )
 
 // Compile time check to ensure Pinecone satisfies the VectorStore interface.
 var _ schema.VectorStore = (*Pinecone)(nil)
 
+// Compile time check to ensure Pinecone satisfies the VectorStoreWithScores interface.
+var _ schema.VectorStoreWithScores = (*Pinecone)(nil)
+
+// Compile time check to ensure Pinecone satisfies the VectorStoreWithFilter interface.
+var _ schema.VectorStoreWithFilter = (*Pinecone)(nil)
+
+// Compile time check to ensure Pinecone satisfies the VectorStoreWithMMR interface.
+var _ schema.VectorStoreWithMMR = (*Pinecone)(nil)
+
+// ErrPineconeServerlessDeleteByFilter is returned by DeleteByFilter for serverless indexes, which do not
+// support deleting vectors by metadata filter.
+var ErrPineconeServerlessDeleteByFilter = errors.New("serverless pinecone indexes do not support deleting by metadata filter, delete the vectors by id instead")
+
+// PineconeSparseEncoder is an interface for encoding texts into sparse vectors, e.g. with BM25 or SPLADE,
+// for the sparse-dense hybrid search.
+type PineconeSparseEncoder interface {
+	// EncodeDocuments encodes the texts of documents into sparse vectors.
+	EncodeDocuments(ctx context.Context, texts []string) ([]*pinecone.SparseValues, error)
+	// EncodeQuery encodes a query into a sparse vector.
+	EncodeQuery(ctx context.Context, query string) (*pinecone.SparseValues, error)
+}
+
 type PineconeOptions struct {
 	Namespace string
 	TopK      int64
+	// Filter is the metadata filter applied to each similarity search.
+	// See https://docs.pinecone.io/guides/data/filter-with-metadata for more information.
+	Filter map[string]any
+	// BatchSize is the maximum number of vectors per upsert request.
+	BatchSize int
+	// MaxBatchBytes is the maximum size of the vectors of an upsert request in bytes.
+	MaxBatchBytes int
+	// SparseEncoder enables the sparse-dense hybrid search. The index must use the dotproduct metric.
+	SparseEncoder PineconeSparseEncoder
+	// Alpha weights the dense and the sparse query vector of the hybrid search. 1 is a pure dense
+	// search, 0 a pure sparse search.
+	Alpha float32
+	// Serverless must be set for serverless indexes, which do not support deleting by metadata filter.
+	Serverless bool
 }
 
 type Pinecone struct {
@@ -25,13 +64,20 @@ type Pinecone struct {
 
 func NewPinecone(client pinecone.Client, embedder schema.Embedder, textKey string, optFns ...func(*PineconeOptions)) (*Pinecone, error) {
 	opts := PineconeOptions{
-		TopK: 4,
+		TopK:          4,
+		BatchSize:     100,
+		MaxBatchBytes: 2 * 1024 * 1024,
+		Alpha:         0.5,
 	}
 
 	for _, fn := range optFns {
 		fn(&opts)
 	}
 
+	if opts.Alpha < 0 || opts.Alpha > 1 {
+		return nil, fmt.Errorf("alpha must be between 0 and 1, got %v", opts.Alpha)
+	}
+
 	return &Pinecone{
 		client:   client,
 		embedder: embedder,
@@ -41,6 +87,15 @@ func NewPinecone(client pinecone.Client, embedder schema.Embedder, textKey strin
 }
 
 func (vs *Pinecone) AddDocuments(ctx context.Context, docs []schema.Document) error {
+	return vs.AddDocumentsWithIDs(ctx, newIDs(len(docs)), docs)
+}
+
+// AddDocumentsWithIDs upserts the documents with the given ids. Existing vectors with the same ids are replaced.
+func (vs *Pinecone) AddDocumentsWithIDs(ctx context.Context, ids []string, docs []schema.Document) error {
+	if len(ids) != len(docs) {
+		return ErrIDsDocumentsMismatch
+	}
+
 	texts := make([]string, len(docs))
 	for i, doc := range docs {
 		texts[i] = doc.PageContent
@@ -69,36 +124,125 @@ func (vs *Pinecone) AddDocuments(ctx context.Context, docs []schema.Document) er
 		return err
 	}
 
-	req := &pinecone.UpsertRequest{
-		Vectors: pineconeVectors,
+	for i, v := range pineconeVectors {
+		v.ID = ids[i]
+	}
+
+	if vs.opts.SparseEncoder != nil {
+		sparseValues, err := vs.opts.SparseEncoder.EncodeDocuments(ctx, texts)
+		if err != nil {
+			return err
+		}
+
+		for i, v := range pineconeVectors {
+			v.SparseValues = sparseValues[i]
+		}
+	}
+
+	batches, err := vs.batchVectors(pineconeVectors)
+	if err != nil {
+		return err
+	}
+
+	for _, batch := range batches {
+		if _, err := vs.client.Upsert(ctx, &pinecone.UpsertRequest{
+			Vectors:   batch,
+			Namespace: vs.opts.Namespace,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete deletes the vectors with the given ids from the namespace.
+func (vs *Pinecone) Delete(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	for _, batch := range util.ChunkBy(ids, deleteBatchSize) {
+		if err := vs.client.Delete(ctx, &pinecone.DeleteRequest{
+			IDs:       batch,
+			Namespace: vs.opts.Namespace,
+		}); err != nil {
+			return err
+		}
 	}
 
-	if vs.opts.Namespace != "" {
-		req.Namespace = vs.opts.Namespace
+	return nil
+}
+
+// DeleteByFilter deletes the vectors whose metadata matches all key-value pairs of the filter from the namespace.
+// It returns ErrPineconeServerlessDeleteByFilter for serverless indexes.
+func (vs *Pinecone) DeleteByFilter(ctx context.Context, filter map[string]any) error {
+	if vs.opts.Serverless {
+		return ErrPineconeServerlessDeleteByFilter
 	}
 
-	_, err = vs.client.Upsert(ctx, req)
+	pineconeFilter := make(map[string]any, len(filter))
+	for key, value := range filter {
+		pineconeFilter[key] = map[string]any{"$eq": value}
+	}
 
-	return err
+	return vs.client.Delete(ctx, &pinecone.DeleteRequest{
+		Namespace: vs.opts.Namespace,
+		Filter:    pineconeFilter,
+	})
 }
 
 func (vs *Pinecone) SimilaritySearch(ctx context.Context, query string) ([]schema.Document, error) {
+	scoredDocs, err := vs.SimilaritySearchWithScores(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, len(scoredDocs))
+	for i, d := range scoredDocs {
+		docs[i] = d.Document
+	}
+
+	return docs, nil
+}
+
+// SimilaritySearchWithScores performs a similarity search with the given query and returns the documents
+// together with the scores of the matches.
+func (vs *Pinecone) SimilaritySearchWithScores(ctx context.Context, query string) ([]schema.ScoredDocument, error) {
+	return vs.SimilaritySearchWithFilter(ctx, query, vs.opts.Filter)
+}
+
+// SimilaritySearchWithFilter performs a similarity search with the given query on the vectors matching
+// the metadata filter and returns the documents together with the scores of the matches.
+func (vs *Pinecone) SimilaritySearchWithFilter(ctx context.Context, query string, filter map[string]any) ([]schema.ScoredDocument, error) {
 	vector, err := vs.embedder.EmbedText(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := vs.client.Query(ctx, &pinecone.QueryRequest{
+	req := &pinecone.QueryRequest{
 		Namespace:       vs.opts.Namespace,
 		TopK:            vs.opts.TopK,
+		Filter:          filter,
 		IncludeMetadata: true,
 		Vector:          vector,
-	})
+	}
+
+	if vs.opts.SparseEncoder != nil {
+		sparseVector, err := vs.opts.SparseEncoder.EncodeQuery(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Vector, req.SparseVector = hybridScale(vector, sparseVector, vs.opts.Alpha)
+	}
+
+	res, err := vs.client.Query(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	docs := make([]schema.Document, 0, len(res.Matches))
+	docs := make([]schema.ScoredDocument, 0, len(res.Matches))
 
 	for _, match := range res.Matches {
 		pageContent, ok := match.Metadata[vs.textKey].(string)
@@ -108,9 +252,12 @@ func (vs *Pinecone) SimilaritySearch(ctx context.Context, query string) ([]schem
 
 		delete(match.Metadata, vs.textKey)
 
-		doc := schema.Document{
-			PageContent: pageContent,
-			Metadata:    match.Metadata,
+		doc := schema.ScoredDocument{
+			Document: schema.Document{
+				PageContent: pageContent,
+				Metadata:    match.Metadata,
+			},
+			Score: float32(match.Score),
 		}
 
 		docs = append(docs, doc)
@@ -118,3 +265,111 @@ func (vs *Pinecone) SimilaritySearch(ctx context.Context, query string) ([]schem
 
 	return docs, nil
 }
+
+// MaxMarginalRelevanceSearch fetches the fetchK vectors most similar to the query and selects TopK of them
+// by maximal marginal relevance. The search is dense only, even if a sparse encoder is configured.
+func (vs *Pinecone) MaxMarginalRelevanceSearch(ctx context.Context, query string, fetchK int, lambdaMult float32) ([]schema.Document, error) {
+	vector, err := vs.embedder.EmbedText(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := vs.client.Query(ctx, &pinecone.QueryRequest{
+		Namespace:       vs.opts.Namespace,
+		TopK:            util.Max(int64(fetchK), vs.opts.TopK),
+		Filter:          vs.opts.Filter,
+		IncludeMetadata: true,
+		IncludeValues:   true,
+		Vector:          vector,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	vectors := make([][]float32, len(res.Matches))
+	for i, match := range res.Matches {
+		vectors[i] = match.Values
+	}
+
+	selected, err := maxMarginalRelevance(vector, vectors, int(vs.opts.TopK), lambdaMult)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, len(selected))
+
+	for i, index := range selected {
+		match := res.Matches[index]
+
+		pageContent, ok := match.Metadata[vs.textKey].(string)
+		if !ok {
+			return nil, fmt.Errorf("no content for textKey %s", vs.textKey)
+		}
+
+		delete(match.Metadata, vs.textKey)
+
+		docs[i] = schema.Document{
+			PageContent: pageContent,
+			Metadata:    match.Metadata,
+		}
+	}
+
+	return docs, nil
+}
+
+// batchVectors splits the vectors into batches respecting the maximum number of vectors
+// and the maximum size of a batch.
+func (vs *Pinecone) batchVectors(vectors []*pinecone.Vector) ([][]*pinecone.Vector, error) {
+	batches := [][]*pinecone.Vector{}
+	batch := []*pinecone.Vector{}
+	batchBytes := 0
+
+	for _, v := range vectors {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(b) > vs.opts.MaxBatchBytes {
+			return nil, fmt.Errorf("vector %s exceeds the maximum batch size of %d bytes", v.ID, vs.opts.MaxBatchBytes)
+		}
+
+		if len(batch) > 0 && (len(batch) == vs.opts.BatchSize || batchBytes+len(b) > vs.opts.MaxBatchBytes) {
+			batches = append(batches, batch)
+			batch = []*pinecone.Vector{}
+			batchBytes = 0
+		}
+
+		batch = append(batch, v)
+		batchBytes += len(b)
+	}
+
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+
+	return batches, nil
+}
+
+// hybridScale weights the dense vector by alpha and the sparse vector by 1 - alpha.
+func hybridScale(dense []float32, sparse *pinecone.SparseValues, alpha float32) ([]float32, *pinecone.SparseValues) {
+	scaledDense := make([]float32, len(dense))
+	for i, v := range dense {
+		scaledDense[i] = v * alpha
+	}
+
+	if sparse == nil {
+		return scaledDense, nil
+	}
+
+	scaledSparse := &pinecone.SparseValues{
+		Indices: sparse.Indices,
+		Values:  make([]float32, len(sparse.Values)),
+	}
+
+	for i, v := range sparse.Values {
+		scaledSparse.Values[i] = v * (1 - alpha)
+	}
+
+	return scaledDense, scaledSparse
+}
@@ -0,0 +1,207 @@
+package vectorstore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hupe1980/golc/integration/pinecone"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPinecone(t *testing.T) {
+	docs := []schema.Document{
+		{PageContent: "document1", Metadata: map[string]any{"source": "a"}},
+		{PageContent: "document2"},
+		{PageContent: "document3"},
+	}
+
+	t.Run("AddDocumentsInBatches", func(t *testing.T) {
+		client := &mockPineconeClient{}
+
+		vs, err := NewPinecone(client, &mockEmbedder{}, "text", func(o *PineconeOptions) {
+			o.Namespace = "ns"
+			o.BatchSize = 2
+			o.SparseEncoder = &mockSparseEncoder{}
+		})
+		assert.NoError(t, err)
+
+		err = vs.AddDocuments(context.Background(), docs)
+		assert.NoError(t, err)
+		assert.Len(t, client.upsertRequests, 2)
+		assert.Len(t, client.upsertRequests[0].Vectors, 2)
+		assert.Len(t, client.upsertRequests[1].Vectors, 1)
+		assert.Equal(t, "ns", client.upsertRequests[0].Namespace)
+		assert.Equal(t, map[string]any{"source": "a", "text": "document1"}, client.upsertRequests[0].Vectors[0].Metadata)
+		assert.Equal(t, &pinecone.SparseValues{Indices: []uint32{1}, Values: []float32{1}}, client.upsertRequests[0].Vectors[0].SparseValues)
+	})
+
+	t.Run("AddDocumentsWithMaxBatchBytes", func(t *testing.T) {
+		client := &mockPineconeClient{}
+
+		vs, err := NewPinecone(client, &mockEmbedder{}, "text", func(o *PineconeOptions) {
+			o.MaxBatchBytes = 150
+		})
+		assert.NoError(t, err)
+
+		err = vs.AddDocuments(context.Background(), docs)
+		assert.NoError(t, err)
+		assert.Len(t, client.upsertRequests, 3)
+
+		vs, err = NewPinecone(client, &mockEmbedder{}, "text", func(o *PineconeOptions) {
+			o.MaxBatchBytes = 10
+		})
+		assert.NoError(t, err)
+
+		err = vs.AddDocuments(context.Background(), docs)
+		assert.Error(t, err)
+	})
+
+	t.Run("HybridSearch", func(t *testing.T) {
+		client := &mockPineconeClient{
+			queryResponse: &pinecone.QueryResponse{
+				Matches: []*pinecone.Match{
+					{ID: "1", Score: 0.8, Metadata: map[string]any{"source": "a", "text": "document1"}},
+				},
+			},
+		}
+
+		vs, err := NewPinecone(client, &mockEmbedder{}, "text", func(o *PineconeOptions) {
+			o.Filter = map[string]any{"source": map[string]any{"$eq": "a"}}
+			o.SparseEncoder = &mockSparseEncoder{}
+			o.Alpha = 0.75
+		})
+		assert.NoError(t, err)
+
+		result, err := vs.SimilaritySearchWithScores(context.Background(), "query")
+		assert.NoError(t, err)
+		assert.Equal(t, []schema.ScoredDocument{
+			{Document: schema.Document{PageContent: "document1", Metadata: map[string]any{"source": "a"}}, Score: 0.8},
+		}, result)
+		assert.Equal(t, map[string]any{"source": map[string]any{"$eq": "a"}}, client.queryRequest.Filter)
+		assert.Equal(t, []float32{0.75, 1.5, 2.25}, client.queryRequest.Vector)
+		assert.Equal(t, &pinecone.SparseValues{Indices: []uint32{7}, Values: []float32{0.5}}, client.queryRequest.SparseVector)
+	})
+
+	t.Run("AddDocumentsWithIDs", func(t *testing.T) {
+		client := &mockPineconeClient{}
+
+		vs, err := NewPinecone(client, &mockEmbedder{}, "text")
+		assert.NoError(t, err)
+
+		err = vs.AddDocumentsWithIDs(context.Background(), []string{"a", "b", "c"}, docs)
+		assert.NoError(t, err)
+		assert.Equal(t, "a", client.upsertRequests[0].Vectors[0].ID)
+		assert.Equal(t, "c", client.upsertRequests[0].Vectors[2].ID)
+
+		err = vs.AddDocumentsWithIDs(context.Background(), []string{"a"}, docs)
+		assert.ErrorIs(t, err, ErrIDsDocumentsMismatch)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		client := &mockPineconeClient{}
+
+		vs, err := NewPinecone(client, &mockEmbedder{}, "text", func(o *PineconeOptions) {
+			o.Namespace = "ns"
+		})
+		assert.NoError(t, err)
+
+		err = vs.Delete(context.Background(), []string{"a", "b"})
+		assert.NoError(t, err)
+		assert.Equal(t, []*pinecone.DeleteRequest{{IDs: []string{"a", "b"}, Namespace: "ns"}}, client.deleteRequests)
+
+		err = vs.DeleteByFilter(context.Background(), map[string]any{"source": "a"})
+		assert.NoError(t, err)
+		assert.Equal(t, &pinecone.DeleteRequest{
+			Namespace: "ns",
+			Filter:    map[string]any{"source": map[string]any{"$eq": "a"}},
+		}, client.deleteRequests[1])
+	})
+
+	t.Run("DeleteBatches", func(t *testing.T) {
+		client := &mockPineconeClient{}
+
+		vs, err := NewPinecone(client, &mockEmbedder{}, "text")
+		assert.NoError(t, err)
+
+		ids := make([]string, 1200)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("id-%d", i)
+		}
+
+		err = vs.Delete(context.Background(), ids)
+		assert.NoError(t, err)
+		assert.Len(t, client.deleteRequests, 3)
+		assert.Len(t, client.deleteRequests[0].IDs, 500)
+		assert.Len(t, client.deleteRequests[2].IDs, 200)
+		assert.Equal(t, "id-1199", client.deleteRequests[2].IDs[199])
+	})
+
+	t.Run("DeleteByFilterServerless", func(t *testing.T) {
+		client := &mockPineconeClient{}
+
+		vs, err := NewPinecone(client, &mockEmbedder{}, "text", func(o *PineconeOptions) {
+			o.Serverless = true
+		})
+		assert.NoError(t, err)
+
+		err = vs.DeleteByFilter(context.Background(), map[string]any{"source": "a"})
+		assert.ErrorIs(t, err, ErrPineconeServerlessDeleteByFilter)
+		assert.Empty(t, client.deleteRequests)
+	})
+
+	t.Run("InvalidAlpha", func(t *testing.T) {
+		_, err := NewPinecone(&mockPineconeClient{}, &mockEmbedder{}, "text", func(o *PineconeOptions) {
+			o.Alpha = 2
+		})
+		assert.Error(t, err)
+	})
+}
+
+// mockPineconeClient is a mock implementation of the pinecone.Client interface.
+type mockPineconeClient struct {
+	upsertRequests []*pinecone.UpsertRequest
+	queryRequest   *pinecone.QueryRequest
+	queryResponse  *pinecone.QueryResponse
+	deleteRequests []*pinecone.DeleteRequest
+}
+
+func (m *mockPineconeClient) Upsert(ctx context.Context, req *pinecone.UpsertRequest) (*pinecone.UpsertResponse, error) {
+	m.upsertRequests = append(m.upsertRequests, req)
+	return &pinecone.UpsertResponse{UpsertedCount: uint32(len(req.Vectors))}, nil
+}
+
+func (m *mockPineconeClient) Fetch(ctx context.Context, req *pinecone.FetchRequest) (*pinecone.FetchResponse, error) {
+	return &pinecone.FetchResponse{}, nil
+}
+
+func (m *mockPineconeClient) Query(ctx context.Context, req *pinecone.QueryRequest) (*pinecone.QueryResponse, error) {
+	m.queryRequest = req
+	return m.queryResponse, nil
+}
+
+func (m *mockPineconeClient) Delete(ctx context.Context, req *pinecone.DeleteRequest) error {
+	m.deleteRequests = append(m.deleteRequests, req)
+	return nil
+}
+
+func (m *mockPineconeClient) Close() error {
+	return nil
+}
+
+// mockSparseEncoder is a mock implementation of the PineconeSparseEncoder interface.
+type mockSparseEncoder struct{}
+
+func (m *mockSparseEncoder) EncodeDocuments(ctx context.Context, texts []string) ([]*pinecone.SparseValues, error) {
+	values := make([]*pinecone.SparseValues, len(texts))
+	for i := range texts {
+		values[i] = &pinecone.SparseValues{Indices: []uint32{uint32(i + 1)}, Values: []float32{1}}
+	}
+
+	return values, nil
+}
+
+func (m *mockSparseEncoder) EncodeQuery(ctx context.Context, query string) (*pinecone.SparseValues, error) {
+	return &pinecone.SparseValues{Indices: []uint32{7}, Values: []float32{2}}, nil
+}
@@ -0,0 +1,385 @@
+package vectorstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hupe1980/golc/integration/qdrant"
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Qdrant satisfies the VectorStore interface.
+var _ schema.VectorStore = (*Qdrant)(nil)
+
+// Compile time check to ensure Qdrant satisfies the VectorStoreWithScores interface.
+var _ schema.VectorStoreWithScores = (*Qdrant)(nil)
+
+// Compile time check to ensure Qdrant satisfies the VectorStoreWithMMR interface.
+var _ schema.VectorStoreWithMMR = (*Qdrant)(nil)
+
+// QdrantClient is an interface for interacting with the Qdrant API.
+type QdrantClient interface {
+	CollectionExists(ctx context.Context, collectionName string) (bool, error)
+	CreateCollection(ctx context.Context, collectionName string, req *qdrant.CreateCollectionRequest) error
+	Upsert(ctx context.Context, collectionName string, req *qdrant.UpsertPointsRequest) error
+	Search(ctx context.Context, collectionName string, req *qdrant.SearchRequest) ([]qdrant.ScoredPoint, error)
+	Scroll(ctx context.Context, collectionName string, req *qdrant.ScrollRequest) (*qdrant.ScrollResponse, error)
+	DeletePoints(ctx context.Context, collectionName string, req *qdrant.DeletePointsRequest) error
+}
+
+// QdrantOptions contains options for the Qdrant vector store.
+type QdrantOptions struct {
+	// TopK is the number of documents returned by a similarity search.
+	TopK int
+	// ContentKey is the payload key of the page content.
+	ContentKey string
+	// MetadataKey is the payload key of the metadata. Filters on metadata fields use keys like "metadata.source".
+	MetadataKey string
+	// VectorName is the name of the vector in collections with named vectors. If empty, the unnamed vector is used.
+	VectorName string
+	// Distance is the distance function of a collection created by the vector store.
+	Distance qdrant.Distance
+	// BatchSize is the number of points per upsert, scroll and delete request.
+	BatchSize int
+	// Filter is the payload filter applied to each similarity search.
+	Filter *qdrant.Filter
+}
+
+// Qdrant is a vector store backed by a Qdrant collection.
+type Qdrant struct {
+	client         QdrantClient
+	embedder       schema.Embedder
+	collectionName string
+	opts           QdrantOptions
+}
+
+// NewQdrant creates a new Qdrant vector store for the given collection.
+func NewQdrant(client QdrantClient, embedder schema.Embedder, collectionName string, optFns ...func(*QdrantOptions)) *Qdrant {
+	opts := QdrantOptions{
+		TopK:        4,
+		ContentKey:  "page_content",
+		MetadataKey: "metadata",
+		Distance:    qdrant.DistanceCosine,
+		BatchSize:   64,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Qdrant{
+		client:         client,
+		embedder:       embedder,
+		collectionName: collectionName,
+		opts:           opts,
+	}
+}
+
+// CreateCollectionIfNotExists creates the collection with vectors of the given size, if it does not exist.
+func (vs *Qdrant) CreateCollectionIfNotExists(ctx context.Context, vectorSize int) error {
+	exists, err := vs.client.CollectionExists(ctx, vs.collectionName)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return nil
+	}
+
+	params := qdrant.VectorParams{
+		Size:     vectorSize,
+		Distance: vs.opts.Distance,
+	}
+
+	vectors := qdrant.VectorsConfig{Params: &params}
+	if vs.opts.VectorName != "" {
+		vectors = qdrant.VectorsConfig{Named: map[string]qdrant.VectorParams{vs.opts.VectorName: params}}
+	}
+
+	return vs.client.CreateCollection(ctx, vs.collectionName, &qdrant.CreateCollectionRequest{
+		Vectors: vectors,
+	})
+}
+
+// AddDocuments embeds the documents and upserts them in batches into the collection.
+func (vs *Qdrant) AddDocuments(ctx context.Context, docs []schema.Document) error {
+	return vs.AddDocumentsWithIDs(ctx, newIDs(len(docs)), docs)
+}
+
+// AddDocumentsWithIDs embeds the documents and upserts them with the given ids in batches into the
+// collection. As Qdrant accepts only UUIDs and integers as point ids, other ids are converted into
+// deterministic UUIDs.
+func (vs *Qdrant) AddDocumentsWithIDs(ctx context.Context, ids []string, docs []schema.Document) error {
+	if len(ids) != len(docs) {
+		return ErrIDsDocumentsMismatch
+	}
+
+	texts := make([]string, len(docs))
+	for i, doc := range docs {
+		texts[i] = doc.PageContent
+	}
+
+	vectors, err := vs.embedder.BatchEmbedText(ctx, texts)
+	if err != nil {
+		return err
+	}
+
+	points := make([]qdrant.Point, len(docs))
+
+	for i, doc := range docs {
+		var vector any = vectors[i]
+		if vs.opts.VectorName != "" {
+			vector = map[string][]float32{vs.opts.VectorName: vectors[i]}
+		}
+
+		points[i] = qdrant.Point{
+			ID:     toUUID(ids[i]),
+			Vector: vector,
+			Payload: map[string]any{
+				vs.opts.ContentKey:  doc.PageContent,
+				vs.opts.MetadataKey: doc.Metadata,
+			},
+		}
+	}
+
+	for start := 0; start < len(points); start += vs.opts.BatchSize {
+		end := util.Min(start+vs.opts.BatchSize, len(points))
+
+		if err := vs.client.Upsert(ctx, vs.collectionName, &qdrant.UpsertPointsRequest{
+			Points: points[start:end],
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SimilaritySearch performs a similarity search with the given query in the collection.
+func (vs *Qdrant) SimilaritySearch(ctx context.Context, query string) ([]schema.Document, error) {
+	scoredDocs, err := vs.SimilaritySearchWithScores(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, len(scoredDocs))
+	for i, d := range scoredDocs {
+		docs[i] = d.Document
+	}
+
+	return docs, nil
+}
+
+// SimilaritySearchWithScores performs a similarity search with the given query in the collection
+// and returns the documents together with their scores.
+func (vs *Qdrant) SimilaritySearchWithScores(ctx context.Context, query string) ([]schema.ScoredDocument, error) {
+	return vs.SimilaritySearchWithFilter(ctx, query, vs.opts.Filter)
+}
+
+// SimilaritySearchWithFilter performs a similarity search with the given query on the points of the
+// collection matching the payload filter.
+func (vs *Qdrant) SimilaritySearchWithFilter(ctx context.Context, query string, filter *qdrant.Filter) ([]schema.ScoredDocument, error) {
+	vector, err := vs.embedder.EmbedText(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var queryVector any = vector
+	if vs.opts.VectorName != "" {
+		queryVector = qdrant.NamedVector{Name: vs.opts.VectorName, Vector: vector}
+	}
+
+	points, err := vs.client.Search(ctx, vs.collectionName, &qdrant.SearchRequest{
+		Vector:      queryVector,
+		Filter:      filter,
+		Limit:       vs.opts.TopK,
+		WithPayload: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.ScoredDocument, len(points))
+
+	for i, point := range points {
+		doc, err := vs.toDocument(point)
+		if err != nil {
+			return nil, err
+		}
+
+		docs[i] = schema.ScoredDocument{
+			Document: doc,
+			Score:    point.Score,
+		}
+	}
+
+	return docs, nil
+}
+
+// MaxMarginalRelevanceSearch fetches the fetchK points most similar to the query and selects TopK of them
+// by maximal marginal relevance.
+func (vs *Qdrant) MaxMarginalRelevanceSearch(ctx context.Context, query string, fetchK int, lambdaMult float32) ([]schema.Document, error) {
+	vector, err := vs.embedder.EmbedText(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var queryVector any = vector
+	if vs.opts.VectorName != "" {
+		queryVector = qdrant.NamedVector{Name: vs.opts.VectorName, Vector: vector}
+	}
+
+	points, err := vs.client.Search(ctx, vs.collectionName, &qdrant.SearchRequest{
+		Vector:      queryVector,
+		Filter:      vs.opts.Filter,
+		Limit:       util.Max(fetchK, vs.opts.TopK),
+		WithPayload: true,
+		WithVector:  true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	vectors := make([][]float32, len(points))
+
+	for i, point := range points {
+		vectors[i], err = vs.pointVector(point)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	selected, err := maxMarginalRelevance(vector, vectors, vs.opts.TopK, lambdaMult)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, len(selected))
+
+	for i, index := range selected {
+		docs[i], err = vs.toDocument(points[index])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return docs, nil
+}
+
+// toDocument returns the document stored in the payload of the point.
+func (vs *Qdrant) toDocument(point qdrant.ScoredPoint) (schema.Document, error) {
+	pageContent, ok := point.Payload[vs.opts.ContentKey].(string)
+	if !ok {
+		return schema.Document{}, fmt.Errorf("no content for contentKey %s", vs.opts.ContentKey)
+	}
+
+	metadata, _ := point.Payload[vs.opts.MetadataKey].(map[string]any)
+
+	return schema.Document{
+		PageContent: pageContent,
+		Metadata:    metadata,
+	}, nil
+}
+
+// pointVector decodes the vector of the point, which is a named vector if VectorName is set.
+func (vs *Qdrant) pointVector(point qdrant.ScoredPoint) ([]float32, error) {
+	if vs.opts.VectorName == "" {
+		vector := []float32{}
+		if err := json.Unmarshal(point.Vector, &vector); err != nil {
+			return nil, err
+		}
+
+		return vector, nil
+	}
+
+	vectors := map[string][]float32{}
+	if err := json.Unmarshal(point.Vector, &vectors); err != nil {
+		return nil, err
+	}
+
+	vector, ok := vectors[vs.opts.VectorName]
+	if !ok {
+		return nil, fmt.Errorf("no vector for vectorName %s", vs.opts.VectorName)
+	}
+
+	return vector, nil
+}
+
+// Delete deletes the points with the given ids from the collection.
+func (vs *Qdrant) Delete(ctx context.Context, ids []string) error {
+	for start := 0; start < len(ids); start += vs.opts.BatchSize {
+		end := util.Min(start+vs.opts.BatchSize, len(ids))
+
+		points := make([]any, 0, end-start)
+		for _, id := range ids[start:end] {
+			points = append(points, toUUID(id))
+		}
+
+		if err := vs.client.DeletePoints(ctx, vs.collectionName, &qdrant.DeletePointsRequest{
+			Points: points,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteByFilter deletes all points of the collection whose metadata matches all key-value pairs of the filter.
+func (vs *Qdrant) DeleteByFilter(ctx context.Context, filter map[string]any) error {
+	conditions := make([]qdrant.Condition, 0, len(filter))
+	for key, value := range filter {
+		conditions = append(conditions, qdrant.Condition{
+			Key:   fmt.Sprintf("%s.%s", vs.opts.MetadataKey, key),
+			Match: &qdrant.Match{Value: value},
+		})
+	}
+
+	_, err := vs.DeleteByPayloadFilter(ctx, &qdrant.Filter{Must: conditions})
+
+	return err
+}
+
+// DeleteByPayloadFilter deletes all points of the collection matching the payload filter. The ids of the
+// points are collected by scrolling through the collection before they are deleted in batches.
+// It returns the number of deleted points.
+func (vs *Qdrant) DeleteByPayloadFilter(ctx context.Context, filter *qdrant.Filter) (int, error) {
+	ids := []any{}
+
+	var offset any
+
+	for {
+		res, err := vs.client.Scroll(ctx, vs.collectionName, &qdrant.ScrollRequest{
+			Filter: filter,
+			Limit:  vs.opts.BatchSize,
+			Offset: offset,
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		for _, point := range res.Points {
+			ids = append(ids, point.ID)
+		}
+
+		if res.NextPageOffset == nil {
+			break
+		}
+
+		offset = res.NextPageOffset
+	}
+
+	for start := 0; start < len(ids); start += vs.opts.BatchSize {
+		end := util.Min(start+vs.opts.BatchSize, len(ids))
+
+		if err := vs.client.DeletePoints(ctx, vs.collectionName, &qdrant.DeletePointsRequest{
+			Points: ids[start:end],
+		}); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(ids), nil
+}
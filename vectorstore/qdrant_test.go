@@ -0,0 +1,185 @@
+package vectorstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc/integration/qdrant"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQdrant(t *testing.T) {
+	t.Run("CreateCollectionIfNotExists", func(t *testing.T) {
+		client := &mockQdrantClient{}
+
+		vs := NewQdrant(client, &mockEmbedder{}, "test", func(o *QdrantOptions) {
+			o.VectorName = "text"
+		})
+
+		err := vs.CreateCollectionIfNotExists(context.Background(), 3)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]qdrant.VectorParams{
+			"text": {Size: 3, Distance: qdrant.DistanceCosine},
+		}, client.createCollectionRequest.Vectors.Named)
+
+		client.createCollectionRequest = nil
+		client.exists = true
+
+		err = vs.CreateCollectionIfNotExists(context.Background(), 3)
+		assert.NoError(t, err)
+		assert.Nil(t, client.createCollectionRequest)
+	})
+
+	t.Run("AddDocuments", func(t *testing.T) {
+		client := &mockQdrantClient{}
+
+		vs := NewQdrant(client, &mockEmbedder{}, "test", func(o *QdrantOptions) {
+			o.BatchSize = 2
+		})
+
+		err := vs.AddDocuments(context.Background(), []schema.Document{
+			{PageContent: "document1", Metadata: map[string]any{"source": "a"}},
+			{PageContent: "document2"},
+			{PageContent: "document3"},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, client.upsertRequests, 2)
+		assert.Len(t, client.upsertRequests[0].Points, 2)
+		assert.Len(t, client.upsertRequests[1].Points, 1)
+		assert.Equal(t, []float32{1.0, 2.0, 3.0}, client.upsertRequests[0].Points[0].Vector)
+		assert.Equal(t, map[string]any{
+			"page_content": "document1",
+			"metadata":     map[string]any{"source": "a"},
+		}, client.upsertRequests[0].Points[0].Payload)
+	})
+
+	t.Run("SimilaritySearchWithScores", func(t *testing.T) {
+		filter := &qdrant.Filter{
+			Must: []qdrant.Condition{{Key: "metadata.source", Match: &qdrant.Match{Value: "a"}}},
+		}
+
+		client := &mockQdrantClient{
+			points: []qdrant.ScoredPoint{
+				{ID: "1", Score: 0.9, Payload: map[string]any{"page_content": "document1", "metadata": map[string]any{"source": "a"}}},
+			},
+		}
+
+		vs := NewQdrant(client, &mockEmbedder{}, "test", func(o *QdrantOptions) {
+			o.VectorName = "text"
+			o.Filter = filter
+		})
+
+		docs, err := vs.SimilaritySearchWithScores(context.Background(), "query")
+		assert.NoError(t, err)
+		assert.Equal(t, []schema.ScoredDocument{
+			{Document: schema.Document{PageContent: "document1", Metadata: map[string]any{"source": "a"}}, Score: 0.9},
+		}, docs)
+		assert.Equal(t, qdrant.NamedVector{Name: "text", Vector: []float32{1.0, 2.0, 3.0}}, client.searchRequest.Vector)
+		assert.Equal(t, filter, client.searchRequest.Filter)
+		assert.Equal(t, 4, client.searchRequest.Limit)
+	})
+
+	t.Run("DeleteByPayloadFilter", func(t *testing.T) {
+		client := &mockQdrantClient{
+			scrollResponses: []*qdrant.ScrollResponse{
+				{Points: []qdrant.Record{{ID: "1"}, {ID: "2"}}, NextPageOffset: "3"},
+				{Points: []qdrant.Record{{ID: "3"}}},
+			},
+		}
+
+		vs := NewQdrant(client, &mockEmbedder{}, "test", func(o *QdrantOptions) {
+			o.BatchSize = 2
+		})
+
+		n, err := vs.DeleteByPayloadFilter(context.Background(), &qdrant.Filter{})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, n)
+		assert.Equal(t, "3", client.scrollRequests[1].Offset)
+		assert.Equal(t, []*qdrant.DeletePointsRequest{
+			{Points: []any{"1", "2"}},
+			{Points: []any{"3"}},
+		}, client.deleteRequests)
+	})
+
+	t.Run("DeleteByFilter", func(t *testing.T) {
+		client := &mockQdrantClient{
+			scrollResponses: []*qdrant.ScrollResponse{
+				{Points: []qdrant.Record{{ID: "1"}}},
+			},
+		}
+
+		vs := NewQdrant(client, &mockEmbedder{}, "test")
+
+		err := vs.DeleteByFilter(context.Background(), map[string]any{"source": "a"})
+		assert.NoError(t, err)
+		assert.Equal(t, &qdrant.Filter{
+			Must: []qdrant.Condition{{Key: "metadata.source", Match: &qdrant.Match{Value: "a"}}},
+		}, client.scrollRequests[0].Filter)
+		assert.Equal(t, []*qdrant.DeletePointsRequest{{Points: []any{"1"}}}, client.deleteRequests)
+	})
+
+	t.Run("AddDocumentsWithIDsAndDelete", func(t *testing.T) {
+		client := &mockQdrantClient{}
+
+		vs := NewQdrant(client, &mockEmbedder{}, "test")
+
+		id := "5c56c793-69f3-4fbf-87e6-c4bf54c28c26"
+
+		err := vs.AddDocumentsWithIDs(context.Background(), []string{id, "doc2"}, []schema.Document{
+			{PageContent: "document1"},
+			{PageContent: "document2"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, id, client.upsertRequests[0].Points[0].ID)
+		assert.Equal(t, toUUID("doc2"), client.upsertRequests[0].Points[1].ID)
+		assert.NotEqual(t, "doc2", client.upsertRequests[0].Points[1].ID)
+
+		err = vs.Delete(context.Background(), []string{id, "doc2"})
+		assert.NoError(t, err)
+		assert.Equal(t, []*qdrant.DeletePointsRequest{{Points: []any{id, toUUID("doc2")}}}, client.deleteRequests)
+	})
+}
+
+// mockQdrantClient is a mock implementation of the QdrantClient interface.
+type mockQdrantClient struct {
+	exists                  bool
+	createCollectionRequest *qdrant.CreateCollectionRequest
+	upsertRequests          []*qdrant.UpsertPointsRequest
+	searchRequest           *qdrant.SearchRequest
+	points                  []qdrant.ScoredPoint
+	scrollRequests          []*qdrant.ScrollRequest
+	scrollResponses         []*qdrant.ScrollResponse
+	deleteRequests          []*qdrant.DeletePointsRequest
+}
+
+func (m *mockQdrantClient) CollectionExists(ctx context.Context, collectionName string) (bool, error) {
+	return m.exists, nil
+}
+
+func (m *mockQdrantClient) CreateCollection(ctx context.Context, collectionName string, req *qdrant.CreateCollectionRequest) error {
+	m.createCollectionRequest = req
+	return nil
+}
+
+func (m *mockQdrantClient) Upsert(ctx context.Context, collectionName string, req *qdrant.UpsertPointsRequest) error {
+	m.upsertRequests = append(m.upsertRequests, req)
+	return nil
+}
+
+func (m *mockQdrantClient) Search(ctx context.Context, collectionName string, req *qdrant.SearchRequest) ([]qdrant.ScoredPoint, error) {
+	m.searchRequest = req
+	return m.points, nil
+}
+
+func (m *mockQdrantClient) Scroll(ctx context.Context, collectionName string, req *qdrant.ScrollRequest) (*qdrant.ScrollResponse, error) {
+	m.scrollRequests = append(m.scrollRequests, req)
+	res := m.scrollResponses[len(m.scrollRequests)-1]
+
+	return res, nil
+}
+
+func (m *mockQdrantClient) DeletePoints(ctx context.Context, collectionName string, req *qdrant.DeletePointsRequest) error {
+	m.deleteRequests = append(m.deleteRequests, req)
+	return nil
+}
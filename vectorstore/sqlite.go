@@ -0,0 +1,393 @@
+package vectorstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/metric"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure SQLite satisfies the VectorStore interface.
+var _ schema.VectorStore = (*SQLite)(nil)
+
+// Compile time check to ensure SQLite satisfies the VectorStoreWithScores interface.
+var _ schema.VectorStoreWithScores = (*SQLite)(nil)
+
+// Compile time check to ensure SQLite satisfies the VectorStoreWithMMR interface.
+var _ schema.VectorStoreWithMMR = (*SQLite)(nil)
+
+// sqliteTableNameRegexp matches the valid table names of the SQLite vector store.
+var sqliteTableNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// SQLiteOptions represents options for the SQLite vector store.
+type SQLiteOptions struct {
+	// TableName is the name of the table storing the documents. It is created if it does not exist.
+	TableName string
+	// TopK is the number of documents returned by a similarity search.
+	TopK int
+	// DistanceFunc calculates the distance between the query vector and the document vectors.
+	DistanceFunc DistanceFunc
+	// RelevanceScoreFunc converts the distance of a document to the query into its similarity score.
+	RelevanceScoreFunc RelevanceScoreFunc
+}
+
+// SQLite represents a local vector store persisted in a SQLite database. The vectors are stored as blobs
+// and searched by brute force, so no SQLite extension is required. It is suited for CLI tools, tests and
+// corpora up to some ten thousand documents.
+type SQLite struct {
+	db       *sql.DB
+	embedder schema.Embedder
+	opts     SQLiteOptions
+}
+
+// NewSQLite creates a new instance of the SQLite vector store using the given database. The database
+// driver, e.g. github.com/mattn/go-sqlite3 or modernc.org/sqlite, is chosen by the caller.
+func NewSQLite(ctx context.Context, db *sql.DB, embedder schema.Embedder, optFns ...func(*SQLiteOptions)) (*SQLite, error) {
+	opts := SQLiteOptions{
+		TableName:    "golc_vectors",
+		TopK:         3,
+		DistanceFunc: metric.SquaredL2,
+		RelevanceScoreFunc: func(distance float32) float32 {
+			return 1 / (1 + distance)
+		},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if !sqliteTableNameRegexp.MatchString(opts.TableName) {
+		return nil, fmt.Errorf("invalid table name: %s", opts.TableName)
+	}
+
+	if _, err := db.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		id TEXT PRIMARY KEY,
+		content TEXT NOT NULL,
+		metadata TEXT,
+		vector BLOB NOT NULL
+	)`, opts.TableName)); err != nil {
+		return nil, err
+	}
+
+	return &SQLite{
+		db:       db,
+		embedder: embedder,
+		opts:     opts,
+	}, nil
+}
+
+// AddDocuments adds a batch of documents to the SQLite vector store within a single transaction.
+func (vs *SQLite) AddDocuments(ctx context.Context, docs []schema.Document) error {
+	return vs.AddDocumentsWithIDs(ctx, newIDs(len(docs)), docs)
+}
+
+// AddDocumentsWithIDs adds a batch of documents with the given ids to the SQLite vector store within
+// a single transaction. Existing documents with the same ids are replaced.
+func (vs *SQLite) AddDocumentsWithIDs(ctx context.Context, ids []string, docs []schema.Document) error {
+	if len(ids) != len(docs) {
+		return ErrIDsDocumentsMismatch
+	}
+
+	texts := make([]string, len(docs))
+	for i, doc := range docs {
+		texts[i] = doc.PageContent
+	}
+
+	vectors, err := vs.embedder.BatchEmbedText(ctx, texts)
+	if err != nil {
+		return err
+	}
+
+	tx, err := vs.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	stmt, err := tx.PrepareContext(ctx, fmt.Sprintf("INSERT OR REPLACE INTO %s (id, content, metadata, vector) VALUES (?, ?, ?, ?)", vs.opts.TableName))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for i, doc := range docs {
+		metadata, err := json.Marshal(doc.Metadata)
+		if err != nil {
+			return err
+		}
+
+		if _, err := stmt.ExecContext(ctx, ids[i], doc.PageContent, string(metadata), encodeVector(vectors[i])); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Delete deletes the documents with the given ids from the SQLite vector store.
+func (vs *SQLite) Delete(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	tx, err := vs.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// The ids are deleted in batches, as SQLite limits the number of variables per statement.
+	for _, batch := range util.ChunkBy(ids, deleteBatchSize) {
+		placeholders := make([]string, len(batch))
+		args := make([]any, len(batch))
+
+		for i, id := range batch {
+			placeholders[i] = "?"
+			args[i] = id
+		}
+
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE id IN (%s)", vs.opts.TableName, strings.Join(placeholders, ", ")), args...); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// DeleteByFilter deletes the documents whose metadata matches all key-value pairs of the filter
+// from the SQLite vector store. The metadata is matched in Go, so no SQLite JSON support is required.
+func (vs *SQLite) DeleteByFilter(ctx context.Context, filter map[string]any) error {
+	rows, err := vs.db.QueryContext(ctx, fmt.Sprintf("SELECT id, metadata FROM %s", vs.opts.TableName))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+
+	for rows.Next() {
+		var (
+			id       string
+			raw      sql.NullString
+			metadata map[string]any
+		)
+
+		if err := rows.Scan(&id, &raw); err != nil {
+			return err
+		}
+
+		if raw.Valid {
+			if err := json.Unmarshal([]byte(raw.String), &metadata); err != nil {
+				return err
+			}
+		}
+
+		if matchMetadata(metadata, filter) {
+			ids = append(ids, id)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	// Close the rows before deleting, as the database may be limited to a single connection.
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return vs.Delete(ctx, ids)
+}
+
+// SimilaritySearch performs a similarity search with the given query in the SQLite vector store.
+func (vs *SQLite) SimilaritySearch(ctx context.Context, query string) ([]schema.Document, error) {
+	scoredDocs, err := vs.SimilaritySearchWithScores(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, len(scoredDocs))
+	for i, d := range scoredDocs {
+		docs[i] = d.Document
+	}
+
+	return docs, nil
+}
+
+// SimilaritySearchWithScores performs a similarity search with the given query in the SQLite vector store
+// and returns the documents together with their similarity scores.
+func (vs *SQLite) SimilaritySearchWithScores(ctx context.Context, query string) ([]schema.ScoredDocument, error) {
+	queryVector, err := vs.embedder.EmbedText(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := vs.nearest(ctx, queryVector, vs.opts.TopK)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.ScoredDocument, len(candidates))
+
+	for i, c := range candidates {
+		docs[i] = schema.ScoredDocument{
+			Document: c.doc,
+			Score:    vs.opts.RelevanceScoreFunc(c.distance),
+		}
+	}
+
+	return docs, nil
+}
+
+// MaxMarginalRelevanceSearch fetches the fetchK documents nearest to the query and selects TopK of them by
+// maximal marginal relevance.
+func (vs *SQLite) MaxMarginalRelevanceSearch(ctx context.Context, query string, fetchK int, lambdaMult float32) ([]schema.Document, error) {
+	queryVector, err := vs.embedder.EmbedText(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := vs.nearest(ctx, queryVector, util.Max(fetchK, vs.opts.TopK))
+	if err != nil {
+		return nil, err
+	}
+
+	vectors := make([][]float32, len(candidates))
+	for i, c := range candidates {
+		vectors[i] = c.vector
+	}
+
+	selected, err := maxMarginalRelevance(queryVector, vectors, vs.opts.TopK, lambdaMult)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, len(selected))
+	for i, index := range selected {
+		docs[i] = candidates[index].doc
+	}
+
+	return docs, nil
+}
+
+// sqliteCandidate is a document with its vector and its distance to the query vector.
+type sqliteCandidate struct {
+	doc      schema.Document
+	vector   []float32
+	distance float32
+}
+
+// nearest returns the k documents nearest to the query vector sorted by their distance.
+func (vs *SQLite) nearest(ctx context.Context, queryVector []float32, k int) ([]sqliteCandidate, error) {
+	rows, err := vs.db.QueryContext(ctx, fmt.Sprintf("SELECT content, metadata, vector FROM %s", vs.opts.TableName))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type candidate struct {
+		content  string
+		metadata sql.NullString
+		vector   []float32
+		distance float32
+	}
+
+	candidates := []candidate{}
+
+	for rows.Next() {
+		var (
+			c    candidate
+			blob []byte
+		)
+
+		if err := rows.Scan(&c.content, &c.metadata, &blob); err != nil {
+			return nil, err
+		}
+
+		c.vector, err = decodeVector(blob)
+		if err != nil {
+			return nil, err
+		}
+
+		c.distance, err = vs.opts.DistanceFunc(queryVector, c.vector)
+		if err != nil {
+			return nil, err
+		}
+
+		candidates = append(candidates, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	if len(candidates) > k {
+		candidates = candidates[:k]
+	}
+
+	nearest := make([]sqliteCandidate, len(candidates))
+
+	for i, c := range candidates {
+		var metadata map[string]any
+
+		if c.metadata.Valid {
+			if err := json.Unmarshal([]byte(c.metadata.String), &metadata); err != nil {
+				return nil, err
+			}
+		}
+
+		nearest[i] = sqliteCandidate{
+			doc: schema.Document{
+				PageContent: c.content,
+				Metadata:    metadata,
+			},
+			vector:   c.vector,
+			distance: c.distance,
+		}
+	}
+
+	return nearest, nil
+}
+
+// encodeVector encodes the vector as little-endian float32 blob.
+func encodeVector(vector []float32) []byte {
+	blob := make([]byte, 4*len(vector))
+	for i, v := range vector {
+		binary.LittleEndian.PutUint32(blob[4*i:], math.Float32bits(v))
+	}
+
+	return blob
+}
+
+// decodeVector decodes a little-endian float32 blob into a vector.
+func decodeVector(blob []byte) ([]float32, error) {
+	if len(blob)%4 != 0 {
+		return nil, fmt.Errorf("invalid vector blob of length %d", len(blob))
+	}
+
+	vector := make([]float32, len(blob)/4)
+	for i := range vector {
+		vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(blob[4*i:]))
+	}
+
+	return vector, nil
+}
@@ -0,0 +1,126 @@
+package vectorstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+func TestSQLite(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	// Each connection opens its own in-memory database.
+	db.SetMaxOpenConns(1)
+
+	vs, err := NewSQLite(context.Background(), db, &mockEmbedder{}, func(o *SQLiteOptions) {
+		o.TopK = 2
+	})
+	require.NoError(t, err)
+
+	t.Run("AddDocuments", func(t *testing.T) {
+		err := vs.AddDocuments(context.Background(), []schema.Document{
+			{PageContent: "document1", Metadata: map[string]any{"source": "a"}},
+			{PageContent: "document2"},
+			{PageContent: "document3"},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("SimilaritySearchWithScores", func(t *testing.T) {
+		docs, err := vs.SimilaritySearchWithScores(context.Background(), "query")
+		assert.NoError(t, err)
+		assert.Equal(t, []schema.ScoredDocument{
+			{Document: schema.Document{PageContent: "document1", Metadata: map[string]any{"source": "a"}}, Score: 1},
+			{Document: schema.Document{PageContent: "document2"}, Score: 0.25},
+		}, docs)
+	})
+
+	t.Run("Persistence", func(t *testing.T) {
+		reopened, err := NewSQLite(context.Background(), db, &mockEmbedder{})
+		require.NoError(t, err)
+
+		docs, err := reopened.SimilaritySearch(context.Background(), "query")
+		assert.NoError(t, err)
+		assert.Len(t, docs, 3)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		vs, err := NewSQLite(context.Background(), db, &mockEmbedder{}, func(o *SQLiteOptions) {
+			o.TableName = "delete_test"
+		})
+		require.NoError(t, err)
+
+		err = vs.AddDocumentsWithIDs(context.Background(), []string{"a", "b", "c"}, []schema.Document{
+			{PageContent: "document1", Metadata: map[string]any{"source": "x"}},
+			{PageContent: "document2", Metadata: map[string]any{"source": "y", "page": 1}},
+			{PageContent: "document3", Metadata: map[string]any{"source": "y", "page": 2}},
+		})
+		require.NoError(t, err)
+
+		// Adding a document with an existing id replaces it.
+		err = vs.AddDocumentsWithIDs(context.Background(), []string{"a"}, []schema.Document{{PageContent: "document1"}})
+		require.NoError(t, err)
+
+		require.NoError(t, vs.Delete(context.Background(), []string{"a"}))
+		require.NoError(t, vs.DeleteByFilter(context.Background(), map[string]any{"page": 2}))
+
+		docs, err := vs.SimilaritySearch(context.Background(), "query")
+		require.NoError(t, err)
+		assert.Equal(t, []schema.Document{{PageContent: "document2", Metadata: map[string]any{"source": "y", "page": 1.0}}}, docs)
+	})
+
+	t.Run("DeleteBatches", func(t *testing.T) {
+		vs, err := NewSQLite(context.Background(), db, &mockEmbedder{}, func(o *SQLiteOptions) {
+			o.TableName = "delete_batches_test"
+		})
+		require.NoError(t, err)
+
+		err = vs.AddDocumentsWithIDs(context.Background(), []string{"a", "b", "c"}, []schema.Document{
+			{PageContent: "document1"},
+			{PageContent: "document2"},
+			{PageContent: "document3"},
+		})
+		require.NoError(t, err)
+
+		ids := make([]string, 1200)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("id-%d", i)
+		}
+
+		ids[0], ids[1199] = "a", "c"
+
+		require.NoError(t, vs.Delete(context.Background(), ids))
+
+		docs, err := vs.SimilaritySearch(context.Background(), "query")
+		require.NoError(t, err)
+		assert.Equal(t, []schema.Document{{PageContent: "document2"}}, docs)
+	})
+
+	t.Run("InvalidTableName", func(t *testing.T) {
+		_, err := NewSQLite(context.Background(), db, &mockEmbedder{}, func(o *SQLiteOptions) {
+			o.TableName = "vectors; DROP TABLE golc_vectors"
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestVectorEncoding(t *testing.T) {
+	vector := []float32{1.5, -2, 0}
+
+	decoded, err := decodeVector(encodeVector(vector))
+	assert.NoError(t, err)
+	assert.Equal(t, vector, decoded)
+
+	_, err = decodeVector([]byte{1, 2, 3})
+	assert.Error(t, err)
+}
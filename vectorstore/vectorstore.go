@@ -2,11 +2,90 @@
 package vectorstore
 
 import (
+	"errors"
+	"reflect"
+
+	"github.com/google/uuid"
 	"github.com/hupe1980/golc/retriever"
 	"github.com/hupe1980/golc/schema"
 )
 
+// ErrIDsDocumentsMismatch is returned when the number of ids and documents differs.
+var ErrIDsDocumentsMismatch = errors.New("number of ids and documents must be equal")
+
+// deleteBatchSize is the maximum number of ids deleted at once. It stays below the limits of
+// Pinecone (1000 ids per request) and SQLite (999 variables per statement in older versions).
+const deleteBatchSize = 500
+
 // ToRetriever takes a vector store and returns a retriever
 func ToRetriever(vectorStore schema.VectorStore, optFns ...func(o *retriever.VectorStoreOptions)) schema.Retriever {
 	return retriever.NewVectorStore(vectorStore, optFns...)
 }
+
+// newIDs returns n new random ids.
+func newIDs(n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = uuid.New().String()
+	}
+
+	return ids
+}
+
+// toUUID returns the id, if it is a UUID, or otherwise a UUID derived from the id. It is used for
+// vector stores accepting only UUIDs as ids.
+func toUUID(id string) string {
+	if _, err := uuid.Parse(id); err == nil {
+		return id
+	}
+
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(id)).String()
+}
+
+// matchMetadata reports whether the metadata contains all key-value pairs of the filter.
+func matchMetadata(metadata, filter map[string]any) bool {
+	for key, value := range filter {
+		v, ok := metadata[key]
+		if !ok || !equalValues(v, value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// equalValues reports whether the values are equal. Numbers are compared by their value regardless
+// of their type, as decoding JSON metadata turns all numbers into float64.
+func equalValues(a, b any) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+
+	if isNumber(va) && isNumber(vb) {
+		return toFloat64(va) == toFloat64(vb)
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// isNumber reports whether the value is an integer or a floating-point number.
+func isNumber(v reflect.Value) bool {
+	switch v.Kind() { // nolint exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// toFloat64 converts a number to float64.
+func toFloat64(v reflect.Value) float64 {
+	switch v.Kind() { // nolint exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	default:
+		return v.Float()
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/hupe1980/golc/schema"
 	"github.com/weaviate/weaviate-go-client/v4/weaviate"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/filters"
 	"github.com/weaviate/weaviate-go-client/v4/weaviate/graphql"
 	"github.com/weaviate/weaviate/entities/models"
 )
@@ -83,6 +84,17 @@ func (vs *Weaviate) CreateClassIfNotExist(ctx context.Context) error {
 
 // AddDocuments adds a batch of documents to the Weaviate vector store.
 func (vs *Weaviate) AddDocuments(ctx context.Context, docs []schema.Document) error {
+	return vs.AddDocumentsWithIDs(ctx, newIDs(len(docs)), docs)
+}
+
+// AddDocumentsWithIDs adds a batch of documents with the given ids to the Weaviate vector store. Existing
+// objects with the same ids are replaced. As Weaviate accepts only UUIDs as object ids, other ids are
+// converted into deterministic UUIDs.
+func (vs *Weaviate) AddDocumentsWithIDs(ctx context.Context, ids []string, docs []schema.Document) error {
+	if len(ids) != len(docs) {
+		return ErrIDsDocumentsMismatch
+	}
+
 	texts := make([]string, len(docs))
 	for i, doc := range docs {
 		texts[i] = doc.PageContent
@@ -105,7 +117,7 @@ func (vs *Weaviate) AddDocuments(ctx context.Context, docs []schema.Document) er
 
 		objects = append(objects, &models.Object{
 			Class:      vs.opts.IndexName,
-			ID:         strfmt.UUID(uuid.New().String()),
+			ID:         strfmt.UUID(toUUID(ids[i])),
 			Vector:     vectors[i],
 			Properties: metadata,
 		})
@@ -182,7 +194,62 @@ func (vs *Weaviate) SimilaritySearch(ctx context.Context, query string) ([]schem
 	return docs, nil
 }
 
-// Delete removes a document from the Weaviate vector store based on its UUID.
-func (vs *Weaviate) Delete(ctx context.Context, uuid string) error {
-	return vs.client.Data().Deleter().WithID(uuid).Do(ctx)
+// Delete removes the documents with the given ids from the Weaviate vector store.
+func (vs *Weaviate) Delete(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	uuids := make([]string, len(ids))
+	for i, id := range ids {
+		uuids[i] = toUUID(id)
+	}
+
+	return vs.batchDelete(ctx, filters.Where().
+		WithPath([]string{"id"}).
+		WithOperator(filters.ContainsAny).
+		WithValueText(uuids...))
+}
+
+// DeleteByFilter removes the documents whose metadata matches all key-value pairs of the filter
+// from the Weaviate vector store.
+func (vs *Weaviate) DeleteByFilter(ctx context.Context, filter map[string]any) error {
+	operands := make([]*filters.WhereBuilder, 0, len(filter))
+
+	for key, value := range filter {
+		where := filters.Where().WithPath([]string{key}).WithOperator(filters.Equal)
+
+		switch v := value.(type) {
+		case string:
+			where = where.WithValueText(v)
+		case bool:
+			where = where.WithValueBoolean(v)
+		case int:
+			where = where.WithValueInt(int64(v))
+		case int64:
+			where = where.WithValueInt(v)
+		case float64:
+			where = where.WithValueNumber(v)
+		default:
+			return fmt.Errorf("unsupported filter value type %T for key %s", value, key)
+		}
+
+		operands = append(operands, where)
+	}
+
+	if len(operands) == 1 {
+		return vs.batchDelete(ctx, operands[0])
+	}
+
+	return vs.batchDelete(ctx, filters.Where().WithOperator(filters.And).WithOperands(operands))
+}
+
+// batchDelete removes the objects of the index matching the where filter.
+func (vs *Weaviate) batchDelete(ctx context.Context, where *filters.WhereBuilder) error {
+	_, err := vs.client.Batch().ObjectsBatchDeleter().
+		WithClassName(vs.opts.IndexName).
+		WithWhere(where).
+		Do(ctx)
+
+	return err
 }